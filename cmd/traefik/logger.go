@@ -35,7 +35,9 @@ func setupLogger(staticConfiguration *static.Configuration) {
 
 	log.Logger = logCtx.Logger().Level(logLevel)
 	zerolog.DefaultContextLogger = &log.Logger
-	zerolog.SetGlobalLevel(logLevel)
+
+	// The plugins can have a lower log level than Traefik, the Traefik logs are still filtered by the level of the logger.
+	zerolog.SetGlobalLevel(min(logLevel, getPluginsLogLevel(staticConfiguration)))
 
 	// Global logrus replacement (related to lib like go-rancher-metadata, docker, etc.)
 	logrus.StandardLogger().Out = logs.NoLevel(log.Logger, zerolog.DebugLevel)
@@ -87,3 +89,32 @@ func getLogLevel(staticConfiguration *static.Configuration) zerolog.Level {
 
 	return logLevel
 }
+
+// getPluginsLogLevel returns the lowest log level of the plugins,
+// or zerolog.Disabled if none of them has its own log level.
+func getPluginsLogLevel(staticConfiguration *static.Configuration) zerolog.Level {
+	if staticConfiguration.Experimental == nil {
+		return zerolog.Disabled
+	}
+
+	var levels []string
+	for _, desc := range staticConfiguration.Experimental.Plugins {
+		levels = append(levels, desc.LogLevel)
+	}
+	for _, desc := range staticConfiguration.Experimental.LocalPlugins {
+		levels = append(levels, desc.LogLevel)
+	}
+
+	pluginsLevel := zerolog.Disabled
+	for _, levelStr := range levels {
+		// The invalid log levels are reported by the setup of the plugins.
+		level, err := zerolog.ParseLevel(strings.ToLower(levelStr))
+		if err != nil || level == zerolog.NoLevel {
+			continue
+		}
+
+		pluginsLevel = min(pluginsLevel, level)
+	}
+
+	return pluginsLevel
+}
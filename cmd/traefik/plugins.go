@@ -1,14 +1,54 @@
 package main
 
 import (
+	"context"
 	"fmt"
-
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+
+	"github.com/mitchellh/copystructure"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/paerser/cli"
+	"github.com/traefik/traefik/v3/cmd"
 	"github.com/traefik/traefik/v3/pkg/config/static"
 	"github.com/traefik/traefik/v3/pkg/plugins"
+	"github.com/traefik/traefik/v3/pkg/safe"
+	"github.com/traefik/traefik/v3/pkg/server"
 )
 
 const outputDir = "./plugins-storage/"
 
+// staticConfigurationLoader loads a fresh copy of the static configuration.
+type staticConfigurationLoader func() (*static.Configuration, error)
+
+func newStaticConfigurationLoader(args []string, loaders []cli.ResourceLoader) staticConfigurationLoader {
+	return func() (*static.Configuration, error) {
+		tConfig := cmd.NewTraefikConfiguration()
+
+		command := &cli.Command{
+			Name:          "traefik",
+			Configuration: tConfig,
+			Resources:     loaders,
+		}
+
+		for _, loader := range loaders {
+			done, err := loader.Load(args, command)
+			if err != nil {
+				return nil, err
+			}
+			if done {
+				break
+			}
+		}
+
+		tConfig.Configuration.SetEffectiveConfiguration()
+
+		return &tConfig.Configuration, nil
+	}
+}
+
 func createPluginBuilder(staticConfiguration *static.Configuration) (*plugins.Builder, error) {
 	client, plgs, localPlgs, err := initPlugins(staticConfiguration)
 	if err != nil {
@@ -18,6 +58,104 @@ func createPluginBuilder(staticConfiguration *static.Configuration) (*plugins.Bu
 	return plugins.NewBuilder(client, plgs, localPlgs)
 }
 
+// snapshotPlugins returns a deep copy of the plugin descriptors of the given configuration.
+// It must be taken before the plugins setup, which resolves the version constraints and removes the skipped plugins in place.
+func snapshotPlugins(e *static.Experimental) (*static.Experimental, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	snapshot, err := copystructure.Copy(&static.Experimental{Plugins: e.Plugins, LocalPlugins: e.LocalPlugins})
+	if err != nil {
+		return nil, fmt.Errorf("copying the plugins configuration: %w", err)
+	}
+
+	return snapshot.(*static.Experimental), nil
+}
+
+// watchPluginsReload reloads the plugins when a SIGHUP is received and their descriptors changed in the static configuration.
+// Only the middleware plugins are hot-reloaded, the provider plugins still require a restart.
+// The current descriptors are the ones of the static configuration, as snapshotted before the plugins setup.
+func watchPluginsReload(pool *safe.Pool, current *static.Experimental, loadStaticConfiguration staticConfigurationLoader, builder *plugins.Builder, watcher *server.ConfigurationWatcher) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	pool.GoCtx(func(ctx context.Context) {
+		defer signal.Stop(signals)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-signals:
+				staticCfg, err := loadStaticConfiguration()
+				if err != nil {
+					log.Error().Err(err).Msg("Unable to load the static configuration, plugins are not reloaded")
+					continue
+				}
+
+				if samePlugins(current, staticCfg.Experimental) {
+					log.Debug().Msg("Skipping plugins reload: configuration unchanged")
+					continue
+				}
+
+				snapshot, err := snapshotPlugins(staticCfg.Experimental)
+				if err != nil {
+					log.Error().Err(err).Msg("Unable to reload plugins, keeping the previous ones")
+					continue
+				}
+
+				log.Info().Msg("Reloading plugins...")
+
+				if err := reloadPlugins(staticCfg, builder); err != nil {
+					log.Error().Err(err).Msg("Unable to reload plugins, keeping the previous ones")
+					continue
+				}
+
+				current = snapshot
+				watcher.Reapply()
+
+				log.Info().Msg("Plugins reloaded.")
+			}
+		}
+	})
+}
+
+func reloadPlugins(staticCfg *static.Configuration, builder *plugins.Builder) error {
+	client, plgs, localPlgs, err := initPlugins(staticCfg)
+	if err != nil {
+		return err
+	}
+
+	return builder.Reload(client, plgs, localPlgs)
+}
+
+func samePlugins(a, b *static.Experimental) bool {
+	aPlugins, aLocalPlugins := getPlugins(a)
+	bPlugins, bLocalPlugins := getPlugins(b)
+
+	return reflect.DeepEqual(aPlugins, bPlugins) && reflect.DeepEqual(aLocalPlugins, bLocalPlugins)
+}
+
+func getPlugins(e *static.Experimental) (map[string]plugins.Descriptor, map[string]plugins.LocalDescriptor) {
+	if e == nil {
+		return nil, nil
+	}
+
+	plgs := e.Plugins
+	if len(plgs) == 0 {
+		plgs = nil
+	}
+
+	localPlgs := e.LocalPlugins
+	if len(localPlgs) == 0 {
+		localPlgs = nil
+	}
+
+	return plgs, localPlgs
+}
+
 func initPlugins(staticCfg *static.Configuration) (*plugins.Client, map[string]plugins.Descriptor, map[string]plugins.LocalDescriptor, error) {
 	err := checkUniquePluginNames(staticCfg.Experimental)
 	if err != nil {
@@ -28,12 +166,8 @@ func initPlugins(staticCfg *static.Configuration) (*plugins.Client, map[string]p
 	plgs := map[string]plugins.Descriptor{}
 
 	if hasPlugins(staticCfg) {
-		opts := plugins.ClientOptions{
-			Output: outputDir,
-		}
-
 		var err error
-		client, err = plugins.NewClient(opts)
+		client, err = plugins.NewClient(newPluginsClientOptions(staticCfg, pluginsStorageDir(staticCfg)))
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("unable to create plugins client: %w", err)
 		}
@@ -60,6 +194,44 @@ func initPlugins(staticCfg *static.Configuration) (*plugins.Client, map[string]p
 	return client, plgs, localPlgs, nil
 }
 
+func newPluginsClientOptions(staticCfg *static.Configuration, output string) plugins.ClientOptions {
+	opts := plugins.ClientOptions{
+		Output: output,
+	}
+
+	if staticCfg.Experimental == nil {
+		return opts
+	}
+
+	opts.Storage = staticCfg.Experimental.PluginsStorage
+	if opts.Storage != nil {
+		opts.KeepLast = opts.Storage.KeepLast
+	}
+
+	if registry := staticCfg.Experimental.PluginsRegistry; registry != nil {
+		opts.RegistryURL = registry.URL
+		opts.Token = registry.Token
+		opts.TLS = registry.TLS
+		opts.PublicKey = registry.PublicKey
+		opts.RequireSignature = registry.RequireSignature
+		opts.Mirrors = registry.Mirrors
+		opts.Retry = registry.Retry
+		opts.Proxy = registry.Proxy
+		opts.RootCAs = registry.RootCAs
+	}
+
+	return opts
+}
+
+// pluginsStorageDir returns the directory of the plugins storage.
+func pluginsStorageDir(staticCfg *static.Configuration) string {
+	if staticCfg.Experimental != nil && staticCfg.Experimental.PluginsStorage != nil && staticCfg.Experimental.PluginsStorage.Path != "" {
+		return staticCfg.Experimental.PluginsStorage.Path
+	}
+
+	return outputDir
+}
+
 func checkUniquePluginNames(e *static.Experimental) error {
 	if e == nil {
 		return nil
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/traefik/paerser/cli"
+	"github.com/traefik/traefik/v3/cmd"
+	tcli "github.com/traefik/traefik/v3/pkg/cli"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/plugins"
+)
+
+// pluginTestTimeout is the time given to a plugin to answer a test request, or to provide a configuration.
+const pluginTestTimeout = 10 * time.Second
+
+// pluginInstallConfiguration is the configuration of the plugins install command.
+type pluginInstallConfiguration struct {
+	FromFile    string `description:"Path of the plugins bundle to install."`
+	StoragePath string `description:"Directory of the plugins storage (default: ./plugins-storage)."`
+}
+
+// newPluginsCmd builds the plugins command, managing the plugins storage outside of the serving process.
+func newPluginsCmd(tConfig *cmd.TraefikCmdConfiguration, loaders []cli.ResourceLoader) (*cli.Command, error) {
+	pluginsCmd := &cli.Command{
+		Name:        "plugins",
+		Description: `Manages the plugins storage.`,
+	}
+	pluginsCmd.Run = func(_ []string) error {
+		return pluginsCmd.PrintHelp(os.Stdout)
+	}
+
+	installConfiguration := &pluginInstallConfiguration{}
+
+	subCommands := []*cli.Command{
+		{
+			Name:          "list",
+			Description:   `Lists the plugins of the static configuration, and the plugin archives of the plugins storage.`,
+			Configuration: tConfig,
+			Resources:     loaders,
+			Run: func(_ []string) error {
+				return listPlugins(&tConfig.Configuration, os.Stdout)
+			},
+		},
+		{
+			Name:          "download",
+			Description:   `Downloads the plugins of the static configuration into the plugins storage, and checks their integrity.`,
+			Configuration: tConfig,
+			Resources:     loaders,
+			Run: func(_ []string) error {
+				return downloadPlugins(&tConfig.Configuration)
+			},
+		},
+		{
+			Name:          "verify",
+			Description:   `Verifies the integrity and the signature of the archives of the plugins of the static configuration.`,
+			Configuration: tConfig,
+			Resources:     loaders,
+			Run: func(_ []string) error {
+				return verifyPlugins(&tConfig.Configuration)
+			},
+		},
+		{
+			Name:          "clean",
+			Description:   `Removes the plugin archives which are not used by the static configuration from the plugins storage.`,
+			Configuration: tConfig,
+			Resources:     loaders,
+			Run: func(_ []string) error {
+				return cleanPlugins(&tConfig.Configuration)
+			},
+		},
+		{
+			Name:          "test",
+			Description:   `Loads the local plugins of the static configuration, and runs them with the test data of their manifest.`,
+			Configuration: tConfig,
+			Resources:     loaders,
+			Run: func(_ []string) error {
+				return testPlugins(&tConfig.Configuration, os.Stdout)
+			},
+		},
+		{
+			Name:          "bundle",
+			Description:   `Downloads the plugins of the static configuration, and writes them as a bundle to the standard output.`,
+			Configuration: tConfig,
+			Resources:     loaders,
+			Run: func(_ []string) error {
+				return bundlePlugins(&tConfig.Configuration)
+			},
+		},
+		{
+			Name:          "install",
+			Description:   `Installs a plugins bundle created by the "plugins bundle" command, so that the plugins are set up without contacting the plugins registry.`,
+			Configuration: installConfiguration,
+			Resources:     []cli.ResourceLoader{&tcli.FlagLoader{}},
+			Run: func(_ []string) error {
+				return installPlugins(installConfiguration)
+			},
+		},
+	}
+
+	for _, subCommand := range subCommands {
+		if err := pluginsCmd.AddCommand(subCommand); err != nil {
+			return nil, err
+		}
+	}
+
+	return pluginsCmd, nil
+}
+
+func listPlugins(staticCfg *static.Configuration, w io.Writer) error {
+	staticCfg.SetEffectiveConfiguration()
+
+	client, err := plugins.NewClient(newPluginsClientOptions(staticCfg, pluginsStorageDir(staticCfg)))
+	if err != nil {
+		return fmt.Errorf("unable to create plugins client: %w", err)
+	}
+
+	var plgs map[string]plugins.Descriptor
+	if hasPlugins(staticCfg) {
+		plgs = staticCfg.Experimental.Plugins
+	}
+
+	list, err := client.List(plgs)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tMODULE\tVERSION\tSTATUS")
+
+	for _, plugin := range list {
+		name := plugin.Name
+		if name == "" {
+			name = "-"
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", name, plugin.ModuleName, plugin.Version, plugin.Status)
+	}
+
+	return tw.Flush()
+}
+
+func downloadPlugins(staticCfg *static.Configuration) error {
+	staticCfg.SetEffectiveConfiguration()
+
+	if !hasPlugins(staticCfg) {
+		return errors.New("no plugins to download")
+	}
+
+	client, err := plugins.NewClient(newPluginsClientOptions(staticCfg, pluginsStorageDir(staticCfg)))
+	if err != nil {
+		return fmt.Errorf("unable to create plugins client: %w", err)
+	}
+
+	plgs := staticCfg.Experimental.Plugins
+
+	aliases := sortedKeys(plgs)
+
+	err = plugins.SetupRemotePlugins(client, plgs)
+	if err != nil {
+		return fmt.Errorf("unable to set up plugins environment: %w", err)
+	}
+
+	// The non-required plugins which could not be set up are removed from the descriptors.
+	var unavailable []string
+	for _, pAlias := range aliases {
+		desc, ok := plgs[pAlias]
+		if !ok {
+			unavailable = append(unavailable, pAlias)
+			continue
+		}
+
+		fmt.Printf("Plugin %s: %s@%s downloaded\n", pAlias, desc.ModuleName, desc.Version)
+	}
+
+	if len(unavailable) > 0 {
+		return fmt.Errorf("unable to download the plugins: %s", strings.Join(unavailable, ", "))
+	}
+
+	return nil
+}
+
+func verifyPlugins(staticCfg *static.Configuration) error {
+	staticCfg.SetEffectiveConfiguration()
+
+	if !hasPlugins(staticCfg) {
+		return errors.New("no plugins to verify")
+	}
+
+	client, err := plugins.NewClient(newPluginsClientOptions(staticCfg, pluginsStorageDir(staticCfg)))
+	if err != nil {
+		return fmt.Errorf("unable to create plugins client: %w", err)
+	}
+
+	plgs := staticCfg.Experimental.Plugins
+
+	var invalid []string
+	for _, pAlias := range sortedKeys(plgs) {
+		desc := plgs[pAlias]
+
+		if err = client.Verify(context.Background(), desc); err != nil {
+			fmt.Printf("Plugin %s: %s@%s: %v\n", pAlias, desc.ModuleName, desc.Version, err)
+			invalid = append(invalid, pAlias)
+			continue
+		}
+
+		fmt.Printf("Plugin %s: %s@%s verified\n", pAlias, desc.ModuleName, desc.Version)
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("unable to verify the plugins: %s", strings.Join(invalid, ", "))
+	}
+
+	return nil
+}
+
+func cleanPlugins(staticCfg *static.Configuration) error {
+	staticCfg.SetEffectiveConfiguration()
+
+	client, err := plugins.NewClient(newPluginsClientOptions(staticCfg, pluginsStorageDir(staticCfg)))
+	if err != nil {
+		return fmt.Errorf("unable to create plugins client: %w", err)
+	}
+
+	var plgs map[string]plugins.Descriptor
+	if hasPlugins(staticCfg) {
+		plgs = staticCfg.Experimental.Plugins
+	}
+
+	removed, err := client.Prune(plgs)
+	for _, archive := range removed {
+		fmt.Printf("Archive %s@%s removed\n", archive.ModuleName, archive.Version)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to clean the plugins storage: %w", err)
+	}
+
+	return nil
+}
+
+func testPlugins(staticCfg *static.Configuration, w io.Writer) error {
+	staticCfg.SetEffectiveConfiguration()
+
+	if !hasLocalPlugins(staticCfg) {
+		return errors.New("no local plugins to test")
+	}
+
+	localPlgs := staticCfg.Experimental.LocalPlugins
+
+	var failed []string
+	for _, pAlias := range sortedKeys(localPlgs) {
+		desc := localPlgs[pAlias]
+
+		_, _ = fmt.Fprintf(w, "Plugin %s: %s\n", pAlias, desc.ModuleName)
+
+		passed := true
+		for _, result := range plugins.RunLocalPluginTests(context.Background(), pAlias, desc, pluginTestTimeout) {
+			if !result.Passed() {
+				passed = false
+				_, _ = fmt.Fprintf(w, "  FAIL %s: %v\n", result.Name, result.Err)
+				continue
+			}
+
+			_, _ = fmt.Fprintf(w, "  PASS %s: %s\n", result.Name, result.Detail)
+		}
+
+		if !passed {
+			failed = append(failed, pAlias)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("plugins tests failed: %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+func bundlePlugins(staticCfg *static.Configuration) error {
+	staticCfg.SetEffectiveConfiguration()
+
+	if !hasPlugins(staticCfg) {
+		return errors.New("no plugins to bundle")
+	}
+
+	output, err := os.MkdirTemp("", "traefik-plugins-")
+	if err != nil {
+		return fmt.Errorf("unable to create plugins storage: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(output) }()
+
+	client, err := plugins.NewClient(newPluginsClientOptions(staticCfg, output))
+	if err != nil {
+		return fmt.Errorf("unable to create plugins client: %w", err)
+	}
+
+	plgs := staticCfg.Experimental.Plugins
+
+	err = plugins.SetupRemotePlugins(client, plgs)
+	if err != nil {
+		return fmt.Errorf("unable to set up plugins environment: %w", err)
+	}
+
+	return client.WriteBundle(context.Background(), plgs, os.Stdout)
+}
+
+func installPlugins(installConfiguration *pluginInstallConfiguration) error {
+	if installConfiguration.FromFile == "" {
+		return errors.New("the path of the plugins bundle is required")
+	}
+
+	file, err := os.Open(installConfiguration.FromFile)
+	if err != nil {
+		return fmt.Errorf("unable to open plugins bundle: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	output := installConfiguration.StoragePath
+	if output == "" {
+		output = outputDir
+	}
+
+	err = plugins.InstallBundle(output, file)
+	if err != nil {
+		return fmt.Errorf("unable to install plugins bundle: %w", err)
+	}
+
+	fmt.Printf("Plugins bundle installed in %s\n", output)
+
+	return nil
+}
+
+func sortedKeys[T any](plgs map[string]T) []string {
+	keys := make([]string, 0, len(plgs))
+	for key := range plgs {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
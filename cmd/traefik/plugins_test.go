@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/plugins"
+)
+
+func TestSamePlugins_versionConstraint(t *testing.T) {
+	newConfiguration := func() *static.Experimental {
+		return &static.Experimental{
+			Plugins: map[string]plugins.Descriptor{
+				"demo": {
+					ModuleName: "github.com/traefik/plugindemo",
+					Version:    "^0.2.0",
+					Settings:   plugins.Settings{Envs: []string{"A"}},
+				},
+				"optional": {
+					ModuleName: "github.com/traefik/optional",
+					Version:    "~1.0",
+				},
+			},
+			LocalPlugins: map[string]plugins.LocalDescriptor{
+				"local": {ModuleName: "github.com/traefik/local"},
+			},
+		}
+	}
+
+	current := newConfiguration()
+
+	snapshot, err := snapshotPlugins(current)
+	require.NoError(t, err)
+
+	// The plugins setup resolves the version constraints, and removes the skipped plugins.
+	demo := current.Plugins["demo"]
+	demo.Version = "v0.2.1"
+	current.Plugins["demo"] = demo
+	delete(current.Plugins, "optional")
+
+	next := newConfiguration()
+
+	assert.False(t, samePlugins(current, next))
+	assert.True(t, samePlugins(snapshot, next))
+
+	next.Plugins["demo"] = plugins.Descriptor{
+		ModuleName: "github.com/traefik/plugindemo",
+		Version:    "^0.3.0",
+		Settings:   plugins.Settings{Envs: []string{"A"}},
+	}
+
+	assert.False(t, samePlugins(snapshot, next))
+}
+
+func TestSnapshotPlugins_nil(t *testing.T) {
+	snapshot, err := snapshotPlugins(nil)
+	require.NoError(t, err)
+
+	assert.True(t, samePlugins(snapshot, &static.Experimental{}))
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/traefik/traefik/v3/cmd"
 	"github.com/traefik/traefik/v3/cmd/healthcheck"
 	cmdVersion "github.com/traefik/traefik/v3/cmd/version"
+	"github.com/traefik/traefik/v3/pkg/api"
 	tcli "github.com/traefik/traefik/v3/pkg/cli"
 	"github.com/traefik/traefik/v3/pkg/collector"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
@@ -33,6 +34,11 @@ import (
 	"github.com/traefik/traefik/v3/pkg/logs"
 	"github.com/traefik/traefik/v3/pkg/metrics"
 	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
+	"github.com/traefik/traefik/v3/pkg/middlewares/cache"
+	"github.com/traefik/traefik/v3/pkg/middlewares/circuitbreaker"
+	"github.com/traefik/traefik/v3/pkg/middlewares/fail2ban"
+	"github.com/traefik/traefik/v3/pkg/middlewares/idempotency"
+	"github.com/traefik/traefik/v3/pkg/middlewares/maintenance"
 	"github.com/traefik/traefik/v3/pkg/provider/acme"
 	"github.com/traefik/traefik/v3/pkg/provider/aggregator"
 	"github.com/traefik/traefik/v3/pkg/provider/tailscale"
@@ -61,8 +67,8 @@ func main() {
 Complete documentation is available at https://traefik.io`,
 		Configuration: tConfig,
 		Resources:     loaders,
-		Run: func(_ []string) error {
-			return runCmd(&tConfig.Configuration)
+		Run: func(args []string) error {
+			return runCmd(&tConfig.Configuration, newStaticConfigurationLoader(args, loaders))
 		},
 	}
 
@@ -78,6 +84,18 @@ Complete documentation is available at https://traefik.io`,
 		os.Exit(1)
 	}
 
+	pluginsCmd, err := newPluginsCmd(tConfig, loaders)
+	if err != nil {
+		stdlog.Println(err)
+		os.Exit(1)
+	}
+
+	err = cmdTraefik.AddCommand(pluginsCmd)
+	if err != nil {
+		stdlog.Println(err)
+		os.Exit(1)
+	}
+
 	err = cli.Execute(cmdTraefik)
 	if err != nil {
 		log.Error().Err(err).Msg("Command error")
@@ -87,7 +105,7 @@ Complete documentation is available at https://traefik.io`,
 	logrus.Exit(0)
 }
 
-func runCmd(staticConfiguration *static.Configuration) error {
+func runCmd(staticConfiguration *static.Configuration, loadStaticConfiguration staticConfigurationLoader) error {
 	setupLogger(staticConfiguration)
 
 	http.DefaultTransport.(*http.Transport).Proxy = http.ProxyFromEnvironment
@@ -114,7 +132,7 @@ func runCmd(staticConfiguration *static.Configuration) error {
 
 	stats(staticConfiguration)
 
-	svr, err := setupServer(staticConfiguration)
+	svr, err := setupServer(staticConfiguration, loadStaticConfiguration)
 	if err != nil {
 		return err
 	}
@@ -164,7 +182,7 @@ func runCmd(staticConfiguration *static.Configuration) error {
 	return nil
 }
 
-func setupServer(staticConfiguration *static.Configuration) (*server.Server, error) {
+func setupServer(staticConfiguration *static.Configuration, loadStaticConfiguration staticConfigurationLoader) (*server.Server, error) {
 	providerAggregator := aggregator.NewProviderAggregator(*staticConfiguration.Providers)
 
 	ctx := context.Background()
@@ -235,6 +253,11 @@ func setupServer(staticConfiguration *static.Configuration) (*server.Server, err
 		pluginLogger.Info().Msg("Loading plugins...")
 	}
 
+	pluginsSnapshot, err := snapshotPlugins(staticConfiguration.Experimental)
+	if err != nil {
+		return nil, err
+	}
+
 	pluginBuilder, err := createPluginBuilder(staticConfiguration)
 	if err != nil {
 		pluginLogger.Err(err).Msg("Cannot load required plugins.")
@@ -285,11 +308,55 @@ func setupServer(staticConfiguration *static.Configuration) (*server.Server, err
 	roundTripperManager := service.NewRoundTripperManager(spiffeX509Source)
 	dialerManager := tcp.NewDialerManager(spiffeX509Source)
 	acmeHTTPHandler := getHTTPChallengeHandler(acmeProviders, httpChallengeProvider)
-	managerFactory := service.NewManagerFactory(*staticConfiguration, routinesPool, observabilityMgr, roundTripperManager, acmeHTTPHandler)
+	var pluginsInventory api.PluginsInventory
+	if pluginBuilder != nil {
+		pluginsInventory = pluginBuilder
+	}
+
+	// The stores of the cache middlewares are kept across the configuration reloads.
+	cacheManager := cache.NewManager()
+	routinesPool.GoCtx(func(ctx context.Context) {
+		<-ctx.Done()
+		cacheManager.Close()
+	})
+
+	// The maintenance modes set at runtime through the API are kept across the configuration reloads.
+	maintenanceManager := maintenance.NewManager()
+
+	// The circuit breaker states are kept across the configuration reloads, and exposed through the API.
+	circuitBreakerManager := circuitbreaker.NewManager()
+
+	// The bans of the fail2Ban middlewares are kept across the configuration reloads, and managed through the API.
+	fail2BanManager := fail2ban.NewManager()
+	routinesPool.GoCtx(func(ctx context.Context) {
+		<-ctx.Done()
+		fail2BanManager.Close()
+	})
+
+	// The responses stored by the idempotency middlewares are kept across the configuration reloads.
+	idempotencyManager := idempotency.NewManager()
+	routinesPool.GoCtx(func(ctx context.Context) {
+		<-ctx.Done()
+		idempotencyManager.Close()
+	})
+
+	managerFactory := service.NewManagerFactory(*staticConfiguration, routinesPool, observabilityMgr, roundTripperManager, acmeHTTPHandler, api.Dependencies{
+		PluginsInventory:        pluginsInventory,
+		CachePurger:             cacheManager,
+		MaintenanceToggler:      maintenanceManager,
+		CircuitBreakerInspector: circuitBreakerManager,
+		BanManager:              fail2BanManager,
+	})
 
 	// Router factory
 
-	routerFactory := server.NewRouterFactory(*staticConfiguration, managerFactory, tlsManager, observabilityMgr, pluginBuilder, dialerManager)
+	routerFactory := server.NewRouterFactory(*staticConfiguration, managerFactory, tlsManager, observabilityMgr, pluginBuilder, dialerManager, middleware.Managers{
+		Cache:          cacheManager,
+		Maintenance:    maintenanceManager,
+		CircuitBreaker: circuitBreakerManager,
+		Fail2Ban:       fail2BanManager,
+		Idempotency:    idempotencyManager,
+	})
 
 	// Watcher
 
@@ -326,8 +393,26 @@ func setupServer(staticConfiguration *static.Configuration) (*server.Server, err
 	// Switch router
 	watcher.AddListener(switchRouter(routerFactory, serverEntryPointsTCP, serverEntryPointsUDP))
 
+	// Plugins hot-reload
+	if pluginBuilder != nil {
+		// The processes of the gRPC plugins are stopped along with Traefik.
+		routinesPool.GoCtx(func(ctx context.Context) {
+			<-ctx.Done()
+			pluginBuilder.Close()
+		})
+
+		watchPluginsReload(routinesPool, pluginsSnapshot, loadStaticConfiguration, pluginBuilder, watcher)
+
+		if hasLocalPlugins(staticConfiguration) {
+			err = pluginBuilder.WatchLocalPlugins(routinesPool, staticConfiguration.Experimental.LocalPlugins, watcher.Reapply)
+			if err != nil {
+				return nil, fmt.Errorf("plugin: %w", err)
+			}
+		}
+	}
+
 	// Metrics
-	if metricsRegistry.IsEpEnabled() || metricsRegistry.IsRouterEnabled() || metricsRegistry.IsSvcEnabled() {
+	if metricsRegistry.IsEpEnabled() || metricsRegistry.IsRouterEnabled() || metricsRegistry.IsSvcEnabled() || metricsRegistry.IsPluginEnabled() {
 		var eps []string
 		for key := range serverEntryPointsTCP {
 			eps = append(eps, key)
@@ -426,6 +511,8 @@ func switchRouter(routerFactory *server.RouterFactory, serverEntryPointsTCP serv
 
 		serverEntryPointsTCP.Switch(routers)
 		serverEntryPointsUDP.Switch(udpRouters)
+
+		routerFactory.DrainRemovedServers()
 	}
 }
 
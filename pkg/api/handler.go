@@ -51,12 +51,35 @@ type Handler struct {
 
 	// runtimeConfiguration is the data set used to create all the data representations exposed by the API.
 	runtimeConfiguration *runtime.Configuration
+
+	pluginsInventory        PluginsInventory
+	cachePurger             CachePurger
+	maintenanceToggler      MaintenanceToggler
+	circuitBreakerInspector CircuitBreakerInspector
+	banManager              BanManager
+}
+
+// Dependencies holds the components inspected and managed through the API.
+// All of them are optional.
+type Dependencies struct {
+	PluginsInventory        PluginsInventory
+	CachePurger             CachePurger
+	MaintenanceToggler      MaintenanceToggler
+	CircuitBreakerInspector CircuitBreakerInspector
+	BanManager              BanManager
 }
 
 // NewBuilder returns a http.Handler builder based on runtime.Configuration.
-func NewBuilder(staticConfig static.Configuration) func(*runtime.Configuration) http.Handler {
+func NewBuilder(staticConfig static.Configuration, deps Dependencies) func(*runtime.Configuration) http.Handler {
 	return func(configuration *runtime.Configuration) http.Handler {
-		return New(staticConfig, configuration).createRouter()
+		h := New(staticConfig, configuration)
+		h.pluginsInventory = deps.PluginsInventory
+		h.cachePurger = deps.CachePurger
+		h.maintenanceToggler = deps.MaintenanceToggler
+		h.circuitBreakerInspector = deps.CircuitBreakerInspector
+		h.banManager = deps.BanManager
+
+		return h.createRouter()
 	}
 }
 
@@ -96,6 +119,12 @@ func (h Handler) createRouter() *mux.Router {
 	router.Methods(http.MethodGet).Path("/api/http/services/{serviceID}").HandlerFunc(h.getService)
 	router.Methods(http.MethodGet).Path("/api/http/middlewares").HandlerFunc(h.getMiddlewares)
 	router.Methods(http.MethodGet).Path("/api/http/middlewares/{middlewareID}").HandlerFunc(h.getMiddleware)
+	router.Methods(http.MethodDelete).Path("/api/http/middlewares/{middlewareID}/cache").HandlerFunc(h.purgeMiddlewareCache)
+	router.Methods(http.MethodPut).Path("/api/http/middlewares/{middlewareID}/maintenance").HandlerFunc(h.setMiddlewareMaintenance)
+	router.Methods(http.MethodDelete).Path("/api/http/middlewares/{middlewareID}/maintenance").HandlerFunc(h.resetMiddlewareMaintenance)
+	router.Methods(http.MethodGet).Path("/api/http/middlewares/{middlewareID}/circuitbreaker").HandlerFunc(h.getMiddlewareCircuitBreaker)
+	router.Methods(http.MethodGet).Path("/api/http/middlewares/{middlewareID}/bans").HandlerFunc(h.getMiddlewareBans)
+	router.Methods(http.MethodDelete).Path("/api/http/middlewares/{middlewareID}/bans/{clientIP}").HandlerFunc(h.deleteMiddlewareBan)
 
 	router.Methods(http.MethodGet).Path("/api/tcp/routers").HandlerFunc(h.getTCPRouters)
 	router.Methods(http.MethodGet).Path("/api/tcp/routers/{routerID}").HandlerFunc(h.getTCPRouter)
@@ -109,6 +138,8 @@ func (h Handler) createRouter() *mux.Router {
 	router.Methods(http.MethodGet).Path("/api/udp/services").HandlerFunc(h.getUDPServices)
 	router.Methods(http.MethodGet).Path("/api/udp/services/{serviceID}").HandlerFunc(h.getUDPService)
 
+	router.Methods(http.MethodGet).Path("/api/plugins").HandlerFunc(h.getPlugins)
+
 	version.Handler{}.Append(router)
 
 	return router
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/middlewares/cache"
+)
+
+// CachePurger purges the responses stored by the cache middlewares.
+type CachePurger interface {
+	Purge(ctx context.Context, middlewareName, prefix string) error
+}
+
+func (h Handler) purgeMiddlewareCache(rw http.ResponseWriter, request *http.Request) {
+	scapedMiddlewareID := mux.Vars(request)["middlewareID"]
+
+	middlewareID, err := url.PathUnescape(scapedMiddlewareID)
+	if err != nil {
+		writeError(rw, fmt.Sprintf("unable to decode middlewareID %q: %s", scapedMiddlewareID, err), http.StatusBadRequest)
+		return
+	}
+
+	middleware, ok := h.runtimeConfiguration.Middlewares[middlewareID]
+	if !ok || middleware.Middleware == nil || middleware.Cache == nil || h.cachePurger == nil {
+		writeError(rw, fmt.Sprintf("cache middleware not found: %s", middlewareID), http.StatusNotFound)
+		return
+	}
+
+	err = h.cachePurger.Purge(request.Context(), middlewareID, request.URL.Query().Get("prefix"))
+	if errors.Is(err, cache.ErrCacheNotFound) {
+		writeError(rw, fmt.Sprintf("cache middleware not found: %s", middlewareID), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Ctx(request.Context()).Error().Err(err).Send()
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/middlewares/cache"
+)
+
+type cachePurgerMock struct {
+	err        error
+	middleware string
+	prefix     string
+}
+
+func (m *cachePurgerMock) Purge(_ context.Context, middlewareName, prefix string) error {
+	m.middleware = middlewareName
+	m.prefix = prefix
+
+	return m.err
+}
+
+func TestHandler_PurgeMiddlewareCache(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		path               string
+		purgeErr           error
+		expectedStatusCode int
+		expectedMiddleware string
+		expectedPrefix     string
+	}{
+		{
+			desc:               "purge all",
+			path:               "/api/http/middlewares/cache@myprovider/cache",
+			expectedStatusCode: http.StatusNoContent,
+			expectedMiddleware: "cache@myprovider",
+		},
+		{
+			desc:               "purge prefix",
+			path:               "/api/http/middlewares/cache@myprovider/cache?prefix=example.com/foo",
+			expectedStatusCode: http.StatusNoContent,
+			expectedMiddleware: "cache@myprovider",
+			expectedPrefix:     "example.com/foo",
+		},
+		{
+			desc:               "not a cache middleware",
+			path:               "/api/http/middlewares/auth@myprovider/cache",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			desc:               "unknown middleware",
+			path:               "/api/http/middlewares/unknown@myprovider/cache",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			desc:               "cache not created yet",
+			path:               "/api/http/middlewares/cache@myprovider/cache",
+			purgeErr:           cache.ErrCacheNotFound,
+			expectedStatusCode: http.StatusNotFound,
+			expectedMiddleware: "cache@myprovider",
+		},
+		{
+			desc:               "purge error",
+			path:               "/api/http/middlewares/cache@myprovider/cache",
+			purgeErr:           errors.New("connection refused"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedMiddleware: "cache@myprovider",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rtConf := &runtime.Configuration{
+				Middlewares: map[string]*runtime.MiddlewareInfo{
+					"cache@myprovider": {
+						Middleware: &dynamic.Middleware{Cache: &dynamic.Cache{}},
+					},
+					"auth@myprovider": {
+						Middleware: &dynamic.Middleware{BasicAuth: &dynamic.BasicAuth{}},
+					},
+				},
+			}
+
+			purger := &cachePurgerMock{err: test.purgeErr}
+
+			handler := NewBuilder(static.Configuration{API: &static.API{}, Global: &static.Global{}}, Dependencies{CachePurger: purger})(rtConf)
+			server := httptest.NewServer(handler)
+			t.Cleanup(server.Close)
+
+			req, err := http.NewRequest(http.MethodDelete, server.URL+test.path, nil)
+			require.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, test.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, test.expectedMiddleware, purger.middleware)
+			assert.Equal(t, test.expectedPrefix, purger.prefix)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/middlewares/circuitbreaker"
+)
+
+// CircuitBreakerInspector returns the status of the circuit breakers of the circuitBreaker middlewares.
+type CircuitBreakerInspector interface {
+	Status(middlewareName string) (circuitbreaker.Status, bool)
+}
+
+func (h Handler) getMiddlewareCircuitBreaker(rw http.ResponseWriter, request *http.Request) {
+	scapedMiddlewareID := mux.Vars(request)["middlewareID"]
+
+	middlewareID, err := url.PathUnescape(scapedMiddlewareID)
+	if err != nil {
+		writeError(rw, fmt.Sprintf("unable to decode middlewareID %q: %s", scapedMiddlewareID, err), http.StatusBadRequest)
+		return
+	}
+
+	middleware, ok := h.runtimeConfiguration.Middlewares[middlewareID]
+	if !ok || middleware.Middleware == nil || middleware.CircuitBreaker == nil || h.circuitBreakerInspector == nil {
+		writeError(rw, fmt.Sprintf("circuit breaker middleware not found: %s", middlewareID), http.StatusNotFound)
+		return
+	}
+
+	status, ok := h.circuitBreakerInspector.Status(middlewareID)
+	if !ok {
+		writeError(rw, fmt.Sprintf("circuit breaker middleware not in use: %s", middlewareID), http.StatusNotFound)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(rw).Encode(status)
+	if err != nil {
+		log.Ctx(request.Context()).Error().Err(err).Send()
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
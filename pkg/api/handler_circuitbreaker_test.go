@@ -0,0 +1,100 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/middlewares/circuitbreaker"
+)
+
+type circuitBreakerInspectorMock map[string]circuitbreaker.Status
+
+func (m circuitBreakerInspectorMock) Status(middlewareName string) (circuitbreaker.Status, bool) {
+	status, ok := m[middlewareName]
+	return status, ok
+}
+
+func TestHandler_MiddlewareCircuitBreaker(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		path               string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			desc:               "circuit breaker status",
+			path:               "/api/http/middlewares/cb@myprovider/circuitbreaker",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"perBackend":true,"breakers":[{"router":"web@myprovider","backend":"first","state":"open","trips":2,"evaluations":10,"matches":2},{"router":"web@myprovider","backend":"second","state":"closed","trips":0,"evaluations":10,"matches":0}]}`,
+		},
+		{
+			desc:               "circuit breaker not in use",
+			path:               "/api/http/middlewares/unused@myprovider/circuitbreaker",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			desc:               "not a circuit breaker middleware",
+			path:               "/api/http/middlewares/auth@myprovider/circuitbreaker",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			desc:               "unknown middleware",
+			path:               "/api/http/middlewares/unknown@myprovider/circuitbreaker",
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rtConf := &runtime.Configuration{
+				Middlewares: map[string]*runtime.MiddlewareInfo{
+					"cb@myprovider": {
+						Middleware: &dynamic.Middleware{CircuitBreaker: &dynamic.CircuitBreaker{}},
+					},
+					"unused@myprovider": {
+						Middleware: &dynamic.Middleware{CircuitBreaker: &dynamic.CircuitBreaker{}},
+					},
+					"auth@myprovider": {
+						Middleware: &dynamic.Middleware{BasicAuth: &dynamic.BasicAuth{}},
+					},
+				},
+			}
+
+			inspector := circuitBreakerInspectorMock{
+				"cb@myprovider": {
+					PerBackend: true,
+					Breakers: []circuitbreaker.BreakerStatus{
+						{Router: "web@myprovider", Backend: "first", State: "open", Trips: 2, Evaluations: 10, Matches: 2},
+						{Router: "web@myprovider", Backend: "second", State: "closed", Evaluations: 10},
+					},
+				},
+			}
+
+			handler := NewBuilder(static.Configuration{API: &static.API{}, Global: &static.Global{}}, Dependencies{CircuitBreakerInspector: inspector})(rtConf)
+			server := httptest.NewServer(handler)
+			t.Cleanup(server.Close)
+
+			resp, err := http.Get(server.URL + test.path)
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, test.expectedStatusCode, resp.StatusCode)
+
+			if test.expectedBody != "" {
+				assert.JSONEq(t, test.expectedBody, string(body))
+			}
+		})
+	}
+}
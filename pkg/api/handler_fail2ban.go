@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/middlewares/fail2ban"
+)
+
+// BanManager lists and lifts the bans of the fail2Ban middlewares.
+type BanManager interface {
+	Bans(ctx context.Context, middlewareName string) ([]fail2ban.Ban, error)
+	Unban(ctx context.Context, middlewareName, clientIP string) (bool, error)
+}
+
+func (h Handler) getMiddlewareBans(rw http.ResponseWriter, request *http.Request) {
+	middlewareID, ok := h.getFail2BanMiddlewareID(rw, request)
+	if !ok {
+		return
+	}
+
+	bans, err := h.banManager.Bans(request.Context(), middlewareID)
+	if errors.Is(err, fail2ban.ErrMiddlewareNotFound) {
+		writeError(rw, fmt.Sprintf("fail2Ban middleware not in use: %s", middlewareID), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Ctx(request.Context()).Error().Err(err).Send()
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(rw).Encode(bans)
+	if err != nil {
+		log.Ctx(request.Context()).Error().Err(err).Send()
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h Handler) deleteMiddlewareBan(rw http.ResponseWriter, request *http.Request) {
+	middlewareID, ok := h.getFail2BanMiddlewareID(rw, request)
+	if !ok {
+		return
+	}
+
+	scapedClientIP := mux.Vars(request)["clientIP"]
+
+	clientIP, err := url.PathUnescape(scapedClientIP)
+	if err != nil {
+		writeError(rw, fmt.Sprintf("unable to decode clientIP %q: %s", scapedClientIP, err), http.StatusBadRequest)
+		return
+	}
+
+	unbanned, err := h.banManager.Unban(request.Context(), middlewareID, clientIP)
+	if errors.Is(err, fail2ban.ErrMiddlewareNotFound) {
+		writeError(rw, fmt.Sprintf("fail2Ban middleware not in use: %s", middlewareID), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Ctx(request.Context()).Error().Err(err).Send()
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !unbanned {
+		writeError(rw, fmt.Sprintf("client not banned: %s", clientIP), http.StatusNotFound)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// getFail2BanMiddlewareID returns the ID of the fail2Ban middleware targeted by the request,
+// and writes the error response when there is no such middleware.
+func (h Handler) getFail2BanMiddlewareID(rw http.ResponseWriter, request *http.Request) (string, bool) {
+	scapedMiddlewareID := mux.Vars(request)["middlewareID"]
+
+	middlewareID, err := url.PathUnescape(scapedMiddlewareID)
+	if err != nil {
+		writeError(rw, fmt.Sprintf("unable to decode middlewareID %q: %s", scapedMiddlewareID, err), http.StatusBadRequest)
+		return "", false
+	}
+
+	middleware, ok := h.runtimeConfiguration.Middlewares[middlewareID]
+	if !ok || middleware.Middleware == nil || middleware.Fail2Ban == nil || h.banManager == nil {
+		writeError(rw, fmt.Sprintf("fail2Ban middleware not found: %s", middlewareID), http.StatusNotFound)
+		return "", false
+	}
+
+	return middlewareID, true
+}
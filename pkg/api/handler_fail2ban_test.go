@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/middlewares/fail2ban"
+)
+
+type banManagerMock map[string][]fail2ban.Ban
+
+func (m banManagerMock) Bans(_ context.Context, middlewareName string) ([]fail2ban.Ban, error) {
+	bans, ok := m[middlewareName]
+	if !ok {
+		return nil, fail2ban.ErrMiddlewareNotFound
+	}
+
+	return bans, nil
+}
+
+func (m banManagerMock) Unban(_ context.Context, middlewareName, clientIP string) (bool, error) {
+	bans, ok := m[middlewareName]
+	if !ok {
+		return false, fail2ban.ErrMiddlewareNotFound
+	}
+
+	for _, ban := range bans {
+		if ban.ClientIP == clientIP {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func TestHandler_MiddlewareBans(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		method             string
+		path               string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			desc:               "list bans",
+			method:             http.MethodGet,
+			path:               "/api/http/middlewares/ban@myprovider/bans",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `[{"clientIP":"10.0.0.1","until":"2024-01-01T00:00:00Z"}]`,
+		},
+		{
+			desc:               "list bans of a middleware not in use",
+			method:             http.MethodGet,
+			path:               "/api/http/middlewares/unused@myprovider/bans",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			desc:               "list bans of a middleware which is not a fail2Ban middleware",
+			method:             http.MethodGet,
+			path:               "/api/http/middlewares/auth@myprovider/bans",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			desc:               "unban",
+			method:             http.MethodDelete,
+			path:               "/api/http/middlewares/ban@myprovider/bans/10.0.0.1",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			desc:               "unban IPv6 client",
+			method:             http.MethodDelete,
+			path:               "/api/http/middlewares/ban@myprovider/bans/2001:db8::1",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			desc:               "unban client not banned",
+			method:             http.MethodDelete,
+			path:               "/api/http/middlewares/ban@myprovider/bans/10.0.0.2",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			desc:               "unban on unknown middleware",
+			method:             http.MethodDelete,
+			path:               "/api/http/middlewares/unknown@myprovider/bans/10.0.0.1",
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rtConf := &runtime.Configuration{
+				Middlewares: map[string]*runtime.MiddlewareInfo{
+					"ban@myprovider": {
+						Middleware: &dynamic.Middleware{Fail2Ban: &dynamic.Fail2Ban{}},
+					},
+					"unused@myprovider": {
+						Middleware: &dynamic.Middleware{Fail2Ban: &dynamic.Fail2Ban{}},
+					},
+					"auth@myprovider": {
+						Middleware: &dynamic.Middleware{BasicAuth: &dynamic.BasicAuth{}},
+					},
+				},
+			}
+
+			manager := banManagerMock{
+				"ban@myprovider": {
+					{ClientIP: "10.0.0.1", Until: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{ClientIP: "2001:db8::1", Until: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			}
+			if test.method == http.MethodGet {
+				manager["ban@myprovider"] = manager["ban@myprovider"][:1]
+			}
+
+			handler := NewBuilder(static.Configuration{API: &static.API{}, Global: &static.Global{}}, Dependencies{BanManager: manager})(rtConf)
+			server := httptest.NewServer(handler)
+			t.Cleanup(server.Close)
+
+			req, err := http.NewRequest(test.method, server.URL+test.path, nil)
+			require.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, test.expectedStatusCode, resp.StatusCode)
+
+			if test.expectedBody != "" {
+				assert.JSONEq(t, test.expectedBody, string(body))
+			}
+		})
+	}
+}
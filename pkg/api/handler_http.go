@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -18,9 +19,12 @@ type routerRepresentation struct {
 	*runtime.RouterInfo
 	Name     string `json:"name,omitempty"`
 	Provider string `json:"provider,omitempty"`
+
+	// Degraded is true when one of the plugin middlewares of the router is unhealthy.
+	Degraded bool `json:"degraded,omitempty"`
 }
 
-func newRouterRepresentation(name string, rt *runtime.RouterInfo) routerRepresentation {
+func newRouterRepresentation(name string, rt *runtime.RouterInfo, middlewares map[string]*runtime.MiddlewareInfo) routerRepresentation {
 	if rt.TLS != nil && rt.TLS.Options == "" {
 		rt.TLS.Options = tls.DefaultTLSConfigName
 	}
@@ -29,7 +33,23 @@ func newRouterRepresentation(name string, rt *runtime.RouterInfo) routerRepresen
 		RouterInfo: rt,
 		Name:       name,
 		Provider:   getProviderName(name),
+		Degraded:   isRouterDegraded(name, middlewares),
+	}
+}
+
+// isRouterDegraded returns true if one of the plugin middlewares used by the router is unhealthy.
+func isRouterDegraded(name string, middlewares map[string]*runtime.MiddlewareInfo) bool {
+	for _, mi := range middlewares {
+		if health, _ := mi.GetPluginHealth(); health != runtime.PluginHealthUnhealthy {
+			continue
+		}
+
+		if slices.Contains(mi.UsedBy, name) {
+			return true
+		}
 	}
+
+	return false
 }
 
 type serviceRepresentation struct {
@@ -55,14 +75,23 @@ type middlewareRepresentation struct {
 	Name     string `json:"name,omitempty"`
 	Provider string `json:"provider,omitempty"`
 	Type     string `json:"type,omitempty"`
+
+	PluginStatus      string `json:"pluginStatus,omitempty"`
+	PluginHealth      string `json:"pluginHealth,omitempty"`
+	PluginHealthError string `json:"pluginHealthError,omitempty"`
 }
 
 func newMiddlewareRepresentation(name string, mi *runtime.MiddlewareInfo) middlewareRepresentation {
+	pluginHealth, pluginHealthError := mi.GetPluginHealth()
+
 	return middlewareRepresentation{
-		MiddlewareInfo: mi,
-		Name:           name,
-		Provider:       getProviderName(name),
-		Type:           strings.ToLower(extractType(mi.Middleware)),
+		MiddlewareInfo:    mi,
+		Name:              name,
+		Provider:          getProviderName(name),
+		Type:              strings.ToLower(extractType(mi.Middleware)),
+		PluginStatus:      mi.GetPluginStatus(),
+		PluginHealth:      pluginHealth,
+		PluginHealthError: pluginHealthError,
 	}
 }
 
@@ -74,7 +103,7 @@ func (h Handler) getRouters(rw http.ResponseWriter, request *http.Request) {
 
 	for name, rt := range h.runtimeConfiguration.Routers {
 		if keepRouter(name, rt, criterion) {
-			results = append(results, newRouterRepresentation(name, rt))
+			results = append(results, newRouterRepresentation(name, rt, h.runtimeConfiguration.Middlewares))
 		}
 	}
 
@@ -114,7 +143,7 @@ func (h Handler) getRouter(rw http.ResponseWriter, request *http.Request) {
 		return
 	}
 
-	result := newRouterRepresentation(routerID, router)
+	result := newRouterRepresentation(routerID, router, h.runtimeConfiguration.Middlewares)
 
 	err = json.NewEncoder(rw).Encode(result)
 	if err != nil {
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -1047,6 +1048,24 @@ func TestHandler_HTTP(t *testing.T) {
 	}
 }
 
+func TestIsRouterDegraded(t *testing.T) {
+	unhealthy := &runtime.MiddlewareInfo{UsedBy: []string{"bar@myprovider"}}
+	unhealthy.SetPluginHealth(errors.New("backend unreachable"))
+
+	healthy := &runtime.MiddlewareInfo{UsedBy: []string{"foo@myprovider", "bar@myprovider"}}
+	healthy.SetPluginHealth(nil)
+
+	middlewares := map[string]*runtime.MiddlewareInfo{
+		"unhealthy@myprovider": unhealthy,
+		"healthy@myprovider":   healthy,
+		"other@myprovider":     {UsedBy: []string{"foo@myprovider"}},
+	}
+
+	assert.True(t, isRouterDegraded("bar@myprovider", middlewares))
+	assert.False(t, isRouterDegraded("foo@myprovider", middlewares))
+	assert.False(t, isRouterDegraded("baz@myprovider", middlewares))
+}
+
 func generateHTTPRouters(nbRouters int) map[string]*runtime.RouterInfo {
 	routers := make(map[string]*runtime.RouterInfo, nbRouters)
 	for i := range nbRouters {
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+)
+
+// MaintenanceToggler sets the maintenance modes of the maintenance middlewares at runtime.
+type MaintenanceToggler interface {
+	SetEnabled(middlewareName string, enabled bool)
+	Reset(middlewareName string)
+}
+
+type maintenanceRepresentation struct {
+	Enabled *bool `json:"enabled"`
+}
+
+func (h Handler) setMiddlewareMaintenance(rw http.ResponseWriter, request *http.Request) {
+	middlewareID, ok := h.getMaintenanceMiddlewareID(rw, request)
+	if !ok {
+		return
+	}
+
+	var state maintenanceRepresentation
+	if err := json.NewDecoder(request.Body).Decode(&state); err != nil {
+		writeError(rw, fmt.Sprintf("unable to decode maintenance state: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if state.Enabled == nil {
+		writeError(rw, "missing enabled field", http.StatusBadRequest)
+		return
+	}
+
+	h.maintenanceToggler.SetEnabled(middlewareID, *state.Enabled)
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (h Handler) resetMiddlewareMaintenance(rw http.ResponseWriter, request *http.Request) {
+	middlewareID, ok := h.getMaintenanceMiddlewareID(rw, request)
+	if !ok {
+		return
+	}
+
+	h.maintenanceToggler.Reset(middlewareID)
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// getMaintenanceMiddlewareID returns the ID of the maintenance middleware targeted by the request,
+// and writes the error response when there is no such middleware.
+func (h Handler) getMaintenanceMiddlewareID(rw http.ResponseWriter, request *http.Request) (string, bool) {
+	scapedMiddlewareID := mux.Vars(request)["middlewareID"]
+
+	middlewareID, err := url.PathUnescape(scapedMiddlewareID)
+	if err != nil {
+		writeError(rw, fmt.Sprintf("unable to decode middlewareID %q: %s", scapedMiddlewareID, err), http.StatusBadRequest)
+		return "", false
+	}
+
+	middleware, ok := h.runtimeConfiguration.Middlewares[middlewareID]
+	if !ok || middleware.Middleware == nil || middleware.Maintenance == nil || h.maintenanceToggler == nil {
+		writeError(rw, fmt.Sprintf("maintenance middleware not found: %s", middlewareID), http.StatusNotFound)
+		return "", false
+	}
+
+	return middlewareID, true
+}
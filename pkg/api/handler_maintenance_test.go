@@ -0,0 +1,132 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+)
+
+type maintenanceTogglerMock struct {
+	middleware string
+	enabled    *bool
+	reset      bool
+}
+
+func (m *maintenanceTogglerMock) SetEnabled(middlewareName string, enabled bool) {
+	m.middleware = middlewareName
+	m.enabled = &enabled
+}
+
+func (m *maintenanceTogglerMock) Reset(middlewareName string) {
+	m.middleware = middlewareName
+	m.reset = true
+}
+
+func TestHandler_MiddlewareMaintenance(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		method             string
+		path               string
+		body               string
+		expectedStatusCode int
+		expectedMiddleware string
+		expectedEnabled    *bool
+		expectedReset      bool
+	}{
+		{
+			desc:               "enable",
+			method:             http.MethodPut,
+			path:               "/api/http/middlewares/maintenance@myprovider/maintenance",
+			body:               `{"enabled":true}`,
+			expectedStatusCode: http.StatusNoContent,
+			expectedMiddleware: "maintenance@myprovider",
+			expectedEnabled:    Bool(true),
+		},
+		{
+			desc:               "disable",
+			method:             http.MethodPut,
+			path:               "/api/http/middlewares/maintenance@myprovider/maintenance",
+			body:               `{"enabled":false}`,
+			expectedStatusCode: http.StatusNoContent,
+			expectedMiddleware: "maintenance@myprovider",
+			expectedEnabled:    Bool(false),
+		},
+		{
+			desc:               "reset",
+			method:             http.MethodDelete,
+			path:               "/api/http/middlewares/maintenance@myprovider/maintenance",
+			expectedStatusCode: http.StatusNoContent,
+			expectedMiddleware: "maintenance@myprovider",
+			expectedReset:      true,
+		},
+		{
+			desc:               "missing enabled field",
+			method:             http.MethodPut,
+			path:               "/api/http/middlewares/maintenance@myprovider/maintenance",
+			body:               `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "invalid body",
+			method:             http.MethodPut,
+			path:               "/api/http/middlewares/maintenance@myprovider/maintenance",
+			body:               `enabled`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "not a maintenance middleware",
+			method:             http.MethodPut,
+			path:               "/api/http/middlewares/auth@myprovider/maintenance",
+			body:               `{"enabled":true}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			desc:               "unknown middleware",
+			method:             http.MethodDelete,
+			path:               "/api/http/middlewares/unknown@myprovider/maintenance",
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rtConf := &runtime.Configuration{
+				Middlewares: map[string]*runtime.MiddlewareInfo{
+					"maintenance@myprovider": {
+						Middleware: &dynamic.Middleware{Maintenance: &dynamic.Maintenance{}},
+					},
+					"auth@myprovider": {
+						Middleware: &dynamic.Middleware{BasicAuth: &dynamic.BasicAuth{}},
+					},
+				},
+			}
+
+			toggler := &maintenanceTogglerMock{}
+
+			handler := NewBuilder(static.Configuration{API: &static.API{}, Global: &static.Global{}}, Dependencies{MaintenanceToggler: toggler})(rtConf)
+			server := httptest.NewServer(handler)
+			t.Cleanup(server.Close)
+
+			req, err := http.NewRequest(test.method, server.URL+test.path, strings.NewReader(test.body))
+			require.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, test.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, test.expectedMiddleware, toggler.middleware)
+			assert.Equal(t, test.expectedEnabled, toggler.enabled)
+			assert.Equal(t, test.expectedReset, toggler.reset)
+		})
+	}
+}
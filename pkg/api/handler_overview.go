@@ -8,6 +8,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
 	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/plugins"
 )
 
 type schemeOverview struct {
@@ -29,12 +30,21 @@ type features struct {
 	// TODO add certificates resolvers
 }
 
+// pluginsOverview counts the plugins of the static configuration,
+// the non-required plugins skipped because they could not be set up being warnings.
+type pluginsOverview struct {
+	Total    int            `json:"total"`
+	Warnings int            `json:"warnings"`
+	Skipped  []plugins.Info `json:"skipped,omitempty"`
+}
+
 type overview struct {
-	HTTP      schemeOverview `json:"http"`
-	TCP       schemeOverview `json:"tcp"`
-	UDP       schemeOverview `json:"udp"`
-	Features  features       `json:"features,omitempty"`
-	Providers []string       `json:"providers,omitempty"`
+	HTTP      schemeOverview   `json:"http"`
+	TCP       schemeOverview   `json:"tcp"`
+	UDP       schemeOverview   `json:"udp"`
+	Features  features         `json:"features,omitempty"`
+	Providers []string         `json:"providers,omitempty"`
+	Plugins   *pluginsOverview `json:"plugins,omitempty"`
 }
 
 func (h Handler) getOverview(rw http.ResponseWriter, request *http.Request) {
@@ -55,6 +65,7 @@ func (h Handler) getOverview(rw http.ResponseWriter, request *http.Request) {
 		},
 		Features:  getFeatures(h.staticConfig),
 		Providers: getProviders(h.staticConfig),
+		Plugins:   getPluginsOverview(h.pluginsInventory),
 	}
 
 	rw.Header().Set("Content-Type", "application/json")
@@ -66,6 +77,27 @@ func (h Handler) getOverview(rw http.ResponseWriter, request *http.Request) {
 	}
 }
 
+func getPluginsOverview(inventory PluginsInventory) *pluginsOverview {
+	if inventory == nil {
+		return nil
+	}
+
+	infos := inventory.Inventory()
+	if len(infos) == 0 {
+		return nil
+	}
+
+	result := &pluginsOverview{Total: len(infos)}
+	for _, info := range infos {
+		if info.Status == plugins.StatusSkipped {
+			result.Warnings++
+			result.Skipped = append(result.Skipped, info)
+		}
+	}
+
+	return result
+}
+
 func getHTTPRouterSection(routers map[string]*runtime.RouterInfo) *section {
 	var countErrors int
 	var countWarnings int
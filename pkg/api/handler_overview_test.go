@@ -13,6 +13,7 @@ import (
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
 	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/plugins"
 	"github.com/traefik/traefik/v3/pkg/provider/docker"
 	"github.com/traefik/traefik/v3/pkg/provider/file"
 	"github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd"
@@ -32,6 +33,7 @@ func TestHandler_Overview(t *testing.T) {
 		path       string
 		confStatic static.Configuration
 		confDyn    runtime.Configuration
+		inventory  PluginsInventory
 		expected   expected
 	}{
 		{
@@ -266,6 +268,33 @@ func TestHandler_Overview(t *testing.T) {
 				jsonFile:   "testdata/overview-features.json",
 			},
 		},
+		{
+			desc:       "with skipped plugins",
+			path:       "/api/overview",
+			confStatic: static.Configuration{API: &static.API{}, Global: &static.Global{}},
+			confDyn:    runtime.Configuration{},
+			inventory: pluginsInventoryMock{
+				{
+					Name:       "demo",
+					ModuleName: "github.com/traefik/plugindemo",
+					Version:    "v0.2.1",
+					Type:       "middleware",
+					Runtime:    "yaegi",
+					Status:     plugins.StatusEnabled,
+				},
+				{
+					Name:       "unavailable",
+					ModuleName: "github.com/traefik/pluginunavailable",
+					Version:    "v1.0.0",
+					Status:     plugins.StatusSkipped,
+					Error:      "failed to download plugin github.com/traefik/pluginunavailable: error: 404: Not Found",
+				},
+			},
+			expected: expected{
+				statusCode: http.StatusOK,
+				jsonFile:   "testdata/overview-plugins.json",
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -273,6 +302,7 @@ func TestHandler_Overview(t *testing.T) {
 			t.Parallel()
 
 			handler := New(test.confStatic, &test.confDyn)
+			handler.pluginsInventory = test.inventory
 			server := httptest.NewServer(handler.createRouter())
 
 			resp, err := http.DefaultClient.Get(server.URL + test.path)
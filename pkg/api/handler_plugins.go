@@ -0,0 +1,38 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/plugins"
+)
+
+// PluginsInventory provides the information about the plugins of the static configuration.
+type PluginsInventory interface {
+	Inventory() []plugins.Info
+}
+
+func (h Handler) getPlugins(rw http.ResponseWriter, request *http.Request) {
+	results := make([]plugins.Info, 0)
+	if h.pluginsInventory != nil {
+		results = append(results, h.pluginsInventory.Inventory()...)
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+
+	pageInfo, err := pagination(request, len(results))
+	if err != nil {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rw.Header().Set(nextPageHeader, strconv.Itoa(pageInfo.nextPage))
+
+	err = json.NewEncoder(rw).Encode(results[pageInfo.startIndex:pageInfo.endIndex])
+	if err != nil {
+		log.Ctx(request.Context()).Error().Err(err).Send()
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/plugins"
+)
+
+type pluginsInventoryMock []plugins.Info
+
+func (m pluginsInventoryMock) Inventory() []plugins.Info {
+	return m
+}
+
+func TestHandler_Plugins(t *testing.T) {
+	type expected struct {
+		statusCode int
+		nextPage   string
+		jsonFile   string
+	}
+
+	testCases := []struct {
+		desc      string
+		path      string
+		inventory PluginsInventory
+		expected  expected
+	}{
+		{
+			desc: "no plugins inventory",
+			path: "/api/plugins",
+			expected: expected{
+				statusCode: http.StatusOK,
+				nextPage:   "1",
+				jsonFile:   "testdata/plugins-empty.json",
+			},
+		},
+		{
+			desc: "all plugins",
+			path: "/api/plugins",
+			inventory: pluginsInventoryMock{
+				{
+					Name:       "demo",
+					ModuleName: "github.com/traefik/plugindemo",
+					Version:    "v0.2.1",
+					Type:       "middleware",
+					Runtime:    "yaegi",
+					Status:     plugins.StatusEnabled,
+				},
+				{
+					Name:       "local",
+					ModuleName: "github.com/traefik/pluginlocal",
+					Type:       "provider",
+					Runtime:    "wasm",
+					Local:      true,
+					Status:     plugins.StatusEnabled,
+				},
+				{
+					Name:       "unavailable",
+					ModuleName: "github.com/traefik/pluginunavailable",
+					Version:    "v1.0.0",
+					Status:     plugins.StatusSkipped,
+					Error:      "unable to download plugin github.com/traefik/pluginunavailable: error: 404: not found",
+				},
+			},
+			expected: expected{
+				statusCode: http.StatusOK,
+				nextPage:   "1",
+				jsonFile:   "testdata/plugins.json",
+			},
+		},
+		{
+			desc: "plugins, second page",
+			path: "/api/plugins?page=2&per_page=1",
+			inventory: pluginsInventoryMock{
+				{Name: "bar", ModuleName: "github.com/traefik/pluginbar", Status: plugins.StatusEnabled},
+				{Name: "foo", ModuleName: "github.com/traefik/pluginfoo", Status: plugins.StatusEnabled},
+			},
+			expected: expected{
+				statusCode: http.StatusOK,
+				nextPage:   "1",
+				jsonFile:   "testdata/plugins-page2.json",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := New(static.Configuration{API: &static.API{}, Global: &static.Global{}}, &runtime.Configuration{})
+			handler.pluginsInventory = test.inventory
+			server := httptest.NewServer(handler.createRouter())
+
+			resp, err := http.DefaultClient.Get(server.URL + test.path)
+			require.NoError(t, err)
+
+			require.Equal(t, test.expected.statusCode, resp.StatusCode)
+
+			assert.Equal(t, test.expected.nextPage, resp.Header.Get(nextPageHeader))
+
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			contents, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+
+			if *updateExpected {
+				var results interface{}
+				err := json.Unmarshal(contents, &results)
+				require.NoError(t, err)
+
+				newJSON, err := json.MarshalIndent(results, "", "\t")
+				require.NoError(t, err)
+
+				err = os.WriteFile(test.expected.jsonFile, newJSON, 0o644)
+				require.NoError(t, err)
+			}
+
+			data, err := os.ReadFile(test.expected.jsonFile)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(data), string(contents))
+		})
+	}
+}
@@ -21,6 +21,9 @@ const (
 
 	// DefaultFlushInterval is the default value for the ResponseForwarding flush interval.
 	DefaultFlushInterval = ptypes.Duration(100 * time.Millisecond)
+
+	// DefaultDrainGracePeriod is the default value for the Drain grace period.
+	DefaultDrainGracePeriod = ptypes.Duration(30 * time.Second)
 )
 
 // +k8s:deepcopy-gen=true
@@ -51,6 +54,7 @@ type Service struct {
 	Weighted     *WeightedRoundRobin  `json:"weighted,omitempty" toml:"weighted,omitempty" yaml:"weighted,omitempty" label:"-" export:"true"`
 	Mirroring    *Mirroring           `json:"mirroring,omitempty" toml:"mirroring,omitempty" yaml:"mirroring,omitempty" label:"-" export:"true"`
 	Failover     *Failover            `json:"failover,omitempty" toml:"failover,omitempty" yaml:"failover,omitempty" label:"-" export:"true"`
+	TrafficSplit *TrafficSplit        `json:"trafficSplit,omitempty" toml:"trafficSplit,omitempty" yaml:"trafficSplit,omitempty" label:"-" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -64,7 +68,19 @@ type Router struct {
 	RuleSyntax  string           `json:"ruleSyntax,omitempty" toml:"ruleSyntax,omitempty" yaml:"ruleSyntax,omitempty" export:"true"`
 	Priority    int              `json:"priority,omitempty" toml:"priority,omitempty,omitzero" yaml:"priority,omitempty" export:"true"`
 	TLS         *RouterTLSConfig `json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
-	DefaultRule bool             `json:"-" toml:"-" yaml:"-" label:"-" file:"-"`
+	// RespondingTimeouts overrides the responding timeouts of the entry points for the requests handled by the router.
+	RespondingTimeouts *RouterRespondingTimeouts `json:"respondingTimeouts,omitempty" toml:"respondingTimeouts,omitempty" yaml:"respondingTimeouts,omitempty" export:"true"`
+	// MaxRequestBodyBytes limits the size of the bodies of the requests handled by the router.
+	MaxRequestBodyBytes int64 `json:"maxRequestBodyBytes,omitempty" toml:"maxRequestBodyBytes,omitempty" yaml:"maxRequestBodyBytes,omitempty" export:"true"`
+	DefaultRule         bool  `json:"-" toml:"-" yaml:"-" label:"-" file:"-"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RouterRespondingTimeouts holds the timeouts overriding the ones of the entry points for the requests handled by a router.
+type RouterRespondingTimeouts struct {
+	ReadTimeout  ptypes.Duration `description:"ReadTimeout is the maximum duration for reading the rest of the request, including the body, once routed. If zero, the timeout of the entry point applies." json:"readTimeout,omitempty" toml:"readTimeout,omitempty" yaml:"readTimeout,omitempty" export:"true"`
+	WriteTimeout ptypes.Duration `description:"WriteTimeout is the maximum duration before timing out writes of the response, once routed. If zero, the timeout of the entry point applies." json:"writeTimeout,omitempty" toml:"writeTimeout,omitempty" yaml:"writeTimeout,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -106,14 +122,53 @@ type Failover struct {
 
 // +k8s:deepcopy-gen=true
 
-// MirrorService holds the MirrorService configuration.
-type MirrorService struct {
+// TrafficSplit holds the TrafficSplit configuration.
+// It assigns each client to a variant, and forwards its requests to the service of the variant.
+type TrafficSplit struct {
+	Variants []SplitVariant `json:"variants,omitempty" toml:"variants,omitempty" yaml:"variants,omitempty" export:"true"`
+	// Cookie defines the cookie used to keep a client on its assigned variant.
+	Cookie *Cookie `json:"cookie,omitempty" toml:"cookie,omitempty" yaml:"cookie,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// HashHeader defines the request header whose value is hashed to assign the variant,
+	// when the client does not hold a valid variant cookie.
+	HashHeader string `json:"hashHeader,omitempty" toml:"hashHeader,omitempty" yaml:"hashHeader,omitempty" export:"true"`
+	// VariantHeader defines the request header carrying the assigned variant name to the backend.
+	VariantHeader string `json:"variantHeader,omitempty" toml:"variantHeader,omitempty" yaml:"variantHeader,omitempty" export:"true"`
+}
+
+// SetDefaults Default values for a TrafficSplit.
+func (t *TrafficSplit) SetDefaults() {
+	t.VariantHeader = "X-Variant"
+}
+
+// +k8s:deepcopy-gen=true
+
+// SplitVariant is a variant of a TrafficSplit, served by a service.
+type SplitVariant struct {
 	Name    string `json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty" export:"true"`
+	Service string `json:"service,omitempty" toml:"service,omitempty" yaml:"service,omitempty" export:"true"`
 	Percent int    `json:"percent,omitempty" toml:"percent,omitempty" yaml:"percent,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
 
+// MirrorService holds the MirrorService configuration.
+type MirrorService struct {
+	Name string `json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty" export:"true"`
+	// Percent defines the percentage of the requests, among the ones matching the Match rule, mirrored to the service.
+	Percent int `json:"percent,omitempty" toml:"percent,omitempty" yaml:"percent,omitempty" export:"true"`
+	// Match defines the rule, in the router rule syntax, matching the requests mirrored to the service,
+	// e.g. Method(`POST`) && PathPrefix(`/api`) or Header(`X-Shadow`, `true`).
+	Match string `json:"match,omitempty" toml:"match,omitempty" yaml:"match,omitempty" export:"true"`
+	// MaxBodySize defines the maximum size allowed for the body of the requests mirrored to the service,
+	// overriding the one of the mirroring.
+	MaxBodySize *int64 `json:"maxBodySize,omitempty" toml:"maxBodySize,omitempty" yaml:"maxBodySize,omitempty" export:"true"`
+	// Timeout defines the maximum duration of the requests mirrored to the service.
+	// Default: 0 (no timeout).
+	Timeout ptypes.Duration `json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // WeightedRoundRobin is a weighted round robin load-balancer of services.
 type WeightedRoundRobin struct {
 	Services []WRRService `json:"services,omitempty" toml:"services,omitempty" yaml:"services,omitempty" export:"true"`
@@ -195,6 +250,12 @@ type ServersLoadBalancer struct {
 	PassHostHeader     *bool               `json:"passHostHeader" toml:"passHostHeader" yaml:"passHostHeader" export:"true"`
 	ResponseForwarding *ResponseForwarding `json:"responseForwarding,omitempty" toml:"responseForwarding,omitempty" yaml:"responseForwarding,omitempty" export:"true"`
 	ServersTransport   string              `json:"serversTransport,omitempty" toml:"serversTransport,omitempty" yaml:"serversTransport,omitempty" export:"true"`
+	// ResponseHeaderTimeout bounds the time to wait for the response headers of the servers,
+	// in addition to the response header timeout of the servers transport.
+	ResponseHeaderTimeout ptypes.Duration `json:"responseHeaderTimeout,omitempty" toml:"responseHeaderTimeout,omitempty" yaml:"responseHeaderTimeout,omitempty" export:"true"`
+	// Drain defines how the in-flight requests forwarded to a server are drained,
+	// once the server is removed from the load balancer, or the service is no longer used by any router.
+	Drain *Drain `json:"drain,omitempty" toml:"drain,omitempty" yaml:"drain,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 }
 
 // Mergeable tells if the given service is mergeable.
@@ -242,6 +303,26 @@ func (r *ResponseForwarding) SetDefaults() {
 
 // +k8s:deepcopy-gen=true
 
+// Drain holds the draining configuration of the in-flight requests forwarded to a removed server.
+type Drain struct {
+	// GracePeriod defines the duration given to the in-flight requests to complete, before they are forcibly closed.
+	// Zero means that the requests are never forcibly closed.
+	// Default: 30s
+	GracePeriod ptypes.Duration `json:"gracePeriod,omitempty" toml:"gracePeriod,omitempty" yaml:"gracePeriod,omitempty" export:"true"`
+	// CloseConnections defines whether the client connections of the in-flight requests are closed once their response is sent,
+	// by setting the Connection: close header for HTTP/1, and by sending a GOAWAY frame for HTTP/2.
+	// Default: true
+	CloseConnections bool `json:"closeConnections,omitempty" toml:"closeConnections,omitempty" yaml:"closeConnections,omitempty" export:"true"`
+}
+
+// SetDefaults Default values for a Drain.
+func (d *Drain) SetDefaults() {
+	d.GracePeriod = DefaultDrainGracePeriod
+	d.CloseConnections = true
+}
+
+// +k8s:deepcopy-gen=true
+
 // Server holds the server configuration.
 type Server struct {
 	URL    string `json:"url,omitempty" toml:"url,omitempty" yaml:"url,omitempty" label:"-"`
@@ -297,6 +378,7 @@ type ServersTransport struct {
 	MaxIdleConnsPerHost int                     `description:"If non-zero, controls the maximum idle (keep-alive) to keep per-host. If zero, DefaultMaxIdleConnsPerHost is used" json:"maxIdleConnsPerHost,omitempty" toml:"maxIdleConnsPerHost,omitempty" yaml:"maxIdleConnsPerHost,omitempty" export:"true"`
 	ForwardingTimeouts  *ForwardingTimeouts     `description:"Defines the timeouts for requests forwarded to the backend servers." json:"forwardingTimeouts,omitempty" toml:"forwardingTimeouts,omitempty" yaml:"forwardingTimeouts,omitempty" export:"true"`
 	DisableHTTP2        bool                    `description:"Disables HTTP/2 for connections with backend servers." json:"disableHTTP2,omitempty" toml:"disableHTTP2,omitempty" yaml:"disableHTTP2,omitempty" export:"true"`
+	EnableHTTP3         bool                    `description:"Enables HTTP/3 for connections with backend servers, falling back to HTTP/2 and HTTP/1.1 when the QUIC connection cannot be established." json:"enableHTTP3,omitempty" toml:"enableHTTP3,omitempty" yaml:"enableHTTP3,omitempty" export:"true"`
 	PeerCertURI         string                  `description:"Defines the URI used to match against SAN URI during the peer certificate verification." json:"peerCertURI,omitempty" toml:"peerCertURI,omitempty" yaml:"peerCertURI,omitempty" export:"true"`
 	Spiffe              *Spiffe                 `description:"Defines the SPIFFE configuration." json:"spiffe,omitempty" toml:"spiffe,omitempty" yaml:"spiffe,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
 }
@@ -320,6 +402,7 @@ type ForwardingTimeouts struct {
 	IdleConnTimeout       ptypes.Duration `description:"The maximum period for which an idle HTTP keep-alive connection will remain open before closing itself." json:"idleConnTimeout,omitempty" toml:"idleConnTimeout,omitempty" yaml:"idleConnTimeout,omitempty" export:"true"`
 	ReadIdleTimeout       ptypes.Duration `description:"The timeout after which a health check using ping frame will be carried out if no frame is received on the HTTP/2 connection. If zero, no health check is performed." json:"readIdleTimeout,omitempty" toml:"readIdleTimeout,omitempty" yaml:"readIdleTimeout,omitempty" export:"true"`
 	PingTimeout           ptypes.Duration `description:"The timeout after which the HTTP/2 connection will be closed if a response to ping is not received." json:"pingTimeout,omitempty" toml:"pingTimeout,omitempty" yaml:"pingTimeout,omitempty" export:"true"`
+	HTTP3HandshakeTimeout ptypes.Duration `description:"The amount of time to wait until a QUIC connection to a backend server is established, when HTTP/3 is enabled, before falling back to HTTP/2 and HTTP/1.1. If zero, only the dial timeout applies." json:"http3HandshakeTimeout,omitempty" toml:"http3HandshakeTimeout,omitempty" yaml:"http3HandshakeTimeout,omitempty" export:"true"`
 }
 
 // SetDefaults sets the default values.
@@ -327,4 +410,5 @@ func (f *ForwardingTimeouts) SetDefaults() {
 	f.DialTimeout = ptypes.Duration(30 * time.Second)
 	f.IdleConnTimeout = ptypes.Duration(90 * time.Second)
 	f.PingTimeout = ptypes.Duration(15 * time.Second)
+	f.HTTP3HandshakeTimeout = ptypes.Duration(3 * time.Second)
 }
@@ -29,14 +29,50 @@ type Middleware struct {
 	BasicAuth         *BasicAuth         `json:"basicAuth,omitempty" toml:"basicAuth,omitempty" yaml:"basicAuth,omitempty" export:"true"`
 	DigestAuth        *DigestAuth        `json:"digestAuth,omitempty" toml:"digestAuth,omitempty" yaml:"digestAuth,omitempty" export:"true"`
 	ForwardAuth       *ForwardAuth       `json:"forwardAuth,omitempty" toml:"forwardAuth,omitempty" yaml:"forwardAuth,omitempty" export:"true"`
+	OIDC              *OIDC              `json:"oidc,omitempty" toml:"oidc,omitempty" yaml:"oidc,omitempty" export:"true"`
+	JWT               *JWT               `json:"jwt,omitempty" toml:"jwt,omitempty" yaml:"jwt,omitempty" export:"true"`
 	InFlightReq       *InFlightReq       `json:"inFlightReq,omitempty" toml:"inFlightReq,omitempty" yaml:"inFlightReq,omitempty" export:"true"`
 	Buffering         *Buffering         `json:"buffering,omitempty" toml:"buffering,omitempty" yaml:"buffering,omitempty" export:"true"`
+	Cache             *Cache             `json:"cache,omitempty" toml:"cache,omitempty" yaml:"cache,omitempty" export:"true"`
 	CircuitBreaker    *CircuitBreaker    `json:"circuitBreaker,omitempty" toml:"circuitBreaker,omitempty" yaml:"circuitBreaker,omitempty" export:"true"`
 	Compress          *Compress          `json:"compress,omitempty" toml:"compress,omitempty" yaml:"compress,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 	PassTLSClientCert *PassTLSClientCert `json:"passTLSClientCert,omitempty" toml:"passTLSClientCert,omitempty" yaml:"passTLSClientCert,omitempty" export:"true"`
 	Retry             *Retry             `json:"retry,omitempty" toml:"retry,omitempty" yaml:"retry,omitempty" export:"true"`
 	ContentType       *ContentType       `json:"contentType,omitempty" toml:"contentType,omitempty" yaml:"contentType,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 	GrpcWeb           *GrpcWeb           `json:"grpcWeb,omitempty" toml:"grpcWeb,omitempty" yaml:"grpcWeb,omitempty" export:"true"`
+	WAF               *WAF               `json:"waf,omitempty" toml:"waf,omitempty" yaml:"waf,omitempty" export:"true"`
+	GeoIP             *GeoIP             `json:"geoIP,omitempty" toml:"geoIP,omitempty" yaml:"geoIP,omitempty" export:"true"`
+	BotManager        *BotManager        `json:"botManager,omitempty" toml:"botManager,omitempty" yaml:"botManager,omitempty" export:"true"`
+	BodyValidation    *BodyValidation    `json:"bodyValidation,omitempty" toml:"bodyValidation,omitempty" yaml:"bodyValidation,omitempty" export:"true"`
+	RewriteBody       *RewriteBody       `json:"rewriteBody,omitempty" toml:"rewriteBody,omitempty" yaml:"rewriteBody,omitempty" export:"true"`
+	Script            *Script            `json:"script,omitempty" toml:"script,omitempty" yaml:"script,omitempty" export:"true"`
+	Query             *Query             `json:"query,omitempty" toml:"query,omitempty" yaml:"query,omitempty" export:"true"`
+	Cookies           *Cookies           `json:"cookies,omitempty" toml:"cookies,omitempty" yaml:"cookies,omitempty" export:"true"`
+	CORS              *CORS              `json:"cors,omitempty" toml:"cors,omitempty" yaml:"cors,omitempty" export:"true"`
+	CSRF              *CSRF              `json:"csrf,omitempty" toml:"csrf,omitempty" yaml:"csrf,omitempty" export:"true"`
+	OPA               *OPA               `json:"opa,omitempty" toml:"opa,omitempty" yaml:"opa,omitempty" export:"true"`
+	HMACSignature     *HMACSignature     `json:"hmacSignature,omitempty" toml:"hmacSignature,omitempty" yaml:"hmacSignature,omitempty" export:"true"`
+	AWSSigV4          *AWSSigV4          `json:"awsSigV4,omitempty" toml:"awsSigV4,omitempty" yaml:"awsSigV4,omitempty" export:"true"`
+	Maintenance       *Maintenance       `json:"maintenance,omitempty" toml:"maintenance,omitempty" yaml:"maintenance,omitempty" export:"true"`
+	RedirectMap       *RedirectMap       `json:"redirectMap,omitempty" toml:"redirectMap,omitempty" yaml:"redirectMap,omitempty" export:"true"`
+	RequestID         *RequestID         `json:"requestId,omitempty" toml:"requestId,omitempty" yaml:"requestId,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	Tarpit            *Tarpit            `json:"tarpit,omitempty" toml:"tarpit,omitempty" yaml:"tarpit,omitempty" export:"true"`
+	Fail2Ban          *Fail2Ban          `json:"fail2Ban,omitempty" toml:"fail2Ban,omitempty" yaml:"fail2Ban,omitempty" export:"true"`
+	GraphQL           *GraphQL           `json:"graphQL,omitempty" toml:"graphQL,omitempty" yaml:"graphQL,omitempty" export:"true"`
+	WebSocket         *WebSocket         `json:"webSocket,omitempty" toml:"webSocket,omitempty" yaml:"webSocket,omitempty" export:"true"`
+	SSE               *SSE               `json:"sse,omitempty" toml:"sse,omitempty" yaml:"sse,omitempty" export:"true"`
+	SignedURL         *SignedURL         `json:"signedURL,omitempty" toml:"signedURL,omitempty" yaml:"signedURL,omitempty" export:"true"`
+	APIKeyAuth        *APIKeyAuth        `json:"apiKeyAuth,omitempty" toml:"apiKeyAuth,omitempty" yaml:"apiKeyAuth,omitempty" export:"true"`
+	Coalesce          *Coalesce          `json:"coalesce,omitempty" toml:"coalesce,omitempty" yaml:"coalesce,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	Canary            *Canary            `json:"canary,omitempty" toml:"canary,omitempty" yaml:"canary,omitempty" export:"true"`
+	FormatConversion  *FormatConversion  `json:"formatConversion,omitempty" toml:"formatConversion,omitempty" yaml:"formatConversion,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	HeaderAllowList   *HeaderAllowList   `json:"headerAllowList,omitempty" toml:"headerAllowList,omitempty" yaml:"headerAllowList,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	ConcurrencyQuota  *ConcurrencyQuota  `json:"concurrencyQuota,omitempty" toml:"concurrencyQuota,omitempty" yaml:"concurrencyQuota,omitempty" export:"true"`
+	MapPath           *MapPath           `json:"mapPath,omitempty" toml:"mapPath,omitempty" yaml:"mapPath,omitempty" export:"true"`
+	MapHost           *MapHost           `json:"mapHost,omitempty" toml:"mapHost,omitempty" yaml:"mapHost,omitempty" export:"true"`
+	SecurityHeaders   *SecurityHeaders   `json:"securityHeaders,omitempty" toml:"securityHeaders,omitempty" yaml:"securityHeaders,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	UpstreamAuth      *UpstreamAuth      `json:"upstreamAuth,omitempty" toml:"upstreamAuth,omitempty" yaml:"upstreamAuth,omitempty" export:"true"`
+	Idempotency       *Idempotency       `json:"idempotency,omitempty" toml:"idempotency,omitempty" yaml:"idempotency,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 
 	Plugin map[string]PluginConf `json:"plugin,omitempty" toml:"plugin,omitempty" yaml:"plugin,omitempty" export:"true"`
 
@@ -59,6 +95,899 @@ type GrpcWeb struct {
 
 // +k8s:deepcopy-gen=true
 
+// GeoIP holds the GeoIP middleware configuration.
+// This middleware resolves the location of the client IP, forwards it to the services,
+// and can restrict the access to the routers by country.
+type GeoIP struct {
+	// Databases defines the paths of the GeoIP databases, in the MaxMind DB (MMDB) format.
+	// The locations are resolved from all the databases, e.g. a country database and an ASN database.
+	Databases []string `json:"databases,omitempty" toml:"databases,omitempty" yaml:"databases,omitempty"`
+	// AllowedCountries defines the ISO 3166-1 alpha-2 codes of the countries allowed to access the routers.
+	AllowedCountries []string `json:"allowedCountries,omitempty" toml:"allowedCountries,omitempty" yaml:"allowedCountries,omitempty"`
+	// DeniedCountries defines the ISO 3166-1 alpha-2 codes of the countries denied to access the routers.
+	DeniedCountries []string    `json:"deniedCountries,omitempty" toml:"deniedCountries,omitempty" yaml:"deniedCountries,omitempty"`
+	IPStrategy      *IPStrategy `json:"ipStrategy,omitempty" toml:"ipStrategy,omitempty" yaml:"ipStrategy,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// RejectStatusCode defines the HTTP status code used for refused requests.
+	// If not set, the default is 403 (Forbidden).
+	RejectStatusCode int `json:"rejectStatusCode,omitempty" toml:"rejectStatusCode,omitempty" yaml:"rejectStatusCode,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// BotManager holds the bot manager middleware configuration.
+// This middleware identifies the bots from a signature list, and applies an action per bot category.
+type BotManager struct {
+	// Actions defines the action applied to each bot category: allow, block, challenge, or tarpit.
+	// The categories without action are allowed.
+	Actions map[string]string `json:"actions,omitempty" toml:"actions,omitempty" yaml:"actions,omitempty" export:"true"`
+	// Signatures defines additional bot signatures, matched before the built-in ones.
+	Signatures []BotSignature `json:"signatures,omitempty" toml:"signatures,omitempty" yaml:"signatures,omitempty" export:"true"`
+	// ChallengeSecret defines the secret used to sign the challenge cookies.
+	// If not set, a random secret is generated, and the challenges are solved again after each configuration reload.
+	ChallengeSecret string `json:"challengeSecret,omitempty" toml:"challengeSecret,omitempty" yaml:"challengeSecret,omitempty" loggable:"false"`
+	// ChallengeTTL defines how long a solved challenge remains valid.
+	ChallengeTTL ptypes.Duration `json:"challengeTTL,omitempty" toml:"challengeTTL,omitempty" yaml:"challengeTTL,omitempty" export:"true"`
+	// TarpitDelay defines how long the tarpitted requests are delayed before being forwarded.
+	TarpitDelay ptypes.Duration `json:"tarpitDelay,omitempty" toml:"tarpitDelay,omitempty" yaml:"tarpitDelay,omitempty" export:"true"`
+	IPStrategy  *IPStrategy     `json:"ipStrategy,omitempty" toml:"ipStrategy,omitempty" yaml:"ipStrategy,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+}
+
+// SetDefaults sets the default values on a BotManager.
+func (b *BotManager) SetDefaults() {
+	b.ChallengeTTL = ptypes.Duration(time.Hour)
+	b.TarpitDelay = ptypes.Duration(10 * time.Second)
+}
+
+// +k8s:deepcopy-gen=true
+
+// BotSignature holds a bot signature.
+// A request matches the signature when its user agent or its TLS fingerprints match,
+// or, for a signature defining only source ranges, when it comes from one of them.
+type BotSignature struct {
+	// Name defines the name of the bot.
+	Name string `json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty" export:"true"`
+	// Category defines the category of the bot, used to select the action.
+	Category string `json:"category,omitempty" toml:"category,omitempty" yaml:"category,omitempty" export:"true"`
+	// UserAgents defines the regular expressions matching the user agent of the bot.
+	UserAgents []string `json:"userAgents,omitempty" toml:"userAgents,omitempty" yaml:"userAgents,omitempty" export:"true"`
+	// SourceRanges defines the IP ranges, in CIDR format, used by the bot.
+	// The requests matching the user agents from another IP are categorized as impersonator.
+	SourceRanges []string `json:"sourceRanges,omitempty" toml:"sourceRanges,omitempty" yaml:"sourceRanges,omitempty" export:"true"`
+	// JA3 defines the JA3 fingerprints of the TLS clients of the bot.
+	JA3 []string `json:"ja3,omitempty" toml:"ja3,omitempty" yaml:"ja3,omitempty" export:"true"`
+	// JA4 defines the JA4 fingerprints of the TLS clients of the bot.
+	JA4 []string `json:"ja4,omitempty" toml:"ja4,omitempty" yaml:"ja4,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// BodyValidation holds the body validation middleware configuration.
+// This middleware rejects the requests whose body is too large, has a forbidden content type,
+// or does not match a JSON Schema.
+type BodyValidation struct {
+	// MaxBodyBytes defines the maximum allowed body size for the requests, in bytes.
+	// If not set, or set to 0, the body size is not limited.
+	MaxBodyBytes int64 `json:"maxBodyBytes,omitempty" toml:"maxBodyBytes,omitempty" yaml:"maxBodyBytes,omitempty" export:"true"`
+	// AllowedContentTypes defines the media types allowed for the request bodies, e.g. application/json or image/*.
+	AllowedContentTypes []string `json:"allowedContentTypes,omitempty" toml:"allowedContentTypes,omitempty" yaml:"allowedContentTypes,omitempty" export:"true"`
+	// JSONSchema defines the path of the JSON Schema validating the JSON request bodies.
+	JSONSchema string `json:"jsonSchema,omitempty" toml:"jsonSchema,omitempty" yaml:"jsonSchema,omitempty" export:"true"`
+	// OpenAPI defines the OpenAPI operation whose request body schema validates the JSON request bodies.
+	OpenAPI *OpenAPIOperation `json:"openAPI,omitempty" toml:"openAPI,omitempty" yaml:"openAPI,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// OpenAPIOperation holds the reference to an operation of an OpenAPI document.
+type OpenAPIOperation struct {
+	// File defines the path of the OpenAPI 3 document, in the JSON or YAML format.
+	File string `json:"file,omitempty" toml:"file,omitempty" yaml:"file,omitempty" export:"true"`
+	// OperationID defines the identifier of the operation.
+	OperationID string `json:"operationId,omitempty" toml:"operationId,omitempty" yaml:"operationId,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RewriteBody holds the rewrite body middleware configuration.
+// This middleware rewrites the request and response bodies with regular expressions.
+type RewriteBody struct {
+	// Rewrites defines the rewrites applied, in order, to the bodies.
+	Rewrites []BodyRewrite `json:"rewrites,omitempty" toml:"rewrites,omitempty" yaml:"rewrites,omitempty" export:"true"`
+	// Request defines whether the request bodies are rewritten.
+	Request bool `json:"request,omitempty" toml:"request,omitempty" yaml:"request,omitempty" export:"true"`
+	// Response defines whether the response bodies are rewritten.
+	Response bool `json:"response,omitempty" toml:"response,omitempty" yaml:"response,omitempty" export:"true"`
+	// ContentTypes defines the media types of the rewritten bodies, e.g. text/html or text/*.
+	// If not set, the textual media types are rewritten.
+	ContentTypes []string `json:"contentTypes,omitempty" toml:"contentTypes,omitempty" yaml:"contentTypes,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a RewriteBody.
+func (r *RewriteBody) SetDefaults() {
+	r.Response = true
+}
+
+// +k8s:deepcopy-gen=true
+
+// BodyRewrite holds a body rewrite.
+type BodyRewrite struct {
+	// Regex defines the regular expression matching the rewritten content.
+	Regex string `json:"regex,omitempty" toml:"regex,omitempty" yaml:"regex,omitempty" export:"true"`
+	// Replacement defines the replacement of the matched content.
+	// It can reference the capturing groups of the regular expression, e.g. ${1},
+	// and is a Go template evaluated with the request host, scheme, method, path, and headers.
+	Replacement string `json:"replacement,omitempty" toml:"replacement,omitempty" yaml:"replacement,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Script holds the script middleware configuration.
+// This middleware runs an expression able to modify the requests, answer them, or select the service handling them.
+type Script struct {
+	// Source defines the expression run for each request.
+	Source string `json:"source,omitempty" toml:"source,omitempty" yaml:"source,omitempty" export:"true"`
+	// Services defines the names of the services which can be selected by the expression.
+	Services []string `json:"services,omitempty" toml:"services,omitempty" yaml:"services,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Cookies holds the cookies middleware configuration.
+// This middleware modifies the cookies of the requests, and the cookies set by the responses.
+type Cookies struct {
+	// Request defines the modifications of the cookies sent by the clients.
+	Request *CookieModifications `json:"request,omitempty" toml:"request,omitempty" yaml:"request,omitempty" export:"true"`
+	// Response defines the modifications of the cookies set by the services.
+	Response *CookieModifications `json:"response,omitempty" toml:"response,omitempty" yaml:"response,omitempty" export:"true"`
+	// Secure defines whether the Secure attribute is enforced on the cookies set by the responses.
+	Secure bool `json:"secure,omitempty" toml:"secure,omitempty" yaml:"secure,omitempty" export:"true"`
+	// HTTPOnly defines whether the HttpOnly attribute is enforced on the cookies set by the responses.
+	HTTPOnly bool `json:"httpOnly,omitempty" toml:"httpOnly,omitempty" yaml:"httpOnly,omitempty" export:"true"`
+	// SameSite defines the SameSite attribute enforced on the cookies set by the responses: none, lax, or strict.
+	// More info: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite
+	SameSite string `json:"sameSite,omitempty" toml:"sameSite,omitempty" yaml:"sameSite,omitempty" export:"true"`
+	// Encryption defines the cookies encrypted in the responses, and decrypted in the requests.
+	Encryption *CookieEncryption `json:"encryption,omitempty" toml:"encryption,omitempty" yaml:"encryption,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// CookieModifications holds the modifications of cookies.
+type CookieModifications struct {
+	// Remove defines the names of the cookies to remove.
+	Remove []string `json:"remove,omitempty" toml:"remove,omitempty" yaml:"remove,omitempty" export:"true"`
+	// Rename defines the new names of the cookies, by current name.
+	Rename map[string]string `json:"rename,omitempty" toml:"rename,omitempty" yaml:"rename,omitempty" export:"true"`
+	// Set defines the values of the cookies to set, by name.
+	Set map[string]string `json:"set,omitempty" toml:"set,omitempty" yaml:"set,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// CookieEncryption holds the cookie encryption configuration.
+type CookieEncryption struct {
+	// Secret defines the secret used to encrypt and authenticate the cookie values.
+	Secret string `json:"secret,omitempty" toml:"secret,omitempty" yaml:"secret,omitempty" loggable:"false"`
+	// Cookies defines the names of the encrypted cookies.
+	Cookies []string `json:"cookies,omitempty" toml:"cookies,omitempty" yaml:"cookies,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// CORS holds the CORS middleware configuration.
+// This middleware answers the CORS preflight requests, and adds the CORS headers to the responses.
+// More info: https://developer.mozilla.org/en-US/docs/Web/HTTP/CORS
+type CORS struct {
+	// AllowOriginList defines the allowed origins. Can also be a wildcard origin "*".
+	AllowOriginList []string `json:"allowOriginList,omitempty" toml:"allowOriginList,omitempty" yaml:"allowOriginList,omitempty"`
+	// AllowOriginListRegex defines the allowed origins written following the Regular Expression syntax (https://golang.org/pkg/regexp/).
+	AllowOriginListRegex []string `json:"allowOriginListRegex,omitempty" toml:"allowOriginListRegex,omitempty" yaml:"allowOriginListRegex,omitempty"`
+	// AllowMethods defines the methods allowed by the preflight requests. Can also be a wildcard "*".
+	// Default: GET, HEAD, POST.
+	AllowMethods []string `json:"allowMethods,omitempty" toml:"allowMethods,omitempty" yaml:"allowMethods,omitempty" export:"true"`
+	// AllowHeaders defines the headers allowed by the preflight requests. Can also be a wildcard "*".
+	AllowHeaders []string `json:"allowHeaders,omitempty" toml:"allowHeaders,omitempty" yaml:"allowHeaders,omitempty" export:"true"`
+	// ExposeHeaders defines the response headers exposed to the scripts of the allowed origins.
+	ExposeHeaders []string `json:"exposeHeaders,omitempty" toml:"exposeHeaders,omitempty" yaml:"exposeHeaders,omitempty" export:"true"`
+	// AllowCredentials defines whether the requests of the allowed origins can include user credentials.
+	AllowCredentials bool `json:"allowCredentials,omitempty" toml:"allowCredentials,omitempty" yaml:"allowCredentials,omitempty" export:"true"`
+	// MaxAge defines the time, in seconds, during which the clients may cache the preflight responses.
+	MaxAge int64 `json:"maxAge,omitempty" toml:"maxAge,omitempty" yaml:"maxAge,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// CSRF holds the CSRF middleware configuration.
+// This middleware rejects the requests with unsafe methods which do not send the CSRF token of their cookie in a header.
+// More info: https://cheatsheetseries.owasp.org/cheatsheets/Cross-Site_Request_Forgery_Prevention_Cheat_Sheet.html
+type CSRF struct {
+	// SafeMethods defines the methods of the requests which are not checked.
+	// Default: GET, HEAD, OPTIONS, TRACE.
+	SafeMethods []string `json:"safeMethods,omitempty" toml:"safeMethods,omitempty" yaml:"safeMethods,omitempty" export:"true"`
+	// CookieName defines the name of the cookie holding the token.
+	// Default: _csrf.
+	CookieName string `json:"cookieName,omitempty" toml:"cookieName,omitempty" yaml:"cookieName,omitempty" export:"true"`
+	// HeaderName defines the name of the request header holding the token.
+	// Default: X-CSRF-Token.
+	HeaderName string `json:"headerName,omitempty" toml:"headerName,omitempty" yaml:"headerName,omitempty" export:"true"`
+	// ExemptPaths defines the path prefixes of the requests which are not checked.
+	ExemptPaths []string `json:"exemptPaths,omitempty" toml:"exemptPaths,omitempty" yaml:"exemptPaths,omitempty" export:"true"`
+	// Secret defines the secret used to sign the tokens, so that they cannot be forged.
+	Secret string `json:"secret,omitempty" toml:"secret,omitempty" yaml:"secret,omitempty" loggable:"false"`
+	// SessionCookieName defines the name of the session cookie the signed tokens are bound to.
+	SessionCookieName string `json:"sessionCookieName,omitempty" toml:"sessionCookieName,omitempty" yaml:"sessionCookieName,omitempty" export:"true"`
+	// Secure defines whether the Secure attribute is set on the token cookie.
+	Secure bool `json:"secure,omitempty" toml:"secure,omitempty" yaml:"secure,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// OPA holds the OPA middleware configuration.
+// This middleware authorizes the requests with an Open Policy Agent decision.
+// Exactly one of Policy, BundleURL, or URL must be set.
+// More info: https://www.openpolicyagent.org/docs/latest/policy-language/
+type OPA struct {
+	// Policy defines the Rego policy evaluated for each request.
+	Policy string `json:"policy,omitempty" toml:"policy,omitempty" yaml:"policy,omitempty"`
+	// BundleURL defines the URL of the policy bundle evaluated for each request.
+	BundleURL string `json:"bundleURL,omitempty" toml:"bundleURL,omitempty" yaml:"bundleURL,omitempty"`
+	// PollInterval defines the interval between two downloads of the bundle.
+	PollInterval ptypes.Duration `json:"pollInterval,omitempty" toml:"pollInterval,omitempty" yaml:"pollInterval,omitempty" export:"true"`
+	// URL defines the URL of the remote OPA server evaluating the decision.
+	URL string `json:"url,omitempty" toml:"url,omitempty" yaml:"url,omitempty"`
+	// Decision defines the path of the decision document.
+	// Default: traefik/authz.
+	Decision string `json:"decision,omitempty" toml:"decision,omitempty" yaml:"decision,omitempty" export:"true"`
+	// RejectStatusCode defines the status code of the response to the denied requests, unless the decision defines it.
+	// Default: 403.
+	RejectStatusCode int `json:"rejectStatusCode,omitempty" toml:"rejectStatusCode,omitempty" yaml:"rejectStatusCode,omitempty" export:"true"`
+	// TLS defines the configuration used to secure the connection to the bundle URL, or to the remote OPA server.
+	TLS *ClientTLS `json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on an OPA.
+func (o *OPA) SetDefaults() {
+	o.PollInterval = ptypes.Duration(time.Minute)
+	o.Decision = "traefik/authz"
+}
+
+// +k8s:deepcopy-gen=true
+
+// HMACSignature holds the HMAC signature middleware configuration.
+// This middleware rejects the requests which are not signed with one of the keys, e.g. the webhook calls.
+type HMACSignature struct {
+	// Keys defines the keys accepted to sign the requests, several keys allowing their rotation.
+	Keys []HMACSignatureKey `json:"keys,omitempty" toml:"keys,omitempty" yaml:"keys,omitempty"`
+	// KeyIDHeader defines the name of the request header holding the ID of the key signing the request.
+	// When not set, or when the request does not have it, all the keys are tried.
+	KeyIDHeader string `json:"keyIDHeader,omitempty" toml:"keyIDHeader,omitempty" yaml:"keyIDHeader,omitempty" export:"true"`
+	// Algorithm defines the hash function of the HMAC, among sha1, sha256, and sha512.
+	// Default: sha256.
+	Algorithm string `json:"algorithm,omitempty" toml:"algorithm,omitempty" yaml:"algorithm,omitempty" export:"true"`
+	// SignatureHeader defines the name of the request header holding the signature.
+	// Default: X-Signature.
+	SignatureHeader string `json:"signatureHeader,omitempty" toml:"signatureHeader,omitempty" yaml:"signatureHeader,omitempty" export:"true"`
+	// SignaturePrefix defines the prefix of the signature in the header, e.g. sha256=.
+	SignaturePrefix string `json:"signaturePrefix,omitempty" toml:"signaturePrefix,omitempty" yaml:"signaturePrefix,omitempty" export:"true"`
+	// Encoding defines the encoding of the signature, hex or base64.
+	// Default: hex.
+	Encoding string `json:"encoding,omitempty" toml:"encoding,omitempty" yaml:"encoding,omitempty" export:"true"`
+	// SignedComponents defines the request components, joined by the Separator, which are signed.
+	// The components are method, host, path, query, body, timestamp, and header:<name>.
+	// Default: body.
+	SignedComponents []string `json:"signedComponents,omitempty" toml:"signedComponents,omitempty" yaml:"signedComponents,omitempty" export:"true"`
+	// Separator defines the separator of the signed components.
+	// Default: ".".
+	Separator string `json:"separator,omitempty" toml:"separator,omitempty" yaml:"separator,omitempty" export:"true"`
+	// TimestampHeader defines the name of the request header holding the time of the signature, in Unix seconds.
+	// The requests signed outside the ClockSkew are rejected, so that the signed requests cannot be replayed later.
+	TimestampHeader string `json:"timestampHeader,omitempty" toml:"timestampHeader,omitempty" yaml:"timestampHeader,omitempty" export:"true"`
+	// ClockSkew defines the maximum difference between the time of the signature and the current time.
+	// Default: 5m.
+	ClockSkew ptypes.Duration `json:"clockSkew,omitempty" toml:"clockSkew,omitempty" yaml:"clockSkew,omitempty" export:"true"`
+	// MaxBodyBytes defines the maximum size of the signed body, in bytes.
+	// Default: 10485760.
+	MaxBodyBytes int64 `json:"maxBodyBytes,omitempty" toml:"maxBodyBytes,omitempty" yaml:"maxBodyBytes,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// HMACSignatureKey holds a key accepted to sign the requests.
+type HMACSignatureKey struct {
+	// ID defines the ID of the key, matched against the KeyIDHeader.
+	ID string `json:"id,omitempty" toml:"id,omitempty" yaml:"id,omitempty" export:"true"`
+	// Secret defines the secret of the key.
+	Secret string `json:"secret,omitempty" toml:"secret,omitempty" yaml:"secret,omitempty" loggable:"false"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// AWSSigV4 holds the AWS SigV4 middleware configuration.
+// This middleware signs the forwarded requests with AWS Signature Version 4, for the services requiring signed requests.
+// More info: https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_aws-signing.html
+type AWSSigV4 struct {
+	// Service defines the name of the AWS service the requests are signed for, e.g. s3, execute-api, or es.
+	Service string `json:"service,omitempty" toml:"service,omitempty" yaml:"service,omitempty" export:"true"`
+	// Region defines the AWS region the requests are signed for.
+	// When not set, the region is read from the AWS_REGION environment variable, or from the shared configuration.
+	Region string `json:"region,omitempty" toml:"region,omitempty" yaml:"region,omitempty" export:"true"`
+	// Host defines the host of the AWS service endpoint, set in the forwarded requests before signing them.
+	Host string `json:"host,omitempty" toml:"host,omitempty" yaml:"host,omitempty"`
+	// AccessKeyID defines the access key ID of the credentials.
+	// When not set, the credentials are read from the environment, the shared credentials file, the instance or container metadata, or the web identity token.
+	AccessKeyID string `json:"accessKeyID,omitempty" toml:"accessKeyID,omitempty" yaml:"accessKeyID,omitempty" loggable:"false"`
+	// SecretAccessKey defines the secret access key of the credentials.
+	SecretAccessKey string `json:"secretAccessKey,omitempty" toml:"secretAccessKey,omitempty" yaml:"secretAccessKey,omitempty" loggable:"false"`
+	// SessionToken defines the session token of temporary credentials.
+	SessionToken string `json:"sessionToken,omitempty" toml:"sessionToken,omitempty" yaml:"sessionToken,omitempty" loggable:"false"`
+	// UnsignedPayload defines whether the body is not signed, so that it is streamed instead of buffered.
+	// It is only supported by some services, such as s3.
+	UnsignedPayload bool `json:"unsignedPayload,omitempty" toml:"unsignedPayload,omitempty" yaml:"unsignedPayload,omitempty" export:"true"`
+	// MaxBodyBytes defines the maximum size of the signed body, in bytes.
+	// Default: 10485760.
+	MaxBodyBytes int64 `json:"maxBodyBytes,omitempty" toml:"maxBodyBytes,omitempty" yaml:"maxBodyBytes,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Maintenance holds the maintenance middleware configuration.
+// This middleware serves a maintenance page instead of forwarding the requests, while the maintenance mode is enabled.
+type Maintenance struct {
+	// Enabled defines whether the maintenance mode is enabled.
+	// The maintenance mode can also be enabled with the FlagFile, or at runtime through the API.
+	Enabled bool `json:"enabled,omitempty" toml:"enabled,omitempty" yaml:"enabled,omitempty" export:"true"`
+	// FlagFile defines the path of a file enabling the maintenance mode while it exists.
+	FlagFile string `json:"flagFile,omitempty" toml:"flagFile,omitempty" yaml:"flagFile,omitempty" export:"true"`
+	// StatusCode defines the status code of the maintenance responses.
+	// Default: 503.
+	StatusCode int `json:"statusCode,omitempty" toml:"statusCode,omitempty" yaml:"statusCode,omitempty" export:"true"`
+	// RetryAfter defines the duration set in the Retry-After header of the maintenance responses.
+	RetryAfter ptypes.Duration `json:"retryAfter,omitempty" toml:"retryAfter,omitempty" yaml:"retryAfter,omitempty" export:"true"`
+	// ContentType defines the content type of the maintenance responses.
+	// Default: text/html; charset=utf-8.
+	ContentType string `json:"contentType,omitempty" toml:"contentType,omitempty" yaml:"contentType,omitempty" export:"true"`
+	// Body defines the Go template of the body of the maintenance responses.
+	Body string `json:"body,omitempty" toml:"body,omitempty" yaml:"body,omitempty" export:"true"`
+	// File defines the path of the file holding the Go template of the body of the maintenance responses.
+	File string `json:"file,omitempty" toml:"file,omitempty" yaml:"file,omitempty" export:"true"`
+	// SourceRange defines the set of IPs (or ranges of IPs by using CIDR notation) bypassing the maintenance mode.
+	SourceRange []string    `json:"sourceRange,omitempty" toml:"sourceRange,omitempty" yaml:"sourceRange,omitempty"`
+	IPStrategy  *IPStrategy `json:"ipStrategy,omitempty" toml:"ipStrategy,omitempty" yaml:"ipStrategy,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RedirectMap holds the redirect map middleware configuration.
+// This middleware redirects the requests according to a map of sources to target URLs.
+type RedirectMap struct {
+	// File defines the path of a CSV or JSON file holding the redirects, which is reloaded when it changes.
+	File string `json:"file,omitempty" toml:"file,omitempty" yaml:"file,omitempty" export:"true"`
+	// Redirects defines the redirects, along with the ones of the File.
+	Redirects []RedirectMapEntry `json:"redirects,omitempty" toml:"redirects,omitempty" yaml:"redirects,omitempty"`
+	// MatchMode defines the default match mode of the redirects: exact, prefix, or regex.
+	// Default: exact.
+	MatchMode string `json:"matchMode,omitempty" toml:"matchMode,omitempty" yaml:"matchMode,omitempty" export:"true"`
+	// StatusCode defines the default status code of the redirects.
+	// Default: 302.
+	StatusCode int `json:"statusCode,omitempty" toml:"statusCode,omitempty" yaml:"statusCode,omitempty" export:"true"`
+	// PreserveQuery defines whether the query of the request is added to the target URLs without a query.
+	PreserveQuery bool `json:"preserveQuery,omitempty" toml:"preserveQuery,omitempty" yaml:"preserveQuery,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RedirectMapEntry holds a redirect of the redirect map middleware.
+type RedirectMapEntry struct {
+	// Source defines the request path, or host followed by the path, matched by the redirect.
+	Source string `json:"source,omitempty" toml:"source,omitempty" yaml:"source,omitempty"`
+	// Target defines the URL the matching requests are redirected to.
+	Target string `json:"target,omitempty" toml:"target,omitempty" yaml:"target,omitempty"`
+	// MatchMode defines the match mode of the redirect, overriding the default one.
+	MatchMode string `json:"matchMode,omitempty" toml:"matchMode,omitempty" yaml:"matchMode,omitempty" export:"true"`
+	// StatusCode defines the status code of the redirect, overriding the default one.
+	StatusCode int `json:"statusCode,omitempty" toml:"statusCode,omitempty" yaml:"statusCode,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RequestID holds the request ID middleware configuration.
+// This middleware sets a unique ID on the requests, and returns it in the responses.
+type RequestID struct {
+	// HeaderName defines the name of the header holding the request ID, in the requests and in the responses.
+	// Default: X-Request-ID.
+	HeaderName string `json:"headerName,omitempty" toml:"headerName,omitempty" yaml:"headerName,omitempty" export:"true"`
+	// Generator defines the format of the generated IDs: uuidv7, or ulid.
+	// Default: uuidv7.
+	Generator string `json:"generator,omitempty" toml:"generator,omitempty" yaml:"generator,omitempty" export:"true"`
+	// Override defines whether the request ID sent by the client is replaced by a generated one.
+	Override bool `json:"override,omitempty" toml:"override,omitempty" yaml:"override,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Tarpit holds the tarpit middleware configuration.
+// This middleware delays the requests of the abusive clients, instead of rejecting them.
+// A request is delayed when it matches at least one of the SourceRange, Rate, and BotCategories criteria.
+type Tarpit struct {
+	// SourceRange defines the set of IPs (or ranges of IPs by using CIDR notation) whose requests are delayed.
+	SourceRange []string `json:"sourceRange,omitempty" toml:"sourceRange,omitempty" yaml:"sourceRange,omitempty"`
+	// Rate defines the request rate of a client above which its requests are delayed.
+	Rate *TarpitRate `json:"rate,omitempty" toml:"rate,omitempty" yaml:"rate,omitempty" export:"true"`
+	// BotCategories defines the bot categories, identified by a preceding botManager middleware, whose requests are delayed.
+	BotCategories []string `json:"botCategories,omitempty" toml:"botCategories,omitempty" yaml:"botCategories,omitempty" export:"true"`
+	// Delay defines how long the matching requests are delayed.
+	// Default: 5s.
+	Delay ptypes.Duration `json:"delay,omitempty" toml:"delay,omitempty" yaml:"delay,omitempty" export:"true"`
+	// MaxDelay defines the maximum delay, when greater than Delay:
+	// the delay then grows by Delay with each consecutive matching request of a client, up to MaxDelay.
+	MaxDelay ptypes.Duration `json:"maxDelay,omitempty" toml:"maxDelay,omitempty" yaml:"maxDelay,omitempty" export:"true"`
+	// MaxConcurrent defines the maximum number of requests delayed at the same time.
+	// The matching requests above this number are rejected with a 429 status code.
+	// Default: 100.
+	MaxConcurrent int `json:"maxConcurrent,omitempty" toml:"maxConcurrent,omitempty" yaml:"maxConcurrent,omitempty" export:"true"`
+	// StatusCode defines the status code of the responses sent once the delay has elapsed.
+	// If not set, the delayed requests are forwarded to the service.
+	StatusCode int         `json:"statusCode,omitempty" toml:"statusCode,omitempty" yaml:"statusCode,omitempty" export:"true"`
+	IPStrategy *IPStrategy `json:"ipStrategy,omitempty" toml:"ipStrategy,omitempty" yaml:"ipStrategy,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+}
+
+// SetDefaults sets the default values on a Tarpit.
+func (t *Tarpit) SetDefaults() {
+	t.Delay = ptypes.Duration(5 * time.Second)
+	t.MaxConcurrent = 100
+}
+
+// +k8s:deepcopy-gen=true
+
+// TarpitRate holds the request rate criterion of the tarpit middleware.
+type TarpitRate struct {
+	// Average defines the maximum average number of requests of a client during the Period.
+	Average int64 `json:"average,omitempty" toml:"average,omitempty" yaml:"average,omitempty" export:"true"`
+	// Period defines the period of the Average rate.
+	// Default: 1s.
+	Period ptypes.Duration `json:"period,omitempty" toml:"period,omitempty" yaml:"period,omitempty" export:"true"`
+	// Burst defines the maximum number of requests of a client above the Average rate.
+	// Default: 1.
+	Burst int64 `json:"burst,omitempty" toml:"burst,omitempty" yaml:"burst,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a TarpitRate.
+func (t *TarpitRate) SetDefaults() {
+	t.Period = ptypes.Duration(time.Second)
+	t.Burst = 1
+}
+
+// +k8s:deepcopy-gen=true
+
+// Fail2Ban holds the fail2Ban middleware configuration.
+// This middleware temporarily bans the clients whose requests fail too often.
+type Fail2Ban struct {
+	// StatusCodes defines the status codes, or ranges of status codes, of the failed requests.
+	// Default: ["401", "403", "429"].
+	StatusCodes []string `json:"statusCodes,omitempty" toml:"statusCodes,omitempty" yaml:"statusCodes,omitempty" export:"true"`
+	// MaxFailures defines the number of failed requests, within the FindTime window, after which a client is banned.
+	// Default: 5.
+	MaxFailures int `json:"maxFailures,omitempty" toml:"maxFailures,omitempty" yaml:"maxFailures,omitempty" export:"true"`
+	// FindTime defines the sliding window in which the failed requests of a client are counted.
+	// Default: 10m.
+	FindTime ptypes.Duration `json:"findTime,omitempty" toml:"findTime,omitempty" yaml:"findTime,omitempty" export:"true"`
+	// BanTime defines how long a client is banned.
+	// Default: 1h.
+	BanTime ptypes.Duration `json:"banTime,omitempty" toml:"banTime,omitempty" yaml:"banTime,omitempty" export:"true"`
+	// BanStatusCode defines the status code of the responses to the banned clients.
+	// Default: 403.
+	BanStatusCode int `json:"banStatusCode,omitempty" toml:"banStatusCode,omitempty" yaml:"banStatusCode,omitempty" export:"true"`
+	// IgnoredSourceRange defines the IPs (or ranges of IPs by using CIDR notation) which are never banned.
+	IgnoredSourceRange []string    `json:"ignoredSourceRange,omitempty" toml:"ignoredSourceRange,omitempty" yaml:"ignoredSourceRange,omitempty"`
+	IPStrategy         *IPStrategy `json:"ipStrategy,omitempty" toml:"ipStrategy,omitempty" yaml:"ipStrategy,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// Redis defines the Redis server storing the failures and the bans, to share them across the Traefik instances.
+	// Default: the failures and the bans are stored in memory.
+	Redis *Redis `json:"redis,omitempty" toml:"redis,omitempty" yaml:"redis,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// GraphQL holds the GraphQL middleware configuration.
+// This middleware parses the GraphQL requests, and rejects the ones which are too expensive or not allowed.
+type GraphQL struct {
+	// MaxDepth defines the maximum nesting depth of the fields of an operation.
+	// If not set, or set to 0, the depth is not limited.
+	MaxDepth int `json:"maxDepth,omitempty" toml:"maxDepth,omitempty" yaml:"maxDepth,omitempty" export:"true"`
+	// MaxComplexity defines the maximum complexity score of an operation.
+	// Each field scores 1, and the score of a field with a first, last or limit argument is multiplied by its value.
+	// If not set, or set to 0, the complexity is not limited.
+	MaxComplexity int `json:"maxComplexity,omitempty" toml:"maxComplexity,omitempty" yaml:"maxComplexity,omitempty" export:"true"`
+	// AllowedOperations defines the names of the operations allowed.
+	// If not set, all the operations are allowed.
+	AllowedOperations []string `json:"allowedOperations,omitempty" toml:"allowedOperations,omitempty" yaml:"allowedOperations,omitempty" export:"true"`
+	// PersistedQueriesFile defines the path of the JSON file holding the persisted queries, keyed by the hex-encoded SHA-256 hash of the query.
+	PersistedQueriesFile string `json:"persistedQueriesFile,omitempty" toml:"persistedQueriesFile,omitempty" yaml:"persistedQueriesFile,omitempty" export:"true"`
+	// PersistedQueriesOnly defines whether only the persisted queries are allowed.
+	PersistedQueriesOnly bool `json:"persistedQueriesOnly,omitempty" toml:"persistedQueriesOnly,omitempty" yaml:"persistedQueriesOnly,omitempty" export:"true"`
+	// BlockIntrospection defines whether the introspection queries are rejected.
+	BlockIntrospection bool `json:"blockIntrospection,omitempty" toml:"blockIntrospection,omitempty" yaml:"blockIntrospection,omitempty" export:"true"`
+	// MaxBodyBytes defines the maximum size of the request bodies, in bytes.
+	// Default: 1048576 (1 MiB).
+	MaxBodyBytes int64 `json:"maxBodyBytes,omitempty" toml:"maxBodyBytes,omitempty" yaml:"maxBodyBytes,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// WebSocket holds the WebSocket middleware configuration.
+// This middleware applies limits to the proxied WebSocket connections.
+type WebSocket struct {
+	// MaxMessageSize defines the maximum size of the messages sent by the clients, in bytes.
+	// The connection is closed when a message exceeds it.
+	// If not set, or set to 0, the message size is not limited.
+	MaxMessageSize int64 `json:"maxMessageSize,omitempty" toml:"maxMessageSize,omitempty" yaml:"maxMessageSize,omitempty" export:"true"`
+	// MaxLifetime defines how long a connection can stay open.
+	// If not set, or set to 0, the lifetime is not limited.
+	MaxLifetime ptypes.Duration `json:"maxLifetime,omitempty" toml:"maxLifetime,omitempty" yaml:"maxLifetime,omitempty" export:"true"`
+	// IdleTimeout defines how long a connection can stay open without any data sent in either direction.
+	// If not set, or set to 0, the idle connections are not closed.
+	IdleTimeout ptypes.Duration `json:"idleTimeout,omitempty" toml:"idleTimeout,omitempty" yaml:"idleTimeout,omitempty" export:"true"`
+	// AllowedSubprotocols defines the subprotocols the clients can negotiate.
+	// If set, the other subprotocols are removed from the handshakes, and the handshakes without an allowed subprotocol are rejected.
+	AllowedSubprotocols []string `json:"allowedSubprotocols,omitempty" toml:"allowedSubprotocols,omitempty" yaml:"allowedSubprotocols,omitempty" export:"true"`
+	// MaxConnections defines the maximum number of concurrent connections per router.
+	// If not set, or set to 0, the number of connections is not limited.
+	MaxConnections int `json:"maxConnections,omitempty" toml:"maxConnections,omitempty" yaml:"maxConnections,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// SSE holds the SSE middleware configuration.
+// This middleware streams the Server-Sent Events responses to the clients.
+type SSE struct {
+	// FlushInterval defines the maximum delay before the events written by the service are sent to the client.
+	// If not set, or set to 0, the events are sent as soon as they are written.
+	FlushInterval ptypes.Duration `json:"flushInterval,omitempty" toml:"flushInterval,omitempty" yaml:"flushInterval,omitempty" export:"true"`
+	// MaxLifetime defines how long a stream can stay open.
+	// If not set, or set to 0, the lifetime is not limited.
+	MaxLifetime ptypes.Duration `json:"maxLifetime,omitempty" toml:"maxLifetime,omitempty" yaml:"maxLifetime,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// SignedURL holds the signed URL middleware configuration.
+// This middleware rejects the requests whose URL is not signed with one of the keys, or has expired, e.g. the download links.
+type SignedURL struct {
+	// Keys defines the keys accepted to sign the URLs, several keys allowing their rotation.
+	Keys []SignedURLKey `json:"keys,omitempty" toml:"keys,omitempty" yaml:"keys,omitempty"`
+	// KeyIDParam defines the name of the query parameter holding the ID of the key signing the URL.
+	// When not set, or when the URL does not have it, all the keys are tried.
+	KeyIDParam string `json:"keyIDParam,omitempty" toml:"keyIDParam,omitempty" yaml:"keyIDParam,omitempty" export:"true"`
+	// Algorithm defines the hash function of the HMAC, among sha1, sha256, and sha512.
+	// Default: sha256.
+	Algorithm string `json:"algorithm,omitempty" toml:"algorithm,omitempty" yaml:"algorithm,omitempty" export:"true"`
+	// Encoding defines the encoding of the signature, hex or base64url.
+	// Default: hex.
+	Encoding string `json:"encoding,omitempty" toml:"encoding,omitempty" yaml:"encoding,omitempty" export:"true"`
+	// SignatureParam defines the name of the query parameter holding the signature.
+	// Default: signature.
+	SignatureParam string `json:"signatureParam,omitempty" toml:"signatureParam,omitempty" yaml:"signatureParam,omitempty" export:"true"`
+	// ExpiresParam defines the name of the query parameter holding the expiry time of the URL, in Unix seconds.
+	// Default: expires.
+	ExpiresParam string `json:"expiresParam,omitempty" toml:"expiresParam,omitempty" yaml:"expiresParam,omitempty" export:"true"`
+	// MaxValidity defines how far in the future the expiry time of a URL can be.
+	// If not set, or set to 0, the expiry time is not limited.
+	MaxValidity ptypes.Duration `json:"maxValidity,omitempty" toml:"maxValidity,omitempty" yaml:"maxValidity,omitempty" export:"true"`
+	// StripParams defines whether the signature, expiry and key ID query parameters are removed from the forwarded requests.
+	StripParams bool `json:"stripParams,omitempty" toml:"stripParams,omitempty" yaml:"stripParams,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// SignedURLKey holds a key accepted to sign the URLs.
+type SignedURLKey struct {
+	// ID defines the ID of the key, matched against the KeyIDParam.
+	ID string `json:"id,omitempty" toml:"id,omitempty" yaml:"id,omitempty" export:"true"`
+	// Secret defines the secret of the key.
+	Secret string `json:"secret,omitempty" toml:"secret,omitempty" yaml:"secret,omitempty" loggable:"false"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// APIKeyAuth holds the API key authentication middleware configuration.
+// This middleware rejects the requests without one of the accepted API keys.
+type APIKeyAuth struct {
+	// HeaderName defines the name of the request header holding the API key.
+	// Default: X-API-Key.
+	HeaderName string `json:"headerName,omitempty" toml:"headerName,omitempty" yaml:"headerName,omitempty" export:"true"`
+	// QueryParam defines the name of the query parameter holding the API key, for the requests without the header.
+	QueryParam string `json:"queryParam,omitempty" toml:"queryParam,omitempty" yaml:"queryParam,omitempty" export:"true"`
+	// Keys defines the accepted API keys.
+	Keys []APIKey `json:"keys,omitempty" toml:"keys,omitempty" yaml:"keys,omitempty"`
+	// KeysFile defines the path to a YAML file holding a list of accepted API keys, in the same format as the Keys.
+	KeysFile string `json:"keysFile,omitempty" toml:"keysFile,omitempty" yaml:"keysFile,omitempty" export:"true"`
+	// Redis defines the Redis store of the accepted API keys, looked up for the keys which are not in the Keys or the KeysFile.
+	Redis *Redis `json:"redis,omitempty" toml:"redis,omitempty" yaml:"redis,omitempty" export:"true"`
+	// HeaderField defines the header field set to the name of the API key on the forwarded requests.
+	HeaderField string `json:"headerField,omitempty" toml:"headerField,omitempty" yaml:"headerField,omitempty" export:"true"`
+	// MetadataHeaderPrefix defines the prefix of the header fields set to the metadata of the API key on the forwarded requests.
+	// Default: X-Api-Key-.
+	MetadataHeaderPrefix string `json:"metadataHeaderPrefix,omitempty" toml:"metadataHeaderPrefix,omitempty" yaml:"metadataHeaderPrefix,omitempty" export:"true"`
+	// RemoveKey defines whether to remove the API key from the forwarded requests.
+	RemoveKey bool `json:"removeKey,omitempty" toml:"removeKey,omitempty" yaml:"removeKey,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// APIKey holds an API key accepted by the API key authentication middleware.
+type APIKey struct {
+	// Name defines the name of the key, e.g. the client it is issued to.
+	Name string `json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty" export:"true"`
+	// Value defines the key, or its SHA-256 digest in hexadecimal prefixed with sha256:.
+	Value string `json:"value,omitempty" toml:"value,omitempty" yaml:"value,omitempty" loggable:"false"`
+	// Metadata defines the metadata of the key, forwarded as header fields.
+	Metadata map[string]string `json:"metadata,omitempty" toml:"metadata,omitempty" yaml:"metadata,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Coalesce holds the request coalescing middleware configuration.
+// This middleware collapses the concurrent identical GET requests into a single request to the service,
+// whose response is sent to all of them.
+type Coalesce struct {
+	// MaxBodySize defines the maximum size, in bytes, of the body of a response sent to the collapsed requests.
+	// The collapsed requests are forwarded to the service when the response is larger.
+	// Default: 1048576.
+	MaxBodySize int64 `json:"maxBodySize,omitempty" toml:"maxBodySize,omitempty" yaml:"maxBodySize,omitempty" export:"true"`
+	// Key defines the parts of the request telling whether the requests are identical, as for the cache middleware.
+	Key *CacheKey `json:"key,omitempty" toml:"key,omitempty" yaml:"key,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Canary holds the canary routing middleware configuration.
+// This middleware forwards the requests matching one of the conditions to an alternate service,
+// and the other requests to the next handler.
+type Canary struct {
+	// Service defines the name of the service the matching requests are forwarded to.
+	Service string `json:"service,omitempty" toml:"service,omitempty" yaml:"service,omitempty" export:"true"`
+	// Headers defines the request header values matching the requests, keyed by header name.
+	Headers map[string]string `json:"headers,omitempty" toml:"headers,omitempty" yaml:"headers,omitempty" export:"true"`
+	// Cookies defines the request cookie values matching the requests, keyed by cookie name.
+	Cookies map[string]string `json:"cookies,omitempty" toml:"cookies,omitempty" yaml:"cookies,omitempty" export:"true"`
+	// Claims defines the claim values, of the token verified by a preceding JWT middleware, matching the requests, keyed by claim name.
+	// A claim holding a list matches when one of its values matches.
+	Claims map[string]string `json:"claims,omitempty" toml:"claims,omitempty" yaml:"claims,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// FormatConversion holds the format conversion middleware configuration.
+// This middleware converts the JSON responses of the service to the format accepted by the client,
+// and the request bodies sent in another format to JSON.
+type FormatConversion struct {
+	// Formats defines the formats converted from and to JSON, among xml, csv and msgpack.
+	// Default: xml, csv, msgpack.
+	Formats []string `json:"formats,omitempty" toml:"formats,omitempty" yaml:"formats,omitempty" export:"true"`
+	// Request defines whether the request bodies are converted to JSON.
+	Request bool `json:"request,omitempty" toml:"request,omitempty" yaml:"request,omitempty" export:"true"`
+	// Response defines whether the JSON responses are converted to the format accepted by the client.
+	Response bool `json:"response,omitempty" toml:"response,omitempty" yaml:"response,omitempty" export:"true"`
+	// XMLRootElement defines the name of the root element of the XML responses.
+	// Default: root.
+	XMLRootElement string `json:"xmlRootElement,omitempty" toml:"xmlRootElement,omitempty" yaml:"xmlRootElement,omitempty" export:"true"`
+	// MaxBodySize defines the maximum size, in bytes, of the converted bodies.
+	// The larger responses are forwarded as is, and the larger requests are rejected.
+	// Default: 10485760.
+	MaxBodySize int64 `json:"maxBodySize,omitempty" toml:"maxBodySize,omitempty" yaml:"maxBodySize,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a FormatConversion.
+func (f *FormatConversion) SetDefaults() {
+	f.Request = true
+	f.Response = true
+}
+
+// +k8s:deepcopy-gen=true
+
+// HeaderAllowList holds the header allow list middleware configuration.
+// This middleware removes the request headers, and optionally the response headers, which are not allowed.
+type HeaderAllowList struct {
+	// RequestHeaders defines the request headers forwarded to the service, the other ones being removed.
+	// A name ending with * matches the headers starting with the given prefix.
+	RequestHeaders []string `json:"requestHeaders,omitempty" toml:"requestHeaders,omitempty" yaml:"requestHeaders,omitempty" export:"true"`
+	// ResponseHeaders defines the response headers sent to the client, the other ones being removed.
+	// A name ending with * matches the headers starting with the given prefix.
+	// If not set, the response headers are not filtered.
+	ResponseHeaders []string `json:"responseHeaders,omitempty" toml:"responseHeaders,omitempty" yaml:"responseHeaders,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// ConcurrencyQuota holds the concurrency quota middleware configuration.
+// This middleware limits the number of simultaneous in-flight requests of each tenant,
+// so that a tenant cannot starve the other ones.
+type ConcurrencyQuota struct {
+	// Amount defines the maximum amount of simultaneous in-flight requests of each tenant.
+	// The middleware responds with HTTP 429 Too Many Requests if the tenant already has amount requests in progress.
+	Amount int64 `json:"amount,omitempty" toml:"amount,omitempty" yaml:"amount,omitempty" export:"true"`
+	// SourceCriterion defines what criterion is used to identify the tenant of the requests,
+	// e.g. the Header(`X-Tenant`), Header(`X-API-Key`) or JWTClaim(`tenant`) expression.
+	// If none are set, the default is to use the client IP.
+	SourceCriterion *SourceCriterion `json:"sourceCriterion,omitempty" toml:"sourceCriterion,omitempty" yaml:"sourceCriterion,omitempty" export:"true"`
+	// Tenants defines the amounts of given tenants, overriding the default one.
+	// Their requests are reported by their name in the metrics, the other tenants being reported together.
+	Tenants []ConcurrencyQuotaTenant `json:"tenants,omitempty" toml:"tenants,omitempty" yaml:"tenants,omitempty" export:"true"`
+	// MaxTenants defines the maximum number of tenants tracked at once.
+	// The least recently used tenants without in-flight requests are forgotten beyond,
+	// and the requests of new tenants are rejected when all the tracked ones have in-flight requests.
+	// Default: 10000.
+	MaxTenants int `json:"maxTenants,omitempty" toml:"maxTenants,omitempty" yaml:"maxTenants,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// ConcurrencyQuotaTenant holds the concurrency quota of a given tenant.
+type ConcurrencyQuotaTenant struct {
+	// Name defines the name of the tenant, as identified by the source criterion.
+	Name string `json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty" export:"true"`
+	// Amount defines the maximum amount of simultaneous in-flight requests of the tenant.
+	Amount int64 `json:"amount,omitempty" toml:"amount,omitempty" yaml:"amount,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// MapPath holds the map path middleware configuration.
+// This middleware rewrites the request path according to a lookup table of source paths to target paths.
+type MapPath struct {
+	// File defines the path of a CSV or JSON file holding the mappings, which is reloaded when it changes.
+	File string `json:"file,omitempty" toml:"file,omitempty" yaml:"file,omitempty" export:"true"`
+	// Mappings defines the mappings, along with the ones of the File.
+	Mappings []MapEntry `json:"mappings,omitempty" toml:"mappings,omitempty" yaml:"mappings,omitempty"`
+	// MatchMode defines the default match mode of the mappings: exact, prefix, or regex.
+	// Default: exact.
+	MatchMode string `json:"matchMode,omitempty" toml:"matchMode,omitempty" yaml:"matchMode,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// MapHost holds the map host middleware configuration.
+// This middleware rewrites the request host according to a lookup table of source hosts to target hosts.
+type MapHost struct {
+	// File defines the path of a CSV or JSON file holding the mappings, which is reloaded when it changes.
+	File string `json:"file,omitempty" toml:"file,omitempty" yaml:"file,omitempty" export:"true"`
+	// Mappings defines the mappings, along with the ones of the File.
+	Mappings []MapEntry `json:"mappings,omitempty" toml:"mappings,omitempty" yaml:"mappings,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// MapEntry holds a mapping of the map path and map host middlewares.
+type MapEntry struct {
+	// Source defines the request path, or host followed by the path, matched by the mapping of a map path middleware,
+	// or the request host matched by the mapping of a map host middleware.
+	Source string `json:"source,omitempty" toml:"source,omitempty" yaml:"source,omitempty"`
+	// Target defines the path, or the host, the matching requests are rewritten to.
+	Target string `json:"target,omitempty" toml:"target,omitempty" yaml:"target,omitempty"`
+	// MatchMode defines the match mode of the mapping of a map path middleware, overriding the default one.
+	MatchMode string `json:"matchMode,omitempty" toml:"matchMode,omitempty" yaml:"matchMode,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// SecurityHeaders holds the security headers middleware configuration.
+// This middleware adds the response headers of a versioned preset of security headers.
+type SecurityHeaders struct {
+	// Preset defines the version of the preset of security headers.
+	// Default: v1.
+	Preset string `json:"preset,omitempty" toml:"preset,omitempty" yaml:"preset,omitempty" export:"true"`
+	// ContentSecurityPolicy defines the directives overriding the ones of the Content-Security-Policy template of the preset, by directive name.
+	// An empty value removes the directive.
+	ContentSecurityPolicy map[string]string `json:"contentSecurityPolicy,omitempty" toml:"contentSecurityPolicy,omitempty" yaml:"contentSecurityPolicy,omitempty" export:"true"`
+	// ContentSecurityPolicyReportOnly defines whether the Content-Security-Policy is sent in the Content-Security-Policy-Report-Only header,
+	// for the violations to be reported without being enforced.
+	ContentSecurityPolicyReportOnly bool `json:"contentSecurityPolicyReportOnly,omitempty" toml:"contentSecurityPolicyReportOnly,omitempty" yaml:"contentSecurityPolicyReportOnly,omitempty" export:"true"`
+	// Headers defines the headers overriding the ones of the preset, by header name.
+	// An empty value removes the header.
+	Headers map[string]string `json:"headers,omitempty" toml:"headers,omitempty" yaml:"headers,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// UpstreamAuth holds the upstream authentication middleware configuration.
+// This middleware sets the credentials of the service on the forwarded requests,
+// replacing the ones sent by the clients.
+type UpstreamAuth struct {
+	// HeaderName defines the name of the request header holding the credentials.
+	// Default: Authorization.
+	HeaderName string `json:"headerName,omitempty" toml:"headerName,omitempty" yaml:"headerName,omitempty" export:"true"`
+	// Basic defines the basic authentication credentials.
+	Basic *UpstreamBasicAuth `json:"basic,omitempty" toml:"basic,omitempty" yaml:"basic,omitempty" export:"true"`
+	// Bearer defines the static bearer token.
+	Bearer *UpstreamBearerAuth `json:"bearer,omitempty" toml:"bearer,omitempty" yaml:"bearer,omitempty" export:"true"`
+	// OAuth2 defines the OAuth 2.0 client credentials, the bearer tokens being fetched from the token endpoint,
+	// and fetched again when they expire.
+	OAuth2 *UpstreamOAuth2 `json:"oauth2,omitempty" toml:"oauth2,omitempty" yaml:"oauth2,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// UpstreamBasicAuth holds the basic authentication credentials of an upstream authentication middleware.
+type UpstreamBasicAuth struct {
+	// Username defines the user name.
+	Username string `json:"username,omitempty" toml:"username,omitempty" yaml:"username,omitempty" loggable:"false"`
+	// Password defines the password.
+	Password string `json:"password,omitempty" toml:"password,omitempty" yaml:"password,omitempty" loggable:"false"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// UpstreamBearerAuth holds the static bearer token of an upstream authentication middleware.
+type UpstreamBearerAuth struct {
+	// Token defines the bearer token.
+	Token string `json:"token,omitempty" toml:"token,omitempty" yaml:"token,omitempty" loggable:"false"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// UpstreamOAuth2 holds the OAuth 2.0 client credentials of an upstream authentication middleware.
+type UpstreamOAuth2 struct {
+	// TokenURL defines the URL of the token endpoint of the authorization server.
+	TokenURL string `json:"tokenURL,omitempty" toml:"tokenURL,omitempty" yaml:"tokenURL,omitempty" export:"true"`
+	// ClientID defines the client identifier.
+	ClientID string `json:"clientID,omitempty" toml:"clientID,omitempty" yaml:"clientID,omitempty" export:"true"`
+	// ClientSecret defines the client secret.
+	ClientSecret string `json:"clientSecret,omitempty" toml:"clientSecret,omitempty" yaml:"clientSecret,omitempty" loggable:"false"`
+	// Scopes defines the scopes requested to the token endpoint.
+	Scopes []string `json:"scopes,omitempty" toml:"scopes,omitempty" yaml:"scopes,omitempty" export:"true"`
+	// EndpointParams defines the additional parameters of the token requests, e.g. the audience.
+	EndpointParams map[string]string `json:"endpointParams,omitempty" toml:"endpointParams,omitempty" yaml:"endpointParams,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Idempotency holds the idempotency middleware configuration.
+// This middleware stores the first response to the requests carrying an idempotency key,
+// and replays it to the requests carrying the same key, for the retries of the clients not to be processed twice.
+type Idempotency struct {
+	// HeaderName defines the name of the request header holding the idempotency key.
+	// Default: Idempotency-Key.
+	HeaderName string `json:"headerName,omitempty" toml:"headerName,omitempty" yaml:"headerName,omitempty" export:"true"`
+	// Methods defines the methods of the requests the idempotency keys apply to.
+	// Default: POST, PATCH.
+	Methods []string `json:"methods,omitempty" toml:"methods,omitempty" yaml:"methods,omitempty" export:"true"`
+	// Scope defines the values of the request identifying its caller, as a source expression, e.g. JWTClaim(`sub`),
+	// the idempotency keys of a caller never replaying the responses to another one.
+	// Default: Header(`Authorization`) && Header(`Cookie`) && ClientIP().
+	Scope string `json:"scope,omitempty" toml:"scope,omitempty" yaml:"scope,omitempty" export:"true"`
+	// TTL defines the duration during which a response is replayed.
+	// Default: 24h.
+	TTL ptypes.Duration `json:"ttl,omitempty" toml:"ttl,omitempty" yaml:"ttl,omitempty" export:"true"`
+	// MaxBodySize defines the maximum size, in bytes, of the bodies of the requests carrying an idempotency key,
+	// and of the stored responses.
+	// Default: 1048576.
+	MaxBodySize int64 `json:"maxBodySize,omitempty" toml:"maxBodySize,omitempty" yaml:"maxBodySize,omitempty" export:"true"`
+	// Store defines where the responses are stored.
+	// Default: in memory.
+	Store *IdempotencyStore `json:"store,omitempty" toml:"store,omitempty" yaml:"store,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// IdempotencyStore holds the idempotency store configuration.
+// Only one of the stores can be defined.
+type IdempotencyStore struct {
+	// Memory defines an in-memory store, forgetting the oldest keys first when full.
+	Memory *MemoryIdempotencyStore `json:"memory,omitempty" toml:"memory,omitempty" yaml:"memory,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// Redis defines a Redis store, shared by the Traefik instances.
+	Redis *Redis `json:"redis,omitempty" toml:"redis,omitempty" yaml:"redis,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// MemoryIdempotencyStore holds the in-memory idempotency store configuration.
+type MemoryIdempotencyStore struct {
+	// MaxSize defines the maximum size, in bytes, of the stored keys and responses.
+	// Default: 104857600.
+	MaxSize int64 `json:"maxSize,omitempty" toml:"maxSize,omitempty" yaml:"maxSize,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // ContentType holds the content-type middleware configuration.
 // This middleware exists to enable the correct behavior until at least the default one can be changed in a future version.
 type ContentType struct {
@@ -100,6 +1029,44 @@ type BasicAuth struct {
 	// HeaderField defines a header field to store the authenticated user.
 	// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/basicauth/#headerfield
 	HeaderField string `json:"headerField,omitempty" toml:"headerField,omitempty" yaml:"headerField,omitempty" export:"true"`
+	// LDAP defines the LDAP server validating the credentials of the users which are not declared in Users and UsersFile.
+	LDAP *BasicAuthLDAP `json:"ldap,omitempty" toml:"ldap,omitempty" yaml:"ldap,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// BasicAuthLDAP holds the configuration of the LDAP, or Active Directory, server validating the basic auth credentials.
+type BasicAuthLDAP struct {
+	// URL defines the URL of the LDAP server, with the ldap or ldaps scheme.
+	URL string `json:"url,omitempty" toml:"url,omitempty" yaml:"url,omitempty"`
+	// StartTLS defines whether the connection to the ldap URL is upgraded to TLS.
+	StartTLS bool `json:"startTLS,omitempty" toml:"startTLS,omitempty" yaml:"startTLS,omitempty" export:"true"`
+	// BindDN defines the DN of the account searching the users, an anonymous bind being used if empty.
+	BindDN string `json:"bindDN,omitempty" toml:"bindDN,omitempty" yaml:"bindDN,omitempty"`
+	// BindPassword defines the password of the BindDN account.
+	BindPassword string `json:"bindPassword,omitempty" toml:"bindPassword,omitempty" yaml:"bindPassword,omitempty" loggable:"false"`
+	// BaseDN defines the DN under which the users are searched.
+	BaseDN string `json:"baseDN,omitempty" toml:"baseDN,omitempty" yaml:"baseDN,omitempty"`
+	// UserFilter defines the filter searching the user, %s being replaced by the username.
+	// Default: (uid=%s).
+	UserFilter string `json:"userFilter,omitempty" toml:"userFilter,omitempty" yaml:"userFilter,omitempty" export:"true"`
+	// GroupFilter defines the filter the user must also match, e.g. to require a group membership.
+	GroupFilter string `json:"groupFilter,omitempty" toml:"groupFilter,omitempty" yaml:"groupFilter,omitempty" export:"true"`
+	// PoolSize defines the maximum number of idle connections kept open to the LDAP server.
+	// Default: 10.
+	PoolSize int `json:"poolSize,omitempty" toml:"poolSize,omitempty" yaml:"poolSize,omitempty" export:"true"`
+	// CacheTTL defines how long the validated credentials are cached, 0 disabling the cache.
+	// Default: 5m.
+	CacheTTL ptypes.Duration `json:"cacheTTL,omitempty" toml:"cacheTTL,omitempty" yaml:"cacheTTL,omitempty" export:"true"`
+	// TLS defines the configuration used to secure the connection to the LDAP server.
+	TLS *ClientTLS `json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a BasicAuthLDAP.
+func (l *BasicAuthLDAP) SetDefaults() {
+	l.UserFilter = "(uid=%s)"
+	l.PoolSize = 10
+	l.CacheTTL = ptypes.Duration(5 * time.Minute)
 }
 
 // +k8s:deepcopy-gen=true
@@ -130,6 +1097,77 @@ type Buffering struct {
 
 // +k8s:deepcopy-gen=true
 
+// Cache holds the cache middleware configuration.
+// This middleware caches the responses of the services, following the HTTP caching semantics (RFC 9111).
+type Cache struct {
+	// TTL defines the freshness lifetime of the stored responses, overriding the one given by the Cache-Control and Expires response headers.
+	TTL ptypes.Duration `json:"ttl,omitempty" toml:"ttl,omitempty" yaml:"ttl,omitempty" export:"true"`
+	// DefaultTTL defines the freshness lifetime of the cacheable responses which do not define one.
+	DefaultTTL ptypes.Duration `json:"defaultTTL,omitempty" toml:"defaultTTL,omitempty" yaml:"defaultTTL,omitempty" export:"true"`
+	// StaleWhileRevalidate defines the duration during which a stale response is served while it is revalidated in the background,
+	// for the responses without a stale-while-revalidate Cache-Control directive.
+	StaleWhileRevalidate ptypes.Duration `json:"staleWhileRevalidate,omitempty" toml:"staleWhileRevalidate,omitempty" yaml:"staleWhileRevalidate,omitempty" export:"true"`
+	// MaxBodySize defines the maximum size, in bytes, of the body of a stored response.
+	// Default: 1048576.
+	MaxBodySize int64 `json:"maxBodySize,omitempty" toml:"maxBodySize,omitempty" yaml:"maxBodySize,omitempty" export:"true"`
+	// Key defines the parts of the request making up the cache key.
+	Key *CacheKey `json:"key,omitempty" toml:"key,omitempty" yaml:"key,omitempty" export:"true"`
+	// Store defines where the responses are stored.
+	// Default: in memory.
+	Store *CacheStore `json:"store,omitempty" toml:"store,omitempty" yaml:"store,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// CacheKey holds the cache key configuration.
+// The key is made of the host and the path of the request, and of its query unless ignored.
+type CacheKey struct {
+	// IgnoreQuery defines whether to leave the query of the request out of the key.
+	IgnoreQuery bool `json:"ignoreQuery,omitempty" toml:"ignoreQuery,omitempty" yaml:"ignoreQuery,omitempty" export:"true"`
+	// Headers defines the request headers added to the key.
+	Headers []string `json:"headers,omitempty" toml:"headers,omitempty" yaml:"headers,omitempty" export:"true"`
+	// Cookies defines the request cookies added to the key.
+	Cookies []string `json:"cookies,omitempty" toml:"cookies,omitempty" yaml:"cookies,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// CacheStore holds the cache store configuration.
+// Only one of the stores can be defined.
+type CacheStore struct {
+	// Memory defines an in-memory store, evicting the least recently used responses.
+	Memory *MemoryCacheStore `json:"memory,omitempty" toml:"memory,omitempty" yaml:"memory,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// Redis defines a Redis store, shared by the Traefik instances.
+	Redis *Redis `json:"redis,omitempty" toml:"redis,omitempty" yaml:"redis,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// MemoryCacheStore holds the in-memory cache store configuration.
+type MemoryCacheStore struct {
+	// MaxSize defines the maximum size, in bytes, of the stored responses.
+	// Default: 104857600.
+	MaxSize int64 `json:"maxSize,omitempty" toml:"maxSize,omitempty" yaml:"maxSize,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Redis holds the configuration of the connection to a Redis server or cluster.
+type Redis struct {
+	// Endpoints defines the addresses of the Redis servers.
+	Endpoints []string `json:"endpoints,omitempty" toml:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+	// Username defines the username used to authenticate.
+	Username string `json:"username,omitempty" toml:"username,omitempty" yaml:"username,omitempty" loggable:"false"`
+	// Password defines the password used to authenticate.
+	Password string `json:"password,omitempty" toml:"password,omitempty" yaml:"password,omitempty" loggable:"false"`
+	// DB defines the database selected after connecting to the server.
+	DB int `json:"db,omitempty" toml:"db,omitempty" yaml:"db,omitempty" export:"true"`
+	// TLS defines the configuration used to secure the connection to the servers.
+	TLS *ClientTLS `json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // Chain holds the chain middleware configuration.
 // This middleware enables to define reusable combinations of other pieces of middleware.
 type Chain struct {
@@ -153,6 +1191,12 @@ type CircuitBreaker struct {
 	RecoveryDuration ptypes.Duration `json:"recoveryDuration,omitempty" toml:"recoveryDuration,omitempty" yaml:"recoveryDuration,omitempty" export:"true"`
 	// ResponseCode is the status code that the circuit breaker will return while it is in the open state.
 	ResponseCode int `json:"responseCode,omitempty" toml:"responseCode,omitempty" yaml:"responseCode,omitempty" export:"true"`
+	// ProbePercent is the percentage of requests forwarded to the services at the end of the recovering state.
+	// The percentage of forwarded requests grows linearly from 0 to this value during the recovery duration.
+	ProbePercent int `json:"probePercent,omitempty" toml:"probePercent,omitempty" yaml:"probePercent,omitempty" export:"true"`
+	// PerBackend defines whether the circuit breaker holds one state per server (or per child service of a weighted service), instead of one state for the whole service.
+	// The servers whose circuit breaker is open are skipped by the load balancer.
+	PerBackend bool `json:"perBackend,omitempty" toml:"perBackend,omitempty" yaml:"perBackend,omitempty" export:"true"`
 }
 
 // SetDefaults sets the default values on a RateLimit.
@@ -161,6 +1205,7 @@ func (c *CircuitBreaker) SetDefaults() {
 	c.FallbackDuration = ptypes.Duration(10 * time.Second)
 	c.RecoveryDuration = ptypes.Duration(10 * time.Second)
 	c.ResponseCode = http.StatusServiceUnavailable
+	c.ProbePercent = 50
 }
 
 // +k8s:deepcopy-gen=true
@@ -180,6 +1225,22 @@ type Compress struct {
 	Encodings []string `json:"encodings,omitempty" toml:"encodings,omitempty" yaml:"encodings,omitempty" export:"true"`
 	// DefaultEncoding specifies the default encoding if the `Accept-Encoding` header is not in the request or contains a wildcard (`*`).
 	DefaultEncoding string `json:"defaultEncoding,omitempty" toml:"defaultEncoding,omitempty" yaml:"defaultEncoding,omitempty" export:"true"`
+	// NegotiationOrder defines which preference wins when choosing the encoding:
+	// client (the weights of the `Accept-Encoding` header), or server (the order of Encodings).
+	// Default: client.
+	NegotiationOrder string `json:"negotiationOrder,omitempty" toml:"negotiationOrder,omitempty" yaml:"negotiationOrder,omitempty" export:"true"`
+	// ContentTypeMinSizes defines the minimum amount of bytes a response body must have to be compressed, for the given content types.
+	// They take precedence over MinResponseBodyBytes.
+	ContentTypeMinSizes []CompressContentTypeMinSize `json:"contentTypeMinSizes,omitempty" toml:"contentTypeMinSizes,omitempty" yaml:"contentTypeMinSizes,omitempty" export:"true"`
+	// Levels defines the compression level of each algorithm.
+	Levels *CompressLevels `json:"levels,omitempty" toml:"levels,omitempty" yaml:"levels,omitempty" export:"true"`
+	// ExcludedPaths defines the list of request path prefixes for which the responses are not compressed.
+	ExcludedPaths []string `json:"excludedPaths,omitempty" toml:"excludedPaths,omitempty" yaml:"excludedPaths,omitempty" export:"true"`
+	// ExcludedRouters defines the list of routers for which the responses are not compressed.
+	ExcludedRouters []string `json:"excludedRouters,omitempty" toml:"excludedRouters,omitempty" yaml:"excludedRouters,omitempty" export:"true"`
+	// PreCompressed defines whether only the negotiated encoding is requested from the service, for it to send its pre-compressed variant of the response.
+	// The responses already encoded by the service are forwarded as is, and the other ones are compressed.
+	PreCompressed bool `json:"preCompressed,omitempty" toml:"preCompressed,omitempty" yaml:"preCompressed,omitempty" export:"true"`
 }
 
 func (c *Compress) SetDefaults() {
@@ -188,6 +1249,29 @@ func (c *Compress) SetDefaults() {
 
 // +k8s:deepcopy-gen=true
 
+// CompressContentTypeMinSize holds the minimum size of the responses to compress, for a content type.
+type CompressContentTypeMinSize struct {
+	// ContentType defines the media type of the responses, without parameters.
+	ContentType string `json:"contentType,omitempty" toml:"contentType,omitempty" yaml:"contentType,omitempty" export:"true"`
+	// MinResponseBodyBytes defines the minimum amount of bytes a response body must have to be compressed.
+	MinResponseBodyBytes int `json:"minResponseBodyBytes,omitempty" toml:"minResponseBodyBytes,omitempty" yaml:"minResponseBodyBytes,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// CompressLevels holds the compression level of each algorithm.
+// A zero value keeps the default level of the algorithm.
+type CompressLevels struct {
+	// Gzip defines the gzip compression level, from 1 to 9.
+	Gzip int `json:"gzip,omitempty" toml:"gzip,omitempty" yaml:"gzip,omitempty" export:"true"`
+	// Brotli defines the brotli compression level, from 1 to 11.
+	Brotli int `json:"brotli,omitempty" toml:"brotli,omitempty" yaml:"brotli,omitempty" export:"true"`
+	// Zstd defines the zstd compression level, from 1 to 22.
+	Zstd int `json:"zstd,omitempty" toml:"zstd,omitempty" yaml:"zstd,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // DigestAuth holds the digest auth middleware configuration.
 // This middleware restricts access to your services to known users.
 // More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/digestauth/
@@ -220,9 +1304,20 @@ type ErrorPage struct {
 	Status []string `json:"status,omitempty" toml:"status,omitempty" yaml:"status,omitempty" export:"true"`
 	// Service defines the name of the service that will serve the error page.
 	Service string `json:"service,omitempty" toml:"service,omitempty" yaml:"service,omitempty" export:"true"`
+	// StatusServices defines the names of the services serving the error pages of specific statuses, overriding the Service.
+	// The statuses are given as a status code (404), a range of status codes (500-504),
+	// or a class of status codes (5xx), and the narrowest one matching the status is used.
+	StatusServices map[string]string `json:"statusServices,omitempty" toml:"statusServices,omitempty" yaml:"statusServices,omitempty" export:"true"`
 	// Query defines the URL for the error page (hosted by service).
 	// The {status} variable can be used in order to insert the status code in the URL.
 	Query string `json:"query,omitempty" toml:"query,omitempty" yaml:"query,omitempty" export:"true"`
+	// ContentType defines the content type of the error pages built from the Body or the File.
+	// Default: text/html; charset=utf-8.
+	ContentType string `json:"contentType,omitempty" toml:"contentType,omitempty" yaml:"contentType,omitempty" export:"true"`
+	// Body defines the Go template of the error pages, served instead of calling the Service.
+	Body string `json:"body,omitempty" toml:"body,omitempty" yaml:"body,omitempty" export:"true"`
+	// File defines the path of the file holding the Go template of the error pages, served instead of calling the Service.
+	File string `json:"file,omitempty" toml:"file,omitempty" yaml:"file,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -250,6 +1345,42 @@ type ForwardAuth struct {
 	// HeaderField defines a header field to store the authenticated user.
 	// More info: https://doc.traefik.io/traefik/v3.0/middlewares/http/forwardauth/#headerfield
 	HeaderField string `json:"headerField,omitempty" toml:"headerField,omitempty" yaml:"headerField,omitempty" export:"true"`
+	// ForwardBody defines whether to forward the request body to the authentication server.
+	ForwardBody bool `json:"forwardBody,omitempty" toml:"forwardBody,omitempty" yaml:"forwardBody,omitempty" export:"true"`
+	// MaxBodySize defines the maximum size, in bytes, of the body prefix forwarded to the authentication server.
+	// The rest of the body is only forwarded to the service.
+	// Default: 1048576.
+	MaxBodySize int64 `json:"maxBodySize,omitempty" toml:"maxBodySize,omitempty" yaml:"maxBodySize,omitempty" export:"true"`
+	// Retry defines how the calls to the authentication server failing with a transient error are retried.
+	Retry *ForwardAuthRetry `json:"retry,omitempty" toml:"retry,omitempty" yaml:"retry,omitempty" export:"true"`
+	// Cache defines how the allow decisions of the authentication server are cached.
+	Cache *ForwardAuthCache `json:"cache,omitempty" toml:"cache,omitempty" yaml:"cache,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// ForwardAuthRetry holds the retry configuration of the calls to the authentication server.
+// The calls failing with a network error, or with a 502, 503 or 504 status code, are retried.
+type ForwardAuthRetry struct {
+	// Attempts defines how many times the call to the authentication server is attempted.
+	Attempts int `json:"attempts,omitempty" toml:"attempts,omitempty" yaml:"attempts,omitempty" export:"true"`
+	// InitialInterval defines the first wait time in the exponential backoff series.
+	// If not set, the calls are retried immediately.
+	InitialInterval ptypes.Duration `json:"initialInterval,omitempty" toml:"initialInterval,omitempty" yaml:"initialInterval,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// ForwardAuthCache holds the cache configuration of the allow decisions of the authentication server.
+type ForwardAuthCache struct {
+	// Key defines the values of the request the decisions are cached for, as a source expression, e.g. Header(`Authorization`).
+	// The requests whose key is empty are not cached.
+	Key string `json:"key,omitempty" toml:"key,omitempty" yaml:"key,omitempty" export:"true"`
+	// TTL defines how long an allow decision is cached.
+	TTL ptypes.Duration `json:"ttl,omitempty" toml:"ttl,omitempty" yaml:"ttl,omitempty" export:"true"`
+	// MaxEntries defines the maximum number of cached decisions.
+	// Default: 10000.
+	MaxEntries int `json:"maxEntries,omitempty" toml:"maxEntries,omitempty" yaml:"maxEntries,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -273,8 +1404,10 @@ type ClientTLS struct {
 // More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/headers/#customrequestheaders
 type Headers struct {
 	// CustomRequestHeaders defines the header names and values to apply to the request.
+	// The values containing {{ are Go templates, evaluated with the request attributes.
 	CustomRequestHeaders map[string]string `json:"customRequestHeaders,omitempty" toml:"customRequestHeaders,omitempty" yaml:"customRequestHeaders,omitempty" export:"true"`
 	// CustomResponseHeaders defines the header names and values to apply to the response.
+	// The values containing {{ are Go templates, evaluated with the request attributes.
 	CustomResponseHeaders map[string]string `json:"customResponseHeaders,omitempty" toml:"customResponseHeaders,omitempty" yaml:"customResponseHeaders,omitempty" export:"true"`
 
 	// AccessControlAllowCredentials defines whether the request can include user credentials.
@@ -438,14 +1571,32 @@ func (s *IPStrategy) Get() (ip.Strategy, error) {
 
 // +k8s:deepcopy-gen=true
 
+// DynamicSourceRange holds the sources of allowed IPs loaded at runtime.
+type DynamicSourceRange struct {
+	// URLs defines the URLs serving lists of allowed IPs (or ranges of allowed IPs by using CIDR notation), one per line.
+	URLs []string `json:"urls,omitempty" toml:"urls,omitempty" yaml:"urls,omitempty"`
+	// DNSNames defines the DNS names resolving to allowed IPs.
+	DNSNames []string `json:"dnsNames,omitempty" toml:"dnsNames,omitempty" yaml:"dnsNames,omitempty"`
+	// Files defines the files holding lists of allowed IPs (or ranges of allowed IPs by using CIDR notation), one per line.
+	// The files are reloaded when they change.
+	Files []string `json:"files,omitempty" toml:"files,omitempty" yaml:"files,omitempty"`
+	// RefreshInterval defines the interval between two refreshes of the URLs and DNS names.
+	// Default: 1m.
+	RefreshInterval ptypes.Duration `json:"refreshInterval,omitempty" toml:"refreshInterval,omitempty" yaml:"refreshInterval,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // IPWhiteList holds the IP whitelist middleware configuration.
 // This middleware limits allowed requests based on the client IP.
 // More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/ipwhitelist/
 // Deprecated: please use IPAllowList instead.
 type IPWhiteList struct {
 	// SourceRange defines the set of allowed IPs (or ranges of allowed IPs by using CIDR notation). Required.
-	SourceRange []string    `json:"sourceRange,omitempty" toml:"sourceRange,omitempty" yaml:"sourceRange,omitempty"`
-	IPStrategy  *IPStrategy `json:"ipStrategy,omitempty" toml:"ipStrategy,omitempty" yaml:"ipStrategy,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	SourceRange []string `json:"sourceRange,omitempty" toml:"sourceRange,omitempty" yaml:"sourceRange,omitempty"`
+	// DynamicSourceRange defines the sources of allowed IPs loaded at runtime, along with the SourceRange.
+	DynamicSourceRange *DynamicSourceRange `json:"dynamicSourceRange,omitempty" toml:"dynamicSourceRange,omitempty" yaml:"dynamicSourceRange,omitempty"`
+	IPStrategy         *IPStrategy         `json:"ipStrategy,omitempty" toml:"ipStrategy,omitempty" yaml:"ipStrategy,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -455,8 +1606,10 @@ type IPWhiteList struct {
 // More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/ipallowlist/
 type IPAllowList struct {
 	// SourceRange defines the set of allowed IPs (or ranges of allowed IPs by using CIDR notation).
-	SourceRange []string    `json:"sourceRange,omitempty" toml:"sourceRange,omitempty" yaml:"sourceRange,omitempty"`
-	IPStrategy  *IPStrategy `json:"ipStrategy,omitempty" toml:"ipStrategy,omitempty" yaml:"ipStrategy,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	SourceRange []string `json:"sourceRange,omitempty" toml:"sourceRange,omitempty" yaml:"sourceRange,omitempty"`
+	// DynamicSourceRange defines the sources of allowed IPs loaded at runtime, along with the SourceRange.
+	DynamicSourceRange *DynamicSourceRange `json:"dynamicSourceRange,omitempty" toml:"dynamicSourceRange,omitempty" yaml:"dynamicSourceRange,omitempty"`
+	IPStrategy         *IPStrategy         `json:"ipStrategy,omitempty" toml:"ipStrategy,omitempty" yaml:"ipStrategy,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 	// RejectStatusCode defines the HTTP status code used for refused requests.
 	// If not set, the default is 403 (Forbidden).
 	RejectStatusCode int `json:"rejectStatusCode,omitempty" toml:"rejectStatusCode,omitempty" yaml:"rejectStatusCode,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
@@ -476,6 +1629,92 @@ type InFlightReq struct {
 	// If none are set, the default is to use the requestHost.
 	// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/inflightreq/#sourcecriterion
 	SourceCriterion *SourceCriterion `json:"sourceCriterion,omitempty" toml:"sourceCriterion,omitempty" yaml:"sourceCriterion,omitempty" export:"true"`
+	// Queue defines the queue holding the requests exceeding the amount until a slot is released,
+	// instead of rejecting them right away.
+	Queue *InFlightReqQueue `json:"queue,omitempty" toml:"queue,omitempty" yaml:"queue,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// InFlightReqQueue holds the in-flight request queue configuration.
+type InFlightReqQueue struct {
+	// Size defines the maximum number of requests waiting in the queue, for each source.
+	// The middleware responds with HTTP 429 Too Many Requests when the queue is full.
+	Size int64 `json:"size,omitempty" toml:"size,omitempty" yaml:"size,omitempty" export:"true"`
+	// MaxWait defines the maximum duration a request waits in the queue,
+	// before the middleware responds with HTTP 429 Too Many Requests.
+	MaxWait ptypes.Duration `json:"maxWait,omitempty" toml:"maxWait,omitempty" yaml:"maxWait,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on an InFlightReqQueue.
+func (i *InFlightReqQueue) SetDefaults() {
+	i.MaxWait = ptypes.Duration(10 * time.Second)
+}
+
+// +k8s:deepcopy-gen=true
+
+// JWT holds the JWT middleware configuration.
+// This middleware validates the JSON Web Tokens sent by the clients as bearer tokens.
+type JWT struct {
+	// SigningSecret defines the secret used to verify the tokens signed with an HMAC algorithm.
+	SigningSecret string `json:"signingSecret,omitempty" toml:"signingSecret,omitempty" yaml:"signingSecret,omitempty" loggable:"false"`
+	// PublicKey defines the PEM encoded public key, or the path to a file containing it, used to verify the tokens.
+	PublicKey string `json:"publicKey,omitempty" toml:"publicKey,omitempty" yaml:"publicKey,omitempty"`
+	// JWKSURL defines the URL of the JSON Web Key Set used to verify the tokens.
+	// The key set is fetched again when a token is signed with an unknown key, to follow the keys rotation.
+	JWKSURL string `json:"jwksURL,omitempty" toml:"jwksURL,omitempty" yaml:"jwksURL,omitempty"`
+	// Issuer defines the expected issuer (iss claim) of the tokens.
+	Issuer string `json:"issuer,omitempty" toml:"issuer,omitempty" yaml:"issuer,omitempty"`
+	// Audience defines the accepted audiences (aud claim) of the tokens, at least one of them being expected.
+	Audience []string `json:"audience,omitempty" toml:"audience,omitempty" yaml:"audience,omitempty"`
+	// HeaderName defines the request header holding the token.
+	// Default: Authorization.
+	HeaderName string `json:"headerName,omitempty" toml:"headerName,omitempty" yaml:"headerName,omitempty" export:"true"`
+	// RemoveHeader defines whether to remove the header holding the token before forwarding the request to the backend.
+	RemoveHeader bool `json:"removeHeader,omitempty" toml:"removeHeader,omitempty" yaml:"removeHeader,omitempty" export:"true"`
+	// ClaimsHeaders defines the request headers set from the claims of the token, keyed by header name.
+	ClaimsHeaders map[string]string `json:"claimsHeaders,omitempty" toml:"claimsHeaders,omitempty" yaml:"claimsHeaders,omitempty" export:"true"`
+	// RejectStatusCode defines the status code of the response to the requests without a valid token.
+	// Default: 401.
+	RejectStatusCode int `json:"rejectStatusCode,omitempty" toml:"rejectStatusCode,omitempty" yaml:"rejectStatusCode,omitempty" export:"true"`
+	// TLS defines the configuration used to secure the connection to the JWKS URL.
+	TLS *ClientTLS `json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// OIDC holds the OpenID Connect middleware configuration.
+// This middleware authenticates the users against an OpenID Connect provider, with the authorization code flow.
+type OIDC struct {
+	// Issuer is the URL of the OpenID Connect provider, its configuration being discovered from the /.well-known/openid-configuration path.
+	Issuer string `json:"issuer,omitempty" toml:"issuer,omitempty" yaml:"issuer,omitempty"`
+	// ClientID is the identifier of the client registered at the provider.
+	ClientID string `json:"clientID,omitempty" toml:"clientID,omitempty" yaml:"clientID,omitempty"`
+	// ClientSecret is the secret of the client registered at the provider.
+	ClientSecret string `json:"clientSecret,omitempty" toml:"clientSecret,omitempty" yaml:"clientSecret,omitempty" loggable:"false"`
+	// Scopes defines the scopes requested to the provider.
+	// Default: openid, profile, email.
+	Scopes []string `json:"scopes,omitempty" toml:"scopes,omitempty" yaml:"scopes,omitempty" export:"true"`
+	// RedirectPath defines the path of the callback of the provider, handled by the middleware.
+	// Default: /oauth2/callback.
+	RedirectPath string `json:"redirectPath,omitempty" toml:"redirectPath,omitempty" yaml:"redirectPath,omitempty" export:"true"`
+	// LogoutPath defines the path, handled by the middleware, ending the session of the user.
+	// Default: /oauth2/logout.
+	LogoutPath string `json:"logoutPath,omitempty" toml:"logoutPath,omitempty" yaml:"logoutPath,omitempty" export:"true"`
+	// PostLogoutRedirectURI defines the URL the user is redirected to once logged out.
+	// Default: /.
+	PostLogoutRedirectURI string `json:"postLogoutRedirectURI,omitempty" toml:"postLogoutRedirectURI,omitempty" yaml:"postLogoutRedirectURI,omitempty" export:"true"`
+	// SessionSecret defines the secret used to encrypt the session cookie.
+	SessionSecret string `json:"sessionSecret,omitempty" toml:"sessionSecret,omitempty" yaml:"sessionSecret,omitempty" loggable:"false"`
+	// SessionCookie defines the session cookie.
+	// Default: a secure, HTTP only, and lax same site cookie named traefik_oidc, expiring with the browser session.
+	SessionCookie *Cookie `json:"sessionCookie,omitempty" toml:"sessionCookie,omitempty" yaml:"sessionCookie,omitempty" export:"true"`
+	// ClaimsHeaders defines the request headers set from the claims of the ID token, keyed by header name.
+	ClaimsHeaders map[string]string `json:"claimsHeaders,omitempty" toml:"claimsHeaders,omitempty" yaml:"claimsHeaders,omitempty" export:"true"`
+	// ForwardAccessToken defines whether to forward the access token in the Authorization header.
+	ForwardAccessToken bool `json:"forwardAccessToken,omitempty" toml:"forwardAccessToken,omitempty" yaml:"forwardAccessToken,omitempty" export:"true"`
+	// TLS defines the configuration used to secure the connection to the provider.
+	TLS *ClientTLS `json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -488,6 +1727,8 @@ type PassTLSClientCert struct {
 	PEM bool `json:"pem,omitempty" toml:"pem,omitempty" yaml:"pem,omitempty" export:"true"`
 	// Info selects the specific client certificate details you want to add to the X-Forwarded-Tls-Client-Cert-Info header.
 	Info *TLSClientCertificateInfo `json:"info,omitempty" toml:"info,omitempty" yaml:"info,omitempty" export:"true"`
+	// SPIFFE sets the X-Forwarded-Tls-Client-Cert-Spiffe-Id header with the SPIFFE ID of the client certificate.
+	SPIFFE *TLSClientCertificateSPIFFE `json:"spiffe,omitempty" toml:"spiffe,omitempty" yaml:"spiffe,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -501,6 +1742,9 @@ type SourceCriterion struct {
 	RequestHeaderName string `json:"requestHeaderName,omitempty" toml:"requestHeaderName,omitempty" yaml:"requestHeaderName,omitempty" export:"true"`
 	// RequestHost defines whether to consider the request Host as the source.
 	RequestHost bool `json:"requestHost,omitempty" toml:"requestHost,omitempty" yaml:"requestHost,omitempty" export:"true"`
+	// Expression defines the values of the request used to group incoming requests,
+	// e.g. Header(`X-API-Key`), or Header(`X-Tenant`) && JWTClaim(`sub`).
+	Expression string `json:"expression,omitempty" toml:"expression,omitempty" yaml:"expression,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -526,6 +1770,10 @@ type RateLimit struct {
 	// If several strategies are defined at the same time, an error will be raised.
 	// If none are set, the default is to use the request's remote address field (as an ipStrategy).
 	SourceCriterion *SourceCriterion `json:"sourceCriterion,omitempty" toml:"sourceCriterion,omitempty" yaml:"sourceCriterion,omitempty" export:"true"`
+
+	// Redis defines the Redis server storing the token buckets, to share the rate limit across the Traefik instances.
+	// If not set, the token buckets are kept in memory, and the rate limit applies to each Traefik instance.
+	Redis *Redis `json:"redis,omitempty" toml:"redis,omitempty" yaml:"redis,omitempty" export:"true"`
 }
 
 // SetDefaults sets the default values on a RateLimit.
@@ -586,6 +1834,38 @@ type ReplacePathRegex struct {
 
 // +k8s:deepcopy-gen=true
 
+// Query holds the query middleware configuration.
+// This middleware modifies the query parameters of the request URL.
+// The modifications are applied in the order of the options.
+type Query struct {
+	// AllowedParameters defines the only query parameters kept, all the others being removed.
+	AllowedParameters []string `json:"allowedParameters,omitempty" toml:"allowedParameters,omitempty" yaml:"allowedParameters,omitempty" export:"true"`
+	// Remove defines the names of the query parameters to remove.
+	Remove []string `json:"remove,omitempty" toml:"remove,omitempty" yaml:"remove,omitempty" export:"true"`
+	// Rename defines the new names of the query parameters, by current name.
+	Rename map[string]string `json:"rename,omitempty" toml:"rename,omitempty" yaml:"rename,omitempty" export:"true"`
+	// Rewrites defines the regular expression rewrites of the query parameter values.
+	Rewrites []QueryRewrite `json:"rewrites,omitempty" toml:"rewrites,omitempty" yaml:"rewrites,omitempty" export:"true"`
+	// Set defines the values of the query parameters to set, replacing their existing values.
+	Set map[string]string `json:"set,omitempty" toml:"set,omitempty" yaml:"set,omitempty" export:"true"`
+	// Add defines the values to add to the query parameters, keeping their existing values.
+	Add map[string]string `json:"add,omitempty" toml:"add,omitempty" yaml:"add,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// QueryRewrite holds a query parameter rewrite.
+type QueryRewrite struct {
+	// Parameter defines the name of the rewritten query parameter.
+	Parameter string `json:"parameter,omitempty" toml:"parameter,omitempty" yaml:"parameter,omitempty" export:"true"`
+	// Regex defines the regular expression matching the rewritten values.
+	Regex string `json:"regex,omitempty" toml:"regex,omitempty" yaml:"regex,omitempty" export:"true"`
+	// Replacement defines the replacement of the matched values, which can include captured variables.
+	Replacement string `json:"replacement,omitempty" toml:"replacement,omitempty" yaml:"replacement,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // Retry holds the retry middleware configuration.
 // This middleware reissues requests a given number of times to a backend server if that server does not reply.
 // As soon as the server answers, the middleware stops retrying, regardless of the response status.
@@ -599,6 +1879,39 @@ type Retry struct {
 	// The value of initialInterval should be provided in seconds or as a valid duration format,
 	// see https://pkg.go.dev/time#ParseDuration.
 	InitialInterval ptypes.Duration `json:"initialInterval,omitempty" toml:"initialInterval,omitempty" yaml:"initialInterval,omitempty" export:"true"`
+	// Budget limits the share of the requests that can be retried or hedged, to avoid retry storms.
+	Budget *RetryBudget `json:"budget,omitempty" toml:"budget,omitempty" yaml:"budget,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// Hedging defines the hedging of the requests, i.e. sending a duplicate request when the first one is slow to answer.
+	Hedging *RetryHedging `json:"hedging,omitempty" toml:"hedging,omitempty" yaml:"hedging,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RetryBudget holds the retry budget configuration.
+type RetryBudget struct {
+	// Percent defines the maximum percentage of the requests, over the last ten seconds, that can be retried or hedged.
+	// Default: 20.
+	Percent int `json:"percent,omitempty" toml:"percent,omitempty" yaml:"percent,omitempty" export:"true"`
+	// MinRetriesPerSecond defines the number of retries per second allowed regardless of the Percent,
+	// so that the requests of a low traffic can still be retried.
+	// Default: 10.
+	MinRetriesPerSecond int `json:"minRetriesPerSecond,omitempty" toml:"minRetriesPerSecond,omitempty" yaml:"minRetriesPerSecond,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a RetryBudget.
+func (r *RetryBudget) SetDefaults() {
+	r.Percent = 20
+	r.MinRetriesPerSecond = 10
+}
+
+// +k8s:deepcopy-gen=true
+
+// RetryHedging holds the request hedging configuration.
+type RetryHedging struct {
+	// Delay defines how long to wait for the response before sending a duplicate request,
+	// the first response received being forwarded to the client.
+	// Only the GET, HEAD, and OPTIONS requests without a body are hedged.
+	Delay ptypes.Duration `json:"delay,omitempty" toml:"delay,omitempty" yaml:"delay,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -667,6 +1980,15 @@ type TLSClientCertificateIssuerDNInfo struct {
 
 // +k8s:deepcopy-gen=true
 
+// TLSClientCertificateSPIFFE holds the client TLS certificate SPIFFE ID configuration.
+type TLSClientCertificateSPIFFE struct {
+	// TrustDomains defines the trust domains the SPIFFE ID of the client certificate must belong to.
+	// The requests without a client certificate with a SPIFFE ID from one of these trust domains are rejected.
+	TrustDomains []string `json:"trustDomains,omitempty" toml:"trustDomains,omitempty" yaml:"trustDomains,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // TLSClientCertificateSubjectDNInfo holds the client TLS certificate distinguished name info configuration.
 // cf https://tools.ietf.org/html/rfc3739
 type TLSClientCertificateSubjectDNInfo struct {
@@ -695,6 +2017,33 @@ type Users []string
 
 // +k8s:deepcopy-gen=true
 
+// WAF holds the web application firewall middleware configuration.
+// This middleware inspects the requests, and the responses, with the Coraza engine.
+type WAF struct {
+	// CoreRuleSet defines whether to load the OWASP Core Rule Set.
+	CoreRuleSet bool `json:"coreRuleSet,omitempty" toml:"coreRuleSet,omitempty" yaml:"coreRuleSet,omitempty" export:"true"`
+	// Directives defines additional SecLang directives, such as custom rules, or the tuning of the Core Rule Set.
+	Directives []string `json:"directives,omitempty" toml:"directives,omitempty" yaml:"directives,omitempty"`
+	// ExcludedRules defines the IDs of the rules to disable.
+	ExcludedRules []int `json:"excludedRules,omitempty" toml:"excludedRules,omitempty" yaml:"excludedRules,omitempty" export:"true"`
+	// DetectionOnly defines whether the requests matching the rules are only logged, instead of being blocked.
+	DetectionOnly bool `json:"detectionOnly,omitempty" toml:"detectionOnly,omitempty" yaml:"detectionOnly,omitempty" export:"true"`
+	// AuditLog defines the audit log of the transactions matching the rules.
+	AuditLog *WAFAuditLog `json:"auditLog,omitempty" toml:"auditLog,omitempty" yaml:"auditLog,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// WAFAuditLog holds the WAF audit log configuration.
+type WAFAuditLog struct {
+	// FilePath defines the path of the audit log file. It defaults to the standard output.
+	FilePath string `json:"filePath,omitempty" toml:"filePath,omitempty" yaml:"filePath,omitempty"`
+	// Format defines the format of the audit log: json (default), or native.
+	Format string `json:"format,omitempty" toml:"format,omitempty" yaml:"format,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // HeaderModifier holds the request/response header modifier configuration.
 type HeaderModifier struct {
 	Set    map[string]string `json:"set,omitempty"`
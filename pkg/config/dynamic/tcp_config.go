@@ -94,6 +94,9 @@ type TCPServersLoadBalancer struct {
 	// means an infinite deadline (i.e. the reading capability is never closed).
 	// Deprecated: use ServersTransport to configure the TerminationDelay instead.
 	TerminationDelay *int `json:"terminationDelay,omitempty" toml:"terminationDelay,omitempty" yaml:"terminationDelay,omitempty" export:"true"`
+	// Drain defines how the connections forwarded to a server are drained,
+	// once the server is removed from the load balancer, or the service is no longer used by any router.
+	Drain *TCPDrain `json:"drain,omitempty" toml:"drain,omitempty" yaml:"drain,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 }
 
 // Mergeable tells if the given service is mergeable.
@@ -115,6 +118,21 @@ func (l *TCPServersLoadBalancer) Mergeable(loadBalancer *TCPServersLoadBalancer)
 
 // +k8s:deepcopy-gen=true
 
+// TCPDrain holds the draining configuration of the connections forwarded to a removed server.
+type TCPDrain struct {
+	// GracePeriod defines the duration given to the connections to complete, before they are closed.
+	// Zero means that the connections are never closed.
+	// Default: 30s
+	GracePeriod ptypes.Duration `json:"gracePeriod,omitempty" toml:"gracePeriod,omitempty" yaml:"gracePeriod,omitempty" export:"true"`
+}
+
+// SetDefaults Default values for a TCPDrain.
+func (d *TCPDrain) SetDefaults() {
+	d.GracePeriod = DefaultDrainGracePeriod
+}
+
+// +k8s:deepcopy-gen=true
+
 // TCPServer holds a TCP Server configuration.
 type TCPServer struct {
 	Address string `json:"address,omitempty" toml:"address,omitempty" yaml:"address,omitempty" label:"-"`
@@ -129,6 +147,12 @@ type TCPServer struct {
 type ProxyProtocol struct {
 	// Version defines the PROXY Protocol version to use.
 	Version int `json:"version,omitempty" toml:"version,omitempty" yaml:"version,omitempty" export:"true"`
+	// TLVs defines the TLVs to add to the PROXY Protocol header, indexed by TLV type.
+	// It requires the version 2 of the PROXY Protocol.
+	TLVs map[string]string `json:"tlvs,omitempty" toml:"tlvs,omitempty" yaml:"tlvs,omitempty" export:"true"`
+	// PassthroughTLVs defines whether the TLVs of the PROXY Protocol header received by the entry point are forwarded to the servers.
+	// It requires the version 2 of the PROXY Protocol.
+	PassthroughTLVs bool `json:"passthroughTLVs,omitempty" toml:"passthroughTLVs,omitempty" yaml:"passthroughTLVs,omitempty" export:"true"`
 }
 
 // SetDefaults Default values for a ProxyProtocol.
@@ -149,6 +173,7 @@ type TCPServersTransport struct {
 	// means an infinite deadline (i.e. the reading capability is never closed).
 	TerminationDelay ptypes.Duration  `description:"Defines the delay to wait before fully terminating the connection, after one connected peer has closed its writing capability." json:"terminationDelay,omitempty" toml:"terminationDelay,omitempty" yaml:"terminationDelay,omitempty" export:"true"`
 	TLS              *TLSClientConfig `description:"Defines the TLS configuration." json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	ProxyProtocol    *ProxyProtocol   `description:"Defines the PROXY Protocol configuration, used when the service load balancer does not define one." json:"proxyProtocol,omitempty" toml:"proxyProtocol,omitempty" yaml:"proxyProtocol,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
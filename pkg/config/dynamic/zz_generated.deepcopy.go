@@ -35,372 +35,1115 @@ import (
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddPrefix) DeepCopyInto(out *AddPrefix) {
+func (in *APIKey) DeepCopyInto(out *APIKey) {
 	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddPrefix.
-func (in *AddPrefix) DeepCopy() *AddPrefix {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKey.
+func (in *APIKey) DeepCopy() *APIKey {
 	if in == nil {
 		return nil
 	}
-	out := new(AddPrefix)
+	out := new(APIKey)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
+func (in *APIKeyAuth) DeepCopyInto(out *APIKeyAuth) {
 	*out = *in
-	if in.Users != nil {
-		in, out := &in.Users, &out.Users
-		*out = make(Users, len(*in))
-		copy(*out, *in)
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]APIKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(Redis)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
-func (in *BasicAuth) DeepCopy() *BasicAuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyAuth.
+func (in *APIKeyAuth) DeepCopy() *APIKeyAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(BasicAuth)
+	out := new(APIKeyAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Buffering) DeepCopyInto(out *Buffering) {
+func (in *AWSSigV4) DeepCopyInto(out *AWSSigV4) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Buffering.
-func (in *Buffering) DeepCopy() *Buffering {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSigV4.
+func (in *AWSSigV4) DeepCopy() *AWSSigV4 {
 	if in == nil {
 		return nil
 	}
-	out := new(Buffering)
+	out := new(AWSSigV4)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Chain) DeepCopyInto(out *Chain) {
+func (in *AddPrefix) DeepCopyInto(out *AddPrefix) {
 	*out = *in
-	if in.Middlewares != nil {
-		in, out := &in.Middlewares, &out.Middlewares
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Chain.
-func (in *Chain) DeepCopy() *Chain {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddPrefix.
+func (in *AddPrefix) DeepCopy() *AddPrefix {
 	if in == nil {
 		return nil
 	}
-	out := new(Chain)
+	out := new(AddPrefix)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CircuitBreaker) DeepCopyInto(out *CircuitBreaker) {
+func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make(Users, len(*in))
+		copy(*out, *in)
+	}
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(BasicAuthLDAP)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreaker.
-func (in *CircuitBreaker) DeepCopy() *CircuitBreaker {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
+func (in *BasicAuth) DeepCopy() *BasicAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(CircuitBreaker)
+	out := new(BasicAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClientTLS) DeepCopyInto(out *ClientTLS) {
+func (in *BasicAuthLDAP) DeepCopyInto(out *BasicAuthLDAP) {
 	*out = *in
-	if in.CAOptional != nil {
-		in, out := &in.CAOptional, &out.CAOptional
-		*out = new(bool)
-		**out = **in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClientTLS)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientTLS.
-func (in *ClientTLS) DeepCopy() *ClientTLS {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuthLDAP.
+func (in *BasicAuthLDAP) DeepCopy() *BasicAuthLDAP {
 	if in == nil {
 		return nil
 	}
-	out := new(ClientTLS)
+	out := new(BasicAuthLDAP)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Compress) DeepCopyInto(out *Compress) {
+func (in *BodyRewrite) DeepCopyInto(out *BodyRewrite) {
 	*out = *in
-	if in.ExcludedContentTypes != nil {
-		in, out := &in.ExcludedContentTypes, &out.ExcludedContentTypes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.IncludedContentTypes != nil {
-		in, out := &in.IncludedContentTypes, &out.IncludedContentTypes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Encodings != nil {
-		in, out := &in.Encodings, &out.Encodings
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Compress.
-func (in *Compress) DeepCopy() *Compress {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodyRewrite.
+func (in *BodyRewrite) DeepCopy() *BodyRewrite {
 	if in == nil {
 		return nil
 	}
-	out := new(Compress)
+	out := new(BodyRewrite)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Configuration) DeepCopyInto(out *Configuration) {
+func (in *BodyValidation) DeepCopyInto(out *BodyValidation) {
 	*out = *in
-	if in.HTTP != nil {
-		in, out := &in.HTTP, &out.HTTP
-		*out = new(HTTPConfiguration)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.TCP != nil {
-		in, out := &in.TCP, &out.TCP
-		*out = new(TCPConfiguration)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.UDP != nil {
-		in, out := &in.UDP, &out.UDP
-		*out = new(UDPConfiguration)
-		(*in).DeepCopyInto(*out)
+	if in.AllowedContentTypes != nil {
+		in, out := &in.AllowedContentTypes, &out.AllowedContentTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(TLSConfiguration)
-		(*in).DeepCopyInto(*out)
+	if in.OpenAPI != nil {
+		in, out := &in.OpenAPI, &out.OpenAPI
+		*out = new(OpenAPIOperation)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Configuration.
-func (in *Configuration) DeepCopy() *Configuration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodyValidation.
+func (in *BodyValidation) DeepCopy() *BodyValidation {
 	if in == nil {
 		return nil
 	}
-	out := new(Configuration)
+	out := new(BodyValidation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in Configurations) DeepCopyInto(out *Configurations) {
-	{
-		in := &in
-		*out = make(Configurations, len(*in))
+func (in *BotManager) DeepCopyInto(out *BotManager) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
-			var outVal *Configuration
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = new(Configuration)
-				(*in).DeepCopyInto(*out)
-			}
-			(*out)[key] = outVal
+			(*out)[key] = val
 		}
-		return
 	}
+	if in.Signatures != nil {
+		in, out := &in.Signatures, &out.Signatures
+		*out = make([]BotSignature, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IPStrategy != nil {
+		in, out := &in.IPStrategy, &out.IPStrategy
+		*out = new(IPStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Configurations.
-func (in Configurations) DeepCopy() Configurations {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BotManager.
+func (in *BotManager) DeepCopy() *BotManager {
 	if in == nil {
 		return nil
 	}
-	out := new(Configurations)
+	out := new(BotManager)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContentType) DeepCopyInto(out *ContentType) {
+func (in *BotSignature) DeepCopyInto(out *BotSignature) {
 	*out = *in
-	if in.AutoDetect != nil {
-		in, out := &in.AutoDetect, &out.AutoDetect
-		*out = new(bool)
-		**out = **in
+	if in.UserAgents != nil {
+		in, out := &in.UserAgents, &out.UserAgents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceRanges != nil {
+		in, out := &in.SourceRanges, &out.SourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.JA3 != nil {
+		in, out := &in.JA3, &out.JA3
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.JA4 != nil {
+		in, out := &in.JA4, &out.JA4
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentType.
-func (in *ContentType) DeepCopy() *ContentType {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BotSignature.
+func (in *BotSignature) DeepCopy() *BotSignature {
 	if in == nil {
 		return nil
 	}
-	out := new(ContentType)
+	out := new(BotSignature)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Cookie) DeepCopyInto(out *Cookie) {
+func (in *Buffering) DeepCopyInto(out *Buffering) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cookie.
-func (in *Cookie) DeepCopy() *Cookie {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Buffering.
+func (in *Buffering) DeepCopy() *Buffering {
 	if in == nil {
 		return nil
 	}
-	out := new(Cookie)
+	out := new(Buffering)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DigestAuth) DeepCopyInto(out *DigestAuth) {
+func (in *CORS) DeepCopyInto(out *CORS) {
 	*out = *in
-	if in.Users != nil {
-		in, out := &in.Users, &out.Users
-		*out = make(Users, len(*in))
+	if in.AllowOriginList != nil {
+		in, out := &in.AllowOriginList, &out.AllowOriginList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowOriginListRegex != nil {
+		in, out := &in.AllowOriginListRegex, &out.AllowOriginListRegex
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowMethods != nil {
+		in, out := &in.AllowMethods, &out.AllowMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowHeaders != nil {
+		in, out := &in.AllowHeaders, &out.AllowHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposeHeaders != nil {
+		in, out := &in.ExposeHeaders, &out.ExposeHeaders
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DigestAuth.
-func (in *DigestAuth) DeepCopy() *DigestAuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CORS.
+func (in *CORS) DeepCopy() *CORS {
 	if in == nil {
 		return nil
 	}
-	out := new(DigestAuth)
+	out := new(CORS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ErrorPage) DeepCopyInto(out *ErrorPage) {
+func (in *CSRF) DeepCopyInto(out *CSRF) {
 	*out = *in
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
+	if in.SafeMethods != nil {
+		in, out := &in.SafeMethods, &out.SafeMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExemptPaths != nil {
+		in, out := &in.ExemptPaths, &out.ExemptPaths
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorPage.
-func (in *ErrorPage) DeepCopy() *ErrorPage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSRF.
+func (in *CSRF) DeepCopy() *CSRF {
 	if in == nil {
 		return nil
 	}
-	out := new(ErrorPage)
+	out := new(CSRF)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Failover) DeepCopyInto(out *Failover) {
+func (in *Cache) DeepCopyInto(out *Cache) {
 	*out = *in
-	if in.HealthCheck != nil {
-		in, out := &in.HealthCheck, &out.HealthCheck
-		*out = new(HealthCheck)
-		**out = **in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(CacheKey)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Store != nil {
+		in, out := &in.Store, &out.Store
+		*out = new(CacheStore)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Failover.
-func (in *Failover) DeepCopy() *Failover {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cache.
+func (in *Cache) DeepCopy() *Cache {
 	if in == nil {
 		return nil
 	}
-	out := new(Failover)
+	out := new(Cache)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ForwardAuth) DeepCopyInto(out *ForwardAuth) {
+func (in *CacheKey) DeepCopyInto(out *CacheKey) {
 	*out = *in
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(ClientTLS)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AuthResponseHeaders != nil {
-		in, out := &in.AuthResponseHeaders, &out.AuthResponseHeaders
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.AuthRequestHeaders != nil {
-		in, out := &in.AuthRequestHeaders, &out.AuthRequestHeaders
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.AddAuthCookiesToResponse != nil {
-		in, out := &in.AddAuthCookiesToResponse, &out.AddAuthCookiesToResponse
+	if in.Cookies != nil {
+		in, out := &in.Cookies, &out.Cookies
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardAuth.
-func (in *ForwardAuth) DeepCopy() *ForwardAuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheKey.
+func (in *CacheKey) DeepCopy() *CacheKey {
 	if in == nil {
 		return nil
 	}
-	out := new(ForwardAuth)
+	out := new(CacheKey)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ForwardingTimeouts) DeepCopyInto(out *ForwardingTimeouts) {
+func (in *CacheStore) DeepCopyInto(out *CacheStore) {
 	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(MemoryCacheStore)
+		**out = **in
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(Redis)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardingTimeouts.
-func (in *ForwardingTimeouts) DeepCopy() *ForwardingTimeouts {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheStore.
+func (in *CacheStore) DeepCopy() *CacheStore {
 	if in == nil {
 		return nil
 	}
-	out := new(ForwardingTimeouts)
+	out := new(CacheStore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GRPCStatus) DeepCopyInto(out *GRPCStatus) {
+func (in *Canary) DeepCopyInto(out *Canary) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Cookies != nil {
+		in, out := &in.Cookies, &out.Cookies
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCStatus.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Canary.
+func (in *Canary) DeepCopy() *Canary {
+	if in == nil {
+		return nil
+	}
+	out := new(Canary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Chain) DeepCopyInto(out *Chain) {
+	*out = *in
+	if in.Middlewares != nil {
+		in, out := &in.Middlewares, &out.Middlewares
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Chain.
+func (in *Chain) DeepCopy() *Chain {
+	if in == nil {
+		return nil
+	}
+	out := new(Chain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreaker) DeepCopyInto(out *CircuitBreaker) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreaker.
+func (in *CircuitBreaker) DeepCopy() *CircuitBreaker {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreaker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientTLS) DeepCopyInto(out *ClientTLS) {
+	*out = *in
+	if in.CAOptional != nil {
+		in, out := &in.CAOptional, &out.CAOptional
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientTLS.
+func (in *ClientTLS) DeepCopy() *ClientTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Coalesce) DeepCopyInto(out *Coalesce) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(CacheKey)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Coalesce.
+func (in *Coalesce) DeepCopy() *Coalesce {
+	if in == nil {
+		return nil
+	}
+	out := new(Coalesce)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Compress) DeepCopyInto(out *Compress) {
+	*out = *in
+	if in.ExcludedContentTypes != nil {
+		in, out := &in.ExcludedContentTypes, &out.ExcludedContentTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludedContentTypes != nil {
+		in, out := &in.IncludedContentTypes, &out.IncludedContentTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Encodings != nil {
+		in, out := &in.Encodings, &out.Encodings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContentTypeMinSizes != nil {
+		in, out := &in.ContentTypeMinSizes, &out.ContentTypeMinSizes
+		*out = make([]CompressContentTypeMinSize, len(*in))
+		copy(*out, *in)
+	}
+	if in.Levels != nil {
+		in, out := &in.Levels, &out.Levels
+		*out = new(CompressLevels)
+		**out = **in
+	}
+	if in.ExcludedPaths != nil {
+		in, out := &in.ExcludedPaths, &out.ExcludedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedRouters != nil {
+		in, out := &in.ExcludedRouters, &out.ExcludedRouters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Compress.
+func (in *Compress) DeepCopy() *Compress {
+	if in == nil {
+		return nil
+	}
+	out := new(Compress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompressContentTypeMinSize) DeepCopyInto(out *CompressContentTypeMinSize) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompressContentTypeMinSize.
+func (in *CompressContentTypeMinSize) DeepCopy() *CompressContentTypeMinSize {
+	if in == nil {
+		return nil
+	}
+	out := new(CompressContentTypeMinSize)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompressLevels) DeepCopyInto(out *CompressLevels) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompressLevels.
+func (in *CompressLevels) DeepCopy() *CompressLevels {
+	if in == nil {
+		return nil
+	}
+	out := new(CompressLevels)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConcurrencyQuota) DeepCopyInto(out *ConcurrencyQuota) {
+	*out = *in
+	if in.SourceCriterion != nil {
+		in, out := &in.SourceCriterion, &out.SourceCriterion
+		*out = new(SourceCriterion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tenants != nil {
+		in, out := &in.Tenants, &out.Tenants
+		*out = make([]ConcurrencyQuotaTenant, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencyQuota.
+func (in *ConcurrencyQuota) DeepCopy() *ConcurrencyQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ConcurrencyQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConcurrencyQuotaTenant) DeepCopyInto(out *ConcurrencyQuotaTenant) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencyQuotaTenant.
+func (in *ConcurrencyQuotaTenant) DeepCopy() *ConcurrencyQuotaTenant {
+	if in == nil {
+		return nil
+	}
+	out := new(ConcurrencyQuotaTenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Configuration) DeepCopyInto(out *Configuration) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TCP != nil {
+		in, out := &in.TCP, &out.TCP
+		*out = new(TCPConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UDP != nil {
+		in, out := &in.UDP, &out.UDP
+		*out = new(UDPConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Configuration.
+func (in *Configuration) DeepCopy() *Configuration {
+	if in == nil {
+		return nil
+	}
+	out := new(Configuration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Configurations) DeepCopyInto(out *Configurations) {
+	{
+		in := &in
+		*out = make(Configurations, len(*in))
+		for key, val := range *in {
+			var outVal *Configuration
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(Configuration)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Configurations.
+func (in Configurations) DeepCopy() Configurations {
+	if in == nil {
+		return nil
+	}
+	out := new(Configurations)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentType) DeepCopyInto(out *ContentType) {
+	*out = *in
+	if in.AutoDetect != nil {
+		in, out := &in.AutoDetect, &out.AutoDetect
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentType.
+func (in *ContentType) DeepCopy() *ContentType {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cookie) DeepCopyInto(out *Cookie) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cookie.
+func (in *Cookie) DeepCopy() *Cookie {
+	if in == nil {
+		return nil
+	}
+	out := new(Cookie)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CookieEncryption) DeepCopyInto(out *CookieEncryption) {
+	*out = *in
+	if in.Cookies != nil {
+		in, out := &in.Cookies, &out.Cookies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CookieEncryption.
+func (in *CookieEncryption) DeepCopy() *CookieEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(CookieEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CookieModifications) DeepCopyInto(out *CookieModifications) {
+	*out = *in
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rename != nil {
+		in, out := &in.Rename, &out.Rename
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CookieModifications.
+func (in *CookieModifications) DeepCopy() *CookieModifications {
+	if in == nil {
+		return nil
+	}
+	out := new(CookieModifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cookies) DeepCopyInto(out *Cookies) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(CookieModifications)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(CookieModifications)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(CookieEncryption)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cookies.
+func (in *Cookies) DeepCopy() *Cookies {
+	if in == nil {
+		return nil
+	}
+	out := new(Cookies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DigestAuth) DeepCopyInto(out *DigestAuth) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make(Users, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DigestAuth.
+func (in *DigestAuth) DeepCopy() *DigestAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(DigestAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Drain) DeepCopyInto(out *Drain) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Drain.
+func (in *Drain) DeepCopy() *Drain {
+	if in == nil {
+		return nil
+	}
+	out := new(Drain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicSourceRange) DeepCopyInto(out *DynamicSourceRange) {
+	*out = *in
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicSourceRange.
+func (in *DynamicSourceRange) DeepCopy() *DynamicSourceRange {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicSourceRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorPage) DeepCopyInto(out *ErrorPage) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StatusServices != nil {
+		in, out := &in.StatusServices, &out.StatusServices
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorPage.
+func (in *ErrorPage) DeepCopy() *ErrorPage {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorPage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Fail2Ban) DeepCopyInto(out *Fail2Ban) {
+	*out = *in
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoredSourceRange != nil {
+		in, out := &in.IgnoredSourceRange, &out.IgnoredSourceRange
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPStrategy != nil {
+		in, out := &in.IPStrategy, &out.IPStrategy
+		*out = new(IPStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(Redis)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fail2Ban.
+func (in *Fail2Ban) DeepCopy() *Fail2Ban {
+	if in == nil {
+		return nil
+	}
+	out := new(Fail2Ban)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Failover) DeepCopyInto(out *Failover) {
+	*out = *in
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheck)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Failover.
+func (in *Failover) DeepCopy() *Failover {
+	if in == nil {
+		return nil
+	}
+	out := new(Failover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FormatConversion) DeepCopyInto(out *FormatConversion) {
+	*out = *in
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FormatConversion.
+func (in *FormatConversion) DeepCopy() *FormatConversion {
+	if in == nil {
+		return nil
+	}
+	out := new(FormatConversion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardAuth) DeepCopyInto(out *ForwardAuth) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClientTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuthResponseHeaders != nil {
+		in, out := &in.AuthResponseHeaders, &out.AuthResponseHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuthRequestHeaders != nil {
+		in, out := &in.AuthRequestHeaders, &out.AuthRequestHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AddAuthCookiesToResponse != nil {
+		in, out := &in.AddAuthCookiesToResponse, &out.AddAuthCookiesToResponse
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(ForwardAuthRetry)
+		**out = **in
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(ForwardAuthCache)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardAuth.
+func (in *ForwardAuth) DeepCopy() *ForwardAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardAuthCache) DeepCopyInto(out *ForwardAuthCache) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardAuthCache.
+func (in *ForwardAuthCache) DeepCopy() *ForwardAuthCache {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardAuthCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardAuthRetry) DeepCopyInto(out *ForwardAuthRetry) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardAuthRetry.
+func (in *ForwardAuthRetry) DeepCopy() *ForwardAuthRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardAuthRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardingTimeouts) DeepCopyInto(out *ForwardingTimeouts) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardingTimeouts.
+func (in *ForwardingTimeouts) DeepCopy() *ForwardingTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardingTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCStatus) DeepCopyInto(out *GRPCStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCStatus.
 func (in *GRPCStatus) DeepCopy() *GRPCStatus {
 	if in == nil {
 		return nil
@@ -410,6 +1153,63 @@ func (in *GRPCStatus) DeepCopy() *GRPCStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeoIP) DeepCopyInto(out *GeoIP) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedCountries != nil {
+		in, out := &in.AllowedCountries, &out.AllowedCountries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedCountries != nil {
+		in, out := &in.DeniedCountries, &out.DeniedCountries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPStrategy != nil {
+		in, out := &in.IPStrategy, &out.IPStrategy
+		*out = new(IPStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeoIP.
+func (in *GeoIP) DeepCopy() *GeoIP {
+	if in == nil {
+		return nil
+	}
+	out := new(GeoIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GraphQL) DeepCopyInto(out *GraphQL) {
+	*out = *in
+	if in.AllowedOperations != nil {
+		in, out := &in.AllowedOperations, &out.AllowedOperations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GraphQL.
+func (in *GraphQL) DeepCopy() *GraphQL {
+	if in == nil {
+		return nil
+	}
+	out := new(GraphQL)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrpcWeb) DeepCopyInto(out *GrpcWeb) {
 	*out = *in
@@ -431,6 +1231,48 @@ func (in *GrpcWeb) DeepCopy() *GrpcWeb {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HMACSignature) DeepCopyInto(out *HMACSignature) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]HMACSignatureKey, len(*in))
+		copy(*out, *in)
+	}
+	if in.SignedComponents != nil {
+		in, out := &in.SignedComponents, &out.SignedComponents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HMACSignature.
+func (in *HMACSignature) DeepCopy() *HMACSignature {
+	if in == nil {
+		return nil
+	}
+	out := new(HMACSignature)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HMACSignatureKey) DeepCopyInto(out *HMACSignatureKey) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HMACSignatureKey.
+func (in *HMACSignatureKey) DeepCopy() *HMACSignatureKey {
+	if in == nil {
+		return nil
+	}
+	out := new(HMACSignatureKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPConfiguration) DeepCopyInto(out *HTTPConfiguration) {
 	*out = *in
@@ -522,6 +1364,32 @@ func (in *HTTPConfiguration) DeepCopy() *HTTPConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderAllowList) DeepCopyInto(out *HeaderAllowList) {
+	*out = *in
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderAllowList.
+func (in *HeaderAllowList) DeepCopy() *HeaderAllowList {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderAllowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HeaderModifier) DeepCopyInto(out *HeaderModifier) {
 	*out = *in
@@ -678,6 +1546,11 @@ func (in *IPAllowList) DeepCopyInto(out *IPAllowList) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DynamicSourceRange != nil {
+		in, out := &in.DynamicSourceRange, &out.DynamicSourceRange
+		*out = new(DynamicSourceRange)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.IPStrategy != nil {
 		in, out := &in.IPStrategy, &out.IPStrategy
 		*out = new(IPStrategy)
@@ -719,6 +1592,164 @@ func (in *IPStrategy) DeepCopy() *IPStrategy {
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPWhiteList) DeepCopyInto(out *IPWhiteList) {
+	*out = *in
+	if in.SourceRange != nil {
+		in, out := &in.SourceRange, &out.SourceRange
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DynamicSourceRange != nil {
+		in, out := &in.DynamicSourceRange, &out.DynamicSourceRange
+		*out = new(DynamicSourceRange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPStrategy != nil {
+		in, out := &in.IPStrategy, &out.IPStrategy
+		*out = new(IPStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPWhiteList.
+func (in *IPWhiteList) DeepCopy() *IPWhiteList {
+	if in == nil {
+		return nil
+	}
+	out := new(IPWhiteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Idempotency) DeepCopyInto(out *Idempotency) {
+	*out = *in
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Store != nil {
+		in, out := &in.Store, &out.Store
+		*out = new(IdempotencyStore)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Idempotency.
+func (in *Idempotency) DeepCopy() *Idempotency {
+	if in == nil {
+		return nil
+	}
+	out := new(Idempotency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdempotencyStore) DeepCopyInto(out *IdempotencyStore) {
+	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(MemoryIdempotencyStore)
+		**out = **in
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(Redis)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdempotencyStore.
+func (in *IdempotencyStore) DeepCopy() *IdempotencyStore {
+	if in == nil {
+		return nil
+	}
+	out := new(IdempotencyStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InFlightReq) DeepCopyInto(out *InFlightReq) {
+	*out = *in
+	if in.SourceCriterion != nil {
+		in, out := &in.SourceCriterion, &out.SourceCriterion
+		*out = new(SourceCriterion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Queue != nil {
+		in, out := &in.Queue, &out.Queue
+		*out = new(InFlightReqQueue)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InFlightReq.
+func (in *InFlightReq) DeepCopy() *InFlightReq {
+	if in == nil {
+		return nil
+	}
+	out := new(InFlightReq)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InFlightReqQueue) DeepCopyInto(out *InFlightReqQueue) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InFlightReqQueue.
+func (in *InFlightReqQueue) DeepCopy() *InFlightReqQueue {
+	if in == nil {
+		return nil
+	}
+	out := new(InFlightReqQueue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWT) DeepCopyInto(out *JWT) {
+	*out = *in
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClaimsHeaders != nil {
+		in, out := &in.ClaimsHeaders, &out.ClaimsHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClientTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWT.
+func (in *JWT) DeepCopy() *JWT {
+	if in == nil {
+		return nil
+	}
+	out := new(JWT)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Maintenance) DeepCopyInto(out *Maintenance) {
 	*out = *in
 	if in.SourceRange != nil {
 		in, out := &in.SourceRange, &out.SourceRange
@@ -733,33 +1764,102 @@ func (in *IPWhiteList) DeepCopyInto(out *IPWhiteList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPWhiteList.
-func (in *IPWhiteList) DeepCopy() *IPWhiteList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Maintenance.
+func (in *Maintenance) DeepCopy() *Maintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(Maintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MapEntry) DeepCopyInto(out *MapEntry) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MapEntry.
+func (in *MapEntry) DeepCopy() *MapEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(MapEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MapHost) DeepCopyInto(out *MapHost) {
+	*out = *in
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = make([]MapEntry, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MapHost.
+func (in *MapHost) DeepCopy() *MapHost {
+	if in == nil {
+		return nil
+	}
+	out := new(MapHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MapPath) DeepCopyInto(out *MapPath) {
+	*out = *in
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = make([]MapEntry, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MapPath.
+func (in *MapPath) DeepCopy() *MapPath {
 	if in == nil {
 		return nil
 	}
-	out := new(IPWhiteList)
+	out := new(MapPath)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InFlightReq) DeepCopyInto(out *InFlightReq) {
+func (in *MemoryCacheStore) DeepCopyInto(out *MemoryCacheStore) {
 	*out = *in
-	if in.SourceCriterion != nil {
-		in, out := &in.SourceCriterion, &out.SourceCriterion
-		*out = new(SourceCriterion)
-		(*in).DeepCopyInto(*out)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryCacheStore.
+func (in *MemoryCacheStore) DeepCopy() *MemoryCacheStore {
+	if in == nil {
+		return nil
 	}
+	out := new(MemoryCacheStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryIdempotencyStore) DeepCopyInto(out *MemoryIdempotencyStore) {
+	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InFlightReq.
-func (in *InFlightReq) DeepCopy() *InFlightReq {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryIdempotencyStore.
+func (in *MemoryIdempotencyStore) DeepCopy() *MemoryIdempotencyStore {
 	if in == nil {
 		return nil
 	}
-	out := new(InFlightReq)
+	out := new(MemoryIdempotencyStore)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -868,6 +1968,16 @@ func (in *Middleware) DeepCopyInto(out *Middleware) {
 		*out = new(ForwardAuth)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDC)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWT)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InFlightReq != nil {
 		in, out := &in.InFlightReq, &out.InFlightReq
 		*out = new(InFlightReq)
@@ -878,6 +1988,11 @@ func (in *Middleware) DeepCopyInto(out *Middleware) {
 		*out = new(Buffering)
 		**out = **in
 	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(Cache)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CircuitBreaker != nil {
 		in, out := &in.CircuitBreaker, &out.CircuitBreaker
 		*out = new(CircuitBreaker)
@@ -896,7 +2011,7 @@ func (in *Middleware) DeepCopyInto(out *Middleware) {
 	if in.Retry != nil {
 		in, out := &in.Retry, &out.Retry
 		*out = new(Retry)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ContentType != nil {
 		in, out := &in.ContentType, &out.ContentType
@@ -908,6 +2023,171 @@ func (in *Middleware) DeepCopyInto(out *Middleware) {
 		*out = new(GrpcWeb)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WAF != nil {
+		in, out := &in.WAF, &out.WAF
+		*out = new(WAF)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GeoIP != nil {
+		in, out := &in.GeoIP, &out.GeoIP
+		*out = new(GeoIP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BotManager != nil {
+		in, out := &in.BotManager, &out.BotManager
+		*out = new(BotManager)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BodyValidation != nil {
+		in, out := &in.BodyValidation, &out.BodyValidation
+		*out = new(BodyValidation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RewriteBody != nil {
+		in, out := &in.RewriteBody, &out.RewriteBody
+		*out = new(RewriteBody)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Script != nil {
+		in, out := &in.Script, &out.Script
+		*out = new(Script)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = new(Query)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cookies != nil {
+		in, out := &in.Cookies, &out.Cookies
+		*out = new(Cookies)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CORS != nil {
+		in, out := &in.CORS, &out.CORS
+		*out = new(CORS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CSRF != nil {
+		in, out := &in.CSRF, &out.CSRF
+		*out = new(CSRF)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OPA != nil {
+		in, out := &in.OPA, &out.OPA
+		*out = new(OPA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HMACSignature != nil {
+		in, out := &in.HMACSignature, &out.HMACSignature
+		*out = new(HMACSignature)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSSigV4 != nil {
+		in, out := &in.AWSSigV4, &out.AWSSigV4
+		*out = new(AWSSigV4)
+		**out = **in
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(Maintenance)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedirectMap != nil {
+		in, out := &in.RedirectMap, &out.RedirectMap
+		*out = new(RedirectMap)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestID != nil {
+		in, out := &in.RequestID, &out.RequestID
+		*out = new(RequestID)
+		**out = **in
+	}
+	if in.Tarpit != nil {
+		in, out := &in.Tarpit, &out.Tarpit
+		*out = new(Tarpit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fail2Ban != nil {
+		in, out := &in.Fail2Ban, &out.Fail2Ban
+		*out = new(Fail2Ban)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GraphQL != nil {
+		in, out := &in.GraphQL, &out.GraphQL
+		*out = new(GraphQL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebSocket != nil {
+		in, out := &in.WebSocket, &out.WebSocket
+		*out = new(WebSocket)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSE != nil {
+		in, out := &in.SSE, &out.SSE
+		*out = new(SSE)
+		**out = **in
+	}
+	if in.SignedURL != nil {
+		in, out := &in.SignedURL, &out.SignedURL
+		*out = new(SignedURL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIKeyAuth != nil {
+		in, out := &in.APIKeyAuth, &out.APIKeyAuth
+		*out = new(APIKeyAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Coalesce != nil {
+		in, out := &in.Coalesce, &out.Coalesce
+		*out = new(Coalesce)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(Canary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FormatConversion != nil {
+		in, out := &in.FormatConversion, &out.FormatConversion
+		*out = new(FormatConversion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeaderAllowList != nil {
+		in, out := &in.HeaderAllowList, &out.HeaderAllowList
+		*out = new(HeaderAllowList)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConcurrencyQuota != nil {
+		in, out := &in.ConcurrencyQuota, &out.ConcurrencyQuota
+		*out = new(ConcurrencyQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MapPath != nil {
+		in, out := &in.MapPath, &out.MapPath
+		*out = new(MapPath)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MapHost != nil {
+		in, out := &in.MapHost, &out.MapHost
+		*out = new(MapHost)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityHeaders != nil {
+		in, out := &in.SecurityHeaders, &out.SecurityHeaders
+		*out = new(SecurityHeaders)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpstreamAuth != nil {
+		in, out := &in.UpstreamAuth, &out.UpstreamAuth
+		*out = new(UpstreamAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Idempotency != nil {
+		in, out := &in.Idempotency, &out.Idempotency
+		*out = new(Idempotency)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Plugin != nil {
 		in, out := &in.Plugin, &out.Plugin
 		*out = make(map[string]PluginConf, len(*in))
@@ -930,156 +2210,360 @@ func (in *Middleware) DeepCopyInto(out *Middleware) {
 		*out = new(RequestRedirect)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.URLRewrite != nil {
-		in, out := &in.URLRewrite, &out.URLRewrite
-		*out = new(URLRewrite)
+	if in.URLRewrite != nil {
+		in, out := &in.URLRewrite, &out.URLRewrite
+		*out = new(URLRewrite)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Middleware.
+func (in *Middleware) DeepCopy() *Middleware {
+	if in == nil {
+		return nil
+	}
+	out := new(Middleware)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirrorService) DeepCopyInto(out *MirrorService) {
+	*out = *in
+	if in.MaxBodySize != nil {
+		in, out := &in.MaxBodySize, &out.MaxBodySize
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirrorService.
+func (in *MirrorService) DeepCopy() *MirrorService {
+	if in == nil {
+		return nil
+	}
+	out := new(MirrorService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Mirroring) DeepCopyInto(out *Mirroring) {
+	*out = *in
+	if in.MirrorBody != nil {
+		in, out := &in.MirrorBody, &out.MirrorBody
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxBodySize != nil {
+		in, out := &in.MaxBodySize, &out.MaxBodySize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]MirrorService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheck)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mirroring.
+func (in *Mirroring) DeepCopy() *Mirroring {
+	if in == nil {
+		return nil
+	}
+	out := new(Mirroring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Model) DeepCopyInto(out *Model) {
+	*out = *in
+	if in.Middlewares != nil {
+		in, out := &in.Middlewares, &out.Middlewares
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RouterTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Model.
+func (in *Model) DeepCopy() *Model {
+	if in == nil {
+		return nil
+	}
+	out := new(Model)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDC) DeepCopyInto(out *OIDC) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SessionCookie != nil {
+		in, out := &in.SessionCookie, &out.SessionCookie
+		*out = new(Cookie)
+		**out = **in
+	}
+	if in.ClaimsHeaders != nil {
+		in, out := &in.ClaimsHeaders, &out.ClaimsHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClientTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDC.
+func (in *OIDC) DeepCopy() *OIDC {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPA) DeepCopyInto(out *OPA) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClientTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPA.
+func (in *OPA) DeepCopy() *OPA {
+	if in == nil {
+		return nil
+	}
+	out := new(OPA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIOperation) DeepCopyInto(out *OpenAPIOperation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIOperation.
+func (in *OpenAPIOperation) DeepCopy() *OpenAPIOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PassTLSClientCert) DeepCopyInto(out *PassTLSClientCert) {
+	*out = *in
+	if in.Info != nil {
+		in, out := &in.Info, &out.Info
+		*out = new(TLSClientCertificateInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SPIFFE != nil {
+		in, out := &in.SPIFFE, &out.SPIFFE
+		*out = new(TLSClientCertificateSPIFFE)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Middleware.
-func (in *Middleware) DeepCopy() *Middleware {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PassTLSClientCert.
+func (in *PassTLSClientCert) DeepCopy() *PassTLSClientCert {
 	if in == nil {
 		return nil
 	}
-	out := new(Middleware)
+	out := new(PassTLSClientCert)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MirrorService) DeepCopyInto(out *MirrorService) {
+func (in *ProxyProtocol) DeepCopyInto(out *ProxyProtocol) {
 	*out = *in
+	if in.TLVs != nil {
+		in, out := &in.TLVs, &out.TLVs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirrorService.
-func (in *MirrorService) DeepCopy() *MirrorService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyProtocol.
+func (in *ProxyProtocol) DeepCopy() *ProxyProtocol {
 	if in == nil {
 		return nil
 	}
-	out := new(MirrorService)
+	out := new(ProxyProtocol)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Mirroring) DeepCopyInto(out *Mirroring) {
+func (in *Query) DeepCopyInto(out *Query) {
 	*out = *in
-	if in.MirrorBody != nil {
-		in, out := &in.MirrorBody, &out.MirrorBody
-		*out = new(bool)
-		**out = **in
+	if in.AllowedParameters != nil {
+		in, out := &in.AllowedParameters, &out.AllowedParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.MaxBodySize != nil {
-		in, out := &in.MaxBodySize, &out.MaxBodySize
-		*out = new(int64)
-		**out = **in
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Mirrors != nil {
-		in, out := &in.Mirrors, &out.Mirrors
-		*out = make([]MirrorService, len(*in))
+	if in.Rename != nil {
+		in, out := &in.Rename, &out.Rename
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Rewrites != nil {
+		in, out := &in.Rewrites, &out.Rewrites
+		*out = make([]QueryRewrite, len(*in))
 		copy(*out, *in)
 	}
-	if in.HealthCheck != nil {
-		in, out := &in.HealthCheck, &out.HealthCheck
-		*out = new(HealthCheck)
-		**out = **in
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mirroring.
-func (in *Mirroring) DeepCopy() *Mirroring {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Query.
+func (in *Query) DeepCopy() *Query {
 	if in == nil {
 		return nil
 	}
-	out := new(Mirroring)
+	out := new(Query)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Model) DeepCopyInto(out *Model) {
+func (in *QueryRewrite) DeepCopyInto(out *QueryRewrite) {
 	*out = *in
-	if in.Middlewares != nil {
-		in, out := &in.Middlewares, &out.Middlewares
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(RouterTLSConfig)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Model.
-func (in *Model) DeepCopy() *Model {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryRewrite.
+func (in *QueryRewrite) DeepCopy() *QueryRewrite {
 	if in == nil {
 		return nil
 	}
-	out := new(Model)
+	out := new(QueryRewrite)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PassTLSClientCert) DeepCopyInto(out *PassTLSClientCert) {
+func (in *RateLimit) DeepCopyInto(out *RateLimit) {
 	*out = *in
-	if in.Info != nil {
-		in, out := &in.Info, &out.Info
-		*out = new(TLSClientCertificateInfo)
+	if in.SourceCriterion != nil {
+		in, out := &in.SourceCriterion, &out.SourceCriterion
+		*out = new(SourceCriterion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(Redis)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PassTLSClientCert.
-func (in *PassTLSClientCert) DeepCopy() *PassTLSClientCert {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
+func (in *RateLimit) DeepCopy() *RateLimit {
 	if in == nil {
 		return nil
 	}
-	out := new(PassTLSClientCert)
+	out := new(RateLimit)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyProtocol) DeepCopyInto(out *ProxyProtocol) {
+func (in *RedirectMap) DeepCopyInto(out *RedirectMap) {
 	*out = *in
+	if in.Redirects != nil {
+		in, out := &in.Redirects, &out.Redirects
+		*out = make([]RedirectMapEntry, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyProtocol.
-func (in *ProxyProtocol) DeepCopy() *ProxyProtocol {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectMap.
+func (in *RedirectMap) DeepCopy() *RedirectMap {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyProtocol)
+	out := new(RedirectMap)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RateLimit) DeepCopyInto(out *RateLimit) {
+func (in *RedirectMapEntry) DeepCopyInto(out *RedirectMapEntry) {
 	*out = *in
-	if in.SourceCriterion != nil {
-		in, out := &in.SourceCriterion, &out.SourceCriterion
-		*out = new(SourceCriterion)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
-func (in *RateLimit) DeepCopy() *RateLimit {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectMapEntry.
+func (in *RedirectMapEntry) DeepCopy() *RedirectMapEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(RateLimit)
+	out := new(RedirectMapEntry)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1116,6 +2600,32 @@ func (in *RedirectScheme) DeepCopy() *RedirectScheme {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Redis) DeepCopyInto(out *Redis) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClientTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Redis.
+func (in *Redis) DeepCopy() *Redis {
+	if in == nil {
+		return nil
+	}
+	out := new(Redis)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplacePath) DeepCopyInto(out *ReplacePath) {
 	*out = *in
@@ -1148,6 +2658,22 @@ func (in *ReplacePathRegex) DeepCopy() *ReplacePathRegex {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestID) DeepCopyInto(out *RequestID) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestID.
+func (in *RequestID) DeepCopy() *RequestID {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestID)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestRedirect) DeepCopyInto(out *RequestRedirect) {
 	*out = *in
@@ -1208,6 +2734,16 @@ func (in *ResponseForwarding) DeepCopy() *ResponseForwarding {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Retry) DeepCopyInto(out *Retry) {
 	*out = *in
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(RetryBudget)
+		**out = **in
+	}
+	if in.Hedging != nil {
+		in, out := &in.Hedging, &out.Hedging
+		*out = new(RetryHedging)
+		**out = **in
+	}
 	return
 }
 
@@ -1221,6 +2757,64 @@ func (in *Retry) DeepCopy() *Retry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryBudget) DeepCopyInto(out *RetryBudget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryBudget.
+func (in *RetryBudget) DeepCopy() *RetryBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryHedging) DeepCopyInto(out *RetryHedging) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryHedging.
+func (in *RetryHedging) DeepCopy() *RetryHedging {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryHedging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RewriteBody) DeepCopyInto(out *RewriteBody) {
+	*out = *in
+	if in.Rewrites != nil {
+		in, out := &in.Rewrites, &out.Rewrites
+		*out = make([]BodyRewrite, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContentTypes != nil {
+		in, out := &in.ContentTypes, &out.ContentTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RewriteBody.
+func (in *RewriteBody) DeepCopy() *RewriteBody {
+	if in == nil {
+		return nil
+	}
+	out := new(RewriteBody)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Router) DeepCopyInto(out *Router) {
 	*out = *in
@@ -1239,6 +2833,11 @@ func (in *Router) DeepCopyInto(out *Router) {
 		*out = new(RouterTLSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RespondingTimeouts != nil {
+		in, out := &in.RespondingTimeouts, &out.RespondingTimeouts
+		*out = new(RouterRespondingTimeouts)
+		**out = **in
+	}
 	return
 }
 
@@ -1252,6 +2851,22 @@ func (in *Router) DeepCopy() *Router {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterRespondingTimeouts) DeepCopyInto(out *RouterRespondingTimeouts) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterRespondingTimeouts.
+func (in *RouterRespondingTimeouts) DeepCopy() *RouterRespondingTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterRespondingTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouterTCPTLSConfig) DeepCopyInto(out *RouterTCPTLSConfig) {
 	*out = *in
@@ -1288,12 +2903,79 @@ func (in *RouterTLSConfig) DeepCopyInto(out *RouterTLSConfig) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterTLSConfig.
-func (in *RouterTLSConfig) DeepCopy() *RouterTLSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterTLSConfig.
+func (in *RouterTLSConfig) DeepCopy() *RouterTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSE) DeepCopyInto(out *SSE) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSE.
+func (in *SSE) DeepCopy() *SSE {
+	if in == nil {
+		return nil
+	}
+	out := new(SSE)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Script) DeepCopyInto(out *Script) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Script.
+func (in *Script) DeepCopy() *Script {
+	if in == nil {
+		return nil
+	}
+	out := new(Script)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityHeaders) DeepCopyInto(out *SecurityHeaders) {
+	*out = *in
+	if in.ContentSecurityPolicy != nil {
+		in, out := &in.ContentSecurityPolicy, &out.ContentSecurityPolicy
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityHeaders.
+func (in *SecurityHeaders) DeepCopy() *SecurityHeaders {
 	if in == nil {
 		return nil
 	}
-	out := new(RouterTLSConfig)
+	out := new(SecurityHeaders)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1377,6 +3059,11 @@ func (in *ServersLoadBalancer) DeepCopyInto(out *ServersLoadBalancer) {
 		*out = new(ResponseForwarding)
 		**out = **in
 	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(Drain)
+		**out = **in
+	}
 	return
 }
 
@@ -1449,6 +3136,11 @@ func (in *Service) DeepCopyInto(out *Service) {
 		*out = new(Failover)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TrafficSplit != nil {
+		in, out := &in.TrafficSplit, &out.TrafficSplit
+		*out = new(TrafficSplit)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1462,6 +3154,43 @@ func (in *Service) DeepCopy() *Service {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SignedURL) DeepCopyInto(out *SignedURL) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]SignedURLKey, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignedURL.
+func (in *SignedURL) DeepCopy() *SignedURL {
+	if in == nil {
+		return nil
+	}
+	out := new(SignedURL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SignedURLKey) DeepCopyInto(out *SignedURLKey) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignedURLKey.
+func (in *SignedURLKey) DeepCopy() *SignedURLKey {
+	if in == nil {
+		return nil
+	}
+	out := new(SignedURLKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceCriterion) DeepCopyInto(out *SourceCriterion) {
 	*out = *in
@@ -1504,6 +3233,22 @@ func (in *Spiffe) DeepCopy() *Spiffe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SplitVariant) DeepCopyInto(out *SplitVariant) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SplitVariant.
+func (in *SplitVariant) DeepCopy() *SplitVariant {
+	if in == nil {
+		return nil
+	}
+	out := new(SplitVariant)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Sticky) DeepCopyInto(out *Sticky) {
 	*out = *in
@@ -1663,6 +3408,22 @@ func (in *TCPConfiguration) DeepCopy() *TCPConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPDrain) DeepCopyInto(out *TCPDrain) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPDrain.
+func (in *TCPDrain) DeepCopy() *TCPDrain {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPDrain)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TCPIPAllowList) DeepCopyInto(out *TCPIPAllowList) {
 	*out = *in
@@ -1821,7 +3582,7 @@ func (in *TCPServersLoadBalancer) DeepCopyInto(out *TCPServersLoadBalancer) {
 	if in.ProxyProtocol != nil {
 		in, out := &in.ProxyProtocol, &out.ProxyProtocol
 		*out = new(ProxyProtocol)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Servers != nil {
 		in, out := &in.Servers, &out.Servers
@@ -1833,6 +3594,11 @@ func (in *TCPServersLoadBalancer) DeepCopyInto(out *TCPServersLoadBalancer) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(TCPDrain)
+		**out = **in
+	}
 	return
 }
 
@@ -1854,6 +3620,11 @@ func (in *TCPServersTransport) DeepCopyInto(out *TCPServersTransport) {
 		*out = new(TLSClientConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ProxyProtocol != nil {
+		in, out := &in.ProxyProtocol, &out.ProxyProtocol
+		*out = new(ProxyProtocol)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1979,6 +3750,27 @@ func (in *TLSClientCertificateIssuerDNInfo) DeepCopy() *TLSClientCertificateIssu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSClientCertificateSPIFFE) DeepCopyInto(out *TLSClientCertificateSPIFFE) {
+	*out = *in
+	if in.TrustDomains != nil {
+		in, out := &in.TrustDomains, &out.TrustDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSClientCertificateSPIFFE.
+func (in *TLSClientCertificateSPIFFE) DeepCopy() *TLSClientCertificateSPIFFE {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSClientCertificateSPIFFE)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSClientCertificateSubjectDNInfo) DeepCopyInto(out *TLSClientCertificateSubjectDNInfo) {
 	*out = *in
@@ -2067,6 +3859,84 @@ func (in *TLSConfiguration) DeepCopy() *TLSConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tarpit) DeepCopyInto(out *Tarpit) {
+	*out = *in
+	if in.SourceRange != nil {
+		in, out := &in.SourceRange, &out.SourceRange
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rate != nil {
+		in, out := &in.Rate, &out.Rate
+		*out = new(TarpitRate)
+		**out = **in
+	}
+	if in.BotCategories != nil {
+		in, out := &in.BotCategories, &out.BotCategories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPStrategy != nil {
+		in, out := &in.IPStrategy, &out.IPStrategy
+		*out = new(IPStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tarpit.
+func (in *Tarpit) DeepCopy() *Tarpit {
+	if in == nil {
+		return nil
+	}
+	out := new(Tarpit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TarpitRate) DeepCopyInto(out *TarpitRate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TarpitRate.
+func (in *TarpitRate) DeepCopy() *TarpitRate {
+	if in == nil {
+		return nil
+	}
+	out := new(TarpitRate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficSplit) DeepCopyInto(out *TrafficSplit) {
+	*out = *in
+	if in.Variants != nil {
+		in, out := &in.Variants, &out.Variants
+		*out = make([]SplitVariant, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cookie != nil {
+		in, out := &in.Cookie, &out.Cookie
+		*out = new(Cookie)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficSplit.
+func (in *TrafficSplit) DeepCopy() *TrafficSplit {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficSplit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UDPConfiguration) DeepCopyInto(out *UDPConfiguration) {
 	*out = *in
@@ -2272,6 +4142,97 @@ func (in *URLRewrite) DeepCopy() *URLRewrite {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpstreamAuth) DeepCopyInto(out *UpstreamAuth) {
+	*out = *in
+	if in.Basic != nil {
+		in, out := &in.Basic, &out.Basic
+		*out = new(UpstreamBasicAuth)
+		**out = **in
+	}
+	if in.Bearer != nil {
+		in, out := &in.Bearer, &out.Bearer
+		*out = new(UpstreamBearerAuth)
+		**out = **in
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(UpstreamOAuth2)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamAuth.
+func (in *UpstreamAuth) DeepCopy() *UpstreamAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpstreamBasicAuth) DeepCopyInto(out *UpstreamBasicAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamBasicAuth.
+func (in *UpstreamBasicAuth) DeepCopy() *UpstreamBasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamBasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpstreamBearerAuth) DeepCopyInto(out *UpstreamBearerAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamBearerAuth.
+func (in *UpstreamBearerAuth) DeepCopy() *UpstreamBearerAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamBearerAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpstreamOAuth2) DeepCopyInto(out *UpstreamOAuth2) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EndpointParams != nil {
+		in, out := &in.EndpointParams, &out.EndpointParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamOAuth2.
+func (in *UpstreamOAuth2) DeepCopy() *UpstreamOAuth2 {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamOAuth2)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Users) DeepCopyInto(out *Users) {
 	{
@@ -2292,6 +4253,53 @@ func (in Users) DeepCopy() Users {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAF) DeepCopyInto(out *WAF) {
+	*out = *in
+	if in.Directives != nil {
+		in, out := &in.Directives, &out.Directives
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedRules != nil {
+		in, out := &in.ExcludedRules, &out.ExcludedRules
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(WAFAuditLog)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAF.
+func (in *WAF) DeepCopy() *WAF {
+	if in == nil {
+		return nil
+	}
+	out := new(WAF)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFAuditLog) DeepCopyInto(out *WAFAuditLog) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFAuditLog.
+func (in *WAFAuditLog) DeepCopy() *WAFAuditLog {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFAuditLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WRRService) DeepCopyInto(out *WRRService) {
 	*out = *in
@@ -2323,6 +4331,27 @@ func (in *WRRService) DeepCopy() *WRRService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSocket) DeepCopyInto(out *WebSocket) {
+	*out = *in
+	if in.AllowedSubprotocols != nil {
+		in, out := &in.AllowedSubprotocols, &out.AllowedSubprotocols
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSocket.
+func (in *WebSocket) DeepCopy() *WebSocket {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSocket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WeightedRoundRobin) DeepCopyInto(out *WeightedRoundRobin) {
 	*out = *in
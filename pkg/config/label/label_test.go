@@ -35,6 +35,8 @@ func TestDecodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Middlewares.Middleware4.circuitbreaker.fallbackduration":                     "1s",
 		"traefik.HTTP.Middlewares.Middleware4.circuitbreaker.recoveryduration":                     "1s",
 		"traefik.HTTP.Middlewares.Middleware4.circuitbreaker.responsecode":                         "403",
+		"traefik.HTTP.Middlewares.Middleware4.circuitbreaker.probepercent":                         "20",
+		"traefik.HTTP.Middlewares.Middleware4.circuitbreaker.perbackend":                           "true",
 		"traefik.http.middlewares.Middleware5.digestauth.headerfield":                              "foobar",
 		"traefik.http.middlewares.Middleware5.digestauth.realm":                                    "foobar",
 		"traefik.http.middlewares.Middleware5.digestauth.removeheader":                             "true",
@@ -46,6 +48,13 @@ func TestDecodeConfiguration(t *testing.T) {
 		"traefik.http.middlewares.Middleware7.forwardauth.address":                                 "foobar",
 		"traefik.http.middlewares.Middleware7.forwardauth.authresponseheaders":                     "foobar, fiibar",
 		"traefik.http.middlewares.Middleware7.forwardauth.authrequestheaders":                      "foobar, fiibar",
+		"traefik.http.middlewares.Middleware7.forwardauth.cache.key":                               "foobar",
+		"traefik.http.middlewares.Middleware7.forwardauth.cache.maxentries":                        "42",
+		"traefik.http.middlewares.Middleware7.forwardauth.cache.ttl":                               "42",
+		"traefik.http.middlewares.Middleware7.forwardauth.forwardbody":                             "true",
+		"traefik.http.middlewares.Middleware7.forwardauth.maxbodysize":                             "42",
+		"traefik.http.middlewares.Middleware7.forwardauth.retry.attempts":                          "42",
+		"traefik.http.middlewares.Middleware7.forwardauth.retry.initialinterval":                   "42",
 		"traefik.http.middlewares.Middleware7.forwardauth.tls.ca":                                  "foobar",
 		"traefik.http.middlewares.Middleware7.forwardauth.tls.caoptional":                          "true",
 		"traefik.http.middlewares.Middleware7.forwardauth.tls.cert":                                "foobar",
@@ -139,6 +148,9 @@ func TestDecodeConfiguration(t *testing.T) {
 		"traefik.http.middlewares.Middleware18.stripprefixregex.regex":                             "foobar, fiibar",
 		"traefik.http.middlewares.Middleware19.compress.encodings":                                 "foobar, fiibar",
 		"traefik.http.middlewares.Middleware19.compress.minresponsebodybytes":                      "42",
+		"traefik.http.middlewares.Middleware19.compress.negotiationorder":                          "server",
+		"traefik.http.middlewares.Middleware19.compress.levels.zstd":                               "3",
+		"traefik.http.middlewares.Middleware19.compress.excludedpaths":                             "foobar, fiibar",
 		"traefik.http.middlewares.Middleware20.plugin.tomato.aaa":                                  "foo1",
 		"traefik.http.middlewares.Middleware20.plugin.tomato.bbb":                                  "foo2",
 		"traefik.http.routers.Router0.entrypoints":                                                 "foobar, fiibar",
@@ -165,6 +177,8 @@ func TestDecodeConfiguration(t *testing.T) {
 		"traefik.http.services.Service0.loadbalancer.healthcheck.mode":                 "foobar",
 		"traefik.http.services.Service0.loadbalancer.healthcheck.timeout":              "1s",
 		"traefik.http.services.Service0.loadbalancer.healthcheck.followredirects":      "true",
+		"traefik.http.services.Service0.loadbalancer.drain.closeconnections":           "true",
+		"traefik.http.services.Service0.loadbalancer.drain.graceperiod":                "1s",
 		"traefik.http.services.Service0.loadbalancer.passhostheader":                   "true",
 		"traefik.http.services.Service0.loadbalancer.responseforwarding.flushinterval": "1s",
 		"traefik.http.services.Service0.loadbalancer.server.scheme":                    "foobar",
@@ -192,28 +206,31 @@ func TestDecodeConfiguration(t *testing.T) {
 		"traefik.http.services.Service1.loadbalancer.sticky.cookie.name":               "fui",
 		"traefik.http.services.Service1.loadbalancer.serversTransport":                 "foobar",
 
-		"traefik.tcp.middlewares.Middleware0.ipallowlist.sourcerange":      "foobar, fiibar",
-		"traefik.tcp.middlewares.Middleware2.inflightconn.amount":          "42",
-		"traefik.tcp.routers.Router0.rule":                                 "foobar",
-		"traefik.tcp.routers.Router0.priority":                             "42",
-		"traefik.tcp.routers.Router0.entrypoints":                          "foobar, fiibar",
-		"traefik.tcp.routers.Router0.service":                              "foobar",
-		"traefik.tcp.routers.Router0.tls.passthrough":                      "false",
-		"traefik.tcp.routers.Router0.tls.options":                          "foo",
-		"traefik.tcp.routers.Router1.rule":                                 "foobar",
-		"traefik.tcp.routers.Router1.priority":                             "42",
-		"traefik.tcp.routers.Router1.entrypoints":                          "foobar, fiibar",
-		"traefik.tcp.routers.Router1.service":                              "foobar",
-		"traefik.tcp.routers.Router1.tls.options":                          "foo",
-		"traefik.tcp.routers.Router1.tls.passthrough":                      "false",
-		"traefik.tcp.services.Service0.loadbalancer.server.Port":           "42",
-		"traefik.tcp.services.Service0.loadbalancer.TerminationDelay":      "42",
-		"traefik.tcp.services.Service0.loadbalancer.proxyProtocol.version": "42",
-		"traefik.tcp.services.Service0.loadbalancer.serversTransport":      "foo",
-		"traefik.tcp.services.Service1.loadbalancer.server.Port":           "42",
-		"traefik.tcp.services.Service1.loadbalancer.TerminationDelay":      "42",
-		"traefik.tcp.services.Service1.loadbalancer.proxyProtocol":         "true",
-		"traefik.tcp.services.Service1.loadbalancer.serversTransport":      "foo",
+		"traefik.tcp.middlewares.Middleware0.ipallowlist.sourcerange":              "foobar, fiibar",
+		"traefik.tcp.middlewares.Middleware2.inflightconn.amount":                  "42",
+		"traefik.tcp.routers.Router0.rule":                                         "foobar",
+		"traefik.tcp.routers.Router0.priority":                                     "42",
+		"traefik.tcp.routers.Router0.entrypoints":                                  "foobar, fiibar",
+		"traefik.tcp.routers.Router0.service":                                      "foobar",
+		"traefik.tcp.routers.Router0.tls.passthrough":                              "false",
+		"traefik.tcp.routers.Router0.tls.options":                                  "foo",
+		"traefik.tcp.routers.Router1.rule":                                         "foobar",
+		"traefik.tcp.routers.Router1.priority":                                     "42",
+		"traefik.tcp.routers.Router1.entrypoints":                                  "foobar, fiibar",
+		"traefik.tcp.routers.Router1.service":                                      "foobar",
+		"traefik.tcp.routers.Router1.tls.options":                                  "foo",
+		"traefik.tcp.routers.Router1.tls.passthrough":                              "false",
+		"traefik.tcp.services.Service0.loadbalancer.server.Port":                   "42",
+		"traefik.tcp.services.Service0.loadbalancer.TerminationDelay":              "42",
+		"traefik.tcp.services.Service0.loadbalancer.drain.gracePeriod":             "1s",
+		"traefik.tcp.services.Service0.loadbalancer.proxyProtocol.version":         "42",
+		"traefik.tcp.services.Service0.loadbalancer.proxyProtocol.tlvs.224":        "foobar",
+		"traefik.tcp.services.Service0.loadbalancer.proxyProtocol.passthroughTLVs": "true",
+		"traefik.tcp.services.Service0.loadbalancer.serversTransport":              "foo",
+		"traefik.tcp.services.Service1.loadbalancer.server.Port":                   "42",
+		"traefik.tcp.services.Service1.loadbalancer.TerminationDelay":              "42",
+		"traefik.tcp.services.Service1.loadbalancer.proxyProtocol":                 "true",
+		"traefik.tcp.services.Service1.loadbalancer.serversTransport":              "foo",
 
 		"traefik.udp.routers.Router0.entrypoints":                "foobar, fiibar",
 		"traefik.udp.routers.Router0.service":                    "foobar",
@@ -281,8 +298,15 @@ func TestDecodeConfiguration(t *testing.T) {
 							},
 						},
 						TerminationDelay: func(i int) *int { return &i }(42),
-						ProxyProtocol:    &dynamic.ProxyProtocol{Version: 42},
+						ProxyProtocol: &dynamic.ProxyProtocol{
+							Version:         42,
+							TLVs:            map[string]string{"224": "foobar"},
+							PassthroughTLVs: true,
+						},
 						ServersTransport: "foo",
+						Drain: &dynamic.TCPDrain{
+							GracePeriod: ptypes.Duration(time.Second),
+						},
 					},
 				},
 				"Service1": {
@@ -498,6 +522,14 @@ func TestDecodeConfiguration(t *testing.T) {
 							"foobar",
 							"fiibar",
 						},
+						NegotiationOrder: "server",
+						Levels: &dynamic.CompressLevels{
+							Zstd: 3,
+						},
+						ExcludedPaths: []string{
+							"foobar",
+							"fiibar",
+						},
 					},
 				},
 				"Middleware2": {
@@ -524,6 +556,8 @@ func TestDecodeConfiguration(t *testing.T) {
 						FallbackDuration: ptypes.Duration(time.Second),
 						RecoveryDuration: ptypes.Duration(time.Second),
 						ResponseCode:     403,
+						ProbePercent:     20,
+						PerBackend:       true,
 					},
 				},
 				"Middleware5": {
@@ -567,6 +601,17 @@ func TestDecodeConfiguration(t *testing.T) {
 							"foobar",
 							"fiibar",
 						},
+						ForwardBody: true,
+						MaxBodySize: 42,
+						Retry: &dynamic.ForwardAuthRetry{
+							Attempts:        42,
+							InitialInterval: ptypes.Duration(42 * time.Second),
+						},
+						Cache: &dynamic.ForwardAuthCache{
+							Key:        "foobar",
+							TTL:        ptypes.Duration(42 * time.Second),
+							MaxEntries: 42,
+						},
 					},
 				},
 				"Middleware8": {
@@ -696,6 +741,10 @@ func TestDecodeConfiguration(t *testing.T) {
 						ResponseForwarding: &dynamic.ResponseForwarding{
 							FlushInterval: ptypes.Duration(time.Second),
 						},
+						Drain: &dynamic.Drain{
+							GracePeriod:      ptypes.Duration(time.Second),
+							CloseConnections: true,
+						},
 						ServersTransport: "foobar",
 					},
 				},
@@ -1018,6 +1067,14 @@ func TestEncodeConfiguration(t *testing.T) {
 							"foobar",
 							"fiibar",
 						},
+						NegotiationOrder: "server",
+						Levels: &dynamic.CompressLevels{
+							Zstd: 3,
+						},
+						ExcludedPaths: []string{
+							"foobar",
+							"fiibar",
+						},
 					},
 				},
 				"Middleware2": {
@@ -1052,6 +1109,8 @@ func TestEncodeConfiguration(t *testing.T) {
 						FallbackDuration: ptypes.Duration(time.Second),
 						RecoveryDuration: ptypes.Duration(time.Second),
 						ResponseCode:     404,
+						ProbePercent:     20,
+						PerBackend:       true,
 					},
 				},
 				"Middleware5": {
@@ -1095,6 +1154,17 @@ func TestEncodeConfiguration(t *testing.T) {
 							"foobar",
 							"fiibar",
 						},
+						ForwardBody: true,
+						MaxBodySize: 42,
+						Retry: &dynamic.ForwardAuthRetry{
+							Attempts:        42,
+							InitialInterval: ptypes.Duration(42 * time.Second),
+						},
+						Cache: &dynamic.ForwardAuthCache{
+							Key:        "foobar",
+							TTL:        ptypes.Duration(42 * time.Second),
+							MaxEntries: 42,
+						},
 					},
 				},
 				"Middleware8": {
@@ -1283,6 +1353,8 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Middlewares.Middleware4.CircuitBreaker.FallbackDuration":                     "1000000000",
 		"traefik.HTTP.Middlewares.Middleware4.CircuitBreaker.RecoveryDuration":                     "1000000000",
 		"traefik.HTTP.Middlewares.Middleware4.CircuitBreaker.ResponseCode":                         "404",
+		"traefik.HTTP.Middlewares.Middleware4.CircuitBreaker.ProbePercent":                         "20",
+		"traefik.HTTP.Middlewares.Middleware4.CircuitBreaker.PerBackend":                           "true",
 		"traefik.HTTP.Middlewares.Middleware5.DigestAuth.HeaderField":                              "foobar",
 		"traefik.HTTP.Middlewares.Middleware5.DigestAuth.Realm":                                    "foobar",
 		"traefik.HTTP.Middlewares.Middleware5.DigestAuth.RemoveHeader":                             "true",
@@ -1294,6 +1366,13 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.Address":                                 "foobar",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.AuthResponseHeaders":                     "foobar, fiibar",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.AuthRequestHeaders":                      "foobar, fiibar",
+		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.Cache.Key":                               "foobar",
+		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.Cache.MaxEntries":                        "42",
+		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.Cache.TTL":                               "42000000000",
+		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.ForwardBody":                             "true",
+		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.MaxBodySize":                             "42",
+		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.Retry.Attempts":                          "42",
+		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.Retry.InitialInterval":                   "42000000000",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.TLS.CA":                                  "foobar",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.TLS.CAOptional":                          "true",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.TLS.Cert":                                "foobar",
@@ -1388,20 +1467,28 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Middlewares.Middleware18.StripPrefixRegex.Regex":                             "foobar, fiibar",
 		"traefik.HTTP.Middlewares.Middleware19.Compress.Encodings":                                 "foobar, fiibar",
 		"traefik.HTTP.Middlewares.Middleware19.Compress.MinResponseBodyBytes":                      "42",
+		"traefik.HTTP.Middlewares.Middleware19.Compress.NegotiationOrder":                          "server",
+		"traefik.HTTP.Middlewares.Middleware19.Compress.PreCompressed":                             "false",
+		"traefik.HTTP.Middlewares.Middleware19.Compress.Levels.Gzip":                               "0",
+		"traefik.HTTP.Middlewares.Middleware19.Compress.Levels.Brotli":                             "0",
+		"traefik.HTTP.Middlewares.Middleware19.Compress.Levels.Zstd":                               "3",
+		"traefik.HTTP.Middlewares.Middleware19.Compress.ExcludedPaths":                             "foobar, fiibar",
 		"traefik.HTTP.Middlewares.Middleware20.Plugin.tomato.aaa":                                  "foo1",
 		"traefik.HTTP.Middlewares.Middleware20.Plugin.tomato.bbb":                                  "foo2",
 
-		"traefik.HTTP.Routers.Router0.EntryPoints": "foobar, fiibar",
-		"traefik.HTTP.Routers.Router0.Middlewares": "foobar, fiibar",
-		"traefik.HTTP.Routers.Router0.Priority":    "42",
-		"traefik.HTTP.Routers.Router0.Rule":        "foobar",
-		"traefik.HTTP.Routers.Router0.Service":     "foobar",
-		"traefik.HTTP.Routers.Router0.TLS":         "true",
-		"traefik.HTTP.Routers.Router1.EntryPoints": "foobar, fiibar",
-		"traefik.HTTP.Routers.Router1.Middlewares": "foobar, fiibar",
-		"traefik.HTTP.Routers.Router1.Priority":    "42",
-		"traefik.HTTP.Routers.Router1.Rule":        "foobar",
-		"traefik.HTTP.Routers.Router1.Service":     "foobar",
+		"traefik.HTTP.Routers.Router0.EntryPoints":         "foobar, fiibar",
+		"traefik.HTTP.Routers.Router0.MaxRequestBodyBytes": "0",
+		"traefik.HTTP.Routers.Router0.Middlewares":         "foobar, fiibar",
+		"traefik.HTTP.Routers.Router0.Priority":            "42",
+		"traefik.HTTP.Routers.Router0.Rule":                "foobar",
+		"traefik.HTTP.Routers.Router0.Service":             "foobar",
+		"traefik.HTTP.Routers.Router0.TLS":                 "true",
+		"traefik.HTTP.Routers.Router1.EntryPoints":         "foobar, fiibar",
+		"traefik.HTTP.Routers.Router1.MaxRequestBodyBytes": "0",
+		"traefik.HTTP.Routers.Router1.Middlewares":         "foobar, fiibar",
+		"traefik.HTTP.Routers.Router1.Priority":            "42",
+		"traefik.HTTP.Routers.Router1.Rule":                "foobar",
+		"traefik.HTTP.Routers.Router1.Service":             "foobar",
 
 		"traefik.HTTP.Services.Service0.LoadBalancer.HealthCheck.Headers.name0":        "foobar",
 		"traefik.HTTP.Services.Service0.LoadBalancer.HealthCheck.Headers.name1":        "foobar",
@@ -1415,6 +1502,7 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Services.Service0.LoadBalancer.HealthCheck.Timeout":              "1000000000",
 		"traefik.HTTP.Services.Service0.LoadBalancer.PassHostHeader":                   "true",
 		"traefik.HTTP.Services.Service0.LoadBalancer.ResponseForwarding.FlushInterval": "1000000000",
+		"traefik.HTTP.Services.Service0.LoadBalancer.ResponseHeaderTimeout":            "0",
 		"traefik.HTTP.Services.Service0.LoadBalancer.server.Port":                      "8080",
 		"traefik.HTTP.Services.Service0.LoadBalancer.server.Scheme":                    "foobar",
 		"traefik.HTTP.Services.Service0.LoadBalancer.Sticky.Cookie.Name":               "foobar",
@@ -1434,6 +1522,7 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Services.Service1.LoadBalancer.HealthCheck.Timeout":              "1000000000",
 		"traefik.HTTP.Services.Service1.LoadBalancer.PassHostHeader":                   "true",
 		"traefik.HTTP.Services.Service1.LoadBalancer.ResponseForwarding.FlushInterval": "1000000000",
+		"traefik.HTTP.Services.Service1.LoadBalancer.ResponseHeaderTimeout":            "0",
 		"traefik.HTTP.Services.Service1.LoadBalancer.server.Port":                      "8080",
 		"traefik.HTTP.Services.Service1.LoadBalancer.server.Scheme":                    "foobar",
 		"traefik.HTTP.Services.Service1.LoadBalancer.ServersTransport":                 "foobar",
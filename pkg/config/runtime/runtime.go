@@ -22,6 +22,19 @@ const (
 	StatusDown = "DOWN"
 )
 
+// Status of the plugins protected by a circuit breaker.
+const (
+	PluginStatusEnabled  = "enabled"
+	PluginStatusBypassed = "bypassed"
+	PluginStatusDisabled = "disabled"
+)
+
+// Health of the plugins providing a health hook.
+const (
+	PluginHealthHealthy   = "healthy"
+	PluginHealthUnhealthy = "unhealthy"
+)
+
 // Configuration holds the information about the currently running traefik instance.
 type Configuration struct {
 	Routers        map[string]*RouterInfo        `json:"routers,omitempty"`
@@ -11,6 +11,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/logs"
+	httpmuxer "github.com/traefik/traefik/v3/pkg/muxer/http"
 )
 
 // GetRoutersByEntryPoints returns all the http routers by entry points name and routers name.
@@ -80,6 +81,8 @@ type RouterInfo struct {
 	// It is the caller's responsibility to set the initial status.
 	Status string   `json:"status,omitempty"`
 	Using  []string `json:"using,omitempty"` // Effective entry points used by that router.
+	// Specificity is the specificity of the rule, when the priority of the router is computed from it.
+	Specificity *httpmuxer.RuleSpecificity `json:"specificity,omitempty"`
 }
 
 // AddError adds err to r.Err, if it does not already exist.
@@ -110,6 +113,11 @@ type MiddlewareInfo struct {
 	Err    []string `json:"error,omitempty"`
 	Status string   `json:"status,omitempty"`
 	UsedBy []string `json:"usedBy,omitempty"` // list of routers and services using that middleware.
+
+	pluginStatusMu    sync.RWMutex
+	pluginStatus      string
+	pluginHealth      string
+	pluginHealthError string
 }
 
 // AddError adds err to s.Err, if it does not already exist.
@@ -133,6 +141,50 @@ func (m *MiddlewareInfo) AddError(err error, critical bool) {
 	}
 }
 
+// SetPluginStatus sets the status of the plugin circuit breaker in the MiddlewareInfo.
+// It is the responsibility of the caller to check that m is not nil.
+func (m *MiddlewareInfo) SetPluginStatus(status string) {
+	m.pluginStatusMu.Lock()
+	defer m.pluginStatusMu.Unlock()
+
+	m.pluginStatus = status
+}
+
+// GetPluginStatus returns the status of the plugin circuit breaker in the MiddlewareInfo.
+// It is the responsibility of the caller to check that m is not nil.
+func (m *MiddlewareInfo) GetPluginStatus() string {
+	m.pluginStatusMu.RLock()
+	defer m.pluginStatusMu.RUnlock()
+
+	return m.pluginStatus
+}
+
+// SetPluginHealth sets the health reported by the plugin health hook in the MiddlewareInfo.
+// It is the responsibility of the caller to check that m is not nil.
+func (m *MiddlewareInfo) SetPluginHealth(err error) {
+	m.pluginStatusMu.Lock()
+	defer m.pluginStatusMu.Unlock()
+
+	if err != nil {
+		m.pluginHealth = PluginHealthUnhealthy
+		m.pluginHealthError = err.Error()
+		return
+	}
+
+	m.pluginHealth = PluginHealthHealthy
+	m.pluginHealthError = ""
+}
+
+// GetPluginHealth returns the health reported by the plugin health hook in the MiddlewareInfo,
+// and the error reported by the hook if the plugin is unhealthy.
+// It is the responsibility of the caller to check that m is not nil.
+func (m *MiddlewareInfo) GetPluginHealth() (string, string) {
+	m.pluginStatusMu.RLock()
+	defer m.pluginStatusMu.RUnlock()
+
+	return m.pluginHealth, m.pluginHealthError
+}
+
 // ServiceInfo holds information about a currently running service.
 type ServiceInfo struct {
 	*dynamic.Service // dynamic configuration
@@ -1,11 +1,16 @@
 package static
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"net/http"
+	"os"
+	"strconv"
 	"strings"
 
 	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/proxyprotocol"
 	"github.com/traefik/traefik/v3/pkg/types"
 )
 
@@ -13,6 +18,7 @@ import (
 type EntryPoint struct {
 	Address          string                `description:"Entry point address." json:"address,omitempty" toml:"address,omitempty" yaml:"address,omitempty"`
 	ReusePort        bool                  `description:"Enables EntryPoints from the same or different processes listening on the same TCP/UDP port." json:"reusePort,omitempty" toml:"reusePort,omitempty" yaml:"reusePort,omitempty"`
+	SocketOptions    *SocketOptions        `description:"Options of the listening socket." json:"socketOptions,omitempty" toml:"socketOptions,omitempty" yaml:"socketOptions,omitempty" export:"true"`
 	AsDefault        bool                  `description:"Adds this EntryPoint to the list of default EntryPoints to be used on routers that don't have any Entrypoint defined." json:"asDefault,omitempty" toml:"asDefault,omitempty" yaml:"asDefault,omitempty"`
 	Transport        *EntryPointsTransport `description:"Configures communication between clients and Traefik." json:"transport,omitempty" toml:"transport,omitempty" yaml:"transport,omitempty" export:"true"`
 	ProxyProtocol    *ProxyProtocol        `description:"Proxy-Protocol configuration." json:"proxyProtocol,omitempty" toml:"proxyProtocol,omitempty" yaml:"proxyProtocol,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
@@ -21,18 +27,37 @@ type EntryPoint struct {
 	HTTP2            *HTTP2Config          `description:"HTTP/2 configuration." json:"http2,omitempty" toml:"http2,omitempty" yaml:"http2,omitempty" export:"true"`
 	HTTP3            *HTTP3Config          `description:"HTTP/3 configuration." json:"http3,omitempty" toml:"http3,omitempty" yaml:"http3,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
 	UDP              *UDPConfig            `description:"UDP configuration." json:"udp,omitempty" toml:"udp,omitempty" yaml:"udp,omitempty"`
+	UnixSocket       *UnixSocketConfig     `description:"Unix domain socket configuration, for the entry points whose address is a unix socket path." json:"unixSocket,omitempty" toml:"unixSocket,omitempty" yaml:"unixSocket,omitempty" export:"true"`
+}
+
+// unixSocketPrefix is the prefix of the entry point addresses that are unix domain socket paths.
+const unixSocketPrefix = "unix:"
+
+// IsUnixSocket returns whether the entry point listens on a unix domain socket.
+func (ep EntryPoint) IsUnixSocket() bool {
+	return strings.HasPrefix(ep.Address, unixSocketPrefix)
 }
 
 // GetAddress strips any potential protocol part of the address field of the
 // entry point, in order to return the actual address.
+// For a unix domain socket, it returns the path of the socket.
 func (ep EntryPoint) GetAddress() string {
+	if ep.IsUnixSocket() {
+		return strings.TrimPrefix(ep.Address, unixSocketPrefix)
+	}
+
 	splitN := strings.SplitN(ep.Address, "/", 2)
 	return splitN[0]
 }
 
 // GetProtocol returns the protocol part of the address field of the entry point.
 // If none is specified, it defaults to "tcp".
+// A unix domain socket is a stream socket, handled as a "tcp" entry point.
 func (ep EntryPoint) GetProtocol() (string, error) {
+	if ep.IsUnixSocket() {
+		return "tcp", nil
+	}
+
 	splitN := strings.SplitN(ep.Address, "/", 2)
 	if len(splitN) < 2 {
 		return "tcp", nil
@@ -57,12 +82,87 @@ func (ep *EntryPoint) SetDefaults() {
 	ep.HTTP2.SetDefaults()
 }
 
+// SocketOptions holds the options of the listening socket of an entry point.
+type SocketOptions struct {
+	Backlog     int           `description:"Maximum length of the queue of the pending connections. If zero, the system default is used." json:"backlog,omitempty" toml:"backlog,omitempty" yaml:"backlog,omitempty" export:"true"`
+	TCPFastOpen int           `description:"Maximum length of the queue of the pending TCP Fast Open connections. If zero, TCP Fast Open is disabled." json:"tcpFastOpen,omitempty" toml:"tcpFastOpen,omitempty" yaml:"tcpFastOpen,omitempty" export:"true"`
+	FreeBind    bool          `description:"Allows listening on an IP address not assigned to any interface yet (IP_FREEBIND)." json:"freeBind,omitempty" toml:"freeBind,omitempty" yaml:"freeBind,omitempty" export:"true"`
+	KeepAlive   *TCPKeepAlive `description:"TCP keep-alive configuration of the accepted connections." json:"keepAlive,omitempty" toml:"keepAlive,omitempty" yaml:"keepAlive,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (o *SocketOptions) SetDefaults() {
+	o.KeepAlive = &TCPKeepAlive{}
+	o.KeepAlive.SetDefaults()
+}
+
+func (o *SocketOptions) validate() error {
+	if o == nil {
+		return nil
+	}
+
+	if o.Backlog < 0 {
+		return fmt.Errorf("invalid socket backlog %d: must be positive", o.Backlog)
+	}
+
+	if o.TCPFastOpen < 0 {
+		return fmt.Errorf("invalid TCP Fast Open queue length %d: must be positive", o.TCPFastOpen)
+	}
+
+	if o.KeepAlive != nil && (o.KeepAlive.Idle < 0 || o.KeepAlive.Interval < 0 || o.KeepAlive.Count < 0) {
+		return errors.New("invalid TCP keep-alive configuration: values must be positive")
+	}
+
+	return nil
+}
+
+// TCPKeepAlive holds the TCP keep-alive configuration of the connections accepted by an entry point.
+type TCPKeepAlive struct {
+	Idle     ptypes.Duration `description:"Duration the connection must be idle before the first keep-alive probe is sent." json:"idle,omitempty" toml:"idle,omitempty" yaml:"idle,omitempty" export:"true"`
+	Interval ptypes.Duration `description:"Duration between two keep-alive probes." json:"interval,omitempty" toml:"interval,omitempty" yaml:"interval,omitempty" export:"true"`
+	Count    int             `description:"Number of unanswered keep-alive probes before the connection is closed. If zero, the system default is used." json:"count,omitempty" toml:"count,omitempty" yaml:"count,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (k *TCPKeepAlive) SetDefaults() {
+	k.Idle = ptypes.Duration(DefaultTCPKeepAlivePeriod)
+	k.Interval = ptypes.Duration(DefaultTCPKeepAlivePeriod)
+}
+
+// UnixSocketConfig is the configuration of the unix domain socket of an entry point.
+type UnixSocketConfig struct {
+	Mode  string `description:"File mode of the socket, in octal notation (e.g. 0660)." json:"mode,omitempty" toml:"mode,omitempty" yaml:"mode,omitempty" export:"true"`
+	Owner string `description:"User owning the socket, as a name or a numeric ID." json:"owner,omitempty" toml:"owner,omitempty" yaml:"owner,omitempty" export:"true"`
+	Group string `description:"Group owning the socket, as a name or a numeric ID." json:"group,omitempty" toml:"group,omitempty" yaml:"group,omitempty" export:"true"`
+}
+
+// GetMode returns the file mode of the socket, or zero if it is not set.
+func (u *UnixSocketConfig) GetMode() (os.FileMode, error) {
+	if u == nil || u.Mode == "" {
+		return 0, nil
+	}
+
+	mode, err := strconv.ParseUint(u.Mode, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, fmt.Errorf("invalid unix socket mode %q: must be an octal permission value", u.Mode)
+	}
+
+	return os.FileMode(mode), nil
+}
+
 // HTTPConfig is the HTTP configuration of an entry point.
 type HTTPConfig struct {
-	Redirections          *Redirections `description:"Set of redirection" json:"redirections,omitempty" toml:"redirections,omitempty" yaml:"redirections,omitempty" export:"true"`
-	Middlewares           []string      `description:"Default middlewares for the routers linked to the entry point." json:"middlewares,omitempty" toml:"middlewares,omitempty" yaml:"middlewares,omitempty" export:"true"`
-	TLS                   *TLSConfig    `description:"Default TLS configuration for the routers linked to the entry point." json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
-	EncodeQuerySemicolons bool          `description:"Defines whether request query semicolons should be URLEncoded." json:"encodeQuerySemicolons,omitempty" toml:"encodeQuerySemicolons,omitempty" yaml:"encodeQuerySemicolons,omitempty"`
+	Redirections          *Redirections      `description:"Set of redirection" json:"redirections,omitempty" toml:"redirections,omitempty" yaml:"redirections,omitempty" export:"true"`
+	Middlewares           []string           `description:"Default middlewares for the routers linked to the entry point." json:"middlewares,omitempty" toml:"middlewares,omitempty" yaml:"middlewares,omitempty" export:"true"`
+	TLS                   *TLSConfig         `description:"Default TLS configuration for the routers linked to the entry point." json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
+	EncodeQuerySemicolons bool               `description:"Defines whether request query semicolons should be URLEncoded." json:"encodeQuerySemicolons,omitempty" toml:"encodeQuerySemicolons,omitempty" yaml:"encodeQuerySemicolons,omitempty"`
+	Plugins               []EntryPointPlugin `description:"Plugin middlewares for the routers linked to the entry point, in order." json:"plugins,omitempty" toml:"plugins,omitempty" yaml:"plugins,omitempty"`
+}
+
+// EntryPointPlugin is a plugin middleware for the routers linked to an entry point.
+type EntryPointPlugin struct {
+	Name   string     `description:"Plugin name, as declared in the plugins configuration." json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty"`
+	Config PluginConf `description:"Plugin configuration." json:"config,omitempty" toml:"config,omitempty" yaml:"config,omitempty"`
 }
 
 // HTTP2Config is the HTTP2 configuration of an entry point.
@@ -77,7 +177,22 @@ func (c *HTTP2Config) SetDefaults() {
 
 // HTTP3Config is the HTTP3 configuration of an entry point.
 type HTTP3Config struct {
-	AdvertisedPort int `description:"UDP port to advertise, on which HTTP/3 is available." json:"advertisedPort,omitempty" toml:"advertisedPort,omitempty" yaml:"advertisedPort,omitempty" export:"true"`
+	AdvertisedPort  int             `description:"UDP port to advertise, on which HTTP/3 is available." json:"advertisedPort,omitempty" toml:"advertisedPort,omitempty" yaml:"advertisedPort,omitempty" export:"true"`
+	ZeroRTT         *ZeroRTTConfig  `description:"Enables the 0-RTT early data." json:"zeroRTT,omitempty" toml:"zeroRTT,omitempty" yaml:"zeroRTT,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
+	EnableDatagrams bool            `description:"Enables the HTTP/3 datagrams (RFC 9297)." json:"enableDatagrams,omitempty" toml:"enableDatagrams,omitempty" yaml:"enableDatagrams,omitempty" export:"true"`
+	MaxIdleTimeout  ptypes.Duration `description:"Maximum duration a QUIC connection can stay idle before being closed. If zero, the QUIC default (30s) is used." json:"maxIdleTimeout,omitempty" toml:"maxIdleTimeout,omitempty" yaml:"maxIdleTimeout,omitempty" export:"true"`
+	WebTransport    bool            `description:"Enables the proxying of the WebTransport sessions." json:"webTransport,omitempty" toml:"webTransport,omitempty" yaml:"webTransport,omitempty" export:"true"`
+}
+
+// ZeroRTTConfig is the 0-RTT configuration of an HTTP/3 entry point.
+// As the 0-RTT early data can be replayed by an attacker, it is only accepted for the requests with a replay-safe method.
+type ZeroRTTConfig struct {
+	Methods []string `description:"Methods of the requests accepted in 0-RTT early data, the others are answered with a 425 Too Early response." json:"methods,omitempty" toml:"methods,omitempty" yaml:"methods,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (c *ZeroRTTConfig) SetDefaults() {
+	c.Methods = []string{http.MethodGet, http.MethodHead, http.MethodOptions}
 }
 
 // Redirections is a set of redirection for an entry point.
@@ -115,8 +230,27 @@ type ForwardedHeaders struct {
 
 // ProxyProtocol contains Proxy-Protocol configuration.
 type ProxyProtocol struct {
-	Insecure   bool     `description:"Trust all." json:"insecure,omitempty" toml:"insecure,omitempty" yaml:"insecure,omitempty" export:"true"`
-	TrustedIPs []string `description:"Trust only selected IPs." json:"trustedIPs,omitempty" toml:"trustedIPs,omitempty" yaml:"trustedIPs,omitempty"`
+	Insecure   bool              `description:"Trust all." json:"insecure,omitempty" toml:"insecure,omitempty" yaml:"insecure,omitempty" export:"true"`
+	TrustedIPs []string          `description:"Trust only selected IPs." json:"trustedIPs,omitempty" toml:"trustedIPs,omitempty" yaml:"trustedIPs,omitempty"`
+	TLVHeaders map[string]string `description:"Request headers set from the PROXY protocol TLVs, indexed by TLV type or name." json:"tlvHeaders,omitempty" toml:"tlvHeaders,omitempty" yaml:"tlvHeaders,omitempty" export:"true"`
+}
+
+func (p *ProxyProtocol) validate() error {
+	if p == nil {
+		return nil
+	}
+
+	for tlv, header := range p.TLVHeaders {
+		if _, err := proxyprotocol.NewTLVLookup(tlv); err != nil {
+			return fmt.Errorf("invalid PROXY protocol TLV header: %w", err)
+		}
+
+		if header == "" {
+			return fmt.Errorf("invalid PROXY protocol TLV header: empty header name for TLV %q", tlv)
+		}
+	}
+
+	return nil
 }
 
 // EntryPoints holds the HTTP entry point list.
@@ -1,6 +1,7 @@
 package static
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -42,6 +43,13 @@ func TestEntryPointProtocol(t *testing.T) {
 			expectedProtocol: "udp",
 			expectedError:    false,
 		},
+		{
+			name:             "Unix socket",
+			address:          "unix:/run/traefik/web.sock",
+			expectedAddress:  "/run/traefik/web.sock",
+			expectedProtocol: "tcp",
+			expectedError:    false,
+		},
 
 		{
 			name:          "With invalid protocol",
@@ -65,3 +73,46 @@ func TestEntryPointProtocol(t *testing.T) {
 		})
 	}
 }
+
+func TestUnixSocketConfig_GetMode(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        *UnixSocketConfig
+		expectedMode  os.FileMode
+		expectedError bool
+	}{
+		{
+			name: "No configuration",
+		},
+		{
+			name:   "No mode",
+			config: &UnixSocketConfig{Owner: "traefik"},
+		},
+		{
+			name:         "Octal mode",
+			config:       &UnixSocketConfig{Mode: "0660"},
+			expectedMode: 0o660,
+		},
+		{
+			name:          "Invalid mode",
+			config:        &UnixSocketConfig{Mode: "rw-rw----"},
+			expectedError: true,
+		},
+		{
+			name:          "Mode out of range",
+			config:        &UnixSocketConfig{Mode: "4755"},
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := tt.config.GetMode()
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedMode, mode)
+		})
+	}
+}
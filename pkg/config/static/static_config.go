@@ -51,6 +51,10 @@ const (
 	// DefaultUDPTimeout defines how long to wait by default on an idle session,
 	// before releasing all resources related to that session.
 	DefaultUDPTimeout = 3 * time.Second
+
+	// DefaultTCPKeepAlivePeriod defines the default idle duration and interval of the TCP keep-alive probes
+	// of the connections accepted by the entry points.
+	DefaultTCPKeepAlivePeriod = 3 * time.Minute
 )
 
 // Configuration is the static configuration.
@@ -72,6 +76,8 @@ type Configuration struct {
 
 	HostResolver *types.HostResolverConfig `description:"Enable CNAME Flattening." json:"hostResolver,omitempty" toml:"hostResolver,omitempty" yaml:"hostResolver,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
 
+	GeoIP *GeoIP `description:"GeoIP databases used by the ClientCountry matcher." json:"geoIP,omitempty" toml:"geoIP,omitempty" yaml:"geoIP,omitempty" export:"true"`
+
 	CertificatesResolvers map[string]CertificateResolver `description:"Certificates resolvers configuration." json:"certificatesResolvers,omitempty" toml:"certificatesResolvers,omitempty" yaml:"certificatesResolvers,omitempty" export:"true"`
 
 	Experimental *Experimental `description:"Experimental features." json:"experimental,omitempty" toml:"experimental,omitempty" yaml:"experimental,omitempty" export:"true"`
@@ -83,12 +89,19 @@ type Configuration struct {
 
 // Core configures Traefik core behavior.
 type Core struct {
-	DefaultRuleSyntax string `description:"Defines the rule parser default syntax (v2 or v3)" json:"defaultRuleSyntax,omitempty" toml:"defaultRuleSyntax,omitempty" yaml:"defaultRuleSyntax,omitempty"`
+	DefaultRuleSyntax   string `description:"Defines the rule parser default syntax (v2 or v3)" json:"defaultRuleSyntax,omitempty" toml:"defaultRuleSyntax,omitempty" yaml:"defaultRuleSyntax,omitempty"`
+	DefaultRulePriority string `description:"Defines how the default priority of the HTTP routers is computed from their rule (length or specificity)" json:"defaultRulePriority,omitempty" toml:"defaultRulePriority,omitempty" yaml:"defaultRulePriority,omitempty"`
 }
 
 // SetDefaults sets the default values.
 func (c *Core) SetDefaults() {
 	c.DefaultRuleSyntax = "v3"
+	c.DefaultRulePriority = "length"
+}
+
+// GeoIP holds the GeoIP databases configuration.
+type GeoIP struct {
+	Databases []string `description:"Paths of the GeoIP databases, in the MaxMind DB (MMDB) format." json:"databases,omitempty" toml:"databases,omitempty" yaml:"databases,omitempty"`
 }
 
 // SpiffeClientConfig defines the SPIFFE client configuration.
@@ -340,6 +353,41 @@ func (c *Configuration) ValidateConfiguration() error {
 		default:
 			return fmt.Errorf("unsupported default rule syntax configuration: %q", c.Core.DefaultRuleSyntax)
 		}
+
+		switch c.Core.DefaultRulePriority {
+		case "", "length", "specificity": // NOOP
+		default:
+			return fmt.Errorf("unsupported default rule priority configuration: %q", c.Core.DefaultRulePriority)
+		}
+	}
+
+	for name, entryPoint := range c.EntryPoints {
+		if err := entryPoint.SocketOptions.validate(); err != nil {
+			return fmt.Errorf("entry point %q: %w", name, err)
+		}
+
+		if err := entryPoint.ProxyProtocol.validate(); err != nil {
+			return fmt.Errorf("entry point %q: %w", name, err)
+		}
+
+		if !entryPoint.IsUnixSocket() {
+			if entryPoint.UnixSocket != nil {
+				return fmt.Errorf("entry point %q: the unixSocket options require a unix socket address", name)
+			}
+			continue
+		}
+
+		if entryPoint.GetAddress() == "" {
+			return fmt.Errorf("entry point %q: empty unix socket path", name)
+		}
+
+		if entryPoint.HTTP3 != nil {
+			return fmt.Errorf("entry point %q: HTTP/3 is not supported on a unix socket", name)
+		}
+
+		if _, err := entryPoint.UnixSocket.GetMode(); err != nil {
+			return fmt.Errorf("entry point %q: %w", name, err)
+		}
 	}
 
 	if c.Tracing != nil && c.Tracing.OTLP != nil {
@@ -0,0 +1,201 @@
+// Package geoip resolves the location of IP addresses from GeoIP databases in the MaxMind DB (MMDB) format,
+// as the MaxMind GeoIP2 and GeoLite2, or the IP2Location, ones.
+package geoip
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/safe"
+)
+
+// databases holds the opened databases, by path.
+// A database is shared by the middlewares and routers using it, and kept open for the lifetime of the process,
+// as the middlewares and routers are created again on each configuration change.
+var databases = struct {
+	sync.Mutex
+	byPath map[string]*Database
+}{byPath: make(map[string]*Database)}
+
+// Location is the location of an IP address.
+type Location struct {
+	// CountryCode is the ISO 3166-1 alpha-2 code of the country.
+	CountryCode string
+	// ASN is the number of the autonomous system.
+	ASN uint
+	// ASOrganization is the organization of the autonomous system.
+	ASOrganization string
+}
+
+// record is the GeoIP2 schema of the databases entries.
+type record struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	ASN            uint   `maxminddb:"autonomous_system_number"`
+	ASOrganization string `maxminddb:"autonomous_system_organization"`
+}
+
+// Resolver resolves the location of IP addresses from one or several databases,
+// e.g. a country database and an ASN database.
+type Resolver struct {
+	databases []*Database
+}
+
+// NewResolver creates a Resolver from the databases at the given paths.
+func NewResolver(paths []string) (*Resolver, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("no GeoIP database defined")
+	}
+
+	resolver := &Resolver{}
+	for _, path := range paths {
+		db, err := Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		resolver.databases = append(resolver.databases, db)
+	}
+
+	return resolver, nil
+}
+
+// Lookup returns the location of the given IP address.
+// The fields of the location are the ones of the first database defining them.
+func (r *Resolver) Lookup(ip net.IP) (Location, error) {
+	var location Location
+	for _, db := range r.databases {
+		rec, err := db.lookup(ip)
+		if err != nil {
+			return Location{}, err
+		}
+
+		if location.CountryCode == "" {
+			location.CountryCode = rec.Country.ISOCode
+		}
+		if location.ASN == 0 {
+			location.ASN = rec.ASN
+			location.ASOrganization = rec.ASOrganization
+		}
+	}
+
+	return location, nil
+}
+
+// Database is a GeoIP database, reloaded when its file changes.
+type Database struct {
+	path string
+
+	mu     sync.RWMutex
+	reader *maxminddb.Reader
+}
+
+// Open returns the database at the given path, opened once and shared by all its users.
+func Open(path string) (*Database, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolving GeoIP database path: %w", err)
+	}
+
+	databases.Lock()
+	defer databases.Unlock()
+
+	if db, ok := databases.byPath[path]; ok {
+		return db, nil
+	}
+
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening GeoIP database %s: %w", path, err)
+	}
+
+	db := &Database{path: path, reader: reader}
+	if err = db.watch(); err != nil {
+		_ = reader.Close()
+		return nil, err
+	}
+
+	databases.byPath[path] = db
+
+	return db, nil
+}
+
+func (d *Database) lookup(ip net.IP) (record, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var rec record
+	if err := d.reader.Lookup(ip, &rec); err != nil {
+		return record{}, fmt.Errorf("looking up %s in GeoIP database %s: %w", ip, d.path, err)
+	}
+
+	return rec, nil
+}
+
+// watch reloads the database when its file changes.
+// The directory of the file is watched, as the databases are usually updated by replacing their file.
+func (d *Database) watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating GeoIP database watcher: %w", err)
+	}
+
+	if err = watcher.Add(filepath.Dir(d.path)); err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("watching GeoIP database %s: %w", d.path, err)
+	}
+
+	safe.Go(func() {
+		defer watcher.Close()
+
+		for {
+			select {
+			case evt, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if filepath.Clean(evt.Name) != d.path || !evt.Has(fsnotify.Create|fsnotify.Write) {
+					continue
+				}
+
+				if err := d.reload(); err != nil {
+					log.Error().Err(err).Str("path", d.path).Msg("Error while reloading GeoIP database")
+					continue
+				}
+
+				log.Info().Str("path", d.path).Msg("GeoIP database reloaded")
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				log.Error().Err(err).Str("path", d.path).Msg("Error while watching GeoIP database")
+			}
+		}
+	})
+
+	return nil
+}
+
+func (d *Database) reload() error {
+	reader, err := maxminddb.Open(d.path)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	previous := d.reader
+	d.reader = reader
+	d.mu.Unlock()
+
+	return previous.Close()
+}
@@ -0,0 +1,115 @@
+package geoip
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver_Lookup(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		paths            []string
+		ip               string
+		expectedLocation Location
+	}{
+		{
+			desc:             "country",
+			paths:            []string{"./fixtures/country.mmdb"},
+			ip:               "1.2.3.4",
+			expectedLocation: Location{CountryCode: "FR"},
+		},
+		{
+			desc:             "IPv6 country",
+			paths:            []string{"./fixtures/country.mmdb"},
+			ip:               "2a00:1450::1",
+			expectedLocation: Location{CountryCode: "DE"},
+		},
+		{
+			desc:             "unknown IP",
+			paths:            []string{"./fixtures/country.mmdb"},
+			ip:               "10.0.0.1",
+			expectedLocation: Location{},
+		},
+		{
+			desc:             "country and ASN",
+			paths:            []string{"./fixtures/country.mmdb", "./fixtures/asn.mmdb"},
+			ip:               "1.2.3.4",
+			expectedLocation: Location{CountryCode: "FR", ASN: 64496, ASOrganization: "Example Org"},
+		},
+		{
+			desc:             "ASN only defined for another IP",
+			paths:            []string{"./fixtures/country.mmdb", "./fixtures/asn.mmdb"},
+			ip:               "2.3.4.5",
+			expectedLocation: Location{CountryCode: "US"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			resolver, err := NewResolver(test.paths)
+			require.NoError(t, err)
+
+			location, err := resolver.Lookup(net.ParseIP(test.ip))
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedLocation, location)
+		})
+	}
+}
+
+func TestNewResolver_invalid(t *testing.T) {
+	_, err := NewResolver(nil)
+	assert.Error(t, err)
+
+	_, err = NewResolver([]string{"./fixtures/unknown.mmdb"})
+	assert.Error(t, err)
+}
+
+func TestOpen_shared(t *testing.T) {
+	first, err := Open("./fixtures/country.mmdb")
+	require.NoError(t, err)
+
+	second, err := Open("fixtures/../fixtures/country.mmdb")
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+}
+
+func TestDatabase_reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "country.mmdb")
+	copyFile(t, "./fixtures/country.mmdb", path)
+
+	resolver, err := NewResolver([]string{path})
+	require.NoError(t, err)
+
+	location, err := resolver.Lookup(net.ParseIP("1.2.3.4"))
+	require.NoError(t, err)
+	assert.Equal(t, "FR", location.CountryCode)
+
+	// The database is replaced, as done by the database updaters.
+	tmpPath := filepath.Join(filepath.Dir(path), "country.mmdb.tmp")
+	copyFile(t, "./fixtures/country_updated.mmdb", tmpPath)
+	require.NoError(t, os.Rename(tmpPath, path))
+
+	assert.Eventually(t, func() bool {
+		location, err := resolver.Lookup(net.ParseIP("1.2.3.4"))
+		return err == nil && location.CountryCode == "BE"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(dst, data, 0o600))
+}
@@ -0,0 +1,341 @@
+package ip
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// maxListSize is the maximum size of a list of IPs fetched from a URL.
+	maxListSize = 10 * 1024 * 1024
+
+	// maxFileCheckInterval is the maximum interval between two checks of the list files for changes.
+	maxFileCheckInterval = 5 * time.Second
+)
+
+// DynamicSources defines the sources of trusted IPs loaded at runtime.
+type DynamicSources struct {
+	// URLs are the URLs serving lists of trusted IPs, one IP or CIDR per line.
+	URLs []string
+	// DNSNames are the DNS names resolving to trusted IPs.
+	DNSNames []string
+	// Files are the files holding lists of trusted IPs, one IP or CIDR per line.
+	Files []string
+	// RefreshInterval is the interval between two refreshes of the URLs and DNS names.
+	RefreshInterval time.Duration
+}
+
+// DynamicChecker allows to check that addresses are in trusted IPs loaded at runtime,
+// along with static trusted IPs.
+// The sources are loaded synchronously on the first check, and refreshed in the background on the next ones.
+type DynamicChecker struct {
+	logger   *zerolog.Logger
+	client   *http.Client
+	resolver *net.Resolver
+	static   []string
+	sources  DynamicSources
+
+	checker atomic.Pointer[Checker]
+	// attemptedAt is the time, in Unix nanoseconds, of the last refresh attempt.
+	attemptedAt atomic.Int64
+
+	// mu serializes the refreshes, and guards the fields below.
+	mu             sync.Mutex
+	refreshedAt    time.Time
+	urlLists       map[string]*urlList
+	dnsIPs         map[string][]string
+	fileLists      map[string]*fileList
+	filesCheckedAt time.Time
+}
+
+type urlList struct {
+	etag string
+	ips  []string
+}
+
+type fileList struct {
+	modTime time.Time
+	size    int64
+	ips     []string
+}
+
+// NewDynamicChecker builds a new DynamicChecker given a list of CIDR-Strings to trusted IPs and the dynamic sources.
+func NewDynamicChecker(logger *zerolog.Logger, trustedIPs []string, sources DynamicSources) (*DynamicChecker, error) {
+	if len(trustedIPs) == 0 && len(sources.URLs) == 0 && len(sources.DNSNames) == 0 && len(sources.Files) == 0 {
+		return nil, errors.New("no trusted IPs provided")
+	}
+
+	if _, err := parseList(trustedIPs); err != nil {
+		return nil, err
+	}
+
+	if sources.RefreshInterval <= 0 {
+		return nil, errors.New("refresh interval must be positive")
+	}
+
+	return &DynamicChecker{
+		logger:    logger,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		resolver:  net.DefaultResolver,
+		static:    trustedIPs,
+		sources:   sources,
+		urlLists:  make(map[string]*urlList),
+		dnsIPs:    make(map[string][]string),
+		fileLists: make(map[string]*fileList),
+	}, nil
+}
+
+// IsAuthorized checks if provided request is authorized by the trusted IPs.
+func (d *DynamicChecker) IsAuthorized(addr string) error {
+	checker := d.current()
+	if checker == nil {
+		return errors.New("no trusted IPs loaded")
+	}
+
+	return checker.IsAuthorized(addr)
+}
+
+// current returns the checker built from the last loaded sources.
+func (d *DynamicChecker) current() *Checker {
+	checker := d.checker.Load()
+	if checker != nil {
+		if d.isStale() && d.mu.TryLock() {
+			go func() {
+				defer d.mu.Unlock()
+
+				d.refresh(context.Background())
+			}()
+		}
+
+		return checker
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if checker = d.checker.Load(); checker != nil {
+		return checker
+	}
+
+	// The failed loads are not attempted again before the check interval,
+	// so that the requests cannot flood the sources.
+	if d.attemptedAt.Load() != 0 && !d.isStale() {
+		return nil
+	}
+
+	d.refresh(context.Background())
+
+	return d.checker.Load()
+}
+
+func (d *DynamicChecker) isStale() bool {
+	return time.Since(time.Unix(0, d.attemptedAt.Load())) >= d.checkInterval()
+}
+
+// checkInterval returns the interval between two checks of the sources.
+func (d *DynamicChecker) checkInterval() time.Duration {
+	if len(d.sources.Files) > 0 {
+		return min(d.sources.RefreshInterval, maxFileCheckInterval)
+	}
+
+	return d.sources.RefreshInterval
+}
+
+// refresh reloads the stale sources and rebuilds the checker, it must be called with the lock held.
+// The sources failing to load keep their previous trusted IPs.
+func (d *DynamicChecker) refresh(ctx context.Context) {
+	now := time.Now()
+	d.attemptedAt.Store(now.UnixNano())
+
+	if d.refreshedAt.IsZero() || now.Sub(d.refreshedAt) >= d.sources.RefreshInterval {
+		d.refreshedAt = now
+
+		for _, u := range d.sources.URLs {
+			if err := d.fetchURL(ctx, u); err != nil {
+				d.logger.Error().Err(err).Str("url", u).Msg("Error while fetching the trusted IPs")
+			}
+		}
+
+		for _, name := range d.sources.DNSNames {
+			if err := d.resolveDNSName(ctx, name); err != nil {
+				d.logger.Error().Err(err).Str("dnsName", name).Msg("Error while resolving the trusted IPs")
+			}
+		}
+	}
+
+	if d.filesCheckedAt.IsZero() || now.Sub(d.filesCheckedAt) >= d.checkInterval() {
+		d.filesCheckedAt = now
+
+		for _, path := range d.sources.Files {
+			if err := d.readFile(path); err != nil {
+				d.logger.Error().Err(err).Str("file", path).Msg("Error while reading the trusted IPs")
+			}
+		}
+	}
+
+	trustedIPs := append([]string{}, d.static...)
+	for _, list := range d.urlLists {
+		trustedIPs = append(trustedIPs, list.ips...)
+	}
+	for _, ips := range d.dnsIPs {
+		trustedIPs = append(trustedIPs, ips...)
+	}
+	for _, list := range d.fileLists {
+		trustedIPs = append(trustedIPs, list.ips...)
+	}
+
+	if len(trustedIPs) == 0 {
+		d.checker.Store(nil)
+		return
+	}
+
+	checker, err := NewChecker(trustedIPs)
+	if err != nil {
+		// The trusted IPs are validated when they are loaded.
+		d.logger.Error().Err(err).Msg("Error while building the trusted IPs checker")
+		return
+	}
+
+	d.checker.Store(checker)
+}
+
+func (d *DynamicChecker) fetchURL(ctx context.Context, u string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	previous := d.urlLists[u]
+	if previous != nil && previous.etag != "" {
+		req.Header.Set("If-None-Match", previous.etag)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetching list: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		if previous != nil {
+			return nil
+		}
+
+		return errors.New("fetching list: not modified while no list was fetched")
+	default:
+		return fmt.Errorf("fetching list: unexpected status code %d", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxListSize+1))
+	if err != nil {
+		return fmt.Errorf("reading list: %w", err)
+	}
+
+	if len(content) > maxListSize {
+		return fmt.Errorf("list size exceeds the limit of %d bytes", maxListSize)
+	}
+
+	ips, err := parseContent(content)
+	if err != nil {
+		return err
+	}
+
+	d.urlLists[u] = &urlList{etag: resp.Header.Get("ETag"), ips: ips}
+
+	return nil
+}
+
+func (d *DynamicChecker) resolveDNSName(ctx context.Context, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	addrs, err := d.resolver.LookupIPAddr(ctx, name)
+	if err != nil {
+		return fmt.Errorf("resolving DNS name: %w", err)
+	}
+
+	ips := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP.String())
+	}
+
+	d.dnsIPs[name] = ips
+
+	return nil
+}
+
+func (d *DynamicChecker) readFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("reading file info: %w", err)
+	}
+
+	previous := d.fileLists[path]
+	if previous != nil && previous.modTime.Equal(info.ModTime()) && previous.size == info.Size() {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+
+	ips, err := parseContent(content)
+	if err != nil {
+		return err
+	}
+
+	d.fileLists[path] = &fileList{modTime: info.ModTime(), size: info.Size(), ips: ips}
+
+	return nil
+}
+
+// parseContent parses a list of IPs, one IP or CIDR per line.
+// The empty lines, and the comments starting with a `#`, are ignored.
+func parseContent(content []byte) ([]string, error) {
+	var entries []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			entries = append(entries, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading list: %w", err)
+	}
+
+	return parseList(entries)
+}
+
+// parseList checks that the given entries are IPs or CIDRs.
+func parseList(entries []string) ([]string, error) {
+	for _, entry := range entries {
+		if net.ParseIP(entry) != nil {
+			continue
+		}
+
+		if _, _, err := net.ParseCIDR(entry); err != nil {
+			return nil, fmt.Errorf("parsing trusted IP %q: %w", entry, err)
+		}
+	}
+
+	return entries, nil
+}
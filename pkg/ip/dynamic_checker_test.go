@@ -0,0 +1,153 @@
+package ip
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var logger = zerolog.Nop()
+
+func TestNewDynamicChecker(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		trustedIPs []string
+		sources    DynamicSources
+	}{
+		{
+			desc:    "no trusted IPs",
+			sources: DynamicSources{RefreshInterval: time.Minute},
+		},
+		{
+			desc:       "invalid static IP",
+			trustedIPs: []string{"10.0.0.1/33"},
+			sources:    DynamicSources{DNSNames: []string{"localhost"}, RefreshInterval: time.Minute},
+		},
+		{
+			desc:    "no refresh interval",
+			sources: DynamicSources{DNSNames: []string{"localhost"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewDynamicChecker(&logger, test.trustedIPs, test.sources)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDynamicChecker_URL(t *testing.T) {
+	var mu sync.Mutex
+	list := "# Office\n10.0.0.0/24\n\n192.168.1.1 # VPN\n"
+	etag := `"v1"`
+
+	var notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if req.Header.Get("If-None-Match") == etag {
+			notModified.Add(1)
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		rw.Header().Set("ETag", etag)
+		_, _ = rw.Write([]byte(list))
+	}))
+	t.Cleanup(server.Close)
+
+	checker, err := NewDynamicChecker(&logger, []string{"172.16.0.1"}, DynamicSources{
+		URLs:            []string{server.URL},
+		RefreshInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, checker.IsAuthorized("10.0.0.10:1234"))
+	require.NoError(t, checker.IsAuthorized("192.168.1.1:1234"))
+	require.NoError(t, checker.IsAuthorized("172.16.0.1:1234"))
+	require.Error(t, checker.IsAuthorized("10.0.1.10:1234"))
+
+	assert.Eventually(t, func() bool {
+		_ = checker.IsAuthorized("10.0.0.10:1234")
+		return notModified.Load() > 0
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, checker.IsAuthorized("10.0.0.10:1234"))
+
+	mu.Lock()
+	list = "10.0.1.0/24\n"
+	etag = `"v2"`
+	mu.Unlock()
+
+	assert.Eventually(t, func() bool {
+		return checker.IsAuthorized("10.0.1.10:1234") == nil && checker.IsAuthorized("10.0.0.10:1234") != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// An invalid list keeps the previous one.
+	mu.Lock()
+	list = "not an IP\n"
+	etag = `"v3"`
+	mu.Unlock()
+
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, checker.IsAuthorized("10.0.1.10:1234"))
+}
+
+func TestDynamicChecker_file(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "allowlist.txt")
+	require.NoError(t, os.WriteFile(path, []byte("10.0.0.1\n"), 0o600))
+
+	checker, err := NewDynamicChecker(&logger, nil, DynamicSources{
+		Files:           []string{path},
+		RefreshInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, checker.IsAuthorized("10.0.0.1:1234"))
+	require.Error(t, checker.IsAuthorized("10.0.0.2:1234"))
+
+	require.NoError(t, os.WriteFile(path, []byte("10.0.0.2\n10.0.0.3\n"), 0o600))
+
+	assert.Eventually(t, func() bool {
+		return checker.IsAuthorized("10.0.0.2:1234") == nil && checker.IsAuthorized("10.0.0.1:1234") != nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDynamicChecker_DNSName(t *testing.T) {
+	checker, err := NewDynamicChecker(&logger, nil, DynamicSources{
+		DNSNames:        []string{"localhost"},
+		RefreshInterval: time.Minute,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, checker.IsAuthorized("127.0.0.1:1234"))
+	require.Error(t, checker.IsAuthorized("10.0.0.1:1234"))
+}
+
+func TestDynamicChecker_unavailableSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	checker, err := NewDynamicChecker(&logger, nil, DynamicSources{
+		URLs:            []string{server.URL},
+		RefreshInterval: time.Minute,
+	})
+	require.NoError(t, err)
+
+	assert.Error(t, checker.IsAuthorized("10.0.0.1:1234"))
+}
@@ -18,6 +18,8 @@ type Registry interface {
 	IsRouterEnabled() bool
 	// IsSvcEnabled shows whether metrics instrumentation is enabled on services.
 	IsSvcEnabled() bool
+	// IsPluginEnabled shows whether metrics instrumentation is enabled on plugins.
+	IsPluginEnabled() bool
 
 	// server metrics
 
@@ -54,6 +56,58 @@ type Registry interface {
 	ServiceServerUpGauge() metrics.Gauge
 	ServiceReqsBytesCounter() metrics.Counter
 	ServiceRespsBytesCounter() metrics.Counter
+
+	// plugin metrics
+
+	PluginReqsCounter() metrics.Counter
+	PluginErrorsCounter() metrics.Counter
+	PluginPanicsCounter() metrics.Counter
+	PluginReqDurationHistogram() ScalableHistogram
+
+	// bot metrics
+
+	BotReqsCounter() metrics.Counter
+
+	// CORS metrics
+
+	CORSReqsCounter() metrics.Counter
+
+	// GraphQL metrics
+
+	GraphQLReqsCounter() metrics.Counter
+
+	// WebSocket metrics
+
+	WebSocketConnsGauge() metrics.Gauge
+
+	// WebTransport metrics
+
+	WebTransportSessionsGauge() metrics.Gauge
+	WebTransportStreamsCounter() metrics.Counter
+
+	// apiKeyAuth metrics
+
+	APIKeyReqsCounter() metrics.Counter
+
+	// inFlightReq metrics
+
+	InFlightReqQueueDepthGauge() metrics.Gauge
+	InFlightReqQueueReqsCounter() metrics.Counter
+
+	// circuitBreaker metrics
+
+	CircuitBreakerStateGauge() metrics.Gauge
+	CircuitBreakerTripsCounter() metrics.Counter
+	CircuitBreakerEvaluationsCounter() metrics.Counter
+
+	// concurrencyQuota metrics
+
+	ConcurrencyQuotaInFlightGauge() metrics.Gauge
+	ConcurrencyQuotaReqsCounter() metrics.Counter
+
+	// mirroring metrics
+
+	MirrorReqsCounter() metrics.Counter
 }
 
 // NewVoidRegistry is a noop implementation of metrics.Registry.
@@ -87,6 +141,25 @@ func NewMultiRegistry(registries []Registry) Registry {
 	var serviceServerUpGauge []metrics.Gauge
 	var serviceReqsBytesCounter []metrics.Counter
 	var serviceRespsBytesCounter []metrics.Counter
+	var pluginReqsCounter []metrics.Counter
+	var pluginErrorsCounter []metrics.Counter
+	var pluginPanicsCounter []metrics.Counter
+	var pluginReqDurationHistogram []ScalableHistogram
+	var botReqsCounter []metrics.Counter
+	var corsReqsCounter []metrics.Counter
+	var graphQLReqsCounter []metrics.Counter
+	var webSocketConnsGauge []metrics.Gauge
+	var webTransportSessionsGauge []metrics.Gauge
+	var webTransportStreamsCounter []metrics.Counter
+	var apiKeyReqsCounter []metrics.Counter
+	var inFlightReqQueueDepthGauge []metrics.Gauge
+	var inFlightReqQueueReqsCounter []metrics.Counter
+	var circuitBreakerStateGauge []metrics.Gauge
+	var circuitBreakerTripsCounter []metrics.Counter
+	var circuitBreakerEvaluationsCounter []metrics.Counter
+	var concurrencyQuotaInFlightGauge []metrics.Gauge
+	var concurrencyQuotaReqsCounter []metrics.Counter
+	var mirrorReqsCounter []metrics.Counter
 
 	for _, r := range registries {
 		if r.ConfigReloadsCounter() != nil {
@@ -152,61 +225,158 @@ func NewMultiRegistry(registries []Registry) Registry {
 		if r.ServiceRespsBytesCounter() != nil {
 			serviceRespsBytesCounter = append(serviceRespsBytesCounter, r.ServiceRespsBytesCounter())
 		}
+		if r.PluginReqsCounter() != nil {
+			pluginReqsCounter = append(pluginReqsCounter, r.PluginReqsCounter())
+		}
+		if r.PluginErrorsCounter() != nil {
+			pluginErrorsCounter = append(pluginErrorsCounter, r.PluginErrorsCounter())
+		}
+		if r.PluginPanicsCounter() != nil {
+			pluginPanicsCounter = append(pluginPanicsCounter, r.PluginPanicsCounter())
+		}
+		if r.PluginReqDurationHistogram() != nil {
+			pluginReqDurationHistogram = append(pluginReqDurationHistogram, r.PluginReqDurationHistogram())
+		}
+		if r.BotReqsCounter() != nil {
+			botReqsCounter = append(botReqsCounter, r.BotReqsCounter())
+		}
+		if r.CORSReqsCounter() != nil {
+			corsReqsCounter = append(corsReqsCounter, r.CORSReqsCounter())
+		}
+		if r.GraphQLReqsCounter() != nil {
+			graphQLReqsCounter = append(graphQLReqsCounter, r.GraphQLReqsCounter())
+		}
+		if r.WebSocketConnsGauge() != nil {
+			webSocketConnsGauge = append(webSocketConnsGauge, r.WebSocketConnsGauge())
+		}
+		if r.WebTransportSessionsGauge() != nil {
+			webTransportSessionsGauge = append(webTransportSessionsGauge, r.WebTransportSessionsGauge())
+		}
+		if r.WebTransportStreamsCounter() != nil {
+			webTransportStreamsCounter = append(webTransportStreamsCounter, r.WebTransportStreamsCounter())
+		}
+		if r.APIKeyReqsCounter() != nil {
+			apiKeyReqsCounter = append(apiKeyReqsCounter, r.APIKeyReqsCounter())
+		}
+		if r.InFlightReqQueueDepthGauge() != nil {
+			inFlightReqQueueDepthGauge = append(inFlightReqQueueDepthGauge, r.InFlightReqQueueDepthGauge())
+		}
+		if r.InFlightReqQueueReqsCounter() != nil {
+			inFlightReqQueueReqsCounter = append(inFlightReqQueueReqsCounter, r.InFlightReqQueueReqsCounter())
+		}
+		if r.CircuitBreakerStateGauge() != nil {
+			circuitBreakerStateGauge = append(circuitBreakerStateGauge, r.CircuitBreakerStateGauge())
+		}
+		if r.CircuitBreakerTripsCounter() != nil {
+			circuitBreakerTripsCounter = append(circuitBreakerTripsCounter, r.CircuitBreakerTripsCounter())
+		}
+		if r.CircuitBreakerEvaluationsCounter() != nil {
+			circuitBreakerEvaluationsCounter = append(circuitBreakerEvaluationsCounter, r.CircuitBreakerEvaluationsCounter())
+		}
+		if r.ConcurrencyQuotaInFlightGauge() != nil {
+			concurrencyQuotaInFlightGauge = append(concurrencyQuotaInFlightGauge, r.ConcurrencyQuotaInFlightGauge())
+		}
+		if r.ConcurrencyQuotaReqsCounter() != nil {
+			concurrencyQuotaReqsCounter = append(concurrencyQuotaReqsCounter, r.ConcurrencyQuotaReqsCounter())
+		}
+		if r.MirrorReqsCounter() != nil {
+			mirrorReqsCounter = append(mirrorReqsCounter, r.MirrorReqsCounter())
+		}
 	}
 
 	return &standardRegistry{
-		epEnabled:                      len(entryPointReqsCounter) > 0 || len(entryPointReqDurationHistogram) > 0,
-		svcEnabled:                     len(serviceReqsCounter) > 0 || len(serviceReqDurationHistogram) > 0 || len(serviceRetriesCounter) > 0 || len(serviceServerUpGauge) > 0,
-		routerEnabled:                  len(routerReqsCounter) > 0 || len(routerReqDurationHistogram) > 0,
-		configReloadsCounter:           multi.NewCounter(configReloadsCounter...),
-		lastConfigReloadSuccessGauge:   multi.NewGauge(lastConfigReloadSuccessGauge...),
-		openConnectionsGauge:           multi.NewGauge(openConnectionsGauge...),
-		tlsCertsNotAfterTimestampGauge: multi.NewGauge(tlsCertsNotAfterTimestampGauge...),
-		entryPointReqsCounter:          NewMultiCounterWithHeaders(entryPointReqsCounter...),
-		entryPointReqsTLSCounter:       multi.NewCounter(entryPointReqsTLSCounter...),
-		entryPointReqDurationHistogram: MultiHistogram(entryPointReqDurationHistogram),
-		entryPointReqsBytesCounter:     multi.NewCounter(entryPointReqsBytesCounter...),
-		entryPointRespsBytesCounter:    multi.NewCounter(entryPointRespsBytesCounter...),
-		routerReqsCounter:              NewMultiCounterWithHeaders(routerReqsCounter...),
-		routerReqsTLSCounter:           multi.NewCounter(routerReqsTLSCounter...),
-		routerReqDurationHistogram:     MultiHistogram(routerReqDurationHistogram),
-		routerReqsBytesCounter:         multi.NewCounter(routerReqsBytesCounter...),
-		routerRespsBytesCounter:        multi.NewCounter(routerRespsBytesCounter...),
-		serviceReqsCounter:             NewMultiCounterWithHeaders(serviceReqsCounter...),
-		serviceReqsTLSCounter:          multi.NewCounter(serviceReqsTLSCounter...),
-		serviceReqDurationHistogram:    MultiHistogram(serviceReqDurationHistogram),
-		serviceRetriesCounter:          multi.NewCounter(serviceRetriesCounter...),
-		serviceServerUpGauge:           multi.NewGauge(serviceServerUpGauge...),
-		serviceReqsBytesCounter:        multi.NewCounter(serviceReqsBytesCounter...),
-		serviceRespsBytesCounter:       multi.NewCounter(serviceRespsBytesCounter...),
+		epEnabled:                        len(entryPointReqsCounter) > 0 || len(entryPointReqDurationHistogram) > 0,
+		svcEnabled:                       len(serviceReqsCounter) > 0 || len(serviceReqDurationHistogram) > 0 || len(serviceRetriesCounter) > 0 || len(serviceServerUpGauge) > 0,
+		routerEnabled:                    len(routerReqsCounter) > 0 || len(routerReqDurationHistogram) > 0,
+		pluginEnabled:                    len(pluginReqsCounter) > 0 || len(pluginReqDurationHistogram) > 0,
+		configReloadsCounter:             multi.NewCounter(configReloadsCounter...),
+		lastConfigReloadSuccessGauge:     multi.NewGauge(lastConfigReloadSuccessGauge...),
+		openConnectionsGauge:             multi.NewGauge(openConnectionsGauge...),
+		tlsCertsNotAfterTimestampGauge:   multi.NewGauge(tlsCertsNotAfterTimestampGauge...),
+		entryPointReqsCounter:            NewMultiCounterWithHeaders(entryPointReqsCounter...),
+		entryPointReqsTLSCounter:         multi.NewCounter(entryPointReqsTLSCounter...),
+		entryPointReqDurationHistogram:   MultiHistogram(entryPointReqDurationHistogram),
+		entryPointReqsBytesCounter:       multi.NewCounter(entryPointReqsBytesCounter...),
+		entryPointRespsBytesCounter:      multi.NewCounter(entryPointRespsBytesCounter...),
+		routerReqsCounter:                NewMultiCounterWithHeaders(routerReqsCounter...),
+		routerReqsTLSCounter:             multi.NewCounter(routerReqsTLSCounter...),
+		routerReqDurationHistogram:       MultiHistogram(routerReqDurationHistogram),
+		routerReqsBytesCounter:           multi.NewCounter(routerReqsBytesCounter...),
+		routerRespsBytesCounter:          multi.NewCounter(routerRespsBytesCounter...),
+		serviceReqsCounter:               NewMultiCounterWithHeaders(serviceReqsCounter...),
+		serviceReqsTLSCounter:            multi.NewCounter(serviceReqsTLSCounter...),
+		serviceReqDurationHistogram:      MultiHistogram(serviceReqDurationHistogram),
+		serviceRetriesCounter:            multi.NewCounter(serviceRetriesCounter...),
+		serviceServerUpGauge:             multi.NewGauge(serviceServerUpGauge...),
+		serviceReqsBytesCounter:          multi.NewCounter(serviceReqsBytesCounter...),
+		serviceRespsBytesCounter:         multi.NewCounter(serviceRespsBytesCounter...),
+		pluginReqsCounter:                multi.NewCounter(pluginReqsCounter...),
+		pluginErrorsCounter:              multi.NewCounter(pluginErrorsCounter...),
+		pluginPanicsCounter:              multi.NewCounter(pluginPanicsCounter...),
+		pluginReqDurationHistogram:       MultiHistogram(pluginReqDurationHistogram),
+		botReqsCounter:                   multi.NewCounter(botReqsCounter...),
+		corsReqsCounter:                  multi.NewCounter(corsReqsCounter...),
+		graphQLReqsCounter:               multi.NewCounter(graphQLReqsCounter...),
+		webSocketConnsGauge:              multi.NewGauge(webSocketConnsGauge...),
+		webTransportSessionsGauge:        multi.NewGauge(webTransportSessionsGauge...),
+		webTransportStreamsCounter:       multi.NewCounter(webTransportStreamsCounter...),
+		apiKeyReqsCounter:                multi.NewCounter(apiKeyReqsCounter...),
+		inFlightReqQueueDepthGauge:       multi.NewGauge(inFlightReqQueueDepthGauge...),
+		inFlightReqQueueReqsCounter:      multi.NewCounter(inFlightReqQueueReqsCounter...),
+		circuitBreakerStateGauge:         multi.NewGauge(circuitBreakerStateGauge...),
+		circuitBreakerTripsCounter:       multi.NewCounter(circuitBreakerTripsCounter...),
+		circuitBreakerEvaluationsCounter: multi.NewCounter(circuitBreakerEvaluationsCounter...),
+		concurrencyQuotaInFlightGauge:    multi.NewGauge(concurrencyQuotaInFlightGauge...),
+		concurrencyQuotaReqsCounter:      multi.NewCounter(concurrencyQuotaReqsCounter...),
+		mirrorReqsCounter:                multi.NewCounter(mirrorReqsCounter...),
 	}
 }
 
 type standardRegistry struct {
-	epEnabled                      bool
-	routerEnabled                  bool
-	svcEnabled                     bool
-	configReloadsCounter           metrics.Counter
-	lastConfigReloadSuccessGauge   metrics.Gauge
-	openConnectionsGauge           metrics.Gauge
-	tlsCertsNotAfterTimestampGauge metrics.Gauge
-	entryPointReqsCounter          CounterWithHeaders
-	entryPointReqsTLSCounter       metrics.Counter
-	entryPointReqDurationHistogram ScalableHistogram
-	entryPointReqsBytesCounter     metrics.Counter
-	entryPointRespsBytesCounter    metrics.Counter
-	routerReqsCounter              CounterWithHeaders
-	routerReqsTLSCounter           metrics.Counter
-	routerReqDurationHistogram     ScalableHistogram
-	routerReqsBytesCounter         metrics.Counter
-	routerRespsBytesCounter        metrics.Counter
-	serviceReqsCounter             CounterWithHeaders
-	serviceReqsTLSCounter          metrics.Counter
-	serviceReqDurationHistogram    ScalableHistogram
-	serviceRetriesCounter          metrics.Counter
-	serviceServerUpGauge           metrics.Gauge
-	serviceReqsBytesCounter        metrics.Counter
-	serviceRespsBytesCounter       metrics.Counter
+	epEnabled                        bool
+	routerEnabled                    bool
+	svcEnabled                       bool
+	pluginEnabled                    bool
+	configReloadsCounter             metrics.Counter
+	lastConfigReloadSuccessGauge     metrics.Gauge
+	openConnectionsGauge             metrics.Gauge
+	tlsCertsNotAfterTimestampGauge   metrics.Gauge
+	entryPointReqsCounter            CounterWithHeaders
+	entryPointReqsTLSCounter         metrics.Counter
+	entryPointReqDurationHistogram   ScalableHistogram
+	entryPointReqsBytesCounter       metrics.Counter
+	entryPointRespsBytesCounter      metrics.Counter
+	routerReqsCounter                CounterWithHeaders
+	routerReqsTLSCounter             metrics.Counter
+	routerReqDurationHistogram       ScalableHistogram
+	routerReqsBytesCounter           metrics.Counter
+	routerRespsBytesCounter          metrics.Counter
+	serviceReqsCounter               CounterWithHeaders
+	serviceReqsTLSCounter            metrics.Counter
+	serviceReqDurationHistogram      ScalableHistogram
+	serviceRetriesCounter            metrics.Counter
+	serviceServerUpGauge             metrics.Gauge
+	serviceReqsBytesCounter          metrics.Counter
+	serviceRespsBytesCounter         metrics.Counter
+	pluginReqsCounter                metrics.Counter
+	pluginErrorsCounter              metrics.Counter
+	pluginPanicsCounter              metrics.Counter
+	pluginReqDurationHistogram       ScalableHistogram
+	botReqsCounter                   metrics.Counter
+	corsReqsCounter                  metrics.Counter
+	graphQLReqsCounter               metrics.Counter
+	webSocketConnsGauge              metrics.Gauge
+	webTransportSessionsGauge        metrics.Gauge
+	webTransportStreamsCounter       metrics.Counter
+	apiKeyReqsCounter                metrics.Counter
+	inFlightReqQueueDepthGauge       metrics.Gauge
+	inFlightReqQueueReqsCounter      metrics.Counter
+	circuitBreakerStateGauge         metrics.Gauge
+	circuitBreakerTripsCounter       metrics.Counter
+	circuitBreakerEvaluationsCounter metrics.Counter
+	concurrencyQuotaInFlightGauge    metrics.Gauge
+	concurrencyQuotaReqsCounter      metrics.Counter
+	mirrorReqsCounter                metrics.Counter
 }
 
 func (r *standardRegistry) IsEpEnabled() bool {
@@ -221,6 +391,10 @@ func (r *standardRegistry) IsSvcEnabled() bool {
 	return r.svcEnabled
 }
 
+func (r *standardRegistry) IsPluginEnabled() bool {
+	return r.pluginEnabled
+}
+
 func (r *standardRegistry) ConfigReloadsCounter() metrics.Counter {
 	return r.configReloadsCounter
 }
@@ -305,6 +479,82 @@ func (r *standardRegistry) ServiceRespsBytesCounter() metrics.Counter {
 	return r.serviceRespsBytesCounter
 }
 
+func (r *standardRegistry) PluginReqsCounter() metrics.Counter {
+	return r.pluginReqsCounter
+}
+
+func (r *standardRegistry) PluginErrorsCounter() metrics.Counter {
+	return r.pluginErrorsCounter
+}
+
+func (r *standardRegistry) PluginPanicsCounter() metrics.Counter {
+	return r.pluginPanicsCounter
+}
+
+func (r *standardRegistry) PluginReqDurationHistogram() ScalableHistogram {
+	return r.pluginReqDurationHistogram
+}
+
+func (r *standardRegistry) BotReqsCounter() metrics.Counter {
+	return r.botReqsCounter
+}
+
+func (r *standardRegistry) CORSReqsCounter() metrics.Counter {
+	return r.corsReqsCounter
+}
+
+func (r *standardRegistry) GraphQLReqsCounter() metrics.Counter {
+	return r.graphQLReqsCounter
+}
+
+func (r *standardRegistry) WebSocketConnsGauge() metrics.Gauge {
+	return r.webSocketConnsGauge
+}
+
+func (r *standardRegistry) WebTransportSessionsGauge() metrics.Gauge {
+	return r.webTransportSessionsGauge
+}
+
+func (r *standardRegistry) WebTransportStreamsCounter() metrics.Counter {
+	return r.webTransportStreamsCounter
+}
+
+func (r *standardRegistry) APIKeyReqsCounter() metrics.Counter {
+	return r.apiKeyReqsCounter
+}
+
+func (r *standardRegistry) InFlightReqQueueDepthGauge() metrics.Gauge {
+	return r.inFlightReqQueueDepthGauge
+}
+
+func (r *standardRegistry) InFlightReqQueueReqsCounter() metrics.Counter {
+	return r.inFlightReqQueueReqsCounter
+}
+
+func (r *standardRegistry) CircuitBreakerStateGauge() metrics.Gauge {
+	return r.circuitBreakerStateGauge
+}
+
+func (r *standardRegistry) CircuitBreakerTripsCounter() metrics.Counter {
+	return r.circuitBreakerTripsCounter
+}
+
+func (r *standardRegistry) CircuitBreakerEvaluationsCounter() metrics.Counter {
+	return r.circuitBreakerEvaluationsCounter
+}
+
+func (r *standardRegistry) ConcurrencyQuotaInFlightGauge() metrics.Gauge {
+	return r.concurrencyQuotaInFlightGauge
+}
+
+func (r *standardRegistry) ConcurrencyQuotaReqsCounter() metrics.Counter {
+	return r.concurrencyQuotaReqsCounter
+}
+
+func (r *standardRegistry) MirrorReqsCounter() metrics.Counter {
+	return r.mirrorReqsCounter
+}
+
 // ScalableHistogram is a Histogram with a predefined time unit,
 // used when producing observations without explicitly setting the observed value.
 type ScalableHistogram interface {
@@ -55,6 +55,58 @@ const (
 	serviceServerUpName        = metricServicePrefix + "server_up"
 	serviceReqsBytesTotalName  = metricServicePrefix + "requests_bytes_total"
 	serviceRespsBytesTotalName = metricServicePrefix + "responses_bytes_total"
+
+	// plugin level.
+	metricPluginPrefix    = MetricNamePrefix + "plugin_"
+	pluginReqsTotalName   = metricPluginPrefix + "requests_total"
+	pluginErrorsTotalName = metricPluginPrefix + "errors_total"
+	pluginPanicsTotalName = metricPluginPrefix + "panics_total"
+	pluginReqDurationName = metricPluginPrefix + "request_duration_seconds"
+
+	// bot level.
+	metricBotPrefix  = MetricNamePrefix + "bot_"
+	botReqsTotalName = metricBotPrefix + "requests_total"
+
+	// cors level.
+	metricCORSPrefix  = MetricNamePrefix + "cors_"
+	corsReqsTotalName = metricCORSPrefix + "requests_total"
+
+	// graphQL level.
+	metricGraphQLPrefix  = MetricNamePrefix + "graphql_"
+	graphQLReqsTotalName = metricGraphQLPrefix + "requests_total"
+
+	// webSocket level.
+	metricWebSocketPrefix = MetricNamePrefix + "websocket_"
+	webSocketConnsName    = metricWebSocketPrefix + "connections"
+
+	// webTransport level.
+	metricWebTransportPrefix     = MetricNamePrefix + "webtransport_"
+	webTransportSessionsName     = metricWebTransportPrefix + "sessions"
+	webTransportStreamsTotalName = metricWebTransportPrefix + "streams_total"
+
+	// apiKeyAuth level.
+	metricAPIKeyPrefix  = MetricNamePrefix + "apikey_"
+	apiKeyReqsTotalName = metricAPIKeyPrefix + "requests_total"
+
+	// inFlightReq level.
+	metricInFlightReqPrefix       = MetricNamePrefix + "inflightreq_"
+	inFlightReqQueueDepthName     = metricInFlightReqPrefix + "queue_depth"
+	inFlightReqQueueReqsTotalName = metricInFlightReqPrefix + "queue_requests_total"
+
+	// circuitBreaker level.
+	metricCircuitBreakerPrefix         = MetricNamePrefix + "circuitbreaker_"
+	circuitBreakerStateName            = metricCircuitBreakerPrefix + "state"
+	circuitBreakerTripsTotalName       = metricCircuitBreakerPrefix + "trips_total"
+	circuitBreakerEvaluationsTotalName = metricCircuitBreakerPrefix + "evaluations_total"
+
+	// concurrencyQuota level.
+	metricConcurrencyQuotaPrefix  = MetricNamePrefix + "concurrencyquota_"
+	concurrencyQuotaInFlightName  = metricConcurrencyQuotaPrefix + "inflight_requests"
+	concurrencyQuotaReqsTotalName = metricConcurrencyQuotaPrefix + "requests_total"
+
+	// mirroring level.
+	metricMirrorPrefix  = MetricNamePrefix + "mirror_"
+	mirrorReqsTotalName = metricMirrorPrefix + "requests_total"
 )
 
 // promState holds all metric state internally and acts as the only Collector we register for Prometheus.
@@ -126,22 +178,113 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 		Name: openConnectionsName,
 		Help: "How many open connections exist, by entryPoint and protocol",
 	}, []string{"entrypoint", "protocol"})
+	botReqs := newCounterFrom(stdprometheus.CounterOpts{
+		Name: botReqsTotalName,
+		Help: "How many HTTP requests are identified as bots by a botManager middleware, partitioned by middleware, bot category, and action.",
+	}, []string{"middleware", "category", "action"})
+	corsReqs := newCounterFrom(stdprometheus.CounterOpts{
+		Name: corsReqsTotalName,
+		Help: "How many CORS requests are processed by a cors middleware, partitioned by middleware, allowed origin, request type, and result.",
+	}, []string{"middleware", "origin", "type", "result"})
+	graphQLReqs := newCounterFrom(stdprometheus.CounterOpts{
+		Name: graphQLReqsTotalName,
+		Help: "How many GraphQL requests are processed by a graphQL middleware, partitioned by middleware, operation, and result.",
+	}, []string{"middleware", "operation", "result"})
+	webSocketConns := newGaugeFrom(stdprometheus.GaugeOpts{
+		Name: webSocketConnsName,
+		Help: "How many WebSocket connections are open through a webSocket middleware, partitioned by middleware and router.",
+	}, []string{"middleware", "router"})
+	webTransportSessions := newGaugeFrom(stdprometheus.GaugeOpts{
+		Name: webTransportSessionsName,
+		Help: "How many WebTransport sessions are open to a service, partitioned by service.",
+	}, []string{"service"})
+	webTransportStreams := newCounterFrom(stdprometheus.CounterOpts{
+		Name: webTransportStreamsTotalName,
+		Help: "How many WebTransport streams are proxied to a service, partitioned by service and type.",
+	}, []string{"service", "type"})
+	apiKeyReqs := newCounterFrom(stdprometheus.CounterOpts{
+		Name: apiKeyReqsTotalName,
+		Help: "How many HTTP requests are processed by an apiKeyAuth middleware, partitioned by middleware, key, and result.",
+	}, []string{"middleware", "key", "result"})
+	inFlightReqQueueDepth := newGaugeFrom(stdprometheus.GaugeOpts{
+		Name: inFlightReqQueueDepthName,
+		Help: "How many HTTP requests are waiting in the queue of an inFlightReq middleware, partitioned by middleware.",
+	}, []string{"middleware"})
+	inFlightReqQueueReqs := newCounterFrom(stdprometheus.CounterOpts{
+		Name: inFlightReqQueueReqsTotalName,
+		Help: "How many HTTP requests exceeding the limit of an inFlightReq middleware are handled by its queue, partitioned by middleware and result.",
+	}, []string{"middleware", "result"})
+	circuitBreakerState := newGaugeFrom(stdprometheus.GaugeOpts{
+		Name: circuitBreakerStateName,
+		Help: "Circuit breaker state (0 = closed, 1 = open, 2 = recovering), partitioned by middleware, router, and backend.",
+	}, []string{"middleware", "router", "backend"})
+	circuitBreakerTrips := newCounterFrom(stdprometheus.CounterOpts{
+		Name: circuitBreakerTripsTotalName,
+		Help: "How many times a circuit breaker has opened, partitioned by middleware, router, and backend.",
+	}, []string{"middleware", "router", "backend"})
+	circuitBreakerEvaluations := newCounterFrom(stdprometheus.CounterOpts{
+		Name: circuitBreakerEvaluationsTotalName,
+		Help: "How many times the expression of a circuit breaker has been evaluated, partitioned by middleware, router, backend, and result.",
+	}, []string{"middleware", "router", "backend", "result"})
+	concurrencyQuotaInFlight := newGaugeFrom(stdprometheus.GaugeOpts{
+		Name: concurrencyQuotaInFlightName,
+		Help: "How many HTTP requests are in flight through a concurrencyQuota middleware, partitioned by middleware and tenant.",
+	}, []string{"middleware", "tenant"})
+	concurrencyQuotaReqs := newCounterFrom(stdprometheus.CounterOpts{
+		Name: concurrencyQuotaReqsTotalName,
+		Help: "How many HTTP requests are processed by a concurrencyQuota middleware, partitioned by middleware, tenant, and result.",
+	}, []string{"middleware", "tenant", "result"})
+	mirrorReqs := newCounterFrom(stdprometheus.CounterOpts{
+		Name: mirrorReqsTotalName,
+		Help: "How many HTTP requests have been mirrored, partitioned by mirroring service, mirror service, status code of the mirror, and status code of the primary service.",
+	}, []string{"service", "mirror", "code", "primary_code"})
 
 	promState.vectors = []vector{
 		configReloads.cv,
 		lastConfigReloadSuccess.gv,
 		tlsCertsNotAfterTimestamp.gv,
 		openConnections.gv,
+		botReqs.cv,
+		corsReqs.cv,
+		graphQLReqs.cv,
+		webSocketConns.gv,
+		webTransportSessions.gv,
+		webTransportStreams.cv,
+		apiKeyReqs.cv,
+		inFlightReqQueueDepth.gv,
+		inFlightReqQueueReqs.cv,
+		circuitBreakerState.gv,
+		circuitBreakerTrips.cv,
+		circuitBreakerEvaluations.cv,
+		concurrencyQuotaInFlight.gv,
+		concurrencyQuotaReqs.cv,
+		mirrorReqs.cv,
 	}
 
 	reg := &standardRegistry{
-		epEnabled:                      config.AddEntryPointsLabels,
-		routerEnabled:                  config.AddRoutersLabels,
-		svcEnabled:                     config.AddServicesLabels,
-		configReloadsCounter:           configReloads,
-		lastConfigReloadSuccessGauge:   lastConfigReloadSuccess,
-		tlsCertsNotAfterTimestampGauge: tlsCertsNotAfterTimestamp,
-		openConnectionsGauge:           openConnections,
+		epEnabled:                        config.AddEntryPointsLabels,
+		routerEnabled:                    config.AddRoutersLabels,
+		svcEnabled:                       config.AddServicesLabels,
+		pluginEnabled:                    config.AddPluginsLabels,
+		configReloadsCounter:             configReloads,
+		lastConfigReloadSuccessGauge:     lastConfigReloadSuccess,
+		tlsCertsNotAfterTimestampGauge:   tlsCertsNotAfterTimestamp,
+		openConnectionsGauge:             openConnections,
+		botReqsCounter:                   botReqs,
+		corsReqsCounter:                  corsReqs,
+		graphQLReqsCounter:               graphQLReqs,
+		webSocketConnsGauge:              webSocketConns,
+		webTransportSessionsGauge:        webTransportSessions,
+		webTransportStreamsCounter:       webTransportStreams,
+		apiKeyReqsCounter:                apiKeyReqs,
+		inFlightReqQueueDepthGauge:       inFlightReqQueueDepth,
+		inFlightReqQueueReqsCounter:      inFlightReqQueueReqs,
+		circuitBreakerStateGauge:         circuitBreakerState,
+		circuitBreakerTripsCounter:       circuitBreakerTrips,
+		circuitBreakerEvaluationsCounter: circuitBreakerEvaluations,
+		concurrencyQuotaInFlightGauge:    concurrencyQuotaInFlight,
+		concurrencyQuotaReqsCounter:      concurrencyQuotaReqs,
+		mirrorReqsCounter:                mirrorReqs,
 	}
 
 	if config.AddEntryPointsLabels {
@@ -269,6 +412,38 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 		reg.serviceRespsBytesCounter = serviceRespsBytesTotal
 	}
 
+	if config.AddPluginsLabels {
+		pluginReqs := newCounterFrom(stdprometheus.CounterOpts{
+			Name: pluginReqsTotalName,
+			Help: "How many HTTP requests are processed by a plugin, partitioned by plugin and middleware.",
+		}, []string{"plugin", "middleware"})
+		pluginErrors := newCounterFrom(stdprometheus.CounterOpts{
+			Name: pluginErrorsTotalName,
+			Help: "How many HTTP requests are answered by a plugin with a server error status code, partitioned by plugin and middleware.",
+		}, []string{"plugin", "middleware"})
+		pluginPanics := newCounterFrom(stdprometheus.CounterOpts{
+			Name: pluginPanicsTotalName,
+			Help: "How many times a plugin panicked while processing a request, partitioned by plugin and middleware.",
+		}, []string{"plugin", "middleware"})
+		pluginReqDurations := newHistogramFrom(stdprometheus.HistogramOpts{
+			Name:    pluginReqDurationName,
+			Help:    "How long it took a plugin to process the request, excluding the rest of the chain, partitioned by plugin and middleware.",
+			Buckets: buckets,
+		}, []string{"plugin", "middleware"})
+
+		promState.vectors = append(promState.vectors,
+			pluginReqs.cv,
+			pluginErrors.cv,
+			pluginPanics.cv,
+			pluginReqDurations.hv,
+		)
+
+		reg.pluginReqsCounter = pluginReqs
+		reg.pluginErrorsCounter = pluginErrors
+		reg.pluginPanicsCounter = pluginPanics
+		reg.pluginReqDurationHistogram, _ = NewHistogramWithScale(pluginReqDurations, time.Second)
+	}
+
 	return reg
 }
 
@@ -309,6 +484,10 @@ func OnConfigurationUpdate(conf dynamic.Configuration, entryPoints []string) {
 		dynCfg.routers[name] = true
 	}
 
+	for name := range conf.HTTP.Middlewares {
+		dynCfg.middlewares[name] = true
+	}
+
 	for serviceName, service := range conf.HTTP.Services {
 		dynCfg.services[serviceName] = make(map[string]bool)
 		if service.LoadBalancer != nil {
@@ -336,12 +515,13 @@ type vector interface {
 type prometheusState struct {
 	vectors []vector
 
-	mtx             sync.Mutex
-	dynamicConfig   *dynamicConfig
-	deletedEP       []string
-	deletedRouters  []string
-	deletedServices []string
-	deletedURLs     map[string][]string
+	mtx                sync.Mutex
+	dynamicConfig      *dynamicConfig
+	deletedEP          []string
+	deletedRouters     []string
+	deletedServices    []string
+	deletedMiddlewares []string
+	deletedURLs        map[string][]string
 }
 
 func (ps *prometheusState) SetDynamicConfig(dynamicConfig *dynamicConfig) {
@@ -360,6 +540,12 @@ func (ps *prometheusState) SetDynamicConfig(dynamicConfig *dynamicConfig) {
 		}
 	}
 
+	for middleware := range ps.dynamicConfig.middlewares {
+		if _, ok := dynamicConfig.middlewares[middleware]; !ok {
+			ps.deletedMiddlewares = append(ps.deletedMiddlewares, middleware)
+		}
+	}
+
 	for service, serV := range ps.dynamicConfig.services {
 		actualService, ok := dynamicConfig.services[service]
 		if !ok {
@@ -414,6 +600,12 @@ func (ps *prometheusState) Collect(ch chan<- stdprometheus.Metric) {
 		}
 	}
 
+	for _, middleware := range ps.deletedMiddlewares {
+		if !ps.dynamicConfig.hasMiddleware(middleware) {
+			ps.DeletePartialMatch(map[string]string{"middleware": middleware})
+		}
+	}
+
 	for service, urls := range ps.deletedURLs {
 		for _, url := range urls {
 			if !ps.dynamicConfig.hasServerURL(service, url) {
@@ -425,6 +617,7 @@ func (ps *prometheusState) Collect(ch chan<- stdprometheus.Metric) {
 	ps.deletedEP = nil
 	ps.deletedRouters = nil
 	ps.deletedServices = nil
+	ps.deletedMiddlewares = nil
 	ps.deletedURLs = make(map[string][]string)
 }
 
@@ -444,17 +637,19 @@ func newDynamicConfig() *dynamicConfig {
 		entryPoints: make(map[string]bool),
 		routers:     make(map[string]bool),
 		services:    make(map[string]map[string]bool),
+		middlewares: make(map[string]bool),
 	}
 }
 
-// dynamicConfig holds the current configuration for entryPoints, services,
-// and server URLs in an optimized way to check for existence. This provides
+// dynamicConfig holds the current configuration for entryPoints, routers, services,
+// server URLs, and middlewares in an optimized way to check for existence. This provides
 // a performant way to check whether the collected metrics belong to the
 // current configuration or to an outdated one.
 type dynamicConfig struct {
 	entryPoints map[string]bool
 	routers     map[string]bool
 	services    map[string]map[string]bool
+	middlewares map[string]bool
 }
 
 func (d *dynamicConfig) hasEntryPoint(entrypointName string) bool {
@@ -472,6 +667,11 @@ func (d *dynamicConfig) hasRouter(routerName string) bool {
 	return ok
 }
 
+func (d *dynamicConfig) hasMiddleware(middlewareName string) bool {
+	_, ok := d.middlewares[middlewareName]
+	return ok
+}
+
 func (d *dynamicConfig) hasServerURL(serviceName, serverURL string) bool {
 	if service, hasService := d.services[serviceName]; hasService {
 		_, ok := service[serverURL]
@@ -95,12 +95,13 @@ func TestPrometheus(t *testing.T) {
 		AddEntryPointsLabels: true,
 		AddRoutersLabels:     true,
 		AddServicesLabels:    true,
+		AddPluginsLabels:     true,
 		HeaderLabels:         map[string]string{"useragent": "User-Agent"},
 	})
 	defer promRegistry.Unregister(promState)
 
-	if !prometheusRegistry.IsEpEnabled() || !prometheusRegistry.IsRouterEnabled() || !prometheusRegistry.IsSvcEnabled() {
-		t.Errorf("PrometheusRegistry should return true for IsEnabled(), IsRouterEnabled() and IsSvcEnabled()")
+	if !prometheusRegistry.IsEpEnabled() || !prometheusRegistry.IsRouterEnabled() || !prometheusRegistry.IsSvcEnabled() || !prometheusRegistry.IsPluginEnabled() {
+		t.Errorf("PrometheusRegistry should return true for IsEnabled(), IsRouterEnabled(), IsSvcEnabled() and IsPluginEnabled()")
 	}
 
 	prometheusRegistry.ConfigReloadsCounter().Add(1)
@@ -182,6 +183,98 @@ func TestPrometheus(t *testing.T) {
 		With("service", "service1", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
 		Add(1)
 
+	prometheusRegistry.
+		PluginReqsCounter().
+		With("plugin", "github.com/traefik/plugindemo", "middleware", "demo").
+		Add(1)
+	prometheusRegistry.
+		PluginErrorsCounter().
+		With("plugin", "github.com/traefik/plugindemo", "middleware", "demo").
+		Add(1)
+	prometheusRegistry.
+		PluginPanicsCounter().
+		With("plugin", "github.com/traefik/plugindemo", "middleware", "demo").
+		Add(1)
+	prometheusRegistry.
+		PluginReqDurationHistogram().
+		With("plugin", "github.com/traefik/plugindemo", "middleware", "demo").
+		Observe(10000)
+
+	prometheusRegistry.
+		BotReqsCounter().
+		With("middleware", "demo", "category", "searchEngine", "action", "allow").
+		Add(1)
+
+	prometheusRegistry.
+		CORSReqsCounter().
+		With("middleware", "demo", "origin", "https://example.com", "type", "preflight", "result", "allowed").
+		Add(1)
+
+	prometheusRegistry.
+		GraphQLReqsCounter().
+		With("middleware", "demo", "operation", "GetUser", "result", "allowed").
+		Add(1)
+
+	prometheusRegistry.
+		WebSocketConnsGauge().
+		With("middleware", "demo", "router", "demo").
+		Set(1)
+
+	prometheusRegistry.
+		WebTransportSessionsGauge().
+		With("service", "demo").
+		Set(1)
+
+	prometheusRegistry.
+		WebTransportStreamsCounter().
+		With("service", "demo", "type", "bidirectional").
+		Add(1)
+
+	prometheusRegistry.
+		APIKeyReqsCounter().
+		With("middleware", "demo", "key", "mobile-app", "result", "allowed").
+		Add(1)
+
+	prometheusRegistry.
+		InFlightReqQueueDepthGauge().
+		With("middleware", "demo").
+		Set(1)
+
+	prometheusRegistry.
+		InFlightReqQueueReqsCounter().
+		With("middleware", "demo", "result", "expired").
+		Add(1)
+
+	prometheusRegistry.
+		CircuitBreakerStateGauge().
+		With("middleware", "demo", "router", "demo", "backend", "whoami").
+		Set(1)
+
+	prometheusRegistry.
+		CircuitBreakerTripsCounter().
+		With("middleware", "demo", "router", "demo", "backend", "whoami").
+		Add(1)
+
+	prometheusRegistry.
+		CircuitBreakerEvaluationsCounter().
+		With("middleware", "demo", "router", "demo", "backend", "whoami", "result", "matched").
+		Add(1)
+
+	prometheusRegistry.
+		ConcurrencyQuotaInFlightGauge().
+		With("middleware", "demo", "tenant", "acme").
+		Set(1)
+
+	prometheusRegistry.
+		ConcurrencyQuotaReqsCounter().
+		With("middleware", "demo", "tenant", "acme", "result", "rejected").
+		Add(1)
+
+	prometheusRegistry.
+		MirrorReqsCounter().
+		With("service", "demo", "mirror", "shadow", "code", strconv.Itoa(http.StatusInternalServerError), "primary_code", strconv.Itoa(http.StatusOK)).
+		Add(1)
+
 	delayForTrackingCompletion()
 
 	metricsFamilies := mustScrape()
@@ -377,6 +470,168 @@ func TestPrometheus(t *testing.T) {
 			},
 			assert: buildCounterAssert(t, serviceRespsBytesTotalName, 1),
 		},
+		{
+			name: pluginReqsTotalName,
+			labels: map[string]string{
+				"plugin":     "github.com/traefik/plugindemo",
+				"middleware": "demo",
+			},
+			assert: buildCounterAssert(t, pluginReqsTotalName, 1),
+		},
+		{
+			name: pluginErrorsTotalName,
+			labels: map[string]string{
+				"plugin":     "github.com/traefik/plugindemo",
+				"middleware": "demo",
+			},
+			assert: buildCounterAssert(t, pluginErrorsTotalName, 1),
+		},
+		{
+			name: pluginPanicsTotalName,
+			labels: map[string]string{
+				"plugin":     "github.com/traefik/plugindemo",
+				"middleware": "demo",
+			},
+			assert: buildCounterAssert(t, pluginPanicsTotalName, 1),
+		},
+		{
+			name: pluginReqDurationName,
+			labels: map[string]string{
+				"plugin":     "github.com/traefik/plugindemo",
+				"middleware": "demo",
+			},
+			assert: buildHistogramAssert(t, pluginReqDurationName, 1),
+		},
+		{
+			name: botReqsTotalName,
+			labels: map[string]string{
+				"middleware": "demo",
+				"category":   "searchEngine",
+				"action":     "allow",
+			},
+			assert: buildCounterAssert(t, botReqsTotalName, 1),
+		},
+		{
+			name: corsReqsTotalName,
+			labels: map[string]string{
+				"middleware": "demo",
+				"origin":     "https://example.com",
+				"type":       "preflight",
+				"result":     "allowed",
+			},
+			assert: buildCounterAssert(t, corsReqsTotalName, 1),
+		},
+		{
+			name: graphQLReqsTotalName,
+			labels: map[string]string{
+				"middleware": "demo",
+				"operation":  "GetUser",
+				"result":     "allowed",
+			},
+			assert: buildCounterAssert(t, graphQLReqsTotalName, 1),
+		},
+		{
+			name: webSocketConnsName,
+			labels: map[string]string{
+				"middleware": "demo",
+				"router":     "demo",
+			},
+			assert: buildGaugeAssert(t, webSocketConnsName, 1),
+		},
+		{
+			name: webTransportSessionsName,
+			labels: map[string]string{
+				"service": "demo",
+			},
+			assert: buildGaugeAssert(t, webTransportSessionsName, 1),
+		},
+		{
+			name: webTransportStreamsTotalName,
+			labels: map[string]string{
+				"service": "demo",
+				"type":    "bidirectional",
+			},
+			assert: buildCounterAssert(t, webTransportStreamsTotalName, 1),
+		},
+		{
+			name: apiKeyReqsTotalName,
+			labels: map[string]string{
+				"middleware": "demo",
+				"key":        "mobile-app",
+				"result":     "allowed",
+			},
+			assert: buildCounterAssert(t, apiKeyReqsTotalName, 1),
+		},
+		{
+			name: inFlightReqQueueDepthName,
+			labels: map[string]string{
+				"middleware": "demo",
+			},
+			assert: buildGaugeAssert(t, inFlightReqQueueDepthName, 1),
+		},
+		{
+			name: inFlightReqQueueReqsTotalName,
+			labels: map[string]string{
+				"middleware": "demo",
+				"result":     "expired",
+			},
+			assert: buildCounterAssert(t, inFlightReqQueueReqsTotalName, 1),
+		},
+		{
+			name: circuitBreakerStateName,
+			labels: map[string]string{
+				"middleware": "demo",
+				"router":     "demo",
+				"backend":    "whoami",
+			},
+			assert: buildGaugeAssert(t, circuitBreakerStateName, 1),
+		},
+		{
+			name: circuitBreakerTripsTotalName,
+			labels: map[string]string{
+				"middleware": "demo",
+				"router":     "demo",
+				"backend":    "whoami",
+			},
+			assert: buildCounterAssert(t, circuitBreakerTripsTotalName, 1),
+		},
+		{
+			name: circuitBreakerEvaluationsTotalName,
+			labels: map[string]string{
+				"middleware": "demo",
+				"router":     "demo",
+				"backend":    "whoami",
+				"result":     "matched",
+			},
+			assert: buildCounterAssert(t, circuitBreakerEvaluationsTotalName, 1),
+		},
+		{
+			name: concurrencyQuotaInFlightName,
+			labels: map[string]string{
+				"middleware": "demo",
+				"tenant":     "acme",
+			},
+			assert: buildGaugeAssert(t, concurrencyQuotaInFlightName, 1),
+		},
+		{
+			name: concurrencyQuotaReqsTotalName,
+			labels: map[string]string{
+				"middleware": "demo",
+				"tenant":     "acme",
+				"result":     "rejected",
+			},
+			assert: buildCounterAssert(t, concurrencyQuotaReqsTotalName, 1),
+		},
+		{
+			name: mirrorReqsTotalName,
+			labels: map[string]string{
+				"service":      "demo",
+				"mirror":       "shadow",
+				"code":         "500",
+				"primary_code": "200",
+			},
+			assert: buildCounterAssert(t, mirrorReqsTotalName, 1),
+		},
 	}
 
 	for _, test := range testCases {
@@ -82,6 +82,9 @@ const (
 	TraceID = "TraceId"
 	// SpanID is the unique identifier for Traefik’s root span (EntryPoint) within a request trace, formatted as a 16-hex digit string.
 	SpanID = "SpanId"
+
+	// RequestID is the unique identifier of the request, set by the requestId middleware.
+	RequestID = "RequestId"
 )
 
 // These are written out in the default case when no config is provided to specify keys of interest.
@@ -0,0 +1,328 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/metrics"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	traefikredis "github.com/traefik/traefik/v3/pkg/redis"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	typeNameAPIKey = "APIKeyAuth"
+)
+
+const (
+	defaultAPIKeyHeaderName           = "X-API-Key"
+	defaultAPIKeyMetadataHeaderPrefix = "X-Api-Key-"
+	apiKeyDigestPrefix                = "sha256:"
+	// apiKeyRedisPrefix is the prefix of the Redis hashes holding the API keys, followed by the digest of the key.
+	apiKeyRedisPrefix = "traefik:apikey:"
+	// apiKeyRedisNameField is the field of the Redis hashes holding the name of the API key.
+	apiKeyRedisNameField = "name"
+)
+
+// apiKey is an accepted API key.
+type apiKey struct {
+	name     string
+	metadata map[string]string
+}
+
+// apiKeyStore looks up the API keys by the SHA-256 digest of their value, in hexadecimal.
+// It returns a nil key for the unknown digests.
+type apiKeyStore interface {
+	lookup(ctx context.Context, digest string) (*apiKey, error)
+}
+
+// staticKeyStore holds the API keys of the configuration, and of the keys file.
+type staticKeyStore map[string]*apiKey
+
+func (s staticKeyStore) lookup(_ context.Context, digest string) (*apiKey, error) {
+	return s[digest], nil
+}
+
+// redisKeyStore looks up the API keys in Redis hashes, whose fields are the name and the metadata of the keys.
+type redisKeyStore struct {
+	client redis.UniversalClient
+}
+
+func (s redisKeyStore) lookup(ctx context.Context, digest string) (*apiKey, error) {
+	fields, err := s.client.HGetAll(ctx, apiKeyRedisPrefix+digest).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	key := &apiKey{name: fields[apiKeyRedisNameField], metadata: make(map[string]string)}
+	for field, value := range fields {
+		if field != apiKeyRedisNameField {
+			key.metadata[field] = value
+		}
+	}
+
+	return key, nil
+}
+
+type apiKeyAuth struct {
+	next                 http.Handler
+	name                 string
+	headerName           string
+	queryParam           string
+	stores               []apiKeyStore
+	headerField          string
+	metadataHeaderPrefix string
+	removeKey            bool
+	reqsCounter          gokitmetrics.Counter
+}
+
+// NewAPIKey creates an apiKeyAuth middleware.
+// The requests are counted with the given metrics registry, which can be nil.
+func NewAPIKey(ctx context.Context, next http.Handler, config dynamic.APIKeyAuth, name string, registry metrics.Registry) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeNameAPIKey).Debug().Msg("Creating middleware")
+
+	if len(config.Keys) == 0 && config.KeysFile == "" && config.Redis == nil {
+		return nil, errors.New("at least one of keys, keysFile or redis must be defined")
+	}
+
+	a := &apiKeyAuth{
+		next:                 next,
+		name:                 name,
+		headerName:           config.HeaderName,
+		queryParam:           config.QueryParam,
+		headerField:          config.HeaderField,
+		metadataHeaderPrefix: http.CanonicalHeaderKey(config.MetadataHeaderPrefix),
+		removeKey:            config.RemoveKey,
+	}
+
+	if a.headerName == "" {
+		a.headerName = defaultAPIKeyHeaderName
+	}
+
+	if a.metadataHeaderPrefix == "" {
+		a.metadataHeaderPrefix = defaultAPIKeyMetadataHeaderPrefix
+	}
+
+	keys, err := loadAPIKeys(config.KeysFile, config.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) > 0 {
+		store, err := newStaticKeyStore(keys)
+		if err != nil {
+			return nil, err
+		}
+
+		a.stores = append(a.stores, store)
+	}
+
+	if config.Redis != nil {
+		client, err := traefikredis.SharedClient(ctx, *config.Redis)
+		if err != nil {
+			return nil, fmt.Errorf("creating Redis client: %w", err)
+		}
+
+		a.stores = append(a.stores, redisKeyStore{client: client})
+	}
+
+	if registry != nil {
+		a.reqsCounter = registry.APIKeyReqsCounter()
+	}
+
+	return a, nil
+}
+
+func (a *apiKeyAuth) GetTracingInformation() (string, string, trace.SpanKind) {
+	return a.name, typeNameAPIKey, trace.SpanKindInternal
+}
+
+func (a *apiKeyAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), a.name, typeNameAPIKey)
+
+	value, fromQuery := a.keyValue(req)
+	if value == "" {
+		a.reject(rw, req, "missing")
+		return
+	}
+
+	sum := sha256.Sum256([]byte(value))
+	key, err := a.lookup(req.Context(), hex.EncodeToString(sum[:]))
+	if err != nil {
+		logger.Error().Err(err).Msg("Error while looking up the API key")
+		observability.SetStatusErrorf(req.Context(), "Error while looking up the API key: %v", err)
+
+		a.record("", "error")
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if key == nil {
+		a.reject(rw, req, "invalid")
+		return
+	}
+
+	logger.Debug().Msg("Authentication succeeded")
+	a.record(key.name, "allowed")
+
+	logData := accesslog.GetLogData(req)
+	if logData != nil {
+		logData.Core[accesslog.ClientUsername] = key.name
+	}
+
+	// The metadata header fields sent by the client are removed, for them not to be taken for the metadata of the key.
+	for headerName := range req.Header {
+		if strings.HasPrefix(http.CanonicalHeaderKey(headerName), a.metadataHeaderPrefix) {
+			req.Header.Del(headerName)
+		}
+	}
+
+	for field, value := range key.metadata {
+		req.Header.Set(a.metadataHeaderPrefix+field, value)
+	}
+
+	if a.headerField != "" {
+		req.Header[a.headerField] = []string{key.name}
+	}
+
+	if a.removeKey {
+		logger.Debug().Msg("Removing the API key")
+
+		req.Header.Del(a.headerName)
+
+		if fromQuery {
+			query := req.URL.Query()
+			query.Del(a.queryParam)
+			req.URL.RawQuery = query.Encode()
+			req.RequestURI = req.URL.RequestURI()
+		}
+	}
+
+	a.next.ServeHTTP(rw, req)
+}
+
+// keyValue returns the API key sent with the request, from the header, or else from the query parameter,
+// and whether it is from the query parameter.
+func (a *apiKeyAuth) keyValue(req *http.Request) (string, bool) {
+	if value := req.Header.Get(a.headerName); value != "" {
+		return value, false
+	}
+
+	if a.queryParam == "" {
+		return "", false
+	}
+
+	value := req.URL.Query().Get(a.queryParam)
+	return value, value != ""
+}
+
+// lookup looks up the API key in the stores, in order.
+func (a *apiKeyAuth) lookup(ctx context.Context, digest string) (*apiKey, error) {
+	for _, store := range a.stores {
+		key, err := store.lookup(ctx, digest)
+		if err != nil || key != nil {
+			return key, err
+		}
+	}
+
+	return nil, nil
+}
+
+func (a *apiKeyAuth) reject(rw http.ResponseWriter, req *http.Request, result string) {
+	logger := middlewares.GetLogger(req.Context(), a.name, typeNameAPIKey)
+	logger.Debug().Msgf("Authentication failed: %s API key", result)
+
+	observability.SetStatusErrorf(req.Context(), "Authentication failed: %s API key", result)
+
+	a.record("", result)
+
+	rw.WriteHeader(http.StatusUnauthorized)
+	if _, err := rw.Write([]byte(http.StatusText(http.StatusUnauthorized))); err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Send()
+	}
+}
+
+func (a *apiKeyAuth) record(key, result string) {
+	if a.reqsCounter != nil {
+		a.reqsCounter.With("middleware", a.name, "key", key, "result", result).Add(1)
+	}
+}
+
+// loadAPIKeys returns the API keys of the given file, followed by the given keys.
+func loadAPIKeys(fileName string, appendKeys []dynamic.APIKey) ([]dynamic.APIKey, error) {
+	if fileName == "" {
+		return appendKeys, nil
+	}
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []dynamic.APIKey
+	if err := yaml.Unmarshal(content, &keys); err != nil {
+		return nil, fmt.Errorf("parsing keys file %s: %w", fileName, err)
+	}
+
+	return append(keys, appendKeys...), nil
+}
+
+func newStaticKeyStore(keys []dynamic.APIKey) (staticKeyStore, error) {
+	store := make(staticKeyStore)
+	for _, key := range keys {
+		if key.Name == "" {
+			return nil, errors.New("API key without name")
+		}
+
+		digest, err := apiKeyDigest(key.Value)
+		if err != nil {
+			return nil, fmt.Errorf("API key %q: %w", key.Name, err)
+		}
+
+		if _, exists := store[digest]; exists {
+			return nil, fmt.Errorf("API key %q is defined several times", key.Name)
+		}
+
+		store[digest] = &apiKey{name: key.Name, metadata: key.Metadata}
+	}
+
+	return store, nil
+}
+
+// apiKeyDigest returns the SHA-256 digest, in hexadecimal, of the given API key,
+// which can also be given as its digest prefixed with sha256:.
+func apiKeyDigest(value string) (string, error) {
+	if value == "" {
+		return "", errors.New("empty value")
+	}
+
+	if digest, found := strings.CutPrefix(value, apiKeyDigestPrefix); found {
+		decoded, err := hex.DecodeString(digest)
+		if err != nil || len(decoded) != sha256.Size {
+			return "", errors.New("invalid SHA-256 digest")
+		}
+
+		return strings.ToLower(digest), nil
+	}
+
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:]), nil
+}
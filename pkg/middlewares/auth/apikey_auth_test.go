@@ -0,0 +1,322 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/metrics"
+)
+
+func TestNewAPIKey_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.APIKeyAuth
+	}{
+		{
+			desc:   "no keys",
+			config: dynamic.APIKeyAuth{},
+		},
+		{
+			desc:   "key without name",
+			config: dynamic.APIKeyAuth{Keys: []dynamic.APIKey{{Value: "secret"}}},
+		},
+		{
+			desc:   "key without value",
+			config: dynamic.APIKeyAuth{Keys: []dynamic.APIKey{{Name: "app"}}},
+		},
+		{
+			desc:   "invalid digest",
+			config: dynamic.APIKeyAuth{Keys: []dynamic.APIKey{{Name: "app", Value: "sha256:abc"}}},
+		},
+		{
+			desc: "duplicated key",
+			config: dynamic.APIKeyAuth{Keys: []dynamic.APIKey{
+				{Name: "app", Value: "secret"},
+				{Name: "other", Value: "sha256:" + digest("secret")},
+			}},
+		},
+		{
+			desc:   "missing keys file",
+			config: dynamic.APIKeyAuth{KeysFile: "/path/to/missing.yaml"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewAPIKey(context.Background(), http.NotFoundHandler(), test.config, "apiKey", nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	config := dynamic.APIKeyAuth{
+		QueryParam: "api_key",
+		Keys: []dynamic.APIKey{
+			{Name: "mobile-app", Value: "mobile-secret", Metadata: map[string]string{"Tenant": "acme"}},
+			{Name: "partner", Value: "sha256:" + digest("partner-secret")},
+		},
+		HeaderField: "X-Api-Key-Name",
+	}
+
+	testCases := []struct {
+		desc             string
+		target           string
+		header           http.Header
+		expectedStatus   int
+		expectedName     string
+		expectedMetadata string
+	}{
+		{
+			desc:             "key in the header",
+			target:           "/",
+			header:           http.Header{"X-Api-Key": {"mobile-secret"}},
+			expectedStatus:   http.StatusOK,
+			expectedName:     "mobile-app",
+			expectedMetadata: "acme",
+		},
+		{
+			desc:           "key given as its digest",
+			target:         "/",
+			header:         http.Header{"X-Api-Key": {"partner-secret"}},
+			expectedStatus: http.StatusOK,
+			expectedName:   "partner",
+		},
+		{
+			desc:             "key in the query parameter",
+			target:           "/?api_key=mobile-secret",
+			expectedStatus:   http.StatusOK,
+			expectedName:     "mobile-app",
+			expectedMetadata: "acme",
+		},
+		{
+			desc:           "spoofed metadata",
+			target:         "/",
+			header:         http.Header{"X-Api-Key": {"partner-secret"}, "X-Api-Key-Tenant": {"acme"}},
+			expectedStatus: http.StatusOK,
+			expectedName:   "partner",
+		},
+		{
+			desc:           "missing key",
+			target:         "/",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "invalid key",
+			target:         "/",
+			header:         http.Header{"X-Api-Key": {"unknown"}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "digest sent as the key",
+			target:         "/",
+			header:         http.Header{"X-Api-Key": {"sha256:" + digest("partner-secret")}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwarded *http.Request
+			handler, err := NewAPIKey(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwarded = req
+			}), config, "apiKey", nil)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, test.target, nil)
+			for name, values := range test.header {
+				req.Header[name] = values
+			}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+			if test.expectedStatus != http.StatusOK {
+				return
+			}
+
+			require.NotNil(t, forwarded)
+			assert.Equal(t, test.expectedName, forwarded.Header.Get("X-Api-Key-Name"))
+			assert.Equal(t, test.expectedMetadata, forwarded.Header.Get("X-Api-Key-Tenant"))
+		})
+	}
+}
+
+func TestAPIKeyAuth_removeKey(t *testing.T) {
+	var forwarded *http.Request
+	handler, err := NewAPIKey(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwarded = req
+	}), dynamic.APIKeyAuth{
+		HeaderName: "Api-Key",
+		QueryParam: "api_key",
+		Keys:       []dynamic.APIKey{{Name: "app", Value: "secret"}},
+		RemoveKey:  true,
+	}, "apiKey", nil)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/?page=2&api_key=secret", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NotNil(t, forwarded)
+	assert.Equal(t, "page=2", forwarded.URL.RawQuery)
+	assert.Equal(t, "/?page=2", forwarded.RequestURI)
+
+	forwarded = nil
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Api-Key", "secret")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NotNil(t, forwarded)
+	assert.Empty(t, forwarded.Header.Get("Api-Key"))
+}
+
+func TestAPIKeyAuth_keysFile(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "keys.yaml")
+	err := os.WriteFile(keysFile, []byte(`
+- name: mobile-app
+  value: mobile-secret
+  metadata:
+    plan: gold
+`), 0o600)
+	require.NoError(t, err)
+
+	var forwarded *http.Request
+	handler, err := NewAPIKey(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwarded = req
+	}), dynamic.APIKeyAuth{
+		KeysFile: keysFile,
+		Keys:     []dynamic.APIKey{{Name: "partner", Value: "partner-secret"}},
+	}, "apiKey", nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		value        string
+		expectedPlan string
+	}{
+		{value: "mobile-secret", expectedPlan: "gold"},
+		{value: "partner-secret"},
+	}
+
+	for _, test := range testCases {
+		forwarded = nil
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-API-Key", test.value)
+
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+
+		assert.Equal(t, http.StatusOK, rw.Code, test.value)
+		require.NotNil(t, forwarded, test.value)
+		assert.Equal(t, test.expectedPlan, forwarded.Header.Get("X-Api-Key-Plan"), test.value)
+	}
+}
+
+func TestAPIKeyAuth_redis(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.HSet(apiKeyRedisPrefix+digest("redis-secret"), "name", "batch-job", "tenant", "acme")
+
+	var forwarded *http.Request
+	handler, err := NewAPIKey(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwarded = req
+	}), dynamic.APIKeyAuth{
+		Keys:        []dynamic.APIKey{{Name: "app", Value: "secret"}},
+		Redis:       &dynamic.Redis{Endpoints: []string{server.Addr()}},
+		HeaderField: "X-Consumer",
+	}, "apiKey", nil)
+	require.NoError(t, err)
+
+	serve := func(value string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-API-Key", value)
+
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+
+		return rw.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("secret"))
+	assert.Equal(t, http.StatusOK, serve("redis-secret"))
+	require.NotNil(t, forwarded)
+	assert.Equal(t, "batch-job", forwarded.Header.Get("X-Consumer"))
+	assert.Equal(t, "acme", forwarded.Header.Get("X-Api-Key-Tenant"))
+
+	assert.Equal(t, http.StatusUnauthorized, serve("unknown"))
+
+	// The keys removed from Redis are rejected right away.
+	server.Del(apiKeyRedisPrefix + digest("redis-secret"))
+	assert.Equal(t, http.StatusUnauthorized, serve("redis-secret"))
+
+	server.Close()
+	assert.Equal(t, http.StatusInternalServerError, serve("redis-secret"))
+}
+
+func TestAPIKeyAuth_metrics(t *testing.T) {
+	registry := &apiKeyRegistry{Registry: metrics.NewVoidRegistry(), counter: &lastLabelsCounter{}}
+
+	handler, err := NewAPIKey(context.Background(), http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), dynamic.APIKeyAuth{
+		Keys: []dynamic.APIKey{{Name: "app", Value: "secret"}},
+	}, "apiKey", registry)
+	require.NoError(t, err)
+
+	serve := func(value string) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if value != "" {
+			req.Header.Set("X-API-Key", value)
+		}
+
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve("secret")
+	assert.Equal(t, []string{"middleware", "apiKey", "key", "app", "result", "allowed"}, registry.counter.lastLabelValues)
+
+	serve("unknown")
+	assert.Equal(t, []string{"middleware", "apiKey", "key", "", "result", "invalid"}, registry.counter.lastLabelValues)
+
+	serve("")
+	assert.Equal(t, []string{"middleware", "apiKey", "key", "", "result", "missing"}, registry.counter.lastLabelValues)
+}
+
+func digest(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+type apiKeyRegistry struct {
+	metrics.Registry
+
+	counter *lastLabelsCounter
+}
+
+func (r *apiKeyRegistry) APIKeyReqsCounter() gokitmetrics.Counter {
+	return r.counter
+}
+
+type lastLabelsCounter struct {
+	lastLabelValues []string
+}
+
+func (c *lastLabelsCounter) With(labelValues ...string) gokitmetrics.Counter {
+	c.lastLabelValues = labelValues
+	return c
+}
+
+func (c *lastLabelsCounter) Add(float64) {}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	goauth "github.com/abbot/go-http-auth"
+	"github.com/rs/zerolog"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
 	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
@@ -23,6 +24,7 @@ type basicAuth struct {
 	next         http.Handler
 	auth         *goauth.BasicAuth
 	users        map[string]string
+	ldap         *ldapAuthenticator
 	headerField  string
 	removeHeader bool
 	name         string
@@ -52,6 +54,13 @@ func NewBasic(ctx context.Context, next http.Handler, authConfig dynamic.BasicAu
 
 	ba.auth = &goauth.BasicAuth{Realm: realm, Secrets: ba.secretBasic}
 
+	if authConfig.LDAP != nil {
+		ba.ldap, err = newLDAPAuthenticator(ctx, *authConfig.LDAP)
+		if err != nil {
+			return nil, fmt.Errorf("creating LDAP authenticator: %w", err)
+		}
+	}
+
 	return ba, nil
 }
 
@@ -64,10 +73,7 @@ func (b *basicAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 
 	user, password, ok := req.BasicAuth()
 	if ok {
-		secret := b.auth.Secrets(user, b.auth.Realm)
-		if secret == "" || !goauth.CheckSecret(password, secret) {
-			ok = false
-		}
+		ok = b.checkCredentials(logger, user, password)
 	}
 
 	logData := accesslog.GetLogData(req)
@@ -97,6 +103,26 @@ func (b *basicAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	b.next.ServeHTTP(rw, req)
 }
 
+// checkCredentials validates the credentials against the declared users,
+// or against the LDAP server when the user is not declared.
+func (b *basicAuth) checkCredentials(logger *zerolog.Logger, user, password string) bool {
+	if secret := b.auth.Secrets(user, b.auth.Realm); secret != "" {
+		return goauth.CheckSecret(password, secret)
+	}
+
+	if b.ldap == nil {
+		return false
+	}
+
+	ok, err := b.ldap.authenticate(user, password)
+	if err != nil {
+		logger.Error().Err(err).Msg("Error while validating the credentials with LDAP")
+		return false
+	}
+
+	return ok
+}
+
 func (b *basicAuth) secretBasic(user, realm string) string {
 	if secret, ok := b.users[user]; ok {
 		return secret
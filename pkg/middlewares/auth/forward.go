@@ -1,7 +1,10 @@
 package auth
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -11,6 +14,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
+	"github.com/patrickmn/go-cache"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
 	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
@@ -24,8 +29,14 @@ import (
 )
 
 const (
-	xForwardedURI    = "X-Forwarded-Uri"
-	xForwardedMethod = "X-Forwarded-Method"
+	xForwardedURI           = "X-Forwarded-Uri"
+	xForwardedMethod        = "X-Forwarded-Method"
+	xForwardedBodyTruncated = "X-Forwarded-Body-Truncated"
+)
+
+const (
+	defaultForwardAuthMaxBodySize     = 1024 * 1024
+	defaultForwardAuthCacheMaxEntries = 10000
 )
 
 const typeNameForward = "ForwardAuth"
@@ -53,6 +64,14 @@ type forwardAuth struct {
 	authRequestHeaders       []string
 	addAuthCookiesToResponse map[string]struct{}
 	headerField              string
+	forwardBody              bool
+	maxBodySize              int64
+	retryAttempts            int
+	retryInterval            time.Duration
+	// decisions holds the response headers of the allow decisions, by cache key.
+	decisions          *cache.Cache
+	decisionKey        utils.SourceExtractor
+	decisionMaxEntries int
 }
 
 // NewForward creates a forward auth middleware.
@@ -74,6 +93,49 @@ func NewForward(ctx context.Context, next http.Handler, config dynamic.ForwardAu
 		authRequestHeaders:       config.AuthRequestHeaders,
 		addAuthCookiesToResponse: addAuthCookiesToResponse,
 		headerField:              config.HeaderField,
+		forwardBody:              config.ForwardBody,
+		maxBodySize:              config.MaxBodySize,
+		retryAttempts:            1,
+	}
+
+	if fa.maxBodySize <= 0 {
+		fa.maxBodySize = defaultForwardAuthMaxBodySize
+	}
+
+	if config.Retry != nil {
+		if config.Retry.Attempts < 0 {
+			return nil, fmt.Errorf("negative retry attempts %d", config.Retry.Attempts)
+		}
+
+		if config.Retry.Attempts > 0 {
+			fa.retryAttempts = config.Retry.Attempts
+		}
+
+		fa.retryInterval = time.Duration(config.Retry.InitialInterval)
+	}
+
+	if config.Cache != nil {
+		if config.Cache.Key == "" {
+			return nil, errors.New("the cache key must be defined")
+		}
+
+		ttl := time.Duration(config.Cache.TTL)
+		if ttl <= 0 {
+			return nil, errors.New("the cache TTL must be greater than zero")
+		}
+
+		var err error
+		fa.decisionKey, err = middlewares.GetSourceExtractor(ctx, &dynamic.SourceCriterion{Expression: config.Cache.Key})
+		if err != nil {
+			return nil, fmt.Errorf("parsing cache key: %w", err)
+		}
+
+		fa.decisions = cache.New(ttl, ttl)
+
+		fa.decisionMaxEntries = config.Cache.MaxEntries
+		if fa.decisionMaxEntries <= 0 {
+			fa.decisionMaxEntries = defaultForwardAuthCacheMaxEntries
+		}
 	}
 
 	// Ensure our request client does not follow redirects
@@ -126,7 +188,37 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 
 	req = connectionheader.Remove(req)
 
-	forwardReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, fa.address, nil)
+	var cacheKey string
+	if fa.decisions != nil {
+		cacheKey = fa.cacheKey(req)
+
+		if cached, found := fa.decisions.Get(cacheKey); cacheKey != "" && found {
+			logger.Debug().Msg("Using the cached authentication decision")
+
+			authHeader := cached.(http.Header)
+			fa.setClientUsername(req, authHeader)
+			fa.allow(rw, req, authHeader)
+			return
+		}
+	}
+
+	var authBody io.Reader
+	var bodyTruncated bool
+	if fa.forwardBody {
+		prefix, truncated, err := fa.readBodyPrefix(req)
+		if err != nil {
+			logger.Debug().Msgf("Error reading request body. Cause: %s", err)
+			observability.SetStatusErrorf(req.Context(), "Error reading request body. Cause: %s", err)
+
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		authBody = bytes.NewReader(prefix)
+		bodyTruncated = truncated
+	}
+
+	forwardReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, fa.address, authBody)
 	if err != nil {
 		logger.Debug().Msgf("Error calling %s. Cause %s", fa.address, err)
 		observability.SetStatusErrorf(req.Context(), "Error calling %s. Cause %s", fa.address, err)
@@ -137,6 +229,11 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 
 	writeHeader(req, forwardReq, fa.trustForwardHeader, fa.authRequestHeaders)
 
+	forwardReq.Header.Del(xForwardedBodyTruncated)
+	if bodyTruncated {
+		forwardReq.Header.Set(xForwardedBodyTruncated, "true")
+	}
+
 	var forwardSpan trace.Span
 	var tracer *tracing.Tracer
 	if tracer = tracing.TracerFromContext(req.Context()); tracer != nil {
@@ -150,7 +247,7 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		tracer.CaptureClientRequest(forwardSpan, forwardReq)
 	}
 
-	forwardResponse, forwardErr := fa.client.Do(forwardReq)
+	forwardResponse, forwardErr := fa.call(forwardReq)
 	if forwardErr != nil {
 		logger.Debug().Msgf("Error calling %s. Cause: %s", fa.address, forwardErr)
 		observability.SetStatusErrorf(req.Context(), "Error calling %s. Cause: %s", fa.address, forwardErr)
@@ -175,14 +272,7 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		forwardSpan.End()
 	}
 
-	if fa.headerField != "" {
-		if elems := forwardResponse.Header[http.CanonicalHeaderKey(fa.headerField)]; len(elems) > 0 {
-			logData := accesslog.GetLogData(req)
-			if logData != nil {
-				logData.Core[accesslog.ClientUsername] = elems[0]
-			}
-		}
-	}
+	fa.setClientUsername(req, forwardResponse.Header)
 
 	// Pass the forward response's body and selected headers if it
 	// didn't return a response within the range of [200, 300).
@@ -217,11 +307,23 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	tracer.CaptureResponse(forwardSpan, forwardResponse.Header, forwardResponse.StatusCode, trace.SpanKindClient)
+
+	// The decisions setting cookies are specific to the request, and are not cached.
+	if cacheKey != "" && len(forwardResponse.Cookies()) == 0 && fa.decisions.ItemCount() < fa.decisionMaxEntries {
+		fa.decisions.SetDefault(cacheKey, forwardResponse.Header.Clone())
+	}
+
+	fa.allow(rw, req, forwardResponse.Header)
+}
+
+// allow forwards the request to the next handler, with the headers of the authentication server response.
+func (fa *forwardAuth) allow(rw http.ResponseWriter, req *http.Request, authHeader http.Header) {
 	for _, headerName := range fa.authResponseHeaders {
 		headerKey := http.CanonicalHeaderKey(headerName)
 		req.Header.Del(headerKey)
-		if len(forwardResponse.Header[headerKey]) > 0 {
-			req.Header[headerKey] = append([]string(nil), forwardResponse.Header[headerKey]...)
+		if len(authHeader[headerKey]) > 0 {
+			req.Header[headerKey] = append([]string(nil), authHeader[headerKey]...)
 		}
 	}
 
@@ -232,18 +334,16 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 			}
 		}
 
-		for headerKey, headerValues := range forwardResponse.Header {
+		for headerKey, headerValues := range authHeader {
 			if fa.authResponseHeadersRegex.MatchString(headerKey) {
 				req.Header[headerKey] = append([]string(nil), headerValues...)
 			}
 		}
 	}
 
-	tracer.CaptureResponse(forwardSpan, forwardResponse.Header, forwardResponse.StatusCode, trace.SpanKindClient)
-
 	req.RequestURI = req.URL.RequestURI()
 
-	authCookies := forwardResponse.Cookies()
+	authCookies := (&http.Response{Header: authHeader}).Cookies()
 	if len(authCookies) == 0 {
 		fa.next.ServeHTTP(rw, req)
 		return
@@ -252,6 +352,126 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	fa.next.ServeHTTP(middlewares.NewResponseModifier(rw, req, fa.buildModifier(authCookies)), req)
 }
 
+// call calls the authentication server, retrying the calls failing with a transient error.
+// The response to the last attempt is returned, whatever its status code.
+func (fa *forwardAuth) call(forwardReq *http.Request) (*http.Response, error) {
+	if fa.retryAttempts < 2 {
+		return fa.client.Do(forwardReq)
+	}
+
+	logger := middlewares.GetLogger(forwardReq.Context(), fa.name, typeNameForward)
+
+	var attempt int
+	var forwardResponse *http.Response
+	operation := func() error {
+		attempt++
+
+		attemptReq := forwardReq
+		if forwardReq.GetBody != nil {
+			body, err := forwardReq.GetBody()
+			if err != nil {
+				return backoff.Permanent(err)
+			}
+
+			attemptReq = forwardReq.Clone(forwardReq.Context())
+			attemptReq.Body = body
+		}
+
+		res, err := fa.client.Do(attemptReq)
+		if err != nil {
+			if forwardReq.Context().Err() != nil {
+				return backoff.Permanent(err)
+			}
+
+			return err
+		}
+
+		if attempt < fa.retryAttempts && isTransientStatus(res.StatusCode) {
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+
+			return fmt.Errorf("authentication server responded with status %d", res.StatusCode)
+		}
+
+		forwardResponse = res
+		return nil
+	}
+
+	notify := func(err error, d time.Duration) {
+		logger.Debug().Err(err).Msgf("Retrying the call to %s in %s", fa.address, d)
+	}
+
+	backOff := backoff.WithContext(backoff.WithMaxRetries(fa.newBackOff(), uint64(fa.retryAttempts-1)), forwardReq.Context())
+	if err := backoff.RetryNotify(operation, backOff, notify); err != nil {
+		return nil, err
+	}
+
+	return forwardResponse, nil
+}
+
+func (fa *forwardAuth) newBackOff() backoff.BackOff {
+	if fa.retryInterval <= 0 {
+		return &backoff.ZeroBackOff{}
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = fa.retryInterval
+
+	// according to docs, b.Reset() must be called before using
+	b.Reset()
+	return b
+}
+
+// readBodyPrefix returns the prefix of the request body forwarded to the authentication server,
+// and whether the body is longer than the prefix.
+// The request body is replaced, for the whole body to be forwarded to the service.
+func (fa *forwardAuth) readBodyPrefix(req *http.Request) ([]byte, bool, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, false, nil
+	}
+
+	prefix, err := io.ReadAll(io.LimitReader(req.Body, fa.maxBodySize+1))
+	if err != nil {
+		return nil, false, err
+	}
+
+	req.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(prefix), req.Body),
+		Closer: req.Body,
+	}
+
+	if int64(len(prefix)) > fa.maxBodySize {
+		return prefix[:fa.maxBodySize], true, nil
+	}
+
+	return prefix, false, nil
+}
+
+// cacheKey returns the key of the request in the decisions cache, empty if the request has no key.
+func (fa *forwardAuth) cacheKey(req *http.Request) string {
+	key, _, err := fa.decisionKey.Extract(req)
+	if err != nil || key == "" {
+		return ""
+	}
+
+	// The keys are hashed, for the cache not to hold the credentials of the requests.
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func (fa *forwardAuth) setClientUsername(req *http.Request, authHeader http.Header) {
+	if fa.headerField == "" {
+		return
+	}
+
+	if elems := authHeader[http.CanonicalHeaderKey(fa.headerField)]; len(elems) > 0 {
+		logData := accesslog.GetLogData(req)
+		if logData != nil {
+			logData.Core[accesslog.ClientUsername] = elems[0]
+		}
+	}
+}
+
 func (fa *forwardAuth) buildModifier(authCookies []*http.Cookie) func(res *http.Response) error {
 	return func(res *http.Response) error {
 		cookies := res.Cookies()
@@ -273,6 +493,16 @@ func (fa *forwardAuth) buildModifier(authCookies []*http.Cookie) func(res *http.
 	}
 }
 
+// isTransientStatus reports whether the status code of the authentication server response denotes a transient failure.
+func isTransientStatus(statusCode int) bool {
+	return statusCode == http.StatusBadGateway || statusCode == http.StatusServiceUnavailable || statusCode == http.StatusGatewayTimeout
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
 func writeHeader(req, forwardReq *http.Request, trustForwardHeader bool, allowedHeaders []string) {
 	utils.CopyHeaders(forwardReq.Header, req.Header)
 	utils.RemoveHeaders(forwardReq.Header, hopHeaders...)
@@ -9,10 +9,14 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/testhelpers"
 	"github.com/traefik/traefik/v3/pkg/tracing"
@@ -259,6 +263,278 @@ func TestForwardAuthFailResponseHeaders(t *testing.T) {
 	assert.Equal(t, "Forbidden\n", string(body))
 }
 
+func TestForwardAuthForwardBody(t *testing.T) {
+	testCases := []struct {
+		desc              string
+		body              string
+		maxBodySize       int64
+		expectedAuthBody  string
+		expectedTruncated string
+	}{
+		{
+			desc:             "whole body",
+			body:             "hello traefik",
+			expectedAuthBody: "hello traefik",
+		},
+		{
+			desc:             "body as long as the max body size",
+			body:             "hello",
+			maxBodySize:      5,
+			expectedAuthBody: "hello",
+		},
+		{
+			desc:              "body prefix",
+			body:              "hello traefik",
+			maxBodySize:       5,
+			expectedAuthBody:  "hello",
+			expectedTruncated: "true",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				assert.Equal(t, test.expectedAuthBody, string(body))
+				assert.Equal(t, test.expectedTruncated, r.Header.Get(xForwardedBodyTruncated))
+			}))
+			t.Cleanup(server.Close)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				assert.Equal(t, test.body, string(body))
+			})
+
+			middleware, err := NewForward(context.Background(), next, dynamic.ForwardAuth{
+				Address:     server.URL,
+				ForwardBody: true,
+				MaxBodySize: test.maxBodySize,
+			}, "authTest")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(test.body))
+			req.Header.Set(xForwardedBodyTruncated, "false")
+
+			rw := httptest.NewRecorder()
+			middleware.ServeHTTP(rw, req)
+
+			assert.Equal(t, http.StatusOK, rw.Code)
+		})
+	}
+}
+
+func TestForwardAuthRetry(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		attempts         int
+		failures         int32
+		expectedStatus   int
+		expectedAttempts int32
+	}{
+		{
+			desc:             "no retry",
+			failures:         1,
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedAttempts: 1,
+		},
+		{
+			desc:             "success after a retry",
+			attempts:         3,
+			failures:         1,
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+		{
+			desc:             "failure of all the attempts",
+			attempts:         3,
+			failures:         5,
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedAttempts: 3,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, "payload", string(body))
+
+				if attempts.Add(1) <= test.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintln(w, "traefik")
+			})
+
+			middleware, err := NewForward(context.Background(), next, dynamic.ForwardAuth{
+				Address:     server.URL,
+				ForwardBody: true,
+				Retry:       &dynamic.ForwardAuthRetry{Attempts: test.attempts},
+			}, "authTest")
+			require.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			middleware.ServeHTTP(rw, httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("payload")))
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+			assert.Equal(t, test.expectedAttempts, attempts.Load())
+		})
+	}
+}
+
+func TestForwardAuthCache(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		switch r.Header.Get("Authorization") {
+		case "Bearer valid":
+			w.Header().Set("X-Auth-User", "user@example.com")
+		case "Bearer session":
+			w.Header().Set("Set-Cookie", "session=value")
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Header.Get("X-Auth-User"))
+	})
+
+	middleware, err := NewForward(context.Background(), next, dynamic.ForwardAuth{
+		Address:             server.URL,
+		AuthResponseHeaders: []string{"X-Auth-User"},
+		Cache: &dynamic.ForwardAuthCache{
+			Key: "Header(`Authorization`)",
+			TTL: ptypes.Duration(time.Minute),
+		},
+	}, "authTest")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc          string
+		authorization string
+		expectedCode  int
+		expectedBody  string
+		expectedCalls int32
+	}{
+		{
+			desc:          "allowed",
+			authorization: "Bearer valid",
+			expectedCode:  http.StatusOK,
+			expectedBody:  "user@example.com",
+			expectedCalls: 1,
+		},
+		{
+			desc:          "cached allow decision",
+			authorization: "Bearer valid",
+			expectedCode:  http.StatusOK,
+			expectedBody:  "user@example.com",
+			expectedCalls: 1,
+		},
+		{
+			desc:          "denied",
+			authorization: "Bearer invalid",
+			expectedCode:  http.StatusUnauthorized,
+			expectedCalls: 2,
+		},
+		{
+			desc:          "deny decisions are not cached",
+			authorization: "Bearer invalid",
+			expectedCode:  http.StatusUnauthorized,
+			expectedCalls: 3,
+		},
+		{
+			desc:          "allowed with a cookie",
+			authorization: "Bearer session",
+			expectedCode:  http.StatusOK,
+			expectedCalls: 4,
+		},
+		{
+			desc:          "allow decisions setting a cookie are not cached",
+			authorization: "Bearer session",
+			expectedCode:  http.StatusOK,
+			expectedCalls: 5,
+		},
+		{
+			desc:          "requests without key are not cached",
+			expectedCode:  http.StatusUnauthorized,
+			expectedCalls: 6,
+		},
+		{
+			desc:          "requests without key are not served from the cache",
+			expectedCode:  http.StatusUnauthorized,
+			expectedCalls: 7,
+		},
+	}
+
+	// The test cases are run in sequence, each one depending on the cache state left by the previous ones.
+	for _, test := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		if test.authorization != "" {
+			req.Header.Set("Authorization", test.authorization)
+		}
+
+		rw := httptest.NewRecorder()
+		middleware.ServeHTTP(rw, req)
+
+		assert.Equal(t, test.expectedCode, rw.Code, test.desc)
+		if test.expectedBody != "" {
+			assert.Equal(t, test.expectedBody, rw.Body.String(), test.desc)
+		}
+		assert.Equal(t, test.expectedCalls, calls.Load(), test.desc)
+	}
+}
+
+func TestForwardAuth_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.ForwardAuth
+	}{
+		{
+			desc:   "negative retry attempts",
+			config: dynamic.ForwardAuth{Retry: &dynamic.ForwardAuthRetry{Attempts: -1}},
+		},
+		{
+			desc:   "cache without key",
+			config: dynamic.ForwardAuth{Cache: &dynamic.ForwardAuthCache{TTL: ptypes.Duration(time.Minute)}},
+		},
+		{
+			desc:   "cache without TTL",
+			config: dynamic.ForwardAuth{Cache: &dynamic.ForwardAuthCache{Key: "Header(`Authorization`)"}},
+		},
+		{
+			desc:   "cache with an invalid key",
+			config: dynamic.ForwardAuth{Cache: &dynamic.ForwardAuthCache{Key: "Unknown(`Authorization`)", TTL: ptypes.Duration(time.Minute)}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			test.config.Address = "http://auth.example.com"
+
+			_, err := NewForward(context.Background(), http.NotFoundHandler(), test.config, "authTest")
+			assert.Error(t, err)
+		})
+	}
+}
+
 func Test_writeHeader(t *testing.T) {
 	testCases := []struct {
 		name                      string
@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"golang.org/x/sync/singleflight"
+)
+
+// jwksMinRefreshInterval is the minimum interval between two fetches of a key set, successful or not,
+// so that tokens signed with unknown keys cannot make the middleware flood the key set server.
+const jwksMinRefreshInterval = time.Minute
+
+// asymmetricAlgorithms are the signature algorithms accepted for the tokens verified with a key set.
+var asymmetricAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// remoteKeySet is a JSON Web Key Set served at a URL,
+// fetched again when a token is signed with a key it does not hold, to follow the keys rotation.
+type remoteKeySet struct {
+	url    string
+	client *http.Client
+
+	// fetches ensures that a single fetch of the key set is in flight,
+	// the requests signed with an unknown key all waiting for it.
+	fetches singleflight.Group
+
+	mu        sync.RWMutex
+	keys      jose.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+func newRemoteKeySet(url string, client *http.Client) *remoteKeySet {
+	return &remoteKeySet{url: url, client: client}
+}
+
+// verify verifies the signature of the given token, and returns its payload decoded in dest.
+func (s *remoteKeySet) verify(ctx context.Context, token *jwt.JSONWebToken, dest ...interface{}) error {
+	if len(token.Headers) == 0 {
+		return errors.New("token without header")
+	}
+
+	kid := token.Headers[0].KeyID
+
+	keys, err := s.keysFor(ctx, kid)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err = token.Claims(key, dest...); err == nil {
+			return nil
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid token signature: %w", err)
+	}
+
+	return fmt.Errorf("no key matching the token key ID %q", kid)
+}
+
+// keysFor returns the keys matching the given key ID, all the keys if the ID is empty.
+// The known keys are returned right away, the key set being only fetched for an unknown key,
+// at most once per jwksMinRefreshInterval.
+func (s *remoteKeySet) keysFor(ctx context.Context, kid string) ([]jose.JSONWebKey, error) {
+	s.mu.RLock()
+	keys := s.lookup(kid)
+	fetchedAt := s.fetchedAt
+	s.mu.RUnlock()
+
+	if len(keys) > 0 {
+		return keys, nil
+	}
+
+	if !fetchedAt.IsZero() && time.Since(fetchedAt) < jwksMinRefreshInterval {
+		return nil, nil
+	}
+
+	// The fetch is shared by the waiting requests, so it is not canceled with the request starting it.
+	result := s.fetches.DoChan("", func() (interface{}, error) {
+		return nil, s.fetch(context.WithoutCancel(ctx))
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.lookup(kid), nil
+}
+
+func (s *remoteKeySet) lookup(kid string) []jose.JSONWebKey {
+	if kid == "" {
+		return s.keys.Keys
+	}
+
+	return s.keys.Key(kid)
+}
+
+// fetch fetches the key set, and records the time of the attempt, even if it fails,
+// so that an unavailable key set server is not called again before jwksMinRefreshInterval.
+func (s *remoteKeySet) fetch(ctx context.Context) error {
+	s.mu.RLock()
+	fetchedAt := s.fetchedAt
+	s.mu.RUnlock()
+
+	// The key set has been fetched while waiting for the previous fetch to complete.
+	if !fetchedAt.IsZero() && time.Since(fetchedAt) < jwksMinRefreshInterval {
+		return nil
+	}
+
+	keys, err := s.get(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fetchedAt = time.Now()
+	if err != nil {
+		return err
+	}
+
+	s.keys = keys
+
+	return nil
+}
+
+func (s *remoteKeySet) get(ctx context.Context) (jose.JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return jose.JSONWebKeySet{}, fmt.Errorf("creating key set request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return jose.JSONWebKeySet{}, fmt.Errorf("fetching key set: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return jose.JSONWebKeySet{}, fmt.Errorf("fetching key set: unexpected status code %d", resp.StatusCode)
+	}
+
+	var keys jose.JSONWebKeySet
+	if err = json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		return jose.JSONWebKeySet{}, fmt.Errorf("decoding key set: %w", err)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,275 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"github.com/traefik/traefik/v3/pkg/types"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeNameJWT = "JWT"
+
+// hmacAlgorithms are the signature algorithms accepted for the tokens verified with a signing secret.
+var hmacAlgorithms = []jose.SignatureAlgorithm{jose.HS256, jose.HS384, jose.HS512}
+
+// tokenVerifier verifies the signature of a token, and decodes its payload in dest.
+type tokenVerifier interface {
+	verify(ctx context.Context, token *jwt.JSONWebToken, dest ...interface{}) error
+}
+
+// staticKey is a key given in the configuration.
+type staticKey struct {
+	key interface{}
+}
+
+func (k staticKey) verify(_ context.Context, token *jwt.JSONWebToken, dest ...interface{}) error {
+	if err := token.Claims(k.key, dest...); err != nil {
+		return fmt.Errorf("invalid token signature: %w", err)
+	}
+
+	return nil
+}
+
+type jwtClaimsKey struct{}
+
+// GetJWTClaims returns the claims of the token of the request, as verified by a preceding JWT middleware.
+func GetJWTClaims(ctx context.Context) map[string]interface{} {
+	claims, _ := ctx.Value(jwtClaimsKey{}).(map[string]interface{})
+	return claims
+}
+
+type jwtAuth struct {
+	next http.Handler
+	name string
+
+	verifier         tokenVerifier
+	algorithms       []jose.SignatureAlgorithm
+	issuer           string
+	audience         []string
+	headerName       string
+	removeHeader     bool
+	claimsHeaders    map[string]string
+	rejectStatusCode int
+}
+
+// NewJWT creates a JWT validation middleware.
+func NewJWT(ctx context.Context, next http.Handler, config dynamic.JWT, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeNameJWT)
+	logger.Debug().Msg("Creating middleware")
+
+	ja := &jwtAuth{
+		next:             next,
+		name:             name,
+		issuer:           config.Issuer,
+		audience:         config.Audience,
+		headerName:       http.CanonicalHeaderKey(config.HeaderName),
+		removeHeader:     config.RemoveHeader,
+		claimsHeaders:    config.ClaimsHeaders,
+		rejectStatusCode: config.RejectStatusCode,
+	}
+
+	if ja.headerName == "" {
+		ja.headerName = authorizationHeader
+	}
+
+	if ja.rejectStatusCode == 0 {
+		ja.rejectStatusCode = http.StatusUnauthorized
+	}
+
+	if ja.rejectStatusCode < http.StatusBadRequest || ja.rejectStatusCode > 599 {
+		return nil, fmt.Errorf("invalid rejectStatusCode %d: must be an error status code", ja.rejectStatusCode)
+	}
+
+	var sources int
+	for _, source := range []string{config.SigningSecret, config.PublicKey, config.JWKSURL} {
+		if source != "" {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		return nil, errors.New("exactly one of signingSecret, publicKey or jwksURL must be set")
+	}
+
+	switch {
+	case config.SigningSecret != "":
+		// The key of an HMAC algorithm must be at least as long as its hash output.
+		if len(config.SigningSecret) < 32 {
+			return nil, errors.New("signingSecret must be at least 32 bytes long")
+		}
+
+		ja.verifier = staticKey{key: []byte(config.SigningSecret)}
+		ja.algorithms = hmacAlgorithms
+
+	case config.PublicKey != "":
+		key, err := parsePublicKey(config.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("parsing public key: %w", err)
+		}
+
+		ja.verifier = staticKey{key: key}
+		ja.algorithms = asymmetricAlgorithms
+
+	default:
+		client := &http.Client{Timeout: 30 * time.Second}
+
+		if config.TLS != nil {
+			clientTLS := &types.ClientTLS{
+				CA:                 config.TLS.CA,
+				Cert:               config.TLS.Cert,
+				Key:                config.TLS.Key,
+				InsecureSkipVerify: config.TLS.InsecureSkipVerify,
+			}
+
+			tlsConfig, err := clientTLS.CreateTLSConfig(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create client TLS configuration: %w", err)
+			}
+
+			tr := http.DefaultTransport.(*http.Transport).Clone()
+			tr.TLSClientConfig = tlsConfig
+			client.Transport = tr
+		}
+
+		ja.verifier = newRemoteKeySet(config.JWKSURL, client)
+		ja.algorithms = asymmetricAlgorithms
+	}
+
+	return ja, nil
+}
+
+func (j *jwtAuth) GetTracingInformation() (string, string, trace.SpanKind) {
+	return j.name, typeNameJWT, trace.SpanKindInternal
+}
+
+func (j *jwtAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), j.name, typeNameJWT)
+
+	rawToken := j.extractToken(req)
+	if rawToken == "" {
+		logger.Debug().Msg("Missing token")
+		j.reject(rw, req, "Missing token", false)
+		return
+	}
+
+	claims, err := j.validate(req.Context(), rawToken)
+	if err != nil {
+		logger.Debug().Err(err).Msg("Invalid token")
+		j.reject(rw, req, "Invalid token", true)
+		return
+	}
+
+	if logData := accesslog.GetLogData(req); logData != nil {
+		if sub, ok := claims["sub"].(string); ok {
+			logData.Core[accesslog.ClientUsername] = sub
+		}
+	}
+
+	if j.removeHeader {
+		req.Header.Del(j.headerName)
+	}
+
+	for header, claim := range j.claimsHeaders {
+		req.Header.Del(header)
+
+		if value, ok := claimValue(claims[claim]); ok {
+			req.Header.Set(header, value)
+		}
+	}
+
+	j.next.ServeHTTP(rw, req.WithContext(context.WithValue(req.Context(), jwtClaimsKey{}, claims)))
+}
+
+// extractToken returns the token of the request, read from the bearer credentials of the Authorization header,
+// or from the configured header.
+func (j *jwtAuth) extractToken(req *http.Request) string {
+	value := strings.TrimSpace(req.Header.Get(j.headerName))
+
+	scheme, token, found := strings.Cut(value, " ")
+	if found && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+
+	if j.headerName == authorizationHeader {
+		return ""
+	}
+
+	return value
+}
+
+// validate verifies the signature and the registered claims of the token, and returns all its claims.
+func (j *jwtAuth) validate(ctx context.Context, rawToken string) (map[string]interface{}, error) {
+	token, err := jwt.ParseSigned(rawToken, j.algorithms)
+	if err != nil {
+		return nil, err
+	}
+
+	var registered jwt.Claims
+	claims := make(map[string]interface{})
+	if err = j.verifier.verify(ctx, token, &registered, &claims); err != nil {
+		return nil, err
+	}
+
+	err = registered.Validate(jwt.Expected{
+		Issuer:      j.issuer,
+		AnyAudience: j.audience,
+		Time:        time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (j *jwtAuth) reject(rw http.ResponseWriter, req *http.Request, msg string, invalidToken bool) {
+	observability.SetStatusErrorf(req.Context(), "%s", msg)
+
+	if j.rejectStatusCode == http.StatusUnauthorized {
+		challenge := "Bearer"
+		if invalidToken {
+			challenge += ` error="invalid_token"`
+		}
+
+		rw.Header().Set("WWW-Authenticate", challenge)
+	}
+
+	http.Error(rw, http.StatusText(j.rejectStatusCode), j.rejectStatusCode)
+}
+
+// parsePublicKey parses a PEM encoded public key or certificate, given as a file path or as content.
+func parsePublicKey(publicKey string) (interface{}, error) {
+	content, err := types.FileOrContent(publicKey).Read()
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return cert.PublicKey, nil
+	}
+
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
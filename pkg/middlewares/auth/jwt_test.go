@@ -0,0 +1,452 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+const testJWTSecret = "a-secret-of-at-least-32-bytes-long"
+
+func TestJWT_signingSecret(t *testing.T) {
+	claims := func(mutate func(map[string]interface{})) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss": "https://issuer.example.com",
+			"aud": []string{"api", "other"},
+			"sub": "user1",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+		if mutate != nil {
+			mutate(c)
+		}
+		return c
+	}
+
+	testCases := []struct {
+		desc           string
+		config         dynamic.JWT
+		header         string
+		value          string
+		expectedStatus int
+		expectedAuth   string
+	}{
+		{
+			desc:           "valid token",
+			config:         dynamic.JWT{},
+			header:         "Authorization",
+			value:          "Bearer " + signHMAC(t, testJWTSecret, claims(nil)),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "missing token",
+			config:         dynamic.JWT{},
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   "Bearer",
+		},
+		{
+			desc:           "not a bearer token",
+			config:         dynamic.JWT{},
+			header:         "Authorization",
+			value:          "Basic dXNlcjpwYXNz",
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   "Bearer",
+		},
+		{
+			desc:           "invalid signature",
+			config:         dynamic.JWT{},
+			header:         "Authorization",
+			value:          "Bearer " + signHMAC(t, "another-secret-of-at-least-32-bytes", claims(nil)),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:   "expired token",
+			config: dynamic.JWT{},
+			header: "Authorization",
+			value: "Bearer " + signHMAC(t, testJWTSecret, claims(func(c map[string]interface{}) {
+				c["exp"] = time.Now().Add(-time.Hour).Unix()
+			})),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "expected issuer and audience",
+			config:         dynamic.JWT{Issuer: "https://issuer.example.com", Audience: []string{"api"}},
+			header:         "Authorization",
+			value:          "Bearer " + signHMAC(t, testJWTSecret, claims(nil)),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "unexpected issuer",
+			config:         dynamic.JWT{Issuer: "https://other.example.com"},
+			header:         "Authorization",
+			value:          "Bearer " + signHMAC(t, testJWTSecret, claims(nil)),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "unexpected audience",
+			config:         dynamic.JWT{Audience: []string{"admin"}},
+			header:         "Authorization",
+			value:          "Bearer " + signHMAC(t, testJWTSecret, claims(nil)),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "custom header",
+			config:         dynamic.JWT{HeaderName: "X-Token"},
+			header:         "X-Token",
+			value:          signHMAC(t, testJWTSecret, claims(nil)),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "custom reject status code",
+			config:         dynamic.JWT{RejectStatusCode: http.StatusForbidden},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			config := test.config
+			config.SigningSecret = testJWTSecret
+
+			handler, err := NewJWT(context.Background(), next, config, "jwt")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			if test.header != "" {
+				req.Header.Set(test.header, test.value)
+			}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+			assert.Equal(t, test.expectedAuth, rw.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func TestJWT_publicKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	handler, err := NewJWT(context.Background(), next, dynamic.JWT{
+		PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}, "jwt")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, jose.ES256, key, "", map[string]interface{}{"sub": "user1"}))
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	// A token signed with the public key as HMAC secret must be rejected.
+	req = httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, jose.HS256, der, "", map[string]interface{}{"sub": "user1"}))
+
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+}
+
+func TestJWT_jwksRotation(t *testing.T) {
+	key1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	key2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var (
+		mu      sync.Mutex
+		keys    = []jose.JSONWebKey{{Key: &key1.PublicKey, KeyID: "key1", Algorithm: string(jose.ES256), Use: "sig"}}
+		fetches int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		fetches++
+		_ = json.NewEncoder(rw).Encode(jose.JSONWebKeySet{Keys: keys})
+	}))
+	t.Cleanup(server.Close)
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	handler, err := NewJWT(context.Background(), next, dynamic.JWT{JWKSURL: server.URL}, "jwt")
+	require.NoError(t, err)
+
+	serve := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+
+		return rw.Code
+	}
+
+	token1 := signToken(t, jose.ES256, key1, "key1", map[string]interface{}{"sub": "user1"})
+	token2 := signToken(t, jose.ES256, key2, "key2", map[string]interface{}{"sub": "user1"})
+
+	assert.Equal(t, http.StatusOK, serve(token1))
+	assert.Equal(t, http.StatusOK, serve(token1))
+	assert.Equal(t, 1, fetches)
+
+	mu.Lock()
+	keys = []jose.JSONWebKey{{Key: &key2.PublicKey, KeyID: "key2", Algorithm: string(jose.ES256), Use: "sig"}}
+	mu.Unlock()
+
+	// The key set is not fetched again before the minimum refresh interval.
+	assert.Equal(t, http.StatusUnauthorized, serve(token2))
+	assert.Equal(t, 1, fetches)
+
+	keySet := handler.(*jwtAuth).verifier.(*remoteKeySet)
+	keySet.mu.Lock()
+	keySet.fetchedAt = time.Now().Add(-2 * jwksMinRefreshInterval)
+	keySet.mu.Unlock()
+
+	assert.Equal(t, http.StatusOK, serve(token2))
+	assert.Equal(t, 2, fetches)
+}
+
+func TestJWT_jwksFetchFailure(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	handler, err := NewJWT(context.Background(), next, dynamic.JWT{JWKSURL: server.URL}, "jwt")
+	require.NoError(t, err)
+
+	for i := range 3 {
+		token := signToken(t, jose.ES256, key, fmt.Sprintf("key%d", i), map[string]interface{}{"sub": "user1"})
+
+		req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	}
+
+	// The failed fetch is not attempted again before the minimum refresh interval.
+	assert.Equal(t, int32(1), fetches.Load())
+}
+
+func TestJWT_jwksKnownKeysDuringFetch(t *testing.T) {
+	key1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	key2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		keys := []jose.JSONWebKey{{Key: &key1.PublicKey, KeyID: "key1", Algorithm: string(jose.ES256), Use: "sig"}}
+
+		// The fetches following the first one hang until released.
+		if fetches.Add(1) > 1 {
+			<-release
+			keys = append(keys, jose.JSONWebKey{Key: &key2.PublicKey, KeyID: "key2", Algorithm: string(jose.ES256), Use: "sig"})
+		}
+
+		_ = json.NewEncoder(rw).Encode(jose.JSONWebKeySet{Keys: keys})
+	}))
+	t.Cleanup(server.Close)
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	handler, err := NewJWT(context.Background(), next, dynamic.JWT{JWKSURL: server.URL}, "jwt")
+	require.NoError(t, err)
+
+	serve := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+
+		return rw.Code
+	}
+
+	token1 := signToken(t, jose.ES256, key1, "key1", map[string]interface{}{"sub": "user1"})
+	token2 := signToken(t, jose.ES256, key2, "key2", map[string]interface{}{"sub": "user2"})
+
+	assert.Equal(t, http.StatusOK, serve(token1))
+
+	keySet := handler.(*jwtAuth).verifier.(*remoteKeySet)
+	keySet.mu.Lock()
+	keySet.fetchedAt = time.Now().Add(-2 * jwksMinRefreshInterval)
+	keySet.mu.Unlock()
+
+	var wg sync.WaitGroup
+	codes := make([]int, 2)
+	for i := range codes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes[i] = serve(token2)
+		}()
+	}
+
+	require.Eventually(t, func() bool { return fetches.Load() == 2 }, time.Second, 10*time.Millisecond)
+
+	// The requests signed with a known key do not wait for the fetch in flight.
+	assert.Equal(t, http.StatusOK, serve(token1))
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK}, codes)
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestJWT_claimsHeaders(t *testing.T) {
+	var forwarded http.Header
+	var claims map[string]interface{}
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwarded = req.Header.Clone()
+		claims = GetJWTClaims(req.Context())
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	handler, err := NewJWT(context.Background(), next, dynamic.JWT{
+		SigningSecret: testJWTSecret,
+		RemoveHeader:  true,
+		ClaimsHeaders: map[string]string{
+			"X-User":   "sub",
+			"X-Groups": "groups",
+			"X-Email":  "email",
+		},
+	}, "jwt")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set("Authorization", "Bearer "+signHMAC(t, testJWTSecret, map[string]interface{}{
+		"sub":    "user1",
+		"groups": []string{"admin", "dev"},
+	}))
+	req.Header.Set("X-Email", "spoofed@example.com")
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	require.Equal(t, http.StatusOK, rw.Code)
+	assert.Empty(t, forwarded.Get("Authorization"))
+	assert.Equal(t, "user1", forwarded.Get("X-User"))
+	assert.Equal(t, "admin,dev", forwarded.Get("X-Groups"))
+	assert.Empty(t, forwarded.Values("X-Email"))
+	assert.Equal(t, "user1", claims["sub"])
+}
+
+func TestNewJWT_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.JWT
+	}{
+		{
+			desc:   "no key",
+			config: dynamic.JWT{},
+		},
+		{
+			desc:   "several keys",
+			config: dynamic.JWT{SigningSecret: testJWTSecret, JWKSURL: "http://example.com/jwks"},
+		},
+		{
+			desc:   "short signing secret",
+			config: dynamic.JWT{SigningSecret: "secret"},
+		},
+		{
+			desc:   "invalid public key",
+			config: dynamic.JWT{PublicKey: "not a key"},
+		},
+		{
+			desc:   "invalid reject status code",
+			config: dynamic.JWT{SigningSecret: testJWTSecret, RejectStatusCode: http.StatusFound},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewJWT(context.Background(), http.NotFoundHandler(), test.config, "jwt")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func signHMAC(t *testing.T, secret string, claims map[string]interface{}) string {
+	t.Helper()
+
+	return signToken(t, jose.HS256, []byte(secret), "", claims)
+}
+
+func signToken(t *testing.T, alg jose.SignatureAlgorithm, key interface{}, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	opts := (&jose.SignerOptions{}).WithType("JWT")
+	if kid != "" {
+		opts = opts.WithHeader("kid", kid)
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, opts)
+	require.NoError(t, err)
+
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	require.NoError(t, err)
+
+	return token
+}
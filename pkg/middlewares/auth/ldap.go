@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/patrickmn/go-cache"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/types"
+)
+
+const (
+	ldapDefaultUserFilter = "(uid=%s)"
+	ldapDefaultPoolSize   = 10
+	ldapTimeout           = 10 * time.Second
+	// ldapMaxCacheSize bounds the number of cached credentials.
+	ldapMaxCacheSize = 10000
+)
+
+// ldapConn is the subset of an LDAP connection used to authenticate the users.
+type ldapConn interface {
+	Bind(username, password string) error
+	UnauthenticatedBind(username string) error
+	Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close() error
+	IsClosing() bool
+}
+
+// ldapAuthenticator validates the credentials of the users against an LDAP server,
+// by searching the user with the bind DN account, and then binding as the user.
+type ldapAuthenticator struct {
+	dial func() (ldapConn, error)
+
+	bindDN       string
+	bindPassword string
+	baseDN       string
+	userFilter   string
+	groupFilter  string
+
+	// conns holds the idle connections.
+	conns chan ldapConn
+
+	// credentials holds the digests of the validated credentials, by username.
+	credentials *cache.Cache
+	digestKey   []byte
+}
+
+func newLDAPAuthenticator(ctx context.Context, config dynamic.BasicAuthLDAP) (*ldapAuthenticator, error) {
+	serverURL, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing LDAP URL: %w", err)
+	}
+
+	if serverURL.Scheme != "ldap" && serverURL.Scheme != "ldaps" {
+		return nil, fmt.Errorf("unsupported LDAP URL scheme %q", serverURL.Scheme)
+	}
+
+	if config.StartTLS && serverURL.Scheme == "ldaps" {
+		return nil, errors.New("startTLS cannot be used with the ldaps scheme")
+	}
+
+	userFilter := config.UserFilter
+	if userFilter == "" {
+		userFilter = ldapDefaultUserFilter
+	}
+
+	if strings.Count(userFilter, "%s") != 1 {
+		return nil, fmt.Errorf("userFilter %q must contain the %%s username placeholder once", userFilter)
+	}
+
+	poolSize := config.PoolSize
+	if poolSize <= 0 {
+		poolSize = ldapDefaultPoolSize
+	}
+
+	tlsConfig := &tls.Config{}
+	if config.TLS != nil {
+		clientTLS := &types.ClientTLS{
+			CA:                 config.TLS.CA,
+			Cert:               config.TLS.Cert,
+			Key:                config.TLS.Key,
+			InsecureSkipVerify: config.TLS.InsecureSkipVerify,
+		}
+
+		tlsConfig, err = clientTLS.CreateTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create client TLS configuration: %w", err)
+		}
+	}
+
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = serverURL.Hostname()
+	}
+
+	a := &ldapAuthenticator{
+		bindDN:       config.BindDN,
+		bindPassword: config.BindPassword,
+		baseDN:       config.BaseDN,
+		userFilter:   userFilter,
+		groupFilter:  config.GroupFilter,
+		conns:        make(chan ldapConn, poolSize),
+	}
+
+	a.dial = func() (ldapConn, error) {
+		conn, err := ldap.DialURL(config.URL,
+			ldap.DialWithDialer(&net.Dialer{Timeout: ldapTimeout}),
+			ldap.DialWithTLSConfig(tlsConfig),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		conn.SetTimeout(ldapTimeout)
+
+		if config.StartTLS {
+			if err := conn.StartTLS(tlsConfig); err != nil {
+				_ = conn.Close()
+				return nil, fmt.Errorf("starting TLS: %w", err)
+			}
+		}
+
+		return conn, nil
+	}
+
+	if ttl := time.Duration(config.CacheTTL); ttl > 0 {
+		a.digestKey = make([]byte, 32)
+		if _, err := rand.Read(a.digestKey); err != nil {
+			return nil, fmt.Errorf("generating cache key: %w", err)
+		}
+
+		a.credentials = cache.New(ttl, ttl)
+	}
+
+	return a, nil
+}
+
+// authenticate returns whether the given credentials are valid.
+// An error is returned when the LDAP server cannot validate them.
+func (a *ldapAuthenticator) authenticate(user, password string) (bool, error) {
+	// An empty password would result in an unauthenticated bind, which succeeds for any user.
+	if user == "" || password == "" {
+		return false, nil
+	}
+
+	var digest []byte
+	if a.credentials != nil {
+		digest = a.digest(user, password)
+
+		if cached, ok := a.credentials.Get(user); ok && hmac.Equal(cached.([]byte), digest) {
+			return true, nil
+		}
+	}
+
+	conn, err := a.getConn()
+	if err != nil {
+		return false, fmt.Errorf("connecting to the LDAP server: %w", err)
+	}
+
+	ok, err := a.check(conn, user, password)
+	if err != nil {
+		_ = conn.Close()
+		return false, err
+	}
+
+	a.putConn(conn)
+
+	if ok && a.credentials != nil && a.credentials.ItemCount() < ldapMaxCacheSize {
+		a.credentials.SetDefault(user, digest)
+	}
+
+	return ok, nil
+}
+
+// check searches the user matching the filters, and binds as the user to validate the password.
+func (a *ldapAuthenticator) check(conn ldapConn, user, password string) (bool, error) {
+	var err error
+	if a.bindDN != "" {
+		err = conn.Bind(a.bindDN, a.bindPassword)
+	} else {
+		err = conn.UnauthenticatedBind("")
+	}
+	if err != nil {
+		return false, fmt.Errorf("binding with the bind DN: %w", err)
+	}
+
+	filter := fmt.Sprintf(a.userFilter, ldap.EscapeFilter(user))
+	if a.groupFilter != "" {
+		filter = "(&" + filter + a.groupFilter + ")"
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		a.baseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2, int(ldapTimeout.Seconds()), false,
+		filter, []string{"dn"}, nil,
+	))
+	if err != nil {
+		// Several users match the filter.
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("searching the user: %w", err)
+	}
+
+	if len(result.Entries) != 1 {
+		return false, nil
+	}
+
+	if err := conn.Bind(result.Entries[0].DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("binding as the user: %w", err)
+	}
+
+	return true, nil
+}
+
+func (a *ldapAuthenticator) getConn() (ldapConn, error) {
+	for {
+		select {
+		case conn := <-a.conns:
+			if conn.IsClosing() {
+				continue
+			}
+
+			return conn, nil
+
+		default:
+			return a.dial()
+		}
+	}
+}
+
+func (a *ldapAuthenticator) putConn(conn ldapConn) {
+	select {
+	case a.conns <- conn:
+	default:
+		_ = conn.Close()
+	}
+}
+
+func (a *ldapAuthenticator) digest(user, password string) []byte {
+	mac := hmac.New(sha256.New, a.digestKey)
+	mac.Write([]byte(user))
+	mac.Write([]byte{0})
+	mac.Write([]byte(password))
+
+	return mac.Sum(nil)
+}
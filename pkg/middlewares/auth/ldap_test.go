@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// fakeLDAPDirectory is an LDAP directory holding the passwords by DN, and the DNs returned by filter.
+type fakeLDAPDirectory struct {
+	passwords map[string]string
+	filters   map[string][]string
+
+	dials int
+	binds int
+}
+
+func (d *fakeLDAPDirectory) dial() (ldapConn, error) {
+	d.dials++
+	return &fakeLDAPConn{directory: d}, nil
+}
+
+type fakeLDAPConn struct {
+	directory *fakeLDAPDirectory
+	closed    bool
+}
+
+func (c *fakeLDAPConn) Bind(username, password string) error {
+	c.directory.binds++
+
+	if expected, ok := c.directory.passwords[username]; !ok || expected != password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, nil)
+	}
+
+	return nil
+}
+
+func (c *fakeLDAPConn) UnauthenticatedBind(username string) error {
+	return nil
+}
+
+func (c *fakeLDAPConn) Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	result := &ldap.SearchResult{}
+	for _, dn := range c.directory.filters[searchRequest.Filter] {
+		result.Entries = append(result.Entries, ldap.NewEntry(dn, nil))
+	}
+
+	if len(result.Entries) > searchRequest.SizeLimit {
+		return result, ldap.NewError(ldap.LDAPResultSizeLimitExceeded, nil)
+	}
+
+	return result, nil
+}
+
+func (c *fakeLDAPConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (c *fakeLDAPConn) IsClosing() bool {
+	return c.closed
+}
+
+func TestBasicAuthLDAP(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		user           string
+		password       string
+		expectedStatus int
+	}{
+		{
+			desc:           "valid credentials",
+			user:           "alice",
+			password:       "secret",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "invalid password",
+			user:           "alice",
+			password:       "wrong",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "empty password",
+			user:           "alice",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "user not in the group",
+			user:           "bob",
+			password:       "secret",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "ambiguous user",
+			user:           "carol",
+			password:       "secret",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "declared user",
+			user:           "test",
+			password:       "test",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "declared user with the LDAP password",
+			user:           "test",
+			password:       "ldap",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			directory := &fakeLDAPDirectory{
+				passwords: map[string]string{
+					"cn=admin,dc=example,dc=org":            "admin",
+					"uid=alice,ou=people,dc=example,dc=org": "secret",
+					"uid=bob,ou=people,dc=example,dc=org":   "secret",
+					"uid=test,ou=people,dc=example,dc=org":  "ldap",
+				},
+				filters: map[string][]string{
+					"(&(uid=alice)(memberOf=cn=admins,dc=example,dc=org))": {"uid=alice,ou=people,dc=example,dc=org"},
+					"(&(uid=carol)(memberOf=cn=admins,dc=example,dc=org))": {"uid=carol,ou=people,dc=example,dc=org", "uid=carol,ou=guests,dc=example,dc=org"},
+					"(&(uid=test)(memberOf=cn=admins,dc=example,dc=org))":  {"uid=test,ou=people,dc=example,dc=org"},
+				},
+			}
+
+			handler := newLDAPBasicAuth(t, directory, dynamic.BasicAuthLDAP{
+				BindDN:       "cn=admin,dc=example,dc=org",
+				BindPassword: "admin",
+				GroupFilter:  "(memberOf=cn=admins,dc=example,dc=org)",
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+			req.SetBasicAuth(test.user, test.password)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+		})
+	}
+}
+
+func TestBasicAuthLDAP_poolAndCache(t *testing.T) {
+	directory := &fakeLDAPDirectory{
+		passwords: map[string]string{
+			"uid=alice,ou=people,dc=example,dc=org": "secret",
+		},
+		filters: map[string][]string{
+			"(uid=alice)": {"uid=alice,ou=people,dc=example,dc=org"},
+		},
+	}
+
+	handler := newLDAPBasicAuth(t, directory, dynamic.BasicAuthLDAP{
+		PoolSize: 1,
+		CacheTTL: ptypes.Duration(time.Minute),
+	})
+
+	serve := func(password string) int {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+		req.SetBasicAuth("alice", password)
+
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+
+		return rw.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve("wrong"))
+	assert.Equal(t, http.StatusOK, serve("secret"))
+	assert.Equal(t, 1, directory.dials)
+	assert.Equal(t, 2, directory.binds)
+
+	// The validated credentials are cached.
+	assert.Equal(t, http.StatusOK, serve("secret"))
+	assert.Equal(t, 2, directory.binds)
+
+	// Other credentials are validated again.
+	assert.Equal(t, http.StatusUnauthorized, serve("wrong"))
+	assert.Equal(t, 3, directory.binds)
+	assert.Equal(t, 1, directory.dials)
+}
+
+func TestNewBasic_invalidLDAP(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.BasicAuthLDAP
+	}{
+		{
+			desc:   "unsupported scheme",
+			config: dynamic.BasicAuthLDAP{URL: "http://ldap.example.org"},
+		},
+		{
+			desc:   "startTLS with ldaps",
+			config: dynamic.BasicAuthLDAP{URL: "ldaps://ldap.example.org", StartTLS: true},
+		},
+		{
+			desc:   "user filter without placeholder",
+			config: dynamic.BasicAuthLDAP{URL: "ldap://ldap.example.org", UserFilter: "(uid=alice)"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewBasic(context.Background(), http.NotFoundHandler(), dynamic.BasicAuth{LDAP: &test.config}, "authName")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func newLDAPBasicAuth(t *testing.T, directory *fakeLDAPDirectory, config dynamic.BasicAuthLDAP) http.Handler {
+	t.Helper()
+
+	config.URL = "ldap://ldap.example.org"
+
+	handler, err := NewBasic(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), dynamic.BasicAuth{
+		Users: []string{"test:$apr1$H6uskkkW$IgXLP6ewTrSuBkTrqE8wj/"},
+		LDAP:  &config,
+	}, "authName")
+	require.NoError(t, err)
+
+	handler.(*basicAuth).ldap.dial = directory.dial
+
+	return handler
+}
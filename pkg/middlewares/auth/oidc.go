@@ -0,0 +1,583 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"github.com/traefik/traefik/v3/pkg/types"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
+)
+
+const typeNameOIDC = "OIDC"
+
+const (
+	defaultOIDCRedirectPath = "/oauth2/callback"
+	defaultOIDCLogoutPath   = "/oauth2/logout"
+	defaultOIDCCookieName   = "traefik_oidc"
+
+	// oidcStateMaxAge is the duration, in seconds, given to the user to authenticate at the provider.
+	oidcStateMaxAge = 600
+)
+
+var defaultOIDCScopes = []string{"openid", "profile", "email"}
+
+// oidcProvider is the configuration of an OpenID Connect provider, discovered from its issuer URL.
+type oidcProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	EndSessionEndpoint    string `json:"end_session_endpoint"`
+
+	keys *remoteKeySet
+}
+
+// oidcClaims are the claims of an ID token.
+type oidcClaims struct {
+	jwt.Claims
+
+	Nonce string `json:"nonce"`
+}
+
+type oidcAuth struct {
+	next http.Handler
+	name string
+
+	issuer                string
+	clientID              string
+	clientSecret          string
+	scopes                []string
+	redirectPath          string
+	logoutPath            string
+	postLogoutRedirectURI string
+	claimsHeaders         map[string]string
+	forwardAccessToken    bool
+
+	cookieName string
+	cookieAge  int
+	store      *cookieStore
+	stateStore *cookieStore
+
+	client *http.Client
+
+	providerMu sync.Mutex
+	provider   *oidcProvider
+}
+
+// NewOIDC creates an OpenID Connect authentication middleware.
+func NewOIDC(ctx context.Context, next http.Handler, config dynamic.OIDC, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeNameOIDC)
+	logger.Debug().Msg("Creating middleware")
+
+	if config.Issuer == "" {
+		return nil, errors.New("issuer is required")
+	}
+
+	if config.ClientID == "" {
+		return nil, errors.New("clientID is required")
+	}
+
+	if config.SessionSecret == "" {
+		return nil, errors.New("sessionSecret is required")
+	}
+
+	cookie := dynamic.Cookie{Name: defaultOIDCCookieName, Secure: true, HTTPOnly: true, SameSite: "lax"}
+	if config.SessionCookie != nil {
+		// The configured attributes are applied over the secure defaults, which cannot be disabled.
+		if config.SessionCookie.Name != "" {
+			cookie.Name = config.SessionCookie.Name
+		}
+
+		if config.SessionCookie.SameSite != "" {
+			cookie.SameSite = config.SessionCookie.SameSite
+		}
+
+		cookie.MaxAge = config.SessionCookie.MaxAge
+	}
+
+	store, err := newCookieStore(config.SessionSecret, cookie)
+	if err != nil {
+		return nil, fmt.Errorf("creating session cookie store: %w", err)
+	}
+
+	// The state cookie must be sent along the top-level redirect back from the provider,
+	// which the strict same site policy prevents.
+	stateCookie := cookie
+	stateCookie.SameSite = "lax"
+
+	oa := &oidcAuth{
+		next:                  next,
+		name:                  name,
+		issuer:                strings.TrimSuffix(config.Issuer, "/"),
+		clientID:              config.ClientID,
+		clientSecret:          config.ClientSecret,
+		scopes:                config.Scopes,
+		redirectPath:          config.RedirectPath,
+		logoutPath:            config.LogoutPath,
+		postLogoutRedirectURI: config.PostLogoutRedirectURI,
+		claimsHeaders:         config.ClaimsHeaders,
+		forwardAccessToken:    config.ForwardAccessToken,
+		cookieName:            cookie.Name,
+		cookieAge:             cookie.MaxAge,
+		store:                 store,
+		stateStore:            store.withCookie(stateCookie),
+		client:                &http.Client{Timeout: 30 * time.Second},
+	}
+
+	if len(oa.scopes) == 0 {
+		oa.scopes = defaultOIDCScopes
+	}
+
+	if oa.redirectPath == "" {
+		oa.redirectPath = defaultOIDCRedirectPath
+	}
+
+	if oa.logoutPath == "" {
+		oa.logoutPath = defaultOIDCLogoutPath
+	}
+
+	if oa.postLogoutRedirectURI == "" {
+		oa.postLogoutRedirectURI = "/"
+	}
+
+	if config.TLS != nil {
+		clientTLS := &types.ClientTLS{
+			CA:                 config.TLS.CA,
+			Cert:               config.TLS.Cert,
+			Key:                config.TLS.Key,
+			InsecureSkipVerify: config.TLS.InsecureSkipVerify,
+		}
+
+		tlsConfig, err := clientTLS.CreateTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create client TLS configuration: %w", err)
+		}
+
+		tr := http.DefaultTransport.(*http.Transport).Clone()
+		tr.TLSClientConfig = tlsConfig
+		oa.client.Transport = tr
+	}
+
+	return oa, nil
+}
+
+func (o *oidcAuth) GetTracingInformation() (string, string, trace.SpanKind) {
+	return o.name, typeNameOIDC, trace.SpanKindInternal
+}
+
+func (o *oidcAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), o.name, typeNameOIDC)
+
+	provider, err := o.getProvider(req.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to discover the OpenID Connect provider")
+		observability.SetStatusErrorf(req.Context(), "Unable to discover the OpenID Connect provider: %v", err)
+
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	switch req.URL.Path {
+	case o.redirectPath:
+		o.handleCallback(rw, req, provider)
+		return
+
+	case o.logoutPath:
+		o.handleLogout(rw, req, provider)
+		return
+	}
+
+	var session oidcSession
+	if err = o.store.load(req, o.cookieName, &session); err != nil {
+		if !errors.Is(err, http.ErrNoCookie) {
+			logger.Debug().Err(err).Msg("Invalid session cookie")
+		}
+
+		o.authenticate(rw, req, provider)
+		return
+	}
+
+	if !session.Expiry.IsZero() && time.Now().After(session.Expiry) {
+		if err = o.refresh(req.Context(), provider, &session); err != nil {
+			logger.Debug().Err(err).Msg("Unable to refresh the session")
+
+			o.store.clear(rw, req, o.cookieName)
+			o.authenticate(rw, req, provider)
+			return
+		}
+
+		if err = o.store.save(rw, req, o.cookieName, session, o.cookieAge); err != nil {
+			logger.Error().Err(err).Msg("Unable to save the session")
+		}
+	}
+
+	claims, err := unverifiedClaims(session.IDToken)
+	if err != nil {
+		logger.Debug().Err(err).Msg("Invalid ID token in session")
+
+		o.store.clear(rw, req, o.cookieName)
+		o.authenticate(rw, req, provider)
+		return
+	}
+
+	if logData := accesslog.GetLogData(req); logData != nil {
+		if sub, ok := claims["sub"].(string); ok {
+			logData.Core[accesslog.ClientUsername] = sub
+		}
+	}
+
+	for header, claim := range o.claimsHeaders {
+		req.Header.Del(header)
+
+		if value, ok := claimValue(claims[claim]); ok {
+			req.Header.Set(header, value)
+		}
+	}
+
+	if o.forwardAccessToken && session.AccessToken != "" {
+		req.Header.Set(authorizationHeader, "Bearer "+session.AccessToken)
+	}
+
+	o.next.ServeHTTP(rw, req)
+}
+
+// authenticate redirects the user to the provider, or rejects the requests which are not navigations.
+func (o *oidcAuth) authenticate(rw http.ResponseWriter, req *http.Request, provider *oidcProvider) {
+	logger := middlewares.GetLogger(req.Context(), o.name, typeNameOIDC)
+
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		observability.SetStatusErrorf(req.Context(), "Authentication required")
+
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	state := oidcState{
+		State:       randomString(),
+		Nonce:       randomString(),
+		Verifier:    oauth2.GenerateVerifier(),
+		RedirectURI: req.URL.RequestURI(),
+	}
+
+	if err := o.stateStore.save(rw, req, o.stateCookieName(), state, oidcStateMaxAge); err != nil {
+		logger.Error().Err(err).Msg("Unable to save the authentication state")
+		observability.SetStatusErrorf(req.Context(), "Unable to save the authentication state: %v", err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	authURL := o.oauth2Config(req, provider).AuthCodeURL(state.State,
+		oauth2.S256ChallengeOption(state.Verifier),
+		oauth2.SetAuthURLParam("nonce", state.Nonce),
+	)
+
+	http.Redirect(rw, req, authURL, http.StatusFound)
+}
+
+// handleCallback exchanges the authorization code against the tokens, and creates the session.
+func (o *oidcAuth) handleCallback(rw http.ResponseWriter, req *http.Request, provider *oidcProvider) {
+	logger := middlewares.GetLogger(req.Context(), o.name, typeNameOIDC)
+
+	var state oidcState
+	if err := o.stateStore.load(req, o.stateCookieName(), &state); err != nil {
+		logger.Debug().Err(err).Msg("Invalid authentication state cookie")
+		o.unauthorized(rw, req, "Invalid authentication state")
+		return
+	}
+
+	o.stateStore.clear(rw, req, o.stateCookieName())
+
+	query := req.URL.Query()
+
+	if errCode := query.Get("error"); errCode != "" {
+		logger.Debug().Str("error", errCode).Str("description", query.Get("error_description")).Msg("Authentication failed at the provider")
+		o.unauthorized(rw, req, "Authentication failed")
+		return
+	}
+
+	if query.Get("state") != state.State {
+		o.unauthorized(rw, req, "Invalid authentication state")
+		return
+	}
+
+	ctx := context.WithValue(req.Context(), oauth2.HTTPClient, o.client)
+
+	token, err := o.oauth2Config(req, provider).Exchange(ctx, query.Get("code"), oauth2.VerifierOption(state.Verifier))
+	if err != nil {
+		logger.Debug().Err(err).Msg("Unable to exchange the authorization code")
+		o.unauthorized(rw, req, "Unable to exchange the authorization code")
+		return
+	}
+
+	rawIDToken, _ := token.Extra("id_token").(string)
+
+	if err = o.verifyIDToken(req.Context(), provider, rawIDToken, state.Nonce); err != nil {
+		logger.Debug().Err(err).Msg("Invalid ID token")
+		o.unauthorized(rw, req, "Invalid ID token")
+		return
+	}
+
+	session := oidcSession{
+		IDToken:      rawIDToken,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Expiry:       token.Expiry,
+	}
+
+	if err = o.store.save(rw, req, o.cookieName, session, o.cookieAge); err != nil {
+		logger.Error().Err(err).Msg("Unable to save the session")
+		observability.SetStatusErrorf(req.Context(), "Unable to save the session: %v", err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	redirectURI := state.RedirectURI
+	// Only the local paths are followed, so that the state cannot redirect to another site.
+	if !strings.HasPrefix(redirectURI, "/") || strings.HasPrefix(redirectURI, "//") {
+		redirectURI = "/"
+	}
+
+	http.Redirect(rw, req, redirectURI, http.StatusFound)
+}
+
+// handleLogout removes the session, and ends the session at the provider when it supports it.
+func (o *oidcAuth) handleLogout(rw http.ResponseWriter, req *http.Request, provider *oidcProvider) {
+	var session oidcSession
+	_ = o.store.load(req, o.cookieName, &session)
+
+	o.store.clear(rw, req, o.cookieName)
+
+	if provider.EndSessionEndpoint == "" {
+		http.Redirect(rw, req, o.postLogoutRedirectURI, http.StatusFound)
+		return
+	}
+
+	endSessionURL, err := url.Parse(provider.EndSessionEndpoint)
+	if err != nil {
+		http.Redirect(rw, req, o.postLogoutRedirectURI, http.StatusFound)
+		return
+	}
+
+	query := endSessionURL.Query()
+	query.Set("client_id", o.clientID)
+	query.Set("post_logout_redirect_uri", o.absoluteURL(req, o.postLogoutRedirectURI))
+	if session.IDToken != "" {
+		query.Set("id_token_hint", session.IDToken)
+	}
+	endSessionURL.RawQuery = query.Encode()
+
+	http.Redirect(rw, req, endSessionURL.String(), http.StatusFound)
+}
+
+// refresh refreshes the tokens of the session with its refresh token.
+func (o *oidcAuth) refresh(ctx context.Context, provider *oidcProvider, session *oidcSession) error {
+	if session.RefreshToken == "" {
+		return errors.New("session expired")
+	}
+
+	config := oauth2.Config{
+		ClientID:     o.clientID,
+		ClientSecret: o.clientSecret,
+		Endpoint:     oauth2.Endpoint{AuthURL: provider.AuthorizationEndpoint, TokenURL: provider.TokenEndpoint},
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, o.client)
+
+	token, err := config.TokenSource(ctx, &oauth2.Token{RefreshToken: session.RefreshToken, Expiry: time.Now().Add(-time.Second)}).Token()
+	if err != nil {
+		return err
+	}
+
+	// The ID token is optional in a refresh response.
+	if rawIDToken, ok := token.Extra("id_token").(string); ok && rawIDToken != "" {
+		if err = o.verifyIDToken(ctx, provider, rawIDToken, ""); err != nil {
+			return err
+		}
+
+		session.IDToken = rawIDToken
+	}
+
+	session.AccessToken = token.AccessToken
+	session.Expiry = token.Expiry
+	if token.RefreshToken != "" {
+		session.RefreshToken = token.RefreshToken
+	}
+
+	return nil
+}
+
+// verifyIDToken verifies the signature and the claims of an ID token, and its nonce when one is given.
+func (o *oidcAuth) verifyIDToken(ctx context.Context, provider *oidcProvider, rawIDToken, nonce string) error {
+	if rawIDToken == "" {
+		return errors.New("missing ID token")
+	}
+
+	token, err := jwt.ParseSigned(rawIDToken, asymmetricAlgorithms)
+	if err != nil {
+		return err
+	}
+
+	var claims oidcClaims
+	if err = provider.keys.verify(ctx, token, &claims); err != nil {
+		return err
+	}
+
+	err = claims.Validate(jwt.Expected{
+		Issuer:      provider.Issuer,
+		AnyAudience: jwt.Audience{o.clientID},
+		Time:        time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if nonce != "" && claims.Nonce != nonce {
+		return errors.New("invalid nonce")
+	}
+
+	return nil
+}
+
+// getProvider returns the provider configuration, discovered on the first request.
+func (o *oidcAuth) getProvider(ctx context.Context) (*oidcProvider, error) {
+	o.providerMu.Lock()
+	defer o.providerMu.Unlock()
+
+	if o.provider != nil {
+		return o.provider, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var provider oidcProvider
+	if err = json.NewDecoder(resp.Body).Decode(&provider); err != nil {
+		return nil, fmt.Errorf("decoding provider configuration: %w", err)
+	}
+
+	if strings.TrimSuffix(provider.Issuer, "/") != o.issuer {
+		return nil, fmt.Errorf("issuer %q of the provider configuration does not match %q", provider.Issuer, o.issuer)
+	}
+
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		return nil, errors.New("incomplete provider configuration")
+	}
+
+	provider.keys = newRemoteKeySet(provider.JWKSURI, o.client)
+	o.provider = &provider
+
+	return o.provider, nil
+}
+
+func (o *oidcAuth) oauth2Config(req *http.Request, provider *oidcProvider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     o.clientID,
+		ClientSecret: o.clientSecret,
+		Endpoint:     oauth2.Endpoint{AuthURL: provider.AuthorizationEndpoint, TokenURL: provider.TokenEndpoint},
+		RedirectURL:  o.absoluteURL(req, o.redirectPath),
+		Scopes:       o.scopes,
+	}
+}
+
+// absoluteURL returns the given path as an URL on the host of the request, unless it is already absolute.
+func (o *oidcAuth) absoluteURL(req *http.Request, path string) string {
+	if u, err := url.Parse(path); err == nil && u.IsAbs() {
+		return path
+	}
+
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+
+	if proto := req.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return scheme + "://" + req.Host + path
+}
+
+func (o *oidcAuth) stateCookieName() string {
+	return o.cookieName + "_state"
+}
+
+func (o *oidcAuth) unauthorized(rw http.ResponseWriter, req *http.Request, msg string) {
+	observability.SetStatusErrorf(req.Context(), "%s", msg)
+
+	http.Error(rw, msg, http.StatusUnauthorized)
+}
+
+// unverifiedClaims returns the claims of an ID token already verified, read from the session.
+func unverifiedClaims(rawIDToken string) (map[string]interface{}, error) {
+	token, err := jwt.ParseSigned(rawIDToken, asymmetricAlgorithms)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := make(map[string]interface{})
+	if err = token.UnsafeClaimsWithoutVerification(&claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// claimValue returns the value of a claim as a header value, the arrays being joined with commas.
+func claimValue(claim interface{}) (string, bool) {
+	switch value := claim.(type) {
+	case string:
+		return value, true
+	case bool:
+		return strconv.FormatBool(value), true
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	case []interface{}:
+		var values []string
+		for _, v := range value {
+			if s, ok := claimValue(v); ok {
+				values = append(values, s)
+			}
+		}
+
+		return strings.Join(values, ","), len(values) > 0
+	default:
+		return "", false
+	}
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+const (
+	// cookieChunkSize is the maximum size of the value of each cookie holding a part of a session,
+	// the browsers limiting the size of a cookie to 4KiB.
+	cookieChunkSize = 3800
+	// maxCookieChunks is the maximum number of cookies holding a session.
+	maxCookieChunks = 10
+)
+
+// oidcSession is the session of an authenticated user, stored encrypted in the session cookie.
+type oidcSession struct {
+	IDToken      string    `json:"id"`
+	AccessToken  string    `json:"at,omitempty"`
+	RefreshToken string    `json:"rt,omitempty"`
+	Expiry       time.Time `json:"exp"`
+}
+
+// oidcState is the state of an authorization request, stored encrypted in the state cookie until the callback.
+type oidcState struct {
+	State       string `json:"s"`
+	Nonce       string `json:"n"`
+	Verifier    string `json:"v"`
+	RedirectURI string `json:"r"`
+}
+
+// cookieStore stores values encrypted with AES-GCM in cookies, split into several cookies when too large.
+type cookieStore struct {
+	aead   cipher.AEAD
+	cookie dynamic.Cookie
+}
+
+func newCookieStore(secret string, cookie dynamic.Cookie) (*cookieStore, error) {
+	// The secret is hashed into an AES-256 key, so that it can be of any length.
+	key := sha256.Sum256([]byte(secret))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cookieStore{aead: aead, cookie: cookie}, nil
+}
+
+// withCookie returns a store sharing the encryption key of s, and setting the given cookie attributes.
+func (s *cookieStore) withCookie(cookie dynamic.Cookie) *cookieStore {
+	return &cookieStore{aead: s.aead, cookie: cookie}
+}
+
+// save stores the value in the cookies of the given name.
+func (s *cookieStore) save(rw http.ResponseWriter, req *http.Request, name string, value interface{}, maxAge int) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return err
+	}
+
+	// The cookie name is authenticated, so that a value cannot be replayed in another cookie.
+	encoded := base64.RawURLEncoding.EncodeToString(s.aead.Seal(nonce, nonce, data, []byte(name)))
+
+	chunks := (len(encoded) + cookieChunkSize - 1) / cookieChunkSize
+	if chunks > maxCookieChunks {
+		return fmt.Errorf("value too large to be stored in cookies: %d bytes", len(encoded))
+	}
+
+	for i := range chunks {
+		chunk := encoded[i*cookieChunkSize : min((i+1)*cookieChunkSize, len(encoded))]
+		http.SetCookie(rw, s.newCookie(chunkName(name, i), chunk, maxAge))
+	}
+
+	// The chunks of a previous larger value are removed.
+	for i := chunks; i < maxCookieChunks; i++ {
+		if _, err := req.Cookie(chunkName(name, i)); err != nil {
+			break
+		}
+
+		http.SetCookie(rw, s.newCookie(chunkName(name, i), "", -1))
+	}
+
+	return nil
+}
+
+// load decodes in value the content of the cookies of the given name.
+func (s *cookieStore) load(req *http.Request, name string, value interface{}) error {
+	var encoded string
+	for i := range maxCookieChunks {
+		cookie, err := req.Cookie(chunkName(name, i))
+		if err != nil {
+			break
+		}
+
+		encoded += cookie.Value
+	}
+
+	if encoded == "" {
+		return http.ErrNoCookie
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+
+	if len(sealed) < s.aead.NonceSize() {
+		return errors.New("invalid cookie value")
+	}
+
+	data, err := s.aead.Open(nil, sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():], []byte(name))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, value)
+}
+
+// clear removes the cookies of the given name.
+func (s *cookieStore) clear(rw http.ResponseWriter, req *http.Request, name string) {
+	for i := range maxCookieChunks {
+		if _, err := req.Cookie(chunkName(name, i)); err != nil {
+			return
+		}
+
+		http.SetCookie(rw, s.newCookie(chunkName(name, i), "", -1))
+	}
+}
+
+func (s *cookieStore) newCookie(name, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Secure:   s.cookie.Secure,
+		HttpOnly: s.cookie.HTTPOnly,
+		SameSite: convertSameSite(s.cookie.SameSite),
+		MaxAge:   maxAge,
+	}
+}
+
+func chunkName(name string, i int) string {
+	if i == 0 {
+		return name
+	}
+
+	return name + "_" + strconv.Itoa(i)
+}
+
+func convertSameSite(sameSite string) http.SameSite {
+	switch sameSite {
+	case "none":
+		return http.SameSiteNoneMode
+	case "lax":
+		return http.SameSiteLaxMode
+	case "strict":
+		return http.SameSiteStrictMode
+	default:
+		return http.SameSiteDefaultMode
+	}
+}
@@ -0,0 +1,381 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// fakeOIDCProvider is an OpenID Connect provider issuing the tokens of a single user.
+type fakeOIDCProvider struct {
+	*httptest.Server
+
+	key *rsa.PrivateKey
+
+	mu           sync.Mutex
+	nonce        string
+	expiresIn    int
+	refreshCalls int
+}
+
+func newFakeOIDCProvider(t *testing.T) *fakeOIDCProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &fakeOIDCProvider{key: key, expiresIn: 3600}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(rw http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(rw).Encode(map[string]string{
+			"issuer":                 p.URL,
+			"authorization_endpoint": p.URL + "/authorize",
+			"token_endpoint":         p.URL + "/token",
+			"jwks_uri":               p.URL + "/jwks",
+			"end_session_endpoint":   p.URL + "/logout",
+		})
+	})
+	mux.HandleFunc("/jwks", func(rw http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(rw).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "key1", Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", func(rw http.ResponseWriter, req *http.Request) {
+		require.NoError(t, req.ParseForm())
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		switch req.PostForm.Get("grant_type") {
+		case "authorization_code":
+			if req.PostForm.Get("code") != "code" || req.PostForm.Get("code_verifier") == "" {
+				rw.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+		case "refresh_token":
+			if req.PostForm.Get("refresh_token") != "refresh" {
+				rw.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			p.refreshCalls++
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{
+			"access_token":  "access",
+			"token_type":    "Bearer",
+			"refresh_token": "refresh",
+			"expires_in":    p.expiresIn,
+			"id_token":      p.idToken(t, p.nonce),
+		})
+	})
+
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+
+	return p
+}
+
+func (p *fakeOIDCProvider) idToken(t *testing.T, nonce string) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: p.key}, (&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "key1"))
+	require.NoError(t, err)
+
+	claims := map[string]interface{}{
+		"iss":    p.URL,
+		"sub":    "user1",
+		"aud":    "client",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"iat":    time.Now().Unix(),
+		"nonce":  nonce,
+		"email":  "user1@example.com",
+		"groups": []string{"admin", "dev"},
+	}
+
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	require.NoError(t, err)
+
+	return token
+}
+
+// login runs the authorization code flow, and returns the session cookies.
+func (p *fakeOIDCProvider) login(t *testing.T, handler http.Handler) []*http.Cookie {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://app.localhost/foo?bar=baz", nil))
+	require.Equal(t, http.StatusFound, rec.Code)
+
+	location, err := url.Parse(rec.Header().Get("Location"))
+	require.NoError(t, err)
+
+	p.mu.Lock()
+	p.nonce = location.Query().Get("nonce")
+	p.mu.Unlock()
+
+	callback := httptest.NewRequest(http.MethodGet, "http://app.localhost/oauth2/callback?code=code&state="+location.Query().Get("state"), nil)
+	for _, cookie := range rec.Result().Cookies() {
+		callback.AddCookie(cookie)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, callback)
+	require.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "/foo?bar=baz", rec.Header().Get("Location"))
+
+	var cookies []*http.Cookie
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.MaxAge >= 0 {
+			cookies = append(cookies, cookie)
+		}
+	}
+
+	return cookies
+}
+
+func newTestOIDC(t *testing.T, provider *fakeOIDCProvider, next http.Handler) http.Handler {
+	t.Helper()
+
+	handler, err := NewOIDC(context.Background(), next, dynamic.OIDC{
+		Issuer:        provider.URL,
+		ClientID:      "client",
+		ClientSecret:  "secret",
+		SessionSecret: "session-secret",
+		ClaimsHeaders: map[string]string{
+			"X-User":   "sub",
+			"X-Email":  "email",
+			"X-Groups": "groups",
+		},
+		ForwardAccessToken: true,
+	}, "oidc")
+	require.NoError(t, err)
+
+	return handler
+}
+
+func TestOIDC_authenticate(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+
+	handler := newTestOIDC(t, provider, http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Fatal("unauthenticated request forwarded")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://app.localhost/foo", nil))
+
+	require.Equal(t, http.StatusFound, rec.Code)
+
+	location, err := url.Parse(rec.Header().Get("Location"))
+	require.NoError(t, err)
+
+	assert.Equal(t, provider.URL+"/authorize", location.Scheme+"://"+location.Host+location.Path)
+
+	query := location.Query()
+	assert.Equal(t, "client", query.Get("client_id"))
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, "http://app.localhost/oauth2/callback", query.Get("redirect_uri"))
+	assert.Equal(t, "openid profile email", query.Get("scope"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.NotEmpty(t, query.Get("state"))
+	assert.NotEmpty(t, query.Get("nonce"))
+
+	// The requests which are not navigations are rejected.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "http://app.localhost/foo", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestOIDC_session(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+
+	var forwarded http.Header
+	handler := newTestOIDC(t, provider, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwarded = req.Header.Clone()
+		rw.WriteHeader(http.StatusOK)
+	}))
+
+	cookies := provider.login(t, handler)
+	require.NotEmpty(t, cookies)
+
+	req := httptest.NewRequest(http.MethodGet, "http://app.localhost/foo", nil)
+	req.Header.Set("X-User", "spoofed")
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "user1", forwarded.Get("X-User"))
+	assert.Equal(t, "user1@example.com", forwarded.Get("X-Email"))
+	assert.Equal(t, "admin,dev", forwarded.Get("X-Groups"))
+	assert.Equal(t, "Bearer access", forwarded.Get("Authorization"))
+}
+
+func TestOIDC_refresh(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+	// The access tokens expire immediately.
+	provider.expiresIn = -1
+
+	handler := newTestOIDC(t, provider, http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}))
+
+	cookies := provider.login(t, handler)
+
+	req := httptest.NewRequest(http.MethodGet, "http://app.localhost/foo", nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, provider.refreshCalls)
+	assert.NotEmpty(t, rec.Result().Cookies())
+}
+
+func TestOIDC_callback_invalidState(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+
+	handler := newTestOIDC(t, provider, http.NotFoundHandler())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://app.localhost/foo", nil))
+	require.Equal(t, http.StatusFound, rec.Code)
+
+	callback := httptest.NewRequest(http.MethodGet, "http://app.localhost/oauth2/callback?code=code&state=other", nil)
+	for _, cookie := range rec.Result().Cookies() {
+		callback.AddCookie(cookie)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, callback)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestOIDC_logout(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+
+	handler := newTestOIDC(t, provider, http.NotFoundHandler())
+
+	cookies := provider.login(t, handler)
+
+	req := httptest.NewRequest(http.MethodGet, "http://app.localhost/oauth2/logout", nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusFound, rec.Code)
+
+	location, err := url.Parse(rec.Header().Get("Location"))
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(location.String(), provider.URL+"/logout?"))
+	assert.Equal(t, "http://app.localhost/", location.Query().Get("post_logout_redirect_uri"))
+	assert.NotEmpty(t, location.Query().Get("id_token_hint"))
+
+	for _, cookie := range rec.Result().Cookies() {
+		assert.Negative(t, cookie.MaxAge, cookie.Name)
+	}
+}
+
+func TestOIDC_cookies(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+
+	handler, err := NewOIDC(context.Background(), http.NotFoundHandler(), dynamic.OIDC{
+		Issuer:        provider.URL,
+		ClientID:      "client",
+		ClientSecret:  "secret",
+		SessionSecret: "session-secret",
+		SessionCookie: &dynamic.Cookie{Name: "session", SameSite: "strict"},
+	}, "oidc")
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://app.localhost/foo", nil))
+	require.Equal(t, http.StatusFound, rec.Code)
+
+	// The state cookie is sent along the redirection back from the provider, whatever the session cookie policy.
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "session_state", cookies[0].Name)
+	assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+	assert.True(t, cookies[0].Secure)
+	assert.True(t, cookies[0].HttpOnly)
+
+	cookies = provider.login(t, handler)
+	require.NotEmpty(t, cookies)
+
+	for _, cookie := range cookies {
+		assert.Equal(t, "session", cookie.Name)
+		assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+		assert.True(t, cookie.Secure)
+		assert.True(t, cookie.HttpOnly)
+	}
+}
+
+func TestNewOIDC_missingOptions(t *testing.T) {
+	_, err := NewOIDC(context.Background(), http.NotFoundHandler(), dynamic.OIDC{ClientID: "client", SessionSecret: "secret"}, "oidc")
+	assert.Error(t, err)
+
+	_, err = NewOIDC(context.Background(), http.NotFoundHandler(), dynamic.OIDC{Issuer: "https://issuer.example.com", SessionSecret: "secret"}, "oidc")
+	assert.Error(t, err)
+
+	_, err = NewOIDC(context.Background(), http.NotFoundHandler(), dynamic.OIDC{Issuer: "https://issuer.example.com", ClientID: "client"}, "oidc")
+	assert.Error(t, err)
+}
+
+func TestCookieStore_chunks(t *testing.T) {
+	store, err := newCookieStore("secret", dynamic.Cookie{Name: "session"})
+	require.NoError(t, err)
+
+	value := map[string]string{"data": strings.Repeat("a", 3*cookieChunkSize)}
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, store.save(rec, httptest.NewRequest(http.MethodGet, "/", nil), "session", value, 0))
+
+	cookies := rec.Result().Cookies()
+	require.Greater(t, len(cookies), 1)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	var loaded map[string]string
+	require.NoError(t, store.load(req, "session", &loaded))
+	assert.Equal(t, value, loaded)
+
+	// A value cannot be read from cookies of another name.
+	replayed := httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, cookie := range cookies {
+		replayed.AddCookie(&http.Cookie{Name: strings.Replace(cookie.Name, "session", "other", 1), Value: cookie.Value})
+	}
+
+	assert.Error(t, store.load(replayed, "other", &loaded))
+}
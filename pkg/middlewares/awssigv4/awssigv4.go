@@ -0,0 +1,199 @@
+package awssigv4
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/defaults"
+	"github.com/aws/aws-sdk-go/aws/session"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "AWSSigV4"
+
+const defaultMaxBodyBytes = 10 * 1024 * 1024
+
+// signatureHeaders are the headers set by the signer.
+var signatureHeaders = []string{"Authorization", "X-Amz-Date", "X-Amz-Security-Token", "X-Amz-Content-Sha256"}
+
+// awsSigV4 is a middleware signing the forwarded requests with AWS Signature Version 4.
+type awsSigV4 struct {
+	next            http.Handler
+	name            string
+	signer          *v4.Signer
+	service         string
+	region          string
+	host            string
+	unsignedPayload bool
+	maxBodyBytes    int64
+}
+
+// New creates an AWS SigV4 middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.AWSSigV4, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if config.Service == "" {
+		return nil, errors.New("service must be defined")
+	}
+
+	if config.AccessKeyID == "" != (config.SecretAccessKey == "") {
+		return nil, errors.New("accessKeyID and secretAccessKey must be defined together")
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating AWS session: %w", err)
+	}
+
+	region := config.Region
+	if region == "" {
+		region = aws.StringValue(sess.Config.Region)
+	}
+
+	if region == "" {
+		return nil, errors.New("region must be defined")
+	}
+
+	var creds *credentials.Credentials
+	if config.AccessKeyID != "" {
+		creds = credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, config.SessionToken)
+	} else {
+		creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvProvider{},
+			&credentials.SharedCredentialsProvider{},
+			defaults.RemoteCredProvider(*(defaults.Config()), defaults.Handlers()),
+			stscreds.NewWebIdentityRoleProviderWithOptions(
+				sts.New(sess, aws.NewConfig().WithRegion(region)),
+				os.Getenv("AWS_ROLE_ARN"),
+				"",
+				stscreds.FetchTokenPath(os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE")),
+			),
+		})
+	}
+
+	s := &awsSigV4{
+		next:            next,
+		name:            name,
+		service:         config.Service,
+		region:          region,
+		host:            config.Host,
+		unsignedPayload: config.UnsignedPayload,
+		maxBodyBytes:    config.MaxBodyBytes,
+	}
+
+	if s.maxBodyBytes <= 0 {
+		s.maxBodyBytes = defaultMaxBodyBytes
+	}
+
+	s.signer = v4.NewSigner(creds, func(signer *v4.Signer) {
+		signer.UnsignedPayload = config.UnsignedPayload
+		// S3 does not expect the path to be escaped again.
+		signer.DisableURIPathEscaping = config.Service == "s3"
+	})
+
+	return s, nil
+}
+
+func (s *awsSigV4) GetTracingInformation() (string, string, trace.SpanKind) {
+	return s.name, typeName, trace.SpanKindInternal
+}
+
+func (s *awsSigV4) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if s.host != "" {
+		req.Host = s.host
+	}
+
+	var body io.ReadSeeker
+	if !s.unsignedPayload && req.Body != nil && req.Body != http.NoBody {
+		if req.ContentLength > s.maxBodyBytes {
+			s.reject(rw, req, http.StatusRequestEntityTooLarge, fmt.Errorf("body size %d exceeds the limit of %d bytes", req.ContentLength, s.maxBodyBytes))
+			return
+		}
+
+		content, err := io.ReadAll(http.MaxBytesReader(rw, req.Body, s.maxBodyBytes))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				s.reject(rw, req, http.StatusRequestEntityTooLarge, fmt.Errorf("body size exceeds the limit of %d bytes", s.maxBodyBytes))
+				return
+			}
+
+			s.reject(rw, req, http.StatusBadRequest, fmt.Errorf("reading body: %w", err))
+			return
+		}
+
+		body = bytes.NewReader(content)
+
+		req.Body = io.NopCloser(bytes.NewReader(content))
+		req.ContentLength = int64(len(content))
+	}
+
+	for _, header := range signatureHeaders {
+		req.Header.Del(header)
+	}
+
+	// Only the headers which are not modified by the proxy are signed,
+	// as the signature would not match otherwise.
+	signed := &http.Request{
+		Method: req.Method,
+		URL:    req.URL,
+		Host:   req.Host,
+		Header: make(http.Header),
+	}
+
+	for name, values := range req.Header {
+		if name == "Content-Type" || name == "Content-Md5" || strings.HasPrefix(name, "X-Amz-") {
+			signed.Header[name] = values
+		}
+	}
+
+	if _, err := s.signer.Sign(signed, body, s.service, s.region, time.Now()); err != nil {
+		logger := middlewares.GetLogger(req.Context(), s.name, typeName)
+		logger.Error().Err(err).Msg("Error while signing the request")
+		observability.SetStatusErrorf(req.Context(), "Error while signing the request: %s", err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// The signer removes the default port from the signed host.
+	req.Host = signed.Host
+
+	for _, header := range signatureHeaders {
+		if value := signed.Header.Get(header); value != "" {
+			req.Header.Set(header, value)
+		}
+	}
+
+	s.next.ServeHTTP(rw, req)
+}
+
+func (s *awsSigV4) reject(rw http.ResponseWriter, req *http.Request, statusCode int, reason error) {
+	logger := middlewares.GetLogger(req.Context(), s.name, typeName)
+	logger.Debug().Err(reason).Msg("Rejecting request")
+
+	observability.SetStatusErrorf(req.Context(), "Rejecting request: %v", reason)
+
+	rw.WriteHeader(statusCode)
+	if _, err := rw.Write([]byte(http.StatusText(statusCode))); err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Send()
+	}
+}
@@ -0,0 +1,189 @@
+package bodyvalidation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "BodyValidation"
+
+// bodyValidation is a middleware validating the request bodies.
+type bodyValidation struct {
+	next                http.Handler
+	name                string
+	maxBodyBytes        int64
+	allowedContentTypes []string
+	schema              *jsonschema.Schema
+	bodyRequired        bool
+}
+
+// New creates a BodyValidation middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.BodyValidation, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeName)
+	logger.Debug().Msg("Creating middleware")
+
+	if config.MaxBodyBytes < 0 {
+		return nil, fmt.Errorf("invalid maxBodyBytes %d: must be positive", config.MaxBodyBytes)
+	}
+
+	if config.JSONSchema != "" && config.OpenAPI != nil {
+		return nil, errors.New("jsonSchema and openAPI are mutually exclusive")
+	}
+
+	bv := &bodyValidation{
+		next:         next,
+		name:         name,
+		maxBodyBytes: config.MaxBodyBytes,
+	}
+
+	for _, contentType := range config.AllowedContentTypes {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, fmt.Errorf("parsing allowed content type %q: %w", contentType, err)
+		}
+
+		bv.allowedContentTypes = append(bv.allowedContentTypes, mediaType)
+	}
+
+	var err error
+	switch {
+	case config.JSONSchema != "":
+		bv.schema, err = jsonschema.NewCompiler().Compile(config.JSONSchema)
+		if err != nil {
+			return nil, fmt.Errorf("compiling JSON schema: %w", err)
+		}
+
+	case config.OpenAPI != nil:
+		bv.schema, bv.bodyRequired, err = compileOpenAPISchema(*config.OpenAPI)
+		if err != nil {
+			return nil, fmt.Errorf("compiling OpenAPI operation %q schema: %w", config.OpenAPI.OperationID, err)
+		}
+	}
+
+	return bv, nil
+}
+
+func (b *bodyValidation) GetTracingInformation() (string, string, trace.SpanKind) {
+	return b.name, typeName, trace.SpanKindInternal
+}
+
+func (b *bodyValidation) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if b.maxBodyBytes > 0 && req.ContentLength > b.maxBodyBytes {
+		b.reject(rw, req, http.StatusRequestEntityTooLarge, fmt.Errorf("body size %d exceeds the limit of %d bytes", req.ContentLength, b.maxBodyBytes))
+		return
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		if b.bodyRequired {
+			b.reject(rw, req, http.StatusBadRequest, errors.New("missing required body"))
+			return
+		}
+
+		b.next.ServeHTTP(rw, req)
+		return
+	}
+
+	// An invalid or missing Content-Type is handled as an empty media type, which is never allowed.
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+
+	if len(b.allowedContentTypes) > 0 && !b.isAllowed(mediaType) {
+		b.reject(rw, req, http.StatusUnsupportedMediaType, fmt.Errorf("content type %q is not allowed", mediaType))
+		return
+	}
+
+	if b.maxBodyBytes > 0 {
+		// When the body is not validated, its size is checked while it is forwarded.
+		req.Body = http.MaxBytesReader(rw, req.Body, b.maxBodyBytes)
+	}
+
+	if b.schema == nil {
+		b.next.ServeHTTP(rw, req)
+		return
+	}
+
+	if !isJSON(mediaType) {
+		b.reject(rw, req, http.StatusUnsupportedMediaType, fmt.Errorf("content type %q is not JSON", mediaType))
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			b.reject(rw, req, http.StatusRequestEntityTooLarge, fmt.Errorf("body size exceeds the limit of %d bytes", b.maxBodyBytes))
+			return
+		}
+
+		b.reject(rw, req, http.StatusBadRequest, fmt.Errorf("reading body: %w", err))
+		return
+	}
+
+	if len(bytes.TrimSpace(body)) > 0 {
+		value, err := jsonschema.UnmarshalJSON(bytes.NewReader(body))
+		if err != nil {
+			b.reject(rw, req, http.StatusBadRequest, fmt.Errorf("parsing JSON body: %w", err))
+			return
+		}
+
+		if err := b.schema.Validate(value); err != nil {
+			b.reject(rw, req, http.StatusBadRequest, fmt.Errorf("validating JSON body: %w", err))
+			return
+		}
+	} else if b.bodyRequired {
+		b.reject(rw, req, http.StatusBadRequest, errors.New("missing required body"))
+		return
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+
+	b.next.ServeHTTP(rw, req)
+}
+
+func (b *bodyValidation) isAllowed(mediaType string) bool {
+	if mediaType == "" {
+		return false
+	}
+
+	for _, allowed := range b.allowedContentTypes {
+		if allowed == mediaType {
+			return true
+		}
+
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *bodyValidation) reject(rw http.ResponseWriter, req *http.Request, statusCode int, reason error) {
+	logger := middlewares.GetLogger(req.Context(), b.name, typeName)
+	logger.Debug().Err(reason).Msg("Rejecting request")
+
+	observability.SetStatusErrorf(req.Context(), "Rejecting request: %v", reason)
+
+	rw.WriteHeader(statusCode)
+	if _, err := rw.Write([]byte(http.StatusText(statusCode))); err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Send()
+	}
+}
+
+// isJSON returns whether the given media type is JSON, e.g. application/json or application/problem+json.
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,255 @@
+package bodyvalidation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.BodyValidation
+	}{
+		{
+			desc:   "negative max body bytes",
+			config: dynamic.BodyValidation{MaxBodyBytes: -1},
+		},
+		{
+			desc:   "invalid allowed content type",
+			config: dynamic.BodyValidation{AllowedContentTypes: []string{"application/"}},
+		},
+		{
+			desc: "JSON schema and OpenAPI",
+			config: dynamic.BodyValidation{
+				JSONSchema: "./fixtures/pet.schema.json",
+				OpenAPI:    &dynamic.OpenAPIOperation{File: "./fixtures/openapi-3.1.json", OperationID: "createPet"},
+			},
+		},
+		{
+			desc:   "unknown JSON schema",
+			config: dynamic.BodyValidation{JSONSchema: "./fixtures/unknown.json"},
+		},
+		{
+			desc:   "unknown OpenAPI operation",
+			config: dynamic.BodyValidation{OpenAPI: &dynamic.OpenAPIOperation{File: "./fixtures/openapi-3.1.json", OperationID: "deletePet"}},
+		},
+		{
+			desc:   "OpenAPI operation without request body",
+			config: dynamic.BodyValidation{OpenAPI: &dynamic.OpenAPIOperation{File: "./fixtures/openapi-3.0.yaml", OperationID: "getPet"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "bodyvalidation")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBodyValidation_ServeHTTP(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		config         dynamic.BodyValidation
+		contentType    string
+		body           string
+		chunked        bool
+		expectedStatus int
+	}{
+		{
+			desc:           "no body",
+			config:         dynamic.BodyValidation{AllowedContentTypes: []string{"application/json"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "body under the limit",
+			config:         dynamic.BodyValidation{MaxBodyBytes: 10},
+			contentType:    "text/plain",
+			body:           "foo",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "body over the limit",
+			config:         dynamic.BodyValidation{MaxBodyBytes: 2},
+			contentType:    "text/plain",
+			body:           "foo",
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:           "chunked body over the limit",
+			config:         dynamic.BodyValidation{MaxBodyBytes: 2, JSONSchema: "./fixtures/pet.schema.json"},
+			contentType:    "application/json",
+			body:           `{"name":"foo"}`,
+			chunked:        true,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:           "allowed content type",
+			config:         dynamic.BodyValidation{AllowedContentTypes: []string{"application/json", "image/*"}},
+			contentType:    "image/png",
+			body:           "foo",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "allowed content type with parameters",
+			config:         dynamic.BodyValidation{AllowedContentTypes: []string{"text/plain"}},
+			contentType:    "Text/Plain; charset=utf-8",
+			body:           "foo",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "not allowed content type",
+			config:         dynamic.BodyValidation{AllowedContentTypes: []string{"application/json", "image/*"}},
+			contentType:    "text/plain",
+			body:           "foo",
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:           "missing content type",
+			config:         dynamic.BodyValidation{AllowedContentTypes: []string{"application/json"}},
+			body:           "foo",
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:           "valid JSON body",
+			config:         dynamic.BodyValidation{JSONSchema: "./fixtures/pet.schema.json"},
+			contentType:    "application/json",
+			body:           `{"name":"foo","age":2}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "invalid JSON body",
+			config:         dynamic.BodyValidation{JSONSchema: "./fixtures/pet.schema.json"},
+			contentType:    "application/json",
+			body:           `{"name":"foo","age":-2}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			desc:           "malformed JSON body",
+			config:         dynamic.BodyValidation{JSONSchema: "./fixtures/pet.schema.json"},
+			contentType:    "application/json",
+			body:           `{"name":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			desc:           "non JSON body with schema",
+			config:         dynamic.BodyValidation{JSONSchema: "./fixtures/pet.schema.json"},
+			contentType:    "text/plain",
+			body:           "foo",
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:           "valid OpenAPI 3.0 body",
+			config:         dynamic.BodyValidation{OpenAPI: &dynamic.OpenAPIOperation{File: "./fixtures/openapi-3.0.yaml", OperationID: "createPet"}},
+			contentType:    "application/json",
+			body:           `{"name":"foo","tag":null,"age":1}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "invalid OpenAPI 3.0 body",
+			config:         dynamic.BodyValidation{OpenAPI: &dynamic.OpenAPIOperation{File: "./fixtures/openapi-3.0.yaml", OperationID: "createPet"}},
+			contentType:    "application/json",
+			body:           `{"name":"foo","age":0}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			desc:           "missing required OpenAPI body",
+			config:         dynamic.BodyValidation{OpenAPI: &dynamic.OpenAPIOperation{File: "./fixtures/openapi-3.0.yaml", OperationID: "createPet"}},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			desc:           "OpenAPI request body reference",
+			config:         dynamic.BodyValidation{OpenAPI: &dynamic.OpenAPIOperation{File: "./fixtures/openapi-3.0.yaml", OperationID: "updatePet"}},
+			contentType:    "application/merge-patch+json",
+			body:           `{"tag":"cat"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			desc:           "valid OpenAPI 3.1 body",
+			config:         dynamic.BodyValidation{OpenAPI: &dynamic.OpenAPIOperation{File: "./fixtures/openapi-3.1.json", OperationID: "createPet"}},
+			contentType:    "application/json",
+			body:           `{"name":"foo","tag":null}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "invalid OpenAPI 3.1 body",
+			config:         dynamic.BodyValidation{OpenAPI: &dynamic.OpenAPIOperation{File: "./fixtures/openapi-3.1.json", OperationID: "createPet"}},
+			contentType:    "application/json",
+			body:           `{"name":""}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			desc:           "optional OpenAPI 3.1 body",
+			config:         dynamic.BodyValidation{OpenAPI: &dynamic.OpenAPIOperation{File: "./fixtures/openapi-3.1.json", OperationID: "createPet"}},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwardedBody string
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+
+				forwardedBody = string(body)
+			})
+
+			handler, err := New(context.Background(), next, test.config, "bodyvalidation")
+			require.NoError(t, err)
+
+			var body io.Reader = http.NoBody
+			if test.body != "" {
+				body = strings.NewReader(test.body)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost", body)
+			if test.chunked {
+				req.ContentLength = -1
+			}
+			if test.contentType != "" {
+				req.Header.Set("Content-Type", test.contentType)
+			}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+
+			if test.expectedStatus == http.StatusOK {
+				assert.Equal(t, test.body, forwardedBody)
+			}
+		})
+	}
+}
+
+func TestBodyValidation_streamedBodyOverLimit(t *testing.T) {
+	var readErr error
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, readErr = io.ReadAll(req.Body)
+	})
+
+	handler, err := New(context.Background(), next, dynamic.BodyValidation{MaxBodyBytes: 2}, "bodyvalidation")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader("foo"))
+	req.ContentLength = -1
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var maxBytesErr *http.MaxBytesError
+	assert.True(t, errors.As(readErr, &maxBytesErr))
+}
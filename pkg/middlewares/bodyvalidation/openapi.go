@@ -0,0 +1,200 @@
+package bodyvalidation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+var operationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// compileOpenAPISchema compiles the schema of the JSON request body of the given OpenAPI operation,
+// and returns whether the request body is required.
+func compileOpenAPISchema(config dynamic.OpenAPIOperation) (*jsonschema.Schema, bool, error) {
+	if config.File == "" || config.OperationID == "" {
+		return nil, false, errors.New("file and operationId must be defined")
+	}
+
+	path, err := filepath.Abs(config.File)
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The JSON documents are also valid YAML documents.
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing OpenAPI document: %w", err)
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing OpenAPI document: %w", err)
+	}
+
+	root, ok := doc.(map[string]any)
+	if !ok {
+		return nil, false, errors.New("invalid OpenAPI document")
+	}
+
+	version, _ := root["openapi"].(string)
+
+	var draft *jsonschema.Draft
+	switch {
+	case strings.HasPrefix(version, "3.0."):
+		// The OpenAPI 3.0 schemas are an extended subset of the JSON Schema draft 4.
+		draft = jsonschema.Draft4
+		convertNullable(root)
+
+	case strings.HasPrefix(version, "3.1."):
+		draft = jsonschema.Draft2020
+
+	default:
+		return nil, false, fmt.Errorf("unsupported OpenAPI version %q", version)
+	}
+
+	pointer, err := findRequestBodySchema(root, config.OperationID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	location := (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(draft)
+
+	if err := compiler.AddResource(location, doc); err != nil {
+		return nil, false, err
+	}
+
+	schema, err := compiler.Compile(location + "#" + (&url.URL{Fragment: pointer.schema}).EscapedFragment())
+	if err != nil {
+		return nil, false, err
+	}
+
+	return schema, pointer.required, nil
+}
+
+type requestBodySchema struct {
+	// schema is the JSON pointer of the request body schema in the OpenAPI document.
+	schema   string
+	required bool
+}
+
+func findRequestBodySchema(root map[string]any, operationID string) (*requestBodySchema, error) {
+	paths, _ := root["paths"].(map[string]any)
+
+	for path, item := range paths {
+		item, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for _, method := range operationMethods {
+			operation, ok := item[method].(map[string]any)
+			if !ok || operation["operationId"] != operationID {
+				continue
+			}
+
+			requestBody, ok := operation["requestBody"].(map[string]any)
+			if !ok {
+				return nil, errors.New("operation without request body")
+			}
+
+			pointer := "/paths/" + pointerEscaper.Replace(path) + "/" + method + "/requestBody"
+
+			// The request bodies can be defined in the components of the document.
+			if ref, ok := requestBody["$ref"].(string); ok {
+				name, found := strings.CutPrefix(ref, "#/components/requestBodies/")
+				if !found {
+					return nil, fmt.Errorf("unsupported request body reference %q", ref)
+				}
+
+				components, _ := root["components"].(map[string]any)
+				requestBodies, _ := components["requestBodies"].(map[string]any)
+				if requestBody, ok = requestBodies[name].(map[string]any); !ok {
+					return nil, fmt.Errorf("request body %q not found", ref)
+				}
+
+				pointer = strings.TrimPrefix(ref, "#")
+			}
+
+			mediaType, err := findJSONMediaType(requestBody)
+			if err != nil {
+				return nil, err
+			}
+
+			required, _ := requestBody["required"].(bool)
+
+			return &requestBodySchema{
+				schema:   pointer + "/content/" + pointerEscaper.Replace(mediaType) + "/schema",
+				required: required,
+			}, nil
+		}
+	}
+
+	return nil, errors.New("operation not found")
+}
+
+// findJSONMediaType returns the JSON media type of the request body having a schema.
+func findJSONMediaType(requestBody map[string]any) (string, error) {
+	content, _ := requestBody["content"].(map[string]any)
+
+	var mediaTypes []string
+	for mediaType, value := range content {
+		value, ok := value.(map[string]any)
+		if !ok || value["schema"] == nil || !isJSON(strings.ToLower(mediaType)) {
+			continue
+		}
+
+		if mediaType == "application/json" {
+			return mediaType, nil
+		}
+
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+
+	if len(mediaTypes) == 0 {
+		return "", errors.New("request body without JSON schema")
+	}
+
+	slices.Sort(mediaTypes)
+
+	return mediaTypes[0], nil
+}
+
+// convertNullable converts the OpenAPI 3.0 nullable schemas into JSON schemas allowing the null type.
+func convertNullable(value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		if nullable, _ := value["nullable"].(bool); nullable {
+			if typ, ok := value["type"].(string); ok {
+				value["type"] = []any{typ, "null"}
+			}
+		}
+
+		for _, child := range value {
+			convertNullable(child)
+		}
+
+	case []any:
+		for _, child := range value {
+			convertNullable(child)
+		}
+	}
+}
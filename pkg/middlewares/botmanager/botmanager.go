@@ -0,0 +1,370 @@
+package botmanager
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/ip"
+	"github.com/traefik/traefik/v3/pkg/metrics"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"github.com/traefik/traefik/v3/pkg/tls/fingerprint"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "BotManager"
+
+// Actions applied to the bot categories.
+const (
+	ActionAllow     = "allow"
+	ActionBlock     = "block"
+	ActionChallenge = "challenge"
+	ActionTarpit    = "tarpit"
+)
+
+// ChallengeCookieName is the name of the cookie holding the solved challenge.
+const ChallengeCookieName = "_traefik_bot_challenge"
+
+const (
+	defaultChallengeTTL = time.Hour
+	defaultTarpitDelay  = 10 * time.Second
+)
+
+var challengePage = template.Must(template.New("challenge").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Checking your browser</title></head>
+<body>
+<noscript>Please enable JavaScript to access this page.</noscript>
+<script>document.cookie = {{.}}; window.location.reload();</script>
+</body>
+</html>
+`))
+
+type categoryKey struct{}
+
+// GetCategory returns the bot category of the request, as identified by a preceding BotManager middleware.
+func GetCategory(ctx context.Context) string {
+	category, _ := ctx.Value(categoryKey{}).(string)
+	return category
+}
+
+// botManager is a middleware identifying the bots, and applying an action per bot category.
+type botManager struct {
+	next              http.Handler
+	name              string
+	signatures        []*signature
+	needsFingerprints bool
+	actions           map[string]string
+	strategy          ip.Strategy
+	challengeSecret   []byte
+	challengeTTL      time.Duration
+	tarpitDelay       time.Duration
+	reqsCounter       gokitmetrics.Counter
+}
+
+// New creates a BotManager middleware.
+// The requests identified as bots are counted with the given metrics registry, which can be nil.
+func New(ctx context.Context, next http.Handler, config dynamic.BotManager, name string, registry metrics.Registry) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeName)
+	logger.Debug().Msg("Creating middleware")
+
+	for category, action := range config.Actions {
+		switch action {
+		case ActionAllow, ActionBlock, ActionChallenge, ActionTarpit:
+		default:
+			return nil, fmt.Errorf("invalid action %q for category %q", action, category)
+		}
+	}
+
+	bm := &botManager{
+		next:         next,
+		name:         name,
+		actions:      config.Actions,
+		challengeTTL: time.Duration(config.ChallengeTTL),
+		tarpitDelay:  time.Duration(config.TarpitDelay),
+	}
+
+	if bm.challengeTTL <= 0 {
+		bm.challengeTTL = defaultChallengeTTL
+	}
+	if bm.tarpitDelay <= 0 {
+		bm.tarpitDelay = defaultTarpitDelay
+	}
+
+	for _, config := range slices.Concat(config.Signatures, defaultSignatures) {
+		sig, err := newSignature(config)
+		if err != nil {
+			return nil, fmt.Errorf("signature %q: %w", config.Name, err)
+		}
+
+		bm.signatures = append(bm.signatures, sig)
+		bm.needsFingerprints = bm.needsFingerprints || len(sig.ja3) > 0 || len(sig.ja4) > 0
+	}
+
+	var err error
+	bm.strategy, err = config.IPStrategy.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	bm.challengeSecret = []byte(config.ChallengeSecret)
+	if len(bm.challengeSecret) == 0 {
+		bm.challengeSecret = make([]byte, 32)
+		if _, err := rand.Read(bm.challengeSecret); err != nil {
+			return nil, fmt.Errorf("generating challenge secret: %w", err)
+		}
+	}
+
+	if registry != nil {
+		bm.reqsCounter = registry.BotReqsCounter()
+	}
+
+	return bm, nil
+}
+
+func (b *botManager) GetTracingInformation() (string, string, trace.SpanKind) {
+	return b.name, typeName, trace.SpanKindInternal
+}
+
+func (b *botManager) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), b.name, typeName)
+
+	clientIP := b.strategy.GetIP(req)
+
+	sig, category := b.classify(req, clientIP)
+	if sig == nil {
+		b.next.ServeHTTP(rw, req)
+		return
+	}
+
+	action := b.actions[category]
+	if action == "" {
+		action = ActionAllow
+	}
+
+	if b.reqsCounter != nil {
+		b.reqsCounter.With("middleware", b.name, "category", category, "action", action).Add(1)
+	}
+
+	logger.Debug().Msgf("Request from IP %s identified as %s (%s), action: %s", clientIP, sig.name, category, action)
+
+	req = req.WithContext(context.WithValue(req.Context(), categoryKey{}, category))
+
+	switch action {
+	case ActionBlock:
+		observability.SetStatusErrorf(req.Context(), "Blocking bot %s (%s)", sig.name, category)
+
+		rw.WriteHeader(http.StatusForbidden)
+		if _, err := rw.Write([]byte(http.StatusText(http.StatusForbidden))); err != nil {
+			log.Ctx(req.Context()).Error().Err(err).Send()
+		}
+
+	case ActionChallenge:
+		if b.isChallengeSolved(req, clientIP) {
+			b.next.ServeHTTP(rw, req)
+			return
+		}
+
+		b.serveChallenge(rw, req, clientIP)
+
+	case ActionTarpit:
+		timer := time.NewTimer(b.tarpitDelay)
+		defer timer.Stop()
+
+		select {
+		case <-req.Context().Done():
+			return
+		case <-timer.C:
+		}
+
+		b.next.ServeHTTP(rw, req)
+
+	default:
+		b.next.ServeHTTP(rw, req)
+	}
+}
+
+// classify returns the first signature matching the request, and the category of the request.
+func (b *botManager) classify(req *http.Request, clientIP string) (*signature, string) {
+	parsedIP := net.ParseIP(clientIP)
+
+	var fingerprints *fingerprint.Fingerprints
+	if b.needsFingerprints && req.TLS != nil {
+		fingerprints = fingerprint.FromContext(req.Context())
+	}
+
+	userAgent := req.UserAgent()
+
+	for _, sig := range b.signatures {
+		matched, verified := sig.match(userAgent, parsedIP, fingerprints)
+		if !matched {
+			continue
+		}
+
+		if !verified {
+			return sig, CategoryImpersonator
+		}
+
+		return sig, sig.category
+	}
+
+	return nil, ""
+}
+
+func (b *botManager) serveChallenge(rw http.ResponseWriter, req *http.Request, clientIP string) {
+	cookie := &http.Cookie{
+		Name:     ChallengeCookieName,
+		Value:    b.challengeToken(clientIP, time.Now().Add(b.challengeTTL)),
+		Path:     "/",
+		MaxAge:   int(b.challengeTTL.Seconds()),
+		Secure:   req.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	}
+
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.WriteHeader(http.StatusForbidden)
+
+	if err := challengePage.Execute(rw, cookie.String()); err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Send()
+	}
+}
+
+// challengeToken returns the signed token proving that the client with the given IP solved the challenge,
+// valid until the given expiration time.
+func (b *botManager) challengeToken(clientIP string, expiration time.Time) string {
+	expiry := strconv.FormatInt(expiration.Unix(), 10)
+
+	mac := hmac.New(sha256.New, b.challengeSecret)
+	mac.Write([]byte(clientIP + "|" + expiry))
+
+	return expiry + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (b *botManager) isChallengeSolved(req *http.Request, clientIP string) bool {
+	cookie, err := req.Cookie(ChallengeCookieName)
+	if err != nil {
+		return false
+	}
+
+	expiry, _, ok := strings.Cut(cookie.Value, ".")
+	if !ok {
+		return false
+	}
+
+	expiration, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || time.Now().Unix() > expiration {
+		return false
+	}
+
+	expected := b.challengeToken(clientIP, time.Unix(expiration, 0))
+
+	return hmac.Equal([]byte(cookie.Value), []byte(expected))
+}
+
+// signature is a compiled bot signature.
+type signature struct {
+	name         string
+	category     string
+	userAgents   []*regexp.Regexp
+	sourceRanges *ip.Checker
+	ja3          map[string]struct{}
+	ja4          map[string]struct{}
+}
+
+func newSignature(config dynamic.BotSignature) (*signature, error) {
+	if config.Category == "" {
+		return nil, errors.New("empty category")
+	}
+
+	if len(config.UserAgents) == 0 && len(config.SourceRanges) == 0 && len(config.JA3) == 0 && len(config.JA4) == 0 {
+		return nil, errors.New("no user agent, source range, or TLS fingerprint defined")
+	}
+
+	sig := &signature{
+		name:     config.Name,
+		category: config.Category,
+		ja3:      toSet(config.JA3),
+		ja4:      toSet(config.JA4),
+	}
+
+	for _, userAgent := range config.UserAgents {
+		re, err := regexp.Compile(userAgent)
+		if err != nil {
+			return nil, fmt.Errorf("compiling user agent %q: %w", userAgent, err)
+		}
+
+		sig.userAgents = append(sig.userAgents, re)
+	}
+
+	if len(config.SourceRanges) > 0 {
+		var err error
+		sig.sourceRanges, err = ip.NewChecker(config.SourceRanges)
+		if err != nil {
+			return nil, fmt.Errorf("parsing source ranges: %w", err)
+		}
+	}
+
+	return sig, nil
+}
+
+// match returns whether the request matches the signature,
+// and whether a request matched by its user agent comes from the source ranges of the bot.
+func (s *signature) match(userAgent string, clientIP net.IP, fingerprints *fingerprint.Fingerprints) (matched, verified bool) {
+	inSourceRanges := s.sourceRanges != nil && clientIP != nil && s.sourceRanges.ContainsIP(clientIP)
+
+	if s.matchFingerprints(fingerprints) {
+		return true, true
+	}
+
+	for _, re := range s.userAgents {
+		if re.MatchString(userAgent) {
+			return true, s.sourceRanges == nil || inSourceRanges
+		}
+	}
+
+	// A signature defining only source ranges identifies the bot by its IP.
+	if len(s.userAgents) == 0 && len(s.ja3) == 0 && len(s.ja4) == 0 {
+		return inSourceRanges, true
+	}
+
+	return false, false
+}
+
+func (s *signature) matchFingerprints(fingerprints *fingerprint.Fingerprints) bool {
+	if fingerprints == nil {
+		return false
+	}
+
+	if _, ok := s.ja3[fingerprints.JA3]; ok {
+		return true
+	}
+
+	_, ok := s.ja4[fingerprints.JA4]
+	return ok
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+
+	return set
+}
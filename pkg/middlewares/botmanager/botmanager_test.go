@@ -0,0 +1,355 @@
+package botmanager
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/metrics"
+	"github.com/traefik/traefik/v3/pkg/testhelpers"
+	"github.com/traefik/traefik/v3/pkg/tls/fingerprint"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.BotManager
+	}{
+		{
+			desc:   "invalid action",
+			config: dynamic.BotManager{Actions: map[string]string{CategoryScanner: "drop"}},
+		},
+		{
+			desc: "signature without category",
+			config: dynamic.BotManager{Signatures: []dynamic.BotSignature{
+				{Name: "foo", UserAgents: []string{"foo"}},
+			}},
+		},
+		{
+			desc: "signature without criterion",
+			config: dynamic.BotManager{Signatures: []dynamic.BotSignature{
+				{Name: "foo", Category: "custom"},
+			}},
+		},
+		{
+			desc: "invalid user agent",
+			config: dynamic.BotManager{Signatures: []dynamic.BotSignature{
+				{Name: "foo", Category: "custom", UserAgents: []string{"foo("}},
+			}},
+		},
+		{
+			desc: "invalid source range",
+			config: dynamic.BotManager{Signatures: []dynamic.BotSignature{
+				{Name: "foo", Category: "custom", SourceRanges: []string{"foo"}},
+			}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "botmanager", nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBotManager_ServeHTTP(t *testing.T) {
+	actions := map[string]string{
+		CategorySearchEngine: ActionAllow,
+		CategoryScanner:      ActionBlock,
+		CategoryImpersonator: ActionBlock,
+		CategoryAutomation:   ActionTarpit,
+	}
+
+	testCases := []struct {
+		desc             string
+		config           dynamic.BotManager
+		userAgent        string
+		remoteAddr       string
+		fingerprints     *fingerprint.Fingerprints
+		expectedStatus   int
+		expectedCategory string
+		expectedAction   string
+	}{
+		{
+			desc:           "browser",
+			config:         dynamic.BotManager{Actions: actions},
+			userAgent:      "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0",
+			remoteAddr:     "10.0.0.1:1234",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:             "verified search engine",
+			config:           dynamic.BotManager{Actions: actions},
+			userAgent:        "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			remoteAddr:       "66.249.66.1:1234",
+			expectedStatus:   http.StatusOK,
+			expectedCategory: CategorySearchEngine,
+			expectedAction:   ActionAllow,
+		},
+		{
+			desc:             "search engine impersonator",
+			config:           dynamic.BotManager{Actions: actions},
+			userAgent:        "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			remoteAddr:       "10.0.0.1:1234",
+			expectedStatus:   http.StatusForbidden,
+			expectedCategory: CategoryImpersonator,
+			expectedAction:   ActionBlock,
+		},
+		{
+			desc:             "scanner",
+			config:           dynamic.BotManager{Actions: actions},
+			userAgent:        "sqlmap/1.8#stable (https://sqlmap.org)",
+			remoteAddr:       "10.0.0.1:1234",
+			expectedStatus:   http.StatusForbidden,
+			expectedCategory: CategoryScanner,
+			expectedAction:   ActionBlock,
+		},
+		{
+			desc:             "category without action",
+			config:           dynamic.BotManager{Actions: actions},
+			userAgent:        "Mozilla/5.0 (compatible; AhrefsBot/7.0; +http://ahrefs.com/robot/)",
+			remoteAddr:       "10.0.0.1:1234",
+			expectedStatus:   http.StatusOK,
+			expectedCategory: CategorySEO,
+			expectedAction:   ActionAllow,
+		},
+		{
+			desc:             "tarpit",
+			config:           dynamic.BotManager{Actions: actions, TarpitDelay: ptypes.Duration(10 * time.Millisecond)},
+			userAgent:        "curl/8.5.0",
+			remoteAddr:       "10.0.0.1:1234",
+			expectedStatus:   http.StatusOK,
+			expectedCategory: CategoryAutomation,
+			expectedAction:   ActionTarpit,
+		},
+		{
+			desc: "challenge",
+			config: dynamic.BotManager{
+				Actions: map[string]string{CategoryHeadless: ActionChallenge},
+			},
+			userAgent:        "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/130.0.0.0 Safari/537.36",
+			remoteAddr:       "10.0.0.1:1234",
+			expectedStatus:   http.StatusForbidden,
+			expectedCategory: CategoryHeadless,
+			expectedAction:   ActionChallenge,
+		},
+		{
+			desc: "custom signature before built-in ones",
+			config: dynamic.BotManager{
+				Actions: map[string]string{"partner": ActionAllow, CategoryAutomation: ActionBlock},
+				Signatures: []dynamic.BotSignature{
+					{Name: "Partner", Category: "partner", UserAgents: []string{`^curl/`}, SourceRanges: []string{"10.0.0.0/8"}},
+				},
+			},
+			userAgent:        "curl/8.5.0",
+			remoteAddr:       "10.0.0.1:1234",
+			expectedStatus:   http.StatusOK,
+			expectedCategory: "partner",
+			expectedAction:   ActionAllow,
+		},
+		{
+			desc: "source ranges only signature",
+			config: dynamic.BotManager{
+				Actions: map[string]string{"cloud": ActionBlock},
+				Signatures: []dynamic.BotSignature{
+					{Name: "Cloud", Category: "cloud", SourceRanges: []string{"192.0.2.0/24"}},
+				},
+			},
+			userAgent:        "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0",
+			remoteAddr:       "192.0.2.1:1234",
+			expectedStatus:   http.StatusForbidden,
+			expectedCategory: "cloud",
+			expectedAction:   ActionBlock,
+		},
+		{
+			desc: "TLS fingerprint",
+			config: dynamic.BotManager{
+				Actions: map[string]string{"stealth": ActionBlock},
+				Signatures: []dynamic.BotSignature{
+					{Name: "Stealth", Category: "stealth", JA4: []string{"t13d1516h2_8daaf6152771_02713d6af862"}},
+				},
+			},
+			userAgent:        "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0",
+			remoteAddr:       "10.0.0.1:1234",
+			fingerprints:     &fingerprint.Fingerprints{JA3: "ja3", JA4: "t13d1516h2_8daaf6152771_02713d6af862"},
+			expectedStatus:   http.StatusForbidden,
+			expectedCategory: "stealth",
+			expectedAction:   ActionBlock,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			registry := newCollectingRegistry()
+
+			var forwardedCategory string
+			handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwardedCategory = GetCategory(req.Context())
+			}), test.config, "botmanager", registry)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+			req.RemoteAddr = test.remoteAddr
+			req.Header.Set("User-Agent", test.userAgent)
+			if test.fingerprints != nil {
+				req.TLS = &tls.ConnectionState{}
+				req = req.WithContext(fingerprint.NewContext(req.Context(), staticSource{test.fingerprints}))
+			}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+
+			if test.expectedStatus == http.StatusOK {
+				assert.Equal(t, test.expectedCategory, forwardedCategory)
+			}
+
+			if test.expectedCategory == "" {
+				assert.Empty(t, registry.counter.LastLabelValues)
+				return
+			}
+
+			assert.Equal(t, []string{"middleware", "botmanager", "category", test.expectedCategory, "action", test.expectedAction}, registry.counter.LastLabelValues)
+		})
+	}
+}
+
+func TestBotManager_challenge(t *testing.T) {
+	config := dynamic.BotManager{
+		Actions:         map[string]string{CategoryAutomation: ActionChallenge},
+		ChallengeSecret: "secret",
+	}
+
+	var forwarded bool
+	handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwarded = true
+	}), config, "botmanager", nil)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("User-Agent", "curl/8.5.0")
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	assert.False(t, forwarded)
+	assert.Equal(t, http.StatusForbidden, rw.Code)
+	assert.Contains(t, rw.Body.String(), ChallengeCookieName)
+
+	bm := handler.(*botManager)
+
+	testCases := []struct {
+		desc              string
+		cookie            string
+		remoteAddr        string
+		expectedForwarded bool
+	}{
+		{
+			desc:              "valid cookie",
+			cookie:            bm.challengeToken("10.0.0.1", time.Now().Add(time.Minute)),
+			remoteAddr:        "10.0.0.1:1234",
+			expectedForwarded: true,
+		},
+		{
+			desc:       "cookie of another IP",
+			cookie:     bm.challengeToken("10.0.0.2", time.Now().Add(time.Minute)),
+			remoteAddr: "10.0.0.1:1234",
+		},
+		{
+			desc:       "expired cookie",
+			cookie:     bm.challengeToken("10.0.0.1", time.Now().Add(-time.Minute)),
+			remoteAddr: "10.0.0.1:1234",
+		},
+		{
+			desc:       "forged cookie",
+			cookie:     "4102444800.Zm9vYmFy",
+			remoteAddr: "10.0.0.1:1234",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			forwarded = false
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+			req.RemoteAddr = test.remoteAddr
+			req.Header.Set("User-Agent", "curl/8.5.0")
+			req.AddCookie(&http.Cookie{Name: ChallengeCookieName, Value: test.cookie})
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedForwarded, forwarded)
+		})
+	}
+}
+
+func TestBotManager_tarpitCanceled(t *testing.T) {
+	config := dynamic.BotManager{
+		Actions:     map[string]string{CategoryAutomation: ActionTarpit},
+		TarpitDelay: ptypes.Duration(time.Hour),
+	}
+
+	var forwarded bool
+	handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwarded = true
+	}), config, "botmanager", nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil).WithContext(ctx)
+	req.Header.Set("User-Agent", "curl/8.5.0")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.False(t, forwarded)
+}
+
+func TestDefaultSignatures(t *testing.T) {
+	for _, config := range defaultSignatures {
+		_, err := newSignature(config)
+		require.NoError(t, err, config.Name)
+	}
+}
+
+type staticSource struct {
+	fingerprints *fingerprint.Fingerprints
+}
+
+func (s staticSource) TLSFingerprints() *fingerprint.Fingerprints {
+	return s.fingerprints
+}
+
+type collectingRegistry struct {
+	metrics.Registry
+
+	counter *testhelpers.CollectingCounter
+}
+
+func newCollectingRegistry() *collectingRegistry {
+	return &collectingRegistry{
+		Registry: metrics.NewVoidRegistry(),
+		counter:  &testhelpers.CollectingCounter{},
+	}
+}
+
+func (r *collectingRegistry) BotReqsCounter() gokitmetrics.Counter {
+	return r.counter
+}
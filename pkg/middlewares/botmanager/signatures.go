@@ -0,0 +1,112 @@
+package botmanager
+
+import "github.com/traefik/traefik/v3/pkg/config/dynamic"
+
+// Bot categories of the built-in signatures.
+const (
+	CategorySearchEngine = "searchEngine"
+	CategoryAICrawler    = "aiCrawler"
+	CategorySocialMedia  = "socialMedia"
+	CategoryMonitoring   = "monitoring"
+	CategorySEO          = "seo"
+	CategoryScanner      = "scanner"
+	CategoryAutomation   = "automation"
+	CategoryHeadless     = "headless"
+	// CategoryImpersonator is the category of the requests using the user agent of a bot,
+	// from outside the source ranges of this bot.
+	CategoryImpersonator = "impersonator"
+)
+
+// defaultSignatures is the built-in signature list.
+// The signatures are evaluated in order, the first matching one giving the category of the request,
+// so the most specific user agents come first.
+var defaultSignatures = []dynamic.BotSignature{
+	// Search engines.
+	{
+		Name:         "Googlebot",
+		Category:     CategorySearchEngine,
+		UserAgents:   []string{`Googlebot`, `Google-InspectionTool`, `Storebot-Google`, `AdsBot-Google`},
+		SourceRanges: []string{"66.249.64.0/19"},
+	},
+	{
+		Name:         "Bingbot",
+		Category:     CategorySearchEngine,
+		UserAgents:   []string{`bingbot`, `BingPreview`, `adidxbot`},
+		SourceRanges: []string{"13.66.139.0/24", "40.77.167.0/24", "157.55.39.0/24", "207.46.13.0/24"},
+	},
+	{
+		Name:         "Applebot",
+		Category:     CategorySearchEngine,
+		UserAgents:   []string{`Applebot`},
+		SourceRanges: []string{"17.0.0.0/8"},
+	},
+	{Name: "DuckDuckBot", Category: CategorySearchEngine, UserAgents: []string{`DuckDuckBot`, `DuckAssistBot`}},
+	{Name: "YandexBot", Category: CategorySearchEngine, UserAgents: []string{`YandexBot`, `YandexImages`, `YandexMobileBot`}},
+	{Name: "Baiduspider", Category: CategorySearchEngine, UserAgents: []string{`Baiduspider`}},
+	{Name: "Qwantbot", Category: CategorySearchEngine, UserAgents: []string{`Qwantify`, `Qwantbot`}},
+	{Name: "SeznamBot", Category: CategorySearchEngine, UserAgents: []string{`SeznamBot`}},
+
+	// AI crawlers and assistants.
+	{Name: "GPTBot", Category: CategoryAICrawler, UserAgents: []string{`GPTBot`, `ChatGPT-User`, `OAI-SearchBot`}},
+	{Name: "ClaudeBot", Category: CategoryAICrawler, UserAgents: []string{`ClaudeBot`, `Claude-Web`, `Claude-User`, `anthropic-ai`}},
+	{Name: "CCBot", Category: CategoryAICrawler, UserAgents: []string{`CCBot`}},
+	{Name: "PerplexityBot", Category: CategoryAICrawler, UserAgents: []string{`PerplexityBot`, `Perplexity-User`}},
+	{Name: "Bytespider", Category: CategoryAICrawler, UserAgents: []string{`Bytespider`}},
+	{Name: "Amazonbot", Category: CategoryAICrawler, UserAgents: []string{`Amazonbot`}},
+	{Name: "Meta AI", Category: CategoryAICrawler, UserAgents: []string{`meta-externalagent`, `meta-externalfetcher`}},
+	{Name: "Google-CloudVertexBot", Category: CategoryAICrawler, UserAgents: []string{`Google-CloudVertexBot`}},
+	{Name: "cohere-ai", Category: CategoryAICrawler, UserAgents: []string{`cohere-ai`, `cohere-training-data-crawler`}},
+	{Name: "Diffbot", Category: CategoryAICrawler, UserAgents: []string{`Diffbot`}},
+
+	// Link previews of social media and messaging applications.
+	{Name: "Facebook", Category: CategorySocialMedia, UserAgents: []string{`facebookexternalhit`, `facebookcatalog`}},
+	{Name: "Twitterbot", Category: CategorySocialMedia, UserAgents: []string{`Twitterbot`}},
+	{Name: "LinkedInBot", Category: CategorySocialMedia, UserAgents: []string{`LinkedInBot`}},
+	{Name: "Slackbot", Category: CategorySocialMedia, UserAgents: []string{`Slackbot`, `Slack-ImgProxy`}},
+	{Name: "Discordbot", Category: CategorySocialMedia, UserAgents: []string{`Discordbot`}},
+	{Name: "TelegramBot", Category: CategorySocialMedia, UserAgents: []string{`TelegramBot`}},
+	{Name: "WhatsApp", Category: CategorySocialMedia, UserAgents: []string{`WhatsApp/`}},
+	{Name: "Pinterestbot", Category: CategorySocialMedia, UserAgents: []string{`Pinterestbot`, `Pinterest/`}},
+
+	// Uptime monitoring services.
+	{Name: "UptimeRobot", Category: CategoryMonitoring, UserAgents: []string{`UptimeRobot`}},
+	{Name: "Pingdom", Category: CategoryMonitoring, UserAgents: []string{`Pingdom`}},
+	{Name: "StatusCake", Category: CategoryMonitoring, UserAgents: []string{`StatusCake`}},
+	{Name: "Site24x7", Category: CategoryMonitoring, UserAgents: []string{`Site24x7`}},
+	{Name: "Better Stack", Category: CategoryMonitoring, UserAgents: []string{`Better Uptime Bot`, `Better Stack Bot`}},
+	{Name: "Datadog Synthetics", Category: CategoryMonitoring, UserAgents: []string{`DatadogSynthetics`}},
+
+	// SEO tools.
+	{Name: "AhrefsBot", Category: CategorySEO, UserAgents: []string{`AhrefsBot`, `AhrefsSiteAudit`}},
+	{Name: "SemrushBot", Category: CategorySEO, UserAgents: []string{`SemrushBot`, `SiteAuditBot`}},
+	{Name: "MJ12bot", Category: CategorySEO, UserAgents: []string{`MJ12bot`}},
+	{Name: "DotBot", Category: CategorySEO, UserAgents: []string{`DotBot`}},
+	{Name: "DataForSeoBot", Category: CategorySEO, UserAgents: []string{`DataForSeoBot`}},
+	{Name: "Screaming Frog", Category: CategorySEO, UserAgents: []string{`Screaming Frog SEO Spider`}},
+	{Name: "BLEXBot", Category: CategorySEO, UserAgents: []string{`BLEXBot`}},
+
+	// Vulnerability scanners.
+	{Name: "sqlmap", Category: CategoryScanner, UserAgents: []string{`sqlmap/`}},
+	{Name: "Nikto", Category: CategoryScanner, UserAgents: []string{`Nikto`}},
+	{Name: "Nmap", Category: CategoryScanner, UserAgents: []string{`Nmap Scripting Engine`}},
+	{Name: "masscan", Category: CategoryScanner, UserAgents: []string{`masscan`}},
+	{Name: "ZGrab", Category: CategoryScanner, UserAgents: []string{`zgrab`}},
+	{Name: "Nuclei", Category: CategoryScanner, UserAgents: []string{`Nuclei`}},
+	{Name: "WPScan", Category: CategoryScanner, UserAgents: []string{`WPScan`}},
+	{Name: "Acunetix", Category: CategoryScanner, UserAgents: []string{`Acunetix`}},
+	{Name: "Content discovery", Category: CategoryScanner, UserAgents: []string{`(?i)dirbuster`, `(?i)gobuster`, `Fuzz Faster U Fool`}},
+
+	// Headless browsers.
+	{Name: "Headless Chrome", Category: CategoryHeadless, UserAgents: []string{`HeadlessChrome`}},
+	{Name: "PhantomJS", Category: CategoryHeadless, UserAgents: []string{`PhantomJS`}},
+
+	// HTTP libraries and command-line tools.
+	{Name: "curl", Category: CategoryAutomation, UserAgents: []string{`^curl/`}},
+	{Name: "Wget", Category: CategoryAutomation, UserAgents: []string{`^Wget/`}},
+	{Name: "Python", Category: CategoryAutomation, UserAgents: []string{`python-requests/`, `Python-urllib/`, `python-httpx/`, `aiohttp/`}},
+	{Name: "Go", Category: CategoryAutomation, UserAgents: []string{`^Go-http-client/`}},
+	{Name: "Java", Category: CategoryAutomation, UserAgents: []string{`^Java/`, `Apache-HttpClient/`, `^okhttp/`}},
+	{Name: "Node.js", Category: CategoryAutomation, UserAgents: []string{`^axios/`, `^node-fetch`, `^undici`}},
+	{Name: "Perl", Category: CategoryAutomation, UserAgents: []string{`libwww-perl/`}},
+	{Name: "Scrapy", Category: CategoryAutomation, UserAgents: []string{`Scrapy/`}},
+}
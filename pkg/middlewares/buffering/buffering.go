@@ -18,6 +18,7 @@ const (
 
 type buffer struct {
 	name   string
+	next   http.Handler
 	buffer *oxybuffer.Buffer
 }
 
@@ -44,6 +45,7 @@ func New(ctx context.Context, next http.Handler, config dynamic.Buffering, name
 
 	return &buffer{
 		name:   name,
+		next:   next,
 		buffer: oxyBuffer,
 	}, nil
 }
@@ -53,5 +55,11 @@ func (b *buffer) GetTracingInformation() (string, string, trace.SpanKind) {
 }
 
 func (b *buffer) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	// The Server-Sent Events streams would stall until their end if the response was buffered.
+	if middlewares.IsEventStreamRequest(req) {
+		b.next.ServeHTTP(rw, req)
+		return
+	}
+
 	b.buffer.ServeHTTP(rw, req)
 }
@@ -69,3 +69,28 @@ func TestBuffering(t *testing.T) {
 		})
 	}
 }
+
+func TestBuffering_eventStream(t *testing.T) {
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "text/event-stream")
+		rw.WriteHeader(http.StatusOK)
+		_, err := rw.Write([]byte("data: event\n\n"))
+		require.NoError(t, err)
+
+		// The event is sent to the client before the end of the response.
+		rw.(http.Flusher).Flush()
+		assert.True(t, rw.(*httptest.ResponseRecorder).Flushed)
+	})
+
+	buffMiddleware, err := New(context.Background(), next, dynamic.Buffering{MaxResponseBodyBytes: 1}, "foo")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	req.Header.Set("Accept", "text/event-stream")
+
+	recorder := httptest.NewRecorder()
+	buffMiddleware.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "data: event\n\n", recorder.Body.String())
+}
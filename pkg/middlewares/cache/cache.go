@@ -0,0 +1,592 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/safe"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "Cache"
+
+const (
+	defaultMaxBodySize = 1024 * 1024
+
+	// cacheStatusHeader is the header telling how the cache handled the request.
+	// https://www.rfc-editor.org/rfc/rfc9211
+	cacheStatusHeader = "Cache-Status"
+)
+
+// entry is a stored response.
+type entry struct {
+	Status       int         `json:"status,omitempty"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         []byte      `json:"body,omitempty"`
+	RequestTime  time.Time   `json:"requestTime"`
+	ResponseTime time.Time   `json:"responseTime"`
+
+	Lifetime             time.Duration `json:"lifetime,omitempty"`
+	StaleWhileRevalidate time.Duration `json:"staleWhileRevalidate,omitempty"`
+	MustRevalidate       bool          `json:"mustRevalidate,omitempty"`
+
+	// Vary holds, in the entry stored at the key of a response varying on request headers, the names of these headers,
+	// the response itself being stored at a key made of the values of these headers.
+	Vary []string `json:"vary,omitempty"`
+}
+
+// age returns the age of the response.
+// https://www.rfc-editor.org/rfc/rfc9111#section-4.2.3
+func (e *entry) age(now time.Time) time.Duration {
+	var apparentAge time.Duration
+	if date, err := http.ParseTime(e.Header.Get("Date")); err == nil {
+		apparentAge = max(0, e.ResponseTime.Sub(date))
+	}
+
+	var ageValue time.Duration
+	if seconds, err := strconv.ParseInt(e.Header.Get("Age"), 10, 64); err == nil && seconds > 0 {
+		ageValue = time.Duration(seconds) * time.Second
+	}
+
+	correctedAgeValue := ageValue + e.ResponseTime.Sub(e.RequestTime)
+
+	return max(apparentAge, correctedAgeValue) + now.Sub(e.ResponseTime)
+}
+
+func (e *entry) setPolicy(p policy) {
+	e.Lifetime = p.lifetime
+	e.StaleWhileRevalidate = p.staleWhileRevalidate
+	e.MustRevalidate = p.mustRevalidate
+}
+
+// refresh returns the entry updated with the headers of a not modified response.
+// https://www.rfc-editor.org/rfc/rfc9111#section-4.3.4
+func (e *entry) refresh(header http.Header, requestTime, responseTime time.Time) *entry {
+	refreshed := *e
+	refreshed.Header = e.Header.Clone()
+	refreshed.Header.Del("Age")
+	refreshed.Header.Del("Date")
+
+	for name, values := range header {
+		if name == "Content-Length" {
+			continue
+		}
+
+		refreshed.Header[name] = values
+	}
+
+	refreshed.RequestTime = requestTime
+	refreshed.ResponseTime = responseTime
+
+	return &refreshed
+}
+
+// cache is a middleware caching the responses of the next handler.
+type cache struct {
+	next  http.Handler
+	name  string
+	store store
+
+	ttl                  time.Duration
+	defaultTTL           time.Duration
+	staleWhileRevalidate time.Duration
+	maxBodySize          int64
+
+	keys requestKey
+
+	// revalidations holds the keys of the responses being revalidated in the background.
+	revalidations sync.Map
+}
+
+// New creates a cache middleware.
+// The store of the middleware is given by the manager, which can be nil.
+func New(ctx context.Context, next http.Handler, manager *Manager, config dynamic.Cache, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeName)
+	logger.Debug().Msg("Creating middleware")
+
+	if config.MaxBodySize < 0 {
+		return nil, fmt.Errorf("invalid maxBodySize %d: must be positive", config.MaxBodySize)
+	}
+
+	var storeConfig dynamic.CacheStore
+	if config.Store != nil {
+		storeConfig = *config.Store
+	}
+
+	s, err := manager.getStore(ctx, name, storeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating cache store: %w", err)
+	}
+
+	c := &cache{
+		next:                 next,
+		name:                 name,
+		store:                s,
+		ttl:                  time.Duration(config.TTL),
+		defaultTTL:           time.Duration(config.DefaultTTL),
+		staleWhileRevalidate: time.Duration(config.StaleWhileRevalidate),
+		maxBodySize:          config.MaxBodySize,
+		keys:                 newRequestKey(config.Key),
+	}
+
+	if c.maxBodySize == 0 {
+		c.maxBodySize = defaultMaxBodySize
+	}
+
+	return c, nil
+}
+
+func (c *cache) GetTracingInformation() (string, string, trace.SpanKind) {
+	return c.name, typeName, trace.SpanKindInternal
+}
+
+func (c *cache) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), c.name, typeName)
+
+	key := c.keys.of(req)
+
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodHead, http.MethodOptions, http.MethodTrace:
+		c.next.ServeHTTP(rw, req)
+		return
+
+	default:
+		// The responses stored for the target of a successful unsafe request are invalidated.
+		// https://www.rfc-editor.org/rfc/rfc9111#section-4.4
+		cw := &captureWriter{rw: rw, header: rw.Header()}
+		c.next.ServeHTTP(cw, req)
+
+		if cw.status < http.StatusBadRequest {
+			if err := c.store.delete(req.Context(), key); err != nil {
+				logger.Error().Err(err).Msg("Unable to invalidate the cached response")
+			}
+		}
+		return
+	}
+
+	reqCC := parseCacheControl(req.Header.Values("Cache-Control"))
+	if reqCC.has("no-store") || reqCC.has("no-cache") || req.Header.Get("Pragma") == "no-cache" {
+		c.forward(rw, req, key, nil, "request")
+		return
+	}
+
+	stored, err := c.lookup(req.Context(), key, req)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to read the cached response")
+	}
+
+	if stored == nil {
+		if reqCC.has("only-if-cached") {
+			rw.Header().Set(cacheStatusHeader, cacheStatus("fwd=miss"))
+			rw.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+
+		c.forward(rw, req, key, nil, "miss")
+		return
+	}
+
+	age := stored.age(time.Now())
+	maxAge, hasMaxAge := reqCC.duration("max-age")
+
+	if age < stored.Lifetime && (!hasMaxAge || age <= maxAge) {
+		c.serve(rw, req, stored, age, "hit")
+		return
+	}
+
+	if !stored.MustRevalidate && !hasMaxAge && age < stored.Lifetime+stored.StaleWhileRevalidate {
+		c.serve(rw, req, stored, age, "hit")
+		c.revalidateInBackground(req, key, stored)
+		return
+	}
+
+	c.forward(rw, req, key, stored, "stale")
+}
+
+// forward sends the request to the next handler, as a conditional request when a stale response is stored,
+// and stores the response when allowed.
+// The response is written to rw, unless it is nil.
+func (c *cache) forward(rw http.ResponseWriter, req *http.Request, key string, stored *entry, fwd string) {
+	ctx := req.Context()
+	logger := middlewares.GetLogger(ctx, c.name, typeName)
+
+	etag := ""
+	lastModified := ""
+	if stored != nil {
+		etag = stored.Header.Get("ETag")
+		lastModified = stored.Header.Get("Last-Modified")
+	}
+
+	revalidating := etag != "" || lastModified != ""
+
+	outReq := req
+	if revalidating {
+		outReq = req.Clone(ctx)
+		outReq.Header.Del("If-None-Match")
+		outReq.Header.Del("If-Modified-Since")
+
+		if etag != "" {
+			outReq.Header.Set("If-None-Match", etag)
+		}
+
+		if lastModified != "" {
+			outReq.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	cw := &captureWriter{
+		rw:          rw,
+		header:      make(http.Header),
+		cacheStatus: cacheStatus("fwd=" + fwd),
+		maxSize:     c.maxBodySize,
+		hold: func(status int) bool {
+			// A not modified response refreshes the stored response, which is served instead.
+			return rw == nil || (revalidating && status == http.StatusNotModified)
+		},
+	}
+
+	requestTime := time.Now()
+	c.next.ServeHTTP(cw, outReq)
+	cw.finish()
+	responseTime := time.Now()
+
+	if revalidating && cw.status == http.StatusNotModified {
+		refreshed := stored.refresh(cw.header, requestTime, responseTime)
+
+		if p, ok := c.policy(req, refreshed.Status, refreshed.Header, responseTime); ok {
+			refreshed.setPolicy(p)
+
+			if err := c.save(ctx, key, req, refreshed, p.retention()); err != nil {
+				logger.Error().Err(err).Msg("Unable to store the revalidated response")
+			}
+		}
+
+		if rw != nil {
+			c.serve(rw, req, refreshed, refreshed.age(time.Now()), "fwd=stale; fwd-status=304")
+		}
+		return
+	}
+
+	p, ok := c.policy(req, cw.status, cw.header, responseTime)
+	if !ok || cw.tooLarge {
+		if stored != nil {
+			if err := c.store.delete(ctx, key); err != nil {
+				logger.Error().Err(err).Msg("Unable to invalidate the cached response")
+			}
+		}
+		return
+	}
+
+	e := &entry{
+		Status:       cw.status,
+		Header:       cw.header,
+		Body:         cw.body.Bytes(),
+		RequestTime:  requestTime,
+		ResponseTime: responseTime,
+	}
+	e.setPolicy(p)
+
+	if err := c.save(ctx, key, req, e, p.retention()); err != nil {
+		logger.Error().Err(err).Msg("Unable to store the response")
+	}
+}
+
+// revalidateInBackground revalidates the given stale response, unless it is already being revalidated.
+func (c *cache) revalidateInBackground(req *http.Request, key string, stored *entry) {
+	if _, loaded := c.revalidations.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+
+	// The revalidation outlives the request, and must not share its context.
+	outReq := req.Clone(context.Background())
+
+	safe.Go(func() {
+		defer c.revalidations.Delete(key)
+
+		c.forward(nil, outReq, key, stored, "stale")
+	})
+}
+
+// serve writes the stored response, or a not modified response when the request preconditions allow it.
+func (c *cache) serve(rw http.ResponseWriter, req *http.Request, e *entry, age time.Duration, status string) {
+	header := rw.Header()
+	for name, values := range e.Header {
+		header[name] = slices.Clone(values)
+	}
+
+	header.Set("Age", strconv.FormatInt(int64(age/time.Second), 10))
+
+	if status == "hit" {
+		status += "; ttl=" + strconv.FormatInt(int64((e.Lifetime-age)/time.Second), 10)
+	}
+
+	header.Set(cacheStatusHeader, cacheStatus(status))
+
+	if e.Status == http.StatusOK && notModified(req, e.Header) {
+		rw.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	rw.WriteHeader(e.Status)
+
+	if _, err := rw.Write(e.Body); err != nil {
+		middlewares.GetLogger(req.Context(), c.name, typeName).Debug().Err(err).Msg("Unable to write the cached response")
+	}
+}
+
+// requestKey builds the keys of the requests, from their host and path, and from the configured parts of the requests.
+type requestKey struct {
+	ignoreQuery bool
+	headers     []string
+	cookies     []string
+}
+
+func newRequestKey(config *dynamic.CacheKey) requestKey {
+	var k requestKey
+	if config == nil {
+		return k
+	}
+
+	k.ignoreQuery = config.IgnoreQuery
+	k.cookies = config.Cookies
+
+	for _, header := range config.Headers {
+		k.headers = append(k.headers, http.CanonicalHeaderKey(header))
+	}
+
+	return k
+}
+
+// of returns the key of the given request.
+func (k requestKey) of(req *http.Request) string {
+	var b strings.Builder
+
+	b.WriteString(strings.ToLower(req.Host))
+	b.WriteString(req.URL.EscapedPath())
+
+	if !k.ignoreQuery && req.URL.RawQuery != "" {
+		b.WriteString("?")
+		b.WriteString(req.URL.Query().Encode())
+	}
+
+	for _, header := range k.headers {
+		b.WriteString("|" + header + "=" + strings.Join(req.Header.Values(header), ","))
+	}
+
+	for _, name := range k.cookies {
+		var value string
+		if cookie, err := req.Cookie(name); err == nil {
+			value = cookie.Value
+		}
+
+		b.WriteString("|cookie:" + name + "=" + value)
+	}
+
+	return b.String()
+}
+
+// lookup returns the response stored for the given request, or nil if there is none.
+func (c *cache) lookup(ctx context.Context, key string, req *http.Request) (*entry, error) {
+	e, err := c.load(ctx, key)
+	if err != nil || e == nil || len(e.Vary) == 0 {
+		return e, err
+	}
+
+	return c.load(ctx, key+varyKey(req, e.Vary))
+}
+
+func (c *cache) load(ctx context.Context, key string) (*entry, error) {
+	data, err := c.store.get(ctx, key)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var e entry
+	if err = json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("decoding cached response: %w", err)
+	}
+
+	return &e, nil
+}
+
+// save stores the given response to the given request.
+// A response varying on request headers is stored at a key made of their values,
+// the names of the headers being stored at the key of the request.
+func (c *cache) save(ctx context.Context, key string, req *http.Request, e *entry, retention time.Duration) error {
+	if vary := varyHeaders(e.Header); len(vary) > 0 {
+		data, err := json.Marshal(entry{Vary: vary})
+		if err != nil {
+			return err
+		}
+
+		if err = c.store.set(ctx, key, data, retention); err != nil {
+			return err
+		}
+
+		key += varyKey(req, vary)
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	return c.store.set(ctx, key, data, retention)
+}
+
+// varyHeaders returns the sorted names of the request headers listed in the Vary header of the response.
+func varyHeaders(header http.Header) []string {
+	var names []string
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+
+	slices.Sort(names)
+
+	return slices.Compact(names)
+}
+
+func varyKey(req *http.Request, names []string) string {
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString("#" + name + "=" + strings.Join(req.Header.Values(name), ","))
+	}
+
+	return b.String()
+}
+
+// notModified reports whether the preconditions of the request match the stored response.
+// https://www.rfc-editor.org/rfc/rfc9110#section-13.1
+func notModified(req *http.Request, header http.Header) bool {
+	if inm := req.Header.Get("If-None-Match"); inm != "" {
+		etag := strings.TrimPrefix(header.Get("ETag"), "W/")
+		if etag == "" {
+			return false
+		}
+
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	ims, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	lastModified, err := http.ParseTime(header.Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+
+	return !lastModified.After(ims)
+}
+
+func cacheStatus(params string) string {
+	return "Traefik; " + params
+}
+
+// captureWriter captures the response of the next handler, while writing it to the client
+// unless the response is held.
+type captureWriter struct {
+	rw          http.ResponseWriter
+	header      http.Header
+	hold        func(status int) bool
+	cacheStatus string
+	maxSize     int64
+
+	status   int
+	held     bool
+	body     bytes.Buffer
+	tooLarge bool
+}
+
+func (w *captureWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *captureWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+
+	w.status = status
+
+	if w.hold != nil && w.hold(status) {
+		w.held = true
+		return
+	}
+
+	header := w.rw.Header()
+	for name, values := range w.header {
+		header[name] = values
+	}
+
+	if w.cacheStatus != "" {
+		header.Set(cacheStatusHeader, w.cacheStatus)
+	}
+
+	w.rw.WriteHeader(status)
+}
+
+func (w *captureWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !w.tooLarge {
+		if int64(w.body.Len()+len(b)) > w.maxSize {
+			w.tooLarge = true
+			w.body.Reset()
+		} else {
+			w.body.Write(b)
+		}
+	}
+
+	if w.held {
+		return len(b), nil
+	}
+
+	return w.rw.Write(b)
+}
+
+func (w *captureWriter) Flush() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.held {
+		return
+	}
+
+	if flusher, ok := w.rw.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// finish writes the status of a response without body.
+func (w *captureWriter) finish() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+}
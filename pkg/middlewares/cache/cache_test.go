@@ -0,0 +1,398 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// countingHandler answers with the number of requests it received, and the given headers.
+type countingHandler struct {
+	calls  atomic.Int32
+	header http.Header
+	status int
+}
+
+func (h *countingHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	n := h.calls.Add(1)
+
+	for name, values := range h.header {
+		rw.Header()[name] = values
+	}
+
+	if etag := h.header.Get("ETag"); etag != "" && req.Header.Get("If-None-Match") == etag {
+		rw.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	status := h.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	rw.WriteHeader(status)
+	_, _ = fmt.Fprintf(rw, "response %d", n)
+}
+
+func TestCache_storability(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		config        dynamic.Cache
+		status        int
+		reqHeader     http.Header
+		respHeader    http.Header
+		expectedCalls int32
+	}{
+		{
+			desc:          "max-age",
+			respHeader:    http.Header{"Cache-Control": {"max-age=60"}},
+			expectedCalls: 1,
+		},
+		{
+			desc:          "s-maxage",
+			respHeader:    http.Header{"Cache-Control": {"s-maxage=60"}},
+			expectedCalls: 1,
+		},
+		{
+			desc:          "expires",
+			respHeader:    http.Header{"Expires": {time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)}},
+			expectedCalls: 1,
+		},
+		{
+			desc:          "expired",
+			respHeader:    http.Header{"Expires": {time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)}},
+			expectedCalls: 2,
+		},
+		{
+			desc:          "no freshness information",
+			expectedCalls: 2,
+		},
+		{
+			desc:          "default TTL",
+			config:        dynamic.Cache{DefaultTTL: ptypes.Duration(time.Minute)},
+			expectedCalls: 1,
+		},
+		{
+			desc:          "default TTL for a not heuristically cacheable status",
+			config:        dynamic.Cache{DefaultTTL: ptypes.Duration(time.Minute)},
+			status:        http.StatusInternalServerError,
+			expectedCalls: 2,
+		},
+		{
+			desc:          "TTL overriding max-age",
+			config:        dynamic.Cache{TTL: ptypes.Duration(time.Minute)},
+			respHeader:    http.Header{"Cache-Control": {"max-age=0"}},
+			expectedCalls: 1,
+		},
+		{
+			desc:          "no-store response",
+			config:        dynamic.Cache{TTL: ptypes.Duration(time.Minute)},
+			respHeader:    http.Header{"Cache-Control": {"no-store"}},
+			expectedCalls: 2,
+		},
+		{
+			desc:          "private response",
+			respHeader:    http.Header{"Cache-Control": {"private, max-age=60"}},
+			expectedCalls: 2,
+		},
+		{
+			desc:          "response setting a cookie",
+			respHeader:    http.Header{"Cache-Control": {"max-age=60"}, "Set-Cookie": {"session=foo"}},
+			expectedCalls: 2,
+		},
+		{
+			desc:          "vary on any header",
+			respHeader:    http.Header{"Cache-Control": {"max-age=60"}, "Vary": {"*"}},
+			expectedCalls: 2,
+		},
+		{
+			desc:          "authorized request",
+			reqHeader:     http.Header{"Authorization": {"Bearer foo"}},
+			respHeader:    http.Header{"Cache-Control": {"max-age=60"}},
+			expectedCalls: 2,
+		},
+		{
+			desc:          "authorized request with a public response",
+			reqHeader:     http.Header{"Authorization": {"Bearer foo"}},
+			respHeader:    http.Header{"Cache-Control": {"public, max-age=60"}},
+			expectedCalls: 1,
+		},
+		{
+			desc:          "no-cache request",
+			reqHeader:     http.Header{"Cache-Control": {"no-cache"}},
+			respHeader:    http.Header{"Cache-Control": {"max-age=60"}},
+			expectedCalls: 2,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := &countingHandler{header: test.respHeader, status: test.status}
+
+			handler, err := New(context.Background(), next, nil, test.config, "cache")
+			require.NoError(t, err)
+
+			for range 2 {
+				req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+				for name, values := range test.reqHeader {
+					req.Header[name] = values
+				}
+
+				handler.ServeHTTP(httptest.NewRecorder(), req)
+			}
+
+			assert.Equal(t, test.expectedCalls, next.calls.Load())
+		})
+	}
+}
+
+func TestCache_hit(t *testing.T) {
+	next := &countingHandler{header: http.Header{"Cache-Control": {"max-age=60"}, "X-Foo": {"bar"}}}
+
+	handler, err := New(context.Background(), next, nil, dynamic.Cache{}, "cache")
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	assert.Equal(t, "Traefik; fwd=miss", rw.Header().Get(cacheStatusHeader))
+	assert.Equal(t, "response 1", rw.Body.String())
+
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "Traefik; hit; ttl=59", rw.Header().Get(cacheStatusHeader))
+	assert.Equal(t, "0", rw.Header().Get("Age"))
+	assert.Equal(t, "bar", rw.Header().Get("X-Foo"))
+	assert.Equal(t, "response 1", rw.Body.String())
+
+	// Another query is another response.
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.com/foo?bar=baz", nil))
+
+	assert.Equal(t, "response 2", rw.Body.String())
+}
+
+func TestCache_key(t *testing.T) {
+	next := &countingHandler{header: http.Header{"Cache-Control": {"max-age=60"}}}
+
+	handler, err := New(context.Background(), next, nil, dynamic.Cache{
+		Key: &dynamic.CacheKey{
+			IgnoreQuery: true,
+			Headers:     []string{"x-tenant"},
+			Cookies:     []string{"lang"},
+		},
+	}, "cache")
+	require.NoError(t, err)
+
+	serve := func(target, tenant, lang string) string {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("X-Tenant", tenant)
+		req.AddCookie(&http.Cookie{Name: "lang", Value: lang})
+
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+
+		return rw.Body.String()
+	}
+
+	assert.Equal(t, "response 1", serve("http://example.com/foo?a=1", "tenant1", "en"))
+	assert.Equal(t, "response 1", serve("http://example.com/foo?a=2", "tenant1", "en"))
+	assert.Equal(t, "response 2", serve("http://example.com/foo", "tenant2", "en"))
+	assert.Equal(t, "response 3", serve("http://example.com/foo", "tenant1", "fr"))
+}
+
+func TestCache_vary(t *testing.T) {
+	next := &countingHandler{header: http.Header{"Cache-Control": {"max-age=60"}, "Vary": {"Accept-Language"}}}
+
+	handler, err := New(context.Background(), next, nil, dynamic.Cache{}, "cache")
+	require.NoError(t, err)
+
+	serve := func(lang string) string {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+		req.Header.Set("Accept-Language", lang)
+
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+
+		return rw.Body.String()
+	}
+
+	assert.Equal(t, "response 1", serve("en"))
+	assert.Equal(t, "response 2", serve("fr"))
+	assert.Equal(t, "response 1", serve("en"))
+	assert.Equal(t, "response 2", serve("fr"))
+}
+
+func TestCache_conditionalRequest(t *testing.T) {
+	next := &countingHandler{header: http.Header{"Cache-Control": {"max-age=60"}, "Etag": {`"v1"`}}}
+
+	handler, err := New(context.Background(), next, nil, dynamic.Cache{}, "cache")
+	require.NoError(t, err)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+	req.Header.Set("If-None-Match", `W/"v1"`)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	assert.Equal(t, http.StatusNotModified, rw.Code)
+	assert.Empty(t, rw.Body.String())
+	assert.Equal(t, int32(1), next.calls.Load())
+}
+
+func TestCache_revalidation(t *testing.T) {
+	next := &countingHandler{header: http.Header{"Cache-Control": {"max-age=60"}, "Etag": {`"v1"`}}}
+
+	handler, err := New(context.Background(), next, nil, dynamic.Cache{}, "cache")
+	require.NoError(t, err)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	expire(t, handler.(*cache), "example.com/foo", 2*time.Minute)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "Traefik; fwd=stale; fwd-status=304", rw.Header().Get(cacheStatusHeader))
+	assert.Equal(t, "response 1", rw.Body.String())
+	assert.Equal(t, int32(2), next.calls.Load())
+
+	// The revalidated response is fresh again.
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	assert.Equal(t, "response 1", rw.Body.String())
+	assert.Equal(t, int32(2), next.calls.Load())
+}
+
+func TestCache_staleWhileRevalidate(t *testing.T) {
+	next := &countingHandler{header: http.Header{"Cache-Control": {"max-age=60"}}}
+
+	handler, err := New(context.Background(), next, nil, dynamic.Cache{StaleWhileRevalidate: ptypes.Duration(time.Hour)}, "cache")
+	require.NoError(t, err)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	expire(t, handler.(*cache), "example.com/foo", 2*time.Minute)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	// The stale response is served, while the response is fetched again in the background.
+	assert.Equal(t, "response 1", rw.Body.String())
+	assert.Equal(t, "Traefik; hit; ttl=-60", rw.Header().Get(cacheStatusHeader))
+
+	require.Eventually(t, func() bool {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+		return rw.Body.String() == "response 2"
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, int32(2), next.calls.Load())
+}
+
+func TestCache_invalidation(t *testing.T) {
+	next := &countingHandler{header: http.Header{"Cache-Control": {"max-age=60"}}}
+
+	handler, err := New(context.Background(), next, nil, dynamic.Cache{}, "cache")
+	require.NoError(t, err)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "http://example.com/foo", nil))
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+	assert.Equal(t, "response 3", rw.Body.String())
+}
+
+func TestCache_maxBodySize(t *testing.T) {
+	next := &countingHandler{header: http.Header{"Cache-Control": {"max-age=60"}}}
+
+	handler, err := New(context.Background(), next, nil, dynamic.Cache{MaxBodySize: 5}, "cache")
+	require.NoError(t, err)
+
+	for range 2 {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil))
+
+		assert.Equal(t, http.StatusOK, rw.Code)
+	}
+
+	assert.Equal(t, int32(2), next.calls.Load())
+}
+
+func TestManager(t *testing.T) {
+	manager := NewManager()
+	t.Cleanup(manager.Close)
+
+	next := &countingHandler{header: http.Header{"Cache-Control": {"max-age=60"}}}
+
+	newHandler := func(config dynamic.Cache) http.Handler {
+		t.Helper()
+
+		handler, err := New(context.Background(), next, manager, config, "cache@file")
+		require.NoError(t, err)
+
+		return handler
+	}
+
+	serve := func(handler http.Handler, target string) string {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, target, nil))
+
+		return rw.Body.String()
+	}
+
+	handler := newHandler(dynamic.Cache{})
+	assert.Equal(t, "response 1", serve(handler, "http://example.com/foo"))
+	assert.Equal(t, "response 2", serve(handler, "http://example.com/bar"))
+
+	// The store is kept when the middleware is created again with the same store configuration.
+	handler = newHandler(dynamic.Cache{TTL: ptypes.Duration(time.Minute)})
+	assert.Equal(t, "response 1", serve(handler, "http://example.com/foo"))
+
+	require.NoError(t, manager.Purge(context.Background(), "cache@file", "example.com/foo"))
+	assert.Equal(t, "response 3", serve(handler, "http://example.com/foo"))
+	assert.Equal(t, "response 2", serve(handler, "http://example.com/bar"))
+
+	require.NoError(t, manager.Purge(context.Background(), "cache@file", ""))
+	assert.Equal(t, "response 4", serve(handler, "http://example.com/bar"))
+
+	// The store is created again when its configuration changes.
+	handler = newHandler(dynamic.Cache{Store: &dynamic.CacheStore{Memory: &dynamic.MemoryCacheStore{MaxSize: 1024}}})
+	assert.Equal(t, "response 5", serve(handler, "http://example.com/bar"))
+
+	assert.ErrorIs(t, manager.Purge(context.Background(), "unknown@file", ""), ErrCacheNotFound)
+}
+
+// expire makes the response stored at the given key older by the given duration.
+func expire(t *testing.T, c *cache, key string, d time.Duration) {
+	t.Helper()
+
+	e, err := c.load(context.Background(), key)
+	require.NoError(t, err)
+	require.NotNil(t, e)
+
+	e.RequestTime = e.RequestTime.Add(-d)
+	e.ResponseTime = e.ResponseTime.Add(-d)
+
+	require.NoError(t, c.save(context.Background(), key, httptest.NewRequest(http.MethodGet, "http://"+key, nil), e, time.Hour))
+}
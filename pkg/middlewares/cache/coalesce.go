@@ -0,0 +1,209 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeNameCoalesce = "Coalesce"
+
+// conditionalHeaders are the request headers added to the keys of the collapsed requests,
+// as the responses depend on them.
+var conditionalHeaders = []string{"If-Modified-Since", "If-None-Match", "If-Range", "Range"}
+
+// sharedResponse is the response sent to the collapsed requests.
+type sharedResponse struct {
+	status int
+	header http.Header
+	body   []byte
+
+	// vary holds the names of the request headers the response varies on,
+	// and varyKey their values in the forwarded request, which the collapsed requests must have.
+	vary    []string
+	varyKey string
+}
+
+// flight is a request being forwarded to the next handler, which the identical requests wait for.
+type flight struct {
+	done chan struct{}
+
+	// response is the response to send to the waiting requests, or nil if it cannot be shared.
+	response *sharedResponse
+	// retry tells whether the forwarded request was abandoned, e.g. when its client went away,
+	// so that one of the waiting requests is forwarded instead.
+	retry bool
+}
+
+// coalesce is a middleware collapsing the concurrent identical GET requests into a single request to the next handler.
+type coalesce struct {
+	next        http.Handler
+	name        string
+	keys        requestKey
+	maxBodySize int64
+
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// NewCoalesce creates a coalesce middleware.
+func NewCoalesce(ctx context.Context, next http.Handler, config dynamic.Coalesce, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeNameCoalesce).Debug().Msg("Creating middleware")
+
+	if config.MaxBodySize < 0 {
+		return nil, fmt.Errorf("invalid maxBodySize %d: must be positive", config.MaxBodySize)
+	}
+
+	c := &coalesce{
+		next:        next,
+		name:        name,
+		keys:        newRequestKey(config.Key),
+		maxBodySize: config.MaxBodySize,
+		flights:     make(map[string]*flight),
+	}
+
+	if c.maxBodySize == 0 {
+		c.maxBodySize = defaultMaxBodySize
+	}
+
+	return c, nil
+}
+
+func (c *coalesce) GetTracingInformation() (string, string, trace.SpanKind) {
+	return c.name, typeNameCoalesce, trace.SpanKindInternal
+}
+
+func (c *coalesce) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if !c.coalescable(req) {
+		c.next.ServeHTTP(rw, req)
+		return
+	}
+
+	key := c.keys.of(req) + varyKey(req, conditionalHeaders)
+
+	for {
+		c.mu.Lock()
+		f, waiting := c.flights[key]
+		if !waiting {
+			f = &flight{done: make(chan struct{})}
+			c.flights[key] = f
+		}
+		c.mu.Unlock()
+
+		if !waiting {
+			c.forward(rw, req, key, f)
+			return
+		}
+
+		select {
+		case <-f.done:
+		case <-req.Context().Done():
+			return
+		}
+
+		if f.retry {
+			continue
+		}
+
+		if f.response == nil || varyKey(req, f.response.vary) != f.response.varyKey {
+			c.next.ServeHTTP(rw, req)
+			return
+		}
+
+		c.serve(rw, req, f.response)
+		return
+	}
+}
+
+// coalescable reports whether the given request can be collapsed with the identical ones.
+func (c *coalesce) coalescable(req *http.Request) bool {
+	if req.Method != http.MethodGet || req.ContentLength != 0 {
+		return false
+	}
+
+	// The upgraded connections and the event streams are not responses which can be shared.
+	if req.Header.Get("Upgrade") != "" || middlewares.IsEventStreamRequest(req) {
+		return false
+	}
+
+	// The responses to the authenticated requests are only shared between the requests with the same credentials.
+	// https://www.rfc-editor.org/rfc/rfc9111#section-3.5
+	return req.Header.Get("Authorization") == "" || slices.Contains(c.keys.headers, "Authorization")
+}
+
+// forward sends the request to the next handler, and then hands its response to the requests waiting for it.
+func (c *coalesce) forward(rw http.ResponseWriter, req *http.Request, key string, f *flight) {
+	defer func() {
+		c.mu.Lock()
+		delete(c.flights, key)
+		c.mu.Unlock()
+
+		close(f.done)
+	}()
+
+	cw := &captureWriter{
+		rw:      rw,
+		header:  make(http.Header),
+		maxSize: c.maxBodySize,
+	}
+
+	c.next.ServeHTTP(cw, req)
+	cw.finish()
+
+	if req.Context().Err() != nil {
+		f.retry = true
+		return
+	}
+
+	f.response = c.share(req, cw)
+}
+
+// share returns the response captured by the given writer to send to the collapsed requests,
+// or nil if it cannot be shared.
+func (c *coalesce) share(req *http.Request, cw *captureWriter) *sharedResponse {
+	logger := middlewares.GetLogger(req.Context(), c.name, typeNameCoalesce)
+
+	if cw.tooLarge {
+		logger.Debug().Msg("Response too large to be shared with the identical requests")
+		return nil
+	}
+
+	cc := parseCacheControl(cw.header.Values("Cache-Control"))
+	if cc.has("private") || cc.has("no-store") || len(cw.header.Values("Set-Cookie")) > 0 {
+		logger.Debug().Msg("Private response not shared with the identical requests")
+		return nil
+	}
+
+	vary := varyHeaders(cw.header)
+	if slices.Contains(vary, "*") {
+		return nil
+	}
+
+	return &sharedResponse{
+		status:  cw.status,
+		header:  cw.header.Clone(),
+		body:    cw.body.Bytes(),
+		vary:    vary,
+		varyKey: varyKey(req, vary),
+	}
+}
+
+// serve writes the response of an identical request.
+func (c *coalesce) serve(rw http.ResponseWriter, req *http.Request, r *sharedResponse) {
+	header := rw.Header()
+	for name, values := range r.header {
+		header[name] = slices.Clone(values)
+	}
+
+	rw.WriteHeader(r.status)
+
+	if _, err := rw.Write(r.body); err != nil {
+		middlewares.GetLogger(req.Context(), c.name, typeNameCoalesce).Debug().Err(err).Msg("Unable to write the shared response")
+	}
+}
@@ -0,0 +1,228 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// blockingHandler is a countingHandler holding the requests until released.
+type blockingHandler struct {
+	countingHandler
+
+	entered chan struct{}
+	release chan struct{}
+}
+
+func newBlockingHandler(header http.Header) *blockingHandler {
+	return &blockingHandler{
+		countingHandler: countingHandler{header: header},
+		entered:         make(chan struct{}, 16),
+		release:         make(chan struct{}),
+	}
+}
+
+func (h *blockingHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	h.entered <- struct{}{}
+
+	select {
+	case <-h.release:
+	case <-req.Context().Done():
+		return
+	}
+
+	h.countingHandler.ServeHTTP(rw, req)
+}
+
+func TestNewCoalesce_invalidConfig(t *testing.T) {
+	_, err := NewCoalesce(context.Background(), http.NotFoundHandler(), dynamic.Coalesce{MaxBodySize: -1}, "coalesce")
+	assert.Error(t, err)
+}
+
+func TestCoalesce(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		config        dynamic.Coalesce
+		method        string
+		target        string
+		reqHeader     http.Header
+		respHeader    http.Header
+		expectedCalls int32
+	}{
+		{
+			desc:          "identical requests",
+			expectedCalls: 1,
+		},
+		{
+			desc:          "other query",
+			target:        "/?page=2",
+			expectedCalls: 2,
+		},
+		{
+			desc:          "query ignored",
+			config:        dynamic.Coalesce{Key: &dynamic.CacheKey{IgnoreQuery: true}},
+			target:        "/?page=2",
+			expectedCalls: 1,
+		},
+		{
+			desc:          "other key header",
+			config:        dynamic.Coalesce{Key: &dynamic.CacheKey{Headers: []string{"X-Tenant"}}},
+			reqHeader:     http.Header{"X-Tenant": {"acme"}},
+			expectedCalls: 2,
+		},
+		{
+			desc:          "range requests",
+			reqHeader:     http.Header{"Range": {"bytes=0-9"}},
+			expectedCalls: 2,
+		},
+		{
+			desc:          "authorized requests",
+			reqHeader:     http.Header{"Authorization": {"Bearer token"}},
+			expectedCalls: 4,
+		},
+		{
+			desc:          "authorized requests with the credentials in the key",
+			config:        dynamic.Coalesce{Key: &dynamic.CacheKey{Headers: []string{"Authorization"}}},
+			reqHeader:     http.Header{"Authorization": {"Bearer token"}},
+			expectedCalls: 2,
+		},
+		{
+			desc:          "HEAD requests",
+			method:        http.MethodHead,
+			expectedCalls: 4,
+		},
+		{
+			desc:          "private response",
+			respHeader:    http.Header{"Cache-Control": {"private"}},
+			expectedCalls: 4,
+		},
+		{
+			desc:          "response setting a cookie",
+			respHeader:    http.Header{"Set-Cookie": {"session=abc"}},
+			expectedCalls: 4,
+		},
+		{
+			desc:          "response varying on the same header value",
+			respHeader:    http.Header{"Vary": {"Accept-Language"}},
+			expectedCalls: 1,
+		},
+		{
+			desc:          "response varying on another header value",
+			reqHeader:     http.Header{"Accept-Language": {"fr"}},
+			respHeader:    http.Header{"Vary": {"Accept-Language"}},
+			expectedCalls: 4,
+		},
+		{
+			desc:          "response too large",
+			config:        dynamic.Coalesce{MaxBodySize: 4},
+			expectedCalls: 4,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := newBlockingHandler(test.respHeader)
+
+			handler, err := NewCoalesce(context.Background(), next, test.config, "coalesce")
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+			serve := func(req *http.Request) *httptest.ResponseRecorder {
+				rw := httptest.NewRecorder()
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					handler.ServeHTTP(rw, req)
+				}()
+
+				return rw
+			}
+
+			first := serve(httptest.NewRequest(http.MethodGet, "/", nil))
+			<-next.entered
+
+			method := http.MethodGet
+			if test.method != "" {
+				method = test.method
+			}
+
+			target := "/"
+			if test.target != "" {
+				target = test.target
+			}
+
+			var others []*httptest.ResponseRecorder
+			for range 3 {
+				req := httptest.NewRequest(method, target, nil)
+				for name, values := range test.reqHeader {
+					req.Header[name] = values
+				}
+
+				others = append(others, serve(req))
+			}
+
+			// Gives the other requests the time to wait for the first one.
+			time.Sleep(50 * time.Millisecond)
+			close(next.release)
+			wg.Wait()
+
+			assert.Equal(t, test.expectedCalls, next.calls.Load())
+
+			assert.Equal(t, http.StatusOK, first.Code)
+			for _, rw := range others {
+				assert.Equal(t, http.StatusOK, rw.Code)
+				assert.Equal(t, test.respHeader.Get("Vary"), rw.Header().Get("Vary"))
+
+				if test.expectedCalls == 1 && method == http.MethodGet {
+					assert.Equal(t, first.Body.String(), rw.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestCoalesce_abandonedRequest(t *testing.T) {
+	next := newBlockingHandler(nil)
+
+	handler, err := NewCoalesce(context.Background(), next, dynamic.Coalesce{}, "coalesce")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+	}()
+	<-next.entered
+
+	rw := httptest.NewRecorder()
+	waiterDone := make(chan struct{})
+	go func() {
+		defer close(waiterDone)
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+
+	// The waiting request is forwarded once the first one is abandoned by its client.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+	<-next.entered
+
+	close(next.release)
+	<-waiterDone
+
+	assert.Equal(t, int32(1), next.calls.Load())
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "response 1", rw.Body.String())
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// ErrCacheNotFound is returned when purging the cache of an unknown middleware.
+var ErrCacheNotFound = errors.New("cache not found")
+
+type managedStore struct {
+	config dynamic.CacheStore
+	store  store
+}
+
+// Manager holds the stores of the cache middlewares, so that the cached responses are kept across the configuration reloads,
+// and purges them.
+type Manager struct {
+	mu     sync.Mutex
+	stores map[string]*managedStore
+}
+
+// NewManager creates a new Manager.
+func NewManager() *Manager {
+	return &Manager{stores: make(map[string]*managedStore)}
+}
+
+// Purge removes, from the cache of the given middleware, the responses whose key starts with the given prefix.
+func (m *Manager) Purge(ctx context.Context, middlewareName, prefix string) error {
+	m.mu.Lock()
+	managed, ok := m.stores[middlewareName]
+	m.mu.Unlock()
+
+	if !ok {
+		return ErrCacheNotFound
+	}
+
+	return managed.store.purge(ctx, prefix)
+}
+
+// Close closes the connections of the stores.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, managed := range m.stores {
+		if err := managed.store.close(); err != nil {
+			log.Error().Err(err).Str("middlewareName", name).Msg("Unable to close cache store")
+		}
+	}
+
+	m.stores = make(map[string]*managedStore)
+}
+
+// getStore returns the store of the given middleware, which is created again only when its configuration changes.
+// A nil Manager creates a new store on each call.
+func (m *Manager) getStore(ctx context.Context, middlewareName string, config dynamic.CacheStore) (store, error) {
+	if m == nil {
+		return newStore(ctx, middlewareName, config)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if managed, ok := m.stores[middlewareName]; ok {
+		if reflect.DeepEqual(managed.config, config) {
+			return managed.store, nil
+		}
+
+		if err := managed.store.close(); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Unable to close cache store")
+		}
+
+		delete(m.stores, middlewareName)
+	}
+
+	s, err := newStore(ctx, middlewareName, config)
+	if err != nil {
+		return nil, err
+	}
+
+	m.stores[middlewareName] = &managedStore{config: *config.DeepCopy(), store: s}
+
+	return s, nil
+}
+
+func newStore(ctx context.Context, middlewareName string, config dynamic.CacheStore) (store, error) {
+	if config.Memory != nil && config.Redis != nil {
+		return nil, errors.New("only one of the memory and redis stores can be defined")
+	}
+
+	if config.Redis != nil {
+		return newRedisStore(ctx, middlewareName, *config.Redis)
+	}
+
+	var maxSize int64
+	if config.Memory != nil {
+		maxSize = config.Memory.MaxSize
+	}
+
+	return newMemoryStore(maxSize), nil
+}
@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// heuristicallyCacheable are the status codes of the responses which can be stored without an explicit freshness lifetime.
+// https://www.rfc-editor.org/rfc/rfc9110#section-15.1
+var heuristicallyCacheable = []int{
+	http.StatusOK,
+	http.StatusNonAuthoritativeInfo,
+	http.StatusNoContent,
+	http.StatusMultipleChoices,
+	http.StatusMovedPermanently,
+	http.StatusPermanentRedirect,
+	http.StatusNotFound,
+	http.StatusMethodNotAllowed,
+	http.StatusGone,
+	http.StatusRequestURITooLong,
+	http.StatusNotImplemented,
+}
+
+// cacheControl holds the directives of Cache-Control headers.
+type cacheControl map[string]string
+
+func parseCacheControl(values []string) cacheControl {
+	cc := make(cacheControl)
+	for _, value := range values {
+		for _, directive := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if name == "" {
+				continue
+			}
+
+			cc[strings.ToLower(name)] = strings.Trim(arg, `"`)
+		}
+	}
+
+	return cc
+}
+
+func (cc cacheControl) has(directive string) bool {
+	_, ok := cc[directive]
+	return ok
+}
+
+// duration returns the value of a directive holding a number of seconds.
+func (cc cacheControl) duration(directive string) (time.Duration, bool) {
+	arg, ok := cc[directive]
+	if !ok {
+		return 0, false
+	}
+
+	seconds, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
+
+// policy is how long a response can be served from the cache.
+type policy struct {
+	// lifetime is the freshness lifetime of the response.
+	lifetime time.Duration
+	// staleWhileRevalidate is the duration, after the freshness lifetime, during which the response can be served
+	// while it is revalidated.
+	staleWhileRevalidate time.Duration
+	// mustRevalidate forbids to serve the response once stale.
+	mustRevalidate bool
+}
+
+// retention returns how long the response is kept in the store.
+func (p policy) retention() time.Duration {
+	if p.mustRevalidate {
+		return p.lifetime
+	}
+
+	return p.lifetime + p.staleWhileRevalidate
+}
+
+// policy returns how long the given response to the given request can be served from the cache,
+// or false if the response cannot be stored.
+// https://www.rfc-editor.org/rfc/rfc9111#section-3
+func (c *cache) policy(req *http.Request, status int, header http.Header, responseTime time.Time) (policy, bool) {
+	if status < http.StatusOK || status == http.StatusPartialContent || status == http.StatusNotModified {
+		return policy{}, false
+	}
+
+	reqCC := parseCacheControl(req.Header.Values("Cache-Control"))
+	respCC := parseCacheControl(header.Values("Cache-Control"))
+
+	if reqCC.has("no-store") || respCC.has("no-store") || respCC.has("private") || respCC.has("no-cache") {
+		return policy{}, false
+	}
+
+	// The responses setting cookies are specific to a client.
+	if header.Get("Set-Cookie") != "" {
+		return policy{}, false
+	}
+
+	for _, vary := range header.Values("Vary") {
+		if strings.TrimSpace(vary) == "*" {
+			return policy{}, false
+		}
+	}
+
+	if req.Header.Get("Authorization") != "" && !respCC.has("public") && !respCC.has("s-maxage") && !respCC.has("must-revalidate") {
+		return policy{}, false
+	}
+
+	p := policy{
+		staleWhileRevalidate: c.staleWhileRevalidate,
+		mustRevalidate:       respCC.has("must-revalidate") || respCC.has("proxy-revalidate"),
+	}
+
+	if swr, ok := respCC.duration("stale-while-revalidate"); ok {
+		p.staleWhileRevalidate = swr
+	}
+
+	lifetime, ok := explicitLifetime(respCC, header, responseTime)
+	switch {
+	case c.ttl > 0:
+		p.lifetime = c.ttl
+	case ok:
+		p.lifetime = lifetime
+	case c.defaultTTL > 0 && slices.Contains(heuristicallyCacheable, status):
+		p.lifetime = c.defaultTTL
+	default:
+		return policy{}, false
+	}
+
+	if p.retention() <= 0 {
+		return policy{}, false
+	}
+
+	return p, true
+}
+
+// explicitLifetime returns the freshness lifetime defined by the response headers, for a shared cache.
+// https://www.rfc-editor.org/rfc/rfc9111#section-4.2.1
+func explicitLifetime(cc cacheControl, header http.Header, responseTime time.Time) (time.Duration, bool) {
+	if sMaxAge, ok := cc.duration("s-maxage"); ok {
+		return sMaxAge, true
+	}
+
+	if maxAge, ok := cc.duration("max-age"); ok {
+		return maxAge, true
+	}
+
+	expires := header.Get("Expires")
+	if expires == "" {
+		return 0, false
+	}
+
+	// An invalid date means that the response is already expired.
+	expiresAt, err := http.ParseTime(expires)
+	if err != nil {
+		return 0, true
+	}
+
+	date := responseTime
+	if d, err := http.ParseTime(header.Get("Date")); err == nil {
+		date = d
+	}
+
+	return max(0, expiresAt.Sub(date)), true
+}
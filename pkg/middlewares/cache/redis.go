@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikredis "github.com/traefik/traefik/v3/pkg/redis"
+)
+
+// redisScanCount is the number of keys asked to Redis on each iteration of a scan.
+const redisScanCount = 100
+
+// redisStore is a store shared by the Traefik instances connected to the same Redis server or cluster.
+type redisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func newRedisStore(ctx context.Context, name string, config dynamic.Redis) (*redisStore, error) {
+	client, err := traefikredis.NewClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redisStore{
+		client: client,
+		prefix: "traefik:cache:" + name + ":",
+	}, nil
+}
+
+func (s *redisStore) get(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+
+	return value, err
+}
+
+func (s *redisStore) set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, s.prefix+key, value, ttl).Err()
+}
+
+func (s *redisStore) delete(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key).Err()
+}
+
+func (s *redisStore) purge(ctx context.Context, prefix string) error {
+	match := escapeGlob(s.prefix+prefix) + "*"
+
+	// The keys of a cluster are spread across its masters, which are scanned one by one.
+	if cluster, ok := s.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+			return scanDelete(ctx, client, match)
+		})
+	}
+
+	return scanDelete(ctx, s.client, match)
+}
+
+func (s *redisStore) close() error {
+	return s.client.Close()
+}
+
+func scanDelete(ctx context.Context, client redis.Cmdable, match string) error {
+	iter := client.Scan(ctx, 0, match, redisScanCount).Iterator()
+	for iter.Next(ctx) {
+		if err := client.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+
+	return iter.Err()
+}
+
+// escapeGlob escapes the special characters of the Redis glob-style patterns.
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteRune('\\')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,133 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultMemoryMaxSize = 100 * 1024 * 1024
+
+// store stores the encoded cache entries.
+type store interface {
+	// get returns the value of the given key, or nil if there is none.
+	get(ctx context.Context, key string) ([]byte, error)
+	// set stores the value of the given key for the given duration.
+	set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// delete removes the given key.
+	delete(ctx context.Context, key string) error
+	// purge removes all the keys starting with the given prefix.
+	purge(ctx context.Context, prefix string) error
+	// close releases the resources of the store.
+	close() error
+}
+
+type memoryItem struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func (i *memoryItem) size() int64 {
+	return int64(len(i.key) + len(i.value))
+}
+
+// memoryStore is an in-memory store, evicting the least recently used entries when full.
+type memoryStore struct {
+	mu      sync.Mutex
+	maxSize int64
+	size    int64
+	items   map[string]*list.Element
+	lru     *list.List
+}
+
+func newMemoryStore(maxSize int64) *memoryStore {
+	if maxSize <= 0 {
+		maxSize = defaultMemoryMaxSize
+	}
+
+	return &memoryStore{
+		maxSize: maxSize,
+		items:   make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+func (s *memoryStore) get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elt, ok := s.items[key]
+	if !ok {
+		return nil, nil
+	}
+
+	item := elt.Value.(*memoryItem)
+	if time.Now().After(item.expiresAt) {
+		s.remove(elt)
+		return nil, nil
+	}
+
+	s.lru.MoveToFront(elt)
+
+	return item.value, nil
+}
+
+func (s *memoryStore) set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elt, ok := s.items[key]; ok {
+		s.remove(elt)
+	}
+
+	item := &memoryItem{key: key, value: value, expiresAt: time.Now().Add(ttl)}
+	if item.size() > s.maxSize {
+		return nil
+	}
+
+	s.items[key] = s.lru.PushFront(item)
+	s.size += item.size()
+
+	for s.size > s.maxSize {
+		s.remove(s.lru.Back())
+	}
+
+	return nil
+}
+
+func (s *memoryStore) delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elt, ok := s.items[key]; ok {
+		s.remove(elt)
+	}
+
+	return nil
+}
+
+func (s *memoryStore) purge(_ context.Context, prefix string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, elt := range s.items {
+		if strings.HasPrefix(key, prefix) {
+			s.remove(elt)
+		}
+	}
+
+	return nil
+}
+
+func (s *memoryStore) close() error {
+	return nil
+}
+
+func (s *memoryStore) remove(elt *list.Element) {
+	item := s.lru.Remove(elt).(*memoryItem)
+	delete(s.items, item.key)
+	s.size -= item.size()
+}
@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_eviction(t *testing.T) {
+	ctx := context.Background()
+
+	// Each item is 4 bytes: a 1 byte key, and a 3 bytes value.
+	s := newMemoryStore(12)
+
+	require.NoError(t, s.set(ctx, "a", []byte("aaa"), time.Minute))
+	require.NoError(t, s.set(ctx, "b", []byte("bbb"), time.Minute))
+	require.NoError(t, s.set(ctx, "c", []byte("ccc"), time.Minute))
+
+	// a becomes the most recently used item, b being evicted in favor of d.
+	value, err := s.get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("aaa"), value)
+
+	require.NoError(t, s.set(ctx, "d", []byte("ddd"), time.Minute))
+
+	value, err = s.get(ctx, "b")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	for _, key := range []string{"a", "c", "d"} {
+		value, err = s.get(ctx, key)
+		require.NoError(t, err)
+		assert.NotNil(t, value, key)
+	}
+
+	assert.Equal(t, int64(12), s.size)
+
+	// An item larger than the store is not stored.
+	require.NoError(t, s.set(ctx, "e", []byte("too large value"), time.Minute))
+
+	value, err = s.get(ctx, "e")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestMemoryStore_expiration(t *testing.T) {
+	ctx := context.Background()
+	s := newMemoryStore(0)
+
+	require.NoError(t, s.set(ctx, "a", []byte("aaa"), -time.Second))
+
+	value, err := s.get(ctx, "a")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Equal(t, int64(0), s.size)
+}
+
+func TestEscapeGlob(t *testing.T) {
+	assert.Equal(t, `example.com/\*\?\[a\]\\`, escapeGlob(`example.com/*?[a]\`))
+}
@@ -0,0 +1,135 @@
+package canary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/auth"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "Canary"
+
+type serviceBuilder interface {
+	BuildHTTP(ctx context.Context, serviceName string) (http.Handler, error)
+}
+
+// canary is a middleware forwarding the requests matching one of the conditions to an alternate service.
+type canary struct {
+	next        http.Handler
+	name        string
+	serviceName string
+	service     http.Handler
+
+	headers map[string]string
+	cookies map[string]string
+	claims  map[string]string
+}
+
+// New creates a Canary middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.Canary, serviceBuilder serviceBuilder, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeName)
+	logger.Debug().Msg("Creating middleware")
+
+	if config.Service == "" {
+		return nil, errors.New("empty service")
+	}
+
+	if len(config.Headers) == 0 && len(config.Cookies) == 0 && len(config.Claims) == 0 {
+		return nil, errors.New("at least one of headers, cookies or claims must be defined")
+	}
+
+	service, err := serviceBuilder.BuildHTTP(ctx, config.Service)
+	if err != nil {
+		return nil, fmt.Errorf("building service %q: %w", config.Service, err)
+	}
+
+	c := &canary{
+		next:        next,
+		name:        name,
+		serviceName: config.Service,
+		service:     service,
+		headers:     make(map[string]string),
+		cookies:     config.Cookies,
+		claims:      config.Claims,
+	}
+
+	for header, value := range config.Headers {
+		c.headers[http.CanonicalHeaderKey(header)] = value
+	}
+
+	return c, nil
+}
+
+func (c *canary) GetTracingInformation() (string, string, trace.SpanKind) {
+	return c.name, typeName, trace.SpanKindInternal
+}
+
+func (c *canary) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if reason, ok := c.match(req); ok {
+		middlewares.GetLogger(req.Context(), c.name, typeName).Debug().
+			Msgf("Forwarding the request to the service %s, matching the %s", c.serviceName, reason)
+
+		c.service.ServeHTTP(rw, req)
+		return
+	}
+
+	c.next.ServeHTTP(rw, req)
+}
+
+// match reports whether the request matches one of the conditions, and which one.
+func (c *canary) match(req *http.Request) (string, bool) {
+	for header, expected := range c.headers {
+		for _, value := range req.Header.Values(header) {
+			if value == expected {
+				return "header " + header, true
+			}
+		}
+	}
+
+	for name, expected := range c.cookies {
+		if cookie, err := req.Cookie(name); err == nil && cookie.Value == expected {
+			return "cookie " + name, true
+		}
+	}
+
+	if len(c.claims) == 0 {
+		return "", false
+	}
+
+	claims := auth.GetJWTClaims(req.Context())
+	for name, expected := range c.claims {
+		if claimMatches(claims[name], expected) {
+			return "claim " + name, true
+		}
+	}
+
+	return "", false
+}
+
+// claimMatches reports whether the given claim holds the expected value, or a list holding it.
+func claimMatches(claim interface{}, expected string) bool {
+	switch value := claim.(type) {
+	case string:
+		return value == expected
+	case bool:
+		return strconv.FormatBool(value) == expected
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64) == expected
+	case []interface{}:
+		for _, v := range value {
+			if claimMatches(v, expected) {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return false
+	}
+}
@@ -0,0 +1,177 @@
+package canary
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares/auth"
+)
+
+const testJWTSecret = "0123456789abcdef0123456789abcdef"
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.Canary
+	}{
+		{
+			desc:   "no service",
+			config: dynamic.Canary{Headers: map[string]string{"X-Canary": "true"}},
+		},
+		{
+			desc:   "no condition",
+			config: dynamic.Canary{Service: "beta"},
+		},
+		{
+			desc:   "unknown service",
+			config: dynamic.Canary{Service: "unknown", Headers: map[string]string{"X-Canary": "true"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, newServiceBuilder(), "canary")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCanary(t *testing.T) {
+	config := dynamic.Canary{
+		Service: "beta",
+		Headers: map[string]string{"x-canary": "true"},
+		Cookies: map[string]string{"release": "beta"},
+		Claims:  map[string]string{"groups": "beta", "tester": "true"},
+	}
+
+	testCases := []struct {
+		desc            string
+		header          http.Header
+		cookie          *http.Cookie
+		claims          map[string]interface{}
+		expectedService string
+	}{
+		{
+			desc:            "no condition matching",
+			expectedService: "next",
+		},
+		{
+			desc:            "header matching",
+			header:          http.Header{"X-Canary": {"true"}},
+			expectedService: "beta",
+		},
+		{
+			desc:            "header not matching",
+			header:          http.Header{"X-Canary": {"false"}},
+			expectedService: "next",
+		},
+		{
+			desc:            "cookie matching",
+			cookie:          &http.Cookie{Name: "release", Value: "beta"},
+			expectedService: "beta",
+		},
+		{
+			desc:            "cookie not matching",
+			cookie:          &http.Cookie{Name: "release", Value: "stable"},
+			expectedService: "next",
+		},
+		{
+			desc:            "list claim holding the value",
+			claims:          map[string]interface{}{"groups": []string{"admin", "beta"}},
+			expectedService: "beta",
+		},
+		{
+			desc:            "list claim not holding the value",
+			claims:          map[string]interface{}{"groups": []string{"admin"}},
+			expectedService: "next",
+		},
+		{
+			desc:            "boolean claim matching",
+			claims:          map[string]interface{}{"tester": true},
+			expectedService: "beta",
+		},
+		{
+			desc:            "claim of a token not verified",
+			header:          http.Header{"X-Token": {signHMAC(t, map[string]interface{}{"groups": "beta"})}},
+			expectedService: "next",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler, err := New(context.Background(), newHandler("next"), config, newServiceBuilder(), "canary")
+			require.NoError(t, err)
+
+			if test.claims != nil {
+				handler, err = auth.NewJWT(context.Background(), handler, dynamic.JWT{SigningSecret: testJWTSecret}, "jwt")
+				require.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			for name, values := range test.header {
+				req.Header[name] = values
+			}
+
+			if test.cookie != nil {
+				req.AddCookie(test.cookie)
+			}
+
+			if test.claims != nil {
+				req.Header.Set("Authorization", "Bearer "+signHMAC(t, test.claims))
+			}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			require.Equal(t, http.StatusOK, rw.Code)
+			assert.Equal(t, test.expectedService, rw.Body.String())
+		})
+	}
+}
+
+func newHandler(name string) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(rw, name)
+	})
+}
+
+func newServiceBuilder() *mockServiceBuilder {
+	return &mockServiceBuilder{handlers: map[string]http.Handler{"beta": newHandler("beta")}}
+}
+
+type mockServiceBuilder struct {
+	handlers map[string]http.Handler
+}
+
+func (m *mockServiceBuilder) BuildHTTP(_ context.Context, serviceName string) (http.Handler, error) {
+	handler, ok := m.handlers[serviceName]
+	if !ok {
+		return nil, fmt.Errorf("service %q not found", serviceName)
+	}
+
+	return handler, nil
+}
+
+func signHMAC(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte(testJWTSecret)}, (&jose.SignerOptions{}).WithType("JWT"))
+	require.NoError(t, err)
+
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	require.NoError(t, err)
+
+	return token
+}
@@ -0,0 +1,248 @@
+package circuitbreaker
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/rs/zerolog"
+	"github.com/traefik/traefik/v3/pkg/logs"
+	"github.com/vulcand/oxy/v2/memmetrics"
+	"github.com/vulcand/oxy/v2/utils"
+)
+
+// state is the state of a circuit breaker.
+type state int
+
+const (
+	// stateClosed is the state where all the requests are forwarded, while the condition is checked periodically.
+	stateClosed state = iota
+	// stateOpen is the state where the fallback is applied to all the requests.
+	stateOpen
+	// stateRecovering is the state where a growing share of the requests is forwarded again.
+	stateRecovering
+)
+
+func (s state) String() string {
+	switch s {
+	case stateOpen:
+		return "open"
+	case stateRecovering:
+		return "recovering"
+	default:
+		return "closed"
+	}
+}
+
+// settings holds the configuration shared by the breakers of a circuitBreaker middleware.
+type settings struct {
+	condition        condition
+	checkPeriod      time.Duration
+	fallbackDuration time.Duration
+	recoveryDuration time.Duration
+	probeRatio       float64
+
+	logger *zerolog.Logger
+	now    func() time.Time
+}
+
+// breaker is the circuit breaker of a router, for a whole service or for one of its backends.
+type breaker struct {
+	settings *settings
+	router   string
+	backend  string
+	metrics  *memmetrics.RTMetrics
+
+	stateGauge        gokitmetrics.Gauge
+	tripsCounter      gokitmetrics.Counter
+	matchedCounter    gokitmetrics.Counter
+	notMatchedCounter gokitmetrics.Counter
+
+	mu    sync.RWMutex
+	state state
+	// until is the end of the open or recovering state.
+	until time.Time
+	// lastCheck is the time before which the condition is not checked again.
+	lastCheck time.Time
+
+	recoveryStart time.Time
+	probesAllowed int
+	probesDenied  int
+
+	trips       uint64
+	evaluations uint64
+	matches     uint64
+}
+
+// allow reports whether the request can be forwarded, or whether the fallback must be applied.
+// A nil breaker allows all the requests.
+func (b *breaker) allow() bool {
+	if b == nil {
+		return true
+	}
+
+	// Fast path for the most common case.
+	b.mu.RLock()
+	closed := b.state == stateClosed
+	b.mu.RUnlock()
+
+	if closed {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.settings.now()
+
+	switch b.state {
+	case stateOpen:
+		if now.Before(b.until) {
+			return false
+		}
+
+		b.setState(stateRecovering, now.Add(b.settings.recoveryDuration))
+		b.recoveryStart = now
+		b.probesAllowed = 0
+		b.probesDenied = 0
+
+		fallthrough
+
+	case stateRecovering:
+		if now.After(b.until) {
+			b.setState(stateClosed, time.Time{})
+			return true
+		}
+
+		return b.allowProbe(now)
+	}
+
+	return true
+}
+
+// allowProbe reports whether the request can be forwarded while recovering.
+// The ratio of the forwarded requests grows linearly from 0 to the probe ratio during the recovery duration.
+func (b *breaker) allowProbe(now time.Time) bool {
+	target := b.settings.probeRatio * float64(now.Sub(b.recoveryStart)) / float64(b.settings.recoveryDuration)
+
+	// Would the ratio of the forwarded requests stay below the target if this request is forwarded?
+	if float64(b.probesAllowed+1)/float64(b.probesAllowed+b.probesDenied+1) < target {
+		b.probesAllowed++
+		return true
+	}
+
+	b.probesDenied++
+	return false
+}
+
+// serve forwards the request to the next handler and records its outcome.
+func (b *breaker) serve(next http.Handler, rw http.ResponseWriter, req *http.Request) {
+	if b == nil {
+		next.ServeHTTP(rw, req)
+		return
+	}
+
+	start := time.Now()
+	writer := utils.NewProxyWriter(rw)
+
+	next.ServeHTTP(writer, req)
+
+	b.metrics.Record(writer.StatusCode(), time.Since(start))
+	b.check()
+}
+
+// check evaluates the condition, at most once per check period, and opens the circuit breaker when it matches.
+func (b *breaker) check() {
+	now := b.settings.now()
+
+	b.mu.RLock()
+	due := !now.Before(b.lastCheck)
+	b.mu.RUnlock()
+
+	if !due {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// The condition could have been checked by another request in the meantime.
+	if now.Before(b.lastCheck) {
+		return
+	}
+	b.lastCheck = now.Add(b.settings.checkPeriod)
+
+	if b.state == stateOpen {
+		return
+	}
+
+	b.evaluations++
+	if !b.settings.condition(b.metrics) {
+		if b.notMatchedCounter != nil {
+			b.notMatchedCounter.Add(1)
+		}
+		return
+	}
+
+	b.matches++
+	if b.matchedCounter != nil {
+		b.matchedCounter.Add(1)
+	}
+
+	b.trips++
+	if b.tripsCounter != nil {
+		b.tripsCounter.Add(1)
+	}
+
+	b.setState(stateOpen, now.Add(b.settings.fallbackDuration))
+	b.metrics.Reset()
+}
+
+// setState sets the state of the circuit breaker.
+// The caller must hold the lock.
+func (b *breaker) setState(s state, until time.Time) {
+	b.settings.logger.Debug().Str(logs.RouterName, b.router).Str("backend", b.backend).Msgf("Circuit breaker state changes from %s to %s", b.state, s)
+
+	b.state = s
+	b.until = until
+
+	if b.stateGauge != nil {
+		b.stateGauge.Set(float64(s))
+	}
+}
+
+// status returns the status of the circuit breaker.
+// The transitions happen on the next request, the returned state is the one that request would see.
+func (b *breaker) status() BreakerStatus {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	current := b.state
+	until := b.until
+	now := b.settings.now()
+
+	if current == stateOpen && !now.Before(until) {
+		current = stateRecovering
+		until = until.Add(b.settings.recoveryDuration)
+	}
+
+	if current == stateRecovering && now.After(until) {
+		current = stateClosed
+	}
+
+	status := BreakerStatus{
+		Router:      b.router,
+		Backend:     b.backend,
+		State:       current.String(),
+		Trips:       b.trips,
+		Evaluations: b.evaluations,
+		Matches:     b.matches,
+	}
+
+	if current != stateClosed {
+		status.Until = &until
+	}
+
+	return status
+}
@@ -2,76 +2,266 @@ package circuitbreaker
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"sort"
+	"sync"
 	"time"
 
-	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
-	"github.com/traefik/traefik/v3/pkg/logs"
+	"github.com/traefik/traefik/v3/pkg/metrics"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
 	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
-	"github.com/vulcand/oxy/v2/cbreaker"
+	"github.com/vulcand/oxy/v2/memmetrics"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const typeName = "CircuitBreaker"
 
+const (
+	defaultCheckPeriod      = 100 * time.Millisecond
+	defaultFallbackDuration = 10 * time.Second
+	defaultRecoveryDuration = 10 * time.Second
+	defaultProbePercent     = 50
+)
+
+type backendGateKey struct{}
+
+// BackendGate is set in the request context by the circuitBreaker middlewares holding one state per backend.
+// The load balancers use it to skip the backends whose circuit breaker is open.
+type BackendGate interface {
+	// Allow reports whether the request can be forwarded to the given backend.
+	Allow(backend string) bool
+	// Serve forwards the request to the handler of the given backend, and records its outcome.
+	Serve(backend string, next http.Handler, rw http.ResponseWriter, req *http.Request)
+	// Fallback writes the response of the circuit breaker, when the circuit breakers of all the backends are open.
+	Fallback(rw http.ResponseWriter, req *http.Request)
+}
+
+// WithBackendGate returns a copy of the context holding the given BackendGate.
+// A nil BackendGate removes the one of the parent context.
+func WithBackendGate(ctx context.Context, gate BackendGate) context.Context {
+	return context.WithValue(ctx, backendGateKey{}, gate)
+}
+
+// GetBackendGate returns the BackendGate of the context, if any.
+func GetBackendGate(ctx context.Context) BackendGate {
+	gate, _ := ctx.Value(backendGateKey{}).(BackendGate)
+	return gate
+}
+
 type circuitBreaker struct {
-	circuitBreaker *cbreaker.CircuitBreaker
-	name           string
+	next     http.Handler
+	name     string
+	breakers *breakerSet
 }
 
 // New creates a new circuit breaker middleware.
-func New(ctx context.Context, next http.Handler, confCircuitBreaker dynamic.CircuitBreaker, name string) (http.Handler, error) {
-	expression := confCircuitBreaker.Expression
-
+// The manager keeps the circuit breakers across the configuration reloads, it is optional.
+func New(ctx context.Context, next http.Handler, manager *Manager, confCircuitBreaker dynamic.CircuitBreaker, name string, registry metrics.Registry) (http.Handler, error) {
 	logger := middlewares.GetLogger(ctx, name, typeName)
 	logger.Debug().Msg("Creating middleware")
-	logger.Debug().Msgf("Setting up with expression: %s", expression)
+	logger.Debug().Msgf("Setting up with expression: %s", confCircuitBreaker.Expression)
 
-	responseCode := confCircuitBreaker.ResponseCode
+	breakers, err := manager.breakerSet(name, confCircuitBreaker, func() (*breakerSet, error) {
+		return newBreakerSet(ctx, confCircuitBreaker, name, registry)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	cbOpts := []cbreaker.Option{
-		cbreaker.Fallback(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-			observability.SetStatusErrorf(req.Context(), "blocked by circuit-breaker (%q)", expression)
-			rw.WriteHeader(responseCode)
+	return &circuitBreaker{
+		next:     next,
+		name:     name,
+		breakers: breakers,
+	}, nil
+}
 
-			if _, err := rw.Write([]byte(http.StatusText(responseCode))); err != nil {
-				log.Ctx(req.Context()).Error().Err(err).Send()
-			}
-		})),
-		cbreaker.Logger(logs.NewOxyWrapper(*logger)),
-		cbreaker.Verbose(logger.GetLevel() == zerolog.TraceLevel),
-	}
+func (c *circuitBreaker) GetTracingInformation() (string, string, trace.SpanKind) {
+	return c.name, typeName, trace.SpanKindInternal
+}
 
-	if confCircuitBreaker.CheckPeriod > 0 {
-		cbOpts = append(cbOpts, cbreaker.CheckPeriod(time.Duration(confCircuitBreaker.CheckPeriod)))
+func (c *circuitBreaker) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	// Each router gets its own circuit breakers.
+	gate := &routerGate{breakers: c.breakers, router: middlewares.GetRouterName(req.Context())}
+
+	if c.breakers.config.PerBackend {
+		c.next.ServeHTTP(rw, req.WithContext(WithBackendGate(req.Context(), gate)))
+		return
 	}
 
-	if confCircuitBreaker.FallbackDuration > 0 {
-		cbOpts = append(cbOpts, cbreaker.FallbackDuration(time.Duration(confCircuitBreaker.FallbackDuration)))
+	if !gate.Allow("") {
+		gate.Fallback(rw, req)
+		return
 	}
 
-	if confCircuitBreaker.RecoveryDuration > 0 {
-		cbOpts = append(cbOpts, cbreaker.RecoveryDuration(time.Duration(confCircuitBreaker.RecoveryDuration)))
+	gate.Serve("", c.next, rw, req)
+}
+
+// routerGate is the BackendGate of the circuit breakers of a router.
+type routerGate struct {
+	breakers *breakerSet
+	router   string
+}
+
+// Allow implements BackendGate.
+func (g *routerGate) Allow(backend string) bool {
+	return g.breakers.get(g.router, backend).allow()
+}
+
+// Serve implements BackendGate.
+func (g *routerGate) Serve(backend string, next http.Handler, rw http.ResponseWriter, req *http.Request) {
+	g.breakers.get(g.router, backend).serve(next, rw, req)
+}
+
+// Fallback implements BackendGate.
+func (g *routerGate) Fallback(rw http.ResponseWriter, req *http.Request) {
+	config := g.breakers.config
+
+	observability.SetStatusErrorf(req.Context(), "blocked by circuit-breaker (%q)", config.Expression)
+	rw.WriteHeader(config.ResponseCode)
+
+	if _, err := rw.Write([]byte(http.StatusText(config.ResponseCode))); err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Send()
 	}
+}
+
+type breakerKey struct {
+	router  string
+	backend string
+}
 
-	oxyCircuitBreaker, err := cbreaker.New(next, expression, cbOpts...)
+// breakerSet holds the circuit breakers of a circuitBreaker middleware:
+// one per router, for the whole service or for each backend.
+type breakerSet struct {
+	config   dynamic.CircuitBreaker
+	name     string
+	settings *settings
+	registry metrics.Registry
+
+	mu       sync.RWMutex
+	breakers map[breakerKey]*breaker
+}
+
+func newBreakerSet(ctx context.Context, config dynamic.CircuitBreaker, name string, registry metrics.Registry) (*breakerSet, error) {
+	cond, err := parseExpression(config.Expression)
 	if err != nil {
 		return nil, err
 	}
 
-	return &circuitBreaker{
-		circuitBreaker: oxyCircuitBreaker,
-		name:           name,
-	}, nil
+	probePercent := config.ProbePercent
+	if probePercent == 0 {
+		probePercent = defaultProbePercent
+	}
+	if probePercent < 0 || probePercent > 100 {
+		return nil, errors.New("probePercent must be between 1 and 100")
+	}
+
+	s := &settings{
+		condition:        cond,
+		checkPeriod:      defaultCheckPeriod,
+		fallbackDuration: defaultFallbackDuration,
+		recoveryDuration: defaultRecoveryDuration,
+		probeRatio:       float64(probePercent) / 100,
+		logger:           middlewares.GetLogger(ctx, name, typeName),
+		now:              time.Now,
+	}
+
+	if config.CheckPeriod > 0 {
+		s.checkPeriod = time.Duration(config.CheckPeriod)
+	}
+
+	if config.FallbackDuration > 0 {
+		s.fallbackDuration = time.Duration(config.FallbackDuration)
+	}
+
+	if config.RecoveryDuration > 0 {
+		s.recoveryDuration = time.Duration(config.RecoveryDuration)
+	}
+
+	set := &breakerSet{
+		config:   config,
+		name:     name,
+		settings: s,
+		registry: registry,
+		breakers: make(map[breakerKey]*breaker),
+	}
+
+	return set, nil
 }
 
-func (c *circuitBreaker) GetTracingInformation() (string, string, trace.SpanKind) {
-	return c.name, typeName, trace.SpanKindInternal
+// get returns the breaker of the given router and backend, and creates it if needed.
+// It returns nil if the breaker cannot be created.
+func (s *breakerSet) get(router, backend string) *breaker {
+	key := breakerKey{router: router, backend: backend}
+
+	s.mu.RLock()
+	b, ok := s.breakers[key]
+	s.mu.RUnlock()
+
+	if ok {
+		return b
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if b, ok = s.breakers[key]; ok {
+		return b
+	}
+
+	rtMetrics, err := memmetrics.NewRTMetrics()
+	if err != nil {
+		// The requests are forwarded without circuit breaker.
+		s.settings.logger.Error().Err(err).Str("backend", backend).Msg("Unable to create circuit breaker")
+		return nil
+	}
+
+	b = &breaker{
+		settings: s.settings,
+		router:   router,
+		backend:  backend,
+		metrics:  rtMetrics,
+	}
+
+	if s.registry != nil {
+		b.stateGauge = s.registry.CircuitBreakerStateGauge().With("middleware", s.name, "router", router, "backend", backend)
+		b.tripsCounter = s.registry.CircuitBreakerTripsCounter().With("middleware", s.name, "router", router, "backend", backend)
+		b.matchedCounter = s.registry.CircuitBreakerEvaluationsCounter().With("middleware", s.name, "router", router, "backend", backend, "result", "matched")
+		b.notMatchedCounter = s.registry.CircuitBreakerEvaluationsCounter().With("middleware", s.name, "router", router, "backend", backend, "result", "not_matched")
+
+		b.stateGauge.Set(float64(stateClosed))
+	}
+
+	s.breakers[key] = b
+
+	return b
 }
 
-func (c *circuitBreaker) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
-	c.circuitBreaker.ServeHTTP(rw, req)
+func (s *breakerSet) status() Status {
+	s.mu.RLock()
+	breakers := make([]*breaker, 0, len(s.breakers))
+	for _, b := range s.breakers {
+		breakers = append(breakers, b)
+	}
+	s.mu.RUnlock()
+
+	status := Status{
+		PerBackend: s.config.PerBackend,
+		Breakers:   make([]BreakerStatus, 0, len(breakers)),
+	}
+
+	for _, b := range breakers {
+		status.Breakers = append(status.Breakers, b.status())
+	}
+
+	sort.Slice(status.Breakers, func(i, j int) bool {
+		if status.Breakers[i].Router != status.Breakers[j].Router {
+			return status.Breakers[i].Router < status.Breakers[j].Router
+		}
+		return status.Breakers[i].Backend < status.Breakers[j].Backend
+	})
+
+	return status
 }
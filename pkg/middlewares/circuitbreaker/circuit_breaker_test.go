@@ -0,0 +1,196 @@
+package circuitbreaker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+)
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		expression string
+		probe      int
+	}{
+		{
+			desc:       "empty expression",
+			expression: "",
+		},
+		{
+			desc:       "unknown function",
+			expression: "ErrorRatio() > 0.5",
+		},
+		{
+			desc:       "not a condition",
+			expression: "NetworkErrorRatio()",
+		},
+		{
+			desc:       "int compared with a float",
+			expression: "LatencyAtQuantileMS(50.0) > 0.5",
+		},
+		{
+			desc:       "invalid probe percent",
+			expression: "NetworkErrorRatio() > 0.5",
+			probe:      101,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			config := dynamic.CircuitBreaker{}
+			config.SetDefaults()
+			config.Expression = test.expression
+			if test.probe != 0 {
+				config.ProbePercent = test.probe
+			}
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+			_, err := New(context.Background(), next, nil, config, "traefikTest", nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCircuitBreaker_states(t *testing.T) {
+	config := dynamic.CircuitBreaker{}
+	config.SetDefaults()
+	config.Expression = "ResponseCodeRatio(500, 600, 0, 600) > 0.5"
+	config.CheckPeriod = ptypes.Duration(time.Nanosecond)
+	config.ProbePercent = 100
+
+	statusCode := http.StatusInternalServerError
+	var forwarded int
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwarded++
+		rw.WriteHeader(statusCode)
+	})
+
+	manager := NewManager()
+
+	cb, err := New(context.Background(), next, manager, config, "cb@file", nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	cb.(*circuitBreaker).breakers.settings.now = func() time.Time { return now }
+
+	handler, err := middlewares.WrapRouterName("router@file")(cb)
+	require.NoError(t, err)
+
+	serve := func() int {
+		now = now.Add(time.Millisecond)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		return recorder.Code
+	}
+
+	assertStatus := func(expectedState string, expectedTrips uint64) {
+		t.Helper()
+
+		status, ok := manager.Status("cb@file")
+		require.True(t, ok)
+		require.Len(t, status.Breakers, 1)
+		assert.Equal(t, "router@file", status.Breakers[0].Router)
+		assert.Equal(t, expectedState, status.Breakers[0].State)
+		assert.Equal(t, expectedTrips, status.Breakers[0].Trips)
+	}
+
+	status, ok := manager.Status("cb@file")
+	require.True(t, ok)
+	assert.Empty(t, status.Breakers)
+
+	// The failing request trips the circuit breaker.
+	assert.Equal(t, http.StatusInternalServerError, serve())
+	assertStatus("open", 1)
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve())
+	assert.Equal(t, 1, forwarded)
+
+	// Half of the recovery duration, with a probe percent of 100, lets half of the requests through.
+	statusCode = http.StatusOK
+	now = now.Add(10 * time.Second)
+	assert.Equal(t, http.StatusServiceUnavailable, serve())
+	assertStatus("recovering", 1)
+
+	now = now.Add(5 * time.Second)
+	forwarded = 0
+	for range 100 {
+		serve()
+		now = now.Add(-time.Millisecond)
+	}
+	assert.InDelta(t, 50, forwarded, 2)
+
+	now = now.Add(5 * time.Second)
+	assert.Equal(t, http.StatusOK, serve())
+	assertStatus("closed", 1)
+
+	// The state is kept when the middleware is created again with the same configuration.
+	_, err = New(context.Background(), next, manager, config, "cb@file", nil)
+	require.NoError(t, err)
+	assertStatus("closed", 1)
+
+	config.ProbePercent = 50
+	_, err = New(context.Background(), next, manager, config, "cb@file", nil)
+	require.NoError(t, err)
+
+	status, ok = manager.Status("cb@file")
+	require.True(t, ok)
+	assert.Empty(t, status.Breakers)
+}
+
+func TestCircuitBreaker_perBackend(t *testing.T) {
+	config := dynamic.CircuitBreaker{}
+	config.SetDefaults()
+	config.Expression = "NetworkErrorRatio() > 0.5"
+	config.PerBackend = true
+
+	manager := NewManager()
+
+	var gate BackendGate
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		gate = GetBackendGate(req.Context())
+	})
+
+	handler, err := New(context.Background(), next, manager, config, "cb@file", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NotNil(t, gate)
+
+	status, ok := manager.Status("cb@file")
+	require.True(t, ok)
+	assert.True(t, status.PerBackend)
+	assert.Empty(t, status.Breakers)
+
+	badGateway := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadGateway)
+	})
+
+	assert.True(t, gate.Allow("first"))
+	gate.Serve("first", badGateway, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.False(t, gate.Allow("first"))
+	assert.True(t, gate.Allow("second"))
+
+	status, ok = manager.Status("cb@file")
+	require.True(t, ok)
+	require.Len(t, status.Breakers, 2)
+	assert.Equal(t, "first", status.Breakers[0].Backend)
+	assert.Equal(t, "open", status.Breakers[0].State)
+	assert.NotNil(t, status.Breakers[0].Until)
+	assert.Equal(t, "second", status.Breakers[1].Backend)
+	assert.Equal(t, "closed", status.Breakers[1].State)
+
+	recorder := httptest.NewRecorder()
+	gate.Fallback(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
@@ -0,0 +1,138 @@
+package circuitbreaker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vulcand/oxy/v2/memmetrics"
+	"github.com/vulcand/predicate"
+)
+
+// condition is the condition of a circuit breaker, evaluated against the metrics of the forwarded requests.
+type condition func(metrics *memmetrics.RTMetrics) bool
+
+type toInt func(metrics *memmetrics.RTMetrics) int
+
+type toFloat64 func(metrics *memmetrics.RTMetrics) float64
+
+// parseExpression parses the expression of a circuit breaker, e.g. NetworkErrorRatio() > 0.5 || LatencyAtQuantileMS(50.0) > 50.
+func parseExpression(expression string) (condition, error) {
+	parser, err := predicate.NewParser(predicate.Def{
+		Operators: predicate.Operators{
+			AND: and,
+			OR:  or,
+			EQ:  eq,
+			NEQ: neq,
+			LT:  lt,
+			LE:  le,
+			GT:  gt,
+			GE:  ge,
+		},
+		Functions: map[string]interface{}{
+			"LatencyAtQuantileMS": latencyAtQuantileMS,
+			"NetworkErrorRatio":   networkErrorRatio,
+			"ResponseCodeRatio":   responseCodeRatio,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := parser.Parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("parsing circuit breaker expression %q: %w", expression, err)
+	}
+
+	cond, ok := parsed.(condition)
+	if !ok {
+		return nil, fmt.Errorf("invalid circuit breaker expression %q: expected a condition, got %T", expression, parsed)
+	}
+
+	return cond, nil
+}
+
+func latencyAtQuantileMS(quantile float64) toInt {
+	return func(metrics *memmetrics.RTMetrics) int {
+		histogram, err := metrics.LatencyHistogram()
+		if err != nil {
+			return 0
+		}
+		return int(histogram.LatencyAtQuantile(quantile) / time.Millisecond)
+	}
+}
+
+func networkErrorRatio() toFloat64 {
+	return func(metrics *memmetrics.RTMetrics) float64 {
+		return metrics.NetworkErrorRatio()
+	}
+}
+
+func responseCodeRatio(startA, endA, startB, endB int) toFloat64 {
+	return func(metrics *memmetrics.RTMetrics) float64 {
+		return metrics.ResponseCodeRatio(startA, endA, startB, endB)
+	}
+}
+
+func and(left, right condition) condition {
+	return func(metrics *memmetrics.RTMetrics) bool {
+		return left(metrics) && right(metrics)
+	}
+}
+
+func or(left, right condition) condition {
+	return func(metrics *memmetrics.RTMetrics) bool {
+		return left(metrics) || right(metrics)
+	}
+}
+
+func eq(mapper, value interface{}) (condition, error) {
+	return compare(mapper, value, func(a, b float64) bool { return a == b })
+}
+
+func neq(mapper, value interface{}) (condition, error) {
+	return compare(mapper, value, func(a, b float64) bool { return a != b })
+}
+
+func lt(mapper, value interface{}) (condition, error) {
+	return compare(mapper, value, func(a, b float64) bool { return a < b })
+}
+
+func le(mapper, value interface{}) (condition, error) {
+	return compare(mapper, value, func(a, b float64) bool { return a <= b })
+}
+
+func gt(mapper, value interface{}) (condition, error) {
+	return compare(mapper, value, func(a, b float64) bool { return a > b })
+}
+
+func ge(mapper, value interface{}) (condition, error) {
+	return compare(mapper, value, func(a, b float64) bool { return a >= b })
+}
+
+// compare returns the condition comparing the value of the mapper with the given constant.
+func compare(mapper, value interface{}, cmp func(a, b float64) bool) (condition, error) {
+	switch m := mapper.(type) {
+	case toInt:
+		v, ok := value.(int)
+		if !ok {
+			return nil, fmt.Errorf("expected int, got %T", value)
+		}
+
+		return func(metrics *memmetrics.RTMetrics) bool {
+			return cmp(float64(m(metrics)), float64(v))
+		}, nil
+
+	case toFloat64:
+		v, ok := value.(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected float64, got %T", value)
+		}
+
+		return func(metrics *memmetrics.RTMetrics) bool {
+			return cmp(m(metrics), v)
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported argument: %T", mapper)
+	}
+}
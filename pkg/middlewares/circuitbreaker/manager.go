@@ -0,0 +1,76 @@
+package circuitbreaker
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// Status is the status of the circuit breakers of a circuitBreaker middleware.
+type Status struct {
+	PerBackend bool            `json:"perBackend"`
+	Breakers   []BreakerStatus `json:"breakers"`
+}
+
+// BreakerStatus is the status of the circuit breaker of a router, for a whole service or for one of its backends.
+type BreakerStatus struct {
+	Router  string `json:"router,omitempty"`
+	Backend string `json:"backend,omitempty"`
+	State   string `json:"state"`
+	// Until is the end of the open or recovering state.
+	Until       *time.Time `json:"until,omitempty"`
+	Trips       uint64     `json:"trips"`
+	Evaluations uint64     `json:"evaluations"`
+	Matches     uint64     `json:"matches"`
+}
+
+// Manager holds the circuit breakers of the circuitBreaker middlewares,
+// so that their states are kept across the configuration reloads, as long as their configurations do not change.
+type Manager struct {
+	mu   sync.Mutex
+	sets map[string]*breakerSet
+}
+
+// NewManager creates a new Manager.
+func NewManager() *Manager {
+	return &Manager{sets: make(map[string]*breakerSet)}
+}
+
+// Status returns the status of the circuit breakers of the given middleware.
+func (m *Manager) Status(middlewareName string) (Status, bool) {
+	m.mu.Lock()
+	set, ok := m.sets[middlewareName]
+	m.mu.Unlock()
+
+	if !ok {
+		return Status{}, false
+	}
+
+	return set.status(), true
+}
+
+// breakerSet returns the breakers of the given middleware, created with newSet if there is none for its configuration.
+// A nil Manager always creates new breakers.
+func (m *Manager) breakerSet(middlewareName string, config dynamic.CircuitBreaker, newSet func() (*breakerSet, error)) (*breakerSet, error) {
+	if m == nil {
+		return newSet()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if set, ok := m.sets[middlewareName]; ok && reflect.DeepEqual(set.config, config) {
+		return set, nil
+	}
+
+	set, err := newSet()
+	if err != nil {
+		return nil, err
+	}
+
+	m.sets[middlewareName] = set
+
+	return set, nil
+}
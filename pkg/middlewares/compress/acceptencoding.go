@@ -70,6 +70,27 @@ func getCompressionEncoding(acceptEncoding []string, defaultEncoding string, sup
 	return identityName
 }
 
+// getServerPreferredEncoding returns the first of the supported encodings accepted by the client,
+// regardless of the weights of the accepted encodings.
+func getServerPreferredEncoding(acceptEncoding, supportedEncodings []string) string {
+	encodings, _ := parseAcceptEncoding(acceptEncoding, supportedEncodings)
+
+	wildcard := slices.IndexFunc(encodings, func(e Encoding) bool { return e.Type == wildcardName })
+
+	for _, dt := range supportedEncodings {
+		i := slices.IndexFunc(encodings, func(e Encoding) bool { return e.Type == dt })
+		if i < 0 {
+			i = wildcard
+		}
+
+		if i >= 0 && (encodings[i].Weight == nil || *encodings[i].Weight > 0) {
+			return dt
+		}
+	}
+
+	return identityName
+}
+
 func parseAcceptEncoding(acceptEncoding, supportedEncodings []string) ([]Encoding, bool) {
 	var encodings []Encoding
 	var hasWeight bool
@@ -104,6 +104,71 @@ func Test_getCompressionEncoding(t *testing.T) {
 	}
 }
 
+func Test_getServerPreferredEncoding(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		acceptEncoding     []string
+		supportedEncodings []string
+		expected           string
+	}{
+		{
+			desc:           "server order (no weight)",
+			acceptEncoding: []string{"gzip, br"},
+			expected:       brotliName,
+		},
+		{
+			desc:           "server order over weight",
+			acceptEncoding: []string{"gzip;q=1.0, zstd;q=0.1"},
+			expected:       zstdName,
+		},
+		{
+			desc:               "server order of the supported encodings",
+			acceptEncoding:     []string{"zstd, br, gzip"},
+			supportedEncodings: []string{gzipName, zstdName},
+			expected:           gzipName,
+		},
+		{
+			desc:           "refused encoding",
+			acceptEncoding: []string{"zstd;q=0, gzip"},
+			expected:       gzipName,
+		},
+		{
+			desc:           "wildcard",
+			acceptEncoding: []string{"gzip, *"},
+			expected:       zstdName,
+		},
+		{
+			desc:           "refused encoding with wildcard",
+			acceptEncoding: []string{"zstd;q=0, *;q=0.5"},
+			expected:       brotliName,
+		},
+		{
+			desc:           "unknown compression encoding",
+			acceptEncoding: []string{"compress, rar"},
+			expected:       identityName,
+		},
+		{
+			desc:           "refused wildcard",
+			acceptEncoding: []string{"*;q=0"},
+			expected:       identityName,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			if test.supportedEncodings == nil {
+				test.supportedEncodings = defaultSupportedEncodings
+			}
+
+			encoding := getServerPreferredEncoding(test.acceptEncoding, test.supportedEncodings)
+
+			assert.Equal(t, test.expected, encoding)
+		})
+	}
+}
+
 func Test_parseAcceptEncoding(t *testing.T) {
 	testCases := []struct {
 		desc               string
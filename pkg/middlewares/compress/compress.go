@@ -7,8 +7,10 @@ import (
 	"mime"
 	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/klauspost/compress/gzhttp"
+	"github.com/klauspost/compress/gzip"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
 	"go.opentelemetry.io/otel/trace"
@@ -22,6 +24,11 @@ const defaultMinSize = 1024
 
 var defaultSupportedEncodings = []string{zstdName, brotliName, gzipName}
 
+const (
+	negotiationOrderClient = "client"
+	negotiationOrderServer = "server"
+)
+
 // Compress is a middleware that allows to compress the response.
 type compress struct {
 	next            http.Handler
@@ -29,8 +36,14 @@ type compress struct {
 	excludes        []string
 	includes        []string
 	minSize         int
+	minSizes        map[string]int
+	levels          dynamic.CompressLevels
 	encodings       []string
 	defaultEncoding string
+	serverOrder     bool
+	excludedPaths   []string
+	excludedRouters []string
+	preCompressed   bool
 
 	brotliHandler http.Handler
 	gzipHandler   http.Handler
@@ -45,7 +58,7 @@ func New(ctx context.Context, next http.Handler, conf dynamic.Compress, name str
 		return nil, errors.New("excludedContentTypes and includedContentTypes options are mutually exclusive")
 	}
 
-	excludes := []string{"application/grpc"}
+	excludes := []string{"application/grpc", middlewares.EventStreamMediaType}
 	for _, v := range conf.ExcludedContentTypes {
 		mediaType, _, err := mime.ParseMediaType(v)
 		if err != nil {
@@ -82,29 +95,77 @@ func New(ctx context.Context, next http.Handler, conf dynamic.Compress, name str
 		return nil, fmt.Errorf("unsupported default encoding: %s", conf.DefaultEncoding)
 	}
 
+	switch conf.NegotiationOrder {
+	case "", negotiationOrderClient, negotiationOrderServer:
+	default:
+		return nil, fmt.Errorf("unsupported negotiation order: %s", conf.NegotiationOrder)
+	}
+
+	var minSizes map[string]int
+	for _, v := range conf.ContentTypeMinSizes {
+		mediaType, _, err := mime.ParseMediaType(v.ContentType)
+		if err != nil {
+			return nil, fmt.Errorf("parsing minimum size media type: %w", err)
+		}
+
+		if v.MinResponseBodyBytes < 0 {
+			return nil, fmt.Errorf("minimum size of %s must be greater than or equal to zero", mediaType)
+		}
+
+		if minSizes == nil {
+			minSizes = make(map[string]int)
+		}
+		minSizes[mediaType] = v.MinResponseBodyBytes
+	}
+
+	var levels dynamic.CompressLevels
+	if conf.Levels != nil {
+		levels = *conf.Levels
+	}
+	if levels.Gzip < 0 || levels.Gzip > 9 {
+		return nil, fmt.Errorf("gzip compression level must be between 1 and 9: %d", levels.Gzip)
+	}
+	if levels.Brotli < 0 || levels.Brotli > 11 {
+		return nil, fmt.Errorf("brotli compression level must be between 1 and 11: %d", levels.Brotli)
+	}
+	if levels.Zstd < 0 || levels.Zstd > 22 {
+		return nil, fmt.Errorf("zstd compression level must be between 1 and 22: %d", levels.Zstd)
+	}
+
 	c := &compress{
 		next:            next,
 		name:            name,
 		excludes:        excludes,
 		includes:        includes,
 		minSize:         minSize,
+		minSizes:        minSizes,
+		levels:          levels,
 		encodings:       conf.Encodings,
 		defaultEncoding: conf.DefaultEncoding,
+		serverOrder:     conf.NegotiationOrder == negotiationOrderServer,
+		excludedPaths:   conf.ExcludedPaths,
+		excludedRouters: conf.ExcludedRouters,
+		preCompressed:   conf.PreCompressed,
 	}
 
 	var err error
 
-	c.zstdHandler, err = c.newCompressionHandler(zstdName, name)
+	c.zstdHandler, err = c.newCompressionHandler(zstdName, levels.Zstd, name)
 	if err != nil {
 		return nil, err
 	}
 
-	c.brotliHandler, err = c.newCompressionHandler(brotliName, name)
+	c.brotliHandler, err = c.newCompressionHandler(brotliName, levels.Brotli, name)
 	if err != nil {
 		return nil, err
 	}
 
-	c.gzipHandler, err = c.newGzipHandler()
+	// The gzhttp handler only supports a single minimum size.
+	if len(minSizes) > 0 {
+		c.gzipHandler, err = c.newCompressionHandler(gzipName, levels.Gzip, name)
+	} else {
+		c.gzipHandler, err = c.newGzipHandler()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +176,8 @@ func New(ctx context.Context, next http.Handler, conf dynamic.Compress, name str
 func (c *compress) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	logger := middlewares.GetLogger(req.Context(), c.name, typeName)
 
-	if req.Method == http.MethodHead {
+	// The Server-Sent Events streams must reach the client as soon as the events are written.
+	if req.Method == http.MethodHead || c.isExcluded(req) || middlewares.IsEventStreamRequest(req) {
 		c.next.ServeHTTP(rw, req)
 		return
 	}
@@ -147,10 +209,44 @@ func (c *compress) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if c.serverOrder {
+		c.chooseHandler(getServerPreferredEncoding(acceptEncoding, c.encodings), rw, req)
+		return
+	}
+
 	c.chooseHandler(getCompressionEncoding(acceptEncoding, c.defaultEncoding, c.encodings), rw, req)
 }
 
+// isExcluded reports whether the response to the request must not be compressed, because of its path or router.
+func (c *compress) isExcluded(req *http.Request) bool {
+	if len(c.excludedRouters) > 0 && slices.Contains(c.excludedRouters, middlewares.GetRouterName(req.Context())) {
+		return true
+	}
+
+	return slices.ContainsFunc(c.excludedPaths, func(prefix string) bool {
+		return strings.HasPrefix(req.URL.Path, prefix)
+	})
+}
+
+// isIncluded reports whether a response of the given content type can be compressed, when included content types are set.
+// The Server-Sent Events streams are never compressed, even if they are included.
+func (c *compress) isIncluded(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType != middlewares.EventStreamMediaType && slices.Contains(c.includes, mediaType)
+}
+
 func (c *compress) chooseHandler(typ string, rw http.ResponseWriter, req *http.Request) {
+	// The service is only asked for the negotiated encoding,
+	// the responses it does not encode being compressed by the handler.
+	if c.preCompressed && slices.Contains(defaultSupportedEncodings, typ) {
+		req = req.Clone(req.Context())
+		req.Header.Set(acceptEncodingHeader, typ)
+	}
+
 	switch typ {
 	case zstdName:
 		c.zstdHandler.ServeHTTP(rw, req)
@@ -171,15 +267,22 @@ func (c *compress) newGzipHandler() (http.Handler, error) {
 	var wrapper func(http.Handler) http.HandlerFunc
 	var err error
 
+	level := gzip.DefaultCompression
+	if c.levels.Gzip > 0 {
+		level = c.levels.Gzip
+	}
+
 	if len(c.includes) > 0 {
 		wrapper, err = gzhttp.NewWrapper(
-			gzhttp.ContentTypes(c.includes),
+			gzhttp.ContentTypeFilter(c.isIncluded),
 			gzhttp.MinSize(c.minSize),
+			gzhttp.CompressionLevel(level),
 		)
 	} else {
 		wrapper, err = gzhttp.NewWrapper(
 			gzhttp.ExceptContentTypes(c.excludes),
 			gzhttp.MinSize(c.minSize),
+			gzhttp.CompressionLevel(level),
 		)
 	}
 
@@ -190,8 +293,8 @@ func (c *compress) newGzipHandler() (http.Handler, error) {
 	return wrapper(c.next), nil
 }
 
-func (c *compress) newCompressionHandler(algo string, middlewareName string) (http.Handler, error) {
-	cfg := Config{MinSize: c.minSize, Algorithm: algo, MiddlewareName: middlewareName}
+func (c *compress) newCompressionHandler(algo string, level int, middlewareName string) (http.Handler, error) {
+	cfg := Config{MinSize: c.minSize, ContentTypeMinSizes: c.minSizes, Algorithm: algo, Level: level, MiddlewareName: middlewareName}
 	if len(c.includes) > 0 {
 		cfg.IncludedContentTypes = c.includes
 	} else {
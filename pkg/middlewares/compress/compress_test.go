@@ -8,12 +8,14 @@ import (
 	"net/http/httptest"
 	"net/http/httptrace"
 	"net/textproto"
+	"strings"
 	"testing"
 
 	"github.com/klauspost/compress/gzhttp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
 	"github.com/traefik/traefik/v3/pkg/testhelpers"
 )
 
@@ -273,6 +275,7 @@ func TestShouldNotCompressWhenSpecificContentType(t *testing.T) {
 		desc            string
 		conf            dynamic.Compress
 		reqContentType  string
+		reqAccept       string
 		respContentType string
 	}{
 		{
@@ -299,6 +302,28 @@ func TestShouldNotCompressWhenSpecificContentType(t *testing.T) {
 			},
 			respContentType: "text/html",
 		},
+		{
+			desc: "Ignoring event stream response with no option",
+			conf: dynamic.Compress{
+				Encodings: defaultSupportedEncodings,
+			},
+			respContentType: "text/event-stream",
+		},
+		{
+			desc: "Ignoring event stream response with include option",
+			conf: dynamic.Compress{
+				Encodings:            defaultSupportedEncodings,
+				IncludedContentTypes: []string{"text/event-stream"},
+			},
+			respContentType: "text/event-stream; charset=utf-8",
+		},
+		{
+			desc: "Ignoring event stream request",
+			conf: dynamic.Compress{
+				Encodings: defaultSupportedEncodings,
+			},
+			reqAccept: "text/event-stream",
+		},
 		{
 			desc: "Ignoring application/grpc with exclude option",
 			conf: dynamic.Compress{
@@ -333,6 +358,9 @@ func TestShouldNotCompressWhenSpecificContentType(t *testing.T) {
 			if test.reqContentType != "" {
 				req.Header.Add(contentTypeHeader, test.reqContentType)
 			}
+			if test.reqAccept != "" {
+				req.Header.Add("Accept", test.reqAccept)
+			}
 
 			next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 				if len(test.respContentType) > 0 {
@@ -605,6 +633,399 @@ func TestMinResponseBodyBytes(t *testing.T) {
 	}
 }
 
+func TestContentTypeMinSizes(t *testing.T) {
+	fakeBody := generateBytes(2000)
+
+	testCases := []struct {
+		desc                string
+		encoding            string
+		contentType         string
+		expectedCompression bool
+	}{
+		{
+			desc:        "gzip, larger minimum size",
+			encoding:    gzipName,
+			contentType: "application/json",
+		},
+		{
+			desc:                "gzip, smaller minimum size",
+			encoding:            gzipName,
+			contentType:         "text/html; charset=utf-8",
+			expectedCompression: true,
+		},
+		{
+			desc:                "gzip, default minimum size",
+			encoding:            gzipName,
+			contentType:         "text/plain",
+			expectedCompression: true,
+		},
+		{
+			desc:        "brotli, larger minimum size",
+			encoding:    brotliName,
+			contentType: "application/json",
+		},
+		{
+			desc:                "zstd, default minimum size",
+			encoding:            zstdName,
+			contentType:         "text/plain",
+			expectedCompression: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			req := testhelpers.MustNewRequest(http.MethodGet, "http://localhost", nil)
+			req.Header.Add(acceptEncodingHeader, test.encoding)
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				rw.Header().Set(contentTypeHeader, test.contentType)
+
+				if _, err := rw.Write(fakeBody); err != nil {
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+				}
+			})
+			cfg := dynamic.Compress{
+				Encodings: defaultSupportedEncodings,
+				ContentTypeMinSizes: []dynamic.CompressContentTypeMinSize{
+					{ContentType: "application/json", MinResponseBodyBytes: 4096},
+					{ContentType: "text/html", MinResponseBodyBytes: 10},
+				},
+			}
+			handler, err := New(context.Background(), next, cfg, "testing")
+			require.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			if test.expectedCompression {
+				assert.Equal(t, test.encoding, rw.Header().Get(contentEncodingHeader))
+				assert.NotEqualValues(t, rw.Body.Bytes(), fakeBody)
+				return
+			}
+
+			assert.Empty(t, rw.Header().Get(contentEncodingHeader))
+			assert.EqualValues(t, rw.Body.Bytes(), fakeBody)
+		})
+	}
+}
+
+func TestLevels(t *testing.T) {
+	fakeBody := generateBytes(100000)
+
+	for _, encoding := range defaultSupportedEncodings {
+		t.Run(encoding, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				_, _ = rw.Write(fakeBody)
+			})
+
+			serve := func(levels *dynamic.CompressLevels) int {
+				t.Helper()
+
+				cfg := dynamic.Compress{
+					Encodings: defaultSupportedEncodings,
+					Levels:    levels,
+				}
+				handler, err := New(context.Background(), next, cfg, "testing")
+				require.NoError(t, err)
+
+				req := testhelpers.MustNewRequest(http.MethodGet, "http://localhost", nil)
+				req.Header.Add(acceptEncodingHeader, encoding)
+
+				rw := httptest.NewRecorder()
+				handler.ServeHTTP(rw, req)
+
+				assert.Equal(t, encoding, rw.Header().Get(contentEncodingHeader))
+
+				return rw.Body.Len()
+			}
+
+			fastest := serve(&dynamic.CompressLevels{Gzip: 1, Brotli: 1, Zstd: 1})
+			best := serve(&dynamic.CompressLevels{Gzip: 9, Brotli: 11, Zstd: 22})
+			assert.Less(t, best, fastest)
+		})
+	}
+}
+
+func TestNegotiationOrder(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		negotiationOrder string
+		encodings        []string
+		acceptEncHeader  string
+		expEncoding      string
+	}{
+		{
+			desc:            "client order",
+			encodings:       []string{gzipName, brotliName},
+			acceptEncHeader: "br;q=1.0, gzip;q=0.5",
+			expEncoding:     brotliName,
+		},
+		{
+			desc:             "server order",
+			negotiationOrder: "server",
+			encodings:        []string{gzipName, brotliName},
+			acceptEncHeader:  "br;q=1.0, gzip;q=0.5",
+			expEncoding:      gzipName,
+		},
+		{
+			desc:             "server order, refused encoding",
+			negotiationOrder: "server",
+			encodings:        []string{gzipName, brotliName},
+			acceptEncHeader:  "br;q=1.0, gzip;q=0",
+			expEncoding:      brotliName,
+		},
+		{
+			desc:             "server order, wildcard",
+			negotiationOrder: "server",
+			encodings:        []string{zstdName, gzipName},
+			acceptEncHeader:  "gzip;q=0.5, *",
+			expEncoding:      zstdName,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			req := testhelpers.MustNewRequest(http.MethodGet, "http://localhost", nil)
+			req.Header.Add(acceptEncodingHeader, test.acceptEncHeader)
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				_, _ = rw.Write(generateBytes(10))
+			})
+			cfg := dynamic.Compress{
+				MinResponseBodyBytes: 1,
+				Encodings:            test.encodings,
+				NegotiationOrder:     test.negotiationOrder,
+			}
+			handler, err := New(context.Background(), next, cfg, "testing")
+			require.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expEncoding, rw.Header().Get(contentEncodingHeader))
+		})
+	}
+}
+
+func TestExclusions(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		path                string
+		router              string
+		expectedCompression bool
+	}{
+		{
+			desc:                "not excluded",
+			path:                "/api/users",
+			router:              "web@file",
+			expectedCompression: true,
+		},
+		{
+			desc:   "excluded path",
+			path:   "/downloads/archive.zip",
+			router: "web@file",
+		},
+		{
+			desc:   "excluded router",
+			path:   "/api/users",
+			router: "stream@file",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				_, _ = rw.Write(generateBytes(gzhttp.DefaultMinSize))
+			})
+			cfg := dynamic.Compress{
+				Encodings:       defaultSupportedEncodings,
+				ExcludedPaths:   []string{"/downloads/"},
+				ExcludedRouters: []string{"stream@file"},
+			}
+			compressHandler, err := New(context.Background(), next, cfg, "testing")
+			require.NoError(t, err)
+
+			handler, err := middlewares.WrapRouterName(test.router)(compressHandler)
+			require.NoError(t, err)
+
+			req := testhelpers.MustNewRequest(http.MethodGet, "http://localhost"+test.path, nil)
+			req.Header.Add(acceptEncodingHeader, gzipName)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			if test.expectedCompression {
+				assert.Equal(t, gzipName, rw.Header().Get(contentEncodingHeader))
+				return
+			}
+
+			assert.Empty(t, rw.Header().Get(contentEncodingHeader))
+		})
+	}
+}
+
+func TestPreCompressed(t *testing.T) {
+	testCases := []struct {
+		desc                   string
+		preCompressed          bool
+		acceptEncoding         string
+		variants               []string
+		expectedAcceptEncoding string
+		expectedEncoding       string
+		expectedPassthrough    bool
+	}{
+		{
+			desc:                   "disabled",
+			acceptEncoding:         "gzip, br",
+			variants:               []string{brotliName},
+			expectedAcceptEncoding: "gzip, br",
+			expectedEncoding:       brotliName,
+			expectedPassthrough:    true,
+		},
+		{
+			desc:                   "pre-compressed variant",
+			preCompressed:          true,
+			acceptEncoding:         "gzip, br",
+			variants:               []string{gzipName, brotliName},
+			expectedAcceptEncoding: brotliName,
+			expectedEncoding:       brotliName,
+			expectedPassthrough:    true,
+		},
+		{
+			desc:                   "pre-compressed gzip variant",
+			preCompressed:          true,
+			acceptEncoding:         "gzip",
+			variants:               []string{gzipName},
+			expectedAcceptEncoding: gzipName,
+			expectedEncoding:       gzipName,
+			expectedPassthrough:    true,
+		},
+		{
+			desc:                   "no pre-compressed variant",
+			preCompressed:          true,
+			acceptEncoding:         "gzip, br",
+			variants:               []string{gzipName},
+			expectedAcceptEncoding: brotliName,
+			expectedEncoding:       brotliName,
+		},
+		{
+			desc:                   "no pre-compressed gzip variant",
+			preCompressed:          true,
+			acceptEncoding:         "gzip",
+			expectedAcceptEncoding: gzipName,
+			expectedEncoding:       gzipName,
+		},
+		{
+			desc:                   "unsupported encoding",
+			preCompressed:          true,
+			acceptEncoding:         "notreal",
+			variants:               []string{gzipName},
+			expectedAcceptEncoding: "notreal",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			body := generateBytes(gzhttp.DefaultMinSize)
+
+			var acceptEncoding string
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				acceptEncoding = req.Header.Get(acceptEncodingHeader)
+
+				for _, variant := range test.variants {
+					if strings.Contains(acceptEncoding, variant) {
+						rw.Header().Set(contentEncodingHeader, variant)
+						break
+					}
+				}
+
+				_, _ = rw.Write(body)
+			})
+			cfg := dynamic.Compress{
+				Encodings:     defaultSupportedEncodings,
+				PreCompressed: test.preCompressed,
+			}
+			handler, err := New(context.Background(), next, cfg, "testing")
+			require.NoError(t, err)
+
+			req := testhelpers.MustNewRequest(http.MethodGet, "http://localhost", nil)
+			req.Header.Set(acceptEncodingHeader, test.acceptEncoding)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedAcceptEncoding, acceptEncoding)
+			assert.Equal(t, test.acceptEncoding, req.Header.Get(acceptEncodingHeader))
+			assert.Equal(t, test.expectedEncoding, rw.Header().Get(contentEncodingHeader))
+
+			if test.expectedEncoding != "" {
+				assert.Equal(t, acceptEncodingHeader, rw.Header().Get(varyHeader))
+			}
+
+			if test.expectedPassthrough || test.expectedEncoding == "" {
+				assert.Equal(t, body, rw.Body.Bytes())
+				return
+			}
+
+			assert.NotEqual(t, body, rw.Body.Bytes())
+		})
+	}
+}
+
+func TestNewInvalidConfiguration(t *testing.T) {
+	testCases := []struct {
+		desc string
+		conf dynamic.Compress
+	}{
+		{
+			desc: "unsupported negotiation order",
+			conf: dynamic.Compress{NegotiationOrder: "random"},
+		},
+		{
+			desc: "invalid gzip level",
+			conf: dynamic.Compress{Levels: &dynamic.CompressLevels{Gzip: 10}},
+		},
+		{
+			desc: "invalid brotli level",
+			conf: dynamic.Compress{Levels: &dynamic.CompressLevels{Brotli: 12}},
+		},
+		{
+			desc: "invalid zstd level",
+			conf: dynamic.Compress{Levels: &dynamic.CompressLevels{Zstd: 23}},
+		},
+		{
+			desc: "negative content type minimum size",
+			conf: dynamic.Compress{ContentTypeMinSizes: []dynamic.CompressContentTypeMinSize{{ContentType: "text/html", MinResponseBodyBytes: -1}}},
+		},
+		{
+			desc: "invalid content type minimum size media type",
+			conf: dynamic.Compress{ContentTypeMinSizes: []dynamic.CompressContentTypeMinSize{{ContentType: "text/html;;"}}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			test.conf.Encodings = defaultSupportedEncodings
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {})
+			_, err := New(context.Background(), next, test.conf, "testing")
+			assert.Error(t, err)
+		})
+	}
+}
+
 // This test is an adapted version of net/http/httputil.Test1xxResponses test.
 func Test1xxResponses(t *testing.T) {
 	fakeBody := generateBytes(100000)
@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/gzip"
 	"github.com/klauspost/compress/zstd"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
 	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
@@ -33,13 +34,18 @@ type Config struct {
 	IncludedContentTypes []string
 	// MinSize is the minimum size (in bytes) required to enable compression.
 	MinSize int
-	// Algorithm used for the compression (currently Brotli and Zstandard)
+	// ContentTypeMinSizes are the minimum sizes (in bytes) required to enable compression, by media type.
+	// They take precedence over MinSize.
+	ContentTypeMinSizes map[string]int
+	// Algorithm used for the compression (currently Brotli, Zstandard and Gzip)
 	Algorithm string
+	// Level is the compression level, zero means the default level of the algorithm.
+	Level int
 	// MiddlewareName use for logging purposes
 	MiddlewareName string
 }
 
-// CompressionHandler handles Brolti and Zstd compression.
+// CompressionHandler handles Brotli, Zstd and Gzip compression.
 type CompressionHandler struct {
 	cfg                  Config
 	excludedContentTypes []parsedContentType
@@ -92,7 +98,7 @@ func NewCompressionHandler(cfg Config, next http.Handler) (http.Handler, error)
 func (c *CompressionHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	rw.Header().Add(vary, acceptEncoding)
 
-	compressionWriter, err := newCompressionWriter(c.cfg.Algorithm, rw)
+	compressionWriter, err := newCompressionWriter(c.cfg.Algorithm, c.cfg.Level, rw)
 	if err != nil {
 		logger := middlewares.GetLogger(r.Context(), c.cfg.MiddlewareName, typeName)
 		logger.Debug().Msgf("Create compression handler: %v", err)
@@ -105,6 +111,7 @@ func (c *CompressionHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request)
 		rw:                   rw,
 		compressionWriter:    compressionWriter,
 		minSize:              c.cfg.MinSize,
+		minSizes:             c.cfg.ContentTypeMinSizes,
 		statusCode:           http.StatusOK,
 		excludedContentTypes: c.excludedContentTypes,
 		includedContentTypes: c.includedContentTypes,
@@ -137,18 +144,37 @@ type compressionWriter struct {
 	alg string
 }
 
-func newCompressionWriter(algo string, in io.Writer) (*compressionWriter, error) {
+func newCompressionWriter(algo string, level int, in io.Writer) (*compressionWriter, error) {
 	switch algo {
 	case brotliName:
-		return &compressionWriter{compression: brotli.NewWriter(in), alg: algo}, nil
+		if level == 0 {
+			level = brotli.DefaultCompression
+		}
+		return &compressionWriter{compression: brotli.NewWriterLevel(in, level), alg: algo}, nil
 
 	case zstdName:
-		writer, err := zstd.NewWriter(in)
+		var opts []zstd.EOption
+		if level > 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}
+
+		writer, err := zstd.NewWriter(in, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("creating zstd writer: %w", err)
 		}
 		return &compressionWriter{compression: writer, alg: algo}, nil
 
+	case gzipName:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+
+		writer, err := gzip.NewWriterLevel(in, level)
+		if err != nil {
+			return nil, fmt.Errorf("creating gzip writer: %w", err)
+		}
+		return &compressionWriter{compression: writer, alg: algo}, nil
+
 	default:
 		return nil, fmt.Errorf("unknown compression algo: %s", algo)
 	}
@@ -165,6 +191,7 @@ type responseWriter struct {
 	compressionWriter *compressionWriter
 
 	minSize              int
+	minSizes             map[string]int
 	excludedContentTypes []parsedContentType
 	includedContentTypes []parsedContentType
 
@@ -227,6 +254,13 @@ func (r *responseWriter) Write(p []byte) (int, error) {
 			return 0, fmt.Errorf("parsing content-type media type: %w", err)
 		}
 
+		// The Server-Sent Events streams are never compressed, for the events to reach the client as soon as they are written.
+		if mediaType == middlewares.EventStreamMediaType {
+			r.compressionDisabled = true
+			r.rw.WriteHeader(r.statusCode)
+			return r.rw.Write(p)
+		}
+
 		if len(r.includedContentTypes) > 0 {
 			var found bool
 			for _, includedContentType := range r.includedContentTypes {
@@ -249,6 +283,10 @@ func (r *responseWriter) Write(p []byte) (int, error) {
 				return r.rw.Write(p)
 			}
 		}
+
+		if minSize, ok := r.minSizes[mediaType]; ok {
+			r.minSize = minSize
+		}
 	}
 
 	// We buffer until we know whether to compress (i.e. when we reach minSize received).
@@ -0,0 +1,225 @@
+package concurrencyquota
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/metrics"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/vulcand/oxy/v2/utils"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "ConcurrencyQuota"
+
+const defaultMaxTenants = 10000
+
+// otherTenantsLabel is the tenant label of the metrics of the tenants which are not configured.
+const otherTenantsLabel = "other"
+
+// Results of the requests, as reported by the requests metric.
+const (
+	resultServed   = "served"
+	resultRejected = "rejected"
+)
+
+// tenant holds the in-flight requests of a tenant.
+type tenant struct {
+	key      string
+	amount   int64
+	label    string
+	inFlight int64
+}
+
+// concurrencyQuota is a middleware limiting the number of in-flight requests of each tenant.
+// The tenants are tracked in an LRU list, from which the least recently used idle tenants are evicted.
+type concurrencyQuota struct {
+	next       http.Handler
+	name       string
+	extractor  utils.SourceExtractor
+	amount     int64
+	tenants    map[string]int64
+	maxTenants int
+
+	mu      sync.Mutex
+	tracked map[string]*list.Element
+	lru     *list.List
+
+	inFlightGauge gokitmetrics.Gauge
+	reqsCounter   gokitmetrics.Counter
+}
+
+// New creates a ConcurrencyQuota middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.ConcurrencyQuota, name string, registry metrics.Registry) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeName)
+	logger.Debug().Msg("Creating middleware")
+
+	if config.Amount <= 0 {
+		return nil, fmt.Errorf("invalid amount %d, must be positive", config.Amount)
+	}
+
+	if config.MaxTenants < 0 {
+		return nil, fmt.Errorf("invalid maxTenants %d, must be positive", config.MaxTenants)
+	}
+
+	extractor, err := middlewares.GetSourceExtractor(logger.WithContext(ctx), config.SourceCriterion)
+	if err != nil {
+		return nil, fmt.Errorf("creating source extractor: %w", err)
+	}
+
+	q := &concurrencyQuota{
+		next:       next,
+		name:       name,
+		extractor:  extractor,
+		amount:     config.Amount,
+		tenants:    make(map[string]int64),
+		maxTenants: config.MaxTenants,
+		tracked:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+
+	if q.maxTenants == 0 {
+		q.maxTenants = defaultMaxTenants
+	}
+
+	for _, t := range config.Tenants {
+		if t.Amount <= 0 {
+			return nil, fmt.Errorf("invalid amount %d of tenant %q, must be positive", t.Amount, t.Name)
+		}
+
+		if _, ok := q.tenants[t.Name]; ok {
+			return nil, fmt.Errorf("duplicate tenant %q", t.Name)
+		}
+
+		q.tenants[t.Name] = t.Amount
+	}
+
+	if registry != nil {
+		q.inFlightGauge = registry.ConcurrencyQuotaInFlightGauge()
+		q.reqsCounter = registry.ConcurrencyQuotaReqsCounter()
+	}
+
+	return q, nil
+}
+
+func (q *concurrencyQuota) GetTracingInformation() (string, string, trace.SpanKind) {
+	return q.name, typeName, trace.SpanKindInternal
+}
+
+func (q *concurrencyQuota) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), q.name, typeName)
+
+	key, _, err := q.extractor.Extract(req)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to extract the tenant of the request")
+		utils.DefaultHandler.ServeHTTP(rw, req, err)
+		return
+	}
+
+	t, msg := q.acquire(key)
+	if msg != "" {
+		logger.Debug().Msgf("Request rejected: %s", msg)
+		q.count(q.label(key), resultRejected)
+		rw.WriteHeader(http.StatusTooManyRequests)
+		_, _ = rw.Write([]byte(msg))
+		return
+	}
+
+	q.count(t.label, resultServed)
+	q.setInFlight(t.label, 1)
+
+	defer func() {
+		q.release(t)
+		q.setInFlight(t.label, -1)
+	}()
+
+	q.next.ServeHTTP(rw, req)
+}
+
+// acquire takes an in-flight slot of the tenant with the given key,
+// or returns the reason why the request is rejected.
+func (q *concurrencyQuota) acquire(key string) (*tenant, string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	elt, ok := q.tracked[key]
+	if ok {
+		q.lru.MoveToFront(elt)
+	} else {
+		if q.lru.Len() >= q.maxTenants && !q.evict() {
+			return nil, "max tracked tenants reached"
+		}
+
+		amount, ok := q.tenants[key]
+		if !ok {
+			amount = q.amount
+		}
+
+		elt = q.lru.PushFront(&tenant{key: key, amount: amount, label: q.label(key)})
+		q.tracked[key] = elt
+	}
+
+	t := elt.Value.(*tenant)
+	if t.inFlight >= t.amount {
+		return nil, "max in-flight requests reached"
+	}
+
+	t.inFlight++
+
+	return t, ""
+}
+
+// evict forgets the least recently used tenant without in-flight requests, if any.
+// It must be called with the lock held.
+func (q *concurrencyQuota) evict() bool {
+	for elt := q.lru.Back(); elt != nil; elt = elt.Prev() {
+		t := elt.Value.(*tenant)
+		if t.inFlight > 0 {
+			continue
+		}
+
+		q.lru.Remove(elt)
+		delete(q.tracked, t.key)
+
+		return true
+	}
+
+	return false
+}
+
+// release frees the in-flight slot of the given tenant.
+// The tenant cannot have been evicted, as it had an in-flight request.
+func (q *concurrencyQuota) release(t *tenant) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	t.inFlight--
+}
+
+// label returns the tenant label of the metrics of the tenant with the given key.
+// Only the configured tenants are reported by their key,
+// to bound the cardinality of the metrics, and not to expose the keys such as API keys.
+func (q *concurrencyQuota) label(key string) string {
+	if _, ok := q.tenants[key]; ok {
+		return key
+	}
+
+	return otherTenantsLabel
+}
+
+func (q *concurrencyQuota) setInFlight(label string, delta float64) {
+	if q.inFlightGauge != nil {
+		q.inFlightGauge.With("middleware", q.name, "tenant", label).Add(delta)
+	}
+}
+
+func (q *concurrencyQuota) count(label, result string) {
+	if q.reqsCounter != nil {
+		q.reqsCounter.With("middleware", q.name, "tenant", label, "result", result).Add(1)
+	}
+}
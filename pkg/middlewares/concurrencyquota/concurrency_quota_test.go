@@ -0,0 +1,174 @@
+package concurrencyquota
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.ConcurrencyQuota
+	}{
+		{
+			desc:   "no amount",
+			config: dynamic.ConcurrencyQuota{},
+		},
+		{
+			desc:   "negative max tenants",
+			config: dynamic.ConcurrencyQuota{Amount: 1, MaxTenants: -1},
+		},
+		{
+			desc: "no tenant amount",
+			config: dynamic.ConcurrencyQuota{
+				Amount:  1,
+				Tenants: []dynamic.ConcurrencyQuotaTenant{{Name: "acme"}},
+			},
+		},
+		{
+			desc: "duplicate tenant",
+			config: dynamic.ConcurrencyQuota{
+				Amount:  1,
+				Tenants: []dynamic.ConcurrencyQuotaTenant{{Name: "acme", Amount: 1}, {Name: "acme", Amount: 2}},
+			},
+		},
+		{
+			desc: "invalid source criterion",
+			config: dynamic.ConcurrencyQuota{
+				Amount:          1,
+				SourceCriterion: &dynamic.SourceCriterion{RequestHeaderName: "X-Tenant", RequestHost: true},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "concurrencyQuota", nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestConcurrencyQuota(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan string, 10)
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		started <- req.Header.Get("X-Tenant")
+		<-release
+	})
+
+	config := dynamic.ConcurrencyQuota{
+		Amount:          1,
+		SourceCriterion: &dynamic.SourceCriterion{Expression: "Header(`X-Tenant`)"},
+		Tenants:         []dynamic.ConcurrencyQuotaTenant{{Name: "acme", Amount: 2}},
+	}
+
+	handler, err := New(context.Background(), next, config, "concurrencyQuota", nil)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	serve := func(tenant string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, newRequest(tenant))
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		}()
+
+		assert.Equal(t, tenant, <-started)
+	}
+
+	serve("foo")
+	serve("acme")
+	serve("acme")
+
+	// The tenants having reached their amount are rejected.
+	for _, tenant := range []string{"foo", "acme"} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newRequest(tenant))
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	}
+
+	// The other tenants are not affected.
+	serve("bar")
+
+	for range 4 {
+		release <- struct{}{}
+	}
+
+	wg.Wait()
+
+	// The released slots can be taken again.
+	serve("foo")
+	release <- struct{}{}
+
+	wg.Wait()
+}
+
+func TestConcurrencyQuota_maxTenants(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 10)
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Tenant") != "foo" {
+			return
+		}
+
+		started <- struct{}{}
+		<-release
+	})
+
+	config := dynamic.ConcurrencyQuota{
+		Amount:          1,
+		SourceCriterion: &dynamic.SourceCriterion{RequestHeaderName: "X-Tenant"},
+		MaxTenants:      1,
+	}
+
+	handler, err := New(context.Background(), next, config, "concurrencyQuota", nil)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		handler.ServeHTTP(httptest.NewRecorder(), newRequest("foo"))
+	}()
+
+	<-started
+
+	// The only tracked tenant has an in-flight request, and cannot be evicted.
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newRequest("bar"))
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+
+	release <- struct{}{}
+	<-done
+
+	// The idle tenant is evicted for the new one.
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newRequest("bar"))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	q := handler.(*concurrencyQuota)
+	assert.Len(t, q.tracked, 1)
+	assert.Contains(t, q.tracked, "bar")
+}
+
+func newRequest(tenant string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "http://foo.com/", nil)
+	req.Header.Set("X-Tenant", tenant)
+
+	return req
+}
@@ -0,0 +1,248 @@
+package cookies
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "Cookies"
+
+// cookies is a middleware modifying the cookies of the requests and of the responses.
+type cookies struct {
+	next     http.Handler
+	name     string
+	request  dynamic.CookieModifications
+	response dynamic.CookieModifications
+	secure   bool
+	httpOnly bool
+	sameSite http.SameSite
+
+	// aead encrypts the values of the encrypted cookies, it is nil when no cookie is encrypted.
+	aead      cipher.AEAD
+	encrypted []string
+}
+
+// New creates a Cookies middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.Cookies, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	c := &cookies{
+		next:     next,
+		name:     name,
+		secure:   config.Secure,
+		httpOnly: config.HTTPOnly,
+	}
+
+	if config.Request != nil {
+		c.request = *config.Request
+	}
+
+	if config.Response != nil {
+		c.response = *config.Response
+	}
+
+	switch strings.ToLower(config.SameSite) {
+	case "":
+	case "none":
+		c.sameSite = http.SameSiteNoneMode
+	case "lax":
+		c.sameSite = http.SameSiteLaxMode
+	case "strict":
+		c.sameSite = http.SameSiteStrictMode
+	default:
+		return nil, fmt.Errorf("invalid sameSite %q: must be none, lax, or strict", config.SameSite)
+	}
+
+	if config.Encryption != nil && len(config.Encryption.Cookies) > 0 {
+		if config.Encryption.Secret == "" {
+			return nil, errors.New("encryption secret must be defined")
+		}
+
+		// The secret is hashed into an AES-256 key, so that it can be of any length.
+		key := sha256.Sum256([]byte(config.Encryption.Secret))
+
+		block, err := aes.NewCipher(key[:])
+		if err != nil {
+			return nil, err
+		}
+
+		c.aead, err = cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+
+		c.encrypted = config.Encryption.Cookies
+	}
+
+	return c, nil
+}
+
+func (c *cookies) GetTracingInformation() (string, string, trace.SpanKind) {
+	return c.name, typeName, trace.SpanKindInternal
+}
+
+func (c *cookies) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	c.modifyRequest(req)
+
+	c.next.ServeHTTP(middlewares.NewResponseModifier(rw, req, c.modifyResponse), req)
+}
+
+// modifyRequest decrypts, renames, removes, and sets the request cookies.
+func (c *cookies) modifyRequest(req *http.Request) {
+	if len(c.encrypted) == 0 && len(c.request.Remove) == 0 && len(c.request.Rename) == 0 && len(c.request.Set) == 0 {
+		return
+	}
+
+	var values []*http.Cookie
+	for _, cookie := range req.Cookies() {
+		if slices.Contains(c.encrypted, cookie.Name) {
+			value, err := c.decrypt(cookie.Name, cookie.Value)
+			if err != nil {
+				// The cookies which were not encrypted by the middleware are not forwarded.
+				middlewares.GetLogger(req.Context(), c.name, typeName).Debug().Err(err).Msgf("Removing invalid encrypted cookie %q", cookie.Name)
+				continue
+			}
+
+			cookie.Value = value
+		}
+
+		if newName, ok := c.request.Rename[cookie.Name]; ok {
+			cookie.Name = newName
+		}
+
+		if slices.Contains(c.request.Remove, cookie.Name) {
+			continue
+		}
+
+		if _, ok := c.request.Set[cookie.Name]; ok {
+			continue
+		}
+
+		values = append(values, cookie)
+	}
+
+	for name, value := range c.request.Set {
+		values = append(values, &http.Cookie{Name: name, Value: value})
+	}
+
+	req.Header.Del("Cookie")
+	for _, cookie := range values {
+		req.AddCookie(cookie)
+	}
+}
+
+// modifyResponse removes, renames, sets, encrypts, and enforces the attributes of the response cookies.
+func (c *cookies) modifyResponse(res *http.Response) error {
+	var setCookies []string
+	for _, line := range res.Header.Values("Set-Cookie") {
+		cookie, err := http.ParseSetCookie(line)
+		if err != nil {
+			// The invalid cookies are forwarded unchanged, as the clients ignore them.
+			setCookies = append(setCookies, line)
+			continue
+		}
+
+		if slices.Contains(c.response.Remove, cookie.Name) {
+			continue
+		}
+
+		if _, ok := c.response.Set[cookie.Name]; ok {
+			continue
+		}
+
+		modified := false
+		if newName, ok := c.response.Rename[cookie.Name]; ok {
+			cookie.Name = newName
+			modified = true
+		}
+
+		if c.finalize(cookie) || modified {
+			line = cookie.String()
+		}
+
+		setCookies = append(setCookies, line)
+	}
+
+	for name, value := range c.response.Set {
+		cookie := &http.Cookie{Name: name, Value: value, Path: "/"}
+		c.finalize(cookie)
+
+		setCookies = append(setCookies, cookie.String())
+	}
+
+	if len(setCookies) == 0 {
+		res.Header.Del("Set-Cookie")
+		return nil
+	}
+
+	res.Header["Set-Cookie"] = setCookies
+	return nil
+}
+
+// finalize encrypts the cookie value if needed, enforces the cookie attributes, and returns whether the cookie was modified.
+func (c *cookies) finalize(cookie *http.Cookie) bool {
+	var modified bool
+
+	// The cookies removed from the clients are not encrypted.
+	if slices.Contains(c.encrypted, cookie.Name) && cookie.MaxAge >= 0 && cookie.Value != "" {
+		cookie.Value = c.encrypt(cookie.Name, cookie.Value)
+		modified = true
+	}
+
+	if c.secure && !cookie.Secure {
+		cookie.Secure = true
+		modified = true
+	}
+
+	if c.httpOnly && !cookie.HttpOnly {
+		cookie.HttpOnly = true
+		modified = true
+	}
+
+	if c.sameSite != 0 && cookie.SameSite != c.sameSite {
+		cookie.SameSite = c.sameSite
+		modified = true
+	}
+
+	return modified
+}
+
+func (c *cookies) encrypt(name, value string) string {
+	nonce := make([]byte, c.aead.NonceSize())
+	// The random source of the crypto/rand package never fails.
+	_, _ = rand.Read(nonce)
+
+	// The cookie name is authenticated, so that a value cannot be replayed in another cookie.
+	return base64.RawURLEncoding.EncodeToString(c.aead.Seal(nonce, nonce, []byte(value), []byte(name)))
+}
+
+func (c *cookies) decrypt(name, value string) (string, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < c.aead.NonceSize() {
+		return "", errors.New("invalid cookie value")
+	}
+
+	data, err := c.aead.Open(nil, sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():], []byte(name))
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
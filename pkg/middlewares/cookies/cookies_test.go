@@ -0,0 +1,220 @@
+package cookies
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.Cookies
+	}{
+		{
+			desc:   "invalid same site",
+			config: dynamic.Cookies{SameSite: "foo"},
+		},
+		{
+			desc:   "encryption without secret",
+			config: dynamic.Cookies{Encryption: &dynamic.CookieEncryption{Cookies: []string{"session"}}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "cookies")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCookies_request(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		config   dynamic.CookieModifications
+		cookie   string
+		expected string
+	}{
+		{
+			desc:     "remove",
+			config:   dynamic.CookieModifications{Remove: []string{"tracking"}},
+			cookie:   "session=foo; tracking=bar",
+			expected: "session=foo",
+		},
+		{
+			desc:     "rename",
+			config:   dynamic.CookieModifications{Rename: map[string]string{"sid": "session"}},
+			cookie:   "sid=foo; lang=en",
+			expected: "session=foo; lang=en",
+		},
+		{
+			desc:     "set",
+			config:   dynamic.CookieModifications{Set: map[string]string{"lang": "fr"}},
+			cookie:   "session=foo; lang=en",
+			expected: "session=foo; lang=fr",
+		},
+		{
+			desc:     "set without cookies",
+			config:   dynamic.CookieModifications{Set: map[string]string{"lang": "fr"}},
+			expected: "lang=fr",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwarded *http.Request
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwarded = req
+			})
+
+			handler, err := New(context.Background(), next, dynamic.Cookies{Request: &test.config}, "cookies")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+			if test.cookie != "" {
+				req.Header.Set("Cookie", test.cookie)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.NotNil(t, forwarded)
+			assert.Equal(t, test.expected, forwarded.Header.Get("Cookie"))
+		})
+	}
+}
+
+func TestCookies_response(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		config     dynamic.Cookies
+		setCookies []string
+		expected   []string
+	}{
+		{
+			desc:       "unchanged",
+			config:     dynamic.Cookies{Response: &dynamic.CookieModifications{Remove: []string{"tracking"}}},
+			setCookies: []string{"session=foo; Path=/; Priority=High"},
+			expected:   []string{"session=foo; Path=/; Priority=High"},
+		},
+		{
+			desc:       "remove",
+			config:     dynamic.Cookies{Response: &dynamic.CookieModifications{Remove: []string{"tracking"}}},
+			setCookies: []string{"session=foo", "tracking=bar"},
+			expected:   []string{"session=foo"},
+		},
+		{
+			desc:       "rename",
+			config:     dynamic.Cookies{Response: &dynamic.CookieModifications{Rename: map[string]string{"JSESSIONID": "session"}}},
+			setCookies: []string{"JSESSIONID=foo; Path=/app"},
+			expected:   []string{"session=foo; Path=/app"},
+		},
+		{
+			desc:       "set",
+			config:     dynamic.Cookies{Response: &dynamic.CookieModifications{Set: map[string]string{"lang": "fr"}}},
+			setCookies: []string{"lang=en", "session=foo"},
+			expected:   []string{"session=foo", "lang=fr; Path=/"},
+		},
+		{
+			desc: "enforced attributes",
+			config: dynamic.Cookies{
+				Secure:   true,
+				HTTPOnly: true,
+				SameSite: "strict",
+			},
+			setCookies: []string{"session=foo; Path=/; SameSite=None", "lang=en; Secure; HttpOnly; SameSite=Strict"},
+			expected:   []string{"session=foo; Path=/; HttpOnly; Secure; SameSite=Strict", "lang=en; Secure; HttpOnly; SameSite=Strict"},
+		},
+		{
+			desc:       "invalid cookie",
+			config:     dynamic.Cookies{Secure: true},
+			setCookies: []string{"invalid"},
+			expected:   []string{"invalid"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				for _, setCookie := range test.setCookies {
+					rw.Header().Add("Set-Cookie", setCookie)
+				}
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			handler, err := New(context.Background(), next, test.config, "cookies")
+			require.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+
+			assert.Equal(t, test.expected, rw.Header().Values("Set-Cookie"))
+		})
+	}
+}
+
+func TestCookies_encryption(t *testing.T) {
+	var forwardedCookie string
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwardedCookie = req.Header.Get("Cookie")
+		http.SetCookie(rw, &http.Cookie{Name: "session", Value: "user=alice", Path: "/"})
+		http.SetCookie(rw, &http.Cookie{Name: "lang", Value: "en"})
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	handler, err := New(context.Background(), next, dynamic.Cookies{
+		Encryption: &dynamic.CookieEncryption{Secret: "secret", Cookies: []string{"session"}},
+	}, "cookies")
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+
+	var session *http.Cookie
+	for _, cookie := range rw.Result().Cookies() {
+		switch cookie.Name {
+		case "session":
+			session = cookie
+		case "lang":
+			assert.Equal(t, "en", cookie.Value)
+		}
+	}
+
+	require.NotNil(t, session)
+	assert.NotContains(t, session.Value, "alice")
+
+	// The encrypted cookie is decrypted before being forwarded.
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: session.Value})
+	req.AddCookie(&http.Cookie{Name: "lang", Value: "en"})
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "session=user=alice; lang=en", forwardedCookie)
+
+	// The encrypted value cannot be moved to another encrypted cookie, nor be forged.
+	handler, err = New(context.Background(), next, dynamic.Cookies{
+		Encryption: &dynamic.CookieEncryption{Secret: "secret", Cookies: []string{"session", "admin"}},
+	}, "cookies")
+	require.NoError(t, err)
+
+	req = httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	req.AddCookie(&http.Cookie{Name: "admin", Value: session.Value})
+	req.AddCookie(&http.Cookie{Name: "session", Value: "user=mallory"})
+	req.AddCookie(&http.Cookie{Name: "lang", Value: "en"})
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "lang=en", forwardedCookie)
+}
@@ -0,0 +1,306 @@
+package cors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/patrickmn/go-cache"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/metrics"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "CORS"
+
+const (
+	// preflightCacheTTL is the duration during which a preflight decision is cached.
+	preflightCacheTTL = 10 * time.Minute
+	// maxPreflightCacheSize bounds the number of cached preflight decisions,
+	// as the cache keys are built from the request headers.
+	maxPreflightCacheSize = 10000
+)
+
+// Request types and results reported by the metrics.
+const (
+	typePreflight = "preflight"
+	typeRequest   = "request"
+
+	resultAllowed  = "allowed"
+	resultRejected = "rejected"
+)
+
+var defaultAllowMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+
+// cors is a middleware answering the CORS preflight requests, and adding the CORS headers to the responses.
+type cors struct {
+	next               http.Handler
+	name               string
+	allowOrigins       []string
+	allowOriginRegexes []*regexp.Regexp
+	allowMethods       []string
+	allowHeaders       []string
+	exposeHeaders      string
+	allowCredentials   bool
+	maxAge             string
+
+	preflights  *cache.Cache
+	reqsCounter gokitmetrics.Counter
+}
+
+// preflightDecision is the outcome of a preflight request, cached by origin, requested method, and requested headers.
+type preflightDecision struct {
+	// match is the allowed origin entry, or regex, matching the request origin, empty if the origin is not allowed.
+	match        string
+	allowOrigin  string
+	allowMethods string
+	allowHeaders string
+	reason       string
+}
+
+// New creates a CORS middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.CORS, name string, registry metrics.Registry) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if len(config.AllowOriginList) == 0 && len(config.AllowOriginListRegex) == 0 {
+		return nil, errors.New("at least one allowed origin must be defined")
+	}
+
+	if config.AllowCredentials && slices.Contains(config.AllowOriginList, "*") {
+		return nil, errors.New("wildcard origin cannot be allowed with credentials")
+	}
+
+	c := &cors{
+		next:             next,
+		name:             name,
+		allowOrigins:     config.AllowOriginList,
+		allowMethods:     config.AllowMethods,
+		exposeHeaders:    strings.Join(config.ExposeHeaders, ","),
+		allowCredentials: config.AllowCredentials,
+		preflights:       cache.New(preflightCacheTTL, preflightCacheTTL),
+	}
+
+	for _, str := range config.AllowOriginListRegex {
+		regex, err := regexp.Compile(str)
+		if err != nil {
+			return nil, fmt.Errorf("compiling origin regex %q: %w", str, err)
+		}
+
+		c.allowOriginRegexes = append(c.allowOriginRegexes, regex)
+	}
+
+	if len(c.allowMethods) == 0 {
+		c.allowMethods = defaultAllowMethods
+	}
+
+	for _, header := range config.AllowHeaders {
+		c.allowHeaders = append(c.allowHeaders, http.CanonicalHeaderKey(strings.TrimSpace(header)))
+	}
+
+	if config.MaxAge != 0 {
+		c.maxAge = strconv.FormatInt(config.MaxAge, 10)
+	}
+
+	if registry != nil {
+		c.reqsCounter = registry.CORSReqsCounter()
+	}
+
+	return c, nil
+}
+
+func (c *cors) GetTracingInformation() (string, string, trace.SpanKind) {
+	return c.name, typeName, trace.SpanKindInternal
+}
+
+func (c *cors) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		// The responses also vary when the requests have no origin, as they could be served from a cache to the cross-origin requests.
+		c.next.ServeHTTP(middlewares.NewResponseModifier(rw, req, addVaryOrigin), req)
+		return
+	}
+
+	requestMethod := req.Header.Get("Access-Control-Request-Method")
+	if req.Method == http.MethodOptions && requestMethod != "" {
+		c.servePreflight(rw, req, origin, requestMethod)
+		return
+	}
+
+	match, allowed := c.matchOrigin(origin)
+	if !allowed {
+		c.count(match, typeRequest, resultRejected)
+		c.next.ServeHTTP(middlewares.NewResponseModifier(rw, req, addVaryOrigin), req)
+		return
+	}
+
+	c.count(match, typeRequest, resultAllowed)
+
+	c.next.ServeHTTP(middlewares.NewResponseModifier(rw, req, func(res *http.Response) error {
+		res.Header.Set("Access-Control-Allow-Origin", allowOrigin(origin, match))
+
+		if c.allowCredentials {
+			res.Header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if c.exposeHeaders != "" {
+			res.Header.Set("Access-Control-Expose-Headers", c.exposeHeaders)
+		}
+
+		return addVaryOrigin(res)
+	}), req)
+}
+
+// servePreflight answers the preflight request, without forwarding it to the service.
+func (c *cors) servePreflight(rw http.ResponseWriter, req *http.Request, origin, requestMethod string) {
+	decision := c.preflight(origin, requestMethod, req.Header.Get("Access-Control-Request-Headers"))
+
+	rw.Header().Add("Vary", "Origin, Access-Control-Request-Method, Access-Control-Request-Headers")
+
+	if decision.reason != "" {
+		c.count(decision.match, typePreflight, resultRejected)
+
+		middlewares.GetLogger(req.Context(), c.name, typeName).Debug().Msgf("Rejecting preflight request from origin %s: %s", origin, decision.reason)
+		observability.SetStatusErrorf(req.Context(), "Rejecting CORS preflight request: %s", decision.reason)
+
+		rw.WriteHeader(http.StatusForbidden)
+		if _, err := rw.Write([]byte(http.StatusText(http.StatusForbidden))); err != nil {
+			log.Ctx(req.Context()).Error().Err(err).Send()
+		}
+		return
+	}
+
+	c.count(decision.match, typePreflight, resultAllowed)
+
+	rw.Header().Set("Access-Control-Allow-Origin", decision.allowOrigin)
+	rw.Header().Set("Access-Control-Allow-Methods", decision.allowMethods)
+
+	if decision.allowHeaders != "" {
+		rw.Header().Set("Access-Control-Allow-Headers", decision.allowHeaders)
+	}
+
+	if c.allowCredentials {
+		rw.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	if c.maxAge != "" {
+		rw.Header().Set("Access-Control-Max-Age", c.maxAge)
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// preflight returns the decision for a preflight request, from the cache if possible.
+func (c *cors) preflight(origin, requestMethod, requestHeaders string) preflightDecision {
+	key := origin + "\n" + requestMethod + "\n" + requestHeaders
+	if decision, ok := c.preflights.Get(key); ok {
+		return decision.(preflightDecision)
+	}
+
+	decision := c.decidePreflight(origin, requestMethod, requestHeaders)
+
+	if c.preflights.ItemCount() < maxPreflightCacheSize {
+		c.preflights.SetDefault(key, decision)
+	}
+
+	return decision
+}
+
+func (c *cors) decidePreflight(origin, requestMethod, requestHeaders string) preflightDecision {
+	match, allowed := c.matchOrigin(origin)
+	if !allowed {
+		return preflightDecision{reason: "origin not allowed"}
+	}
+
+	decision := preflightDecision{
+		match:        match,
+		allowOrigin:  allowOrigin(origin, match),
+		allowMethods: strings.Join(c.allowMethods, ","),
+		allowHeaders: strings.Join(c.allowHeaders, ","),
+	}
+
+	// The wildcard is echoed, as it is not supported by the clients for the requests with credentials.
+	if slices.Contains(c.allowMethods, "*") {
+		decision.allowMethods = requestMethod
+	} else if !slices.Contains(c.allowMethods, requestMethod) {
+		decision.reason = fmt.Sprintf("method %s not allowed", requestMethod)
+		return decision
+	}
+
+	if slices.Contains(c.allowHeaders, "*") {
+		decision.allowHeaders = requestHeaders
+		return decision
+	}
+
+	for _, header := range strings.Split(requestHeaders, ",") {
+		header = http.CanonicalHeaderKey(strings.TrimSpace(header))
+		if header != "" && !slices.Contains(c.allowHeaders, header) {
+			decision.reason = fmt.Sprintf("header %s not allowed", header)
+			return decision
+		}
+	}
+
+	return decision
+}
+
+// matchOrigin returns the allowed origin entry, or the source of the allowed origin regex, matching the given origin,
+// and whether the origin is allowed.
+func (c *cors) matchOrigin(origin string) (string, bool) {
+	for _, item := range c.allowOrigins {
+		if item == "*" || item == origin {
+			return item, true
+		}
+	}
+
+	for _, regex := range c.allowOriginRegexes {
+		if regex.MatchString(origin) {
+			return regex.String(), true
+		}
+	}
+
+	return "", false
+}
+
+// allowOrigin returns the Access-Control-Allow-Origin value for the given origin.
+func allowOrigin(origin, match string) string {
+	if match == "*" {
+		return "*"
+	}
+
+	return origin
+}
+
+// count reports a CORS request to the metrics.
+// The origin label is the matching allowed origin entry, or regex,
+// so that the origins, chosen by the clients, do not increase the metrics cardinality.
+func (c *cors) count(match, requestType, result string) {
+	if c.reqsCounter == nil {
+		return
+	}
+
+	c.reqsCounter.With("middleware", c.name, "origin", match, "type", requestType, "result", result).Add(1)
+}
+
+// addVaryOrigin adds Origin to the Vary header of the response,
+// as the CORS headers of the response depend on the request origin.
+func addVaryOrigin(res *http.Response) error {
+	for _, value := range res.Header.Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), "Origin") {
+				return nil
+			}
+		}
+	}
+
+	res.Header.Add("Vary", "Origin")
+	return nil
+}
@@ -0,0 +1,299 @@
+package cors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/metrics"
+	"github.com/traefik/traefik/v3/pkg/testhelpers"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.CORS
+	}{
+		{
+			desc:   "no allowed origin",
+			config: dynamic.CORS{AllowMethods: []string{http.MethodGet}},
+		},
+		{
+			desc:   "wildcard origin with credentials",
+			config: dynamic.CORS{AllowOriginList: []string{"*"}, AllowCredentials: true},
+		},
+		{
+			desc:   "invalid origin regex",
+			config: dynamic.CORS{AllowOriginListRegex: []string{"(foo"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "cors", nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCORS_preflight(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		config          dynamic.CORS
+		origin          string
+		method          string
+		headers         string
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			desc:           "allowed origin",
+			config:         dynamic.CORS{AllowOriginList: []string{"https://foo.com"}, MaxAge: 600},
+			origin:         "https://foo.com",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "https://foo.com",
+				"Access-Control-Allow-Methods": "GET,HEAD,POST",
+				"Access-Control-Max-Age":       "600",
+			},
+		},
+		{
+			desc:           "wildcard origin",
+			config:         dynamic.CORS{AllowOriginList: []string{"*"}, AllowMethods: []string{"PUT"}},
+			origin:         "https://foo.com",
+			method:         http.MethodPut,
+			expectedStatus: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Methods": "PUT",
+			},
+		},
+		{
+			desc: "regex origin with credentials",
+			config: dynamic.CORS{
+				AllowOriginListRegex: []string{`^https://[a-z]+\.foo\.com$`},
+				AllowHeaders:         []string{"x-custom", "Content-Type"},
+				AllowCredentials:     true,
+			},
+			origin:         "https://bar.foo.com",
+			method:         http.MethodPost,
+			headers:        "content-type, X-Custom",
+			expectedStatus: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://bar.foo.com",
+				"Access-Control-Allow-Headers":     "X-Custom,Content-Type",
+				"Access-Control-Allow-Credentials": "true",
+			},
+		},
+		{
+			desc: "wildcard method and headers",
+			config: dynamic.CORS{
+				AllowOriginList: []string{"https://foo.com"},
+				AllowMethods:    []string{"*"},
+				AllowHeaders:    []string{"*"},
+			},
+			origin:         "https://foo.com",
+			method:         http.MethodDelete,
+			headers:        "authorization",
+			expectedStatus: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Methods": "DELETE",
+				"Access-Control-Allow-Headers": "authorization",
+			},
+		},
+		{
+			desc:           "origin not allowed",
+			config:         dynamic.CORS{AllowOriginListRegex: []string{`^https://[a-z]+\.foo\.com$`}},
+			origin:         "https://foo.com.evil.com",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusForbidden,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+			},
+		},
+		{
+			desc:           "method not allowed",
+			config:         dynamic.CORS{AllowOriginList: []string{"https://foo.com"}},
+			origin:         "https://foo.com",
+			method:         http.MethodDelete,
+			expectedStatus: http.StatusForbidden,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+			},
+		},
+		{
+			desc:           "header not allowed",
+			config:         dynamic.CORS{AllowOriginList: []string{"https://foo.com"}, AllowHeaders: []string{"X-Custom"}},
+			origin:         "https://foo.com",
+			method:         http.MethodGet,
+			headers:        "X-Custom,Authorization",
+			expectedStatus: http.StatusForbidden,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				t.Error("preflight request forwarded to the service")
+			})
+
+			handler, err := New(context.Background(), next, test.config, "cors", nil)
+			require.NoError(t, err)
+
+			// The second request is answered from the cached decision.
+			for range 2 {
+				req := httptest.NewRequest(http.MethodOptions, "http://localhost", nil)
+				req.Header.Set("Origin", test.origin)
+				req.Header.Set("Access-Control-Request-Method", test.method)
+				if test.headers != "" {
+					req.Header.Set("Access-Control-Request-Headers", test.headers)
+				}
+
+				rw := httptest.NewRecorder()
+				handler.ServeHTTP(rw, req)
+
+				assert.Equal(t, test.expectedStatus, rw.Code)
+				assert.Equal(t, "Origin, Access-Control-Request-Method, Access-Control-Request-Headers", rw.Header().Get("Vary"))
+				for name, value := range test.expectedHeaders {
+					assert.Equal(t, value, rw.Header().Get(name), name)
+				}
+			}
+		})
+	}
+}
+
+func TestCORS_request(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		config          dynamic.CORS
+		method          string
+		origin          string
+		expectedHeaders map[string]string
+	}{
+		{
+			desc:   "without origin",
+			config: dynamic.CORS{AllowOriginList: []string{"*"}},
+			method: http.MethodGet,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+			},
+		},
+		{
+			desc: "allowed origin",
+			config: dynamic.CORS{
+				AllowOriginList:  []string{"https://foo.com"},
+				ExposeHeaders:    []string{"X-Foo", "X-Bar"},
+				AllowCredentials: true,
+			},
+			method: http.MethodGet,
+			origin: "https://foo.com",
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://foo.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "X-Foo,X-Bar",
+			},
+		},
+		{
+			desc:   "origin not allowed",
+			config: dynamic.CORS{AllowOriginList: []string{"https://foo.com"}},
+			method: http.MethodGet,
+			origin: "https://bar.com",
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+			},
+		},
+		{
+			desc:   "options request without requested method",
+			config: dynamic.CORS{AllowOriginList: []string{"*"}},
+			method: http.MethodOptions,
+			origin: "https://foo.com",
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "*",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwarded bool
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwarded = true
+				rw.Header().Set("Vary", "Accept-Encoding")
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			handler, err := New(context.Background(), next, test.config, "cors", nil)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(test.method, "http://localhost", nil)
+			if test.origin != "" {
+				req.Header.Set("Origin", test.origin)
+			}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.True(t, forwarded)
+			assert.Equal(t, http.StatusOK, rw.Code)
+			for name, value := range test.expectedHeaders {
+				assert.Equal(t, value, rw.Header().Get(name), name)
+			}
+
+			assert.Equal(t, []string{"Accept-Encoding", "Origin"}, rw.Header().Values("Vary"))
+		})
+	}
+}
+
+func TestCORS_metrics(t *testing.T) {
+	registry := newCollectingRegistry()
+
+	handler, err := New(context.Background(), http.NotFoundHandler(), dynamic.CORS{
+		AllowOriginListRegex: []string{`^https://[a-z]+\.foo\.com$`},
+	}, "cors", registry)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodOptions, "http://localhost", nil)
+	req.Header.Set("Origin", "https://bar.foo.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, []string{"middleware", "cors", "origin", `^https://[a-z]+\.foo\.com$`, "type", "preflight", "result", "allowed"}, registry.counter.LastLabelValues)
+
+	req = httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	req.Header.Set("Origin", "https://evil.com")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, []string{"middleware", "cors", "origin", "", "type", "request", "result", "rejected"}, registry.counter.LastLabelValues)
+}
+
+type collectingRegistry struct {
+	metrics.Registry
+
+	counter *testhelpers.CollectingCounter
+}
+
+func newCollectingRegistry() *collectingRegistry {
+	return &collectingRegistry{
+		Registry: metrics.NewVoidRegistry(),
+		counter:  &testhelpers.CollectingCounter{},
+	}
+}
+
+func (r *collectingRegistry) CORSReqsCounter() gokitmetrics.Counter {
+	return r.counter
+}
@@ -0,0 +1,190 @@
+package csrf
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "CSRF"
+
+const (
+	defaultCookieName = "_csrf"
+	defaultHeaderName = "X-CSRF-Token"
+
+	// tokenSize is the number of random bytes of a token.
+	tokenSize = 32
+)
+
+var defaultSafeMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace}
+
+// csrf is a middleware protecting the services against cross-site request forgery,
+// by requiring the requests with unsafe methods to send the token of their cookie in a header.
+type csrf struct {
+	next              http.Handler
+	name              string
+	safeMethods       []string
+	cookieName        string
+	headerName        string
+	exemptPaths       []string
+	secret            []byte
+	sessionCookieName string
+	secure            bool
+}
+
+// New creates a CSRF middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.CSRF, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if config.SessionCookieName != "" && config.Secret == "" {
+		return nil, errors.New("a secret must be defined to bind the tokens to the session cookie")
+	}
+
+	c := &csrf{
+		next:              next,
+		name:              name,
+		safeMethods:       config.SafeMethods,
+		cookieName:        config.CookieName,
+		headerName:        config.HeaderName,
+		exemptPaths:       config.ExemptPaths,
+		secret:            []byte(config.Secret),
+		sessionCookieName: config.SessionCookieName,
+		secure:            config.Secure,
+	}
+
+	if len(c.safeMethods) == 0 {
+		c.safeMethods = defaultSafeMethods
+	}
+
+	if c.cookieName == "" {
+		c.cookieName = defaultCookieName
+	}
+
+	if c.headerName == "" {
+		c.headerName = defaultHeaderName
+	}
+
+	return c, nil
+}
+
+func (c *csrf) GetTracingInformation() (string, string, trace.SpanKind) {
+	return c.name, typeName, trace.SpanKindInternal
+}
+
+func (c *csrf) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	token := c.cookieToken(req)
+
+	if !slices.Contains(c.safeMethods, req.Method) && !c.isExempt(req.URL.Path) {
+		if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(req.Header.Get(c.headerName))) != 1 {
+			middlewares.GetLogger(req.Context(), c.name, typeName).Debug().Msgf("Rejecting %s request without a valid CSRF token", req.Method)
+			observability.SetStatusErrorf(req.Context(), "Missing or invalid CSRF token")
+
+			rw.WriteHeader(http.StatusForbidden)
+			if _, err := rw.Write([]byte(http.StatusText(http.StatusForbidden))); err != nil {
+				log.Ctx(req.Context()).Error().Err(err).Send()
+			}
+			return
+		}
+
+		c.next.ServeHTTP(rw, req)
+		return
+	}
+
+	if token == "" {
+		token = c.newToken(req)
+
+		http.SetCookie(rw, &http.Cookie{
+			Name:     c.cookieName,
+			Value:    token,
+			Path:     "/",
+			Secure:   c.secure,
+			SameSite: http.SameSiteStrictMode,
+		})
+	}
+
+	// The token is forwarded, so that the services can render it in their pages.
+	req.Header.Set(c.headerName, token)
+
+	c.next.ServeHTTP(rw, req)
+}
+
+// cookieToken returns the token of the request cookie, or an empty string if it is missing or invalid.
+func (c *csrf) cookieToken(req *http.Request) string {
+	cookie, err := req.Cookie(c.cookieName)
+	if err != nil || cookie.Value == "" {
+		return ""
+	}
+
+	if len(c.secret) == 0 {
+		return cookie.Value
+	}
+
+	nonce, signature, ok := strings.Cut(cookie.Value, ".")
+	if !ok {
+		return ""
+	}
+
+	expected := c.sign(req, nonce)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ""
+	}
+
+	return cookie.Value
+}
+
+// newToken returns a new random token, signed if a secret is defined.
+func (c *csrf) newToken(req *http.Request) string {
+	nonce := make([]byte, tokenSize)
+	// The random source of the crypto/rand package never fails.
+	_, _ = rand.Read(nonce)
+
+	token := base64.RawURLEncoding.EncodeToString(nonce)
+	if len(c.secret) == 0 {
+		return token
+	}
+
+	return token + "." + c.sign(req, token)
+}
+
+// sign returns the signature of the token nonce, bound to the session cookie if configured.
+func (c *csrf) sign(req *http.Request, nonce string) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(nonce))
+
+	if c.sessionCookieName != "" {
+		// A missing session cookie is signed as an empty session,
+		// so that the token is renewed once the session is created.
+		var session string
+		if cookie, err := req.Cookie(c.sessionCookieName); err == nil {
+			session = cookie.Value
+		}
+
+		mac.Write([]byte{0})
+		mac.Write([]byte(session))
+	}
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (c *csrf) isExempt(path string) bool {
+	for _, prefix := range c.exemptPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,210 @@
+package csrf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	_, err := New(context.Background(), http.NotFoundHandler(), dynamic.CSRF{SessionCookieName: "session"}, "csrf")
+	assert.Error(t, err)
+}
+
+func TestCSRF_ServeHTTP(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		config         dynamic.CSRF
+		method         string
+		path           string
+		cookie         string
+		header         string
+		expectedStatus int
+	}{
+		{
+			desc:           "safe method without token",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "unsafe method without token",
+			method:         http.MethodPost,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "unsafe method without header",
+			method:         http.MethodPost,
+			cookie:         "token",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "unsafe method with mismatching header",
+			method:         http.MethodDelete,
+			cookie:         "token",
+			header:         "other",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "unsafe method with matching header",
+			method:         http.MethodPost,
+			cookie:         "token",
+			header:         "token",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "custom safe methods",
+			config:         dynamic.CSRF{SafeMethods: []string{http.MethodGet}},
+			method:         http.MethodOptions,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "custom names",
+			config:         dynamic.CSRF{CookieName: "XSRF-TOKEN", HeaderName: "X-XSRF-Token"},
+			method:         http.MethodPut,
+			cookie:         "token",
+			header:         "token",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "exempt path",
+			config:         dynamic.CSRF{ExemptPaths: []string{"/webhooks/"}},
+			method:         http.MethodPost,
+			path:           "/webhooks/github",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "forged unsigned token",
+			config:         dynamic.CSRF{Secret: "secret"},
+			method:         http.MethodPost,
+			cookie:         "token",
+			header:         "token",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			handler, err := New(context.Background(), next, test.config, "csrf")
+			require.NoError(t, err)
+
+			cookieName := test.config.CookieName
+			if cookieName == "" {
+				cookieName = defaultCookieName
+			}
+
+			headerName := test.config.HeaderName
+			if headerName == "" {
+				headerName = defaultHeaderName
+			}
+
+			req := httptest.NewRequest(test.method, "http://localhost"+test.path, nil)
+			if test.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: cookieName, Value: test.cookie})
+			}
+			if test.header != "" {
+				req.Header.Set(headerName, test.header)
+			}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+		})
+	}
+}
+
+func TestCSRF_tokenIssuance(t *testing.T) {
+	var forwardedToken string
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwardedToken = req.Header.Get(defaultHeaderName)
+	})
+
+	handler, err := New(context.Background(), next, dynamic.CSRF{Secure: true}, "csrf")
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+
+	cookies := rw.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, defaultCookieName, cookies[0].Name)
+	assert.Equal(t, "/", cookies[0].Path)
+	assert.True(t, cookies[0].Secure)
+	assert.False(t, cookies[0].HttpOnly)
+	assert.Equal(t, http.SameSiteStrictMode, cookies[0].SameSite)
+	assert.NotEmpty(t, cookies[0].Value)
+	assert.Equal(t, cookies[0].Value, forwardedToken)
+
+	// The existing token is kept.
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	req.AddCookie(cookies[0])
+
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	assert.Empty(t, rw.Result().Cookies())
+	assert.Equal(t, cookies[0].Value, forwardedToken)
+}
+
+func TestCSRF_sessionBoundToken(t *testing.T) {
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	handler, err := New(context.Background(), next, dynamic.CSRF{Secret: "secret", SessionCookieName: "session"}, "csrf")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "alice"})
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	cookies := rw.Result().Cookies()
+	require.Len(t, cookies, 1)
+	token := cookies[0].Value
+
+	testCases := []struct {
+		desc           string
+		session        string
+		expectedStatus int
+	}{
+		{
+			desc:           "same session",
+			session:        "alice",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "other session",
+			session:        "mallory",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost", nil)
+			req.AddCookie(&http.Cookie{Name: "session", Value: test.session})
+			req.AddCookie(&http.Cookie{Name: defaultCookieName, Value: token})
+			req.Header.Set(defaultHeaderName, token)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+		})
+	}
+}
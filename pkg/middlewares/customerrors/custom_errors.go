@@ -2,14 +2,24 @@ package customerrors
 
 import (
 	"bufio"
+	"bytes"
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"mime"
 	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"text/template"
 
+	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
 	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
@@ -26,15 +36,44 @@ var (
 
 const typeName = "CustomError"
 
+const defaultContentType = "text/html; charset=utf-8"
+
 type serviceBuilder interface {
 	BuildHTTP(ctx context.Context, serviceName string) (http.Handler, error)
 }
 
+// pageTemplate is implemented by the text and HTML templates.
+type pageTemplate interface {
+	Execute(wr io.Writer, data any) error
+}
+
+// statusBackend is a handler serving the error pages of a range of statuses.
+type statusBackend struct {
+	codeRange [2]int
+	handler   http.Handler
+}
+
+// templateData is the data available in the error page template.
+type templateData struct {
+	StatusCode int
+	Status     string
+	ClientIP   string
+	Method     string
+	Host       string
+	Path       string
+	URL        string
+	RouterName string
+	Header     http.Header
+}
+
 // customErrors is a middleware that provides the custom error pages.
 type customErrors struct {
 	name           string
 	next           http.Handler
 	backendHandler http.Handler
+	statusBackends []statusBackend
+	page           pageTemplate
+	contentType    string
 	httpCodeRanges types.HTTPCodeRanges
 	backendQuery   string
 }
@@ -48,18 +87,111 @@ func New(ctx context.Context, next http.Handler, config dynamic.ErrorPage, servi
 		return nil, err
 	}
 
-	backend, err := serviceBuilder.BuildHTTP(ctx, config.Service)
-	if err != nil {
-		return nil, err
+	if config.Body != "" && config.File != "" {
+		return nil, errors.New("only one of body and file can be defined")
 	}
 
-	return &customErrors{
+	hasPage := config.Body != "" || config.File != ""
+	if hasPage && config.Service != "" {
+		return nil, errors.New("only one of service, and body or file, can be defined")
+	}
+
+	if !hasPage && config.Service == "" && len(config.StatusServices) == 0 {
+		return nil, errors.New("no service nor error page defined")
+	}
+
+	c := &customErrors{
 		name:           name,
 		next:           next,
-		backendHandler: backend,
 		httpCodeRanges: httpCodeRanges,
 		backendQuery:   config.Query,
-	}, nil
+	}
+
+	if config.Service != "" {
+		c.backendHandler, err = serviceBuilder.BuildHTTP(ctx, config.Service)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for status, serviceName := range config.StatusServices {
+		codeRange, err := parseStatus(status)
+		if err != nil {
+			return nil, err
+		}
+
+		handler, err := serviceBuilder.BuildHTTP(ctx, serviceName)
+		if err != nil {
+			return nil, err
+		}
+
+		c.statusBackends = append(c.statusBackends, statusBackend{codeRange: codeRange, handler: handler})
+	}
+
+	// The narrowest ranges come first, so that the most specific backend is used.
+	slices.SortFunc(c.statusBackends, func(a, b statusBackend) int {
+		return cmp.Or(
+			cmp.Compare(a.codeRange[1]-a.codeRange[0], b.codeRange[1]-b.codeRange[0]),
+			cmp.Compare(a.codeRange[0], b.codeRange[0]),
+		)
+	})
+
+	if hasPage {
+		c.contentType = config.ContentType
+		if c.contentType == "" {
+			c.contentType = defaultContentType
+		}
+
+		body := config.Body
+		if config.File != "" {
+			content, err := os.ReadFile(config.File)
+			if err != nil {
+				return nil, fmt.Errorf("reading error page file: %w", err)
+			}
+
+			body = string(content)
+		}
+
+		c.page, err = parseTemplate(name, c.contentType, body)
+		if err != nil {
+			return nil, fmt.Errorf("parsing error page template: %w", err)
+		}
+	}
+
+	return c, nil
+}
+
+// parseStatus parses a status code (404), a range of status codes (500-504), or a class of status codes (5xx).
+func parseStatus(status string) ([2]int, error) {
+	if len(status) == 3 && strings.EqualFold(status[1:], "xx") && status[0] >= '1' && status[0] <= '5' {
+		class := int(status[0]-'0') * 100
+		return [2]int{class, class + 99}, nil
+	}
+
+	codeRanges, err := types.NewHTTPCodeRanges([]string{status})
+	if err != nil {
+		return [2]int{}, fmt.Errorf("invalid status %q: %w", status, err)
+	}
+
+	if codeRanges[0][0] > codeRanges[0][1] {
+		return [2]int{}, fmt.Errorf("invalid status %q: empty range", status)
+	}
+
+	return codeRanges[0], nil
+}
+
+// parseTemplate parses the error page template, escaping the data in the HTML pages.
+func parseTemplate(name, contentType, body string) (pageTemplate, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content type %q: %w", contentType, err)
+	}
+
+	if mediaType == "text/html" {
+		return htmltemplate.New(name).Parse(body)
+	}
+
+	return template.New(name).Parse(body)
 }
 
 func (c *customErrors) GetTracingInformation() (string, string, trace.SpanKind) {
@@ -69,7 +201,7 @@ func (c *customErrors) GetTracingInformation() (string, string, trace.SpanKind)
 func (c *customErrors) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	logger := middlewares.GetLogger(req.Context(), c.name, typeName)
 
-	if c.backendHandler == nil {
+	if c.backendHandler == nil && len(c.statusBackends) == 0 && c.page == nil {
 		logger.Error().Msg("No backend handler.")
 		observability.SetStatusErrorf(req.Context(), "No backend handler.")
 		c.next.ServeHTTP(rw, req)
@@ -86,6 +218,18 @@ func (c *customErrors) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	code := catcher.getCode()
 	logger.Debug().Msgf("Caught HTTP Status Code %d, returning error page", code)
 
+	backendHandler := c.getBackendHandler(code)
+	if backendHandler == nil {
+		if c.page != nil {
+			c.servePage(rw, req, code)
+			return
+		}
+
+		logger.Debug().Msgf("No backend handler for HTTP Status Code %d", code)
+		http.Error(rw, http.StatusText(code), code)
+		return
+	}
+
 	var query string
 	if len(c.backendQuery) > 0 {
 		query = "/" + strings.TrimPrefix(c.backendQuery, "/")
@@ -102,10 +246,56 @@ func (c *customErrors) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	}
 
 	utils.CopyHeaders(pageReq.Header, req.Header)
-	c.backendHandler.ServeHTTP(newCodeModifier(rw, code),
+	backendHandler.ServeHTTP(newCodeModifier(rw, code),
 		pageReq.WithContext(req.Context()))
 }
 
+// getBackendHandler returns the handler serving the error page of the given status code, if any.
+func (c *customErrors) getBackendHandler(code int) http.Handler {
+	for _, backend := range c.statusBackends {
+		if code >= backend.codeRange[0] && code <= backend.codeRange[1] {
+			return backend.handler
+		}
+	}
+
+	return c.backendHandler
+}
+
+// servePage serves the error page built from the template.
+func (c *customErrors) servePage(rw http.ResponseWriter, req *http.Request, code int) {
+	clientIP, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		clientIP = req.RemoteAddr
+	}
+
+	data := templateData{
+		StatusCode: code,
+		Status:     http.StatusText(code),
+		ClientIP:   clientIP,
+		Method:     req.Method,
+		Host:       req.Host,
+		Path:       req.URL.Path,
+		URL:        req.URL.String(),
+		RouterName: middlewares.GetRouterName(req.Context()),
+		Header:     req.Header,
+	}
+
+	var body bytes.Buffer
+	if err := c.page.Execute(&body, data); err != nil {
+		middlewares.GetLogger(req.Context(), c.name, typeName).Error().Err(err).Msg("Unable to execute the error page template")
+		observability.SetStatusErrorf(req.Context(), "Unable to execute the error page template: %v", err)
+		http.Error(rw, http.StatusText(code), code)
+		return
+	}
+
+	rw.Header().Set("Content-Type", c.contentType)
+	rw.Header().Set("Content-Length", strconv.Itoa(body.Len()))
+	rw.WriteHeader(code)
+	if _, err := rw.Write(body.Bytes()); err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Send()
+	}
+}
+
 func newRequest(baseURL string) (*http.Request, error) {
 	u, err := url.Parse(baseURL)
 	if err != nil {
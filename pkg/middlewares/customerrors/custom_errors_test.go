@@ -8,6 +8,9 @@ import (
 	"net/http/httptest"
 	"net/http/httptrace"
 	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -183,6 +186,206 @@ func TestHandler(t *testing.T) {
 	}
 }
 
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		errorPage     dynamic.ErrorPage
+		expectedError bool
+	}{
+		{
+			desc:      "service",
+			errorPage: dynamic.ErrorPage{Service: "error", Status: []string{"500-599"}},
+		},
+		{
+			desc:      "status services",
+			errorPage: dynamic.ErrorPage{StatusServices: map[string]string{"4xx": "error4xx", "500-503": "error5xx"}, Status: []string{"400-599"}},
+		},
+		{
+			desc:      "body with status services",
+			errorPage: dynamic.ErrorPage{Body: "{{ .Status }}", StatusServices: map[string]string{"404": "error404"}, Status: []string{"400-599"}},
+		},
+		{
+			desc:          "no service nor page",
+			errorPage:     dynamic.ErrorPage{Status: []string{"500-599"}},
+			expectedError: true,
+		},
+		{
+			desc:          "service and body",
+			errorPage:     dynamic.ErrorPage{Service: "error", Body: "{{ .Status }}", Status: []string{"500-599"}},
+			expectedError: true,
+		},
+		{
+			desc:          "body and file",
+			errorPage:     dynamic.ErrorPage{Body: "{{ .Status }}", File: "error.html", Status: []string{"500-599"}},
+			expectedError: true,
+		},
+		{
+			desc:          "missing file",
+			errorPage:     dynamic.ErrorPage{File: "does-not-exist.html", Status: []string{"500-599"}},
+			expectedError: true,
+		},
+		{
+			desc:          "invalid template",
+			errorPage:     dynamic.ErrorPage{Body: "{{ .Status ", Status: []string{"500-599"}},
+			expectedError: true,
+		},
+		{
+			desc:          "invalid status class",
+			errorPage:     dynamic.ErrorPage{StatusServices: map[string]string{"6xx": "error"}, Status: []string{"500-599"}},
+			expectedError: true,
+		},
+		{
+			desc:          "invalid status range",
+			errorPage:     dynamic.ErrorPage{StatusServices: map[string]string{"599-500": "error"}, Status: []string{"500-599"}},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			handler, err := New(context.Background(), next, test.errorPage, &mockServiceBuilder{handler: next}, "test")
+
+			if test.expectedError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, handler)
+			}
+		})
+	}
+}
+
+func TestHandler_statusServices(t *testing.T) {
+	services := mockServicesBuilder{
+		"error":    serviceResponding("default error page"),
+		"error4xx": serviceResponding("4xx error page"),
+		"error404": serviceResponding("404 error page"),
+		"error5xx": serviceResponding("5xx error page"),
+	}
+
+	config := dynamic.ErrorPage{
+		Service: "error",
+		StatusServices: map[string]string{
+			"4xx":     "error4xx",
+			"404":     "error404",
+			"500-503": "error5xx",
+		},
+		Status: []string{"400-599"},
+	}
+
+	testCases := []struct {
+		backendCode  int
+		expectedBody string
+	}{
+		{backendCode: http.StatusNotFound, expectedBody: "404 error page"},
+		{backendCode: http.StatusForbidden, expectedBody: "4xx error page"},
+		{backendCode: http.StatusBadGateway, expectedBody: "5xx error page"},
+		{backendCode: http.StatusGatewayTimeout, expectedBody: "default error page"},
+	}
+
+	for _, test := range testCases {
+		t.Run(strconv.Itoa(test.backendCode), func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.backendCode)
+			})
+
+			errorPageHandler, err := New(context.Background(), next, config, services, "test")
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			errorPageHandler.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://localhost/test", nil))
+
+			assert.Equal(t, test.backendCode, recorder.Code)
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestHandler_page(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "error.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"status":{{ .StatusCode }},"path":"{{ .Path }}"}`), 0o600))
+
+	testCases := []struct {
+		desc                string
+		errorPage           dynamic.ErrorPage
+		backendCode         int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			desc: "HTML body",
+			errorPage: dynamic.ErrorPage{
+				Body:   "<h1>{{ .StatusCode }} {{ .Status }}</h1><p>{{ .Path }}</p>",
+				Status: []string{"500-599"},
+			},
+			backendCode:         http.StatusBadGateway,
+			expectedContentType: "text/html; charset=utf-8",
+			expectedBody:        "<h1>502 Bad Gateway</h1><p>/&lt;test&gt;</p>",
+		},
+		{
+			desc: "file",
+			errorPage: dynamic.ErrorPage{
+				File:        file,
+				ContentType: "application/json",
+				Status:      []string{"404"},
+			},
+			backendCode:         http.StatusNotFound,
+			expectedContentType: "application/json",
+			expectedBody:        `{"status":404,"path":"/<test>"}`,
+		},
+		{
+			desc: "status service before the page",
+			errorPage: dynamic.ErrorPage{
+				Body:           "{{ .Status }}",
+				StatusServices: map[string]string{"5xx": "error"},
+				Status:         []string{"500-599"},
+			},
+			backendCode:         http.StatusBadGateway,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedBody:        "service error page",
+		},
+		{
+			desc: "not in the range",
+			errorPage: dynamic.ErrorPage{
+				Body:   "{{ .Status }}",
+				Status: []string{"404"},
+			},
+			backendCode:         http.StatusBadGateway,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedBody:        "backend error",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+				w.WriteHeader(test.backendCode)
+				_, _ = w.Write([]byte("backend error"))
+			})
+
+			services := mockServicesBuilder{"error": serviceResponding("service error page")}
+
+			errorPageHandler, err := New(context.Background(), next, test.errorPage, services, "test")
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			errorPageHandler.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://localhost/%3Ctest%3E", nil))
+
+			assert.Equal(t, test.backendCode, recorder.Code)
+			assert.Equal(t, test.expectedContentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
 // This test is an adapted version of net/http/httputil.Test1xxResponses test.
 func Test1xxResponses(t *testing.T) {
 	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -272,3 +475,21 @@ type mockServiceBuilder struct {
 func (m *mockServiceBuilder) BuildHTTP(_ context.Context, _ string) (http.Handler, error) {
 	return m.handler, nil
 }
+
+type mockServicesBuilder map[string]http.Handler
+
+func (m mockServicesBuilder) BuildHTTP(_ context.Context, serviceName string) (http.Handler, error) {
+	handler, ok := m[serviceName]
+	if !ok {
+		return nil, fmt.Errorf("service %q not found", serviceName)
+	}
+
+	return handler, nil
+}
+
+func serviceResponding(body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(body))
+	})
+}
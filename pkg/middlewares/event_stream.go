@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// EventStreamMediaType is the media type of the Server-Sent Events streams.
+const EventStreamMediaType = "text/event-stream"
+
+// IsEventStreamRequest reports whether the request subscribes to Server-Sent Events,
+// i.e. whether it accepts a text/event-stream response.
+func IsEventStreamRequest(req *http.Request) bool {
+	for _, value := range req.Header.Values("Accept") {
+		for _, item := range strings.Split(value, ",") {
+			mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(item))
+			if err == nil && mediaType == EventStreamMediaType {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// IsEventStreamResponse reports whether the given response headers describe a Server-Sent Events stream.
+func IsEventStreamResponse(header http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && mediaType == EventStreamMediaType
+}
@@ -25,11 +25,15 @@ func GetSourceExtractor(ctx context.Context, sourceMatcher *dynamic.SourceCriter
 		if sourceMatcher.RequestHeaderName != "" && sourceMatcher.RequestHost {
 			return nil, errors.New("requestHost and RequestHeaderName are mutually exclusive")
 		}
+		if sourceMatcher.Expression != "" && (sourceMatcher.IPStrategy != nil || sourceMatcher.RequestHeaderName != "" || sourceMatcher.RequestHost) {
+			return nil, errors.New("expression is mutually exclusive with the other criteria")
+		}
 	}
 
 	if sourceMatcher == nil ||
 		sourceMatcher.IPStrategy == nil &&
-			sourceMatcher.RequestHeaderName == "" && !sourceMatcher.RequestHost {
+			sourceMatcher.RequestHeaderName == "" && !sourceMatcher.RequestHost &&
+			sourceMatcher.Expression == "" {
 		sourceMatcher = &dynamic.SourceCriterion{
 			IPStrategy: &dynamic.IPStrategy{},
 		}
@@ -58,5 +62,10 @@ func GetSourceExtractor(ctx context.Context, sourceMatcher *dynamic.SourceCriter
 		return utils.NewExtractor("request.host")
 	}
 
+	if sourceMatcher.Expression != "" {
+		logger.Debug().Msg("Using Expression")
+		return newSourceExpressionExtractor(sourceMatcher.Expression)
+	}
+
 	return nil, errors.New("no SourceCriterion criterion defined")
 }
@@ -0,0 +1,227 @@
+package fail2ban
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/ip"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"github.com/traefik/traefik/v3/pkg/types"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "Fail2Ban"
+
+const (
+	defaultMaxFailures   = 5
+	defaultFindTime      = 10 * time.Minute
+	defaultBanTime       = time.Hour
+	defaultBanStatusCode = http.StatusForbidden
+)
+
+var defaultStatusCodes = []string{"401", "403", "429"}
+
+// fail2Ban is a middleware banning temporarily the clients whose requests fail too often.
+type fail2Ban struct {
+	next          http.Handler
+	name          string
+	store         store
+	statusCodes   types.HTTPCodeRanges
+	maxFailures   int
+	findTime      time.Duration
+	banTime       time.Duration
+	banStatusCode int
+	ignored       *ip.Checker
+	strategy      ip.Strategy
+}
+
+// New creates a Fail2Ban middleware.
+// The bans are kept by the given manager, which can be nil.
+func New(ctx context.Context, next http.Handler, manager *Manager, config dynamic.Fail2Ban, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	statusCodes := config.StatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = defaultStatusCodes
+	}
+
+	codeRanges, err := types.NewHTTPCodeRanges(statusCodes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing status codes: %w", err)
+	}
+
+	maxFailures := config.MaxFailures
+	if maxFailures < 0 {
+		return nil, fmt.Errorf("negative maxFailures %d", maxFailures)
+	}
+	if maxFailures == 0 {
+		maxFailures = defaultMaxFailures
+	}
+
+	findTime := time.Duration(config.FindTime)
+	if findTime < 0 {
+		return nil, fmt.Errorf("negative findTime %s", findTime)
+	}
+	if findTime == 0 {
+		findTime = defaultFindTime
+	}
+
+	banTime := time.Duration(config.BanTime)
+	if banTime < 0 {
+		return nil, fmt.Errorf("negative banTime %s", banTime)
+	}
+	if banTime == 0 {
+		banTime = defaultBanTime
+	}
+
+	banStatusCode := config.BanStatusCode
+	if banStatusCode == 0 {
+		banStatusCode = defaultBanStatusCode
+	} else if http.StatusText(banStatusCode) == "" {
+		return nil, fmt.Errorf("invalid HTTP status code %d", banStatusCode)
+	}
+
+	strategy, err := config.IPStrategy.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	var ignored *ip.Checker
+	if len(config.IgnoredSourceRange) > 0 {
+		ignored, err = ip.NewChecker(config.IgnoredSourceRange)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse CIDRs %s: %w", config.IgnoredSourceRange, err)
+		}
+	}
+
+	s, err := manager.getStore(ctx, name, config.Redis)
+	if err != nil {
+		return nil, fmt.Errorf("creating store: %w", err)
+	}
+
+	return &fail2Ban{
+		next:          next,
+		name:          name,
+		store:         s,
+		statusCodes:   codeRanges,
+		maxFailures:   maxFailures,
+		findTime:      findTime,
+		banTime:       banTime,
+		banStatusCode: banStatusCode,
+		ignored:       ignored,
+		strategy:      strategy,
+	}, nil
+}
+
+func (f *fail2Ban) GetTracingInformation() (string, string, trace.SpanKind) {
+	return f.name, typeName, trace.SpanKindInternal
+}
+
+func (f *fail2Ban) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), f.name, typeName)
+	ctx := req.Context()
+
+	clientIP := f.strategy.GetIP(req)
+
+	if f.ignored != nil {
+		if ignored, _ := f.ignored.Contains(clientIP); ignored {
+			f.next.ServeHTTP(rw, req)
+			return
+		}
+	}
+
+	// The errors of the store let the requests through, for an unavailable store not to make the service unavailable.
+	until, err := f.store.bannedUntil(ctx, clientIP, time.Now())
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to get the ban of the client")
+	}
+
+	if !until.IsZero() {
+		logger.Debug().Msgf("Rejecting banned IP %s", clientIP)
+		observability.SetStatusErrorf(ctx, "Rejecting banned IP %s", clientIP)
+
+		rw.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(until).Seconds()))))
+		rw.WriteHeader(f.banStatusCode)
+		if _, err := rw.Write([]byte(http.StatusText(f.banStatusCode))); err != nil {
+			log.Ctx(ctx).Error().Err(err).Send()
+		}
+		return
+	}
+
+	recorder := &statusRecorder{ResponseWriter: rw, status: http.StatusOK}
+	f.next.ServeHTTP(recorder, req)
+
+	if !f.statusCodes.Contains(recorder.status) {
+		return
+	}
+
+	// The failure is recorded even if the client has gone away.
+	ctx = context.WithoutCancel(ctx)
+	now := time.Now()
+
+	failures, err := f.store.addFailure(ctx, clientIP, now, f.findTime)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to record the failure of the client")
+		return
+	}
+
+	if failures < f.maxFailures {
+		return
+	}
+
+	logger.Debug().Msgf("Banning IP %s for %s after %d failures", clientIP, f.banTime, failures)
+
+	if err := f.store.ban(ctx, clientIP, now.Add(f.banTime)); err != nil {
+		logger.Error().Err(err).Msg("Unable to ban the client")
+	}
+}
+
+// statusRecorder records the status code of the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader records the first final status code.
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader && status >= http.StatusOK {
+		r.status = status
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes the body of the response, the status code being 200 if it has not been written yet.
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+
+	return r.ResponseWriter.Write(b)
+}
+
+// Hijack hijacks the connection.
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("%T is not a http.Hijacker", r.ResponseWriter)
+	}
+
+	return hijacker.Hijack()
+}
+
+// Flush sends any buffered data to the client.
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
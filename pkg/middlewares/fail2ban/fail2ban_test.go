@@ -0,0 +1,228 @@
+package fail2ban
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.Fail2Ban
+	}{
+		{
+			desc:   "invalid status codes",
+			config: dynamic.Fail2Ban{StatusCodes: []string{"foo"}},
+		},
+		{
+			desc:   "negative max failures",
+			config: dynamic.Fail2Ban{MaxFailures: -1},
+		},
+		{
+			desc:   "negative find time",
+			config: dynamic.Fail2Ban{FindTime: ptypes.Duration(-time.Second)},
+		},
+		{
+			desc:   "negative ban time",
+			config: dynamic.Fail2Ban{BanTime: ptypes.Duration(-time.Second)},
+		},
+		{
+			desc:   "invalid ban status code",
+			config: dynamic.Fail2Ban{BanStatusCode: 999},
+		},
+		{
+			desc:   "invalid ignored source range",
+			config: dynamic.Fail2Ban{IgnoredSourceRange: []string{"foo"}},
+		},
+		{
+			desc:   "no Redis endpoints",
+			config: dynamic.Fail2Ban{Redis: &dynamic.Redis{}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), nil, test.config, "fail2ban")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFail2Ban_ServeHTTP(t *testing.T) {
+	config := dynamic.Fail2Ban{
+		StatusCodes:        []string{"401", "500-599"},
+		MaxFailures:        3,
+		BanTime:            ptypes.Duration(time.Minute),
+		BanStatusCode:      http.StatusTooManyRequests,
+		IgnoredSourceRange: []string{"10.0.1.0/24"},
+	}
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/unauthorized":
+			rw.WriteHeader(http.StatusUnauthorized)
+		case "/error":
+			rw.WriteHeader(http.StatusBadGateway)
+		case "/notfound":
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	handler, err := New(context.Background(), next, nil, config, "fail2ban")
+	require.NoError(t, err)
+
+	serve := func(remoteAddr, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost"+path, nil)
+		req.RemoteAddr = remoteAddr
+
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+
+		return rw
+	}
+
+	// The status codes which are not failures are not counted.
+	for range 5 {
+		assert.Equal(t, http.StatusNotFound, serve("10.0.0.1:1234", "/notfound").Code)
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve("10.0.0.1:1234", "/unauthorized").Code)
+	assert.Equal(t, http.StatusBadGateway, serve("10.0.0.1:1234", "/error").Code)
+	assert.Equal(t, http.StatusOK, serve("10.0.0.1:1234", "/").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("10.0.0.1:1234", "/unauthorized").Code)
+
+	rw := serve("10.0.0.1:1234", "/")
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "60", rw.Header().Get("Retry-After"))
+
+	// The other clients are not banned.
+	assert.Equal(t, http.StatusOK, serve("10.0.0.2:1234", "/").Code)
+
+	// The ignored clients are never banned.
+	for range 5 {
+		assert.Equal(t, http.StatusUnauthorized, serve("10.0.1.1:1234", "/unauthorized").Code)
+	}
+	assert.Equal(t, http.StatusOK, serve("10.0.1.1:1234", "/").Code)
+}
+
+func TestFail2Ban_manager(t *testing.T) {
+	ctx := context.Background()
+
+	config := dynamic.Fail2Ban{MaxFailures: 1}
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusForbidden)
+	})
+
+	manager := NewManager()
+
+	_, err := manager.Bans(ctx, "fail2ban@file")
+	assert.ErrorIs(t, err, ErrMiddlewareNotFound)
+
+	handler, err := New(ctx, next, manager, config, "fail2ban@file")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// The bans are kept when the middleware is created again with the same store configuration.
+	config.BanStatusCode = http.StatusTooManyRequests
+	handler, err = New(ctx, next, manager, config, "fail2ban@file")
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+
+	bans, err := manager.Bans(ctx, "fail2ban@file")
+	require.NoError(t, err)
+	require.Len(t, bans, 1)
+	assert.Equal(t, "10.0.0.1", bans[0].ClientIP)
+
+	unbanned, err := manager.Unban(ctx, "fail2ban@file", "10.0.0.1")
+	require.NoError(t, err)
+	assert.True(t, unbanned)
+
+	unbanned, err = manager.Unban(ctx, "fail2ban@file", "10.0.0.1")
+	require.NoError(t, err)
+	assert.False(t, unbanned)
+
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusForbidden, rw.Code)
+}
+
+func TestStores(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	redisStore, err := newRedisStore(context.Background(), "fail2ban@file", dynamic.Redis{Endpoints: []string{server.Addr()}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = redisStore.close() })
+
+	stores := map[string]store{
+		"memory": newMemoryStore(),
+		"redis":  redisStore,
+	}
+
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Now().Truncate(time.Microsecond)
+
+			count, err := s.addFailure(ctx, "10.0.0.1", now.Add(-2*time.Minute), time.Minute)
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+
+			// The failures out of the window are not counted.
+			count, err = s.addFailure(ctx, "10.0.0.1", now, time.Minute)
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+
+			count, err = s.addFailure(ctx, "10.0.0.1", now, time.Minute)
+			require.NoError(t, err)
+			assert.Equal(t, 2, count)
+
+			require.NoError(t, s.ban(ctx, "10.0.0.1", now.Add(time.Hour)))
+			require.NoError(t, s.ban(ctx, "10.0.0.2", now.Add(time.Minute)))
+			require.NoError(t, s.ban(ctx, "10.0.0.3", now.Add(-time.Minute)))
+
+			until, err := s.bannedUntil(ctx, "10.0.0.1", now)
+			require.NoError(t, err)
+			assert.True(t, until.Equal(now.Add(time.Hour)))
+
+			until, err = s.bannedUntil(ctx, "10.0.0.3", now)
+			require.NoError(t, err)
+			assert.True(t, until.IsZero())
+
+			bans, err := s.bans(ctx, now)
+			require.NoError(t, err)
+			require.Len(t, bans, 2)
+			assert.Equal(t, "10.0.0.2", bans[0].ClientIP)
+			assert.Equal(t, "10.0.0.1", bans[1].ClientIP)
+
+			// The failures are forgotten once banned.
+			count, err = s.addFailure(ctx, "10.0.0.1", now, time.Minute)
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+
+			unbanned, err := s.unban(ctx, "10.0.0.1")
+			require.NoError(t, err)
+			assert.True(t, unbanned)
+
+			until, err = s.bannedUntil(ctx, "10.0.0.1", now)
+			require.NoError(t, err)
+			assert.True(t, until.IsZero())
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package fail2ban
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// ErrMiddlewareNotFound is returned when managing the bans of an unknown middleware.
+var ErrMiddlewareNotFound = errors.New("fail2Ban middleware not found")
+
+type managedStore struct {
+	config *dynamic.Redis
+	store  store
+}
+
+// Manager holds the stores of the fail2Ban middlewares, so that the bans are kept across the configuration reloads,
+// and lists and lifts the bans.
+type Manager struct {
+	mu     sync.Mutex
+	stores map[string]*managedStore
+}
+
+// NewManager creates a new Manager.
+func NewManager() *Manager {
+	return &Manager{stores: make(map[string]*managedStore)}
+}
+
+// Bans returns the bans in effect of the given middleware.
+func (m *Manager) Bans(ctx context.Context, middlewareName string) ([]Ban, error) {
+	s, ok := m.lookup(middlewareName)
+	if !ok {
+		return nil, ErrMiddlewareNotFound
+	}
+
+	return s.bans(ctx, time.Now())
+}
+
+// Unban lifts the ban of the given client by the given middleware, and reports whether the client was banned.
+func (m *Manager) Unban(ctx context.Context, middlewareName, clientIP string) (bool, error) {
+	s, ok := m.lookup(middlewareName)
+	if !ok {
+		return false, ErrMiddlewareNotFound
+	}
+
+	return s.unban(ctx, clientIP)
+}
+
+// Close closes the connections of the stores.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, managed := range m.stores {
+		if err := managed.store.close(); err != nil {
+			log.Error().Err(err).Str("middlewareName", name).Msg("Unable to close fail2Ban store")
+		}
+	}
+
+	m.stores = make(map[string]*managedStore)
+}
+
+func (m *Manager) lookup(middlewareName string) (store, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	managed, ok := m.stores[middlewareName]
+	if !ok {
+		return nil, false
+	}
+
+	return managed.store, true
+}
+
+// getStore returns the store of the given middleware, which is created again only when its Redis configuration changes.
+// A nil Manager creates a new store on each call.
+func (m *Manager) getStore(ctx context.Context, middlewareName string, config *dynamic.Redis) (store, error) {
+	if m == nil {
+		return newStore(ctx, middlewareName, config)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if managed, ok := m.stores[middlewareName]; ok {
+		if reflect.DeepEqual(managed.config, config) {
+			return managed.store, nil
+		}
+
+		if err := managed.store.close(); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Unable to close fail2Ban store")
+		}
+
+		delete(m.stores, middlewareName)
+	}
+
+	s, err := newStore(ctx, middlewareName, config)
+	if err != nil {
+		return nil, err
+	}
+
+	m.stores[middlewareName] = &managedStore{config: config.DeepCopy(), store: s}
+
+	return s, nil
+}
+
+func newStore(ctx context.Context, middlewareName string, config *dynamic.Redis) (store, error) {
+	if config != nil {
+		return newRedisStore(ctx, middlewareName, *config)
+	}
+
+	return newMemoryStore(), nil
+}
@@ -0,0 +1,134 @@
+package fail2ban
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikredis "github.com/traefik/traefik/v3/pkg/redis"
+)
+
+// addFailure adds the failure ARGV[2], happening at ARGV[1], to the sorted set KEYS[1] of the failures of a client,
+// after removing the failures that happened before the start ARGV[3] of the window, the times being in microseconds.
+// The set expires after ARGV[4] milliseconds without failures.
+// It returns the number of failures within the window.
+var addFailure = redis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", "(" .. ARGV[3])
+redis.call("ZADD", KEYS[1], ARGV[1], ARGV[2])
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+
+return redis.call("ZCARD", KEYS[1])
+`)
+
+// redisStore is a store shared by the Traefik instances connected to the same Redis server or cluster.
+// The bans are stored in a single sorted set, scored by their end, for them to be listed without scanning the cluster.
+type redisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func newRedisStore(ctx context.Context, name string, config dynamic.Redis) (*redisStore, error) {
+	client, err := traefikredis.NewClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redisStore{
+		client: client,
+		prefix: "traefik:fail2ban:" + name + ":",
+	}, nil
+}
+
+func (s *redisStore) addFailure(ctx context.Context, clientIP string, now time.Time, window time.Duration) (int, error) {
+	// The member is made unique, for the failures happening at the same time to be counted.
+	member := strconv.FormatInt(now.UnixMicro(), 10) + "-" + strconv.FormatUint(rand.Uint64(), 36)
+
+	args := []interface{}{
+		now.UnixMicro(),
+		member,
+		now.Add(-window).UnixMicro(),
+		window.Milliseconds() + 1,
+	}
+
+	count, err := addFailure.Run(ctx, s.client, []string{s.failuresKey(clientIP)}, args...).Int()
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *redisStore) ban(ctx context.Context, clientIP string, until time.Time) error {
+	if err := s.client.ZAdd(ctx, s.bansKey(), redis.Z{Score: float64(until.UnixMicro()), Member: clientIP}).Err(); err != nil {
+		return err
+	}
+
+	return s.client.Del(ctx, s.failuresKey(clientIP)).Err()
+}
+
+func (s *redisStore) bannedUntil(ctx context.Context, clientIP string, now time.Time) (time.Time, error) {
+	score, err := s.client.ZScore(ctx, s.bansKey(), clientIP).Result()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	until := time.UnixMicro(int64(score))
+	if !until.After(now) {
+		return time.Time{}, nil
+	}
+
+	return until, nil
+}
+
+func (s *redisStore) bans(ctx context.Context, now time.Time) ([]Ban, error) {
+	nowScore := strconv.FormatInt(now.UnixMicro(), 10)
+
+	if err := s.client.ZRemRangeByScore(ctx, s.bansKey(), "-inf", nowScore).Err(); err != nil {
+		return nil, err
+	}
+
+	members, err := s.client.ZRangeByScoreWithScores(ctx, s.bansKey(), &redis.ZRangeBy{Min: "(" + nowScore, Max: "+inf"}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	bans := make([]Ban, 0, len(members))
+	for _, member := range members {
+		clientIP, _ := member.Member.(string)
+		bans = append(bans, Ban{ClientIP: clientIP, Until: time.UnixMicro(int64(member.Score))})
+	}
+
+	return bans, nil
+}
+
+func (s *redisStore) unban(ctx context.Context, clientIP string) (bool, error) {
+	if err := s.client.Del(ctx, s.failuresKey(clientIP)).Err(); err != nil {
+		return false, err
+	}
+
+	removed, err := s.client.ZRem(ctx, s.bansKey(), clientIP).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return removed > 0, nil
+}
+
+func (s *redisStore) close() error {
+	return s.client.Close()
+}
+
+func (s *redisStore) failuresKey(clientIP string) string {
+	return s.prefix + "failures:" + clientIP
+}
+
+func (s *redisStore) bansKey() string {
+	return s.prefix + "bans"
+}
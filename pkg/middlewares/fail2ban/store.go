@@ -0,0 +1,148 @@
+package fail2ban
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxClients is the maximum number of clients whose failures are tracked by the memory store.
+const maxClients = 65536
+
+// Ban is the ban of a client.
+type Ban struct {
+	ClientIP string    `json:"clientIP"`
+	Until    time.Time `json:"until"`
+}
+
+// store stores the failures and the bans of the clients.
+type store interface {
+	// addFailure records a failure of the client at the given time,
+	// and returns the number of failures of the client within the given window.
+	addFailure(ctx context.Context, clientIP string, now time.Time, window time.Duration) (int, error)
+	// ban bans the client until the given time, and forgets its failures.
+	ban(ctx context.Context, clientIP string, until time.Time) error
+	// bannedUntil returns the end of the ban of the client, or the zero time if it is not banned at the given time.
+	bannedUntil(ctx context.Context, clientIP string, now time.Time) (time.Time, error)
+	// bans returns the bans in effect at the given time, sorted by end.
+	bans(ctx context.Context, now time.Time) ([]Ban, error)
+	// unban lifts the ban of the client, and reports whether it was banned.
+	unban(ctx context.Context, clientIP string) (bool, error)
+	// close releases the resources of the store.
+	close() error
+}
+
+// memoryStore is an in-memory store, local to the Traefik instance.
+type memoryStore struct {
+	mu       sync.Mutex
+	failures map[string][]time.Time
+	banned   map[string]time.Time
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		failures: make(map[string][]time.Time),
+		banned:   make(map[string]time.Time),
+	}
+}
+
+func (s *memoryStore) addFailure(_ context.Context, clientIP string, now time.Time, window time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := now.Add(-window)
+
+	failures, ok := s.failures[clientIP]
+	if !ok && len(s.failures) >= maxClients {
+		s.removeStaleFailures(start)
+
+		// Too many clients are failing at the same time to track a new one.
+		if len(s.failures) >= maxClients {
+			return 0, nil
+		}
+	}
+
+	failures = slices.DeleteFunc(failures, func(t time.Time) bool { return t.Before(start) })
+	failures = append(failures, now)
+	s.failures[clientIP] = failures
+
+	return len(failures), nil
+}
+
+func (s *memoryStore) ban(_ context.Context, clientIP string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.failures, clientIP)
+	s.banned[clientIP] = until
+
+	return nil
+}
+
+func (s *memoryStore) bannedUntil(_ context.Context, clientIP string, now time.Time) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	until, ok := s.banned[clientIP]
+	if !ok {
+		return time.Time{}, nil
+	}
+
+	if !until.After(now) {
+		delete(s.banned, clientIP)
+		return time.Time{}, nil
+	}
+
+	return until, nil
+}
+
+func (s *memoryStore) bans(_ context.Context, now time.Time) ([]Ban, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bans := make([]Ban, 0, len(s.banned))
+	for clientIP, until := range s.banned {
+		if !until.After(now) {
+			delete(s.banned, clientIP)
+			continue
+		}
+
+		bans = append(bans, Ban{ClientIP: clientIP, Until: until})
+	}
+
+	slices.SortFunc(bans, func(a, b Ban) int {
+		if c := a.Until.Compare(b.Until); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ClientIP, b.ClientIP)
+	})
+
+	return bans, nil
+}
+
+func (s *memoryStore) unban(_ context.Context, clientIP string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.failures, clientIP)
+
+	_, ok := s.banned[clientIP]
+	delete(s.banned, clientIP)
+
+	return ok, nil
+}
+
+func (s *memoryStore) close() error {
+	return nil
+}
+
+// removeStaleFailures removes the clients without failures since the given time.
+func (s *memoryStore) removeStaleFailures(start time.Time) {
+	for clientIP, failures := range s.failures {
+		if len(failures) == 0 || failures[len(failures)-1].Before(start) {
+			delete(s.failures, clientIP)
+		}
+	}
+}
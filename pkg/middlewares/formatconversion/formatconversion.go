@@ -0,0 +1,280 @@
+package formatconversion
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/ugorji/go/codec"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "FormatConversion"
+
+const (
+	defaultXMLRootElement = "root"
+	defaultMaxBodySize    = 10 * 1024 * 1024
+)
+
+// defaultFormats are the formats converted when none is configured.
+var defaultFormats = []string{formatXML, formatCSV, formatMsgpack}
+
+// formatConversion is a middleware converting the bodies between JSON and other formats.
+type formatConversion struct {
+	next           http.Handler
+	name           string
+	request        bool
+	response       bool
+	xmlRootElement string
+	maxBodySize    int64
+
+	// formats holds the enabled formats, keyed by media type.
+	formats map[string]string
+	msgpack *codec.MsgpackHandle
+}
+
+// New creates a FormatConversion middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.FormatConversion, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeName)
+	logger.Debug().Msg("Creating middleware")
+
+	if !config.Request && !config.Response {
+		return nil, errors.New("neither the request nor the response is converted")
+	}
+
+	if config.MaxBodySize < 0 {
+		return nil, fmt.Errorf("invalid maxBodySize %d: must be positive", config.MaxBodySize)
+	}
+
+	f := &formatConversion{
+		next:           next,
+		name:           name,
+		request:        config.Request,
+		response:       config.Response,
+		xmlRootElement: config.XMLRootElement,
+		maxBodySize:    config.MaxBodySize,
+		formats:        make(map[string]string),
+		msgpack:        newMsgpackHandle(),
+	}
+
+	if f.xmlRootElement == "" {
+		f.xmlRootElement = defaultXMLRootElement
+	}
+
+	if xmlName(f.xmlRootElement) != f.xmlRootElement {
+		return nil, fmt.Errorf("invalid xmlRootElement %q", f.xmlRootElement)
+	}
+
+	if f.maxBodySize == 0 {
+		f.maxBodySize = defaultMaxBodySize
+	}
+
+	formats := config.Formats
+	if len(formats) == 0 {
+		formats = defaultFormats
+	}
+
+	for _, format := range formats {
+		mediaTypes, ok := formatMediaTypes[format]
+		if !ok {
+			return nil, fmt.Errorf("unsupported format %q", format)
+		}
+
+		for _, mediaType := range mediaTypes {
+			f.formats[mediaType] = format
+		}
+	}
+
+	return f, nil
+}
+
+func (f *formatConversion) GetTracingInformation() (string, string, trace.SpanKind) {
+	return f.name, typeName, trace.SpanKindInternal
+}
+
+func (f *formatConversion) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), f.name, typeName)
+
+	var requestFormat string
+	if f.request && req.Body != nil && req.Body != http.NoBody {
+		requestFormat = f.formatOf(req.Header.Get("Content-Type"))
+	}
+
+	if requestFormat != "" {
+		body, err := io.ReadAll(io.LimitReader(req.Body, f.maxBodySize+1))
+		if err != nil {
+			logger.Debug().Err(err).Msg("Unable to read the request body")
+			http.Error(rw, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		if int64(len(body)) > f.maxBodySize {
+			http.Error(rw, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		body, err = f.toJSON(requestFormat, body)
+		if err != nil {
+			logger.Debug().Err(err).Msgf("Unable to convert the %s request body", requestFormat)
+			http.Error(rw, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+		req.TransferEncoding = nil
+		req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		req.Header.Set("Content-Type", jsonMediaType)
+	}
+
+	if !f.response || req.Method == http.MethodHead {
+		f.next.ServeHTTP(rw, req)
+		return
+	}
+
+	// The format of the responses depends on the Accept header.
+	rw.Header().Add("Vary", "Accept")
+
+	responseFormat := f.negotiate(req.Header.Values("Accept"), requestFormat)
+	if responseFormat == "" {
+		f.next.ServeHTTP(rw, req)
+		return
+	}
+
+	// The service is asked for uncompressed JSON responses, to be able to convert them.
+	req.Header.Set("Accept", jsonMediaType)
+	req.Header.Del("Accept-Encoding")
+
+	writer := &convertWriter{
+		rw:          rw,
+		maxBodySize: f.maxBodySize,
+		mediaType:   formatMediaTypes[responseFormat][0],
+		convert: func(body []byte) ([]byte, error) {
+			return f.fromJSON(responseFormat, body)
+		},
+		onError: func(err error) {
+			logger.Debug().Err(err).Msgf("Unable to convert the response to %s, forwarded as is", responseFormat)
+		},
+	}
+	defer writer.finish()
+
+	f.next.ServeHTTP(writer, req)
+}
+
+// formatOf returns the enabled format of the given content type, if any.
+func (f *formatConversion) formatOf(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	return f.formats[mediaType]
+}
+
+// negotiate returns the format the responses are converted to, from the given Accept header values,
+// or an empty string if the JSON responses are accepted.
+// When the client accepts any media type, the responses are converted to the format of the request body, if any.
+func (f *formatConversion) negotiate(accept []string, requestFormat string) string {
+	type mediaRange struct {
+		mediaType string
+		quality   float64
+	}
+
+	var ranges []mediaRange
+	for _, value := range accept {
+		for _, item := range strings.Split(value, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(item))
+			if err != nil {
+				continue
+			}
+
+			quality := 1.0
+			if q, ok := params["q"]; ok {
+				quality, err = strconv.ParseFloat(q, 64)
+				if err != nil {
+					continue
+				}
+			}
+
+			if quality > 0 {
+				ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+			}
+		}
+	}
+
+	slices.SortStableFunc(ranges, func(a, b mediaRange) int {
+		switch {
+		case a.quality > b.quality:
+			return -1
+		case a.quality < b.quality:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	for _, r := range ranges {
+		switch {
+		case r.mediaType == "*/*":
+			return requestFormat
+		case isJSON(r.mediaType) || r.mediaType == "application/*":
+			return ""
+		case f.formats[r.mediaType] != "":
+			return f.formats[r.mediaType]
+		}
+	}
+
+	if len(ranges) == 0 {
+		return requestFormat
+	}
+
+	return ""
+}
+
+// toJSON converts a body in the given format to JSON.
+func (f *formatConversion) toJSON(format string, body []byte) ([]byte, error) {
+	var value interface{}
+	var err error
+
+	switch format {
+	case formatXML:
+		value, err = decodeXML(body)
+	case formatCSV:
+		value, err = decodeCSV(body)
+	case formatMsgpack:
+		value, err = decodeMsgpack(f.msgpack, body)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeJSON(value)
+}
+
+// fromJSON converts a JSON body to the given format.
+func (f *formatConversion) fromJSON(format string, body []byte) ([]byte, error) {
+	value, err := decodeJSON(body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case formatXML:
+		return encodeXML(f.xmlRootElement, value), nil
+	case formatCSV:
+		return encodeCSV(value)
+	case formatMsgpack:
+		return encodeMsgpack(f.msgpack, value)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
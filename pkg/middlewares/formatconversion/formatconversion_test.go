@@ -0,0 +1,305 @@
+package formatconversion
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/ugorji/go/codec"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.FormatConversion
+	}{
+		{
+			desc:   "nothing converted",
+			config: dynamic.FormatConversion{},
+		},
+		{
+			desc:   "unsupported format",
+			config: dynamic.FormatConversion{Response: true, Formats: []string{"yaml"}},
+		},
+		{
+			desc:   "invalid XML root element",
+			config: dynamic.FormatConversion{Response: true, XMLRootElement: "my root"},
+		},
+		{
+			desc:   "negative max body size",
+			config: dynamic.FormatConversion{Response: true, MaxBodySize: -1},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "formatConversion")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFormatConversion_response(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		config              dynamic.FormatConversion
+		accept              string
+		contentType         string
+		body                string
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			desc:                "JSON accepted",
+			accept:              "application/json",
+			body:                `{"id":1}`,
+			expectedContentType: "application/json",
+			expectedBody:        `{"id":1}`,
+		},
+		{
+			desc:                "any media type accepted",
+			accept:              "*/*",
+			body:                `{"id":1}`,
+			expectedContentType: "application/json",
+			expectedBody:        `{"id":1}`,
+		},
+		{
+			desc:                "JSON preferred",
+			accept:              "application/xml;q=0.5, application/json",
+			body:                `{"id":1}`,
+			expectedContentType: "application/json",
+			expectedBody:        `{"id":1}`,
+		},
+		{
+			desc:                "XML accepted",
+			accept:              "application/xml",
+			body:                `{"id":1,"name":"Tom & Jerry","tags":["a","b"],"owner":{"id":2},"deleted":null,"1st":true}`,
+			expectedContentType: "application/xml",
+			expectedBody:        `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<root><_1st>true</_1st><deleted/><id>1</id><name>Tom &amp; Jerry</name><owner><id>2</id></owner><tags>a</tags><tags>b</tags></root>`,
+		},
+		{
+			desc:                "XML array with a custom root element",
+			config:              dynamic.FormatConversion{XMLRootElement: "users"},
+			accept:              "text/xml",
+			body:                `[{"id":1},{"id":2}]`,
+			expectedContentType: "application/xml",
+			expectedBody:        `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<users><item><id>1</id></item><item><id>2</id></item></users>`,
+		},
+		{
+			desc:                "CSV accepted",
+			accept:              "text/csv",
+			body:                `[{"id":1,"name":"Tom, Jerry"},{"id":2.5,"tags":["a"]}]`,
+			expectedContentType: "text/csv",
+			expectedBody:        "id,name,tags\n1,\"Tom, Jerry\",\n2.5,,\"[\"\"a\"\"]\"\n",
+		},
+		{
+			desc:                "CSV not convertible",
+			accept:              "text/csv",
+			body:                `[1,2]`,
+			expectedContentType: "application/json",
+			expectedBody:        `[1,2]`,
+		},
+		{
+			desc:                "invalid JSON",
+			accept:              "application/xml",
+			body:                `{"id":`,
+			expectedContentType: "application/json",
+			expectedBody:        `{"id":`,
+		},
+		{
+			desc:                "format not enabled",
+			config:              dynamic.FormatConversion{Formats: []string{"csv"}},
+			accept:              "application/xml",
+			body:                `{"id":1}`,
+			expectedContentType: "application/json",
+			expectedBody:        `{"id":1}`,
+		},
+		{
+			desc:                "not a JSON response",
+			accept:              "application/xml",
+			contentType:         "text/plain",
+			body:                `hello`,
+			expectedContentType: "text/plain",
+			expectedBody:        `hello`,
+		},
+		{
+			desc:                "response too large",
+			config:              dynamic.FormatConversion{MaxBodySize: 4},
+			accept:              "application/xml",
+			body:                `{"id":1}`,
+			expectedContentType: "application/json",
+			expectedBody:        `{"id":1}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			contentType := test.contentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+
+			var forwardedAccept string
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwardedAccept = req.Header.Get("Accept")
+
+				rw.Header().Set("Content-Type", contentType)
+				_, _ = io.WriteString(rw, test.body[:len(test.body)/2])
+				_, _ = io.WriteString(rw, test.body[len(test.body)/2:])
+			})
+
+			config := test.config
+			config.Response = true
+
+			handler, err := New(context.Background(), next, config, "formatConversion")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept", test.accept)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, http.StatusOK, rw.Code)
+			assert.Equal(t, test.expectedContentType, rw.Header().Get("Content-Type"))
+			assert.Equal(t, test.expectedBody, rw.Body.String())
+			assert.Equal(t, "Accept", rw.Header().Get("Vary"))
+
+			if test.expectedContentType != test.contentType && test.expectedContentType != "application/json" {
+				assert.Equal(t, "application/json", forwardedAccept)
+			}
+		})
+	}
+}
+
+func TestFormatConversion_request(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		contentType    string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			desc:           "XML body",
+			contentType:    "application/xml; charset=utf-8",
+			body:           `<?xml version="1.0"?><user id="1"><name>Tom</name><tag>a</tag><tag>b</tag></user>`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"1","name":"Tom","tag":["a","b"]}`,
+		},
+		{
+			desc:           "XML array",
+			contentType:    "text/xml",
+			body:           `<users><item><id>1</id></item><item><id>2</id></item></users>`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"id":"1"},{"id":"2"}]`,
+		},
+		{
+			desc:           "CSV body",
+			contentType:    "text/csv",
+			body:           "id,name\n1,Tom\n2,\"Jerry, Jr\"\n",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"id":"1","name":"Tom"},{"id":"2","name":"Jerry, Jr"}]`,
+		},
+		{
+			desc:           "JSON body",
+			contentType:    "application/json",
+			body:           `{"id":1}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":1}`,
+		},
+		{
+			desc:           "invalid XML body",
+			contentType:    "application/xml",
+			body:           `<user><name>Tom</user>`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			desc:           "body too large",
+			contentType:    "text/csv",
+			body:           strings.Repeat("a", 129),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwarded *http.Request
+			var forwardedBody string
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwarded = req
+
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				forwardedBody = string(body)
+			})
+
+			handler, err := New(context.Background(), next, dynamic.FormatConversion{Request: true, MaxBodySize: 128}, "formatConversion")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+			req.Header.Set("Content-Type", test.contentType)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			require.Equal(t, test.expectedStatus, rw.Code)
+			if test.expectedStatus != http.StatusOK {
+				assert.Nil(t, forwarded)
+				return
+			}
+
+			assert.JSONEq(t, test.expectedBody, forwardedBody)
+			assert.Equal(t, "application/json", forwarded.Header.Get("Content-Type"))
+			assert.Equal(t, int64(len(forwardedBody)), forwarded.ContentLength)
+		})
+	}
+}
+
+func TestFormatConversion_msgpack(t *testing.T) {
+	h := newMsgpackHandle()
+
+	var requestBody []byte
+	require.NoError(t, codec.NewEncoderBytes(&requestBody, h).Encode(map[string]interface{}{"id": 1, "name": "Tom"}))
+
+	var forwardedBody string
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		forwardedBody = string(body)
+
+		rw.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(rw, `{"id":1,"price":9.99,"tags":["a"]}`)
+	})
+
+	handler, err := New(context.Background(), next, dynamic.FormatConversion{Request: true, Response: true}, "formatConversion")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(requestBody)))
+	req.Header.Set("Content-Type", "application/msgpack")
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	require.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `{"id":1,"name":"Tom"}`, forwardedBody)
+
+	// Without an Accept header, the response has the format of the request body.
+	assert.Equal(t, "application/msgpack", rw.Header().Get("Content-Type"))
+
+	var response map[string]interface{}
+	require.NoError(t, codec.NewDecoderBytes(rw.Body.Bytes(), h).Decode(&response))
+	assert.Equal(t, map[string]interface{}{"id": int64(1), "price": 9.99, "tags": []interface{}{"a"}}, response)
+}
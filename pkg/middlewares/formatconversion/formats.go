@@ -0,0 +1,412 @@
+package formatconversion
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	formatXML     = "xml"
+	formatCSV     = "csv"
+	formatMsgpack = "msgpack"
+)
+
+// jsonMediaType is the media type of the bodies exchanged with the service.
+const jsonMediaType = "application/json"
+
+// formatMediaTypes are the media types of the formats, the first one being the media type of the converted bodies.
+var formatMediaTypes = map[string][]string{
+	formatXML:     {"application/xml", "text/xml"},
+	formatCSV:     {"text/csv"},
+	formatMsgpack: {"application/msgpack", "application/x-msgpack", "application/vnd.msgpack"},
+}
+
+// xmlArrayItem is the name of the XML elements holding the items of the arrays which are not object fields.
+const xmlArrayItem = "item"
+
+// xmlTextField is the JSON field holding the text of the XML elements having attributes or child elements.
+const xmlTextField = "#text"
+
+// isJSON reports whether the given media type is a JSON one.
+func isJSON(mediaType string) bool {
+	return mediaType == jsonMediaType || strings.HasSuffix(mediaType, "+json")
+}
+
+// decodeJSON decodes a JSON body, keeping the numbers as written.
+func decodeJSON(body []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+
+	return value, nil
+}
+
+func encodeJSON(value interface{}) ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
+
+// encodeXML encodes a JSON value as an XML document with the given root element.
+// The object fields are encoded as child elements, repeated for the arrays,
+// and the other array items as item elements.
+func encodeXML(root string, value interface{}) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+
+	writeXMLElement(&b, root, value)
+
+	return b.Bytes()
+}
+
+func writeXMLElement(b *bytes.Buffer, name string, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		b.WriteString("<" + name + "/>")
+
+	case []interface{}:
+		b.WriteString("<" + name + ">")
+		for _, item := range v {
+			writeXMLElement(b, xmlArrayItem, item)
+		}
+		b.WriteString("</" + name + ">")
+
+	case map[string]interface{}:
+		b.WriteString("<" + name + ">")
+		for _, key := range sortedKeys(v) {
+			childName := xmlName(key)
+
+			if items, ok := v[key].([]interface{}); ok {
+				for _, item := range items {
+					writeXMLElement(b, childName, item)
+				}
+				continue
+			}
+
+			writeXMLElement(b, childName, v[key])
+		}
+		b.WriteString("</" + name + ">")
+
+	default:
+		b.WriteString("<" + name + ">")
+		_ = xml.EscapeText(b, []byte(scalarString(v)))
+		b.WriteString("</" + name + ">")
+	}
+}
+
+// xmlName returns the given object key made a valid XML element name.
+func xmlName(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+		case unicode.IsDigit(r) || r == '-' || r == '.':
+			if i == 0 {
+				b.WriteRune('_')
+			}
+		default:
+			r = '_'
+		}
+
+		b.WriteRune(r)
+	}
+
+	if b.Len() == 0 {
+		return "_"
+	}
+
+	return b.String()
+}
+
+// decodeXML decodes an XML document to the JSON value of its root element.
+// The attributes and the child elements are decoded as object fields, the repeated ones as arrays,
+// and the elements holding only text as strings.
+func decodeXML(body []byte) (interface{}, error) {
+	dec := xml.NewDecoder(bytes.NewReader(body))
+
+	for {
+		token, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("no root element")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		value, err := decodeXMLElement(dec, start)
+		if err != nil {
+			return nil, err
+		}
+
+		// The root element holding only items is an array.
+		if fields, ok := value.(map[string]interface{}); ok && len(fields) == 1 {
+			if items, ok := fields[xmlArrayItem].([]interface{}); ok {
+				return items, nil
+			}
+		}
+
+		return value, nil
+	}
+}
+
+func decodeXMLElement(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	fields := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		addField(fields, attr.Name.Local, attr.Value)
+	}
+
+	var text strings.Builder
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			value, err := decodeXMLElement(dec, t)
+			if err != nil {
+				return nil, err
+			}
+
+			addField(fields, t.Name.Local, value)
+
+		case xml.CharData:
+			text.Write(t)
+
+		case xml.EndElement:
+			s := strings.TrimSpace(text.String())
+			if len(fields) == 0 {
+				return s, nil
+			}
+
+			if s != "" {
+				fields[xmlTextField] = s
+			}
+
+			return fields, nil
+		}
+	}
+}
+
+// addField adds a field to the given object, the repeated fields being collected in an array.
+func addField(fields map[string]interface{}, name string, value interface{}) {
+	existing, ok := fields[name]
+	if !ok {
+		fields[name] = value
+		return
+	}
+
+	if items, ok := existing.([]interface{}); ok {
+		fields[name] = append(items, value)
+		return
+	}
+
+	fields[name] = []interface{}{existing, value}
+}
+
+// encodeCSV encodes a JSON object, or array of objects, as CSV records.
+// The header record holds the sorted keys of the objects, and the nested values are encoded as JSON.
+func encodeCSV(value interface{}) ([]byte, error) {
+	var rows []map[string]interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		rows = append(rows, v)
+
+	case []interface{}:
+		for _, item := range v {
+			row, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("array items are not objects")
+			}
+
+			rows = append(rows, row)
+		}
+
+	default:
+		return nil, errors.New("neither an object nor an array")
+	}
+
+	var columns []string
+	for _, row := range rows {
+		for key := range row {
+			if !slices.Contains(columns, key) {
+				columns = append(columns, key)
+			}
+		}
+	}
+
+	slices.Sort(columns)
+
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+
+	if err := w.Write(columns); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			switch v := row[column].(type) {
+			case nil:
+			case map[string]interface{}, []interface{}:
+				data, err := encodeJSON(v)
+				if err != nil {
+					return nil, err
+				}
+
+				record[i] = string(data)
+			default:
+				record[i] = scalarString(v)
+			}
+		}
+
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+
+	return b.Bytes(), w.Error()
+}
+
+// decodeCSV decodes CSV records to an array of objects, keyed by the fields of the header record.
+func decodeCSV(body []byte) (interface{}, error) {
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]interface{}, 0, max(len(records)-1, 0))
+	if len(records) == 0 {
+		return rows, nil
+	}
+
+	header := records[0]
+	for _, record := range records[1:] {
+		row := make(map[string]interface{}, len(header))
+		for i, field := range record {
+			row[header[i]] = field
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func newMsgpackHandle() *codec.MsgpackHandle {
+	h := &codec.MsgpackHandle{}
+	h.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	h.RawToString = true
+	// WriteExt enables the str8 and bin types of the current MessagePack specification.
+	h.WriteExt = true
+
+	return h
+}
+
+func encodeMsgpack(h *codec.MsgpackHandle, value interface{}) ([]byte, error) {
+	var out []byte
+	if err := codec.NewEncoderBytes(&out, h).Encode(msgpackValue(value)); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// msgpackValue returns the given JSON value with its numbers converted to integers or floats.
+func msgpackValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := v.Float64()
+		return f
+
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = msgpackValue(item)
+		}
+		return items
+
+	case map[string]interface{}:
+		fields := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			fields[key] = msgpackValue(field)
+		}
+		return fields
+
+	default:
+		return v
+	}
+}
+
+func decodeMsgpack(h *codec.MsgpackHandle, body []byte) (interface{}, error) {
+	var value interface{}
+	if err := codec.NewDecoderBytes(body, h).Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func scalarString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
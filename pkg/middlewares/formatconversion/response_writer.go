@@ -0,0 +1,143 @@
+package formatconversion
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// convertWriter holds the JSON responses of the next handler, to write them converted once entirely written.
+// The other responses, and the JSON ones larger than the maximum body size, are written as is.
+type convertWriter struct {
+	rw          http.ResponseWriter
+	maxBodySize int64
+	mediaType   string
+	convert     func(body []byte) ([]byte, error)
+	onError     func(err error)
+
+	status     int
+	converting bool
+	buffer     bytes.Buffer
+}
+
+func (w *convertWriter) Header() http.Header {
+	return w.rw.Header()
+}
+
+func (w *convertWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+
+	// The informational responses are forwarded, as they precede the final response.
+	if status >= 100 && status < 200 && status != http.StatusSwitchingProtocols {
+		w.rw.WriteHeader(status)
+		return
+	}
+
+	w.status = status
+
+	if status != http.StatusNoContent && status != http.StatusNotModified && isJSONBody(w.rw.Header()) {
+		// The status is written with the converted body.
+		w.converting = true
+		return
+	}
+
+	w.rw.WriteHeader(status)
+}
+
+func (w *convertWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !w.converting {
+		return w.rw.Write(b)
+	}
+
+	if int64(w.buffer.Len()+len(b)) > w.maxBodySize {
+		w.onError(fmt.Errorf("body larger than %d bytes", w.maxBodySize))
+
+		if err := w.writeHeld(); err != nil {
+			return 0, err
+		}
+
+		return w.rw.Write(b)
+	}
+
+	return w.buffer.Write(b)
+}
+
+// Hijack hijacks the connection.
+func (w *convertWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.rw.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+
+	return nil, nil, fmt.Errorf("not a hijacker: %T", w.rw)
+}
+
+// Flush sends the written body to the client, unless it is being converted.
+func (w *convertWriter) Flush() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.converting {
+		return
+	}
+
+	if flusher, ok := w.rw.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// finish writes the converted body, or the held one when it cannot be converted.
+func (w *convertWriter) finish() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !w.converting {
+		return
+	}
+
+	body, err := w.convert(w.buffer.Bytes())
+	if err != nil {
+		w.onError(err)
+		_ = w.writeHeld()
+		return
+	}
+
+	header := w.rw.Header()
+	header.Set("Content-Type", w.mediaType)
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	w.rw.WriteHeader(w.status)
+	_, _ = w.rw.Write(body)
+}
+
+// writeHeld writes the held status and body, and stops converting the response.
+func (w *convertWriter) writeHeld() error {
+	w.converting = false
+
+	w.rw.WriteHeader(w.status)
+	_, err := w.rw.Write(w.buffer.Bytes())
+
+	return err
+}
+
+// isJSONBody reports whether the given headers describe an uncompressed JSON body.
+func isJSONBody(header http.Header) bool {
+	if encoding := header.Get("Content-Encoding"); encoding != "" && !strings.EqualFold(encoding, "identity") {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && isJSON(mediaType)
+}
@@ -0,0 +1,145 @@
+package geoip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikgeoip "github.com/traefik/traefik/v3/pkg/geoip"
+	"github.com/traefik/traefik/v3/pkg/ip"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "GeoIP"
+
+// Headers forwarding the location of the client IP to the services.
+const (
+	CountryHeader        = "X-GeoIP-Country"
+	ASNHeader            = "X-GeoIP-ASN"
+	ASOrganizationHeader = "X-GeoIP-AS-Organization"
+)
+
+// geoIP is a middleware resolving the location of the client IP.
+type geoIP struct {
+	next             http.Handler
+	name             string
+	resolver         *traefikgeoip.Resolver
+	strategy         ip.Strategy
+	allowedCountries map[string]struct{}
+	deniedCountries  map[string]struct{}
+	rejectStatusCode int
+}
+
+// New creates a GeoIP middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.GeoIP, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeName)
+	logger.Debug().Msg("Creating middleware")
+
+	if len(config.AllowedCountries) > 0 && len(config.DeniedCountries) > 0 {
+		return nil, errors.New("allowedCountries and deniedCountries are mutually exclusive")
+	}
+
+	rejectStatusCode := config.RejectStatusCode
+	// If RejectStatusCode is not given, default to Forbidden (403).
+	if rejectStatusCode == 0 {
+		rejectStatusCode = http.StatusForbidden
+	} else if http.StatusText(rejectStatusCode) == "" {
+		return nil, fmt.Errorf("invalid HTTP status code %d", rejectStatusCode)
+	}
+
+	resolver, err := traefikgeoip.NewResolver(config.Databases)
+	if err != nil {
+		return nil, err
+	}
+
+	strategy, err := config.IPStrategy.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return &geoIP{
+		next:             next,
+		name:             name,
+		resolver:         resolver,
+		strategy:         strategy,
+		allowedCountries: toSet(config.AllowedCountries),
+		deniedCountries:  toSet(config.DeniedCountries),
+		rejectStatusCode: rejectStatusCode,
+	}, nil
+}
+
+func (g *geoIP) GetTracingInformation() (string, string, trace.SpanKind) {
+	return g.name, typeName, trace.SpanKindInternal
+}
+
+func (g *geoIP) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), g.name, typeName)
+
+	// The headers sent by the client are never forwarded, as they would spoof the location.
+	req.Header.Del(CountryHeader)
+	req.Header.Del(ASNHeader)
+	req.Header.Del(ASOrganizationHeader)
+
+	clientIP := g.strategy.GetIP(req)
+
+	var location traefikgeoip.Location
+	if parsedIP := net.ParseIP(clientIP); parsedIP != nil {
+		var err error
+		location, err = g.resolver.Lookup(parsedIP)
+		if err != nil {
+			logger.Debug().Err(err).Msg("Unable to resolve client IP location")
+		}
+	}
+
+	if !g.isAllowed(location.CountryCode) {
+		logger.Debug().Msgf("Rejecting IP %s from country %q", clientIP, location.CountryCode)
+		observability.SetStatusErrorf(req.Context(), "Rejecting IP %s from country %q", clientIP, location.CountryCode)
+
+		rw.WriteHeader(g.rejectStatusCode)
+		if _, err := rw.Write([]byte(http.StatusText(g.rejectStatusCode))); err != nil {
+			log.Ctx(req.Context()).Error().Err(err).Send()
+		}
+		return
+	}
+
+	if location.CountryCode != "" {
+		req.Header.Set(CountryHeader, location.CountryCode)
+	}
+	if location.ASN != 0 {
+		req.Header.Set(ASNHeader, strconv.FormatUint(uint64(location.ASN), 10))
+	}
+	if location.ASOrganization != "" {
+		req.Header.Set(ASOrganizationHeader, location.ASOrganization)
+	}
+
+	g.next.ServeHTTP(rw, req)
+}
+
+// isAllowed returns whether the given country is allowed.
+// When allowed countries are defined, the requests from an unknown country are rejected.
+func (g *geoIP) isAllowed(country string) bool {
+	if len(g.allowedCountries) > 0 {
+		_, ok := g.allowedCountries[country]
+		return ok
+	}
+
+	_, denied := g.deniedCountries[country]
+	return !denied
+}
+
+func toSet(countries []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(countries))
+	for _, country := range countries {
+		set[strings.ToUpper(country)] = struct{}{}
+	}
+
+	return set
+}
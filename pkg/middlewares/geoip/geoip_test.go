@@ -0,0 +1,161 @@
+package geoip
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+var databases = []string{"../../geoip/fixtures/country.mmdb", "../../geoip/fixtures/asn.mmdb"}
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.GeoIP
+	}{
+		{
+			desc:   "no database",
+			config: dynamic.GeoIP{},
+		},
+		{
+			desc:   "unknown database",
+			config: dynamic.GeoIP{Databases: []string{"./unknown.mmdb"}},
+		},
+		{
+			desc: "allowed and denied countries",
+			config: dynamic.GeoIP{
+				Databases:        databases,
+				AllowedCountries: []string{"FR"},
+				DeniedCountries:  []string{"US"},
+			},
+		},
+		{
+			desc:   "invalid reject status code",
+			config: dynamic.GeoIP{Databases: databases, RejectStatusCode: 42},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "geoip")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGeoIP_ServeHTTP(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		config          dynamic.GeoIP
+		remoteAddr      string
+		xForwardedFor   string
+		requestHeaders  map[string]string
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			desc:           "location headers",
+			config:         dynamic.GeoIP{Databases: databases},
+			remoteAddr:     "1.2.3.4:1234",
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				CountryHeader:        "FR",
+				ASNHeader:            "64496",
+				ASOrganizationHeader: "Example Org",
+			},
+		},
+		{
+			desc:           "unknown location",
+			config:         dynamic.GeoIP{Databases: databases},
+			remoteAddr:     "10.0.0.1:1234",
+			requestHeaders: map[string]string{CountryHeader: "FR"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				CountryHeader:        "",
+				ASNHeader:            "",
+				ASOrganizationHeader: "",
+			},
+		},
+		{
+			desc:           "allowed country",
+			config:         dynamic.GeoIP{Databases: databases, AllowedCountries: []string{"fr"}},
+			remoteAddr:     "1.2.3.4:1234",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "not allowed country",
+			config:         dynamic.GeoIP{Databases: databases, AllowedCountries: []string{"FR"}},
+			remoteAddr:     "2.3.4.5:1234",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "unknown country with allowed countries",
+			config:         dynamic.GeoIP{Databases: databases, AllowedCountries: []string{"FR"}},
+			remoteAddr:     "10.0.0.1:1234",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "denied country",
+			config:         dynamic.GeoIP{Databases: databases, DeniedCountries: []string{"US"}, RejectStatusCode: http.StatusNotFound},
+			remoteAddr:     "2.3.4.5:1234",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			desc:           "unknown country with denied countries",
+			config:         dynamic.GeoIP{Databases: databases, DeniedCountries: []string{"US"}},
+			remoteAddr:     "10.0.0.1:1234",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc: "IP strategy",
+			config: dynamic.GeoIP{
+				Databases:        databases,
+				AllowedCountries: []string{"US"},
+				IPStrategy:       &dynamic.IPStrategy{Depth: 1},
+			},
+			remoteAddr:      "1.2.3.4:1234",
+			xForwardedFor:   "2.3.4.5",
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{CountryHeader: "US"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwardedHeaders http.Header
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwardedHeaders = req.Header
+			})
+
+			handler, err := New(context.Background(), next, test.config, "geoip")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+			req.RemoteAddr = test.remoteAddr
+			if test.xForwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", test.xForwardedFor)
+			}
+			for name, value := range test.requestHeaders {
+				req.Header.Set(name, value)
+			}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+
+			for name, value := range test.expectedHeaders {
+				assert.Equal(t, value, forwardedHeaders.Get(name), name)
+			}
+		})
+	}
+}
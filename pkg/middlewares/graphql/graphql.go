@@ -0,0 +1,641 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/metrics"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "GraphQL"
+
+const defaultMaxBodyBytes = 1 << 20
+
+// maxScore caps the complexity scores, for the scoring not to overflow.
+const maxScore = math.MaxInt32
+
+// Results of the GraphQL requests, as reported by the metrics.
+const (
+	resultAllowed                = "allowed"
+	resultInvalid                = "invalid"
+	resultOperationNotAllowed    = "operationNotAllowed"
+	resultPersistedQueryNotFound = "persistedQueryNotFound"
+	resultNotPersisted           = "notPersisted"
+	resultIntrospection          = "introspection"
+	resultMaxDepth               = "maxDepth"
+	resultMaxComplexity          = "maxComplexity"
+)
+
+var errBodyTooLarge = errors.New("body too large")
+
+// listSizeArguments are the arguments defining the number of items returned by a list field.
+var listSizeArguments = []string{"first", "last", "limit"}
+
+// graphQL is a middleware protecting the GraphQL backends from the expensive or unexpected operations.
+type graphQL struct {
+	next                 http.Handler
+	name                 string
+	maxDepth             int
+	maxComplexity        int
+	allowedOperations    map[string]struct{}
+	persistedQueries     map[string]string
+	persistedQueriesOnly bool
+	blockIntrospection   bool
+	maxBodyBytes         int64
+	reqsCounter          gokitmetrics.Counter
+}
+
+// New creates a GraphQL middleware.
+// The GraphQL requests are counted with the given metrics registry, which can be nil.
+func New(ctx context.Context, next http.Handler, config dynamic.GraphQL, name string, registry metrics.Registry) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if config.MaxDepth < 0 {
+		return nil, fmt.Errorf("negative maxDepth %d", config.MaxDepth)
+	}
+
+	if config.MaxComplexity < 0 {
+		return nil, fmt.Errorf("negative maxComplexity %d", config.MaxComplexity)
+	}
+
+	if config.MaxBodyBytes < 0 {
+		return nil, fmt.Errorf("negative maxBodyBytes %d", config.MaxBodyBytes)
+	}
+
+	if config.PersistedQueriesOnly && config.PersistedQueriesFile == "" {
+		return nil, errors.New("persistedQueriesOnly requires a persistedQueriesFile")
+	}
+
+	g := &graphQL{
+		next:                 next,
+		name:                 name,
+		maxDepth:             config.MaxDepth,
+		maxComplexity:        config.MaxComplexity,
+		persistedQueriesOnly: config.PersistedQueriesOnly,
+		blockIntrospection:   config.BlockIntrospection,
+		maxBodyBytes:         config.MaxBodyBytes,
+	}
+
+	if g.maxBodyBytes == 0 {
+		g.maxBodyBytes = defaultMaxBodyBytes
+	}
+
+	if len(config.AllowedOperations) > 0 {
+		g.allowedOperations = make(map[string]struct{}, len(config.AllowedOperations))
+		for _, operation := range config.AllowedOperations {
+			g.allowedOperations[operation] = struct{}{}
+		}
+	}
+
+	if config.PersistedQueriesFile != "" {
+		var err error
+		g.persistedQueries, err = loadPersistedQueries(config.PersistedQueriesFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading persisted queries: %w", err)
+		}
+	}
+
+	if registry != nil {
+		g.reqsCounter = registry.GraphQLReqsCounter()
+	}
+
+	return g, nil
+}
+
+func (g *graphQL) GetTracingInformation() (string, string, trace.SpanKind) {
+	return g.name, typeName, trace.SpanKindInternal
+}
+
+func (g *graphQL) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		g.serveGet(rw, req)
+	case http.MethodPost:
+		g.servePost(rw, req)
+	default:
+		g.next.ServeHTTP(rw, req)
+	}
+}
+
+// serveGet handles the GraphQL requests whose parameters are in the query string.
+// The GET requests without GraphQL parameters, e.g. the ones of a GraphQL IDE, are forwarded as is.
+func (g *graphQL) serveGet(rw http.ResponseWriter, req *http.Request) {
+	values := req.URL.Query()
+	if !values.Has("query") && !values.Has("extensions") {
+		g.next.ServeHTTP(rw, req)
+		return
+	}
+
+	gqlReq := &request{
+		query:         values.Get("query"),
+		operationName: values.Get("operationName"),
+	}
+
+	if variables := values.Get("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &gqlReq.variables); err != nil {
+			g.reject(rw, req, "", resultInvalid, fmt.Errorf("parsing variables: %w", err))
+			return
+		}
+	}
+
+	if extensions := values.Get("extensions"); extensions != "" {
+		var ext requestExtensions
+		if err := json.Unmarshal([]byte(extensions), &ext); err != nil {
+			g.reject(rw, req, "", resultInvalid, fmt.Errorf("parsing extensions: %w", err))
+			return
+		}
+
+		gqlReq.hash = ext.PersistedQuery.SHA256Hash
+	}
+
+	if !g.check(rw, req, gqlReq) {
+		return
+	}
+
+	if gqlReq.persisted {
+		values.Set("query", gqlReq.query)
+		req.URL.RawQuery = values.Encode()
+		req.RequestURI = req.URL.RequestURI()
+	}
+
+	g.next.ServeHTTP(rw, req)
+}
+
+// servePost handles the GraphQL requests whose parameters are in the body,
+// either as a JSON object, a JSON array of objects for the batched requests, or as a GraphQL document.
+func (g *graphQL) servePost(rw http.ResponseWriter, req *http.Request) {
+	// An invalid or missing Content-Type is handled as an empty media type, which is never supported.
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+
+	graphQLDocument := mediaType == "application/graphql"
+	if !graphQLDocument && mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+		g.reject(rw, req, "", resultInvalid, fmt.Errorf("unsupported content type %q", mediaType))
+		return
+	}
+
+	if req.ContentLength > g.maxBodyBytes {
+		g.reject(rw, req, "", resultInvalid, fmt.Errorf("%w: size %d exceeds the limit of %d bytes", errBodyTooLarge, req.ContentLength, g.maxBodyBytes))
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(rw, req.Body, g.maxBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = fmt.Errorf("%w: size exceeds the limit of %d bytes", errBodyTooLarge, g.maxBodyBytes)
+		}
+
+		g.reject(rw, req, "", resultInvalid, err)
+		return
+	}
+
+	if graphQLDocument {
+		if !g.check(rw, req, &request{query: string(body)}) {
+			return
+		}
+
+		g.forward(rw, req, body)
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	batch := len(body) > 0 && body[0] == '['
+
+	// The items are not decoded into a slice holding the body, as they would overwrite it.
+	var items []json.RawMessage
+	if batch {
+		if err := json.Unmarshal(body, &items); err != nil {
+			g.reject(rw, req, "", resultInvalid, fmt.Errorf("parsing body: %w", err))
+			return
+		}
+
+		if len(items) == 0 {
+			g.reject(rw, req, "", resultInvalid, errors.New("empty batch"))
+			return
+		}
+	} else {
+		items = []json.RawMessage{body}
+	}
+
+	var rewritten bool
+	for i, item := range items {
+		gqlReq, err := decodeRequest(item)
+		if err != nil {
+			g.reject(rw, req, "", resultInvalid, fmt.Errorf("parsing body: %w", err))
+			return
+		}
+
+		if !g.check(rw, req, gqlReq) {
+			return
+		}
+
+		if gqlReq.persisted {
+			items[i], err = withQuery(item, gqlReq.query)
+			if err != nil {
+				g.reject(rw, req, "", resultInvalid, err)
+				return
+			}
+
+			rewritten = true
+		}
+	}
+
+	if rewritten {
+		if batch {
+			body, err = json.Marshal(items)
+		} else {
+			body, err = json.Marshal(items[0])
+		}
+
+		if err != nil {
+			log.Ctx(req.Context()).Error().Err(err).Send()
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	g.forward(rw, req, body)
+}
+
+func (g *graphQL) forward(rw http.ResponseWriter, req *http.Request, body []byte) {
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+
+	g.next.ServeHTTP(rw, req)
+}
+
+// check checks the given GraphQL request, and writes the response rejecting it if it is not allowed.
+// The query of a request referencing a persisted query by its hash is filled in.
+func (g *graphQL) check(rw http.ResponseWriter, req *http.Request, gqlReq *request) bool {
+	if gqlReq.query == "" && gqlReq.hash != "" {
+		query, ok := g.persistedQueries[strings.ToLower(gqlReq.hash)]
+		if !ok {
+			g.reject(rw, req, "", resultPersistedQueryNotFound, errors.New("PersistedQueryNotFound"))
+			return false
+		}
+
+		gqlReq.query = query
+		gqlReq.persisted = true
+	}
+
+	if gqlReq.query == "" {
+		g.reject(rw, req, "", resultInvalid, errors.New("missing query"))
+		return false
+	}
+
+	if g.persistedQueriesOnly && !gqlReq.persisted {
+		if _, ok := g.persistedQueries[hash(gqlReq.query)]; !ok {
+			g.reject(rw, req, "", resultNotPersisted, errors.New("only the persisted queries are allowed"))
+			return false
+		}
+	}
+
+	doc, err := parse(gqlReq.query)
+	if err != nil {
+		g.reject(rw, req, "", resultInvalid, fmt.Errorf("parsing query: %w", err))
+		return false
+	}
+
+	op, err := doc.operation(gqlReq.operationName)
+	if err != nil {
+		g.reject(rw, req, "", resultInvalid, err)
+		return false
+	}
+
+	if g.allowedOperations != nil {
+		if _, ok := g.allowedOperations[op.name]; !ok {
+			g.reject(rw, req, op.name, resultOperationNotAllowed, fmt.Errorf("operation %q is not allowed", op.name))
+			return false
+		}
+	}
+
+	a := &analyzer{
+		fragments: doc.fragments,
+		variables: gqlReq.variables,
+		defaults:  op.defaults,
+		results:   make(map[string]*analysis),
+	}
+
+	result, err := a.analyzeSelectionSet(op.selectionSet)
+	if err != nil {
+		g.reject(rw, req, op.name, resultInvalid, err)
+		return false
+	}
+
+	if g.blockIntrospection && result.introspection {
+		g.reject(rw, req, op.name, resultIntrospection, errors.New("introspection is not allowed"))
+		return false
+	}
+
+	if g.maxDepth > 0 && result.depth > g.maxDepth {
+		g.reject(rw, req, op.name, resultMaxDepth, fmt.Errorf("query depth %d exceeds the limit of %d", result.depth, g.maxDepth))
+		return false
+	}
+
+	if g.maxComplexity > 0 && result.complexity > g.maxComplexity {
+		g.reject(rw, req, op.name, resultMaxComplexity, fmt.Errorf("query complexity %d exceeds the limit of %d", result.complexity, g.maxComplexity))
+		return false
+	}
+
+	g.record(op.name, resultAllowed)
+
+	return true
+}
+
+func (g *graphQL) reject(rw http.ResponseWriter, req *http.Request, operation, result string, reason error) {
+	logger := middlewares.GetLogger(req.Context(), g.name, typeName)
+	logger.Debug().Err(reason).Msg("Rejecting request")
+
+	observability.SetStatusErrorf(req.Context(), "Rejecting request: %v", reason)
+
+	g.record(operation, result)
+
+	respErr := responseError{Message: reason.Error()}
+	statusCode := http.StatusBadRequest
+
+	// The clients send the query of a persisted query they do not know yet on such errors,
+	// which are reported with a 200 status code as the clients expect them.
+	switch {
+	case result == resultPersistedQueryNotFound:
+		respErr.Extensions = map[string]string{"code": "PERSISTED_QUERY_NOT_FOUND"}
+		statusCode = http.StatusOK
+	case errors.Is(reason, errBodyTooLarge):
+		statusCode = http.StatusRequestEntityTooLarge
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(rw).Encode(response{Errors: []responseError{respErr}}); err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Send()
+	}
+}
+
+func (g *graphQL) record(operation, result string) {
+	if g.reqsCounter != nil {
+		g.reqsCounter.With("middleware", g.name, "operation", operation, "result", result).Add(1)
+	}
+}
+
+// request is a GraphQL request.
+type request struct {
+	query         string
+	operationName string
+	variables     map[string]any
+	// hash is the SHA-256 hash of the persisted query referenced by the request.
+	hash string
+	// persisted is whether the query has been filled in from the persisted queries.
+	persisted bool
+}
+
+type requestExtensions struct {
+	PersistedQuery struct {
+		SHA256Hash string `json:"sha256Hash"`
+	} `json:"persistedQuery"`
+}
+
+// decodeRequest decodes a GraphQL request in the JSON format.
+func decodeRequest(data []byte) (*request, error) {
+	var fields struct {
+		Query         string            `json:"query"`
+		OperationName string            `json:"operationName"`
+		Variables     map[string]any    `json:"variables"`
+		Extensions    requestExtensions `json:"extensions"`
+	}
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	return &request{
+		query:         fields.Query,
+		operationName: fields.OperationName,
+		variables:     fields.Variables,
+		hash:          fields.Extensions.PersistedQuery.SHA256Hash,
+	}, nil
+}
+
+// withQuery returns the given GraphQL request in the JSON format, with its query set.
+func withQuery(data []byte, query string) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	rawQuery, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	fields["query"] = rawQuery
+
+	return json.Marshal(fields)
+}
+
+type response struct {
+	Errors []responseError `json:"errors"`
+}
+
+type responseError struct {
+	Message    string            `json:"message"`
+	Extensions map[string]string `json:"extensions,omitempty"`
+}
+
+// operation returns the operation of the document to execute.
+func (d *document) operation(name string) (*operation, error) {
+	if name == "" {
+		if len(d.operations) > 1 {
+			return nil, errors.New("operationName is required when the document holds several operations")
+		}
+
+		return d.operations[0], nil
+	}
+
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+// analysis is the result of the analysis of a selection set.
+type analysis struct {
+	depth         int
+	complexity    int
+	introspection bool
+}
+
+// analyzer analyzes the selection sets of an operation.
+// The analyses of the fragments are memoized, for the documents spreading the same fragments many times to be analyzed in linear time.
+type analyzer struct {
+	fragments map[string]*fragment
+	variables map[string]any
+	defaults  map[string]value
+	results   map[string]*analysis
+	visiting  []string
+}
+
+func (a *analyzer) analyzeSelectionSet(selectionSet []selection) (analysis, error) {
+	var result analysis
+
+	for _, sel := range selectionSet {
+		var (
+			child analysis
+			err   error
+		)
+
+		switch {
+		case sel.fragment != "":
+			child, err = a.analyzeFragment(sel.fragment)
+
+		case sel.name == "":
+			child, err = a.analyzeSelectionSet(sel.selectionSet)
+
+		default:
+			child, err = a.analyzeSelectionSet(sel.selectionSet)
+
+			child.depth++
+			child.complexity = multiply(a.listSize(sel.arguments), add(child.complexity, 1))
+			child.introspection = child.introspection || sel.name == "__schema" || sel.name == "__type"
+		}
+
+		if err != nil {
+			return analysis{}, err
+		}
+
+		result.depth = max(result.depth, child.depth)
+		result.complexity = add(result.complexity, child.complexity)
+		result.introspection = result.introspection || child.introspection
+	}
+
+	return result, nil
+}
+
+func (a *analyzer) analyzeFragment(name string) (analysis, error) {
+	if result, ok := a.results[name]; ok {
+		return *result, nil
+	}
+
+	for _, visiting := range a.visiting {
+		if visiting == name {
+			return analysis{}, fmt.Errorf("fragment %q spreads itself", name)
+		}
+	}
+
+	frag, ok := a.fragments[name]
+	if !ok {
+		return analysis{}, fmt.Errorf("unknown fragment %q", name)
+	}
+
+	a.visiting = append(a.visiting, name)
+	result, err := a.analyzeSelectionSet(frag.selectionSet)
+	a.visiting = a.visiting[:len(a.visiting)-1]
+
+	if err != nil {
+		return analysis{}, err
+	}
+
+	a.results[name] = &result
+
+	return result, nil
+}
+
+// listSize returns the number of items requested by the list size arguments, 1 if there are none.
+func (a *analyzer) listSize(arguments map[string]value) int {
+	size := 1
+
+	for _, name := range listSizeArguments {
+		arg, ok := arguments[name]
+		if !ok {
+			continue
+		}
+
+		if n, ok := a.resolveInt(arg); ok && n > int64(size) {
+			size = int(min(n, maxScore))
+		}
+	}
+
+	return size
+}
+
+func (a *analyzer) resolveInt(v value) (int64, bool) {
+	if v.variable == "" {
+		return v.integer, v.isInt
+	}
+
+	if variable, ok := a.variables[v.variable]; ok {
+		n, ok := variable.(float64)
+		if !ok || n != math.Trunc(n) {
+			return 0, false
+		}
+
+		return int64(min(n, maxScore)), true
+	}
+
+	if defaultValue, ok := a.defaults[v.variable]; ok {
+		return defaultValue.integer, defaultValue.isInt
+	}
+
+	return 0, false
+}
+
+// loadPersistedQueries loads the persisted queries of the given JSON file, keyed by their SHA-256 hash.
+func loadPersistedQueries(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var queries map[string]string
+	if err := json.Unmarshal(content, &queries); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	persistedQueries := make(map[string]string, len(queries))
+	for h, query := range queries {
+		if hash(query) != strings.ToLower(h) {
+			return nil, fmt.Errorf("hash %q does not match the SHA-256 hash of its query", h)
+		}
+
+		persistedQueries[strings.ToLower(h)] = query
+	}
+
+	return persistedQueries, nil
+}
+
+// hash returns the hex-encoded SHA-256 hash of the given query.
+func hash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+func add(a, b int) int {
+	return min(a+b, maxScore)
+}
+
+func multiply(a, b int) int {
+	if a != 0 && b > maxScore/a {
+		return maxScore
+	}
+
+	return a * b
+}
@@ -0,0 +1,616 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/metrics"
+	"github.com/traefik/traefik/v3/pkg/testhelpers"
+)
+
+const persistedQuery = `query GetUser { user { name } }`
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.GraphQL
+	}{
+		{
+			desc:   "negative max depth",
+			config: dynamic.GraphQL{MaxDepth: -1},
+		},
+		{
+			desc:   "negative max complexity",
+			config: dynamic.GraphQL{MaxComplexity: -1},
+		},
+		{
+			desc:   "negative max body bytes",
+			config: dynamic.GraphQL{MaxBodyBytes: -1},
+		},
+		{
+			desc:   "persisted queries only without persisted queries",
+			config: dynamic.GraphQL{PersistedQueriesOnly: true},
+		},
+		{
+			desc:   "missing persisted queries file",
+			config: dynamic.GraphQL{PersistedQueriesFile: "missing.json"},
+		},
+		{
+			desc:   "persisted query not matching its hash",
+			config: dynamic.GraphQL{PersistedQueriesFile: writePersistedQueries(t, map[string]string{hash("{ a }"): "{ b }"})},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "graphql", nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGraphQL_ServeHTTP(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		config             dynamic.GraphQL
+		body               string
+		expectedStatusCode int
+		expectedError      string
+	}{
+		{
+			desc:               "allowed query",
+			body:               `{"query": "{ user(id: 1) { name friends { name } } }"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "invalid JSON",
+			body:               `{"query": `,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "parsing body: unexpected end of JSON input",
+		},
+		{
+			desc:               "missing query",
+			body:               `{"variables": {}}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "missing query",
+		},
+		{
+			desc:               "invalid query",
+			body:               `{"query": "{ user { name }"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "parsing query: unexpected end of document",
+		},
+		{
+			desc:               "unknown fragment",
+			body:               `{"query": "{ user { ...UserFields } }"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      `unknown fragment "UserFields"`,
+		},
+		{
+			desc:               "fragment cycle",
+			body:               `{"query": "{ user { ...A } } fragment A on User { friends { ...B } } fragment B on User { ...A }"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      `fragment "A" spreads itself`,
+		},
+		{
+			desc:               "several operations without operation name",
+			body:               `{"query": "query A { a } query B { b }"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "operationName is required when the document holds several operations",
+		},
+		{
+			desc:               "several operations with operation name",
+			config:             dynamic.GraphQL{AllowedOperations: []string{"B"}},
+			body:               `{"query": "query A { a } query B { b }", "operationName": "B"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "operation not allowed",
+			config:             dynamic.GraphQL{AllowedOperations: []string{"B"}},
+			body:               `{"query": "query A { a } query B { b }", "operationName": "A"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      `operation "A" is not allowed`,
+		},
+		{
+			desc:               "anonymous operation not allowed",
+			config:             dynamic.GraphQL{AllowedOperations: []string{"B"}},
+			body:               `{"query": "{ b }"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      `operation "" is not allowed`,
+		},
+		{
+			desc:               "introspection blocked",
+			config:             dynamic.GraphQL{BlockIntrospection: true},
+			body:               `{"query": "{ __schema { types { name } } }"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "introspection is not allowed",
+		},
+		{
+			desc:               "introspection in a fragment blocked",
+			config:             dynamic.GraphQL{BlockIntrospection: true},
+			body:               `{"query": "{ user { ...F } } fragment F on User { __type(name: \"User\") { name } }"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "introspection is not allowed",
+		},
+		{
+			desc:               "typename allowed when introspection is blocked",
+			config:             dynamic.GraphQL{BlockIntrospection: true},
+			body:               `{"query": "{ __typename user { __typename name } }"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "depth within the limit",
+			config:             dynamic.GraphQL{MaxDepth: 3},
+			body:               `{"query": "{ user { friends { name } } }"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "depth exceeding the limit",
+			config:             dynamic.GraphQL{MaxDepth: 3},
+			body:               `{"query": "{ user { friends { friends { name } } } }"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "query depth 4 exceeds the limit of 3",
+		},
+		{
+			desc:               "depth exceeding the limit through fragments",
+			config:             dynamic.GraphQL{MaxDepth: 3},
+			body:               `{"query": "{ user { ...F } } fragment F on User { friends { ... on User { friends { name } } } }"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "query depth 4 exceeds the limit of 3",
+		},
+		{
+			desc:               "complexity within the limit",
+			config:             dynamic.GraphQL{MaxComplexity: 22},
+			body:               `{"query": "{ users(first: 10) { name } }"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "complexity exceeding the limit",
+			config:             dynamic.GraphQL{MaxComplexity: 100},
+			body:               `{"query": "{ users(first: 10) { name friends(limit: 10) { name } } }"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "query complexity 220 exceeds the limit of 100",
+		},
+		{
+			desc:               "complexity exceeding the limit with a variable",
+			config:             dynamic.GraphQL{MaxComplexity: 100},
+			body:               `{"query": "query Users($n: Int) { users(last: $n) { name } }", "variables": {"n": 100}}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "query complexity 200 exceeds the limit of 100",
+		},
+		{
+			desc:               "complexity exceeding the limit with a variable default value",
+			config:             dynamic.GraphQL{MaxComplexity: 100},
+			body:               `{"query": "query Users($n: Int = 100) { users(first: $n) { name } }"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "query complexity 200 exceeds the limit of 100",
+		},
+		{
+			desc:               "complexity of aliases",
+			config:             dynamic.GraphQL{MaxComplexity: 3},
+			body:               `{"query": "{ a: user { name } b: user { name } }"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "query complexity 4 exceeds the limit of 3",
+		},
+		{
+			desc:               "complexity of spread fragments",
+			config:             dynamic.GraphQL{MaxComplexity: 5},
+			body:               `{"query": "{ user { ...F ...F ...F } } fragment F on User { name }"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "batch",
+			config:             dynamic.GraphQL{MaxDepth: 1},
+			body:               `[{"query": "{ a }"}, {"query": "{ b }"}]`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "batch with a rejected operation",
+			config:             dynamic.GraphQL{MaxDepth: 1},
+			body:               `[{"query": "{ a }"}, {"query": "{ b { c } }"}]`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "query depth 2 exceeds the limit of 1",
+		},
+		{
+			desc:               "empty batch",
+			body:               `[]`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "empty batch",
+		},
+		{
+			desc:               "body too large",
+			config:             dynamic.GraphQL{MaxBodyBytes: 10},
+			body:               `{"query": "{ a }"}`,
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedError:      "body too large: size 18 exceeds the limit of 10 bytes",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwardedBody string
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+
+				forwardedBody = string(body)
+			})
+
+			handler, err := New(context.Background(), next, test.config, "graphql", nil)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(test.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatusCode, rw.Code)
+
+			if test.expectedError == "" {
+				assert.Equal(t, test.body, forwardedBody)
+				return
+			}
+
+			assert.Empty(t, forwardedBody)
+			assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+			assert.JSONEq(t, `{"errors":[{"message":`+jsonString(t, test.expectedError)+`}]}`, rw.Body.String())
+		})
+	}
+}
+
+func TestGraphQL_ServeHTTP_requestFormats(t *testing.T) {
+	config := dynamic.GraphQL{MaxDepth: 1}
+
+	testCases := []struct {
+		desc               string
+		method             string
+		target             string
+		contentType        string
+		body               string
+		expectedStatusCode int
+	}{
+		{
+			desc:               "GET query",
+			method:             http.MethodGet,
+			target:             "/graphql?query=" + url.QueryEscape("{ a }"),
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "GET query exceeding the depth",
+			method:             http.MethodGet,
+			target:             "/graphql?query=" + url.QueryEscape("{ a { b } }"),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "GET invalid variables",
+			method:             http.MethodGet,
+			target:             "/graphql?query=" + url.QueryEscape("{ a }") + "&variables=foo",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "GET without GraphQL parameters",
+			method:             http.MethodGet,
+			target:             "/graphql",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "POST GraphQL document",
+			method:             http.MethodPost,
+			target:             "/graphql",
+			contentType:        "application/graphql",
+			body:               "{ a }",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "POST GraphQL document exceeding the depth",
+			method:             http.MethodPost,
+			target:             "/graphql",
+			contentType:        "application/graphql",
+			body:               "{ a { b } }",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "POST unsupported content type",
+			method:             http.MethodPost,
+			target:             "/graphql",
+			contentType:        "text/plain",
+			body:               "{ a }",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "OPTIONS",
+			method:             http.MethodOptions,
+			target:             "/graphql",
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler, err := New(context.Background(), http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), config, "graphql", nil)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(test.method, test.target, strings.NewReader(test.body))
+			if test.contentType != "" {
+				req.Header.Set("Content-Type", test.contentType)
+			}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatusCode, rw.Code)
+		})
+	}
+}
+
+func TestGraphQL_ServeHTTP_persistedQueries(t *testing.T) {
+	persistedQueriesFile := writePersistedQueries(t, map[string]string{hash(persistedQuery): persistedQuery})
+	extensions := `{"persistedQuery": {"version": 1, "sha256Hash": "` + hash(persistedQuery) + `"}}`
+	unknownExtensions := `{"persistedQuery": {"version": 1, "sha256Hash": "` + hash("{ a }") + `"}}`
+
+	testCases := []struct {
+		desc               string
+		persistedOnly      bool
+		body               string
+		expectedStatusCode int
+		expectedBody       string
+		expectedForwarded  string
+	}{
+		{
+			desc:               "persisted query",
+			body:               `{"extensions": ` + extensions + `}`,
+			expectedStatusCode: http.StatusOK,
+			expectedForwarded:  `{"extensions": ` + extensions + `, "query": "` + persistedQuery + `"}`,
+		},
+		{
+			desc:               "unknown persisted query",
+			body:               `{"extensions": ` + unknownExtensions + `}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"errors":[{"message":"PersistedQueryNotFound","extensions":{"code":"PERSISTED_QUERY_NOT_FOUND"}}]}`,
+		},
+		{
+			desc:               "query with its hash",
+			body:               `{"query": "{ a }", "extensions": ` + unknownExtensions + `}`,
+			expectedStatusCode: http.StatusOK,
+			expectedForwarded:  `{"query": "{ a }", "extensions": ` + unknownExtensions + `}`,
+		},
+		{
+			desc:               "persisted query only",
+			persistedOnly:      true,
+			body:               `{"extensions": ` + extensions + `}`,
+			expectedStatusCode: http.StatusOK,
+			expectedForwarded:  `{"extensions": ` + extensions + `, "query": "` + persistedQuery + `"}`,
+		},
+		{
+			desc:               "query matching a persisted query only",
+			persistedOnly:      true,
+			body:               `{"query": "` + persistedQuery + `"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedForwarded:  `{"query": "` + persistedQuery + `"}`,
+		},
+		{
+			desc:               "query not persisted",
+			persistedOnly:      true,
+			body:               `{"query": "{ a }"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"errors":[{"message":"only the persisted queries are allowed"}]}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwardedBody string
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+
+				forwardedBody = string(body)
+			})
+
+			config := dynamic.GraphQL{
+				PersistedQueriesFile: persistedQueriesFile,
+				PersistedQueriesOnly: test.persistedOnly,
+			}
+
+			handler, err := New(context.Background(), next, config, "graphql", nil)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(test.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatusCode, rw.Code)
+
+			if test.expectedBody != "" {
+				assert.JSONEq(t, test.expectedBody, rw.Body.String())
+			}
+
+			if test.expectedForwarded != "" {
+				assert.JSONEq(t, test.expectedForwarded, forwardedBody)
+			} else {
+				assert.Empty(t, forwardedBody)
+			}
+		})
+	}
+}
+
+func TestGraphQL_ServeHTTP_persistedQueryGET(t *testing.T) {
+	config := dynamic.GraphQL{
+		PersistedQueriesFile: writePersistedQueries(t, map[string]string{hash(persistedQuery): persistedQuery}),
+	}
+
+	var forwardedQuery string
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwardedQuery = req.URL.Query().Get("query")
+	})
+
+	handler, err := New(context.Background(), next, config, "graphql", nil)
+	require.NoError(t, err)
+
+	extensions := `{"persistedQuery": {"version": 1, "sha256Hash": "` + hash(persistedQuery) + `"}}`
+	req := httptest.NewRequest(http.MethodGet, "/graphql?extensions="+url.QueryEscape(extensions), nil)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, persistedQuery, forwardedQuery)
+}
+
+func TestGraphQL_metrics(t *testing.T) {
+	registry := &collectingRegistry{Registry: metrics.NewVoidRegistry(), counter: &testhelpers.CollectingCounter{}}
+
+	handler, err := New(context.Background(), http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), dynamic.GraphQL{MaxDepth: 1}, "graphql", registry)
+	require.NoError(t, err)
+
+	serve := func(body string) {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve(`{"query": "query GetUser { user }"}`)
+	assert.Equal(t, []string{"middleware", "graphql", "operation", "GetUser", "result", "allowed"}, registry.counter.LastLabelValues)
+
+	serve(`{"query": "query GetUser { user { name } }"}`)
+	assert.Equal(t, []string{"middleware", "graphql", "operation", "GetUser", "result", "maxDepth"}, registry.counter.LastLabelValues)
+
+	serve(`{"query": "{"}`)
+	assert.Equal(t, []string{"middleware", "graphql", "operation", "", "result", "invalid"}, registry.counter.LastLabelValues)
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		query         string
+		expectedError string
+	}{
+		{
+			desc: "full document",
+			query: `# Comment
+query GetUsers($first: Int! = 10, $filter: [Filter!] @deprecated) @live {
+  users(first: $first, filter: {name: "a\"b", tags: ["x", """block "" string"""], score: -1.5e3, active: true, role: ADMIN, nothing: null}) {
+    id, name
+    ... on Admin @include(if: true) { level }
+    ... @skip(if: false) { email }
+    ...UserFields
+  }
+}
+
+mutation { delete(id: 1) }
+
+subscription OnEvent { event { id } }
+
+fragment UserFields on User { friends { id } }`,
+		},
+		{
+			desc:          "type system definition",
+			query:         `type User { name: String }`,
+			expectedError: `unexpected "type" at offset 0`,
+		},
+		{
+			desc:          "no operation",
+			query:         `fragment F on User { name }`,
+			expectedError: "no operation",
+		},
+		{
+			desc:          "empty selection set",
+			query:         `{ user { } }`,
+			expectedError: "empty selection set",
+		},
+		{
+			desc:          "duplicate fragment",
+			query:         `{ a } fragment F on A { a } fragment F on A { a }`,
+			expectedError: `duplicate fragment "F"`,
+		},
+		{
+			desc:          "unterminated string",
+			query:         `{ a(b: "c) }`,
+			expectedError: "unterminated string at offset 7",
+		},
+		{
+			desc:          "invalid number",
+			query:         `{ a(b: 1x) }`,
+			expectedError: "invalid number at offset 7",
+		},
+		{
+			desc:          "invalid character",
+			query:         `{ a; }`,
+			expectedError: `unexpected character ';' at offset 3`,
+		},
+		{
+			desc:          "nesting too deep",
+			query:         strings.Repeat("{ a ", maxNesting+1) + strings.Repeat("}", maxNesting+1),
+			expectedError: "nesting exceeds the limit of 256",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parse(test.query)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func writePersistedQueries(t *testing.T, queries map[string]string) string {
+	t.Helper()
+
+	content, err := json.Marshal(queries)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "persisted-queries.json")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+
+	return path
+}
+
+func jsonString(t *testing.T, s string) string {
+	t.Helper()
+
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+
+	return string(b)
+}
+
+type collectingRegistry struct {
+	metrics.Registry
+
+	counter *testhelpers.CollectingCounter
+}
+
+func (r *collectingRegistry) GraphQLReqsCounter() gokitmetrics.Counter {
+	return r.counter
+}
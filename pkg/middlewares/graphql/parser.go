@@ -0,0 +1,699 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxNesting is the maximum nesting of the selection sets and values accepted by the parser,
+// for the parsing of hostile documents not to exhaust the resources.
+const maxNesting = 256
+
+// document is a parsed GraphQL executable document.
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+// operation is an operation definition.
+type operation struct {
+	// kind is query, mutation or subscription.
+	kind         string
+	name         string
+	defaults     map[string]value
+	selectionSet []selection
+}
+
+// fragment is a fragment definition.
+type fragment struct {
+	name         string
+	selectionSet []selection
+}
+
+// selection is a field, a fragment spread, or an inline fragment.
+type selection struct {
+	// name is the name of the field, empty for the fragment spreads and the inline fragments.
+	name      string
+	arguments map[string]value
+	// fragment is the name of the spread fragment.
+	fragment     string
+	selectionSet []selection
+}
+
+// value is an argument or a default value.
+// Only the integers and the variables are kept, as they are the only values used to score the complexity.
+type value struct {
+	variable string
+	integer  int64
+	isInt    bool
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// parser is a recursive descent parser of the GraphQL executable documents.
+type parser struct {
+	src     string
+	pos     int
+	tok     token
+	nesting int
+}
+
+// parse parses the given GraphQL executable document.
+func parse(src string) (*document, error) {
+	p := &parser{src: src}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: make(map[string]*fragment)}
+
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.is(tokenPunctuator, "{"):
+			selectionSet, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+
+			doc.operations = append(doc.operations, &operation{kind: "query", selectionSet: selectionSet})
+
+		case p.is(tokenName, "query"), p.is(tokenName, "mutation"), p.is(tokenName, "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+
+			doc.operations = append(doc.operations, op)
+
+		case p.is(tokenName, "fragment"):
+			frag, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+
+			if _, exists := doc.fragments[frag.name]; exists {
+				return nil, fmt.Errorf("duplicate fragment %q", frag.name)
+			}
+
+			doc.fragments[frag.name] = frag
+
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.operations) == 0 {
+		return nil, errors.New("no operation")
+	}
+
+	return doc, nil
+}
+
+func (p *parser) parseOperation() (*operation, error) {
+	op := &operation{kind: p.tok.value}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokenName {
+		op.name = p.tok.value
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.is(tokenPunctuator, "(") {
+		var err error
+		op.defaults, err = p.parseVariableDefinitions()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.skipDirectives(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	op.selectionSet, err = p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() (map[string]value, error) {
+	defaults := make(map[string]value)
+
+	if err := p.expect(tokenPunctuator, "("); err != nil {
+		return nil, err
+	}
+
+	for !p.is(tokenPunctuator, ")") {
+		if err := p.expect(tokenPunctuator, "$"); err != nil {
+			return nil, err
+		}
+
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+
+		if err := p.skipType(); err != nil {
+			return nil, err
+		}
+
+		if p.is(tokenPunctuator, "=") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+
+			defaults[name], err = p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err := p.skipDirectives(); err != nil {
+			return nil, err
+		}
+	}
+
+	return defaults, p.next()
+}
+
+func (p *parser) skipType() error {
+	if p.is(tokenPunctuator, "[") {
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		if err := p.skipType(); err != nil {
+			return err
+		}
+
+		if err := p.expect(tokenPunctuator, "]"); err != nil {
+			return err
+		}
+	} else if _, err := p.parseName(); err != nil {
+		return err
+	}
+
+	if p.is(tokenPunctuator, "!") {
+		return p.next()
+	}
+
+	return nil
+}
+
+func (p *parser) parseFragment() (*fragment, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(tokenName, "on"); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.parseName(); err != nil {
+		return nil, err
+	}
+
+	if err := p.skipDirectives(); err != nil {
+		return nil, err
+	}
+
+	selectionSet, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return &fragment{name: name, selectionSet: selectionSet}, nil
+}
+
+func (p *parser) parseSelectionSet() ([]selection, error) {
+	if err := p.expect(tokenPunctuator, "{"); err != nil {
+		return nil, err
+	}
+
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	var selectionSet []selection
+	for !p.is(tokenPunctuator, "}") {
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+
+		selectionSet = append(selectionSet, sel)
+	}
+
+	if len(selectionSet) == 0 {
+		return nil, errors.New("empty selection set")
+	}
+
+	return selectionSet, p.next()
+}
+
+func (p *parser) parseSelection() (selection, error) {
+	if p.is(tokenPunctuator, "...") {
+		return p.parseFragmentSelection()
+	}
+
+	name, err := p.parseName()
+	if err != nil {
+		return selection{}, err
+	}
+
+	// The name was an alias.
+	if p.is(tokenPunctuator, ":") {
+		if err := p.next(); err != nil {
+			return selection{}, err
+		}
+
+		name, err = p.parseName()
+		if err != nil {
+			return selection{}, err
+		}
+	}
+
+	sel := selection{name: name}
+
+	if p.is(tokenPunctuator, "(") {
+		sel.arguments, err = p.parseArguments()
+		if err != nil {
+			return selection{}, err
+		}
+	}
+
+	if err := p.skipDirectives(); err != nil {
+		return selection{}, err
+	}
+
+	if p.is(tokenPunctuator, "{") {
+		sel.selectionSet, err = p.parseSelectionSet()
+		if err != nil {
+			return selection{}, err
+		}
+	}
+
+	return sel, nil
+}
+
+func (p *parser) parseFragmentSelection() (selection, error) {
+	if err := p.next(); err != nil {
+		return selection{}, err
+	}
+
+	// Fragment spread.
+	if p.tok.kind == tokenName && p.tok.value != "on" {
+		name := p.tok.value
+		if err := p.next(); err != nil {
+			return selection{}, err
+		}
+
+		return selection{fragment: name}, p.skipDirectives()
+	}
+
+	// Inline fragment.
+	if p.is(tokenName, "on") {
+		if err := p.next(); err != nil {
+			return selection{}, err
+		}
+
+		if _, err := p.parseName(); err != nil {
+			return selection{}, err
+		}
+	}
+
+	if err := p.skipDirectives(); err != nil {
+		return selection{}, err
+	}
+
+	selectionSet, err := p.parseSelectionSet()
+	if err != nil {
+		return selection{}, err
+	}
+
+	return selection{selectionSet: selectionSet}, nil
+}
+
+func (p *parser) parseArguments() (map[string]value, error) {
+	if err := p.expect(tokenPunctuator, "("); err != nil {
+		return nil, err
+	}
+
+	arguments := make(map[string]value)
+	for !p.is(tokenPunctuator, ")") {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+
+		arguments[name], err = p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return arguments, p.next()
+}
+
+func (p *parser) skipDirectives() error {
+	for p.is(tokenPunctuator, "@") {
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		if _, err := p.parseName(); err != nil {
+			return err
+		}
+
+		if p.is(tokenPunctuator, "(") {
+			if _, err := p.parseArguments(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *parser) parseValue() (value, error) {
+	switch {
+	case p.is(tokenPunctuator, "$"):
+		if err := p.next(); err != nil {
+			return value{}, err
+		}
+
+		name, err := p.parseName()
+		if err != nil {
+			return value{}, err
+		}
+
+		return value{variable: name}, nil
+
+	case p.tok.kind == tokenInt:
+		integer, err := strconv.ParseInt(p.tok.value, 10, 64)
+		if err != nil {
+			return value{}, fmt.Errorf("invalid integer %q", p.tok.value)
+		}
+
+		return value{integer: integer, isInt: true}, p.next()
+
+	case p.tok.kind == tokenFloat, p.tok.kind == tokenString, p.tok.kind == tokenName:
+		return value{}, p.next()
+
+	case p.is(tokenPunctuator, "["):
+		return value{}, p.skipCompositeValue("]", func() error {
+			_, err := p.parseValue()
+			return err
+		})
+
+	case p.is(tokenPunctuator, "{"):
+		return value{}, p.skipCompositeValue("}", func() error {
+			if _, err := p.parseName(); err != nil {
+				return err
+			}
+
+			if err := p.expect(tokenPunctuator, ":"); err != nil {
+				return err
+			}
+
+			_, err := p.parseValue()
+			return err
+		})
+
+	default:
+		return value{}, p.unexpected()
+	}
+}
+
+// skipCompositeValue skips a list or an input object value, whose items are parsed by the given function.
+func (p *parser) skipCompositeValue(closing string, parseItem func() error) error {
+	if err := p.next(); err != nil {
+		return err
+	}
+
+	if err := p.enter(); err != nil {
+		return err
+	}
+	defer p.leave()
+
+	for !p.is(tokenPunctuator, closing) {
+		if err := parseItem(); err != nil {
+			return err
+		}
+	}
+
+	return p.next()
+}
+
+func (p *parser) parseName() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+
+	name := p.tok.value
+	return name, p.next()
+}
+
+func (p *parser) enter() error {
+	p.nesting++
+	if p.nesting > maxNesting {
+		return fmt.Errorf("nesting exceeds the limit of %d", maxNesting)
+	}
+
+	return nil
+}
+
+func (p *parser) leave() {
+	p.nesting--
+}
+
+func (p *parser) is(kind tokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+func (p *parser) expect(kind tokenKind, value string) error {
+	if !p.is(kind, value) {
+		return p.unexpected()
+	}
+
+	return p.next()
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return errors.New("unexpected end of document")
+	}
+
+	return fmt.Errorf("unexpected %q at offset %d", p.tok.value, p.pos-len(p.tok.value))
+}
+
+// next reads the next token, skipping the ignored tokens: whitespaces, line terminators, commas, and comments.
+func (p *parser) next() error {
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == ' ', c == '\t', c == '\n', c == '\r', c == ',':
+			p.pos++
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' && p.src[p.pos] != '\r' {
+				p.pos++
+			}
+		case c == 0xEF && strings.HasPrefix(p.src[p.pos:], "\uFEFF"):
+			p.pos += 3
+		default:
+			return p.readToken()
+		}
+	}
+
+	p.tok = token{kind: tokenEOF}
+	return nil
+}
+
+func (p *parser) readToken() error {
+	start := p.pos
+	c := p.src[p.pos]
+
+	switch {
+	case c == '.':
+		if len(p.src) < p.pos+3 || p.src[p.pos:p.pos+3] != "..." {
+			return fmt.Errorf("unexpected character %q at offset %d", c, p.pos)
+		}
+
+		p.pos += 3
+		p.tok = token{kind: tokenPunctuator, value: "..."}
+
+	case isPunctuator(c):
+		p.pos++
+		p.tok = token{kind: tokenPunctuator, value: string(c)}
+
+	case isNameStart(c):
+		for p.pos < len(p.src) && isNameContinue(p.src[p.pos]) {
+			p.pos++
+		}
+
+		p.tok = token{kind: tokenName, value: p.src[start:p.pos]}
+
+	case c == '-' || isDigit(c):
+		return p.readNumber()
+
+	case c == '"':
+		return p.readString()
+
+	default:
+		return fmt.Errorf("unexpected character %q at offset %d", c, p.pos)
+	}
+
+	return nil
+}
+
+func (p *parser) readNumber() error {
+	start := p.pos
+	kind := tokenInt
+
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+
+	if !p.readDigits() {
+		return fmt.Errorf("invalid number at offset %d", start)
+	}
+
+	if p.pos < len(p.src) && p.src[p.pos] == '.' {
+		kind = tokenFloat
+		p.pos++
+
+		if !p.readDigits() {
+			return fmt.Errorf("invalid number at offset %d", start)
+		}
+	}
+
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		kind = tokenFloat
+		p.pos++
+
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+
+		if !p.readDigits() {
+			return fmt.Errorf("invalid number at offset %d", start)
+		}
+	}
+
+	if p.pos < len(p.src) && (isNameStart(p.src[p.pos]) || p.src[p.pos] == '.') {
+		return fmt.Errorf("invalid number at offset %d", start)
+	}
+
+	p.tok = token{kind: kind, value: p.src[start:p.pos]}
+	return nil
+}
+
+func (p *parser) readDigits() bool {
+	start := p.pos
+	for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+		p.pos++
+	}
+
+	return p.pos > start
+}
+
+// readString reads a string or a block string.
+// The content of the strings is not decoded, as the strings are not used to score the operations.
+func (p *parser) readString() error {
+	start := p.pos
+
+	if len(p.src) >= p.pos+3 && p.src[p.pos:p.pos+3] == `"""` {
+		p.pos += 3
+
+		for p.pos < len(p.src) {
+			switch {
+			case len(p.src) >= p.pos+4 && p.src[p.pos:p.pos+4] == `\"""`:
+				p.pos += 4
+			case len(p.src) >= p.pos+3 && p.src[p.pos:p.pos+3] == `"""`:
+				p.pos += 3
+				p.tok = token{kind: tokenString, value: p.src[start:p.pos]}
+				return nil
+			default:
+				p.pos++
+			}
+		}
+
+		return fmt.Errorf("unterminated string at offset %d", start)
+	}
+
+	p.pos++
+
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos += 2
+		case '\n', '\r':
+			return fmt.Errorf("unterminated string at offset %d", start)
+		case '"':
+			p.pos++
+			p.tok = token{kind: tokenString, value: p.src[start:p.pos]}
+			return nil
+		default:
+			p.pos++
+		}
+	}
+
+	return fmt.Errorf("unterminated string at offset %d", start)
+}
+
+func isPunctuator(c byte) bool {
+	switch c {
+	case '!', '$', '&', '(', ')', ':', '=', '@', '[', ']', '{', '|', '}':
+		return true
+	default:
+		return false
+	}
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameContinue(c byte) bool {
+	return isNameStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,155 @@
+package grpcweb
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestGrpcWeb(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		contentType         string
+		messages            []string
+		expectedContentType string
+		text                bool
+	}{
+		{
+			desc:                "unary call",
+			contentType:         "application/grpc-web+proto",
+			messages:            []string{"response"},
+			expectedContentType: "application/grpc-web+proto",
+		},
+		{
+			desc:                "server-streaming call",
+			contentType:         "application/grpc-web+proto",
+			messages:            []string{"first", "second", "third"},
+			expectedContentType: "application/grpc-web+proto",
+		},
+		{
+			desc:                "server-streaming call in text format",
+			contentType:         "application/grpc-web-text+proto",
+			messages:            []string{"first", "second"},
+			expectedContentType: "application/grpc-web-text+proto",
+			text:                true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				// The backend receives a gRPC request.
+				assert.Equal(t, "application/grpc+proto", req.Header.Get("Content-Type"))
+				assert.Equal(t, 2, req.ProtoMajor)
+
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				assert.Equal(t, frame(0, "request"), body)
+
+				rw.Header().Set("Content-Type", "application/grpc+proto")
+				rw.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+				rw.WriteHeader(http.StatusOK)
+
+				for _, message := range test.messages {
+					_, err = rw.Write(frame(0, message))
+					require.NoError(t, err)
+					rw.(http.Flusher).Flush()
+				}
+
+				rw.Header().Set("Grpc-Status", "0")
+				rw.Header().Set("Grpc-Message", "OK")
+			})
+
+			handler := New(context.Background(), next, dynamic.GrpcWeb{AllowOrigins: []string{"*"}}, "grpcweb")
+
+			reqBody := frame(0, "request")
+			if test.text {
+				reqBody = []byte(base64.StdEncoding.EncodeToString(reqBody))
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/helloworld.Greeter/SayHello", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", test.contentType)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, http.StatusOK, rw.Code)
+			assert.Equal(t, test.expectedContentType, rw.Header().Get("Content-Type"))
+
+			body := rw.Body.Bytes()
+			if test.text {
+				body = decodeBase64Chunks(t, body)
+			}
+
+			// The messages are followed by the trailers, sent in the body as a trailer frame.
+			var expected []byte
+			for _, message := range test.messages {
+				expected = append(expected, frame(0, message)...)
+			}
+			expected = append(expected, frame(1<<7, "grpc-message: OK\r\ngrpc-status: 0\r\n")...)
+
+			assert.Equal(t, expected, body)
+		})
+	}
+}
+
+func TestGrpcWeb_notGrpcWebRequest(t *testing.T) {
+	var forwardedContentType string
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwardedContentType = req.Header.Get("Content-Type")
+	})
+
+	handler := New(context.Background(), next, dynamic.GrpcWeb{AllowOrigins: []string{"*"}}, "grpcweb")
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "application/json", forwardedContentType)
+}
+
+// frame returns the gRPC length-prefixed frame of the given message.
+func frame(flags byte, message string) []byte {
+	b := make([]byte, 5, 5+len(message))
+	b[0] = flags
+	binary.BigEndian.PutUint32(b[1:], uint32(len(message)))
+
+	return append(b, message...)
+}
+
+// decodeBase64Chunks decodes a body made of several padded base64 chunks, as allowed by the gRPC-Web text format.
+func decodeBase64Chunks(t *testing.T, body []byte) []byte {
+	t.Helper()
+
+	var decoded []byte
+	for len(body) > 0 {
+		end := bytes.IndexByte(body, '=')
+		if end < 0 {
+			end = len(body)
+		} else {
+			for end < len(body) && body[end] == '=' {
+				end++
+			}
+		}
+
+		chunk, err := base64.StdEncoding.DecodeString(string(body[:end]))
+		require.NoError(t, err)
+
+		decoded = append(decoded, chunk...)
+		body = body[end:]
+	}
+
+	return decoded
+}
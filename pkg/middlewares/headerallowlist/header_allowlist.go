@@ -0,0 +1,183 @@
+package headerallowlist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/http/httpguts"
+)
+
+const typeName = "HeaderAllowList"
+
+// hopByHopHeaders are handled by the proxy itself, and are always kept for the protocol upgrades and the trailers to keep working.
+var hopByHopHeaders = []string{"connection", "keep-alive", "proxy-connection", "te", "trailer", "transfer-encoding", "upgrade"}
+
+// representationHeaders describe the body, and are always kept for the body to be read.
+var representationHeaders = []string{"content-encoding", "content-length", "content-type"}
+
+// forwardedHeaders are set by the entry point, which removes the values sent by the untrusted clients,
+// and are always kept.
+var forwardedHeaders = []string{
+	"x-forwarded-for",
+	"x-forwarded-host",
+	"x-forwarded-method",
+	"x-forwarded-port",
+	"x-forwarded-proto",
+	"x-forwarded-server",
+	"x-forwarded-tls-client-cert",
+	"x-forwarded-tls-client-cert-info",
+	"x-forwarded-uri",
+	"x-real-ip",
+}
+
+// spoofableHeaders carry the client address, or the original request, without being checked by the entry point.
+// They are forwarded only when allowed by their exact name, and never through a prefix.
+// The X-Forwarded headers which are not forwardedHeaders are spoofable too.
+var spoofableHeaders = []string{
+	"forwarded",
+	"true-client-ip",
+	"x-client-ip",
+	"x-cluster-client-ip",
+	"x-host",
+	"x-http-method-override",
+	"x-method-override",
+	"x-original-forwarded-for",
+	"x-original-host",
+	"x-original-url",
+	"x-rewrite-url",
+}
+
+// headerAllowList is a middleware removing the headers which are not allowed.
+type headerAllowList struct {
+	next            http.Handler
+	name            string
+	requestHeaders  *allowList
+	responseHeaders *allowList
+}
+
+// New creates a HeaderAllowList middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.HeaderAllowList, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	requestHeaders, err := newAllowList(config.RequestHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("invalid requestHeaders: %w", err)
+	}
+
+	h := &headerAllowList{
+		next:           next,
+		name:           name,
+		requestHeaders: requestHeaders,
+	}
+
+	if len(config.ResponseHeaders) > 0 {
+		h.responseHeaders, err = newAllowList(config.ResponseHeaders)
+		if err != nil {
+			return nil, fmt.Errorf("invalid responseHeaders: %w", err)
+		}
+	}
+
+	return h, nil
+}
+
+func (h *headerAllowList) GetTracingInformation() (string, string, trace.SpanKind) {
+	return h.name, typeName, trace.SpanKindInternal
+}
+
+func (h *headerAllowList) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	for name := range req.Header {
+		if !h.isRequestHeaderAllowed(strings.ToLower(name)) {
+			delete(req.Header, name)
+		}
+	}
+
+	if h.responseHeaders == nil {
+		h.next.ServeHTTP(rw, req)
+		return
+	}
+
+	h.next.ServeHTTP(middlewares.NewResponseModifier(rw, req, func(res *http.Response) error {
+		for name := range res.Header {
+			if !h.isResponseHeaderAllowed(strings.ToLower(name)) {
+				delete(res.Header, name)
+			}
+		}
+
+		return nil
+	}), req)
+}
+
+// isRequestHeaderAllowed reports whether the request header with the given lower-cased name is forwarded.
+func (h *headerAllowList) isRequestHeaderAllowed(name string) bool {
+	switch {
+	case slices.Contains(hopByHopHeaders, name),
+		slices.Contains(representationHeaders, name),
+		slices.Contains(forwardedHeaders, name):
+		return true
+	case slices.Contains(spoofableHeaders, name), strings.HasPrefix(name, "x-forwarded-"):
+		return h.requestHeaders.hasName(name)
+	default:
+		return h.requestHeaders.allows(name)
+	}
+}
+
+// isResponseHeaderAllowed reports whether the response header with the given lower-cased name is sent.
+func (h *headerAllowList) isResponseHeaderAllowed(name string) bool {
+	return slices.Contains(hopByHopHeaders, name) ||
+		slices.Contains(representationHeaders, name) ||
+		h.responseHeaders.allows(name)
+}
+
+// allowList holds the lower-cased names and prefixes of allowed headers.
+type allowList struct {
+	names    map[string]struct{}
+	prefixes []string
+}
+
+func newAllowList(headers []string) (*allowList, error) {
+	a := &allowList{names: make(map[string]struct{})}
+
+	for _, header := range headers {
+		name, isPrefix := strings.CutSuffix(strings.ToLower(header), "*")
+
+		if strings.Contains(name, "*") || (name != "" || !isPrefix) && !httpguts.ValidHeaderFieldName(name) {
+			return nil, fmt.Errorf("invalid header name %q", header)
+		}
+
+		if isPrefix {
+			a.prefixes = append(a.prefixes, name)
+			continue
+		}
+
+		a.names[name] = struct{}{}
+	}
+
+	return a, nil
+}
+
+// hasName reports whether the given lower-cased name is allowed by its exact name.
+func (a *allowList) hasName(name string) bool {
+	_, ok := a.names[name]
+	return ok
+}
+
+// allows reports whether the given lower-cased name is allowed, by its exact name or a prefix.
+func (a *allowList) allows(name string) bool {
+	if a.hasName(name) {
+		return true
+	}
+
+	for _, prefix := range a.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
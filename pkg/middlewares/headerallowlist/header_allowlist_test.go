@@ -0,0 +1,158 @@
+package headerallowlist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.HeaderAllowList
+	}{
+		{
+			desc:   "empty request header name",
+			config: dynamic.HeaderAllowList{RequestHeaders: []string{""}},
+		},
+		{
+			desc:   "invalid request header name",
+			config: dynamic.HeaderAllowList{RequestHeaders: []string{"X-Foo Bar"}},
+		},
+		{
+			desc:   "wildcard inside a response header name",
+			config: dynamic.HeaderAllowList{ResponseHeaders: []string{"X-*-Id"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "headerAllowList")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestHeaderAllowList_request(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		requestHeaders  []string
+		header          map[string]string
+		expectedHeaders []string
+	}{
+		{
+			desc:            "nothing allowed",
+			header:          map[string]string{"Authorization": "Basic Zm9vOmJhcg==", "Cookie": "a=b", "Content-Type": "text/plain", "Connection": "Upgrade", "Upgrade": "websocket"},
+			expectedHeaders: []string{"Connection", "Content-Type", "Upgrade"},
+		},
+		{
+			desc:            "allowed names",
+			requestHeaders:  []string{"authorization", "Accept"},
+			header:          map[string]string{"Authorization": "Basic Zm9vOmJhcg==", "Accept": "*/*", "Cookie": "a=b"},
+			expectedHeaders: []string{"Accept", "Authorization"},
+		},
+		{
+			desc:            "allowed prefix",
+			requestHeaders:  []string{"x-custom-*"},
+			header:          map[string]string{"X-Custom-Id": "1", "X-Custom-Name": "foo", "X-Other": "bar"},
+			expectedHeaders: []string{"X-Custom-Id", "X-Custom-Name"},
+		},
+		{
+			desc:            "forwarded headers set by the entry point",
+			header:          map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "example.com", "X-Real-Ip": "10.0.0.1"},
+			expectedHeaders: []string{"X-Forwarded-Host", "X-Forwarded-Proto", "X-Real-Ip"},
+		},
+		{
+			desc:            "spoofable headers allowed through a prefix",
+			requestHeaders:  []string{"*"},
+			header:          map[string]string{"Forwarded": "for=1.2.3.4", "True-Client-Ip": "1.2.3.4", "X-Forwarded-Prefix": "/admin", "Accept": "*/*"},
+			expectedHeaders: []string{"Accept"},
+		},
+		{
+			desc:            "spoofable headers allowed by name",
+			requestHeaders:  []string{"X-Forwarded-Prefix", "Forwarded"},
+			header:          map[string]string{"Forwarded": "for=1.2.3.4", "True-Client-Ip": "1.2.3.4", "X-Forwarded-Prefix": "/admin"},
+			expectedHeaders: []string{"Forwarded", "X-Forwarded-Prefix"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwarded http.Header
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwarded = req.Header
+			})
+
+			handler, err := New(context.Background(), next, dynamic.HeaderAllowList{RequestHeaders: test.requestHeaders}, "headerAllowList")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for name, value := range test.header {
+				req.Header.Set(name, value)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			var names []string
+			for name := range forwarded {
+				names = append(names, name)
+			}
+
+			assert.ElementsMatch(t, test.expectedHeaders, names)
+		})
+	}
+}
+
+func TestHeaderAllowList_response(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		responseHeaders []string
+		expectedHeaders []string
+	}{
+		{
+			desc:            "not filtered",
+			expectedHeaders: []string{"Content-Type", "Server", "X-Powered-By", "X-Custom-Id"},
+		},
+		{
+			desc:            "allowed names and prefix",
+			responseHeaders: []string{"x-custom-*", "Cache-Control"},
+			expectedHeaders: []string{"Content-Type", "X-Custom-Id"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Header().Set("Content-Type", "text/plain")
+				rw.Header().Set("Server", "backend/1.0")
+				rw.Header().Set("X-Powered-By", "PHP/5.6")
+				rw.Header().Set("X-Custom-Id", "1")
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			handler, err := New(context.Background(), next, dynamic.HeaderAllowList{ResponseHeaders: test.responseHeaders}, "headerAllowList")
+			require.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			var names []string
+			for name := range rw.Header() {
+				names = append(names, name)
+			}
+
+			assert.ElementsMatch(t, test.expectedHeaders, names)
+		})
+	}
+}
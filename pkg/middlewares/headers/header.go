@@ -21,6 +21,8 @@ type Header struct {
 	hasCorsHeaders     bool
 	headers            *dynamic.Headers
 	allowOriginRegexes []*regexp.Regexp
+	requestTemplates   headerTemplates
+	responseTemplates  headerTemplates
 }
 
 // NewHeader constructs a new header instance from supplied frontend header struct.
@@ -37,12 +39,24 @@ func NewHeader(next http.Handler, cfg dynamic.Headers) (*Header, error) {
 		regexes[i] = reg
 	}
 
+	requestTemplates, err := parseHeaderTemplates(cfg.CustomRequestHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	responseTemplates, err := parseHeaderTemplates(cfg.CustomResponseHeaders)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Header{
 		next:               next,
 		headers:            &cfg,
 		hasCustomHeaders:   hasCustomHeaders,
 		hasCorsHeaders:     hasCorsHeaders,
 		allowOriginRegexes: regexes,
+		requestTemplates:   requestTemplates,
+		responseTemplates:  responseTemplates,
 	}, nil
 }
 
@@ -66,7 +80,7 @@ func (s *Header) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 // modifyCustomRequestHeaders sets or deletes custom request headers.
 func (s *Header) modifyCustomRequestHeaders(req *http.Request) {
 	// Loop through Custom request headers
-	for header, value := range s.headers.CustomRequestHeaders {
+	for header, value := range s.requestTemplates.apply(req, s.headers.CustomRequestHeaders) {
 		switch {
 		// Handling https://github.com/golang/go/commit/ecdbffd4ec68b509998792f120868fec319de59b.
 		case value == "" && header == forward.XForwardedFor:
@@ -88,8 +102,13 @@ func (s *Header) modifyCustomRequestHeaders(req *http.Request) {
 // This method is called AFTER the response is generated from the backend
 // and can merge/override headers from the backend response.
 func (s *Header) PostRequestModifyResponseHeaders(res *http.Response) error {
+	customResponseHeaders := s.headers.CustomResponseHeaders
+	if res != nil && res.Request != nil {
+		customResponseHeaders = s.responseTemplates.apply(res.Request, customResponseHeaders)
+	}
+
 	// Loop through Custom response headers
-	for header, value := range s.headers.CustomResponseHeaders {
+	for header, value := range customResponseHeaders {
 		if value == "" {
 			res.Header.Del(header)
 		} else {
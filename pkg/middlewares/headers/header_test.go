@@ -1,6 +1,10 @@
 package headers
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
 )
 
 func TestNewHeader_customRequestHeader(t *testing.T) {
@@ -114,6 +119,88 @@ func TestNewHeader_customRequestHeader_Host(t *testing.T) {
 	}
 }
 
+func TestNewHeader_customRequestHeader_template(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject:      pkix.Name{CommonName: "alice", Organization: []string{"Acme"}},
+		SerialNumber: big.NewInt(42),
+	}
+
+	testCases := []struct {
+		desc     string
+		value    string
+		tls      *tls.ConnectionState
+		expected []string
+	}{
+		{
+			desc:     "request attributes",
+			value:    "{{ .Method }} {{ .Host }}{{ .Path }} from {{ .ClientIP }} on {{ .RouterName }}",
+			expected: []string{"GET example.com/foo from 192.0.2.1 on router@file"},
+		},
+		{
+			desc:     "other header",
+			value:    `{{ .Header.Get "Foo" }}-suffix`,
+			expected: []string{"bar-suffix"},
+		},
+		{
+			desc:     "client certificate",
+			value:    "{{ .TLS.ClientCert.CN }}",
+			tls:      &tls.ConnectionState{Version: tls.VersionTLS13, PeerCertificates: []*x509.Certificate{cert}},
+			expected: []string{"alice"},
+		},
+		{
+			desc:  "client certificate without TLS",
+			value: "{{ .TLS.ClientCert.CN }}",
+		},
+		{
+			desc:     "TLS version",
+			value:    "{{ .TLS.Version }}",
+			tls:      &tls.ConnectionState{Version: tls.VersionTLS13},
+			expected: []string{"TLS 1.3"},
+		},
+		{
+			desc:  "execution error",
+			value: `{{ index .Header.Foo 2 }}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwarded http.Header
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwarded = req.Header
+			})
+
+			mid, err := NewHeader(next, dynamic.Headers{
+				CustomRequestHeaders: map[string]string{"X-Custom": test.value},
+			})
+			require.NoError(t, err)
+
+			handler, err := middlewares.WrapRouterName("router@file")(mid)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com/foo", nil)
+			req.Header.Set("Foo", "bar")
+			req.Header.Set("X-Custom", "spoofed")
+			req.TLS = test.tls
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.NotNil(t, forwarded)
+			assert.Equal(t, test.expected, forwarded.Values("X-Custom"))
+		})
+	}
+}
+
+func TestNewHeader_invalidTemplate(t *testing.T) {
+	_, err := NewHeader(nil, dynamic.Headers{CustomRequestHeaders: map[string]string{"X-Custom": "{{ .Host "}})
+	assert.Error(t, err)
+
+	_, err = NewHeader(nil, dynamic.Headers{CustomResponseHeaders: map[string]string{"X-Custom": "{{ .Host "}})
+	assert.Error(t, err)
+}
+
 func TestNewHeader_CORSPreflights(t *testing.T) {
 	testCases := []struct {
 		desc           string
@@ -519,6 +606,16 @@ func TestNewHeader_customResponseHeaders(t *testing.T) {
 				"Testing": {"foo"},
 			},
 		},
+		{
+			desc: "Template Custom Header",
+			config: map[string]string{
+				"Testing": "{{ .Method }} {{ .Path }}",
+			},
+			expected: map[string][]string{
+				"Foo":     {"bar"},
+				"Testing": {"GET /foo"},
+			},
+		},
 		{
 			desc: "Deleting Custom Header",
 			config: map[string]string{
@@ -0,0 +1,138 @@
+package headers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+)
+
+// headerTemplates are the templates of the header values, by header name.
+type headerTemplates map[string]*template.Template
+
+// parseHeaderTemplates parses the header values which are templates, i.e. containing an action delimiter.
+func parseHeaderTemplates(headers map[string]string) (headerTemplates, error) {
+	templates := make(headerTemplates)
+	for name, value := range headers {
+		if !strings.Contains(value, "{{") {
+			continue
+		}
+
+		tmpl, err := template.New(name).Option("missingkey=zero").Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("parsing header %q template: %w", name, err)
+		}
+
+		templates[name] = tmpl
+	}
+
+	return templates, nil
+}
+
+// apply returns the given header values, with the values of the templates evaluated for the given request.
+func (h headerTemplates) apply(req *http.Request, headers map[string]string) map[string]string {
+	if len(h) == 0 {
+		return headers
+	}
+
+	data := newTemplateData(req)
+
+	values := maps.Clone(headers)
+	for name, tmpl := range h {
+		var value strings.Builder
+		if err := tmpl.Execute(&value, data); err != nil {
+			log.Ctx(req.Context()).Error().Err(err).Msgf("Unable to execute header %q template", name)
+
+			// The header is removed, rather than set with a partial value.
+			value.Reset()
+		}
+
+		values[name] = value.String()
+	}
+
+	return values
+}
+
+// templateData is the data available to the header templates.
+type templateData struct {
+	ClientIP   string
+	Host       string
+	Method     string
+	Path       string
+	RouterName string
+	Header     http.Header
+	TLS        tlsData
+}
+
+// tlsData describes the TLS connection of a request, and is empty for the requests without TLS.
+type tlsData struct {
+	Version     string
+	CipherSuite string
+	ServerName  string
+	ClientCert  certData
+}
+
+// certData describes the client certificate of a request.
+type certData struct {
+	CN             string
+	Subject        string
+	Issuer         string
+	SerialNumber   string
+	DNSNames       []string
+	EmailAddresses []string
+	NotBefore      time.Time
+	NotAfter       time.Time
+}
+
+func newTemplateData(req *http.Request) templateData {
+	clientIP, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		clientIP = req.RemoteAddr
+	}
+
+	data := templateData{
+		ClientIP:   clientIP,
+		Host:       req.Host,
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		RouterName: middlewares.GetRouterName(req.Context()),
+		Header:     req.Header,
+	}
+
+	if req.TLS == nil {
+		return data
+	}
+
+	data.TLS = tlsData{
+		Version:     tls.VersionName(req.TLS.Version),
+		CipherSuite: tls.CipherSuiteName(req.TLS.CipherSuite),
+		ServerName:  req.TLS.ServerName,
+	}
+
+	if len(req.TLS.PeerCertificates) > 0 {
+		data.TLS.ClientCert = newCertData(req.TLS.PeerCertificates[0])
+	}
+
+	return data
+}
+
+func newCertData(cert *x509.Certificate) certData {
+	return certData{
+		CN:             cert.Subject.CommonName,
+		Subject:        cert.Subject.String(),
+		Issuer:         cert.Issuer.String(),
+		SerialNumber:   cert.SerialNumber.String(),
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+		NotBefore:      cert.NotBefore,
+		NotAfter:       cert.NotAfter,
+	}
+}
@@ -0,0 +1,328 @@
+package hmacsignature
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "HMACSignature"
+
+const (
+	defaultAlgorithm       = "sha256"
+	defaultSignatureHeader = "X-Signature"
+	defaultEncoding        = "hex"
+	defaultSeparator       = "."
+	defaultClockSkew       = 5 * time.Minute
+	defaultMaxBodyBytes    = 10 * 1024 * 1024
+
+	headerComponentPrefix = "header:"
+)
+
+var algorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+var decoders = map[string]func(string) ([]byte, error){
+	"hex":    hex.DecodeString,
+	"base64": base64.StdEncoding.DecodeString,
+}
+
+var defaultSignedComponents = []string{"body"}
+
+type key struct {
+	id     string
+	secret []byte
+}
+
+// hmacSignature is a middleware rejecting the requests which are not signed with one of the keys.
+type hmacSignature struct {
+	next            http.Handler
+	name            string
+	keys            []key
+	keyIDHeader     string
+	hash            func() hash.Hash
+	signatureHeader string
+	signaturePrefix string
+	decode          func(string) ([]byte, error)
+	components      []string
+	separator       string
+	timestampHeader string
+	clockSkew       time.Duration
+	maxBodyBytes    int64
+	signsBody       bool
+}
+
+// New creates an HMAC signature middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.HMACSignature, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if len(config.Keys) == 0 {
+		return nil, errors.New("at least one key must be defined")
+	}
+
+	h := &hmacSignature{
+		next:            next,
+		name:            name,
+		keyIDHeader:     config.KeyIDHeader,
+		signatureHeader: config.SignatureHeader,
+		signaturePrefix: config.SignaturePrefix,
+		components:      config.SignedComponents,
+		separator:       config.Separator,
+		timestampHeader: config.TimestampHeader,
+		clockSkew:       time.Duration(config.ClockSkew),
+		maxBodyBytes:    config.MaxBodyBytes,
+	}
+
+	for _, k := range config.Keys {
+		if k.Secret == "" {
+			return nil, fmt.Errorf("key %q has an empty secret", k.ID)
+		}
+
+		h.keys = append(h.keys, key{id: k.ID, secret: []byte(k.Secret)})
+	}
+
+	algorithm := config.Algorithm
+	if algorithm == "" {
+		algorithm = defaultAlgorithm
+	}
+
+	var ok bool
+	if h.hash, ok = algorithms[algorithm]; !ok {
+		return nil, fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+
+	encoding := config.Encoding
+	if encoding == "" {
+		encoding = defaultEncoding
+	}
+
+	if h.decode, ok = decoders[encoding]; !ok {
+		return nil, fmt.Errorf("unsupported encoding %q", encoding)
+	}
+
+	if h.signatureHeader == "" {
+		h.signatureHeader = defaultSignatureHeader
+	}
+
+	if len(h.components) == 0 {
+		h.components = defaultSignedComponents
+	}
+
+	if h.separator == "" {
+		h.separator = defaultSeparator
+	}
+
+	if h.clockSkew <= 0 {
+		h.clockSkew = defaultClockSkew
+	}
+
+	if h.maxBodyBytes <= 0 {
+		h.maxBodyBytes = defaultMaxBodyBytes
+	}
+
+	for _, component := range h.components {
+		switch {
+		case component == "body":
+			h.signsBody = true
+
+		case component == "timestamp":
+			if h.timestampHeader == "" {
+				return nil, errors.New("timestampHeader must be defined to sign the timestamp")
+			}
+
+		case component == "method", component == "host", component == "path", component == "query":
+
+		case strings.HasPrefix(component, headerComponentPrefix) && len(component) > len(headerComponentPrefix):
+
+		default:
+			return nil, fmt.Errorf("unsupported signed component %q", component)
+		}
+	}
+
+	return h, nil
+}
+
+func (h *hmacSignature) GetTracingInformation() (string, string, trace.SpanKind) {
+	return h.name, typeName, trace.SpanKindInternal
+}
+
+func (h *hmacSignature) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if h.timestampHeader != "" {
+		if err := h.checkTimestamp(req.Header.Get(h.timestampHeader)); err != nil {
+			h.reject(rw, req, http.StatusUnauthorized, err)
+			return
+		}
+	}
+
+	var body []byte
+	if h.signsBody {
+		if req.ContentLength > h.maxBodyBytes {
+			h.reject(rw, req, http.StatusRequestEntityTooLarge, fmt.Errorf("body size %d exceeds the limit of %d bytes", req.ContentLength, h.maxBodyBytes))
+			return
+		}
+
+		var err error
+		body, err = io.ReadAll(http.MaxBytesReader(rw, req.Body, h.maxBodyBytes))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				h.reject(rw, req, http.StatusRequestEntityTooLarge, fmt.Errorf("body size exceeds the limit of %d bytes", h.maxBodyBytes))
+				return
+			}
+
+			h.reject(rw, req, http.StatusBadRequest, fmt.Errorf("reading body: %w", err))
+			return
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+	}
+
+	signatures := h.signatures(req.Header.Values(h.signatureHeader))
+	if len(signatures) == 0 {
+		h.reject(rw, req, http.StatusUnauthorized, errors.New("missing signature"))
+		return
+	}
+
+	keys := h.keys
+	if h.keyIDHeader != "" {
+		if id := req.Header.Get(h.keyIDHeader); id != "" {
+			keys = h.keysWithID(id)
+		}
+	}
+
+	message := h.message(req, body)
+
+	for _, k := range keys {
+		mac := hmac.New(h.hash, k.secret)
+		mac.Write(message)
+		expected := mac.Sum(nil)
+
+		for _, signature := range signatures {
+			if hmac.Equal(signature, expected) {
+				h.next.ServeHTTP(rw, req)
+				return
+			}
+		}
+	}
+
+	h.reject(rw, req, http.StatusUnauthorized, errors.New("invalid signature"))
+}
+
+// checkTimestamp checks that the request has been signed within the clock skew.
+func (h *hmacSignature) checkTimestamp(value string) error {
+	if value == "" {
+		return errors.New("missing timestamp")
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q", value)
+	}
+
+	skew := time.Since(time.Unix(seconds, 0))
+	if skew > h.clockSkew || skew < -h.clockSkew {
+		return fmt.Errorf("timestamp %q is outside the clock skew", value)
+	}
+
+	return nil
+}
+
+// signatures returns the decoded signatures of the header values,
+// which can hold several comma-separated signatures, e.g. during a key rotation.
+func (h *hmacSignature) signatures(values []string) [][]byte {
+	var signatures [][]byte
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+
+			encoded, found := strings.CutPrefix(item, h.signaturePrefix)
+			if !found || encoded == "" {
+				continue
+			}
+
+			signature, err := h.decode(encoded)
+			if err != nil {
+				continue
+			}
+
+			signatures = append(signatures, signature)
+		}
+	}
+
+	return signatures
+}
+
+func (h *hmacSignature) keysWithID(id string) []key {
+	var keys []key
+	for _, k := range h.keys {
+		if k.id == id {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+// message returns the signed components of the request, joined by the separator.
+func (h *hmacSignature) message(req *http.Request, body []byte) []byte {
+	var message bytes.Buffer
+	for i, component := range h.components {
+		if i > 0 {
+			message.WriteString(h.separator)
+		}
+
+		switch component {
+		case "method":
+			message.WriteString(req.Method)
+		case "host":
+			message.WriteString(req.Host)
+		case "path":
+			message.WriteString(req.URL.Path)
+		case "query":
+			message.WriteString(req.URL.RawQuery)
+		case "body":
+			message.Write(body)
+		case "timestamp":
+			message.WriteString(req.Header.Get(h.timestampHeader))
+		default:
+			message.WriteString(req.Header.Get(strings.TrimPrefix(component, headerComponentPrefix)))
+		}
+	}
+
+	return message.Bytes()
+}
+
+func (h *hmacSignature) reject(rw http.ResponseWriter, req *http.Request, statusCode int, reason error) {
+	logger := middlewares.GetLogger(req.Context(), h.name, typeName)
+	logger.Debug().Err(reason).Msg("Rejecting request")
+
+	observability.SetStatusErrorf(req.Context(), "Rejecting request: %v", reason)
+
+	rw.WriteHeader(statusCode)
+	if _, err := rw.Write([]byte(http.StatusText(statusCode))); err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Send()
+	}
+}
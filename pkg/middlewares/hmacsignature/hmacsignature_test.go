@@ -0,0 +1,231 @@
+package hmacsignature
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.HMACSignature
+	}{
+		{
+			desc:   "no key",
+			config: dynamic.HMACSignature{},
+		},
+		{
+			desc:   "empty secret",
+			config: dynamic.HMACSignature{Keys: []dynamic.HMACSignatureKey{{ID: "v1"}}},
+		},
+		{
+			desc:   "unsupported algorithm",
+			config: dynamic.HMACSignature{Keys: []dynamic.HMACSignatureKey{{Secret: "secret"}}, Algorithm: "md5"},
+		},
+		{
+			desc:   "unsupported encoding",
+			config: dynamic.HMACSignature{Keys: []dynamic.HMACSignatureKey{{Secret: "secret"}}, Encoding: "base32"},
+		},
+		{
+			desc:   "unsupported component",
+			config: dynamic.HMACSignature{Keys: []dynamic.HMACSignatureKey{{Secret: "secret"}}, SignedComponents: []string{"cookie"}},
+		},
+		{
+			desc:   "timestamp without header",
+			config: dynamic.HMACSignature{Keys: []dynamic.HMACSignatureKey{{Secret: "secret"}}, SignedComponents: []string{"timestamp", "body"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "hmac")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestHMACSignature_body(t *testing.T) {
+	config := dynamic.HMACSignature{
+		Keys: []dynamic.HMACSignatureKey{
+			{ID: "v2", Secret: "new-secret"},
+			{ID: "v1", Secret: "old-secret"},
+		},
+		KeyIDHeader:     "X-Key-ID",
+		SignatureHeader: "X-Hub-Signature-256",
+		SignaturePrefix: "sha256=",
+		MaxBodyBytes:    64,
+	}
+
+	testCases := []struct {
+		desc           string
+		body           string
+		signature      string
+		keyID          string
+		expectedStatus int
+	}{
+		{
+			desc:           "signed with the current key",
+			body:           `{"action":"opened"}`,
+			signature:      "sha256=" + hex.EncodeToString(sign("new-secret", `{"action":"opened"}`)),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "signed with the previous key",
+			body:           `{"action":"opened"}`,
+			signature:      "sha256=" + hex.EncodeToString(sign("old-secret", `{"action":"opened"}`)),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "signed with the identified key",
+			body:           `{"action":"opened"}`,
+			signature:      "sha256=" + hex.EncodeToString(sign("old-secret", `{"action":"opened"}`)),
+			keyID:          "v1",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "signed with another key than the identified one",
+			body:           `{"action":"opened"}`,
+			signature:      "sha256=" + hex.EncodeToString(sign("old-secret", `{"action":"opened"}`)),
+			keyID:          "v2",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "several signatures",
+			body:           `{"action":"opened"}`,
+			signature:      "sha256=" + hex.EncodeToString(sign("unknown", `{"action":"opened"}`)) + ", sha256=" + hex.EncodeToString(sign("new-secret", `{"action":"opened"}`)),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "tampered body",
+			body:           `{"action":"closed"}`,
+			signature:      "sha256=" + hex.EncodeToString(sign("new-secret", `{"action":"opened"}`)),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "missing prefix",
+			body:           `{"action":"opened"}`,
+			signature:      hex.EncodeToString(sign("new-secret", `{"action":"opened"}`)),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "missing signature",
+			body:           `{"action":"opened"}`,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "body too large",
+			body:           strings.Repeat("a", 65),
+			signature:      "sha256=" + hex.EncodeToString(sign("new-secret", strings.Repeat("a", 65))),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwardedBody string
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+
+				forwardedBody = string(body)
+			})
+
+			handler, err := New(context.Background(), next, config, "hmac")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost/webhooks", strings.NewReader(test.body))
+			if test.signature != "" {
+				req.Header.Set("X-Hub-Signature-256", test.signature)
+			}
+			if test.keyID != "" {
+				req.Header.Set("X-Key-ID", test.keyID)
+			}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+
+			if test.expectedStatus == http.StatusOK {
+				assert.Equal(t, test.body, forwardedBody)
+			}
+		})
+	}
+}
+
+func TestHMACSignature_components(t *testing.T) {
+	handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), dynamic.HMACSignature{
+		Keys:             []dynamic.HMACSignatureKey{{Secret: "secret"}},
+		Encoding:         "base64",
+		SignedComponents: []string{"timestamp", "method", "path", "query", "header:X-Request-ID", "body"},
+		Separator:        "\n",
+		TimestampHeader:  "X-Timestamp",
+	}, "hmac")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc           string
+		timestamp      time.Time
+		expectedStatus int
+	}{
+		{
+			desc:           "within the clock skew",
+			timestamp:      time.Now().Add(-time.Minute),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "outside the clock skew",
+			timestamp:      time.Now().Add(-10 * time.Minute),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "in the future",
+			timestamp:      time.Now().Add(10 * time.Minute),
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			timestamp := strconv.FormatInt(test.timestamp.Unix(), 10)
+			message := strings.Join([]string{timestamp, http.MethodPut, "/items/1", "force=true", "abc", "payload"}, "\n")
+
+			req := httptest.NewRequest(http.MethodPut, "http://localhost/items/1?force=true", strings.NewReader("payload"))
+			req.Header.Set("X-Timestamp", timestamp)
+			req.Header.Set("X-Request-ID", "abc")
+			req.Header.Set("X-Signature", base64.StdEncoding.EncodeToString(sign("secret", message)))
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+		})
+	}
+}
+
+func sign(secret, message string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+
+	return mac.Sum(nil)
+}
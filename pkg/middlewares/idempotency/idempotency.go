@@ -0,0 +1,407 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"github.com/vulcand/oxy/v2/utils"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "Idempotency"
+
+const (
+	defaultHeaderName  = "Idempotency-Key"
+	defaultScope       = "Header(`Authorization`) && Header(`Cookie`) && ClientIP()"
+	defaultTTL         = 24 * time.Hour
+	defaultLockTTL     = 30 * time.Second
+	defaultMaxBodySize = 1024 * 1024
+
+	// maxKeyLength is the maximum length of an idempotency key.
+	maxKeyLength = 255
+
+	// replayedHeader is the header set on the replayed responses.
+	replayedHeader = "Idempotent-Replayed"
+)
+
+var defaultMethods = []string{http.MethodPost, http.MethodPatch}
+
+// entry is the state of an idempotency key.
+// An entry without status is stored while the first request carrying the key is processed.
+type entry struct {
+	Fingerprint string      `json:"fingerprint"`
+	Status      int         `json:"status,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// idempotency is a middleware replaying the stored response to the requests carrying the same idempotency key.
+type idempotency struct {
+	next  http.Handler
+	name  string
+	store store
+
+	headerName  string
+	methods     []string
+	scope       utils.SourceExtractor
+	ttl         time.Duration
+	maxBodySize int64
+
+	// lockTTL is the duration the key of a request being processed is stored for,
+	// refreshed until its response is stored, for the key to be released if the instance processing it goes away.
+	lockTTL time.Duration
+}
+
+// New creates an idempotency middleware.
+// The store of the middleware is given by the manager, which can be nil.
+func New(ctx context.Context, next http.Handler, manager *Manager, config dynamic.Idempotency, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if config.TTL < 0 {
+		return nil, fmt.Errorf("invalid ttl %s: must be positive", time.Duration(config.TTL))
+	}
+
+	if config.MaxBodySize < 0 {
+		return nil, fmt.Errorf("invalid maxBodySize %d: must be positive", config.MaxBodySize)
+	}
+
+	var storeConfig dynamic.IdempotencyStore
+	if config.Store != nil {
+		storeConfig = *config.Store
+	}
+
+	scopeExpression := config.Scope
+	if scopeExpression == "" {
+		scopeExpression = defaultScope
+	}
+
+	scope, err := middlewares.GetSourceExtractor(ctx, &dynamic.SourceCriterion{Expression: scopeExpression})
+	if err != nil {
+		return nil, fmt.Errorf("parsing scope: %w", err)
+	}
+
+	s, err := manager.getStore(ctx, name, storeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating idempotency store: %w", err)
+	}
+
+	i := &idempotency{
+		next:        next,
+		name:        name,
+		store:       s,
+		headerName:  config.HeaderName,
+		methods:     defaultMethods,
+		scope:       scope,
+		ttl:         time.Duration(config.TTL),
+		maxBodySize: config.MaxBodySize,
+		lockTTL:     defaultLockTTL,
+	}
+
+	if i.headerName == "" {
+		i.headerName = defaultHeaderName
+	}
+
+	if len(config.Methods) > 0 {
+		i.methods = make([]string, len(config.Methods))
+		for j, method := range config.Methods {
+			i.methods[j] = strings.ToUpper(method)
+		}
+	}
+
+	if i.ttl == 0 {
+		i.ttl = defaultTTL
+	}
+
+	if i.maxBodySize == 0 {
+		i.maxBodySize = defaultMaxBodySize
+	}
+
+	return i, nil
+}
+
+func (i *idempotency) GetTracingInformation() (string, string, trace.SpanKind) {
+	return i.name, typeName, trace.SpanKindInternal
+}
+
+func (i *idempotency) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	key := req.Header.Get(i.headerName)
+	if key == "" || !slices.Contains(i.methods, req.Method) {
+		i.next.ServeHTTP(rw, req)
+		return
+	}
+
+	if len(key) > maxKeyLength {
+		http.Error(rw, fmt.Sprintf("Idempotency key longer than %d characters", maxKeyLength), http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, i.maxBodySize+1))
+	if err != nil {
+		http.Error(rw, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if int64(len(body)) > i.maxBodySize {
+		http.Error(rw, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	logger := middlewares.GetLogger(req.Context(), i.name, typeName)
+
+	key, err = i.storeKey(req, key)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to read the scope of the idempotency key")
+		observability.SetStatusErrorf(req.Context(), "Unable to read the scope of the idempotency key: %v", err)
+
+		http.Error(rw, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	// The store is updated even when the client goes away, for its retries to get the response.
+	ctx := context.WithoutCancel(req.Context())
+
+	fingerprint := fingerprintOf(req, body)
+
+	placeholder, err := json.Marshal(&entry{Fingerprint: fingerprint})
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to encode the idempotency key")
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	added, err := i.store.add(ctx, key, placeholder, i.lockTTL)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to store the idempotency key")
+		observability.SetStatusErrorf(req.Context(), "Unable to store the idempotency key: %v", err)
+
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	if !added {
+		i.replay(rw, req, key, fingerprint)
+		return
+	}
+
+	cw := &captureWriter{rw: rw, maxSize: i.maxBodySize}
+
+	stored := false
+	defer func() {
+		// The key is released when the response is not stored, for the request to be retried.
+		if stored {
+			return
+		}
+
+		if err := i.store.delete(ctx, key); err != nil {
+			logger.Error().Err(err).Msg("Unable to release the idempotency key")
+		}
+	}()
+
+	unlock := i.keepLocked(ctx, key, placeholder)
+	defer unlock()
+
+	i.next.ServeHTTP(cw, req)
+
+	unlock()
+
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+
+	if cw.status >= http.StatusInternalServerError || cw.tooLarge {
+		return
+	}
+
+	value, err := json.Marshal(&entry{
+		Fingerprint: fingerprint,
+		Status:      cw.status,
+		Header:      cw.header,
+		Body:        cw.body.Bytes(),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to encode the response")
+		return
+	}
+
+	if err := i.store.set(ctx, key, value, i.ttl); err != nil {
+		logger.Error().Err(err).Msg("Unable to store the response")
+		return
+	}
+
+	stored = true
+}
+
+// storeKey returns the key of the store for the given idempotency key,
+// scoped to the caller of the request for its response not to be replayed to another caller.
+// The keys are hashed, for the store not to hold the credentials of the requests.
+func (i *idempotency) storeKey(req *http.Request, key string) (string, error) {
+	scope, _, err := i.scope.Extract(req)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(strconv.Quote(scope) + "\n" + key))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// keepLocked refreshes the placeholder of the given key until the returned function is called.
+func (i *idempotency) keepLocked(ctx context.Context, key string, placeholder []byte) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(i.lockTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := i.store.set(ctx, key, placeholder, i.lockTTL); err != nil {
+					middlewares.GetLogger(ctx, i.name, typeName).Error().Err(err).Msg("Unable to refresh the idempotency key")
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+// replay serves the response stored for the given key.
+func (i *idempotency) replay(rw http.ResponseWriter, req *http.Request, key, fingerprint string) {
+	logger := middlewares.GetLogger(req.Context(), i.name, typeName)
+
+	value, err := i.store.get(req.Context(), key)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to read the stored response")
+		observability.SetStatusErrorf(req.Context(), "Unable to read the stored response: %v", err)
+
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	var stored entry
+	if value != nil {
+		if err := json.Unmarshal(value, &stored); err != nil {
+			logger.Error().Err(err).Msg("Unable to decode the stored response")
+			observability.SetStatusErrorf(req.Context(), "Unable to decode the stored response: %v", err)
+
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	switch {
+	case value != nil && stored.Fingerprint != fingerprint:
+		http.Error(rw, "The idempotency key has been used for another request", http.StatusUnprocessableEntity)
+
+	case value == nil || stored.Status == 0:
+		// The first request carrying the key is being processed, or its response has just been released.
+		http.Error(rw, "A request with the same idempotency key is being processed", http.StatusConflict)
+
+	default:
+		header := rw.Header()
+		for name, values := range stored.Header {
+			header[name] = values
+		}
+
+		header.Set(replayedHeader, "true")
+		rw.WriteHeader(stored.Status)
+
+		if _, err := rw.Write(stored.Body); err != nil {
+			logger.Debug().Err(err).Msg("Unable to write the stored response")
+		}
+	}
+}
+
+// fingerprintOf returns the fingerprint of the request, telling apart the requests sent with the same idempotency key.
+func fingerprintOf(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.RequestURI() + "\n"))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// captureWriter captures the response of the next handler, while writing it to the client.
+type captureWriter struct {
+	rw      http.ResponseWriter
+	maxSize int64
+
+	status   int
+	header   http.Header
+	body     bytes.Buffer
+	tooLarge bool
+}
+
+func (w *captureWriter) Header() http.Header {
+	return w.rw.Header()
+}
+
+func (w *captureWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+
+	// The informational responses are not stored.
+	if status < http.StatusOK {
+		w.rw.WriteHeader(status)
+		return
+	}
+
+	w.status = status
+	w.header = w.rw.Header().Clone()
+	w.rw.WriteHeader(status)
+}
+
+func (w *captureWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !w.tooLarge {
+		if int64(w.body.Len()+len(b)) > w.maxSize {
+			w.tooLarge = true
+			w.body.Reset()
+		} else {
+			w.body.Write(b)
+		}
+	}
+
+	return w.rw.Write(b)
+}
+
+func (w *captureWriter) Flush() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if flusher, ok := w.rw.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,431 @@
+package idempotency
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// countingHandler responds with the count of the requests it served, and the given status.
+type countingHandler struct {
+	count  atomic.Int32
+	status int
+}
+
+func (h *countingHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	n := h.count.Add(1)
+
+	status := h.status
+	if status == 0 {
+		status = http.StatusCreated
+	}
+
+	rw.Header().Set("Location", "/payments/"+strconv.Itoa(int(n)))
+	rw.WriteHeader(status)
+	_, _ = io.WriteString(rw, "payment "+strconv.Itoa(int(n)))
+}
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.Idempotency
+	}{
+		{
+			desc:   "negative ttl",
+			config: dynamic.Idempotency{TTL: ptypes.Duration(-time.Second)},
+		},
+		{
+			desc:   "negative maxBodySize",
+			config: dynamic.Idempotency{MaxBodySize: -1},
+		},
+		{
+			desc:   "invalid scope",
+			config: dynamic.Idempotency{Scope: "Header("},
+		},
+		{
+			desc: "several stores",
+			config: dynamic.Idempotency{
+				Store: &dynamic.IdempotencyStore{
+					Memory: &dynamic.MemoryIdempotencyStore{},
+					Redis:  &dynamic.Redis{Endpoints: []string{"localhost:6379"}},
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), nil, test.config, "idempotency")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestIdempotency(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		config           dynamic.Idempotency
+		status           int
+		requests         []*http.Request
+		expectedStatuses []int
+		expectedBodies   []string
+		expectedReplayed []string
+	}{
+		{
+			desc: "replayed response",
+			requests: []*http.Request{
+				newRequest(http.MethodPost, "/payments", "key", "amount=10"),
+				newRequest(http.MethodPost, "/payments", "key", "amount=10"),
+			},
+			expectedStatuses: []int{http.StatusCreated, http.StatusCreated},
+			expectedBodies:   []string{"payment 1", "payment 1"},
+			expectedReplayed: []string{"", "true"},
+		},
+		{
+			desc: "different keys",
+			requests: []*http.Request{
+				newRequest(http.MethodPost, "/payments", "key1", "amount=10"),
+				newRequest(http.MethodPost, "/payments", "key2", "amount=10"),
+			},
+			expectedStatuses: []int{http.StatusCreated, http.StatusCreated},
+			expectedBodies:   []string{"payment 1", "payment 2"},
+			expectedReplayed: []string{"", ""},
+		},
+		{
+			desc: "different callers",
+			requests: []*http.Request{
+				withHeader(newRequest(http.MethodPost, "/payments", "key", "amount=10"), "Authorization", "Bearer alice"),
+				withHeader(newRequest(http.MethodPost, "/payments", "key", "amount=10"), "Authorization", "Bearer bob"),
+				withHeader(newRequest(http.MethodPost, "/payments", "key", "amount=10"), "Authorization", "Bearer alice"),
+			},
+			expectedStatuses: []int{http.StatusCreated, http.StatusCreated, http.StatusCreated},
+			expectedBodies:   []string{"payment 1", "payment 2", "payment 1"},
+			expectedReplayed: []string{"", "", "true"},
+		},
+		{
+			desc: "different anonymous callers",
+			requests: []*http.Request{
+				withRemoteAddr(newRequest(http.MethodPost, "/payments", "key", "amount=10"), "10.0.0.1:1234"),
+				withRemoteAddr(newRequest(http.MethodPost, "/payments", "key", "amount=10"), "10.0.0.2:1234"),
+				withRemoteAddr(newRequest(http.MethodPost, "/payments", "key", "amount=10"), "10.0.0.1:5678"),
+			},
+			expectedStatuses: []int{http.StatusCreated, http.StatusCreated, http.StatusCreated},
+			expectedBodies:   []string{"payment 1", "payment 2", "payment 1"},
+			expectedReplayed: []string{"", "", "true"},
+		},
+		{
+			desc:   "custom scope",
+			config: dynamic.Idempotency{Scope: "Header(`X-Tenant`)"},
+			requests: []*http.Request{
+				withHeader(withHeader(newRequest(http.MethodPost, "/payments", "key", "amount=10"), "X-Tenant", "acme"), "Authorization", "Bearer alice"),
+				withHeader(withHeader(newRequest(http.MethodPost, "/payments", "key", "amount=10"), "X-Tenant", "acme"), "Authorization", "Bearer bob"),
+				withHeader(newRequest(http.MethodPost, "/payments", "key", "amount=10"), "X-Tenant", "other"),
+			},
+			expectedStatuses: []int{http.StatusCreated, http.StatusCreated, http.StatusCreated},
+			expectedBodies:   []string{"payment 1", "payment 1", "payment 2"},
+			expectedReplayed: []string{"", "true", ""},
+		},
+		{
+			desc: "no key",
+			requests: []*http.Request{
+				newRequest(http.MethodPost, "/payments", "", "amount=10"),
+				newRequest(http.MethodPost, "/payments", "", "amount=10"),
+			},
+			expectedStatuses: []int{http.StatusCreated, http.StatusCreated},
+			expectedBodies:   []string{"payment 1", "payment 2"},
+			expectedReplayed: []string{"", ""},
+		},
+		{
+			desc: "method without key",
+			requests: []*http.Request{
+				newRequest(http.MethodPut, "/payments/1", "key", "amount=10"),
+				newRequest(http.MethodPut, "/payments/1", "key", "amount=10"),
+			},
+			expectedStatuses: []int{http.StatusCreated, http.StatusCreated},
+			expectedBodies:   []string{"payment 1", "payment 2"},
+			expectedReplayed: []string{"", ""},
+		},
+		{
+			desc:   "custom header and methods",
+			config: dynamic.Idempotency{HeaderName: "X-Request-Key", Methods: []string{"put"}},
+			requests: []*http.Request{
+				newRequestWithHeader(http.MethodPut, "/payments/1", "X-Request-Key", "key"),
+				newRequestWithHeader(http.MethodPut, "/payments/1", "X-Request-Key", "key"),
+			},
+			expectedStatuses: []int{http.StatusCreated, http.StatusCreated},
+			expectedBodies:   []string{"payment 1", "payment 1"},
+			expectedReplayed: []string{"", "true"},
+		},
+		{
+			desc: "key used for another request",
+			requests: []*http.Request{
+				newRequest(http.MethodPost, "/payments", "key", "amount=10"),
+				newRequest(http.MethodPost, "/payments", "key", "amount=20"),
+			},
+			expectedStatuses: []int{http.StatusCreated, http.StatusUnprocessableEntity},
+			expectedBodies:   []string{"payment 1", "The idempotency key has been used for another request\n"},
+			expectedReplayed: []string{"", ""},
+		},
+		{
+			desc:   "server error not stored",
+			status: http.StatusBadGateway,
+			requests: []*http.Request{
+				newRequest(http.MethodPost, "/payments", "key", "amount=10"),
+				newRequest(http.MethodPost, "/payments", "key", "amount=10"),
+			},
+			expectedStatuses: []int{http.StatusBadGateway, http.StatusBadGateway},
+			expectedBodies:   []string{"payment 1", "payment 2"},
+			expectedReplayed: []string{"", ""},
+		},
+		{
+			desc:   "client error stored",
+			status: http.StatusPaymentRequired,
+			requests: []*http.Request{
+				newRequest(http.MethodPost, "/payments", "key", "amount=10"),
+				newRequest(http.MethodPost, "/payments", "key", "amount=10"),
+			},
+			expectedStatuses: []int{http.StatusPaymentRequired, http.StatusPaymentRequired},
+			expectedBodies:   []string{"payment 1", "payment 1"},
+			expectedReplayed: []string{"", "true"},
+		},
+		{
+			desc:   "response too large",
+			config: dynamic.Idempotency{MaxBodySize: 8},
+			requests: []*http.Request{
+				newRequest(http.MethodPost, "/payments", "key", ""),
+				newRequest(http.MethodPost, "/payments", "key", ""),
+			},
+			expectedStatuses: []int{http.StatusCreated, http.StatusCreated},
+			expectedBodies:   []string{"payment 1", "payment 2"},
+			expectedReplayed: []string{"", ""},
+		},
+		{
+			desc:   "request too large",
+			config: dynamic.Idempotency{MaxBodySize: 5},
+			requests: []*http.Request{
+				newRequest(http.MethodPost, "/payments", "key", "amount=10"),
+			},
+			expectedStatuses: []int{http.StatusRequestEntityTooLarge},
+			expectedBodies:   []string{"Request Entity Too Large\n"},
+			expectedReplayed: []string{""},
+		},
+		{
+			desc: "key too long",
+			requests: []*http.Request{
+				newRequest(http.MethodPost, "/payments", strings.Repeat("k", 256), ""),
+			},
+			expectedStatuses: []int{http.StatusBadRequest},
+			expectedBodies:   []string{"Idempotency key longer than 255 characters\n"},
+			expectedReplayed: []string{""},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := &countingHandler{status: test.status}
+
+			handler, err := New(context.Background(), next, nil, test.config, "idempotency")
+			require.NoError(t, err)
+
+			for i, req := range test.requests {
+				rw := httptest.NewRecorder()
+				handler.ServeHTTP(rw, req)
+
+				assert.Equal(t, test.expectedStatuses[i], rw.Code, i)
+				assert.Equal(t, test.expectedBodies[i], rw.Body.String(), i)
+				assert.Equal(t, test.expectedReplayed[i], rw.Header().Get("Idempotent-Replayed"), i)
+			}
+		})
+	}
+}
+
+func TestIdempotency_replayedHeaders(t *testing.T) {
+	handler, err := New(context.Background(), &countingHandler{}, nil, dynamic.Idempotency{}, "idempotency")
+	require.NoError(t, err)
+
+	for range 2 {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newRequest(http.MethodPost, "/payments", "key", "amount=10"))
+
+		assert.Equal(t, "/payments/1", rw.Header().Get("Location"))
+	}
+}
+
+func TestIdempotency_concurrentRequest(t *testing.T) {
+	release := make(chan struct{})
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		<-release
+		rw.WriteHeader(http.StatusCreated)
+	})
+
+	handler, err := New(context.Background(), next, nil, dynamic.Idempotency{}, "idempotency")
+	require.NoError(t, err)
+
+	done := make(chan int)
+	go func() {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newRequest(http.MethodPost, "/payments", "key", "amount=10"))
+		done <- rw.Code
+	}()
+
+	// The second request is rejected while the first one is processed.
+	assert.Eventually(t, func() bool {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newRequest(http.MethodPost, "/payments", "key", "amount=10"))
+		return rw.Code == http.StatusConflict
+	}, time.Second, 10*time.Millisecond)
+
+	close(release)
+	assert.Equal(t, http.StatusCreated, <-done)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, newRequest(http.MethodPost, "/payments", "key", "amount=10"))
+	assert.Equal(t, http.StatusCreated, rw.Code)
+	assert.Equal(t, "true", rw.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotency_lockRefreshed(t *testing.T) {
+	release := make(chan struct{})
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		<-release
+		rw.WriteHeader(http.StatusCreated)
+	})
+
+	handler, err := New(context.Background(), next, nil, dynamic.Idempotency{}, "idempotency")
+	require.NoError(t, err)
+
+	handler.(*idempotency).lockTTL = 30 * time.Millisecond
+
+	done := make(chan int)
+	go func() {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newRequest(http.MethodPost, "/payments", "key", "amount=10"))
+		done <- rw.Code
+	}()
+
+	assert.Eventually(t, func() bool {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newRequest(http.MethodPost, "/payments", "key", "amount=10"))
+		return rw.Code == http.StatusConflict
+	}, time.Second, 10*time.Millisecond)
+
+	// The key is still locked after the lock TTL, as long as the first request is processed.
+	time.Sleep(100 * time.Millisecond)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, newRequest(http.MethodPost, "/payments", "key", "amount=10"))
+	assert.Equal(t, http.StatusConflict, rw.Code)
+
+	close(release)
+	assert.Equal(t, http.StatusCreated, <-done)
+
+	// The response is stored for the full TTL.
+	time.Sleep(100 * time.Millisecond)
+
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, newRequest(http.MethodPost, "/payments", "key", "amount=10"))
+	assert.Equal(t, http.StatusCreated, rw.Code)
+	assert.Equal(t, "true", rw.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotency_redis(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	manager := NewManager()
+	t.Cleanup(manager.Close)
+
+	next := &countingHandler{}
+	config := dynamic.Idempotency{
+		Store: &dynamic.IdempotencyStore{Redis: &dynamic.Redis{Endpoints: []string{server.Addr()}}},
+	}
+
+	handler, err := New(context.Background(), next, manager, config, "idempotency@file")
+	require.NoError(t, err)
+
+	for range 2 {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newRequest(http.MethodPost, "/payments", "key", "amount=10"))
+
+		assert.Equal(t, http.StatusCreated, rw.Code)
+		assert.Equal(t, "payment 1", rw.Body.String())
+	}
+
+	key, err := handler.(*idempotency).storeKey(newRequest(http.MethodPost, "/payments", "key", ""), "key")
+	require.NoError(t, err)
+
+	assert.True(t, server.Exists("traefik:idempotency:idempotency@file:"+key))
+	assert.False(t, server.Exists("traefik:idempotency:idempotency@file:key"))
+}
+
+func TestManager(t *testing.T) {
+	manager := NewManager()
+	t.Cleanup(manager.Close)
+
+	next := &countingHandler{}
+
+	serve := func(config dynamic.Idempotency) string {
+		t.Helper()
+
+		handler, err := New(context.Background(), next, manager, config, "idempotency@file")
+		require.NoError(t, err)
+
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newRequest(http.MethodPost, "/payments", "key", "amount=10"))
+
+		return rw.Body.String()
+	}
+
+	assert.Equal(t, "payment 1", serve(dynamic.Idempotency{}))
+
+	// The store is kept when the middleware is created again with the same store configuration.
+	assert.Equal(t, "payment 1", serve(dynamic.Idempotency{TTL: ptypes.Duration(time.Hour)}))
+
+	// The store is created again when its configuration changes.
+	assert.Equal(t, "payment 2", serve(dynamic.Idempotency{
+		Store: &dynamic.IdempotencyStore{Memory: &dynamic.MemoryIdempotencyStore{MaxSize: 1024}},
+	}))
+}
+
+func newRequest(method, target, key, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	return req
+}
+
+func newRequestWithHeader(method, target, name, key string) *http.Request {
+	req := httptest.NewRequest(method, target, http.NoBody)
+	req.Header.Set(name, key)
+
+	return req
+}
+
+func withHeader(req *http.Request, name, value string) *http.Request {
+	req.Header.Set(name, value)
+
+	return req
+}
+
+func withRemoteAddr(req *http.Request, remoteAddr string) *http.Request {
+	req.RemoteAddr = remoteAddr
+
+	return req
+}
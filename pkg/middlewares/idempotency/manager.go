@@ -0,0 +1,91 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+type managedStore struct {
+	config dynamic.IdempotencyStore
+	store  store
+}
+
+// Manager holds the stores of the idempotency middlewares,
+// so that the stored responses are kept across the configuration reloads.
+type Manager struct {
+	mu     sync.Mutex
+	stores map[string]*managedStore
+}
+
+// NewManager creates a new Manager.
+func NewManager() *Manager {
+	return &Manager{stores: make(map[string]*managedStore)}
+}
+
+// Close closes the connections of the stores.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, managed := range m.stores {
+		if err := managed.store.close(); err != nil {
+			log.Error().Err(err).Str("middlewareName", name).Msg("Unable to close idempotency store")
+		}
+	}
+
+	m.stores = make(map[string]*managedStore)
+}
+
+// getStore returns the store of the given middleware, which is created again only when its configuration changes.
+// A nil Manager creates a new store on each call.
+func (m *Manager) getStore(ctx context.Context, middlewareName string, config dynamic.IdempotencyStore) (store, error) {
+	if m == nil {
+		return newStore(ctx, middlewareName, config)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if managed, ok := m.stores[middlewareName]; ok {
+		if reflect.DeepEqual(managed.config, config) {
+			return managed.store, nil
+		}
+
+		if err := managed.store.close(); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Unable to close idempotency store")
+		}
+
+		delete(m.stores, middlewareName)
+	}
+
+	s, err := newStore(ctx, middlewareName, config)
+	if err != nil {
+		return nil, err
+	}
+
+	m.stores[middlewareName] = &managedStore{config: *config.DeepCopy(), store: s}
+
+	return s, nil
+}
+
+func newStore(ctx context.Context, middlewareName string, config dynamic.IdempotencyStore) (store, error) {
+	if config.Memory != nil && config.Redis != nil {
+		return nil, errors.New("only one of the memory and redis stores can be defined")
+	}
+
+	if config.Redis != nil {
+		return newRedisStore(ctx, middlewareName, *config.Redis)
+	}
+
+	var maxSize int64
+	if config.Memory != nil {
+		maxSize = config.Memory.MaxSize
+	}
+
+	return newMemoryStore(maxSize), nil
+}
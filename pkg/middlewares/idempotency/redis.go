@@ -0,0 +1,54 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikredis "github.com/traefik/traefik/v3/pkg/redis"
+)
+
+// redisStore is a store shared by the Traefik instances connected to the same Redis server or cluster.
+type redisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func newRedisStore(ctx context.Context, name string, config dynamic.Redis) (*redisStore, error) {
+	client, err := traefikredis.NewClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redisStore{
+		client: client,
+		prefix: "traefik:idempotency:" + name + ":",
+	}, nil
+}
+
+func (s *redisStore) get(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+
+	return value, err
+}
+
+func (s *redisStore) add(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, s.prefix+key, value, ttl).Result()
+}
+
+func (s *redisStore) set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, s.prefix+key, value, ttl).Err()
+}
+
+func (s *redisStore) delete(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key).Err()
+}
+
+func (s *redisStore) close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,147 @@
+package idempotency
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultMemoryMaxSize = 100 * 1024 * 1024
+
+// store stores the encoded entries of the idempotency keys.
+type store interface {
+	// get returns the value of the given key, or nil if there is none.
+	get(ctx context.Context, key string) ([]byte, error)
+	// add stores the value of the given key for the given duration, unless the key is already stored,
+	// and reports whether the value was stored.
+	add(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	// set stores the value of the given key for the given duration.
+	set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// delete removes the given key.
+	delete(ctx context.Context, key string) error
+	// close releases the resources of the store.
+	close() error
+}
+
+type memoryItem struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func (i *memoryItem) size() int64 {
+	return int64(len(i.key) + len(i.value))
+}
+
+// memoryStore is an in-memory store, forgetting the oldest keys first when full.
+// The items are kept in the order they are stored, and the expired ones are removed
+// when reached in this order, or when looked up.
+type memoryStore struct {
+	mu      sync.Mutex
+	maxSize int64
+	size    int64
+	items   map[string]*list.Element
+	order   *list.List
+}
+
+func newMemoryStore(maxSize int64) *memoryStore {
+	if maxSize <= 0 {
+		maxSize = defaultMemoryMaxSize
+	}
+
+	return &memoryStore{
+		maxSize: maxSize,
+		items:   make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (s *memoryStore) get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elt, ok := s.items[key]
+	if !ok {
+		return nil, nil
+	}
+
+	item := elt.Value.(*memoryItem)
+	if time.Now().After(item.expiresAt) {
+		s.remove(elt)
+		return nil, nil
+	}
+
+	return item.value, nil
+}
+
+func (s *memoryStore) add(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elt, ok := s.items[key]; ok {
+		if time.Now().Before(elt.Value.(*memoryItem).expiresAt) {
+			return false, nil
+		}
+
+		s.remove(elt)
+	}
+
+	s.store(key, value, ttl)
+
+	return true, nil
+}
+
+func (s *memoryStore) set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elt, ok := s.items[key]; ok {
+		s.remove(elt)
+	}
+
+	s.store(key, value, ttl)
+
+	return nil
+}
+
+func (s *memoryStore) delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elt, ok := s.items[key]; ok {
+		s.remove(elt)
+	}
+
+	return nil
+}
+
+func (s *memoryStore) close() error {
+	return nil
+}
+
+func (s *memoryStore) store(key string, value []byte, ttl time.Duration) {
+	item := &memoryItem{key: key, value: value, expiresAt: time.Now().Add(ttl)}
+	if item.size() > s.maxSize {
+		return
+	}
+
+	s.items[key] = s.order.PushBack(item)
+	s.size += item.size()
+
+	// The expired items are removed, then the oldest ones while the store is full.
+	now := time.Now()
+	for front := s.order.Front(); front != nil; front = s.order.Front() {
+		if s.size <= s.maxSize && now.Before(front.Value.(*memoryItem).expiresAt) {
+			break
+		}
+
+		s.remove(front)
+	}
+}
+
+func (s *memoryStore) remove(elt *list.Element) {
+	item := s.order.Remove(elt).(*memoryItem)
+	delete(s.items, item.key)
+	s.size -= item.size()
+}
@@ -0,0 +1,76 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_add(t *testing.T) {
+	ctx := context.Background()
+	s := newMemoryStore(0)
+
+	added, err := s.add(ctx, "a", []byte("aaa"), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = s.add(ctx, "a", []byte("bbb"), time.Minute)
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	value, err := s.get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("aaa"), value)
+
+	// An expired key is added again.
+	require.NoError(t, s.set(ctx, "b", []byte("bbb"), -time.Second))
+
+	added, err = s.add(ctx, "b", []byte("ccc"), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, added)
+}
+
+func TestMemoryStore_eviction(t *testing.T) {
+	ctx := context.Background()
+
+	// Each item is 4 bytes: a 1 byte key, and a 3 bytes value.
+	s := newMemoryStore(12)
+
+	require.NoError(t, s.set(ctx, "a", []byte("aaa"), time.Minute))
+	require.NoError(t, s.set(ctx, "b", []byte("bbb"), time.Minute))
+	require.NoError(t, s.set(ctx, "c", []byte("ccc"), time.Minute))
+
+	// a is the oldest item, evicted in favor of d, even when read recently.
+	value, err := s.get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("aaa"), value)
+
+	require.NoError(t, s.set(ctx, "d", []byte("ddd"), time.Minute))
+
+	value, err = s.get(ctx, "a")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	for _, key := range []string{"b", "c", "d"} {
+		value, err = s.get(ctx, key)
+		require.NoError(t, err)
+		assert.NotNil(t, value, key)
+	}
+
+	assert.Equal(t, int64(12), s.size)
+}
+
+func TestMemoryStore_expiration(t *testing.T) {
+	ctx := context.Background()
+	s := newMemoryStore(0)
+
+	require.NoError(t, s.set(ctx, "a", []byte("aaa"), -time.Second))
+
+	value, err := s.get(ctx, "a")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Equal(t, int64(0), s.size)
+}
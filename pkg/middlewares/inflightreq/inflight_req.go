@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/logs"
+	"github.com/traefik/traefik/v3/pkg/metrics"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
 	"github.com/vulcand/oxy/v2/connlimit"
+	"github.com/vulcand/oxy/v2/utils"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -24,7 +27,8 @@ type inFlightReq struct {
 
 // New creates a max request middleware.
 // If no source criterion is provided in the config, it defaults to RequestHost.
-func New(ctx context.Context, next http.Handler, config dynamic.InFlightReq, name string) (http.Handler, error) {
+// When a queue is configured, the requests exceeding the amount wait for a slot instead of being rejected.
+func New(ctx context.Context, next http.Handler, config dynamic.InFlightReq, name string, registry metrics.Registry) (http.Handler, error) {
 	logger := middlewares.GetLogger(ctx, name, typeName)
 	logger.Debug().Msg("Creating middleware")
 
@@ -32,7 +36,8 @@ func New(ctx context.Context, next http.Handler, config dynamic.InFlightReq, nam
 
 	if config.SourceCriterion == nil ||
 		config.SourceCriterion.IPStrategy == nil &&
-			config.SourceCriterion.RequestHeaderName == "" && !config.SourceCriterion.RequestHost {
+			config.SourceCriterion.RequestHeaderName == "" && !config.SourceCriterion.RequestHost &&
+			config.SourceCriterion.Expression == "" {
 		config.SourceCriterion = &dynamic.SourceCriterion{
 			RequestHost: true,
 		}
@@ -43,6 +48,10 @@ func New(ctx context.Context, next http.Handler, config dynamic.InFlightReq, nam
 		return nil, fmt.Errorf("error creating requests limiter: %w", err)
 	}
 
+	if config.Queue != nil {
+		return newQueue(next, config, name, sourceMatcher, registry)
+	}
+
 	handler, err := connlimit.New(next, sourceMatcher, config.Amount,
 		connlimit.Logger(logs.NewOxyWrapper(*logger)),
 		connlimit.Verbose(logger.GetLevel() == zerolog.TraceLevel))
@@ -53,6 +62,37 @@ func New(ctx context.Context, next http.Handler, config dynamic.InFlightReq, nam
 	return &inFlightReq{handler: handler, name: name}, nil
 }
 
+func newQueue(next http.Handler, config dynamic.InFlightReq, name string, extractor utils.SourceExtractor, registry metrics.Registry) (http.Handler, error) {
+	if config.Amount <= 0 {
+		return nil, fmt.Errorf("invalid amount %d, must be positive", config.Amount)
+	}
+
+	if config.Queue.Size <= 0 {
+		return nil, fmt.Errorf("invalid queue size %d, must be positive", config.Queue.Size)
+	}
+
+	if config.Queue.MaxWait <= 0 {
+		return nil, fmt.Errorf("invalid queue max wait %s, must be positive", time.Duration(config.Queue.MaxWait))
+	}
+
+	q := &queueLimiter{
+		next:      next,
+		name:      name,
+		extractor: extractor,
+		amount:    config.Amount,
+		queueSize: config.Queue.Size,
+		maxWait:   time.Duration(config.Queue.MaxWait),
+		sources:   make(map[string]*source),
+	}
+
+	if registry != nil {
+		q.depthGauge = registry.InFlightReqQueueDepthGauge()
+		q.queuedCounter = registry.InFlightReqQueueReqsCounter()
+	}
+
+	return &inFlightReq{handler: q, name: name}, nil
+}
+
 func (i *inFlightReq) GetTracingInformation() (string, string, trace.SpanKind) {
 	return i.name, typeName, trace.SpanKindInternal
 }
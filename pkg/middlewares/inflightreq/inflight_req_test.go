@@ -0,0 +1,163 @@
+package inflightreq
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_queue(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.InFlightReq
+	}{
+		{
+			desc: "no amount",
+			config: dynamic.InFlightReq{
+				Queue: &dynamic.InFlightReqQueue{Size: 1, MaxWait: ptypes.Duration(time.Second)},
+			},
+		},
+		{
+			desc: "no queue size",
+			config: dynamic.InFlightReq{
+				Amount: 1,
+				Queue:  &dynamic.InFlightReqQueue{MaxWait: ptypes.Duration(time.Second)},
+			},
+		},
+		{
+			desc: "no max wait",
+			config: dynamic.InFlightReq{
+				Amount: 1,
+				Queue:  &dynamic.InFlightReqQueue{Size: 1},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+			_, err := New(context.Background(), next, test.config, "traefikTest", nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestInFlightReq_queue(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan string, 10)
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Host != "foo.com" {
+			return
+		}
+
+		started <- req.URL.Path
+		<-release
+	})
+
+	config := dynamic.InFlightReq{
+		Amount: 1,
+		Queue:  &dynamic.InFlightReqQueue{Size: 2, MaxWait: ptypes.Duration(5 * time.Second)},
+	}
+
+	handler, err := New(context.Background(), next, config, "traefikTest", nil)
+	require.NoError(t, err)
+
+	codes := make(map[string]int)
+	var codesMu sync.Mutex
+	var wg sync.WaitGroup
+
+	serve := func(path string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://foo.com"+path, nil))
+
+			codesMu.Lock()
+			codes[path] = recorder.Code
+			codesMu.Unlock()
+		}()
+	}
+
+	serve("/first")
+	assert.Equal(t, "/first", <-started)
+
+	serve("/second")
+	waitDepth(t, handler, 1)
+	serve("/third")
+	waitDepth(t, handler, 2)
+
+	// The queue is full.
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://foo.com/fourth", nil))
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+
+	// The requests from another source are not queued.
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://bar.com/other", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// The queued requests are served in order.
+	release <- struct{}{}
+	assert.Equal(t, "/second", <-started)
+	release <- struct{}{}
+	assert.Equal(t, "/third", <-started)
+	release <- struct{}{}
+
+	wg.Wait()
+
+	assert.Equal(t, map[string]int{"/first": http.StatusOK, "/second": http.StatusOK, "/third": http.StatusOK}, codes)
+	waitDepth(t, handler, 0)
+}
+
+func TestInFlightReq_queueMaxWait(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		close(started)
+		<-release
+	})
+
+	config := dynamic.InFlightReq{
+		Amount: 1,
+		Queue:  &dynamic.InFlightReqQueue{Size: 1, MaxWait: ptypes.Duration(50 * time.Millisecond)},
+	}
+
+	handler, err := New(context.Background(), next, config, "traefikTest", nil)
+	require.NoError(t, err)
+
+	go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://foo.com", nil))
+	<-started
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://foo.com", nil))
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+
+	close(release)
+	waitDepth(t, handler, 0)
+}
+
+func waitDepth(t *testing.T, handler http.Handler, depth int64) {
+	t.Helper()
+
+	q := handler.(*inFlightReq).handler.(*queueLimiter)
+	assert.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		return q.depth == depth
+	}, 5*time.Second, 10*time.Millisecond)
+}
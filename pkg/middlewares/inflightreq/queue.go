@@ -0,0 +1,166 @@
+package inflightreq
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/rs/zerolog/log"
+	"github.com/vulcand/oxy/v2/utils"
+)
+
+// Results of the requests handled by the queue, as reported by the queue requests metric.
+const (
+	queueResultServed   = "served"
+	queueResultExpired  = "expired"
+	queueResultRejected = "rejected"
+	queueResultCanceled = "canceled"
+)
+
+// source holds the state of the requests sharing the same source.
+type source struct {
+	inFlight int64
+	// waiters is the FIFO list of the channels of the queued requests,
+	// closed when a slot is handed over to the request.
+	waiters list.List
+}
+
+// queueLimiter limits the number of in-flight requests for each source,
+// and queues the requests exceeding the limit until a slot is released, or the max wait is elapsed.
+type queueLimiter struct {
+	next      http.Handler
+	name      string
+	extractor utils.SourceExtractor
+	amount    int64
+	queueSize int64
+	maxWait   time.Duration
+
+	mu      sync.Mutex
+	sources map[string]*source
+	// depth is the number of queued requests, all sources included.
+	depth int64
+
+	depthGauge    gokitmetrics.Gauge
+	queuedCounter gokitmetrics.Counter
+}
+
+func (q *queueLimiter) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	token, _, err := q.extractor.Extract(req)
+	if err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Msg("Failed to extract source of the request")
+		utils.DefaultHandler.ServeHTTP(rw, req, err)
+		return
+	}
+
+	q.mu.Lock()
+
+	src, ok := q.sources[token]
+	if !ok {
+		src = &source{}
+		q.sources[token] = src
+	}
+
+	if src.inFlight < q.amount && src.waiters.Len() == 0 {
+		src.inFlight++
+		q.mu.Unlock()
+
+		q.serve(rw, req, token)
+		return
+	}
+
+	if int64(src.waiters.Len()) >= q.queueSize {
+		q.mu.Unlock()
+
+		q.count(queueResultRejected)
+		reject(rw, "max queued requests reached")
+		return
+	}
+
+	ready := make(chan struct{})
+	elem := src.waiters.PushBack(ready)
+	q.setDepth(1)
+	q.mu.Unlock()
+
+	timer := time.NewTimer(q.maxWait)
+	defer timer.Stop()
+
+	var result string
+	select {
+	case <-ready:
+		q.count(queueResultServed)
+		q.serve(rw, req, token)
+		return
+	case <-timer.C:
+		result = queueResultExpired
+	case <-req.Context().Done():
+		result = queueResultCanceled
+	}
+
+	q.mu.Lock()
+	select {
+	case <-ready:
+		// The slot has been handed over while giving up, it is released right away.
+		q.mu.Unlock()
+		q.release(token)
+	default:
+		src.waiters.Remove(elem)
+		q.setDepth(-1)
+		q.mu.Unlock()
+	}
+
+	q.count(result)
+
+	if result == queueResultExpired {
+		reject(rw, "max wait exceeded")
+	}
+}
+
+func (q *queueLimiter) serve(rw http.ResponseWriter, req *http.Request, token string) {
+	defer q.release(token)
+
+	q.next.ServeHTTP(rw, req)
+}
+
+// release hands the slot of a finished request over to the first queued request of the same source,
+// or frees it when no request is waiting.
+func (q *queueLimiter) release(token string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	src := q.sources[token]
+
+	if front := src.waiters.Front(); front != nil {
+		src.waiters.Remove(front)
+		q.setDepth(-1)
+		close(front.Value.(chan struct{}))
+		return
+	}
+
+	src.inFlight--
+	if src.inFlight == 0 {
+		delete(q.sources, token)
+	}
+}
+
+// setDepth updates the number of queued requests.
+// It must be called with the lock held.
+func (q *queueLimiter) setDepth(delta int64) {
+	q.depth += delta
+
+	if q.depthGauge != nil {
+		q.depthGauge.With("middleware", q.name).Set(float64(q.depth))
+	}
+}
+
+func (q *queueLimiter) count(result string) {
+	if q.queuedCounter != nil {
+		q.queuedCounter.With("middleware", q.name, "result", result).Add(1)
+	}
+}
+
+func reject(rw http.ResponseWriter, msg string) {
+	rw.WriteHeader(http.StatusTooManyRequests)
+	_, _ = rw.Write([]byte(msg))
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/ip"
@@ -18,10 +20,17 @@ const (
 	typeName = "IPAllowLister"
 )
 
+const defaultRefreshInterval = time.Minute
+
+// authorizer checks that the client IPs are allowed.
+type authorizer interface {
+	IsAuthorized(addr string) error
+}
+
 // ipAllowLister is a middleware that provides Checks of the Requesting IP against a set of Allowlists.
 type ipAllowLister struct {
 	next             http.Handler
-	allowLister      *ip.Checker
+	allowLister      authorizer
 	strategy         ip.Strategy
 	name             string
 	rejectStatusCode int
@@ -32,10 +41,6 @@ func New(ctx context.Context, next http.Handler, config dynamic.IPAllowList, nam
 	logger := middlewares.GetLogger(ctx, name, typeName)
 	logger.Debug().Msg("Creating middleware")
 
-	if len(config.SourceRange) == 0 {
-		return nil, errors.New("sourceRange is empty, IPAllowLister not created")
-	}
-
 	rejectStatusCode := config.RejectStatusCode
 	// If RejectStatusCode is not given, default to Forbidden (403).
 	if rejectStatusCode == 0 {
@@ -44,9 +49,9 @@ func New(ctx context.Context, next http.Handler, config dynamic.IPAllowList, nam
 		return nil, fmt.Errorf("invalid HTTP status code %d", rejectStatusCode)
 	}
 
-	checker, err := ip.NewChecker(config.SourceRange)
+	checker, err := newAuthorizer(logger, config.SourceRange, config.DynamicSourceRange)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse CIDRs %s: %w", config.SourceRange, err)
+		return nil, err
 	}
 
 	strategy, err := config.IPStrategy.Get()
@@ -86,6 +91,39 @@ func (al *ipAllowLister) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	al.next.ServeHTTP(rw, req)
 }
 
+// newAuthorizer builds the checker of the allowed IPs, loading them at runtime from the dynamic sources if any.
+func newAuthorizer(logger *zerolog.Logger, sourceRange []string, dynamicSourceRange *dynamic.DynamicSourceRange) (authorizer, error) {
+	if dynamicSourceRange == nil {
+		if len(sourceRange) == 0 {
+			return nil, errors.New("sourceRange is empty, IPAllowLister not created")
+		}
+
+		checker, err := ip.NewChecker(sourceRange)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse CIDRs %s: %w", sourceRange, err)
+		}
+
+		return checker, nil
+	}
+
+	refreshInterval := time.Duration(dynamicSourceRange.RefreshInterval)
+	if refreshInterval <= 0 {
+		refreshInterval = defaultRefreshInterval
+	}
+
+	checker, err := ip.NewDynamicChecker(logger, sourceRange, ip.DynamicSources{
+		URLs:            dynamicSourceRange.URLs,
+		DNSNames:        dynamicSourceRange.DNSNames,
+		Files:           dynamicSourceRange.Files,
+		RefreshInterval: refreshInterval,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse CIDRs %s: %w", sourceRange, err)
+	}
+
+	return checker, nil
+}
+
 func reject(ctx context.Context, statusCode int, rw http.ResponseWriter) {
 	rw.WriteHeader(statusCode)
 	_, err := rw.Write([]byte(http.StatusText(statusCode)))
@@ -38,6 +38,24 @@ func TestNewIPAllowLister(t *testing.T) {
 			},
 			expectedError: true,
 		},
+		{
+			desc:          "no source range",
+			allowList:     dynamic.IPAllowList{},
+			expectedError: true,
+		},
+		{
+			desc: "dynamic source range only",
+			allowList: dynamic.IPAllowList{
+				DynamicSourceRange: &dynamic.DynamicSourceRange{DNSNames: []string{"localhost"}},
+			},
+		},
+		{
+			desc: "empty dynamic source range",
+			allowList: dynamic.IPAllowList{
+				DynamicSourceRange: &dynamic.DynamicSourceRange{},
+			},
+			expectedError: true,
+		},
 	}
 
 	for _, test := range testCases {
@@ -89,6 +107,24 @@ func TestIPAllowLister_ServeHTTP(t *testing.T) {
 			remoteAddr: "20.20.20.20:1234",
 			expected:   200,
 		},
+		{
+			desc: "authorized with dynamic source range",
+			allowList: dynamic.IPAllowList{
+				SourceRange:        []string{"20.20.20.20"},
+				DynamicSourceRange: &dynamic.DynamicSourceRange{DNSNames: []string{"localhost"}},
+			},
+			remoteAddr: "127.0.0.1:1234",
+			expected:   200,
+		},
+		{
+			desc: "non authorized with dynamic source range",
+			allowList: dynamic.IPAllowList{
+				SourceRange:        []string{"20.20.20.20"},
+				DynamicSourceRange: &dynamic.DynamicSourceRange{DNSNames: []string{"localhost"}},
+			},
+			remoteAddr: "20.20.20.21:1234",
+			expected:   403,
+		},
 		{
 			desc: "non authorized with remote address, reject 404",
 			allowList: dynamic.IPAllowList{
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/ip"
@@ -18,10 +20,17 @@ const (
 	typeName = "IPWhiteLister"
 )
 
+const defaultRefreshInterval = time.Minute
+
+// authorizer checks that the client IPs are allowed.
+type authorizer interface {
+	IsAuthorized(addr string) error
+}
+
 // ipWhiteLister is a middleware that provides Checks of the Requesting IP against a set of Whitelists.
 type ipWhiteLister struct {
 	next        http.Handler
-	whiteLister *ip.Checker
+	whiteLister authorizer
 	strategy    ip.Strategy
 	name        string
 }
@@ -31,13 +40,9 @@ func New(ctx context.Context, next http.Handler, config dynamic.IPWhiteList, nam
 	logger := middlewares.GetLogger(ctx, name, typeName)
 	logger.Debug().Msg("Creating middleware")
 
-	if len(config.SourceRange) == 0 {
-		return nil, errors.New("sourceRange is empty, IPWhiteLister not created")
-	}
-
-	checker, err := ip.NewChecker(config.SourceRange)
+	checker, err := newAuthorizer(logger, config.SourceRange, config.DynamicSourceRange)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse CIDR whitelist %s: %w", config.SourceRange, err)
+		return nil, err
 	}
 
 	strategy, err := config.IPStrategy.Get()
@@ -76,6 +81,39 @@ func (wl *ipWhiteLister) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	wl.next.ServeHTTP(rw, req)
 }
 
+// newAuthorizer builds the checker of the allowed IPs, loading them at runtime from the dynamic sources if any.
+func newAuthorizer(logger *zerolog.Logger, sourceRange []string, dynamicSourceRange *dynamic.DynamicSourceRange) (authorizer, error) {
+	if dynamicSourceRange == nil {
+		if len(sourceRange) == 0 {
+			return nil, errors.New("sourceRange is empty, IPWhiteLister not created")
+		}
+
+		checker, err := ip.NewChecker(sourceRange)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse CIDR whitelist %s: %w", sourceRange, err)
+		}
+
+		return checker, nil
+	}
+
+	refreshInterval := time.Duration(dynamicSourceRange.RefreshInterval)
+	if refreshInterval <= 0 {
+		refreshInterval = defaultRefreshInterval
+	}
+
+	checker, err := ip.NewDynamicChecker(logger, sourceRange, ip.DynamicSources{
+		URLs:            dynamicSourceRange.URLs,
+		DNSNames:        dynamicSourceRange.DNSNames,
+		Files:           dynamicSourceRange.Files,
+		RefreshInterval: refreshInterval,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse CIDR whitelist %s: %w", sourceRange, err)
+	}
+
+	return checker, nil
+}
+
 func reject(ctx context.Context, rw http.ResponseWriter) {
 	statusCode := http.StatusForbidden
 
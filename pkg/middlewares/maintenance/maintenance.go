@@ -0,0 +1,227 @@
+package maintenance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/ip"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "Maintenance"
+
+const (
+	defaultContentType = "text/html; charset=utf-8"
+
+	// flagFileCheckInterval is the interval between two checks of the existence of the flag file.
+	flagFileCheckInterval = time.Second
+)
+
+// bodyTemplate is implemented by the text and HTML templates.
+type bodyTemplate interface {
+	Execute(wr io.Writer, data any) error
+}
+
+// templateData is the data available in the body template.
+type templateData struct {
+	StatusCode int
+	Status     string
+	RetryAfter int
+	Method     string
+	Host       string
+	Path       string
+}
+
+// maintenance is a middleware serving a maintenance page while the maintenance mode is enabled.
+type maintenance struct {
+	next        http.Handler
+	name        string
+	manager     *Manager
+	enabled     bool
+	flagFile    string
+	statusCode  int
+	retryAfter  int
+	contentType string
+	body        bodyTemplate
+	bypass      *ip.Checker
+	strategy    ip.Strategy
+
+	// flagCheckedAt is the time, in Unix nanoseconds, of the last check of the flag file.
+	flagCheckedAt atomic.Int64
+	flagExists    atomic.Bool
+}
+
+// New creates a maintenance middleware.
+// The manager holds the maintenance modes set at runtime, it is optional.
+func New(ctx context.Context, next http.Handler, manager *Manager, config dynamic.Maintenance, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	m := &maintenance{
+		next:        next,
+		name:        name,
+		manager:     manager,
+		enabled:     config.Enabled,
+		flagFile:    config.FlagFile,
+		statusCode:  config.StatusCode,
+		retryAfter:  int(time.Duration(config.RetryAfter).Seconds()),
+		contentType: config.ContentType,
+	}
+
+	if m.statusCode == 0 {
+		m.statusCode = http.StatusServiceUnavailable
+	} else if http.StatusText(m.statusCode) == "" {
+		return nil, fmt.Errorf("invalid HTTP status code %d", m.statusCode)
+	}
+
+	if m.retryAfter < 0 {
+		return nil, fmt.Errorf("invalid retryAfter %s", time.Duration(config.RetryAfter))
+	}
+
+	if m.contentType == "" {
+		m.contentType = defaultContentType
+	}
+
+	if config.Body != "" && config.File != "" {
+		return nil, errors.New("only one of body and file can be defined")
+	}
+
+	body := config.Body
+	if config.File != "" {
+		content, err := os.ReadFile(config.File)
+		if err != nil {
+			return nil, fmt.Errorf("reading body file: %w", err)
+		}
+
+		body = string(content)
+	}
+
+	if body == "" {
+		body = "{{ .Status }}"
+	}
+
+	var err error
+	m.body, err = parseTemplate(name, m.contentType, body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing body template: %w", err)
+	}
+
+	if len(config.SourceRange) > 0 {
+		m.bypass, err = ip.NewChecker(config.SourceRange)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse CIDRs %s: %w", config.SourceRange, err)
+		}
+
+		m.strategy, err = config.IPStrategy.Get()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// parseTemplate parses the body template, escaping the data in the HTML bodies.
+func parseTemplate(name, contentType, body string) (bodyTemplate, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content type %q: %w", contentType, err)
+	}
+
+	if mediaType == "text/html" {
+		return htmltemplate.New(name).Parse(body)
+	}
+
+	return template.New(name).Parse(body)
+}
+
+func (m *maintenance) GetTracingInformation() (string, string, trace.SpanKind) {
+	return m.name, typeName, trace.SpanKindInternal
+}
+
+func (m *maintenance) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if !m.isEnabled() {
+		m.next.ServeHTTP(rw, req)
+		return
+	}
+
+	logger := middlewares.GetLogger(req.Context(), m.name, typeName)
+
+	if m.bypass != nil {
+		clientIP := m.strategy.GetIP(req)
+		if err := m.bypass.IsAuthorized(clientIP); err == nil {
+			logger.Debug().Msgf("Bypassing maintenance mode for IP %s", clientIP)
+			m.next.ServeHTTP(rw, req)
+			return
+		}
+	}
+
+	observability.SetStatusErrorf(req.Context(), "Maintenance mode enabled")
+
+	data := templateData{
+		StatusCode: m.statusCode,
+		Status:     http.StatusText(m.statusCode),
+		RetryAfter: m.retryAfter,
+		Method:     req.Method,
+		Host:       req.Host,
+		Path:       req.URL.Path,
+	}
+
+	var body bytes.Buffer
+	if err := m.body.Execute(&body, data); err != nil {
+		logger.Error().Err(err).Msg("Error while executing the maintenance body template")
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", m.contentType)
+	rw.Header().Set("Cache-Control", "no-store")
+	if m.retryAfter > 0 {
+		rw.Header().Set("Retry-After", strconv.Itoa(m.retryAfter))
+	}
+
+	rw.WriteHeader(m.statusCode)
+	if _, err := rw.Write(body.Bytes()); err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Send()
+	}
+}
+
+// isEnabled returns whether the maintenance mode is enabled,
+// at runtime through the API, in the configuration, or by the flag file.
+func (m *maintenance) isEnabled() bool {
+	if enabled, ok := m.manager.State(m.name); ok {
+		return enabled
+	}
+
+	if m.enabled {
+		return true
+	}
+
+	if m.flagFile == "" {
+		return false
+	}
+
+	if time.Since(time.Unix(0, m.flagCheckedAt.Load())) >= flagFileCheckInterval {
+		m.flagCheckedAt.Store(time.Now().UnixNano())
+
+		_, err := os.Stat(m.flagFile)
+		m.flagExists.Store(err == nil)
+	}
+
+	return m.flagExists.Load()
+}
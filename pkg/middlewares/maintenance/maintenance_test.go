@@ -0,0 +1,227 @@
+package maintenance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		config        dynamic.Maintenance
+		expectedError bool
+	}{
+		{
+			desc:   "default configuration",
+			config: dynamic.Maintenance{},
+		},
+		{
+			desc:          "invalid HTTP status code",
+			config:        dynamic.Maintenance{StatusCode: 600},
+			expectedError: true,
+		},
+		{
+			desc:          "negative retryAfter",
+			config:        dynamic.Maintenance{RetryAfter: ptypes.Duration(-time.Second)},
+			expectedError: true,
+		},
+		{
+			desc:          "body and file",
+			config:        dynamic.Maintenance{Body: "foo", File: "maintenance.html"},
+			expectedError: true,
+		},
+		{
+			desc:          "missing file",
+			config:        dynamic.Maintenance{File: "does-not-exist.html"},
+			expectedError: true,
+		},
+		{
+			desc:          "invalid template",
+			config:        dynamic.Maintenance{Body: "{{ .Status "},
+			expectedError: true,
+		},
+		{
+			desc:          "invalid content type",
+			config:        dynamic.Maintenance{ContentType: "text/html; charset"},
+			expectedError: true,
+		},
+		{
+			desc:          "invalid source range",
+			config:        dynamic.Maintenance{SourceRange: []string{"foo"}},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+			handler, err := New(context.Background(), next, nil, test.config, "traefikTest")
+
+			if test.expectedError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, handler)
+			}
+		})
+	}
+}
+
+func TestMaintenance_ServeHTTP(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		config             dynamic.Maintenance
+		remoteAddr         string
+		expectedStatusCode int
+		expectedBody       string
+		expectedHeaders    map[string]string
+	}{
+		{
+			desc:               "disabled",
+			config:             dynamic.Maintenance{},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "backend",
+		},
+		{
+			desc:               "enabled with defaults",
+			config:             dynamic.Maintenance{Enabled: true},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "Service Unavailable",
+			expectedHeaders: map[string]string{
+				"Content-Type":  "text/html; charset=utf-8",
+				"Cache-Control": "no-store",
+			},
+		},
+		{
+			desc: "enabled with custom response",
+			config: dynamic.Maintenance{
+				Enabled:     true,
+				StatusCode:  http.StatusTeapot,
+				RetryAfter:  ptypes.Duration(2 * time.Minute),
+				ContentType: "application/json",
+				Body:        `{"status":{{ .StatusCode }},"retryAfter":{{ .RetryAfter }},"path":"{{ .Path }}"}`,
+			},
+			expectedStatusCode: http.StatusTeapot,
+			expectedBody:       `{"status":418,"retryAfter":120,"path":"/foo"}`,
+			expectedHeaders: map[string]string{
+				"Content-Type": "application/json",
+				"Retry-After":  "120",
+			},
+		},
+		{
+			desc: "HTML body is escaped",
+			config: dynamic.Maintenance{
+				Enabled: true,
+				Body:    "<p>{{ .Host }}</p>",
+			},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "<p>&lt;script&gt;</p>",
+		},
+		{
+			desc: "bypassed source IP",
+			config: dynamic.Maintenance{
+				Enabled:     true,
+				SourceRange: []string{"10.0.0.0/24"},
+			},
+			remoteAddr:         "10.0.0.1:1234",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "backend",
+		},
+		{
+			desc: "not bypassed source IP",
+			config: dynamic.Maintenance{
+				Enabled:     true,
+				SourceRange: []string{"10.0.0.0/24"},
+			},
+			remoteAddr:         "10.0.1.1:1234",
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "Service Unavailable",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				_, _ = rw.Write([]byte("backend"))
+			})
+
+			handler, err := New(context.Background(), next, nil, test.config, "traefikTest")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://<script>/foo", nil)
+			if test.remoteAddr != "" {
+				req.RemoteAddr = test.remoteAddr
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, test.expectedStatusCode, recorder.Code)
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+			for name, value := range test.expectedHeaders {
+				assert.Equal(t, value, recorder.Header().Get(name))
+			}
+		})
+	}
+}
+
+func TestMaintenance_flagFile(t *testing.T) {
+	flagFile := filepath.Join(t.TempDir(), "maintenance")
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+	handler, err := New(context.Background(), next, nil, dynamic.Maintenance{FlagFile: flagFile}, "traefikTest")
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	require.NoError(t, os.WriteFile(flagFile, nil, 0o600))
+
+	assert.Eventually(t, func() bool {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		return recorder.Code == http.StatusServiceUnavailable
+	}, 3*time.Second, 100*time.Millisecond)
+}
+
+func TestMaintenance_manager(t *testing.T) {
+	manager := NewManager()
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+	handler, err := New(context.Background(), next, manager, dynamic.Maintenance{}, "traefikTest")
+	require.NoError(t, err)
+
+	serve := func() int {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve())
+
+	manager.SetEnabled("traefikTest", true)
+	assert.Equal(t, http.StatusServiceUnavailable, serve())
+
+	// The state set at runtime is kept when the middleware is rebuilt.
+	handler, err = New(context.Background(), next, manager, dynamic.Maintenance{}, "traefikTest")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, serve())
+
+	manager.Reset("traefikTest")
+	assert.Equal(t, http.StatusOK, serve())
+}
@@ -0,0 +1,47 @@
+package maintenance
+
+import "sync"
+
+// Manager holds the maintenance modes set at runtime through the API,
+// so that they are kept across the configuration reloads.
+type Manager struct {
+	mu     sync.RWMutex
+	states map[string]bool
+}
+
+// NewManager creates a new Manager.
+func NewManager() *Manager {
+	return &Manager{states: make(map[string]bool)}
+}
+
+// SetEnabled enables or disables the maintenance mode of the given middleware,
+// overriding its configuration and its flag file.
+func (m *Manager) SetEnabled(middlewareName string, enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.states[middlewareName] = enabled
+}
+
+// Reset removes the maintenance mode set at runtime for the given middleware,
+// so that it follows its configuration and its flag file again.
+func (m *Manager) Reset(middlewareName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.states, middlewareName)
+}
+
+// State returns the maintenance mode set at runtime for the given middleware, if any.
+// A nil Manager has no maintenance mode set at runtime.
+func (m *Manager) State(middlewareName string) (enabled, ok bool) {
+	if m == nil {
+		return false, false
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	enabled, ok = m.states[middlewareName]
+	return enabled, ok
+}
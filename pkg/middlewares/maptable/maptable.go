@@ -0,0 +1,242 @@
+// Package maptable holds the lookup tables shared by the map middlewares (RedirectMap, MapPath and MapHost),
+// built from the entries of the configuration and of an entries file reloaded when it changes.
+package maptable
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Match modes of the entries.
+const (
+	MatchModeExact  = "exact"
+	MatchModePrefix = "prefix"
+	MatchModeRegex  = "regex"
+)
+
+// fileCheckInterval is the interval between two checks of the entries file for changes.
+const fileCheckInterval = time.Second
+
+// Format describes the entries of a file.
+type Format[E any] struct {
+	// Name is the name of the entries, e.g. redirects, used in the errors and the logs.
+	Name string
+	// MaxColumns is the maximum number of columns of a CSV record, the source and target columns being required.
+	MaxColumns int
+	// FromRecord converts a CSV record into an entry.
+	FromRecord func(record []string) (E, error)
+}
+
+// Table holds the lookup table built from the entries of the configuration and of the entries file,
+// and rebuilds it when the entries file changes.
+type Table[E, T any] struct {
+	logger  *zerolog.Logger
+	file    string
+	format  Format[E]
+	entries []E
+	build   func(entries []E) (*T, error)
+
+	table atomic.Pointer[T]
+	// checkedAt is the time, in Unix nanoseconds, of the last check of the entries file.
+	checkedAt atomic.Int64
+
+	// mu serializes the reloads, and guards the fields below.
+	mu          sync.Mutex
+	fileModTime time.Time
+	fileSize    int64
+}
+
+// New creates a Table from the given entries, preceded by the ones of the given file, if any.
+func New[E, T any](logger *zerolog.Logger, file string, entries []E, format Format[E], build func(entries []E) (*T, error)) (*Table[E, T], error) {
+	t := &Table[E, T]{
+		logger:  logger,
+		file:    file,
+		format:  format,
+		entries: entries,
+		build:   build,
+	}
+
+	if file != "" {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s file info: %w", format.Name, err)
+		}
+
+		fileEntries, err := ReadFile(file, format)
+		if err != nil {
+			return nil, err
+		}
+
+		t.fileModTime = info.ModTime()
+		t.fileSize = info.Size()
+		t.checkedAt.Store(time.Now().UnixNano())
+
+		entries = append(fileEntries, entries...)
+	}
+
+	table, err := build(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	t.table.Store(table)
+
+	return t, nil
+}
+
+// Current returns the lookup table, reloading the entries file in the background when it is time to check it.
+func (t *Table[E, T]) Current() *T {
+	if t.file != "" && time.Since(time.Unix(0, t.checkedAt.Load())) >= fileCheckInterval && t.mu.TryLock() {
+		t.checkedAt.Store(time.Now().UnixNano())
+
+		go func() {
+			defer t.mu.Unlock()
+
+			if err := t.reload(); err != nil {
+				t.logger.Error().Err(err).Str("file", t.file).Msgf("Error while reloading the %s, keeping the previous ones", t.format.Name)
+			}
+		}()
+	}
+
+	return t.table.Load()
+}
+
+// reload reloads the entries file when it has changed, it must be called with the lock held.
+func (t *Table[E, T]) reload() error {
+	info, err := os.Stat(t.file)
+	if err != nil {
+		return fmt.Errorf("reading %s file info: %w", t.format.Name, err)
+	}
+
+	if info.ModTime().Equal(t.fileModTime) && info.Size() == t.fileSize {
+		return nil
+	}
+
+	fileEntries, err := ReadFile(t.file, t.format)
+	if err != nil {
+		return err
+	}
+
+	table, err := t.build(append(fileEntries, t.entries...))
+	if err != nil {
+		return err
+	}
+
+	t.fileModTime = info.ModTime()
+	t.fileSize = info.Size()
+	t.table.Store(table)
+
+	t.logger.Debug().Str("file", t.file).Msgf("Reloaded the %s", t.format.Name)
+
+	return nil
+}
+
+// ReadFile reads the entries of a JSON file, or of a CSV file for the other extensions.
+func ReadFile[E any](path string, format Format[E]) ([]E, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s file: %w", format.Name, err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		var entries []E
+		if err := json.Unmarshal(content, &entries); err != nil {
+			return nil, fmt.Errorf("parsing %s file: %w", format.Name, err)
+		}
+
+		return entries, nil
+	}
+
+	return ParseCSV(content, format)
+}
+
+// ParseCSV parses the entries of a CSV file, starting with the source and target columns.
+// The lines starting with # are ignored, as well as a header line starting with the source column.
+func ParseCSV[E any](content []byte, format Format[E]) ([]E, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var entries []E
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s file: %w", format.Name, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if len(record) < 2 || len(record) > format.MaxColumns {
+			return nil, fmt.Errorf("parsing %s file: line %d: expected 2 to %d columns, got %d", format.Name, line, format.MaxColumns, len(record))
+		}
+
+		if len(entries) == 0 && strings.EqualFold(record[0], "source") {
+			continue
+		}
+
+		entry, err := format.FromRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s file: line %d: %w", format.Name, line, err)
+		}
+
+		entries = append(entries, entry)
+	}
+}
+
+// CheckMatchMode checks that the given match mode is supported.
+func CheckMatchMode(matchMode string) error {
+	switch matchMode {
+	case MatchModeExact, MatchModePrefix, MatchModeRegex:
+		return nil
+	default:
+		return fmt.Errorf("unsupported match mode %q", matchMode)
+	}
+}
+
+// IsPathRegex returns whether the regex source is matched against the request path,
+// rather than the request host followed by the path.
+func IsPathRegex(source string) bool {
+	return strings.HasPrefix(source, "/") || strings.HasPrefix(source, "^/")
+}
+
+// NormalizeSource lowercases the host of the sources matched against the request host followed by the path.
+func NormalizeSource(source string) string {
+	if strings.HasPrefix(source, "/") {
+		return source
+	}
+
+	host, path, found := strings.Cut(source, "/")
+	if !found {
+		return strings.ToLower(source)
+	}
+
+	return strings.ToLower(host) + "/" + path
+}
+
+// RequestHost returns the lower-cased host of the request, without port.
+func RequestHost(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.Host)
+	if err != nil {
+		host = req.Host
+	}
+
+	return strings.ToLower(host)
+}
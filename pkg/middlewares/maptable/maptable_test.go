@@ -0,0 +1,84 @@
+package maptable
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEntry struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+var testFormat = Format[testEntry]{
+	Name:       "entries",
+	MaxColumns: 2,
+	FromRecord: func(record []string) (testEntry, error) {
+		return testEntry{Source: record[0], Target: record[1]}, nil
+	},
+}
+
+func buildTestTable(entries []testEntry) (*map[string]string, error) {
+	table := make(map[string]string)
+	for _, entry := range entries {
+		if _, ok := table[entry.Source]; !ok {
+			table[entry.Source] = entry.Target
+		}
+	}
+
+	return &table, nil
+}
+
+func TestParseCSV(t *testing.T) {
+	entries, err := ParseCSV([]byte("source,target\n# Comment\n/old, /new\n"), testFormat)
+	require.NoError(t, err)
+
+	assert.Equal(t, []testEntry{{Source: "/old", Target: "/new"}}, entries)
+
+	_, err = ParseCSV([]byte("/old\n"), testFormat)
+	assert.Error(t, err)
+}
+
+func TestTable_reload(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "entries.json")
+	require.NoError(t, os.WriteFile(file, []byte(`[{"source":"/old","target":"/new"}]`), 0o600))
+
+	logger := log.Logger
+	table, err := New(&logger, file, []testEntry{{Source: "/old", Target: "/ignored"}, {Source: "/foo", Target: "/bar"}}, testFormat, buildTestTable)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"/old": "/new", "/foo": "/bar"}, *table.Current())
+
+	require.NoError(t, os.WriteFile(file, []byte(`[{"source":"/old","target":"/newer"}]`), 0o600))
+	// Makes sure the modification time changes, whatever the resolution of the file system.
+	require.NoError(t, os.Chtimes(file, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+
+	assert.Eventually(t, func() bool {
+		return (*table.Current())["/old"] == "/newer"
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestNormalizeSource(t *testing.T) {
+	assert.Equal(t, "/Docs", NormalizeSource("/Docs"))
+	assert.Equal(t, "foo.com", NormalizeSource("Foo.COM"))
+	assert.Equal(t, "foo.com/Docs", NormalizeSource("Foo.COM/Docs"))
+}
+
+func TestIsPathRegex(t *testing.T) {
+	assert.True(t, IsPathRegex("/docs/(.*)"))
+	assert.True(t, IsPathRegex("^/docs/(.*)"))
+	assert.False(t, IsPathRegex("^foo\\.com/(.*)"))
+}
+
+func TestRequestHost(t *testing.T) {
+	assert.Equal(t, "foo.com", RequestHost(httptest.NewRequest(http.MethodGet, "http://Foo.com:8080/", nil)))
+	assert.Equal(t, "foo.com", RequestHost(httptest.NewRequest(http.MethodGet, "http://FOO.com/", nil)))
+}
@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/containous/alice"
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/traefik/traefik/v3/pkg/metrics"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/capture"
+)
+
+const namePlugin = "metrics-plugin"
+
+type pluginCallKey struct{}
+
+// pluginCall holds the time spent in the rest of the chain during a plugin call.
+type pluginCall struct {
+	nextCalled   bool
+	nextDuration time.Duration
+}
+
+type pluginMetricsMiddleware struct {
+	plugin               http.Handler
+	reqsCounter          gokitmetrics.Counter
+	errorsCounter        gokitmetrics.Counter
+	panicsCounter        gokitmetrics.Counter
+	reqDurationHistogram metrics.ScalableHistogram
+	labels               []string
+}
+
+// NewPluginMiddleware creates a new metrics middleware for a plugin.
+// The plugin is built around the next handler by the given constructor,
+// so that the time spent in the rest of the chain is not accounted to the plugin.
+func NewPluginMiddleware(ctx context.Context, next http.Handler, registry metrics.Registry, pluginName, middlewareName string, plugin alice.Constructor) (http.Handler, error) {
+	middlewares.GetLogger(ctx, namePlugin, typeName).Debug().Msg("Creating middleware")
+
+	h, err := plugin(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		call, ok := req.Context().Value(pluginCallKey{}).(*pluginCall)
+		if !ok {
+			next.ServeHTTP(rw, req)
+			return
+		}
+
+		call.nextCalled = true
+
+		start := time.Now()
+		defer func() { call.nextDuration += time.Since(start) }()
+
+		next.ServeHTTP(rw, req)
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pluginMetricsMiddleware{
+		plugin:               h,
+		reqsCounter:          registry.PluginReqsCounter(),
+		errorsCounter:        registry.PluginErrorsCounter(),
+		panicsCounter:        registry.PluginPanicsCounter(),
+		reqDurationHistogram: registry.PluginReqDurationHistogram(),
+		labels:               []string{"plugin", pluginName, "middleware", middlewareName},
+	}, nil
+}
+
+func (m *pluginMetricsMiddleware) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	m.reqsCounter.With(m.labels...).Add(1)
+
+	call := &pluginCall{}
+
+	plugin := m.plugin
+
+	capt, captErr := capture.FromContext(req.Context())
+	if captErr == nil && capt.NeedsReset(rw) {
+		plugin = capt.Reset(m.plugin)
+	}
+
+	defer func() {
+		if err := recover(); err != nil {
+			m.panicsCounter.With(m.labels...).Add(1)
+			panic(err)
+		}
+	}()
+
+	start := time.Now()
+	plugin.ServeHTTP(rw, req.WithContext(context.WithValue(req.Context(), pluginCallKey{}, call)))
+
+	m.reqDurationHistogram.With(m.labels...).Observe((time.Since(start) - call.nextDuration).Seconds())
+
+	// The plugin answered the request itself.
+	if !call.nextCalled && captErr == nil && capt.StatusCode() >= http.StatusInternalServerError {
+		m.errorsCounter.With(m.labels...).Add(1)
+	}
+}
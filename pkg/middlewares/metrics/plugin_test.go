@@ -0,0 +1,155 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/metrics"
+	"github.com/traefik/traefik/v3/pkg/middlewares/capture"
+)
+
+func TestPluginMiddleware(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		plugin         func(next http.Handler) http.Handler
+		expectedErrors float64
+		expectedPanics float64
+	}{
+		{
+			desc: "plugin calling next",
+			plugin: func(next http.Handler) http.Handler {
+				return next
+			},
+		},
+		{
+			desc: "plugin answering with an error",
+			plugin: func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					rw.WriteHeader(http.StatusInternalServerError)
+				})
+			},
+			expectedErrors: 1,
+		},
+		{
+			desc: "plugin answering without error",
+			plugin: func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					rw.WriteHeader(http.StatusForbidden)
+				})
+			},
+		},
+		{
+			desc: "plugin panicking",
+			plugin: func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					panic("boom")
+				})
+			},
+			expectedPanics: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			registry := newCollectingPluginRegistry()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				// Not accounted to the plugin.
+				time.Sleep(50 * time.Millisecond)
+				rw.WriteHeader(http.StatusBadGateway)
+			})
+
+			h, err := NewPluginMiddleware(context.Background(), next, registry, "github.com/traefik/plugindemo", "demo@file", func(next http.Handler) (http.Handler, error) {
+				return test.plugin(next), nil
+			})
+			require.NoError(t, err)
+
+			handler, err := capture.Wrap(h)
+			require.NoError(t, err)
+
+			serve := func() {
+				handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+			}
+
+			if test.expectedPanics > 0 {
+				assert.Panics(t, serve)
+			} else {
+				serve()
+			}
+
+			labels := []string{"plugin", "github.com/traefik/plugindemo", "middleware", "demo@file"}
+
+			assert.InDelta(t, 1, registry.reqsCounter.CounterValue, 0)
+			assert.Equal(t, labels, registry.reqsCounter.LastLabelValues)
+			assert.InDelta(t, test.expectedErrors, registry.errorsCounter.CounterValue, 0)
+			assert.InDelta(t, test.expectedPanics, registry.panicsCounter.CounterValue, 0)
+
+			if test.expectedPanics == 0 {
+				require.Len(t, registry.durationHistogram.values, 1)
+				assert.Less(t, registry.durationHistogram.values[0], 0.05)
+				assert.Equal(t, labels, registry.durationHistogram.lastLabelValues)
+			}
+		})
+	}
+}
+
+type collectingPluginRegistry struct {
+	metrics.Registry
+
+	reqsCounter       *CollectingCounter
+	errorsCounter     *CollectingCounter
+	panicsCounter     *CollectingCounter
+	durationHistogram *collectingHistogram
+}
+
+func newCollectingPluginRegistry() *collectingPluginRegistry {
+	return &collectingPluginRegistry{
+		Registry:          metrics.NewVoidRegistry(),
+		reqsCounter:       &CollectingCounter{},
+		errorsCounter:     &CollectingCounter{},
+		panicsCounter:     &CollectingCounter{},
+		durationHistogram: &collectingHistogram{},
+	}
+}
+
+func (r *collectingPluginRegistry) PluginReqsCounter() gokitmetrics.Counter {
+	return r.reqsCounter
+}
+
+func (r *collectingPluginRegistry) PluginErrorsCounter() gokitmetrics.Counter {
+	return r.errorsCounter
+}
+
+func (r *collectingPluginRegistry) PluginPanicsCounter() gokitmetrics.Counter {
+	return r.panicsCounter
+}
+
+func (r *collectingPluginRegistry) PluginReqDurationHistogram() metrics.ScalableHistogram {
+	return r.durationHistogram
+}
+
+type collectingHistogram struct {
+	values          []float64
+	lastLabelValues []string
+}
+
+func (h *collectingHistogram) With(labelValues ...string) metrics.ScalableHistogram {
+	h.lastLabelValues = labelValues
+	return h
+}
+
+func (h *collectingHistogram) Observe(v float64) {
+	h.values = append(h.values, v)
+}
+
+func (h *collectingHistogram) ObserveFromStart(start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
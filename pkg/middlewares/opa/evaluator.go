@@ -0,0 +1,219 @@
+package opa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/bundle"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/rs/zerolog"
+)
+
+// localPolicy is a Rego policy given in the configuration.
+type localPolicy struct {
+	query rego.PreparedEvalQuery
+}
+
+func newLocalPolicy(ctx context.Context, query, policy string) (*localPolicy, error) {
+	prepared, err := rego.New(
+		rego.Query(query),
+		rego.Module("policy.rego", policy),
+		rego.SetRegoVersion(ast.RegoV1),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("compiling policy: %w", err)
+	}
+
+	return &localPolicy{query: prepared}, nil
+}
+
+func (p *localPolicy) eval(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	return evalQuery(ctx, p.query, input)
+}
+
+// remoteBundle is a policy bundle served at a URL,
+// downloaded again in the background once the poll interval has elapsed.
+type remoteBundle struct {
+	logger   *zerolog.Logger
+	url      string
+	client   *http.Client
+	interval time.Duration
+	query    string
+
+	prepared atomic.Pointer[rego.PreparedEvalQuery]
+	// attemptedAt is the time, in Unix nanoseconds, of the last download attempt.
+	attemptedAt atomic.Int64
+
+	// mu serializes the downloads.
+	mu   sync.Mutex
+	etag string
+}
+
+func newRemoteBundle(logger *zerolog.Logger, url string, client *http.Client, interval time.Duration, query string) *remoteBundle {
+	return &remoteBundle{
+		logger:   logger,
+		url:      url,
+		client:   client,
+		interval: interval,
+		query:    query,
+	}
+}
+
+func (b *remoteBundle) eval(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	prepared, err := b.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return evalQuery(ctx, *prepared, input)
+}
+
+// current returns the query prepared from the last downloaded bundle.
+// The first bundle is downloaded synchronously, the next ones in the background.
+func (b *remoteBundle) current(ctx context.Context) (*rego.PreparedEvalQuery, error) {
+	prepared := b.prepared.Load()
+	if prepared != nil {
+		if b.isStale() && b.mu.TryLock() {
+			go func() {
+				defer b.mu.Unlock()
+
+				if err := b.download(context.Background()); err != nil {
+					b.logger.Error().Err(err).Msg("Error while downloading the policy bundle")
+				}
+			}()
+		}
+
+		return prepared, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if prepared = b.prepared.Load(); prepared != nil {
+		return prepared, nil
+	}
+
+	// The failed downloads are not attempted again before the poll interval,
+	// so that the requests cannot flood the bundle server.
+	if b.attemptedAt.Load() != 0 && !b.isStale() {
+		return nil, fmt.Errorf("policy bundle not downloaded from %s", b.url)
+	}
+
+	if err := b.download(ctx); err != nil {
+		return nil, err
+	}
+
+	return b.prepared.Load(), nil
+}
+
+func (b *remoteBundle) isStale() bool {
+	return time.Since(time.Unix(0, b.attemptedAt.Load())) >= b.interval
+}
+
+// download downloads the bundle and prepares its query, it must be called with the lock held.
+func (b *remoteBundle) download(ctx context.Context) error {
+	b.attemptedAt.Store(time.Now().UnixNano())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.url, nil)
+	if err != nil {
+		return fmt.Errorf("creating bundle request: %w", err)
+	}
+
+	if b.etag != "" && b.prepared.Load() != nil {
+		req.Header.Set("If-None-Match", b.etag)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("downloading bundle: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil
+	default:
+		return fmt.Errorf("downloading bundle: unexpected status code %d", resp.StatusCode)
+	}
+
+	parsed, err := bundle.NewReader(resp.Body).WithRegoVersion(ast.RegoV1).Read()
+	if err != nil {
+		return fmt.Errorf("reading bundle: %w", err)
+	}
+
+	prepared, err := rego.New(
+		rego.Query(b.query),
+		rego.ParsedBundle("bundle", &parsed),
+		rego.SetRegoVersion(ast.RegoV1),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return fmt.Errorf("compiling bundle: %w", err)
+	}
+
+	b.prepared.Store(&prepared)
+	b.etag = resp.Header.Get("ETag")
+
+	b.logger.Debug().Msgf("Policy bundle downloaded from %s", b.url)
+
+	return nil
+}
+
+// remoteServer is an OPA server evaluating the decision with its Data API.
+type remoteServer struct {
+	url    string
+	client *http.Client
+}
+
+func (s *remoteServer) eval(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	body, err := json.Marshal(map[string]interface{}{"input": input})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating decision request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting decision: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("requesting decision: unexpected status code %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Result interface{} `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding decision: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+func evalQuery(ctx context.Context, query rego.PreparedEvalQuery, input map[string]interface{}) (interface{}, error) {
+	results, err := query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 || len(results[0].Expressions) == 0 {
+		return nil, nil
+	}
+
+	return results[0].Expressions[0].Value, nil
+}
@@ -0,0 +1,273 @@
+package opa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"github.com/traefik/traefik/v3/pkg/types"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "OPA"
+
+// evaluator evaluates the decision document for a request input.
+// The returned document is nil when the decision is undefined.
+type evaluator interface {
+	eval(ctx context.Context, input map[string]interface{}) (interface{}, error)
+}
+
+// decision is the decision document returned by the policy.
+// A policy can also return a boolean, as the allow field.
+type decision struct {
+	Allow           bool              `json:"allow"`
+	Headers         map[string]string `json:"headers"`
+	ResponseHeaders map[string]string `json:"responseHeaders"`
+	Status          int               `json:"status"`
+}
+
+// opa is a middleware authorizing the requests with an Open Policy Agent decision.
+type opa struct {
+	next             http.Handler
+	name             string
+	evaluator        evaluator
+	rejectStatusCode int
+}
+
+// New creates an OPA middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.OPA, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeName)
+	logger.Debug().Msg("Creating middleware")
+
+	o := &opa{
+		next:             next,
+		name:             name,
+		rejectStatusCode: config.RejectStatusCode,
+	}
+
+	if o.rejectStatusCode == 0 {
+		o.rejectStatusCode = http.StatusForbidden
+	}
+
+	if o.rejectStatusCode < http.StatusBadRequest || o.rejectStatusCode > 599 {
+		return nil, fmt.Errorf("invalid rejectStatusCode %d: must be an error status code", o.rejectStatusCode)
+	}
+
+	var sources int
+	for _, source := range []string{config.Policy, config.BundleURL, config.URL} {
+		if source != "" {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		return nil, errors.New("exactly one of policy, bundleURL or url must be set")
+	}
+
+	decisionPath := strings.Trim(config.Decision, "/")
+	if decisionPath == "" {
+		return nil, errors.New("decision must be defined")
+	}
+
+	query := "data." + strings.ReplaceAll(decisionPath, "/", ".")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	if config.TLS != nil {
+		clientTLS := &types.ClientTLS{
+			CA:                 config.TLS.CA,
+			Cert:               config.TLS.Cert,
+			Key:                config.TLS.Key,
+			InsecureSkipVerify: config.TLS.InsecureSkipVerify,
+		}
+
+		tlsConfig, err := clientTLS.CreateTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create client TLS configuration: %w", err)
+		}
+
+		tr := http.DefaultTransport.(*http.Transport).Clone()
+		tr.TLSClientConfig = tlsConfig
+		client.Transport = tr
+	}
+
+	switch {
+	case config.Policy != "":
+		policy, err := types.FileOrContent(config.Policy).Read()
+		if err != nil {
+			return nil, fmt.Errorf("reading policy: %w", err)
+		}
+
+		o.evaluator, err = newLocalPolicy(ctx, query, string(policy))
+		if err != nil {
+			return nil, err
+		}
+
+	case config.BundleURL != "":
+		pollInterval := time.Duration(config.PollInterval)
+		if pollInterval <= 0 {
+			return nil, errors.New("pollInterval must be greater than zero")
+		}
+
+		o.evaluator = newRemoteBundle(logger, config.BundleURL, client, pollInterval, query)
+
+	default:
+		o.evaluator = &remoteServer{
+			url:    strings.TrimSuffix(config.URL, "/") + "/v1/data/" + decisionPath,
+			client: client,
+		}
+	}
+
+	return o, nil
+}
+
+func (o *opa) GetTracingInformation() (string, string, trace.SpanKind) {
+	return o.name, typeName, trace.SpanKindInternal
+}
+
+func (o *opa) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), o.name, typeName)
+
+	document, err := o.evaluator.eval(req.Context(), buildInput(req))
+	if err != nil {
+		logger.Error().Err(err).Msg("Error while evaluating the decision")
+		observability.SetStatusErrorf(req.Context(), "Error while evaluating the decision: %s", err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	d, err := parseDecision(document)
+	if err != nil {
+		logger.Error().Err(err).Msg("Invalid decision")
+		observability.SetStatusErrorf(req.Context(), "Invalid decision: %s", err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !d.Allow {
+		for name, value := range d.ResponseHeaders {
+			rw.Header().Set(name, value)
+		}
+
+		status := o.rejectStatusCode
+		if d.Status >= http.StatusBadRequest && d.Status <= 599 {
+			status = d.Status
+		}
+
+		logger.Debug().Msg("Request denied by the policy")
+		observability.SetStatusErrorf(req.Context(), "Request denied by the policy")
+
+		rw.WriteHeader(status)
+		if _, err := rw.Write([]byte(http.StatusText(status))); err != nil {
+			log.Ctx(req.Context()).Error().Err(err).Send()
+		}
+		return
+	}
+
+	for name, value := range d.Headers {
+		if value == "" {
+			req.Header.Del(name)
+			continue
+		}
+
+		req.Header.Set(name, value)
+	}
+
+	if len(d.ResponseHeaders) == 0 {
+		o.next.ServeHTTP(rw, req)
+		return
+	}
+
+	o.next.ServeHTTP(middlewares.NewResponseModifier(rw, req, func(res *http.Response) error {
+		for name, value := range d.ResponseHeaders {
+			if value == "" {
+				res.Header.Del(name)
+				continue
+			}
+
+			res.Header.Set(name, value)
+		}
+
+		return nil
+	}), req)
+}
+
+// buildInput returns the input document describing the request.
+func buildInput(req *http.Request) map[string]interface{} {
+	clientIP, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		clientIP = req.RemoteAddr
+	}
+
+	headers := make(map[string]interface{}, len(req.Header))
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.Join(values, ", ")
+	}
+
+	query := make(map[string]interface{})
+	for name, values := range req.URL.Query() {
+		items := make([]interface{}, len(values))
+		for i, value := range values {
+			items[i] = value
+		}
+
+		query[name] = items
+	}
+
+	return map[string]interface{}{
+		"method":     req.Method,
+		"scheme":     scheme(req),
+		"host":       req.Host,
+		"path":       req.URL.Path,
+		"query":      query,
+		"headers":    headers,
+		"clientIP":   clientIP,
+		"routerName": middlewares.GetRouterName(req.Context()),
+	}
+}
+
+func scheme(req *http.Request) string {
+	if req.TLS != nil {
+		return "https"
+	}
+
+	return "http"
+}
+
+// parseDecision parses the decision document, an undefined decision denying the request.
+func parseDecision(document interface{}) (decision, error) {
+	switch value := document.(type) {
+	case nil:
+		return decision{}, nil
+
+	case bool:
+		return decision{Allow: value}, nil
+
+	case map[string]interface{}:
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return decision{}, err
+		}
+
+		var d decision
+		if err := json.Unmarshal(raw, &d); err != nil {
+			return decision{}, err
+		}
+
+		return d, nil
+
+	default:
+		return decision{}, fmt.Errorf("unexpected decision type %T", document)
+	}
+}
@@ -0,0 +1,304 @@
+package opa
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+const testPolicy = `package traefik
+
+default authz := {"allow": false}
+
+authz := {"allow": true, "headers": {"X-User": "alice", "X-Remove": ""}} if {
+	input.headers["authorization"] == "Bearer alice"
+}
+
+authz := {"allow": false, "status": 401, "responseHeaders": {"WWW-Authenticate": "Bearer"}} if {
+	not input.headers["authorization"]
+	input.path != "/public"
+}
+
+authz := {"allow": true, "responseHeaders": {"Cache-Control": "public"}} if {
+	input.path == "/public"
+	input.method == "GET"
+}
+`
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.OPA
+	}{
+		{
+			desc:   "no source",
+			config: dynamic.OPA{Decision: "traefik/authz"},
+		},
+		{
+			desc:   "several sources",
+			config: dynamic.OPA{Policy: testPolicy, URL: "http://opa:8181", Decision: "traefik/authz"},
+		},
+		{
+			desc:   "invalid policy",
+			config: dynamic.OPA{Policy: "package traefik\n\nallow if {", Decision: "traefik/allow"},
+		},
+		{
+			desc:   "no decision",
+			config: dynamic.OPA{Policy: testPolicy},
+		},
+		{
+			desc:   "invalid reject status code",
+			config: dynamic.OPA{Policy: testPolicy, Decision: "traefik/authz", RejectStatusCode: 200},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "opa")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestOPA_policy(t *testing.T) {
+	testCases := []struct {
+		desc                    string
+		method                  string
+		path                    string
+		authorization           string
+		expectedStatus          int
+		expectedForwardHeaders  map[string]string
+		expectedResponseHeaders map[string]string
+	}{
+		{
+			desc:           "allowed with headers",
+			method:         http.MethodPost,
+			path:           "/api",
+			authorization:  "Bearer alice",
+			expectedStatus: http.StatusOK,
+			expectedForwardHeaders: map[string]string{
+				"X-User":   "alice",
+				"X-Remove": "",
+			},
+		},
+		{
+			desc:           "denied with status",
+			method:         http.MethodGet,
+			path:           "/api",
+			expectedStatus: http.StatusUnauthorized,
+			expectedResponseHeaders: map[string]string{
+				"WWW-Authenticate": "Bearer",
+			},
+		},
+		{
+			desc:           "denied by default",
+			method:         http.MethodGet,
+			path:           "/api",
+			authorization:  "Bearer mallory",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "allowed with response headers",
+			method:         http.MethodGet,
+			path:           "/public",
+			expectedStatus: http.StatusOK,
+			expectedResponseHeaders: map[string]string{
+				"Cache-Control": "public",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwarded *http.Request
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwarded = req
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			handler, err := New(context.Background(), next, dynamic.OPA{Policy: testPolicy, Decision: "traefik/authz"}, "opa")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(test.method, "http://localhost"+test.path, nil)
+			req.Header.Set("X-Remove", "foo")
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+
+			for name, value := range test.expectedForwardHeaders {
+				require.NotNil(t, forwarded)
+				assert.Equal(t, value, forwarded.Header.Get(name), name)
+			}
+
+			for name, value := range test.expectedResponseHeaders {
+				assert.Equal(t, value, rw.Header().Get(name), name)
+			}
+		})
+	}
+}
+
+func TestOPA_booleanDecision(t *testing.T) {
+	policy := `package traefik
+
+allow if input.method == "GET"
+`
+
+	handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), dynamic.OPA{Policy: policy, Decision: "traefik/allow"}, "opa")
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	// An undefined decision denies the request.
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodPost, "http://localhost", nil))
+	assert.Equal(t, http.StatusForbidden, rw.Code)
+}
+
+func TestOPA_bundle(t *testing.T) {
+	archive := buildBundle(t, testPolicy)
+
+	var downloads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		downloads.Add(1)
+
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		rw.Header().Set("ETag", `"v1"`)
+		_, _ = rw.Write(archive)
+	}))
+	t.Cleanup(server.Close)
+
+	handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), dynamic.OPA{
+		BundleURL:    server.URL,
+		PollInterval: ptypes.Duration(10 * time.Millisecond),
+		Decision:     "traefik/authz",
+	}, "opa")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/api", nil)
+	req.Header.Set("Authorization", "Bearer alice")
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, int32(1), downloads.Load())
+
+	// Once the poll interval elapsed, the bundle is downloaded again in the background.
+	time.Sleep(20 * time.Millisecond)
+
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	assert.Eventually(t, func() bool { return downloads.Load() == 2 }, time.Second, 5*time.Millisecond)
+}
+
+func TestOPA_bundleUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+
+	handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), dynamic.OPA{
+		BundleURL:    server.URL,
+		PollInterval: ptypes.Duration(time.Minute),
+		Decision:     "traefik/authz",
+	}, "opa")
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+	assert.Equal(t, http.StatusInternalServerError, rw.Code)
+}
+
+func TestOPA_remoteServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/v1/data/traefik/authz" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var body struct {
+			Input struct {
+				Method  string            `json:"method"`
+				Path    string            `json:"path"`
+				Headers map[string]string `json:"headers"`
+			} `json:"input"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		allowed := body.Input.Method == http.MethodGet && body.Input.Path == "/api" && body.Input.Headers["x-team"] == "core"
+
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{
+			"result": map[string]interface{}{"allow": allowed, "headers": map[string]string{"X-Team-Checked": "true"}},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	var forwarded *http.Request
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwarded = req
+	})
+
+	handler, err := New(context.Background(), next, dynamic.OPA{URL: server.URL + "/", Decision: "/traefik/authz"}, "opa")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/api", nil)
+	req.Header.Set("X-Team", "core")
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	require.NotNil(t, forwarded)
+	assert.Equal(t, "true", forwarded.Header.Get("X-Team-Checked"))
+
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://localhost/api", nil))
+
+	assert.Equal(t, http.StatusForbidden, rw.Code)
+}
+
+func buildBundle(t *testing.T, policy string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "/policy.rego", Mode: 0o600, Size: int64(len(policy))}))
+	_, err := tw.Write([]byte(policy))
+	require.NoError(t, err)
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	return buf.Bytes()
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,16 +13,20 @@ import (
 	"strings"
 
 	"github.com/rs/zerolog/log"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const typeName = "PassClientTLSCert"
 
 const (
-	xForwardedTLSClientCert     = "X-Forwarded-Tls-Client-Cert"
-	xForwardedTLSClientCertInfo = "X-Forwarded-Tls-Client-Cert-Info"
+	xForwardedTLSClientCert         = "X-Forwarded-Tls-Client-Cert"
+	xForwardedTLSClientCertInfo     = "X-Forwarded-Tls-Client-Cert-Info"
+	xForwardedTLSClientCertSPIFFEID = "X-Forwarded-Tls-Client-Cert-Spiffe-Id"
 )
 
 const (
@@ -119,23 +124,73 @@ func newTLSClientCertificateInfo(info *dynamic.TLSClientCertificateInfo) *tlsCli
 	}
 }
 
+// tlsClientCertificateSPIFFE is a struct for specifying the SPIFFE ID configuration for the passTLSClientCert middleware.
+type tlsClientCertificateSPIFFE struct {
+	trustDomains []spiffeid.TrustDomain
+}
+
+func newTLSClientCertificateSPIFFE(config *dynamic.TLSClientCertificateSPIFFE) (*tlsClientCertificateSPIFFE, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	spiffe := &tlsClientCertificateSPIFFE{}
+	for _, rawTrustDomain := range config.TrustDomains {
+		trustDomain, err := spiffeid.TrustDomainFromString(rawTrustDomain)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trust domain %q: %w", rawTrustDomain, err)
+		}
+
+		spiffe.trustDomains = append(spiffe.trustDomains, trustDomain)
+	}
+
+	return spiffe, nil
+}
+
+// getID returns the SPIFFE ID of the given certificate, checking that it belongs to one of the trust domains.
+func (s *tlsClientCertificateSPIFFE) getID(cert *x509.Certificate) (spiffeid.ID, error) {
+	id, err := x509svid.IDFromCert(cert)
+	if err != nil {
+		return spiffeid.ID{}, err
+	}
+
+	if len(s.trustDomains) == 0 {
+		return id, nil
+	}
+
+	for _, trustDomain := range s.trustDomains {
+		if id.MemberOf(trustDomain) {
+			return id, nil
+		}
+	}
+
+	return spiffeid.ID{}, fmt.Errorf("SPIFFE ID %q does not belong to the trust domains", id)
+}
+
 // passTLSClientCert is a middleware that helps setup a few tls info features.
 type passTLSClientCert struct {
-	next http.Handler
-	name string
-	pem  bool                      // pass the sanitized pem to the backend in a specific header
-	info *tlsClientCertificateInfo // pass selected information from the client certificate
+	next   http.Handler
+	name   string
+	pem    bool                        // pass the sanitized pem to the backend in a specific header
+	info   *tlsClientCertificateInfo   // pass selected information from the client certificate
+	spiffe *tlsClientCertificateSPIFFE // pass the SPIFFE ID of the client certificate
 }
 
 // New constructs a new PassTLSClientCert instance from supplied frontend header struct.
 func New(ctx context.Context, next http.Handler, config dynamic.PassTLSClientCert, name string) (http.Handler, error) {
 	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
 
+	spiffe, err := newTLSClientCertificateSPIFFE(config.SPIFFE)
+	if err != nil {
+		return nil, err
+	}
+
 	return &passTLSClientCert{
-		next: next,
-		name: name,
-		pem:  config.PEM,
-		info: newTLSClientCertificateInfo(config.Info),
+		next:   next,
+		name:   name,
+		pem:    config.PEM,
+		info:   newTLSClientCertificateInfo(config.Info),
+		spiffe: spiffe,
 	}, nil
 }
 
@@ -164,9 +219,43 @@ func (p *passTLSClientCert) ServeHTTP(rw http.ResponseWriter, req *http.Request)
 		}
 	}
 
+	if p.spiffe != nil {
+		// The header must not be forged by the client.
+		req.Header.Del(xForwardedTLSClientCertSPIFFEID)
+
+		if req.TLS != nil && len(req.TLS.PeerCertificates) > 0 {
+			id, err := p.spiffe.getID(req.TLS.PeerCertificates[0])
+			if err != nil && len(p.spiffe.trustDomains) > 0 {
+				p.reject(rw, req, err)
+				return
+			}
+
+			if err != nil {
+				logger.Debug().Err(err).Msg("Unable to extract the SPIFFE ID of the certificate")
+			} else {
+				req.Header.Set(xForwardedTLSClientCertSPIFFEID, id.String())
+			}
+		} else if len(p.spiffe.trustDomains) > 0 {
+			p.reject(rw, req, errors.New("no client certificate"))
+			return
+		}
+	}
+
 	p.next.ServeHTTP(rw, req)
 }
 
+func (p *passTLSClientCert) reject(rw http.ResponseWriter, req *http.Request, reason error) {
+	logger := middlewares.GetLogger(req.Context(), p.name, typeName)
+	logger.Debug().Err(reason).Msg("Rejecting request")
+
+	observability.SetStatusErrorf(req.Context(), "Rejecting request: %v", reason)
+
+	rw.WriteHeader(http.StatusForbidden)
+	if _, err := rw.Write([]byte(http.StatusText(http.StatusForbidden))); err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Send()
+	}
+}
+
 // getCertInfo Build a string with the wanted client certificates information
 // - the `,` is used to separate certificates
 // - the `;` is used to separate root fields
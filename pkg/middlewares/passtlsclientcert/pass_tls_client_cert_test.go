@@ -563,6 +563,101 @@ func TestPassTLSClientCert_certInfo(t *testing.T) {
 	}
 }
 
+func TestPassTLSClientCert_SPIFFE(t *testing.T) {
+	spiffeCert := &x509.Certificate{URIs: []*url.URL{testhelpers.MustParseURL("spiffe://example.org/ns/prod/sa/web")}}
+	otherSPIFFECert := &x509.Certificate{URIs: []*url.URL{testhelpers.MustParseURL("spiffe://other.org/ns/prod/sa/web")}}
+
+	testCases := []struct {
+		desc           string
+		certs          []*x509.Certificate // set the request TLS attribute if defined
+		config         dynamic.TLSClientCertificateSPIFFE
+		expectedStatus int
+		expectedHeader string
+	}{
+		{
+			desc:           "No TLS, no trust domains",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "No TLS, with trust domains",
+			config:         dynamic.TLSClientCertificateSPIFFE{TrustDomains: []string{"example.org"}},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "Certificate without SPIFFE ID, no trust domains",
+			certs:          []*x509.Certificate{getCertificate(minimalCheeseCrt)},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "Certificate without SPIFFE ID, with trust domains",
+			certs:          []*x509.Certificate{getCertificate(minimalCheeseCrt)},
+			config:         dynamic.TLSClientCertificateSPIFFE{TrustDomains: []string{"example.org"}},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "Certificate with SPIFFE ID, no trust domains",
+			certs:          []*x509.Certificate{otherSPIFFECert},
+			expectedStatus: http.StatusOK,
+			expectedHeader: "spiffe://other.org/ns/prod/sa/web",
+		},
+		{
+			desc:           "Certificate with SPIFFE ID from a trust domain",
+			certs:          []*x509.Certificate{spiffeCert},
+			config:         dynamic.TLSClientCertificateSPIFFE{TrustDomains: []string{"other.org", "spiffe://example.org"}},
+			expectedStatus: http.StatusOK,
+			expectedHeader: "spiffe://example.org/ns/prod/sa/web",
+		},
+		{
+			desc:           "Certificate with SPIFFE ID from another trust domain",
+			certs:          []*x509.Certificate{otherSPIFFECert},
+			config:         dynamic.TLSClientCertificateSPIFFE{TrustDomains: []string{"example.org"}},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc: "Certificate with several URI SANs",
+			certs: []*x509.Certificate{{URIs: []*url.URL{
+				testhelpers.MustParseURL("spiffe://example.org/ns/prod/sa/web"),
+				testhelpers.MustParseURL("spiffe://example.org/ns/prod/sa/api"),
+			}}},
+			config:         dynamic.TLSClientCertificateSPIFFE{TrustDomains: []string{"example.org"}},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler, err := New(context.Background(), next, dynamic.PassTLSClientCert{SPIFFE: &test.config}, "foo")
+			require.NoError(t, err)
+
+			res := httptest.NewRecorder()
+			req := testhelpers.MustNewRequest(http.MethodGet, "http://example.com/foo", nil)
+			req.Header.Set(xForwardedTLSClientCertSPIFFEID, "spiffe://example.org/forged")
+
+			if len(test.certs) > 0 {
+				req.TLS = &tls.ConnectionState{PeerCertificates: test.certs}
+			}
+
+			handler.ServeHTTP(res, req)
+
+			assert.Equal(t, test.expectedStatus, res.Code)
+			if test.expectedStatus == http.StatusOK {
+				assert.Equal(t, test.expectedHeader, req.Header.Get(xForwardedTLSClientCertSPIFFEID))
+			}
+		})
+	}
+}
+
+func TestNew_invalidSPIFFETrustDomain(t *testing.T) {
+	config := dynamic.PassTLSClientCert{
+		SPIFFE: &dynamic.TLSClientCertificateSPIFFE{TrustDomains: []string{"Example.org/path"}},
+	}
+
+	_, err := New(context.Background(), next, config, "foo")
+	assert.Error(t, err)
+}
+
 func Test_sanitize(t *testing.T) {
 	testCases := []struct {
 		desc       string
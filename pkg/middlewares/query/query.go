@@ -0,0 +1,139 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "Query"
+
+type rewrite struct {
+	parameter   string
+	regex       *regexp.Regexp
+	replacement string
+}
+
+// query is a middleware modifying the query parameters of the request URL.
+type query struct {
+	next              http.Handler
+	name              string
+	allowedParameters []string
+	remove            []string
+	rename            map[string]string
+	rewrites          []rewrite
+	set               map[string]string
+	add               map[string]string
+}
+
+// New creates a Query middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.Query, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if len(config.AllowedParameters) == 0 && len(config.Remove) == 0 && len(config.Rename) == 0 &&
+		len(config.Rewrites) == 0 && len(config.Set) == 0 && len(config.Add) == 0 {
+		return nil, errors.New("no query modification defined")
+	}
+
+	q := &query{
+		next:              next,
+		name:              name,
+		allowedParameters: config.AllowedParameters,
+		remove:            config.Remove,
+		rename:            config.Rename,
+		set:               config.Set,
+		add:               config.Add,
+	}
+
+	for _, rw := range config.Rewrites {
+		if rw.Parameter == "" {
+			return nil, errors.New("rewrite without parameter")
+		}
+
+		regex, err := regexp.Compile(rw.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("compiling parameter %q rewrite regex: %w", rw.Parameter, err)
+		}
+
+		q.rewrites = append(q.rewrites, rewrite{parameter: rw.Parameter, regex: regex, replacement: rw.Replacement})
+	}
+
+	return q, nil
+}
+
+func (q *query) GetTracingInformation() (string, string, trace.SpanKind) {
+	return q.name, typeName, trace.SpanKindInternal
+}
+
+func (q *query) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	values, err := url.ParseQuery(req.URL.RawQuery)
+	if err != nil {
+		// The malformed parameters are dropped when the query is modified.
+		middlewares.GetLogger(req.Context(), q.name, typeName).Debug().Err(err).Msg("Malformed query")
+	}
+
+	if q.modify(values) {
+		req.URL.RawQuery = values.Encode()
+		req.RequestURI = req.URL.RequestURI()
+	}
+
+	q.next.ServeHTTP(rw, req)
+}
+
+// modify applies the modifications to the given query values, and returns whether they were modified.
+func (q *query) modify(values url.Values) bool {
+	var modified bool
+
+	if len(q.allowedParameters) > 0 {
+		for name := range values {
+			if !slices.Contains(q.allowedParameters, name) {
+				delete(values, name)
+				modified = true
+			}
+		}
+	}
+
+	for _, name := range q.remove {
+		if values.Has(name) {
+			values.Del(name)
+			modified = true
+		}
+	}
+
+	for name, newName := range q.rename {
+		if value, ok := values[name]; ok {
+			delete(values, name)
+			values[newName] = append(values[newName], value...)
+			modified = true
+		}
+	}
+
+	for _, rw := range q.rewrites {
+		for i, value := range values[rw.parameter] {
+			if newValue := rw.regex.ReplaceAllString(value, rw.replacement); newValue != value {
+				values[rw.parameter][i] = newValue
+				modified = true
+			}
+		}
+	}
+
+	for name, value := range q.set {
+		values.Set(name, value)
+		modified = true
+	}
+
+	for name, value := range q.add {
+		values.Add(name, value)
+		modified = true
+	}
+
+	return modified
+}
@@ -0,0 +1,139 @@
+package query
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.Query
+	}{
+		{
+			desc:   "empty configuration",
+			config: dynamic.Query{},
+		},
+		{
+			desc:   "rewrite without parameter",
+			config: dynamic.Query{Rewrites: []dynamic.QueryRewrite{{Regex: "foo"}}},
+		},
+		{
+			desc:   "invalid rewrite regex",
+			config: dynamic.Query{Rewrites: []dynamic.QueryRewrite{{Parameter: "foo", Regex: "(foo"}}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "query")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestQuery_ServeHTTP(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		config        dynamic.Query
+		query         string
+		expectedQuery string
+	}{
+		{
+			desc:          "allowed parameters",
+			config:        dynamic.Query{AllowedParameters: []string{"page", "sort"}},
+			query:         "page=2&debug=true&sort=name&token=secret",
+			expectedQuery: "page=2&sort=name",
+		},
+		{
+			desc:          "remove",
+			config:        dynamic.Query{Remove: []string{"debug", "missing"}},
+			query:         "page=2&debug=true&debug=false",
+			expectedQuery: "page=2",
+		},
+		{
+			desc:          "rename",
+			config:        dynamic.Query{Rename: map[string]string{"p": "page"}},
+			query:         "p=2&sort=name",
+			expectedQuery: "page=2&sort=name",
+		},
+		{
+			desc:          "rename to an existing parameter",
+			config:        dynamic.Query{Rename: map[string]string{"p": "page"}},
+			query:         "page=1&p=2",
+			expectedQuery: "page=1&page=2",
+		},
+		{
+			desc: "rewrite",
+			config: dynamic.Query{Rewrites: []dynamic.QueryRewrite{
+				{Parameter: "redirect", Regex: `^http://legacy\.local/(.*)$`, Replacement: "https://example.com/$1"},
+			}},
+			query:         "redirect=http%3A%2F%2Flegacy.local%2Ffoo",
+			expectedQuery: "redirect=https%3A%2F%2Fexample.com%2Ffoo",
+		},
+		{
+			desc:          "set",
+			config:        dynamic.Query{Set: map[string]string{"version": "2", "lang": "en"}},
+			query:         "version=1&version=3",
+			expectedQuery: "lang=en&version=2",
+		},
+		{
+			desc:          "add",
+			config:        dynamic.Query{Add: map[string]string{"tag": "proxy"}},
+			query:         "tag=web",
+			expectedQuery: "tag=web&tag=proxy",
+		},
+		{
+			desc: "modifications order",
+			config: dynamic.Query{
+				AllowedParameters: []string{"q"},
+				Rename:            map[string]string{"q": "search"},
+				Add:               map[string]string{"source": "proxy"},
+			},
+			query:         "q=traefik&debug=true",
+			expectedQuery: "search=traefik&source=proxy",
+		},
+		{
+			desc:          "not modified",
+			config:        dynamic.Query{Remove: []string{"debug"}},
+			query:         "b=2&a=1",
+			expectedQuery: "b=2&a=1",
+		},
+		{
+			desc:          "malformed parameters dropped",
+			config:        dynamic.Query{Remove: []string{"debug"}},
+			query:         "a=%zz&b=2&debug=true",
+			expectedQuery: "b=2",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwarded *http.Request
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwarded = req
+			})
+
+			handler, err := New(context.Background(), next, test.config, "query")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/foo?"+test.query, nil)
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.NotNil(t, forwarded)
+			assert.Equal(t, test.expectedQuery, forwarded.URL.RawQuery)
+			assert.Equal(t, "/foo?"+test.expectedQuery, forwarded.RequestURI)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package ratelimiter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mailgun/ttlmap"
+	"golang.org/x/time/rate"
+)
+
+const maxSources = 65536
+
+// inMemoryLimiter keeps the token buckets in memory, the rate limit applying to the Traefik instance only.
+type inMemoryLimiter struct {
+	rate     rate.Limit
+	burst    int64
+	maxDelay time.Duration
+	// each rate limiter for a given source is stored in the buckets ttlmap.
+	// To keep this ttlmap constrained in size,
+	// each ratelimiter is "garbage collected" when it is considered expired.
+	// It is considered expired after it hasn't been used for ttl seconds.
+	ttl int
+
+	buckets *ttlmap.TtlMap // actual buckets, keyed by source.
+}
+
+func newInMemoryLimiter(rtl rate.Limit, burst int64, maxDelay time.Duration) (*inMemoryLimiter, error) {
+	buckets, err := ttlmap.NewConcurrent(maxSources)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make the ttl inversely proportional to how often a rate limiter is supposed to see any activity (when maxed out),
+	// for low rate limiters.
+	// Otherwise just make it a second for all the high rate limiters.
+	// Add an extra second in both cases for continuity between the two cases.
+	ttl := 1
+	if rtl >= 1 {
+		ttl++
+	} else if rtl > 0 {
+		ttl += int(1 / rtl)
+	}
+
+	return &inMemoryLimiter{
+		rate:     rtl,
+		burst:    burst,
+		maxDelay: maxDelay,
+		ttl:      ttl,
+		buckets:  buckets,
+	}, nil
+}
+
+func (l *inMemoryLimiter) allow(_ context.Context, source string) (time.Duration, bool, error) {
+	var bucket *rate.Limiter
+	if rlSource, exists := l.buckets.Get(source); exists {
+		bucket = rlSource.(*rate.Limiter)
+	} else {
+		bucket = rate.NewLimiter(l.rate, int(l.burst))
+	}
+
+	// We Set even in the case where the source already exists,
+	// because we want to update the expiryTime everytime we get the source,
+	// as the expiryTime is supposed to reflect the activity (or lack thereof) on that source.
+	if err := l.buckets.Set(source, bucket, l.ttl); err != nil {
+		return 0, false, fmt.Errorf("could not insert/update bucket: %w", err)
+	}
+
+	// A reservation which cannot be satisfied has an infinite delay.
+	res := bucket.Reserve()
+
+	delay := res.Delay()
+	if delay > l.maxDelay {
+		res.Cancel()
+		return delay, false, nil
+	}
+
+	return delay, true, nil
+}
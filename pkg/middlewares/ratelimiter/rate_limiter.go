@@ -8,7 +8,6 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/mailgun/ttlmap"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
@@ -18,10 +17,7 @@ import (
 	"golang.org/x/time/rate"
 )
 
-const (
-	typeName   = "RateLimiter"
-	maxSources = 65536
-)
+const typeName = "RateLimiter"
 
 // rateLimiter implements rate limiting and traffic shaping with a set of token buckets;
 // one for each traffic source. The same parameters are applied to all the buckets.
@@ -31,16 +27,19 @@ type rateLimiter struct {
 	burst int64
 	// maxDelay is the maximum duration we're willing to wait for a bucket reservation to become effective, in nanoseconds.
 	// For now it is somewhat arbitrarily set to 1/(2*rate).
-	maxDelay time.Duration
-	// each rate limiter for a given source is stored in the buckets ttlmap.
-	// To keep this ttlmap constrained in size,
-	// each ratelimiter is "garbage collected" when it is considered expired.
-	// It is considered expired after it hasn't been used for ttl seconds.
-	ttl           int
+	maxDelay      time.Duration
 	sourceMatcher utils.SourceExtractor
 	next          http.Handler
 
-	buckets *ttlmap.TtlMap // actual buckets, keyed by source.
+	limiter limiter // holds the actual buckets, keyed by source.
+}
+
+// limiter takes the tokens from the buckets of the traffic sources.
+type limiter interface {
+	// allow takes a token from the bucket of the given source,
+	// and returns the delay after which the token becomes effective.
+	// When this delay is greater than maxDelay, no token is taken, and false is returned.
+	allow(ctx context.Context, source string) (time.Duration, bool, error)
 }
 
 // New returns a rate limiter middleware.
@@ -52,7 +51,8 @@ func New(ctx context.Context, next http.Handler, config dynamic.RateLimit, name
 
 	if config.SourceCriterion == nil ||
 		config.SourceCriterion.IPStrategy == nil &&
-			config.SourceCriterion.RequestHeaderName == "" && !config.SourceCriterion.RequestHost {
+			config.SourceCriterion.RequestHeaderName == "" && !config.SourceCriterion.RequestHost &&
+			config.SourceCriterion.Expression == "" {
 		config.SourceCriterion = &dynamic.SourceCriterion{
 			IPStrategy: &dynamic.IPStrategy{},
 		}
@@ -63,11 +63,6 @@ func New(ctx context.Context, next http.Handler, config dynamic.RateLimit, name
 		return nil, err
 	}
 
-	buckets, err := ttlmap.NewConcurrent(maxSources)
-	if err != nil {
-		return nil, err
-	}
-
 	burst := config.Burst
 	if burst < 1 {
 		burst = 1
@@ -99,15 +94,14 @@ func New(ctx context.Context, next http.Handler, config dynamic.RateLimit, name
 		}
 	}
 
-	// Make the ttl inversely proportional to how often a rate limiter is supposed to see any activity (when maxed out),
-	// for low rate limiters.
-	// Otherwise just make it a second for all the high rate limiters.
-	// Add an extra second in both cases for continuity between the two cases.
-	ttl := 1
-	if rtl >= 1 {
-		ttl++
-	} else if rtl > 0 {
-		ttl += int(1 / rtl)
+	var limiter limiter
+	if config.Redis != nil {
+		limiter, err = newRedisLimiter(ctx, name, *config.Redis, rate.Limit(rtl), burst, maxDelay)
+	} else {
+		limiter, err = newInMemoryLimiter(rate.Limit(rtl), burst, maxDelay)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	return &rateLimiter{
@@ -117,8 +111,7 @@ func New(ctx context.Context, next http.Handler, config dynamic.RateLimit, name
 		maxDelay:      maxDelay,
 		next:          next,
 		sourceMatcher: sourceMatcher,
-		buckets:       buckets,
-		ttl:           ttl,
+		limiter:       limiter,
 	}, nil
 }
 
@@ -141,33 +134,15 @@ func (rl *rateLimiter) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		logger.Info().Msgf("ignoring token bucket amount > 1: %d", amount)
 	}
 
-	var bucket *rate.Limiter
-	if rlSource, exists := rl.buckets.Get(source); exists {
-		bucket = rlSource.(*rate.Limiter)
-	} else {
-		bucket = rate.NewLimiter(rl.rate, int(rl.burst))
-	}
-
-	// We Set even in the case where the source already exists,
-	// because we want to update the expiryTime everytime we get the source,
-	// as the expiryTime is supposed to reflect the activity (or lack thereof) on that source.
-	if err := rl.buckets.Set(source, bucket, rl.ttl); err != nil {
-		logger.Error().Err(err).Msg("Could not insert/update bucket")
-		observability.SetStatusErrorf(req.Context(), "Could not insert/update bucket")
-		http.Error(rw, "could not insert/update bucket", http.StatusInternalServerError)
-		return
-	}
-
-	res := bucket.Reserve()
-	if !res.OK() {
-		observability.SetStatusErrorf(req.Context(), "No bursty traffic allowed")
-		http.Error(rw, "No bursty traffic allowed", http.StatusTooManyRequests)
+	delay, ok, err := rl.limiter.allow(ctx, source)
+	if err != nil {
+		logger.Error().Err(err).Msg("Could not take a token")
+		observability.SetStatusErrorf(req.Context(), "Could not take a token")
+		http.Error(rw, "could not take a token", http.StatusInternalServerError)
 		return
 	}
 
-	delay := res.Delay()
-	if delay > rl.maxDelay {
-		res.Cancel()
+	if !ok {
 		rl.serveDelayError(ctx, rw, delay)
 		return
 	}
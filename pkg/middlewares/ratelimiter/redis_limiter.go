@@ -0,0 +1,104 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefikredis "github.com/traefik/traefik/v3/pkg/redis"
+	"golang.org/x/time/rate"
+)
+
+// takeToken refills the token bucket stored at KEYS[1] according to the elapsed time, and takes a token from it,
+// unless the delay after which the token becomes effective is greater than the maximum delay.
+// The bucket can hold a negative amount of tokens, i.e. the tokens taken in advance.
+// ARGV holds the rate, in tokens per second, the burst, the maximum delay, the current time,
+// and the retention of the bucket, all the durations being in microseconds.
+// It returns whether the token is taken, and the delay in microseconds.
+var takeToken = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local maxDelay = tonumber(ARGV[3])
+local now = tonumber(ARGV[4])
+local retention = tonumber(ARGV[5])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "last")
+local tokens = tonumber(bucket[1]) or burst
+local last = tonumber(bucket[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - last) * rate / 1000000) - 1
+
+local delay = 0
+if tokens < 0 then
+	delay = math.ceil(-tokens * 1000000 / rate)
+end
+
+if delay > maxDelay then
+	return {0, delay}
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "last", ARGV[4])
+redis.call("PEXPIRE", KEYS[1], math.ceil(retention / 1000))
+
+return {1, delay}
+`)
+
+// redisLimiter keeps the token buckets in Redis, the rate limit applying to all the Traefik instances sharing it.
+type redisLimiter struct {
+	client   redis.UniversalClient
+	prefix   string
+	rate     rate.Limit
+	burst    int64
+	maxDelay time.Duration
+	// retention is how long a bucket is kept after its last use, i.e. the time needed to refill it.
+	retention time.Duration
+}
+
+func newRedisLimiter(ctx context.Context, name string, config dynamic.Redis, rtl rate.Limit, burst int64, maxDelay time.Duration) (*redisLimiter, error) {
+	client, err := traefikredis.SharedClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	retention := time.Second
+	if rtl > 0 && rtl != rate.Inf {
+		retention += time.Duration(float64(burst) / float64(rtl) * float64(time.Second))
+	}
+
+	return &redisLimiter{
+		client:    client,
+		prefix:    "traefik:ratelimit:" + name + ":",
+		rate:      rtl,
+		burst:     burst,
+		maxDelay:  maxDelay,
+		retention: retention,
+	}, nil
+}
+
+func (l *redisLimiter) allow(ctx context.Context, source string) (time.Duration, bool, error) {
+	if l.rate == rate.Inf {
+		return 0, true, nil
+	}
+
+	args := []interface{}{
+		strconv.FormatFloat(float64(l.rate), 'f', -1, 64),
+		l.burst,
+		l.maxDelay.Microseconds(),
+		time.Now().UnixMicro(),
+		l.retention.Microseconds(),
+	}
+
+	result, err := takeToken.Run(ctx, l.client, []string{l.prefix + source}, args...).Int64Slice()
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(result) != 2 {
+		return 0, false, errors.New("unexpected result of the token bucket script")
+	}
+
+	return time.Duration(result[1]) * time.Microsecond, result[0] == 1, nil
+}
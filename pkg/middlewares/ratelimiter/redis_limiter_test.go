@@ -0,0 +1,108 @@
+package ratelimiter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestRedisLimiter_sharedBuckets(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	config := dynamic.RateLimit{
+		Average: 1,
+		Period:  ptypes.Duration(time.Minute),
+		Burst:   3,
+		Redis:   &dynamic.Redis{Endpoints: []string{server.Addr()}},
+	}
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+
+	// Two middlewares with the same name, as on two Traefik instances.
+	h1, err := New(context.Background(), next, config, "rate-limiter@file")
+	require.NoError(t, err)
+
+	h2, err := New(context.Background(), next, config, "rate-limiter@file")
+	require.NoError(t, err)
+
+	// Another middleware has its own buckets.
+	other, err := New(context.Background(), next, config, "other@file")
+	require.NoError(t, err)
+
+	serve := func(h http.Handler, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+		req.RemoteAddr = remoteAddr
+
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, req)
+
+		return rw
+	}
+
+	assert.Equal(t, http.StatusOK, serve(h1, "10.0.0.1:1234").Code)
+	assert.Equal(t, http.StatusOK, serve(h2, "10.0.0.1:1234").Code)
+	assert.Equal(t, http.StatusOK, serve(h1, "10.0.0.1:1234").Code)
+
+	rw := serve(h2, "10.0.0.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "60", rw.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, serve(h1, "10.0.0.2:1234").Code)
+	assert.Equal(t, http.StatusOK, serve(other, "10.0.0.1:1234").Code)
+}
+
+func TestRedisLimiter_refill(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	h, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), dynamic.RateLimit{
+		Average: 10,
+		Burst:   1,
+		Redis:   &dynamic.Redis{Endpoints: []string{server.Addr()}},
+	}, "rate-limiter@file")
+	require.NoError(t, err)
+
+	serve := func() int {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, req)
+
+		return rw.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve())
+	assert.Equal(t, http.StatusTooManyRequests, serve())
+
+	time.Sleep(100 * time.Millisecond)
+
+	assert.Equal(t, http.StatusOK, serve())
+
+	// The bucket is kept for the time needed to refill it.
+	assert.Equal(t, 1100*time.Millisecond, server.TTL("traefik:ratelimit:rate-limiter@file:10.0.0.1"))
+}
+
+func TestRedisLimiter_unavailable(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	h, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), dynamic.RateLimit{
+		Average: 10,
+		Redis:   &dynamic.Redis{Endpoints: []string{server.Addr()}},
+	}, "rate-limiter@file")
+	require.NoError(t, err)
+
+	server.Close()
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rw.Code)
+}
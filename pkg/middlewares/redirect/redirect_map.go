@@ -0,0 +1,250 @@
+package redirect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/maptable"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeMapName = "RedirectMap"
+
+// redirectsFormat is the format of the redirects files, with the source, target, and optional status code and match mode columns.
+var redirectsFormat = maptable.Format[dynamic.RedirectMapEntry]{
+	Name:       "redirects",
+	MaxColumns: 4,
+	FromRecord: func(record []string) (dynamic.RedirectMapEntry, error) {
+		redirect := dynamic.RedirectMapEntry{Source: record[0], Target: record[1]}
+
+		if len(record) > 2 && record[2] != "" {
+			var err error
+			redirect.StatusCode, err = strconv.Atoi(record[2])
+			if err != nil {
+				return redirect, fmt.Errorf("invalid status code %q", record[2])
+			}
+		}
+
+		if len(record) > 3 {
+			redirect.MatchMode = record[3]
+		}
+
+		return redirect, nil
+	},
+}
+
+// redirectMapEntry is a redirect of the redirect map.
+type redirectMapEntry struct {
+	target     string
+	statusCode int
+	regex      *regexp.Regexp
+}
+
+// redirectTable holds the redirects, indexed by match mode.
+type redirectTable struct {
+	exact  map[string]*redirectMapEntry
+	prefix map[string]*redirectMapEntry
+	// prefixLengths are the distinct lengths of the prefixes, from the longest to the shortest.
+	prefixLengths []int
+	regex         []*redirectMapEntry
+}
+
+type redirectMap struct {
+	next          http.Handler
+	name          string
+	matchMode     string
+	statusCode    int
+	preserveQuery bool
+	redirects     *maptable.Table[dynamic.RedirectMapEntry, redirectTable]
+}
+
+// NewRedirectMap creates a redirect map middleware.
+func NewRedirectMap(ctx context.Context, next http.Handler, conf dynamic.RedirectMap, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeMapName)
+	logger.Debug().Msg("Creating middleware")
+
+	if conf.File == "" && len(conf.Redirects) == 0 {
+		return nil, errors.New("no redirects defined")
+	}
+
+	r := &redirectMap{
+		next:          next,
+		name:          name,
+		matchMode:     conf.MatchMode,
+		statusCode:    conf.StatusCode,
+		preserveQuery: conf.PreserveQuery,
+	}
+
+	if r.matchMode == "" {
+		r.matchMode = maptable.MatchModeExact
+	}
+
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusFound
+	}
+
+	if err := maptable.CheckMatchMode(r.matchMode); err != nil {
+		return nil, err
+	}
+
+	if err := checkRedirectStatusCode(r.statusCode); err != nil {
+		return nil, err
+	}
+
+	var err error
+	r.redirects, err = maptable.New(logger, conf.File, conf.Redirects, redirectsFormat, r.buildTable)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *redirectMap) GetTracingInformation() (string, string, trace.SpanKind) {
+	return r.name, typeMapName, trace.SpanKindInternal
+}
+
+func (r *redirectMap) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	entry, target := r.redirects.Current().lookup(maptable.RequestHost(req), req.URL.Path)
+	if entry == nil {
+		r.next.ServeHTTP(rw, req)
+		return
+	}
+
+	location, err := url.Parse(target)
+	if err != nil {
+		middlewares.GetLogger(req.Context(), r.name, typeMapName).Error().Err(err).Msgf("Invalid redirect target %q", target)
+		http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if r.preserveQuery && location.RawQuery == "" {
+		location.RawQuery = req.URL.RawQuery
+	}
+
+	rw.Header().Set("Location", location.String())
+	rw.WriteHeader(entry.statusCode)
+	if _, err := rw.Write([]byte(http.StatusText(entry.statusCode))); err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Send()
+	}
+}
+
+func (r *redirectMap) buildTable(redirects []dynamic.RedirectMapEntry) (*redirectTable, error) {
+	table := &redirectTable{
+		exact:  make(map[string]*redirectMapEntry),
+		prefix: make(map[string]*redirectMapEntry),
+	}
+
+	for i, redirect := range redirects {
+		if redirect.Source == "" || redirect.Target == "" {
+			return nil, fmt.Errorf("redirect %d: source and target must be defined", i)
+		}
+
+		entry := &redirectMapEntry{target: redirect.Target, statusCode: r.statusCode}
+
+		if redirect.StatusCode != 0 {
+			if err := checkRedirectStatusCode(redirect.StatusCode); err != nil {
+				return nil, fmt.Errorf("redirect %d: %w", i, err)
+			}
+
+			entry.statusCode = redirect.StatusCode
+		}
+
+		matchMode := r.matchMode
+		if redirect.MatchMode != "" {
+			if err := maptable.CheckMatchMode(redirect.MatchMode); err != nil {
+				return nil, fmt.Errorf("redirect %d: %w", i, err)
+			}
+
+			matchMode = redirect.MatchMode
+		}
+
+		source := maptable.NormalizeSource(redirect.Source)
+
+		switch matchMode {
+		case maptable.MatchModeExact:
+			// The first redirect of a source wins.
+			if _, ok := table.exact[source]; !ok {
+				table.exact[source] = entry
+			}
+
+		case maptable.MatchModePrefix:
+			if _, ok := table.prefix[source]; !ok {
+				table.prefix[source] = entry
+			}
+
+			if !slices.Contains(table.prefixLengths, len(source)) {
+				table.prefixLengths = append(table.prefixLengths, len(source))
+			}
+
+		case maptable.MatchModeRegex:
+			regex, err := regexp.Compile(redirect.Source)
+			if err != nil {
+				return nil, fmt.Errorf("redirect %d: compiling source regex: %w", i, err)
+			}
+
+			entry.regex = regex
+			table.regex = append(table.regex, entry)
+		}
+	}
+
+	slices.SortFunc(table.prefixLengths, func(a, b int) int { return b - a })
+
+	return table, nil
+}
+
+// lookup returns the redirect matching the given host and path, along with its target URL.
+// The exact redirects come first, then the longest prefix redirects, and then the regex redirects in their definition order.
+// For each mode, the redirects of the host followed by the path come before the ones of the path.
+func (t *redirectTable) lookup(host, path string) (*redirectMapEntry, string) {
+	hostPath := host + path
+
+	for _, candidate := range []string{hostPath, path} {
+		if entry, ok := t.exact[candidate]; ok {
+			return entry, entry.target
+		}
+	}
+
+	for _, candidate := range []string{hostPath, path} {
+		for _, length := range t.prefixLengths {
+			if length > len(candidate) {
+				continue
+			}
+
+			if entry, ok := t.prefix[candidate[:length]]; ok {
+				return entry, entry.target + candidate[length:]
+			}
+		}
+	}
+
+	for _, entry := range t.regex {
+		candidate := hostPath
+		if maptable.IsPathRegex(entry.regex.String()) {
+			candidate = path
+		}
+
+		if match := entry.regex.FindStringSubmatchIndex(candidate); match != nil {
+			return entry, string(entry.regex.ExpandString(nil, entry.target, candidate, match))
+		}
+	}
+
+	return nil, ""
+}
+
+func checkRedirectStatusCode(statusCode int) error {
+	switch statusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return nil
+	default:
+		return fmt.Errorf("unsupported redirect status code %d", statusCode)
+	}
+}
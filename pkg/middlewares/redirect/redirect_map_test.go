@@ -0,0 +1,243 @@
+package redirect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares/maptable"
+)
+
+func TestNewRedirectMap(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.RedirectMap
+	}{
+		{
+			desc:   "no redirects",
+			config: dynamic.RedirectMap{},
+		},
+		{
+			desc: "missing target",
+			config: dynamic.RedirectMap{
+				Redirects: []dynamic.RedirectMapEntry{{Source: "/old"}},
+			},
+		},
+		{
+			desc: "unsupported match mode",
+			config: dynamic.RedirectMap{
+				MatchMode: "suffix",
+				Redirects: []dynamic.RedirectMapEntry{{Source: "/old", Target: "/new"}},
+			},
+		},
+		{
+			desc: "unsupported status code",
+			config: dynamic.RedirectMap{
+				Redirects: []dynamic.RedirectMapEntry{{Source: "/old", Target: "/new", StatusCode: http.StatusOK}},
+			},
+		},
+		{
+			desc: "invalid regex",
+			config: dynamic.RedirectMap{
+				Redirects: []dynamic.RedirectMapEntry{{Source: "^/(old", Target: "/new", MatchMode: "regex"}},
+			},
+		},
+		{
+			desc: "missing file",
+			config: dynamic.RedirectMap{
+				File: "does-not-exist.csv",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+			_, err := NewRedirectMap(context.Background(), next, test.config, "traefikTest")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRedirectMap_ServeHTTP(t *testing.T) {
+	config := dynamic.RedirectMap{
+		StatusCode:    http.StatusMovedPermanently,
+		PreserveQuery: true,
+		Redirects: []dynamic.RedirectMapEntry{
+			{Source: "/old", Target: "/new"},
+			{Source: "Example.com/old", Target: "https://example.org/new"},
+			{Source: "/docs/", Target: "/documentation/", MatchMode: "prefix"},
+			{Source: "/docs/v1/", Target: "/archive/v1/", MatchMode: "prefix", StatusCode: http.StatusFound},
+			{Source: `^/blog/(\d+)/(.*)$`, Target: "/posts/$2?year=$1", MatchMode: "regex"},
+			{Source: `^shop\.example\.com/(.*)$`, Target: "https://example.com/shop/$1", MatchMode: "regex", StatusCode: http.StatusPermanentRedirect},
+		},
+	}
+
+	testCases := []struct {
+		desc             string
+		url              string
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			desc:           "no match",
+			url:            "http://foo.com/other",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:             "exact path",
+			url:              "http://foo.com/old?foo=bar",
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "/new?foo=bar",
+		},
+		{
+			desc:             "exact host and path",
+			url:              "http://example.com:8080/old",
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "https://example.org/new",
+		},
+		{
+			desc:           "exact path does not match a longer path",
+			url:            "http://foo.com/old/page",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:             "prefix",
+			url:              "http://foo.com/docs/install",
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "/documentation/install",
+		},
+		{
+			desc:             "longest prefix",
+			url:              "http://foo.com/docs/v1/install",
+			expectedStatus:   http.StatusFound,
+			expectedLocation: "/archive/v1/install",
+		},
+		{
+			desc:             "path regex does not preserve a query of the target",
+			url:              "http://foo.com/blog/2021/hello?foo=bar",
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "/posts/hello?year=2021",
+		},
+		{
+			desc:             "host regex",
+			url:              "http://shop.example.com/cart",
+			expectedStatus:   http.StatusPermanentRedirect,
+			expectedLocation: "https://example.com/shop/cart",
+		},
+	}
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+
+	handler, err := NewRedirectMap(context.Background(), next, config, "traefikTest")
+	require.NoError(t, err)
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.url, nil))
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			assert.Equal(t, test.expectedLocation, recorder.Header().Get("Location"))
+		})
+	}
+}
+
+func TestRedirectMap_file(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		filename string
+		content  string
+		updated  string
+	}{
+		{
+			desc:     "CSV",
+			filename: "redirects.csv",
+			content:  "source,target,statusCode,matchMode\n# Migration\n/old,/new,301\n/docs/,/documentation/,,prefix\n",
+			updated:  "/old,/newer,301\n",
+		},
+		{
+			desc:     "JSON",
+			filename: "redirects.json",
+			content:  `[{"source":"/old","target":"/new","statusCode":301},{"source":"/docs/","target":"/documentation/","matchMode":"prefix"}]`,
+			updated:  `[{"source":"/old","target":"/newer","statusCode":301}]`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			file := filepath.Join(t.TempDir(), test.filename)
+			require.NoError(t, os.WriteFile(file, []byte(test.content), 0o600))
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+			handler, err := NewRedirectMap(context.Background(), next, dynamic.RedirectMap{File: file}, "traefikTest")
+			require.NoError(t, err)
+
+			serve := func(target string) *httptest.ResponseRecorder {
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+				return recorder
+			}
+
+			recorder := serve("http://foo.com/old")
+			assert.Equal(t, http.StatusMovedPermanently, recorder.Code)
+			assert.Equal(t, "/new", recorder.Header().Get("Location"))
+
+			recorder = serve("http://foo.com/docs/install")
+			assert.Equal(t, http.StatusFound, recorder.Code)
+			assert.Equal(t, "/documentation/install", recorder.Header().Get("Location"))
+
+			require.NoError(t, os.WriteFile(file, []byte(test.updated), 0o600))
+			// Makes sure the modification time changes, whatever the resolution of the file system.
+			require.NoError(t, os.Chtimes(file, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+
+			assert.Eventually(t, func() bool {
+				return serve("http://foo.com/old").Header().Get("Location") == "/newer"
+			}, 5*time.Second, 100*time.Millisecond)
+
+			assert.Equal(t, http.StatusOK, serve("http://foo.com/docs/install").Code)
+		})
+	}
+}
+
+func TestParseRedirectsCSV_invalid(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		content string
+	}{
+		{
+			desc:    "missing target",
+			content: "/old\n",
+		},
+		{
+			desc:    "too many columns",
+			content: "/old,/new,301,exact,foo\n",
+		},
+		{
+			desc:    "invalid status code",
+			content: "/old,/new,moved\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := maptable.ParseCSV([]byte(test.content), redirectsFormat)
+			assert.Error(t, err)
+		})
+	}
+}
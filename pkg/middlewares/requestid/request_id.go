@@ -0,0 +1,155 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "RequestID"
+
+const defaultHeaderName = "X-Request-ID"
+
+const (
+	generatorUUIDv7 = "uuidv7"
+	generatorULID   = "ulid"
+)
+
+// maxInboundIDLength is the maximum length of the request IDs sent by the clients.
+// Longer request IDs are replaced by a generated one.
+const maxInboundIDLength = 128
+
+// requestID is a middleware setting a unique ID on the requests, and returning it in the responses.
+type requestID struct {
+	next       http.Handler
+	name       string
+	headerName string
+	override   bool
+	generate   func() (string, error)
+}
+
+// New creates a RequestID middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.RequestID, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	r := &requestID{
+		next:       next,
+		name:       name,
+		headerName: http.CanonicalHeaderKey(config.HeaderName),
+		override:   config.Override,
+	}
+
+	if r.headerName == "" {
+		r.headerName = defaultHeaderName
+	}
+
+	switch config.Generator {
+	case "", generatorUUIDv7:
+		r.generate = newUUIDv7
+	case generatorULID:
+		r.generate = newULID
+	default:
+		return nil, fmt.Errorf("unsupported request ID generator: %s", config.Generator)
+	}
+
+	return r, nil
+}
+
+func (r *requestID) GetTracingInformation() (string, string, trace.SpanKind) {
+	return r.name, typeName, trace.SpanKindInternal
+}
+
+func (r *requestID) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	id := req.Header.Get(r.headerName)
+
+	if r.override || !isValidID(id) {
+		var err error
+		id, err = r.generate()
+		if err != nil {
+			middlewares.GetLogger(req.Context(), r.name, typeName).Error().Err(err).Msg("Unable to generate request ID")
+			r.next.ServeHTTP(rw, req)
+			return
+		}
+
+		req.Header.Set(r.headerName, id)
+	}
+
+	if logData := accesslog.GetLogData(req); logData != nil {
+		logData.Core[accesslog.RequestID] = id
+	}
+
+	trace.SpanFromContext(req.Context()).SetAttributes(attribute.String("traefik.request.id", id))
+
+	// The request ID is set once the response headers are known, to replace the one the service could have sent.
+	r.next.ServeHTTP(middlewares.NewResponseModifier(rw, req, func(res *http.Response) error {
+		res.Header.Set(r.headerName, id)
+		return nil
+	}), req)
+}
+
+// isValidID reports whether the request ID sent by the client can be kept.
+// It must be made of printable ASCII characters, to be safely logged.
+func isValidID(id string) bool {
+	if id == "" || len(id) > maxInboundIDLength {
+		return false
+	}
+
+	for i := range len(id) {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newUUIDv7() (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("generating UUIDv7: %w", err)
+	}
+
+	return id.String(), nil
+}
+
+// crockfordAlphabet is the Crockford's Base32 alphabet used by the ULIDs.
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID returns a ULID, made of a 48 bits timestamp in milliseconds followed by 80 random bits.
+// More info: https://github.com/ulid/spec
+func newULID() (string, error) {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixMilli())<<16)
+
+	if _, err := rand.Read(b[6:]); err != nil {
+		return "", fmt.Errorf("generating ULID: %w", err)
+	}
+
+	// The 128 bits are encoded as 26 characters of 5 bits, the first character holding only 3 bits.
+	var dst [26]byte
+	for i := range dst {
+		var v byte
+		for j := range 5 {
+			v <<= 1
+
+			bit := i*5 + j - 2
+			if bit >= 0 && b[bit/8]&(0x80>>(bit%8)) != 0 {
+				v |= 1
+			}
+		}
+
+		dst[i] = crockfordAlphabet[v]
+	}
+
+	return string(dst[:]), nil
+}
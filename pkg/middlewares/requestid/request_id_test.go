@@ -0,0 +1,137 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	_, err := New(context.Background(), http.NotFoundHandler(), dynamic.RequestID{Generator: "uuidv4"}, "requestId")
+	assert.Error(t, err)
+}
+
+func TestRequestID(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		config     dynamic.RequestID
+		headerName string
+		inboundID  string
+		expectedID string
+	}{
+		{
+			desc:       "generated ID",
+			headerName: "X-Request-Id",
+		},
+		{
+			desc:       "inbound ID",
+			headerName: "X-Request-Id",
+			inboundID:  "f2ad1cd2-3d3e-4d1f-b4f4-56d5b2a0e8a5",
+			expectedID: "f2ad1cd2-3d3e-4d1f-b4f4-56d5b2a0e8a5",
+		},
+		{
+			desc:       "overridden inbound ID",
+			config:     dynamic.RequestID{Override: true},
+			headerName: "X-Request-Id",
+			inboundID:  "f2ad1cd2-3d3e-4d1f-b4f4-56d5b2a0e8a5",
+		},
+		{
+			desc:       "invalid inbound ID",
+			headerName: "X-Request-Id",
+			inboundID:  "foo\tbar",
+		},
+		{
+			desc:       "too long inbound ID",
+			headerName: "X-Request-Id",
+			inboundID:  strings.Repeat("a", maxInboundIDLength+1),
+		},
+		{
+			desc:       "custom header name",
+			config:     dynamic.RequestID{HeaderName: "x-correlation-id"},
+			headerName: "X-Correlation-Id",
+			inboundID:  "foo",
+			expectedID: "foo",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwardedID string
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwardedID = req.Header.Get(test.headerName)
+
+				// The request ID of the service is replaced.
+				rw.Header().Set(test.headerName, "service")
+				rw.WriteHeader(http.StatusNoContent)
+			})
+
+			handler, err := New(context.Background(), next, test.config, "requestId")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.inboundID != "" {
+				req.Header.Set(test.headerName, test.inboundID)
+			}
+
+			logData := &accesslog.LogData{Core: accesslog.CoreLogData{}}
+			req = req.WithContext(context.WithValue(req.Context(), accesslog.DataTableKey, logData))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusNoContent, recorder.Code)
+			assert.Equal(t, []string{forwardedID}, recorder.Header().Values(test.headerName))
+			assert.Equal(t, forwardedID, logData.Core[accesslog.RequestID])
+
+			if test.expectedID != "" {
+				assert.Equal(t, test.expectedID, forwardedID)
+				return
+			}
+
+			id, err := uuid.Parse(forwardedID)
+			require.NoError(t, err)
+			assert.Equal(t, uuid.Version(7), id.Version())
+		})
+	}
+}
+
+func TestRequestID_ULID(t *testing.T) {
+	var forwardedID string
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwardedID = req.Header.Get(defaultHeaderName)
+	})
+
+	handler, err := New(context.Background(), next, dynamic.RequestID{Generator: "ulid"}, "requestId")
+	require.NoError(t, err)
+
+	before := time.Now().UnixMilli()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	after := time.Now().UnixMilli()
+
+	require.Len(t, forwardedID, 26)
+
+	// The first 10 characters encode the timestamp in milliseconds.
+	var timestamp int64
+	for _, c := range forwardedID[:10] {
+		i := strings.IndexRune(crockfordAlphabet, c)
+		require.GreaterOrEqual(t, i, 0)
+		timestamp = timestamp<<5 | int64(i)
+	}
+	assert.GreaterOrEqual(t, timestamp, before)
+	assert.LessOrEqual(t, timestamp, after)
+
+	for _, c := range forwardedID[10:] {
+		assert.Contains(t, crockfordAlphabet, string(c))
+	}
+}
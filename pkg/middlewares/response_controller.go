@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+)
+
+type responseControllerKey struct{}
+
+// WrapResponseController returns a handler adding the response controller of the connection in the context of the requests,
+// for the handlers to reach it through the response writers wrapped by the middlewares.
+func WrapResponseController(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := context.WithValue(req.Context(), responseControllerKey{}, http.NewResponseController(rw))
+		next.ServeHTTP(rw, req.WithContext(ctx))
+	})
+}
+
+// GetResponseController returns the response controller of the connection of the request,
+// or the one of the given response writer when the context does not hold one.
+func GetResponseController(rw http.ResponseWriter, req *http.Request) *http.ResponseController {
+	if rc, ok := req.Context().Value(responseControllerKey{}).(*http.ResponseController); ok {
+		return rc
+	}
+
+	return http.NewResponseController(rw)
+}
@@ -0,0 +1,94 @@
+package retry
+
+import (
+	"sync"
+	"time"
+)
+
+// budgetWindow is the number of seconds over which the requests and the retries are accounted.
+const budgetWindow = 10
+
+type budgetBucket struct {
+	second   int64
+	requests int64
+	retries  int64
+}
+
+// budget limits the retries to a percentage of the requests handled over the last seconds,
+// plus a minimum number of retries per second.
+type budget struct {
+	percent    int64
+	minRetries int64
+
+	mu      sync.Mutex
+	buckets [budgetWindow]budgetBucket
+
+	now func() time.Time
+}
+
+func newBudget(percent, minRetriesPerSecond int) *budget {
+	return &budget{
+		percent:    int64(percent),
+		minRetries: int64(minRetriesPerSecond) * budgetWindow,
+		now:        time.Now,
+	}
+}
+
+// deposit accounts for a new request.
+func (b *budget) deposit() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.bucket().requests++
+}
+
+// canRetry tells whether the budget allows another retry.
+func (b *budget) canRetry() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now().Unix()
+
+	var requests, retries int64
+	for _, bucket := range b.buckets {
+		if now-bucket.second < budgetWindow {
+			requests += bucket.requests
+			retries += bucket.retries
+		}
+	}
+
+	return retries < b.minRetries+requests*b.percent/100
+}
+
+// withdraw accounts for a retry.
+func (b *budget) withdraw() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.bucket().retries++
+}
+
+// bucket returns the bucket of the current second, resetting it if it holds an older second.
+// It must be called with the lock held.
+func (b *budget) bucket() *budgetBucket {
+	now := b.now().Unix()
+
+	bucket := &b.buckets[now%budgetWindow]
+	if bucket.second != now {
+		*bucket = budgetBucket{second: now}
+	}
+
+	return bucket
+}
@@ -0,0 +1,270 @@
+package retry
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"golang.org/x/net/http/httpguts"
+)
+
+// maxHedgedBodySize is the size of the response body buffered for each hedged request,
+// beyond which the response is forwarded to the client as it is received, and the other request canceled.
+const maxHedgedBodySize = 1024 * 1024
+
+// isHedgeable tells whether the request can safely be sent twice.
+func isHedgeable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		return false
+	}
+
+	// The streams are not hedged, as their responses cannot be buffered.
+	if middlewares.IsEventStreamRequest(req) || httpguts.HeaderValuesContainsToken(req.Header["Connection"], "Upgrade") {
+		return false
+	}
+
+	return req.ContentLength == 0 && len(req.TransferEncoding) == 0
+}
+
+// serveHedged forwards the request, and sends a duplicate request when no response is received after the hedging delay.
+// The first response received is written to rw, and the other request is canceled.
+// A response too large to be buffered, or flushed by the service, is forwarded as it is received,
+// if no other response was received first.
+func (r *retry) serveHedged(rw *responseWriter, req *http.Request) {
+	h := &hedge{rw: rw}
+	defer h.cancel()
+
+	responses := make(chan *hedgedResponse, 2)
+	send := func() {
+		ctx, cancel := context.WithCancel(req.Context())
+		resp := &hedgedResponse{hedge: h, header: make(http.Header)}
+		h.add(resp, cancel)
+
+		// Records whether the backend already received request data, to disable the retries.
+		clientTrace := &httptrace.ClientTrace{
+			WroteHeaders: func() {
+				resp.wroteRequest.Store(true)
+			},
+			WroteRequest: func(httptrace.WroteRequestInfo) {
+				resp.wroteRequest.Store(true)
+			},
+		}
+		hedgedReq := req.Clone(httptrace.WithClientTrace(ctx, clientTrace))
+
+		go func() {
+			defer func() {
+				if err := recover(); err != nil {
+					if err != http.ErrAbortHandler {
+						log.Ctx(ctx).Error().Interface("error", err).Msg("Panic while serving a hedged request")
+					}
+					resp.abort()
+				}
+
+				responses <- resp
+			}()
+
+			r.next.ServeHTTP(resp, hedgedReq)
+		}()
+	}
+
+	send()
+
+	timer := time.NewTimer(r.hedgingDelay)
+	defer timer.Stop()
+
+	var resp *hedgedResponse
+	select {
+	case resp = <-responses:
+	case <-timer.C:
+		// No request is hedged once a response is forwarded.
+		if h.winner() == nil && r.budget.canRetry() {
+			r.budget.withdraw()
+
+			log.Ctx(req.Context()).Debug().Msgf("Sending hedged request: %v", req.URL)
+			send()
+		}
+
+		resp = <-responses
+	}
+
+	// The response being forwarded, if any, is waited for.
+	winner := h.claim(resp)
+	for resp != winner {
+		resp = <-responses
+	}
+
+	if winner.streaming {
+		return
+	}
+
+	if winner.wroteRequest.Load() {
+		rw.DisableRetries()
+	}
+
+	winner.writeTo(rw)
+}
+
+// hedge elects the response written to the client among the ones of the hedged requests.
+type hedge struct {
+	rw *responseWriter
+
+	mu        sync.Mutex
+	responses []*hedgedResponse
+	cancels   []context.CancelFunc
+	elected   *hedgedResponse
+}
+
+func (h *hedge) add(resp *hedgedResponse, cancel context.CancelFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.responses = append(h.responses, resp)
+	h.cancels = append(h.cancels, cancel)
+}
+
+// claim elects the given response, unless another one already is, cancels the other requests, and returns the elected response.
+func (h *hedge) claim(resp *hedgedResponse) *hedgedResponse {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.elected != nil {
+		return h.elected
+	}
+
+	h.elected = resp
+	for i, other := range h.responses {
+		if other != resp {
+			h.cancels[i]()
+		}
+	}
+
+	return resp
+}
+
+func (h *hedge) winner() *hedgedResponse {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.elected
+}
+
+// cancel cancels all the requests.
+func (h *hedge) cancel() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, cancel := range h.cancels {
+		cancel()
+	}
+}
+
+// hedgedResponse buffers the response of a hedged request,
+// until it is known whether it is the first response received.
+type hedgedResponse struct {
+	hedge *hedge
+
+	header http.Header
+	code   int
+	body   bytes.Buffer
+
+	// streaming is set once the response is forwarded to the client as it is received.
+	streaming bool
+	// discarded is set once another response is elected.
+	discarded bool
+
+	wroteRequest atomic.Bool
+}
+
+func (h *hedgedResponse) Header() http.Header {
+	if h.streaming {
+		return h.hedge.rw.Header()
+	}
+
+	return h.header
+}
+
+func (h *hedgedResponse) Write(buf []byte) (int, error) {
+	if h.code == 0 {
+		h.code = http.StatusOK
+	}
+
+	if !h.streaming && h.body.Len()+len(buf) > maxHedgedBodySize {
+		h.stream()
+	}
+
+	switch {
+	case h.streaming:
+		return h.hedge.rw.Write(buf)
+	case h.discarded:
+		return len(buf), nil
+	default:
+		return h.body.Write(buf)
+	}
+}
+
+func (h *hedgedResponse) WriteHeader(code int) {
+	// The informational responses are not forwarded, as the request may be answered by the other backend.
+	if h.code != 0 || code < http.StatusOK {
+		return
+	}
+
+	h.code = code
+}
+
+// Flush forwards the response to the client, and the following writes as they are received,
+// if no other response was received first.
+func (h *hedgedResponse) Flush() {
+	if h.streaming || h.stream() {
+		h.hedge.rw.Flush()
+	}
+}
+
+// stream starts forwarding the response to the client, if no other response was received first.
+func (h *hedgedResponse) stream() bool {
+	if h.discarded {
+		return false
+	}
+
+	if h.hedge.claim(h) != h {
+		h.discarded = true
+		h.body = bytes.Buffer{}
+		return false
+	}
+
+	if h.wroteRequest.Load() {
+		h.hedge.rw.DisableRetries()
+	}
+
+	h.writeTo(h.hedge.rw)
+	h.body = bytes.Buffer{}
+	h.streaming = true
+
+	return true
+}
+
+func (h *hedgedResponse) abort() {
+	if h.code == 0 {
+		h.code = http.StatusBadGateway
+	}
+}
+
+func (h *hedgedResponse) writeTo(rw http.ResponseWriter) {
+	for name, values := range h.header {
+		rw.Header()[name] = values
+	}
+
+	if h.code == 0 {
+		h.code = http.StatusOK
+	}
+
+	rw.WriteHeader(h.code)
+	_, _ = rw.Write(h.body.Bytes())
+}
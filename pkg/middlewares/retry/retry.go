@@ -40,6 +40,8 @@ type Listeners []Listener
 type retry struct {
 	attempts        int
 	initialInterval time.Duration
+	budget          *budget
+	hedgingDelay    time.Duration
 	next            http.Handler
 	listener        Listener
 	name            string
@@ -53,17 +55,39 @@ func New(ctx context.Context, next http.Handler, config dynamic.Retry, listener
 		return nil, fmt.Errorf("incorrect (or empty) value for attempt (%d)", config.Attempts)
 	}
 
-	return &retry{
+	r := &retry{
 		attempts:        config.Attempts,
 		initialInterval: time.Duration(config.InitialInterval),
 		next:            next,
 		listener:        listener,
 		name:            name,
-	}, nil
+	}
+
+	if config.Budget != nil {
+		if config.Budget.Percent < 0 || config.Budget.MinRetriesPerSecond < 0 {
+			return nil, fmt.Errorf("incorrect value for budget (percent: %d, minRetriesPerSecond: %d)", config.Budget.Percent, config.Budget.MinRetriesPerSecond)
+		}
+
+		r.budget = newBudget(config.Budget.Percent, config.Budget.MinRetriesPerSecond)
+	}
+
+	if config.Hedging != nil {
+		if config.Hedging.Delay <= 0 {
+			return nil, fmt.Errorf("incorrect (or empty) value for hedging delay (%s)", time.Duration(config.Hedging.Delay))
+		}
+
+		r.hedgingDelay = time.Duration(config.Hedging.Delay)
+	}
+
+	return r, nil
 }
 
 func (r *retry) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
-	if r.attempts == 1 {
+	r.budget.deposit()
+
+	hedge := r.hedgingDelay > 0 && isHedgeable(req)
+
+	if r.attempts == 1 && !hedge {
 		r.next.ServeHTTP(rw, req)
 		return
 	}
@@ -101,26 +125,33 @@ func (r *retry) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 			req = req.WithContext(tracingCtx)
 		}
 
-		shouldRetry := attempts < r.attempts
+		shouldRetry := attempts < r.attempts && r.budget.canRetry()
 		retryResponseWriter := newResponseWriter(rw, shouldRetry)
 
-		// Disable retries when the backend already received request data
-		clientTrace := &httptrace.ClientTrace{
-			WroteHeaders: func() {
-				retryResponseWriter.DisableRetries()
-			},
-			WroteRequest: func(httptrace.WroteRequestInfo) {
-				retryResponseWriter.DisableRetries()
-			},
-		}
-		newCtx := httptrace.WithClientTrace(req.Context(), clientTrace)
+		if hedge {
+			// The hedged requests record on their own whether the backend received request data,
+			// as the request canceled in favor of the first response may outlive the attempt.
+			r.serveHedged(retryResponseWriter, req)
+		} else {
+			// Disable retries when the backend already received request data
+			clientTrace := &httptrace.ClientTrace{
+				WroteHeaders: func() {
+					retryResponseWriter.DisableRetries()
+				},
+				WroteRequest: func(httptrace.WroteRequestInfo) {
+					retryResponseWriter.DisableRetries()
+				},
+			}
+			newCtx := httptrace.WithClientTrace(req.Context(), clientTrace)
 
-		r.next.ServeHTTP(retryResponseWriter, req.Clone(newCtx))
+			r.next.ServeHTTP(retryResponseWriter, req.Clone(newCtx))
+		}
 
 		if !retryResponseWriter.ShouldRetry() {
 			return nil
 		}
 
+		r.budget.withdraw()
 		attempts++
 
 		return fmt.Errorf("attempt %d failed", attempts-1)
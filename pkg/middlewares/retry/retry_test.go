@@ -9,6 +9,7 @@ import (
 	"net/http/httptrace"
 	"net/textproto"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -152,6 +153,214 @@ func TestRetryEmptyServerList(t *testing.T) {
 	assert.Equal(t, 0, retryListener.timesCalled)
 }
 
+func TestRetryBudget(t *testing.T) {
+	next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusBadGateway)
+	})
+
+	config := dynamic.Retry{
+		Attempts: 3,
+		Budget:   &dynamic.RetryBudget{Percent: 50},
+	}
+
+	retryListener := &countingRetryListener{}
+	retry, err := New(context.Background(), next, config, retryListener, "traefikTest")
+	require.NoError(t, err)
+
+	for range 4 {
+		recorder := httptest.NewRecorder()
+		retry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost:3000/ok", nil))
+
+		assert.Equal(t, http.StatusBadGateway, recorder.Code)
+	}
+
+	// The four requests allow two retries.
+	assert.Equal(t, 2, retryListener.timesCalled)
+}
+
+func TestBudget(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	b := newBudget(20, 1)
+	b.now = func() time.Time { return now }
+
+	// The minimum retries are allowed without any request.
+	for range 10 {
+		require.True(t, b.canRetry())
+		b.withdraw()
+	}
+	assert.False(t, b.canRetry())
+
+	for range 10 {
+		b.deposit()
+	}
+	assert.True(t, b.canRetry())
+	b.withdraw()
+	assert.True(t, b.canRetry())
+	b.withdraw()
+	assert.False(t, b.canRetry())
+
+	// The retries are forgotten after the window.
+	now = now.Add(budgetWindow * time.Second)
+	assert.True(t, b.canRetry())
+}
+
+func TestRetryHedging(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		method         string
+		budget         *dynamic.RetryBudget
+		expectedCalls  int
+		expectedServer string
+	}{
+		{
+			desc:           "hedged request",
+			method:         http.MethodGet,
+			expectedCalls:  2,
+			expectedServer: "second",
+		},
+		{
+			desc:           "not hedged when the budget is exhausted",
+			method:         http.MethodGet,
+			budget:         &dynamic.RetryBudget{},
+			expectedCalls:  1,
+			expectedServer: "first",
+		},
+		{
+			desc:           "not hedged when the method is not idempotent",
+			method:         http.MethodPost,
+			expectedCalls:  1,
+			expectedServer: "first",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					// The first backend is slow, and answers unless the request is canceled.
+					select {
+					case <-time.After(200 * time.Millisecond):
+						rw.Header().Set("server", "first")
+					case <-r.Context().Done():
+					}
+					return
+				}
+
+				rw.Header().Set("server", "second")
+			})
+
+			config := dynamic.Retry{
+				Attempts: 1,
+				Budget:   test.budget,
+				Hedging:  &dynamic.RetryHedging{Delay: ptypes.Duration(10 * time.Millisecond)},
+			}
+
+			retry, err := New(context.Background(), next, config, &countingRetryListener{}, "traefikTest")
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			retry.ServeHTTP(recorder, httptest.NewRequest(test.method, "http://localhost:3000/ok", nil))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, test.expectedServer, recorder.Header().Get("server"))
+			assert.EqualValues(t, test.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func TestRetryHedging_streams(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		header        http.Header
+		expectedCalls int
+	}{
+		{
+			desc:          "not hedged when the request is an event stream",
+			header:        http.Header{"Accept": []string{"text/event-stream"}},
+			expectedCalls: 1,
+		},
+		{
+			desc:          "not hedged when the request is an upgrade",
+			header:        http.Header{"Connection": []string{"Upgrade"}, "Upgrade": []string{"websocket"}},
+			expectedCalls: 1,
+		},
+		{
+			desc:          "hedged request",
+			expectedCalls: 2,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+
+				select {
+				case <-time.After(50 * time.Millisecond):
+				case <-r.Context().Done():
+				}
+			})
+
+			config := dynamic.Retry{
+				Attempts: 1,
+				Hedging:  &dynamic.RetryHedging{Delay: ptypes.Duration(10 * time.Millisecond)},
+			}
+
+			retry, err := New(context.Background(), next, config, &countingRetryListener{}, "traefikTest")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:3000/ok", nil)
+			for name, values := range test.header {
+				req.Header[name] = values
+			}
+
+			retry.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.EqualValues(t, test.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func TestRetryHedging_largeResponse(t *testing.T) {
+	var calls atomic.Int32
+	next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) > 1 {
+			rw.Header().Set("server", "second")
+			return
+		}
+
+		// The first backend sends a response too large to be buffered, and ends it slowly.
+		rw.Header().Set("server", "first")
+		_, _ = rw.Write(make([]byte, maxHedgedBodySize+1))
+
+		time.Sleep(50 * time.Millisecond)
+		_, _ = rw.Write([]byte("end"))
+	})
+
+	config := dynamic.Retry{
+		Attempts: 1,
+		Hedging:  &dynamic.RetryHedging{Delay: ptypes.Duration(10 * time.Millisecond)},
+	}
+
+	retry, err := New(context.Background(), next, config, &countingRetryListener{}, "traefikTest")
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	retry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost:3000/ok", nil))
+
+	// The response is forwarded as it is received, and no request is hedged anymore.
+	assert.Equal(t, "first", recorder.Header().Get("server"))
+	assert.Equal(t, maxHedgedBodySize+4, recorder.Body.Len())
+	assert.EqualValues(t, 1, calls.Load())
+}
+
 func TestRetryListeners(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	retryListeners := Listeners{&countingRetryListener{}, &countingRetryListener{}}
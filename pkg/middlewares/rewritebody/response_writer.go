@@ -0,0 +1,128 @@
+package rewritebody
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// maxLineSize is the size above which a line is rewritten and written without waiting for its end.
+const maxLineSize = 1 << 20
+
+// rewriteWriter rewrites the response bodies line by line, to stream them while being able to
+// rewrite the matches spanning several writes of the next handler.
+type rewriteWriter struct {
+	rw         http.ResponseWriter
+	replacer   *replacer
+	rewritable func(header http.Header) bool
+
+	status    int
+	rewriting bool
+	buffer    bytes.Buffer
+	writeErr  error
+}
+
+func (w *rewriteWriter) Header() http.Header {
+	return w.rw.Header()
+}
+
+func (w *rewriteWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+
+	// The informational responses are forwarded, as they precede the final response.
+	if status >= 100 && status < 200 && status != http.StatusSwitchingProtocols {
+		w.rw.WriteHeader(status)
+		return
+	}
+
+	w.status = status
+
+	if status != http.StatusNoContent && status != http.StatusNotModified && w.rewritable(w.rw.Header()) {
+		w.rewriting = true
+
+		// The length of the rewritten body is unknown until it is entirely written.
+		w.rw.Header().Del("Content-Length")
+	}
+
+	w.rw.WriteHeader(status)
+}
+
+func (w *rewriteWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !w.rewriting {
+		return w.rw.Write(b)
+	}
+
+	if w.writeErr != nil {
+		return 0, w.writeErr
+	}
+
+	w.buffer.Write(b)
+
+	end := bytes.LastIndexByte(w.buffer.Bytes(), '\n')
+	switch {
+	case end >= 0:
+		w.writeRewritten(end + 1)
+	case w.buffer.Len() > maxLineSize:
+		w.writeRewritten(w.buffer.Len())
+	}
+
+	if w.writeErr != nil {
+		return 0, w.writeErr
+	}
+
+	return len(b), nil
+}
+
+// Hijack hijacks the connection.
+func (w *rewriteWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.rw.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+
+	return nil, nil, fmt.Errorf("not a hijacker: %T", w.rw)
+}
+
+// Flush rewrites and writes the buffered body, and sends it to the client.
+func (w *rewriteWriter) Flush() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.rewriting {
+		w.writeRewritten(w.buffer.Len())
+	}
+
+	if flusher, ok := w.rw.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// finish rewrites and writes the rest of the body.
+func (w *rewriteWriter) finish() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.rewriting {
+		w.writeRewritten(w.buffer.Len())
+	}
+}
+
+// writeRewritten rewrites and writes the first n buffered bytes.
+func (w *rewriteWriter) writeRewritten(n int) {
+	if n == 0 || w.writeErr != nil {
+		return
+	}
+
+	if _, err := w.rw.Write(w.replacer.replace(w.buffer.Next(n))); err != nil {
+		w.writeErr = err
+	}
+}
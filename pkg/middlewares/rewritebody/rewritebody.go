@@ -0,0 +1,246 @@
+package rewritebody
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "RewriteBody"
+
+// defaultContentTypes are the media types rewritten when none is configured.
+var defaultContentTypes = []string{
+	"text/*",
+	"application/javascript",
+	"application/json",
+	"application/xml",
+	"application/xhtml+xml",
+}
+
+// rewrite is a compiled body rewrite.
+type rewrite struct {
+	regex *regexp.Regexp
+	// replacement is the static replacement, used when template is nil.
+	replacement []byte
+	template    *template.Template
+}
+
+// templateData is the data available to the replacement templates.
+type templateData struct {
+	Host   string
+	Scheme string
+	Method string
+	Path   string
+	Header http.Header
+}
+
+// rewriteBody is a middleware rewriting the request and response bodies.
+type rewriteBody struct {
+	next         http.Handler
+	name         string
+	rewrites     []rewrite
+	request      bool
+	response     bool
+	contentTypes []string
+}
+
+// New creates a RewriteBody middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.RewriteBody, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeName)
+	logger.Debug().Msg("Creating middleware")
+
+	if len(config.Rewrites) == 0 {
+		return nil, errors.New("no rewrite defined")
+	}
+
+	if !config.Request && !config.Response {
+		return nil, errors.New("neither the request nor the response is rewritten")
+	}
+
+	rb := &rewriteBody{
+		next:     next,
+		name:     name,
+		request:  config.Request,
+		response: config.Response,
+	}
+
+	for i, config := range config.Rewrites {
+		regex, err := regexp.Compile(config.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("compiling rewrite %d regex: %w", i, err)
+		}
+
+		rw := rewrite{regex: regex, replacement: []byte(config.Replacement)}
+
+		if strings.Contains(config.Replacement, "{{") {
+			rw.template, err = template.New("replacement").Parse(config.Replacement)
+			if err != nil {
+				return nil, fmt.Errorf("parsing rewrite %d replacement template: %w", i, err)
+			}
+		}
+
+		rb.rewrites = append(rb.rewrites, rw)
+	}
+
+	contentTypes := config.ContentTypes
+	if len(contentTypes) == 0 {
+		contentTypes = defaultContentTypes
+	}
+
+	for _, contentType := range contentTypes {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, fmt.Errorf("parsing content type %q: %w", contentType, err)
+		}
+
+		rb.contentTypes = append(rb.contentTypes, mediaType)
+	}
+
+	return rb, nil
+}
+
+func (r *rewriteBody) GetTracingInformation() (string, string, trace.SpanKind) {
+	return r.name, typeName, trace.SpanKindInternal
+}
+
+func (r *rewriteBody) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), r.name, typeName)
+
+	replacer, err := r.newReplacer(req)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to evaluate the replacement templates")
+		http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if r.request && req.Body != nil && req.Body != http.NoBody && r.isRewritable(req.Header) {
+		// The request body is read entirely, to forward it with its new length,
+		// as some services do not support the chunked transfer encoding.
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			logger.Debug().Err(err).Msg("Unable to read the request body")
+			http.Error(rw, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		body = replacer.replace(body)
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+		req.TransferEncoding = nil
+		req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+
+	if !r.response || req.Method == http.MethodHead {
+		r.next.ServeHTTP(rw, req)
+		return
+	}
+
+	// The responses are requested without compression, to be able to rewrite them.
+	req.Header.Del("Accept-Encoding")
+
+	writer := &rewriteWriter{
+		rw:       rw,
+		replacer: replacer,
+		rewritable: func(header http.Header) bool {
+			return r.isRewritable(header)
+		},
+	}
+	defer writer.finish()
+
+	r.next.ServeHTTP(writer, req)
+}
+
+// isRewritable returns whether the body described by the given headers is rewritten.
+func (r *rewriteBody) isRewritable(header http.Header) bool {
+	if encoding := header.Get("Content-Encoding"); encoding != "" && !strings.EqualFold(encoding, "identity") {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	for _, contentType := range r.contentTypes {
+		if contentType == mediaType {
+			return true
+		}
+
+		if prefix, ok := strings.CutSuffix(contentType, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newReplacer returns the replacer of the bodies of the given request, with the evaluated replacement templates.
+func (r *rewriteBody) newReplacer(req *http.Request) (*replacer, error) {
+	rep := &replacer{rewrites: r.rewrites}
+
+	var data *templateData
+	for i, rewrite := range r.rewrites {
+		if rewrite.template == nil {
+			continue
+		}
+
+		if data == nil {
+			data = newTemplateData(req)
+			rep.rewrites = slices.Clone(r.rewrites)
+		}
+
+		var replacement bytes.Buffer
+		if err := rewrite.template.Execute(&replacement, data); err != nil {
+			return nil, err
+		}
+
+		rep.rewrites[i].replacement = replacement.Bytes()
+	}
+
+	return rep, nil
+}
+
+func newTemplateData(req *http.Request) *templateData {
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	if proto := req.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return &templateData{
+		Host:   req.Host,
+		Scheme: scheme,
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Header: req.Header,
+	}
+}
+
+// replacer applies the rewrites of a request.
+type replacer struct {
+	rewrites []rewrite
+}
+
+func (r *replacer) replace(body []byte) []byte {
+	for _, rewrite := range r.rewrites {
+		body = rewrite.regex.ReplaceAll(body, rewrite.replacement)
+	}
+
+	return body
+}
@@ -0,0 +1,312 @@
+package rewritebody
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.RewriteBody
+	}{
+		{
+			desc:   "no rewrite",
+			config: dynamic.RewriteBody{Response: true},
+		},
+		{
+			desc: "neither request nor response",
+			config: dynamic.RewriteBody{
+				Rewrites: []dynamic.BodyRewrite{{Regex: "foo", Replacement: "bar"}},
+			},
+		},
+		{
+			desc: "invalid regex",
+			config: dynamic.RewriteBody{
+				Rewrites: []dynamic.BodyRewrite{{Regex: "(foo", Replacement: "bar"}},
+				Response: true,
+			},
+		},
+		{
+			desc: "invalid template",
+			config: dynamic.RewriteBody{
+				Rewrites: []dynamic.BodyRewrite{{Regex: "foo", Replacement: "{{ .Host "}},
+				Response: true,
+			},
+		},
+		{
+			desc: "invalid content type",
+			config: dynamic.RewriteBody{
+				Rewrites:     []dynamic.BodyRewrite{{Regex: "foo", Replacement: "bar"}},
+				Response:     true,
+				ContentTypes: []string{"text/"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "rewritebody")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRewriteBody_response(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		config          dynamic.RewriteBody
+		contentType     string
+		contentEncoding string
+		status          int
+		chunks          []string
+		expectedBody    string
+	}{
+		{
+			desc: "static replacement",
+			config: dynamic.RewriteBody{
+				Rewrites: []dynamic.BodyRewrite{{Regex: "foo", Replacement: "bar"}},
+			},
+			contentType:  "text/html; charset=utf-8",
+			chunks:       []string{"foo baz foo"},
+			expectedBody: "bar baz bar",
+		},
+		{
+			desc: "replacement with capture groups",
+			config: dynamic.RewriteBody{
+				Rewrites: []dynamic.BodyRewrite{{Regex: `http://legacy\.local(/\S*)`, Replacement: "https://example.com${1}"}},
+			},
+			contentType:  "application/json",
+			chunks:       []string{`{"next":"http://legacy.local/page/2"}`},
+			expectedBody: `{"next":"https://example.com/page/2"}`,
+		},
+		{
+			desc: "template replacement",
+			config: dynamic.RewriteBody{
+				Rewrites: []dynamic.BodyRewrite{{Regex: `http://legacy\.local`, Replacement: "{{ .Scheme }}://{{ .Host }}"}},
+			},
+			contentType:  "text/html",
+			chunks:       []string{`<a href="http://legacy.local/foo">`},
+			expectedBody: `<a href="http://example.org/foo">`,
+		},
+		{
+			desc: "successive rewrites",
+			config: dynamic.RewriteBody{
+				Rewrites: []dynamic.BodyRewrite{
+					{Regex: "foo", Replacement: "bar"},
+					{Regex: "bar", Replacement: "baz"},
+				},
+			},
+			contentType:  "text/plain",
+			chunks:       []string{"foo"},
+			expectedBody: "baz",
+		},
+		{
+			desc: "match spanning chunks of a line",
+			config: dynamic.RewriteBody{
+				Rewrites: []dynamic.BodyRewrite{{Regex: "foobar", Replacement: "baz"}},
+			},
+			contentType:  "text/plain",
+			chunks:       []string{"a foo", "bar\nfoo", "bar\n"},
+			expectedBody: "a baz\nbaz\n",
+		},
+		{
+			desc: "not rewritten content type",
+			config: dynamic.RewriteBody{
+				Rewrites: []dynamic.BodyRewrite{{Regex: "foo", Replacement: "bar"}},
+			},
+			contentType:  "image/png",
+			chunks:       []string{"foo"},
+			expectedBody: "foo",
+		},
+		{
+			desc: "configured content types",
+			config: dynamic.RewriteBody{
+				Rewrites:     []dynamic.BodyRewrite{{Regex: "foo", Replacement: "bar"}},
+				ContentTypes: []string{"application/*"},
+			},
+			contentType:  "application/octet-stream",
+			chunks:       []string{"foo"},
+			expectedBody: "bar",
+		},
+		{
+			desc: "encoded body",
+			config: dynamic.RewriteBody{
+				Rewrites: []dynamic.BodyRewrite{{Regex: "foo", Replacement: "bar"}},
+			},
+			contentType:     "text/plain",
+			contentEncoding: "br",
+			chunks:          []string{"foo"},
+			expectedBody:    "foo",
+		},
+		{
+			desc: "error status",
+			config: dynamic.RewriteBody{
+				Rewrites: []dynamic.BodyRewrite{{Regex: "foo", Replacement: "bar"}},
+			},
+			contentType:  "text/plain",
+			status:       http.StatusNotFound,
+			chunks:       []string{"foo"},
+			expectedBody: "bar",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Empty(t, req.Header.Get("Accept-Encoding"))
+
+				length := 0
+				for _, chunk := range test.chunks {
+					length += len(chunk)
+				}
+
+				rw.Header().Set("Content-Type", test.contentType)
+				rw.Header().Set("Content-Length", strconv.Itoa(length))
+				if test.contentEncoding != "" {
+					rw.Header().Set("Content-Encoding", test.contentEncoding)
+				}
+
+				if test.status != 0 {
+					rw.WriteHeader(test.status)
+				}
+
+				for _, chunk := range test.chunks {
+					_, err := rw.Write([]byte(chunk))
+					require.NoError(t, err)
+				}
+			})
+
+			config := test.config
+			config.SetDefaults()
+
+			handler, err := New(context.Background(), next, config, "rewritebody")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.org/foo", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			expectedStatus := http.StatusOK
+			if test.status != 0 {
+				expectedStatus = test.status
+			}
+
+			assert.Equal(t, expectedStatus, rw.Code)
+			assert.Equal(t, test.expectedBody, rw.Body.String())
+
+			if test.expectedBody != strings.Join(test.chunks, "") {
+				assert.Empty(t, rw.Header().Get("Content-Length"))
+			}
+		})
+	}
+}
+
+func TestRewriteBody_responseFlush(t *testing.T) {
+	flushed := make(chan string)
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "text/event-stream")
+
+		_, err := rw.Write([]byte("data: foo"))
+		require.NoError(t, err)
+
+		rw.(http.Flusher).Flush()
+
+		flushed <- rw.(*rewriteWriter).rw.(*httptest.ResponseRecorder).Body.String()
+	})
+
+	handler, err := New(context.Background(), next, dynamic.RewriteBody{
+		Rewrites: []dynamic.BodyRewrite{{Regex: "foo", Replacement: "bar"}},
+		Response: true,
+	}, "rewritebody")
+	require.NoError(t, err)
+
+	go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+
+	assert.Equal(t, "data: bar", <-flushed)
+}
+
+func TestRewriteBody_request(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		contentType  string
+		body         string
+		chunked      bool
+		expectedBody string
+	}{
+		{
+			desc:         "rewritten body",
+			contentType:  "application/json",
+			body:         `{"url":"https://example.com/foo"}`,
+			expectedBody: `{"url":"http://legacy.local/foo"}`,
+		},
+		{
+			desc:         "rewritten chunked body",
+			contentType:  "application/json",
+			body:         `{"url":"https://example.com/foo"}`,
+			chunked:      true,
+			expectedBody: `{"url":"http://legacy.local/foo"}`,
+		},
+		{
+			desc:         "not rewritten content type",
+			contentType:  "application/octet-stream",
+			body:         "https://example.com",
+			expectedBody: "https://example.com",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwarded *http.Request
+			var forwardedBody string
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+
+				forwarded = req
+				forwardedBody = string(body)
+			})
+
+			handler, err := New(context.Background(), next, dynamic.RewriteBody{
+				Rewrites: []dynamic.BodyRewrite{{Regex: `https://example\.com`, Replacement: "http://legacy.local"}},
+				Request:  true,
+			}, "rewritebody")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader(test.body))
+			req.Header.Set("Content-Type", test.contentType)
+			if test.chunked {
+				req.ContentLength = -1
+				req.TransferEncoding = []string{"chunked"}
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.NotNil(t, forwarded)
+			assert.Equal(t, test.expectedBody, forwardedBody)
+
+			if test.body != test.expectedBody {
+				assert.Equal(t, int64(len(test.expectedBody)), forwarded.ContentLength)
+				assert.Equal(t, strconv.Itoa(len(test.expectedBody)), forwarded.Header.Get("Content-Length"))
+				assert.Empty(t, forwarded.TransferEncoding)
+			}
+		})
+	}
+}
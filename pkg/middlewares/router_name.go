@@ -0,0 +1,25 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/containous/alice"
+)
+
+type routerNameKey struct{}
+
+// WrapRouterName returns a constructor adding the name of the router handling the requests in their context.
+func WrapRouterName(routerName string) alice.Constructor {
+	return func(next http.Handler) (http.Handler, error) {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(rw, req.WithContext(context.WithValue(req.Context(), routerNameKey{}, routerName)))
+		}), nil
+	}
+}
+
+// GetRouterName returns the name of the router handling the request of the given context.
+func GetRouterName(ctx context.Context) string {
+	routerName, _ := ctx.Value(routerNameKey{}).(string)
+	return routerName
+}
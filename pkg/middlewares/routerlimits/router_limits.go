@@ -0,0 +1,81 @@
+package routerlimits
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/containous/alice"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/logs"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+)
+
+const typeName = "RouterLimits"
+
+// routerLimits is an internal middleware applying the responding timeouts and the request body size limit of a router.
+type routerLimits struct {
+	next                http.Handler
+	routerName          string
+	readTimeout         time.Duration
+	writeTimeout        time.Duration
+	maxRequestBodyBytes int64
+}
+
+// WrapHandler returns a constructor applying the limits of the given router.
+func WrapHandler(routerName string, router *dynamic.Router) alice.Constructor {
+	return func(next http.Handler) (http.Handler, error) {
+		return New(next, routerName, router), nil
+	}
+}
+
+// New creates a handler applying the limits of the given router, or returns the next handler when the router does not define any.
+func New(next http.Handler, routerName string, router *dynamic.Router) http.Handler {
+	l := &routerLimits{
+		next:                next,
+		routerName:          routerName,
+		maxRequestBodyBytes: router.MaxRequestBodyBytes,
+	}
+
+	if router.RespondingTimeouts != nil {
+		l.readTimeout = time.Duration(router.RespondingTimeouts.ReadTimeout)
+		l.writeTimeout = time.Duration(router.RespondingTimeouts.WriteTimeout)
+	}
+
+	if l.readTimeout <= 0 && l.writeTimeout <= 0 && l.maxRequestBodyBytes <= 0 {
+		return next
+	}
+
+	return l
+}
+
+func (l *routerLimits) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if l.readTimeout > 0 || l.writeTimeout > 0 {
+		logger := log.Ctx(req.Context()).With().Str(logs.RouterName, l.routerName).Str(logs.MiddlewareType, typeName).Logger()
+		rc := middlewares.GetResponseController(rw, req)
+
+		if l.readTimeout > 0 {
+			if err := rc.SetReadDeadline(time.Now().Add(l.readTimeout)); err != nil {
+				logger.Debug().Err(err).Msg("Unable to override the read timeout")
+			}
+		}
+
+		if l.writeTimeout > 0 {
+			if err := rc.SetWriteDeadline(time.Now().Add(l.writeTimeout)); err != nil {
+				logger.Debug().Err(err).Msg("Unable to override the write timeout")
+			}
+		}
+	}
+
+	if l.maxRequestBodyBytes > 0 {
+		if req.ContentLength > l.maxRequestBodyBytes {
+			http.Error(rw, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		// The bodies sent without length are limited while being read.
+		req.Body = http.MaxBytesReader(rw, req.Body, l.maxRequestBodyBytes)
+	}
+
+	l.next.ServeHTTP(rw, req)
+}
@@ -0,0 +1,113 @@
+package routerlimits
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_noLimits(t *testing.T) {
+	next := http.RedirectHandler("/", http.StatusFound)
+
+	handler := New(next, "router", &dynamic.Router{RespondingTimeouts: &dynamic.RouterRespondingTimeouts{}})
+
+	assert.Same(t, next, handler)
+}
+
+func TestRouterLimits_maxRequestBodyBytes(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		body           string
+		contentLength  int64
+		expectedStatus int
+	}{
+		{
+			desc:           "body within the limit",
+			body:           "12345",
+			contentLength:  5,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "body length over the limit",
+			body:           "123456",
+			contentLength:  6,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:           "body without length over the limit",
+			body:           "123456",
+			contentLength:  -1,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				_, err := io.ReadAll(req.Body)
+
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					rw.WriteHeader(http.StatusRequestEntityTooLarge)
+				}
+			})
+
+			handler := New(next, "router", &dynamic.Router{MaxRequestBodyBytes: 5})
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+			req.ContentLength = test.contentLength
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+		})
+	}
+}
+
+func TestRouterLimits_readTimeout(t *testing.T) {
+	readErr := make(chan error, 1)
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, err := io.ReadAll(req.Body)
+		readErr <- err
+	})
+
+	router := &dynamic.Router{
+		RespondingTimeouts: &dynamic.RouterRespondingTimeouts{ReadTimeout: ptypes.Duration(100 * time.Millisecond)},
+	}
+
+	server := httptest.NewUnstartedServer(New(next, "router", router))
+	server.Config.ReadTimeout = time.Minute
+	server.Start()
+	t.Cleanup(server.Close)
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	// The body is not sent entirely, for the router read timeout to elapse before the one of the server.
+	_, err = conn.Write([]byte("POST / HTTP/1.1\r\nHost: example.com\r\nContent-Length: 10\r\n\r\n12345"))
+	require.NoError(t, err)
+
+	select {
+	case err := <-readErr:
+		var netErr net.Error
+		require.ErrorAs(t, err, &netErr)
+		assert.True(t, netErr.Timeout())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the read timeout of the router did not apply")
+	}
+}
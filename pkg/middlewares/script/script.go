@@ -0,0 +1,219 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "Script"
+
+// maxNodes is the maximum size of the syntax tree of a script.
+const maxNodes = 10000
+
+type serviceBuilder interface {
+	BuildHTTP(ctx context.Context, serviceName string) (http.Handler, error)
+}
+
+type varsKey struct{}
+
+// script is a middleware running an expression for each request.
+type script struct {
+	next     http.Handler
+	name     string
+	program  *vm.Program
+	services map[string]http.Handler
+}
+
+// New creates a Script middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.Script, serviceBuilder serviceBuilder, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeName)
+	logger.Debug().Msg("Creating middleware")
+
+	if config.Source == "" {
+		return nil, errors.New("empty script source")
+	}
+
+	program, err := expr.Compile(config.Source, expr.Env(env{}), expr.MaxNodes(maxNodes))
+	if err != nil {
+		return nil, fmt.Errorf("compiling script: %w", err)
+	}
+
+	s := &script{
+		next:     next,
+		name:     name,
+		program:  program,
+		services: make(map[string]http.Handler),
+	}
+
+	for _, service := range config.Services {
+		handler, err := serviceBuilder.BuildHTTP(ctx, service)
+		if err != nil {
+			return nil, fmt.Errorf("building service %q: %w", service, err)
+		}
+
+		s.services[service] = handler
+	}
+
+	return s, nil
+}
+
+func (s *script) GetTracingInformation() (string, string, trace.SpanKind) {
+	return s.name, typeName, trace.SpanKindInternal
+}
+
+func (s *script) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), s.name, typeName)
+
+	// The variables are shared by the scripts handling the same request.
+	vars, ok := req.Context().Value(varsKey{}).(map[string]string)
+	if !ok {
+		vars = make(map[string]string)
+		req = req.WithContext(context.WithValue(req.Context(), varsKey{}, vars))
+	}
+
+	state := &state{responseHeader: make(http.Header)}
+
+	if _, err := expr.Run(s.program, newEnv(req, vars, state)); err != nil {
+		logger.Error().Err(err).Msg("Unable to run the script")
+		observability.SetStatusErrorf(req.Context(), "Unable to run the script: %v", err)
+
+		http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if state.status != 0 {
+		for name, values := range state.responseHeader {
+			rw.Header()[name] = values
+		}
+
+		rw.WriteHeader(state.status)
+		if _, err := rw.Write([]byte(state.body)); err != nil {
+			log.Ctx(req.Context()).Error().Err(err).Send()
+		}
+		return
+	}
+
+	next := s.next
+	if state.service != "" {
+		handler, ok := s.services[state.service]
+		if !ok {
+			logger.Error().Msgf("Service %q is not in the script services", state.service)
+			observability.SetStatusErrorf(req.Context(), "Service %q is not in the script services", state.service)
+
+			http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		next = handler
+	}
+
+	if len(state.responseHeader) > 0 {
+		rw = middlewares.NewResponseModifier(rw, req, func(resp *http.Response) error {
+			for name, values := range state.responseHeader {
+				resp.Header[name] = slices.Clone(values)
+			}
+			return nil
+		})
+	}
+
+	next.ServeHTTP(rw, req)
+}
+
+// state holds the effects of a script run which are applied once it has completed.
+type state struct {
+	status         int
+	body           string
+	service        string
+	responseHeader http.Header
+}
+
+// env is the environment of the scripts.
+type env struct {
+	Method   string            `expr:"method"`
+	Host     string            `expr:"host"`
+	Path     string            `expr:"path"`
+	ClientIP string            `expr:"clientIP"`
+	Vars     map[string]string `expr:"vars"`
+
+	Header func(name string) string `expr:"header"`
+	Query  func(name string) string `expr:"query"`
+	Cookie func(name string) string `expr:"cookie"`
+
+	SetHeader         func(name, value string) bool      `expr:"setHeader"`
+	DelHeader         func(name string) bool             `expr:"delHeader"`
+	SetPath           func(path string) bool             `expr:"setPath"`
+	SetResponseHeader func(name, value string) bool      `expr:"setResponseHeader"`
+	SetVar            func(name, value string) bool      `expr:"setVar"`
+	Respond           func(status int, body string) bool `expr:"respond"`
+	UseService        func(name string) bool             `expr:"useService"`
+}
+
+func newEnv(req *http.Request, vars map[string]string, state *state) env {
+	clientIP, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		clientIP = req.RemoteAddr
+	}
+
+	return env{
+		Method:   req.Method,
+		Host:     req.Host,
+		Path:     req.URL.Path,
+		ClientIP: clientIP,
+		Vars:     vars,
+
+		Header: req.Header.Get,
+		Query: func(name string) string {
+			return req.URL.Query().Get(name)
+		},
+		Cookie: func(name string) string {
+			if cookie, err := req.Cookie(name); err == nil {
+				return cookie.Value
+			}
+			return ""
+		},
+
+		SetHeader: func(name, value string) bool {
+			req.Header.Set(name, value)
+			return true
+		},
+		DelHeader: func(name string) bool {
+			req.Header.Del(name)
+			return true
+		},
+		SetPath: func(path string) bool {
+			req.URL.Path = path
+			req.URL.RawPath = ""
+			req.RequestURI = req.URL.RequestURI()
+			return true
+		},
+		SetResponseHeader: func(name, value string) bool {
+			state.responseHeader.Set(name, value)
+			return true
+		},
+		SetVar: func(name, value string) bool {
+			vars[name] = value
+			return true
+		},
+		Respond: func(status int, body string) bool {
+			state.status = status
+			state.body = body
+			return true
+		},
+		UseService: func(name string) bool {
+			state.service = name
+			return true
+		},
+	}
+}
@@ -0,0 +1,216 @@
+package script
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.Script
+	}{
+		{
+			desc:   "empty source",
+			config: dynamic.Script{},
+		},
+		{
+			desc:   "syntax error",
+			config: dynamic.Script{Source: `setHeader("X-Foo",`},
+		},
+		{
+			desc:   "unknown function",
+			config: dynamic.Script{Source: `exec("rm")`},
+		},
+		{
+			desc:   "invalid argument type",
+			config: dynamic.Script{Source: `respond("403", "Forbidden")`},
+		},
+		{
+			desc:   "unknown service",
+			config: dynamic.Script{Source: `useService("unknown")`, Services: []string{"unknown"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, &mockServiceBuilder{}, "script")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestScript_ServeHTTP(t *testing.T) {
+	testCases := []struct {
+		desc                   string
+		source                 string
+		path                   string
+		header                 http.Header
+		expectedStatus         int
+		expectedBody           string
+		expectedHeader         http.Header
+		expectedPath           string
+		expectedResponseHeader http.Header
+	}{
+		{
+			desc:           "no effect",
+			source:         `true`,
+			path:           "/foo",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "next",
+			expectedPath:   "/foo",
+		},
+		{
+			desc:           "set request header",
+			source:         `setHeader("X-Tenant", query("tenant")); delHeader("X-Debug")`,
+			path:           "/foo?tenant=acme",
+			header:         http.Header{"X-Debug": {"true"}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "next",
+			expectedHeader: http.Header{"X-Tenant": {"acme"}},
+			expectedPath:   "/foo",
+		},
+		{
+			desc:           "conditional path rewrite",
+			source:         `if header("X-Version") == "2" { setPath("/v2" + path) } else { true }`,
+			path:           "/foo",
+			header:         http.Header{"X-Version": {"2"}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "next",
+			expectedPath:   "/v2/foo",
+		},
+		{
+			desc:           "short circuit",
+			source:         `header("Authorization") == "" && respond(401, "Unauthorized") && setResponseHeader("WWW-Authenticate", "Bearer")`,
+			path:           "/foo",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "Unauthorized",
+			expectedResponseHeader: http.Header{
+				"Www-Authenticate": {"Bearer"},
+			},
+		},
+		{
+			desc:           "response header",
+			source:         `setResponseHeader("X-Served-By", host)`,
+			path:           "/foo",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "next",
+			expectedPath:   "/foo",
+			expectedResponseHeader: http.Header{
+				"X-Served-By": {"example.com"},
+			},
+		},
+		{
+			desc:           "select service",
+			source:         `path startsWith "/beta" && useService("beta")`,
+			path:           "/beta/foo",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "beta",
+			expectedPath:   "/beta/foo",
+		},
+		{
+			desc:           "select service not in the script services",
+			source:         `useService("other")`,
+			path:           "/foo",
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Internal Server Error\n",
+		},
+		{
+			desc:           "runtime error",
+			source:         `int(header("X-Count")) > 1`,
+			path:           "/foo",
+			header:         http.Header{"X-Count": {"foo"}},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Internal Server Error\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwarded *http.Request
+			newHandler := func(name string) http.Handler {
+				return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					forwarded = req
+					rw.Header().Set("X-Served-By", "backend")
+					_, _ = rw.Write([]byte(name))
+				})
+			}
+
+			serviceBuilder := &mockServiceBuilder{handlers: map[string]http.Handler{"beta": newHandler("beta")}}
+
+			handler, err := New(context.Background(), newHandler("next"), dynamic.Script{Source: test.source, Services: []string{"beta"}}, serviceBuilder, "script")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com"+test.path, nil)
+			for name, values := range test.header {
+				req.Header[name] = values
+			}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+			assert.Equal(t, test.expectedBody, rw.Body.String())
+
+			for name, values := range test.expectedResponseHeader {
+				assert.Equal(t, values, rw.Header().Values(name))
+			}
+
+			if test.expectedPath == "" {
+				assert.Nil(t, forwarded)
+				return
+			}
+
+			require.NotNil(t, forwarded)
+			assert.Equal(t, test.expectedPath, forwarded.URL.Path)
+			assert.Empty(t, forwarded.Header.Get("X-Debug"))
+
+			for name, values := range test.expectedHeader {
+				assert.Equal(t, values, forwarded.Header.Values(name))
+			}
+		})
+	}
+}
+
+func TestScript_sharedVariables(t *testing.T) {
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	second, err := New(context.Background(), next, dynamic.Script{Source: `setHeader("X-Tier", vars.tier)`}, &mockServiceBuilder{}, "second")
+	require.NoError(t, err)
+
+	first, err := New(context.Background(), second, dynamic.Script{Source: `setVar("tier", cookie("plan") == "pro" ? "gold" : "silver")`}, &mockServiceBuilder{}, "first")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.AddCookie(&http.Cookie{Name: "plan", Value: "pro"})
+
+	first.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "gold", req.Header.Get("X-Tier"))
+}
+
+type mockServiceBuilder struct {
+	handlers map[string]http.Handler
+}
+
+func (m *mockServiceBuilder) BuildHTTP(_ context.Context, serviceName string) (http.Handler, error) {
+	handler, ok := m.handlers[serviceName]
+	if !ok {
+		return nil, fmt.Errorf("service %q not found", serviceName)
+	}
+
+	return handler, nil
+}
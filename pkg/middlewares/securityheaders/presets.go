@@ -0,0 +1,45 @@
+package securityheaders
+
+const (
+	stsHeader           = "Strict-Transport-Security"
+	cspHeader           = "Content-Security-Policy"
+	cspReportOnlyHeader = "Content-Security-Policy-Report-Only"
+)
+
+// header is a response header of a preset.
+type header struct {
+	name  string
+	value string
+}
+
+// directive is a Content-Security-Policy directive of a preset.
+type directive struct {
+	name  string
+	value string
+}
+
+// preset is a versioned set of security headers.
+// A released preset must never change, for the responses not to change when Traefik is upgraded,
+// the changes being made in a new version.
+type preset struct {
+	headers               []header
+	contentSecurityPolicy []directive
+}
+
+var presets = map[string]preset{
+	"v1": {
+		headers: []header{
+			{name: stsHeader, value: "max-age=31536000; includeSubDomains"},
+			{name: "X-Content-Type-Options", value: "nosniff"},
+			{name: "Referrer-Policy", value: "strict-origin-when-cross-origin"},
+			{name: "Permissions-Policy", value: "camera=(), geolocation=(), microphone=(), payment=(), usb=()"},
+		},
+		contentSecurityPolicy: []directive{
+			{name: "default-src", value: "'self'"},
+			{name: "base-uri", value: "'self'"},
+			{name: "form-action", value: "'self'"},
+			{name: "frame-ancestors", value: "'none'"},
+			{name: "object-src", value: "'none'"},
+		},
+	},
+}
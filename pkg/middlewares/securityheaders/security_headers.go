@@ -0,0 +1,198 @@
+package securityheaders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/http/httpguts"
+)
+
+const (
+	typeName      = "SecurityHeaders"
+	defaultPreset = "v1"
+)
+
+// securityHeaders is a middleware adding the response headers of a preset of security headers.
+type securityHeaders struct {
+	next    http.Handler
+	name    string
+	headers []header
+	// sts is the Strict-Transport-Security header value, which is only sent over HTTPS.
+	sts string
+}
+
+// New creates a SecurityHeaders middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.SecurityHeaders, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	presetName := config.Preset
+	if presetName == "" {
+		presetName = defaultPreset
+	}
+
+	p, ok := presets[presetName]
+	if !ok {
+		return nil, fmt.Errorf("unknown preset %q", presetName)
+	}
+
+	csp, err := buildContentSecurityPolicy(p.contentSecurityPolicy, config.ContentSecurityPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contentSecurityPolicy: %w", err)
+	}
+
+	headers := append(slices.Clone(p.headers), header{name: cspHeader, value: csp})
+
+	headers, err = overrideHeaders(headers, config.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid headers: %w", err)
+	}
+
+	s := &securityHeaders{
+		next: next,
+		name: name,
+	}
+
+	for _, h := range headers {
+		switch {
+		case h.name == stsHeader:
+			s.sts = h.value
+			continue
+		case h.name == cspHeader && config.ContentSecurityPolicyReportOnly:
+			h.name = cspReportOnlyHeader
+		}
+
+		s.headers = append(s.headers, h)
+	}
+
+	return s, nil
+}
+
+func (s *securityHeaders) GetTracingInformation() (string, string, trace.SpanKind) {
+	return s.name, typeName, trace.SpanKindInternal
+}
+
+func (s *securityHeaders) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	s.next.ServeHTTP(middlewares.NewResponseModifier(rw, req, func(res *http.Response) error {
+		for _, h := range s.headers {
+			res.Header.Set(h.name, h.value)
+		}
+
+		if s.sts != "" && isHTTPS(req) {
+			res.Header.Set(stsHeader, s.sts)
+		}
+
+		return nil
+	}), req)
+}
+
+// isHTTPS reports whether the request was received over HTTPS,
+// directly or by a proxy trusted by the entry point.
+func isHTTPS(req *http.Request) bool {
+	return req.TLS != nil || strings.EqualFold(req.Header.Get("X-Forwarded-Proto"), "https")
+}
+
+// buildContentSecurityPolicy renders the Content-Security-Policy template of a preset,
+// the directives being overridden or removed by the given ones, and the other given directives appended in name order.
+func buildContentSecurityPolicy(template []directive, overrides map[string]string) (string, error) {
+	directives := make(map[string]string, len(overrides))
+	for name, value := range overrides {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !isDirectiveName(name) {
+			return "", fmt.Errorf("invalid directive name %q", name)
+		}
+
+		value = strings.TrimSpace(value)
+		if strings.ContainsAny(value, ";,") || !httpguts.ValidHeaderFieldValue(value) {
+			return "", fmt.Errorf("invalid value %q of directive %q", value, name)
+		}
+
+		directives[name] = value
+	}
+
+	var policy []string
+	for _, d := range template {
+		value, ok := directives[d.name]
+		if !ok {
+			value = d.value
+		}
+		delete(directives, d.name)
+
+		if value != "" {
+			policy = append(policy, d.name+" "+value)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(directives)) {
+		if directives[name] != "" {
+			policy = append(policy, name+" "+directives[name])
+		}
+	}
+
+	return strings.Join(policy, "; "), nil
+}
+
+// isDirectiveName reports whether the given name is a lower-cased Content-Security-Policy directive name.
+func isDirectiveName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// overrideHeaders overrides or removes the given preset headers by the given ones,
+// the other given headers being appended in name order.
+func overrideHeaders(headers []header, overrides map[string]string) ([]header, error) {
+	values := make(map[string]string, len(overrides))
+	for name, value := range overrides {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return nil, fmt.Errorf("invalid header name %q", name)
+		}
+
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return nil, fmt.Errorf("invalid value %q of header %q", value, name)
+		}
+
+		name = http.CanonicalHeaderKey(name)
+		if name == cspReportOnlyHeader {
+			return nil, errors.New("the Content-Security-Policy-Report-Only header is set by the contentSecurityPolicyReportOnly option")
+		}
+
+		values[name] = strings.TrimSpace(value)
+	}
+
+	var result []header
+	for _, h := range headers {
+		value, ok := values[h.name]
+		if !ok {
+			value = h.value
+		}
+		delete(values, h.name)
+
+		if value != "" {
+			result = append(result, header{name: h.name, value: value})
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if values[name] != "" {
+			result = append(result, header{name: name, value: values[name]})
+		}
+	}
+
+	return result, nil
+}
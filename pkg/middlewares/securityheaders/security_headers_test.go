@@ -0,0 +1,185 @@
+package securityheaders
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.SecurityHeaders
+	}{
+		{
+			desc:   "unknown preset",
+			config: dynamic.SecurityHeaders{Preset: "v0"},
+		},
+		{
+			desc:   "invalid directive name",
+			config: dynamic.SecurityHeaders{ContentSecurityPolicy: map[string]string{"script src": "'self'"}},
+		},
+		{
+			desc:   "directive value with a separator",
+			config: dynamic.SecurityHeaders{ContentSecurityPolicy: map[string]string{"script-src": "'self'; object-src *"}},
+		},
+		{
+			desc:   "invalid header name",
+			config: dynamic.SecurityHeaders{Headers: map[string]string{"X-Foo Bar": "baz"}},
+		},
+		{
+			desc:   "invalid header value",
+			config: dynamic.SecurityHeaders{Headers: map[string]string{"X-Foo": "bar\nbaz"}},
+		},
+		{
+			desc:   "report-only header",
+			config: dynamic.SecurityHeaders{Headers: map[string]string{"Content-Security-Policy-Report-Only": "default-src *"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "securityHeaders")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		config          dynamic.SecurityHeaders
+		https           bool
+		serviceHeaders  map[string]string
+		expectedHeaders map[string]string
+	}{
+		{
+			desc:  "default preset",
+			https: true,
+			expectedHeaders: map[string]string{
+				"Strict-Transport-Security":           "max-age=31536000; includeSubDomains",
+				"Content-Security-Policy":             "default-src 'self'; base-uri 'self'; form-action 'self'; frame-ancestors 'none'; object-src 'none'",
+				"Content-Security-Policy-Report-Only": "",
+				"X-Content-Type-Options":              "nosniff",
+				"Referrer-Policy":                     "strict-origin-when-cross-origin",
+				"Permissions-Policy":                  "camera=(), geolocation=(), microphone=(), payment=(), usb=()",
+			},
+		},
+		{
+			desc: "no Strict-Transport-Security over HTTP",
+			expectedHeaders: map[string]string{
+				"Strict-Transport-Security": "",
+				"X-Content-Type-Options":    "nosniff",
+			},
+		},
+		{
+			desc:           "service headers overridden",
+			serviceHeaders: map[string]string{"Referrer-Policy": "unsafe-url", "X-App": "foo"},
+			expectedHeaders: map[string]string{
+				"Referrer-Policy": "strict-origin-when-cross-origin",
+				"X-App":           "foo",
+			},
+		},
+		{
+			desc: "directives overridden",
+			config: dynamic.SecurityHeaders{
+				ContentSecurityPolicy: map[string]string{
+					"Default-Src": "'self' cdn.example.com",
+					"object-src":  "",
+					"script-src":  "'self' 'nonce-abc'",
+					"img-src":     "*",
+				},
+			},
+			expectedHeaders: map[string]string{
+				"Content-Security-Policy": "default-src 'self' cdn.example.com; base-uri 'self'; form-action 'self'; frame-ancestors 'none'; img-src *; script-src 'self' 'nonce-abc'",
+			},
+		},
+		{
+			desc: "report-only policy",
+			config: dynamic.SecurityHeaders{
+				ContentSecurityPolicy:           map[string]string{"report-uri": "/csp-reports"},
+				ContentSecurityPolicyReportOnly: true,
+			},
+			expectedHeaders: map[string]string{
+				"Content-Security-Policy":             "",
+				"Content-Security-Policy-Report-Only": "default-src 'self'; base-uri 'self'; form-action 'self'; frame-ancestors 'none'; object-src 'none'; report-uri /csp-reports",
+			},
+		},
+		{
+			desc:  "headers overridden",
+			https: true,
+			config: dynamic.SecurityHeaders{
+				Headers: map[string]string{
+					"strict-transport-security": "max-age=63072000; includeSubDomains; preload",
+					"Permissions-Policy":        "",
+					"X-Frame-Options":           "DENY",
+				},
+			},
+			expectedHeaders: map[string]string{
+				"Strict-Transport-Security": "max-age=63072000; includeSubDomains; preload",
+				"Permissions-Policy":        "",
+				"X-Frame-Options":           "DENY",
+			},
+		},
+		{
+			desc: "policy overridden",
+			config: dynamic.SecurityHeaders{
+				ContentSecurityPolicy: map[string]string{"img-src": "*"},
+				Headers:               map[string]string{"Content-Security-Policy": "default-src *"},
+			},
+			expectedHeaders: map[string]string{
+				"Content-Security-Policy": "default-src *",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				for name, value := range test.serviceHeaders {
+					rw.Header().Set(name, value)
+				}
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			handler, err := New(context.Background(), next, test.config, "securityHeaders")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			if test.https {
+				req.TLS = &tls.ConnectionState{}
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			for name, value := range test.expectedHeaders {
+				assert.Equal(t, value, recorder.Header().Get(name), name)
+			}
+		})
+	}
+}
+
+func TestSecurityHeaders_forwardedHTTPS(t *testing.T) {
+	handler, err := New(context.Background(), http.NotFoundHandler(), dynamic.SecurityHeaders{}, "securityHeaders")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, "max-age=31536000; includeSubDomains", recorder.Header().Get("Strict-Transport-Security"))
+}
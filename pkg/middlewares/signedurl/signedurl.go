@@ -0,0 +1,270 @@
+package signedurl
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "SignedURL"
+
+const (
+	defaultAlgorithm      = "sha256"
+	defaultEncoding       = "hex"
+	defaultSignatureParam = "signature"
+	defaultExpiresParam   = "expires"
+)
+
+var algorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+var decoders = map[string]func(string) ([]byte, error){
+	"hex": hex.DecodeString,
+	"base64url": func(s string) ([]byte, error) {
+		return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	},
+}
+
+type key struct {
+	id     string
+	secret []byte
+}
+
+// signedURL is a middleware rejecting the requests whose URL is not signed with one of the keys, or has expired.
+type signedURL struct {
+	next           http.Handler
+	name           string
+	keys           []key
+	keyIDParam     string
+	hash           func() hash.Hash
+	decode         func(string) ([]byte, error)
+	signatureParam string
+	expiresParam   string
+	maxValidity    time.Duration
+	stripParams    bool
+}
+
+// New creates a signed URL middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.SignedURL, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if len(config.Keys) == 0 {
+		return nil, errors.New("at least one key must be defined")
+	}
+
+	if config.MaxValidity < 0 {
+		return nil, fmt.Errorf("negative maxValidity %s", time.Duration(config.MaxValidity))
+	}
+
+	s := &signedURL{
+		next:           next,
+		name:           name,
+		keyIDParam:     config.KeyIDParam,
+		signatureParam: config.SignatureParam,
+		expiresParam:   config.ExpiresParam,
+		maxValidity:    time.Duration(config.MaxValidity),
+		stripParams:    config.StripParams,
+	}
+
+	for _, k := range config.Keys {
+		if k.Secret == "" {
+			return nil, fmt.Errorf("key %q has an empty secret", k.ID)
+		}
+
+		s.keys = append(s.keys, key{id: k.ID, secret: []byte(k.Secret)})
+	}
+
+	algorithm := config.Algorithm
+	if algorithm == "" {
+		algorithm = defaultAlgorithm
+	}
+
+	var ok bool
+	if s.hash, ok = algorithms[algorithm]; !ok {
+		return nil, fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+
+	encoding := config.Encoding
+	if encoding == "" {
+		encoding = defaultEncoding
+	}
+
+	if s.decode, ok = decoders[encoding]; !ok {
+		return nil, fmt.Errorf("unsupported encoding %q", encoding)
+	}
+
+	if s.signatureParam == "" {
+		s.signatureParam = defaultSignatureParam
+	}
+
+	if s.expiresParam == "" {
+		s.expiresParam = defaultExpiresParam
+	}
+
+	if s.signatureParam == s.expiresParam || s.signatureParam == s.keyIDParam || s.expiresParam == s.keyIDParam {
+		return nil, errors.New("signatureParam, expiresParam and keyIDParam must be different")
+	}
+
+	return s, nil
+}
+
+func (s *signedURL) GetTracingInformation() (string, string, trace.SpanKind) {
+	return s.name, typeName, trace.SpanKindInternal
+}
+
+func (s *signedURL) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	if err := s.checkExpiry(query.Get(s.expiresParam)); err != nil {
+		s.reject(rw, req, err)
+		return
+	}
+
+	signatures := s.signatures(query[s.signatureParam])
+	if len(signatures) == 0 {
+		s.reject(rw, req, errors.New("missing signature"))
+		return
+	}
+
+	keys := s.keys
+	if s.keyIDParam != "" {
+		if id := query.Get(s.keyIDParam); id != "" {
+			keys = s.keysWithID(id)
+		}
+	}
+
+	message := []byte(req.URL.EscapedPath() + "?" + withoutParams(req.URL.RawQuery, s.signatureParam))
+
+	for _, k := range keys {
+		mac := hmac.New(s.hash, k.secret)
+		mac.Write(message)
+		expected := mac.Sum(nil)
+
+		for _, signature := range signatures {
+			if hmac.Equal(signature, expected) {
+				s.forward(rw, req)
+				return
+			}
+		}
+	}
+
+	s.reject(rw, req, errors.New("invalid signature"))
+}
+
+func (s *signedURL) forward(rw http.ResponseWriter, req *http.Request) {
+	if s.stripParams {
+		params := []string{s.signatureParam, s.expiresParam}
+		if s.keyIDParam != "" {
+			params = append(params, s.keyIDParam)
+		}
+
+		req.URL.RawQuery = withoutParams(req.URL.RawQuery, params...)
+		req.RequestURI = req.URL.RequestURI()
+	}
+
+	s.next.ServeHTTP(rw, req)
+}
+
+// checkExpiry checks that the URL has not expired, and that its expiry time is within the maximum validity.
+func (s *signedURL) checkExpiry(value string) error {
+	if value == "" {
+		return errors.New("missing expiry time")
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid expiry time %q", value)
+	}
+
+	validity := time.Until(time.Unix(seconds, 0))
+	if validity <= 0 {
+		return fmt.Errorf("URL has expired at %q", value)
+	}
+
+	if s.maxValidity > 0 && validity > s.maxValidity {
+		return fmt.Errorf("expiry time %q exceeds the maximum validity", value)
+	}
+
+	return nil
+}
+
+// signatures returns the decoded signatures of the query parameter values.
+func (s *signedURL) signatures(values []string) [][]byte {
+	var signatures [][]byte
+	for _, value := range values {
+		signature, err := s.decode(value)
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+
+		signatures = append(signatures, signature)
+	}
+
+	return signatures
+}
+
+func (s *signedURL) keysWithID(id string) []key {
+	var keys []key
+	for _, k := range s.keys {
+		if k.id == id {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+func (s *signedURL) reject(rw http.ResponseWriter, req *http.Request, reason error) {
+	logger := middlewares.GetLogger(req.Context(), s.name, typeName)
+	logger.Debug().Err(reason).Msg("Rejecting request")
+
+	observability.SetStatusErrorf(req.Context(), "Rejecting request: %v", reason)
+
+	rw.WriteHeader(http.StatusForbidden)
+	if _, err := rw.Write([]byte(http.StatusText(http.StatusForbidden))); err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Send()
+	}
+}
+
+// withoutParams returns the raw query without the given parameters, keeping the other parameters as is, in their order.
+func withoutParams(rawQuery string, names ...string) string {
+	var kept []string
+	for _, param := range strings.Split(rawQuery, "&") {
+		if param == "" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(name); err == nil {
+			name = unescaped
+		}
+
+		if !slices.Contains(names, name) {
+			kept = append(kept, param)
+		}
+	}
+
+	return strings.Join(kept, "&")
+}
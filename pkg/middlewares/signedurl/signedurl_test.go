@@ -0,0 +1,269 @@
+package signedurl
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.SignedURL
+	}{
+		{
+			desc:   "no key",
+			config: dynamic.SignedURL{},
+		},
+		{
+			desc:   "empty secret",
+			config: dynamic.SignedURL{Keys: []dynamic.SignedURLKey{{ID: "v1"}}},
+		},
+		{
+			desc:   "unsupported algorithm",
+			config: dynamic.SignedURL{Keys: []dynamic.SignedURLKey{{Secret: "secret"}}, Algorithm: "md5"},
+		},
+		{
+			desc:   "unsupported encoding",
+			config: dynamic.SignedURL{Keys: []dynamic.SignedURLKey{{Secret: "secret"}}, Encoding: "base32"},
+		},
+		{
+			desc:   "negative max validity",
+			config: dynamic.SignedURL{Keys: []dynamic.SignedURLKey{{Secret: "secret"}}, MaxValidity: ptypes.Duration(-time.Hour)},
+		},
+		{
+			desc:   "same parameters",
+			config: dynamic.SignedURL{Keys: []dynamic.SignedURLKey{{Secret: "secret"}}, KeyIDParam: "expires"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "signedurl")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSignedURL(t *testing.T) {
+	config := dynamic.SignedURL{
+		Keys: []dynamic.SignedURLKey{
+			{ID: "v2", Secret: "new-secret"},
+			{ID: "v1", Secret: "old-secret"},
+		},
+		KeyIDParam:  "keyId",
+		MaxValidity: ptypes.Duration(24 * time.Hour),
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	expired := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+	tooLate := strconv.FormatInt(time.Now().Add(48*time.Hour).Unix(), 10)
+
+	testCases := []struct {
+		desc           string
+		target         string
+		expectedStatus int
+	}{
+		{
+			desc:           "signed with the current key",
+			target:         signedTarget("new-secret", "/videos/intro.mp4?expires="+expires),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "signed with the previous key",
+			target:         signedTarget("old-secret", "/videos/intro.mp4?expires="+expires),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "signed with the identified key",
+			target:         signedTarget("old-secret", "/videos/intro.mp4?expires="+expires+"&keyId=v1"),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "signed with another key than the identified one",
+			target:         signedTarget("old-secret", "/videos/intro.mp4?expires="+expires+"&keyId=v2"),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "signed with an unknown key",
+			target:         signedTarget("unknown", "/videos/intro.mp4?expires="+expires),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "signed with other query parameters",
+			target:         signedTarget("new-secret", "/videos/intro.mp4?quality=hd&expires="+expires+"&start=10"),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "tampered path",
+			target:         strings.Replace(signedTarget("new-secret", "/videos/intro.mp4?expires="+expires), "intro", "outro", 1),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "tampered expiry time",
+			target:         strings.Replace(signedTarget("new-secret", "/videos/intro.mp4?expires="+expires), expires, tooLate, 1),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "added query parameter",
+			target:         signedTarget("new-secret", "/videos/intro.mp4?expires="+expires) + "&quality=hd",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "expired",
+			target:         signedTarget("new-secret", "/videos/intro.mp4?expires="+expired),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "expiry time beyond the maximum validity",
+			target:         signedTarget("new-secret", "/videos/intro.mp4?expires="+tooLate),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "missing expiry time",
+			target:         signedTarget("new-secret", "/videos/intro.mp4?quality=hd"),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "invalid expiry time",
+			target:         signedTarget("new-secret", "/videos/intro.mp4?expires=tomorrow"),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "missing signature",
+			target:         "/videos/intro.mp4?expires=" + expires,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "invalid signature encoding",
+			target:         "/videos/intro.mp4?expires=" + expires + "&signature=zz",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			}), config, "signedurl")
+			require.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, test.target, nil))
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+		})
+	}
+}
+
+func TestSignedURL_base64url(t *testing.T) {
+	expires := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	path := "/downloads/report.pdf?expires=" + expires + "&sig_key=v1"
+
+	handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}), dynamic.SignedURL{
+		Keys:           []dynamic.SignedURLKey{{ID: "v1", Secret: "secret"}},
+		KeyIDParam:     "sig_key",
+		Encoding:       "base64url",
+		SignatureParam: "sig",
+	}, "signedurl")
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, path+"&sig="+base64.RawURLEncoding.EncodeToString(sign("secret", path)), nil))
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+}
+
+func TestSignedURL_stripParams(t *testing.T) {
+	expires := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+
+	var forwarded *http.Request
+	handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwarded = req
+		rw.WriteHeader(http.StatusOK)
+	}), dynamic.SignedURL{
+		Keys:        []dynamic.SignedURLKey{{ID: "v1", Secret: "secret"}},
+		KeyIDParam:  "keyId",
+		StripParams: true,
+	}, "signedurl")
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, signedTarget("secret", "/videos/intro.mp4?quality=hd&expires="+expires+"&keyId=v1"), nil))
+
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.NotNil(t, forwarded)
+	assert.Equal(t, "quality=hd", forwarded.URL.RawQuery)
+	assert.Equal(t, "/videos/intro.mp4?quality=hd", forwarded.RequestURI)
+}
+
+func TestWithoutParams(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rawQuery string
+		names    []string
+		expected string
+	}{
+		{
+			desc:     "empty query",
+			names:    []string{"signature"},
+			expected: "",
+		},
+		{
+			desc:     "keeps the order of the other parameters",
+			rawQuery: "b=2&signature=abc&a=1",
+			names:    []string{"signature"},
+			expected: "b=2&a=1",
+		},
+		{
+			desc:     "removes the repeated and escaped parameters",
+			rawQuery: "signature=abc&sig%6Eature=def&a=1%202",
+			names:    []string{"signature"},
+			expected: "a=1%202",
+		},
+		{
+			desc:     "removes several parameters",
+			rawQuery: "expires=1&a=&signature=abc&keyId",
+			names:    []string{"signature", "expires", "keyId"},
+			expected: "a=",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, withoutParams(test.rawQuery, test.names...))
+		})
+	}
+}
+
+// signedTarget returns the given target with its hex-encoded signature appended.
+func signedTarget(secret, target string) string {
+	return target + "&signature=" + hex.EncodeToString(sign(secret, target))
+}
+
+func sign(secret, message string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
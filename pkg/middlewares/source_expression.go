@@ -0,0 +1,130 @@
+package middlewares
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/traefik/traefik/v3/pkg/ip"
+	"github.com/vulcand/oxy/v2/utils"
+	"github.com/vulcand/predicate"
+)
+
+// sourceFuncs are the values of a request making its source, as parsed from a source expression.
+type sourceFuncs []func(req *http.Request) string
+
+// newSourceExpressionExtractor returns the SourceExtractor of the given expression,
+// made of one or several value functions joined by the && operator, e.g. Header(`X-Tenant`) && Query(`plan`).
+func newSourceExpressionExtractor(expression string) (utils.SourceExtractor, error) {
+	parser, err := predicate.NewParser(predicate.Def{
+		Operators: predicate.Operators{
+			AND: func(left, right sourceFuncs) sourceFuncs {
+				return append(append(sourceFuncs{}, left...), right...)
+			},
+		},
+		Functions: map[string]interface{}{
+			"ClientIP": func() sourceFuncs {
+				strategy := &ip.RemoteAddrStrategy{}
+				return sourceFuncs{strategy.GetIP}
+			},
+			"Host": func() sourceFuncs {
+				return sourceFuncs{func(req *http.Request) string { return req.Host }}
+			},
+			"Header": func(name string) sourceFuncs {
+				return sourceFuncs{func(req *http.Request) string { return req.Header.Get(name) }}
+			},
+			"Query": func(name string) sourceFuncs {
+				return sourceFuncs{func(req *http.Request) string { return req.URL.Query().Get(name) }}
+			},
+			"Cookie": func(name string) sourceFuncs {
+				return sourceFuncs{func(req *http.Request) string {
+					if cookie, err := req.Cookie(name); err == nil {
+						return cookie.Value
+					}
+					return ""
+				}}
+			},
+			"JWTClaim": func(name string) sourceFuncs {
+				return sourceFuncs{func(req *http.Request) string { return jwtClaim(req, name) }}
+			},
+			"ClientCertSubject": func() sourceFuncs {
+				return sourceFuncs{func(req *http.Request) string {
+					if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+						return ""
+					}
+					return req.TLS.PeerCertificates[0].Subject.String()
+				}}
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := parser.Parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("parsing source expression %q: %w", expression, err)
+	}
+
+	funcs, ok := parsed.(sourceFuncs)
+	if !ok {
+		return nil, fmt.Errorf("invalid source expression %q", expression)
+	}
+
+	if len(funcs) == 1 {
+		return utils.ExtractorFunc(func(req *http.Request) (string, int64, error) {
+			return funcs[0](req), 1, nil
+		}), nil
+	}
+
+	return utils.ExtractorFunc(func(req *http.Request) (string, int64, error) {
+		// The values are quoted, for the sources to be unambiguous.
+		values := make([]string, len(funcs))
+		for i, fn := range funcs {
+			values[i] = strconv.Quote(fn(req))
+		}
+
+		return strings.Join(values, ","), 1, nil
+	}), nil
+}
+
+// jwtClaim returns the value of the given claim of the bearer token of the request.
+// The signature of the token is not verified,
+// which is left to an authentication middleware placed before the one using the claim.
+func jwtClaim(req *http.Request, name string) string {
+	scheme, token, ok := strings.Cut(req.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+
+	var claims map[string]interface{}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+
+	switch value := claims[name].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+}
@@ -0,0 +1,124 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestGetSourceExtractor_expression(t *testing.T) {
+	token := "header." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"alice","tenant":{"id":42}}`)) + ".signature"
+
+	testCases := []struct {
+		desc           string
+		expression     string
+		expectedSource string
+		expectedError  bool
+	}{
+		{
+			desc:           "header",
+			expression:     "Header(`X-API-Key`)",
+			expectedSource: "key",
+		},
+		{
+			desc:           "query",
+			expression:     "Query(`plan`)",
+			expectedSource: "gold",
+		},
+		{
+			desc:           "cookie",
+			expression:     "Cookie(`session`)",
+			expectedSource: "abc",
+		},
+		{
+			desc:           "missing cookie",
+			expression:     "Cookie(`unknown`)",
+			expectedSource: "",
+		},
+		{
+			desc:           "host",
+			expression:     "Host()",
+			expectedSource: "example.com",
+		},
+		{
+			desc:           "client IP",
+			expression:     "ClientIP()",
+			expectedSource: "10.0.0.1",
+		},
+		{
+			desc:           "JWT claim",
+			expression:     "JWTClaim(`sub`)",
+			expectedSource: "alice",
+		},
+		{
+			desc:           "JWT object claim",
+			expression:     "JWTClaim(`tenant`)",
+			expectedSource: `{"id":42}`,
+		},
+		{
+			desc:           "client certificate subject",
+			expression:     "ClientCertSubject()",
+			expectedSource: "CN=client,O=Traefik",
+		},
+		{
+			desc:           "several values",
+			expression:     "Header(`X-API-Key`) && Query(`plan`)",
+			expectedSource: `"key","gold"`,
+		},
+		{
+			desc:          "unknown function",
+			expression:    "Unknown(`foo`)",
+			expectedError: true,
+		},
+		{
+			desc:          "or operator",
+			expression:    "Header(`X-API-Key`) || Query(`plan`)",
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			extractor, err := GetSourceExtractor(context.Background(), &dynamic.SourceCriterion{Expression: test.expression})
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com/foo?plan=gold", nil)
+			req.RemoteAddr = "10.0.0.1:1234"
+			req.Header.Set("X-API-Key", "key")
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+			req.TLS = &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "client", Organization: []string{"Traefik"}}}},
+			}
+
+			source, amount, err := extractor.Extract(req)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedSource, source)
+			assert.Equal(t, int64(1), amount)
+		})
+	}
+}
+
+func TestGetSourceExtractor_expressionExclusivity(t *testing.T) {
+	_, err := GetSourceExtractor(context.Background(), &dynamic.SourceCriterion{
+		Expression:        "Header(`X-API-Key`)",
+		RequestHeaderName: "X-API-Key",
+	})
+	assert.EqualError(t, err, "expression is mutually exclusive with the other criteria")
+}
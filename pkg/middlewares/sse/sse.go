@@ -0,0 +1,240 @@
+package sse
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "SSE"
+
+// sse is a middleware streaming the Server-Sent Events responses to the clients.
+type sse struct {
+	next          http.Handler
+	name          string
+	flushInterval time.Duration
+	maxLifetime   time.Duration
+}
+
+// New creates an SSE middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.SSE, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if config.FlushInterval < 0 {
+		return nil, fmt.Errorf("negative flushInterval %s", time.Duration(config.FlushInterval))
+	}
+
+	if config.MaxLifetime < 0 {
+		return nil, fmt.Errorf("negative maxLifetime %s", time.Duration(config.MaxLifetime))
+	}
+
+	return &sse{
+		next:          next,
+		name:          name,
+		flushInterval: time.Duration(config.FlushInterval),
+		maxLifetime:   time.Duration(config.MaxLifetime),
+	}, nil
+}
+
+func (s *sse) GetTracingInformation() (string, string, trace.SpanKind) {
+	return s.name, typeName, trace.SpanKindInternal
+}
+
+func (s *sse) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	// The request context is canceled to close the stream once it reaches its maximum lifetime.
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	req = req.WithContext(ctx)
+
+	w := &responseWriter{
+		ResponseWriter: rw,
+		req:            req,
+		policy:         s,
+		cancel:         cancel,
+	}
+	defer w.stop()
+
+	s.next.ServeHTTP(w, req)
+}
+
+// responseWriter sends the Server-Sent Events to the client according to the policy,
+// and writes the other responses as is.
+type responseWriter struct {
+	http.ResponseWriter
+
+	req    *http.Request
+	policy *sse
+	cancel context.CancelFunc
+
+	wroteHeader bool
+	stream      bool
+
+	mu            sync.Mutex
+	stopped       bool
+	flushPending  bool
+	flushTimer    *time.Timer
+	lifetimeTimer *time.Timer
+}
+
+func (r *responseWriter) WriteHeader(code int) {
+	if r.wroteHeader {
+		r.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	// The informational responses do not hold the headers of the final response.
+	if code >= 100 && code < 200 && code != http.StatusSwitchingProtocols {
+		r.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	r.wroteHeader = true
+	r.stream = middlewares.IsEventStreamResponse(r.Header())
+
+	r.ResponseWriter.WriteHeader(code)
+
+	if !r.stream {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.policy.maxLifetime > 0 {
+		r.lifetimeTimer = time.AfterFunc(r.policy.maxLifetime, r.expire)
+	}
+
+	// The headers are sent right away, for the client to know the stream is open.
+	r.flush()
+}
+
+func (r *responseWriter) Write(b []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+
+	if !r.stream {
+		return r.ResponseWriter.Write(b)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n, err := r.ResponseWriter.Write(b)
+	if err != nil {
+		return n, err
+	}
+
+	r.scheduleFlush()
+
+	return n, nil
+}
+
+// Flush sends the buffered data to the client.
+// The events of a stream are sent once the flush interval has elapsed.
+func (r *responseWriter) Flush() {
+	if !r.stream {
+		if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.scheduleFlush()
+}
+
+// Hijack hijacks the connection, for the responses which are not streams, such as the WebSocket handshakes.
+func (r *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("%T is not a http.Hijacker", r.ResponseWriter)
+	}
+
+	return hijacker.Hijack()
+}
+
+// scheduleFlush flushes the stream right away if there is no flush interval, or once it has elapsed otherwise.
+// It must be called with the lock held.
+func (r *responseWriter) scheduleFlush() {
+	if r.stopped {
+		return
+	}
+
+	if r.policy.flushInterval <= 0 {
+		r.flush()
+		return
+	}
+
+	if r.flushPending {
+		return
+	}
+
+	r.flushPending = true
+
+	if r.flushTimer == nil {
+		r.flushTimer = time.AfterFunc(r.policy.flushInterval, r.delayedFlush)
+		return
+	}
+
+	r.flushTimer.Reset(r.policy.flushInterval)
+}
+
+func (r *responseWriter) delayedFlush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The pending flush has already been done, or the response is complete.
+	if !r.flushPending || r.stopped {
+		return
+	}
+
+	r.flush()
+}
+
+// flush must be called with the lock held.
+func (r *responseWriter) flush() {
+	r.flushPending = false
+
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *responseWriter) expire() {
+	logger := middlewares.GetLogger(r.req.Context(), r.policy.name, typeName)
+	logger.Debug().Msg("Closing the event stream: maximum lifetime reached")
+
+	r.cancel()
+}
+
+// stop flushes the pending events, and stops the timers once the response is complete.
+func (r *responseWriter) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.flushPending {
+		r.flush()
+	}
+
+	r.stopped = true
+
+	if r.flushTimer != nil {
+		r.flushTimer.Stop()
+	}
+
+	if r.lifetimeTimer != nil {
+		r.lifetimeTimer.Stop()
+	}
+}
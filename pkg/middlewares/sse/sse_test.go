@@ -0,0 +1,156 @@
+package sse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.SSE
+	}{
+		{
+			desc:   "negative flush interval",
+			config: dynamic.SSE{FlushInterval: ptypes.Duration(-time.Second)},
+		},
+		{
+			desc:   "negative max lifetime",
+			config: dynamic.SSE{MaxLifetime: ptypes.Duration(-time.Second)},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "sse")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSSE_notEventStream(t *testing.T) {
+	handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Content-Type", "text/plain")
+		_, _ = rw.Write([]byte("event"))
+	}), dynamic.SSE{FlushInterval: ptypes.Duration(time.Hour)}, "sse")
+	require.NoError(t, err)
+
+	rw := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "event", rw.Body.String())
+	assert.Zero(t, rw.flushCount())
+}
+
+func TestSSE_flushEachWrite(t *testing.T) {
+	handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		rw.WriteHeader(http.StatusOK)
+
+		for range 3 {
+			_, _ = rw.Write([]byte("data: event\n\n"))
+		}
+	}), dynamic.SSE{}, "sse")
+	require.NoError(t, err)
+
+	rw := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "data: event\n\ndata: event\n\ndata: event\n\n", rw.Body.String())
+	// The headers, then each event.
+	assert.Equal(t, 4, rw.flushCount())
+}
+
+func TestSSE_flushInterval(t *testing.T) {
+	rw := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+
+	written := make(chan struct{})
+	release := make(chan struct{})
+
+	handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Content-Type", "text/event-stream")
+
+		for range 3 {
+			_, _ = rw.Write([]byte("data: event\n\n"))
+			rw.(http.Flusher).Flush()
+		}
+
+		close(written)
+		<-release
+	}), dynamic.SSE{FlushInterval: ptypes.Duration(50 * time.Millisecond)}, "sse")
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+
+	<-written
+
+	// The events written within the interval are flushed at once.
+	assert.Eventually(t, func() bool { return rw.flushCount() == 2 }, time.Second, 10*time.Millisecond)
+
+	close(release)
+	<-done
+
+	assert.Equal(t, 2, rw.flushCount())
+}
+
+func TestSSE_maxLifetime(t *testing.T) {
+	handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "text/event-stream")
+		_, _ = rw.Write([]byte("data: event\n\n"))
+
+		<-req.Context().Done()
+	}), dynamic.SSE{MaxLifetime: ptypes.Duration(50 * time.Millisecond)}, "sse")
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(&flushRecorder{ResponseRecorder: httptest.NewRecorder()}, httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the stream has not been closed")
+	}
+}
+
+// flushRecorder is a response recorder counting the flushes.
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+
+	mu      sync.Mutex
+	flushes int
+}
+
+func (f *flushRecorder) Flush() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.flushes++
+	f.ResponseRecorder.Flush()
+}
+
+func (f *flushRecorder) flushCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.flushes
+}
@@ -0,0 +1,264 @@
+package tarpit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/mailgun/ttlmap"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/ip"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/botmanager"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+)
+
+const typeName = "Tarpit"
+
+const (
+	defaultDelay         = 5 * time.Second
+	defaultMaxConcurrent = 100
+
+	maxSources = 65536
+	// strikesTTL is the time, in seconds, after which the consecutive matching requests of a client are forgotten.
+	strikesTTL = 60
+)
+
+// tarpit is a middleware delaying the requests of the abusive clients.
+type tarpit struct {
+	next          http.Handler
+	name          string
+	sourceRange   *ip.Checker
+	botCategories []string
+	strategy      ip.Strategy
+	delay         time.Duration
+	maxDelay      time.Duration
+	statusCode    int
+
+	// slots bounds the number of requests delayed at the same time.
+	slots chan struct{}
+
+	rate       rate.Limit
+	burst      int
+	rateTTL    int
+	buckets    *ttlmap.TtlMap // token buckets of the clients, keyed by client IP.
+	strikesMap *ttlmap.TtlMap // consecutive matching requests of the clients, keyed by client IP.
+}
+
+// New creates a Tarpit middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.Tarpit, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if len(config.SourceRange) == 0 && config.Rate == nil && len(config.BotCategories) == 0 {
+		return nil, errors.New("at least one of sourceRange, rate, or botCategories must be set")
+	}
+
+	if config.StatusCode != 0 && http.StatusText(config.StatusCode) == "" {
+		return nil, fmt.Errorf("invalid HTTP status code %d", config.StatusCode)
+	}
+
+	strategy, err := config.IPStrategy.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	delay := time.Duration(config.Delay)
+	if delay < 0 {
+		return nil, fmt.Errorf("negative delay %s", delay)
+	}
+	if delay == 0 {
+		delay = defaultDelay
+	}
+
+	maxDelay := time.Duration(config.MaxDelay)
+	if maxDelay < delay {
+		maxDelay = delay
+	}
+
+	maxConcurrent := config.MaxConcurrent
+	if maxConcurrent < 0 {
+		return nil, fmt.Errorf("negative maxConcurrent %d", maxConcurrent)
+	}
+	if maxConcurrent == 0 {
+		maxConcurrent = defaultMaxConcurrent
+	}
+
+	strikesMap, err := ttlmap.NewConcurrent(maxSources)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &tarpit{
+		next:          next,
+		name:          name,
+		botCategories: config.BotCategories,
+		strategy:      strategy,
+		delay:         delay,
+		maxDelay:      maxDelay,
+		statusCode:    config.StatusCode,
+		slots:         make(chan struct{}, maxConcurrent),
+		strikesMap:    strikesMap,
+	}
+
+	if len(config.SourceRange) > 0 {
+		t.sourceRange, err = ip.NewChecker(config.SourceRange)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse CIDRs %s: %w", config.SourceRange, err)
+		}
+	}
+
+	if config.Rate != nil {
+		if config.Rate.Average <= 0 {
+			return nil, errors.New("rate average must be greater than zero")
+		}
+
+		period := time.Duration(config.Rate.Period)
+		if period < 0 {
+			return nil, fmt.Errorf("negative rate period %s", period)
+		}
+		if period == 0 {
+			period = time.Second
+		}
+
+		t.burst = int(config.Rate.Burst)
+		if t.burst <= 0 {
+			t.burst = 1
+		}
+
+		t.rate = rate.Limit(float64(config.Rate.Average) * float64(time.Second) / float64(period))
+
+		// The bucket of a client is kept as long as it has not been refilled.
+		t.rateTTL = 1 + int(float64(t.burst)/float64(t.rate))
+
+		t.buckets, err = ttlmap.NewConcurrent(maxSources)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+func (t *tarpit) GetTracingInformation() (string, string, trace.SpanKind) {
+	return t.name, typeName, trace.SpanKindInternal
+}
+
+func (t *tarpit) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), t.name, typeName)
+
+	clientIP := t.strategy.GetIP(req)
+
+	reason := t.match(req, clientIP)
+	if reason == "" {
+		t.next.ServeHTTP(rw, req)
+		return
+	}
+
+	select {
+	case t.slots <- struct{}{}:
+		defer func() { <-t.slots }()
+	default:
+		logger.Debug().Msgf("Rejecting request from IP %s (%s): too many delayed requests", clientIP, reason)
+		observability.SetStatusErrorf(req.Context(), "Rejecting request from IP %s: too many delayed requests", clientIP)
+
+		writeStatus(req.Context(), rw, http.StatusTooManyRequests)
+		return
+	}
+
+	delay := t.nextDelay(clientIP)
+
+	logger.Debug().Msgf("Delaying request from IP %s (%s) by %s", clientIP, reason, delay)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return
+	case <-timer.C:
+	}
+
+	if t.statusCode != 0 {
+		writeStatus(req.Context(), rw, t.statusCode)
+		return
+	}
+
+	t.next.ServeHTTP(rw, req)
+}
+
+// match returns the reason why the request is delayed, or an empty string if it is not.
+func (t *tarpit) match(req *http.Request, clientIP string) string {
+	if t.sourceRange != nil {
+		if contains, _ := t.sourceRange.Contains(clientIP); contains {
+			return "source range"
+		}
+	}
+
+	if len(t.botCategories) > 0 {
+		if category := botmanager.GetCategory(req.Context()); category != "" && slices.Contains(t.botCategories, category) {
+			return "bot category " + category
+		}
+	}
+
+	if t.buckets != nil && !t.allow(clientIP) {
+		return "rate exceeded"
+	}
+
+	return ""
+}
+
+// allow reports whether the request of the given client is within the configured rate.
+func (t *tarpit) allow(clientIP string) bool {
+	var bucket *rate.Limiter
+	if value, exists := t.buckets.Get(clientIP); exists {
+		bucket = value.(*rate.Limiter)
+	} else {
+		bucket = rate.NewLimiter(t.rate, t.burst)
+	}
+
+	if err := t.buckets.Set(clientIP, bucket, t.rateTTL); err != nil {
+		log.Error().Err(err).Msg("Could not insert/update bucket")
+	}
+
+	return bucket.Allow()
+}
+
+// nextDelay returns the delay of the next matching request of the given client.
+// The delay grows by the configured delay with each consecutive matching request, up to the max delay.
+func (t *tarpit) nextDelay(clientIP string) time.Duration {
+	if t.maxDelay <= t.delay {
+		return t.delay
+	}
+
+	strikes := 1
+	if value, exists := t.strikesMap.Get(clientIP); exists {
+		strikes = value.(int) + 1
+	}
+
+	delay := t.maxDelay
+	if time.Duration(strikes) <= t.maxDelay/t.delay {
+		delay = time.Duration(strikes) * t.delay
+	} else {
+		// Stops the count from growing once the max delay is reached.
+		strikes--
+	}
+
+	if err := t.strikesMap.Set(clientIP, strikes, strikesTTL); err != nil {
+		log.Error().Err(err).Msg("Could not insert/update strikes")
+	}
+
+	return delay
+}
+
+func writeStatus(ctx context.Context, rw http.ResponseWriter, statusCode int) {
+	rw.WriteHeader(statusCode)
+	if _, err := rw.Write([]byte(http.StatusText(statusCode))); err != nil {
+		log.Ctx(ctx).Error().Err(err).Send()
+	}
+}
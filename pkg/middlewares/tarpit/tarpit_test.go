@@ -0,0 +1,214 @@
+package tarpit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.Tarpit
+	}{
+		{
+			desc:   "no criteria",
+			config: dynamic.Tarpit{},
+		},
+		{
+			desc:   "invalid source range",
+			config: dynamic.Tarpit{SourceRange: []string{"foo"}},
+		},
+		{
+			desc:   "invalid status code",
+			config: dynamic.Tarpit{SourceRange: []string{"10.0.0.1"}, StatusCode: 999},
+		},
+		{
+			desc:   "negative delay",
+			config: dynamic.Tarpit{SourceRange: []string{"10.0.0.1"}, Delay: ptypes.Duration(-time.Second)},
+		},
+		{
+			desc:   "negative max concurrent",
+			config: dynamic.Tarpit{SourceRange: []string{"10.0.0.1"}, MaxConcurrent: -1},
+		},
+		{
+			desc:   "zero rate average",
+			config: dynamic.Tarpit{Rate: &dynamic.TarpitRate{}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "tarpit")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTarpit_match(t *testing.T) {
+	config := dynamic.Tarpit{
+		SourceRange:   []string{"10.0.0.0/24"},
+		Rate:          &dynamic.TarpitRate{Average: 1, Period: ptypes.Duration(time.Hour), Burst: 2},
+		BotCategories: []string{"scanner"},
+	}
+
+	handler, err := New(context.Background(), http.NotFoundHandler(), config, "tarpit")
+	require.NoError(t, err)
+
+	tp := handler.(*tarpit)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	assert.Equal(t, "source range", tp.match(req, "10.0.0.1"))
+
+	// The first requests are within the burst.
+	assert.Empty(t, tp.match(req, "10.0.1.1"))
+	assert.Empty(t, tp.match(req, "10.0.1.1"))
+	assert.Equal(t, "rate exceeded", tp.match(req, "10.0.1.1"))
+	assert.Empty(t, tp.match(req, "10.0.1.2"))
+}
+
+func TestTarpit_nextDelay(t *testing.T) {
+	config := dynamic.Tarpit{
+		SourceRange: []string{"10.0.0.0/24"},
+		Delay:       ptypes.Duration(2 * time.Second),
+		MaxDelay:    ptypes.Duration(5 * time.Second),
+	}
+
+	handler, err := New(context.Background(), http.NotFoundHandler(), config, "tarpit")
+	require.NoError(t, err)
+
+	tp := handler.(*tarpit)
+
+	var delays []time.Duration
+	for range 5 {
+		delays = append(delays, tp.nextDelay("10.0.0.1"))
+	}
+
+	assert.Equal(t, []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second, 5 * time.Second}, delays)
+	assert.Equal(t, 2*time.Second, tp.nextDelay("10.0.0.2"))
+}
+
+func TestTarpit_ServeHTTP(t *testing.T) {
+	testCases := []struct {
+		desc              string
+		config            dynamic.Tarpit
+		remoteAddr        string
+		expectedStatus    int
+		expectedForwarded bool
+		expectedDelayed   bool
+	}{
+		{
+			desc: "not matching",
+			config: dynamic.Tarpit{
+				SourceRange: []string{"10.0.0.0/24"},
+				Delay:       ptypes.Duration(50 * time.Millisecond),
+			},
+			remoteAddr:        "10.0.1.1:1234",
+			expectedStatus:    http.StatusOK,
+			expectedForwarded: true,
+		},
+		{
+			desc: "matching",
+			config: dynamic.Tarpit{
+				SourceRange: []string{"10.0.0.0/24"},
+				Delay:       ptypes.Duration(50 * time.Millisecond),
+			},
+			remoteAddr:        "10.0.0.1:1234",
+			expectedStatus:    http.StatusOK,
+			expectedForwarded: true,
+			expectedDelayed:   true,
+		},
+		{
+			desc: "matching with status code",
+			config: dynamic.Tarpit{
+				SourceRange: []string{"10.0.0.0/24"},
+				Delay:       ptypes.Duration(50 * time.Millisecond),
+				StatusCode:  http.StatusForbidden,
+			},
+			remoteAddr:      "10.0.0.1:1234",
+			expectedStatus:  http.StatusForbidden,
+			expectedDelayed: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwarded bool
+			handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwarded = true
+			}), test.config, "tarpit")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = test.remoteAddr
+
+			rw := httptest.NewRecorder()
+
+			start := time.Now()
+			handler.ServeHTTP(rw, req)
+			elapsed := time.Since(start)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+			assert.Equal(t, test.expectedForwarded, forwarded)
+
+			if test.expectedDelayed {
+				assert.GreaterOrEqual(t, elapsed, 50*time.Millisecond)
+			} else {
+				assert.Less(t, elapsed, 50*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestTarpit_maxConcurrent(t *testing.T) {
+	config := dynamic.Tarpit{
+		SourceRange:   []string{"10.0.0.0/24"},
+		Delay:         ptypes.Duration(time.Hour),
+		MaxConcurrent: 1,
+	}
+
+	handler, err := New(context.Background(), http.NotFoundHandler(), config, "tarpit")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	req.RemoteAddr = "10.0.0.1:1234"
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}()
+
+	tp := handler.(*tarpit)
+	require.Eventually(t, func() bool { return len(tp.slots) == 1 }, time.Second, 5*time.Millisecond)
+
+	otherReq := httptest.NewRequest(http.MethodGet, "/", nil)
+	otherReq.RemoteAddr = "10.0.0.2:1234"
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, otherReq)
+
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+
+	// Canceling the delayed request releases its slot.
+	cancel()
+	wg.Wait()
+
+	assert.Empty(t, tp.slots)
+}
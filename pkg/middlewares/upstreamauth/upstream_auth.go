@@ -0,0 +1,151 @@
+package upstreamauth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	typeName          = "UpstreamAuth"
+	defaultHeaderName = "Authorization"
+)
+
+// tokenRequestTimeout is the maximum duration of the requests to the token endpoint.
+const tokenRequestTimeout = 10 * time.Second
+
+// upstreamAuth is a middleware setting the credentials of the service on the forwarded requests.
+type upstreamAuth struct {
+	next       http.Handler
+	name       string
+	headerName string
+	// credentials returns the value of the credentials header.
+	credentials func() (string, error)
+}
+
+// New creates an UpstreamAuth middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.UpstreamAuth, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	u := &upstreamAuth{
+		next:       next,
+		name:       name,
+		headerName: config.HeaderName,
+	}
+
+	if u.headerName == "" {
+		u.headerName = defaultHeaderName
+	}
+
+	var count int
+	if config.Basic != nil {
+		count++
+
+		if config.Basic.Username == "" {
+			return nil, errors.New("basic: empty username")
+		}
+
+		value := "Basic " + base64.StdEncoding.EncodeToString([]byte(config.Basic.Username+":"+config.Basic.Password))
+		u.credentials = func() (string, error) { return value, nil }
+	}
+
+	if config.Bearer != nil {
+		count++
+
+		if config.Bearer.Token == "" {
+			return nil, errors.New("bearer: empty token")
+		}
+
+		value := "Bearer " + config.Bearer.Token
+		u.credentials = func() (string, error) { return value, nil }
+	}
+
+	if config.OAuth2 != nil {
+		count++
+
+		tokenSource, err := newTokenSource(*config.OAuth2)
+		if err != nil {
+			return nil, fmt.Errorf("oauth2: %w", err)
+		}
+
+		u.credentials = func() (string, error) {
+			token, err := tokenSource.Token()
+			if err != nil {
+				return "", err
+			}
+
+			return token.Type() + " " + token.AccessToken, nil
+		}
+	}
+
+	if count != 1 {
+		return nil, errors.New("exactly one of the basic, bearer and oauth2 options must be set")
+	}
+
+	return u, nil
+}
+
+func (u *upstreamAuth) GetTracingInformation() (string, string, trace.SpanKind) {
+	return u.name, typeName, trace.SpanKindInternal
+}
+
+func (u *upstreamAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	value, err := u.credentials()
+	if err != nil {
+		logger := middlewares.GetLogger(req.Context(), u.name, typeName)
+		logger.Error().Err(err).Msg("Unable to get the upstream credentials")
+		observability.SetStatusErrorf(req.Context(), "Unable to get the upstream credentials: %v", err)
+
+		rw.WriteHeader(http.StatusBadGateway)
+		return
+	}
+
+	req.Header.Set(u.headerName, value)
+
+	u.next.ServeHTTP(rw, req)
+}
+
+// newTokenSource returns a token source fetching the tokens from the token endpoint,
+// and reusing them until they expire.
+func newTokenSource(config dynamic.UpstreamOAuth2) (oauth2.TokenSource, error) {
+	if config.TokenURL == "" {
+		return nil, errors.New("empty token URL")
+	}
+
+	if _, err := url.ParseRequestURI(config.TokenURL); err != nil {
+		return nil, fmt.Errorf("invalid token URL: %w", err)
+	}
+
+	if config.ClientID == "" {
+		return nil, errors.New("empty client ID")
+	}
+
+	params := make(url.Values, len(config.EndpointParams))
+	for key, value := range config.EndpointParams {
+		params.Set(key, value)
+	}
+
+	cc := clientcredentials.Config{
+		ClientID:       config.ClientID,
+		ClientSecret:   config.ClientSecret,
+		TokenURL:       config.TokenURL,
+		Scopes:         config.Scopes,
+		EndpointParams: params,
+	}
+
+	// The token source outlives the request which triggers the token fetch.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: tokenRequestTimeout})
+
+	return cc.TokenSource(ctx), nil
+}
@@ -0,0 +1,215 @@
+package upstreamauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.UpstreamAuth
+	}{
+		{
+			desc: "no credentials",
+		},
+		{
+			desc: "several credentials",
+			config: dynamic.UpstreamAuth{
+				Basic:  &dynamic.UpstreamBasicAuth{Username: "user", Password: "secret"},
+				Bearer: &dynamic.UpstreamBearerAuth{Token: "token"},
+			},
+		},
+		{
+			desc:   "empty username",
+			config: dynamic.UpstreamAuth{Basic: &dynamic.UpstreamBasicAuth{Password: "secret"}},
+		},
+		{
+			desc:   "empty token",
+			config: dynamic.UpstreamAuth{Bearer: &dynamic.UpstreamBearerAuth{}},
+		},
+		{
+			desc:   "empty token URL",
+			config: dynamic.UpstreamAuth{OAuth2: &dynamic.UpstreamOAuth2{ClientID: "client"}},
+		},
+		{
+			desc:   "empty client ID",
+			config: dynamic.UpstreamAuth{OAuth2: &dynamic.UpstreamOAuth2{TokenURL: "https://auth.example.com/token"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "upstreamAuth")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestUpstreamAuth_static(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		config        dynamic.UpstreamAuth
+		header        string
+		expectedValue string
+	}{
+		{
+			desc:          "basic",
+			config:        dynamic.UpstreamAuth{Basic: &dynamic.UpstreamBasicAuth{Username: "user", Password: "secret"}},
+			header:        "Authorization",
+			expectedValue: "Basic dXNlcjpzZWNyZXQ=",
+		},
+		{
+			desc:          "bearer",
+			config:        dynamic.UpstreamAuth{Bearer: &dynamic.UpstreamBearerAuth{Token: "token"}},
+			header:        "Authorization",
+			expectedValue: "Bearer token",
+		},
+		{
+			desc: "custom header",
+			config: dynamic.UpstreamAuth{
+				HeaderName: "X-Upstream-Authorization",
+				Bearer:     &dynamic.UpstreamBearerAuth{Token: "token"},
+			},
+			header:        "X-Upstream-Authorization",
+			expectedValue: "Bearer token",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var values []string
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				values = req.Header.Values(test.header)
+			})
+
+			handler, err := New(context.Background(), next, test.config, "upstreamAuth")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			req.Header.Set(test.header, "Basic Y2xpZW50OmNsaWVudA==")
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, []string{test.expectedValue}, values)
+		})
+	}
+}
+
+func TestUpstreamAuth_oauth2(t *testing.T) {
+	var fetches atomic.Int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		clientID, clientSecret, _ := req.BasicAuth()
+		if req.Form.Get("grant_type") != "client_credentials" || clientID != "client" || clientSecret != "secret" ||
+			req.Form.Get("scope") != "read write" || req.Form.Get("audience") != "api" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		n := fetches.Add(1)
+
+		rw.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(rw).Encode(map[string]any{
+			"access_token": "token" + strconv.Itoa(int(n)),
+			"token_type":   "bearer",
+			// The tokens expire within the expiry delta of the token source, and are fetched again on each request.
+			"expires_in": 5,
+		})
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	var value string
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		value = req.Header.Get("Authorization")
+	})
+
+	config := dynamic.UpstreamAuth{
+		OAuth2: &dynamic.UpstreamOAuth2{
+			TokenURL:       tokenServer.URL,
+			ClientID:       "client",
+			ClientSecret:   "secret",
+			Scopes:         []string{"read", "write"},
+			EndpointParams: map[string]string{"audience": "api"},
+		},
+	}
+	handler, err := New(context.Background(), next, config, "upstreamAuth")
+	require.NoError(t, err)
+
+	for _, expected := range []string{"Bearer token1", "Bearer token2"} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://example.com", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, expected, value)
+	}
+}
+
+func TestUpstreamAuth_oauth2Reuse(t *testing.T) {
+	var fetches atomic.Int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		fetches.Add(1)
+
+		rw.Header().Set("Content-Type", "application/json")
+		_, _ = rw.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	config := dynamic.UpstreamAuth{
+		OAuth2: &dynamic.UpstreamOAuth2{TokenURL: tokenServer.URL, ClientID: "client", ClientSecret: "secret"},
+	}
+	handler, err := New(context.Background(), http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), config, "upstreamAuth")
+	require.NoError(t, err)
+
+	for range 3 {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://example.com", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	}
+
+	assert.Equal(t, int32(1), fetches.Load())
+}
+
+func TestUpstreamAuth_oauth2Error(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	var called bool
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		called = true
+	})
+
+	config := dynamic.UpstreamAuth{
+		OAuth2: &dynamic.UpstreamOAuth2{TokenURL: tokenServer.URL, ClientID: "client", ClientSecret: "secret"},
+	}
+	handler, err := New(context.Background(), next, config, "upstreamAuth")
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://example.com", nil))
+
+	assert.Equal(t, http.StatusBadGateway, recorder.Code)
+	assert.False(t, called)
+}
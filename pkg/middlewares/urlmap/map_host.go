@@ -0,0 +1,88 @@
+package urlmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/maptable"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeHostName = "MapHost"
+
+// hostTable holds the target hosts, keyed by lower-cased source host.
+type hostTable map[string]string
+
+// mapHost is a middleware rewriting the request host according to a lookup table.
+type mapHost struct {
+	next     http.Handler
+	name     string
+	mappings *maptable.Table[dynamic.MapEntry, hostTable]
+}
+
+// NewMapHost creates a MapHost middleware.
+func NewMapHost(ctx context.Context, next http.Handler, config dynamic.MapHost, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeHostName)
+	logger.Debug().Msg("Creating middleware")
+
+	m := &mapHost{
+		next: next,
+		name: name,
+	}
+
+	if config.File == "" && len(config.Mappings) == 0 {
+		return nil, errors.New("no mappings defined")
+	}
+
+	var err error
+	m.mappings, err = maptable.New(logger, config.File, config.Mappings, mappingsFormat, buildHostTable)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *mapHost) GetTracingInformation() (string, string, trace.SpanKind) {
+	return m.name, typeHostName, trace.SpanKindInternal
+}
+
+func (m *mapHost) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	// The original host remains available in the X-Forwarded-Host header, set by the entry point.
+	if host, ok := (*m.mappings.Current())[maptable.RequestHost(req)]; ok {
+		req.Host = host
+	}
+
+	m.next.ServeHTTP(rw, req)
+}
+
+func buildHostTable(entries []dynamic.MapEntry) (*hostTable, error) {
+	table := make(hostTable)
+
+	for i, mapping := range entries {
+		if mapping.Source == "" || mapping.Target == "" {
+			return nil, fmt.Errorf("mapping %d: source and target must be defined", i)
+		}
+
+		if mapping.MatchMode != "" && mapping.MatchMode != maptable.MatchModeExact {
+			return nil, fmt.Errorf("mapping %d: unsupported match mode %q, the hosts are matched exactly", i, mapping.MatchMode)
+		}
+
+		if strings.ContainsAny(mapping.Target, "/ ") {
+			return nil, fmt.Errorf("mapping %d: target %q must be a host", i, mapping.Target)
+		}
+
+		// The first mapping of a source wins.
+		source := strings.ToLower(mapping.Source)
+		if _, ok := table[source]; !ok {
+			table[source] = mapping.Target
+		}
+	}
+
+	return &table, nil
+}
@@ -0,0 +1,109 @@
+package urlmap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNewMapHost(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.MapHost
+	}{
+		{
+			desc:   "no mappings",
+			config: dynamic.MapHost{},
+		},
+		{
+			desc: "missing target",
+			config: dynamic.MapHost{
+				Mappings: []dynamic.MapEntry{{Source: "old.example.com"}},
+			},
+		},
+		{
+			desc: "target not a host",
+			config: dynamic.MapHost{
+				Mappings: []dynamic.MapEntry{{Source: "old.example.com", Target: "new.example.com/path"}},
+			},
+		},
+		{
+			desc: "unsupported match mode",
+			config: dynamic.MapHost{
+				Mappings: []dynamic.MapEntry{{Source: "old.example.com", Target: "new.example.com", MatchMode: "prefix"}},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewMapHost(context.Background(), http.NotFoundHandler(), test.config, "mapHost")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMapHost_ServeHTTP(t *testing.T) {
+	config := dynamic.MapHost{
+		Mappings: []dynamic.MapEntry{
+			{Source: "Old.Example.com", Target: "new.example.com"},
+			{Source: "shop.example.com", Target: "store.internal:8080"},
+		},
+	}
+
+	testCases := []struct {
+		desc         string
+		host         string
+		expectedHost string
+	}{
+		{
+			desc:         "mapped host",
+			host:         "old.example.com",
+			expectedHost: "new.example.com",
+		},
+		{
+			desc:         "mapped host with a port",
+			host:         "OLD.example.com:8443",
+			expectedHost: "new.example.com",
+		},
+		{
+			desc:         "mapped host to a host with a port",
+			host:         "shop.example.com",
+			expectedHost: "store.internal:8080",
+		},
+		{
+			desc:         "no mapping",
+			host:         "other.example.com",
+			expectedHost: "other.example.com",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwarded *http.Request
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwarded = req
+			})
+
+			handler, err := NewMapHost(context.Background(), next, config, "mapHost")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = test.host
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.NotNil(t, forwarded)
+			assert.Equal(t, test.expectedHost, forwarded.Host)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package urlmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/maptable"
+	"github.com/traefik/traefik/v3/pkg/middlewares/replacepath"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typePathName = "MapPath"
+
+// pathEntry is a mapping of the path table.
+type pathEntry struct {
+	target string
+	regex  *regexp.Regexp
+}
+
+// pathTable holds the path mappings, indexed by match mode.
+type pathTable struct {
+	exact  map[string]*pathEntry
+	prefix map[string]*pathEntry
+	// prefixLengths are the distinct lengths of the prefixes, from the longest to the shortest.
+	prefixLengths []int
+	regex         []*pathEntry
+}
+
+// mapPath is a middleware rewriting the request path according to a lookup table.
+type mapPath struct {
+	next      http.Handler
+	name      string
+	matchMode string
+	mappings  *maptable.Table[dynamic.MapEntry, pathTable]
+}
+
+// NewMapPath creates a MapPath middleware.
+func NewMapPath(ctx context.Context, next http.Handler, config dynamic.MapPath, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typePathName)
+	logger.Debug().Msg("Creating middleware")
+
+	m := &mapPath{
+		next:      next,
+		name:      name,
+		matchMode: config.MatchMode,
+	}
+
+	if config.File == "" && len(config.Mappings) == 0 {
+		return nil, errors.New("no mappings defined")
+	}
+
+	if m.matchMode == "" {
+		m.matchMode = maptable.MatchModeExact
+	}
+
+	if err := maptable.CheckMatchMode(m.matchMode); err != nil {
+		return nil, err
+	}
+
+	var err error
+	m.mappings, err = maptable.New(logger, config.File, config.Mappings, mappingsFormat, m.buildTable)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *mapPath) GetTracingInformation() (string, string, trace.SpanKind) {
+	return m.name, typePathName, trace.SpanKindInternal
+}
+
+func (m *mapPath) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	path, ok := m.mappings.Current().lookup(maptable.RequestHost(req), req.URL.Path)
+	if !ok {
+		m.next.ServeHTTP(rw, req)
+		return
+	}
+
+	currentPath := req.URL.RawPath
+	if currentPath == "" {
+		currentPath = req.URL.EscapedPath()
+	}
+
+	req.Header.Add(replacepath.ReplacedPathHeader, currentPath)
+	req.URL.Path = path
+	req.URL.RawPath = ""
+	req.RequestURI = req.URL.RequestURI()
+
+	m.next.ServeHTTP(rw, req)
+}
+
+func (m *mapPath) buildTable(entries []dynamic.MapEntry) (*pathTable, error) {
+	table := &pathTable{
+		exact:  make(map[string]*pathEntry),
+		prefix: make(map[string]*pathEntry),
+	}
+
+	for i, mapping := range entries {
+		if mapping.Source == "" || mapping.Target == "" {
+			return nil, fmt.Errorf("mapping %d: source and target must be defined", i)
+		}
+
+		if !strings.HasPrefix(mapping.Target, "/") {
+			return nil, fmt.Errorf("mapping %d: target %q must be a path", i, mapping.Target)
+		}
+
+		matchMode := m.matchMode
+		if mapping.MatchMode != "" {
+			if err := maptable.CheckMatchMode(mapping.MatchMode); err != nil {
+				return nil, fmt.Errorf("mapping %d: %w", i, err)
+			}
+
+			matchMode = mapping.MatchMode
+		}
+
+		entry := &pathEntry{target: mapping.Target}
+		source := maptable.NormalizeSource(mapping.Source)
+
+		switch matchMode {
+		case maptable.MatchModeExact:
+			// The first mapping of a source wins.
+			if _, ok := table.exact[source]; !ok {
+				table.exact[source] = entry
+			}
+
+		case maptable.MatchModePrefix:
+			if _, ok := table.prefix[source]; !ok {
+				table.prefix[source] = entry
+			}
+
+			if !slices.Contains(table.prefixLengths, len(source)) {
+				table.prefixLengths = append(table.prefixLengths, len(source))
+			}
+
+		case maptable.MatchModeRegex:
+			regex, err := regexp.Compile(mapping.Source)
+			if err != nil {
+				return nil, fmt.Errorf("mapping %d: compiling source regex: %w", i, err)
+			}
+
+			entry.regex = regex
+			table.regex = append(table.regex, entry)
+		}
+	}
+
+	slices.SortFunc(table.prefixLengths, func(a, b int) int { return b - a })
+
+	return table, nil
+}
+
+// lookup returns the path the request with the given host and path is rewritten to, if any.
+// The exact mappings come first, then the longest prefix mappings, and then the regex mappings in their definition order.
+// For each mode, the mappings of the host followed by the path come before the ones of the path.
+func (t *pathTable) lookup(host, path string) (string, bool) {
+	hostPath := host + path
+
+	for _, candidate := range []string{hostPath, path} {
+		if entry, ok := t.exact[candidate]; ok {
+			return entry.target, true
+		}
+	}
+
+	for _, candidate := range []string{hostPath, path} {
+		for _, length := range t.prefixLengths {
+			if length > len(candidate) {
+				continue
+			}
+
+			if entry, ok := t.prefix[candidate[:length]]; ok {
+				return entry.target + candidate[length:], true
+			}
+		}
+	}
+
+	for _, entry := range t.regex {
+		candidate := hostPath
+		if maptable.IsPathRegex(entry.regex.String()) {
+			candidate = path
+		}
+
+		if match := entry.regex.FindStringSubmatchIndex(candidate); match != nil {
+			return string(entry.regex.ExpandString(nil, entry.target, candidate, match)), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,229 @@
+package urlmap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares/maptable"
+)
+
+func TestNewMapPath(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.MapPath
+	}{
+		{
+			desc:   "no mappings",
+			config: dynamic.MapPath{},
+		},
+		{
+			desc: "missing target",
+			config: dynamic.MapPath{
+				Mappings: []dynamic.MapEntry{{Source: "/old"}},
+			},
+		},
+		{
+			desc: "target not a path",
+			config: dynamic.MapPath{
+				Mappings: []dynamic.MapEntry{{Source: "/old", Target: "new"}},
+			},
+		},
+		{
+			desc: "unsupported match mode",
+			config: dynamic.MapPath{
+				MatchMode: "suffix",
+				Mappings:  []dynamic.MapEntry{{Source: "/old", Target: "/new"}},
+			},
+		},
+		{
+			desc: "invalid regex",
+			config: dynamic.MapPath{
+				Mappings: []dynamic.MapEntry{{Source: "^/(old", Target: "/new", MatchMode: "regex"}},
+			},
+		},
+		{
+			desc: "missing file",
+			config: dynamic.MapPath{
+				File: "does-not-exist.csv",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewMapPath(context.Background(), http.NotFoundHandler(), test.config, "mapPath")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMapPath_ServeHTTP(t *testing.T) {
+	config := dynamic.MapPath{
+		Mappings: []dynamic.MapEntry{
+			{Source: "/old", Target: "/new"},
+			{Source: "Legacy.com/old", Target: "/legacy/new"},
+			{Source: "/docs/", Target: "/documentation/", MatchMode: "prefix"},
+			{Source: "/docs/v1/", Target: "/archive/v1/", MatchMode: "prefix"},
+			{Source: `^/products/(\d+)\.aspx$`, Target: "/products/$1", MatchMode: "regex"},
+		},
+	}
+
+	testCases := []struct {
+		desc                 string
+		url                  string
+		expectedPath         string
+		expectedReplacedPath string
+	}{
+		{
+			desc:                 "exact path",
+			url:                  "http://foo.com/old?a=b",
+			expectedPath:         "/new",
+			expectedReplacedPath: "/old",
+		},
+		{
+			desc:                 "exact host and path",
+			url:                  "http://legacy.com:8080/old",
+			expectedPath:         "/legacy/new",
+			expectedReplacedPath: "/old",
+		},
+		{
+			desc:                 "longest prefix",
+			url:                  "http://foo.com/docs/v1/install",
+			expectedPath:         "/archive/v1/install",
+			expectedReplacedPath: "/docs/v1/install",
+		},
+		{
+			desc:                 "prefix",
+			url:                  "http://foo.com/docs/install",
+			expectedPath:         "/documentation/install",
+			expectedReplacedPath: "/docs/install",
+		},
+		{
+			desc:                 "regex",
+			url:                  "http://foo.com/products/42.aspx",
+			expectedPath:         "/products/42",
+			expectedReplacedPath: "/products/42.aspx",
+		},
+		{
+			desc:         "no mapping",
+			url:          "http://foo.com/other",
+			expectedPath: "/other",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwarded *http.Request
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwarded = req
+			})
+
+			handler, err := NewMapPath(context.Background(), next, config, "mapPath")
+			require.NoError(t, err)
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, test.url, nil))
+
+			require.NotNil(t, forwarded)
+			assert.Equal(t, test.expectedPath, forwarded.URL.Path)
+			assert.Equal(t, test.expectedReplacedPath, forwarded.Header.Get("X-Replaced-Path"))
+
+			if test.expectedReplacedPath != "" {
+				assert.Equal(t, forwarded.URL.RequestURI(), forwarded.RequestURI)
+			}
+		})
+	}
+}
+
+func TestMapPath_file(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		filename string
+		content  string
+		updated  string
+	}{
+		{
+			desc:     "CSV",
+			filename: "mappings.csv",
+			content:  "source,target,matchMode\n# Migration\n/old,/new\n/docs/,/documentation/,prefix\n",
+			updated:  "/old,/newer\n",
+		},
+		{
+			desc:     "JSON",
+			filename: "mappings.json",
+			content:  `[{"source":"/old","target":"/new"},{"source":"/docs/","target":"/documentation/","matchMode":"prefix"}]`,
+			updated:  `[{"source":"/old","target":"/newer"}]`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			file := filepath.Join(t.TempDir(), test.filename)
+			require.NoError(t, os.WriteFile(file, []byte(test.content), 0o600))
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				_, _ = rw.Write([]byte(req.URL.Path))
+			})
+
+			handler, err := NewMapPath(context.Background(), next, dynamic.MapPath{File: file}, "mapPath")
+			require.NoError(t, err)
+
+			serve := func(target string) string {
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+				return recorder.Body.String()
+			}
+
+			assert.Equal(t, "/new", serve("http://foo.com/old"))
+			assert.Equal(t, "/documentation/install", serve("http://foo.com/docs/install"))
+
+			require.NoError(t, os.WriteFile(file, []byte(test.updated), 0o600))
+			// Makes sure the modification time changes, whatever the resolution of the file system.
+			require.NoError(t, os.Chtimes(file, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+
+			assert.Eventually(t, func() bool {
+				return serve("http://foo.com/old") == "/newer"
+			}, 5*time.Second, 100*time.Millisecond)
+
+			assert.Equal(t, "/docs/install", serve("http://foo.com/docs/install"))
+		})
+	}
+}
+
+func TestParseMappingsCSV_invalid(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		content string
+	}{
+		{
+			desc:    "missing target",
+			content: "/old\n",
+		},
+		{
+			desc:    "too many columns",
+			content: "/old,/new,exact,foo\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := maptable.ParseCSV([]byte(test.content), mappingsFormat)
+			assert.Error(t, err)
+		})
+	}
+}
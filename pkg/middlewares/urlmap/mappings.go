@@ -0,0 +1,20 @@
+package urlmap
+
+import (
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares/maptable"
+)
+
+// mappingsFormat is the format of the mappings files, with the source, target, and optional match mode columns.
+var mappingsFormat = maptable.Format[dynamic.MapEntry]{
+	Name:       "mappings",
+	MaxColumns: 3,
+	FromRecord: func(record []string) (dynamic.MapEntry, error) {
+		entry := dynamic.MapEntry{Source: record[0], Target: record[1]}
+		if len(record) > 2 {
+			entry.MatchMode = record[2]
+		}
+
+		return entry, nil
+	},
+}
@@ -0,0 +1,172 @@
+// Package waf implements a web application firewall middleware with the Coraza engine.
+package waf
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	coreruleset "github.com/corazawaf/coraza-coreruleset/v4"
+	"github.com/corazawaf/coraza/v3"
+	txhttp "github.com/corazawaf/coraza/v3/http"
+	"github.com/corazawaf/coraza/v3/types"
+	"github.com/rs/zerolog"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "WAF"
+
+// coreRuleSetDirectives loads the recommended Coraza configuration, and the OWASP Core Rule Set.
+const coreRuleSetDirectives = `Include @coraza.conf-recommended
+Include @crs-setup.conf.example
+Include @owasp_crs/*.conf`
+
+// wafs holds the WAFs, by middleware name.
+// Loading the rules is costly, and the WAF of a middleware is kept as long as its directives do not change,
+// as the middlewares are created again on each configuration change.
+var wafs = struct {
+	sync.Mutex
+	byName map[string]compiledWAF
+}{byName: make(map[string]compiledWAF)}
+
+type compiledWAF struct {
+	directives string
+	waf        coraza.WAF
+}
+
+type firewall struct {
+	name    string
+	handler http.Handler
+}
+
+// New creates a WAF middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.WAF, name string) (http.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeName)
+	logger.Debug().Msg("Creating middleware")
+
+	directives, err := buildDirectives(config)
+	if err != nil {
+		return nil, err
+	}
+
+	waf, err := getWAF(logger, name, directives)
+	if err != nil {
+		return nil, err
+	}
+
+	return &firewall{
+		name:    name,
+		handler: txhttp.WrapHandler(waf, next),
+	}, nil
+}
+
+func (f *firewall) GetTracingInformation() (string, string, trace.SpanKind) {
+	return f.name, typeName, trace.SpanKindInternal
+}
+
+func (f *firewall) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	f.handler.ServeHTTP(rw, req)
+}
+
+// buildDirectives returns the SecLang directives of the given configuration.
+func buildDirectives(config dynamic.WAF) (string, error) {
+	var directives []string
+
+	if config.CoreRuleSet {
+		directives = append(directives, coreRuleSetDirectives)
+	}
+
+	directives = append(directives, config.Directives...)
+
+	if config.DetectionOnly {
+		directives = append(directives, "SecRuleEngine DetectionOnly")
+	} else {
+		directives = append(directives, "SecRuleEngine On")
+	}
+
+	// The rules are removed once all of them are defined.
+	for _, id := range config.ExcludedRules {
+		directives = append(directives, "SecRuleRemoveById "+strconv.Itoa(id))
+	}
+
+	if config.AuditLog == nil {
+		directives = append(directives, "SecAuditEngine Off")
+		return strings.Join(directives, "\n"), nil
+	}
+
+	format := strings.ToLower(config.AuditLog.Format)
+	switch format {
+	case "":
+		format = "json"
+	case "json", "native":
+	default:
+		return "", fmt.Errorf("unsupported audit log format: %s", config.AuditLog.Format)
+	}
+
+	filePath := config.AuditLog.FilePath
+	if filePath == "" {
+		filePath = "/dev/stdout"
+	}
+
+	directives = append(directives,
+		"SecAuditEngine RelevantOnly",
+		"SecAuditLogType Serial",
+		"SecAuditLogParts ABIJDEFHKZ",
+		"SecAuditLogFormat "+format,
+		"SecAuditLog "+strconv.Quote(filePath),
+	)
+
+	return strings.Join(directives, "\n"), nil
+}
+
+// getWAF returns the WAF of the given middleware, created again only when its directives change.
+func getWAF(logger *zerolog.Logger, name, directives string) (coraza.WAF, error) {
+	wafs.Lock()
+	defer wafs.Unlock()
+
+	if compiled, ok := wafs.byName[name]; ok && compiled.directives == directives {
+		return compiled.waf, nil
+	}
+
+	waf, err := coraza.NewWAF(coraza.NewWAFConfig().
+		WithRootFS(rulesFS{}).
+		WithErrorCallback(func(rule types.MatchedRule) {
+			logMatchedRule(logger, rule)
+		}).
+		WithDirectives(directives))
+	if err != nil {
+		return nil, fmt.Errorf("loading WAF rules: %w", err)
+	}
+
+	wafs.byName[name] = compiledWAF{directives: directives, waf: waf}
+
+	return waf, nil
+}
+
+func logMatchedRule(logger *zerolog.Logger, rule types.MatchedRule) {
+	logger.Warn().
+		Int("ruleID", rule.Rule().ID()).
+		Str("severity", rule.Rule().Severity().String()).
+		Str("clientIP", rule.ClientIPAddress()).
+		Str("uri", rule.URI()).
+		Msg(rule.Message())
+}
+
+// rulesFS is the file system of the included rules files:
+// the paths starting with @ are the ones of the embedded OWASP Core Rule Set, the other ones are read from the disk.
+type rulesFS struct{}
+
+func (rulesFS) Open(name string) (fs.File, error) {
+	if strings.HasPrefix(name, "@") {
+		return coreruleset.FS.Open(name)
+	}
+
+	return os.Open(name)
+}
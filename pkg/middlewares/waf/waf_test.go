@@ -0,0 +1,169 @@
+package waf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+const testRule = `SecRule ARGS:id "@streq attack" "id:1001,phase:1,deny,status:403,log,msg:'Attack detected'"`
+
+func TestWAF(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "rules.conf")
+	require.NoError(t, os.WriteFile(rulesFile, []byte(testRule), 0o600))
+
+	testCases := []struct {
+		desc           string
+		config         dynamic.WAF
+		target         string
+		expectedStatus int
+	}{
+		{
+			desc:           "allowed request",
+			config:         dynamic.WAF{Directives: []string{testRule}},
+			target:         "/?id=42",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "blocked request",
+			config:         dynamic.WAF{Directives: []string{testRule}},
+			target:         "/?id=attack",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "detection only",
+			config:         dynamic.WAF{Directives: []string{testRule}, DetectionOnly: true},
+			target:         "/?id=attack",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "excluded rule",
+			config:         dynamic.WAF{Directives: []string{testRule}, ExcludedRules: []int{1001}},
+			target:         "/?id=attack",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "rules file",
+			config:         dynamic.WAF{Directives: []string{"Include " + rulesFile}},
+			target:         "/?id=attack",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "core rule set",
+			config:         dynamic.WAF{CoreRuleSet: true},
+			target:         "/?id=1%27%20OR%20%271%27=%271",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "core rule set, allowed request",
+			config:         dynamic.WAF{CoreRuleSet: true},
+			target:         "/?id=42",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "core rule set, excluded rule",
+			config:         dynamic.WAF{CoreRuleSet: true, ExcludedRules: []int{942100, 949110}},
+			target:         "/?id=1%27%20OR%20%271%27=%271",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			handler, err := New(context.Background(), next, test.config, "waf-"+test.desc)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost"+test.target, nil)
+			req.Header.Set("User-Agent", "test")
+			req.Header.Set("Accept", "*/*")
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+		})
+	}
+}
+
+func TestWAF_auditLog(t *testing.T) {
+	auditLog := filepath.Join(t.TempDir(), "audit.log")
+
+	handler, err := New(context.Background(), http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), dynamic.WAF{
+		Directives: []string{testRule},
+		AuditLog:   &dynamic.WAFAuditLog{FilePath: auditLog},
+	}, "waf-audit")
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://localhost/?id=attack", nil))
+
+	assert.Equal(t, http.StatusForbidden, rw.Code)
+
+	data, err := os.ReadFile(auditLog)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Attack detected")
+}
+
+func TestWAF_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.WAF
+	}{
+		{
+			desc:   "invalid directive",
+			config: dynamic.WAF{Directives: []string{"SecUnknown On"}},
+		},
+		{
+			desc:   "unknown rules file",
+			config: dynamic.WAF{Directives: []string{"Include /unknown/rules.conf"}},
+		},
+		{
+			desc:   "unknown audit log format",
+			config: dynamic.WAF{AuditLog: &dynamic.WAFAuditLog{Format: "xml"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "waf")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGetWAF_reuse(t *testing.T) {
+	config := dynamic.WAF{Directives: []string{testRule}}
+
+	directives, err := buildDirectives(config)
+	require.NoError(t, err)
+
+	logger := zerolog.Nop()
+
+	first, err := getWAF(&logger, "waf-reuse", directives)
+	require.NoError(t, err)
+
+	second, err := getWAF(&logger, "waf-reuse", directives)
+	require.NoError(t, err)
+	// The WAFs are comparable values, wrapping a pointer.
+	assert.True(t, first == second)
+
+	third, err := getWAF(&logger, "waf-reuse", directives+"\nSecRuleEngine DetectionOnly")
+	require.NoError(t, err)
+	assert.False(t, first == third)
+}
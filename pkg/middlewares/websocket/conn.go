@@ -0,0 +1,243 @@
+package websocket
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// conn is a hijacked WebSocket connection, closed when it exceeds the limits of the policy.
+type conn struct {
+	net.Conn
+
+	// reader reads the bytes sent by the client, including the ones buffered before the connection has been hijacked.
+	reader io.Reader
+	meter  *messageMeter
+	logger *zerolog.Logger
+
+	lastActivity atomic.Int64
+
+	done      chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func newConn(netConn net.Conn, reader io.Reader, policy *webSocket, logger *zerolog.Logger) *conn {
+	c := &conn{
+		Conn:   netConn,
+		reader: reader,
+		logger: logger,
+		done:   make(chan struct{}),
+	}
+
+	if policy.maxMessageSize > 0 {
+		c.meter = &messageMeter{maxSize: policy.maxMessageSize}
+	}
+
+	c.touch()
+
+	if policy.maxLifetime > 0 || policy.idleTimeout > 0 {
+		go c.watch(policy.maxLifetime, policy.idleTimeout)
+	}
+
+	return c
+}
+
+// Read reads the bytes sent by the client, and closes the connection when a message exceeds the maximum size.
+func (c *conn) Read(b []byte) (int, error) {
+	n, err := c.reader.Read(b)
+	if n > 0 {
+		c.touch()
+
+		if c.meter != nil {
+			if meterErr := c.meter.observe(b[:n]); meterErr != nil {
+				c.closeWithReason(meterErr.Error())
+				return 0, meterErr
+			}
+		}
+	}
+
+	return n, err
+}
+
+// Write writes the bytes sent to the client.
+func (c *conn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.touch()
+	}
+
+	return n, err
+}
+
+// Close closes the connection.
+func (c *conn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.closeErr = c.Conn.Close()
+	})
+
+	return c.closeErr
+}
+
+func (c *conn) closeWithReason(reason string) {
+	c.logger.Debug().Msgf("Closing WebSocket connection: %s", reason)
+
+	if err := c.Close(); err != nil {
+		c.logger.Debug().Err(err).Msg("Error while closing WebSocket connection")
+	}
+}
+
+func (c *conn) touch() {
+	c.lastActivity.Store(time.Now().UnixNano())
+}
+
+// watch closes the connection once it reaches its maximum lifetime, or once it has been idle for too long.
+func (c *conn) watch(maxLifetime, idleTimeout time.Duration) {
+	var lifetime, idle <-chan time.Time
+
+	if maxLifetime > 0 {
+		lifetimeTimer := time.NewTimer(maxLifetime)
+		defer lifetimeTimer.Stop()
+
+		lifetime = lifetimeTimer.C
+	}
+
+	var idleTimer *time.Timer
+	if idleTimeout > 0 {
+		idleTimer = time.NewTimer(idleTimeout)
+		defer idleTimer.Stop()
+
+		idle = idleTimer.C
+	}
+
+	for {
+		select {
+		case <-c.done:
+			return
+
+		case <-lifetime:
+			c.closeWithReason("maximum lifetime reached")
+			return
+
+		case <-idle:
+			elapsed := time.Since(time.Unix(0, c.lastActivity.Load()))
+			if elapsed >= idleTimeout {
+				c.closeWithReason("idle timeout reached")
+				return
+			}
+
+			idleTimer.Reset(idleTimeout - elapsed)
+		}
+	}
+}
+
+// messageMeter follows the frames sent by a client, to measure the size of its messages.
+// It only reads the headers of the frames, the payloads are not buffered.
+type messageMeter struct {
+	maxSize int64
+
+	header    [14]byte
+	headerLen int
+	// remaining is the number of payload bytes of the current frame not read yet.
+	remaining   int64
+	messageSize int64
+}
+
+// observe reads the given bytes of the stream, and returns an error once a message exceeds the maximum size.
+func (m *messageMeter) observe(b []byte) error {
+	for len(b) > 0 {
+		if m.remaining > 0 {
+			n := min(int64(len(b)), m.remaining)
+			m.remaining -= n
+			b = b[n:]
+			continue
+		}
+
+		m.header[m.headerLen] = b[0]
+		m.headerLen++
+		b = b[1:]
+
+		if m.headerLen < headerSize(m.header[:m.headerLen]) {
+			continue
+		}
+
+		if err := m.readHeader(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *messageMeter) readHeader() error {
+	header := m.header[:m.headerLen]
+	m.headerLen = 0
+
+	opcode := header[0] & 0x0f
+
+	var length uint64
+	switch payloadLen := header[1] & 0x7f; payloadLen {
+	case 126:
+		length = uint64(header[2])<<8 | uint64(header[3])
+	case 127:
+		for _, b := range header[2:10] {
+			length = length<<8 | uint64(b)
+		}
+	default:
+		length = uint64(payloadLen)
+	}
+
+	if length > math.MaxInt64 {
+		return errors.New("invalid frame length")
+	}
+
+	m.remaining = int64(length)
+
+	// The control frames are not part of the messages.
+	if opcode >= 0x8 {
+		return nil
+	}
+
+	// A text or binary frame starts a new message, a continuation frame continues it.
+	if opcode != 0x0 {
+		m.messageSize = 0
+	}
+
+	if m.remaining > m.maxSize-m.messageSize {
+		return fmt.Errorf("message size exceeds the limit of %d bytes", m.maxSize)
+	}
+
+	m.messageSize += m.remaining
+
+	return nil
+}
+
+// headerSize returns the size of the frame header whose first bytes are given.
+func headerSize(header []byte) int {
+	if len(header) < 2 {
+		return 2
+	}
+
+	size := 2
+	switch header[1] & 0x7f {
+	case 126:
+		size += 2
+	case 127:
+		size += 8
+	}
+
+	// The frames sent by the clients are masked.
+	if header[1]&0x80 != 0 {
+		size += 4
+	}
+
+	return size
+}
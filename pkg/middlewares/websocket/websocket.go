@@ -0,0 +1,207 @@
+package websocket
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/metrics"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/http/httpguts"
+)
+
+const typeName = "WebSocket"
+
+// webSocket is a middleware applying limits to the WebSocket connections.
+type webSocket struct {
+	next                http.Handler
+	name                string
+	maxMessageSize      int64
+	maxLifetime         time.Duration
+	idleTimeout         time.Duration
+	allowedSubprotocols []string
+	maxConnections      int
+	connsGauge          gokitmetrics.Gauge
+
+	connsMu sync.Mutex
+	// conns holds the number of connections per router.
+	conns map[string]int
+}
+
+// New creates a WebSocket middleware.
+// The WebSocket connections are counted with the given metrics registry, which can be nil.
+func New(ctx context.Context, next http.Handler, config dynamic.WebSocket, name string, registry metrics.Registry) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if config.MaxMessageSize < 0 {
+		return nil, fmt.Errorf("negative maxMessageSize %d", config.MaxMessageSize)
+	}
+
+	if config.MaxLifetime < 0 {
+		return nil, fmt.Errorf("negative maxLifetime %s", time.Duration(config.MaxLifetime))
+	}
+
+	if config.IdleTimeout < 0 {
+		return nil, fmt.Errorf("negative idleTimeout %s", time.Duration(config.IdleTimeout))
+	}
+
+	if config.MaxConnections < 0 {
+		return nil, fmt.Errorf("negative maxConnections %d", config.MaxConnections)
+	}
+
+	ws := &webSocket{
+		next:                next,
+		name:                name,
+		maxMessageSize:      config.MaxMessageSize,
+		maxLifetime:         time.Duration(config.MaxLifetime),
+		idleTimeout:         time.Duration(config.IdleTimeout),
+		allowedSubprotocols: config.AllowedSubprotocols,
+		maxConnections:      config.MaxConnections,
+		conns:               make(map[string]int),
+	}
+
+	if registry != nil {
+		ws.connsGauge = registry.WebSocketConnsGauge()
+	}
+
+	return ws, nil
+}
+
+func (w *webSocket) GetTracingInformation() (string, string, trace.SpanKind) {
+	return w.name, typeName, trace.SpanKindInternal
+}
+
+func (w *webSocket) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if !isWebSocketUpgrade(req) {
+		w.next.ServeHTTP(rw, req)
+		return
+	}
+
+	if len(w.allowedSubprotocols) > 0 {
+		var subprotocols []string
+		for _, value := range req.Header.Values("Sec-WebSocket-Protocol") {
+			for _, subprotocol := range strings.Split(value, ",") {
+				subprotocol = strings.TrimSpace(subprotocol)
+				if slices.Contains(w.allowedSubprotocols, subprotocol) {
+					subprotocols = append(subprotocols, subprotocol)
+				}
+			}
+		}
+
+		if len(subprotocols) == 0 {
+			w.reject(rw, req, http.StatusBadRequest, "no allowed subprotocol")
+			return
+		}
+
+		req.Header.Set("Sec-WebSocket-Protocol", strings.Join(subprotocols, ", "))
+	}
+
+	router := middlewares.GetRouterName(req.Context())
+
+	if !w.acquire(router) {
+		w.reject(rw, req, http.StatusTooManyRequests, fmt.Sprintf("router %s has reached the limit of %d connections", router, w.maxConnections))
+		return
+	}
+	defer w.release(router)
+
+	// The reverse proxy handles the upgraded connection until it is closed.
+	if w.maxMessageSize > 0 || w.maxLifetime > 0 || w.idleTimeout > 0 {
+		w.next.ServeHTTP(&responseWriter{ResponseWriter: rw, req: req, policy: w}, req)
+		return
+	}
+
+	w.next.ServeHTTP(rw, req)
+}
+
+func (w *webSocket) acquire(router string) bool {
+	w.connsMu.Lock()
+	defer w.connsMu.Unlock()
+
+	if w.maxConnections > 0 && w.conns[router] >= w.maxConnections {
+		return false
+	}
+
+	w.conns[router]++
+
+	if w.connsGauge != nil {
+		w.connsGauge.With("middleware", w.name, "router", router).Add(1)
+	}
+
+	return true
+}
+
+func (w *webSocket) release(router string) {
+	w.connsMu.Lock()
+	defer w.connsMu.Unlock()
+
+	w.conns[router]--
+	if w.conns[router] == 0 {
+		delete(w.conns, router)
+	}
+
+	if w.connsGauge != nil {
+		w.connsGauge.With("middleware", w.name, "router", router).Add(-1)
+	}
+}
+
+func (w *webSocket) reject(rw http.ResponseWriter, req *http.Request, statusCode int, reason string) {
+	logger := middlewares.GetLogger(req.Context(), w.name, typeName)
+	logger.Debug().Msgf("Rejecting WebSocket handshake: %s", reason)
+
+	observability.SetStatusErrorf(req.Context(), "Rejecting WebSocket handshake: %s", reason)
+
+	rw.WriteHeader(statusCode)
+	if _, err := rw.Write([]byte(http.StatusText(statusCode))); err != nil {
+		log.Ctx(req.Context()).Error().Err(err).Send()
+	}
+}
+
+// responseWriter applies the limits of the policy to the connection hijacked by the reverse proxy.
+type responseWriter struct {
+	http.ResponseWriter
+
+	req    *http.Request
+	policy *webSocket
+}
+
+// Hijack hijacks the connection, and returns it wrapped to apply the limits.
+func (r *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("%T is not a http.Hijacker", r.ResponseWriter)
+	}
+
+	conn, brw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger := middlewares.GetLogger(r.req.Context(), r.policy.name, typeName)
+	c := newConn(conn, brw.Reader, r.policy, logger)
+
+	// The bytes sent by the client and already buffered are read through the wrapped connection.
+	return c, bufio.NewReadWriter(bufio.NewReader(c), brw.Writer), nil
+}
+
+// Flush sends any buffered data to the client.
+func (r *responseWriter) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func isWebSocketUpgrade(req *http.Request) bool {
+	return httpguts.HeaderValuesContainsToken(req.Header["Connection"], "Upgrade") &&
+		strings.EqualFold(req.Header.Get("Upgrade"), "websocket")
+}
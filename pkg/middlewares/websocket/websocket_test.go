@@ -0,0 +1,374 @@
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/metrics"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/testhelpers"
+)
+
+func TestNew_invalidConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config dynamic.WebSocket
+	}{
+		{
+			desc:   "negative max message size",
+			config: dynamic.WebSocket{MaxMessageSize: -1},
+		},
+		{
+			desc:   "negative max lifetime",
+			config: dynamic.WebSocket{MaxLifetime: ptypes.Duration(-time.Second)},
+		},
+		{
+			desc:   "negative idle timeout",
+			config: dynamic.WebSocket{IdleTimeout: ptypes.Duration(-time.Second)},
+		},
+		{
+			desc:   "negative max connections",
+			config: dynamic.WebSocket{MaxConnections: -1},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(context.Background(), http.NotFoundHandler(), test.config, "websocket", nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWebSocket_notWebSocket(t *testing.T) {
+	handler, err := New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	}), dynamic.WebSocket{AllowedSubprotocols: []string{"chat"}, MaxConnections: 1}, "websocket", nil)
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+}
+
+func TestWebSocket_maxMessageSize(t *testing.T) {
+	wsURL := newProxy(t, dynamic.WebSocket{MaxMessageSize: 10}, nil)
+
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	require.NoError(t, conn.WriteMessage(gorillawebsocket.TextMessage, []byte("hello")))
+
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(message))
+
+	require.NoError(t, conn.WriteMessage(gorillawebsocket.TextMessage, []byte("hello, world!")))
+
+	_, _, err = conn.ReadMessage()
+	assert.Error(t, err)
+}
+
+func TestWebSocket_maxLifetime(t *testing.T) {
+	wsURL := newProxy(t, dynamic.WebSocket{MaxLifetime: ptypes.Duration(200 * time.Millisecond)}, nil)
+
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	start := time.Now()
+
+	// The connection is closed even if it is active.
+	go func() {
+		for {
+			if err := conn.WriteMessage(gorillawebsocket.TextMessage, []byte("ping")); err != nil {
+				return
+			}
+
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+
+	for {
+		if _, _, err = conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestWebSocket_idleTimeout(t *testing.T) {
+	wsURL := newProxy(t, dynamic.WebSocket{IdleTimeout: ptypes.Duration(200 * time.Millisecond)}, nil)
+
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	// The connection is kept open while it is active.
+	for range 5 {
+		require.NoError(t, conn.WriteMessage(gorillawebsocket.TextMessage, []byte("ping")))
+
+		_, _, err = conn.ReadMessage()
+		require.NoError(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	start := time.Now()
+
+	_, _, err = conn.ReadMessage()
+	require.Error(t, err)
+
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestWebSocket_allowedSubprotocols(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		subprotocols        []string
+		expectedStatusCode  int
+		expectedSubprotocol string
+	}{
+		{
+			desc:                "allowed subprotocol",
+			subprotocols:        []string{"superchat", "chat"},
+			expectedStatusCode:  http.StatusSwitchingProtocols,
+			expectedSubprotocol: "chat",
+		},
+		{
+			desc:               "subprotocol not allowed",
+			subprotocols:       []string{"superchat"},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "no subprotocol",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	wsURL := newProxy(t, dynamic.WebSocket{AllowedSubprotocols: []string{"chat"}}, nil)
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dialer := gorillawebsocket.Dialer{Subprotocols: test.subprotocols}
+
+			conn, resp, err := dialer.Dial(wsURL, nil)
+			if conn != nil {
+				t.Cleanup(func() { _ = conn.Close() })
+			}
+
+			require.NotNil(t, resp)
+			assert.Equal(t, test.expectedStatusCode, resp.StatusCode)
+
+			if test.expectedStatusCode != http.StatusSwitchingProtocols {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedSubprotocol, conn.Subprotocol())
+		})
+	}
+}
+
+func TestWebSocket_maxConnections(t *testing.T) {
+	registry := &collectingRegistry{Registry: metrics.NewVoidRegistry(), gauge: &testhelpers.CollectingGauge{}}
+	wsURL := newProxy(t, dynamic.WebSocket{MaxConnections: 1}, registry)
+
+	first, _, err := gorillawebsocket.DefaultDialer.Dial(wsURL+"/a", nil)
+	require.NoError(t, err)
+
+	assert.InDelta(t, 1, registry.gauge.GaugeValue, 0)
+	assert.Equal(t, []string{"middleware", "websocket", "router", "a"}, registry.gauge.LastLabelValues)
+
+	_, resp, err := gorillawebsocket.DefaultDialer.Dial(wsURL+"/a", nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	// The connections are limited per router.
+	other, _, err := gorillawebsocket.DefaultDialer.Dial(wsURL+"/b", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = other.Close() })
+
+	require.NoError(t, first.Close())
+
+	assert.Eventually(t, func() bool {
+		conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsURL+"/a", nil)
+		if err != nil {
+			return false
+		}
+
+		t.Cleanup(func() { _ = conn.Close() })
+		return true
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestMessageMeter(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		frames      [][]byte
+		expectError bool
+	}{
+		{
+			desc:   "messages within the limit",
+			frames: [][]byte{frame(true, 0x1, 10), frame(true, 0x2, 10)},
+		},
+		{
+			desc:        "message exceeding the limit",
+			frames:      [][]byte{frame(true, 0x1, 11)},
+			expectError: true,
+		},
+		{
+			desc:        "fragmented message exceeding the limit",
+			frames:      [][]byte{frame(false, 0x1, 6), frame(true, 0x0, 5)},
+			expectError: true,
+		},
+		{
+			desc:   "fragmented message with interleaved control frames",
+			frames: [][]byte{frame(false, 0x1, 5), frame(true, 0x9, 100), frame(true, 0x0, 5)},
+		},
+		{
+			desc:        "message with a 16 bits length exceeding the limit",
+			frames:      [][]byte{frame(true, 0x2, 300)},
+			expectError: true,
+		},
+		{
+			desc:        "message with a 64 bits length exceeding the limit",
+			frames:      [][]byte{frame(true, 0x2, 70000)},
+			expectError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var stream []byte
+			for _, f := range test.frames {
+				stream = append(stream, f...)
+			}
+
+			// The stream is read one byte at a time, to check the frames split across reads.
+			meter := &messageMeter{maxSize: 10}
+
+			var err error
+			for i := 0; i < len(stream) && err == nil; i++ {
+				err = meter.observe(stream[i : i+1])
+			}
+
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// newProxy returns the URL of a reverse proxy to an echo WebSocket server, applying the given policy.
+// The requests to /a and /b are handled by the routers a and b.
+func newProxy(t *testing.T, config dynamic.WebSocket, registry metrics.Registry) string {
+	t.Helper()
+
+	upgrader := gorillawebsocket.Upgrader{Subprotocols: []string{"chat", "superchat"}}
+	backend := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(rw, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			messageType, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			if err := conn.WriteMessage(messageType, message); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(backend.Close)
+
+	target, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+
+	handler, err := New(context.Background(), httputil.NewSingleHostReverseProxy(target), config, "websocket", registry)
+	require.NoError(t, err)
+
+	routers := make(map[string]http.Handler)
+	for _, router := range []string{"a", "b"} {
+		routers[router], err = middlewares.WrapRouterName(router)(handler)
+		require.NoError(t, err)
+	}
+
+	front := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if router, ok := routers[strings.TrimPrefix(req.URL.Path, "/")]; ok {
+			router.ServeHTTP(rw, req)
+			return
+		}
+
+		handler.ServeHTTP(rw, req)
+	}))
+	t.Cleanup(front.Close)
+
+	return "ws" + strings.TrimPrefix(front.URL, "http")
+}
+
+// frame returns the header of a masked client frame, followed by its payload.
+func frame(fin bool, opcode byte, length int) []byte {
+	b := []byte{opcode, 0x80}
+	if fin {
+		b[0] |= 0x80
+	}
+
+	switch {
+	case length < 126:
+		b[1] |= byte(length)
+	case length <= 0xffff:
+		b[1] |= 126
+		b = append(b, byte(length>>8), byte(length))
+	default:
+		b[1] |= 127
+		for i := 7; i >= 0; i-- {
+			b = append(b, byte(length>>(8*i)))
+		}
+	}
+
+	// Masking key.
+	b = append(b, 1, 2, 3, 4)
+
+	return append(b, make([]byte, length)...)
+}
+
+type collectingRegistry struct {
+	metrics.Registry
+
+	gauge *testhelpers.CollectingGauge
+}
+
+func (r *collectingRegistry) WebSocketConnsGauge() gokitmetrics.Gauge {
+	return r.gauge
+}
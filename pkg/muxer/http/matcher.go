@@ -1,30 +1,49 @@
 package http
 
 import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/rs/zerolog/log"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/traefik/traefik/v3/pkg/geoip"
 	"github.com/traefik/traefik/v3/pkg/ip"
 	"github.com/traefik/traefik/v3/pkg/middlewares/requestdecorator"
+	"github.com/traefik/traefik/v3/pkg/proxyprotocol"
 	"golang.org/x/exp/slices"
 )
 
 var httpFuncs = map[string]func(*matchersTree, ...string) error{
-	"ClientIP":     expectNParameters(clientIP, 1),
-	"Method":       expectNParameters(method, 1),
-	"Host":         expectNParameters(host, 1),
-	"HostRegexp":   expectNParameters(hostRegexp, 1),
-	"Path":         expectNParameters(path, 1),
-	"PathRegexp":   expectNParameters(pathRegexp, 1),
-	"PathPrefix":   expectNParameters(pathPrefix, 1),
-	"Header":       expectNParameters(header, 2),
-	"HeaderRegexp": expectNParameters(headerRegexp, 2),
-	"Query":        expectNParameters(query, 1, 2),
-	"QueryRegexp":  expectNParameters(queryRegexp, 1, 2),
+	"ClientIP":         expectNParameters(clientIP, 1),
+	"ClientCountry":    expectNParameters(clientCountry(nil), 1),
+	"ClientCertSPIFFE": expectNParameters(clientCertSPIFFE, 1),
+	"ClientCertCN":     expectNParameters(clientCertCN, 1),
+	"ClientCertOU":     expectNParameters(clientCertOU, 1),
+	"ClientCertSAN":    expectNParameters(clientCertSAN, 1),
+	"Claim":            expectNParameters(claim, 2),
+	"Time":             expectNParameters(timeMatcher, 1, 2),
+	"Method":           expectNParameters(method, 1),
+	"Host":             expectNParameters(host, 1),
+	"HostRegexp":       expectNParameters(hostRegexp, 1),
+	"Path":             expectNParameters(path, 1),
+	"PathRegexp":       expectNParameters(pathRegexp, 1),
+	"PathPrefix":       expectNParameters(pathPrefix, 1),
+	"Header":           expectNParameters(header, 2),
+	"HeaderRegexp":     expectNParameters(headerRegexp, 2),
+	"Query":            expectNParameters(query, 1, 2),
+	"QueryRegexp":      expectNParameters(queryRegexp, 1, 2),
+	"ProxyProtocolTLV": expectNParameters(proxyProtocolTLV, 2),
 }
 
 func expectNParameters(fn func(*matchersTree, ...string) error, n ...int) func(*matchersTree, ...string) error {
@@ -58,6 +77,380 @@ func clientIP(tree *matchersTree, clientIP ...string) error {
 	return nil
 }
 
+// clientCountry returns the ClientCountry matcher, resolving the country of the remote address with the given resolver.
+func clientCountry(resolver *geoip.Resolver) func(*matchersTree, ...string) error {
+	return func(tree *matchersTree, countries ...string) error {
+		if resolver == nil {
+			return errors.New("no GeoIP database configured for ClientCountry matcher")
+		}
+
+		country := strings.ToUpper(countries[0])
+		strategy := ip.RemoteAddrStrategy{}
+
+		tree.matcher = func(req *http.Request) bool {
+			location, err := resolver.Lookup(net.ParseIP(strategy.GetIP(req)))
+			if err != nil {
+				log.Ctx(req.Context()).Debug().Err(err).Msg("ClientCountry matcher: could not resolve remote address country")
+				return false
+			}
+
+			return location.CountryCode == country
+		}
+
+		return nil
+	}
+}
+
+// clientCertSPIFFE matches the SPIFFE ID of the client certificate against the given pattern,
+// where the `*` wildcard matches any sequence of characters in the path of the ID.
+func clientCertSPIFFE(tree *matchersTree, patterns ...string) error {
+	pattern := patterns[0]
+
+	rawTrustDomain, rawPath, hasPath := strings.Cut(strings.TrimPrefix(pattern, "spiffe://"), "/")
+	if hasPath {
+		rawPath = "/" + rawPath
+	}
+	if !strings.HasPrefix(pattern, "spiffe://") || strings.Contains(rawTrustDomain, "*") {
+		return fmt.Errorf("invalid SPIFFE ID pattern %q: must start with spiffe://<trust domain>", pattern)
+	}
+
+	trustDomain, err := spiffeid.TrustDomainFromString(rawTrustDomain)
+	if err != nil {
+		return fmt.Errorf("invalid SPIFFE ID pattern %q: %w", pattern, err)
+	}
+
+	pathRe, err := wildcardRegexp(rawPath)
+	if err != nil {
+		return fmt.Errorf("compiling SPIFFE ID pattern %q: %w", pattern, err)
+	}
+
+	tree.matcher = func(req *http.Request) bool {
+		cert := clientCertificate(req)
+		if cert == nil {
+			return false
+		}
+
+		id, err := x509svid.IDFromCert(cert)
+		if err != nil {
+			log.Ctx(req.Context()).Debug().Err(err).Msg("ClientCertSPIFFE matcher: could not extract the SPIFFE ID of the client certificate")
+			return false
+		}
+
+		return id.MemberOf(trustDomain) && pathRe.MatchString(id.Path())
+	}
+
+	return nil
+}
+
+// clientCertCN matches the common name of the subject of the client certificate against the given pattern,
+// where the `*` wildcard matches any sequence of characters.
+func clientCertCN(tree *matchersTree, patterns ...string) error {
+	re, err := wildcardRegexp(patterns[0])
+	if err != nil {
+		return fmt.Errorf("compiling ClientCertCN pattern %q: %w", patterns[0], err)
+	}
+
+	tree.matcher = func(req *http.Request) bool {
+		cert := clientCertificate(req)
+		return cert != nil && re.MatchString(cert.Subject.CommonName)
+	}
+
+	return nil
+}
+
+// clientCertOU matches the organizational units of the subject of the client certificate against the given pattern,
+// where the `*` wildcard matches any sequence of characters.
+func clientCertOU(tree *matchersTree, patterns ...string) error {
+	re, err := wildcardRegexp(patterns[0])
+	if err != nil {
+		return fmt.Errorf("compiling ClientCertOU pattern %q: %w", patterns[0], err)
+	}
+
+	tree.matcher = func(req *http.Request) bool {
+		cert := clientCertificate(req)
+		return cert != nil && slices.ContainsFunc(cert.Subject.OrganizationalUnit, re.MatchString)
+	}
+
+	return nil
+}
+
+// clientCertSAN matches the subject alternative names of the client certificate (DNS names, email addresses,
+// IP addresses and URIs) against the given pattern, where the `*` wildcard matches any sequence of characters.
+func clientCertSAN(tree *matchersTree, patterns ...string) error {
+	re, err := wildcardRegexp(patterns[0])
+	if err != nil {
+		return fmt.Errorf("compiling ClientCertSAN pattern %q: %w", patterns[0], err)
+	}
+
+	tree.matcher = func(req *http.Request) bool {
+		cert := clientCertificate(req)
+		if cert == nil {
+			return false
+		}
+
+		if slices.ContainsFunc(cert.DNSNames, re.MatchString) || slices.ContainsFunc(cert.EmailAddresses, re.MatchString) {
+			return true
+		}
+
+		for _, address := range cert.IPAddresses {
+			if re.MatchString(address.String()) {
+				return true
+			}
+		}
+
+		for _, uri := range cert.URIs {
+			if re.MatchString(uri.String()) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return nil
+}
+
+// clientCertificate returns the leaf certificate sent by the client, if any.
+func clientCertificate(req *http.Request) *x509.Certificate {
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+
+	return req.TLS.PeerCertificates[0]
+}
+
+// wildcardRegexp compiles the given pattern, where the `*` wildcard matches any sequence of characters,
+// into a regexp matching the whole string.
+func wildcardRegexp(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i, part := range strings.Split(pattern, "*") {
+		if i > 0 {
+			expr.WriteString(".*")
+		}
+		expr.WriteString(regexp.QuoteMeta(part))
+	}
+	expr.WriteString("$")
+
+	return regexp.Compile(expr.String())
+}
+
+// claim matches the claims of the bearer token of the request, without verifying the token.
+// The name of a nested claim is made of the names of its parents, separated by dots,
+// and a value matches an array claim containing it.
+func claim(tree *matchersTree, claims ...string) error {
+	name, value := claims[0], claims[1]
+	if name == "" {
+		return errors.New("empty claim name for Claim matcher")
+	}
+
+	tree.matcher = func(req *http.Request) bool {
+		payload, err := bearerTokenClaims(req)
+		if err != nil {
+			log.Ctx(req.Context()).Debug().Err(err).Msg("Claim matcher: could not decode the bearer token")
+			return false
+		}
+
+		return claimContains(lookupClaim(payload, name), value)
+	}
+
+	return nil
+}
+
+// bearerTokenClaims decodes the claims of the JWT sent as bearer token in the Authorization header.
+func bearerTokenClaims(req *http.Request) (map[string]interface{}, error) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(req.Header.Get("Authorization")), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return nil, nil
+	}
+
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("decoding token payload: %w", err)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, fmt.Errorf("unmarshaling token payload: %w", err)
+	}
+
+	return payload, nil
+}
+
+// lookupClaim returns the claim with the given name, which is either a top-level claim, or a path to a nested claim.
+func lookupClaim(claims map[string]interface{}, name string) interface{} {
+	if value, ok := claims[name]; ok {
+		return value
+	}
+
+	parent, child, found := strings.Cut(name, ".")
+	if !found {
+		return nil
+	}
+
+	nested, ok := claims[parent].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	return lookupClaim(nested, child)
+}
+
+// claimContains reports whether the claim is the given value, or an array containing it.
+func claimContains(claim interface{}, value string) bool {
+	switch v := claim.(type) {
+	case string:
+		return v == value
+	case bool:
+		return strconv.FormatBool(v) == value
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64) == value
+	case []interface{}:
+		return slices.ContainsFunc(v, func(item interface{}) bool {
+			return claimContains(item, value)
+		})
+	default:
+		return false
+	}
+}
+
+// timeMatcher matches the requests received during a weekly time window,
+// in the time zone given as second parameter (UTC by default).
+func timeMatcher(tree *matchersTree, params ...string) error {
+	window, err := parseTimeWindow(params[0])
+	if err != nil {
+		return fmt.Errorf("parsing time window %q for Time matcher: %w", params[0], err)
+	}
+
+	location := time.UTC
+	if len(params) > 1 {
+		location, err = time.LoadLocation(params[1])
+		if err != nil {
+			return fmt.Errorf("loading time zone %q for Time matcher: %w", params[1], err)
+		}
+	}
+
+	tree.matcher = func(_ *http.Request) bool {
+		return window.contains(time.Now().In(location))
+	}
+
+	return nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// timeWindow is a time of day range, repeated on some days of the week.
+type timeWindow struct {
+	days [7]bool
+	// start and end are the durations since midnight, the end being excluded.
+	start time.Duration
+	end   time.Duration
+}
+
+// parseTimeWindow parses a time window such as "Mon-Fri 09:00-17:00",
+// made of optional days, separated by commas or given as ranges, and of a time range, which can cross midnight.
+func parseTimeWindow(value string) (timeWindow, error) {
+	var window timeWindow
+
+	fields := strings.Fields(value)
+
+	switch len(fields) {
+	case 1:
+		for i := range window.days {
+			window.days[i] = true
+		}
+
+	case 2:
+		for _, days := range strings.Split(fields[0], ",") {
+			first, last, found := strings.Cut(days, "-")
+			if !found {
+				last = first
+			}
+
+			from, ok := weekdays[strings.ToLower(first)]
+			if !ok {
+				return timeWindow{}, fmt.Errorf("unknown day %q", first)
+			}
+
+			to, ok := weekdays[strings.ToLower(last)]
+			if !ok {
+				return timeWindow{}, fmt.Errorf("unknown day %q", last)
+			}
+
+			// A range such as Fri-Mon wraps around the end of the week.
+			for day := from; ; day = (day + 1) % 7 {
+				window.days[day] = true
+				if day == to {
+					break
+				}
+			}
+		}
+
+	default:
+		return timeWindow{}, errors.New("expected optional days followed by a time range")
+	}
+
+	start, end, found := strings.Cut(fields[len(fields)-1], "-")
+	if !found {
+		return timeWindow{}, fmt.Errorf("invalid time range %q", fields[len(fields)-1])
+	}
+
+	var err error
+	if window.start, err = parseTimeOfDay(start); err != nil {
+		return timeWindow{}, err
+	}
+
+	if window.end, err = parseTimeOfDay(end); err != nil {
+		return timeWindow{}, err
+	}
+
+	if window.start == window.end || window.start == 24*time.Hour {
+		return timeWindow{}, fmt.Errorf("empty time range %q", fields[len(fields)-1])
+	}
+
+	return window, nil
+}
+
+// parseTimeOfDay parses a time of day in the HH:MM format, 24:00 being the end of the day.
+func parseTimeOfDay(value string) (time.Duration, error) {
+	if value == "24:00" {
+		return 24 * time.Hour, nil
+	}
+
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: expected HH:MM", value)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// contains reports whether the given time is in the window.
+// When the time range crosses midnight, its part after midnight belongs to the day it started on.
+func (w timeWindow) contains(t time.Time) bool {
+	day := t.Weekday()
+	since := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+
+	if w.start < w.end {
+		return w.days[day] && since >= w.start && since < w.end
+	}
+
+	return (w.days[day] && since >= w.start) || (w.days[(day+6)%7] && since < w.end)
+}
+
 func method(tree *matchersTree, methods ...string) error {
 	method := strings.ToUpper(methods[0])
 
@@ -177,6 +570,23 @@ func pathPrefix(tree *matchersTree, paths ...string) error {
 	return nil
 }
 
+// proxyProtocolTLV matches the value of the given TLV of the PROXY protocol header the connection was received with.
+func proxyProtocolTLV(tree *matchersTree, tlv ...string) error {
+	lookup, err := proxyprotocol.NewTLVLookup(tlv[0])
+	if err != nil {
+		return fmt.Errorf("invalid TLV for ProxyProtocolTLV matcher: %w", err)
+	}
+
+	expected := tlv[1]
+
+	tree.matcher = func(req *http.Request) bool {
+		value, ok := lookup(proxyprotocol.FromContext(req.Context()))
+		return ok && value == expected
+	}
+
+	return nil
+}
+
 func header(tree *matchersTree, headers ...string) error {
 	key, value := http.CanonicalHeaderKey(headers[0]), headers[1]
 
@@ -1,14 +1,24 @@
 package http
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/pires/go-proxyproto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/geoip"
 	"github.com/traefik/traefik/v3/pkg/middlewares/requestdecorator"
+	"github.com/traefik/traefik/v3/pkg/proxyprotocol"
 )
 
 func TestClientIPMatcher(t *testing.T) {
@@ -95,6 +105,585 @@ func TestClientIPMatcher(t *testing.T) {
 	}
 }
 
+func TestClientCountryMatcher(t *testing.T) {
+	resolver, err := geoip.NewResolver([]string{"../../geoip/fixtures/country.mmdb"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc          string
+		rule          string
+		resolver      *geoip.Resolver
+		expected      map[string]int
+		expectedError bool
+	}{
+		{
+			desc:          "no GeoIP resolver",
+			rule:          "ClientCountry(`FR`)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid ClientCountry matcher (no parameter)",
+			rule:          "ClientCountry()",
+			resolver:      resolver,
+			expectedError: true,
+		},
+		{
+			desc:          "invalid ClientCountry matcher (too many parameters)",
+			rule:          "ClientCountry(`FR`, `US`)",
+			resolver:      resolver,
+			expectedError: true,
+		},
+		{
+			desc:     "valid ClientCountry matcher",
+			rule:     "ClientCountry(`FR`)",
+			resolver: resolver,
+			expected: map[string]int{
+				"1.2.3.4":      http.StatusOK,
+				"2.3.4.5":      http.StatusNotFound,
+				"10.0.0.1":     http.StatusNotFound,
+				"2a00:1450::1": http.StatusNotFound,
+			},
+		},
+		{
+			desc:     "valid lowercase ClientCountry matcher",
+			rule:     "ClientCountry(`de`)",
+			resolver: resolver,
+			expected: map[string]int{
+				"1.2.3.4":      http.StatusNotFound,
+				"2a00:1450::1": http.StatusOK,
+			},
+		},
+		{
+			desc:     "valid ClientCountry matcher but invalid remote address",
+			rule:     "ClientCountry(`FR`)",
+			resolver: resolver,
+			expected: map[string]int{
+				"1": http.StatusNotFound,
+			},
+		},
+		{
+			desc:     "negated ClientCountry matcher",
+			rule:     "!ClientCountry(`FR`)",
+			resolver: resolver,
+			expected: map[string]int{
+				"1.2.3.4": http.StatusNotFound,
+				"2.3.4.5": http.StatusOK,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			if test.resolver != nil {
+				muxer.SetGeoIPResolver(test.resolver)
+			}
+
+			err = muxer.AddRoute(test.rule, "", 0, handler)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			results := make(map[string]int)
+			for remoteAddr := range test.expected {
+				w := httptest.NewRecorder()
+
+				req := httptest.NewRequest(http.MethodGet, "https://example.com", http.NoBody)
+				req.RemoteAddr = remoteAddr
+
+				muxer.ServeHTTP(w, req)
+				results[remoteAddr] = w.Code
+			}
+			assert.Equal(t, test.expected, results)
+		})
+	}
+}
+
+func TestClientCertSPIFFEMatcher(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		rule          string
+		expected      map[string]int
+		expectedError bool
+	}{
+		{
+			desc:          "invalid ClientCertSPIFFE matcher (no parameter)",
+			rule:          "ClientCertSPIFFE()",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid ClientCertSPIFFE matcher (no scheme)",
+			rule:          "ClientCertSPIFFE(`example.org/ns/*`)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid ClientCertSPIFFE matcher (wildcard trust domain)",
+			rule:          "ClientCertSPIFFE(`spiffe://*.org/ns/*`)",
+			expectedError: true,
+		},
+		{
+			desc: "valid ClientCertSPIFFE matcher",
+			rule: "ClientCertSPIFFE(`spiffe://example.org/ns/prod/sa/web`)",
+			expected: map[string]int{
+				"":                                    http.StatusNotFound,
+				"https://example.org/ns/prod/sa/web":  http.StatusNotFound,
+				"spiffe://example.org/ns/prod/sa/web": http.StatusOK,
+				"spiffe://example.org/ns/prod/sa/api": http.StatusNotFound,
+				"spiffe://other.org/ns/prod/sa/web":   http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid ClientCertSPIFFE matcher with wildcard",
+			rule: "ClientCertSPIFFE(`spiffe://example.org/ns/prod/*`)",
+			expected: map[string]int{
+				"spiffe://example.org/ns/prod/sa/web": http.StatusOK,
+				"spiffe://example.org/ns/prod/sa/api": http.StatusOK,
+				"spiffe://example.org/ns/dev/sa/web":  http.StatusNotFound,
+				"spiffe://example.org/ns/prod":        http.StatusNotFound,
+				"spiffe://other.org/ns/prod/sa/web":   http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid ClientCertSPIFFE matcher with inner wildcard",
+			rule: "ClientCertSPIFFE(`spiffe://example.org/ns/*/sa/web`)",
+			expected: map[string]int{
+				"spiffe://example.org/ns/prod/sa/web": http.StatusOK,
+				"spiffe://example.org/ns/dev/sa/web":  http.StatusOK,
+				"spiffe://example.org/ns/prod/sa/api": http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid ClientCertSPIFFE matcher on a trust domain",
+			rule: "ClientCertSPIFFE(`spiffe://example.org/*`)",
+			expected: map[string]int{
+				"spiffe://example.org/ns/prod/sa/web": http.StatusOK,
+				"spiffe://other.org/ns/prod/sa/web":   http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			err = muxer.AddRoute(test.rule, "", 0, handler)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			results := make(map[string]int)
+			for uri := range test.expected {
+				w := httptest.NewRecorder()
+
+				req := httptest.NewRequest(http.MethodGet, "https://example.com", http.NoBody)
+				if uri != "" {
+					certURI, err := url.Parse(uri)
+					require.NoError(t, err)
+
+					req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{certURI}}}}
+				}
+
+				muxer.ServeHTTP(w, req)
+				results[uri] = w.Code
+			}
+			assert.Equal(t, test.expected, results)
+		})
+	}
+}
+
+func TestClientCertMatchers(t *testing.T) {
+	partner := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "api.partner.com", OrganizationalUnit: []string{"Partners", "Billing"}},
+		DNSNames:       []string{"api.partner.com"},
+		EmailAddresses: []string{"ops@partner.com"},
+	}
+
+	internal := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "billing", OrganizationalUnit: []string{"Internal"}},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:        []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/billing"}},
+	}
+
+	testCases := []struct {
+		desc          string
+		rule          string
+		expected      map[string]int
+		expectedError bool
+	}{
+		{
+			desc:          "invalid ClientCertCN matcher (no parameter)",
+			rule:          "ClientCertCN()",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid ClientCertOU matcher (two parameters)",
+			rule:          "ClientCertOU(`Partners`, `Internal`)",
+			expectedError: true,
+		},
+		{
+			desc: "valid ClientCertCN matcher",
+			rule: "ClientCertCN(`billing`)",
+			expected: map[string]int{
+				"none":     http.StatusNotFound,
+				"partner":  http.StatusNotFound,
+				"internal": http.StatusOK,
+			},
+		},
+		{
+			desc: "valid ClientCertCN matcher with wildcard",
+			rule: "ClientCertCN(`*.partner.com`)",
+			expected: map[string]int{
+				"none":     http.StatusNotFound,
+				"partner":  http.StatusOK,
+				"internal": http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid ClientCertOU matcher",
+			rule: "ClientCertOU(`Billing`)",
+			expected: map[string]int{
+				"none":     http.StatusNotFound,
+				"partner":  http.StatusOK,
+				"internal": http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid ClientCertSAN matcher on a DNS name",
+			rule: "ClientCertSAN(`*.partner.com`)",
+			expected: map[string]int{
+				"none":     http.StatusNotFound,
+				"partner":  http.StatusOK,
+				"internal": http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid ClientCertSAN matcher on an email address",
+			rule: "ClientCertSAN(`*@partner.com`)",
+			expected: map[string]int{
+				"partner":  http.StatusOK,
+				"internal": http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid ClientCertSAN matcher on an IP address",
+			rule: "ClientCertSAN(`10.0.0.*`)",
+			expected: map[string]int{
+				"partner":  http.StatusNotFound,
+				"internal": http.StatusOK,
+			},
+		},
+		{
+			desc: "valid ClientCertSAN matcher on a URI",
+			rule: "ClientCertSAN(`spiffe://example.org/billing`)",
+			expected: map[string]int{
+				"partner":  http.StatusNotFound,
+				"internal": http.StatusOK,
+			},
+		},
+	}
+
+	certs := map[string]*x509.Certificate{
+		"partner":  partner,
+		"internal": internal,
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			err = muxer.AddRoute(test.rule, "", 0, handler)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			results := make(map[string]int)
+			for name := range test.expected {
+				w := httptest.NewRecorder()
+
+				req := httptest.NewRequest(http.MethodGet, "https://example.com", http.NoBody)
+				if cert, ok := certs[name]; ok {
+					req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+				}
+
+				muxer.ServeHTTP(w, req)
+				results[name] = w.Code
+			}
+			assert.Equal(t, test.expected, results)
+		})
+	}
+}
+
+func TestClaimMatcher(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		rule          string
+		expected      map[string]int
+		expectedError bool
+	}{
+		{
+			desc:          "invalid Claim matcher (one parameter)",
+			rule:          "Claim(`role`)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid Claim matcher (empty name)",
+			rule:          "Claim(``, `admin`)",
+			expectedError: true,
+		},
+		{
+			desc: "valid Claim matcher",
+			rule: "Claim(`role`, `admin`)",
+			expected: map[string]int{
+				"":                           http.StatusNotFound,
+				"Basic Zm9vOmJhcg==":         http.StatusNotFound,
+				"Bearer malformed":           http.StatusNotFound,
+				bearer(`{"role":"admin"}`):   http.StatusOK,
+				bearer(`{"role":"user"}`):    http.StatusNotFound,
+				bearer(`{"other":"admin"}`):  http.StatusNotFound,
+				bearer(`{"role":["admin"]}`): http.StatusOK,
+			},
+		},
+		{
+			desc: "valid Claim matcher on an array",
+			rule: "Claim(`groups`, `ops`)",
+			expected: map[string]int{
+				bearer(`{"groups":["dev","ops"]}`): http.StatusOK,
+				bearer(`{"groups":["dev"]}`):       http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid Claim matcher on a nested claim",
+			rule: "Claim(`realm_access.roles`, `admin`)",
+			expected: map[string]int{
+				bearer(`{"realm_access":{"roles":["admin"]}}`): http.StatusOK,
+				bearer(`{"realm_access.roles":"admin"}`):       http.StatusOK,
+				bearer(`{"realm_access":{"roles":["user"]}}`):  http.StatusNotFound,
+				bearer(`{"realm_access":"admin"}`):             http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid Claim matcher on non-string claims",
+			rule: "Claim(`admin`, `true`) || Claim(`level`, `42`)",
+			expected: map[string]int{
+				bearer(`{"admin":true}`):  http.StatusOK,
+				bearer(`{"admin":false}`): http.StatusNotFound,
+				bearer(`{"level":42}`):    http.StatusOK,
+				bearer(`{"level":4.2}`):   http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			err = muxer.AddRoute(test.rule, "", 0, handler)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			results := make(map[string]int)
+			for authorization := range test.expected {
+				w := httptest.NewRecorder()
+
+				req := httptest.NewRequest(http.MethodGet, "https://example.com", http.NoBody)
+				if authorization != "" {
+					req.Header.Set("Authorization", authorization)
+				}
+
+				muxer.ServeHTTP(w, req)
+				results[authorization] = w.Code
+			}
+			assert.Equal(t, test.expected, results)
+		})
+	}
+}
+
+// bearer returns the Authorization header value of an unsigned token holding the given claims.
+func bearer(claims string) string {
+	return "Bearer eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".c2lnbmF0dXJl"
+}
+
+func TestTimeMatcher(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		rule          string
+		expected      int
+		expectedError bool
+	}{
+		{
+			desc:          "invalid Time matcher (no parameter)",
+			rule:          "Time()",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid Time matcher (unknown day)",
+			rule:          "Time(`Mon-Fry 09:00-17:00`)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid Time matcher (invalid time)",
+			rule:          "Time(`Mon-Fri 9h-17h`)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid Time matcher (empty time range)",
+			rule:          "Time(`09:00-09:00`)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid Time matcher (unknown time zone)",
+			rule:          "Time(`09:00-17:00`, `Europe/Nowhere`)",
+			expectedError: true,
+		},
+		{
+			desc:     "valid Time matcher (whole day)",
+			rule:     "Time(`Sun-Sat 00:00-24:00`, `Europe/Paris`)",
+			expected: http.StatusOK,
+		},
+		{
+			desc:     "valid Time matcher (negated whole day)",
+			rule:     "!Time(`00:00-24:00`)",
+			expected: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			err = muxer.AddRoute(test.rule, "", 0, handler)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			muxer.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "https://example.com", http.NoBody))
+			assert.Equal(t, test.expected, w.Code)
+		})
+	}
+}
+
+func TestTimeWindow_contains(t *testing.T) {
+	// 2026-10-12 is a Monday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.October, 12+day, hour, minute, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		desc     string
+		window   string
+		expected map[time.Time]bool
+	}{
+		{
+			desc:   "working hours",
+			window: "Mon-Fri 09:00-17:00",
+			expected: map[time.Time]bool{
+				at(0, 8, 59):  false,
+				at(0, 9, 0):   true,
+				at(2, 12, 30): true,
+				at(4, 16, 59): true,
+				at(4, 17, 0):  false,
+				at(5, 12, 0):  false,
+				at(6, 12, 0):  false,
+			},
+		},
+		{
+			desc:   "every day",
+			window: "12:00-14:00",
+			expected: map[time.Time]bool{
+				at(0, 13, 0): true,
+				at(6, 13, 0): true,
+				at(6, 14, 0): false,
+			},
+		},
+		{
+			desc:   "list of days",
+			window: "mon,Wed-Thu 00:00-24:00",
+			expected: map[time.Time]bool{
+				at(0, 0, 0):   true,
+				at(1, 12, 0):  false,
+				at(2, 12, 0):  true,
+				at(3, 23, 59): true,
+				at(4, 0, 0):   false,
+			},
+		},
+		{
+			desc:   "days wrapping around the end of the week",
+			window: "Sat-Mon 10:00-11:00",
+			expected: map[time.Time]bool{
+				at(5, 10, 30): true,
+				at(6, 10, 30): true,
+				at(0, 10, 30): true,
+				at(1, 10, 30): false,
+			},
+		},
+		{
+			desc:   "time range crossing midnight",
+			window: "Fri 22:00-06:00",
+			expected: map[time.Time]bool{
+				at(4, 21, 59): false,
+				at(4, 22, 0):  true,
+				at(5, 5, 59):  true,
+				at(5, 6, 0):   false,
+				at(5, 22, 0):  false,
+				at(4, 5, 0):   false,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			window, err := parseTimeWindow(test.window)
+			require.NoError(t, err)
+
+			results := make(map[time.Time]bool)
+			for moment := range test.expected {
+				results[moment] = window.contains(moment)
+			}
+			assert.Equal(t, test.expected, results)
+		})
+	}
+}
+
 func TestMethodMatcher(t *testing.T) {
 	testCases := []struct {
 		desc          string
@@ -1012,3 +1601,73 @@ func TestQueryRegexpMatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestProxyProtocolTLVMatcher(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		rule          string
+		tlvs          []proxyproto.TLV
+		expected      int
+		expectedError bool
+	}{
+		{
+			desc:          "invalid ProxyProtocolTLV matcher (one parameter)",
+			rule:          "ProxyProtocolTLV(`0xE0`)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid ProxyProtocolTLV matcher (unknown TLV)",
+			rule:          "ProxyProtocolTLV(`foobar`, `foobar`)",
+			expectedError: true,
+		},
+		{
+			desc:     "valid ProxyProtocolTLV matcher without PROXY protocol header",
+			rule:     "ProxyProtocolTLV(`0xE0`, `foobar`)",
+			expected: http.StatusNotFound,
+		},
+		{
+			desc:     "valid ProxyProtocolTLV matcher on a TLV type",
+			rule:     "ProxyProtocolTLV(`0xE0`, `foobar`)",
+			tlvs:     []proxyproto.TLV{{Type: 0xE0, Value: []byte("foobar")}},
+			expected: http.StatusOK,
+		},
+		{
+			desc:     "valid ProxyProtocolTLV matcher on a TLV type with another value",
+			rule:     "ProxyProtocolTLV(`224`, `foobar`)",
+			tlvs:     []proxyproto.TLV{{Type: 0xE0, Value: []byte("foo")}},
+			expected: http.StatusNotFound,
+		},
+		{
+			desc:     "valid ProxyProtocolTLV matcher on an Azure private endpoint LinkID",
+			rule:     "ProxyProtocolTLV(`azure_link_id`, `42`)",
+			tlvs:     []proxyproto.TLV{{Type: 0xEE, Value: []byte{0x01, 0x2a, 0x00, 0x00, 0x00}}},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			err = muxer.AddRoute(test.rule, "", 0, handler)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodGet, "https://example.com", http.NoBody)
+			req = req.WithContext(proxyprotocol.NewContext(req.Context(), test.tlvs))
+
+			muxer.ServeHTTP(w, req)
+			assert.Equal(t, test.expected, w.Code)
+		})
+	}
+}
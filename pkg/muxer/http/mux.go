@@ -2,10 +2,12 @@ package http
 
 import (
 	"fmt"
+	"maps"
 	"net/http"
 	"sort"
 
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/geoip"
 	"github.com/traefik/traefik/v3/pkg/rules"
 	"github.com/vulcand/predicate"
 )
@@ -15,6 +17,7 @@ type Muxer struct {
 	routes         routes
 	parser         predicate.Parser
 	parserV2       predicate.Parser
+	funcs          matcherFuncs
 	defaultHandler http.Handler
 }
 
@@ -43,6 +46,7 @@ func NewMuxer() (*Muxer, error) {
 	return &Muxer{
 		parser:         parser,
 		parserV2:       parserV2,
+		funcs:          httpFuncs,
 		defaultHandler: http.NotFoundHandler(),
 	}, nil
 }
@@ -65,6 +69,12 @@ func (m *Muxer) SetDefaultHandler(handler http.Handler) {
 	m.defaultHandler = handler
 }
 
+// SetGeoIPResolver sets the GeoIP resolver used by the ClientCountry matcher.
+func (m *Muxer) SetGeoIPResolver(resolver *geoip.Resolver) {
+	m.funcs = maps.Clone(httpFuncs)
+	m.funcs["ClientCountry"] = expectNParameters(clientCountry(resolver), 1)
+}
+
 // GetRulePriority computes the priority for a given rule.
 // The priority is calculated using the length of rule.
 func GetRulePriority(rule string) int {
@@ -75,7 +85,7 @@ func GetRulePriority(rule string) int {
 func (m *Muxer) AddRoute(rule string, syntax string, priority int, handler http.Handler) error {
 	var parse interface{}
 	var err error
-	var matcherFuncs map[string]func(*matchersTree, ...string) error
+	var funcs matcherFuncs
 
 	switch syntax {
 	case "v2":
@@ -84,14 +94,14 @@ func (m *Muxer) AddRoute(rule string, syntax string, priority int, handler http.
 			return fmt.Errorf("error while parsing rule %s: %w", rule, err)
 		}
 
-		matcherFuncs = httpFuncsV2
+		funcs = httpFuncsV2
 	default:
 		parse, err = m.parser.Parse(rule)
 		if err != nil {
 			return fmt.Errorf("error while parsing rule %s: %w", rule, err)
 		}
 
-		matcherFuncs = httpFuncs
+		funcs = m.funcs
 	}
 
 	buildTree, ok := parse.(rules.TreeBuilder)
@@ -100,7 +110,7 @@ func (m *Muxer) AddRoute(rule string, syntax string, priority int, handler http.
 	}
 
 	var matchers matchersTree
-	err = matchers.addRule(buildTree(), matcherFuncs)
+	err = matchers.addRule(buildTree(), funcs)
 	if err != nil {
 		return fmt.Errorf("error while adding rule %s: %w", rule, err)
 	}
@@ -116,6 +126,36 @@ func (m *Muxer) AddRoute(rule string, syntax string, priority int, handler http.
 	return nil
 }
 
+// NewMatcher returns a function reporting whether a request matches the given rule, in the v3 syntax.
+func NewMatcher(rule string) (func(req *http.Request) bool, error) {
+	var matchers []string
+	for matcher := range httpFuncs {
+		matchers = append(matchers, matcher)
+	}
+
+	parser, err := rules.NewParser(matchers)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating parser: %w", err)
+	}
+
+	parse, err := parser.Parse(rule)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing rule %s: %w", rule, err)
+	}
+
+	buildTree, ok := parse.(rules.TreeBuilder)
+	if !ok {
+		return nil, fmt.Errorf("error while parsing rule %s", rule)
+	}
+
+	var tree matchersTree
+	if err = tree.addRule(buildTree(), httpFuncs); err != nil {
+		return nil, fmt.Errorf("error while adding rule %s: %w", rule, err)
+	}
+
+	return tree.match, nil
+}
+
 // ParseDomains extract domains from rule.
 func ParseDomains(rule string) ([]string, error) {
 	var matchers []string
@@ -458,6 +458,65 @@ func TestParseDomains(t *testing.T) {
 	}
 }
 
+func TestNewMatcher(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		rule          string
+		method        string
+		target        string
+		headers       map[string]string
+		expected      bool
+		errorExpected bool
+	}{
+		{
+			desc:          "unknown matcher",
+			rule:          "Foobar(`foo`)",
+			errorExpected: true,
+		},
+		{
+			desc:     "matching method and path",
+			rule:     "Method(`POST`) && PathPrefix(`/api`)",
+			method:   http.MethodPost,
+			target:   "http://example.com/api/orders",
+			expected: true,
+		},
+		{
+			desc:   "not matching method",
+			rule:   "Method(`POST`) && PathPrefix(`/api`)",
+			method: http.MethodGet,
+			target: "http://example.com/api/orders",
+		},
+		{
+			desc:     "matching header",
+			rule:     "Header(`X-Shadow`, `true`)",
+			method:   http.MethodGet,
+			target:   "http://example.com/",
+			headers:  map[string]string{"X-Shadow": "true"},
+			expected: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			match, err := NewMatcher(test.rule)
+			if test.errorExpected {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(test.method, test.target, nil)
+			for name, value := range test.headers {
+				req.Header.Set(name, value)
+			}
+
+			assert.Equal(t, test.expected, match(req))
+		})
+	}
+}
+
 // TestEmptyHost is a non regression test for
 // https://github.com/traefik/traefik/pull/9131
 func TestEmptyHost(t *testing.T) {
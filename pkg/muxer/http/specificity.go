@@ -0,0 +1,163 @@
+package http
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/traefik/traefik/v3/pkg/rules"
+)
+
+const (
+	// specificityExactHost is the host specificity of the rules matching exact hosts.
+	specificityExactHost = 2
+	// specificityHostRegexp is the host specificity of the rules matching hosts with a regexp, e.g. wildcard hosts.
+	specificityHostRegexp = 1
+
+	maxSpecificityPath     = 9_999
+	maxSpecificityMatchers = 9_999
+)
+
+// RuleSpecificity is the specificity of a rule, from which the priority of its router can be computed.
+// The rules are ordered by the specificity of their host, then of their path, then by their number of matchers.
+type RuleSpecificity struct {
+	// Host is 2 for a rule matching exact hosts, 1 for a rule matching a host regexp, and 0 otherwise.
+	Host int `json:"host"`
+	// Path is the length of the path prefix matched by the rule, plus one for an exact path.
+	Path int `json:"path"`
+	// Matchers is the number of matchers of the rule.
+	Matchers int `json:"matchers"`
+}
+
+// Priority returns the priority of a rule with this specificity.
+func (s RuleSpecificity) Priority() int {
+	return s.Host*100_000_000 + min(s.Path, maxSpecificityPath)*10_000 + min(s.Matchers, maxSpecificityMatchers)
+}
+
+// GetRuleSpecificity computes the specificity of the given rule.
+// The specificity of a rule made of alternatives is the one of its least specific alternative.
+func GetRuleSpecificity(rule, syntax string) (RuleSpecificity, error) {
+	funcs := httpFuncs
+	if syntax == "v2" {
+		funcs = httpFuncsV2
+	}
+
+	var matchers []string
+	for matcher := range funcs {
+		matchers = append(matchers, matcher)
+	}
+
+	parser, err := rules.NewParser(matchers)
+	if err != nil {
+		return RuleSpecificity{}, fmt.Errorf("error while creating parser: %w", err)
+	}
+
+	parse, err := parser.Parse(rule)
+	if err != nil {
+		return RuleSpecificity{}, fmt.Errorf("error while parsing rule %s: %w", rule, err)
+	}
+
+	buildTree, ok := parse.(rules.TreeBuilder)
+	if !ok {
+		return RuleSpecificity{}, fmt.Errorf("error while parsing rule %s", rule)
+	}
+
+	return specificityOf(buildTree(), syntax), nil
+}
+
+func specificityOf(tree *rules.Tree, syntax string) RuleSpecificity {
+	switch tree.Matcher {
+	case "and":
+		left, right := specificityOf(tree.RuleLeft, syntax), specificityOf(tree.RuleRight, syntax)
+
+		return RuleSpecificity{
+			Host:     max(left.Host, right.Host),
+			Path:     max(left.Path, right.Path),
+			Matchers: left.Matchers + right.Matchers,
+		}
+
+	case "or":
+		left, right := specificityOf(tree.RuleLeft, syntax), specificityOf(tree.RuleRight, syntax)
+		if left.Priority() < right.Priority() {
+			return left
+		}
+
+		return right
+	}
+
+	specificity := RuleSpecificity{Matchers: 1}
+
+	// A negated matcher does not restrict the hosts and paths matched by the rule.
+	if tree.Not || len(tree.Value) == 0 {
+		return specificity
+	}
+
+	switch tree.Matcher {
+	case "Host", "HostHeader":
+		specificity.Host = specificityExactHost
+
+	case "HostRegexp":
+		specificity.Host = specificityHostRegexp
+
+	case "Path", "PathPrefix", "PathRegexp":
+		// The path specificity of a matcher given several paths is the one of its shortest path.
+		specificity.Path = maxSpecificityPath
+		for _, value := range tree.Value {
+			specificity.Path = min(specificity.Path, pathSpecificity(tree.Matcher, value, syntax))
+		}
+	}
+
+	return specificity
+}
+
+// pathSpecificity returns the length of the prefix of the paths matched by the given matcher, plus one for an exact path.
+func pathSpecificity(matcher, path, syntax string) int {
+	// The v2 paths can hold variables, matching any value.
+	if syntax == "v2" {
+		if prefix, _, found := strings.Cut(path, "{"); found {
+			return len(prefix)
+		}
+	}
+
+	switch matcher {
+	case "Path":
+		return len(path) + 1
+
+	case "PathRegexp":
+		return len(regexpLiteralPrefix(path))
+
+	default:
+		return len(path)
+	}
+}
+
+// regexpLiteralPrefix returns the literal prefix of the paths matched by the given regexp,
+// which is empty when the regexp is not anchored at the start of the path.
+func regexpLiteralPrefix(expr string) string {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+
+	re = re.Simplify()
+
+	subs := []*syntax.Regexp{re}
+	if re.Op == syntax.OpConcat {
+		subs = re.Sub
+	}
+
+	if len(subs) == 0 || (subs[0].Op != syntax.OpBeginText && subs[0].Op != syntax.OpBeginLine) {
+		return ""
+	}
+
+	var prefix strings.Builder
+	for _, sub := range subs[1:] {
+		if sub.Op != syntax.OpLiteral || sub.Flags&syntax.FoldCase != 0 {
+			break
+		}
+
+		prefix.WriteString(string(sub.Rune))
+	}
+
+	return prefix.String()
+}
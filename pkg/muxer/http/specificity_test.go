@@ -0,0 +1,132 @@
+package http
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRuleSpecificity(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		rule          string
+		syntax        string
+		expected      RuleSpecificity
+		expectedError bool
+	}{
+		{
+			desc:          "invalid rule",
+			rule:          "Host(`example.com`",
+			expectedError: true,
+		},
+		{
+			desc:     "exact host",
+			rule:     "Host(`example.com`)",
+			expected: RuleSpecificity{Host: 2, Matchers: 1},
+		},
+		{
+			desc:     "host regexp",
+			rule:     "HostRegexp(`^.+\\.example\\.com$`)",
+			expected: RuleSpecificity{Host: 1, Matchers: 1},
+		},
+		{
+			desc:     "exact path",
+			rule:     "Path(`/api`)",
+			expected: RuleSpecificity{Path: 5, Matchers: 1},
+		},
+		{
+			desc:     "path prefix",
+			rule:     "PathPrefix(`/api`)",
+			expected: RuleSpecificity{Path: 4, Matchers: 1},
+		},
+		{
+			desc:     "anchored path regexp",
+			rule:     "PathRegexp(`^/api/v[0-9]+`)",
+			expected: RuleSpecificity{Path: 6, Matchers: 1},
+		},
+		{
+			desc:     "unanchored path regexp",
+			rule:     "PathRegexp(`/api/v[0-9]+`)",
+			expected: RuleSpecificity{Matchers: 1},
+		},
+		{
+			desc:     "several matchers",
+			rule:     "Host(`example.com`) && PathPrefix(`/api`) && Method(`GET`)",
+			expected: RuleSpecificity{Host: 2, Path: 4, Matchers: 3},
+		},
+		{
+			desc:     "negated matchers",
+			rule:     "!Host(`example.com`) && !PathPrefix(`/api`)",
+			expected: RuleSpecificity{Matchers: 2},
+		},
+		{
+			desc:     "least specific alternative",
+			rule:     "(Host(`example.com`) && PathPrefix(`/api`)) || HostRegexp(`^.+\\.example\\.com$`)",
+			expected: RuleSpecificity{Host: 1, Matchers: 1},
+		},
+		{
+			desc:     "v2 paths",
+			rule:     "Path(`/users/{id}`, `/users/`)",
+			syntax:   "v2",
+			expected: RuleSpecificity{Path: 7, Matchers: 1},
+		},
+		{
+			desc:     "v2 hosts",
+			rule:     "Host(`example.com`, `example.org`)",
+			syntax:   "v2",
+			expected: RuleSpecificity{Host: 2, Matchers: 1},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			specificity, err := GetRuleSpecificity(test.rule, test.syntax)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, specificity)
+		})
+	}
+}
+
+func TestRuleSpecificity_Priority(t *testing.T) {
+	// The rules are sorted from the most specific to the least specific.
+	expected := []string{
+		"Host(`api.example.com`) && Path(`/v1/users`)",
+		"Host(`api.example.com`) && PathPrefix(`/v1/users`)",
+		"Host(`api.example.com`) && PathPrefix(`/v1`) && Method(`GET`)",
+		"Host(`api.example.com`) && PathPrefix(`/v1`)",
+		"Host(`api.example.com`)",
+		"HostRegexp(`^.+\\.example\\.com$`) && PathPrefix(`/v1`)",
+		"HostRegexp(`^.+\\.example\\.com$`)",
+		"PathPrefix(`/v1/users`)",
+		"PathPrefix(`/`) && Header(`X-Debug`, `true`)",
+		"PathPrefix(`/`)",
+	}
+
+	rules := make([]string, len(expected))
+	for i, rule := range expected {
+		rules[len(expected)-1-i] = rule
+	}
+
+	priorities := make(map[string]int)
+	for _, rule := range rules {
+		specificity, err := GetRuleSpecificity(rule, "")
+		require.NoError(t, err)
+
+		priorities[rule] = specificity.Priority()
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		return priorities[rules[i]] > priorities[rules[j]]
+	})
+
+	assert.Equal(t, expected, rules)
+}
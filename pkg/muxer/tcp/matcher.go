@@ -3,19 +3,25 @@ package tcp
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"unicode/utf8"
 
 	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/ip"
+	"github.com/traefik/traefik/v3/pkg/proxyprotocol"
 )
 
 var tcpFuncs = map[string]func(*matchersTree, ...string) error{
-	"ALPN":          expect1Parameter(alpn),
-	"ClientIP":      expect1Parameter(clientIP),
-	"HostSNI":       expect1Parameter(hostSNI),
-	"HostSNIRegexp": expect1Parameter(hostSNIRegexp),
+	"ALPN":             expect1Parameter(alpn),
+	"ALPNRegexp":       expect1Parameter(alpnRegexp),
+	"ClientIP":         expect1Parameter(clientIP),
+	"HostSNI":          expect1Parameter(hostSNI),
+	"HostSNIRegexp":    expect1Parameter(hostSNIRegexp),
+	"JA3":              expect1Parameter(ja3),
+	"JA4":              expect1Parameter(ja4),
+	"ProxyProtocolTLV": expect2Parameters(proxyProtocolTLV),
 }
 
 func expect1Parameter(fn func(*matchersTree, ...string) error) func(*matchersTree, ...string) error {
@@ -28,6 +34,16 @@ func expect1Parameter(fn func(*matchersTree, ...string) error) func(*matchersTre
 	}
 }
 
+func expect2Parameters(fn func(*matchersTree, ...string) error) func(*matchersTree, ...string) error {
+	return func(route *matchersTree, s ...string) error {
+		if len(s) != 2 {
+			return fmt.Errorf("unexpected number of parameters; got %d, expected 2", len(s))
+		}
+
+		return fn(route, s...)
+	}
+}
+
 // alpn checks if any of the connection ALPN protocols matches one of the matcher protocols.
 func alpn(tree *matchersTree, protos ...string) error {
 	proto := protos[0]
@@ -49,6 +65,30 @@ func alpn(tree *matchersTree, protos ...string) error {
 	return nil
 }
 
+// alpnRegexp checks if any of the connection ALPN protocols matches the matcher protocol regexp.
+func alpnRegexp(tree *matchersTree, templates ...string) error {
+	re, err := regexp.Compile(templates[0])
+	if err != nil {
+		return fmt.Errorf("compiling ALPNRegexp matcher: %w", err)
+	}
+
+	if re.MatchString(tlsalpn01.ACMETLS1Protocol) {
+		return fmt.Errorf("invalid value for ALPNRegexp matcher, %q must not match the %q protocol", templates[0], tlsalpn01.ACMETLS1Protocol)
+	}
+
+	tree.matcher = func(meta ConnData) bool {
+		for _, alpnProto := range meta.alpnProtos {
+			if re.MatchString(alpnProto) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return nil
+}
+
 func clientIP(tree *matchersTree, clientIP ...string) error {
 	checker, err := ip.NewChecker(clientIP)
 	if err != nil {
@@ -56,6 +96,10 @@ func clientIP(tree *matchersTree, clientIP ...string) error {
 	}
 
 	tree.matcher = func(meta ConnData) bool {
+		if meta.remoteIP == "" {
+			return false
+		}
+
 		ok, err := checker.Contains(meta.remoteIP)
 		if err != nil {
 			log.Warn().Err(err).Msg("ClientIP matcher: could not match remote address")
@@ -119,6 +163,75 @@ func hostSNIRegexp(tree *matchersTree, templates ...string) error {
 		return re.MatchString(meta.serverName)
 	}
 
+	if slices.ContainsFunc(re.SubexpNames(), func(name string) bool { return name != "" }) {
+		tree.capture = func(meta ConnData, values map[string]string) {
+			submatches := re.FindStringSubmatch(meta.serverName)
+			if submatches == nil {
+				return
+			}
+
+			for i, name := range re.SubexpNames() {
+				if name != "" {
+					values[name] = submatches[i]
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+var (
+	ja3Hash        = regexp.MustCompile(`^[0-9a-f]{32}$`)
+	ja4Fingerprint = regexp.MustCompile(`^[tqd][0-9a-z]{2}[di][0-9]{4}[0-9a-zA-Z]{2}_[0-9a-f]{12}_[0-9a-f]{12}$`)
+)
+
+// ja3 checks if the JA3 fingerprint of the connection TLS ClientHello equals the matcher fingerprint.
+func ja3(tree *matchersTree, fingerprints ...string) error {
+	hash := strings.ToLower(fingerprints[0])
+
+	if !ja3Hash.MatchString(hash) {
+		return fmt.Errorf("invalid value for JA3 matcher, %q is not a JA3 hash", fingerprints[0])
+	}
+
+	tree.matcher = func(meta ConnData) bool {
+		fingerprints := meta.tlsFingerprints()
+		return fingerprints != nil && fingerprints.JA3 == hash
+	}
+
+	return nil
+}
+
+// ja4 checks if the JA4 fingerprint of the connection TLS ClientHello equals the matcher fingerprint.
+func ja4(tree *matchersTree, fingerprints ...string) error {
+	value := fingerprints[0]
+
+	if !ja4Fingerprint.MatchString(value) {
+		return fmt.Errorf("invalid value for JA4 matcher, %q is not a JA4 fingerprint", value)
+	}
+
+	tree.matcher = func(meta ConnData) bool {
+		fingerprints := meta.tlsFingerprints()
+		return fingerprints != nil && fingerprints.JA4 == value
+	}
+
+	return nil
+}
+
+// proxyProtocolTLV checks if the value of the given TLV of the connection PROXY protocol header equals the matcher value.
+func proxyProtocolTLV(tree *matchersTree, tlv ...string) error {
+	lookup, err := proxyprotocol.NewTLVLookup(tlv[0])
+	if err != nil {
+		return fmt.Errorf("invalid TLV for ProxyProtocolTLV matcher: %w", err)
+	}
+
+	expected := tlv[1]
+
+	tree.matcher = func(meta ConnData) bool {
+		value, ok := lookup(meta.proxyProtocolTLVs())
+		return ok && value == expected
+	}
+
 	return nil
 }
 
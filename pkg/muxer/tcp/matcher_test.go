@@ -3,9 +3,12 @@ package tcp
 import (
 	"testing"
 
+	"github.com/pires/go-proxyproto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/proxyprotocol"
 	"github.com/traefik/traefik/v3/pkg/tcp"
+	"github.com/traefik/traefik/v3/pkg/tls/fingerprint"
 )
 
 func Test_HostSNICatchAll(t *testing.T) {
@@ -376,3 +379,282 @@ func Test_ALPN(t *testing.T) {
 		})
 	}
 }
+
+func Test_ALPNRegexp(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rule     string
+		expected map[string]bool
+		buildErr bool
+	}{
+		{
+			desc:     "Invalid ALPNRegexp matcher (invalid regexp)",
+			rule:     "ALPNRegexp(`mqtt[`)",
+			buildErr: true,
+		},
+		{
+			desc:     "Invalid ALPNRegexp matcher (matching TLS proto)",
+			rule:     "ALPNRegexp(`.*`)",
+			buildErr: true,
+		},
+		{
+			desc:     "Invalid ALPNRegexp matcher (too many parameters)",
+			rule:     "ALPNRegexp(`^h2$`, `^mqtt$`)",
+			buildErr: true,
+		},
+		{
+			desc: "Valid ALPNRegexp matcher",
+			rule: "ALPNRegexp(`^(imap|pop3)$`)",
+			expected: map[string]bool{
+				"imap":  true,
+				"pop3":  true,
+				"imaps": false,
+				"h2":    false,
+				"":      false,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			err = muxer.AddRoute(test.rule, "", 0, tcp.HandlerFunc(func(conn tcp.WriteCloser) {}))
+			if test.buildErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			for proto, match := range test.expected {
+				meta := ConnData{
+					alpnProtos: []string{proto},
+				}
+
+				handler, _ := muxer.Match(meta)
+				assert.Equal(t, match, handler != nil, proto)
+			}
+		})
+	}
+}
+
+type fingerprintSource fingerprint.Fingerprints
+
+func (s *fingerprintSource) TLSFingerprints() *fingerprint.Fingerprints {
+	return (*fingerprint.Fingerprints)(s)
+}
+
+func Test_JA3AndJA4(t *testing.T) {
+	fingerprints := &fingerprintSource{
+		JA3: "771cb9a37a9bab4479e8d7a7d6f6a21c",
+		JA4: "t13d1516h2_8daaf6152771_02713d6af862",
+	}
+
+	testCases := []struct {
+		desc         string
+		rule         string
+		fingerprints fingerprint.Source
+		buildErr     bool
+		match        bool
+	}{
+		{
+			desc:     "Invalid JA3 matcher (not a hash)",
+			rule:     "JA3(`771,4865-4866,0-23,29-23,0`)",
+			buildErr: true,
+		},
+		{
+			desc:     "Invalid JA4 matcher (not a fingerprint)",
+			rule:     "JA4(`t13d1516h2`)",
+			buildErr: true,
+		},
+		{
+			desc:         "Matching JA3",
+			rule:         "JA3(`771CB9A37A9BAB4479E8D7A7D6F6A21C`)",
+			fingerprints: fingerprints,
+			match:        true,
+		},
+		{
+			desc:         "Not matching JA3",
+			rule:         "JA3(`00000000000000000000000000000000`)",
+			fingerprints: fingerprints,
+		},
+		{
+			desc:         "Matching JA4",
+			rule:         "JA4(`t13d1516h2_8daaf6152771_02713d6af862`)",
+			fingerprints: fingerprints,
+			match:        true,
+		},
+		{
+			desc:         "Not matching JA4",
+			rule:         "JA4(`t13i1516h2_8daaf6152771_02713d6af862`)",
+			fingerprints: fingerprints,
+		},
+		{
+			desc: "No fingerprints",
+			rule: "JA4(`t13d1516h2_8daaf6152771_02713d6af862`)",
+		},
+		{
+			desc:         "Unavailable fingerprints",
+			rule:         "JA3(`771cb9a37a9bab4479e8d7a7d6f6a21c`)",
+			fingerprints: (*fingerprintSource)(nil),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			err = muxer.AddRoute(test.rule, "", 0, tcp.HandlerFunc(func(conn tcp.WriteCloser) {}))
+			if test.buildErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			handler, _ := muxer.Match(ConnData{fingerprints: test.fingerprints})
+			assert.Equal(t, test.match, handler != nil)
+		})
+	}
+}
+
+type capturesRecorder struct {
+	captures map[string]string
+}
+
+func (r *capturesRecorder) SetSNICaptures(captures map[string]string) {
+	r.captures = captures
+}
+
+func Test_HostSNIRegexpCaptures(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		rule       string
+		serverName string
+		expected   map[string]string
+	}{
+		{
+			desc:       "Named groups",
+			rule:       "HostSNIRegexp(`^(?P<tenant>[a-z]+)\\.(?P<env>dev|prod)\\.example\\.com$`)",
+			serverName: "acme.prod.example.com",
+			expected:   map[string]string{"tenant": "acme", "env": "prod"},
+		},
+		{
+			desc:       "Unnamed groups",
+			rule:       "HostSNIRegexp(`^([a-z]+)\\.example\\.com$`)",
+			serverName: "acme.example.com",
+		},
+		{
+			desc:       "Combined matchers",
+			rule:       "HostSNIRegexp(`^(?P<tenant>[a-z]+)\\.example\\.com$`) && HostSNIRegexp(`^[a-z]+\\.(?P<domain>[a-z.]+)$`)",
+			serverName: "acme.example.com",
+			expected:   map[string]string{"tenant": "acme", "domain": "example.com"},
+		},
+		{
+			desc:       "Matching branch of an or",
+			rule:       "HostSNIRegexp(`^(?P<tenant>[a-z]+)\\.example\\.com$`) || HostSNIRegexp(`^(?P<region>[a-z]+)\\.example\\.org$`)",
+			serverName: "eu.example.org",
+			expected:   map[string]string{"region": "eu"},
+		},
+		{
+			desc:       "Negated matcher",
+			rule:       "HostSNI(`acme.example.com`) && !HostSNIRegexp(`^(?P<tenant>[a-z]+)\\.example\\.org$`)",
+			serverName: "acme.example.com",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			err = muxer.AddRoute(test.rule, "", 0, tcp.HandlerFunc(func(conn tcp.WriteCloser) {}))
+			require.NoError(t, err)
+
+			recorder := &capturesRecorder{}
+			handler, _ := muxer.Match(ConnData{serverName: test.serverName, sniCaptures: recorder})
+			require.NotNil(t, handler)
+
+			assert.Equal(t, test.expected, recorder.captures)
+		})
+	}
+}
+
+type proxyProtocolSource []proxyproto.TLV
+
+func (s proxyProtocolSource) ProxyProtocolTLVs() []proxyproto.TLV {
+	return s
+}
+
+func Test_ProxyProtocolTLV(t *testing.T) {
+	tlvs := proxyProtocolSource{
+		{Type: 0xE0, Value: []byte("foobar")},
+		{Type: 0xEA, Value: append([]byte{0x01}, "vpce-08d2bf15fac5001c9"...)},
+	}
+
+	testCases := []struct {
+		desc     string
+		rule     string
+		tlvs     proxyprotocol.Source
+		buildErr bool
+		match    bool
+	}{
+		{
+			desc:     "Invalid TLV",
+			rule:     "ProxyProtocolTLV(`foobar`, `foobar`)",
+			buildErr: true,
+		},
+		{
+			desc:     "Missing value",
+			rule:     "ProxyProtocolTLV(`0xE0`)",
+			buildErr: true,
+		},
+		{
+			desc:  "Matching TLV type",
+			rule:  "ProxyProtocolTLV(`0xE0`, `foobar`)",
+			tlvs:  tlvs,
+			match: true,
+		},
+		{
+			desc: "Not matching TLV value",
+			rule: "ProxyProtocolTLV(`0xE0`, `foo`)",
+			tlvs: tlvs,
+		},
+		{
+			desc:  "Matching AWS VPC endpoint ID",
+			rule:  "ProxyProtocolTLV(`aws_vpce_id`, `vpce-08d2bf15fac5001c9`)",
+			tlvs:  tlvs,
+			match: true,
+		},
+		{
+			desc: "No PROXY protocol header",
+			rule: "ProxyProtocolTLV(`0xE0`, `foobar`)",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			err = muxer.AddRoute(test.rule, "", 0, tcp.HandlerFunc(func(conn tcp.WriteCloser) {}))
+			if test.buildErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			handler, _ := muxer.Match(ConnData{proxyProtocol: test.tlvs})
+			assert.Equal(t, test.match, handler != nil)
+		})
+	}
+}
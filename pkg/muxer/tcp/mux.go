@@ -6,9 +6,12 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/pires/go-proxyproto"
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/proxyprotocol"
 	"github.com/traefik/traefik/v3/pkg/rules"
 	"github.com/traefik/traefik/v3/pkg/tcp"
+	"github.com/traefik/traefik/v3/pkg/tls/fingerprint"
 	"github.com/traefik/traefik/v3/pkg/types"
 	"github.com/vulcand/predicate"
 )
@@ -18,13 +21,33 @@ type ConnData struct {
 	serverName string
 	remoteIP   string
 	alpnProtos []string
+
+	// fingerprints provides the fingerprints of the TLS ClientHello, computed only when a matcher needs them.
+	fingerprints fingerprint.Source
+	// proxyProtocol provides the TLVs of the PROXY protocol header, parsed only when a matcher needs them.
+	proxyProtocol proxyprotocol.Source
+	// sniCaptures records the values captured from the server name by the rule of the matching route.
+	sniCaptures sniCapturesRecorder
+}
+
+// sniCapturesRecorder is implemented by the connections recording the values captured from their server name.
+type sniCapturesRecorder interface {
+	SetSNICaptures(captures map[string]string)
 }
 
 // NewConnData builds a connData struct from the given parameters.
+// The TLS ClientHello fingerprints are provided by the connection when it implements fingerprint.Source,
+// the PROXY protocol TLVs are provided by the connection when it implements proxyprotocol.Source,
+// and the values captured from the server name are recorded on the connection when it implements SetSNICaptures.
 func NewConnData(serverName string, conn tcp.WriteCloser, alpnProtos []string) (ConnData, error) {
-	remoteIP, _, err := net.SplitHostPort(conn.RemoteAddr().String())
-	if err != nil {
-		return ConnData{}, fmt.Errorf("error while parsing remote address %q: %w", conn.RemoteAddr().String(), err)
+	// The connections accepted on a unix socket have no remote IP.
+	var remoteIP string
+	if _, ok := conn.RemoteAddr().(*net.UnixAddr); !ok {
+		var err error
+		remoteIP, _, err = net.SplitHostPort(conn.RemoteAddr().String())
+		if err != nil {
+			return ConnData{}, fmt.Errorf("error while parsing remote address %q: %w", conn.RemoteAddr().String(), err)
+		}
 	}
 
 	// as per https://datatracker.ietf.org/doc/html/rfc6066:
@@ -32,11 +55,43 @@ func NewConnData(serverName string, conn tcp.WriteCloser, alpnProtos []string) (
 	// so there is no need to trim a potential trailing dot
 	serverName = types.CanonicalDomain(serverName)
 
-	return ConnData{
+	connData := ConnData{
 		serverName: types.CanonicalDomain(serverName),
 		remoteIP:   remoteIP,
 		alpnProtos: alpnProtos,
-	}, nil
+	}
+
+	if source, ok := conn.(fingerprint.Source); ok {
+		connData.fingerprints = source
+	}
+
+	if source, ok := conn.(proxyprotocol.Source); ok {
+		connData.proxyProtocol = source
+	}
+
+	if recorder, ok := conn.(sniCapturesRecorder); ok {
+		connData.sniCaptures = recorder
+	}
+
+	return connData, nil
+}
+
+// tlsFingerprints returns the fingerprints of the TLS ClientHello, or nil if they are not available.
+func (c ConnData) tlsFingerprints() *fingerprint.Fingerprints {
+	if c.fingerprints == nil {
+		return nil
+	}
+
+	return c.fingerprints.TLSFingerprints()
+}
+
+// proxyProtocolTLVs returns the TLVs of the PROXY protocol header, or nil if there are none.
+func (c ConnData) proxyProtocolTLVs() []proxyproto.TLV {
+	if c.proxyProtocol == nil {
+		return nil
+	}
+
+	return c.proxyProtocol.ProxyProtocolTLVs()
 }
 
 // Muxer defines a muxer that handles TCP routing with rules.
@@ -76,9 +131,14 @@ func NewMuxer() (*Muxer, error) {
 
 // Match returns the handler of the first route matching the connection metadata,
 // and whether the match is exactly from the rule HostSNI(*).
+// The values captured from the server name by the route rule are recorded on the connection.
 func (m Muxer) Match(meta ConnData) (tcp.Handler, bool) {
 	for _, route := range m.routes {
 		if route.matchers.match(meta) {
+			if meta.sniCaptures != nil {
+				meta.sniCaptures.SetSNICaptures(route.matchers.captures(meta))
+			}
+
 			return route.handler, route.catchAll
 		}
 	}
@@ -240,6 +300,8 @@ type matchersTree struct {
 	// If matcher is not nil, it means that this matcherTree is a leaf of the tree.
 	// It is therefore mutually exclusive with left and right.
 	matcher func(ConnData) bool
+	// capture adds the values captured by the matcher to the given map, if any.
+	capture func(meta ConnData, values map[string]string)
 	// operator to combine the evaluation of left and right leaves.
 	operator string
 	// Mutually exclusive with matcher.
@@ -270,6 +332,45 @@ func (m *matchersTree) match(meta ConnData) bool {
 	}
 }
 
+// captures returns the values captured by the matchers of the tree, for a connection matching the tree.
+func (m *matchersTree) captures(meta ConnData) map[string]string {
+	values := map[string]string{}
+	m.collectCaptures(meta, values)
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values
+}
+
+func (m *matchersTree) collectCaptures(meta ConnData, values map[string]string) {
+	if m == nil {
+		return
+	}
+
+	if m.matcher != nil {
+		if m.capture != nil {
+			m.capture(meta, values)
+		}
+		return
+	}
+
+	switch m.operator {
+	case "or":
+		// Only the matching branch captures values.
+		if m.left.match(meta) {
+			m.left.collectCaptures(meta, values)
+			return
+		}
+
+		m.right.collectCaptures(meta, values)
+	case "and":
+		m.left.collectCaptures(meta, values)
+		m.right.collectCaptures(meta, values)
+	}
+}
+
 type matcherFuncs map[string]func(*matchersTree, ...string) error
 
 func (m *matchersTree) addRule(rule *rules.Tree, funcs matcherFuncs) error {
@@ -300,6 +401,9 @@ func (m *matchersTree) addRule(rule *rules.Tree, funcs matcherFuncs) error {
 			m.matcher = func(meta ConnData) bool {
 				return !matcherFunc(meta)
 			}
+
+			// A negated matcher does not capture any value.
+			m.capture = nil
 		}
 	}
 
@@ -6,13 +6,22 @@ import (
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"strings"
+	"sync"
 
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
 // Constructor creates a plugin handler.
 type Constructor func(context.Context, http.Handler) (http.Handler, error)
 
+// HealthChecker is implemented by the plugin handlers providing a health hook.
+// Healthy returns an error while the plugin is unable to handle the requests.
+type HealthChecker interface {
+	Healthy() error
+}
+
 type pluginMiddleware interface {
 	NewHandler(ctx context.Context, next http.Handler) (http.Handler, error)
 }
@@ -23,94 +32,189 @@ type middlewareBuilder interface {
 
 // Builder is a plugin builder.
 type Builder struct {
+	mu                 sync.RWMutex
 	providerBuilders   map[string]providerBuilder
 	middlewareBuilders map[string]middlewareBuilder
+	infos              map[string]pluginInfo
+	merger             *providerMerger
+	processes          *grpcProcesses
 }
 
 // NewBuilder creates a new Builder.
 func NewBuilder(client *Client, plugins map[string]Descriptor, localPlugins map[string]LocalDescriptor) (*Builder, error) {
-	ctx := context.Background()
+	processes := newGRPCProcesses()
 
-	pb := &Builder{
-		middlewareBuilders: map[string]middlewareBuilder{},
-		providerBuilders:   map[string]providerBuilder{},
+	middlewareBuilders, providerBuilders, infos, err := newBuilders(client, processes, plugins, localPlugins)
+	if err != nil {
+		return nil, err
 	}
 
+	return &Builder{
+		middlewareBuilders: middlewareBuilders,
+		providerBuilders:   providerBuilders,
+		infos:              infos,
+		merger:             newProviderMerger(),
+		processes:          processes,
+	}, nil
+}
+
+// Reload rebuilds the plugins from the given descriptors and swaps them with the current ones.
+// Handlers created from the previous plugins keep serving their in-flight requests,
+// and are released once the routers have been rebuilt.
+// The processes of the gRPC plugins which are no longer used are stopped.
+func (b *Builder) Reload(client *Client, plugins map[string]Descriptor, localPlugins map[string]LocalDescriptor) error {
+	middlewareBuilders, providerBuilders, infos, err := newBuilders(client, b.processes, plugins, localPlugins)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.middlewareBuilders = middlewareBuilders
+	b.providerBuilders = providerBuilders
+	b.infos = infos
+
+	b.processes.retain(grpcProcessKeys(middlewareBuilders))
+
+	return nil
+}
+
+// Close stops the processes of the gRPC plugins started by Traefik.
+func (b *Builder) Close() {
+	b.processes.retain(nil)
+}
+
+// ModuleName returns the module name of the given plugin.
+func (b *Builder) ModuleName(pName string) string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.infos[pName].ModuleName
+}
+
+// CircuitBreaker returns the circuit breaker configuration of the given plugin, if any.
+func (b *Builder) CircuitBreaker(pName string) *CircuitBreaker {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.infos[pName].circuitBreaker
+}
+
+// HealthCheck returns the configuration of the polling of the health hook of the given plugin, if any.
+func (b *Builder) HealthCheck(pName string) *HealthCheck {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.infos[pName].healthCheck
+}
+
+func newBuilders(client *Client, processes *grpcProcesses, plugins map[string]Descriptor, localPlugins map[string]LocalDescriptor) (map[string]middlewareBuilder, map[string]providerBuilder, map[string]pluginInfo, error) {
+	ctx := context.Background()
+
+	middlewareBuilders := map[string]middlewareBuilder{}
+	providerBuilders := map[string]providerBuilder{}
+	infos := client.skippedPluginInfos()
+
 	for pName, desc := range plugins {
-		manifest, err := client.ReadManifest(desc.ModuleName)
+		goPath := client.GoPathOf(desc.ModuleName, desc.Version)
+
+		manifest, err := ReadManifest(goPath, desc.ModuleName)
 		if err != nil {
 			_ = client.ResetAll()
-			return nil, fmt.Errorf("%s: failed to read manifest: %w", desc.ModuleName, err)
+			return nil, nil, nil, fmt.Errorf("%s: failed to read manifest: %w", desc.ModuleName, err)
 		}
 
-		logger := log.With().
-			Str("plugin", "plugin-"+pName).
-			Str("module", desc.ModuleName).
-			Str("runtime", manifest.Runtime).
-			Logger()
-		logCtx := logger.WithContext(ctx)
+		logCtx := pluginContext(ctx, pName, desc.ModuleName, manifest.Runtime, desc.LogLevel)
 
 		switch manifest.Type {
 		case typeMiddleware:
-			middleware, err := newMiddlewareBuilder(logCtx, client.GoPath(), manifest, desc.ModuleName, desc.Settings)
+			middleware, err := newMiddlewareBuilder(logCtx, goPath, manifest, desc.ModuleName, desc.Settings, true, processes)
 			if err != nil {
-				return nil, err
+				return nil, nil, nil, err
 			}
 
-			pb.middlewareBuilders[pName] = middleware
+			middlewareBuilders[pName] = middleware
 
 		case typeProvider:
-			pBuilder, err := newProviderBuilder(logCtx, manifest, client.GoPath())
+			pBuilder, err := newProviderBuilder(logCtx, goPath, manifest, desc.ModuleName, desc.Settings)
 			if err != nil {
-				return nil, fmt.Errorf("%s: %w", desc.ModuleName, err)
+				return nil, nil, nil, fmt.Errorf("%s: %w", desc.ModuleName, err)
 			}
 
-			pb.providerBuilders[pName] = pBuilder
+			providerBuilders[pName] = pBuilder
 
 		default:
-			return nil, fmt.Errorf("unknow plugin type: %s", manifest.Type)
+			return nil, nil, nil, fmt.Errorf("unknow plugin type: %s", manifest.Type)
 		}
+
+		infos[pName] = newPluginInfo(pName, desc.ModuleName, desc.Version, manifest, false, desc.CircuitBreaker, desc.HealthCheck, desc.Priority)
 	}
 
 	for pName, desc := range localPlugins {
 		manifest, err := ReadManifest(localGoPath, desc.ModuleName)
 		if err != nil {
-			return nil, fmt.Errorf("%s: failed to read manifest: %w", desc.ModuleName, err)
+			return nil, nil, nil, fmt.Errorf("%s: failed to read manifest: %w", desc.ModuleName, err)
 		}
 
-		logger := log.With().
-			Str("plugin", "plugin-"+pName).
-			Str("module", desc.ModuleName).
-			Str("runtime", manifest.Runtime).
-			Logger()
-		logCtx := logger.WithContext(ctx)
+		logCtx := pluginContext(ctx, pName, desc.ModuleName, manifest.Runtime, desc.LogLevel)
 
 		switch manifest.Type {
 		case typeMiddleware:
-			middleware, err := newMiddlewareBuilder(logCtx, localGoPath, manifest, desc.ModuleName, desc.Settings)
+			middleware, err := newMiddlewareBuilder(logCtx, localGoPath, manifest, desc.ModuleName, desc.Settings, false, processes)
 			if err != nil {
-				return nil, err
+				return nil, nil, nil, err
 			}
 
-			pb.middlewareBuilders[pName] = middleware
+			middlewareBuilders[pName] = middleware
 
 		case typeProvider:
-			builder, err := newProviderBuilder(logCtx, manifest, localGoPath)
+			builder, err := newProviderBuilder(logCtx, localGoPath, manifest, desc.ModuleName, desc.Settings)
 			if err != nil {
-				return nil, fmt.Errorf("%s: %w", desc.ModuleName, err)
+				return nil, nil, nil, fmt.Errorf("%s: %w", desc.ModuleName, err)
 			}
 
-			pb.providerBuilders[pName] = builder
+			providerBuilders[pName] = builder
 
 		default:
-			return nil, fmt.Errorf("unknow plugin type: %s", manifest.Type)
+			return nil, nil, nil, fmt.Errorf("unknow plugin type: %s", manifest.Type)
 		}
+
+		infos[pName] = newPluginInfo(pName, desc.ModuleName, "", manifest, true, desc.CircuitBreaker, desc.HealthCheck, desc.Priority)
+	}
+
+	return middlewareBuilders, providerBuilders, infos, nil
+}
+
+func pluginContext(ctx context.Context, pName, moduleName, runtime, logLevel string) context.Context {
+	logger := log.With().
+		Str("plugin", "plugin-"+pName).
+		Str("module", moduleName).
+		Str("runtime", runtime).
+		Logger()
+
+	// The plugin output is logged at the level of Traefik, unless the plugin has its own log level.
+	if level, err := parseLogLevel(logLevel); err == nil {
+		logger = logger.Level(level)
 	}
-	return pb, nil
+
+	return logger.WithContext(ctx)
+}
+
+func parseLogLevel(level string) (zerolog.Level, error) {
+	lvl, err := zerolog.ParseLevel(strings.ToLower(level))
+	if err != nil || lvl == zerolog.NoLevel {
+		return zerolog.NoLevel, fmt.Errorf("unsupported log level %q", level)
+	}
+
+	return lvl, nil
 }
 
 // Build builds a middleware plugin.
-func (b Builder) Build(pName string, config map[string]interface{}, middlewareName string) (Constructor, error) {
+func (b *Builder) Build(pName string, config map[string]interface{}, middlewareName string) (Constructor, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
 	if b.middlewareBuilders == nil {
 		return nil, fmt.Errorf("no plugin definitions in the static configuration: %s", pName)
 	}
@@ -128,45 +232,89 @@ func (b Builder) Build(pName string, config map[string]interface{}, middlewareNa
 	return nil, fmt.Errorf("unknown plugin type: %s", pName)
 }
 
-func newMiddlewareBuilder(ctx context.Context, goPath string, manifest *Manifest, moduleName string, settings Settings) (middlewareBuilder, error) {
+// newMiddlewareBuilder creates the builder of the middlewares of a plugin.
+// When lazy is set, the evaluation of the Yaegi plugins is deferred until a middleware uses them,
+// their sources being only parsed and checked.
+func newMiddlewareBuilder(ctx context.Context, goPath string, manifest *Manifest, moduleName string, settings Settings, lazy bool, processes *grpcProcesses) (middlewareBuilder, error) {
+	builder, err := newRuntimeMiddlewareBuilder(ctx, goPath, manifest, moduleName, settings, lazy, processes)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.ConfigSchema == nil {
+		return builder, nil
+	}
+
+	return newSchemaMiddlewareBuilder(builder, manifest.ConfigSchema)
+}
+
+func newRuntimeMiddlewareBuilder(ctx context.Context, goPath string, manifest *Manifest, moduleName string, settings Settings, lazy bool, processes *grpcProcesses) (middlewareBuilder, error) {
 	switch manifest.Runtime {
+	case runtimeGRPC:
+		return newGRPCMiddlewareBuilder(ctx, goPath, manifest, moduleName, settings, processes)
+
 	case runtimeWasm:
 		wasmPath, err := getWasmPath(manifest)
 		if err != nil {
 			return nil, fmt.Errorf("wasm path: %w", err)
 		}
 
-		return newWasmMiddlewareBuilder(goPath, moduleName, wasmPath, settings)
+		return newWasmMiddlewareBuilder(ctx, goPath, moduleName, wasmPath, settings)
 
 	case runtimeYaegi, "":
-		i, err := newInterpreter(ctx, goPath, manifest.Import)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create Yaegi interpreter: %w", err)
+		build := func() (middlewareBuilder, error) {
+			i, err := newInterpreter(ctx, goPath, manifest.Import)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create Yaegi interpreter: %w", err)
+			}
+
+			return newYaegiMiddlewareBuilder(i, manifest.BasePkg, manifest.Import)
+		}
+
+		if !lazy {
+			return build()
 		}
 
-		return newYaegiMiddlewareBuilder(i, manifest.BasePkg, manifest.Import)
+		if err := checkYaegiMiddlewareSources(goPath, manifest); err != nil {
+			return nil, err
+		}
+
+		log.Ctx(ctx).Debug().Msg("Deferring the evaluation of the plugin sources until a middleware uses it")
+
+		return newLazyMiddlewareBuilder(build), nil
 
 	default:
 		return nil, fmt.Errorf("unknown plugin runtime: %s", manifest.Runtime)
 	}
 }
 
-func newProviderBuilder(ctx context.Context, manifest *Manifest, goPath string) (providerBuilder, error) {
+func newProviderBuilder(ctx context.Context, goPath string, manifest *Manifest, moduleName string, settings Settings) (providerBuilder, error) {
 	switch manifest.Runtime {
+	case runtimeWasm:
+		wasmPath, err := getWasmPath(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("wasm path: %w", err)
+		}
+
+		return newWasmProviderBuilder(ctx, goPath, moduleName, wasmPath, settings)
+
+	case runtimeGRPC:
+		return nil, errors.New("the gRPC runtime works only for middleware plugins")
+
 	case runtimeYaegi, "":
 		i, err := newInterpreter(ctx, goPath, manifest.Import)
 		if err != nil {
-			return providerBuilder{}, err
+			return nil, err
 		}
 
-		return providerBuilder{
+		return yaegiProviderBuilder{
 			interpreter: i,
 			Import:      manifest.Import,
 			BasePkg:     manifest.BasePkg,
 		}, nil
 
 	default:
-		return providerBuilder{}, fmt.Errorf("unknown plugin runtime: %s", manifest.Runtime)
+		return nil, fmt.Errorf("unknown plugin runtime: %s", manifest.Runtime)
 	}
 }
 
@@ -0,0 +1,43 @@
+package plugins
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginContext_logLevel(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		logLevel      string
+		expectedLevel zerolog.Level
+	}{
+		{
+			desc:          "level of Traefik",
+			expectedLevel: log.Logger.GetLevel(),
+		},
+		{
+			desc:          "own level",
+			logLevel:      "TRACE",
+			expectedLevel: zerolog.TraceLevel,
+		},
+		{
+			desc:          "disabled",
+			logLevel:      "disabled",
+			expectedLevel: zerolog.Disabled,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := pluginContext(context.Background(), "demo", "github.com/traefik/plugindemo", runtimeYaegi, test.logLevel)
+
+			assert.Equal(t, test.expectedLevel, log.Ctx(ctx).GetLevel())
+		})
+	}
+}
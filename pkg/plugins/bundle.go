@@ -0,0 +1,319 @@
+package plugins
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	bundleFolder           = "bundle"
+	bundleManifestFilename = "bundle.json"
+)
+
+// bundleManifest describes the plugins of an offline bundle.
+type bundleManifest struct {
+	// Plugins are keyed by module name.
+	Plugins map[string][]bundledPlugin `json:"plugins"`
+}
+
+type bundledPlugin struct {
+	Version   string `json:"version"`
+	Hash      string `json:"hash"`
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// WriteBundle writes a gzipped tarball holding the archives of the given plugins, which must have been set up before,
+// along with their versions, hashes and signatures.
+// The plugins checked out from a Git repository are skipped.
+func (c *Client) WriteBundle(ctx context.Context, plugins map[string]Descriptor, w io.Writer) error {
+	manifest := bundleManifest{Plugins: make(map[string][]bundledPlugin)}
+	bundled := make(pluginsState)
+
+	add := func(name string, desc Descriptor) error {
+		if bundled.has(desc.ModuleName, desc.Version) {
+			return nil
+		}
+
+		hash, err := computeHash(c.buildArchivePath(desc.ModuleName, desc.Version))
+		if err != nil {
+			return fmt.Errorf("%s: failed to compute hash: %w", name, err)
+		}
+
+		plugin := bundledPlugin{Version: desc.Version, Hash: hash}
+
+		if desc.Source == "" && desc.LocalArchive == "" {
+			plugin.Signature, err = c.downloadSignature(ctx, desc.ModuleName, desc.Version)
+			if err != nil && !errors.Is(err, errUnsignedArchive) {
+				return fmt.Errorf("%s: failed to download signature: %w", name, err)
+			}
+		}
+
+		manifest.Plugins[desc.ModuleName] = append(manifest.Plugins[desc.ModuleName], plugin)
+		bundled.add(desc.ModuleName, desc.Version)
+
+		return nil
+	}
+
+	for pAlias, desc := range plugins {
+		if isGitSource(desc.Source) {
+			log.Ctx(ctx).Warn().Msgf("Skipping plugin %s: plugins from a Git repository cannot be bundled", pAlias)
+			continue
+		}
+
+		if err := add(pAlias, desc); err != nil {
+			return err
+		}
+	}
+
+	// The dependencies of the plugins are bundled as well, to be set up without contacting the registry.
+	for _, desc := range c.dependencies {
+		if err := add(desc.ModuleName, desc); err != nil {
+			return err
+		}
+	}
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal bundle manifest: %w", err)
+	}
+
+	if err = writeTarFile(tw, bundleManifestFilename, data); err != nil {
+		return err
+	}
+
+	for pName, versions := range bundled {
+		for _, pVersion := range versions {
+			archive, err := os.ReadFile(c.buildArchivePath(pName, pVersion))
+			if err != nil {
+				return fmt.Errorf("failed to read archive: %w", err)
+			}
+
+			if err = writeTarFile(tw, bundleArchiveName(pName, pVersion), archive); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	if err = gzw.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	return nil
+}
+
+// InstallBundle installs the plugins bundle read from r into the plugins storage,
+// so that the bundled plugins are set up without contacting the plugins registry.
+// A previously installed bundle is replaced.
+func InstallBundle(output string, r io.Reader) (err error) {
+	dir := filepath.Join(filepath.FromSlash(output), bundleFolder)
+	if err = resetDirectory(dir); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(dir)
+		}
+	}()
+
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read bundle: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if !isBundleEntry(header.Name) {
+			return fmt.Errorf("unexpected file %q in bundle", header.Name)
+		}
+
+		dest := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if err = os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+
+		if err = writeFile(dest, tr); err != nil {
+			return err
+		}
+	}
+
+	manifest, err := readBundleManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	if manifest == nil {
+		return fmt.Errorf("missing %s in bundle", bundleManifestFilename)
+	}
+
+	for pName, plugins := range manifest.Plugins {
+		for _, plugin := range plugins {
+			hash, err := computeHash(filepath.Join(dir, filepath.FromSlash(bundleArchiveName(pName, plugin.Version))))
+			if err != nil {
+				return fmt.Errorf("%s@%s: failed to compute hash: %w", pName, plugin.Version, err)
+			}
+
+			if hash != plugin.Hash {
+				return fmt.Errorf("%s@%s: archive hash mismatch", pName, plugin.Version)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isBundled returns true if the given version of the plugin is part of the installed bundle.
+func (c *Client) isBundled(pName, pVersion string) bool {
+	_, ok := c.bundledPlugin(pName, pVersion)
+	return ok
+}
+
+func (c *Client) bundledPlugin(pName, pVersion string) (bundledPlugin, bool) {
+	for _, plugin := range c.bundled[pName] {
+		if plugin.Version == pVersion {
+			return plugin, true
+		}
+	}
+
+	return bundledPlugin{}, false
+}
+
+// bundledVersion returns the latest version of the plugin of the installed bundle matching the constraints.
+func (c *Client) bundledVersion(pName string, constraints *semver.Constraints) (string, bool) {
+	var versions []string
+	for _, plugin := range c.bundled[pName] {
+		versions = append(versions, plugin.Version)
+	}
+
+	version, err := latestMatchingVersion(constraints, versions)
+	if err != nil {
+		return "", false
+	}
+
+	return version, true
+}
+
+// installBundled copies the archive of the plugin from the installed bundle to the archives directory.
+func (c *Client) installBundled(pName, pVersion string) error {
+	src, err := os.Open(filepath.Join(c.bundle, filepath.FromSlash(bundleArchiveName(pName, pVersion))))
+	if err != nil {
+		return fmt.Errorf("failed to open bundled archive: %w", err)
+	}
+
+	defer func() { _ = src.Close() }()
+
+	filename := c.buildArchivePath(pName, pVersion)
+	if err = os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err = writeFile(filename, src); err != nil {
+		return err
+	}
+
+	hash, err := computeHash(filename)
+	if err != nil {
+		return fmt.Errorf("failed to compute hash: %w", err)
+	}
+
+	if plugin, _ := c.bundledPlugin(pName, pVersion); hash != plugin.Hash {
+		return errors.New("archive hash mismatch")
+	}
+
+	return nil
+}
+
+func readBundleManifest(dir string) (*bundleManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, bundleManifestFilename))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle manifest: %w", err)
+	}
+
+	var manifest bundleManifest
+	if err = json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode bundle manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+func bundleArchiveName(pName, pVersion string) string {
+	return path.Join(archivesFolder, pName, pVersion+".zip")
+}
+
+func isBundleEntry(name string) bool {
+	if name == bundleManifestFilename {
+		return true
+	}
+
+	return filepath.IsLocal(filepath.FromSlash(name)) &&
+		strings.HasPrefix(name, archivesFolder+"/") &&
+		strings.HasSuffix(name, ".zip")
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0o644,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	if _, err = tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	return nil
+}
+
+func writeFile(filename string, r io.Reader) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file %q: %w", filename, err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	if _, err = io.Copy(file, r); err != nil {
+		return fmt.Errorf("failed to write file %q: %w", filename, err)
+	}
+
+	return nil
+}
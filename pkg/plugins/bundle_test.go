@@ -0,0 +1,109 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle(t *testing.T) {
+	registry := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/signature/github.com/traefik/plugindemo/v0.1.0" {
+			_, _ = rw.Write([]byte("c2lnbmF0dXJl"))
+			return
+		}
+
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(registry.Close)
+
+	client, err := NewClient(ClientOptions{Output: t.TempDir(), RegistryURL: registry.URL})
+	require.NoError(t, err)
+
+	plugins := map[string]Descriptor{
+		"demo": {ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0"},
+		"oci":  {ModuleName: "github.com/traefik/pluginoci", Version: "v1.2.0", Source: "oci://ghcr.io/traefik/pluginoci"},
+		"git":  {ModuleName: "github.com/traefik/plugingit", Version: "v1.0.0", Source: "git+https://github.com/traefik/plugingit"},
+	}
+
+	for _, desc := range plugins {
+		if isGitSource(desc.Source) {
+			continue
+		}
+
+		filename := client.buildArchivePath(desc.ModuleName, desc.Version)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0o755))
+		require.NoError(t, os.WriteFile(filename, []byte(desc.ModuleName), 0o600))
+	}
+
+	var bundle bytes.Buffer
+	err = client.WriteBundle(context.Background(), plugins, &bundle)
+	require.NoError(t, err)
+
+	output := t.TempDir()
+	err = InstallBundle(output, &bundle)
+	require.NoError(t, err)
+
+	offlineClient, err := NewClient(ClientOptions{Output: output, RegistryURL: "http://127.0.0.1:0"})
+	require.NoError(t, err)
+
+	assert.True(t, offlineClient.isBundled("github.com/traefik/plugindemo", "v0.1.0"))
+	assert.True(t, offlineClient.isBundled("github.com/traefik/pluginoci", "v1.2.0"))
+	assert.False(t, offlineClient.isBundled("github.com/traefik/pluginoci", "v1.3.0"))
+	assert.False(t, offlineClient.isBundled("github.com/traefik/plugingit", "v1.0.0"))
+
+	constraints, err := semver.NewConstraint("~1.2")
+	require.NoError(t, err)
+	version, ok := offlineClient.bundledVersion("github.com/traefik/pluginoci", constraints)
+	assert.True(t, ok)
+	assert.Equal(t, "v1.2.0", version)
+
+	signature, err := offlineClient.getSignature(context.Background(), "github.com/traefik/plugindemo", "v0.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("signature"), signature)
+
+	_, err = offlineClient.getSignature(context.Background(), "github.com/traefik/pluginoci", "v1.2.0")
+	require.ErrorIs(t, err, errUnsignedArchive)
+
+	err = offlineClient.installBundled("github.com/traefik/plugindemo", "v0.1.0")
+	require.NoError(t, err)
+
+	archive, err := os.ReadFile(offlineClient.buildArchivePath("github.com/traefik/plugindemo", "v0.1.0"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("github.com/traefik/plugindemo"), archive)
+}
+
+func TestInstallBundle_invalid(t *testing.T) {
+	err := InstallBundle(t.TempDir(), bytes.NewReader([]byte("not a bundle")))
+	require.Error(t, err)
+}
+
+func Test_isBundleEntry(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected bool
+	}{
+		{name: "bundle.json", expected: true},
+		{name: "archives/github.com/traefik/plugindemo/v0.1.0.zip", expected: true},
+		{name: "archives/../../etc/passwd.zip", expected: false},
+		{name: "/archives/plugin.zip", expected: false},
+		{name: "archives/plugin.sh", expected: false},
+		{name: "state.json", expected: false},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, isBundleEntry(test.name))
+		})
+	}
+}
@@ -3,7 +3,10 @@ package plugins
 import (
 	zipa "archive/zip"
 	"context"
+	"crypto"
 	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -14,14 +17,18 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/logs"
+	"github.com/traefik/traefik/v3/pkg/types"
 	"golang.org/x/mod/module"
 	"golang.org/x/mod/zip"
+	"golang.org/x/net/http/httpproxy"
 	"gopkg.in/yaml.v3"
 )
 
@@ -42,28 +49,90 @@ const (
 // ClientOptions the options of a Traefik plugins client.
 type ClientOptions struct {
 	Output string
+
+	// RegistryURL is the URL of the plugins registry, defaults to the public Traefik plugins registry.
+	RegistryURL string
+	// Token is sent as a bearer token to authenticate against the plugins registry.
+	Token string
+	// TLS is the TLS configuration used to connect to the plugins registry.
+	TLS *types.ClientTLS
+
+	// PublicKey is the PEM encoded public key used to verify the plugin archives signatures.
+	PublicKey types.FileOrContent
+	// RequireSignature makes the verification of unsigned plugin archives fail.
+	RequireSignature bool
+
+	// Mirrors are the URLs of the plugins registry mirrors, tried in order when the registry is unavailable.
+	Mirrors []string
+	// Retry is the retry configuration of the requests to the plugins registry, defaults to 3 retries.
+	Retry *RegistryRetry
+
+	// Proxy is the HTTP(S) proxy used to reach the registry, defaults to the one of the environment variables.
+	Proxy *RegistryProxy
+	// RootCAs are CA certificates trusted in addition to the system ones, or to the CA of the TLS configuration.
+	RootCAs []types.FileOrContent
+
+	// Storage is the configuration of the storage of the plugin archives shared by several instances.
+	Storage *Storage
+	// KeepLast is the number of unused versions of each plugin kept in the plugins storage by the garbage collection.
+	KeepLast int
 }
 
 // Client a Traefik plugins client.
 type Client struct {
 	HTTPClient *http.Client
-	baseURL    *url.URL
+	// registryURLs are the URLs of the registry followed by its mirrors.
+	registryURLs []*url.URL
+	token        string
+
+	publicKey        crypto.PublicKey
+	requireSignature bool
 
 	archives  string
 	stateFile string
 	goPath    string
-	sources   string
+
+	// goPaths isolate the versions of the plugins loaded in several versions, keyed by module@version.
+	goPaths map[string]string
+
+	// bundle is the directory of the installed offline bundle, and bundled its plugins keyed by module name.
+	bundle  string
+	bundled map[string][]bundledPlugin
+
+	// shared is the storage of the plugin archives shared by several instances, if any.
+	shared sharedStore
+
+	// dependencies are the plugin modules set up as dependencies of the plugins.
+	dependencies []Descriptor
+
+	// skipped are the non-required plugins which could not be set up, keyed by alias.
+	skipped map[string]skippedPlugin
+
+	// keepLast is the number of unused versions of each plugin kept by the garbage collection,
+	// and checkouts the Git checkouts used by the last setup.
+	keepLast  int
+	checkouts map[string]bool
 }
 
 // NewClient creates a new Traefik plugins client.
 func NewClient(opts ClientOptions) (*Client, error) {
-	baseURL, err := url.Parse(pluginsURL)
-	if err != nil {
-		return nil, err
+	registryURL := pluginsURL
+	if opts.RegistryURL != "" {
+		registryURL = opts.RegistryURL
+	}
+
+	var registryURLs []*url.URL
+	for _, rawURL := range append([]string{registryURL}, opts.Mirrors...) {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse registry URL %q: %w", rawURL, err)
+		}
+
+		registryURLs = append(registryURLs, u)
 	}
 
 	sourcesRootPath := filepath.Join(filepath.FromSlash(opts.Output), sourcesFolder)
-	err = resetDirectory(sourcesRootPath)
+	err := resetDirectory(sourcesRootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -79,31 +148,177 @@ func NewClient(opts ClientOptions) (*Client, error) {
 		return nil, fmt.Errorf("failed to create archives directory %s: %w", archivesPath, err)
 	}
 
+	bundlePath := filepath.Join(filepath.FromSlash(opts.Output), bundleFolder)
+	bundle, err := readBundleManifest(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var bundled map[string][]bundledPlugin
+	if bundle != nil {
+		bundled = bundle.Plugins
+	}
+
+	var publicKey crypto.PublicKey
+	if opts.PublicKey != "" {
+		data, err := opts.PublicKey.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read public key: %w", err)
+		}
+
+		publicKey, err = parsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key: %w", err)
+		}
+	} else if opts.RequireSignature {
+		return nil, errors.New("a public key is required to verify plugin signatures")
+	}
+
+	transport, err := newRegistryTransport(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := newSharedStore(opts.Storage, transport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plugins shared storage: %w", err)
+	}
+
 	client := retryablehttp.NewClient()
 	client.Logger = logs.NewRetryableHTTPLogger(log.Logger)
-	client.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	client.HTTPClient = &http.Client{Timeout: 10 * time.Second, Transport: transport}
 	client.RetryMax = 3
 
+	if opts.Retry != nil {
+		client.RetryMax = opts.Retry.Attempts
+		if opts.Retry.InitialInterval > 0 {
+			client.RetryWaitMin = time.Duration(opts.Retry.InitialInterval)
+		}
+		if opts.Retry.MaxInterval > 0 {
+			client.RetryWaitMax = time.Duration(opts.Retry.MaxInterval)
+		}
+	}
+
 	return &Client{
-		HTTPClient: client.StandardClient(),
-		baseURL:    baseURL,
+		HTTPClient:   client.StandardClient(),
+		registryURLs: registryURLs,
+		token:        opts.Token,
+
+		publicKey:        publicKey,
+		requireSignature: opts.RequireSignature,
 
 		archives:  archivesPath,
 		stateFile: filepath.Join(archivesPath, stateFilename),
 
-		goPath:  goPath,
-		sources: filepath.Join(goPath, goPathSrc),
+		goPath: goPath,
+
+		bundle:  bundlePath,
+		bundled: bundled,
+
+		shared: shared,
+
+		keepLast: opts.KeepLast,
 	}, nil
 }
 
+func newRegistryTransport(opts ClientOptions) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.TLS != nil {
+		var err error
+		transport.TLSClientConfig, err = opts.TLS.CreateTLSConfig(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create registry TLS configuration: %w", err)
+		}
+	}
+
+	if len(opts.RootCAs) > 0 {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+
+		roots := transport.TLSClientConfig.RootCAs
+		if roots == nil {
+			var err error
+			roots, err = x509.SystemCertPool()
+			if err != nil {
+				return nil, fmt.Errorf("failed to load system CA certificates: %w", err)
+			}
+		}
+
+		for _, rootCA := range opts.RootCAs {
+			data, err := rootCA.Read()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+			}
+
+			if !roots.AppendCertsFromPEM(data) {
+				return nil, errors.New("failed to parse CA certificate")
+			}
+		}
+
+		transport.TLSClientConfig.RootCAs = roots
+	}
+
+	if opts.Proxy != nil && opts.Proxy.URL != "" {
+		if _, err := url.Parse(opts.Proxy.URL); err != nil {
+			return nil, fmt.Errorf("failed to parse proxy URL: %w", err)
+		}
+
+		proxyConfig := httpproxy.Config{
+			HTTPProxy:  opts.Proxy.URL,
+			HTTPSProxy: opts.Proxy.URL,
+			NoProxy:    strings.Join(opts.Proxy.NoProxy, ","),
+		}
+
+		proxy := proxyConfig.ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxy(req.URL)
+		}
+	}
+
+	return transport, nil
+}
+
 // GoPath gets the plugins GoPath.
 func (c *Client) GoPath() string {
 	return c.goPath
 }
 
+// GoPathOf gets the GoPath holding the sources of the given version of the plugin.
+func (c *Client) GoPathOf(pName, pVersion string) string {
+	if goPath, ok := c.goPaths[pName+"@"+pVersion]; ok {
+		return goPath
+	}
+
+	return c.goPath
+}
+
+// isolateVersions creates a dedicated GoPath for each version of the plugins loaded in several versions,
+// since the sources of a module are stored under its module name.
+func (c *Client) isolateVersions(plugins map[string]Descriptor) error {
+	c.goPaths = make(map[string]string)
+
+	for pName, pVersions := range newPluginsState(plugins) {
+		if len(pVersions) < 2 {
+			continue
+		}
+
+		for _, pVersion := range pVersions {
+			goPath, err := os.MkdirTemp(filepath.Dir(c.goPath), "gop-*")
+			if err != nil {
+				return fmt.Errorf("failed to create GoPath: %w", err)
+			}
+
+			c.goPaths[pName+"@"+pVersion] = goPath
+		}
+	}
+
+	return nil
+}
+
 // ReadManifest reads a plugin manifest.
-func (c *Client) ReadManifest(moduleName string) (*Manifest, error) {
-	return ReadManifest(c.goPath, moduleName)
+func (c *Client) ReadManifest(moduleName, version string) (*Manifest, error) {
+	return ReadManifest(c.GoPathOf(moduleName, version), moduleName)
 }
 
 // ReadManifest reads a plugin manifest.
@@ -143,23 +358,9 @@ func (c *Client) Download(ctx context.Context, pName, pVersion string) (string,
 		}
 	}
 
-	endpoint, err := c.baseURL.Parse(path.Join(c.baseURL.Path, "download", pName, pVersion))
-	if err != nil {
-		return "", fmt.Errorf("failed to parse endpoint URL: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	if hash != "" {
-		req.Header.Set(hashHeader, hash)
-	}
-
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.callRegistry(ctx, hash, "download", pName, pVersion)
 	if err != nil {
-		return "", fmt.Errorf("failed to call service: %w", err)
+		return "", err
 	}
 
 	defer func() { _ = resp.Body.Close() }()
@@ -201,54 +402,74 @@ func (c *Client) Download(ctx context.Context, pName, pVersion string) (string,
 	}
 }
 
-// Check checks the plugin archive integrity.
-func (c *Client) Check(ctx context.Context, pName, pVersion, hash string) error {
-	endpoint, err := c.baseURL.Parse(path.Join(c.baseURL.Path, "validate", pName, pVersion))
+// Check checks the plugin archive integrity, against the pinned hash if any, and against the registry.
+func (c *Client) Check(ctx context.Context, pName, pVersion, hash, pinnedHash string) error {
+	err := checkPinnedHash(hash, pinnedHash)
 	if err != nil {
-		return fmt.Errorf("failed to parse endpoint URL: %w", err)
+		return err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	resp, err := c.callRegistry(ctx, hash, "validate", pName, pVersion)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
 
-	if hash != "" {
-		req.Header.Set(hashHeader, hash)
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	return errors.New("plugin integrity check failed")
+}
+
+// checkPinnedArchive checks the archive of the plugin against the pinned hash of its descriptor, if any.
+func (c *Client) checkPinnedArchive(desc Descriptor) error {
+	if desc.Hash == "" {
+		return nil
+	}
+
+	hash, err := computeHash(c.buildArchivePath(desc.ModuleName, desc.Version))
 	if err != nil {
-		return fmt.Errorf("failed to call service: %w", err)
+		return fmt.Errorf("failed to compute hash: %w", err)
 	}
 
-	defer func() { _ = resp.Body.Close() }()
+	return checkPinnedHash(hash, desc.Hash)
+}
 
-	if resp.StatusCode == http.StatusOK {
+// checkPinnedHash checks the hash of an archive against the pinned one, if any.
+func checkPinnedHash(hash, pinnedHash string) error {
+	if pinnedHash == "" || strings.EqualFold(hash, pinnedHash) {
 		return nil
 	}
 
-	return errors.New("plugin integrity check failed")
+	return fmt.Errorf("archive hash %s does not match the pinned hash %s", hash, pinnedHash)
 }
 
 // Unzip unzip a plugin archive.
 func (c *Client) Unzip(pName, pVersion string) error {
-	err := c.unzipModule(pName, pVersion)
+	return c.unzip(c.GoPathOf(pName, pVersion), pName, pVersion)
+}
+
+// unzip extracts the archive of the plugin in the given GoPath.
+func (c *Client) unzip(goPath, pName, pVersion string) error {
+	dest := filepath.Join(goPath, goPathSrc, filepath.FromSlash(pName))
+
+	err := c.unzipModule(pName, pVersion, dest)
 	if err == nil {
 		return nil
 	}
 
-	return c.unzipArchive(pName, pVersion)
+	return c.unzipArchive(pName, pVersion, dest)
 }
 
-func (c *Client) unzipModule(pName, pVersion string) error {
+func (c *Client) unzipModule(pName, pVersion, dest string) error {
 	src := c.buildArchivePath(pName, pVersion)
-	dest := filepath.Join(c.sources, filepath.FromSlash(pName))
 
 	return zip.Unzip(dest, module.Version{Path: pName, Version: pVersion}, src)
 }
 
-func (c *Client) unzipArchive(pName, pVersion string) error {
+func (c *Client) unzipArchive(pName, pVersion, dest string) error {
 	zipPath := c.buildArchivePath(pName, pVersion)
 
 	archive, err := zipa.OpenReader(zipPath)
@@ -258,8 +479,6 @@ func (c *Client) unzipArchive(pName, pVersion string) error {
 
 	defer func() { _ = archive.Close() }()
 
-	dest := filepath.Join(c.sources, filepath.FromSlash(pName))
-
 	for _, f := range archive.File {
 		err = unzipFile(f, dest)
 		if err != nil {
@@ -318,46 +537,115 @@ func unzipFile(f *zipa.File, dest string) error {
 	return nil
 }
 
-// CleanArchives cleans plugins archives.
-func (c *Client) CleanArchives(plugins map[string]Descriptor) error {
+// isStored returns whether the archive of the given version of a plugin is in the plugins storage.
+func (c *Client) isStored(pName, pVersion string) bool {
+	_, err := os.Stat(c.buildArchivePath(pName, pVersion))
+	return err == nil
+}
+
+// pluginsState maps the module names to their versions set up by the last run.
+type pluginsState map[string][]string
+
+func newPluginsState(plugins map[string]Descriptor) pluginsState {
+	state := make(pluginsState)
+	for _, desc := range plugins {
+		state.add(desc.ModuleName, desc.Version)
+	}
+
+	return state
+}
+
+func (s pluginsState) add(pName, pVersion string) {
+	if s.has(pName, pVersion) {
+		return
+	}
+
+	s[pName] = append(s[pName], pVersion)
+	sort.Strings(s[pName])
+}
+
+func (s pluginsState) has(pName, pVersion string) bool {
+	return slices.Contains(s[pName], pVersion)
+}
+
+// MarshalJSON stores the modules with a single version as a string, as in the state files of the previous releases.
+func (s pluginsState) MarshalJSON() ([]byte, error) {
+	raw := make(map[string]interface{}, len(s))
+	for pName, pVersions := range s {
+		if len(pVersions) == 1 {
+			raw[pName] = pVersions[0]
+			continue
+		}
+
+		raw[pName] = pVersions
+	}
+
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON reads a module version stored either as a string, or as a list of versions.
+func (s *pluginsState) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	state := make(pluginsState, len(raw))
+	for pName, value := range raw {
+		var pVersion string
+		if err := json.Unmarshal(value, &pVersion); err == nil {
+			state[pName] = []string{pVersion}
+			continue
+		}
+
+		var pVersions []string
+		if err := json.Unmarshal(value, &pVersions); err != nil {
+			return fmt.Errorf("invalid versions of the plugin %s: %w", pName, err)
+		}
+
+		state[pName] = pVersions
+	}
+
+	*s = state
+
+	return nil
+}
+
+// readState reads the plugins state file, which maps the module names to their versions.
+func (c *Client) readState() (pluginsState, error) {
+	previous := make(pluginsState)
+
 	if _, err := os.Stat(c.stateFile); os.IsNotExist(err) {
-		return nil
+		return previous, nil
 	}
 
 	stateFile, err := os.Open(c.stateFile)
 	if err != nil {
-		return fmt.Errorf("failed to open state file %s: %w", c.stateFile, err)
+		return nil, fmt.Errorf("failed to open state file %s: %w", c.stateFile, err)
 	}
 
-	previous := make(map[string]string)
+	defer func() { _ = stateFile.Close() }()
+
 	err = json.NewDecoder(stateFile).Decode(&previous)
 	if err != nil {
-		return fmt.Errorf("failed to decode state file %s: %w", c.stateFile, err)
-	}
-
-	for pName, pVersion := range previous {
-		for _, desc := range plugins {
-			if desc.ModuleName == pName && desc.Version != pVersion {
-				archivePath := c.buildArchivePath(pName, pVersion)
-				if err = os.RemoveAll(archivePath); err != nil {
-					return fmt.Errorf("failed to remove archive %s: %w", archivePath, err)
-				}
-			}
-		}
+		return nil, fmt.Errorf("failed to decode state file %s: %w", c.stateFile, err)
 	}
 
-	return nil
+	return previous, nil
 }
 
-// WriteState writes the plugins state files.
+// WriteState writes the plugins state files, including the dependencies of the plugins.
 func (c *Client) WriteState(plugins map[string]Descriptor) error {
-	m := make(map[string]string)
-
-	for _, descriptor := range plugins {
-		m[descriptor.ModuleName] = descriptor.Version
+	state := newPluginsState(plugins)
+	for _, dep := range c.dependencies {
+		state.add(dep.ModuleName, dep.Version)
 	}
 
-	mp, err := json.MarshalIndent(m, "", "  ")
+	return c.writeState(state)
+}
+
+func (c *Client) writeState(state pluginsState) error {
+	mp, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
 		return fmt.Errorf("unable to marshal plugin state: %w", err)
 	}
@@ -384,10 +672,71 @@ func (c *Client) ResetAll() error {
 	return nil
 }
 
+// callRegistry sends a GET request to the given path of the registry.
+// When the registry cannot be reached, or answers with a server error once the retries are exhausted,
+// the request is sent to the next mirror.
+func (c *Client) callRegistry(ctx context.Context, hash string, elem ...string) (*http.Response, error) {
+	var errs []error
+	for i, registryURL := range c.registryURLs {
+		resp, err := c.callRegistryURL(ctx, registryURL, hash, elem...)
+		if err == nil && (resp.StatusCode < http.StatusInternalServerError || i == len(c.registryURLs)-1) {
+			return resp, nil
+		}
+
+		if err == nil {
+			_ = resp.Body.Close()
+			err = fmt.Errorf("error: %d", resp.StatusCode)
+		}
+
+		if i < len(c.registryURLs)-1 {
+			log.Ctx(ctx).Warn().Err(err).Msgf("Plugins registry %s unavailable, falling back to the mirror %s", registryURL.Redacted(), c.registryURLs[i+1].Redacted())
+		}
+
+		errs = append(errs, err)
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+func (c *Client) callRegistryURL(ctx context.Context, registryURL *url.URL, hash string, elem ...string) (*http.Response, error) {
+	endpoint, err := registryURL.Parse(path.Join(append([]string{registryURL.Path}, elem...)...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if hash != "" {
+		req.Header.Set(hashHeader, hash)
+	}
+
+	c.setAuthorization(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call service: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) setAuthorization(req *http.Request) {
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+}
+
 func (c *Client) buildArchivePath(pName, pVersion string) string {
 	return filepath.Join(c.archives, filepath.FromSlash(pName), pVersion+".zip")
 }
 
+func (c *Client) buildSourcesPath(pName, pVersion string) string {
+	return filepath.Join(c.GoPathOf(pName, pVersion), goPathSrc, filepath.FromSlash(pName))
+}
+
 func resetDirectory(dir string) error {
 	dirPath, err := filepath.Abs(dir)
 	if err != nil {
@@ -0,0 +1,463 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/types"
+)
+
+func TestClient_Download_mirrors(t *testing.T) {
+	var registryCalls atomic.Int32
+	registry := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		registryCalls.Add(1)
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(registry.Close)
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	mirror := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/mirror/download/github.com/traefik/plugindemo/v0.1.0" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = rw.Write([]byte("archive"))
+	}))
+	t.Cleanup(mirror.Close)
+
+	client, err := NewClient(ClientOptions{
+		Output:      t.TempDir(),
+		RegistryURL: registry.URL,
+		Mirrors:     []string{unreachable.URL, mirror.URL + "/mirror/"},
+		Retry: &RegistryRetry{
+			Attempts:        1,
+			InitialInterval: ptypes.Duration(time.Millisecond),
+			MaxInterval:     ptypes.Duration(time.Millisecond),
+		},
+	})
+	require.NoError(t, err)
+
+	hash, err := client.Download(context.Background(), "github.com/traefik/plugindemo", "v0.1.0")
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, hash)
+	assert.Equal(t, int32(2), registryCalls.Load())
+}
+
+func TestClient_Check_pinnedHash(t *testing.T) {
+	// The compromised registry validates any archive.
+	registry := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(registry.Close)
+
+	client, err := NewClient(ClientOptions{Output: t.TempDir(), RegistryURL: registry.URL})
+	require.NoError(t, err)
+
+	hash := hashOf("archive")
+
+	testCases := []struct {
+		desc        string
+		pinnedHash  string
+		expectedErr string
+	}{
+		{
+			desc: "no pinned hash",
+		},
+		{
+			desc:       "matching pinned hash",
+			pinnedHash: strings.ToUpper(hash),
+		},
+		{
+			desc:        "tampered archive",
+			pinnedHash:  strings.Repeat("0", 64),
+			expectedErr: "archive hash " + hash + " does not match the pinned hash " + strings.Repeat("0", 64),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := client.Check(context.Background(), "github.com/traefik/plugindemo", "v0.1.0", hash, test.pinnedHash)
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckRemotePluginsConfiguration_pinnedHash(t *testing.T) {
+	validHash := strings.Repeat("a", 64)
+
+	testCases := []struct {
+		desc        string
+		plugin      Descriptor
+		expectedErr string
+	}{
+		{
+			desc:   "exact version",
+			plugin: Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0", Hash: validHash},
+		},
+		{
+			desc:        "version constraint",
+			plugin:      Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "^0.1", Hash: validHash},
+			expectedErr: "demo: a pinned hash requires an exact plugin version",
+		},
+		{
+			desc:        "git source",
+			plugin:      Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0", Source: "git+https://github.com/traefik/plugindemo.git", Hash: validHash},
+			expectedErr: "demo: a pinned hash is not supported for plugins from a Git repository",
+		},
+		{
+			desc:        "invalid hash",
+			plugin:      Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0", Hash: "foo"},
+			expectedErr: "demo: the pinned hash must be a hex encoded SHA-256 hash",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkRemotePluginsConfiguration(map[string]Descriptor{"demo": test.plugin})
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckRemotePluginsConfiguration_logLevel(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		logLevel    string
+		expectedErr string
+	}{
+		{
+			desc:     "valid log level",
+			logLevel: "DEBUG",
+		},
+		{
+			desc:     "disabled",
+			logLevel: "disabled",
+		},
+		{
+			desc:        "unsupported log level",
+			logLevel:    "verbose",
+			expectedErr: `demo: unsupported log level "verbose"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkRemotePluginsConfiguration(map[string]Descriptor{
+				"demo": {ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0", LogLevel: test.logLevel},
+			})
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckRemotePluginsConfiguration_fallbackVersion(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		plugin      Descriptor
+		expectedErr string
+	}{
+		{
+			desc:   "exact version",
+			plugin: Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "^0.2", FallbackVersion: "v0.1.0"},
+		},
+		{
+			desc:        "version constraint",
+			plugin:      Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0", FallbackVersion: "^0.1"},
+			expectedErr: "demo: the fallback version must be an exact plugin version",
+		},
+		{
+			desc:        "pinned hash",
+			plugin:      Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0", FallbackVersion: "v0.1.0", Hash: strings.Repeat("a", 64)},
+			expectedErr: "demo: a pinned hash is not supported with a fallback version",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkRemotePluginsConfiguration(map[string]Descriptor{"demo": test.plugin})
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSetupRemotePlugins_fallbackVersion(t *testing.T) {
+	registry := newDependenciesTestRegistry(t, map[string]string{
+		"github.com/traefik/plugindemo@v0.1.0": `type: middleware`,
+	})
+
+	client, err := NewClient(ClientOptions{Output: t.TempDir(), RegistryURL: registry.URL, Retry: &RegistryRetry{}})
+	require.NoError(t, err)
+
+	plugins := map[string]Descriptor{
+		"demo": {ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0", FallbackVersion: "v0.1.0", Required: true},
+	}
+
+	err = SetupRemotePlugins(client, plugins)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v0.1.0", plugins["demo"].Version)
+	assert.FileExists(t, filepath.Join(client.GoPath(), goPathSrc, "github.com", "traefik", "plugindemo", pluginManifest))
+
+	state, err := client.readState()
+	require.NoError(t, err)
+	assert.Equal(t, pluginsState{"github.com/traefik/plugindemo": {"v0.1.0"}}, state)
+}
+
+func TestSetupRemotePlugins_storedFallbackVersion(t *testing.T) {
+	output := t.TempDir()
+
+	registry := newDependenciesTestRegistry(t, map[string]string{
+		"github.com/traefik/plugindemo@v0.1.0": `type: middleware`,
+	})
+
+	client, err := NewClient(ClientOptions{Output: output, RegistryURL: registry.URL})
+	require.NoError(t, err)
+
+	err = SetupRemotePlugins(client, map[string]Descriptor{
+		"demo": {ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0"},
+	})
+	require.NoError(t, err)
+
+	// The registry is now unreachable.
+	registry.Close()
+
+	client, err = NewClient(ClientOptions{Output: output, RegistryURL: registry.URL, Retry: &RegistryRetry{}})
+	require.NoError(t, err)
+
+	plugins := map[string]Descriptor{
+		"demo": {ModuleName: "github.com/traefik/plugindemo", Version: "^0.2", FallbackVersion: "v0.1.0", Required: true},
+	}
+
+	err = SetupRemotePlugins(client, plugins)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v0.1.0", plugins["demo"].Version)
+	assert.FileExists(t, filepath.Join(client.GoPath(), goPathSrc, "github.com", "traefik", "plugindemo", pluginManifest))
+}
+
+func TestSetupRemotePlugins_unavailableFallbackVersion(t *testing.T) {
+	registry := newDependenciesTestRegistry(t, map[string]string{})
+
+	client, err := NewClient(ClientOptions{Output: t.TempDir(), RegistryURL: registry.URL, Retry: &RegistryRetry{}})
+	require.NoError(t, err)
+
+	plugins := map[string]Descriptor{
+		"demo": {ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0", FallbackVersion: "v0.1.0", Required: true},
+	}
+
+	err = SetupRemotePlugins(client, plugins)
+	require.Error(t, err)
+}
+
+func TestClient_Download_allMirrorsUnavailable(t *testing.T) {
+	registry := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(registry.Close)
+
+	mirror := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(mirror.Close)
+
+	client, err := NewClient(ClientOptions{
+		Output:      t.TempDir(),
+		RegistryURL: registry.URL,
+		Mirrors:     []string{mirror.URL},
+		Retry:       &RegistryRetry{},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Download(context.Background(), "github.com/traefik/plugindemo", "v0.1.0")
+	require.Error(t, err)
+}
+
+func TestClient_Download_proxy(t *testing.T) {
+	var proxiedHosts []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		proxiedHosts = append(proxiedHosts, req.Host)
+
+		if req.URL.String() != "http://registry.example.com/download/github.com/traefik/plugindemo/v0.1.0" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = rw.Write([]byte("archive"))
+	}))
+	t.Cleanup(proxy.Close)
+
+	client, err := NewClient(ClientOptions{
+		Output:      t.TempDir(),
+		RegistryURL: "http://registry.example.com",
+		Proxy:       &RegistryProxy{URL: proxy.URL, NoProxy: []string{"mirror.example.com"}},
+		Retry:       &RegistryRetry{},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Download(context.Background(), "github.com/traefik/plugindemo", "v0.1.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"registry.example.com"}, proxiedHosts)
+}
+
+func TestClient_Download_noProxy(t *testing.T) {
+	var proxyCalls atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		proxyCalls.Add(1)
+		_, _ = rw.Write([]byte("archive"))
+	}))
+	t.Cleanup(proxy.Close)
+
+	client, err := NewClient(ClientOptions{
+		Output:      t.TempDir(),
+		RegistryURL: "http://registry.example.invalid",
+		Proxy:       &RegistryProxy{URL: proxy.URL, NoProxy: []string{".example.invalid"}},
+		Retry:       &RegistryRetry{},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Download(context.Background(), "github.com/traefik/plugindemo", "v0.1.0")
+	require.Error(t, err)
+
+	assert.Zero(t, proxyCalls.Load())
+}
+
+func TestClient_Download_rootCAs(t *testing.T) {
+	registry := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte("archive"))
+	}))
+	t.Cleanup(registry.Close)
+
+	rootCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: registry.Certificate().Raw})
+
+	testCases := []struct {
+		desc      string
+		rootCAs   []types.FileOrContent
+		expectErr bool
+	}{
+		{
+			desc:      "unknown authority",
+			expectErr: true,
+		},
+		{
+			desc:    "custom CA",
+			rootCAs: []types.FileOrContent{types.FileOrContent(rootCA)},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := NewClient(ClientOptions{
+				Output:      t.TempDir(),
+				RegistryURL: registry.URL,
+				RootCAs:     test.rootCAs,
+				Retry:       &RegistryRetry{},
+			})
+			require.NoError(t, err)
+
+			_, err = client.Download(context.Background(), "github.com/traefik/plugindemo", "v0.1.0")
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNewClient_invalidRootCA(t *testing.T) {
+	_, err := NewClient(ClientOptions{
+		Output:  t.TempDir(),
+		RootCAs: []types.FileOrContent{"not a certificate"},
+	})
+	require.Error(t, err)
+}
+
+func TestPluginsState_JSON(t *testing.T) {
+	state := newPluginsState(map[string]Descriptor{
+		"demo":       {ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0"},
+		"canary":     {ModuleName: "github.com/traefik/pluginrewrite", Version: "v1.1.0"},
+		"stable":     {ModuleName: "github.com/traefik/pluginrewrite", Version: "v1.0.0"},
+		"stableCopy": {ModuleName: "github.com/traefik/pluginrewrite", Version: "v1.0.0"},
+	})
+
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"github.com/traefik/plugindemo":"v0.1.0","github.com/traefik/pluginrewrite":["v1.0.0","v1.1.0"]}`, string(data))
+
+	var decoded pluginsState
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, state, decoded)
+}
+
+func TestClient_isolateVersions(t *testing.T) {
+	client, err := NewClient(ClientOptions{Output: t.TempDir()})
+	require.NoError(t, err)
+
+	err = client.isolateVersions(map[string]Descriptor{
+		"demo":   {ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0"},
+		"canary": {ModuleName: "github.com/traefik/pluginrewrite", Version: "v1.1.0"},
+		"stable": {ModuleName: "github.com/traefik/pluginrewrite", Version: "v1.0.0"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, client.GoPath(), client.GoPathOf("github.com/traefik/plugindemo", "v0.1.0"))
+
+	canary := client.GoPathOf("github.com/traefik/pluginrewrite", "v1.1.0")
+	stable := client.GoPathOf("github.com/traefik/pluginrewrite", "v1.0.0")
+
+	assert.NotEqual(t, client.GoPath(), canary)
+	assert.NotEqual(t, client.GoPath(), stable)
+	assert.NotEqual(t, canary, stable)
+	assert.DirExists(t, canary)
+	assert.DirExists(t, stable)
+}
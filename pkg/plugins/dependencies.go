@@ -0,0 +1,146 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/rs/zerolog/log"
+)
+
+// dependencyResolver sets up the plugin modules the plugins depend on, in the GoPath of each dependent plugin.
+// A single version of a module is loaded in a GoPath, and it must match the versions required by all the dependent plugins.
+type dependencyResolver struct {
+	client *Client
+
+	// loaded are the versions of the modules loaded in each GoPath, keyed by GoPath and module name.
+	loaded map[string]map[string]string
+	// fetched are the dependency archives already in the plugins storage.
+	fetched pluginsState
+}
+
+func newDependencyResolver(client *Client, plugins map[string]Descriptor) *dependencyResolver {
+	client.dependencies = nil
+
+	loaded := make(map[string]map[string]string)
+	for _, desc := range plugins {
+		goPath := client.GoPathOf(desc.ModuleName, desc.Version)
+		if loaded[goPath] == nil {
+			loaded[goPath] = make(map[string]string)
+		}
+
+		loaded[goPath][desc.ModuleName] = desc.Version
+	}
+
+	return &dependencyResolver{
+		client:  client,
+		loaded:  loaded,
+		fetched: make(pluginsState),
+	}
+}
+
+// setup resolves the dependencies declared in the manifest of the plugin, and their own dependencies,
+// then downloads and unzips them in the GoPath of the plugin.
+func (r *dependencyResolver) setup(ctx context.Context, desc Descriptor) error {
+	goPath := r.client.GoPathOf(desc.ModuleName, desc.Version)
+
+	queue := []Dependency{{ModuleName: desc.ModuleName, Version: desc.Version}}
+	for len(queue) > 0 {
+		dependent := queue[0]
+		queue = queue[1:]
+
+		manifest, err := ReadManifest(goPath, dependent.ModuleName)
+		if err != nil {
+			return fmt.Errorf("%s: failed to read manifest: %w", dependent.ModuleName, err)
+		}
+
+		for _, dep := range manifest.Dependencies {
+			version, err := r.resolve(ctx, goPath, dependent, dep)
+			if err != nil {
+				return err
+			}
+
+			if version == "" {
+				// Already loaded in the GoPath.
+				continue
+			}
+
+			queue = append(queue, Dependency{ModuleName: dep.ModuleName, Version: version})
+		}
+	}
+
+	return nil
+}
+
+// resolve sets up the given dependency in the GoPath, and returns its version,
+// or an empty version if a matching version is already loaded in the GoPath.
+func (r *dependencyResolver) resolve(ctx context.Context, goPath string, dependent, dep Dependency) (string, error) {
+	if dep.ModuleName == "" || dep.Version == "" {
+		return "", fmt.Errorf("%s@%s: the module name and the version of a dependency are required", dependent.ModuleName, dependent.Version)
+	}
+
+	if version, ok := r.loaded[goPath][dep.ModuleName]; ok {
+		matches, err := versionMatches(version, dep.Version)
+		if err != nil {
+			return "", fmt.Errorf("%s@%s: %w", dependent.ModuleName, dependent.Version, err)
+		}
+
+		if !matches {
+			return "", fmt.Errorf("version conflict: %s@%s depends on %s@%s, but the version %s is already loaded",
+				dependent.ModuleName, dependent.Version, dep.ModuleName, dep.Version, version)
+		}
+
+		return "", nil
+	}
+
+	version := dep.Version
+	if isVersionConstraint(version) {
+		var err error
+		version, err = r.client.ResolveVersion(ctx, Descriptor{ModuleName: dep.ModuleName, Version: dep.Version})
+		if err != nil {
+			return "", fmt.Errorf("%s@%s: unable to resolve version %q of the dependency %s: %w",
+				dependent.ModuleName, dependent.Version, dep.Version, dep.ModuleName, err)
+		}
+	}
+
+	log.Ctx(ctx).Debug().Msgf("Loading of dependency: %s@%s, required by %s@%s", dep.ModuleName, version, dependent.ModuleName, dependent.Version)
+
+	if !r.fetched.has(dep.ModuleName, version) {
+		err := fetchRemotePlugin(ctx, r.client, Descriptor{ModuleName: dep.ModuleName, Version: version})
+		if err != nil {
+			return "", fmt.Errorf("%s@%s: unable to set up the dependency %s@%s: %w", dependent.ModuleName, dependent.Version, dep.ModuleName, version, err)
+		}
+
+		r.fetched.add(dep.ModuleName, version)
+		r.client.dependencies = append(r.client.dependencies, Descriptor{ModuleName: dep.ModuleName, Version: version})
+	}
+
+	err := r.client.unzip(goPath, dep.ModuleName, version)
+	if err != nil {
+		return "", fmt.Errorf("unable to unzip archive of the dependency %s@%s: %w", dep.ModuleName, version, err)
+	}
+
+	if r.loaded[goPath] == nil {
+		r.loaded[goPath] = make(map[string]string)
+	}
+
+	r.loaded[goPath][dep.ModuleName] = version
+
+	return version, nil
+}
+
+// versionMatches returns true if the version is the required one, or matches the required version constraint.
+func versionMatches(version, required string) (bool, error) {
+	if !isVersionConstraint(required) {
+		return version == required, nil
+	}
+
+	constraints, err := semver.NewConstraint(required)
+	if err != nil {
+		return false, fmt.Errorf("invalid version constraint %q: %w", required, err)
+	}
+
+	_, err = latestMatchingVersion(constraints, []string{version})
+
+	return err == nil, nil
+}
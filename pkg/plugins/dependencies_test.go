@@ -0,0 +1,216 @@
+package plugins
+
+import (
+	zipa "archive/zip"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDependenciesTestRegistry creates a plugins registry serving the given manifests, keyed by module@version.
+func newDependenciesTestRegistry(t *testing.T, manifests map[string]string) *httptest.Server {
+	t.Helper()
+
+	versions := make(map[string][]string)
+	for key := range manifests {
+		moduleName, version, _ := strings.Cut(key, "@")
+		versions[moduleName] = append(versions[moduleName], version)
+	}
+
+	registry := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		action, rest, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/")
+
+		switch action {
+		case "versions":
+			_ = json.NewEncoder(rw).Encode(versions[rest])
+
+		case "download":
+			i := strings.LastIndex(rest, "/")
+			key := rest[:i] + "@" + rest[i+1:]
+
+			manifest, ok := manifests[key]
+			if !ok {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			var archive bytes.Buffer
+			zw := zipa.NewWriter(&archive)
+			w, err := zw.Create(key + "/" + pluginManifest)
+			require.NoError(t, err)
+			_, err = w.Write([]byte(manifest))
+			require.NoError(t, err)
+			require.NoError(t, zw.Close())
+
+			_, _ = rw.Write(archive.Bytes())
+
+		case "validate":
+			rw.WriteHeader(http.StatusOK)
+
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(registry.Close)
+
+	return registry
+}
+
+func TestSetupRemotePlugins_dependencies(t *testing.T) {
+	registry := newDependenciesTestRegistry(t, map[string]string{
+		"github.com/traefik/plugina@v1.0.0": `
+type: middleware
+import: github.com/traefik/plugina
+dependencies:
+  - moduleName: github.com/traefik/pluginlib
+    version: ^1.1
+`,
+		"github.com/traefik/pluginb@v1.0.0": `
+type: middleware
+import: github.com/traefik/pluginb
+dependencies:
+  - moduleName: github.com/traefik/pluginlib
+    version: v1.2.0
+`,
+		"github.com/traefik/pluginlib@v1.1.0": `type: middleware`,
+		"github.com/traefik/pluginlib@v1.2.0": `
+type: middleware
+dependencies:
+  - moduleName: github.com/traefik/pluginbase
+    version: v0.1.0
+`,
+		"github.com/traefik/pluginlib@v2.0.0": `type: middleware`,
+		"github.com/traefik/pluginbase@v0.1.0": `
+type: middleware
+dependencies:
+  - moduleName: github.com/traefik/pluginlib
+    version: ^1.0
+`,
+	})
+
+	client, err := NewClient(ClientOptions{Output: t.TempDir(), RegistryURL: registry.URL})
+	require.NoError(t, err)
+
+	plugins := map[string]Descriptor{
+		"a": {ModuleName: "github.com/traefik/plugina", Version: "v1.0.0"},
+		"b": {ModuleName: "github.com/traefik/pluginb", Version: "v1.0.0"},
+	}
+
+	err = SetupRemotePlugins(client, plugins)
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(client.GoPath(), goPathSrc, "github.com", "traefik", "pluginlib", pluginManifest))
+	assert.FileExists(t, filepath.Join(client.GoPath(), goPathSrc, "github.com", "traefik", "pluginbase", pluginManifest))
+
+	manifest, err := ReadManifest(client.GoPath(), "github.com/traefik/pluginlib")
+	require.NoError(t, err)
+	assert.Equal(t, []Dependency{{ModuleName: "github.com/traefik/pluginbase", Version: "v0.1.0"}}, manifest.Dependencies)
+
+	state, err := client.readState()
+	require.NoError(t, err)
+
+	expected := pluginsState{
+		"github.com/traefik/plugina":    {"v1.0.0"},
+		"github.com/traefik/pluginb":    {"v1.0.0"},
+		"github.com/traefik/pluginlib":  {"v1.2.0"},
+		"github.com/traefik/pluginbase": {"v0.1.0"},
+	}
+	assert.Equal(t, expected, state)
+}
+
+func TestSetupRemotePlugins_dependenciesConflict(t *testing.T) {
+	manifests := map[string]string{
+		"github.com/traefik/plugina@v1.0.0": `
+type: middleware
+dependencies:
+  - moduleName: github.com/traefik/pluginlib
+    version: v1.2.0
+`,
+		"github.com/traefik/pluginb@v1.0.0": `
+type: middleware
+dependencies:
+  - moduleName: github.com/traefik/pluginlib
+    version: ^2.0
+`,
+		"github.com/traefik/pluginlib@v1.2.0": `type: middleware`,
+		"github.com/traefik/pluginlib@v2.0.0": `type: middleware`,
+	}
+
+	testCases := []struct {
+		desc            string
+		required        bool
+		expectedErr     string
+		expectedPlugins []string
+	}{
+		{
+			desc:        "required plugin",
+			required:    true,
+			expectedErr: "unable to set up the dependencies of the plugin b: version conflict: github.com/traefik/pluginb@v1.0.0 depends on github.com/traefik/pluginlib@^2.0, but the version v1.2.0 is already loaded",
+		},
+		{
+			desc:            "non required plugin",
+			expectedPlugins: []string{"a"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			registry := newDependenciesTestRegistry(t, manifests)
+
+			client, err := NewClient(ClientOptions{Output: t.TempDir(), RegistryURL: registry.URL})
+			require.NoError(t, err)
+
+			plugins := map[string]Descriptor{
+				"a": {ModuleName: "github.com/traefik/plugina", Version: "v1.0.0", Required: true},
+				"b": {ModuleName: "github.com/traefik/pluginb", Version: "v1.0.0", Required: test.required},
+			}
+
+			err = SetupRemotePlugins(client, plugins)
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			var aliases []string
+			for pAlias := range plugins {
+				aliases = append(aliases, pAlias)
+			}
+			assert.Equal(t, test.expectedPlugins, aliases)
+		})
+	}
+}
+
+func TestVersionMatches(t *testing.T) {
+	testCases := []struct {
+		version  string
+		required string
+		expected bool
+	}{
+		{version: "v1.2.0", required: "v1.2.0", expected: true},
+		{version: "v1.2.1", required: "v1.2.0"},
+		{version: "v1.2.1", required: "~1.2", expected: true},
+		{version: "v1.3.0", required: "~1.2"},
+		{version: "v2.0.0", required: "^1.0"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.version+" "+test.required, func(t *testing.T) {
+			t.Parallel()
+
+			matches, err := versionMatches(test.version, test.required)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, matches)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package plugins
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// CollectGarbage removes from the plugins storage the archives of the versions no longer referenced by the state file,
+// and the Git checkouts not used by the last setup, and returns the removed archives.
+// The keepLast most recent unused versions of each plugin, and the fallback versions of the given plugins, are kept.
+func (c *Client) CollectGarbage(plugins map[string]Descriptor) ([]Archive, error) {
+	referenced, err := c.readState()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, desc := range plugins {
+		if desc.FallbackVersion != "" {
+			referenced.add(desc.ModuleName, desc.FallbackVersion)
+		}
+	}
+
+	archives, err := c.Archives()
+	if err != nil {
+		return nil, err
+	}
+
+	unused := make(map[string][]string)
+	for _, archive := range archives {
+		if !referenced.has(archive.ModuleName, archive.Version) {
+			unused[archive.ModuleName] = append(unused[archive.ModuleName], archive.Version)
+		}
+	}
+
+	var removed []Archive
+	for moduleName, versions := range unused {
+		sortVersionsDesc(versions)
+
+		for _, version := range versions[min(c.keepLast, len(versions)):] {
+			filename := c.buildArchivePath(moduleName, version)
+			if err = os.Remove(filename); err != nil {
+				return removed, fmt.Errorf("failed to remove archive %s: %w", filename, err)
+			}
+
+			removeEmptyDirs(c.archives, filepath.Dir(filename))
+
+			removed = append(removed, Archive{ModuleName: moduleName, Version: version})
+		}
+	}
+
+	sort.Slice(removed, func(i, j int) bool {
+		if removed[i].ModuleName != removed[j].ModuleName {
+			return removed[i].ModuleName < removed[j].ModuleName
+		}
+
+		return removed[i].Version < removed[j].Version
+	})
+
+	return removed, c.collectGitCheckouts()
+}
+
+// collectGitCheckouts removes the Git checkouts not used by the last setup,
+// except for the keepLast most recently created ones of each plugin.
+func (c *Client) collectGitCheckouts() error {
+	type checkout struct {
+		path    string
+		created time.Time
+	}
+
+	// unused are the checkouts not used by the last setup, keyed by Git directory.
+	unused := make(map[string][]checkout)
+
+	err := filepath.WalkDir(c.archives, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() || !commitSHA.MatchString(d.Name()) || filepath.Base(filepath.Dir(path)) != gitFolder {
+			return nil
+		}
+
+		if !c.checkouts[path] {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			unused[filepath.Dir(path)] = append(unused[filepath.Dir(path)], checkout{path: path, created: info.ModTime()})
+		}
+
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list Git checkouts: %w", err)
+	}
+
+	for dir, checkouts := range unused {
+		sort.Slice(checkouts, func(i, j int) bool {
+			return checkouts[i].created.After(checkouts[j].created)
+		})
+
+		for _, co := range checkouts[min(c.keepLast, len(checkouts)):] {
+			if err = os.RemoveAll(co.path); err != nil {
+				return fmt.Errorf("failed to remove checkout %s: %w", co.path, err)
+			}
+		}
+
+		removeEmptyDirs(c.archives, dir)
+	}
+
+	return nil
+}
+
+// sortVersionsDesc sorts the versions from the most recent, the versions which are not semver being the least recent ones.
+func sortVersionsDesc(versions []string) {
+	sort.Slice(versions, func(i, j int) bool {
+		vi, erri := semver.NewVersion(versions[i])
+		vj, errj := semver.NewVersion(versions[j])
+
+		switch {
+		case erri == nil && errj == nil:
+			return vi.GreaterThan(vj)
+		case erri == nil || errj == nil:
+			return erri == nil
+		default:
+			return versions[i] > versions[j]
+		}
+	})
+}
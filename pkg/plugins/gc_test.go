@@ -0,0 +1,107 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CollectGarbage(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		keepLast         int
+		expectedRemoved  []Archive
+		expectedArchives []Archive
+	}{
+		{
+			desc: "no retention",
+			expectedRemoved: []Archive{
+				{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0"},
+				{ModuleName: "github.com/traefik/plugindemo", Version: "v0.10.0"},
+				{ModuleName: "github.com/traefik/pluginold", Version: "v1.0.0"},
+			},
+			expectedArchives: []Archive{
+				{ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0"},
+				{ModuleName: "github.com/traefik/plugindemo", Version: "v0.3.0"},
+				{ModuleName: "github.com/traefik/plugindemo", Version: "v0.9.0"},
+			},
+		},
+		{
+			desc:     "keep the last unused version",
+			keepLast: 1,
+			expectedRemoved: []Archive{
+				{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0"},
+			},
+			expectedArchives: []Archive{
+				{ModuleName: "github.com/traefik/plugindemo", Version: "v0.10.0"},
+				{ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0"},
+				{ModuleName: "github.com/traefik/plugindemo", Version: "v0.3.0"},
+				{ModuleName: "github.com/traefik/plugindemo", Version: "v0.9.0"},
+				{ModuleName: "github.com/traefik/pluginold", Version: "v1.0.0"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			client := newStoreTestClient(t, "",
+				Archive{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0"},
+				Archive{ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0"},
+				Archive{ModuleName: "github.com/traefik/plugindemo", Version: "v0.3.0"},
+				Archive{ModuleName: "github.com/traefik/plugindemo", Version: "v0.9.0"},
+				Archive{ModuleName: "github.com/traefik/plugindemo", Version: "v0.10.0"},
+				Archive{ModuleName: "github.com/traefik/pluginold", Version: "v1.0.0"},
+			)
+			client.keepLast = test.keepLast
+
+			plugins := map[string]Descriptor{
+				"demo":     {ModuleName: "github.com/traefik/plugindemo", Version: "v0.9.0", FallbackVersion: "v0.2.0"},
+				"demo-dep": {ModuleName: "github.com/traefik/plugindemo", Version: "v0.3.0"},
+			}
+			require.NoError(t, client.WriteState(plugins))
+
+			removed, err := client.CollectGarbage(plugins)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedRemoved, removed)
+
+			archives, err := client.Archives()
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedArchives, archives)
+		})
+	}
+}
+
+func TestClient_CollectGarbage_gitCheckouts(t *testing.T) {
+	client := newStoreTestClient(t, "")
+	client.keepLast = 1
+
+	gitDir := filepath.Join(client.archives, "github.com", "traefik", "plugingit", gitFolder)
+
+	var checkouts []string
+	for i, c := range []string{"a", "b", "c"} {
+		checkout := filepath.Join(gitDir, strings.Repeat(c, 40))
+		require.NoError(t, os.MkdirAll(checkout, 0o755))
+
+		modTime := time.Now().Add(time.Duration(i-3) * time.Hour)
+		require.NoError(t, os.Chtimes(checkout, modTime, modTime))
+
+		checkouts = append(checkouts, checkout)
+	}
+
+	// The first checkout is used by the last setup.
+	client.checkouts = map[string]bool{checkouts[0]: true}
+
+	_, err := client.CollectGarbage(nil)
+	require.NoError(t, err)
+
+	assert.DirExists(t, checkouts[0])
+	assert.NoDirExists(t, checkouts[1])
+	assert.DirExists(t, checkouts[2])
+}
@@ -0,0 +1,241 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const gitScheme = "git+"
+
+const gitFolder = "git"
+
+var commitSHA = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// isGitSource returns true if the plugin source is a Git repository.
+func isGitSource(source string) bool {
+	return strings.HasPrefix(source, gitScheme)
+}
+
+// parseGitSource parses a Git source (e.g. git+https://github.com/org/plugin.git#v1.2.0) into the repository URL and the ref to check out.
+// When the source has no ref, the given version is used as ref.
+func parseGitSource(source, version string) (string, string, error) {
+	repository, ref, _ := strings.Cut(strings.TrimPrefix(source, gitScheme), "#")
+	if repository == "" {
+		return "", "", fmt.Errorf("invalid Git source %q: missing repository URL", source)
+	}
+
+	if ref == "" {
+		ref = version
+	}
+
+	if ref == "" {
+		return "", "", fmt.Errorf("invalid Git source %q: missing ref", source)
+	}
+
+	return repository, ref, nil
+}
+
+// CheckoutGit checks out the plugin sources from a Git repository (with its submodules) into the plugins GoPath.
+// Checkouts are cached in the archives directory by commit SHA, so a ref is only fetched again when it moves.
+func (c *Client) CheckoutGit(ctx context.Context, pName, pVersion, source string) error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git is required to use a Git plugin source: %w", err)
+	}
+
+	repository, ref, err := parseGitSource(source, pVersion)
+	if err != nil {
+		return err
+	}
+
+	sha, err := resolveGitRef(ctx, repository, ref)
+	if err != nil {
+		return fmt.Errorf("unable to resolve ref %q: %w", ref, err)
+	}
+
+	checkout := filepath.Join(c.archives, filepath.FromSlash(pName), gitFolder, sha)
+
+	if _, err = os.Stat(checkout); os.IsNotExist(err) {
+		err = cloneGit(ctx, repository, sha, checkout)
+		if err != nil {
+			return err
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to read checkout %s: %w", checkout, err)
+	}
+
+	if c.checkouts == nil {
+		c.checkouts = make(map[string]bool)
+	}
+	c.checkouts[checkout] = true
+
+	dest := c.buildSourcesPath(pName, pVersion)
+
+	return copyDir(checkout, dest)
+}
+
+func resolveGitRef(ctx context.Context, repository, ref string) (string, error) {
+	if commitSHA.MatchString(ref) {
+		return ref, nil
+	}
+
+	out, err := runGit(ctx, "", "ls-remote", repository, ref, ref+"^{}")
+	if err != nil {
+		return "", err
+	}
+
+	var sha string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		// The peeled ref of an annotated tag is the commit it points to.
+		if strings.HasSuffix(fields[1], "^{}") {
+			return fields[0], nil
+		}
+
+		if sha == "" {
+			sha = fields[0]
+		}
+	}
+
+	if sha == "" {
+		return "", errors.New("ref not found")
+	}
+
+	return sha, nil
+}
+
+func cloneGit(ctx context.Context, repository, sha, checkout string) error {
+	err := os.MkdirAll(filepath.Dir(checkout), 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.MkdirTemp(filepath.Dir(checkout), "clone-*")
+	if err != nil {
+		return fmt.Errorf("failed to create clone directory: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	if _, err = runGit(ctx, "", "clone", "--quiet", "--no-checkout", repository, tmp); err != nil {
+		return fmt.Errorf("unable to clone %s: %w", repository, err)
+	}
+
+	if _, err = runGit(ctx, tmp, "checkout", "--quiet", sha); err != nil {
+		return fmt.Errorf("unable to checkout %s: %w", sha, err)
+	}
+
+	if _, err = runGit(ctx, tmp, "submodule", "update", "--quiet", "--init", "--recursive"); err != nil {
+		return fmt.Errorf("unable to update submodules: %w", err)
+	}
+
+	return os.Rename(tmp, checkout)
+}
+
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	// Never prompt for credentials, the credential helpers or SSH agent must be configured beforehand.
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// copyDir copies the src directory into dest, without the Git metadata.
+func copyDir(src, dest string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.Name() == ".git" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			// .git file of a submodule.
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dest, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm()|0o700)
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		return copyFile(path, target, info.Mode().Perm())
+	})
+}
+
+func copyFile(src, dest string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = in.Close() }()
+
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = out.Close() }()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+func listGitTags(ctx context.Context, source string) ([]string, error) {
+	repository, _, _ := strings.Cut(strings.TrimPrefix(source, gitScheme), "#")
+
+	out, err := runGit(ctx, "", "ls-remote", "--tags", "--refs", repository)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		tags = append(tags, strings.TrimPrefix(fields[1], "refs/tags/"))
+	}
+
+	return tags, nil
+}
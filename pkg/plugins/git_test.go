@@ -0,0 +1,64 @@
+package plugins
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitSource(t *testing.T) {
+	repository, ref, err := parseGitSource("git+https://github.com/org/plugin.git#main", "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/org/plugin.git", repository)
+	assert.Equal(t, "main", ref)
+
+	repository, ref, err = parseGitSource("git+ssh://git@github.com/org/plugin.git", "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "ssh://git@github.com/org/plugin.git", repository)
+	assert.Equal(t, "v1.0.0", ref)
+
+	_, _, err = parseGitSource("git+", "v1.0.0")
+	assert.Error(t, err)
+}
+
+func TestClient_CheckoutGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	repository := t.TempDir()
+	gitCmd := func(args ...string) {
+		t.Helper()
+
+		cmd := exec.Command("git", append([]string{"-C", repository, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	gitCmd("init", "--quiet")
+	require.NoError(t, os.WriteFile(filepath.Join(repository, ".traefik.yml"), []byte("displayName: test"), 0o600))
+	gitCmd("add", ".")
+	gitCmd("commit", "--quiet", "-m", "init")
+	gitCmd("tag", "-a", "v1.0.0", "-m", "v1.0.0")
+
+	dir := t.TempDir()
+	client := &Client{archives: filepath.Join(dir, "archives"), goPath: dir}
+
+	err := client.CheckoutGit(context.Background(), "github.com/org/plugin", "v1.0.0", "git+file://"+repository)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "src", "github.com", "org", "plugin", ".traefik.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "displayName: test", string(content))
+
+	assert.NoDirExists(t, filepath.Join(dir, "src", "github.com", "org", "plugin", ".git"))
+
+	tags, err := listGitTags(context.Background(), "git+file://"+repository)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0"}, tags)
+}
@@ -0,0 +1,259 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The messages of the gRPC middleware plugins protocol (proto/middleware.proto) are encoded by hand,
+// the protocol being small enough not to generate its code.
+
+// grpcWireMessage is a message of the gRPC middleware plugins protocol.
+type grpcWireMessage interface {
+	marshal() []byte
+	unmarshal(data []byte) error
+}
+
+// grpcPluginCodec encodes the messages of the gRPC middleware plugins protocol in the protobuf wire format.
+type grpcPluginCodec struct{}
+
+func (grpcPluginCodec) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(grpcWireMessage)
+	if !ok {
+		return nil, fmt.Errorf("unsupported message type %T", v)
+	}
+
+	return msg.marshal(), nil
+}
+
+func (grpcPluginCodec) Unmarshal(data []byte, v any) error {
+	msg, ok := v.(grpcWireMessage)
+	if !ok {
+		return fmt.Errorf("unsupported message type %T", v)
+	}
+
+	return msg.unmarshal(data)
+}
+
+// Name is the one of the protobuf codec, so that the plugins are served by any gRPC server.
+func (grpcPluginCodec) Name() string {
+	return "proto"
+}
+
+type grpcPluginRequest struct {
+	MiddlewareName string
+	Config         []byte
+	Method         string
+	URI            string
+	Host           string
+	RemoteAddr     string
+	Headers        http.Header
+	Body           []byte
+}
+
+func (r *grpcPluginRequest) marshal() []byte {
+	var b []byte
+	b = appendString(b, 1, r.MiddlewareName)
+	b = appendBytes(b, 2, r.Config)
+	b = appendString(b, 3, r.Method)
+	b = appendString(b, 4, r.URI)
+	b = appendString(b, 5, r.Host)
+	b = appendString(b, 6, r.RemoteAddr)
+	b = appendHeaders(b, 7, r.Headers)
+	b = appendBytes(b, 8, r.Body)
+
+	return b
+}
+
+func (r *grpcPluginRequest) unmarshal(data []byte) error {
+	r.Headers = http.Header{}
+
+	return consumeFields(data, func(num protowire.Number, value []byte) error {
+		switch num {
+		case 1:
+			r.MiddlewareName = string(value)
+		case 2:
+			r.Config = value
+		case 3:
+			r.Method = string(value)
+		case 4:
+			r.URI = string(value)
+		case 5:
+			r.Host = string(value)
+		case 6:
+			r.RemoteAddr = string(value)
+		case 7:
+			return consumeHeader(value, r.Headers)
+		case 8:
+			r.Body = value
+		}
+
+		return nil
+	}, nil)
+}
+
+type grpcPluginResponse struct {
+	SetRequestHeaders    http.Header
+	RemoveRequestHeaders []string
+	SetResponseHeaders   http.Header
+	Status               int
+	Body                 []byte
+}
+
+func (r *grpcPluginResponse) marshal() []byte {
+	var b []byte
+	b = appendHeaders(b, 1, r.SetRequestHeaders)
+	for _, name := range r.RemoveRequestHeaders {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendString(b, name)
+	}
+	b = appendHeaders(b, 3, r.SetResponseHeaders)
+	if r.Status != 0 {
+		b = protowire.AppendTag(b, 4, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(int64(r.Status)))
+	}
+	b = appendBytes(b, 5, r.Body)
+
+	return b
+}
+
+func (r *grpcPluginResponse) unmarshal(data []byte) error {
+	r.SetRequestHeaders = http.Header{}
+	r.SetResponseHeaders = http.Header{}
+
+	return consumeFields(data, func(num protowire.Number, value []byte) error {
+		switch num {
+		case 1:
+			return consumeHeader(value, r.SetRequestHeaders)
+		case 2:
+			r.RemoveRequestHeaders = append(r.RemoveRequestHeaders, string(value))
+		case 3:
+			return consumeHeader(value, r.SetResponseHeaders)
+		case 5:
+			r.Body = value
+		}
+
+		return nil
+	}, func(num protowire.Number, value uint64) {
+		if num == 4 {
+			r.Status = int(int32(value))
+		}
+	})
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendBytes(b []byte, num protowire.Number, data []byte) []byte {
+	if len(data) == 0 {
+		return b
+	}
+
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, data)
+}
+
+// appendHeaders appends the headers sorted by name, for the messages to be deterministic.
+func appendHeaders(b []byte, num protowire.Number, headers http.Header) []byte {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var header []byte
+		header = appendString(header, 1, name)
+		for _, value := range headers[name] {
+			header = protowire.AppendTag(header, 2, protowire.BytesType)
+			header = protowire.AppendString(header, value)
+		}
+
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		b = protowire.AppendBytes(b, header)
+	}
+
+	return b
+}
+
+func consumeHeader(data []byte, headers http.Header) error {
+	var name string
+	var values []string
+
+	err := consumeFields(data, func(num protowire.Number, value []byte) error {
+		switch num {
+		case 1:
+			name = string(value)
+		case 2:
+			values = append(values, string(value))
+		}
+
+		return nil
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		return errors.New("header without name")
+	}
+
+	headers[http.CanonicalHeaderKey(name)] = append(headers[http.CanonicalHeaderKey(name)], values...)
+
+	return nil
+}
+
+// consumeFields reads the fields of a message, calling onBytes for the length-delimited fields, and onVarint for the varint ones.
+// The fields of the other types are skipped.
+func consumeFields(data []byte, onBytes func(protowire.Number, []byte) error, onVarint func(protowire.Number, uint64)) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		switch typ {
+		case protowire.BytesType:
+			value, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+
+			if err := onBytes(num, value); err != nil {
+				return err
+			}
+
+		case protowire.VarintType:
+			value, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+
+			if onVarint != nil {
+				onVarint(num, value)
+			}
+
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/safe"
+)
+
+// TestResult is the outcome of a step of the test of a local plugin.
+type TestResult struct {
+	Name   string
+	Detail string
+	Err    error
+}
+
+// Passed returns whether the step passed.
+func (r TestResult) Passed() bool {
+	return r.Err == nil
+}
+
+// RunLocalPluginTests loads a local plugin the same way Traefik does, and runs it with the test data of its manifest:
+// a middleware plugin handles synthetic requests, and a provider plugin must provide a configuration before the timeout.
+// The test stops at the first failing step.
+func RunLocalPluginTests(ctx context.Context, pName string, desc LocalDescriptor, timeout time.Duration) []TestResult {
+	localPlugins := map[string]LocalDescriptor{pName: desc}
+
+	if err := SetupLocalPlugins(localPlugins); err != nil {
+		return []TestResult{{Name: "setup", Err: err}}
+	}
+
+	builder, err := NewBuilder(nil, nil, localPlugins)
+	if err != nil {
+		return []TestResult{{Name: "setup", Err: err}}
+	}
+
+	manifest, err := ReadManifest(localGoPath, desc.ModuleName)
+	if err != nil {
+		return []TestResult{{Name: "setup", Err: err}}
+	}
+
+	results := []TestResult{{Name: "setup", Detail: fmt.Sprintf("%s plugin (%s)", manifest.Type, runtimeOf(manifest))}}
+
+	switch manifest.Type {
+	case typeMiddleware:
+		return append(results, testMiddleware(ctx, builder, pName, manifest.TestData, timeout)...)
+	case typeProvider:
+		return append(results, testProvider(ctx, builder, pName, manifest.TestData, timeout))
+	default:
+		return append(results, TestResult{Name: "run", Err: fmt.Errorf("unknow plugin type: %s", manifest.Type)})
+	}
+}
+
+// testMiddleware builds a middleware with the test data, and sends it synthetic requests.
+// A request fails when the middleware panics, times out, or answers with a server error.
+func testMiddleware(ctx context.Context, builder *Builder, pName string, testData map[string]interface{}, timeout time.Duration) []TestResult {
+	constructor, err := builder.Build(pName, testData, "test")
+	if err != nil {
+		return []TestResult{{Name: "build", Err: err}}
+	}
+
+	var nextCalled atomic.Bool
+	next := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		nextCalled.Store(true)
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	handler, err := constructor(ctx, next)
+	if err != nil {
+		return []TestResult{{Name: "build", Err: err}}
+	}
+
+	results := []TestResult{{Name: "build", Detail: "middleware built with the test data"}}
+
+	for _, req := range newTestRequests() {
+		nextCalled.Store(false)
+
+		result := TestResult{Name: req.Method + " " + req.URL.String()}
+
+		rec, err := serveWithTimeout(handler, req, timeout)
+		switch {
+		case err != nil:
+			result.Err = err
+		case rec.Code >= http.StatusInternalServerError:
+			result.Err = fmt.Errorf("server error status %d", rec.Code)
+		default:
+			result.Detail = fmt.Sprintf("status %d", rec.Code)
+			if nextCalled.Load() {
+				result.Detail += ", next handler called"
+			}
+		}
+
+		results = append(results, result)
+		if result.Err != nil {
+			break
+		}
+	}
+
+	return results
+}
+
+func newTestRequests() []*http.Request {
+	get := httptest.NewRequest(http.MethodGet, "http://localhost/", http.NoBody)
+	get.Header.Set("User-Agent", "traefik-plugin-test")
+
+	post := httptest.NewRequest(http.MethodPost, "http://localhost/test?foo=bar", strings.NewReader(`{"foo":"bar"}`))
+	post.Header.Set("User-Agent", "traefik-plugin-test")
+	post.Header.Set("Content-Type", "application/json")
+
+	return []*http.Request{get, post}
+}
+
+func serveWithTimeout(handler http.Handler, req *http.Request, timeout time.Duration) (*httptest.ResponseRecorder, error) {
+	rec := httptest.NewRecorder()
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errCh <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+
+		handler.ServeHTTP(rec, req)
+		errCh <- nil
+	}()
+
+	select {
+	case err := <-errCh:
+		return rec, err
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("no response after %s", timeout)
+	}
+}
+
+// testProvider builds a provider with the test data, and waits for its first configuration.
+func testProvider(ctx context.Context, builder *Builder, pName string, testData map[string]interface{}, timeout time.Duration) TestResult {
+	result := TestResult{Name: "provide"}
+
+	prov, err := builder.BuildProvider(pName, testData)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	if err = prov.Init(); err != nil {
+		result.Err = fmt.Errorf("init: %w", err)
+		return result
+	}
+
+	pool := safe.NewPool(ctx)
+	cfgChan := make(chan dynamic.Message)
+
+	stopped := make(chan struct{})
+	defer func() {
+		// The configurations pushed while the provider stops are discarded.
+		go func() {
+			for {
+				select {
+				case <-cfgChan:
+				case <-stopped:
+					return
+				}
+			}
+		}()
+
+		pool.Stop()
+		close(stopped)
+	}()
+
+	if err = prov.Provide(cfgChan, pool); err != nil {
+		result.Err = err
+		return result
+	}
+
+	select {
+	case msg := <-cfgChan:
+		result.Detail = describeConfiguration(msg.Configuration)
+	case <-time.After(timeout):
+		result.Err = fmt.Errorf("no configuration provided after %s", timeout)
+	case <-ctx.Done():
+		result.Err = ctx.Err()
+	}
+
+	return result
+}
+
+func describeConfiguration(cfg *dynamic.Configuration) string {
+	var routers, services, middlewares int
+	if cfg != nil && cfg.HTTP != nil {
+		routers, services, middlewares = len(cfg.HTTP.Routers), len(cfg.HTTP.Services), len(cfg.HTTP.Middlewares)
+	}
+
+	return fmt.Sprintf("configuration provided with %d HTTP routers, %d services, and %d middlewares", routers, services, middlewares)
+}
+
+func runtimeOf(manifest *Manifest) string {
+	if manifest.IsYaegiPlugin() {
+		return runtimeYaegi
+	}
+
+	return manifest.Runtime
+}
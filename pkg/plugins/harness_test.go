@@ -0,0 +1,137 @@
+package plugins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const yaegiStatusTestPlugin = `package plugindemo
+
+import (
+	"context"
+	"net/http"
+)
+
+type Config struct {
+	Status int
+}
+
+func CreateConfig() *Config {
+	return &Config{}
+}
+
+func New(ctx context.Context, next http.Handler, config *Config, name string) (http.Handler, error) {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if config.Status == 0 {
+			next.ServeHTTP(rw, req)
+			return
+		}
+
+		rw.WriteHeader(config.Status)
+	}), nil
+}
+`
+
+func TestTestMiddleware(t *testing.T) {
+	goPath := t.TempDir()
+	sources := filepath.Join(goPath, goPathSrc, "github.com", "traefik", "plugindemo")
+	require.NoError(t, os.MkdirAll(sources, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(sources, "plugindemo.go"), []byte(yaegiStatusTestPlugin), 0o600))
+
+	manifest := &Manifest{Type: typeMiddleware, Import: "github.com/traefik/plugindemo"}
+
+	middleware, err := newMiddlewareBuilder(context.Background(), goPath, manifest, "github.com/traefik/plugindemo", Settings{}, false, nil)
+	require.NoError(t, err)
+
+	builder := &Builder{middlewareBuilders: map[string]middlewareBuilder{"demo": middleware}}
+
+	testCases := []struct {
+		desc            string
+		testData        map[string]interface{}
+		expectedResults []TestResult
+	}{
+		{
+			desc:     "forwarding to the next handler",
+			testData: map[string]interface{}{},
+			expectedResults: []TestResult{
+				{Name: "build", Detail: "middleware built with the test data"},
+				{Name: "GET http://localhost/", Detail: "status 200, next handler called"},
+				{Name: "POST http://localhost/test?foo=bar", Detail: "status 200, next handler called"},
+			},
+		},
+		{
+			desc:     "client error",
+			testData: map[string]interface{}{"status": 401},
+			expectedResults: []TestResult{
+				{Name: "build", Detail: "middleware built with the test data"},
+				{Name: "GET http://localhost/", Detail: "status 401"},
+				{Name: "POST http://localhost/test?foo=bar", Detail: "status 401"},
+			},
+		},
+		{
+			desc:     "server error",
+			testData: map[string]interface{}{"status": 502},
+			expectedResults: []TestResult{
+				{Name: "build", Detail: "middleware built with the test data"},
+				{Name: "GET http://localhost/", Err: assert.AnError},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			results := testMiddleware(context.Background(), builder, "demo", test.testData, time.Second)
+			require.Len(t, results, len(test.expectedResults))
+
+			for i, expected := range test.expectedResults {
+				assert.Equal(t, expected.Name, results[i].Name)
+
+				if expected.Err != nil {
+					assert.False(t, results[i].Passed())
+					continue
+				}
+
+				assert.True(t, results[i].Passed())
+				assert.Equal(t, expected.Detail, results[i].Detail)
+			}
+		})
+	}
+}
+
+func TestTestMiddleware_unknownPlugin(t *testing.T) {
+	builder := &Builder{middlewareBuilders: map[string]middlewareBuilder{}}
+
+	results := testMiddleware(context.Background(), builder, "demo", nil, time.Second)
+	require.Len(t, results, 1)
+
+	assert.Equal(t, "build", results[0].Name)
+	assert.EqualError(t, results[0].Err, "unknown plugin type: demo")
+}
+
+func TestTestProvider(t *testing.T) {
+	goPath := t.TempDir()
+	moduleName := "github.com/traefik/wasmprovider"
+
+	pluginDir := filepath.Join(goPath, "src", moduleName)
+	require.NoError(t, os.MkdirAll(pluginDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "plugin.wasm"), pushConfigModule(`{"http":{"services":{"svc":{"loadBalancer":{}}}}}`), 0o644))
+
+	provider, err := newWasmProviderBuilder(context.Background(), goPath, moduleName, "plugin.wasm", Settings{})
+	require.NoError(t, err)
+
+	builder := &Builder{providerBuilders: map[string]providerBuilder{"demo": provider}}
+
+	result := testProvider(context.Background(), builder, "demo", nil, 5*time.Second)
+	require.NoError(t, result.Err)
+
+	assert.Equal(t, "provide", result.Name)
+	assert.Equal(t, "configuration provided with 0 HTTP routers, 1 services, and 0 middlewares", result.Detail)
+}
@@ -0,0 +1,117 @@
+package plugins
+
+import (
+	"sort"
+)
+
+// Status of the plugins of the static configuration.
+const (
+	StatusEnabled = "enabled"
+	StatusSkipped = "skipped"
+)
+
+// Info holds the information about a plugin of the static configuration.
+type Info struct {
+	Name       string `json:"name"`
+	ModuleName string `json:"moduleName"`
+	// Version is the resolved version of the plugin, empty for local plugins.
+	Version string `json:"version,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Runtime string `json:"runtime,omitempty"`
+	Local   bool   `json:"local,omitempty"`
+	Status  string `json:"status"`
+	// Error is the reason why a non-required plugin has been skipped.
+	Error string `json:"error,omitempty"`
+	// Priority is the priority of the configuration of a provider plugin.
+	Priority int `json:"priority,omitempty"`
+	// Conflicts are the elements of the configuration of a provider plugin overridden by the ones of provider plugins with a higher priority.
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+type pluginInfo struct {
+	Info
+
+	circuitBreaker *CircuitBreaker
+	healthCheck    *HealthCheck
+}
+
+func newPluginInfo(pName, moduleName, version string, manifest *Manifest, local bool, circuitBreaker *CircuitBreaker, healthCheck *HealthCheck, priority int) pluginInfo {
+	runtime := manifest.Runtime
+	if manifest.IsYaegiPlugin() {
+		runtime = runtimeYaegi
+	}
+
+	if manifest.Type != typeProvider {
+		priority = 0
+	}
+
+	return pluginInfo{
+		Info: Info{
+			Name:       pName,
+			ModuleName: moduleName,
+			Version:    version,
+			Type:       manifest.Type,
+			Runtime:    runtime,
+			Local:      local,
+			Status:     StatusEnabled,
+			Priority:   priority,
+		},
+		circuitBreaker: circuitBreaker,
+		healthCheck:    healthCheck,
+	}
+}
+
+// Inventory returns the information about the plugins of the static configuration, sorted by name.
+// It includes the non-required plugins skipped because they could not be set up.
+func (b *Builder) Inventory() []Info {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	inventory := make([]Info, 0, len(b.infos))
+	for _, info := range b.infos {
+		info.Conflicts = b.merger.conflictsOf(info.Name)
+		inventory = append(inventory, info.Info)
+	}
+
+	sort.Slice(inventory, func(i, j int) bool {
+		return inventory[i].Name < inventory[j].Name
+	})
+
+	return inventory
+}
+
+type skippedPlugin struct {
+	desc Descriptor
+	err  error
+}
+
+// skipPlugin records a non-required plugin which could not be set up.
+func (c *Client) skipPlugin(pAlias string, desc Descriptor, err error) {
+	if c.skipped == nil {
+		c.skipped = make(map[string]skippedPlugin)
+	}
+
+	c.skipped[pAlias] = skippedPlugin{desc: desc, err: err}
+}
+
+// skippedPluginInfos returns the information about the plugins skipped by the last setup.
+func (c *Client) skippedPluginInfos() map[string]pluginInfo {
+	infos := make(map[string]pluginInfo)
+	if c == nil {
+		return infos
+	}
+
+	for pAlias, skipped := range c.skipped {
+		infos[pAlias] = pluginInfo{
+			Info: Info{
+				Name:       pAlias,
+				ModuleName: skipped.desc.ModuleName,
+				Version:    skipped.desc.Version,
+				Status:     StatusSkipped,
+				Error:      skipped.err.Error(),
+			},
+		}
+	}
+
+	return infos
+}
@@ -0,0 +1,39 @@
+package plugins
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder_Inventory(t *testing.T) {
+	client := &Client{}
+	client.skipPlugin("unavailable", Descriptor{ModuleName: "github.com/traefik/pluginunavailable", Version: "v1.0.0"}, errors.New("boom"))
+
+	builder, err := NewBuilder(client, nil, nil)
+	require.NoError(t, err)
+
+	builder.infos["demo"] = newPluginInfo("demo", "github.com/traefik/plugindemo", "v0.2.1", &Manifest{Type: typeMiddleware}, false, nil, nil, 0)
+
+	expected := []Info{
+		{
+			Name:       "demo",
+			ModuleName: "github.com/traefik/plugindemo",
+			Version:    "v0.2.1",
+			Type:       typeMiddleware,
+			Runtime:    runtimeYaegi,
+			Status:     StatusEnabled,
+		},
+		{
+			Name:       "unavailable",
+			ModuleName: "github.com/traefik/pluginunavailable",
+			Version:    "v1.0.0",
+			Status:     StatusSkipped,
+			Error:      "boom",
+		},
+	}
+
+	assert.Equal(t, expected, builder.Inventory())
+}
@@ -0,0 +1,86 @@
+package plugins
+
+import (
+	zipa "archive/zip"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// InstallLocalArchive installs the given plugin archive from the disk into the plugins storage, once validated.
+func (c *Client) InstallLocalArchive(pName, pVersion, archivePath string) error {
+	err := checkLocalArchive(pName, pVersion, archivePath)
+	if err != nil {
+		return fmt.Errorf("invalid archive %s: %w", archivePath, err)
+	}
+
+	hash, err := computeHash(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to compute hash of %s: %w", archivePath, err)
+	}
+
+	filename := c.buildArchivePath(pName, pVersion)
+
+	if stored, err := computeHash(filename); err == nil && stored == hash {
+		// The archive is already in the store.
+		return nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(filename), 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	return copyFile(archivePath, filename, 0o644)
+}
+
+// checkLocalArchive checks that the archive is a zip file holding the sources of a plugin with a valid manifest,
+// either as a module zip file, at its root, or in a single top directory.
+func checkLocalArchive(pName, pVersion, archivePath string) error {
+	archive, err := zipa.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = archive.Close() }()
+
+	var manifest *zipa.File
+	for _, f := range archive.File {
+		name := path.Clean(f.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("file %s is outside of the archive", f.Name)
+		}
+
+		dir, file := path.Split(name)
+		if file == pluginManifest && (dir == "" || strings.Count(dir, "/") == 1 || dir == pName+"@"+pVersion+"/") {
+			manifest = f
+		}
+	}
+
+	if manifest == nil {
+		return fmt.Errorf("missing %s", pluginManifest)
+	}
+
+	rc, err := manifest.Open()
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = rc.Close() }()
+
+	m := &Manifest{}
+	if err = yaml.NewDecoder(rc).Decode(m); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", pluginManifest, err)
+	}
+
+	if m.Type == "" {
+		return errors.New("missing plugin type in the manifest")
+	}
+
+	return nil
+}
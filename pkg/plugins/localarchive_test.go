@@ -0,0 +1,158 @@
+package plugins
+
+import (
+	zipa "archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestArchive writes a zip archive holding the given files, keyed by name, and returns its path.
+func writeTestArchive(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	archivePath := filepath.Join(t.TempDir(), "plugin.zip")
+
+	file, err := os.Create(archivePath)
+	require.NoError(t, err)
+
+	zw := zipa.NewWriter(file)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, zw.Close())
+	require.NoError(t, file.Close())
+
+	return archivePath
+}
+
+func TestClient_InstallLocalArchive(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		files       map[string]string
+		expectedErr bool
+	}{
+		{
+			desc:  "module zip file",
+			files: map[string]string{"github.com/traefik/plugindemo@v0.1.0/" + pluginManifest: "type: middleware"},
+		},
+		{
+			desc:  "top directory",
+			files: map[string]string{"plugindemo-0.1.0/" + pluginManifest: "type: middleware"},
+		},
+		{
+			desc:  "root",
+			files: map[string]string{pluginManifest: "type: middleware"},
+		},
+		{
+			desc:        "missing manifest",
+			files:       map[string]string{"plugindemo-0.1.0/main.go": "package plugindemo"},
+			expectedErr: true,
+		},
+		{
+			desc:        "invalid manifest",
+			files:       map[string]string{pluginManifest: "displayName: Demo"},
+			expectedErr: true,
+		},
+		{
+			desc: "file outside of the archive",
+			files: map[string]string{
+				pluginManifest:    "type: middleware",
+				"../../plugin.go": "package plugindemo",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := NewClient(ClientOptions{Output: t.TempDir()})
+			require.NoError(t, err)
+
+			archivePath := writeTestArchive(t, test.files)
+
+			err = client.InstallLocalArchive("github.com/traefik/plugindemo", "v0.1.0", archivePath)
+			if test.expectedErr {
+				require.Error(t, err)
+				assert.NoFileExists(t, client.buildArchivePath("github.com/traefik/plugindemo", "v0.1.0"))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.FileExists(t, client.buildArchivePath("github.com/traefik/plugindemo", "v0.1.0"))
+		})
+	}
+}
+
+func TestSetupRemotePlugins_localArchive(t *testing.T) {
+	archivePath := writeTestArchive(t, map[string]string{
+		"github.com/traefik/plugindemo@v0.1.0/" + pluginManifest: "type: middleware",
+	})
+
+	// The registry is unreachable.
+	client, err := NewClient(ClientOptions{Output: t.TempDir(), RegistryURL: "http://127.0.0.1:1", Retry: &RegistryRetry{}})
+	require.NoError(t, err)
+
+	plugins := map[string]Descriptor{
+		"demo": {ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0", LocalArchive: archivePath, Required: true},
+	}
+
+	err = SetupRemotePlugins(client, plugins)
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(client.GoPath(), goPathSrc, "github.com", "traefik", "plugindemo", pluginManifest))
+
+	require.NoError(t, client.Verify(context.Background(), plugins["demo"]))
+}
+
+func TestCheckRemotePluginsConfiguration_localArchive(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		plugin      Descriptor
+		expectedErr string
+	}{
+		{
+			desc:   "exact version",
+			plugin: Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0", LocalArchive: "/opt/plugins/plugindemo.zip"},
+		},
+		{
+			desc:        "version constraint",
+			plugin:      Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "^0.1", LocalArchive: "/opt/plugins/plugindemo.zip"},
+			expectedErr: "demo: a local archive requires an exact plugin version",
+		},
+		{
+			desc:        "source",
+			plugin:      Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0", LocalArchive: "/opt/plugins/plugindemo.zip", Source: "oci://ghcr.io/traefik/plugindemo"},
+			expectedErr: "demo: a local archive cannot be used along with a source",
+		},
+		{
+			desc:        "not a zip file",
+			plugin:      Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0", LocalArchive: "/opt/plugins/plugindemo.tar.gz"},
+			expectedErr: "demo: the local archive must be a .zip file",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkRemotePluginsConfiguration(map[string]Descriptor{"demo": test.plugin})
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
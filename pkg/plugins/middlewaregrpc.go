@@ -0,0 +1,468 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/logs"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	grpcMiddlewareService = "traefik.plugins.v1.Middleware"
+	grpcHandleRequest     = "/" + grpcMiddlewareService + "/HandleRequest"
+
+	// grpcSocketEnv is the environment variable holding the path of the Unix socket a started plugin process must listen on.
+	grpcSocketEnv = "TRAEFIK_PLUGIN_SOCKET"
+
+	grpcStartTimeout       = 10 * time.Second
+	grpcStopTimeout        = 5 * time.Second
+	grpcHealthCheckTimeout = 5 * time.Second
+
+	// grpcMaxBodySize is the maximum size of the request bodies sent to the plugin.
+	grpcMaxBodySize = 4 << 20
+)
+
+type grpcMiddlewareBuilder struct {
+	processes *grpcProcesses
+	// processKey identifies the endpoint of the plugin in the processes registry.
+	processKey string
+
+	address  string
+	dir      string
+	command  []string
+	sendBody bool
+	timeout  time.Duration
+
+	logger zerolog.Logger
+}
+
+func newGRPCMiddlewareBuilder(ctx context.Context, goPath string, manifest *Manifest, moduleName string, settings Settings, processes *grpcProcesses) (*grpcMiddlewareBuilder, error) {
+	b := &grpcMiddlewareBuilder{
+		processes: processes,
+		address:   settings.Address,
+		dir:       filepath.Join(goPath, goPathSrc, filepath.FromSlash(moduleName)),
+		timeout:   time.Duration(settings.MaxExecutionTime),
+		logger:    *log.Ctx(ctx),
+	}
+
+	if manifest.GRPC != nil {
+		b.command = manifest.GRPC.Command
+		b.sendBody = manifest.GRPC.SendBody
+	}
+
+	if b.address != "" {
+		b.processKey = "address:" + b.address
+		return b, nil
+	}
+
+	if len(b.command) == 0 {
+		return nil, errors.New("gRPC plugin without command in its manifest, nor address in its settings")
+	}
+
+	if !filepath.IsLocal(b.command[0]) {
+		return nil, errors.New("the command of a gRPC plugin must be a local path")
+	}
+
+	b.processKey = "command:" + b.dir + "\x00" + strings.Join(b.command, "\x00")
+
+	return b, nil
+}
+
+func (b *grpcMiddlewareBuilder) newMiddleware(config map[string]interface{}, middlewareName string) (pluginMiddleware, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling config: %w", err)
+	}
+
+	return &grpcMiddleware{
+		middlewareName: middlewareName,
+		config:         data,
+		builder:        b,
+	}, nil
+}
+
+// endpoint returns the endpoint of the plugin, starting its process if needed.
+func (b *grpcMiddlewareBuilder) endpoint() (*grpcEndpoint, error) {
+	return b.processes.acquire(b.processKey, func() (*grpcEndpoint, error) {
+		if b.address != "" {
+			conn, err := newGRPCClient(b.address)
+			if err != nil {
+				return nil, err
+			}
+
+			return &grpcEndpoint{conn: conn}, nil
+		}
+
+		return startGRPCProcess(b.logger, b.dir, b.command)
+	})
+}
+
+// grpcMiddleware is an HTTP handler plugin wrapper.
+type grpcMiddleware struct {
+	middlewareName string
+	config         []byte
+	builder        *grpcMiddlewareBuilder
+}
+
+// NewHandler creates a new HTTP handler.
+func (m *grpcMiddleware) NewHandler(_ context.Context, next http.Handler) (http.Handler, error) {
+	return &grpcHandler{
+		next:           next,
+		middlewareName: m.middlewareName,
+		config:         m.config,
+		builder:        m.builder,
+	}, nil
+}
+
+// grpcHandler is the HTTP handler of a gRPC middleware, calling the plugin process for each request.
+type grpcHandler struct {
+	next           http.Handler
+	middlewareName string
+	config         []byte
+	builder        *grpcMiddlewareBuilder
+}
+
+func (h *grpcHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := h.builder.logger.With().Str("middleware", h.middlewareName).Logger()
+
+	pReq := &grpcPluginRequest{
+		MiddlewareName: h.middlewareName,
+		Config:         h.config,
+		Method:         req.Method,
+		URI:            req.URL.RequestURI(),
+		Host:           req.Host,
+		RemoteAddr:     req.RemoteAddr,
+		Headers:        req.Header,
+	}
+
+	if h.builder.sendBody && req.Body != nil {
+		body, err := io.ReadAll(io.LimitReader(req.Body, grpcMaxBodySize+1))
+		if err != nil {
+			logger.Debug().Err(err).Msg("Unable to read the request body")
+			http.Error(rw, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		if len(body) > grpcMaxBodySize {
+			http.Error(rw, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		pReq.Body = body
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	pResp, err := h.call(req.Context(), pReq)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to call the gRPC plugin")
+		http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if pResp.Status != 0 {
+		for name, values := range pResp.SetResponseHeaders {
+			rw.Header()[name] = values
+		}
+
+		rw.WriteHeader(pResp.Status)
+		_, _ = rw.Write(pResp.Body)
+		return
+	}
+
+	for name, values := range pResp.SetRequestHeaders {
+		req.Header[name] = values
+	}
+
+	for _, name := range pResp.RemoveRequestHeaders {
+		req.Header.Del(name)
+	}
+
+	if len(pResp.SetResponseHeaders) > 0 {
+		rw = middlewares.NewResponseModifier(rw, req, func(resp *http.Response) error {
+			for name, values := range pResp.SetResponseHeaders {
+				resp.Header[name] = values
+			}
+
+			return nil
+		})
+	}
+
+	h.next.ServeHTTP(rw, req)
+}
+
+func (h *grpcHandler) call(ctx context.Context, req *grpcPluginRequest) (*grpcPluginResponse, error) {
+	endpoint, err := h.builder.endpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	if h.builder.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.builder.timeout)
+		defer cancel()
+	}
+
+	resp := &grpcPluginResponse{}
+	if err = endpoint.conn.Invoke(ctx, grpcHandleRequest, req, resp, grpc.ForceCodec(grpcPluginCodec{})); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Healthy calls the gRPC health service of the plugin process, considered healthy when it does not implement it.
+func (h *grpcHandler) Healthy() error {
+	endpoint, err := h.builder.endpoint()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), grpcHealthCheckTimeout)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(endpoint.conn).Check(ctx, &healthpb.HealthCheckRequest{Service: grpcMiddlewareService})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("plugin status: %s", resp.GetStatus())
+	}
+
+	return nil
+}
+
+// grpcEndpoint is the connection to a gRPC plugin, and its process when it is started by Traefik.
+type grpcEndpoint struct {
+	conn *grpc.ClientConn
+
+	cmd *exec.Cmd
+	// exited is closed once the process has exited.
+	exited chan struct{}
+	dir    string
+}
+
+func newGRPCClient(address string) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("creating gRPC client: %w", err)
+	}
+
+	return conn, nil
+}
+
+// startGRPCProcess starts the process of a plugin, and waits for it to listen on the socket given by the TRAEFIK_PLUGIN_SOCKET environment variable.
+func startGRPCProcess(logger zerolog.Logger, dir string, command []string) (*grpcEndpoint, error) {
+	socketDir, err := os.MkdirTemp("", "traefik-plugin-")
+	if err != nil {
+		return nil, fmt.Errorf("creating socket directory: %w", err)
+	}
+
+	socket := filepath.Join(socketDir, "plugin.sock")
+
+	cmd := exec.Command(filepath.Join(dir, command[0]), command[1:]...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), grpcSocketEnv+"="+socket)
+	cmd.Stdout = logs.NoLevel(logger, zerolog.DebugLevel)
+	cmd.Stderr = logs.NoLevel(logger, zerolog.ErrorLevel)
+
+	if err = cmd.Start(); err != nil {
+		_ = os.RemoveAll(socketDir)
+		return nil, fmt.Errorf("starting plugin process: %w", err)
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		err := cmd.Wait()
+		logger.Debug().Err(err).Msg("gRPC plugin process exited")
+		close(exited)
+	}()
+
+	endpoint := &grpcEndpoint{cmd: cmd, exited: exited, dir: socketDir}
+
+	if err = waitForSocket(socket, exited); err != nil {
+		endpoint.close()
+		return nil, err
+	}
+
+	endpoint.conn, err = newGRPCClient("unix://" + socket)
+	if err != nil {
+		endpoint.close()
+		return nil, err
+	}
+
+	logger.Debug().Int("pid", cmd.Process.Pid).Msg("gRPC plugin process started")
+
+	return endpoint, nil
+}
+
+func waitForSocket(socket string, exited <-chan struct{}) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	timeout := time.After(grpcStartTimeout)
+
+	for {
+		if _, err := os.Stat(socket); err == nil {
+			return nil
+		}
+
+		select {
+		case <-exited:
+			return errors.New("plugin process exited before listening")
+		case <-timeout:
+			return fmt.Errorf("plugin process not listening on %s after %s", socket, grpcStartTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// alive returns false once the process of the endpoint, if any, has exited.
+func (e *grpcEndpoint) alive() bool {
+	if e.exited == nil {
+		return true
+	}
+
+	select {
+	case <-e.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+// close closes the connection, and stops the process, if any, with an interrupt signal and then a kill after a timeout.
+func (e *grpcEndpoint) close() {
+	if e.conn != nil {
+		_ = e.conn.Close()
+	}
+
+	if e.cmd != nil && e.alive() {
+		_ = e.cmd.Process.Signal(os.Interrupt)
+
+		select {
+		case <-e.exited:
+		case <-time.After(grpcStopTimeout):
+			_ = e.cmd.Process.Kill()
+			<-e.exited
+		}
+	}
+
+	if e.dir != "" {
+		_ = os.RemoveAll(e.dir)
+	}
+}
+
+// grpcProcesses is the registry of the endpoints of the gRPC plugins,
+// shared by the successive builders of the plugins so that the processes survive their reloads.
+type grpcProcesses struct {
+	mu        sync.Mutex
+	endpoints map[string]*grpcEndpoint
+}
+
+func newGRPCProcesses() *grpcProcesses {
+	return &grpcProcesses{endpoints: make(map[string]*grpcEndpoint)}
+}
+
+// acquire returns the endpoint of the given key, created with start if it does not exist, or if its process has exited.
+func (p *grpcProcesses) acquire(key string, start func() (*grpcEndpoint, error)) (*grpcEndpoint, error) {
+	if p == nil {
+		return start()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if endpoint, ok := p.endpoints[key]; ok {
+		if endpoint.alive() {
+			return endpoint, nil
+		}
+
+		endpoint.close()
+		delete(p.endpoints, key)
+	}
+
+	endpoint, err := start()
+	if err != nil {
+		return nil, err
+	}
+
+	p.endpoints[key] = endpoint
+
+	return endpoint, nil
+}
+
+// retain closes the endpoints whose keys are not in the given ones.
+func (p *grpcProcesses) retain(keys map[string]struct{}) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, endpoint := range p.endpoints {
+		if _, ok := keys[key]; !ok {
+			endpoint.close()
+			delete(p.endpoints, key)
+		}
+	}
+}
+
+// release closes the endpoint of the given key, if any.
+func (p *grpcProcesses) release(key string) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if endpoint, ok := p.endpoints[key]; ok {
+		endpoint.close()
+		delete(p.endpoints, key)
+	}
+}
+
+// grpcProcessKeys returns the keys of the endpoints used by the given middleware builders.
+func grpcProcessKeys(builders map[string]middlewareBuilder) map[string]struct{} {
+	keys := make(map[string]struct{})
+	for _, builder := range builders {
+		if b, ok := asGRPCMiddlewareBuilder(builder); ok {
+			keys[b.processKey] = struct{}{}
+		}
+	}
+
+	return keys
+}
+
+func asGRPCMiddlewareBuilder(builder middlewareBuilder) (*grpcMiddlewareBuilder, bool) {
+	if schemaBuilder, ok := builder.(*schemaMiddlewareBuilder); ok {
+		builder = schemaBuilder.middlewareBuilder
+	}
+
+	b, ok := builder.(*grpcMiddlewareBuilder)
+	return b, ok
+}
@@ -0,0 +1,245 @@
+package plugins
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// startGRPCPlugin starts a gRPC server serving the middleware plugins protocol with the given function,
+// and returns its address.
+func startGRPCPlugin(t *testing.T, handle func(*grpcPluginRequest) *grpcPluginResponse) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer(grpc.ForceServerCodec(grpcPluginCodec{}))
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: grpcMiddlewareService,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "HandleRequest",
+			Handler: func(_ any, _ context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				req := &grpcPluginRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+
+				return handle(req), nil
+			},
+		}},
+	}, struct{}{})
+
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestGRPCMiddleware(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		sendBody         bool
+		response         *grpcPluginResponse
+		expectedStatus   int
+		expectedBody     string
+		expectedReqHeads http.Header
+		expectedHeader   string
+	}{
+		{
+			desc: "forward with modified headers",
+			response: &grpcPluginResponse{
+				SetRequestHeaders:    http.Header{"X-User": {"john"}},
+				RemoveRequestHeaders: []string{"Authorization"},
+				SetResponseHeaders:   http.Header{"X-Plugin": {"grpc"}},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "next",
+			expectedReqHeads: http.Header{
+				"X-Foo":  {"bar"},
+				"X-User": {"john"},
+			},
+			expectedHeader: "grpc",
+		},
+		{
+			desc: "immediate response",
+			response: &grpcPluginResponse{
+				SetResponseHeaders: http.Header{"X-Plugin": {"grpc"}},
+				Status:             http.StatusForbidden,
+				Body:               []byte("denied"),
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   "denied",
+			expectedHeader: "grpc",
+		},
+		{
+			desc:     "forward with body",
+			sendBody: true,
+			response: &grpcPluginResponse{},
+			expectedReqHeads: http.Header{
+				"Authorization": {"secret"},
+				"X-Foo":         {"bar"},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "next",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var received *grpcPluginRequest
+			address := startGRPCPlugin(t, func(req *grpcPluginRequest) *grpcPluginResponse {
+				received = req
+				return test.response
+			})
+
+			manifest := &Manifest{Runtime: runtimeGRPC, GRPC: &GRPCManifest{SendBody: test.sendBody}}
+
+			builder, err := newGRPCMiddlewareBuilder(context.Background(), t.TempDir(), manifest, "github.com/traefik/plugindemo", Settings{Address: address}, newGRPCProcesses())
+			require.NoError(t, err)
+			t.Cleanup(func() { builder.processes.retain(nil) })
+
+			middleware, err := builder.newMiddleware(map[string]interface{}{"foo": "bar"}, "demo")
+			require.NoError(t, err)
+
+			var forwarded http.Header
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwarded = req.Header.Clone()
+
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				assert.Equal(t, "payload", string(body))
+
+				_, _ = rw.Write([]byte("next"))
+			})
+
+			handler, err := middleware.NewHandler(context.Background(), next)
+			require.NoError(t, err)
+
+			// The plugin does not implement the health service.
+			require.NoError(t, handler.(HealthChecker).Healthy())
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost/foo?bar=baz", strings.NewReader("payload"))
+			req.Header.Set("X-Foo", "bar")
+			req.Header.Set("Authorization", "secret")
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expectedStatus, rec.Code)
+			assert.Equal(t, test.expectedBody, rec.Body.String())
+			assert.Equal(t, test.expectedHeader, rec.Header().Get("X-Plugin"))
+			assert.Equal(t, test.expectedReqHeads, forwarded)
+
+			require.NotNil(t, received)
+			assert.Equal(t, "demo", received.MiddlewareName)
+			assert.JSONEq(t, `{"foo":"bar"}`, string(received.Config))
+			assert.Equal(t, http.MethodPost, received.Method)
+			assert.Equal(t, "/foo?bar=baz", received.URI)
+			assert.Equal(t, "localhost", received.Host)
+			assert.Equal(t, "bar", received.Headers.Get("X-Foo"))
+
+			if test.sendBody {
+				assert.Equal(t, "payload", string(received.Body))
+			} else {
+				assert.Empty(t, received.Body)
+			}
+		})
+	}
+}
+
+func TestGRPCMiddleware_unavailable(t *testing.T) {
+	manifest := &Manifest{Runtime: runtimeGRPC}
+
+	builder, err := newGRPCMiddlewareBuilder(context.Background(), t.TempDir(), manifest, "github.com/traefik/plugindemo", Settings{Address: "unix://" + t.TempDir() + "/missing.sock"}, newGRPCProcesses())
+	require.NoError(t, err)
+	t.Cleanup(func() { builder.processes.retain(nil) })
+
+	middleware, err := builder.newMiddleware(nil, "demo")
+	require.NoError(t, err)
+
+	handler, err := middleware.NewHandler(context.Background(), http.NotFoundHandler())
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Error(t, handler.(HealthChecker).Healthy())
+}
+
+func Test_newGRPCMiddlewareBuilder(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		manifest  *Manifest
+		settings  Settings
+		expectErr bool
+	}{
+		{
+			desc:     "address",
+			manifest: &Manifest{Runtime: runtimeGRPC},
+			settings: Settings{Address: "localhost:50051"},
+		},
+		{
+			desc:     "command",
+			manifest: &Manifest{Runtime: runtimeGRPC, GRPC: &GRPCManifest{Command: []string{"bin/plugin", "--verbose"}}},
+		},
+		{
+			desc:      "neither command nor address",
+			manifest:  &Manifest{Runtime: runtimeGRPC},
+			expectErr: true,
+		},
+		{
+			desc:      "command outside of the plugin directory",
+			manifest:  &Manifest{Runtime: runtimeGRPC, GRPC: &GRPCManifest{Command: []string{"../plugin"}}},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := newGRPCMiddlewareBuilder(context.Background(), t.TempDir(), test.manifest, "github.com/traefik/plugindemo", test.settings, nil)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGRPCPluginCodec(t *testing.T) {
+	codec := grpcPluginCodec{}
+
+	resp := &grpcPluginResponse{
+		SetRequestHeaders:    http.Header{"X-A": {"1", "2"}, "X-B": {"3"}},
+		RemoveRequestHeaders: []string{"X-C", "X-D"},
+		SetResponseHeaders:   http.Header{"X-E": {"4"}},
+		Status:               http.StatusUnauthorized,
+		Body:                 []byte("unauthorized"),
+	}
+
+	data, err := codec.Marshal(resp)
+	require.NoError(t, err)
+
+	decoded := &grpcPluginResponse{}
+	require.NoError(t, codec.Unmarshal(data, decoded))
+	assert.Equal(t, resp, decoded)
+
+	_, err = codec.Marshal("foo")
+	assert.Error(t, err)
+}
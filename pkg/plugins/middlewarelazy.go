@@ -0,0 +1,79 @@
+package plugins
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sync"
+)
+
+// lazyMiddlewareBuilder defers the creation of a middleware builder until a middleware is created from it.
+// It is used for the Yaegi plugins, whose evaluation can add seconds to the startup.
+type lazyMiddlewareBuilder struct {
+	once  sync.Once
+	build func() (middlewareBuilder, error)
+
+	builder middlewareBuilder
+	err     error
+}
+
+func newLazyMiddlewareBuilder(build func() (middlewareBuilder, error)) *lazyMiddlewareBuilder {
+	return &lazyMiddlewareBuilder{build: build}
+}
+
+func (b *lazyMiddlewareBuilder) newMiddleware(config map[string]interface{}, middlewareName string) (pluginMiddleware, error) {
+	b.once.Do(func() {
+		b.builder, b.err = b.build()
+	})
+
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	return b.builder.newMiddleware(config, middlewareName)
+}
+
+// checkYaegiMiddlewareSources parses the sources of the base package of a Yaegi middleware plugin,
+// and checks that they declare the New and CreateConfig functions.
+// It reports the broken plugins at startup without evaluating them,
+// the type errors being only reported by the evaluation.
+func checkYaegiMiddlewareSources(goPath string, manifest *Manifest) error {
+	basePkg := yaegiBasePkg(manifest.BasePkg, manifest.Import)
+	dir := filepath.Join(goPath, goPathSrc, filepath.FromSlash(manifest.Import))
+
+	pkg, err := build.Default.ImportDir(dir, 0)
+	if err != nil {
+		return fmt.Errorf("failed to read plugin sources %q: %w", manifest.Import, err)
+	}
+
+	if pkg.Name != basePkg {
+		return fmt.Errorf("plugin sources %q: found package %s, expected %s", manifest.Import, pkg.Name, basePkg)
+	}
+
+	funcs := make(map[string]struct{})
+
+	fset := token.NewFileSet()
+	for _, name := range pkg.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("failed to parse plugin sources %q: %w", manifest.Import, err)
+		}
+
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+				funcs[fn.Name.Name] = struct{}{}
+			}
+		}
+	}
+
+	for _, name := range []string{"New", "CreateConfig"} {
+		if _, ok := funcs[name]; !ok {
+			return fmt.Errorf("plugin sources %q: function %s.%s not found", manifest.Import, basePkg, name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package plugins
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const yaegiTestPlugin = `package plugindemo
+
+import (
+	"context"
+	"net/http"
+)
+
+type Config struct {
+	Header string
+}
+
+func CreateConfig() *Config {
+	return &Config{}
+}
+
+func New(ctx context.Context, next http.Handler, config *Config, name string) (http.Handler, error) {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set(config.Header, name)
+		next.ServeHTTP(rw, req)
+	}), nil
+}
+`
+
+func writeYaegiTestPlugin(t *testing.T, source string) string {
+	t.Helper()
+
+	goPath := t.TempDir()
+	sources := filepath.Join(goPath, goPathSrc, "github.com", "traefik", "plugindemo")
+	require.NoError(t, os.MkdirAll(sources, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(sources, "plugindemo.go"), []byte(source), 0o600))
+
+	return goPath
+}
+
+func TestLazyMiddlewareBuilder(t *testing.T) {
+	var calls int
+	runtimeBuilder := &fakeMiddlewareBuilder{}
+
+	builder := newLazyMiddlewareBuilder(func() (middlewareBuilder, error) {
+		calls++
+		return runtimeBuilder, nil
+	})
+
+	assert.Equal(t, 0, calls)
+
+	for range 2 {
+		_, err := builder.newMiddleware(map[string]interface{}{"foo": "bar"}, "test")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, runtimeBuilder.config)
+}
+
+func TestNewMiddlewareBuilder_lazyYaegi(t *testing.T) {
+	goPath := writeYaegiTestPlugin(t, yaegiTestPlugin)
+	manifest := &Manifest{Type: typeMiddleware, Import: "github.com/traefik/plugindemo"}
+
+	builder, err := newMiddlewareBuilder(context.Background(), goPath, manifest, "github.com/traefik/plugindemo", Settings{}, true, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &lazyMiddlewareBuilder{}, builder)
+
+	middleware, err := builder.newMiddleware(map[string]interface{}{"header": "X-Plugin"}, "test")
+	require.NoError(t, err)
+
+	handler, err := middleware.NewHandler(context.Background(), http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "test", recorder.Header().Get("X-Plugin"))
+
+	builder, err = newMiddlewareBuilder(context.Background(), goPath, manifest, "github.com/traefik/plugindemo", Settings{}, false, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &yaegiMiddlewareBuilder{}, builder)
+}
+
+func TestNewMiddlewareBuilder_lazyYaegiTypeError(t *testing.T) {
+	// The type errors are only reported by the evaluation.
+	goPath := writeYaegiTestPlugin(t, "package plugindemo\n\nfunc CreateConfig() *Config { return nil }\n\nfunc New() {}\n")
+	manifest := &Manifest{Type: typeMiddleware, Import: "github.com/traefik/plugindemo"}
+
+	builder, err := newMiddlewareBuilder(context.Background(), goPath, manifest, "github.com/traefik/plugindemo", Settings{}, true, nil)
+	require.NoError(t, err)
+
+	_, err = builder.newMiddleware(map[string]interface{}{}, "test")
+	require.Error(t, err)
+}
+
+func TestCheckYaegiMiddlewareSources(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		source   string
+		basePkg  string
+		expected string
+	}{
+		{
+			desc:   "valid plugin",
+			source: yaegiTestPlugin,
+		},
+		{
+			desc:     "syntax error",
+			source:   "package plugindemo\n\nfunc New(",
+			expected: "failed to parse plugin sources",
+		},
+		{
+			desc:     "missing function",
+			source:   "package plugindemo\n\nfunc New() {}\n",
+			expected: "function plugindemo.CreateConfig not found",
+		},
+		{
+			desc:     "method instead of function",
+			source:   "package plugindemo\n\ntype T struct{}\n\nfunc (T) New() {}\n\nfunc CreateConfig() {}\n",
+			expected: "function plugindemo.New not found",
+		},
+		{
+			desc:     "unexpected package",
+			source:   yaegiTestPlugin,
+			basePkg:  "other",
+			expected: "found package plugindemo, expected other",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			goPath := writeYaegiTestPlugin(t, test.source)
+			manifest := &Manifest{Type: typeMiddleware, Import: "github.com/traefik/plugindemo", BasePkg: test.basePkg}
+
+			err := checkYaegiMiddlewareSources(goPath, manifest)
+			if test.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, test.expected)
+		})
+	}
+}
+
+func TestCheckYaegiMiddlewareSources_missingSources(t *testing.T) {
+	manifest := &Manifest{Type: typeMiddleware, Import: "github.com/traefik/plugindemo"}
+
+	err := checkYaegiMiddlewareSources(t.TempDir(), manifest)
+	require.ErrorContains(t, err, "failed to read plugin sources")
+}
@@ -0,0 +1,1090 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// The proxy-wasm ABI (https://github.com/proxy-wasm/spec) is implemented for the HTTP filters,
+// without the timers, the HTTP and gRPC calls, the shared data and queues, and the metrics,
+// whose host functions answer with the Unimplemented status.
+
+const (
+	proxyWasmABIPrefix     = "proxy_abi_version_"
+	proxyWasmRootContextID = 1
+)
+
+// Statuses returned by the proxy-wasm host functions.
+const (
+	proxyWasmStatusOK              uint32 = 0
+	proxyWasmStatusNotFound        uint32 = 1
+	proxyWasmStatusBadArgument     uint32 = 2
+	proxyWasmStatusInternalFailure uint32 = 10
+	proxyWasmStatusUnimplemented   uint32 = 12
+)
+
+// Header map types of the proxy-wasm ABI.
+const (
+	proxyWasmRequestHeaders  uint32 = 0
+	proxyWasmResponseHeaders uint32 = 2
+)
+
+// Buffer types of the proxy-wasm ABI.
+const (
+	proxyWasmRequestBody         uint32 = 0
+	proxyWasmResponseBody        uint32 = 1
+	proxyWasmVMConfiguration     uint32 = 6
+	proxyWasmPluginConfiguration uint32 = 7
+)
+
+type proxyWasmVMKey struct{}
+
+// isProxyWasmModule returns true if the module implements the proxy-wasm ABI rather than the http-wasm one.
+func isProxyWasmModule(mod wazero.CompiledModule) bool {
+	for name := range mod.ExportedFunctions() {
+		if strings.HasPrefix(name, proxyWasmABIPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *wasmMiddlewareBuilder) buildProxyWasmMiddleware(ctx context.Context, next http.Handler, cfg reflect.Value, middlewareName string) (http.Handler, func(ctx context.Context) context.Context, error) {
+	pluginConfig, err := wasmGuestConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Proxy-wasm filters get an empty plugin configuration when the middleware has no options.
+	if cfg.Kind() == reflect.Map && cfg.Len() == 0 {
+		pluginConfig = nil
+	}
+
+	code, err := os.ReadFile(b.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading binary: %w", err)
+	}
+
+	rt := wazero.NewRuntimeWithConfig(ctx, newWasmRuntimeConfig(b.cache, b.settings))
+
+	guestModule, err := rt.CompileModule(ctx, code)
+	if err != nil {
+		return nil, nil, fmt.Errorf("compiling guest module: %w", err)
+	}
+
+	applyCtx, err := InstantiateHost(ctx, rt, guestModule, b.settings)
+	if err != nil {
+		return nil, nil, fmt.Errorf("instantiating host module: %w", err)
+	}
+
+	if err = instantiateProxyWasmHost(ctx, rt, guestModule); err != nil {
+		return nil, nil, fmt.Errorf("instantiating proxy-wasm host module: %w", err)
+	}
+
+	moduleConfig, err := newWasmModuleConfig(b.settings)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	idle := b.settings.PoolSize
+	if idle <= 0 {
+		// Like the proxies running a VM per worker thread.
+		idle = runtime.GOMAXPROCS(0)
+	}
+
+	filter := &proxyWasmFilter{
+		next:         next,
+		runtime:      rt,
+		compiled:     guestModule,
+		moduleConfig: moduleConfig.WithName("").WithStartFunctions("_initialize", "_start"),
+		applyCtx:     applyCtx,
+		pluginConfig: pluginConfig,
+		logger:       b.logger(ctx, middlewareName),
+		vms:          make(chan *proxyWasmVM, idle),
+	}
+
+	// A first VM is started to report the plugin configuration errors when the middleware is built.
+	vm, err := filter.newVM(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter.release(ctx, vm)
+
+	return filter, applyCtx, nil
+}
+
+// proxyWasmFilter is the HTTP handler running a proxy-wasm HTTP filter.
+// Since a VM handles a single stream at a time, the idle VMs are kept to handle the next requests.
+type proxyWasmFilter struct {
+	next http.Handler
+
+	runtime      wazero.Runtime
+	compiled     wazero.CompiledModule
+	moduleConfig wazero.ModuleConfig
+	applyCtx     ContextApplier
+
+	pluginConfig []byte
+	logger       *zerolog.Logger
+
+	vms chan *proxyWasmVM
+}
+
+func (f *proxyWasmFilter) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	vm, err := f.acquire(req.Context())
+	if err != nil {
+		f.logger.Error().Err(err).Msg("Unable to start proxy-wasm VM")
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	defer f.release(req.Context(), vm)
+
+	vm.serveHTTP(rw, req)
+}
+
+func (f *proxyWasmFilter) acquire(ctx context.Context) (*proxyWasmVM, error) {
+	select {
+	case vm := <-f.vms:
+		return vm, nil
+	default:
+		return f.newVM(ctx)
+	}
+}
+
+func (f *proxyWasmFilter) release(ctx context.Context, vm *proxyWasmVM) {
+	if vm.module.IsClosed() {
+		return
+	}
+
+	select {
+	case f.vms <- vm:
+	default:
+		_ = vm.module.Close(ctx)
+	}
+}
+
+// proxyWasmVM is an instance of the guest module, with its root context, handling a stream at a time.
+type proxyWasmVM struct {
+	filter *proxyWasmFilter
+	module api.Module
+
+	// stream is the stream being handled, if any.
+	stream        *proxyWasmStream
+	nextContextID uint32
+}
+
+func (f *proxyWasmFilter) newVM(ctx context.Context) (*proxyWasmVM, error) {
+	vm := &proxyWasmVM{filter: f, nextContextID: proxyWasmRootContextID + 1}
+
+	ctx = context.WithValue(f.applyCtx(ctx), proxyWasmVMKey{}, vm)
+
+	mod, err := f.runtime.InstantiateModule(ctx, f.compiled, f.moduleConfig)
+	if err != nil {
+		return nil, fmt.Errorf("instantiating guest module: %w", err)
+	}
+
+	vm.module = mod
+
+	if mod.ExportedFunction("proxy_on_context_create") == nil {
+		_ = mod.Close(ctx)
+		return nil, errors.New("missing proxy_on_context_create function")
+	}
+
+	if _, err = vm.call(ctx, "proxy_on_context_create", proxyWasmRootContextID, 0); err != nil {
+		_ = mod.Close(ctx)
+		return nil, err
+	}
+
+	started, err := vm.callBool(ctx, "proxy_on_vm_start", proxyWasmRootContextID, 0)
+	if err != nil || !started {
+		_ = mod.Close(ctx)
+		return nil, errors.Join(errors.New("proxy-wasm VM failed to start"), err)
+	}
+
+	configured, err := vm.callBool(ctx, "proxy_on_configure", proxyWasmRootContextID, uint64(len(f.pluginConfig)))
+	if err != nil || !configured {
+		_ = mod.Close(ctx)
+		return nil, errors.Join(errors.New("proxy-wasm plugin rejected its configuration"), err)
+	}
+
+	return vm, nil
+}
+
+// call calls the given guest function, if exported, with the parameters of its ABI version.
+func (vm *proxyWasmVM) call(ctx context.Context, name string, params ...uint64) (uint64, error) {
+	fn := vm.module.ExportedFunction(name)
+	if fn == nil {
+		return 0, nil
+	}
+
+	params = params[:min(len(params), len(fn.Definition().ParamTypes()))]
+
+	results, err := fn.Call(ctx, params...)
+	if err != nil {
+		return 0, fmt.Errorf("calling %s: %w", name, err)
+	}
+
+	if len(results) == 0 {
+		return 0, nil
+	}
+
+	return results[0], nil
+}
+
+// callBool calls a guest function returning a boolean, which is true when the function is not exported.
+func (vm *proxyWasmVM) callBool(ctx context.Context, name string, params ...uint64) (bool, error) {
+	if vm.module.ExportedFunction(name) == nil {
+		return true, nil
+	}
+
+	result, err := vm.call(ctx, name, params...)
+
+	return result != 0, err
+}
+
+// proxyWasmStream is an HTTP stream handled by a VM.
+type proxyWasmStream struct {
+	req         *http.Request
+	requestBody []byte
+
+	response *proxyWasmResponse
+
+	localResponse *proxyWasmResponse
+}
+
+func (vm *proxyWasmVM) serveHTTP(rw http.ResponseWriter, req *http.Request) {
+	ctx := context.WithValue(req.Context(), proxyWasmVMKey{}, vm)
+
+	contextID := uint64(vm.nextContextID)
+	vm.nextContextID++
+
+	stream := &proxyWasmStream{req: req}
+	vm.stream = stream
+
+	defer vm.closeStream(ctx, contextID)
+
+	if err := vm.handleRequest(ctx, contextID, stream); err != nil {
+		vm.filter.logger.Error().Err(err).Msg("proxy-wasm filter failed to handle the request")
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if stream.localResponse != nil {
+		stream.localResponse.writeTo(rw)
+		return
+	}
+
+	stream.response = newProxyWasmResponse()
+	vm.filter.next.ServeHTTP(stream.response, req)
+
+	if err := vm.handleResponse(ctx, contextID, stream); err != nil {
+		vm.filter.logger.Error().Err(err).Msg("proxy-wasm filter failed to handle the response")
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if stream.localResponse != nil {
+		stream.localResponse.writeTo(rw)
+		return
+	}
+
+	stream.response.writeTo(rw)
+}
+
+func (vm *proxyWasmVM) handleRequest(ctx context.Context, contextID uint64, stream *proxyWasmStream) error {
+	if _, err := vm.call(ctx, "proxy_on_context_create", contextID, proxyWasmRootContextID); err != nil {
+		return err
+	}
+
+	req := stream.req
+
+	hasBody := req.Body != nil && req.Body != http.NoBody && req.ContentLength != 0
+	bodyCallback := vm.module.ExportedFunction("proxy_on_request_body") != nil
+
+	headers := newProxyWasmRequestHeaders(req)
+	if _, err := vm.call(ctx, "proxy_on_request_headers", contextID, uint64(len(headers.pairs())), boolToUint64(!hasBody)); err != nil {
+		return err
+	}
+
+	if stream.localResponse != nil || !hasBody || !bodyCallback {
+		return nil
+	}
+
+	// The body is buffered, so that the filter can inspect and modify it at once.
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return fmt.Errorf("reading request body: %w", err)
+	}
+
+	stream.requestBody = body
+
+	if _, err = vm.call(ctx, "proxy_on_request_body", contextID, uint64(len(body)), 1); err != nil {
+		return err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(stream.requestBody))
+	req.ContentLength = int64(len(stream.requestBody))
+	if req.Header.Get("Content-Length") != "" {
+		req.Header.Set("Content-Length", strconv.Itoa(len(stream.requestBody)))
+	}
+
+	return nil
+}
+
+func (vm *proxyWasmVM) handleResponse(ctx context.Context, contextID uint64, stream *proxyWasmStream) error {
+	resp := stream.response
+
+	headers := newProxyWasmResponseHeaders(resp)
+	if _, err := vm.call(ctx, "proxy_on_response_headers", contextID, uint64(len(headers.pairs())), boolToUint64(resp.body.Len() == 0)); err != nil {
+		return err
+	}
+
+	if stream.localResponse != nil || resp.body.Len() == 0 {
+		return nil
+	}
+
+	size := resp.body.Len()
+
+	if _, err := vm.call(ctx, "proxy_on_response_body", contextID, uint64(size), 1); err != nil {
+		return err
+	}
+
+	if resp.body.Len() != size && resp.header.Get("Content-Length") != "" {
+		resp.header.Set("Content-Length", strconv.Itoa(resp.body.Len()))
+	}
+
+	return nil
+}
+
+func (vm *proxyWasmVM) closeStream(ctx context.Context, contextID uint64) {
+	defer func() { vm.stream = nil }()
+
+	if vm.module.IsClosed() {
+		return
+	}
+
+	for _, name := range []string{"proxy_on_log", "proxy_on_done", "proxy_on_delete"} {
+		if _, err := vm.call(ctx, name, contextID); err != nil {
+			vm.filter.logger.Debug().Err(err).Msg("proxy-wasm filter failed to close the stream")
+			return
+		}
+	}
+}
+
+// proxyWasmResponse is a response buffered to be handled by the filter, or a local response sent by the filter.
+type proxyWasmResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+
+	wroteHeader bool
+}
+
+func newProxyWasmResponse() *proxyWasmResponse {
+	return &proxyWasmResponse{header: make(http.Header), status: http.StatusOK}
+}
+
+func (r *proxyWasmResponse) Header() http.Header {
+	return r.header
+}
+
+func (r *proxyWasmResponse) Write(data []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+
+	return r.body.Write(data)
+}
+
+func (r *proxyWasmResponse) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+
+	r.status = status
+	r.wroteHeader = true
+}
+
+func (r *proxyWasmResponse) writeTo(rw http.ResponseWriter) {
+	for key, values := range r.header {
+		rw.Header()[key] = values
+	}
+
+	rw.WriteHeader(r.status)
+	_, _ = rw.Write(r.body.Bytes())
+}
+
+// proxyWasmHeaderMap is a header map exposed to the guest, including the pseudo-headers of the proxies (e.g. :path).
+type proxyWasmHeaderMap interface {
+	get(key string) (string, bool)
+	set(key, value string)
+	add(key, value string)
+	remove(key string)
+	pairs() [][2]string
+}
+
+type proxyWasmRequestHeaderMap struct {
+	req *http.Request
+}
+
+func newProxyWasmRequestHeaders(req *http.Request) proxyWasmRequestHeaderMap {
+	return proxyWasmRequestHeaderMap{req: req}
+}
+
+func (m proxyWasmRequestHeaderMap) get(key string) (string, bool) {
+	switch strings.ToLower(key) {
+	case ":method":
+		return m.req.Method, true
+	case ":path":
+		return m.req.URL.RequestURI(), true
+	case ":authority":
+		return m.req.Host, true
+	case ":scheme":
+		if m.req.TLS != nil {
+			return "https", true
+		}
+
+		return "http", true
+	}
+
+	return getProxyWasmHeader(m.req.Header, key)
+}
+
+func (m proxyWasmRequestHeaderMap) set(key, value string) {
+	switch strings.ToLower(key) {
+	case ":method":
+		m.req.Method = value
+	case ":path":
+		if u, err := url.ParseRequestURI(value); err == nil {
+			m.req.URL.Path = u.Path
+			m.req.URL.RawPath = u.RawPath
+			m.req.URL.RawQuery = u.RawQuery
+			m.req.RequestURI = value
+		}
+	case ":authority":
+		m.req.Host = value
+	case ":scheme":
+		// The scheme is given by the connection.
+	default:
+		m.req.Header.Set(key, value)
+	}
+}
+
+func (m proxyWasmRequestHeaderMap) add(key, value string) {
+	if strings.HasPrefix(key, ":") {
+		m.set(key, value)
+		return
+	}
+
+	m.req.Header.Add(key, value)
+}
+
+func (m proxyWasmRequestHeaderMap) remove(key string) {
+	if !strings.HasPrefix(key, ":") {
+		m.req.Header.Del(key)
+	}
+}
+
+func (m proxyWasmRequestHeaderMap) pairs() [][2]string {
+	pairs := [][2]string{}
+	for _, key := range []string{":authority", ":path", ":method", ":scheme"} {
+		value, _ := m.get(key)
+		pairs = append(pairs, [2]string{key, value})
+	}
+
+	return append(pairs, headerPairs(m.req.Header)...)
+}
+
+type proxyWasmResponseHeaderMap struct {
+	resp *proxyWasmResponse
+}
+
+func newProxyWasmResponseHeaders(resp *proxyWasmResponse) proxyWasmResponseHeaderMap {
+	return proxyWasmResponseHeaderMap{resp: resp}
+}
+
+func (m proxyWasmResponseHeaderMap) get(key string) (string, bool) {
+	if key == ":status" {
+		return strconv.Itoa(m.resp.status), true
+	}
+
+	return getProxyWasmHeader(m.resp.header, key)
+}
+
+func (m proxyWasmResponseHeaderMap) set(key, value string) {
+	if key == ":status" {
+		if status, err := strconv.Atoi(value); err == nil {
+			m.resp.status = status
+		}
+
+		return
+	}
+
+	m.resp.header.Set(key, value)
+}
+
+func (m proxyWasmResponseHeaderMap) add(key, value string) {
+	if strings.HasPrefix(key, ":") {
+		m.set(key, value)
+		return
+	}
+
+	m.resp.header.Add(key, value)
+}
+
+func (m proxyWasmResponseHeaderMap) remove(key string) {
+	if !strings.HasPrefix(key, ":") {
+		m.resp.header.Del(key)
+	}
+}
+
+func (m proxyWasmResponseHeaderMap) pairs() [][2]string {
+	return append([][2]string{{":status", strconv.Itoa(m.resp.status)}}, headerPairs(m.resp.header)...)
+}
+
+// getProxyWasmHeader returns the values of the header joined with a comma, as the proxies do.
+func getProxyWasmHeader(header http.Header, key string) (string, bool) {
+	values := header.Values(key)
+	if len(values) == 0 {
+		return "", false
+	}
+
+	return strings.Join(values, ","), true
+}
+
+// headerPairs returns the pairs of the headers sorted by name, with the lower case names expected by the guests.
+func headerPairs(header http.Header) [][2]string {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var pairs [][2]string
+	for _, key := range keys {
+		for _, value := range header[key] {
+			pairs = append(pairs, [2]string{strings.ToLower(key), value})
+		}
+	}
+
+	return pairs
+}
+
+// encodeProxyWasmPairs serializes the pairs as defined by the proxy-wasm ABI:
+// the number of pairs, the sizes of each key and value, then the null-terminated keys and values.
+func encodeProxyWasmPairs(pairs [][2]string) []byte {
+	size := 4 + 8*len(pairs)
+	for _, pair := range pairs {
+		size += len(pair[0]) + len(pair[1]) + 2
+	}
+
+	data := make([]byte, 4+8*len(pairs), size)
+	binary.LittleEndian.PutUint32(data, uint32(len(pairs)))
+
+	for i, pair := range pairs {
+		binary.LittleEndian.PutUint32(data[4+8*i:], uint32(len(pair[0])))
+		binary.LittleEndian.PutUint32(data[8+8*i:], uint32(len(pair[1])))
+	}
+
+	for _, pair := range pairs {
+		data = append(data, pair[0]...)
+		data = append(data, 0)
+		data = append(data, pair[1]...)
+		data = append(data, 0)
+	}
+
+	return data
+}
+
+func decodeProxyWasmPairs(data []byte) ([][2]string, error) {
+	if len(data) < 4 {
+		return nil, errors.New("invalid pairs")
+	}
+
+	count := int(binary.LittleEndian.Uint32(data))
+	if len(data) < 4+8*count {
+		return nil, errors.New("invalid pairs sizes")
+	}
+
+	pairs := make([][2]string, count)
+
+	offset := 4 + 8*count
+	for i := range count {
+		keySize := int(binary.LittleEndian.Uint32(data[4+8*i:]))
+		valueSize := int(binary.LittleEndian.Uint32(data[8+8*i:]))
+
+		if len(data) < offset+keySize+valueSize+2 {
+			return nil, errors.New("invalid pairs data")
+		}
+
+		pairs[i][0] = string(data[offset : offset+keySize])
+		offset += keySize + 1
+		pairs[i][1] = string(data[offset : offset+valueSize])
+		offset += valueSize + 1
+	}
+
+	return pairs, nil
+}
+
+func boolToUint64(b bool) uint64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// proxyWasmHostCall is the context of a call to a host function by the guest.
+type proxyWasmHostCall struct {
+	ctx context.Context
+	mod api.Module
+	vm  *proxyWasmVM
+}
+
+func (c proxyWasmHostCall) read(ptr, size uint32) ([]byte, bool) {
+	data, ok := c.mod.Memory().Read(ptr, size)
+	if !ok {
+		return nil, false
+	}
+
+	return bytes.Clone(data), true
+}
+
+// returnBytes copies the data to a memory allocated by the guest, and writes its address and size at the given pointers.
+func (c proxyWasmHostCall) returnBytes(data []byte, dataPtr, sizePtr uint32) uint32 {
+	var ptr uint32
+	if len(data) > 0 {
+		alloc := c.mod.ExportedFunction("proxy_on_memory_allocate")
+		if alloc == nil {
+			alloc = c.mod.ExportedFunction("malloc")
+		}
+
+		if alloc == nil {
+			return proxyWasmStatusInternalFailure
+		}
+
+		results, err := alloc.Call(c.ctx, uint64(len(data)))
+		if err != nil || len(results) == 0 {
+			return proxyWasmStatusInternalFailure
+		}
+
+		ptr = uint32(results[0])
+		if !c.mod.Memory().Write(ptr, data) {
+			return proxyWasmStatusInternalFailure
+		}
+	}
+
+	if !c.mod.Memory().WriteUint32Le(dataPtr, ptr) || !c.mod.Memory().WriteUint32Le(sizePtr, uint32(len(data))) {
+		return proxyWasmStatusBadArgument
+	}
+
+	return proxyWasmStatusOK
+}
+
+func (c proxyWasmHostCall) headerMap(mapType uint32) (proxyWasmHeaderMap, bool) {
+	stream := c.vm.stream
+	if stream == nil {
+		return nil, false
+	}
+
+	switch mapType {
+	case proxyWasmRequestHeaders:
+		return newProxyWasmRequestHeaders(stream.req), true
+	case proxyWasmResponseHeaders:
+		if stream.response == nil {
+			return nil, false
+		}
+
+		return newProxyWasmResponseHeaders(stream.response), true
+	default:
+		return nil, false
+	}
+}
+
+// buffer returns the buffer of the given type, and a function to replace it, if the buffer can be modified.
+func (c proxyWasmHostCall) buffer(bufferType uint32) ([]byte, func([]byte), bool) {
+	stream := c.vm.stream
+
+	switch bufferType {
+	case proxyWasmPluginConfiguration:
+		return c.vm.filter.pluginConfig, nil, true
+	case proxyWasmVMConfiguration:
+		return nil, nil, true
+	case proxyWasmRequestBody:
+		if stream == nil {
+			return nil, nil, false
+		}
+
+		return stream.requestBody, func(data []byte) { stream.requestBody = data }, true
+	case proxyWasmResponseBody:
+		if stream == nil || stream.response == nil {
+			return nil, nil, false
+		}
+
+		return stream.response.body.Bytes(), func(data []byte) {
+			stream.response.body.Reset()
+			stream.response.body.Write(data)
+		}, true
+	default:
+		return nil, nil, false
+	}
+}
+
+type proxyWasmHostFunc func(c proxyWasmHostCall, params []uint32) uint32
+
+// proxyWasmHostFuncs are the implemented host functions of the proxy-wasm ABI, all of them taking and returning i32 values.
+var proxyWasmHostFuncs = map[string]proxyWasmHostFunc{
+	"proxy_log": func(c proxyWasmHostCall, p []uint32) uint32 {
+		msg, ok := c.read(p[1], p[2])
+		if !ok {
+			return proxyWasmStatusBadArgument
+		}
+
+		c.vm.filter.logger.WithLevel(proxyWasmLogLevel(p[0])).Msg(string(msg))
+
+		return proxyWasmStatusOK
+	},
+	"proxy_get_log_level": func(c proxyWasmHostCall, p []uint32) uint32 {
+		// The proxy-wasm levels go from trace (0) to critical (5), one above the zerolog ones.
+		level := max(c.vm.filter.logger.GetLevel(), zerolog.GlobalLevel())
+
+		if !c.mod.Memory().WriteUint32Le(p[0], uint32(min(max(level+1, 0), 5))) {
+			return proxyWasmStatusBadArgument
+		}
+
+		return proxyWasmStatusOK
+	},
+	"proxy_get_current_time_nanoseconds": func(c proxyWasmHostCall, p []uint32) uint32 {
+		if !c.mod.Memory().WriteUint64Le(p[0], uint64(time.Now().UnixNano())) {
+			return proxyWasmStatusBadArgument
+		}
+
+		return proxyWasmStatusOK
+	},
+	"proxy_set_effective_context": func(c proxyWasmHostCall, _ []uint32) uint32 {
+		// A VM handles a single stream at a time.
+		return proxyWasmStatusOK
+	},
+	"proxy_done": func(c proxyWasmHostCall, _ []uint32) uint32 {
+		return proxyWasmStatusOK
+	},
+	"proxy_get_buffer_bytes": func(c proxyWasmHostCall, p []uint32) uint32 {
+		data, _, ok := c.buffer(p[0])
+		if !ok {
+			return proxyWasmStatusNotFound
+		}
+
+		start, maxSize := int(p[1]), int(p[2])
+		if start > len(data) {
+			return proxyWasmStatusBadArgument
+		}
+
+		return c.returnBytes(data[start:min(start+maxSize, len(data))], p[3], p[4])
+	},
+	"proxy_set_buffer_bytes": func(c proxyWasmHostCall, p []uint32) uint32 {
+		data, replace, ok := c.buffer(p[0])
+		if !ok || replace == nil {
+			return proxyWasmStatusNotFound
+		}
+
+		value, ok := c.read(p[3], p[4])
+		if !ok {
+			return proxyWasmStatusBadArgument
+		}
+
+		start, size := int(p[1]), int(p[2])
+		if start > len(data) {
+			return proxyWasmStatusBadArgument
+		}
+
+		end := min(start+size, len(data))
+		replace(append(append(bytes.Clone(data[:start]), value...), data[end:]...))
+
+		return proxyWasmStatusOK
+	},
+	"proxy_get_header_map_pairs": func(c proxyWasmHostCall, p []uint32) uint32 {
+		headers, ok := c.headerMap(p[0])
+		if !ok {
+			return proxyWasmStatusNotFound
+		}
+
+		return c.returnBytes(encodeProxyWasmPairs(headers.pairs()), p[1], p[2])
+	},
+	"proxy_set_header_map_pairs": func(c proxyWasmHostCall, p []uint32) uint32 {
+		headers, ok := c.headerMap(p[0])
+		if !ok {
+			return proxyWasmStatusNotFound
+		}
+
+		data, ok := c.read(p[1], p[2])
+		if !ok {
+			return proxyWasmStatusBadArgument
+		}
+
+		pairs, err := decodeProxyWasmPairs(data)
+		if err != nil {
+			return proxyWasmStatusBadArgument
+		}
+
+		for _, pair := range headers.pairs() {
+			headers.remove(pair[0])
+		}
+
+		for _, pair := range pairs {
+			headers.add(pair[0], pair[1])
+		}
+
+		return proxyWasmStatusOK
+	},
+	"proxy_get_header_map_value": func(c proxyWasmHostCall, p []uint32) uint32 {
+		headers, ok := c.headerMap(p[0])
+		if !ok {
+			return proxyWasmStatusNotFound
+		}
+
+		key, ok := c.read(p[1], p[2])
+		if !ok {
+			return proxyWasmStatusBadArgument
+		}
+
+		value, ok := headers.get(string(key))
+		if !ok {
+			return proxyWasmStatusNotFound
+		}
+
+		return c.returnBytes([]byte(value), p[3], p[4])
+	},
+	"proxy_add_header_map_value": func(c proxyWasmHostCall, p []uint32) uint32 {
+		return c.updateHeader(p, proxyWasmHeaderMap.add)
+	},
+	"proxy_replace_header_map_value": func(c proxyWasmHostCall, p []uint32) uint32 {
+		return c.updateHeader(p, proxyWasmHeaderMap.set)
+	},
+	"proxy_remove_header_map_value": func(c proxyWasmHostCall, p []uint32) uint32 {
+		headers, ok := c.headerMap(p[0])
+		if !ok {
+			return proxyWasmStatusNotFound
+		}
+
+		key, ok := c.read(p[1], p[2])
+		if !ok {
+			return proxyWasmStatusBadArgument
+		}
+
+		headers.remove(string(key))
+
+		return proxyWasmStatusOK
+	},
+	"proxy_get_header_map_size": func(c proxyWasmHostCall, p []uint32) uint32 {
+		headers, ok := c.headerMap(p[0])
+		if !ok {
+			return proxyWasmStatusNotFound
+		}
+
+		if !c.mod.Memory().WriteUint32Le(p[1], uint32(len(encodeProxyWasmPairs(headers.pairs())))) {
+			return proxyWasmStatusBadArgument
+		}
+
+		return proxyWasmStatusOK
+	},
+	"proxy_send_local_response": func(c proxyWasmHostCall, p []uint32) uint32 {
+		stream := c.vm.stream
+		if stream == nil {
+			return proxyWasmStatusBadArgument
+		}
+
+		body, ok := c.read(p[3], p[4])
+		if !ok {
+			return proxyWasmStatusBadArgument
+		}
+
+		headers, ok := c.read(p[5], p[6])
+		if !ok {
+			return proxyWasmStatusBadArgument
+		}
+
+		resp := newProxyWasmResponse()
+		resp.WriteHeader(int(p[0]))
+		resp.body.Write(body)
+
+		if len(headers) > 0 {
+			pairs, err := decodeProxyWasmPairs(headers)
+			if err != nil {
+				return proxyWasmStatusBadArgument
+			}
+
+			for _, pair := range pairs {
+				resp.header.Add(pair[0], pair[1])
+			}
+		}
+
+		stream.localResponse = resp
+
+		return proxyWasmStatusOK
+	},
+	"proxy_get_property": func(c proxyWasmHostCall, p []uint32) uint32 {
+		path, ok := c.read(p[0], p[1])
+		if !ok {
+			return proxyWasmStatusBadArgument
+		}
+
+		value, ok := c.property(strings.Split(strings.TrimRight(string(path), "\x00"), "\x00"))
+		if !ok {
+			return proxyWasmStatusNotFound
+		}
+
+		return c.returnBytes([]byte(value), p[2], p[3])
+	},
+}
+
+func (c proxyWasmHostCall) updateHeader(p []uint32, update func(proxyWasmHeaderMap, string, string)) uint32 {
+	headers, ok := c.headerMap(p[0])
+	if !ok {
+		return proxyWasmStatusNotFound
+	}
+
+	key, ok := c.read(p[1], p[2])
+	if !ok {
+		return proxyWasmStatusBadArgument
+	}
+
+	value, ok := c.read(p[3], p[4])
+	if !ok {
+		return proxyWasmStatusBadArgument
+	}
+
+	update(headers, string(key), string(value))
+
+	return proxyWasmStatusOK
+}
+
+// property returns the attribute of the given path (https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/advanced/attributes),
+// only supporting the string attributes of the request.
+func (c proxyWasmHostCall) property(path []string) (string, bool) {
+	stream := c.vm.stream
+	if stream == nil || len(path) != 2 {
+		return "", false
+	}
+
+	req := stream.req
+
+	switch path[0] + "." + path[1] {
+	case "request.path":
+		return req.URL.RequestURI(), true
+	case "request.url_path":
+		return req.URL.Path, true
+	case "request.host":
+		return req.Host, true
+	case "request.scheme":
+		return newProxyWasmRequestHeaders(req).get(":scheme")
+	case "request.method":
+		return req.Method, true
+	case "request.query":
+		return req.URL.RawQuery, true
+	case "request.protocol":
+		return req.Proto, true
+	case "request.referer":
+		return req.Referer(), true
+	case "request.useragent":
+		return req.UserAgent(), true
+	case "source.address":
+		return req.RemoteAddr, true
+	default:
+		return "", false
+	}
+}
+
+func proxyWasmLogLevel(level uint32) zerolog.Level {
+	switch level {
+	case 0:
+		return zerolog.TraceLevel
+	case 1:
+		return zerolog.DebugLevel
+	case 2:
+		return zerolog.InfoLevel
+	case 3:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.ErrorLevel
+	}
+}
+
+// instantiateProxyWasmHost instantiates the env module exporting the proxy-wasm host functions imported by the guest.
+// The host functions which are not implemented answer with the Unimplemented status.
+func instantiateProxyWasmHost(ctx context.Context, rt wazero.Runtime, guest wazero.CompiledModule) error {
+	builder := rt.NewHostModuleBuilder("env")
+
+	for _, def := range guest.ImportedFunctions() {
+		moduleName, name, _ := def.Import()
+		if moduleName != "env" {
+			continue
+		}
+
+		paramTypes, resultTypes := def.ParamTypes(), def.ResultTypes()
+
+		fn, ok := proxyWasmHostFuncs[name]
+		if !ok || !isI32Signature(paramTypes, resultTypes) {
+			fn = func(proxyWasmHostCall, []uint32) uint32 { return proxyWasmStatusUnimplemented }
+		}
+
+		builder.NewFunctionBuilder().
+			WithGoModuleFunction(newProxyWasmGoFunc(fn, paramTypes, resultTypes), paramTypes, resultTypes).
+			Export(name)
+	}
+
+	_, err := builder.Instantiate(ctx)
+
+	return err
+}
+
+func newProxyWasmGoFunc(fn proxyWasmHostFunc, paramTypes, resultTypes []api.ValueType) api.GoModuleFunc {
+	return func(ctx context.Context, mod api.Module, stack []uint64) {
+		status := proxyWasmStatusInternalFailure
+
+		vm, ok := ctx.Value(proxyWasmVMKey{}).(*proxyWasmVM)
+		if ok && isI32Signature(paramTypes, resultTypes) {
+			params := make([]uint32, len(paramTypes))
+			for i := range params {
+				params[i] = api.DecodeU32(stack[i])
+			}
+
+			status = fn(proxyWasmHostCall{ctx: ctx, mod: mod, vm: vm}, params)
+		} else if ok {
+			status = proxyWasmStatusUnimplemented
+		}
+
+		if len(resultTypes) > 0 {
+			stack[0] = api.EncodeU32(status)
+		}
+	}
+}
+
+func isI32Signature(paramTypes, resultTypes []api.ValueType) bool {
+	for _, paramType := range paramTypes {
+		if paramType != api.ValueTypeI32 {
+			return false
+		}
+	}
+
+	return len(resultTypes) == 1 && resultTypes[0] == api.ValueTypeI32
+}
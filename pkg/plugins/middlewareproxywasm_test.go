@@ -0,0 +1,273 @@
+package plugins
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// proxyWasmTestModule returns a proxy-wasm HTTP filter, equivalent to:
+//
+//	func proxy_on_request_headers(contextID, numHeaders, endOfStream uint32) uint32 {
+//		if proxy_get_header_map_value(requestHeaders, "x-block") == OK {
+//			proxy_send_local_response(403, "", "blocked", nil, -1)
+//			return Pause
+//		}
+//		proxy_add_header_map_value(requestHeaders, "x-proxy-wasm", "on")
+//		return Continue
+//	}
+//
+//	func proxy_on_response_headers(contextID, numHeaders, endOfStream uint32) uint32 {
+//		proxy_add_header_map_value(responseHeaders, "x-proxy-wasm", "on")
+//		return Continue
+//	}
+//
+// Its configuration is accepted if it is not empty.
+func proxyWasmTestModule() []byte {
+	const (
+		i32      = 0x7f
+		call     = 0x10
+		drop     = 0x1a
+		localGet = 0x20
+		globGet  = 0x23
+		globSet  = 0x24
+		i32Eqz   = 0x45
+		i32Add   = 0x6a
+		ret      = 0x0f
+		end      = 0x0b
+	)
+
+	funcType := func(params, results int) []byte {
+		return append(append([]byte{0x60}, wasmVec(repeatBytes(i32, params)...)...), wasmVec(repeatBytes(i32, results)...)...)
+	}
+
+	imp := func(name string, typeIdx byte) []byte {
+		return append(append(wasmName("env"), wasmName(name)...), 0x00, typeIdx)
+	}
+
+	export := func(name string, kind, idx byte) []byte {
+		return append(wasmName(name), kind, idx)
+	}
+
+	body := func(code ...[]byte) []byte {
+		var expr []byte
+		for _, c := range code {
+			expr = append(expr, c...)
+		}
+
+		expr = append([]byte{0x00}, append(expr, end)...)
+
+		return append(wasmULEB(uint32(len(expr))), expr...)
+	}
+
+	consts := func(values ...int32) []byte {
+		var code []byte
+		for _, v := range values {
+			code = append(code, wasmI32Const(v)...)
+		}
+
+		return code
+	}
+
+	data := func(offset int32, value string) []byte {
+		return append(append([]byte{0x00}, append(wasmI32Const(offset), end)...), wasmVec(wasmBytes(value)...)...)
+	}
+
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+	module = append(module, wasmSection(1, wasmVec(
+		funcType(5, 1), // 0: header map value functions.
+		funcType(8, 1), // 1: proxy_send_local_response.
+		funcType(2, 0), // 2: proxy_on_context_create.
+		funcType(3, 1), // 3: headers callbacks.
+		funcType(1, 1), // 4: proxy_on_memory_allocate.
+		funcType(0, 0), // 5: ABI version.
+		funcType(2, 1), // 6: root context callbacks.
+	))...)
+
+	module = append(module, wasmSection(2, wasmVec(
+		imp("proxy_add_header_map_value", 0),
+		imp("proxy_send_local_response", 1),
+		imp("proxy_get_header_map_value", 0),
+		imp("proxy_set_tick_period_milliseconds", 4),
+	))...)
+
+	module = append(module, wasmSection(3, wasmVec([]byte{2}, []byte{3}, []byte{3}, []byte{4}, []byte{5}, []byte{6}, []byte{6}))...)
+	module = append(module, wasmSection(5, wasmVec([]byte{0x00, 0x01}))...)
+	module = append(module, wasmSection(6, wasmVec(append([]byte{i32, 0x01}, append(wasmI32Const(1024), end)...)))...)
+
+	module = append(module, wasmSection(7, wasmVec(
+		export("memory", 0x02, 0),
+		export("proxy_on_context_create", 0x00, 4),
+		export("proxy_on_request_headers", 0x00, 5),
+		export("proxy_on_response_headers", 0x00, 6),
+		export("proxy_on_memory_allocate", 0x00, 7),
+		export("proxy_abi_version_0_2_1", 0x00, 8),
+		export("proxy_on_vm_start", 0x00, 9),
+		export("proxy_on_configure", 0x00, 10),
+	))...)
+
+	module = append(module, wasmSection(10, wasmVec(
+		body(),
+		body(
+			consts(0, 32, 7, 200, 204), []byte{call, 2, i32Eqz, 0x04, 0x40},
+			consts(403, 0, 0, 48, 7, 0, 0, -1), []byte{call, 1, drop},
+			consts(1), []byte{ret, end},
+			consts(0, 0, 12, 16, 2), []byte{call, 0, drop},
+			consts(0),
+		),
+		body(consts(2, 0, 12, 16, 2), []byte{call, 0, drop}, consts(0)),
+		body([]byte{globGet, 0, globGet, 0, localGet, 0, i32Add, globSet, 0}),
+		body(),
+		body(consts(1)),
+		body([]byte{localGet, 1}),
+	))...)
+
+	module = append(module, wasmSection(11, wasmVec(
+		data(0, "x-proxy-wasm"),
+		data(16, "on"),
+		data(32, "x-block"),
+		data(48, "blocked"),
+	))...)
+
+	return module
+}
+
+func TestProxyWasmMiddleware(t *testing.T) {
+	goPath := t.TempDir()
+	moduleDir := filepath.Join(goPath, "src", "github.com", "traefik", "pluginproxywasm")
+	require.NoError(t, os.MkdirAll(moduleDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "plugin.wasm"), proxyWasmTestModule(), 0o600))
+
+	builder, err := newWasmMiddlewareBuilder(context.Background(), goPath, "github.com/traefik/pluginproxywasm", "plugin.wasm", Settings{})
+	require.NoError(t, err)
+	assert.True(t, builder.proxyWasm)
+
+	middleware, err := builder.newMiddleware(map[string]interface{}{"foo": "bar"}, "test")
+	require.NoError(t, err)
+
+	var nextCalls int
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		nextCalls++
+		rw.Header().Set("X-Request-Header", req.Header.Get("X-Proxy-Wasm"))
+		_, _ = rw.Write([]byte("next"))
+	})
+
+	handler, err := middleware.NewHandler(context.Background(), next)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "next", recorder.Body.String())
+	assert.Equal(t, "on", recorder.Header().Get("X-Request-Header"))
+	assert.Equal(t, "on", recorder.Header().Get("X-Proxy-Wasm"))
+	assert.Equal(t, 1, nextCalls)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Block", "true")
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.Equal(t, "blocked", recorder.Body.String())
+	assert.Equal(t, 1, nextCalls)
+}
+
+func TestProxyWasmMiddleware_rejectedConfiguration(t *testing.T) {
+	goPath := t.TempDir()
+	moduleDir := filepath.Join(goPath, "src", "github.com", "traefik", "pluginproxywasm")
+	require.NoError(t, os.MkdirAll(moduleDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "plugin.wasm"), proxyWasmTestModule(), 0o600))
+
+	builder, err := newWasmMiddlewareBuilder(context.Background(), goPath, "github.com/traefik/pluginproxywasm", "plugin.wasm", Settings{})
+	require.NoError(t, err)
+
+	middleware, err := builder.newMiddleware(nil, "test")
+	require.NoError(t, err)
+
+	_, err = middleware.NewHandler(context.Background(), http.NotFoundHandler())
+	require.ErrorContains(t, err, "proxy-wasm plugin rejected its configuration")
+}
+
+func TestProxyWasmPairs(t *testing.T) {
+	pairs := [][2]string{{":path", "/foo"}, {"x-foo", "bar"}, {"x-empty", ""}}
+
+	decoded, err := decodeProxyWasmPairs(encodeProxyWasmPairs(pairs))
+	require.NoError(t, err)
+	assert.Equal(t, pairs, decoded)
+
+	_, err = decodeProxyWasmPairs([]byte{0x02, 0x00, 0x00, 0x00})
+	require.Error(t, err)
+}
+
+func wasmSection(id byte, content []byte) []byte {
+	return append(append([]byte{id}, wasmULEB(uint32(len(content)))...), content...)
+}
+
+func wasmVec(items ...[]byte) []byte {
+	vec := wasmULEB(uint32(len(items)))
+	for _, item := range items {
+		vec = append(vec, item...)
+	}
+
+	return vec
+}
+
+func wasmName(name string) []byte {
+	return append(wasmULEB(uint32(len(name))), name...)
+}
+
+func wasmBytes(value string) [][]byte {
+	items := make([][]byte, len(value))
+	for i := range len(value) {
+		items[i] = []byte{value[i]}
+	}
+
+	return items
+}
+
+func wasmI32Const(v int32) []byte {
+	code := []byte{0x41}
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(code, b)
+		}
+
+		code = append(code, b|0x80)
+	}
+}
+
+func wasmULEB(v uint32) []byte {
+	var data []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+
+		if v == 0 {
+			return append(data, b)
+		}
+
+		data = append(data, b|0x80)
+	}
+}
+
+func repeatBytes(b byte, n int) [][]byte {
+	items := make([][]byte, n)
+	for i := range items {
+		items[i] = []byte{b}
+	}
+
+	return items
+}
@@ -9,23 +9,43 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/http-wasm/http-wasm-host-go/handler"
 	wasm "github.com/http-wasm/http-wasm-host-go/handler/nethttp"
 	"github.com/juliens/wasm-goexport/host"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 	"github.com/tetratelabs/wazero"
 	"github.com/traefik/traefik/v3/pkg/logs"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
 )
 
+const (
+	// wasmPageSize is the size of a Wasm memory page.
+	wasmPageSize = 65536
+	// wasmMaxPages is the maximum number of memory pages of a 32-bit Wasm module.
+	wasmMaxPages = 65536
+)
+
 type wasmMiddlewareBuilder struct {
 	path     string
 	cache    wazero.CompilationCache
 	settings Settings
+	// proxyWasm is true if the module implements the proxy-wasm ABI, rather than the http-wasm one.
+	proxyWasm bool
+
+	moduleName string
+	logLevel   zerolog.Level
 }
 
-func newWasmMiddlewareBuilder(goPath, moduleName, wasmPath string, settings Settings) (*wasmMiddlewareBuilder, error) {
-	ctx := context.Background()
+func newWasmMiddlewareBuilder(ctx context.Context, goPath, moduleName, wasmPath string, settings Settings) (*wasmMiddlewareBuilder, error) {
+	if err := checkWasmPolicy(settings); err != nil {
+		return nil, err
+	}
+
 	path := filepath.Join(goPath, "src", moduleName, wasmPath)
 	cache := wazero.NewCompilationCache()
 
@@ -34,12 +54,31 @@ func newWasmMiddlewareBuilder(goPath, moduleName, wasmPath string, settings Sett
 		return nil, fmt.Errorf("loading Wasm binary: %w", err)
 	}
 
-	rt := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithCompilationCache(cache))
-	if _, err = rt.CompileModule(ctx, code); err != nil {
+	rt := wazero.NewRuntimeWithConfig(ctx, newWasmRuntimeConfig(cache, settings))
+
+	guestModule, err := rt.CompileModule(ctx, code)
+	if err != nil {
 		return nil, fmt.Errorf("compiling guest module: %w", err)
 	}
 
-	return &wasmMiddlewareBuilder{path: path, cache: cache, settings: settings}, nil
+	return &wasmMiddlewareBuilder{
+		path:       path,
+		cache:      cache,
+		settings:   settings,
+		proxyWasm:  isProxyWasmModule(guestModule),
+		moduleName: moduleName,
+		logLevel:   log.Ctx(ctx).GetLevel(),
+	}, nil
+}
+
+// logger returns the logger of the guest output, tagged with the plugin module name, at the plugin log level.
+func (b wasmMiddlewareBuilder) logger(ctx context.Context, middlewareName string) *zerolog.Logger {
+	logger := middlewares.GetLogger(ctx, middlewareName, "wasm").With().
+		Str("module", b.moduleName).
+		Logger().
+		Level(b.logLevel)
+
+	return &logger
 }
 
 func (b wasmMiddlewareBuilder) newMiddleware(config map[string]interface{}, middlewareName string) (pluginMiddleware, error) {
@@ -51,14 +90,36 @@ func (b wasmMiddlewareBuilder) newMiddleware(config map[string]interface{}, midd
 }
 
 func (b wasmMiddlewareBuilder) newHandler(ctx context.Context, next http.Handler, cfg reflect.Value, middlewareName string) (http.Handler, error) {
-	h, applyCtx, err := b.buildMiddleware(ctx, next, cfg, middlewareName)
+	h := &wasmHandler{
+		maxExecutionTime: time.Duration(b.settings.MaxExecutionTime),
+	}
+
+	if b.settings.PoolSize > 0 {
+		h.instances = make(chan struct{}, b.settings.PoolSize)
+	}
+
+	h.build = func() (*wasmGuestHandler, error) {
+		build := b.buildMiddleware
+		if b.proxyWasm {
+			build = b.buildProxyWasmMiddleware
+		}
+
+		guest, applyCtx, err := build(ctx, h.nextHandler(next), cfg, middlewareName)
+		if err != nil {
+			return nil, err
+		}
+
+		return &wasmGuestHandler{handler: guest, applyCtx: applyCtx}, nil
+	}
+
+	guest, err := h.build()
 	if err != nil {
 		return nil, fmt.Errorf("building Wasm middleware: %w", err)
 	}
 
-	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		h.ServeHTTP(rw, req.WithContext(applyCtx(req.Context())))
-	}), nil
+	h.guest.Store(guest)
+
+	return h, nil
 }
 
 func (b *wasmMiddlewareBuilder) buildMiddleware(ctx context.Context, next http.Handler, cfg reflect.Value, middlewareName string) (http.Handler, func(ctx context.Context) context.Context, error) {
@@ -67,7 +128,7 @@ func (b *wasmMiddlewareBuilder) buildMiddleware(ctx context.Context, next http.H
 		return nil, nil, fmt.Errorf("loading binary: %w", err)
 	}
 
-	rt := host.NewRuntime(wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithCompilationCache(b.cache)))
+	rt := host.NewRuntime(wazero.NewRuntimeWithConfig(ctx, newWasmRuntimeConfig(b.cache, b.settings)))
 
 	guestModule, err := rt.CompileModule(ctx, code)
 	if err != nil {
@@ -79,32 +140,11 @@ func (b *wasmMiddlewareBuilder) buildMiddleware(ctx context.Context, next http.H
 		return nil, nil, fmt.Errorf("instantiating host module: %w", err)
 	}
 
-	logger := middlewares.GetLogger(ctx, middlewareName, "wasm")
-
-	config := wazero.NewModuleConfig().WithSysWalltime()
-	for _, env := range b.settings.Envs {
-		config.WithEnv(env, os.Getenv(env))
-	}
+	logger := b.logger(ctx, middlewareName)
 
-	if len(b.settings.Mounts) > 0 {
-		fsConfig := wazero.NewFSConfig()
-		for _, mount := range b.settings.Mounts {
-			withDir := fsConfig.WithDirMount
-			prefix, readOnly := strings.CutSuffix(mount, ":ro")
-			if readOnly {
-				withDir = fsConfig.WithReadOnlyDirMount
-			}
-			parts := strings.Split(prefix, ":")
-			switch {
-			case len(parts) == 1:
-				withDir(parts[0], parts[0])
-			case len(parts) == 2:
-				withDir(parts[0], parts[1])
-			default:
-				return nil, nil, fmt.Errorf("invalid directory %q", mount)
-			}
-		}
-		config.WithFSConfig(fsConfig)
+	config, err := newWasmModuleConfig(b.settings)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	opts := []handler.Option{
@@ -112,18 +152,12 @@ func (b *wasmMiddlewareBuilder) buildMiddleware(ctx context.Context, next http.H
 		handler.Logger(logs.NewWasmLogger(logger)),
 	}
 
-	i := cfg.Interface()
-	if i != nil {
-		config, ok := i.(map[string]interface{})
-		if !ok {
-			return nil, nil, fmt.Errorf("could not type assert config: %T", i)
-		}
-
-		data, err := json.Marshal(config)
-		if err != nil {
-			return nil, nil, fmt.Errorf("marshaling config: %w", err)
-		}
+	data, err := wasmGuestConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	if data != nil {
 		opts = append(opts, handler.GuestConfig(data))
 	}
 
@@ -139,6 +173,77 @@ func (b *wasmMiddlewareBuilder) buildMiddleware(ctx context.Context, next http.H
 	return mw.NewHandler(ctx, next), applyCtx, nil
 }
 
+// wasmGuestConfig returns the JSON encoded middleware configuration given to the guest, if any.
+func wasmGuestConfig(cfg reflect.Value) ([]byte, error) {
+	i := cfg.Interface()
+	if i == nil {
+		return nil, nil
+	}
+
+	config, ok := i.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("could not type assert config: %T", i)
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling config: %w", err)
+	}
+
+	return data, nil
+}
+
+// newWasmRuntimeConfig creates the runtime configuration enforcing the resource limits of the plugin settings.
+func newWasmRuntimeConfig(cache wazero.CompilationCache, settings Settings) wazero.RuntimeConfig {
+	config := wazero.NewRuntimeConfig().WithCompilationCache(cache)
+
+	if settings.MaxMemory > 0 {
+		pages := min(max(settings.MaxMemory/wasmPageSize, 1), wasmMaxPages)
+		config = config.WithMemoryLimitPages(uint32(pages))
+	}
+
+	if settings.MaxExecutionTime > 0 {
+		config = config.WithCloseOnContextDone(true)
+	}
+
+	return config
+}
+
+// newWasmModuleConfig creates the guest module configuration from the plugin settings.
+func newWasmModuleConfig(settings Settings) (wazero.ModuleConfig, error) {
+	config := wazero.NewModuleConfig()
+	if settings.Policy.allowsClock() {
+		config = config.WithSysWalltime()
+	}
+
+	for _, env := range settings.Envs {
+		config = config.WithEnv(env, os.Getenv(env))
+	}
+
+	if len(settings.Mounts) > 0 {
+		fsConfig := wazero.NewFSConfig()
+		for _, mount := range settings.Mounts {
+			withDir := fsConfig.WithDirMount
+			prefix, readOnly := strings.CutSuffix(mount, ":ro")
+			if readOnly {
+				withDir = fsConfig.WithReadOnlyDirMount
+			}
+			parts := strings.Split(prefix, ":")
+			switch {
+			case len(parts) == 1:
+				fsConfig = withDir(parts[0], parts[0])
+			case len(parts) == 2:
+				fsConfig = withDir(parts[0], parts[1])
+			default:
+				return nil, fmt.Errorf("invalid directory %q", mount)
+			}
+		}
+		config = config.WithFSConfig(fsConfig)
+	}
+
+	return config, nil
+}
+
 // WasmMiddleware is an HTTP handler plugin wrapper.
 type WasmMiddleware struct {
 	middlewareName string
@@ -150,3 +255,169 @@ type WasmMiddleware struct {
 func (m WasmMiddleware) NewHandler(ctx context.Context, next http.Handler) (http.Handler, error) {
 	return m.builder.newHandler(ctx, next, m.config, m.middlewareName)
 }
+
+// wasmHandler is the HTTP handler of a Wasm middleware, enforcing its execution time and instances limits.
+type wasmHandler struct {
+	maxExecutionTime time.Duration
+	// instances holds a token for each request being handled, when the number of instances is limited.
+	instances chan struct{}
+
+	build   func() (*wasmGuestHandler, error)
+	guest   atomic.Pointer[wasmGuestHandler]
+	buildMu sync.Mutex
+}
+
+type wasmGuestHandler struct {
+	handler  http.Handler
+	applyCtx ContextApplier
+}
+
+func (h *wasmHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if h.instances != nil {
+		select {
+		case h.instances <- struct{}{}:
+			defer func() { <-h.instances }()
+
+		case <-req.Context().Done():
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	guest := h.guest.Load()
+
+	if h.maxExecutionTime <= 0 {
+		guest.handler.ServeHTTP(rw, req.WithContext(guest.applyCtx(req.Context())))
+		return
+	}
+
+	budget := newExecutionBudget(req.Context(), h.maxExecutionTime)
+	defer budget.stop()
+
+	guest.handler.ServeHTTP(rw, req.WithContext(guest.applyCtx(budget)))
+
+	if budget.Err() != nil {
+		// The guest instance has been closed by the runtime, and would be reused by the next requests.
+		h.rebuild(req.Context(), guest)
+	}
+}
+
+// nextHandler returns the next handler, called by the guest,
+// during which the execution budget of the request is paused.
+func (h *wasmHandler) nextHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		budget, ok := req.Context().Value(executionBudgetKey{}).(*executionBudget)
+		if !ok {
+			next.ServeHTTP(rw, req)
+			return
+		}
+
+		budget.pause()
+		defer budget.resume()
+
+		next.ServeHTTP(rw, req.WithContext(budget.parent))
+	})
+}
+
+func (h *wasmHandler) rebuild(ctx context.Context, previous *wasmGuestHandler) {
+	h.buildMu.Lock()
+	defer h.buildMu.Unlock()
+
+	if h.guest.Load() != previous {
+		// Already rebuilt by a concurrent request.
+		return
+	}
+
+	logger := log.Ctx(ctx)
+	logger.Warn().Msgf("Wasm middleware exceeded its maximum execution time of %s, rebuilding it", h.maxExecutionTime)
+
+	guest, err := h.build()
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to rebuild the Wasm middleware")
+		return
+	}
+
+	h.guest.Store(guest)
+}
+
+type executionBudgetKey struct{}
+
+// executionBudget is a context which is done once the guest has been running for the maximum execution time.
+// It is not canceled with its parent context,
+// so that a canceled request does not close a guest instance.
+type executionBudget struct {
+	context.Context
+
+	parent context.Context
+	done   chan struct{}
+	once   sync.Once
+
+	mu        sync.Mutex
+	timer     *time.Timer
+	remaining time.Duration
+	startedAt time.Time
+}
+
+func newExecutionBudget(parent context.Context, maxExecutionTime time.Duration) *executionBudget {
+	b := &executionBudget{
+		Context:   context.WithoutCancel(parent),
+		parent:    parent,
+		done:      make(chan struct{}),
+		remaining: maxExecutionTime,
+	}
+
+	b.resume()
+
+	return b
+}
+
+func (b *executionBudget) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (b *executionBudget) Done() <-chan struct{} {
+	return b.done
+}
+
+func (b *executionBudget) Err() error {
+	select {
+	case <-b.done:
+		return context.DeadlineExceeded
+	default:
+		return nil
+	}
+}
+
+func (b *executionBudget) Value(key any) any {
+	if _, ok := key.(executionBudgetKey); ok {
+		return b
+	}
+
+	return b.Context.Value(key)
+}
+
+func (b *executionBudget) pause() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.timer.Stop() {
+		b.remaining -= time.Since(b.startedAt)
+	}
+}
+
+func (b *executionBudget) resume() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.startedAt = time.Now()
+	b.timer = time.AfterFunc(b.remaining, func() {
+		b.once.Do(func() { close(b.done) })
+	})
+}
+
+func (b *executionBudget) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.timer.Stop()
+}
@@ -0,0 +1,79 @@
+package plugins
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero"
+)
+
+func Test_newWasmRuntimeConfig_maxMemory(t *testing.T) {
+	// Module with a memory of 2 pages.
+	code := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x05, 0x03, 0x01, 0x00, 0x02}
+
+	testCases := []struct {
+		desc      string
+		maxMemory int64
+		expectErr bool
+	}{
+		{
+			desc: "no limit",
+		},
+		{
+			desc:      "limit above the module memory",
+			maxMemory: 4 * wasmPageSize,
+		},
+		{
+			desc:      "limit below the module memory",
+			maxMemory: wasmPageSize,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			rt := wazero.NewRuntimeWithConfig(ctx, newWasmRuntimeConfig(wazero.NewCompilationCache(), Settings{MaxMemory: test.maxMemory}))
+			t.Cleanup(func() { _ = rt.Close(ctx) })
+
+			_, err := rt.CompileModule(ctx, code)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestExecutionBudget(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+
+	budget := newExecutionBudget(parent, 50*time.Millisecond)
+	t.Cleanup(budget.stop)
+
+	budget.pause()
+
+	// Neither the parent cancellation nor the time spent while paused consume the budget.
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, budget.Err())
+
+	budget.resume()
+
+	select {
+	case <-budget.Done():
+		assert.ErrorIs(t, budget.Err(), context.DeadlineExceeded)
+	case <-time.After(time.Second):
+		t.Fatal("budget not exhausted")
+	}
+
+	assert.Equal(t, budget, budget.Value(executionBudgetKey{}))
+}
@@ -20,12 +20,12 @@ import (
 type yaegiMiddlewareBuilder struct {
 	fnNew          reflect.Value
 	fnCreateConfig reflect.Value
+	// fnHealthy is the optional health hook of the plugin.
+	fnHealthy reflect.Value
 }
 
 func newYaegiMiddlewareBuilder(i *interp.Interpreter, basePkg, imp string) (*yaegiMiddlewareBuilder, error) {
-	if basePkg == "" {
-		basePkg = strings.ReplaceAll(path.Base(imp), "-", "_")
-	}
+	basePkg = yaegiBasePkg(basePkg, imp)
 
 	fnNew, err := i.Eval(basePkg + `.New`)
 	if err != nil {
@@ -37,12 +37,32 @@ func newYaegiMiddlewareBuilder(i *interp.Interpreter, basePkg, imp string) (*yae
 		return nil, fmt.Errorf("failed to eval CreateConfig: %w", err)
 	}
 
+	// The Healthy function is optional.
+	fnHealthy, err := i.Eval(basePkg + `.Healthy`)
+	if err != nil {
+		fnHealthy = reflect.Value{}
+	}
+
+	if fnHealthy.IsValid() && fnHealthy.Type() != reflect.TypeOf(func(http.Handler) error { return nil }) {
+		return nil, fmt.Errorf("invalid Healthy function type: %s, expected func(http.Handler) error", fnHealthy.Type())
+	}
+
 	return &yaegiMiddlewareBuilder{
 		fnNew:          fnNew,
 		fnCreateConfig: fnCreateConfig,
+		fnHealthy:      fnHealthy,
 	}, nil
 }
 
+// yaegiBasePkg returns the name of the base package of the plugin, defaults to the last element of its import path.
+func yaegiBasePkg(basePkg, imp string) string {
+	if basePkg == "" {
+		return strings.ReplaceAll(path.Base(imp), "-", "_")
+	}
+
+	return basePkg
+}
+
 func (b yaegiMiddlewareBuilder) newMiddleware(config map[string]interface{}, middlewareName string) (pluginMiddleware, error) {
 	vConfig, err := b.createConfig(config)
 	if err != nil {
@@ -74,9 +94,30 @@ func (b yaegiMiddlewareBuilder) newHandler(ctx context.Context, next http.Handle
 		return nil, fmt.Errorf("invalid handler type: %T", results[0].Interface())
 	}
 
+	if b.fnHealthy.IsValid() {
+		return &yaegiHealthyHandler{Handler: handler, fnHealthy: b.fnHealthy}, nil
+	}
+
 	return handler, nil
 }
 
+// yaegiHealthyHandler provides the health hook of a Yaegi plugin handler.
+// The methods of the handlers returned by the interpreter are not reachable,
+// so the hook is the Healthy function of the plugin package, called with the handler.
+type yaegiHealthyHandler struct {
+	http.Handler
+	fnHealthy reflect.Value
+}
+
+// Healthy calls the Healthy function of the plugin with the handler.
+func (h *yaegiHealthyHandler) Healthy() error {
+	results := h.fnHealthy.Call([]reflect.Value{reflect.ValueOf(h.Handler)})
+
+	err, _ := results[0].Interface().(error)
+
+	return err
+}
+
 func (b yaegiMiddlewareBuilder) createConfig(config map[string]interface{}) (reflect.Value, error) {
 	results := b.fnCreateConfig.Call(nil)
 	if len(results) != 1 {
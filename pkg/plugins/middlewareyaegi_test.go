@@ -0,0 +1,120 @@
+package plugins
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const yaegiHealthyTestPlugin = `package plugindemo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+type Config struct {
+	Healthy bool
+}
+
+func CreateConfig() *Config {
+	return &Config{}
+}
+
+type demo struct {
+	next    http.Handler
+	healthy bool
+}
+
+func (d *demo) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	d.next.ServeHTTP(rw, req)
+}
+
+func New(ctx context.Context, next http.Handler, config *Config, name string) (http.Handler, error) {
+	return &demo{next: next, healthy: config.Healthy}, nil
+}
+
+func Healthy(handler http.Handler) error {
+	if !handler.(*demo).healthy {
+		return errors.New("backend unreachable")
+	}
+
+	return nil
+}
+`
+
+func TestYaegiMiddleware_healthy(t *testing.T) {
+	goPath := t.TempDir()
+	sources := filepath.Join(goPath, goPathSrc, "github.com", "traefik", "plugindemo")
+	require.NoError(t, os.MkdirAll(sources, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(sources, "plugindemo.go"), []byte(yaegiHealthyTestPlugin), 0o600))
+
+	manifest := &Manifest{Type: typeMiddleware, Import: "github.com/traefik/plugindemo"}
+
+	builder, err := newMiddlewareBuilder(context.Background(), goPath, manifest, "github.com/traefik/plugindemo", Settings{}, false, nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc        string
+		config      map[string]interface{}
+		expectedErr string
+	}{
+		{
+			desc:   "healthy",
+			config: map[string]interface{}{"healthy": true},
+		},
+		{
+			desc:        "unhealthy",
+			config:      map[string]interface{}{"healthy": false},
+			expectedErr: "backend unreachable",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			middleware, err := builder.newMiddleware(test.config, "test")
+			require.NoError(t, err)
+
+			handler, err := middleware.NewHandler(context.Background(), http.NotFoundHandler())
+			require.NoError(t, err)
+
+			checker, ok := handler.(HealthChecker)
+			require.True(t, ok)
+
+			err = checker.Healthy()
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestYaegiMiddleware_noHealthHook(t *testing.T) {
+	goPath := t.TempDir()
+	sources := filepath.Join(goPath, goPathSrc, "github.com", "traefik", "plugindemo")
+	require.NoError(t, os.MkdirAll(sources, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(sources, "plugindemo.go"), []byte(yaegiTestPlugin), 0o600))
+
+	manifest := &Manifest{Type: typeMiddleware, Import: "github.com/traefik/plugindemo"}
+
+	builder, err := newMiddlewareBuilder(context.Background(), goPath, manifest, "github.com/traefik/plugindemo", Settings{}, false, nil)
+	require.NoError(t, err)
+
+	middleware, err := builder.newMiddleware(map[string]interface{}{"header": "X-Plugin"}, "test")
+	require.NoError(t, err)
+
+	handler, err := middleware.NewHandler(context.Background(), http.NotFoundHandler())
+	require.NoError(t, err)
+
+	_, ok := handler.(HealthChecker)
+	assert.False(t, ok)
+}
@@ -0,0 +1,421 @@
+package plugins
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const ociScheme = "oci://"
+
+const (
+	mediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// ociReference is a reference to an OCI artifact: <registry>/<repository>[:<tag>|@<digest>].
+type ociReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// isOCISource returns true if the plugin source is an OCI artifact.
+func isOCISource(source string) bool {
+	return strings.HasPrefix(source, ociScheme)
+}
+
+// parseOCIReference parses an OCI artifact reference (e.g. oci://ghcr.io/org/plugin:v1.2.0).
+// When the reference has neither a tag nor a digest, the given version is used as tag.
+func parseOCIReference(source, version string) (ociReference, error) {
+	raw := strings.TrimPrefix(source, ociScheme)
+
+	registry, repository, ok := strings.Cut(raw, "/")
+	if !ok || registry == "" || repository == "" {
+		return ociReference{}, fmt.Errorf("invalid OCI reference %q: missing repository", source)
+	}
+
+	ref := ociReference{Registry: registry}
+
+	if repo, digest, found := strings.Cut(repository, "@"); found {
+		ref.Repository = repo
+		ref.Digest = digest
+		if !strings.HasPrefix(digest, "sha256:") {
+			return ociReference{}, fmt.Errorf("invalid OCI reference %q: unsupported digest algorithm", source)
+		}
+
+		return ref, nil
+	}
+
+	ref.Repository = repository
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		ref.Repository = repository[:i]
+		ref.Tag = repository[i+1:]
+	}
+
+	if ref.Tag == "" {
+		ref.Tag = version
+	}
+
+	if ref.Tag == "" {
+		return ociReference{}, fmt.Errorf("invalid OCI reference %q: missing tag", source)
+	}
+
+	return ref, nil
+}
+
+func (r ociReference) reference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+
+	return r.Tag
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// PullOCI pulls the plugin archive from an OCI registry, verifies its digest, and stores it as a regular plugin archive.
+// The artifact must contain the zip archive of the plugin as single layer (or as a layer with a zip media type).
+func (c *Client) PullOCI(ctx context.Context, pName, pVersion, source string) error {
+	ref, err := parseOCIReference(source, pVersion)
+	if err != nil {
+		return err
+	}
+
+	oci := &ociClient{httpClient: c.HTTPClient, ref: ref}
+
+	manifest, manifestDigest, err := oci.getManifest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get manifest: %w", err)
+	}
+
+	if ref.Digest != "" && manifestDigest != ref.Digest {
+		return fmt.Errorf("manifest digest mismatch: expected %s, got %s", ref.Digest, manifestDigest)
+	}
+
+	layer, err := findArchiveLayer(manifest)
+	if err != nil {
+		return err
+	}
+
+	filename := c.buildArchivePath(pName, pVersion)
+
+	if hash, err := computeHash(filename); err == nil && "sha256:"+hash == layer.Digest {
+		// The archive is already in the store.
+		return nil
+	}
+
+	return oci.downloadBlob(ctx, layer, filename)
+}
+
+func findArchiveLayer(manifest *ociManifest) (ociDescriptor, error) {
+	switch {
+	case len(manifest.Layers) == 0:
+		return ociDescriptor{}, errors.New("no layer found in the OCI artifact")
+
+	case len(manifest.Layers) == 1:
+		return manifest.Layers[0], nil
+	}
+
+	for _, layer := range manifest.Layers {
+		if strings.Contains(layer.MediaType, "zip") {
+			return layer, nil
+		}
+	}
+
+	return ociDescriptor{}, errors.New("unable to find the plugin archive layer in the OCI artifact")
+}
+
+// ociClient is a minimal OCI distribution client.
+type ociClient struct {
+	httpClient *http.Client
+	ref        ociReference
+	token      string
+}
+
+func (o *ociClient) getManifest(ctx context.Context) (*ociManifest, string, error) {
+	endpoint := fmt.Sprintf("https://%s/v2/%s/manifests/%s", o.ref.Registry, o.ref.Repository, o.ref.reference())
+
+	resp, err := o.do(ctx, endpoint, strings.Join([]string{mediaTypeOCIManifest, mediaTypeDockerManifest}, ", "))
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	manifest := &ociManifest{}
+	if err = json.Unmarshal(data, manifest); err != nil {
+		return nil, "", fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	mediaType := manifest.MediaType
+	if mediaType == "" {
+		mediaType = resp.Header.Get("Content-Type")
+	}
+
+	if mediaType != mediaTypeOCIManifest && mediaType != mediaTypeDockerManifest {
+		return nil, "", fmt.Errorf("unsupported manifest media type %q", mediaType)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return manifest, "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+func (o *ociClient) downloadBlob(ctx context.Context, layer ociDescriptor, filename string) error {
+	endpoint := fmt.Sprintf("https://%s/v2/%s/blobs/%s", o.ref.Registry, o.ref.Repository, layer.Digest)
+
+	resp, err := o.do(ctx, endpoint, "")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	err = os.MkdirAll(filepath.Dir(filename), 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file %q: %w", filename, err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(file, hash), resp.Body); err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+
+	if digest := "sha256:" + hex.EncodeToString(hash.Sum(nil)); digest != layer.Digest {
+		_ = os.Remove(filename)
+		return fmt.Errorf("blob digest mismatch: expected %s, got %s", layer.Digest, digest)
+	}
+
+	return nil
+}
+
+// do performs a GET request against the registry, and handles the token authentication flow when challenged.
+func (o *ociClient) do(ctx context.Context, endpoint, accept string) (*http.Response, error) {
+	resp, err := o.get(ctx, endpoint, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized && o.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+
+		o.token, err = o.fetchToken(ctx, challenge)
+		if err != nil {
+			return nil, fmt.Errorf("failed to authenticate: %w", err)
+		}
+
+		resp, err = o.get(ctx, endpoint, accept)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("error: %d: %s", resp.StatusCode, string(data))
+	}
+
+	return resp, nil
+}
+
+func (o *ociClient) get(ctx context.Context, endpoint, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	if o.token != "" {
+		req.Header.Set("Authorization", "Bearer "+o.token)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call registry: %w", err)
+	}
+
+	return resp, nil
+}
+
+// fetchToken gets a registry token according to the Bearer challenge of the registry,
+// using the credentials of the Docker configuration file if any.
+func (o *ociClient) fetchToken(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	values := parseChallengeParams(params)
+
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid authentication realm %q", values["realm"])
+	}
+
+	query := realm.Query()
+	if service, ok := values["service"]; ok {
+		query.Set("service", service)
+	}
+
+	scope := values["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", o.ref.Repository)
+	}
+	query.Set("scope", scope)
+
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if auth := dockerCredentials(o.ref.Registry); auth != "" {
+		req.Header.Set("Authorization", "Basic "+auth)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call authentication service: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("error: %d: %s", resp.StatusCode, string(data))
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode token: %w", err)
+	}
+
+	if token.Token != "" {
+		return token.Token, nil
+	}
+
+	return token.AccessToken, nil
+}
+
+func parseChallengeParams(params string) map[string]string {
+	values := make(map[string]string)
+
+	for _, part := range strings.Split(params, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+
+		values[strings.ToLower(key)] = strings.Trim(value, `"`)
+	}
+
+	return values
+}
+
+// dockerCredentials returns the base64 encoded credentials of the registry from the Docker configuration file.
+func dockerCredentials(registry string) string {
+	configDir := os.Getenv("DOCKER_CONFIG")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+
+		configDir = filepath.Join(home, ".docker")
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "config.json"))
+	if err != nil {
+		return ""
+	}
+
+	var config struct {
+		Auths map[string]struct {
+			Auth     string `json:"auth"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"auths"`
+	}
+	if err = json.Unmarshal(data, &config); err != nil {
+		return ""
+	}
+
+	for _, key := range []string{registry, "https://" + registry, "https://" + registry + "/v1/"} {
+		auth, ok := config.Auths[key]
+		if !ok {
+			continue
+		}
+
+		if auth.Auth != "" {
+			return auth.Auth
+		}
+
+		if auth.Username != "" {
+			return base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password))
+		}
+	}
+
+	return ""
+}
+
+func (o *ociClient) listTags(ctx context.Context) ([]string, error) {
+	endpoint := fmt.Sprintf("https://%s/v2/%s/tags/list", o.ref.Registry, o.ref.Repository)
+
+	resp, err := o.do(ctx, endpoint, "")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	var tags struct {
+		Tags []string `json:"tags"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return nil, fmt.Errorf("failed to decode tags: %w", err)
+	}
+
+	return tags.Tags, nil
+}
@@ -0,0 +1,124 @@
+package plugins
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOCIReference(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		source    string
+		version   string
+		expected  ociReference
+		expectErr bool
+	}{
+		{
+			desc:     "with tag",
+			source:   "oci://ghcr.io/org/plugin:v1.2.0",
+			version:  "v1.0.0",
+			expected: ociReference{Registry: "ghcr.io", Repository: "org/plugin", Tag: "v1.2.0"},
+		},
+		{
+			desc:     "version as tag",
+			source:   "oci://ghcr.io/org/plugin",
+			version:  "v1.0.0",
+			expected: ociReference{Registry: "ghcr.io", Repository: "org/plugin", Tag: "v1.0.0"},
+		},
+		{
+			desc:     "registry with port",
+			source:   "oci://localhost:5000/plugin",
+			version:  "v1.0.0",
+			expected: ociReference{Registry: "localhost:5000", Repository: "plugin", Tag: "v1.0.0"},
+		},
+		{
+			desc:     "with digest",
+			source:   "oci://ghcr.io/org/plugin@sha256:abcd",
+			version:  "v1.0.0",
+			expected: ociReference{Registry: "ghcr.io", Repository: "org/plugin", Digest: "sha256:abcd"},
+		},
+		{
+			desc:      "missing repository",
+			source:    "oci://ghcr.io",
+			expectErr: true,
+		},
+		{
+			desc:      "unsupported digest",
+			source:    "oci://ghcr.io/org/plugin@md5:abcd",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ref, err := parseOCIReference(test.source, test.version)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ref)
+		})
+	}
+}
+
+func TestClient_PullOCI(t *testing.T) {
+	archive := []byte("plugin archive")
+	sum := sha256.Sum256(archive)
+	layerDigest := "sha256:" + hex.EncodeToString(sum[:])
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "repository:org/plugin:pull", req.URL.Query().Get("scope"))
+		_ = json.NewEncoder(rw).Encode(map[string]string{"token": "secret"})
+	})
+	mux.HandleFunc("/v2/org/plugin/manifests/v1.0.0", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			rw.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="https://%s/token",service="registry"`, req.Host))
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_ = json.NewEncoder(rw).Encode(ociManifest{
+			MediaType: mediaTypeOCIManifest,
+			Layers:    []ociDescriptor{{MediaType: "application/zip", Digest: layerDigest, Size: int64(len(archive))}},
+		})
+	})
+	mux.HandleFunc("/v2/org/plugin/blobs/"+layerDigest, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = rw.Write(archive)
+	})
+
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+
+	srvURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	client := &Client{HTTPClient: srv.Client(), archives: t.TempDir()}
+
+	err = client.PullOCI(context.Background(), "github.com/org/plugin", "v1.0.0", "oci://"+srvURL.Host+"/org/plugin")
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(client.buildArchivePath("github.com/org/plugin", "v1.0.0"))
+	require.NoError(t, err)
+	assert.Equal(t, archive, content)
+}
@@ -2,8 +2,14 @@ package plugins
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
@@ -19,48 +25,99 @@ func SetupRemotePlugins(client *Client, plugins map[string]Descriptor) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	err = client.CleanArchives(plugins)
-	if err != nil {
-		return fmt.Errorf("unable to clean archives: %w", err)
-	}
-
 	ctx := context.Background()
 
+	client.skipped = nil
+
+	// fallbacks holds the aliases of the plugins whose fallback version is set up instead of the requested one.
+	fallbacks := make(map[string]bool)
+
 	var unavailablePlugins []string
 	for pAlias, desc := range plugins {
-		log.Ctx(ctx).Debug().Msgf("Loading of plugin: %s: %s@%s", pAlias, desc.ModuleName, desc.Version)
+		if !isVersionConstraint(desc.Version) {
+			continue
+		}
 
-		hash, err := client.Download(ctx, desc.ModuleName, desc.Version)
+		version, err := client.ResolveVersion(ctx, desc)
+		if err != nil && desc.FallbackVersion != "" {
+			log.Ctx(ctx).Warn().Err(err).Msgf("Unable to resolve version %q of the plugin %s, falling back to %s", desc.Version, desc.ModuleName, desc.FallbackVersion)
+			version, err = desc.FallbackVersion, nil
+			fallbacks[pAlias] = true
+		}
 		if err != nil {
-			_ = client.ResetAll()
 			if !desc.Required {
-				log.Ctx(ctx).Warn().Msgf("Unable to download plugin %s: %s", desc.ModuleName, err)
+				log.Ctx(ctx).Warn().Msgf("Unable to resolve version %q of the plugin %s: %s", desc.Version, desc.ModuleName, err)
+				client.skipPlugin(pAlias, desc, fmt.Errorf("unable to resolve version %q: %w", desc.Version, err))
 				unavailablePlugins = append(unavailablePlugins, pAlias)
 				continue
 			}
-			return fmt.Errorf("unable to download plugin %s: %w", desc.ModuleName, err)
+			return fmt.Errorf("unable to resolve version %q of the plugin %s: %w", desc.Version, desc.ModuleName, err)
 		}
 
-		err = client.Check(ctx, desc.ModuleName, desc.Version, hash)
+		log.Ctx(ctx).Debug().Msgf("Plugin %s: version %q resolved to %s", desc.ModuleName, desc.Version, version)
+
+		desc.Version = version
+		plugins[pAlias] = desc
+	}
+
+	for _, pAlias := range unavailablePlugins {
+		delete(plugins, pAlias)
+	}
+	unavailablePlugins = nil
+
+	err = client.isolateVersions(plugins)
+	if err != nil {
+		return fmt.Errorf("unable to set up plugins environment: %w", err)
+	}
+
+	for pAlias, desc := range plugins {
+		log.Ctx(ctx).Debug().Msgf("Loading of plugin: %s: %s@%s", pAlias, desc.ModuleName, desc.Version)
+
+		if fallbacks[pAlias] {
+			err = setupFallbackVersion(ctx, client, desc)
+		} else {
+			err = setupRemotePlugin(ctx, client, desc)
+			if err != nil && desc.FallbackVersion != "" && desc.FallbackVersion != desc.Version {
+				log.Ctx(ctx).Warn().Err(err).Msgf("Unable to set up plugin %s, falling back to %s@%s", pAlias, desc.ModuleName, desc.FallbackVersion)
+
+				desc.Version = desc.FallbackVersion
+				err = setupFallbackVersion(ctx, client, desc)
+				if err == nil {
+					plugins[pAlias] = desc
+				}
+			}
+		}
 		if err != nil {
 			_ = client.ResetAll()
 			if !desc.Required {
-				log.Ctx(ctx).Warn().Msgf("Unable to check archive integrity of the plugin %s: %s", desc.ModuleName, err)
+				log.Ctx(ctx).Warn().Err(err).Msgf("Skipping plugin %s", pAlias)
+				client.skipPlugin(pAlias, desc, err)
 				unavailablePlugins = append(unavailablePlugins, pAlias)
 				continue
 			}
-			return fmt.Errorf("unable to check archive integrity of the plugin %s: %w", desc.ModuleName, err)
+			return err
 		}
+	}
+	for _, pAlias := range unavailablePlugins {
+		delete(plugins, pAlias)
+	}
+	unavailablePlugins = nil
+
+	resolver := newDependencyResolver(client, plugins)
+	for _, pAlias := range slices.Sorted(maps.Keys(plugins)) {
+		desc := plugins[pAlias]
 
-		err = client.Unzip(desc.ModuleName, desc.Version)
+		err = resolver.setup(ctx, desc)
 		if err != nil {
-			_ = client.ResetAll()
 			if !desc.Required {
-				log.Ctx(ctx).Warn().Msgf("Unable to unzip archive: %s", err)
+				log.Ctx(ctx).Warn().Err(err).Msgf("Skipping plugin %s", pAlias)
+				client.skipPlugin(pAlias, desc, err)
 				unavailablePlugins = append(unavailablePlugins, pAlias)
 				continue
 			}
-			return fmt.Errorf("unable to unzip archive: %w", err)
+
+			_ = client.ResetAll()
+			return fmt.Errorf("unable to set up the dependencies of the plugin %s: %w", pAlias, err)
 		}
 	}
 	for _, pAlias := range unavailablePlugins {
@@ -73,6 +130,129 @@ func SetupRemotePlugins(client *Client, plugins map[string]Descriptor) error {
 		return fmt.Errorf("unable to write plugins state: %w", err)
 	}
 
+	removed, err := client.CollectGarbage(plugins)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Unable to collect the garbage of the plugins storage")
+	}
+
+	for _, archive := range removed {
+		log.Ctx(ctx).Debug().Msgf("Plugin %s@%s removed from the plugins storage", archive.ModuleName, archive.Version)
+	}
+
+	return nil
+}
+
+func setupRemotePlugin(ctx context.Context, client *Client, desc Descriptor) error {
+	if isGitSource(desc.Source) {
+		err := client.CheckoutGit(ctx, desc.ModuleName, desc.Version, desc.Source)
+		if err != nil {
+			return fmt.Errorf("unable to checkout plugin %s from %s: %w", desc.ModuleName, desc.Source, err)
+		}
+
+		// The sources are directly checked out in the GoPath.
+		return nil
+	}
+
+	err := fetchRemotePlugin(ctx, client, desc)
+	if err != nil {
+		return err
+	}
+
+	err = client.Unzip(desc.ModuleName, desc.Version)
+	if err != nil {
+		return fmt.Errorf("unable to unzip archive: %w", err)
+	}
+
+	return nil
+}
+
+// setupFallbackVersion sets up the fallback version of a plugin, once its requested version could not be set up.
+// As the registry is likely unreachable, an archive already stored is used as is.
+func setupFallbackVersion(ctx context.Context, client *Client, desc Descriptor) error {
+	// The sources of the requested version may have been partially extracted in the same GoPath.
+	err := os.RemoveAll(client.buildSourcesPath(desc.ModuleName, desc.Version))
+	if err != nil {
+		return fmt.Errorf("unable to clean plugin %s sources: %w", desc.ModuleName, err)
+	}
+
+	if isGitSource(desc.Source) || !client.isStored(desc.ModuleName, desc.Version) {
+		return setupRemotePlugin(ctx, client, desc)
+	}
+
+	err = client.Unzip(desc.ModuleName, desc.Version)
+	if err != nil {
+		return fmt.Errorf("unable to unzip archive: %w", err)
+	}
+
+	return nil
+}
+
+// fetchRemotePlugin gets the archive of the plugin in the plugins storage, and checks it.
+func fetchRemotePlugin(ctx context.Context, client *Client, desc Descriptor) error {
+	switch {
+	case desc.LocalArchive != "":
+		err := client.InstallLocalArchive(desc.ModuleName, desc.Version, desc.LocalArchive)
+		if err != nil {
+			return fmt.Errorf("unable to install plugin %s from %s: %w", desc.ModuleName, desc.LocalArchive, err)
+		}
+
+		err = client.checkPinnedArchive(desc)
+		if err != nil {
+			return fmt.Errorf("unable to check archive integrity of the plugin %s: %w", desc.ModuleName, err)
+		}
+
+	case client.isBundled(desc.ModuleName, desc.Version):
+		err := client.installBundled(desc.ModuleName, desc.Version)
+		if err != nil {
+			return fmt.Errorf("unable to install plugin %s from the bundle: %w", desc.ModuleName, err)
+		}
+
+		if desc.Source == "" {
+			err = client.VerifySignature(ctx, desc.ModuleName, desc.Version)
+			if err != nil {
+				return fmt.Errorf("unable to verify archive signature of the plugin %s: %w", desc.ModuleName, err)
+			}
+		}
+
+		err = client.checkPinnedArchive(desc)
+		if err != nil {
+			return fmt.Errorf("unable to check archive integrity of the plugin %s: %w", desc.ModuleName, err)
+		}
+
+	case isOCISource(desc.Source):
+		err := client.PullOCI(ctx, desc.ModuleName, desc.Version, desc.Source)
+		if err != nil {
+			return fmt.Errorf("unable to pull plugin %s from %s: %w", desc.ModuleName, desc.Source, err)
+		}
+
+		err = client.checkPinnedArchive(desc)
+		if err != nil {
+			return fmt.Errorf("unable to check archive integrity of the plugin %s: %w", desc.ModuleName, err)
+		}
+
+	default:
+		hash, share, err := client.downloadArchive(ctx, desc.ModuleName, desc.Version)
+		if err != nil {
+			return fmt.Errorf("unable to download plugin %s: %w", desc.ModuleName, err)
+		}
+
+		err = client.Check(ctx, desc.ModuleName, desc.Version, hash, desc.Hash)
+		if err != nil {
+			return fmt.Errorf("unable to check archive integrity of the plugin %s: %w", desc.ModuleName, err)
+		}
+
+		err = client.VerifySignature(ctx, desc.ModuleName, desc.Version)
+		if err != nil {
+			return fmt.Errorf("unable to verify archive signature of the plugin %s: %w", desc.ModuleName, err)
+		}
+
+		if share {
+			if err = client.shareArchive(ctx, desc.ModuleName, desc.Version); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msgf("Unable to upload plugin %s to the shared storage", desc.ModuleName)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -81,7 +261,8 @@ func checkRemotePluginsConfiguration(plugins map[string]Descriptor) error {
 		return nil
 	}
 
-	uniq := make(map[string]struct{})
+	// The same version of a plugin can be loaded under several aliases, as long as it comes from the same source.
+	sources := make(map[string]string)
 
 	var errs []string
 	for pAlias, descriptor := range plugins {
@@ -98,12 +279,61 @@ func checkRemotePluginsConfiguration(plugins map[string]Descriptor) error {
 			continue
 		}
 
-		if _, ok := uniq[descriptor.ModuleName]; ok {
-			errs = append(errs, fmt.Sprintf("only one version of a plugin is allowed, there is a duplicate of %s", descriptor.ModuleName))
+		if descriptor.Source != "" && !isOCISource(descriptor.Source) && !isGitSource(descriptor.Source) {
+			errs = append(errs, fmt.Sprintf("%s: unsupported plugin source %q", pAlias, descriptor.Source))
+		}
+
+		if descriptor.LocalArchive != "" {
+			if descriptor.Source != "" {
+				errs = append(errs, fmt.Sprintf("%s: a local archive cannot be used along with a source", pAlias))
+			}
+
+			if isVersionConstraint(descriptor.Version) {
+				errs = append(errs, fmt.Sprintf("%s: a local archive requires an exact plugin version", pAlias))
+			}
+
+			if !strings.EqualFold(filepath.Ext(descriptor.LocalArchive), ".zip") {
+				errs = append(errs, fmt.Sprintf("%s: the local archive must be a .zip file", pAlias))
+			}
+		}
+
+		if descriptor.Hash != "" {
+			if isVersionConstraint(descriptor.Version) {
+				errs = append(errs, fmt.Sprintf("%s: a pinned hash requires an exact plugin version", pAlias))
+			}
+
+			if isGitSource(descriptor.Source) {
+				errs = append(errs, fmt.Sprintf("%s: a pinned hash is not supported for plugins from a Git repository", pAlias))
+			}
+
+			if _, err := hex.DecodeString(descriptor.Hash); err != nil || len(descriptor.Hash) != 2*sha256.Size {
+				errs = append(errs, fmt.Sprintf("%s: the pinned hash must be a hex encoded SHA-256 hash", pAlias))
+			}
+		}
+
+		if descriptor.FallbackVersion != "" {
+			if isVersionConstraint(descriptor.FallbackVersion) {
+				errs = append(errs, fmt.Sprintf("%s: the fallback version must be an exact plugin version", pAlias))
+			}
+
+			if descriptor.Hash != "" {
+				errs = append(errs, fmt.Sprintf("%s: a pinned hash is not supported with a fallback version", pAlias))
+			}
+		}
+
+		if descriptor.LogLevel != "" {
+			if _, err := parseLogLevel(descriptor.LogLevel); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", pAlias, err))
+			}
+		}
+
+		key := descriptor.ModuleName + "@" + descriptor.Version
+		if source, ok := sources[key]; ok && source != descriptor.Source {
+			errs = append(errs, fmt.Sprintf("the version %s of the plugin %s must come from a single source", descriptor.Version, descriptor.ModuleName))
 			continue
 		}
 
-		uniq[descriptor.ModuleName] = struct{}{}
+		sources[key] = descriptor.Source
 	}
 
 	if len(errs) > 0 {
@@ -155,20 +385,33 @@ func checkLocalPluginManifest(descriptor LocalDescriptor) error {
 	var errs *multierror.Error
 
 	switch m.Type {
-	case typeMiddleware:
+	case typeMiddleware, typeProvider:
 		if m.Runtime != runtimeYaegi && m.Runtime != runtimeWasm && m.Runtime != "" {
 			errs = multierror.Append(errs, fmt.Errorf("%s: unsupported runtime '%q'", descriptor.ModuleName, m.Runtime))
 		}
 
-	case typeProvider:
-		if m.Runtime != runtimeYaegi && m.Runtime != "" {
-			errs = multierror.Append(errs, fmt.Errorf("%s: unsupported runtime '%q'", descriptor.ModuleName, m.Runtime))
-		}
-
 	default:
 		errs = multierror.Append(errs, fmt.Errorf("%s: unsupported type %q", descriptor.ModuleName, m.Type))
 	}
 
+	if descriptor.Watch && m.Type != typeMiddleware {
+		errs = multierror.Append(errs, fmt.Errorf("%s: watch mode is only supported for middleware plugins", descriptor.ModuleName))
+	}
+
+	if descriptor.CircuitBreaker != nil && m.Type != typeMiddleware {
+		errs = multierror.Append(errs, fmt.Errorf("%s: circuit breaker is only supported for middleware plugins", descriptor.ModuleName))
+	}
+
+	if descriptor.HealthCheck != nil && m.Type != typeMiddleware {
+		errs = multierror.Append(errs, fmt.Errorf("%s: health check is only supported for middleware plugins", descriptor.ModuleName))
+	}
+
+	if descriptor.LogLevel != "" {
+		if _, err := parseLogLevel(descriptor.LogLevel); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%s: %w", descriptor.ModuleName, err))
+		}
+	}
+
 	if m.IsYaegiPlugin() {
 		if m.Import == "" {
 			errs = multierror.Append(errs, fmt.Errorf("%s: missing import", descriptor.ModuleName))
@@ -0,0 +1,157 @@
+package plugins
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// providerMerger resolves the conflicts between the configurations of the provider plugins:
+// when several provider plugins define an element with the same name, only the element of the plugin with the highest priority is kept,
+// the ties being broken by the plugin names.
+type providerMerger struct {
+	mu sync.Mutex
+
+	priorities map[string]int
+	// configurations are the last configurations provided by the plugins, and sent the ones sent once the conflicts resolved.
+	configurations map[string]*dynamic.Configuration
+	sent           map[string]*dynamic.Configuration
+	conflicts      map[string][]string
+}
+
+func newProviderMerger() *providerMerger {
+	return &providerMerger{
+		priorities:     make(map[string]int),
+		configurations: make(map[string]*dynamic.Configuration),
+		sent:           make(map[string]*dynamic.Configuration),
+		conflicts:      make(map[string][]string),
+	}
+}
+
+// register sets the priority of the given provider plugin.
+func (m *providerMerger) register(pName string, priority int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.priorities[pName] = priority
+}
+
+// conflictsOf returns the elements of the configuration of the given plugin overridden by the ones of plugins with a higher priority.
+func (m *providerMerger) conflictsOf(pName string) []string {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.conflicts[pName])
+}
+
+// push records the configuration provided by the given plugin, and sends it once the conflicts resolved,
+// along with the configurations of the other plugins which changed because of it.
+// The lock is held while sending, so that the configurations of a plugin are sent in order.
+func (m *providerMerger) push(pName string, cfg *dynamic.Configuration, configurationChan chan<- dynamic.Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.configurations[pName] = cfg
+
+	resolved, conflicts := m.resolve()
+	m.conflicts = conflicts
+
+	for _, name := range m.ordered() {
+		if name != pName && reflect.DeepEqual(resolved[name], m.sent[name]) {
+			continue
+		}
+
+		m.sent[name] = resolved[name]
+
+		configurationChan <- dynamic.Message{
+			ProviderName:  "plugin-" + name,
+			Configuration: resolved[name].DeepCopy(),
+		}
+	}
+}
+
+// ordered returns the names of the plugins which provided a configuration, the highest priority first.
+func (m *providerMerger) ordered() []string {
+	names := make([]string, 0, len(m.configurations))
+	for name := range m.configurations {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if m.priorities[names[i]] != m.priorities[names[j]] {
+			return m.priorities[names[i]] > m.priorities[names[j]]
+		}
+
+		return names[i] < names[j]
+	})
+
+	return names
+}
+
+func (m *providerMerger) resolve() (map[string]*dynamic.Configuration, map[string][]string) {
+	resolved := make(map[string]*dynamic.Configuration)
+	conflicts := make(map[string][]string)
+
+	owners := make(map[string]string)
+	for _, name := range m.ordered() {
+		cfg := m.configurations[name].DeepCopy()
+		if cfg == nil {
+			cfg = &dynamic.Configuration{}
+		}
+
+		var pConflicts []string
+		if cfg.HTTP != nil {
+			pConflicts = append(pConflicts, dropClaimed(cfg.HTTP.Routers, "HTTP router", name, owners)...)
+			pConflicts = append(pConflicts, dropClaimed(cfg.HTTP.Services, "HTTP service", name, owners)...)
+			pConflicts = append(pConflicts, dropClaimed(cfg.HTTP.Middlewares, "HTTP middleware", name, owners)...)
+			pConflicts = append(pConflicts, dropClaimed(cfg.HTTP.ServersTransports, "HTTP servers transport", name, owners)...)
+		}
+
+		if cfg.TCP != nil {
+			pConflicts = append(pConflicts, dropClaimed(cfg.TCP.Routers, "TCP router", name, owners)...)
+			pConflicts = append(pConflicts, dropClaimed(cfg.TCP.Services, "TCP service", name, owners)...)
+			pConflicts = append(pConflicts, dropClaimed(cfg.TCP.Middlewares, "TCP middleware", name, owners)...)
+			pConflicts = append(pConflicts, dropClaimed(cfg.TCP.ServersTransports, "TCP servers transport", name, owners)...)
+		}
+
+		if cfg.UDP != nil {
+			pConflicts = append(pConflicts, dropClaimed(cfg.UDP.Routers, "UDP router", name, owners)...)
+			pConflicts = append(pConflicts, dropClaimed(cfg.UDP.Services, "UDP service", name, owners)...)
+		}
+
+		resolved[name] = cfg
+		if len(pConflicts) > 0 {
+			sort.Strings(pConflicts)
+			conflicts[name] = pConflicts
+		}
+	}
+
+	return resolved, conflicts
+}
+
+// dropClaimed removes the elements already claimed by a plugin with a higher priority, and claims the other ones for the given plugin.
+func dropClaimed[T any](elements map[string]T, kind, pName string, owners map[string]string) []string {
+	var conflicts []string
+	for name := range elements {
+		key := kind + " " + name
+
+		owner, ok := owners[key]
+		if !ok {
+			owners[key] = pName
+			continue
+		}
+
+		delete(elements, name)
+		conflicts = append(conflicts, fmt.Sprintf("%s overridden by the plugin %s", key, owner))
+	}
+
+	return conflicts
+}
@@ -0,0 +1,112 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func httpConfiguration(routers, services []string) *dynamic.Configuration {
+	cfg := &dynamic.Configuration{HTTP: &dynamic.HTTPConfiguration{
+		Routers:  map[string]*dynamic.Router{},
+		Services: map[string]*dynamic.Service{},
+	}}
+
+	for _, name := range routers {
+		cfg.HTTP.Routers[name] = &dynamic.Router{Rule: "Host(`" + name + "`)"}
+	}
+
+	for _, name := range services {
+		cfg.HTTP.Services[name] = &dynamic.Service{}
+	}
+
+	return cfg
+}
+
+func receiveMessages(t *testing.T, cfgChan chan dynamic.Message) map[string]*dynamic.Configuration {
+	t.Helper()
+
+	messages := make(map[string]*dynamic.Configuration)
+	for {
+		select {
+		case msg := <-cfgChan:
+			messages[msg.ProviderName] = msg.Configuration
+		default:
+			return messages
+		}
+	}
+}
+
+func TestProviderMerger_push(t *testing.T) {
+	merger := newProviderMerger()
+	merger.register("low", 0)
+	merger.register("high", 10)
+	merger.register("other", 0)
+
+	cfgChan := make(chan dynamic.Message, 10)
+
+	merger.push("low", httpConfiguration([]string{"whoami", "low"}, []string{"whoami"}), cfgChan)
+
+	messages := receiveMessages(t, cfgChan)
+	require.Len(t, messages, 1)
+	assert.Len(t, messages["plugin-low"].HTTP.Routers, 2)
+	assert.Empty(t, merger.conflictsOf("low"))
+
+	// The configuration of the plugin with the higher priority overrides the one already sent.
+	merger.push("high", httpConfiguration([]string{"whoami"}, []string{"whoami"}), cfgChan)
+
+	messages = receiveMessages(t, cfgChan)
+	require.Len(t, messages, 2)
+	assert.Contains(t, messages["plugin-high"].HTTP.Routers, "whoami")
+	assert.NotContains(t, messages["plugin-low"].HTTP.Routers, "whoami")
+	assert.Contains(t, messages["plugin-low"].HTTP.Routers, "low")
+	assert.Empty(t, messages["plugin-low"].HTTP.Services)
+
+	assert.Equal(t, []string{
+		"HTTP router whoami overridden by the plugin high",
+		"HTTP service whoami overridden by the plugin high",
+	}, merger.conflictsOf("low"))
+	assert.Empty(t, merger.conflictsOf("high"))
+
+	// The ties are broken by the plugin names, and the unchanged configurations are not sent again.
+	merger.push("other", httpConfiguration([]string{"low"}, nil), cfgChan)
+
+	messages = receiveMessages(t, cfgChan)
+	require.Len(t, messages, 1)
+	assert.Empty(t, messages["plugin-other"].HTTP.Routers)
+	assert.Equal(t, []string{"HTTP router low overridden by the plugin low"}, merger.conflictsOf("other"))
+
+	// The conflicts are gone once the plugin with the higher priority stops defining the elements.
+	merger.push("high", httpConfiguration(nil, nil), cfgChan)
+
+	messages = receiveMessages(t, cfgChan)
+	require.Len(t, messages, 2)
+	assert.Contains(t, messages["plugin-low"].HTTP.Routers, "whoami")
+	assert.Empty(t, merger.conflictsOf("low"))
+}
+
+func TestBuilder_Inventory_conflicts(t *testing.T) {
+	builder, err := NewBuilder(nil, nil, nil)
+	require.NoError(t, err)
+
+	builder.infos["low"] = newPluginInfo("low", "github.com/traefik/pluginlow", "v0.1.0", &Manifest{Type: typeProvider}, false, nil, nil, 0)
+	builder.infos["high"] = newPluginInfo("high", "github.com/traefik/pluginhigh", "v0.1.0", &Manifest{Type: typeProvider}, false, nil, nil, 10)
+
+	builder.merger.register("low", 0)
+	builder.merger.register("high", 10)
+
+	cfgChan := make(chan dynamic.Message, 10)
+	builder.merger.push("low", httpConfiguration([]string{"whoami"}, nil), cfgChan)
+	builder.merger.push("high", httpConfiguration([]string{"whoami"}, nil), cfgChan)
+
+	inventory := builder.Inventory()
+	require.Len(t, inventory, 2)
+
+	assert.Equal(t, 10, inventory[0].Priority)
+	assert.Empty(t, inventory[0].Conflicts)
+
+	assert.Equal(t, 0, inventory[1].Priority)
+	assert.Equal(t, []string{"HTTP router whoami overridden by the plugin high"}, inventory[1].Conflicts)
+}
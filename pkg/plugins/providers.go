@@ -53,7 +53,10 @@ func ppSymbols() map[string]map[string]reflect.Value {
 }
 
 // BuildProvider builds a plugin's provider.
-func (b Builder) BuildProvider(pName string, config map[string]interface{}) (provider.Provider, error) {
+func (b *Builder) BuildProvider(pName string, config map[string]interface{}) (provider.Provider, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
 	if b.providerBuilders == nil {
 		return nil, fmt.Errorf("no plugin definition in the static configuration: %s", pName)
 	}
@@ -63,10 +66,26 @@ func (b Builder) BuildProvider(pName string, config map[string]interface{}) (pro
 		return nil, fmt.Errorf("unknown plugin type: %s", pName)
 	}
 
-	return newProvider(builder, config, "plugin-"+pName)
+	prov, err := builder.newProvider(config, "plugin-"+pName)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.merger != nil {
+		b.merger.register(pName, b.infos[pName].Priority)
+
+		prov.alias = pName
+		prov.merger = b.merger
+	}
+
+	return prov, nil
 }
 
-type providerBuilder struct {
+type providerBuilder interface {
+	newProvider(config map[string]interface{}, providerName string) (*Provider, error)
+}
+
+type yaegiProviderBuilder struct {
 	// Import plugin's import/package
 	Import string `json:"import,omitempty" toml:"import,omitempty" yaml:"import,omitempty"`
 
@@ -80,9 +99,13 @@ type providerBuilder struct {
 type Provider struct {
 	name string
 	pp   PP
+
+	// alias is the name of the plugin, and merger resolves the conflicts with the configurations of the other provider plugins.
+	alias  string
+	merger *providerMerger
 }
 
-func newProvider(builder providerBuilder, config map[string]interface{}, providerName string) (*Provider, error) {
+func (builder yaegiProviderBuilder) newProvider(config map[string]interface{}, providerName string) (*Provider, error) {
 	basePkg := builder.BasePkg
 	if basePkg == "" {
 		basePkg = strings.ReplaceAll(path.Base(builder.Import), "-", "_")
@@ -192,6 +215,11 @@ func (p *Provider) Provide(configurationChan chan<- dynamic.Message, pool *safe.
 					continue
 				}
 
+				if p.merger != nil {
+					p.merger.push(p.alias, cfg, configurationChan)
+					continue
+				}
+
 				configurationChan <- dynamic.Message{
 					ProviderName:  p.name,
 					Configuration: cfg,
@@ -0,0 +1,396 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/traefik/traefik/v3/pkg/logs"
+	"github.com/traefik/traefik/v3/pkg/safe"
+)
+
+// Wasm provider ABI.
+//
+// The guest module is instantiated as a reactor (its "_initialize" function is called if exported),
+// and must export:
+//   - provide() -> i32: runs the provider, until it returns or the provider is stopped.
+//
+// The guest module can export:
+//   - init() -> i32: initializes the provider before it is started.
+//
+// A non-zero result means that the call failed.
+//
+// The "traefik" host module exposes:
+//   - get_config(buf, buf_limit i32) -> i32: writes the JSON provider configuration into the buffer.
+//   - push_config(ptr, len i32): pushes a JSON dynamic configuration.
+//   - http_fetch(ptr, len i32) -> i32: sends the JSON HTTP request, and stores its JSON response.
+//   - http_response(buf, buf_limit i32) -> i32: writes the last stored JSON HTTP response into the buffer.
+//   - log(level, ptr, len i32): logs the message, level being 0 (debug), 1 (info), 2 (warn) or 3 (error).
+//
+// Functions writing into a buffer return the size of the data,
+// and only write it if this size is lower or equal to the buffer limit.
+const (
+	wasmHostModule     = "traefik"
+	wasmProvideFunc    = "provide"
+	wasmInitFunc       = "init"
+	wasmInitializeFunc = "_initialize"
+)
+
+const wasmHTTPTimeout = 30 * time.Second
+
+type wasmHTTPRequest struct {
+	Method  string      `json:"method,omitempty"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    []byte      `json:"body,omitempty"`
+}
+
+type wasmHTTPResponse struct {
+	Status  int         `json:"status,omitempty"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    []byte      `json:"body,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+type wasmProviderBuilder struct {
+	path     string
+	cache    wazero.CompilationCache
+	settings Settings
+	// logger is the logger of the guest output, tagged with the plugin module name, at the plugin log level.
+	logger zerolog.Logger
+}
+
+func newWasmProviderBuilder(ctx context.Context, goPath, moduleName, wasmPath string, settings Settings) (*wasmProviderBuilder, error) {
+	if err := checkWasmPolicy(settings); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(goPath, "src", moduleName, wasmPath)
+	cache := wazero.NewCompilationCache()
+
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading Wasm binary: %w", err)
+	}
+
+	rt := wazero.NewRuntimeWithConfig(ctx, newWasmRuntimeConfig(cache, settings))
+	defer func() { _ = rt.Close(ctx) }()
+
+	guestModule, err := rt.CompileModule(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("compiling guest module: %w", err)
+	}
+
+	if _, ok := guestModule.ExportedFunctions()[wasmProvideFunc]; !ok {
+		return nil, fmt.Errorf("guest module must export the %q function", wasmProvideFunc)
+	}
+
+	return &wasmProviderBuilder{path: path, cache: cache, settings: settings, logger: *log.Ctx(ctx)}, nil
+}
+
+func (b *wasmProviderBuilder) newProvider(config map[string]interface{}, providerName string) (*Provider, error) {
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling config: %w", err)
+	}
+
+	return &Provider{
+		name: providerName,
+		pp: &wasmProvider{
+			builder:    b,
+			name:       providerName,
+			config:     data,
+			httpClient: &http.Client{Timeout: wasmHTTPTimeout, CheckRedirect: b.checkRedirect},
+		},
+	}, nil
+}
+
+// checkRedirect prevents the redirections of the guest requests to the hosts not allowed by the policy.
+func (b *wasmProviderBuilder) checkRedirect(req *http.Request, via []*http.Request) error {
+	if !b.settings.Policy.allowsURL(req.URL) {
+		return fmt.Errorf("redirection to host %q is not allowed by the policy", req.URL.Host)
+	}
+
+	// Same limit as the default policy of the HTTP client.
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+
+	return nil
+}
+
+// wasmProvider is the PP implementation of a Wasm provider.
+type wasmProvider struct {
+	builder    *wasmProviderBuilder
+	name       string
+	config     []byte
+	httpClient *http.Client
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	runtime  wazero.Runtime
+	module   api.Module
+	applyCtx ContextApplier
+
+	mu       sync.Mutex
+	cfgChan  chan<- json.Marshaler
+	response []byte
+}
+
+func (p *wasmProvider) Init() (err error) {
+	code, err := os.ReadFile(p.builder.path)
+	if err != nil {
+		return fmt.Errorf("loading binary: %w", err)
+	}
+
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	defer func() {
+		if err != nil {
+			p.cancel()
+		}
+	}()
+
+	p.runtime = wazero.NewRuntimeWithConfig(p.ctx, newWasmRuntimeConfig(p.builder.cache, p.builder.settings).WithCloseOnContextDone(true))
+	defer func() {
+		if err != nil {
+			_ = p.runtime.Close(context.Background())
+		}
+	}()
+
+	guestModule, err := p.runtime.CompileModule(p.ctx, code)
+	if err != nil {
+		return fmt.Errorf("compiling guest module: %w", err)
+	}
+
+	p.applyCtx, err = InstantiateHost(p.ctx, p.runtime, guestModule, p.builder.settings)
+	if err != nil {
+		return fmt.Errorf("instantiating host module: %w", err)
+	}
+
+	_, err = p.runtime.NewHostModuleBuilder(wasmHostModule).
+		NewFunctionBuilder().WithFunc(p.getConfig).Export("get_config").
+		NewFunctionBuilder().WithFunc(p.pushConfig).Export("push_config").
+		NewFunctionBuilder().WithFunc(p.httpFetch).Export("http_fetch").
+		NewFunctionBuilder().WithFunc(p.httpResponse).Export("http_response").
+		NewFunctionBuilder().WithFunc(p.log).Export("log").
+		Instantiate(p.ctx)
+	if err != nil {
+		return fmt.Errorf("instantiating %s host module: %w", wasmHostModule, err)
+	}
+
+	config, err := newWasmModuleConfig(p.builder.settings)
+	if err != nil {
+		return err
+	}
+
+	p.module, err = p.runtime.InstantiateModule(p.applyCtx(p.ctx), guestModule, config.WithName(p.name).WithStartFunctions(wasmInitializeFunc))
+	if err != nil {
+		return fmt.Errorf("instantiating guest module: %w", err)
+	}
+
+	if fn := p.module.ExportedFunction(wasmInitFunc); fn != nil {
+		if err = callGuest(p.applyCtx(p.ctx), fn); err != nil {
+			return fmt.Errorf("%s: %w", wasmInitFunc, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *wasmProvider) Provide(cfgChan chan<- json.Marshaler) error {
+	p.mu.Lock()
+	p.cfgChan = cfgChan
+	p.mu.Unlock()
+
+	fn := p.module.ExportedFunction(wasmProvideFunc)
+	if fn == nil {
+		return fmt.Errorf("guest module must export the %q function", wasmProvideFunc)
+	}
+
+	safe.Go(func() {
+		err := callGuest(p.applyCtx(p.ctx), fn)
+		if err != nil && p.ctx.Err() == nil {
+			log.Error().Str(logs.ProviderName, p.name).Err(err).Msgf("Error from %s", wasmProvideFunc)
+		}
+	})
+
+	return nil
+}
+
+func (p *wasmProvider) Stop() error {
+	if p.cancel == nil {
+		return nil
+	}
+
+	p.cancel()
+
+	return p.runtime.Close(context.Background())
+}
+
+func (p *wasmProvider) getConfig(_ context.Context, mod api.Module, buf, bufLimit uint32) uint32 {
+	return writeToGuest(mod, buf, bufLimit, p.config)
+}
+
+func (p *wasmProvider) pushConfig(_ context.Context, mod api.Module, ptr, size uint32) {
+	logger := log.With().Str(logs.ProviderName, p.name).Logger()
+
+	data, ok := readFromGuest(mod, ptr, size)
+	if !ok {
+		logger.Error().Msg("Unable to read the pushed configuration from the guest memory")
+		return
+	}
+
+	if !json.Valid(data) {
+		logger.Error().Msg("Invalid pushed configuration")
+		return
+	}
+
+	p.mu.Lock()
+	cfgChan := p.cfgChan
+	p.mu.Unlock()
+
+	if cfgChan == nil {
+		logger.Error().Msgf("Configuration pushed before %s is called", wasmProvideFunc)
+		return
+	}
+
+	select {
+	case <-p.ctx.Done():
+	case cfgChan <- json.RawMessage(data):
+	}
+}
+
+func (p *wasmProvider) httpFetch(_ context.Context, mod api.Module, ptr, size uint32) uint32 {
+	var resp wasmHTTPResponse
+
+	data, ok := readFromGuest(mod, ptr, size)
+	if ok {
+		resp = p.fetch(data)
+	} else {
+		resp = wasmHTTPResponse{Error: "unable to read the request from the guest memory"}
+	}
+
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		raw, _ = json.Marshal(wasmHTTPResponse{Error: err.Error()})
+	}
+
+	p.mu.Lock()
+	p.response = raw
+	p.mu.Unlock()
+
+	return uint32(len(raw))
+}
+
+func (p *wasmProvider) fetch(data []byte) wasmHTTPResponse {
+	var r wasmHTTPRequest
+	if err := json.Unmarshal(data, &r); err != nil {
+		return wasmHTTPResponse{Error: fmt.Sprintf("invalid request: %v", err)}
+	}
+
+	method := r.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequestWithContext(p.ctx, method, r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		return wasmHTTPResponse{Error: err.Error()}
+	}
+
+	if !p.builder.settings.Policy.allowsURL(req.URL) {
+		return wasmHTTPResponse{Error: fmt.Sprintf("host %q is not allowed by the policy", req.URL.Host)}
+	}
+
+	for k, v := range r.Headers {
+		req.Header[http.CanonicalHeaderKey(k)] = v
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return wasmHTTPResponse{Error: err.Error()}
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return wasmHTTPResponse{Error: err.Error()}
+	}
+
+	return wasmHTTPResponse{
+		Status:  resp.StatusCode,
+		Headers: resp.Header,
+		Body:    body,
+	}
+}
+
+func (p *wasmProvider) httpResponse(_ context.Context, mod api.Module, buf, bufLimit uint32) uint32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return writeToGuest(mod, buf, bufLimit, p.response)
+}
+
+func (p *wasmProvider) log(_ context.Context, mod api.Module, level, ptr, size uint32) {
+	msg, ok := readFromGuest(mod, ptr, size)
+	if !ok {
+		return
+	}
+
+	lvl := zerolog.Level(min(level, uint32(zerolog.ErrorLevel)))
+
+	p.builder.logger.WithLevel(lvl).Str(logs.ProviderName, p.name).Msg(string(msg))
+}
+
+func callGuest(ctx context.Context, fn api.Function) error {
+	results, err := fn.Call(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(results) > 0 && api.DecodeI32(results[0]) != 0 {
+		return errors.New("guest returned a non-zero result")
+	}
+
+	return nil
+}
+
+// readFromGuest returns a copy of the guest memory, since the memory view could be changed by the guest.
+func readFromGuest(mod api.Module, ptr, size uint32) ([]byte, bool) {
+	data, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return nil, false
+	}
+
+	return bytes.Clone(data), true
+}
+
+func writeToGuest(mod api.Module, buf, bufLimit uint32, data []byte) uint32 {
+	size := uint32(len(data))
+	if size > bufLimit || size == 0 {
+		return size
+	}
+
+	if !mod.Memory().Write(buf, data) {
+		return 0
+	}
+
+	return size
+}
@@ -0,0 +1,206 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/safe"
+)
+
+func TestWasmProvider(t *testing.T) {
+	goPath := t.TempDir()
+	moduleName := "github.com/traefik/wasmprovider"
+
+	cfg := `{"http":{"services":{"svc":{"loadBalancer":{}}}}}`
+
+	pluginDir := filepath.Join(goPath, "src", moduleName)
+	require.NoError(t, os.MkdirAll(pluginDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "plugin.wasm"), pushConfigModule(cfg), 0o644))
+
+	builder, err := newWasmProviderBuilder(context.Background(), goPath, moduleName, "plugin.wasm", Settings{})
+	require.NoError(t, err)
+
+	prov, err := builder.newProvider(nil, "plugin-test")
+	require.NoError(t, err)
+
+	require.NoError(t, prov.Init())
+
+	pool := safe.NewPool(context.Background())
+	t.Cleanup(pool.Stop)
+
+	cfgChan := make(chan dynamic.Message)
+	require.NoError(t, prov.Provide(cfgChan, pool))
+
+	select {
+	case msg := <-cfgChan:
+		assert.Equal(t, "plugin-test", msg.ProviderName)
+		require.NotNil(t, msg.Configuration.HTTP)
+		assert.Contains(t, msg.Configuration.HTTP.Services, "svc")
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the configuration")
+	}
+}
+
+func TestNewWasmProviderBuilder_missingProvide(t *testing.T) {
+	goPath := t.TempDir()
+	moduleName := "github.com/traefik/wasmprovider"
+
+	pluginDir := filepath.Join(goPath, "src", moduleName)
+	require.NoError(t, os.MkdirAll(pluginDir, 0o755))
+	// Empty module.
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "plugin.wasm"), []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}, 0o644))
+
+	_, err := newWasmProviderBuilder(context.Background(), goPath, moduleName, "plugin.wasm", Settings{})
+	require.Error(t, err)
+}
+
+func TestWasmProvider_fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.Header.Get("X-Test") != "foo" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		rw.Header().Set("X-Test", "bar")
+		_, _ = rw.Write([]byte("pong"))
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		desc     string
+		request  string
+		policy   *Policy
+		expected wasmHTTPResponse
+	}{
+		{
+			desc:    "valid request",
+			request: `{"method":"POST","url":"` + server.URL + `","headers":{"x-test":["foo"]},"body":"cGluZw=="}`,
+			expected: wasmHTTPResponse{
+				Status: http.StatusOK,
+				Body:   []byte("pong"),
+			},
+		},
+		{
+			desc:     "invalid request",
+			request:  `{"url":`,
+			expected: wasmHTTPResponse{Error: "invalid request: unexpected end of JSON input"},
+		},
+		{
+			desc:     "host not allowed by the policy",
+			request:  `{"method":"GET","url":"http://example.com"}`,
+			policy:   &Policy{AllowedHosts: []string{"example.org"}},
+			expected: wasmHTTPResponse{Error: `host "example.com" is not allowed by the policy`},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := &wasmProvider{
+				builder:    &wasmProviderBuilder{settings: Settings{Policy: test.policy}},
+				ctx:        context.Background(),
+				httpClient: http.DefaultClient,
+			}
+
+			resp := p.fetch([]byte(test.request))
+
+			if test.expected.Status != 0 {
+				assert.Equal(t, "bar", resp.Headers.Get("X-Test"))
+			}
+
+			resp.Headers = nil
+			assert.Equal(t, test.expected, resp)
+
+			_, err := json.Marshal(resp)
+			require.NoError(t, err)
+		})
+	}
+}
+
+// pushConfigModule builds a Wasm module exporting a provide function,
+// which pushes the given configuration using the push_config host function.
+func pushConfigModule(cfg string) []byte {
+	section := func(id byte, content ...byte) []byte {
+		return append([]byte{id}, append(uleb128(uint32(len(content))), content...)...)
+	}
+	name := func(s string) []byte {
+		return append(uleb128(uint32(len(s))), s...)
+	}
+
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+	// Types: (i32, i32) -> () and () -> i32.
+	module = append(module, section(0x01, 0x02, 0x60, 0x02, 0x7f, 0x7f, 0x00, 0x60, 0x00, 0x01, 0x7f)...)
+
+	// Imports: traefik.push_config.
+	imports := []byte{0x01}
+	imports = append(imports, name(wasmHostModule)...)
+	imports = append(imports, name("push_config")...)
+	imports = append(imports, 0x00, 0x00)
+	module = append(module, section(0x02, imports...)...)
+
+	// Functions: provide.
+	module = append(module, section(0x03, 0x01, 0x01)...)
+
+	// Memory: 1 page.
+	module = append(module, section(0x05, 0x01, 0x00, 0x01)...)
+
+	// Exports: memory and provide.
+	exports := []byte{0x02}
+	exports = append(exports, name("memory")...)
+	exports = append(exports, 0x02, 0x00)
+	exports = append(exports, name(wasmProvideFunc)...)
+	exports = append(exports, 0x00, 0x01)
+	module = append(module, section(0x07, exports...)...)
+
+	// Code: push_config(0, len(cfg)); return 0.
+	body := []byte{0x00, 0x41, 0x00, 0x41}
+	body = append(body, sleb128(int32(len(cfg)))...)
+	body = append(body, 0x10, 0x00, 0x41, 0x00, 0x0b)
+	module = append(module, section(0x0a, append([]byte{0x01}, append(uleb128(uint32(len(body))), body...)...)...)...)
+
+	// Data: cfg at offset 0.
+	data := []byte{0x01, 0x00, 0x41, 0x00, 0x0b}
+	data = append(data, name(cfg)...)
+	module = append(module, section(0x0b, data...)...)
+
+	return module
+}
+
+func uleb128(v uint32) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			c |= 0x80
+		}
+		b = append(b, c)
+		if v == 0 {
+			return b
+		}
+	}
+}
+
+func sleb128(v int32) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && c&0x40 == 0) || (v == -1 && c&0x40 != 0) {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
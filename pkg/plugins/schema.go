@@ -0,0 +1,190 @@
+package plugins
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	oapierrors "k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// schemaMiddlewareBuilder validates the configuration of the middlewares against the JSON schema of the plugin manifest,
+// before handing it over to the plugin runtime.
+type schemaMiddlewareBuilder struct {
+	middlewareBuilder
+
+	schema *spec.Schema
+}
+
+func newSchemaMiddlewareBuilder(builder middlewareBuilder, rawSchema map[string]interface{}) (*schemaMiddlewareBuilder, error) {
+	schema, err := newConfigSchema(rawSchema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config schema: %w", err)
+	}
+
+	return &schemaMiddlewareBuilder{middlewareBuilder: builder, schema: schema}, nil
+}
+
+func (b *schemaMiddlewareBuilder) newMiddleware(config map[string]interface{}, middlewareName string) (pluginMiddleware, error) {
+	if err := validateConfig(b.schema, config); err != nil {
+		return nil, fmt.Errorf("invalid configuration of the plugin middleware %s: %w", middlewareName, err)
+	}
+
+	return b.middlewareBuilder.newMiddleware(config, middlewareName)
+}
+
+func newConfigSchema(rawSchema map[string]interface{}) (*spec.Schema, error) {
+	if hasSchemaRef(rawSchema) {
+		return nil, errors.New("schema references ($ref) are not supported")
+	}
+
+	data, err := json.Marshal(rawSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &spec.Schema{}
+	if err = json.Unmarshal(data, schema); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+func hasSchemaRef(value interface{}) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if key == "$ref" || hasSchemaRef(val) {
+				return true
+			}
+		}
+
+	case []interface{}:
+		for _, val := range v {
+			if hasSchemaRef(val) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// validateConfig validates the configuration of a middleware against the schema.
+// The string values, as provided by labels, are converted beforehand to the scalar types expected by the schema.
+func validateConfig(schema *spec.Schema, config map[string]interface{}) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	var value interface{} = map[string]interface{}{}
+	if config != nil {
+		if err = json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+	}
+
+	err = validate.AgainstSchema(schema, coerceConfig(schema, value), strfmt.Default)
+	if err == nil {
+		return nil
+	}
+
+	var compositeErr *oapierrors.CompositeError
+	if !errors.As(err, &compositeErr) {
+		return err
+	}
+
+	// The validator reports the configuration as the body of a request, which makes sense only for APIs.
+	var msgs []string
+	for _, e := range compositeErr.Errors {
+		msgs = append(msgs, strings.TrimPrefix(strings.Replace(e.Error(), " in body", "", 1), "."))
+	}
+
+	return errors.New(strings.Join(msgs, ", "))
+}
+
+func coerceConfig(schema *spec.Schema, value interface{}) interface{} {
+	if schema == nil {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if propSchema, ok := schema.Properties[key]; ok {
+				v[key] = coerceConfig(&propSchema, val)
+				continue
+			}
+
+			if schema.AdditionalProperties != nil {
+				v[key] = coerceConfig(schema.AdditionalProperties.Schema, val)
+			}
+		}
+
+		return v
+
+	case []interface{}:
+		if schema.Items == nil {
+			return v
+		}
+
+		for i, val := range v {
+			itemSchema := schema.Items.Schema
+			if len(schema.Items.Schemas) > i {
+				itemSchema = &schema.Items.Schemas[i]
+			}
+
+			v[i] = coerceConfig(itemSchema, val)
+		}
+
+		return v
+
+	case string:
+		return coerceString(schema, v)
+
+	default:
+		return value
+	}
+}
+
+// coerceString converts a string to the scalar type expected by the schema,
+// and leaves it untouched when it cannot be converted so that the validation reports it.
+func coerceString(schema *spec.Schema, value string) interface{} {
+	if len(schema.Type) == 0 || schema.Type.Contains("string") {
+		return value
+	}
+
+	switch {
+	case schema.Type.Contains("integer"):
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i
+		}
+
+	case schema.Type.Contains("number"):
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+
+	case schema.Type.Contains("boolean"):
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+
+	case schema.Type.Contains("array"):
+		var items []interface{}
+		for _, item := range strings.Split(value, ",") {
+			items = append(items, item)
+		}
+
+		return coerceConfig(schema, items)
+	}
+
+	return value
+}
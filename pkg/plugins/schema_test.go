@@ -0,0 +1,169 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMiddlewareBuilder struct {
+	config map[string]interface{}
+}
+
+func (b *fakeMiddlewareBuilder) newMiddleware(config map[string]interface{}, _ string) (pluginMiddleware, error) {
+	b.config = config
+	return nil, nil
+}
+
+func TestSchemaMiddlewareBuilder(t *testing.T) {
+	rawSchema := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"headers"},
+		"properties": map[string]interface{}{
+			"headers": map[string]interface{}{
+				"type": "object",
+				"additionalProperties": map[string]interface{}{
+					"type": "string",
+				},
+			},
+			"maxRetries": map[string]interface{}{
+				"type":    "integer",
+				"minimum": 0,
+			},
+			"ratio": map[string]interface{}{
+				"type": "number",
+			},
+			"enabled": map[string]interface{}{
+				"type": "boolean",
+			},
+			"mode": map[string]interface{}{
+				"type": "string",
+				"enum": []interface{}{"strict", "lenient"},
+			},
+			"codes": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "integer"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc        string
+		config      map[string]interface{}
+		expectedErr string
+	}{
+		{
+			desc: "valid configuration",
+			config: map[string]interface{}{
+				"headers":    map[string]interface{}{"X-Foo": "bar"},
+				"maxRetries": 3,
+				"ratio":      0.5,
+				"enabled":    true,
+				"mode":       "strict",
+				"codes":      []interface{}{500, 502},
+			},
+		},
+		{
+			desc: "valid configuration from labels",
+			config: map[string]interface{}{
+				"headers":    map[string]interface{}{"X-Foo": "42"},
+				"maxRetries": "3",
+				"ratio":      "0.5",
+				"enabled":    "true",
+				"codes":      "500,502",
+			},
+		},
+		{
+			desc:        "missing required property",
+			config:      map[string]interface{}{"maxRetries": 3},
+			expectedErr: "invalid configuration of the plugin middleware test: headers is required",
+		},
+		{
+			desc:        "nil configuration",
+			expectedErr: "invalid configuration of the plugin middleware test: headers is required",
+		},
+		{
+			desc: "invalid type",
+			config: map[string]interface{}{
+				"headers":    map[string]interface{}{},
+				"maxRetries": "three",
+			},
+			expectedErr: `invalid configuration of the plugin middleware test: maxRetries must be of type integer: "string"`,
+		},
+		{
+			desc: "invalid value",
+			config: map[string]interface{}{
+				"headers":    map[string]interface{}{},
+				"maxRetries": -1,
+			},
+			expectedErr: "invalid configuration of the plugin middleware test: maxRetries should be greater than or equal to 0",
+		},
+		{
+			desc: "invalid enum value",
+			config: map[string]interface{}{
+				"headers": map[string]interface{}{},
+				"mode":    "foo",
+			},
+			expectedErr: `invalid configuration of the plugin middleware test: mode should be one of [strict lenient]`,
+		},
+		{
+			desc: "invalid array item",
+			config: map[string]interface{}{
+				"headers": map[string]interface{}{},
+				"codes":   "500,foo",
+			},
+			expectedErr: `invalid configuration of the plugin middleware test: codes[1] must be of type integer: "string"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			runtimeBuilder := &fakeMiddlewareBuilder{}
+
+			builder, err := newSchemaMiddlewareBuilder(runtimeBuilder, rawSchema)
+			require.NoError(t, err)
+
+			_, err = builder.newMiddleware(test.config, "test")
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				assert.Nil(t, runtimeBuilder.config)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.config, runtimeBuilder.config)
+		})
+	}
+}
+
+func TestNewSchemaMiddlewareBuilder_invalidSchema(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		rawSchema map[string]interface{}
+	}{
+		{
+			desc:      "invalid type",
+			rawSchema: map[string]interface{}{"properties": "foo"},
+		},
+		{
+			desc: "schema reference",
+			rawSchema: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"foo": map[string]interface{}{"$ref": "#/definitions/foo"},
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := newSchemaMiddlewareBuilder(&fakeMiddlewareBuilder{}, test.rawSchema)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/rs/zerolog/log"
+)
+
+var errArchiveNotShared = errors.New("plugin archive not found in the shared storage")
+
+// sharedStore is a storage of the plugin archives shared by several Traefik instances,
+// so that each version of a plugin is downloaded only once from the registry.
+type sharedStore interface {
+	// download writes the archive of the plugin to w, or returns errArchiveNotShared.
+	download(ctx context.Context, pName, pVersion string, w io.Writer) error
+	upload(ctx context.Context, pName, pVersion string, r io.ReadSeeker) error
+}
+
+func newSharedStore(storage *Storage, transport http.RoundTripper) (sharedStore, error) {
+	if storage == nil {
+		return nil, nil
+	}
+
+	var count int
+	for _, configured := range []bool{storage.S3 != nil, storage.GCS != nil, storage.Azure != nil} {
+		if configured {
+			count++
+		}
+	}
+
+	if count > 1 {
+		return nil, errors.New("only one of the S3, GCS, and Azure storages can be configured")
+	}
+
+	switch {
+	case storage.S3 != nil:
+		return newS3Store(storage.S3, transport)
+	case storage.GCS != nil:
+		return newGCSStore(storage.GCS, transport)
+	case storage.Azure != nil:
+		return newAzureStore(storage.Azure, transport)
+	default:
+		return nil, nil
+	}
+}
+
+// hydrateArchive downloads the archive of the plugin from the shared storage to the local one.
+// It returns false if the archive is not in the shared storage.
+func (c *Client) hydrateArchive(ctx context.Context, pName, pVersion string) (bool, error) {
+	filename := c.buildArchivePath(pName, pVersion)
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return false, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return false, fmt.Errorf("failed to create file %q: %w", filename, err)
+	}
+
+	err = c.shared.download(ctx, pName, pVersion, file)
+	_ = file.Close()
+	if err != nil {
+		_ = os.Remove(filename)
+
+		if errors.Is(err, errArchiveNotShared) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// shareArchive uploads the archive of the plugin to the shared storage.
+func (c *Client) shareArchive(ctx context.Context, pName, pVersion string) error {
+	if c.shared == nil {
+		return nil
+	}
+
+	file, err := os.Open(c.buildArchivePath(pName, pVersion))
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	return c.shared.upload(ctx, pName, pVersion, file)
+}
+
+// downloadArchive gets the archive of the plugin from the shared storage when it is not in the local one,
+// or downloads it from the registry, and returns its hash.
+// It returns true when the archive has been downloaded from the registry, to be shared once checked.
+func (c *Client) downloadArchive(ctx context.Context, pName, pVersion string) (string, bool, error) {
+	if c.shared == nil {
+		hash, err := c.Download(ctx, pName, pVersion)
+		return hash, false, err
+	}
+
+	filename := c.buildArchivePath(pName, pVersion)
+
+	_, err := os.Stat(filename)
+	stored := err == nil
+
+	if !stored {
+		hydrated, err := c.hydrateArchive(ctx, pName, pVersion)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("Unable to get plugin %s from the shared storage, downloading it from the registry", pName)
+		}
+
+		if hydrated {
+			hash, err := computeHash(filename)
+			if err != nil {
+				return "", false, fmt.Errorf("failed to compute hash: %w", err)
+			}
+
+			return hash, false, nil
+		}
+	}
+
+	hash, err := c.Download(ctx, pName, pVersion)
+	return hash, !stored, err
+}
+
+type s3Store struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+func newS3Store(config *S3Storage, transport http.RoundTripper) (*s3Store, error) {
+	if config.Bucket == "" {
+		return nil, errors.New("the S3 bucket is required")
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 session: %w", err)
+	}
+
+	cfg := aws.NewConfig().
+		WithHTTPClient(&http.Client{Transport: transport}).
+		WithS3ForcePathStyle(config.ForcePathStyle)
+
+	switch {
+	case config.Region != "":
+		cfg.WithRegion(config.Region)
+	case aws.StringValue(sess.Config.Region) == "":
+		// The S3 compatible storages usually ignore the region, which is still required to sign the requests.
+		cfg.WithRegion("us-east-1")
+	}
+
+	if config.Endpoint != "" {
+		cfg.WithEndpoint(config.Endpoint)
+	}
+
+	if config.AccessKeyID != "" {
+		cfg.WithCredentials(credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, ""))
+	}
+
+	return &s3Store{
+		client: s3.New(sess, cfg),
+		bucket: config.Bucket,
+		prefix: config.Prefix,
+	}, nil
+}
+
+func (s *s3Store) download(ctx context.Context, pName, pVersion string, w io.Writer) error {
+	output, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(pName, pVersion)),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return errArchiveNotShared
+		}
+
+		return fmt.Errorf("failed to get archive from S3: %w", err)
+	}
+
+	defer func() { _ = output.Body.Close() }()
+
+	if _, err = io.Copy(w, output.Body); err != nil {
+		return fmt.Errorf("failed to read archive from S3: %w", err)
+	}
+
+	return nil
+}
+
+func (s *s3Store) upload(ctx context.Context, pName, pVersion string, r io.ReadSeeker) error {
+	_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(pName, pVersion)),
+		Body:   r,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put archive to S3: %w", err)
+	}
+
+	return nil
+}
+
+func (s *s3Store) key(pName, pVersion string) string {
+	return archiveKey(s.prefix, pName, pVersion)
+}
+
+// archiveKey returns the name of the archive of the plugin in the shared storage.
+func archiveKey(prefix, pName, pVersion string) string {
+	return path.Join(prefix, pName, pVersion+".zip")
+}
@@ -0,0 +1,100 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+)
+
+// azureStore stores the plugin archives in an Azure Blob Storage container.
+type azureStore struct {
+	client    *azblob.Client
+	container string
+	prefix    string
+}
+
+func newAzureStore(config *AzureStorage, transport http.RoundTripper) (*azureStore, error) {
+	if config.Container == "" {
+		return nil, errors.New("the Azure container is required")
+	}
+
+	if config.AccountName == "" && (config.Endpoint == "" || config.AccountKey != "") {
+		return nil, errors.New("the Azure account name is required")
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net/", config.AccountName)
+	}
+
+	clientOptions := azcore.ClientOptions{Transport: &http.Client{Transport: transport}}
+
+	var client *azblob.Client
+	if config.AccountKey != "" {
+		cred, err := azblob.NewSharedKeyCredential(config.AccountName, config.AccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Azure account key: %w", err)
+		}
+
+		client, err = azblob.NewClientWithSharedKeyCredential(endpoint, cred, &azblob.ClientOptions{ClientOptions: clientOptions})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Azure Blob Storage client: %w", err)
+		}
+	} else {
+		cred, err := azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{ClientOptions: clientOptions})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Azure credentials: %w", err)
+		}
+
+		client, err = azblob.NewClient(endpoint, cred, &azblob.ClientOptions{ClientOptions: clientOptions})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Azure Blob Storage client: %w", err)
+		}
+	}
+
+	return &azureStore{
+		client:    client,
+		container: config.Container,
+		prefix:    config.Prefix,
+	}, nil
+}
+
+func (s *azureStore) download(ctx context.Context, pName, pVersion string, w io.Writer) error {
+	resp, err := s.client.DownloadStream(ctx, s.container, archiveKey(s.prefix, pName, pVersion), nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return errArchiveNotShared
+		}
+
+		return fmt.Errorf("failed to get archive from Azure: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if _, err = io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to read archive from Azure: %w", err)
+	}
+
+	return nil
+}
+
+func (s *azureStore) upload(ctx context.Context, pName, pVersion string, r io.ReadSeeker) error {
+	blob := s.client.ServiceClient().
+		NewContainerClient(s.container).
+		NewBlockBlobClient(archiveKey(s.prefix, pName, pVersion))
+
+	// The archives are small enough to be uploaded in a single request.
+	if _, err := blob.Upload(ctx, streaming.NopCloser(r), nil); err != nil {
+		return fmt.Errorf("failed to put archive to Azure: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	gcsDefaultEndpoint = "https://storage.googleapis.com"
+	gcsScope           = "https://www.googleapis.com/auth/devstorage.read_write"
+)
+
+// gcsStore stores the plugin archives in a Google Cloud Storage bucket, through its JSON API.
+type gcsStore struct {
+	client   *http.Client
+	endpoint string
+	bucket   string
+	prefix   string
+}
+
+func newGCSStore(config *GCSStorage, transport http.RoundTripper) (*gcsStore, error) {
+	if config.Bucket == "" {
+		return nil, errors.New("the GCS bucket is required")
+	}
+
+	endpoint := strings.TrimSuffix(config.Endpoint, "/")
+	if endpoint == "" {
+		endpoint = gcsDefaultEndpoint
+	}
+
+	client := &http.Client{Transport: transport}
+
+	// The requests to another endpoint, such as an emulator, are only authenticated with explicit credentials.
+	if endpoint == gcsDefaultEndpoint || config.CredentialsFile != "" {
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+
+		var creds *google.Credentials
+		var err error
+		if config.CredentialsFile != "" {
+			var data []byte
+			data, err = os.ReadFile(config.CredentialsFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read GCS credentials file: %w", err)
+			}
+
+			creds, err = google.CredentialsFromJSON(ctx, data, gcsScope)
+		} else {
+			creds, err = google.FindDefaultCredentials(ctx, gcsScope)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GCS credentials: %w", err)
+		}
+
+		client = &http.Client{
+			Transport: &oauth2.Transport{Source: creds.TokenSource, Base: transport},
+		}
+	}
+
+	return &gcsStore{
+		client:   client,
+		endpoint: endpoint,
+		bucket:   config.Bucket,
+		prefix:   config.Prefix,
+	}, nil
+}
+
+func (s *gcsStore) download(ctx context.Context, pName, pVersion string, w io.Writer) error {
+	objectURL := fmt.Sprintf("%s/storage/v1/b/%s/o/%s?alt=media",
+		s.endpoint, url.PathEscape(s.bucket), url.PathEscape(archiveKey(s.prefix, pName, pVersion)))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, objectURL, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get archive from GCS: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return errArchiveNotShared
+	default:
+		return fmt.Errorf("failed to get archive from GCS: %s", resp.Status)
+	}
+
+	if _, err = io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to read archive from GCS: %w", err)
+	}
+
+	return nil
+}
+
+func (s *gcsStore) upload(ctx context.Context, pName, pVersion string, r io.ReadSeeker) error {
+	uploadURL := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s",
+		s.endpoint, url.PathEscape(s.bucket), url.QueryEscape(archiveKey(s.prefix, pName, pVersion)))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, r)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/zip")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to put archive to GCS: %w", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to put archive to GCS: %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package plugins
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeObjectStorage simulates the storage APIs of S3, Google Cloud Storage, and Azure Blob Storage,
+// storing the objects by path.
+type fakeObjectStorage struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeObjectStorage) get(rw http.ResponseWriter, name string, notFound func(rw http.ResponseWriter)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	object, ok := f.objects[name]
+	if !ok {
+		notFound(rw)
+		return
+	}
+
+	_, _ = rw.Write(object)
+}
+
+func (f *fakeObjectStorage) put(rw http.ResponseWriter, req *http.Request, name string, statusCode int) {
+	object, err := io.ReadAll(req.Body)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	f.mu.Lock()
+	f.objects[name] = object
+	f.mu.Unlock()
+
+	rw.WriteHeader(statusCode)
+}
+
+func (f *fakeObjectStorage) s3(rw http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		f.get(rw, req.URL.Path, func(rw http.ResponseWriter) {
+			rw.Header().Set("Content-Type", "application/xml")
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+		})
+
+	case http.MethodPut:
+		f.put(rw, req, req.URL.Path, http.StatusOK)
+
+	default:
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeObjectStorage) gcs(rw http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.Method == http.MethodGet && strings.HasPrefix(req.URL.Path, "/storage/v1/b/") && req.URL.Query().Get("alt") == "media":
+		bucket, name, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/storage/v1/b/"), "/o/")
+		f.get(rw, "/"+bucket+"/"+name, func(rw http.ResponseWriter) {
+			rw.WriteHeader(http.StatusNotFound)
+		})
+
+	case req.Method == http.MethodPost && strings.HasPrefix(req.URL.Path, "/upload/storage/v1/b/") && req.URL.Query().Get("uploadType") == "media":
+		bucket := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/upload/storage/v1/b/"), "/o")
+		f.put(rw, req, "/"+bucket+"/"+req.URL.Query().Get("name"), http.StatusOK)
+
+	default:
+		rw.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (f *fakeObjectStorage) azure(rw http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Authorization") == "" {
+		rw.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		f.get(rw, req.URL.Path, func(rw http.ResponseWriter) {
+			rw.Header().Set("x-ms-error-code", "BlobNotFound")
+			rw.WriteHeader(http.StatusNotFound)
+		})
+
+	case http.MethodPut:
+		f.put(rw, req, req.URL.Path, http.StatusCreated)
+
+	default:
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestClient_sharedStorage(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		handler      func(f *fakeObjectStorage) http.HandlerFunc
+		storage      func(endpoint string) *Storage
+		expectedPath string
+	}{
+		{
+			desc:    "S3",
+			handler: func(f *fakeObjectStorage) http.HandlerFunc { return f.s3 },
+			storage: func(endpoint string) *Storage {
+				return &Storage{
+					S3: &S3Storage{
+						Bucket:          "plugins",
+						Prefix:          "traefik",
+						Endpoint:        endpoint,
+						ForcePathStyle:  true,
+						AccessKeyID:     "id",
+						SecretAccessKey: "secret",
+					},
+				}
+			},
+			expectedPath: "/plugins/traefik/github.com/traefik/plugindemo/v0.1.0.zip",
+		},
+		{
+			desc:    "GCS",
+			handler: func(f *fakeObjectStorage) http.HandlerFunc { return f.gcs },
+			storage: func(endpoint string) *Storage {
+				return &Storage{
+					GCS: &GCSStorage{
+						Bucket:   "plugins",
+						Prefix:   "traefik",
+						Endpoint: endpoint,
+					},
+				}
+			},
+			expectedPath: "/plugins/traefik/github.com/traefik/plugindemo/v0.1.0.zip",
+		},
+		{
+			desc:    "Azure",
+			handler: func(f *fakeObjectStorage) http.HandlerFunc { return f.azure },
+			storage: func(endpoint string) *Storage {
+				return &Storage{
+					Azure: &AzureStorage{
+						AccountName: "account",
+						AccountKey:  "a2V5",
+						Container:   "plugins",
+						Prefix:      "traefik",
+						Endpoint:    endpoint + "/account",
+					},
+				}
+			},
+			expectedPath: "/account/plugins/traefik/github.com/traefik/plugindemo/v0.1.0.zip",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var downloads atomic.Int32
+			registry := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/download/github.com/traefik/plugindemo/v0.1.0" {
+					rw.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				downloads.Add(1)
+				_, _ = rw.Write([]byte("archive"))
+			}))
+			t.Cleanup(registry.Close)
+
+			storage := &fakeObjectStorage{objects: make(map[string][]byte)}
+			storageServer := httptest.NewServer(test.handler(storage))
+			t.Cleanup(storageServer.Close)
+
+			opts := ClientOptions{
+				RegistryURL: registry.URL,
+				Retry:       &RegistryRetry{},
+				Storage:     test.storage(storageServer.URL),
+			}
+
+			ctx := context.Background()
+
+			opts.Output = t.TempDir()
+			client, err := NewClient(opts)
+			require.NoError(t, err)
+
+			hash, share, err := client.downloadArchive(ctx, "github.com/traefik/plugindemo", "v0.1.0")
+			require.NoError(t, err)
+			assert.True(t, share)
+
+			err = client.shareArchive(ctx, "github.com/traefik/plugindemo", "v0.1.0")
+			require.NoError(t, err)
+
+			assert.Equal(t, []byte("archive"), storage.objects[test.expectedPath])
+
+			opts.Output = t.TempDir()
+			otherClient, err := NewClient(opts)
+			require.NoError(t, err)
+
+			otherHash, share, err := otherClient.downloadArchive(ctx, "github.com/traefik/plugindemo", "v0.1.0")
+			require.NoError(t, err)
+			assert.False(t, share)
+
+			assert.Equal(t, hash, otherHash)
+			assert.Equal(t, int32(1), downloads.Load())
+		})
+	}
+}
+
+func Test_newSharedStore_several(t *testing.T) {
+	_, err := newSharedStore(&Storage{
+		S3:    &S3Storage{Bucket: "plugins"},
+		Azure: &AzureStorage{AccountName: "account", Container: "plugins"},
+	}, http.DefaultTransport)
+	require.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package plugins
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// errUnsignedArchive is returned when the registry does not provide a signature for a plugin archive.
+var errUnsignedArchive = errors.New("plugin archive is not signed")
+
+// VerifySignature verifies the signature of the plugin archive against the configured public key.
+// The signature is expected to be a base64 encoded signature of the archive, as produced by "cosign sign-blob".
+func (c *Client) VerifySignature(ctx context.Context, pName, pVersion string) error {
+	if c.publicKey == nil {
+		return nil
+	}
+
+	signature, err := c.getSignature(ctx, pName, pVersion)
+	if errors.Is(err, errUnsignedArchive) && !c.requireSignature {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	archive, err := os.ReadFile(c.buildArchivePath(pName, pVersion))
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	return verifySignature(c.publicKey, archive, signature)
+}
+
+// getSignature returns the signature of the plugin archive, from the installed bundle if the plugin is bundled.
+func (c *Client) getSignature(ctx context.Context, pName, pVersion string) ([]byte, error) {
+	if !c.isBundled(pName, pVersion) {
+		return c.downloadSignature(ctx, pName, pVersion)
+	}
+
+	if plugin, _ := c.bundledPlugin(pName, pVersion); len(plugin.Signature) > 0 {
+		return plugin.Signature, nil
+	}
+
+	return nil, errUnsignedArchive
+}
+
+func (c *Client) downloadSignature(ctx context.Context, pName, pVersion string) ([]byte, error) {
+	resp, err := c.callRegistry(ctx, "", "signature", pName, pVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signature: %w", err)
+		}
+
+		signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode signature: %w", err)
+		}
+
+		return signature, nil
+
+	case http.StatusNotFound:
+		return nil, errUnsignedArchive
+
+	default:
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error: %d: %s", resp.StatusCode, string(data))
+	}
+}
+
+func parsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type: %T", key)
+	}
+}
+
+func verifySignature(publicKey crypto.PublicKey, data, signature []byte) error {
+	digest := sha256.Sum256(data)
+
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("invalid plugin archive signature")
+		}
+
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return errors.New("invalid plugin archive signature")
+		}
+
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("invalid plugin archive signature: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("unsupported public key type: %T", publicKey)
+	}
+
+	return nil
+}
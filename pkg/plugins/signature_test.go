@@ -0,0 +1,106 @@
+package plugins
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	data := []byte("plugin archive")
+	digest := sha256.Sum256(data)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecdsaSig, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, digest[:])
+	require.NoError(t, err)
+
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edSig := ed25519.Sign(edKey, data)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaSig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc      string
+		publicKey crypto.PublicKey
+		data      []byte
+		signature []byte
+		expectErr bool
+	}{
+		{
+			desc:      "ECDSA",
+			publicKey: &ecdsaKey.PublicKey,
+			data:      data,
+			signature: ecdsaSig,
+		},
+		{
+			desc:      "Ed25519",
+			publicKey: edPub,
+			data:      data,
+			signature: edSig,
+		},
+		{
+			desc:      "RSA",
+			publicKey: &rsaKey.PublicKey,
+			data:      data,
+			signature: rsaSig,
+		},
+		{
+			desc:      "tampered archive",
+			publicKey: &ecdsaKey.PublicKey,
+			data:      []byte("tampered archive"),
+			signature: ecdsaSig,
+			expectErr: true,
+		},
+		{
+			desc:      "signature from another key",
+			publicKey: &ecdsaKey.PublicKey,
+			data:      data,
+			signature: edSig,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := verifySignature(test.publicKey, test.data, test.signature)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	publicKey, err := parsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(t, err)
+	assert.Equal(t, &key.PublicKey, publicKey)
+
+	_, err = parsePublicKey([]byte("not a key"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,291 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Status of the plugins in the plugins storage.
+const (
+	StorageStored    = "stored"
+	StorageMissing   = "missing"
+	StorageNotStored = "not stored"
+	StorageUnused    = "unused"
+)
+
+// StoredPlugin describes a plugin of the static configuration, or an unused archive, of the plugins storage.
+type StoredPlugin struct {
+	// Name is the name of the plugin in the static configuration, empty for the unused archives.
+	Name       string
+	ModuleName string
+	Version    string
+	Status     string
+}
+
+// Archive describes a plugin archive of the plugins storage.
+type Archive struct {
+	ModuleName string
+	Version    string
+}
+
+// Archives returns the plugin archives of the plugins storage, sorted by module name and version.
+func (c *Client) Archives() ([]Archive, error) {
+	var archives []Archive
+
+	err := filepath.WalkDir(c.archives, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// The Git checkouts are not archives.
+		if d.IsDir() && commitSHA.MatchString(d.Name()) {
+			return filepath.SkipDir
+		}
+
+		if d.IsDir() || filepath.Ext(path) != ".zip" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(c.archives, path)
+		if err != nil {
+			return err
+		}
+
+		archives = append(archives, Archive{
+			ModuleName: filepath.ToSlash(filepath.Dir(rel)),
+			Version:    strings.TrimSuffix(filepath.Base(rel), ".zip"),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archives: %w", err)
+	}
+
+	sort.Slice(archives, func(i, j int) bool {
+		if archives[i].ModuleName != archives[j].ModuleName {
+			return archives[i].ModuleName < archives[j].ModuleName
+		}
+
+		return archives[i].Version < archives[j].Version
+	})
+
+	return archives, nil
+}
+
+// List returns the storage status of the given plugins sorted by name,
+// followed by the archives of the plugins storage which are not used by any of them.
+func (c *Client) List(plugins map[string]Descriptor) ([]StoredPlugin, error) {
+	archives, err := c.Archives()
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[Archive]bool)
+	for _, archive := range archives {
+		used[archive] = false
+	}
+
+	var list []StoredPlugin
+	for pAlias, desc := range plugins {
+		plugin := StoredPlugin{Name: pAlias, ModuleName: desc.ModuleName, Version: desc.Version, Status: StorageMissing}
+
+		if isGitSource(desc.Source) {
+			plugin.Status = StorageNotStored
+			list = append(list, plugin)
+			continue
+		}
+
+		if version, err := c.storedVersion(desc); err == nil {
+			plugin.Version = version
+		}
+
+		archive := Archive{ModuleName: desc.ModuleName, Version: plugin.Version}
+		if _, ok := used[archive]; ok {
+			plugin.Status = StorageStored
+			used[archive] = true
+		}
+
+		list = append(list, plugin)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	for _, archive := range archives {
+		if !used[archive] {
+			list = append(list, StoredPlugin{ModuleName: archive.ModuleName, Version: archive.Version, Status: StorageUnused})
+		}
+	}
+
+	return list, nil
+}
+
+// Verify checks that the archive of the plugin is in the plugins storage,
+// and verifies its integrity and signature against the registry, or against the installed bundle.
+// The plugins checked out from a Git repository are not stored, and cannot be verified.
+func (c *Client) Verify(ctx context.Context, desc Descriptor) error {
+	if isGitSource(desc.Source) {
+		return errors.New("plugins from a Git repository are not stored")
+	}
+
+	version, err := c.storedVersion(desc)
+	if err != nil {
+		return err
+	}
+
+	filename := c.buildArchivePath(desc.ModuleName, version)
+	if _, err = os.Stat(filename); os.IsNotExist(err) {
+		return fmt.Errorf("archive of version %s not found in the plugins storage", version)
+	}
+
+	hash, err := computeHash(filename)
+	if err != nil {
+		return fmt.Errorf("failed to compute hash of %s: %w", filename, err)
+	}
+
+	// The pinned hash is checked whatever the origin of the archive.
+	if err = checkPinnedHash(hash, desc.Hash); err != nil {
+		return err
+	}
+
+	if desc.LocalArchive != "" {
+		localHash, err := computeHash(desc.LocalArchive)
+		if err != nil {
+			return fmt.Errorf("failed to compute hash of %s: %w", desc.LocalArchive, err)
+		}
+
+		if hash != localHash {
+			return fmt.Errorf("archive hash mismatch with %s", desc.LocalArchive)
+		}
+
+		// The local archives are not known by the registry.
+		return nil
+	}
+
+	if plugin, ok := c.bundledPlugin(desc.ModuleName, version); ok {
+		if hash != plugin.Hash {
+			return errors.New("archive hash mismatch")
+		}
+	} else if desc.Source == "" {
+		if err = c.Check(ctx, desc.ModuleName, version, hash, desc.Hash); err != nil {
+			return err
+		}
+	}
+
+	if desc.Source != "" {
+		// The integrity of the OCI artifacts is checked against their digest when they are pulled.
+		return nil
+	}
+
+	return c.VerifySignature(ctx, desc.ModuleName, version)
+}
+
+// Prune removes the archives of the plugins storage which are not used by the given plugins,
+// and returns the removed archives.
+func (c *Client) Prune(plugins map[string]Descriptor) ([]Archive, error) {
+	installed, err := c.readState()
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(pluginsState)
+	for _, desc := range plugins {
+		if desc.FallbackVersion != "" {
+			used.add(desc.ModuleName, desc.FallbackVersion)
+		}
+
+		version, err := installedVersion(installed, desc)
+		if err != nil {
+			continue
+		}
+
+		used.add(desc.ModuleName, version)
+	}
+
+	archives, err := c.Archives()
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []Archive
+	for _, archive := range archives {
+		if used.has(archive.ModuleName, archive.Version) {
+			continue
+		}
+
+		filename := c.buildArchivePath(archive.ModuleName, archive.Version)
+		if err = os.Remove(filename); err != nil {
+			return removed, fmt.Errorf("failed to remove archive %s: %w", filename, err)
+		}
+
+		removeEmptyDirs(c.archives, filepath.Dir(filename))
+
+		removed = append(removed, archive)
+	}
+
+	state := make(pluginsState)
+	for moduleName, versions := range installed {
+		for _, version := range versions {
+			if used.has(moduleName, version) {
+				state.add(moduleName, version)
+			}
+		}
+	}
+
+	return removed, c.writeState(state)
+}
+
+// storedVersion returns the version of the plugin in the plugins storage,
+// which is the version installed by the last run for a version constraint.
+func (c *Client) storedVersion(desc Descriptor) (string, error) {
+	if !isVersionConstraint(desc.Version) {
+		return desc.Version, nil
+	}
+
+	installed, err := c.readState()
+	if err != nil {
+		return "", err
+	}
+
+	return installedVersion(installed, desc)
+}
+
+// installedVersion returns the version of the plugin, or the latest installed version matching its version constraint.
+func installedVersion(installed pluginsState, desc Descriptor) (string, error) {
+	if !isVersionConstraint(desc.Version) {
+		return desc.Version, nil
+	}
+
+	constraints, err := semver.NewConstraint(desc.Version)
+	if err != nil {
+		return "", fmt.Errorf("invalid version constraint %q: %w", desc.Version, err)
+	}
+
+	version, err := latestMatchingVersion(constraints, installed[desc.ModuleName])
+	if err != nil {
+		return "", fmt.Errorf("no version installed for the constraint %q", desc.Version)
+	}
+
+	return version, nil
+}
+
+// removeEmptyDirs removes dir and its parents while they are empty, up to root (excluded).
+func removeEmptyDirs(root, dir string) {
+	for dir != root && strings.HasPrefix(dir, root) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+
+		dir = filepath.Dir(dir)
+	}
+}
@@ -0,0 +1,176 @@
+package plugins
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStoreTestClient(t *testing.T, registryURL string, archives ...Archive) *Client {
+	t.Helper()
+
+	client, err := NewClient(ClientOptions{Output: t.TempDir(), RegistryURL: registryURL})
+	require.NoError(t, err)
+
+	for _, archive := range archives {
+		filename := client.buildArchivePath(archive.ModuleName, archive.Version)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0o755))
+		require.NoError(t, os.WriteFile(filename, []byte(archive.ModuleName+archive.Version), 0o600))
+	}
+
+	return client
+}
+
+func TestClient_List(t *testing.T) {
+	client := newStoreTestClient(t, "",
+		Archive{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0"},
+		Archive{ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0"},
+		Archive{ModuleName: "github.com/traefik/pluginoci", Version: "v1.2.0"},
+	)
+
+	require.NoError(t, client.WriteState(map[string]Descriptor{
+		"oci": {ModuleName: "github.com/traefik/pluginoci", Version: "v1.2.0"},
+	}))
+
+	list, err := client.List(map[string]Descriptor{
+		"demo":    {ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0"},
+		"oci":     {ModuleName: "github.com/traefik/pluginoci", Version: "~1.2", Source: "oci://ghcr.io/traefik/pluginoci"},
+		"git":     {ModuleName: "github.com/traefik/plugingit", Version: "v1.0.0", Source: "git+https://github.com/traefik/plugingit"},
+		"missing": {ModuleName: "github.com/traefik/pluginmissing", Version: "v1.0.0"},
+	})
+	require.NoError(t, err)
+
+	expected := []StoredPlugin{
+		{Name: "demo", ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0", Status: StorageStored},
+		{Name: "git", ModuleName: "github.com/traefik/plugingit", Version: "v1.0.0", Status: StorageNotStored},
+		{Name: "missing", ModuleName: "github.com/traefik/pluginmissing", Version: "v1.0.0", Status: StorageMissing},
+		{Name: "oci", ModuleName: "github.com/traefik/pluginoci", Version: "v1.2.0", Status: StorageStored},
+		{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0", Status: StorageUnused},
+	}
+	assert.Equal(t, expected, list)
+}
+
+func TestClient_Prune(t *testing.T) {
+	client := newStoreTestClient(t, "",
+		Archive{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0"},
+		Archive{ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0"},
+		Archive{ModuleName: "github.com/traefik/pluginold", Version: "v1.0.0"},
+		Archive{ModuleName: "github.com/traefik/pluginoci", Version: "v1.2.0"},
+	)
+
+	require.NoError(t, client.WriteState(map[string]Descriptor{
+		"old": {ModuleName: "github.com/traefik/pluginold", Version: "v1.0.0"},
+		"oci": {ModuleName: "github.com/traefik/pluginoci", Version: "v1.2.0"},
+	}))
+
+	removed, err := client.Prune(map[string]Descriptor{
+		"demo": {ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0"},
+		"oci":  {ModuleName: "github.com/traefik/pluginoci", Version: "~1.2"},
+	})
+	require.NoError(t, err)
+
+	expectedRemoved := []Archive{
+		{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0"},
+		{ModuleName: "github.com/traefik/pluginold", Version: "v1.0.0"},
+	}
+	assert.Equal(t, expectedRemoved, removed)
+
+	archives, err := client.Archives()
+	require.NoError(t, err)
+
+	expectedArchives := []Archive{
+		{ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0"},
+		{ModuleName: "github.com/traefik/pluginoci", Version: "v1.2.0"},
+	}
+	assert.Equal(t, expectedArchives, archives)
+
+	assert.NoDirExists(t, filepath.Join(client.archives, "github.com", "traefik", "pluginold"))
+
+	state, err := client.readState()
+	require.NoError(t, err)
+	assert.Equal(t, pluginsState{"github.com/traefik/pluginoci": {"v1.2.0"}}, state)
+}
+
+func TestClient_Verify(t *testing.T) {
+	registry := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/validate/github.com/traefik/plugindemo/v0.1.0" && req.Header.Get(hashHeader) != "" {
+			rw.WriteHeader(http.StatusOK)
+			return
+		}
+
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(registry.Close)
+
+	client := newStoreTestClient(t, registry.URL,
+		Archive{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0"},
+		Archive{ModuleName: "github.com/traefik/plugintampered", Version: "v0.1.0"},
+	)
+
+	testCases := []struct {
+		desc      string
+		plugin    Descriptor
+		expectErr bool
+	}{
+		{
+			desc:   "valid archive",
+			plugin: Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0"},
+		},
+		{
+			desc:   "matching pinned hash",
+			plugin: Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0", Hash: hashOf("github.com/traefik/plugindemov0.1.0")},
+		},
+		{
+			desc:      "pinned hash mismatch",
+			plugin:    Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "v0.1.0", Hash: hashOf("tampered")},
+			expectErr: true,
+		},
+		{
+			desc:      "integrity check failure",
+			plugin:    Descriptor{ModuleName: "github.com/traefik/plugintampered", Version: "v0.1.0"},
+			expectErr: true,
+		},
+		{
+			desc:      "missing archive",
+			plugin:    Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "v0.2.0"},
+			expectErr: true,
+		},
+		{
+			desc:      "version constraint not installed",
+			plugin:    Descriptor{ModuleName: "github.com/traefik/plugindemo", Version: "~0.1"},
+			expectErr: true,
+		},
+		{
+			desc:      "git source",
+			plugin:    Descriptor{ModuleName: "github.com/traefik/plugingit", Version: "v1.0.0", Source: "git+https://github.com/traefik/plugingit"},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := client.Verify(context.Background(), test.plugin)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func hashOf(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,124 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/rs/zerolog/log"
+)
+
+// isVersionConstraint returns true if the given version is a semver constraint (e.g. "~1.2", "^2.0.0"), rather than an exact version.
+func isVersionConstraint(version string) bool {
+	if _, err := semver.StrictNewVersion(trimVersionPrefix(version)); err == nil {
+		return false
+	}
+
+	_, err := semver.NewConstraint(version)
+	return err == nil
+}
+
+func trimVersionPrefix(version string) string {
+	if len(version) > 0 && version[0] == 'v' {
+		return version[1:]
+	}
+
+	return version
+}
+
+// ResolveVersion resolves the version constraint of the plugin to its latest matching release.
+// The releases of a plugin pulled from an OCI registry or a Git repository are the tags of its repository.
+// The version of the installed bundle is used if it matches the constraint.
+// When the registry cannot be reached, the previously resolved version recorded in the state file is used if it still matches the constraint.
+func (c *Client) ResolveVersion(ctx context.Context, desc Descriptor) (string, error) {
+	pName, constraint := desc.ModuleName, desc.Version
+
+	constraints, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+	}
+
+	if version, ok := c.bundledVersion(pName, constraints); ok {
+		return version, nil
+	}
+
+	var versions []string
+	switch {
+	case isOCISource(desc.Source):
+		versions, err = c.listOCITags(ctx, desc.Source)
+	case isGitSource(desc.Source):
+		versions, err = listGitTags(ctx, desc.Source)
+	default:
+		versions, err = c.listVersions(ctx, pName)
+	}
+	if err != nil {
+		previous, errState := c.readState()
+		if errState == nil {
+			if version, errVersion := latestMatchingVersion(constraints, previous[pName]); errVersion == nil {
+				log.Ctx(ctx).Warn().Err(err).Msgf("Unable to list versions of the plugin %s, using previously resolved version %s", pName, version)
+				return version, nil
+			}
+		}
+
+		return "", fmt.Errorf("unable to list versions: %w", err)
+	}
+
+	return latestMatchingVersion(constraints, versions)
+}
+
+func (c *Client) listOCITags(ctx context.Context, source string) ([]string, error) {
+	ref, err := parseOCIReference(source, "latest")
+	if err != nil {
+		return nil, err
+	}
+
+	oci := &ociClient{httpClient: c.HTTPClient, ref: ref}
+
+	return oci.listTags(ctx)
+}
+
+func (c *Client) listVersions(ctx context.Context, pName string) ([]string, error) {
+	resp, err := c.callRegistry(ctx, "", "versions", pName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error: %d: %s", resp.StatusCode, string(data))
+	}
+
+	var versions []string
+	if err = json.NewDecoder(resp.Body).Decode(&versions); err != nil {
+		return nil, fmt.Errorf("failed to decode versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+func latestMatchingVersion(constraints *semver.Constraints, versions []string) (string, error) {
+	var latest *semver.Version
+	for _, version := range versions {
+		v, err := semver.NewVersion(version)
+		if err != nil {
+			// Not a semver release, cannot match a constraint.
+			continue
+		}
+
+		if constraints.Check(v) && (latest == nil || v.GreaterThan(latest)) {
+			latest = v
+		}
+	}
+
+	if latest == nil {
+		return "", errors.New("no release matches the version constraint")
+	}
+
+	return latest.Original(), nil
+}
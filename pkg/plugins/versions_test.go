@@ -0,0 +1,66 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsVersionConstraint(t *testing.T) {
+	testCases := []struct {
+		version  string
+		expected bool
+	}{
+		{version: "v1.2.3", expected: false},
+		{version: "1.2.3", expected: false},
+		{version: "v0.0.0-20240101000000-abcdef123456", expected: false},
+		{version: "main", expected: false},
+		{version: "~1.2", expected: true},
+		{version: "^2.0.0", expected: true},
+		{version: ">= 1.2, < 2", expected: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.version, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, isVersionConstraint(test.version))
+		})
+	}
+}
+
+func TestLatestMatchingVersion(t *testing.T) {
+	versions := []string{"v1.1.0", "v1.2.0", "v1.2.5", "v1.3.0-rc.1", "v1.3.0", "v2.0.0", "v2.1.0-beta.1", "latest"}
+
+	testCases := []struct {
+		constraint string
+		expected   string
+		expectErr  bool
+	}{
+		{constraint: "~1.2", expected: "v1.2.5"},
+		{constraint: "^1.0.0", expected: "v1.3.0"},
+		{constraint: "^2.0.0", expected: "v2.0.0"},
+		{constraint: ">= 2.1.0-0", expected: "v2.1.0-beta.1"},
+		{constraint: "^3.0.0", expectErr: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.constraint, func(t *testing.T) {
+			t.Parallel()
+
+			constraints, err := semver.NewConstraint(test.constraint)
+			require.NoError(t, err)
+
+			version, err := latestMatchingVersion(constraints, versions)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, version)
+		})
+	}
+}
@@ -5,8 +5,14 @@ package plugins
 import (
 	"context"
 	"fmt"
+	"net/netip"
 	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
 
+	"github.com/stealthrocket/wasi-go"
 	"github.com/stealthrocket/wasi-go/imports"
 	wazergo_wasip1 "github.com/stealthrocket/wasi-go/imports/wasi_snapshot_preview1"
 	"github.com/stealthrocket/wazergo"
@@ -33,6 +39,23 @@ func InstantiateHost(ctx context.Context, runtime wazero.Runtime, mod wazero.Com
 			builder.WithDirs(settings.Mounts...)
 		}
 
+		if policy := settings.Policy; policy != nil {
+			builder.WithWrappers(func(sys wasi.System) wasi.System {
+				return newPolicySystem(sys, policy)
+			})
+
+			if !policy.allowsClock() {
+				monotonic := frozenMonotonic()
+
+				builder.WithRealtimeClock(func(context.Context) (uint64, error) {
+					return uint64(frozenWalltime.UnixNano()), nil
+				}, time.Second)
+				builder.WithMonotonicClock(func(context.Context) (uint64, error) {
+					return monotonic(), nil
+				}, time.Millisecond)
+			}
+		}
+
 		ctx, sys, err := builder.Instantiate(ctx, runtime)
 		if err != nil {
 			return nil, err
@@ -57,3 +80,96 @@ func InstantiateHost(ctx context.Context, runtime wazero.Runtime, mod wazero.Com
 		return ctx
 	}, nil
 }
+
+// policySystem restricts the outbound connections of a guest to the hosts allowed by its policy.
+type policySystem struct {
+	wasi.System
+
+	policy *Policy
+
+	mu sync.Mutex
+	// resolved holds the allowed host names resolved by the guest, by IP address.
+	resolved map[netip.Addr][]string
+}
+
+func newPolicySystem(sys wasi.System, policy *Policy) *policySystem {
+	return &policySystem{
+		System:   sys,
+		policy:   policy,
+		resolved: make(map[netip.Addr][]string),
+	}
+}
+
+func (s *policySystem) SockAddressInfo(ctx context.Context, name, service string, hints wasi.AddressInfo, results []wasi.AddressInfo) (int, wasi.Errno) {
+	if !s.policy.allowsHost(name, "") {
+		return 0, wasi.EACCES
+	}
+
+	n, errno := s.System.SockAddressInfo(ctx, name, service, hints, results)
+	if errno != wasi.ESUCCESS {
+		return n, errno
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, result := range results[:n] {
+		if addr, _, ok := socketAddress(result.Address); ok && !slices.Contains(s.resolved[addr], name) {
+			s.resolved[addr] = append(s.resolved[addr], name)
+		}
+	}
+
+	return n, errno
+}
+
+func (s *policySystem) SockConnect(ctx context.Context, fd wasi.FD, addr wasi.SocketAddress) (wasi.SocketAddress, wasi.Errno) {
+	if !s.allows(addr) {
+		return nil, wasi.EACCES
+	}
+
+	return s.System.SockConnect(ctx, fd, addr)
+}
+
+func (s *policySystem) SockSendTo(ctx context.Context, fd wasi.FD, iovecs []wasi.IOVec, flags wasi.SIFlags, addr wasi.SocketAddress) (wasi.Size, wasi.Errno) {
+	if !s.allows(addr) {
+		return 0, wasi.EACCES
+	}
+
+	return s.System.SockSendTo(ctx, fd, iovecs, flags, addr)
+}
+
+// allows returns whether the guest can reach the given address,
+// either allowed by the policy, or resolved from a host name allowed by the policy.
+func (s *policySystem) allows(sockAddr wasi.SocketAddress) bool {
+	addr, port, ok := socketAddress(sockAddr)
+	if !ok {
+		return false
+	}
+
+	if s.policy.allowsHost(addr.String(), port) {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, name := range s.resolved[addr] {
+		if s.policy.allowsHost(name, port) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// socketAddress returns the IP address and the port of an Internet socket address.
+func socketAddress(sockAddr wasi.SocketAddress) (netip.Addr, string, bool) {
+	switch a := sockAddr.(type) {
+	case *wasi.Inet4Address:
+		return netip.AddrFrom4(a.Addr), strconv.Itoa(a.Port), true
+	case *wasi.Inet6Address:
+		return netip.AddrFrom16(a.Addr).Unmap(), strconv.Itoa(a.Port), true
+	default:
+		return netip.Addr{}, "", false
+	}
+}
@@ -0,0 +1,57 @@
+//go:build linux || darwin
+
+package plugins
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stealthrocket/wasi-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type resolverSystem struct {
+	wasi.System
+
+	addrs map[string]wasi.SocketAddress
+}
+
+func (s resolverSystem) SockAddressInfo(_ context.Context, name, _ string, _ wasi.AddressInfo, results []wasi.AddressInfo) (int, wasi.Errno) {
+	results[0].Address = s.addrs[name]
+	return 1, wasi.ESUCCESS
+}
+
+func (s resolverSystem) SockConnect(context.Context, wasi.FD, wasi.SocketAddress) (wasi.SocketAddress, wasi.Errno) {
+	return &wasi.Inet4Address{}, wasi.ESUCCESS
+}
+
+func TestPolicySystem(t *testing.T) {
+	sys := newPolicySystem(resolverSystem{addrs: map[string]wasi.SocketAddress{
+		"api.example.com":  &wasi.Inet4Address{Addr: [4]byte{192, 0, 2, 1}},
+		"evil.example.com": &wasi.Inet4Address{Addr: [4]byte{192, 0, 2, 2}},
+	}}, &Policy{AllowedHosts: []string{"api.example.com:443", "192.0.2.10"}})
+
+	ctx := context.Background()
+	results := make([]wasi.AddressInfo, 1)
+
+	_, errno := sys.SockAddressInfo(ctx, "evil.example.com", "443", wasi.AddressInfo{}, results)
+	assert.Equal(t, wasi.EACCES, errno)
+
+	_, errno = sys.SockAddressInfo(ctx, "api.example.com", "443", wasi.AddressInfo{}, results)
+	assert.Equal(t, wasi.ESUCCESS, errno)
+
+	_, errno = sys.SockConnect(ctx, 3, &wasi.Inet4Address{Addr: [4]byte{192, 0, 2, 1}, Port: 443})
+	assert.Equal(t, wasi.ESUCCESS, errno)
+
+	_, errno = sys.SockConnect(ctx, 3, &wasi.Inet4Address{Addr: [4]byte{192, 0, 2, 1}, Port: 80})
+	assert.Equal(t, wasi.EACCES, errno)
+
+	_, errno = sys.SockConnect(ctx, 3, &wasi.Inet4Address{Addr: [4]byte{192, 0, 2, 2}, Port: 443})
+	assert.Equal(t, wasi.EACCES, errno)
+
+	_, errno = sys.SockConnect(ctx, 3, &wasi.Inet4Address{Addr: [4]byte{192, 0, 2, 10}, Port: 22})
+	assert.Equal(t, wasi.ESUCCESS, errno)
+
+	_, errno = sys.SockConnect(ctx, 3, &wasi.UnixAddress{Name: "/var/run/docker.sock"})
+	assert.Equal(t, wasi.EACCES, errno)
+}
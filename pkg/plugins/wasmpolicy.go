@@ -0,0 +1,124 @@
+package plugins
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// frozenWalltime is the wall time of the guests without access to the host clock, the same as the wazero default one.
+var frozenWalltime = time.Unix(1640995200, 0)
+
+// checkWasmPolicy checks that the settings of a wasm plugin only use the capabilities granted by its policy.
+func checkWasmPolicy(settings Settings) error {
+	policy := settings.Policy
+	if policy == nil {
+		return nil
+	}
+
+	for _, env := range settings.Envs {
+		if !slices.Contains(policy.Envs, env) {
+			return fmt.Errorf("environment variable %q is not allowed by the policy", env)
+		}
+	}
+
+	for _, mount := range settings.Mounts {
+		if !policy.allowsMount(mount) {
+			return fmt.Errorf("mount %q is not allowed by the policy", mount)
+		}
+	}
+
+	return nil
+}
+
+// allowsMount returns whether the given mount (host directory, optional guest directory and :ro suffix)
+// is in one of the directories allowed by the policy.
+func (p *Policy) allowsMount(mount string) bool {
+	if p == nil {
+		return true
+	}
+
+	prefix, readOnly := strings.CutSuffix(mount, ":ro")
+	dir, _, _ := strings.Cut(prefix, ":")
+
+	for _, allowed := range p.Mounts {
+		allowedDir, allowedReadOnly := strings.CutSuffix(allowed, ":ro")
+		if allowedReadOnly && !readOnly {
+			continue
+		}
+
+		rel, err := filepath.Rel(filepath.Clean(allowedDir), filepath.Clean(dir))
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowsHost returns whether the guest can connect to the given host and port.
+// An empty port matches all of the ports allowed for the host.
+func (p *Policy) allowsHost(host, port string) bool {
+	if p == nil {
+		return true
+	}
+
+	for _, allowed := range p.AllowedHosts {
+		allowedHost, allowedPort, err := net.SplitHostPort(allowed)
+		if err != nil {
+			allowedHost, allowedPort = allowed, ""
+		}
+
+		if sameHost(allowedHost, host) && (allowedPort == "" || port == "" || allowedPort == port) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowsURL returns whether the guest can send requests to the given URL.
+func (p *Policy) allowsURL(u *url.URL) bool {
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "http":
+			port = "80"
+		case "https":
+			port = "443"
+		}
+	}
+
+	return p.allowsHost(u.Hostname(), port)
+}
+
+// allowsClock returns whether the guest can read the host clock.
+func (p *Policy) allowsClock() bool {
+	return p == nil || p.Clock
+}
+
+func sameHost(a, b string) bool {
+	addrA, errA := netip.ParseAddr(strings.Trim(a, "[]"))
+	addrB, errB := netip.ParseAddr(strings.Trim(b, "[]"))
+	if errA == nil && errB == nil {
+		return addrA.Unmap() == addrB.Unmap()
+	}
+
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
+
+// frozenMonotonic returns a monotonic clock, in nanoseconds, increasing by one millisecond at each reading,
+// like the wazero default one.
+func frozenMonotonic() func() uint64 {
+	var now atomic.Uint64
+
+	return func() uint64 {
+		return now.Add(uint64(time.Millisecond))
+	}
+}
@@ -0,0 +1,106 @@
+package plugins
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWasmPolicy(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		settings    Settings
+		expectedErr string
+	}{
+		{
+			desc:     "no policy",
+			settings: Settings{Envs: []string{"HOME"}, Mounts: []string{"/etc"}},
+		},
+		{
+			desc: "allowed capabilities",
+			settings: Settings{
+				Envs:   []string{"PLUGIN_TOKEN"},
+				Mounts: []string{"/var/lib/plugin/data:/data", "/etc/plugin:ro"},
+				Policy: &Policy{
+					Envs:   []string{"PLUGIN_TOKEN"},
+					Mounts: []string{"/var/lib/plugin", "/etc/plugin:ro"},
+				},
+			},
+		},
+		{
+			desc:        "environment variable not allowed",
+			settings:    Settings{Envs: []string{"HOME"}, Policy: &Policy{}},
+			expectedErr: `environment variable "HOME" is not allowed by the policy`,
+		},
+		{
+			desc:        "mount outside of the allowed directories",
+			settings:    Settings{Mounts: []string{"/var/lib/other"}, Policy: &Policy{Mounts: []string{"/var/lib/plugin"}}},
+			expectedErr: `mount "/var/lib/other" is not allowed by the policy`,
+		},
+		{
+			desc:        "mount escaping the allowed directories",
+			settings:    Settings{Mounts: []string{"/var/lib/plugin/../other"}, Policy: &Policy{Mounts: []string{"/var/lib/plugin"}}},
+			expectedErr: `mount "/var/lib/plugin/../other" is not allowed by the policy`,
+		},
+		{
+			desc:        "writable mount in a read-only directory",
+			settings:    Settings{Mounts: []string{"/etc/plugin"}, Policy: &Policy{Mounts: []string{"/etc/plugin:ro"}}},
+			expectedErr: `mount "/etc/plugin" is not allowed by the policy`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkWasmPolicy(test.settings)
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPolicy_allowsHost(t *testing.T) {
+	policy := &Policy{AllowedHosts: []string{"api.example.com", "auth.example.com:443", "10.0.0.1", "[::1]:8080"}}
+
+	testCases := []struct {
+		desc     string
+		host     string
+		port     string
+		expected bool
+	}{
+		{desc: "allowed host on any port", host: "API.example.com", port: "8443", expected: true},
+		{desc: "allowed host and port", host: "auth.example.com", port: "443", expected: true},
+		{desc: "allowed host on another port", host: "auth.example.com", port: "80"},
+		{desc: "allowed host on unknown port", host: "auth.example.com", expected: true},
+		{desc: "allowed IPv4 address", host: "10.0.0.1", port: "80", expected: true},
+		{desc: "allowed IPv6 address", host: "::1", port: "8080", expected: true},
+		{desc: "IPv4-mapped IPv6 address", host: "::ffff:10.0.0.1", port: "80", expected: true},
+		{desc: "not allowed host", host: "evil.example.com", port: "443"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, policy.allowsHost(test.host, test.port))
+		})
+	}
+}
+
+func TestPolicy_allowsURL(t *testing.T) {
+	policy := &Policy{AllowedHosts: []string{"auth.example.com:443"}}
+
+	assert.True(t, policy.allowsURL(&url.URL{Scheme: "https", Host: "auth.example.com"}))
+	assert.False(t, policy.allowsURL(&url.URL{Scheme: "http", Host: "auth.example.com"}))
+	assert.False(t, policy.allowsURL(&url.URL{Scheme: "https", Host: "example.com"}))
+
+	var noPolicy *Policy
+	assert.True(t, noPolicy.allowsURL(&url.URL{Scheme: "https", Host: "example.com"}))
+}
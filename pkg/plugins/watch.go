@@ -0,0 +1,171 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/safe"
+)
+
+// watchDebounce is the delay without file events after which a watched plugin is rebuilt,
+// to rebuild only once when many files are written at once (e.g. by an editor or a build tool).
+const watchDebounce = 500 * time.Millisecond
+
+// WatchLocalPlugins watches the directories of the local plugins with the watch option enabled,
+// and rebuilds them on file changes.
+// The onReload callback is called once a plugin has been rebuilt, to rebuild the handlers using it.
+func (b *Builder) WatchLocalPlugins(pool *safe.Pool, localPlugins map[string]LocalDescriptor, onReload func()) error {
+	watched := make(map[string]LocalDescriptor)
+	for pName, desc := range localPlugins {
+		if desc.Watch {
+			watched[pName] = desc
+		}
+	}
+
+	if len(watched) == 0 {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to create plugins watcher: %w", err)
+	}
+
+	for _, desc := range watched {
+		err = watchDirectory(watcher, localPluginPath(desc.ModuleName))
+		if err != nil {
+			_ = watcher.Close()
+			return fmt.Errorf("unable to watch plugin %s: %w", desc.ModuleName, err)
+		}
+	}
+
+	pool.GoCtx(func(ctx context.Context) {
+		defer func() { _ = watcher.Close() }()
+
+		pending := make(map[string]struct{})
+		timer := time.NewTimer(watchDebounce)
+		timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if event.Has(fsnotify.Create) {
+					if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+						if err = watchDirectory(watcher, event.Name); err != nil {
+							log.Error().Err(err).Msgf("Unable to watch directory %s", event.Name)
+						}
+					}
+				}
+
+				for pName, desc := range watched {
+					if isInDirectory(event.Name, localPluginPath(desc.ModuleName)) {
+						pending[pName] = struct{}{}
+					}
+				}
+
+				timer.Reset(watchDebounce)
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				log.Error().Err(err).Msg("Plugins watcher error")
+
+			case <-timer.C:
+				var reloaded bool
+				for pName := range pending {
+					desc := watched[pName]
+
+					err := b.rebuildLocalPlugin(ctx, pName, desc)
+					if err != nil {
+						log.Error().Err(err).Str("plugin", "plugin-"+pName).Msg("Unable to rebuild the plugin, keeping the previous build")
+						continue
+					}
+
+					log.Info().Str("plugin", "plugin-"+pName).Msg("Plugin rebuilt")
+					reloaded = true
+				}
+
+				clear(pending)
+
+				if reloaded && onReload != nil {
+					onReload()
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+func (b *Builder) rebuildLocalPlugin(ctx context.Context, pName string, desc LocalDescriptor) error {
+	manifest, err := ReadManifest(localGoPath, desc.ModuleName)
+	if err != nil {
+		return fmt.Errorf("%s: failed to read manifest: %w", desc.ModuleName, err)
+	}
+
+	if manifest.Type != typeMiddleware {
+		return errors.New("only middleware plugins can be watched")
+	}
+
+	middleware, err := newMiddlewareBuilder(pluginContext(ctx, pName, desc.ModuleName, manifest.Runtime, desc.LogLevel), localGoPath, manifest, desc.ModuleName, desc.Settings, false, b.processes)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.middlewareBuilders[pName] = middleware
+
+	// The process of a gRPC plugin is restarted from the changed files by the next request.
+	if grpcBuilder, ok := asGRPCMiddlewareBuilder(middleware); ok && grpcBuilder.address == "" {
+		b.processes.release(grpcBuilder.processKey)
+	}
+
+	return nil
+}
+
+func localPluginPath(moduleName string) string {
+	return filepath.Join(localGoPath, goPathSrc, filepath.FromSlash(moduleName))
+}
+
+// watchDirectory adds the directory and all its subdirectories to the watcher, since fsnotify watches are not recursive.
+func watchDirectory(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		return watcher.Add(path)
+	})
+}
+
+func isInDirectory(path, dir string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,75 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_isInDirectory(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		path     string
+		dir      string
+		expected bool
+	}{
+		{
+			desc:     "file in directory",
+			path:     "plugins-local/src/github.com/foo/bar/main.go",
+			dir:      "plugins-local/src/github.com/foo/bar",
+			expected: true,
+		},
+		{
+			desc:     "file in subdirectory",
+			path:     "plugins-local/src/github.com/foo/bar/pkg/main.go",
+			dir:      "plugins-local/src/github.com/foo/bar",
+			expected: true,
+		},
+		{
+			desc:     "directory itself",
+			path:     "plugins-local/src/github.com/foo/bar",
+			dir:      "plugins-local/src/github.com/foo/bar",
+			expected: true,
+		},
+		{
+			desc: "sibling directory with the same prefix",
+			path: "plugins-local/src/github.com/foo/barbaz/main.go",
+			dir:  "plugins-local/src/github.com/foo/bar",
+		},
+		{
+			desc: "parent directory",
+			path: "plugins-local/src/github.com/foo/main.go",
+			dir:  "plugins-local/src/github.com/foo/bar",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, isInDirectory(test.path, test.dir))
+		})
+	}
+}
+
+func Test_watchDirectory(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "pkg", "sub"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git", "objects"), 0o755))
+
+	watcher, err := fsnotify.NewWatcher()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = watcher.Close() })
+
+	require.NoError(t, watchDirectory(watcher, root))
+
+	assert.ElementsMatch(t, []string{
+		root,
+		filepath.Join(root, "pkg"),
+		filepath.Join(root, "pkg", "sub"),
+	}, watcher.WatchList())
+}
@@ -248,15 +248,13 @@ func (p *Provider) loadConfigurationFromCRD(ctx context.Context, client Client)
 			continue
 		}
 
-		errorPage, errorPageService, err := p.createErrorPageMiddleware(client, middleware.Namespace, middleware.Spec.Errors)
+		errorPage, errorPageServices, err := p.createErrorPageMiddleware(client, middleware.Namespace, id, middleware.Spec.Errors)
 		if err != nil {
 			logger.Error().Err(err).Msg("Error while reading error page middleware")
 			continue
 		}
 
-		if errorPage != nil && errorPageService != nil {
-			serviceName := id + "-errorpage-service"
-			errorPage.Service = serviceName
+		for serviceName, errorPageService := range errorPageServices {
 			conf.HTTP.Services[serviceName] = errorPageService
 		}
 
@@ -266,7 +264,7 @@ func (p *Provider) loadConfigurationFromCRD(ctx context.Context, client Client)
 			continue
 		}
 
-		rateLimit, err := createRateLimitMiddleware(middleware.Spec.RateLimit)
+		rateLimit, err := createRateLimitMiddleware(client, middleware.Namespace, middleware.Spec.RateLimit)
 		if err != nil {
 			logger.Error().Err(err).Msg("Error while reading rateLimit middleware")
 			continue
@@ -284,6 +282,124 @@ func (p *Provider) loadConfigurationFromCRD(ctx context.Context, client Client)
 			continue
 		}
 
+		oidc, err := createOIDCMiddleware(client, middleware.Namespace, middleware.Spec.OIDC)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading OIDC middleware")
+			continue
+		}
+
+		jwt, err := createJWTMiddleware(client, middleware.Namespace, middleware.Spec.JWT)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading JWT middleware")
+			continue
+		}
+
+		cache, err := createCacheMiddleware(client, middleware.Namespace, middleware.Spec.Cache)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading cache middleware")
+			continue
+		}
+
+		botManager, err := createBotManagerMiddleware(client, middleware.Namespace, middleware.Spec.BotManager)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading bot manager middleware")
+			continue
+		}
+
+		cookies, err := createCookiesMiddleware(client, middleware.Namespace, middleware.Spec.Cookies)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading cookies middleware")
+			continue
+		}
+
+		csrf, err := createCSRFMiddleware(client, middleware.Namespace, middleware.Spec.CSRF)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading CSRF middleware")
+			continue
+		}
+
+		opa, err := createOPAMiddleware(client, middleware.Namespace, middleware.Spec.OPA)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading OPA middleware")
+			continue
+		}
+
+		hmacSignature, err := createHMACSignatureMiddleware(client, middleware.Namespace, middleware.Spec.HMACSignature)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading HMAC signature middleware")
+			continue
+		}
+
+		awsSigV4, err := createAWSSigV4Middleware(client, middleware.Namespace, middleware.Spec.AWSSigV4)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading AWS SigV4 middleware")
+			continue
+		}
+
+		maintenance, err := createMaintenanceMiddleware(middleware.Spec.Maintenance)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading maintenance middleware")
+			continue
+		}
+
+		tarpit, err := createTarpitMiddleware(middleware.Spec.Tarpit)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading tarpit middleware")
+			continue
+		}
+
+		fail2Ban, err := createFail2BanMiddleware(client, middleware.Namespace, middleware.Spec.Fail2Ban)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading fail2Ban middleware")
+			continue
+		}
+
+		webSocket, err := createWebSocketMiddleware(middleware.Spec.WebSocket)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading WebSocket middleware")
+			continue
+		}
+
+		sse, err := createSSEMiddleware(middleware.Spec.SSE)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading SSE middleware")
+			continue
+		}
+
+		signedURL, err := createSignedURLMiddleware(client, middleware.Namespace, middleware.Spec.SignedURL)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading signed URL middleware")
+			continue
+		}
+
+		apiKeyAuth, err := createAPIKeyAuthMiddleware(client, middleware.Namespace, middleware.Spec.APIKeyAuth)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading API key auth middleware")
+			continue
+		}
+
+		upstreamAuth, err := createUpstreamAuthMiddleware(client, middleware.Namespace, middleware.Spec.UpstreamAuth)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading upstream auth middleware")
+			continue
+		}
+
+		idempotency, err := createIdempotencyMiddleware(client, middleware.Namespace, middleware.Spec.Idempotency)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading idempotency middleware")
+			continue
+		}
+
+		canary, canaryServiceName, canaryService, err := p.createCanaryMiddleware(ctxMid, client, middleware.Namespace, middleware.Spec.Canary)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading canary middleware")
+			continue
+		}
+
+		if canaryService != nil {
+			conf.HTTP.Services[canaryServiceName] = canaryService
+		}
+
 		conf.HTTP.Middlewares[id] = &dynamic.Middleware{
 			AddPrefix:         middleware.Spec.AddPrefix,
 			StripPrefix:       middleware.Spec.StripPrefix,
@@ -309,6 +425,42 @@ func (p *Provider) loadConfigurationFromCRD(ctx context.Context, client Client)
 			Retry:             retry,
 			ContentType:       middleware.Spec.ContentType,
 			GrpcWeb:           middleware.Spec.GrpcWeb,
+			OIDC:              oidc,
+			JWT:               jwt,
+			Cache:             cache,
+			WAF:               middleware.Spec.WAF,
+			GeoIP:             middleware.Spec.GeoIP,
+			BotManager:        botManager,
+			BodyValidation:    middleware.Spec.BodyValidation,
+			RewriteBody:       createRewriteBodyMiddleware(middleware.Spec.RewriteBody),
+			Script:            middleware.Spec.Script,
+			Query:             middleware.Spec.Query,
+			Cookies:           cookies,
+			CORS:              middleware.Spec.CORS,
+			CSRF:              csrf,
+			OPA:               opa,
+			HMACSignature:     hmacSignature,
+			AWSSigV4:          awsSigV4,
+			Maintenance:       maintenance,
+			RedirectMap:       middleware.Spec.RedirectMap,
+			RequestID:         middleware.Spec.RequestID,
+			Tarpit:            tarpit,
+			Fail2Ban:          fail2Ban,
+			GraphQL:           middleware.Spec.GraphQL,
+			WebSocket:         webSocket,
+			SSE:               sse,
+			SignedURL:         signedURL,
+			APIKeyAuth:        apiKeyAuth,
+			Coalesce:          middleware.Spec.Coalesce,
+			Canary:            canary,
+			FormatConversion:  createFormatConversionMiddleware(middleware.Spec.FormatConversion),
+			HeaderAllowList:   middleware.Spec.HeaderAllowList,
+			ConcurrencyQuota:  middleware.Spec.ConcurrencyQuota,
+			MapPath:           middleware.Spec.MapPath,
+			MapHost:           middleware.Spec.MapHost,
+			SecurityHeaders:   middleware.Spec.SecurityHeaders,
+			UpstreamAuth:      upstreamAuth,
+			Idempotency:       idempotency,
 			Plugin:            plugin,
 		}
 	}
@@ -405,6 +557,13 @@ func (p *Provider) loadConfigurationFromCRD(ctx context.Context, client Client)
 					logger.Error().Err(err).Msg("Error while reading PingTimeout")
 				}
 			}
+
+			if serversTransport.Spec.ForwardingTimeouts.HTTP3HandshakeTimeout != nil {
+				err := forwardingTimeout.HTTP3HandshakeTimeout.Set(serversTransport.Spec.ForwardingTimeouts.HTTP3HandshakeTimeout.String())
+				if err != nil {
+					logger.Error().Err(err).Msg("Error while reading HTTP3HandshakeTimeout")
+				}
+			}
 		}
 
 		id := provider.Normalize(makeID(serversTransport.Namespace, serversTransport.Name))
@@ -414,6 +573,7 @@ func (p *Provider) loadConfigurationFromCRD(ctx context.Context, client Client)
 			RootCAs:             rootCAs,
 			Certificates:        certs,
 			DisableHTTP2:        serversTransport.Spec.DisableHTTP2,
+			EnableHTTP3:         serversTransport.Spec.EnableHTTP3,
 			MaxIdleConnsPerHost: serversTransport.Spec.MaxIdleConnsPerHost,
 			ForwardingTimeouts:  forwardingTimeout,
 			PeerCertURI:         serversTransport.Spec.PeerCertURI,
@@ -652,6 +812,12 @@ func createCircuitBreakerMiddleware(circuitBreaker *traefikv1alpha1.CircuitBreak
 		cb.ResponseCode = circuitBreaker.ResponseCode
 	}
 
+	if circuitBreaker.ProbePercent != 0 {
+		cb.ProbePercent = circuitBreaker.ProbePercent
+	}
+
+	cb.PerBackend = circuitBreaker.PerBackend
+
 	return cb, nil
 }
 
@@ -683,10 +849,20 @@ func createCompressMiddleware(compress *traefikv1alpha1.Compress) *dynamic.Compr
 		c.DefaultEncoding = *compress.DefaultEncoding
 	}
 
+	if compress.NegotiationOrder != nil {
+		c.NegotiationOrder = *compress.NegotiationOrder
+	}
+
+	c.ContentTypeMinSizes = compress.ContentTypeMinSizes
+	c.Levels = compress.Levels
+	c.ExcludedPaths = compress.ExcludedPaths
+	c.ExcludedRouters = compress.ExcludedRouters
+	c.PreCompressed = compress.PreCompressed
+
 	return c
 }
 
-func createRateLimitMiddleware(rateLimit *traefikv1alpha1.RateLimit) (*dynamic.RateLimit, error) {
+func createRateLimitMiddleware(k8sClient Client, namespace string, rateLimit *traefikv1alpha1.RateLimit) (*dynamic.RateLimit, error) {
 	if rateLimit == nil {
 		return nil, nil
 	}
@@ -713,6 +889,12 @@ func createRateLimitMiddleware(rateLimit *traefikv1alpha1.RateLimit) (*dynamic.R
 		rl.SourceCriterion = rateLimit.SourceCriterion
 	}
 
+	var err error
+	rl.Redis, err = createRedis(k8sClient, namespace, rateLimit.Redis)
+	if err != nil {
+		return nil, err
+	}
+
 	return rl, nil
 }
 
@@ -728,191 +910,1040 @@ func createRetryMiddleware(retry *traefikv1alpha1.Retry) (*dynamic.Retry, error)
 		return nil, err
 	}
 
+	if retry.Budget != nil {
+		r.Budget = &dynamic.RetryBudget{}
+		r.Budget.SetDefaults()
+
+		if retry.Budget.Percent != nil {
+			r.Budget.Percent = *retry.Budget.Percent
+		}
+
+		if retry.Budget.MinRetriesPerSecond != nil {
+			r.Budget.MinRetriesPerSecond = *retry.Budget.MinRetriesPerSecond
+		}
+	}
+
+	if retry.Hedging != nil {
+		r.Hedging = &dynamic.RetryHedging{}
+		if err := r.Hedging.Delay.Set(retry.Hedging.Delay.String()); err != nil {
+			return nil, err
+		}
+	}
+
 	return r, nil
 }
 
-func (p *Provider) createErrorPageMiddleware(client Client, namespace string, errorPage *traefikv1alpha1.ErrorPage) (*dynamic.ErrorPage, *dynamic.Service, error) {
-	if errorPage == nil {
-		return nil, nil, nil
+func createOIDCMiddleware(k8sClient Client, namespace string, oidc *traefikv1alpha1.OIDC) (*dynamic.OIDC, error) {
+	if oidc == nil {
+		return nil, nil
 	}
 
-	errorPageMiddleware := &dynamic.ErrorPage{
-		Status: errorPage.Status,
-		Query:  errorPage.Query,
+	o := &dynamic.OIDC{
+		Issuer:                oidc.Issuer,
+		ClientID:              oidc.ClientID,
+		Scopes:                oidc.Scopes,
+		RedirectPath:          oidc.RedirectPath,
+		LogoutPath:            oidc.LogoutPath,
+		PostLogoutRedirectURI: oidc.PostLogoutRedirectURI,
+		SessionCookie:         oidc.SessionCookie,
+		ClaimsHeaders:         oidc.ClaimsHeaders,
+		ForwardAccessToken:    oidc.ForwardAccessToken,
 	}
 
-	cb := configBuilder{
-		client:                    client,
-		allowCrossNamespace:       p.AllowCrossNamespace,
-		allowExternalNameServices: p.AllowExternalNameServices,
-		allowEmptyServices:        p.AllowEmptyServices,
+	if oidc.Secret == "" {
+		return nil, errors.New("OIDC secret must be set")
 	}
 
-	balancerServerHTTP, err := cb.buildServersLB(namespace, errorPage.Service.LoadBalancerSpec)
+	secret, err := loadSecret(k8sClient, namespace, oidc.Secret)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+
+	if o.ClientSecret, err = getSecretKey(secret, "clientSecret"); err != nil {
+		return nil, err
+	}
+
+	if o.SessionSecret, err = getSecretKey(secret, "sessionSecret"); err != nil {
+		return nil, err
+	}
+
+	o.TLS, err = createClientTLS(k8sClient, namespace, oidc.TLS)
+	if err != nil {
+		return nil, err
 	}
 
-	return errorPageMiddleware, balancerServerHTTP, nil
+	return o, nil
 }
 
-func (p *Provider) FillExtensionBuilderRegistry(registry gateway.ExtensionBuilderRegistry) {
-	registry.RegisterFilterFuncs(traefikv1alpha1.GroupName, "Middleware", func(name, namespace string) (string, *dynamic.Middleware, error) {
-		if len(p.Namespaces) > 0 && !slices.Contains(p.Namespaces, namespace) {
-			return "", nil, fmt.Errorf("namespace %q is not allowed", namespace)
+func createJWTMiddleware(k8sClient Client, namespace string, jwt *traefikv1alpha1.JWT) (*dynamic.JWT, error) {
+	if jwt == nil {
+		return nil, nil
+	}
+
+	j := &dynamic.JWT{
+		JWKSURL:          jwt.JWKSURL,
+		Issuer:           jwt.Issuer,
+		Audience:         jwt.Audience,
+		HeaderName:       jwt.HeaderName,
+		RemoveHeader:     jwt.RemoveHeader,
+		ClaimsHeaders:    jwt.ClaimsHeaders,
+		RejectStatusCode: jwt.RejectStatusCode,
+	}
+
+	if jwt.Secret != "" {
+		secret, err := loadSecret(k8sClient, namespace, jwt.Secret)
+		if err != nil {
+			return nil, err
 		}
 
-		return makeID(namespace, name) + providerNamespaceSeparator + providerName, nil, nil
-	})
+		j.SigningSecret = string(secret.Data["signingSecret"])
+		j.PublicKey = string(secret.Data["publicKey"])
 
-	registry.RegisterBackendFuncs(traefikv1alpha1.GroupName, "TraefikService", func(name, namespace string) (string, *dynamic.Service, error) {
-		if len(p.Namespaces) > 0 && !slices.Contains(p.Namespaces, namespace) {
-			return "", nil, fmt.Errorf("namespace %q is not allowed", namespace)
+		if j.SigningSecret == "" && j.PublicKey == "" {
+			return nil, fmt.Errorf("secret '%s/%s' must contain a signingSecret or a publicKey", namespace, jwt.Secret)
 		}
+	}
 
-		return makeID(namespace, name) + providerNamespaceSeparator + providerName, nil, nil
-	})
+	var err error
+	j.TLS, err = createClientTLS(k8sClient, namespace, jwt.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return j, nil
 }
 
-func createForwardAuthMiddleware(k8sClient Client, namespace string, auth *traefikv1alpha1.ForwardAuth) (*dynamic.ForwardAuth, error) {
-	if auth == nil {
+func createCacheMiddleware(k8sClient Client, namespace string, cache *traefikv1alpha1.Cache) (*dynamic.Cache, error) {
+	if cache == nil {
 		return nil, nil
 	}
-	if len(auth.Address) == 0 {
-		return nil, errors.New("forward authentication requires an address")
+
+	c := &dynamic.Cache{
+		MaxBodySize: cache.MaxBodySize,
+		Key:         cache.Key,
 	}
 
-	forwardAuth := &dynamic.ForwardAuth{
-		Address:                  auth.Address,
-		TrustForwardHeader:       auth.TrustForwardHeader,
-		AuthResponseHeaders:      auth.AuthResponseHeaders,
-		AuthResponseHeadersRegex: auth.AuthResponseHeadersRegex,
-		AuthRequestHeaders:       auth.AuthRequestHeaders,
-		AddAuthCookiesToResponse: auth.AddAuthCookiesToResponse,
+	if err := setDuration(&c.TTL, cache.TTL); err != nil {
+		return nil, err
 	}
 
-	if auth.TLS == nil {
-		return forwardAuth, nil
+	if err := setDuration(&c.DefaultTTL, cache.DefaultTTL); err != nil {
+		return nil, err
 	}
 
-	forwardAuth.TLS = &dynamic.ClientTLS{
-		InsecureSkipVerify: auth.TLS.InsecureSkipVerify,
+	if err := setDuration(&c.StaleWhileRevalidate, cache.StaleWhileRevalidate); err != nil {
+		return nil, err
 	}
 
-	if len(auth.TLS.CASecret) > 0 {
-		caSecret, err := loadCASecret(namespace, auth.TLS.CASecret, k8sClient)
+	if cache.Store != nil {
+		redis, err := createRedis(k8sClient, namespace, cache.Store.Redis)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load auth ca secret: %w", err)
+			return nil, err
 		}
-		forwardAuth.TLS.CA = caSecret
+
+		c.Store = &dynamic.CacheStore{
+			Memory: cache.Store.Memory,
+			Redis:  redis,
+		}
+	}
+
+	return c, nil
+}
+
+func createBotManagerMiddleware(k8sClient Client, namespace string, botManager *traefikv1alpha1.BotManager) (*dynamic.BotManager, error) {
+	if botManager == nil {
+		return nil, nil
 	}
 
-	if len(auth.TLS.CertSecret) > 0 {
-		authSecretCert, authSecretKey, err := loadAuthTLSSecret(namespace, auth.TLS.CertSecret, k8sClient)
+	bm := &dynamic.BotManager{}
+	bm.SetDefaults()
+
+	bm.Actions = botManager.Actions
+	bm.Signatures = botManager.Signatures
+	bm.IPStrategy = botManager.IPStrategy
+
+	if botManager.ChallengeSecret != "" {
+		var err error
+		bm.ChallengeSecret, err = loadSecretValue(k8sClient, namespace, botManager.ChallengeSecret, "secret")
 		if err != nil {
-			return nil, fmt.Errorf("failed to load auth secret: %w", err)
+			return nil, err
 		}
-		forwardAuth.TLS.Cert = authSecretCert
-		forwardAuth.TLS.Key = authSecretKey
 	}
 
-	forwardAuth.TLS.CAOptional = auth.TLS.CAOptional
+	if err := setDuration(&bm.ChallengeTTL, botManager.ChallengeTTL); err != nil {
+		return nil, err
+	}
+
+	if err := setDuration(&bm.TarpitDelay, botManager.TarpitDelay); err != nil {
+		return nil, err
+	}
 
-	return forwardAuth, nil
+	return bm, nil
 }
 
-func loadCASecret(namespace, secretName string, k8sClient Client) (string, error) {
-	secret, ok, err := k8sClient.GetSecret(namespace, secretName)
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch secret '%s/%s': %w", namespace, secretName, err)
+func createRewriteBodyMiddleware(rewriteBody *traefikv1alpha1.RewriteBody) *dynamic.RewriteBody {
+	if rewriteBody == nil {
+		return nil
 	}
 
-	if !ok {
-		return "", fmt.Errorf("secret '%s/%s' not found", namespace, secretName)
+	rb := &dynamic.RewriteBody{}
+	rb.SetDefaults()
+
+	rb.Rewrites = rewriteBody.Rewrites
+	rb.Request = rewriteBody.Request
+	rb.ContentTypes = rewriteBody.ContentTypes
+
+	if rewriteBody.Response != nil {
+		rb.Response = *rewriteBody.Response
 	}
 
-	if secret == nil {
-		return "", fmt.Errorf("data for secret '%s/%s' must not be nil", namespace, secretName)
+	return rb
+}
+
+func createCookiesMiddleware(k8sClient Client, namespace string, cookies *traefikv1alpha1.Cookies) (*dynamic.Cookies, error) {
+	if cookies == nil {
+		return nil, nil
 	}
 
-	tlsCAData, err := getCABlocks(secret, namespace, secretName)
-	if err == nil {
-		return tlsCAData, nil
+	c := &dynamic.Cookies{
+		Request:  cookies.Request,
+		Response: cookies.Response,
+		Secure:   cookies.Secure,
+		HTTPOnly: cookies.HTTPOnly,
+		SameSite: cookies.SameSite,
 	}
 
-	// TODO: remove this behavior in the next major version (v3)
-	if len(secret.Data) == 1 {
-		// For backwards compatibility, use the only available secret data as CA if both 'ca.crt' and 'tls.ca' are missing.
-		for _, v := range secret.Data {
-			return string(v), nil
+	if cookies.Encryption != nil {
+		if cookies.Encryption.Secret == "" {
+			return nil, errors.New("cookies encryption secret must be set")
+		}
+
+		secret, err := loadSecretValue(k8sClient, namespace, cookies.Encryption.Secret, "secret")
+		if err != nil {
+			return nil, err
+		}
+
+		c.Encryption = &dynamic.CookieEncryption{
+			Secret:  secret,
+			Cookies: cookies.Encryption.Cookies,
 		}
 	}
 
-	return "", fmt.Errorf("could not find CA block: %w", err)
+	return c, nil
 }
 
-func loadAuthTLSSecret(namespace, secretName string, k8sClient Client) (string, string, error) {
-	secret, exists, err := k8sClient.GetSecret(namespace, secretName)
-	if err != nil {
-		return "", "", fmt.Errorf("failed to fetch secret '%s/%s': %w", namespace, secretName, err)
+func createCSRFMiddleware(k8sClient Client, namespace string, csrf *traefikv1alpha1.CSRF) (*dynamic.CSRF, error) {
+	if csrf == nil {
+		return nil, nil
 	}
 
-	if !exists {
-		return "", "", fmt.Errorf("secret '%s/%s' does not exist", namespace, secretName)
+	c := &dynamic.CSRF{
+		SafeMethods:       csrf.SafeMethods,
+		CookieName:        csrf.CookieName,
+		HeaderName:        csrf.HeaderName,
+		ExemptPaths:       csrf.ExemptPaths,
+		SessionCookieName: csrf.SessionCookieName,
+		Secure:            csrf.Secure,
 	}
 
-	if secret == nil {
-		return "", "", fmt.Errorf("data for secret '%s/%s' must not be nil", namespace, secretName)
+	if csrf.Secret != "" {
+		var err error
+		c.Secret, err = loadSecretValue(k8sClient, namespace, csrf.Secret, "secret")
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return getCertificateBlocks(secret, namespace, secretName)
+	return c, nil
 }
 
-func createBasicAuthMiddleware(client Client, namespace string, basicAuth *traefikv1alpha1.BasicAuth) (*dynamic.BasicAuth, error) {
-	if basicAuth == nil {
+func createOPAMiddleware(k8sClient Client, namespace string, opa *traefikv1alpha1.OPA) (*dynamic.OPA, error) {
+	if opa == nil {
 		return nil, nil
 	}
 
-	if basicAuth.Secret == "" {
-		return nil, errors.New("auth secret must be set")
+	o := &dynamic.OPA{}
+	o.SetDefaults()
+
+	o.Policy = opa.Policy
+	o.BundleURL = opa.BundleURL
+	o.URL = opa.URL
+	o.RejectStatusCode = opa.RejectStatusCode
+
+	if opa.Decision != "" {
+		o.Decision = opa.Decision
 	}
 
-	secret, ok, err := client.GetSecret(namespace, basicAuth.Secret)
+	if err := setDuration(&o.PollInterval, opa.PollInterval); err != nil {
+		return nil, err
+	}
+
+	var err error
+	o.TLS, err = createClientTLS(k8sClient, namespace, opa.TLS)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch secret '%s/%s': %w", namespace, basicAuth.Secret, err)
+		return nil, err
 	}
-	if !ok {
-		return nil, fmt.Errorf("secret '%s/%s' not found", namespace, basicAuth.Secret)
+
+	return o, nil
+}
+
+func createHMACSignatureMiddleware(k8sClient Client, namespace string, hmacSignature *traefikv1alpha1.HMACSignature) (*dynamic.HMACSignature, error) {
+	if hmacSignature == nil {
+		return nil, nil
 	}
-	if secret == nil {
-		return nil, fmt.Errorf("data for secret '%s/%s' must not be nil", namespace, basicAuth.Secret)
+
+	h := &dynamic.HMACSignature{
+		KeyIDHeader:      hmacSignature.KeyIDHeader,
+		Algorithm:        hmacSignature.Algorithm,
+		SignatureHeader:  hmacSignature.SignatureHeader,
+		SignaturePrefix:  hmacSignature.SignaturePrefix,
+		Encoding:         hmacSignature.Encoding,
+		SignedComponents: hmacSignature.SignedComponents,
+		Separator:        hmacSignature.Separator,
+		TimestampHeader:  hmacSignature.TimestampHeader,
+		MaxBodyBytes:     hmacSignature.MaxBodyBytes,
 	}
 
-	if secret.Type == corev1.SecretTypeBasicAuth {
-		credentials, err := loadBasicAuthCredentials(secret)
+	for _, key := range hmacSignature.Keys {
+		secret, err := loadSecretValue(k8sClient, namespace, key.Secret, "secret")
 		if err != nil {
-			return nil, fmt.Errorf("failed to load basic auth credentials: %w", err)
+			return nil, err
 		}
 
-		return &dynamic.BasicAuth{
-			Users:        credentials,
-			Realm:        basicAuth.Realm,
-			RemoveHeader: basicAuth.RemoveHeader,
-			HeaderField:  basicAuth.HeaderField,
-		}, nil
+		h.Keys = append(h.Keys, dynamic.HMACSignatureKey{ID: key.ID, Secret: secret})
 	}
 
-	credentials, err := loadAuthCredentials(secret)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load basic auth credentials: %w", err)
+	if err := setDuration(&h.ClockSkew, hmacSignature.ClockSkew); err != nil {
+		return nil, err
 	}
 
-	return &dynamic.BasicAuth{
-		Users:        credentials,
-		Realm:        basicAuth.Realm,
-		RemoveHeader: basicAuth.RemoveHeader,
-		HeaderField:  basicAuth.HeaderField,
-	}, nil
+	return h, nil
+}
+
+func createAWSSigV4Middleware(k8sClient Client, namespace string, awsSigV4 *traefikv1alpha1.AWSSigV4) (*dynamic.AWSSigV4, error) {
+	if awsSigV4 == nil {
+		return nil, nil
+	}
+
+	a := &dynamic.AWSSigV4{
+		Service:         awsSigV4.Service,
+		Region:          awsSigV4.Region,
+		Host:            awsSigV4.Host,
+		UnsignedPayload: awsSigV4.UnsignedPayload,
+		MaxBodyBytes:    awsSigV4.MaxBodyBytes,
+	}
+
+	if awsSigV4.Secret == "" {
+		return a, nil
+	}
+
+	secret, err := loadSecret(k8sClient, namespace, awsSigV4.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.AccessKeyID, err = getSecretKey(secret, "accessKeyID"); err != nil {
+		return nil, err
+	}
+
+	if a.SecretAccessKey, err = getSecretKey(secret, "secretAccessKey"); err != nil {
+		return nil, err
+	}
+
+	a.SessionToken = string(secret.Data["sessionToken"])
+
+	return a, nil
+}
+
+func createMaintenanceMiddleware(maintenance *traefikv1alpha1.Maintenance) (*dynamic.Maintenance, error) {
+	if maintenance == nil {
+		return nil, nil
+	}
+
+	m := &dynamic.Maintenance{
+		Enabled:     maintenance.Enabled,
+		FlagFile:    maintenance.FlagFile,
+		StatusCode:  maintenance.StatusCode,
+		ContentType: maintenance.ContentType,
+		Body:        maintenance.Body,
+		File:        maintenance.File,
+		SourceRange: maintenance.SourceRange,
+		IPStrategy:  maintenance.IPStrategy,
+	}
+
+	if err := setDuration(&m.RetryAfter, maintenance.RetryAfter); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func createTarpitMiddleware(tarpit *traefikv1alpha1.Tarpit) (*dynamic.Tarpit, error) {
+	if tarpit == nil {
+		return nil, nil
+	}
+
+	t := &dynamic.Tarpit{}
+	t.SetDefaults()
+
+	t.SourceRange = tarpit.SourceRange
+	t.BotCategories = tarpit.BotCategories
+	t.StatusCode = tarpit.StatusCode
+	t.IPStrategy = tarpit.IPStrategy
+
+	if tarpit.MaxConcurrent != nil {
+		t.MaxConcurrent = *tarpit.MaxConcurrent
+	}
+
+	if err := setDuration(&t.Delay, tarpit.Delay); err != nil {
+		return nil, err
+	}
+
+	if err := setDuration(&t.MaxDelay, tarpit.MaxDelay); err != nil {
+		return nil, err
+	}
+
+	if tarpit.Rate != nil {
+		t.Rate = &dynamic.TarpitRate{}
+		t.Rate.SetDefaults()
+
+		t.Rate.Average = tarpit.Rate.Average
+
+		if tarpit.Rate.Burst != nil {
+			t.Rate.Burst = *tarpit.Rate.Burst
+		}
+
+		if err := setDuration(&t.Rate.Period, tarpit.Rate.Period); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+func createFail2BanMiddleware(k8sClient Client, namespace string, fail2Ban *traefikv1alpha1.Fail2Ban) (*dynamic.Fail2Ban, error) {
+	if fail2Ban == nil {
+		return nil, nil
+	}
+
+	f := &dynamic.Fail2Ban{
+		StatusCodes:        fail2Ban.StatusCodes,
+		MaxFailures:        fail2Ban.MaxFailures,
+		BanStatusCode:      fail2Ban.BanStatusCode,
+		IgnoredSourceRange: fail2Ban.IgnoredSourceRange,
+		IPStrategy:         fail2Ban.IPStrategy,
+	}
+
+	if err := setDuration(&f.FindTime, fail2Ban.FindTime); err != nil {
+		return nil, err
+	}
+
+	if err := setDuration(&f.BanTime, fail2Ban.BanTime); err != nil {
+		return nil, err
+	}
+
+	var err error
+	f.Redis, err = createRedis(k8sClient, namespace, fail2Ban.Redis)
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func createWebSocketMiddleware(webSocket *traefikv1alpha1.WebSocket) (*dynamic.WebSocket, error) {
+	if webSocket == nil {
+		return nil, nil
+	}
+
+	ws := &dynamic.WebSocket{
+		MaxMessageSize:      webSocket.MaxMessageSize,
+		AllowedSubprotocols: webSocket.AllowedSubprotocols,
+		MaxConnections:      webSocket.MaxConnections,
+	}
+
+	if err := setDuration(&ws.MaxLifetime, webSocket.MaxLifetime); err != nil {
+		return nil, err
+	}
+
+	if err := setDuration(&ws.IdleTimeout, webSocket.IdleTimeout); err != nil {
+		return nil, err
+	}
+
+	return ws, nil
+}
+
+func createSSEMiddleware(sse *traefikv1alpha1.SSE) (*dynamic.SSE, error) {
+	if sse == nil {
+		return nil, nil
+	}
+
+	s := &dynamic.SSE{}
+
+	if err := setDuration(&s.FlushInterval, sse.FlushInterval); err != nil {
+		return nil, err
+	}
+
+	if err := setDuration(&s.MaxLifetime, sse.MaxLifetime); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func createSignedURLMiddleware(k8sClient Client, namespace string, signedURL *traefikv1alpha1.SignedURL) (*dynamic.SignedURL, error) {
+	if signedURL == nil {
+		return nil, nil
+	}
+
+	s := &dynamic.SignedURL{
+		KeyIDParam:     signedURL.KeyIDParam,
+		Algorithm:      signedURL.Algorithm,
+		Encoding:       signedURL.Encoding,
+		SignatureParam: signedURL.SignatureParam,
+		ExpiresParam:   signedURL.ExpiresParam,
+		StripParams:    signedURL.StripParams,
+	}
+
+	for _, key := range signedURL.Keys {
+		secret, err := loadSecretValue(k8sClient, namespace, key.Secret, "secret")
+		if err != nil {
+			return nil, err
+		}
+
+		s.Keys = append(s.Keys, dynamic.SignedURLKey{ID: key.ID, Secret: secret})
+	}
+
+	if err := setDuration(&s.MaxValidity, signedURL.MaxValidity); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func createAPIKeyAuthMiddleware(k8sClient Client, namespace string, apiKeyAuth *traefikv1alpha1.APIKeyAuth) (*dynamic.APIKeyAuth, error) {
+	if apiKeyAuth == nil {
+		return nil, nil
+	}
+
+	a := &dynamic.APIKeyAuth{
+		HeaderName:           apiKeyAuth.HeaderName,
+		QueryParam:           apiKeyAuth.QueryParam,
+		HeaderField:          apiKeyAuth.HeaderField,
+		MetadataHeaderPrefix: apiKeyAuth.MetadataHeaderPrefix,
+		RemoveKey:            apiKeyAuth.RemoveKey,
+	}
+
+	if apiKeyAuth.Secret != "" {
+		secret, err := loadSecret(k8sClient, namespace, apiKeyAuth.Secret)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, value := range secret.Data {
+			a.Keys = append(a.Keys, dynamic.APIKey{Name: name, Value: string(value)})
+		}
+
+		sort.Slice(a.Keys, func(i, j int) bool {
+			return a.Keys[i].Name < a.Keys[j].Name
+		})
+	}
+
+	var err error
+	a.Redis, err = createRedis(k8sClient, namespace, apiKeyAuth.Redis)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// createCanaryMiddleware returns the canary middleware, along with the service the matching requests are forwarded to,
+// when it is built from a Kubernetes Service.
+func (p *Provider) createCanaryMiddleware(ctx context.Context, client Client, namespace string, canary *traefikv1alpha1.Canary) (*dynamic.Canary, string, *dynamic.Service, error) {
+	if canary == nil {
+		return nil, "", nil, nil
+	}
+
+	cb := configBuilder{
+		client:                    client,
+		allowCrossNamespace:       p.AllowCrossNamespace,
+		allowExternalNameServices: p.AllowExternalNameServices,
+		allowEmptyServices:        p.AllowEmptyServices,
+	}
+
+	serviceName, service, err := cb.nameAndService(ctx, namespace, canary.Service.LoadBalancerSpec)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return &dynamic.Canary{
+		Service: serviceName,
+		Headers: canary.Headers,
+		Cookies: canary.Cookies,
+		Claims:  canary.Claims,
+	}, serviceName, service, nil
+}
+
+func createFormatConversionMiddleware(formatConversion *traefikv1alpha1.FormatConversion) *dynamic.FormatConversion {
+	if formatConversion == nil {
+		return nil
+	}
+
+	fc := &dynamic.FormatConversion{}
+	fc.SetDefaults()
+
+	fc.Formats = formatConversion.Formats
+	fc.XMLRootElement = formatConversion.XMLRootElement
+	fc.MaxBodySize = formatConversion.MaxBodySize
+
+	if formatConversion.Request != nil {
+		fc.Request = *formatConversion.Request
+	}
+
+	if formatConversion.Response != nil {
+		fc.Response = *formatConversion.Response
+	}
+
+	return fc
+}
+
+func createUpstreamAuthMiddleware(k8sClient Client, namespace string, upstreamAuth *traefikv1alpha1.UpstreamAuth) (*dynamic.UpstreamAuth, error) {
+	if upstreamAuth == nil {
+		return nil, nil
+	}
+
+	u := &dynamic.UpstreamAuth{HeaderName: upstreamAuth.HeaderName}
+
+	if upstreamAuth.Basic != nil {
+		secret, err := loadSecret(k8sClient, namespace, upstreamAuth.Basic.Secret)
+		if err != nil {
+			return nil, err
+		}
+
+		u.Basic = &dynamic.UpstreamBasicAuth{}
+		if u.Basic.Username, err = getSecretKey(secret, corev1.BasicAuthUsernameKey); err != nil {
+			return nil, err
+		}
+
+		if u.Basic.Password, err = getSecretKey(secret, corev1.BasicAuthPasswordKey); err != nil {
+			return nil, err
+		}
+	}
+
+	if upstreamAuth.Bearer != nil {
+		token, err := loadSecretValue(k8sClient, namespace, upstreamAuth.Bearer.Secret, "token")
+		if err != nil {
+			return nil, err
+		}
+
+		u.Bearer = &dynamic.UpstreamBearerAuth{Token: token}
+	}
+
+	if upstreamAuth.OAuth2 != nil {
+		clientSecret, err := loadSecretValue(k8sClient, namespace, upstreamAuth.OAuth2.Secret, "clientSecret")
+		if err != nil {
+			return nil, err
+		}
+
+		u.OAuth2 = &dynamic.UpstreamOAuth2{
+			TokenURL:       upstreamAuth.OAuth2.TokenURL,
+			ClientID:       upstreamAuth.OAuth2.ClientID,
+			ClientSecret:   clientSecret,
+			Scopes:         upstreamAuth.OAuth2.Scopes,
+			EndpointParams: upstreamAuth.OAuth2.EndpointParams,
+		}
+	}
+
+	return u, nil
+}
+
+func createIdempotencyMiddleware(k8sClient Client, namespace string, idempotency *traefikv1alpha1.Idempotency) (*dynamic.Idempotency, error) {
+	if idempotency == nil {
+		return nil, nil
+	}
+
+	i := &dynamic.Idempotency{
+		HeaderName:  idempotency.HeaderName,
+		Methods:     idempotency.Methods,
+		Scope:       idempotency.Scope,
+		MaxBodySize: idempotency.MaxBodySize,
+	}
+
+	if err := setDuration(&i.TTL, idempotency.TTL); err != nil {
+		return nil, err
+	}
+
+	if idempotency.Store != nil {
+		redis, err := createRedis(k8sClient, namespace, idempotency.Store.Redis)
+		if err != nil {
+			return nil, err
+		}
+
+		i.Store = &dynamic.IdempotencyStore{
+			Memory: idempotency.Store.Memory,
+			Redis:  redis,
+		}
+	}
+
+	return i, nil
+}
+
+func createRedis(k8sClient Client, namespace string, redis *traefikv1alpha1.Redis) (*dynamic.Redis, error) {
+	if redis == nil {
+		return nil, nil
+	}
+
+	r := &dynamic.Redis{
+		Endpoints: redis.Endpoints,
+		Username:  redis.Username,
+		DB:        redis.DB,
+	}
+
+	var err error
+	if redis.Secret != "" {
+		r.Password, err = loadSecretValue(k8sClient, namespace, redis.Secret, "password")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r.TLS, err = createClientTLS(k8sClient, namespace, redis.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// setDuration sets the duration to the given value, if any.
+func setDuration(d *ptypes.Duration, value *intstr.IntOrString) error {
+	if value == nil {
+		return nil
+	}
+
+	return d.Set(value.String())
+}
+
+// createErrorPageMiddleware returns the error page middleware, along with the services serving the error pages, by name.
+func (p *Provider) createErrorPageMiddleware(client Client, namespace, id string, errorPage *traefikv1alpha1.ErrorPage) (*dynamic.ErrorPage, map[string]*dynamic.Service, error) {
+	if errorPage == nil {
+		return nil, nil, nil
+	}
+
+	errorPageMiddleware := &dynamic.ErrorPage{
+		Status:      errorPage.Status,
+		Query:       errorPage.Query,
+		ContentType: errorPage.ContentType,
+		Body:        errorPage.Body,
+		File:        errorPage.File,
+	}
+
+	cb := configBuilder{
+		client:                    client,
+		allowCrossNamespace:       p.AllowCrossNamespace,
+		allowExternalNameServices: p.AllowExternalNameServices,
+		allowEmptyServices:        p.AllowEmptyServices,
+	}
+
+	services := make(map[string]*dynamic.Service)
+
+	// The error pages built from the body or the file are served without a service.
+	if errorPage.Service.Name != "" || (errorPage.Body == "" && errorPage.File == "" && len(errorPage.StatusServices) == 0) {
+		balancerServerHTTP, err := cb.buildServersLB(namespace, errorPage.Service.LoadBalancerSpec)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		errorPageMiddleware.Service = id + "-errorpage-service"
+		services[errorPageMiddleware.Service] = balancerServerHTTP
+	}
+
+	for status, service := range errorPage.StatusServices {
+		balancerServerHTTP, err := cb.buildServersLB(namespace, service.LoadBalancerSpec)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if errorPageMiddleware.StatusServices == nil {
+			errorPageMiddleware.StatusServices = make(map[string]string)
+		}
+
+		serviceName := id + "-errorpage-service-" + strings.ToLower(status)
+		errorPageMiddleware.StatusServices[status] = serviceName
+		services[serviceName] = balancerServerHTTP
+	}
+
+	return errorPageMiddleware, services, nil
+}
+
+func (p *Provider) FillExtensionBuilderRegistry(registry gateway.ExtensionBuilderRegistry) {
+	registry.RegisterFilterFuncs(traefikv1alpha1.GroupName, "Middleware", func(name, namespace string) (string, *dynamic.Middleware, error) {
+		if len(p.Namespaces) > 0 && !slices.Contains(p.Namespaces, namespace) {
+			return "", nil, fmt.Errorf("namespace %q is not allowed", namespace)
+		}
+
+		return makeID(namespace, name) + providerNamespaceSeparator + providerName, nil, nil
+	})
+
+	registry.RegisterBackendFuncs(traefikv1alpha1.GroupName, "TraefikService", func(name, namespace string) (string, *dynamic.Service, error) {
+		if len(p.Namespaces) > 0 && !slices.Contains(p.Namespaces, namespace) {
+			return "", nil, fmt.Errorf("namespace %q is not allowed", namespace)
+		}
+
+		return makeID(namespace, name) + providerNamespaceSeparator + providerName, nil, nil
+	})
+}
+
+func createForwardAuthMiddleware(k8sClient Client, namespace string, auth *traefikv1alpha1.ForwardAuth) (*dynamic.ForwardAuth, error) {
+	if auth == nil {
+		return nil, nil
+	}
+	if len(auth.Address) == 0 {
+		return nil, errors.New("forward authentication requires an address")
+	}
+
+	forwardAuth := &dynamic.ForwardAuth{
+		Address:                  auth.Address,
+		TrustForwardHeader:       auth.TrustForwardHeader,
+		AuthResponseHeaders:      auth.AuthResponseHeaders,
+		AuthResponseHeadersRegex: auth.AuthResponseHeadersRegex,
+		AuthRequestHeaders:       auth.AuthRequestHeaders,
+		AddAuthCookiesToResponse: auth.AddAuthCookiesToResponse,
+		ForwardBody:              auth.ForwardBody,
+		MaxBodySize:              auth.MaxBodySize,
+	}
+
+	if auth.Retry != nil {
+		forwardAuth.Retry = &dynamic.ForwardAuthRetry{Attempts: auth.Retry.Attempts}
+
+		if err := forwardAuth.Retry.InitialInterval.Set(auth.Retry.InitialInterval.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if auth.Cache != nil {
+		forwardAuth.Cache = &dynamic.ForwardAuthCache{
+			Key:        auth.Cache.Key,
+			MaxEntries: auth.Cache.MaxEntries,
+		}
+
+		if err := forwardAuth.Cache.TTL.Set(auth.Cache.TTL.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	forwardAuth.TLS, err = createClientTLS(k8sClient, namespace, auth.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return forwardAuth, nil
+}
+
+func createClientTLS(k8sClient Client, namespace string, clientTLS *traefikv1alpha1.ClientTLS) (*dynamic.ClientTLS, error) {
+	if clientTLS == nil {
+		return nil, nil
+	}
+
+	tlsConfig := &dynamic.ClientTLS{
+		InsecureSkipVerify: clientTLS.InsecureSkipVerify,
+	}
+
+	if len(clientTLS.CASecret) > 0 {
+		caSecret, err := loadCASecret(namespace, clientTLS.CASecret, k8sClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load auth ca secret: %w", err)
+		}
+		tlsConfig.CA = caSecret
+	}
+
+	if len(clientTLS.CertSecret) > 0 {
+		authSecretCert, authSecretKey, err := loadAuthTLSSecret(namespace, clientTLS.CertSecret, k8sClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load auth secret: %w", err)
+		}
+		tlsConfig.Cert = authSecretCert
+		tlsConfig.Key = authSecretKey
+	}
+
+	tlsConfig.CAOptional = clientTLS.CAOptional
+
+	return tlsConfig, nil
+}
+
+func loadSecret(k8sClient Client, namespace, secretName string) (*corev1.Secret, error) {
+	secret, ok, err := k8sClient.GetSecret(namespace, secretName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch secret '%s/%s': %w", namespace, secretName, err)
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("secret '%s/%s' not found", namespace, secretName)
+	}
+
+	if secret == nil {
+		return nil, fmt.Errorf("data for secret '%s/%s' must not be nil", namespace, secretName)
+	}
+
+	return secret, nil
+}
+
+func loadSecretValue(k8sClient Client, namespace, secretName, key string) (string, error) {
+	secret, err := loadSecret(k8sClient, namespace, secretName)
+	if err != nil {
+		return "", err
+	}
+
+	return getSecretKey(secret, key)
+}
+
+func getSecretKey(secret *corev1.Secret, key string) (string, error) {
+	value, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in secret '%s/%s'", key, secret.Namespace, secret.Name)
+	}
+
+	return string(value), nil
+}
+
+func loadCASecret(namespace, secretName string, k8sClient Client) (string, error) {
+	secret, ok, err := k8sClient.GetSecret(namespace, secretName)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch secret '%s/%s': %w", namespace, secretName, err)
+	}
+
+	if !ok {
+		return "", fmt.Errorf("secret '%s/%s' not found", namespace, secretName)
+	}
+
+	if secret == nil {
+		return "", fmt.Errorf("data for secret '%s/%s' must not be nil", namespace, secretName)
+	}
+
+	tlsCAData, err := getCABlocks(secret, namespace, secretName)
+	if err == nil {
+		return tlsCAData, nil
+	}
+
+	// TODO: remove this behavior in the next major version (v3)
+	if len(secret.Data) == 1 {
+		// For backwards compatibility, use the only available secret data as CA if both 'ca.crt' and 'tls.ca' are missing.
+		for _, v := range secret.Data {
+			return string(v), nil
+		}
+	}
+
+	return "", fmt.Errorf("could not find CA block: %w", err)
+}
+
+func loadAuthTLSSecret(namespace, secretName string, k8sClient Client) (string, string, error) {
+	secret, exists, err := k8sClient.GetSecret(namespace, secretName)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch secret '%s/%s': %w", namespace, secretName, err)
+	}
+
+	if !exists {
+		return "", "", fmt.Errorf("secret '%s/%s' does not exist", namespace, secretName)
+	}
+
+	if secret == nil {
+		return "", "", fmt.Errorf("data for secret '%s/%s' must not be nil", namespace, secretName)
+	}
+
+	return getCertificateBlocks(secret, namespace, secretName)
+}
+
+func createBasicAuthMiddleware(client Client, namespace string, basicAuth *traefikv1alpha1.BasicAuth) (*dynamic.BasicAuth, error) {
+	if basicAuth == nil {
+		return nil, nil
+	}
+
+	if basicAuth.Secret == "" && basicAuth.LDAP == nil {
+		return nil, errors.New("auth secret must be set")
+	}
+
+	ldap, err := createBasicAuthLDAP(client, namespace, basicAuth.LDAP)
+	if err != nil {
+		return nil, err
+	}
+
+	ba := &dynamic.BasicAuth{
+		Realm:        basicAuth.Realm,
+		RemoveHeader: basicAuth.RemoveHeader,
+		HeaderField:  basicAuth.HeaderField,
+		LDAP:         ldap,
+	}
+
+	// With LDAP, the users declared in the Secret are optional.
+	if basicAuth.Secret == "" {
+		return ba, nil
+	}
+
+	secret, ok, err := client.GetSecret(namespace, basicAuth.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch secret '%s/%s': %w", namespace, basicAuth.Secret, err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("secret '%s/%s' not found", namespace, basicAuth.Secret)
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("data for secret '%s/%s' must not be nil", namespace, basicAuth.Secret)
+	}
+
+	if secret.Type == corev1.SecretTypeBasicAuth {
+		ba.Users, err = loadBasicAuthCredentials(secret)
+	} else {
+		ba.Users, err = loadAuthCredentials(secret)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load basic auth credentials: %w", err)
+	}
+
+	return ba, nil
+}
+
+func createBasicAuthLDAP(k8sClient Client, namespace string, ldap *traefikv1alpha1.BasicAuthLDAP) (*dynamic.BasicAuthLDAP, error) {
+	if ldap == nil {
+		return nil, nil
+	}
+
+	l := &dynamic.BasicAuthLDAP{}
+	l.SetDefaults()
+
+	l.URL = ldap.URL
+	l.StartTLS = ldap.StartTLS
+	l.BindDN = ldap.BindDN
+	l.BaseDN = ldap.BaseDN
+	l.GroupFilter = ldap.GroupFilter
+
+	if ldap.UserFilter != "" {
+		l.UserFilter = ldap.UserFilter
+	}
+
+	if ldap.PoolSize != 0 {
+		l.PoolSize = ldap.PoolSize
+	}
+
+	if err := setDuration(&l.CacheTTL, ldap.CacheTTL); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if ldap.BindPasswordSecret != "" {
+		l.BindPassword, err = loadSecretValue(k8sClient, namespace, ldap.BindPasswordSecret, "bindPassword")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	l.TLS, err = createClientTLS(k8sClient, namespace, ldap.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
 }
 
 func createDigestAuthMiddleware(client Client, namespace string, digestAuth *traefikv1alpha1.DigestAuth) (*dynamic.DigestAuth, error) {
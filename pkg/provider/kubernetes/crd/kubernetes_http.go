@@ -217,6 +217,8 @@ func (c configBuilder) buildTraefikService(ctx context.Context, tService *traefi
 		return c.buildServicesLB(ctx, tService.Namespace, tService.Spec, id, conf)
 	} else if tService.Spec.Mirroring != nil {
 		return c.buildMirroring(ctx, tService, id, conf)
+	} else if tService.Spec.TrafficSplit != nil {
+		return c.buildTrafficSplit(ctx, tService, id, conf)
 	}
 
 	return errors.New("unspecified service type")
@@ -280,10 +282,18 @@ func (c configBuilder) buildMirroring(ctx context.Context, tService *traefikv1al
 			conf[mirroredName] = k8sService
 		}
 
-		mirrorServices = append(mirrorServices, dynamic.MirrorService{
-			Name:    mirroredName,
-			Percent: mirror.Percent,
-		})
+		mirrorService := dynamic.MirrorService{
+			Name:        mirroredName,
+			Percent:     mirror.Percent,
+			Match:       mirror.Match,
+			MaxBodySize: mirror.MaxBodySize,
+		}
+
+		if err := setDuration(&mirrorService.Timeout, mirror.Timeout); err != nil {
+			return fmt.Errorf("parsing the timeout of the mirror %s: %w", mirroredName, err)
+		}
+
+		mirrorServices = append(mirrorServices, mirrorService)
 	}
 
 	conf[id] = &dynamic.Service{
@@ -298,6 +308,40 @@ func (c configBuilder) buildMirroring(ctx context.Context, tService *traefikv1al
 	return nil
 }
 
+// buildTrafficSplit creates the configuration for the traffic split service named id, and defined by tService.
+// It adds it to the given conf map.
+func (c configBuilder) buildTrafficSplit(ctx context.Context, tService *traefikv1alpha1.TraefikService, id string, conf map[string]*dynamic.Service) error {
+	trafficSplit := &dynamic.TrafficSplit{}
+	trafficSplit.SetDefaults()
+
+	trafficSplit.Cookie = tService.Spec.TrafficSplit.Cookie
+	trafficSplit.HashHeader = tService.Spec.TrafficSplit.HashHeader
+	if tService.Spec.TrafficSplit.VariantHeader != "" {
+		trafficSplit.VariantHeader = tService.Spec.TrafficSplit.VariantHeader
+	}
+
+	for _, variant := range tService.Spec.TrafficSplit.Variants {
+		fullName, k8sService, err := c.nameAndService(ctx, tService.Namespace, variant.LoadBalancerSpec)
+		if err != nil {
+			return err
+		}
+
+		if k8sService != nil {
+			conf[fullName] = k8sService
+		}
+
+		trafficSplit.Variants = append(trafficSplit.Variants, dynamic.SplitVariant{
+			Name:    variant.Variant,
+			Service: fullName,
+			Percent: variant.Percent,
+		})
+	}
+
+	conf[id] = &dynamic.Service{TrafficSplit: trafficSplit}
+
+	return nil
+}
+
 // buildServersLB creates the configuration for the load-balancer of servers defined by svc.
 func (c configBuilder) buildServersLB(namespace string, svc traefikv1alpha1.LoadBalancerSpec) (*dynamic.Service, error) {
 	servers, err := c.loadServers(namespace, svc)
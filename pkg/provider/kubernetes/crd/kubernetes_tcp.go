@@ -202,6 +202,9 @@ func (p *Provider) createLoadBalancerServerTCP(client Client, parentNamespace st
 		if service.ProxyProtocol.Version != 0 {
 			tcpService.LoadBalancer.ProxyProtocol.Version = service.ProxyProtocol.Version
 		}
+
+		tcpService.LoadBalancer.ProxyProtocol.TLVs = service.ProxyProtocol.TLVs
+		tcpService.LoadBalancer.ProxyProtocol.PassthroughTLVs = service.ProxyProtocol.PassthroughTLVs
 	}
 
 	if service.ServersTransport == "" && service.TerminationDelay != nil {
@@ -2989,6 +2989,157 @@ func TestLoadIngressRoutes(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:  "mirroring with the options of the mirrors",
+			paths: []string{"with_mirroring_options.yml"},
+			expected: &dynamic.Configuration{
+				UDP: &dynamic.UDPConfiguration{
+					Routers:  map[string]*dynamic.UDPRouter{},
+					Services: map[string]*dynamic.UDPService{},
+				},
+				TLS: &dynamic.TLSConfiguration{},
+				TCP: &dynamic.TCPConfiguration{
+					Routers:           map[string]*dynamic.TCPRouter{},
+					Middlewares:       map[string]*dynamic.TCPMiddleware{},
+					Services:          map[string]*dynamic.TCPService{},
+					ServersTransports: map[string]*dynamic.TCPServersTransport{},
+				},
+				HTTP: &dynamic.HTTPConfiguration{
+					Routers: map[string]*dynamic.Router{
+						"default-test-route-77c62dfe9517144aeeaa": {
+							EntryPoints: []string{"web"},
+							Service:     "default-mirror1",
+							Rule:        "Host(`foo.com`) && PathPrefix(`/foo`)",
+							Priority:    12,
+						},
+					},
+					Middlewares: map[string]*dynamic.Middleware{},
+					Services: map[string]*dynamic.Service{
+						"default-mirror1": {
+							Mirroring: &dynamic.Mirroring{
+								Service: "default-whoami4-8080",
+								Mirrors: []dynamic.MirrorService{
+									{
+										Name:        "default-whoami5-8080",
+										Percent:     50,
+										Match:       "Method(`POST`) && PathPrefix(`/api`)",
+										MaxBodySize: func(i int64) *int64 { return &i }(1024),
+										Timeout:     ptypes.Duration(2 * time.Second),
+									},
+								},
+							},
+						},
+						"default-whoami4-8080": {
+							LoadBalancer: &dynamic.ServersLoadBalancer{
+								Servers: []dynamic.Server{
+									{
+										URL: "http://10.10.0.1:8080",
+									},
+									{
+										URL: "http://10.10.0.2:8080",
+									},
+								},
+								PassHostHeader: Bool(true),
+								ResponseForwarding: &dynamic.ResponseForwarding{
+									FlushInterval: ptypes.Duration(100 * time.Millisecond),
+								},
+							},
+						},
+						"default-whoami5-8080": {
+							LoadBalancer: &dynamic.ServersLoadBalancer{
+								Servers: []dynamic.Server{
+									{
+										URL: "http://10.10.0.3:8080",
+									},
+									{
+										URL: "http://10.10.0.4:8080",
+									},
+								},
+								PassHostHeader: Bool(true),
+								ResponseForwarding: &dynamic.ResponseForwarding{
+									FlushInterval: ptypes.Duration(100 * time.Millisecond),
+								},
+							},
+						},
+					},
+					ServersTransports: map[string]*dynamic.ServersTransport{},
+				},
+			},
+		},
+		{
+			desc:  "traffic split between two services",
+			paths: []string{"with_traffic_split.yml"},
+			expected: &dynamic.Configuration{
+				UDP: &dynamic.UDPConfiguration{
+					Routers:  map[string]*dynamic.UDPRouter{},
+					Services: map[string]*dynamic.UDPService{},
+				},
+				TLS: &dynamic.TLSConfiguration{},
+				TCP: &dynamic.TCPConfiguration{
+					Routers:           map[string]*dynamic.TCPRouter{},
+					Middlewares:       map[string]*dynamic.TCPMiddleware{},
+					Services:          map[string]*dynamic.TCPService{},
+					ServersTransports: map[string]*dynamic.TCPServersTransport{},
+				},
+				HTTP: &dynamic.HTTPConfiguration{
+					Routers: map[string]*dynamic.Router{
+						"default-test-route-77c62dfe9517144aeeaa": {
+							EntryPoints: []string{"web"},
+							Service:     "default-split1",
+							Rule:        "Host(`foo.com`) && PathPrefix(`/foo`)",
+							Priority:    12,
+						},
+					},
+					Middlewares: map[string]*dynamic.Middleware{},
+					Services: map[string]*dynamic.Service{
+						"default-split1": {
+							TrafficSplit: &dynamic.TrafficSplit{
+								Variants: []dynamic.SplitVariant{
+									{Name: "control", Service: "default-whoami4-8080", Percent: 90},
+									{Name: "new-checkout", Service: "default-whoami5-8080", Percent: 10},
+								},
+								Cookie:        &dynamic.Cookie{Name: "ab_variant"},
+								HashHeader:    "X-User-Id",
+								VariantHeader: "X-Variant",
+							},
+						},
+						"default-whoami4-8080": {
+							LoadBalancer: &dynamic.ServersLoadBalancer{
+								Servers: []dynamic.Server{
+									{
+										URL: "http://10.10.0.1:8080",
+									},
+									{
+										URL: "http://10.10.0.2:8080",
+									},
+								},
+								PassHostHeader: Bool(true),
+								ResponseForwarding: &dynamic.ResponseForwarding{
+									FlushInterval: ptypes.Duration(100 * time.Millisecond),
+								},
+							},
+						},
+						"default-whoami5-8080": {
+							LoadBalancer: &dynamic.ServersLoadBalancer{
+								Servers: []dynamic.Server{
+									{
+										URL: "http://10.10.0.3:8080",
+									},
+									{
+										URL: "http://10.10.0.4:8080",
+									},
+								},
+								PassHostHeader: Bool(true),
+								ResponseForwarding: &dynamic.ResponseForwarding{
+									FlushInterval: ptypes.Duration(100 * time.Millisecond),
+								},
+							},
+						},
+					},
+					ServersTransports: map[string]*dynamic.ServersTransport{},
+				},
+			},
+		},
 		{
 			desc:  "One ingress Route with two different services, with weights",
 			paths: []string{"services.yml", "with_two_services_weight.yml"},
@@ -4114,6 +4265,238 @@ func TestLoadIngressRoutes(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:  "Simple Ingress Route, with error page middleware using status services and a body",
+			paths: []string{"services.yml", "with_error_page_status_services.yml"},
+			expected: &dynamic.Configuration{
+				UDP: &dynamic.UDPConfiguration{
+					Routers:  map[string]*dynamic.UDPRouter{},
+					Services: map[string]*dynamic.UDPService{},
+				},
+				TLS: &dynamic.TLSConfiguration{},
+				TCP: &dynamic.TCPConfiguration{
+					Routers:           map[string]*dynamic.TCPRouter{},
+					Middlewares:       map[string]*dynamic.TCPMiddleware{},
+					Services:          map[string]*dynamic.TCPService{},
+					ServersTransports: map[string]*dynamic.TCPServersTransport{},
+				},
+				HTTP: &dynamic.HTTPConfiguration{
+					Routers: map[string]*dynamic.Router{},
+					Middlewares: map[string]*dynamic.Middleware{
+						"default-errorpage": {
+							Errors: &dynamic.ErrorPage{
+								Status: []string{"400-599"},
+								StatusServices: map[string]string{
+									"404": "default-errorpage-errorpage-service-404",
+									"5xx": "default-errorpage-errorpage-service-5xx",
+								},
+								Body: "{{ .Status }}",
+							},
+						},
+					},
+					Services: map[string]*dynamic.Service{
+						"default-errorpage-errorpage-service-404": {
+							LoadBalancer: &dynamic.ServersLoadBalancer{
+								Servers: []dynamic.Server{
+									{
+										URL: "http://10.10.0.1:80",
+									},
+									{
+										URL: "http://10.10.0.2:80",
+									},
+								},
+								PassHostHeader: Bool(true),
+								ResponseForwarding: &dynamic.ResponseForwarding{
+									FlushInterval: ptypes.Duration(100 * time.Millisecond),
+								},
+							},
+						},
+						"default-errorpage-errorpage-service-5xx": {
+							LoadBalancer: &dynamic.ServersLoadBalancer{
+								Servers: []dynamic.Server{
+									{
+										URL: "http://10.10.0.3:8080",
+									},
+									{
+										URL: "http://10.10.0.4:8080",
+									},
+								},
+								PassHostHeader: Bool(true),
+								ResponseForwarding: &dynamic.ResponseForwarding{
+									FlushInterval: ptypes.Duration(100 * time.Millisecond),
+								},
+							},
+						},
+					},
+					ServersTransports: map[string]*dynamic.ServersTransport{},
+				},
+			},
+		},
+		{
+			desc:  "Simple Ingress Route, with middlewares reading secrets",
+			paths: []string{"services.yml", "with_secret_middlewares.yml"},
+			expected: &dynamic.Configuration{
+				UDP: &dynamic.UDPConfiguration{
+					Routers:  map[string]*dynamic.UDPRouter{},
+					Services: map[string]*dynamic.UDPService{},
+				},
+				TLS: &dynamic.TLSConfiguration{},
+				TCP: &dynamic.TCPConfiguration{
+					Routers:           map[string]*dynamic.TCPRouter{},
+					Middlewares:       map[string]*dynamic.TCPMiddleware{},
+					Services:          map[string]*dynamic.TCPService{},
+					ServersTransports: map[string]*dynamic.TCPServersTransport{},
+				},
+				HTTP: &dynamic.HTTPConfiguration{
+					Routers: map[string]*dynamic.Router{},
+					Middlewares: map[string]*dynamic.Middleware{
+						"default-oidc": {
+							OIDC: &dynamic.OIDC{
+								Issuer:        "https://auth.example.com",
+								ClientID:      "traefik",
+								ClientSecret:  "client-secret",
+								SessionSecret: "session-secret",
+							},
+						},
+						"default-jwt": {
+							JWT: &dynamic.JWT{
+								SigningSecret: "signing-secret",
+								Issuer:        "https://auth.example.com",
+							},
+						},
+						"default-cache": {
+							Cache: &dynamic.Cache{
+								TTL: ptypes.Duration(time.Minute),
+								Store: &dynamic.CacheStore{
+									Redis: &dynamic.Redis{
+										Endpoints: []string{"redis:6379"},
+										Password:  "redis-password",
+									},
+								},
+							},
+						},
+						"default-basicauth": {
+							BasicAuth: &dynamic.BasicAuth{
+								LDAP: &dynamic.BasicAuthLDAP{
+									URL:          "ldaps://ldap.example.com",
+									BindDN:       "cn=traefik,dc=example,dc=com",
+									BindPassword: "bind-password",
+									BaseDN:       "ou=users,dc=example,dc=com",
+									UserFilter:   "(uid=%s)",
+									PoolSize:     10,
+									CacheTTL:     ptypes.Duration(time.Minute),
+								},
+							},
+						},
+						"default-ratelimit": {
+							RateLimit: &dynamic.RateLimit{
+								Average: 100,
+								Burst:   1,
+								Period:  ptypes.Duration(time.Second),
+								Redis: &dynamic.Redis{
+									Endpoints: []string{"redis:6379"},
+									Password:  "redis-password",
+								},
+							},
+						},
+						"default-hmacsignature": {
+							HMACSignature: &dynamic.HMACSignature{
+								Keys:      []dynamic.HMACSignatureKey{{ID: "v1", Secret: "hmac-secret"}},
+								ClockSkew: ptypes.Duration(30 * time.Second),
+							},
+						},
+						"default-apikeyauth": {
+							APIKeyAuth: &dynamic.APIKeyAuth{
+								Keys: []dynamic.APIKey{
+									{Name: "bar", Value: "bar-key"},
+									{Name: "foo", Value: "foo-key"},
+								},
+							},
+						},
+						"default-upstreamauth": {
+							UpstreamAuth: &dynamic.UpstreamAuth{
+								Basic: &dynamic.UpstreamBasicAuth{
+									Username: "user",
+									Password: "password",
+								},
+							},
+						},
+					},
+					Services:          map[string]*dynamic.Service{},
+					ServersTransports: map[string]*dynamic.ServersTransport{},
+				},
+			},
+		},
+		{
+			desc:  "Simple Ingress Route, with canary middleware and middlewares with defaults",
+			paths: []string{"services.yml", "with_canary_and_defaults_middlewares.yml"},
+			expected: &dynamic.Configuration{
+				UDP: &dynamic.UDPConfiguration{
+					Routers:  map[string]*dynamic.UDPRouter{},
+					Services: map[string]*dynamic.UDPService{},
+				},
+				TLS: &dynamic.TLSConfiguration{},
+				TCP: &dynamic.TCPConfiguration{
+					Routers:           map[string]*dynamic.TCPRouter{},
+					Middlewares:       map[string]*dynamic.TCPMiddleware{},
+					Services:          map[string]*dynamic.TCPService{},
+					ServersTransports: map[string]*dynamic.TCPServersTransport{},
+				},
+				HTTP: &dynamic.HTTPConfiguration{
+					Routers: map[string]*dynamic.Router{},
+					Middlewares: map[string]*dynamic.Middleware{
+						"default-canary": {
+							Canary: &dynamic.Canary{
+								Service: "default-whoami-80",
+								Headers: map[string]string{"X-Canary": "true"},
+							},
+						},
+						"default-tarpit": {
+							Tarpit: &dynamic.Tarpit{
+								SourceRange: []string{"10.0.0.0/8"},
+								Rate: &dynamic.TarpitRate{
+									Average: 10,
+									Period:  ptypes.Duration(time.Second),
+									Burst:   1,
+								},
+								Delay:         ptypes.Duration(5 * time.Second),
+								MaxDelay:      ptypes.Duration(time.Minute),
+								MaxConcurrent: 100,
+							},
+						},
+						"default-formatconversion": {
+							FormatConversion: &dynamic.FormatConversion{
+								Response: true,
+							},
+						},
+						"default-headerallowlist": {
+							HeaderAllowList: &dynamic.HeaderAllowList{
+								RequestHeaders: []string{"Authorization"},
+							},
+						},
+					},
+					Services: map[string]*dynamic.Service{
+						"default-whoami-80": {
+							LoadBalancer: &dynamic.ServersLoadBalancer{
+								Servers: []dynamic.Server{
+									{
+										URL: "http://10.10.0.1:80",
+									},
+									{
+										URL: "http://10.10.0.2:80",
+									},
+								},
+								PassHostHeader: Bool(true),
+								ResponseForwarding: &dynamic.ResponseForwarding{
+									FlushInterval: ptypes.Duration(100 * time.Millisecond),
+								},
+							},
+						},
+					},
+					ServersTransports: map[string]*dynamic.ServersTransport{},
+				},
+			},
+		},
 		{
 			desc:  "Simple Ingress Route, with options",
 			paths: []string{"services.yml", "with_options.yml"},
@@ -4538,6 +4921,7 @@ func TestLoadIngressRoutes(t *testing.T) {
 								IdleConnTimeout:       ptypes.Duration(42 * time.Millisecond),
 								ReadIdleTimeout:       ptypes.Duration(42 * time.Second),
 								PingTimeout:           ptypes.Duration(42 * time.Second),
+								HTTP3HandshakeTimeout: ptypes.Duration(42 * time.Millisecond),
 							},
 							PeerCertURI: "foo://bar",
 							Spiffe: &dynamic.Spiffe{
@@ -4551,9 +4935,10 @@ func TestLoadIngressRoutes(t *testing.T) {
 						"default-test": {
 							ServerName: "test",
 							ForwardingTimeouts: &dynamic.ForwardingTimeouts{
-								DialTimeout:     ptypes.Duration(30 * time.Second),
-								IdleConnTimeout: ptypes.Duration(90 * time.Second),
-								PingTimeout:     ptypes.Duration(15 * time.Second),
+								DialTimeout:           ptypes.Duration(30 * time.Second),
+								IdleConnTimeout:       ptypes.Duration(90 * time.Second),
+								PingTimeout:           ptypes.Duration(15 * time.Second),
+								HTTP3HandshakeTimeout: ptypes.Duration(3 * time.Second),
 							},
 						},
 					},
@@ -6299,6 +6684,7 @@ func TestCrossNamespace(t *testing.T) {
 								IdleConnTimeout:       90000000000,
 								ReadIdleTimeout:       0,
 								PingTimeout:           15000000000,
+								HTTP3HandshakeTimeout: 3000000000,
 							},
 							DisableHTTP2: true,
 						},
@@ -6333,6 +6719,7 @@ func TestCrossNamespace(t *testing.T) {
 								IdleConnTimeout:       90000000000,
 								ReadIdleTimeout:       0,
 								PingTimeout:           15000000000,
+								HTTP3HandshakeTimeout: 3000000000,
 							},
 							DisableHTTP2: true,
 						},
@@ -51,6 +51,45 @@ type MiddlewareSpec struct {
 	Retry             *Retry                     `json:"retry,omitempty"`
 	ContentType       *dynamic.ContentType       `json:"contentType,omitempty"`
 	GrpcWeb           *dynamic.GrpcWeb           `json:"grpcWeb,omitempty"`
+	OIDC              *OIDC                      `json:"oidc,omitempty"`
+	JWT               *JWT                       `json:"jwt,omitempty"`
+	Cache             *Cache                     `json:"cache,omitempty"`
+	WAF               *dynamic.WAF               `json:"waf,omitempty"`
+	GeoIP             *dynamic.GeoIP             `json:"geoIP,omitempty"`
+	BotManager        *BotManager                `json:"botManager,omitempty"`
+	BodyValidation    *dynamic.BodyValidation    `json:"bodyValidation,omitempty"`
+	RewriteBody       *RewriteBody               `json:"rewriteBody,omitempty"`
+	// Script defines the script middleware configuration.
+	// The services selected by the expression are referenced by their name in the Traefik configuration,
+	// e.g. <namespace>-<name> for a TraefikService.
+	Script           *dynamic.Script           `json:"script,omitempty"`
+	Query            *dynamic.Query            `json:"query,omitempty"`
+	Cookies          *Cookies                  `json:"cookies,omitempty"`
+	CORS             *dynamic.CORS             `json:"cors,omitempty"`
+	CSRF             *CSRF                     `json:"csrf,omitempty"`
+	OPA              *OPA                      `json:"opa,omitempty"`
+	HMACSignature    *HMACSignature            `json:"hmacSignature,omitempty"`
+	AWSSigV4         *AWSSigV4                 `json:"awsSigV4,omitempty"`
+	Maintenance      *Maintenance              `json:"maintenance,omitempty"`
+	RedirectMap      *dynamic.RedirectMap      `json:"redirectMap,omitempty"`
+	RequestID        *dynamic.RequestID        `json:"requestId,omitempty"`
+	Tarpit           *Tarpit                   `json:"tarpit,omitempty"`
+	Fail2Ban         *Fail2Ban                 `json:"fail2Ban,omitempty"`
+	GraphQL          *dynamic.GraphQL          `json:"graphQL,omitempty"`
+	WebSocket        *WebSocket                `json:"webSocket,omitempty"`
+	SSE              *SSE                      `json:"sse,omitempty"`
+	SignedURL        *SignedURL                `json:"signedURL,omitempty"`
+	APIKeyAuth       *APIKeyAuth               `json:"apiKeyAuth,omitempty"`
+	Coalesce         *dynamic.Coalesce         `json:"coalesce,omitempty"`
+	Canary           *Canary                   `json:"canary,omitempty"`
+	FormatConversion *FormatConversion         `json:"formatConversion,omitempty"`
+	HeaderAllowList  *dynamic.HeaderAllowList  `json:"headerAllowList,omitempty"`
+	ConcurrencyQuota *dynamic.ConcurrencyQuota `json:"concurrencyQuota,omitempty"`
+	MapPath          *dynamic.MapPath          `json:"mapPath,omitempty"`
+	MapHost          *dynamic.MapHost          `json:"mapHost,omitempty"`
+	SecurityHeaders  *dynamic.SecurityHeaders  `json:"securityHeaders,omitempty"`
+	UpstreamAuth     *UpstreamAuth             `json:"upstreamAuth,omitempty"`
+	Idempotency      *Idempotency              `json:"idempotency,omitempty"`
 	// Plugin defines the middleware plugin configuration.
 	// More info: https://doc.traefik.io/traefik/plugins/
 	Plugin map[string]apiextensionv1.JSON `json:"plugin,omitempty"`
@@ -71,9 +110,20 @@ type ErrorPage struct {
 	// Service defines the reference to a Kubernetes Service that will serve the error page.
 	// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/errorpages/#service
 	Service Service `json:"service,omitempty"`
+	// StatusServices defines the references to the Kubernetes Services serving the error pages of specific statuses, overriding the Service.
+	// The statuses are given as a status code (404), a range of status codes (500-504),
+	// or a class of status codes (5xx), and the narrowest one matching the status is used.
+	StatusServices map[string]Service `json:"statusServices,omitempty"`
 	// Query defines the URL for the error page (hosted by service).
 	// The {status} variable can be used in order to insert the status code in the URL.
 	Query string `json:"query,omitempty"`
+	// ContentType defines the content type of the error pages built from the Body or the File.
+	// Default: text/html; charset=utf-8.
+	ContentType string `json:"contentType,omitempty"`
+	// Body defines the Go template of the error pages, served instead of calling the Service.
+	Body string `json:"body,omitempty"`
+	// File defines the path of the file holding the Go template of the error pages, served instead of calling the Service.
+	File string `json:"file,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -90,6 +140,11 @@ type CircuitBreaker struct {
 	RecoveryDuration *intstr.IntOrString `json:"recoveryDuration,omitempty" toml:"recoveryDuration,omitempty" yaml:"recoveryDuration,omitempty" export:"true"`
 	// ResponseCode is the status code that the circuit breaker will return while it is in the open state.
 	ResponseCode int `json:"responseCode,omitempty" toml:"responseCode,omitempty" yaml:"responseCode,omitempty" export:"true"`
+	// ProbePercent is the percentage of requests forwarded to the services at the end of the recovering state.
+	// The percentage of forwarded requests grows linearly from 0 to this value during the recovery duration.
+	ProbePercent int `json:"probePercent,omitempty" toml:"probePercent,omitempty" yaml:"probePercent,omitempty" export:"true"`
+	// PerBackend defines whether the circuit breaker holds one state per server (or per child service of a weighted service), instead of one state for the whole service.
+	PerBackend bool `json:"perBackend,omitempty" toml:"perBackend,omitempty" yaml:"perBackend,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -119,6 +174,37 @@ type BasicAuth struct {
 	// HeaderField defines a header field to store the authenticated user.
 	// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/basicauth/#headerfield
 	HeaderField string `json:"headerField,omitempty"`
+	// LDAP defines the LDAP server validating the credentials of the users which are not declared in the Secret.
+	LDAP *BasicAuthLDAP `json:"ldap,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// BasicAuthLDAP holds the configuration of the LDAP, or Active Directory, server validating the basic auth credentials.
+type BasicAuthLDAP struct {
+	// URL defines the URL of the LDAP server, with the ldap or ldaps scheme.
+	URL string `json:"url,omitempty"`
+	// StartTLS defines whether the connection to the ldap URL is upgraded to TLS.
+	StartTLS bool `json:"startTLS,omitempty"`
+	// BindDN defines the DN of the account searching the users, an anonymous bind being used if empty.
+	BindDN string `json:"bindDN,omitempty"`
+	// BindPasswordSecret is the name of the referenced Kubernetes Secret containing the password of the BindDN account, in the `bindPassword` key.
+	BindPasswordSecret string `json:"bindPasswordSecret,omitempty"`
+	// BaseDN defines the DN under which the users are searched.
+	BaseDN string `json:"baseDN,omitempty"`
+	// UserFilter defines the filter searching the user, %s being replaced by the username.
+	// Default: (uid=%s).
+	UserFilter string `json:"userFilter,omitempty"`
+	// GroupFilter defines the filter the user must also match, e.g. to require a group membership.
+	GroupFilter string `json:"groupFilter,omitempty"`
+	// PoolSize defines the maximum number of idle connections kept open to the LDAP server.
+	// Default: 10.
+	PoolSize int `json:"poolSize,omitempty"`
+	// CacheTTL defines how long the validated credentials are cached, 0 disabling the cache.
+	// Default: 5m.
+	CacheTTL *intstr.IntOrString `json:"cacheTTL,omitempty"`
+	// TLS defines the configuration used to secure the connection to the LDAP server.
+	TLS *ClientTLS `json:"tls,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -161,6 +247,46 @@ type ForwardAuth struct {
 	TLS *ClientTLS `json:"tls,omitempty"`
 	// AddAuthCookiesToResponse defines the list of cookies to copy from the authentication server response to the response.
 	AddAuthCookiesToResponse []string `json:"addAuthCookiesToResponse,omitempty"`
+	// ForwardBody defines whether to forward the request body to the authentication server.
+	ForwardBody bool `json:"forwardBody,omitempty"`
+	// MaxBodySize defines the maximum size, in bytes, of the body prefix forwarded to the authentication server.
+	// The rest of the body is only forwarded to the service.
+	// Default: 1048576.
+	MaxBodySize int64 `json:"maxBodySize,omitempty"`
+	// Retry defines how the calls to the authentication server failing with a transient error are retried.
+	Retry *ForwardAuthRetry `json:"retry,omitempty"`
+	// Cache defines how the allow decisions of the authentication server are cached.
+	Cache *ForwardAuthCache `json:"cache,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// ForwardAuthRetry holds the retry configuration of the calls to the authentication server.
+// The calls failing with a network error, or with a 502, 503 or 504 status code, are retried.
+type ForwardAuthRetry struct {
+	// Attempts defines how many times the call to the authentication server is attempted.
+	Attempts int `json:"attempts,omitempty"`
+	// InitialInterval defines the first wait time in the exponential backoff series.
+	// If unspecified, the calls are retried immediately.
+	// The value of initialInterval should be provided in seconds or as a valid duration format,
+	// see https://pkg.go.dev/time#ParseDuration.
+	InitialInterval intstr.IntOrString `json:"initialInterval,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// ForwardAuthCache holds the cache configuration of the allow decisions of the authentication server.
+type ForwardAuthCache struct {
+	// Key defines the values of the request the decisions are cached for, as a source expression, e.g. Header(`Authorization`).
+	// The requests whose key is empty are not cached.
+	Key string `json:"key,omitempty"`
+	// TTL defines how long an allow decision is cached.
+	// The value of ttl should be provided in seconds or as a valid duration format,
+	// see https://pkg.go.dev/time#ParseDuration.
+	TTL intstr.IntOrString `json:"ttl,omitempty"`
+	// MaxEntries defines the maximum number of cached decisions.
+	// Default: 10000.
+	MaxEntries int `json:"maxEntries,omitempty"`
 }
 
 // ClientTLS holds the client TLS configuration.
@@ -199,6 +325,9 @@ type RateLimit struct {
 	// If several strategies are defined at the same time, an error will be raised.
 	// If none are set, the default is to use the request's remote address field (as an ipStrategy).
 	SourceCriterion *dynamic.SourceCriterion `json:"sourceCriterion,omitempty"`
+	// Redis defines the Redis server storing the token buckets, to share the rate limit across the Traefik instances.
+	// If not set, the token buckets are kept in memory, and the rate limit applies to each Traefik instance.
+	Redis *Redis `json:"redis,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -219,6 +348,22 @@ type Compress struct {
 	Encodings []string `json:"encodings,omitempty"`
 	// DefaultEncoding specifies the default encoding if the `Accept-Encoding` header is not in the request or contains a wildcard (`*`).
 	DefaultEncoding *string `json:"defaultEncoding,omitempty"`
+	// NegotiationOrder defines which preference wins when choosing the encoding:
+	// client (the weights of the `Accept-Encoding` header), or server (the order of Encodings).
+	// Default: client.
+	NegotiationOrder *string `json:"negotiationOrder,omitempty"`
+	// ContentTypeMinSizes defines the minimum amount of bytes a response body must have to be compressed, for the given content types.
+	// They take precedence over MinResponseBodyBytes.
+	ContentTypeMinSizes []dynamic.CompressContentTypeMinSize `json:"contentTypeMinSizes,omitempty"`
+	// Levels defines the compression level of each algorithm.
+	Levels *dynamic.CompressLevels `json:"levels,omitempty"`
+	// ExcludedPaths defines the list of request path prefixes for which the responses are not compressed.
+	ExcludedPaths []string `json:"excludedPaths,omitempty"`
+	// ExcludedRouters defines the list of routers for which the responses are not compressed.
+	ExcludedRouters []string `json:"excludedRouters,omitempty"`
+	// PreCompressed defines whether only the negotiated encoding is requested from the service, for it to send its pre-compressed variant of the response.
+	// The responses already encoded by the service are forwarded as is, and the other ones are compressed.
+	PreCompressed bool `json:"preCompressed,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -236,6 +381,648 @@ type Retry struct {
 	// The value of initialInterval should be provided in seconds or as a valid duration format,
 	// see https://pkg.go.dev/time#ParseDuration.
 	InitialInterval intstr.IntOrString `json:"initialInterval,omitempty"`
+	// Budget limits the share of the requests that can be retried or hedged, to avoid retry storms.
+	Budget *RetryBudget `json:"budget,omitempty"`
+	// Hedging defines the hedging of the requests, i.e. sending a duplicate request when the first one is slow to answer.
+	Hedging *RetryHedging `json:"hedging,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RetryBudget holds the retry budget configuration.
+type RetryBudget struct {
+	// Percent defines the maximum percentage of the requests, over the last ten seconds, that can be retried or hedged.
+	// Default: 20.
+	Percent *int `json:"percent,omitempty"`
+	// MinRetriesPerSecond defines the number of retries per second allowed regardless of the Percent,
+	// so that the requests of a low traffic can still be retried.
+	// Default: 10.
+	MinRetriesPerSecond *int `json:"minRetriesPerSecond,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RetryHedging holds the request hedging configuration.
+type RetryHedging struct {
+	// Delay defines how long to wait for the response before sending a duplicate request,
+	// the first response received being forwarded to the client.
+	// Only the GET, HEAD, and OPTIONS requests without a body are hedged.
+	Delay intstr.IntOrString `json:"delay,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// OIDC holds the OpenID Connect middleware configuration.
+// This middleware authenticates the users with an OpenID Connect provider, keeping their session in an encrypted cookie.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/oidc/
+type OIDC struct {
+	// Issuer is the URL of the OpenID Connect provider, its configuration being discovered from the /.well-known/openid-configuration path.
+	Issuer string `json:"issuer,omitempty"`
+	// ClientID is the identifier of the client registered at the provider.
+	ClientID string `json:"clientID,omitempty"`
+	// Secret is the name of the referenced Kubernetes Secret containing the client secret, in the `clientSecret` key,
+	// and the secret used to encrypt the session cookie, in the `sessionSecret` key.
+	Secret string `json:"secret,omitempty"`
+	// Scopes defines the scopes requested to the provider.
+	// Default: openid, profile, email.
+	Scopes []string `json:"scopes,omitempty"`
+	// RedirectPath defines the path of the callback of the provider, handled by the middleware.
+	// Default: /oauth2/callback.
+	RedirectPath string `json:"redirectPath,omitempty"`
+	// LogoutPath defines the path, handled by the middleware, ending the session of the user.
+	// Default: /oauth2/logout.
+	LogoutPath string `json:"logoutPath,omitempty"`
+	// PostLogoutRedirectURI defines the URL the user is redirected to once logged out.
+	// Default: /.
+	PostLogoutRedirectURI string `json:"postLogoutRedirectURI,omitempty"`
+	// SessionCookie defines the session cookie.
+	SessionCookie *dynamic.Cookie `json:"sessionCookie,omitempty"`
+	// ClaimsHeaders defines the request headers set from the claims of the ID token, keyed by header name.
+	ClaimsHeaders map[string]string `json:"claimsHeaders,omitempty"`
+	// ForwardAccessToken defines whether to forward the access token in the Authorization header.
+	ForwardAccessToken bool `json:"forwardAccessToken,omitempty"`
+	// TLS defines the configuration used to secure the connection to the provider.
+	TLS *ClientTLS `json:"tls,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// JWT holds the JWT middleware configuration.
+// This middleware verifies the JSON Web Tokens of the requests.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/jwt/
+type JWT struct {
+	// Secret is the name of the referenced Kubernetes Secret containing the secret used to verify the tokens signed with an HMAC algorithm, in the `signingSecret` key,
+	// and the PEM encoded public key used to verify the tokens, in the `publicKey` key.
+	Secret string `json:"secret,omitempty"`
+	// JWKSURL defines the URL of the JSON Web Key Set used to verify the tokens.
+	JWKSURL string `json:"jwksURL,omitempty"`
+	// Issuer defines the expected issuer (iss claim) of the tokens.
+	Issuer string `json:"issuer,omitempty"`
+	// Audience defines the accepted audiences (aud claim) of the tokens, at least one of them being expected.
+	Audience []string `json:"audience,omitempty"`
+	// HeaderName defines the request header holding the token.
+	// Default: Authorization.
+	HeaderName string `json:"headerName,omitempty"`
+	// RemoveHeader defines whether to remove the header holding the token before forwarding the request to the backend.
+	RemoveHeader bool `json:"removeHeader,omitempty"`
+	// ClaimsHeaders defines the request headers set from the claims of the token, keyed by header name.
+	ClaimsHeaders map[string]string `json:"claimsHeaders,omitempty"`
+	// RejectStatusCode defines the status code of the response to the requests without a valid token.
+	// Default: 401.
+	RejectStatusCode int `json:"rejectStatusCode,omitempty"`
+	// TLS defines the configuration used to secure the connection to the JWKS URL.
+	TLS *ClientTLS `json:"tls,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Cache holds the cache middleware configuration.
+// This middleware stores the cacheable responses, and serves them to the following requests.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/cache/
+type Cache struct {
+	// TTL defines the freshness lifetime of the stored responses, overriding the one given by the Cache-Control and Expires response headers.
+	// The value of ttl should be provided in seconds or as a valid duration format,
+	// see https://pkg.go.dev/time#ParseDuration.
+	TTL *intstr.IntOrString `json:"ttl,omitempty"`
+	// DefaultTTL defines the freshness lifetime of the cacheable responses which do not define one.
+	DefaultTTL *intstr.IntOrString `json:"defaultTTL,omitempty"`
+	// StaleWhileRevalidate defines the duration during which a stale response is served while it is revalidated in the background.
+	StaleWhileRevalidate *intstr.IntOrString `json:"staleWhileRevalidate,omitempty"`
+	// MaxBodySize defines the maximum size, in bytes, of the body of a stored response.
+	// Default: 1048576.
+	MaxBodySize int64 `json:"maxBodySize,omitempty"`
+	// Key defines the parts of the request making up the cache key.
+	Key *dynamic.CacheKey `json:"key,omitempty"`
+	// Store defines where the responses are stored.
+	// Default: in memory.
+	Store *CacheStore `json:"store,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// CacheStore holds the store of the cache middleware.
+type CacheStore struct {
+	// Memory defines an in-memory store, evicting the least recently used responses.
+	Memory *dynamic.MemoryCacheStore `json:"memory,omitempty"`
+	// Redis defines a Redis store, shared by the Traefik instances.
+	Redis *Redis `json:"redis,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Redis holds the Redis server configuration.
+type Redis struct {
+	// Endpoints defines the addresses of the Redis servers.
+	Endpoints []string `json:"endpoints,omitempty"`
+	// Username defines the username used to authenticate.
+	Username string `json:"username,omitempty"`
+	// Secret is the name of the referenced Kubernetes Secret containing the password used to authenticate, in the `password` key.
+	Secret string `json:"secret,omitempty"`
+	// DB defines the database selected after connecting to the server.
+	DB int `json:"db,omitempty"`
+	// TLS defines the configuration used to secure the connection to the servers.
+	TLS *ClientTLS `json:"tls,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// BotManager holds the bot manager middleware configuration.
+// This middleware identifies the bots, and allows, blocks, challenges, or delays their requests according to their category.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/botmanager/
+type BotManager struct {
+	// Actions defines the action applied to each bot category: allow, block, challenge, or tarpit.
+	// The categories without action are allowed.
+	Actions map[string]string `json:"actions,omitempty"`
+	// Signatures defines additional bot signatures, matched before the built-in ones.
+	Signatures []dynamic.BotSignature `json:"signatures,omitempty"`
+	// ChallengeSecret is the name of the referenced Kubernetes Secret containing the secret used to sign the challenge cookies, in the `secret` key.
+	// If not set, a random secret is generated, and the challenges are solved again after each configuration reload.
+	ChallengeSecret string `json:"challengeSecret,omitempty"`
+	// ChallengeTTL defines how long a solved challenge remains valid.
+	// Default: 1h.
+	ChallengeTTL *intstr.IntOrString `json:"challengeTTL,omitempty"`
+	// TarpitDelay defines how long the tarpitted requests are delayed before being forwarded.
+	// Default: 10s.
+	TarpitDelay *intstr.IntOrString `json:"tarpitDelay,omitempty"`
+	// IPStrategy holds the IP strategy configuration used by Traefik to determine the client IP.
+	IPStrategy *dynamic.IPStrategy `json:"ipStrategy,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RewriteBody holds the rewrite body middleware configuration.
+// This middleware rewrites the content of the request and response bodies.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/rewritebody/
+type RewriteBody struct {
+	// Rewrites defines the rewrites applied, in order, to the bodies.
+	Rewrites []dynamic.BodyRewrite `json:"rewrites,omitempty"`
+	// Request defines whether the request bodies are rewritten.
+	Request bool `json:"request,omitempty"`
+	// Response defines whether the response bodies are rewritten.
+	// Default: true.
+	Response *bool `json:"response,omitempty"`
+	// ContentTypes defines the media types of the rewritten bodies, e.g. text/html or text/*.
+	// If not set, the textual media types are rewritten.
+	ContentTypes []string `json:"contentTypes,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Cookies holds the cookies middleware configuration.
+// This middleware modifies the cookies of the requests, and the cookies set by the responses.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/cookies/
+type Cookies struct {
+	// Request defines the modifications of the cookies sent by the clients.
+	Request *dynamic.CookieModifications `json:"request,omitempty"`
+	// Response defines the modifications of the cookies set by the services.
+	Response *dynamic.CookieModifications `json:"response,omitempty"`
+	// Secure defines whether the Secure attribute is enforced on the cookies set by the responses.
+	Secure bool `json:"secure,omitempty"`
+	// HTTPOnly defines whether the HttpOnly attribute is enforced on the cookies set by the responses.
+	HTTPOnly bool `json:"httpOnly,omitempty"`
+	// SameSite defines the SameSite attribute enforced on the cookies set by the responses: none, lax, or strict.
+	SameSite string `json:"sameSite,omitempty"`
+	// Encryption defines the cookies encrypted in the responses, and decrypted in the requests.
+	Encryption *CookieEncryption `json:"encryption,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// CookieEncryption holds the encryption of the cookies.
+type CookieEncryption struct {
+	// Secret is the name of the referenced Kubernetes Secret containing the secret used to encrypt and authenticate the cookie values, in the `secret` key.
+	Secret string `json:"secret,omitempty"`
+	// Cookies defines the names of the encrypted cookies.
+	Cookies []string `json:"cookies,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// CSRF holds the CSRF middleware configuration.
+// This middleware protects the services against the cross-site request forgery, with the double submit cookie pattern.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/csrf/
+type CSRF struct {
+	// SafeMethods defines the methods of the requests which are not checked.
+	// Default: GET, HEAD, OPTIONS, TRACE.
+	SafeMethods []string `json:"safeMethods,omitempty"`
+	// CookieName defines the name of the cookie holding the token.
+	// Default: _csrf.
+	CookieName string `json:"cookieName,omitempty"`
+	// HeaderName defines the name of the request header holding the token.
+	// Default: X-CSRF-Token.
+	HeaderName string `json:"headerName,omitempty"`
+	// ExemptPaths defines the path prefixes of the requests which are not checked.
+	ExemptPaths []string `json:"exemptPaths,omitempty"`
+	// Secret is the name of the referenced Kubernetes Secret containing the secret used to sign the tokens, in the `secret` key.
+	Secret string `json:"secret,omitempty"`
+	// SessionCookieName defines the name of the session cookie the signed tokens are bound to.
+	SessionCookieName string `json:"sessionCookieName,omitempty"`
+	// Secure defines whether the Secure attribute is set on the token cookie.
+	Secure bool `json:"secure,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// OPA holds the OPA middleware configuration.
+// This middleware authorizes the requests with an Open Policy Agent policy.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/opa/
+type OPA struct {
+	// Policy defines the Rego policy evaluated for each request.
+	Policy string `json:"policy,omitempty"`
+	// BundleURL defines the URL of the policy bundle evaluated for each request.
+	BundleURL string `json:"bundleURL,omitempty"`
+	// PollInterval defines the interval between two downloads of the bundle.
+	// Default: 1m.
+	PollInterval *intstr.IntOrString `json:"pollInterval,omitempty"`
+	// URL defines the URL of the remote OPA server evaluating the decision.
+	URL string `json:"url,omitempty"`
+	// Decision defines the path of the decision document.
+	// Default: traefik/authz.
+	Decision string `json:"decision,omitempty"`
+	// RejectStatusCode defines the status code of the response to the denied requests, unless the decision defines it.
+	// Default: 403.
+	RejectStatusCode int `json:"rejectStatusCode,omitempty"`
+	// TLS defines the configuration used to secure the connection to the bundle URL, or to the remote OPA server.
+	TLS *ClientTLS `json:"tls,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// HMACSignature holds the HMAC signature middleware configuration.
+// This middleware rejects the requests which are not signed with one of the keys, e.g. the webhook calls.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/hmacsignature/
+type HMACSignature struct {
+	// Keys defines the keys accepted to sign the requests, several keys allowing their rotation.
+	Keys []SigningKey `json:"keys,omitempty"`
+	// KeyIDHeader defines the name of the request header holding the ID of the key signing the request.
+	KeyIDHeader string `json:"keyIDHeader,omitempty"`
+	// Algorithm defines the hash function of the HMAC, among sha1, sha256, and sha512.
+	// Default: sha256.
+	Algorithm string `json:"algorithm,omitempty"`
+	// SignatureHeader defines the name of the request header holding the signature.
+	// Default: X-Signature.
+	SignatureHeader string `json:"signatureHeader,omitempty"`
+	// SignaturePrefix defines the prefix of the signature in the header, e.g. sha256=.
+	SignaturePrefix string `json:"signaturePrefix,omitempty"`
+	// Encoding defines the encoding of the signature, hex or base64.
+	// Default: hex.
+	Encoding string `json:"encoding,omitempty"`
+	// SignedComponents defines the request components, joined by the Separator, which are signed.
+	// Default: body.
+	SignedComponents []string `json:"signedComponents,omitempty"`
+	// Separator defines the separator of the signed components.
+	// Default: ".".
+	Separator string `json:"separator,omitempty"`
+	// TimestampHeader defines the name of the request header holding the time of the signature, in Unix seconds.
+	TimestampHeader string `json:"timestampHeader,omitempty"`
+	// ClockSkew defines the maximum difference between the time of the signature and the current time.
+	// Default: 5m.
+	ClockSkew *intstr.IntOrString `json:"clockSkew,omitempty"`
+	// MaxBodyBytes defines the maximum size of the signed body, in bytes.
+	// Default: 10485760.
+	MaxBodyBytes int64 `json:"maxBodyBytes,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// SigningKey holds a key signing the requests or the URLs.
+type SigningKey struct {
+	// ID defines the identifier of the key.
+	ID string `json:"id,omitempty"`
+	// Secret is the name of the referenced Kubernetes Secret containing the key, in the `secret` key.
+	Secret string `json:"secret,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// AWSSigV4 holds the AWS Signature Version 4 middleware configuration.
+// This middleware signs the forwarded requests with AWS credentials.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/awssigv4/
+type AWSSigV4 struct {
+	// Service defines the name of the AWS service the requests are signed for, e.g. s3, execute-api, or es.
+	Service string `json:"service,omitempty"`
+	// Region defines the AWS region the requests are signed for.
+	Region string `json:"region,omitempty"`
+	// Host defines the host of the AWS service endpoint, set in the forwarded requests before signing them.
+	Host string `json:"host,omitempty"`
+	// Secret is the name of the referenced Kubernetes Secret containing the credentials,
+	// in the `accessKeyID`, `secretAccessKey`, and optional `sessionToken` keys.
+	// When not set, the credentials are read from the environment, the shared credentials file, the instance or container metadata, or the web identity token.
+	Secret string `json:"secret,omitempty"`
+	// UnsignedPayload defines whether the body is not signed, so that it is streamed instead of buffered.
+	UnsignedPayload bool `json:"unsignedPayload,omitempty"`
+	// MaxBodyBytes defines the maximum size of the signed body, in bytes.
+	// Default: 10485760.
+	MaxBodyBytes int64 `json:"maxBodyBytes,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Maintenance holds the maintenance middleware configuration.
+// This middleware answers the requests with a maintenance page while the maintenance mode is enabled.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/maintenance/
+type Maintenance struct {
+	// Enabled defines whether the maintenance mode is enabled.
+	Enabled bool `json:"enabled,omitempty"`
+	// FlagFile defines the path of a file enabling the maintenance mode while it exists.
+	FlagFile string `json:"flagFile,omitempty"`
+	// StatusCode defines the status code of the maintenance responses.
+	// Default: 503.
+	StatusCode int `json:"statusCode,omitempty"`
+	// RetryAfter defines the duration set in the Retry-After header of the maintenance responses.
+	RetryAfter *intstr.IntOrString `json:"retryAfter,omitempty"`
+	// ContentType defines the content type of the maintenance responses.
+	// Default: text/html; charset=utf-8.
+	ContentType string `json:"contentType,omitempty"`
+	// Body defines the Go template of the body of the maintenance responses.
+	Body string `json:"body,omitempty"`
+	// File defines the path of the file holding the Go template of the body of the maintenance responses.
+	File string `json:"file,omitempty"`
+	// SourceRange defines the set of IPs (or ranges of IPs by using CIDR notation) bypassing the maintenance mode.
+	SourceRange []string `json:"sourceRange,omitempty"`
+	// IPStrategy holds the IP strategy configuration used by Traefik to determine the client IP.
+	IPStrategy *dynamic.IPStrategy `json:"ipStrategy,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Tarpit holds the tarpit middleware configuration.
+// This middleware delays the requests of the abusive clients.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/tarpit/
+type Tarpit struct {
+	// SourceRange defines the set of IPs (or ranges of IPs by using CIDR notation) whose requests are delayed.
+	SourceRange []string `json:"sourceRange,omitempty"`
+	// Rate defines the request rate of a client above which its requests are delayed.
+	Rate *TarpitRate `json:"rate,omitempty"`
+	// BotCategories defines the bot categories, identified by a preceding botManager middleware, whose requests are delayed.
+	BotCategories []string `json:"botCategories,omitempty"`
+	// Delay defines how long the matching requests are delayed.
+	// Default: 5s.
+	Delay *intstr.IntOrString `json:"delay,omitempty"`
+	// MaxDelay defines the maximum delay, when greater than Delay.
+	MaxDelay *intstr.IntOrString `json:"maxDelay,omitempty"`
+	// MaxConcurrent defines the maximum number of requests delayed at the same time.
+	// Default: 100.
+	MaxConcurrent *int `json:"maxConcurrent,omitempty"`
+	// StatusCode defines the status code of the responses sent once the delay has elapsed.
+	// If not set, the delayed requests are forwarded to the service.
+	StatusCode int `json:"statusCode,omitempty"`
+	// IPStrategy holds the IP strategy configuration used by Traefik to determine the client IP.
+	IPStrategy *dynamic.IPStrategy `json:"ipStrategy,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// TarpitRate holds the request rate criterion of the tarpit middleware.
+type TarpitRate struct {
+	// Average defines the maximum average number of requests of a client during the Period.
+	Average int64 `json:"average,omitempty"`
+	// Period defines the period of the Average rate.
+	// Default: 1s.
+	Period *intstr.IntOrString `json:"period,omitempty"`
+	// Burst defines the maximum number of requests of a client above the Average rate.
+	// Default: 1.
+	Burst *int64 `json:"burst,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Fail2Ban holds the fail2Ban middleware configuration.
+// This middleware temporarily bans the clients whose requests fail too often.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/fail2ban/
+type Fail2Ban struct {
+	// StatusCodes defines the status codes, or ranges of status codes, of the failed requests.
+	// Default: ["401", "403", "429"].
+	StatusCodes []string `json:"statusCodes,omitempty"`
+	// MaxFailures defines the number of failed requests, within the FindTime window, after which a client is banned.
+	// Default: 5.
+	MaxFailures int `json:"maxFailures,omitempty"`
+	// FindTime defines the sliding window in which the failed requests of a client are counted.
+	// Default: 10m.
+	FindTime *intstr.IntOrString `json:"findTime,omitempty"`
+	// BanTime defines how long a client is banned.
+	// Default: 1h.
+	BanTime *intstr.IntOrString `json:"banTime,omitempty"`
+	// BanStatusCode defines the status code of the responses to the banned clients.
+	// Default: 403.
+	BanStatusCode int `json:"banStatusCode,omitempty"`
+	// IgnoredSourceRange defines the IPs (or ranges of IPs by using CIDR notation) which are never banned.
+	IgnoredSourceRange []string `json:"ignoredSourceRange,omitempty"`
+	// IPStrategy holds the IP strategy configuration used by Traefik to determine the client IP.
+	IPStrategy *dynamic.IPStrategy `json:"ipStrategy,omitempty"`
+	// Redis defines the Redis server storing the failures and the bans, to share them across the Traefik instances.
+	// Default: the failures and the bans are stored in memory.
+	Redis *Redis `json:"redis,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// WebSocket holds the WebSocket middleware configuration.
+// This middleware limits the WebSocket connections.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/websocket/
+type WebSocket struct {
+	// MaxMessageSize defines the maximum size of the messages sent by the clients, in bytes.
+	MaxMessageSize int64 `json:"maxMessageSize,omitempty"`
+	// MaxLifetime defines how long a connection can stay open.
+	MaxLifetime *intstr.IntOrString `json:"maxLifetime,omitempty"`
+	// IdleTimeout defines how long a connection can stay open without any data sent in either direction.
+	IdleTimeout *intstr.IntOrString `json:"idleTimeout,omitempty"`
+	// AllowedSubprotocols defines the subprotocols the clients can negotiate.
+	AllowedSubprotocols []string `json:"allowedSubprotocols,omitempty"`
+	// MaxConnections defines the maximum number of concurrent connections per router.
+	MaxConnections int `json:"maxConnections,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// SSE holds the server-sent events middleware configuration.
+// This middleware streams the server-sent events responses.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/sse/
+type SSE struct {
+	// FlushInterval defines the maximum delay before the events written by the service are sent to the client.
+	FlushInterval *intstr.IntOrString `json:"flushInterval,omitempty"`
+	// MaxLifetime defines how long a stream can stay open.
+	MaxLifetime *intstr.IntOrString `json:"maxLifetime,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// SignedURL holds the signed URL middleware configuration.
+// This middleware rejects the requests whose URL is not signed with one of the keys, or has expired.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/signedurl/
+type SignedURL struct {
+	// Keys defines the keys accepted to sign the URLs, several keys allowing their rotation.
+	Keys []SigningKey `json:"keys,omitempty"`
+	// KeyIDParam defines the name of the query parameter holding the ID of the key signing the URL.
+	KeyIDParam string `json:"keyIDParam,omitempty"`
+	// Algorithm defines the hash function of the HMAC, among sha1, sha256, and sha512.
+	// Default: sha256.
+	Algorithm string `json:"algorithm,omitempty"`
+	// Encoding defines the encoding of the signature, hex or base64url.
+	// Default: hex.
+	Encoding string `json:"encoding,omitempty"`
+	// SignatureParam defines the name of the query parameter holding the signature.
+	// Default: signature.
+	SignatureParam string `json:"signatureParam,omitempty"`
+	// ExpiresParam defines the name of the query parameter holding the expiry time of the URL, in Unix seconds.
+	// Default: expires.
+	ExpiresParam string `json:"expiresParam,omitempty"`
+	// MaxValidity defines how far in the future the expiry time of a URL can be.
+	MaxValidity *intstr.IntOrString `json:"maxValidity,omitempty"`
+	// StripParams defines whether the signature, expiry and key ID query parameters are removed from the forwarded requests.
+	StripParams bool `json:"stripParams,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// APIKeyAuth holds the API key authentication middleware configuration.
+// This middleware restricts access to your services to the requests holding a known API key.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/apikeyauth/
+type APIKeyAuth struct {
+	// HeaderName defines the name of the request header holding the API key.
+	// Default: X-API-Key.
+	HeaderName string `json:"headerName,omitempty"`
+	// QueryParam defines the name of the query parameter holding the API key, for the requests without the header.
+	QueryParam string `json:"queryParam,omitempty"`
+	// Secret is the name of the referenced Kubernetes Secret containing the accepted API keys,
+	// each key of the Secret being the name of an API key, and its value the API key, or its SHA-256 digest in hexadecimal prefixed with sha256:.
+	Secret string `json:"secret,omitempty"`
+	// Redis defines the Redis store of the accepted API keys, looked up for the keys which are not in the Secret.
+	Redis *Redis `json:"redis,omitempty"`
+	// HeaderField defines the header field set to the name of the API key on the forwarded requests.
+	HeaderField string `json:"headerField,omitempty"`
+	// MetadataHeaderPrefix defines the prefix of the header fields set to the metadata of the API key on the forwarded requests.
+	// Default: X-Api-Key-.
+	MetadataHeaderPrefix string `json:"metadataHeaderPrefix,omitempty"`
+	// RemoveKey defines whether to remove the API key from the forwarded requests.
+	RemoveKey bool `json:"removeKey,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Canary holds the canary middleware configuration.
+// This middleware forwards the matching requests to another service.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/canary/
+type Canary struct {
+	// Service defines the reference to the Kubernetes Service, or TraefikService, the matching requests are forwarded to.
+	Service Service `json:"service,omitempty"`
+	// Headers defines the request header values matching the requests, keyed by header name.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Cookies defines the request cookie values matching the requests, keyed by cookie name.
+	Cookies map[string]string `json:"cookies,omitempty"`
+	// Claims defines the claim values, of the token verified by a preceding JWT middleware, matching the requests, keyed by claim name.
+	Claims map[string]string `json:"claims,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// FormatConversion holds the format conversion middleware configuration.
+// This middleware converts the JSON responses of the service to the format accepted by the client,
+// and the request bodies sent in another format to JSON.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/formatconversion/
+type FormatConversion struct {
+	// Formats defines the formats converted from and to JSON, among xml, csv and msgpack.
+	// Default: xml, csv, msgpack.
+	Formats []string `json:"formats,omitempty"`
+	// Request defines whether the request bodies are converted to JSON.
+	// Default: true.
+	Request *bool `json:"request,omitempty"`
+	// Response defines whether the JSON responses are converted to the format accepted by the client.
+	// Default: true.
+	Response *bool `json:"response,omitempty"`
+	// XMLRootElement defines the name of the root element of the XML responses.
+	// Default: root.
+	XMLRootElement string `json:"xmlRootElement,omitempty"`
+	// MaxBodySize defines the maximum size, in bytes, of the converted bodies.
+	// Default: 10485760.
+	MaxBodySize int64 `json:"maxBodySize,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// UpstreamAuth holds the upstream authentication middleware configuration.
+// This middleware sets the credentials of the service on the forwarded requests.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/upstreamauth/
+type UpstreamAuth struct {
+	// HeaderName defines the name of the request header holding the credentials.
+	// Default: Authorization.
+	HeaderName string `json:"headerName,omitempty"`
+	// Basic defines the basic authentication credentials.
+	Basic *UpstreamBasicAuth `json:"basic,omitempty"`
+	// Bearer defines the static bearer token.
+	Bearer *UpstreamBearerAuth `json:"bearer,omitempty"`
+	// OAuth2 defines the OAuth 2.0 client credentials, the bearer tokens being fetched from the token endpoint.
+	OAuth2 *UpstreamOAuth2 `json:"oauth2,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// UpstreamBasicAuth holds the basic authentication credentials set on the forwarded requests.
+type UpstreamBasicAuth struct {
+	// Secret is the name of the referenced Kubernetes Secret containing the credentials, in the `username` and `password` keys.
+	Secret string `json:"secret,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// UpstreamBearerAuth holds the bearer token set on the forwarded requests.
+type UpstreamBearerAuth struct {
+	// Secret is the name of the referenced Kubernetes Secret containing the bearer token, in the `token` key.
+	Secret string `json:"secret,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// UpstreamOAuth2 holds the OAuth 2.0 client credentials used to fetch the bearer tokens set on the forwarded requests.
+type UpstreamOAuth2 struct {
+	// TokenURL defines the URL of the token endpoint of the authorization server.
+	TokenURL string `json:"tokenURL,omitempty"`
+	// ClientID defines the client identifier.
+	ClientID string `json:"clientID,omitempty"`
+	// Secret is the name of the referenced Kubernetes Secret containing the client secret, in the `clientSecret` key.
+	Secret string `json:"secret,omitempty"`
+	// Scopes defines the scopes requested to the token endpoint.
+	Scopes []string `json:"scopes,omitempty"`
+	// EndpointParams defines the additional parameters of the token requests, e.g. the audience.
+	EndpointParams map[string]string `json:"endpointParams,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Idempotency holds the idempotency middleware configuration.
+// This middleware replays the stored response of the requests retried with the same idempotency key.
+// More info: https://doc.traefik.io/traefik/v3.1/middlewares/http/idempotency/
+type Idempotency struct {
+	// HeaderName defines the name of the request header holding the idempotency key.
+	// Default: Idempotency-Key.
+	HeaderName string `json:"headerName,omitempty"`
+	// Methods defines the methods of the requests the idempotency keys apply to.
+	// Default: POST, PATCH.
+	Methods []string `json:"methods,omitempty"`
+	// Scope defines the values of the request identifying its caller, as a source expression, e.g. JWTClaim(`sub`),
+	// the idempotency keys of a caller never replaying the responses to another one.
+	// Default: Header(`Authorization`) && Header(`Cookie`) && ClientIP().
+	Scope string `json:"scope,omitempty"`
+	// TTL defines the duration during which a response is replayed.
+	// Default: 24h.
+	TTL *intstr.IntOrString `json:"ttl,omitempty"`
+	// MaxBodySize defines the maximum size, in bytes, of the bodies of the requests carrying an idempotency key,
+	// and of the stored responses.
+	// Default: 1048576.
+	MaxBodySize int64 `json:"maxBodySize,omitempty"`
+	// Store defines where the responses are stored.
+	// Default: in memory.
+	Store *IdempotencyStore `json:"store,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// IdempotencyStore holds the store of the idempotency middleware.
+type IdempotencyStore struct {
+	// Memory defines an in-memory store, forgetting the oldest keys first when full.
+	Memory *dynamic.MemoryIdempotencyStore `json:"memory,omitempty"`
+	// Redis defines a Redis store, shared by the Traefik instances.
+	Redis *Redis `json:"redis,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
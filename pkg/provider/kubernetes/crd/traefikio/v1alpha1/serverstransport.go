@@ -41,6 +41,8 @@ type ServersTransportSpec struct {
 	ForwardingTimeouts *ForwardingTimeouts `json:"forwardingTimeouts,omitempty"`
 	// DisableHTTP2 disables HTTP/2 for connections with backend servers.
 	DisableHTTP2 bool `json:"disableHTTP2,omitempty"`
+	// EnableHTTP3 enables HTTP/3 for connections with backend servers, falling back to HTTP/2 and HTTP/1.1 when the QUIC connection cannot be established.
+	EnableHTTP3 bool `json:"enableHTTP3,omitempty"`
 	// PeerCertURI defines the peer cert URI used to match against SAN URI during the peer certificate verification.
 	PeerCertURI string `json:"peerCertURI,omitempty"`
 	// Spiffe defines the SPIFFE configuration.
@@ -61,6 +63,8 @@ type ForwardingTimeouts struct {
 	ReadIdleTimeout *intstr.IntOrString `json:"readIdleTimeout,omitempty"`
 	// PingTimeout is the timeout after which the HTTP/2 connection will be closed if a response to ping is not received.
 	PingTimeout *intstr.IntOrString `json:"pingTimeout,omitempty"`
+	// HTTP3HandshakeTimeout is the amount of time to wait until a QUIC connection to a backend server is established, when HTTP/3 is enabled.
+	HTTP3HandshakeTimeout *intstr.IntOrString `json:"http3HandshakeTimeout,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // +genclient
@@ -44,6 +45,8 @@ type TraefikServiceSpec struct {
 	Weighted *WeightedRoundRobin `json:"weighted,omitempty"`
 	// Mirroring defines the Mirroring service configuration.
 	Mirroring *Mirroring `json:"mirroring,omitempty"`
+	// TrafficSplit defines the Traffic Split service configuration.
+	TrafficSplit *TrafficSplit `json:"trafficSplit,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -73,6 +76,46 @@ type MirrorService struct {
 	// Percent defines the part of the traffic to mirror.
 	// Supported values: 0 to 100.
 	Percent int `json:"percent,omitempty"`
+	// Match defines the rule, in the router rule syntax, matching the requests mirrored to the service,
+	// e.g. Method(`POST`) && PathPrefix(`/api`) or Header(`X-Shadow`, `true`).
+	Match string `json:"match,omitempty"`
+	// MaxBodySize defines the maximum size allowed for the body of the requests mirrored to the service,
+	// overriding the one of the mirroring.
+	MaxBodySize *int64 `json:"maxBodySize,omitempty"`
+	// Timeout defines the maximum duration of the requests mirrored to the service.
+	// Default: 0 (no timeout).
+	Timeout *intstr.IntOrString `json:"timeout,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// TrafficSplit holds the traffic split service configuration.
+// It assigns each client to a variant, and forwards its requests to the service of the variant.
+// More info: https://doc.traefik.io/traefik/v3.1/routing/services/#traffic-split-service
+type TrafficSplit struct {
+	// Variants defines the variants, along with the Kubernetes Service or TraefikService serving each of them.
+	Variants []SplitVariant `json:"variants,omitempty"`
+	// Cookie defines the cookie used to keep a client on its assigned variant.
+	Cookie *dynamic.Cookie `json:"cookie,omitempty"`
+	// HashHeader defines the request header whose value is hashed to assign the variant,
+	// when the client does not hold a valid variant cookie.
+	HashHeader string `json:"hashHeader,omitempty"`
+	// VariantHeader defines the request header carrying the assigned variant name to the backend.
+	// Default: X-Variant.
+	VariantHeader string `json:"variantHeader,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// SplitVariant holds a variant of the traffic split service.
+type SplitVariant struct {
+	LoadBalancerSpec `json:",inline"`
+
+	// Variant defines the name of the variant.
+	Variant string `json:"variant"`
+	// Percent defines the share of the new clients assigned to the variant.
+	// The percentages of the variants must add up to 100.
+	Percent int `json:"percent,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -38,9 +38,51 @@ import (
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeyAuth) DeepCopyInto(out *APIKeyAuth) {
+	*out = *in
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(Redis)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyAuth.
+func (in *APIKeyAuth) DeepCopy() *APIKeyAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeyAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSigV4) DeepCopyInto(out *AWSSigV4) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSigV4.
+func (in *AWSSigV4) DeepCopy() *AWSSigV4 {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSigV4)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(BasicAuthLDAP)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -54,6 +96,208 @@ func (in *BasicAuth) DeepCopy() *BasicAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BasicAuthLDAP) DeepCopyInto(out *BasicAuthLDAP) {
+	*out = *in
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClientTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuthLDAP.
+func (in *BasicAuthLDAP) DeepCopy() *BasicAuthLDAP {
+	if in == nil {
+		return nil
+	}
+	out := new(BasicAuthLDAP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BotManager) DeepCopyInto(out *BotManager) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Signatures != nil {
+		in, out := &in.Signatures, &out.Signatures
+		*out = make([]dynamic.BotSignature, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ChallengeTTL != nil {
+		in, out := &in.ChallengeTTL, &out.ChallengeTTL
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.TarpitDelay != nil {
+		in, out := &in.TarpitDelay, &out.TarpitDelay
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.IPStrategy != nil {
+		in, out := &in.IPStrategy, &out.IPStrategy
+		*out = new(dynamic.IPStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BotManager.
+func (in *BotManager) DeepCopy() *BotManager {
+	if in == nil {
+		return nil
+	}
+	out := new(BotManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSRF) DeepCopyInto(out *CSRF) {
+	*out = *in
+	if in.SafeMethods != nil {
+		in, out := &in.SafeMethods, &out.SafeMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExemptPaths != nil {
+		in, out := &in.ExemptPaths, &out.ExemptPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSRF.
+func (in *CSRF) DeepCopy() *CSRF {
+	if in == nil {
+		return nil
+	}
+	out := new(CSRF)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cache) DeepCopyInto(out *Cache) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.DefaultTTL != nil {
+		in, out := &in.DefaultTTL, &out.DefaultTTL
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.StaleWhileRevalidate != nil {
+		in, out := &in.StaleWhileRevalidate, &out.StaleWhileRevalidate
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(dynamic.CacheKey)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Store != nil {
+		in, out := &in.Store, &out.Store
+		*out = new(CacheStore)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cache.
+func (in *Cache) DeepCopy() *Cache {
+	if in == nil {
+		return nil
+	}
+	out := new(Cache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheStore) DeepCopyInto(out *CacheStore) {
+	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(dynamic.MemoryCacheStore)
+		**out = **in
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(Redis)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheStore.
+func (in *CacheStore) DeepCopy() *CacheStore {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Canary) DeepCopyInto(out *Canary) {
+	*out = *in
+	in.Service.DeepCopyInto(&out.Service)
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Cookies != nil {
+		in, out := &in.Cookies, &out.Cookies
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Canary.
+func (in *Canary) DeepCopy() *Canary {
+	if in == nil {
+		return nil
+	}
+	out := new(Canary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Certificate) DeepCopyInto(out *Certificate) {
 	*out = *in
@@ -192,6 +436,31 @@ func (in *Compress) DeepCopyInto(out *Compress) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.NegotiationOrder != nil {
+		in, out := &in.NegotiationOrder, &out.NegotiationOrder
+		*out = new(string)
+		**out = **in
+	}
+	if in.ContentTypeMinSizes != nil {
+		in, out := &in.ContentTypeMinSizes, &out.ContentTypeMinSizes
+		*out = make([]dynamic.CompressContentTypeMinSize, len(*in))
+		copy(*out, *in)
+	}
+	if in.Levels != nil {
+		in, out := &in.Levels, &out.Levels
+		*out = new(dynamic.CompressLevels)
+		**out = **in
+	}
+	if in.ExcludedPaths != nil {
+		in, out := &in.ExcludedPaths, &out.ExcludedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedRouters != nil {
+		in, out := &in.ExcludedRouters, &out.ExcludedRouters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -205,6 +474,58 @@ func (in *Compress) DeepCopy() *Compress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CookieEncryption) DeepCopyInto(out *CookieEncryption) {
+	*out = *in
+	if in.Cookies != nil {
+		in, out := &in.Cookies, &out.Cookies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CookieEncryption.
+func (in *CookieEncryption) DeepCopy() *CookieEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(CookieEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cookies) DeepCopyInto(out *Cookies) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(dynamic.CookieModifications)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(dynamic.CookieModifications)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(CookieEncryption)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cookies.
+func (in *Cookies) DeepCopy() *Cookies {
+	if in == nil {
+		return nil
+	}
+	out := new(Cookies)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DigestAuth) DeepCopyInto(out *DigestAuth) {
 	*out = *in
@@ -230,6 +551,13 @@ func (in *ErrorPage) DeepCopyInto(out *ErrorPage) {
 		copy(*out, *in)
 	}
 	in.Service.DeepCopyInto(&out.Service)
+	if in.StatusServices != nil {
+		in, out := &in.StatusServices, &out.StatusServices
+		*out = make(map[string]Service, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -243,6 +571,83 @@ func (in *ErrorPage) DeepCopy() *ErrorPage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Fail2Ban) DeepCopyInto(out *Fail2Ban) {
+	*out = *in
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FindTime != nil {
+		in, out := &in.FindTime, &out.FindTime
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.BanTime != nil {
+		in, out := &in.BanTime, &out.BanTime
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.IgnoredSourceRange != nil {
+		in, out := &in.IgnoredSourceRange, &out.IgnoredSourceRange
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPStrategy != nil {
+		in, out := &in.IPStrategy, &out.IPStrategy
+		*out = new(dynamic.IPStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(Redis)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fail2Ban.
+func (in *Fail2Ban) DeepCopy() *Fail2Ban {
+	if in == nil {
+		return nil
+	}
+	out := new(Fail2Ban)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FormatConversion) DeepCopyInto(out *FormatConversion) {
+	*out = *in
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FormatConversion.
+func (in *FormatConversion) DeepCopy() *FormatConversion {
+	if in == nil {
+		return nil
+	}
+	out := new(FormatConversion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForwardAuth) DeepCopyInto(out *ForwardAuth) {
 	*out = *in
@@ -266,6 +671,16 @@ func (in *ForwardAuth) DeepCopyInto(out *ForwardAuth) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(ForwardAuthRetry)
+		**out = **in
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(ForwardAuthCache)
+		**out = **in
+	}
 	return
 }
 
@@ -279,6 +694,40 @@ func (in *ForwardAuth) DeepCopy() *ForwardAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardAuthCache) DeepCopyInto(out *ForwardAuthCache) {
+	*out = *in
+	out.TTL = in.TTL
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardAuthCache.
+func (in *ForwardAuthCache) DeepCopy() *ForwardAuthCache {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardAuthCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardAuthRetry) DeepCopyInto(out *ForwardAuthRetry) {
+	*out = *in
+	out.InitialInterval = in.InitialInterval
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardAuthRetry.
+func (in *ForwardAuthRetry) DeepCopy() *ForwardAuthRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardAuthRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForwardingTimeouts) DeepCopyInto(out *ForwardingTimeouts) {
 	*out = *in
@@ -307,6 +756,11 @@ func (in *ForwardingTimeouts) DeepCopyInto(out *ForwardingTimeouts) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.HTTP3HandshakeTimeout != nil {
+		in, out := &in.HTTP3HandshakeTimeout, &out.HTTP3HandshakeTimeout
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 	return
 }
 
@@ -320,6 +774,94 @@ func (in *ForwardingTimeouts) DeepCopy() *ForwardingTimeouts {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HMACSignature) DeepCopyInto(out *HMACSignature) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]SigningKey, len(*in))
+		copy(*out, *in)
+	}
+	if in.SignedComponents != nil {
+		in, out := &in.SignedComponents, &out.SignedComponents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClockSkew != nil {
+		in, out := &in.ClockSkew, &out.ClockSkew
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HMACSignature.
+func (in *HMACSignature) DeepCopy() *HMACSignature {
+	if in == nil {
+		return nil
+	}
+	out := new(HMACSignature)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Idempotency) DeepCopyInto(out *Idempotency) {
+	*out = *in
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Store != nil {
+		in, out := &in.Store, &out.Store
+		*out = new(IdempotencyStore)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Idempotency.
+func (in *Idempotency) DeepCopy() *Idempotency {
+	if in == nil {
+		return nil
+	}
+	out := new(Idempotency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdempotencyStore) DeepCopyInto(out *IdempotencyStore) {
+	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(dynamic.MemoryIdempotencyStore)
+		**out = **in
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(Redis)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdempotencyStore.
+func (in *IdempotencyStore) DeepCopy() *IdempotencyStore {
+	if in == nil {
+		return nil
+	}
+	out := new(IdempotencyStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressRoute) DeepCopyInto(out *IngressRoute) {
 	*out = *in
@@ -584,12 +1126,45 @@ func (in *IngressRouteUDPSpec) DeepCopyInto(out *IngressRouteUDPSpec) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressRouteUDPSpec.
-func (in *IngressRouteUDPSpec) DeepCopy() *IngressRouteUDPSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressRouteUDPSpec.
+func (in *IngressRouteUDPSpec) DeepCopy() *IngressRouteUDPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressRouteUDPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWT) DeepCopyInto(out *JWT) {
+	*out = *in
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClaimsHeaders != nil {
+		in, out := &in.ClaimsHeaders, &out.ClaimsHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClientTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWT.
+func (in *JWT) DeepCopy() *JWT {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressRouteUDPSpec)
+	out := new(JWT)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -641,6 +1216,37 @@ func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Maintenance) DeepCopyInto(out *Maintenance) {
+	*out = *in
+	if in.RetryAfter != nil {
+		in, out := &in.RetryAfter, &out.RetryAfter
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.SourceRange != nil {
+		in, out := &in.SourceRange, &out.SourceRange
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPStrategy != nil {
+		in, out := &in.IPStrategy, &out.IPStrategy
+		*out = new(dynamic.IPStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Maintenance.
+func (in *Maintenance) DeepCopy() *Maintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(Maintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Middleware) DeepCopyInto(out *Middleware) {
 	*out = *in
@@ -788,7 +1394,7 @@ func (in *MiddlewareSpec) DeepCopyInto(out *MiddlewareSpec) {
 	if in.BasicAuth != nil {
 		in, out := &in.BasicAuth, &out.BasicAuth
 		*out = new(BasicAuth)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.DigestAuth != nil {
 		in, out := &in.DigestAuth, &out.DigestAuth
@@ -828,7 +1434,7 @@ func (in *MiddlewareSpec) DeepCopyInto(out *MiddlewareSpec) {
 	if in.Retry != nil {
 		in, out := &in.Retry, &out.Retry
 		*out = new(Retry)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ContentType != nil {
 		in, out := &in.ContentType, &out.ContentType
@@ -840,6 +1446,186 @@ func (in *MiddlewareSpec) DeepCopyInto(out *MiddlewareSpec) {
 		*out = new(dynamic.GrpcWeb)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDC)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWT)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(Cache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WAF != nil {
+		in, out := &in.WAF, &out.WAF
+		*out = new(dynamic.WAF)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GeoIP != nil {
+		in, out := &in.GeoIP, &out.GeoIP
+		*out = new(dynamic.GeoIP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BotManager != nil {
+		in, out := &in.BotManager, &out.BotManager
+		*out = new(BotManager)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BodyValidation != nil {
+		in, out := &in.BodyValidation, &out.BodyValidation
+		*out = new(dynamic.BodyValidation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RewriteBody != nil {
+		in, out := &in.RewriteBody, &out.RewriteBody
+		*out = new(RewriteBody)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Script != nil {
+		in, out := &in.Script, &out.Script
+		*out = new(dynamic.Script)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = new(dynamic.Query)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cookies != nil {
+		in, out := &in.Cookies, &out.Cookies
+		*out = new(Cookies)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CORS != nil {
+		in, out := &in.CORS, &out.CORS
+		*out = new(dynamic.CORS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CSRF != nil {
+		in, out := &in.CSRF, &out.CSRF
+		*out = new(CSRF)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OPA != nil {
+		in, out := &in.OPA, &out.OPA
+		*out = new(OPA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HMACSignature != nil {
+		in, out := &in.HMACSignature, &out.HMACSignature
+		*out = new(HMACSignature)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSSigV4 != nil {
+		in, out := &in.AWSSigV4, &out.AWSSigV4
+		*out = new(AWSSigV4)
+		**out = **in
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(Maintenance)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedirectMap != nil {
+		in, out := &in.RedirectMap, &out.RedirectMap
+		*out = new(dynamic.RedirectMap)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestID != nil {
+		in, out := &in.RequestID, &out.RequestID
+		*out = new(dynamic.RequestID)
+		**out = **in
+	}
+	if in.Tarpit != nil {
+		in, out := &in.Tarpit, &out.Tarpit
+		*out = new(Tarpit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fail2Ban != nil {
+		in, out := &in.Fail2Ban, &out.Fail2Ban
+		*out = new(Fail2Ban)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GraphQL != nil {
+		in, out := &in.GraphQL, &out.GraphQL
+		*out = new(dynamic.GraphQL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebSocket != nil {
+		in, out := &in.WebSocket, &out.WebSocket
+		*out = new(WebSocket)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSE != nil {
+		in, out := &in.SSE, &out.SSE
+		*out = new(SSE)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SignedURL != nil {
+		in, out := &in.SignedURL, &out.SignedURL
+		*out = new(SignedURL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIKeyAuth != nil {
+		in, out := &in.APIKeyAuth, &out.APIKeyAuth
+		*out = new(APIKeyAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Coalesce != nil {
+		in, out := &in.Coalesce, &out.Coalesce
+		*out = new(dynamic.Coalesce)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(Canary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FormatConversion != nil {
+		in, out := &in.FormatConversion, &out.FormatConversion
+		*out = new(FormatConversion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeaderAllowList != nil {
+		in, out := &in.HeaderAllowList, &out.HeaderAllowList
+		*out = new(dynamic.HeaderAllowList)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConcurrencyQuota != nil {
+		in, out := &in.ConcurrencyQuota, &out.ConcurrencyQuota
+		*out = new(dynamic.ConcurrencyQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MapPath != nil {
+		in, out := &in.MapPath, &out.MapPath
+		*out = new(dynamic.MapPath)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MapHost != nil {
+		in, out := &in.MapHost, &out.MapHost
+		*out = new(dynamic.MapHost)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityHeaders != nil {
+		in, out := &in.SecurityHeaders, &out.SecurityHeaders
+		*out = new(dynamic.SecurityHeaders)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpstreamAuth != nil {
+		in, out := &in.UpstreamAuth, &out.UpstreamAuth
+		*out = new(UpstreamAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Idempotency != nil {
+		in, out := &in.Idempotency, &out.Idempotency
+		*out = new(Idempotency)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Plugin != nil {
 		in, out := &in.Plugin, &out.Plugin
 		*out = make(map[string]v1.JSON, len(*in))
@@ -955,6 +1741,16 @@ func (in *MiddlewareTCPSpec) DeepCopy() *MiddlewareTCPSpec {
 func (in *MirrorService) DeepCopyInto(out *MirrorService) {
 	*out = *in
 	in.LoadBalancerSpec.DeepCopyInto(&out.LoadBalancerSpec)
+	if in.MaxBodySize != nil {
+		in, out := &in.MaxBodySize, &out.MaxBodySize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 	return
 }
 
@@ -1002,6 +1798,70 @@ func (in *Mirroring) DeepCopy() *Mirroring {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDC) DeepCopyInto(out *OIDC) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SessionCookie != nil {
+		in, out := &in.SessionCookie, &out.SessionCookie
+		*out = new(dynamic.Cookie)
+		**out = **in
+	}
+	if in.ClaimsHeaders != nil {
+		in, out := &in.ClaimsHeaders, &out.ClaimsHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClientTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDC.
+func (in *OIDC) DeepCopy() *OIDC {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPA) DeepCopyInto(out *OPA) {
+	*out = *in
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClientTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPA.
+func (in *OPA) DeepCopy() *OPA {
+	if in == nil {
+		return nil
+	}
+	out := new(OPA)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
@@ -1021,68 +1881,183 @@ func (in *ObjectReference) DeepCopy() *ObjectReference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimit) DeepCopyInto(out *RateLimit) {
 	*out = *in
-	if in.Average != nil {
-		in, out := &in.Average, &out.Average
-		*out = new(int64)
-		**out = **in
-	}
-	if in.Period != nil {
-		in, out := &in.Period, &out.Period
-		*out = new(intstr.IntOrString)
+	if in.Average != nil {
+		in, out := &in.Average, &out.Average
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SourceCriterion != nil {
+		in, out := &in.SourceCriterion, &out.SourceCriterion
+		*out = new(dynamic.SourceCriterion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(Redis)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
+func (in *RateLimit) DeepCopy() *RateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Redis) DeepCopyInto(out *Redis) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClientTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Redis.
+func (in *Redis) DeepCopy() *Redis {
+	if in == nil {
+		return nil
+	}
+	out := new(Redis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseForwarding) DeepCopyInto(out *ResponseForwarding) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseForwarding.
+func (in *ResponseForwarding) DeepCopy() *ResponseForwarding {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseForwarding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Retry) DeepCopyInto(out *Retry) {
+	*out = *in
+	out.InitialInterval = in.InitialInterval
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(RetryBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hedging != nil {
+		in, out := &in.Hedging, &out.Hedging
+		*out = new(RetryHedging)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Retry.
+func (in *Retry) DeepCopy() *Retry {
+	if in == nil {
+		return nil
+	}
+	out := new(Retry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryBudget) DeepCopyInto(out *RetryBudget) {
+	*out = *in
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int)
 		**out = **in
 	}
-	if in.Burst != nil {
-		in, out := &in.Burst, &out.Burst
-		*out = new(int64)
+	if in.MinRetriesPerSecond != nil {
+		in, out := &in.MinRetriesPerSecond, &out.MinRetriesPerSecond
+		*out = new(int)
 		**out = **in
 	}
-	if in.SourceCriterion != nil {
-		in, out := &in.SourceCriterion, &out.SourceCriterion
-		*out = new(dynamic.SourceCriterion)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
-func (in *RateLimit) DeepCopy() *RateLimit {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryBudget.
+func (in *RetryBudget) DeepCopy() *RetryBudget {
 	if in == nil {
 		return nil
 	}
-	out := new(RateLimit)
+	out := new(RetryBudget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResponseForwarding) DeepCopyInto(out *ResponseForwarding) {
+func (in *RetryHedging) DeepCopyInto(out *RetryHedging) {
 	*out = *in
+	out.Delay = in.Delay
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseForwarding.
-func (in *ResponseForwarding) DeepCopy() *ResponseForwarding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryHedging.
+func (in *RetryHedging) DeepCopy() *RetryHedging {
 	if in == nil {
 		return nil
 	}
-	out := new(ResponseForwarding)
+	out := new(RetryHedging)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Retry) DeepCopyInto(out *Retry) {
+func (in *RewriteBody) DeepCopyInto(out *RewriteBody) {
 	*out = *in
-	out.InitialInterval = in.InitialInterval
+	if in.Rewrites != nil {
+		in, out := &in.Rewrites, &out.Rewrites
+		*out = make([]dynamic.BodyRewrite, len(*in))
+		copy(*out, *in)
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ContentTypes != nil {
+		in, out := &in.ContentTypes, &out.ContentTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Retry.
-func (in *Retry) DeepCopy() *Retry {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RewriteBody.
+func (in *RewriteBody) DeepCopy() *RewriteBody {
 	if in == nil {
 		return nil
 	}
-	out := new(Retry)
+	out := new(RewriteBody)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1166,6 +2141,32 @@ func (in *RouteUDP) DeepCopy() *RouteUDP {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSE) DeepCopyInto(out *SSE) {
+	*out = *in
+	if in.FlushInterval != nil {
+		in, out := &in.FlushInterval, &out.FlushInterval
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxLifetime != nil {
+		in, out := &in.MaxLifetime, &out.MaxLifetime
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSE.
+func (in *SSE) DeepCopy() *SSE {
+	if in == nil {
+		return nil
+	}
+	out := new(SSE)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerHealthCheck) DeepCopyInto(out *ServerHealthCheck) {
 	*out = *in
@@ -1430,7 +2431,7 @@ func (in *ServiceTCP) DeepCopyInto(out *ServiceTCP) {
 	if in.ProxyProtocol != nil {
 		in, out := &in.ProxyProtocol, &out.ProxyProtocol
 		*out = new(dynamic.ProxyProtocol)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.NativeLB != nil {
 		in, out := &in.NativeLB, &out.NativeLB
@@ -1477,6 +2478,65 @@ func (in *ServiceUDP) DeepCopy() *ServiceUDP {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SignedURL) DeepCopyInto(out *SignedURL) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]SigningKey, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxValidity != nil {
+		in, out := &in.MaxValidity, &out.MaxValidity
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignedURL.
+func (in *SignedURL) DeepCopy() *SignedURL {
+	if in == nil {
+		return nil
+	}
+	out := new(SignedURL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SigningKey) DeepCopyInto(out *SigningKey) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SigningKey.
+func (in *SigningKey) DeepCopy() *SigningKey {
+	if in == nil {
+		return nil
+	}
+	out := new(SigningKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SplitVariant) DeepCopyInto(out *SplitVariant) {
+	*out = *in
+	in.LoadBalancerSpec.DeepCopyInto(&out.LoadBalancerSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SplitVariant.
+func (in *SplitVariant) DeepCopy() *SplitVariant {
+	if in == nil {
+		return nil
+	}
+	out := new(SplitVariant)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLS) DeepCopyInto(out *TLS) {
 	*out = *in
@@ -1794,6 +2854,83 @@ func (in *TLSTCP) DeepCopy() *TLSTCP {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tarpit) DeepCopyInto(out *Tarpit) {
+	*out = *in
+	if in.SourceRange != nil {
+		in, out := &in.SourceRange, &out.SourceRange
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rate != nil {
+		in, out := &in.Rate, &out.Rate
+		*out = new(TarpitRate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BotCategories != nil {
+		in, out := &in.BotCategories, &out.BotCategories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int)
+		**out = **in
+	}
+	if in.IPStrategy != nil {
+		in, out := &in.IPStrategy, &out.IPStrategy
+		*out = new(dynamic.IPStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tarpit.
+func (in *Tarpit) DeepCopy() *Tarpit {
+	if in == nil {
+		return nil
+	}
+	out := new(Tarpit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TarpitRate) DeepCopyInto(out *TarpitRate) {
+	*out = *in
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TarpitRate.
+func (in *TarpitRate) DeepCopy() *TarpitRate {
+	if in == nil {
+		return nil
+	}
+	out := new(TarpitRate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TraefikService) DeepCopyInto(out *TraefikService) {
 	*out = *in
@@ -1867,6 +3004,11 @@ func (in *TraefikServiceSpec) DeepCopyInto(out *TraefikServiceSpec) {
 		*out = new(Mirroring)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TrafficSplit != nil {
+		in, out := &in.TrafficSplit, &out.TrafficSplit
+		*out = new(TrafficSplit)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1880,6 +3022,156 @@ func (in *TraefikServiceSpec) DeepCopy() *TraefikServiceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficSplit) DeepCopyInto(out *TrafficSplit) {
+	*out = *in
+	if in.Variants != nil {
+		in, out := &in.Variants, &out.Variants
+		*out = make([]SplitVariant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Cookie != nil {
+		in, out := &in.Cookie, &out.Cookie
+		*out = new(dynamic.Cookie)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficSplit.
+func (in *TrafficSplit) DeepCopy() *TrafficSplit {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficSplit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpstreamAuth) DeepCopyInto(out *UpstreamAuth) {
+	*out = *in
+	if in.Basic != nil {
+		in, out := &in.Basic, &out.Basic
+		*out = new(UpstreamBasicAuth)
+		**out = **in
+	}
+	if in.Bearer != nil {
+		in, out := &in.Bearer, &out.Bearer
+		*out = new(UpstreamBearerAuth)
+		**out = **in
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(UpstreamOAuth2)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamAuth.
+func (in *UpstreamAuth) DeepCopy() *UpstreamAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpstreamBasicAuth) DeepCopyInto(out *UpstreamBasicAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamBasicAuth.
+func (in *UpstreamBasicAuth) DeepCopy() *UpstreamBasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamBasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpstreamBearerAuth) DeepCopyInto(out *UpstreamBearerAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamBearerAuth.
+func (in *UpstreamBearerAuth) DeepCopy() *UpstreamBearerAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamBearerAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpstreamOAuth2) DeepCopyInto(out *UpstreamOAuth2) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EndpointParams != nil {
+		in, out := &in.EndpointParams, &out.EndpointParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamOAuth2.
+func (in *UpstreamOAuth2) DeepCopy() *UpstreamOAuth2 {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamOAuth2)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSocket) DeepCopyInto(out *WebSocket) {
+	*out = *in
+	if in.MaxLifetime != nil {
+		in, out := &in.MaxLifetime, &out.MaxLifetime
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.AllowedSubprotocols != nil {
+		in, out := &in.AllowedSubprotocols, &out.AllowedSubprotocols
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSocket.
+func (in *WebSocket) DeepCopy() *WebSocket {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSocket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WeightedRoundRobin) DeepCopyInto(out *WeightedRoundRobin) {
 	*out = *in
@@ -80,6 +80,13 @@ func Test_buildConfiguration(t *testing.T) {
 		"traefik/http/middlewares/Middleware08/forwardAuth/authResponseHeaders/1":                    "foobar",
 		"traefik/http/middlewares/Middleware08/forwardAuth/authRequestHeaders/0":                     "foobar",
 		"traefik/http/middlewares/Middleware08/forwardAuth/authRequestHeaders/1":                     "foobar",
+		"traefik/http/middlewares/Middleware08/forwardAuth/forwardBody":                              "true",
+		"traefik/http/middlewares/Middleware08/forwardAuth/maxBodySize":                              "42",
+		"traefik/http/middlewares/Middleware08/forwardAuth/retry/attempts":                           "42",
+		"traefik/http/middlewares/Middleware08/forwardAuth/retry/initialInterval":                    "42s",
+		"traefik/http/middlewares/Middleware08/forwardAuth/cache/key":                                "foobar",
+		"traefik/http/middlewares/Middleware08/forwardAuth/cache/ttl":                                "42s",
+		"traefik/http/middlewares/Middleware08/forwardAuth/cache/maxEntries":                         "42",
 		"traefik/http/middlewares/Middleware08/forwardAuth/tls/key":                                  "foobar",
 		"traefik/http/middlewares/Middleware08/forwardAuth/tls/insecureSkipVerify":                   "true",
 		"traefik/http/middlewares/Middleware08/forwardAuth/tls/ca":                                   "foobar",
@@ -183,6 +190,8 @@ func Test_buildConfiguration(t *testing.T) {
 		"traefik/http/middlewares/Middleware04/circuitBreaker/fallbackDuration":                      "1s",
 		"traefik/http/middlewares/Middleware04/circuitBreaker/recoveryDuration":                      "1s",
 		"traefik/http/middlewares/Middleware04/circuitBreaker/responseCode":                          "404",
+		"traefik/http/middlewares/Middleware04/circuitBreaker/probePercent":                          "20",
+		"traefik/http/middlewares/Middleware04/circuitBreaker/perBackend":                            "true",
 		"traefik/http/middlewares/Middleware07/errors/status/0":                                      "foobar",
 		"traefik/http/middlewares/Middleware07/errors/status/1":                                      "foobar",
 		"traefik/http/middlewares/Middleware07/errors/service":                                       "foobar",
@@ -210,6 +219,9 @@ func Test_buildConfiguration(t *testing.T) {
 		"traefik/http/middlewares/Middleware02/buffering/memRequestBodyBytes":                        "42",
 		"traefik/http/middlewares/Middleware05/compress/encodings":                                   "foobar, foobar",
 		"traefik/http/middlewares/Middleware05/compress/minResponseBodyBytes":                        "42",
+		"traefik/http/middlewares/Middleware05/compress/negotiationOrder":                            "server",
+		"traefik/http/middlewares/Middleware05/compress/levels/zstd":                                 "3",
+		"traefik/http/middlewares/Middleware05/compress/excludedRouters":                             "foobar, foobar",
 		"traefik/http/middlewares/Middleware18/retry/attempts":                                       "42",
 		"traefik/http/middlewares/Middleware19/stripPrefix/prefixes/0":                               "foobar",
 		"traefik/http/middlewares/Middleware19/stripPrefix/prefixes/1":                               "foobar",
@@ -409,6 +421,8 @@ func Test_buildConfiguration(t *testing.T) {
 						FallbackDuration: ptypes.Duration(time.Second),
 						RecoveryDuration: ptypes.Duration(time.Second),
 						ResponseCode:     404,
+						ProbePercent:     20,
+						PerBackend:       true,
 					},
 				},
 				"Middleware05": {
@@ -418,6 +432,14 @@ func Test_buildConfiguration(t *testing.T) {
 							"foobar",
 							"foobar",
 						},
+						NegotiationOrder: "server",
+						Levels: &dynamic.CompressLevels{
+							Zstd: 3,
+						},
+						ExcludedRouters: []string{
+							"foobar",
+							"foobar",
+						},
 					},
 				},
 				"Middleware08": {
@@ -439,6 +461,17 @@ func Test_buildConfiguration(t *testing.T) {
 							"foobar",
 							"foobar",
 						},
+						ForwardBody: true,
+						MaxBodySize: 42,
+						Retry: &dynamic.ForwardAuthRetry{
+							Attempts:        42,
+							InitialInterval: ptypes.Duration(42 * time.Second),
+						},
+						Cache: &dynamic.ForwardAuthCache{
+							Key:        "foobar",
+							TTL:        ptypes.Duration(42 * time.Second),
+							MaxEntries: 42,
+						},
 					},
 				},
 				"Middleware06": {
@@ -6,6 +6,7 @@ import (
 	"math"
 	"net"
 	"regexp"
+	"slices"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -78,7 +79,7 @@ func (i *Provider) createConfiguration(ctx context.Context) *dynamic.Configurati
 	i.pingConfiguration(cfg)
 	i.restConfiguration(cfg)
 	i.prometheusConfiguration(cfg)
-	i.entryPointModels(cfg)
+	i.entryPointModels(ctx, cfg)
 	i.redirection(ctx, cfg)
 	i.serverTransport(cfg)
 	i.serverTransportTCP(cfg)
@@ -193,19 +194,21 @@ func (i *Provider) getEntryPointPort(name string, def *static.Redirections) (str
 	return port, nil
 }
 
-func (i *Provider) entryPointModels(cfg *dynamic.Configuration) {
+func (i *Provider) entryPointModels(ctx context.Context, cfg *dynamic.Configuration) {
 	defaultRuleSyntax := ""
 	if i.staticCfg.Core != nil && i.staticCfg.Core.DefaultRuleSyntax != "" {
 		defaultRuleSyntax = i.staticCfg.Core.DefaultRuleSyntax
 	}
 
 	for name, ep := range i.staticCfg.EntryPoints {
-		if len(ep.HTTP.Middlewares) == 0 && ep.HTTP.TLS == nil && defaultRuleSyntax == "" {
+		if len(ep.HTTP.Middlewares) == 0 && len(ep.HTTP.Plugins) == 0 && ep.HTTP.TLS == nil && defaultRuleSyntax == "" {
 			continue
 		}
 
+		// The plugin middlewares run before the default middlewares of the entry point.
+		// The slice is clipped, as the middlewares of the routers are appended to it.
 		m := &dynamic.Model{
-			Middlewares: ep.HTTP.Middlewares,
+			Middlewares: slices.Clip(append(i.entryPointPlugins(ctx, cfg, name, ep), ep.HTTP.Middlewares...)),
 		}
 
 		if ep.HTTP.TLS != nil {
@@ -232,6 +235,36 @@ func (i *Provider) entryPointModels(cfg *dynamic.Configuration) {
 	}
 }
 
+// entryPointPlugins creates the plugin middlewares declared on the entry point,
+// and returns their qualified names, in the declaration order.
+func (i *Provider) entryPointPlugins(ctx context.Context, cfg *dynamic.Configuration, epName string, ep *static.EntryPoint) []string {
+	logger := log.Ctx(ctx).With().Str(logs.EntryPointName, epName).Logger()
+
+	var names []string
+	for _, plugin := range ep.HTTP.Plugins {
+		if plugin.Name == "" {
+			logger.Error().Msg("Unable to create plugin middleware: the plugin name is missing")
+			continue
+		}
+
+		mdName := provider.Normalize(epName + "-plugin-" + plugin.Name)
+		if _, ok := cfg.HTTP.Middlewares[mdName]; ok {
+			logger.Error().Msgf("Unable to create plugin middleware: the plugin %s is declared more than once", plugin.Name)
+			continue
+		}
+
+		cfg.HTTP.Middlewares[mdName] = &dynamic.Middleware{
+			Plugin: map[string]dynamic.PluginConf{
+				plugin.Name: dynamic.PluginConf(plugin.Config),
+			},
+		}
+
+		names = append(names, mdName+"@internal")
+	}
+
+	return names
+}
+
 func (i *Provider) apiConfiguration(cfg *dynamic.Configuration) {
 	if i.staticCfg.API == nil {
 		return
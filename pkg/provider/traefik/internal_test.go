@@ -188,6 +188,23 @@ func Test_createConfiguration(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "models_plugins.json",
+			staticCfg: static.Configuration{
+				EntryPoints: map[string]*static.EntryPoint{
+					"websecure": {
+						HTTP: static.HTTPConfig{
+							Middlewares: []string{"test"},
+							Plugins: []static.EntryPointPlugin{
+								{Name: "requestid", Config: static.PluginConf{"header": "X-Request-Id"}},
+								{Name: "auth", Config: static.PluginConf{"realm": "traefik"}},
+								{Name: "requestid", Config: static.PluginConf{"header": "X-Duplicate"}},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			desc: "redirection.json",
 			staticCfg: static.Configuration{
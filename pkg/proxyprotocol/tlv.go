@@ -0,0 +1,128 @@
+package proxyprotocol
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/pires/go-proxyproto"
+	"github.com/pires/go-proxyproto/tlvparse"
+)
+
+// Names of the well-known TLVs, which values are decoded before being matched or exposed.
+const (
+	// TLVAWSVPCEndpointID is the ID of the AWS VPC endpoint the connection went through.
+	TLVAWSVPCEndpointID = "aws_vpce_id"
+	// TLVAzureLinkID is the LinkID of the Azure private endpoint the connection went through.
+	TLVAzureLinkID = "azure_link_id"
+	// TLVGCPPSCConnectionID is the ID of the GCP Private Service Connect connection.
+	TLVGCPPSCConnectionID = "gcp_psc_connection_id"
+)
+
+// Source is implemented by the connections providing the TLVs of the PROXY protocol header they were received with.
+type Source interface {
+	// ProxyProtocolTLVs returns the TLVs of the PROXY protocol header, or nil if there are none.
+	ProxyProtocolTLVs() []proxyproto.TLV
+}
+
+// TLVsFromConn returns the TLVs of the PROXY protocol header the connection was received with, or nil if there are none.
+// It also applies to the TLS connections terminated by Traefik.
+func TLVsFromConn(conn net.Conn) []proxyproto.TLV {
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		conn = tlsConn.NetConn()
+	}
+
+	source, ok := conn.(Source)
+	if !ok {
+		return nil
+	}
+
+	return source.ProxyProtocolTLVs()
+}
+
+type contextKey struct{}
+
+// NewContext returns a new context carrying the given TLVs.
+func NewContext(ctx context.Context, tlvs []proxyproto.TLV) context.Context {
+	return context.WithValue(ctx, contextKey{}, tlvs)
+}
+
+// FromContext returns the TLVs carried by the given context, or nil if there are none.
+func FromContext(ctx context.Context) []proxyproto.TLV {
+	tlvs, _ := ctx.Value(contextKey{}).([]proxyproto.TLV)
+	return tlvs
+}
+
+// ParseTLVType parses a TLV type, given as a decimal or a 0x prefixed hexadecimal number.
+func ParseTLVType(value string) (proxyproto.PP2Type, error) {
+	tlvType, err := strconv.ParseUint(value, 0, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid TLV type %q: must be a number between 0 and 255", value)
+	}
+
+	return proxyproto.PP2Type(tlvType), nil
+}
+
+// TLVLookup returns the value of a TLV, and whether it was found in the given TLVs.
+type TLVLookup func(tlvs []proxyproto.TLV) (string, bool)
+
+// NewTLVLookup returns the lookup of the TLV with the given name or type.
+// The values of the well-known TLVs are decoded, and the values of the others are returned as is.
+func NewTLVLookup(name string) (TLVLookup, error) {
+	switch name {
+	case TLVAWSVPCEndpointID:
+		return func(tlvs []proxyproto.TLV) (string, bool) {
+			id := tlvparse.FindAWSVPCEndpointID(tlvs)
+			return id, id != ""
+		}, nil
+
+	case TLVAzureLinkID:
+		return func(tlvs []proxyproto.TLV) (string, bool) {
+			linkID, ok := tlvparse.FindAzurePrivateEndpointLinkID(tlvs)
+			return strconv.FormatUint(uint64(linkID), 10), ok
+		}, nil
+
+	case TLVGCPPSCConnectionID:
+		return func(tlvs []proxyproto.TLV) (string, bool) {
+			id, ok := tlvparse.ExtractPSCConnectionID(tlvs)
+			return strconv.FormatUint(id, 10), ok
+		}, nil
+	}
+
+	tlvType, err := ParseTLVType(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w, or one of %s, %s and %s", err, TLVAWSVPCEndpointID, TLVAzureLinkID, TLVGCPPSCConnectionID)
+	}
+
+	return func(tlvs []proxyproto.TLV) (string, bool) {
+		for _, tlv := range tlvs {
+			if tlv.Type == tlvType {
+				return string(tlv.Value), true
+			}
+		}
+
+		return "", false
+	}, nil
+}
+
+// ParseTLVs parses the given TLV values, indexed by TLV type, into TLVs ordered by type.
+func ParseTLVs(values map[string]string) ([]proxyproto.TLV, error) {
+	var tlvs []proxyproto.TLV
+	for name, value := range values {
+		tlvType, err := ParseTLVType(name)
+		if err != nil {
+			return nil, err
+		}
+
+		tlvs = append(tlvs, proxyproto.TLV{Type: tlvType, Value: []byte(value)})
+	}
+
+	sort.Slice(tlvs, func(i, j int) bool {
+		return tlvs[i].Type < tlvs[j].Type
+	})
+
+	return tlvs, nil
+}
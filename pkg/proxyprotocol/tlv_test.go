@@ -0,0 +1,104 @@
+package proxyprotocol
+
+import (
+	"testing"
+
+	"github.com/pires/go-proxyproto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTLVLookup(t *testing.T) {
+	tlvs := []proxyproto.TLV{
+		{Type: 0xE0, Value: []byte("foobar")},
+		{Type: 0xEA, Value: append([]byte{0x01}, "vpce-08d2bf15fac5001c9"...)},
+		{Type: 0xEE, Value: []byte{0x01, 0x2a, 0x00, 0x00, 0x00}},
+	}
+
+	testCases := []struct {
+		desc          string
+		name          string
+		expectedErr   bool
+		expectedValue string
+		expectedFound bool
+	}{
+		{
+			desc:        "Unknown name",
+			name:        "foobar",
+			expectedErr: true,
+		},
+		{
+			desc:        "Out of range type",
+			name:        "256",
+			expectedErr: true,
+		},
+		{
+			desc:          "Decimal type",
+			name:          "224",
+			expectedValue: "foobar",
+			expectedFound: true,
+		},
+		{
+			desc:          "Hexadecimal type",
+			name:          "0xe0",
+			expectedValue: "foobar",
+			expectedFound: true,
+		},
+		{
+			desc: "Missing type",
+			name: "0xE1",
+		},
+		{
+			desc:          "AWS VPC endpoint ID",
+			name:          TLVAWSVPCEndpointID,
+			expectedValue: "vpce-08d2bf15fac5001c9",
+			expectedFound: true,
+		},
+		{
+			desc:          "Azure private endpoint LinkID",
+			name:          TLVAzureLinkID,
+			expectedValue: "42",
+			expectedFound: true,
+		},
+		{
+			desc: "Missing GCP PSC connection ID",
+			name: TLVGCPPSCConnectionID,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			lookup, err := NewTLVLookup(test.name)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			value, found := lookup(tlvs)
+			assert.Equal(t, test.expectedFound, found)
+			if test.expectedFound {
+				assert.Equal(t, test.expectedValue, value)
+			}
+		})
+	}
+}
+
+func TestParseTLVs(t *testing.T) {
+	tlvs, err := ParseTLVs(map[string]string{
+		"0xE1": "bar",
+		"224":  "foo",
+	})
+	require.NoError(t, err)
+
+	expected := []proxyproto.TLV{
+		{Type: 0xE0, Value: []byte("foo")},
+		{Type: 0xE1, Value: []byte("bar")},
+	}
+	assert.Equal(t, expected, tlvs)
+
+	_, err = ParseTLVs(map[string]string{"foo": "bar"})
+	require.Error(t, err)
+}
@@ -144,6 +144,7 @@ func init() {
 					IdleConnTimeout:       42,
 					ReadIdleTimeout:       42,
 					PingTimeout:           42,
+					HTTP3HandshakeTimeout: 42,
 				},
 			},
 		},
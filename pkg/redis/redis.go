@@ -0,0 +1,78 @@
+// Package redis creates the clients of the Redis servers used by the middlewares.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/types"
+)
+
+// sharedClients holds the shared clients, by configuration.
+var sharedClients = struct {
+	sync.Mutex
+	clients map[string]redis.UniversalClient
+}{clients: make(map[string]redis.UniversalClient)}
+
+// SharedClient returns the client of the Redis server, or cluster, defined by the given configuration,
+// shared by the middlewares with the same configuration, which are created again on each configuration change.
+func SharedClient(ctx context.Context, config dynamic.Redis) (redis.UniversalClient, error) {
+	key, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedClients.Lock()
+	defer sharedClients.Unlock()
+
+	if client, ok := sharedClients.clients[string(key)]; ok {
+		return client, nil
+	}
+
+	client, err := NewClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedClients.clients[string(key)] = client
+
+	return client, nil
+}
+
+// NewClient creates a client of the Redis server, or cluster, defined by the given configuration.
+// Several endpoints mean a Redis Cluster.
+func NewClient(ctx context.Context, config dynamic.Redis) (redis.UniversalClient, error) {
+	if len(config.Endpoints) == 0 {
+		return nil, errors.New("no Redis endpoints defined")
+	}
+
+	options := &redis.UniversalOptions{
+		Addrs:    config.Endpoints,
+		Username: config.Username,
+		Password: config.Password,
+		DB:       config.DB,
+	}
+
+	if config.TLS != nil {
+		clientTLS := &types.ClientTLS{
+			CA:                 config.TLS.CA,
+			Cert:               config.TLS.Cert,
+			Key:                config.TLS.Key,
+			InsecureSkipVerify: config.TLS.InsecureSkipVerify,
+		}
+
+		tlsConfig, err := clientTLS.CreateTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create client TLS configuration: %w", err)
+		}
+
+		options.TLSConfig = tlsConfig
+	}
+
+	return redis.NewUniversalClient(options), nil
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"maps"
 	"slices"
 
 	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
@@ -41,7 +42,10 @@ func mergeConfiguration(configurations dynamic.Configurations, defaultEntryPoint
 
 	var defaultTLSOptionProviders []string
 	var defaultTLSStoreProviders []string
-	for pvd, configuration := range configurations {
+	// The providers are merged in a deterministic order, e.g. for the order of the TLS certificates.
+	for _, pvd := range slices.Sorted(maps.Keys(configurations)) {
+		configuration := configurations[pvd]
+
 		if configuration.HTTP != nil {
 			for routerName, router := range configuration.HTTP.Routers {
 				if len(router.EntryPoints) == 0 {
@@ -25,6 +25,8 @@ type ConfigurationWatcher struct {
 
 	newConfigs chan dynamic.Configurations
 
+	reapply chan struct{}
+
 	requiredProvider       string
 	configurationListeners []func(dynamic.Configuration)
 
@@ -42,6 +44,7 @@ func NewConfigurationWatcher(
 		providerAggregator:  pvd,
 		allProvidersConfigs: make(chan dynamic.Message, 100),
 		newConfigs:          make(chan dynamic.Configurations),
+		reapply:             make(chan struct{}, 1),
 		routinesPool:        routinesPool,
 		defaultEntryPoints:  defaultEntryPoints,
 		requiredProvider:    requiredProvider,
@@ -69,6 +72,16 @@ func (c *ConfigurationWatcher) AddListener(listener func(dynamic.Configuration))
 	c.configurationListeners = append(c.configurationListeners, listener)
 }
 
+// Reapply asks for the last applied configuration to be sent again to the listeners,
+// e.g. to rebuild the routers once the plugins have been reloaded.
+func (c *ConfigurationWatcher) Reapply() {
+	select {
+	case c.reapply <- struct{}{}:
+	default:
+		// A reapply is already pending.
+	}
+}
+
 func (c *ConfigurationWatcher) startProviderAggregator() {
 	log.Info().Msgf("Starting provider aggregator %T", c.providerAggregator)
 
@@ -151,6 +164,12 @@ func (c *ConfigurationWatcher) applyConfigurations(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
+		case <-c.reapply:
+			if lastConfigurations == nil {
+				continue
+			}
+
+			c.applyConfiguration(lastConfigurations)
 		case newConfigs, ok := <-c.newConfigs:
 			if !ok {
 				return
@@ -165,18 +184,22 @@ func (c *ConfigurationWatcher) applyConfigurations(ctx context.Context) {
 				continue
 			}
 
-			conf := mergeConfiguration(newConfigs.DeepCopy(), c.defaultEntryPoints)
-			conf = applyModel(conf)
-
-			for _, listener := range c.configurationListeners {
-				listener(conf)
-			}
+			c.applyConfiguration(newConfigs)
 
 			lastConfigurations = newConfigs
 		}
 	}
 }
 
+func (c *ConfigurationWatcher) applyConfiguration(configs dynamic.Configurations) {
+	conf := mergeConfiguration(configs.DeepCopy(), c.defaultEntryPoints)
+	conf = applyModel(conf)
+
+	for _, listener := range c.configurationListeners {
+		listener(conf)
+	}
+}
+
 func logConfiguration(logger zerolog.Logger, configMsg dynamic.Message) {
 	if logger.GetLevel() > zerolog.DebugLevel {
 		return
@@ -163,6 +163,48 @@ func TestWaitForRequiredProvider(t *testing.T) {
 	assert.Equal(t, 2, publishedConfigCount, "times configs were published")
 }
 
+func TestReapplyConfiguration(t *testing.T) {
+	routinesPool := safe.NewPool(context.Background())
+	t.Cleanup(routinesPool.Stop)
+
+	pvd := &mockProvider{
+		messages: []dynamic.Message{{
+			ProviderName: "mock",
+			Configuration: &dynamic.Configuration{
+				HTTP: th.BuildConfiguration(
+					th.WithRouters(th.WithRouter("foo", th.WithEntryPoints("ep"))),
+					th.WithLoadBalancerServices(th.WithService("bar")),
+				),
+			},
+		}},
+	}
+
+	watcher := NewConfigurationWatcher(routinesPool, pvd, []string{}, "")
+
+	applied := make(chan dynamic.Configuration, 2)
+	watcher.AddListener(func(conf dynamic.Configuration) {
+		applied <- conf
+	})
+
+	watcher.Start()
+
+	var first dynamic.Configuration
+	select {
+	case first = <-applied:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the configuration")
+	}
+
+	watcher.Reapply()
+
+	select {
+	case second := <-applied:
+		assert.Equal(t, first, second)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the configuration to be reapplied")
+	}
+}
+
 func TestIgnoreTransientConfiguration(t *testing.T) {
 	routinesPool := safe.NewPool(context.Background())
 
@@ -8,35 +8,69 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/containous/alice"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/metrics"
 	"github.com/traefik/traefik/v3/pkg/middlewares/addprefix"
 	"github.com/traefik/traefik/v3/pkg/middlewares/auth"
+	"github.com/traefik/traefik/v3/pkg/middlewares/awssigv4"
+	"github.com/traefik/traefik/v3/pkg/middlewares/bodyvalidation"
+	"github.com/traefik/traefik/v3/pkg/middlewares/botmanager"
 	"github.com/traefik/traefik/v3/pkg/middlewares/buffering"
+	"github.com/traefik/traefik/v3/pkg/middlewares/cache"
+	"github.com/traefik/traefik/v3/pkg/middlewares/canary"
 	"github.com/traefik/traefik/v3/pkg/middlewares/chain"
 	"github.com/traefik/traefik/v3/pkg/middlewares/circuitbreaker"
 	"github.com/traefik/traefik/v3/pkg/middlewares/compress"
+	"github.com/traefik/traefik/v3/pkg/middlewares/concurrencyquota"
 	"github.com/traefik/traefik/v3/pkg/middlewares/contenttype"
+	"github.com/traefik/traefik/v3/pkg/middlewares/cookies"
+	"github.com/traefik/traefik/v3/pkg/middlewares/cors"
+	"github.com/traefik/traefik/v3/pkg/middlewares/csrf"
 	"github.com/traefik/traefik/v3/pkg/middlewares/customerrors"
+	"github.com/traefik/traefik/v3/pkg/middlewares/fail2ban"
+	"github.com/traefik/traefik/v3/pkg/middlewares/formatconversion"
 	"github.com/traefik/traefik/v3/pkg/middlewares/gatewayapi/headermodifier"
 	gapiredirect "github.com/traefik/traefik/v3/pkg/middlewares/gatewayapi/redirect"
 	"github.com/traefik/traefik/v3/pkg/middlewares/gatewayapi/urlrewrite"
+	"github.com/traefik/traefik/v3/pkg/middlewares/geoip"
+	"github.com/traefik/traefik/v3/pkg/middlewares/graphql"
 	"github.com/traefik/traefik/v3/pkg/middlewares/grpcweb"
+	"github.com/traefik/traefik/v3/pkg/middlewares/headerallowlist"
 	"github.com/traefik/traefik/v3/pkg/middlewares/headers"
+	"github.com/traefik/traefik/v3/pkg/middlewares/hmacsignature"
+	"github.com/traefik/traefik/v3/pkg/middlewares/idempotency"
 	"github.com/traefik/traefik/v3/pkg/middlewares/inflightreq"
 	"github.com/traefik/traefik/v3/pkg/middlewares/ipallowlist"
 	"github.com/traefik/traefik/v3/pkg/middlewares/ipwhitelist"
+	"github.com/traefik/traefik/v3/pkg/middlewares/maintenance"
+	metricsMiddle "github.com/traefik/traefik/v3/pkg/middlewares/metrics"
 	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"github.com/traefik/traefik/v3/pkg/middlewares/opa"
 	"github.com/traefik/traefik/v3/pkg/middlewares/passtlsclientcert"
+	"github.com/traefik/traefik/v3/pkg/middlewares/query"
 	"github.com/traefik/traefik/v3/pkg/middlewares/ratelimiter"
 	"github.com/traefik/traefik/v3/pkg/middlewares/redirect"
 	"github.com/traefik/traefik/v3/pkg/middlewares/replacepath"
 	"github.com/traefik/traefik/v3/pkg/middlewares/replacepathregex"
+	"github.com/traefik/traefik/v3/pkg/middlewares/requestid"
 	"github.com/traefik/traefik/v3/pkg/middlewares/retry"
+	"github.com/traefik/traefik/v3/pkg/middlewares/rewritebody"
+	"github.com/traefik/traefik/v3/pkg/middlewares/script"
+	"github.com/traefik/traefik/v3/pkg/middlewares/securityheaders"
+	"github.com/traefik/traefik/v3/pkg/middlewares/signedurl"
+	"github.com/traefik/traefik/v3/pkg/middlewares/sse"
 	"github.com/traefik/traefik/v3/pkg/middlewares/stripprefix"
 	"github.com/traefik/traefik/v3/pkg/middlewares/stripprefixregex"
+	"github.com/traefik/traefik/v3/pkg/middlewares/tarpit"
+	"github.com/traefik/traefik/v3/pkg/middlewares/upstreamauth"
+	"github.com/traefik/traefik/v3/pkg/middlewares/urlmap"
+	"github.com/traefik/traefik/v3/pkg/middlewares/waf"
+	"github.com/traefik/traefik/v3/pkg/middlewares/websocket"
+	"github.com/traefik/traefik/v3/pkg/plugins"
 	"github.com/traefik/traefik/v3/pkg/server/provider"
 )
 
@@ -46,11 +80,29 @@ const (
 	middlewareStackKey middlewareStackType = iota
 )
 
+// Managers holds the managers of the middleware states kept across the configuration reloads.
+// All of them are optional.
+type Managers struct {
+	Cache          *cache.Manager
+	Maintenance    *maintenance.Manager
+	CircuitBreaker *circuitbreaker.Manager
+	Fail2Ban       *fail2ban.Manager
+	Idempotency    *idempotency.Manager
+}
+
 // Builder the middleware builder.
 type Builder struct {
-	configs        map[string]*runtime.MiddlewareInfo
-	pluginBuilder  PluginsBuilder
-	serviceBuilder serviceBuilder
+	configs          map[string]*runtime.MiddlewareInfo
+	pluginBuilder    PluginsBuilder
+	serviceBuilder   serviceBuilder
+	observabilityMgr *ObservabilityMgr
+	managers         Managers
+
+	pluginBreakersMu sync.Mutex
+	pluginBreakers   map[string]*pluginBreaker
+
+	pluginHealthsMu sync.Mutex
+	pluginHealths   map[string]*pluginHealth
 }
 
 type serviceBuilder interface {
@@ -58,8 +110,8 @@ type serviceBuilder interface {
 }
 
 // NewBuilder creates a new Builder.
-func NewBuilder(configs map[string]*runtime.MiddlewareInfo, serviceBuilder serviceBuilder, pluginBuilder PluginsBuilder) *Builder {
-	return &Builder{configs: configs, serviceBuilder: serviceBuilder, pluginBuilder: pluginBuilder}
+func NewBuilder(configs map[string]*runtime.MiddlewareInfo, serviceBuilder serviceBuilder, pluginBuilder PluginsBuilder, observabilityMgr *ObservabilityMgr, managers Managers) *Builder {
+	return &Builder{configs: configs, serviceBuilder: serviceBuilder, pluginBuilder: pluginBuilder, observabilityMgr: observabilityMgr, managers: managers}
 }
 
 // BuildChain creates a middleware chain.
@@ -146,6 +198,119 @@ func (b *Builder) buildConstructor(ctx context.Context, middlewareName string) (
 		}
 	}
 
+	// Cache
+	if config.Cache != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return cache.New(ctx, next, b.managers.Cache, *config.Cache, middlewareName)
+		}
+	}
+
+	// Coalesce
+	if config.Coalesce != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return cache.NewCoalesce(ctx, next, *config.Coalesce, middlewareName)
+		}
+	}
+
+	// Canary
+	if config.Canary != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return canary.New(ctx, next, *config.Canary, b.serviceBuilder, middlewareName)
+		}
+	}
+
+	// FormatConversion
+	if config.FormatConversion != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return formatconversion.New(ctx, next, *config.FormatConversion, middlewareName)
+		}
+	}
+
+	// HeaderAllowList
+	if config.HeaderAllowList != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return headerallowlist.New(ctx, next, *config.HeaderAllowList, middlewareName)
+		}
+	}
+
+	// ConcurrencyQuota
+	if config.ConcurrencyQuota != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+
+		registry := b.metricsRegistry(middlewareName)
+
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return concurrencyquota.New(ctx, next, *config.ConcurrencyQuota, middlewareName, registry)
+		}
+	}
+
+	// MapPath
+	if config.MapPath != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return urlmap.NewMapPath(ctx, next, *config.MapPath, middlewareName)
+		}
+	}
+
+	// MapHost
+	if config.MapHost != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return urlmap.NewMapHost(ctx, next, *config.MapHost, middlewareName)
+		}
+	}
+
+	// SecurityHeaders
+	if config.SecurityHeaders != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return securityheaders.New(ctx, next, *config.SecurityHeaders, middlewareName)
+		}
+	}
+
+	// UpstreamAuth
+	if config.UpstreamAuth != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return upstreamauth.New(ctx, next, *config.UpstreamAuth, middlewareName)
+		}
+	}
+
+	// Idempotency
+	if config.Idempotency != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return idempotency.New(ctx, next, b.managers.Idempotency, *config.Idempotency, middlewareName)
+		}
+	}
+
 	// Chain
 	if config.Chain != nil {
 		if middleware != nil {
@@ -167,8 +332,10 @@ func (b *Builder) buildConstructor(ctx context.Context, middlewareName string) (
 		if middleware != nil {
 			return nil, badConf
 		}
+		registry := b.metricsRegistry(middlewareName)
+
 		middleware = func(next http.Handler) (http.Handler, error) {
-			return circuitbreaker.New(ctx, next, *config.CircuitBreaker, middlewareName)
+			return circuitbreaker.New(ctx, next, b.managers.CircuitBreaker, *config.CircuitBreaker, middlewareName, registry)
 		}
 	}
 
@@ -177,8 +344,16 @@ func (b *Builder) buildConstructor(ctx context.Context, middlewareName string) (
 		if middleware != nil {
 			return nil, badConf
 		}
+
+		// The router names are qualified on a copy, the runtime configuration being shared with the API.
+		compressConfig := *config.Compress
+		compressConfig.ExcludedRouters = nil
+		for _, name := range config.Compress.ExcludedRouters {
+			compressConfig.ExcludedRouters = append(compressConfig.ExcludedRouters, provider.GetQualifiedName(ctx, name))
+		}
+
 		middleware = func(next http.Handler) (http.Handler, error) {
-			return compress.New(ctx, next, *config.Compress, middlewareName)
+			return compress.New(ctx, next, compressConfig, middlewareName)
 		}
 	}
 
@@ -222,6 +397,26 @@ func (b *Builder) buildConstructor(ctx context.Context, middlewareName string) (
 		}
 	}
 
+	// OIDC
+	if config.OIDC != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return auth.NewOIDC(ctx, next, *config.OIDC, middlewareName)
+		}
+	}
+
+	// JWT
+	if config.JWT != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return auth.NewJWT(ctx, next, *config.JWT, middlewareName)
+		}
+	}
+
 	// GrpcWeb
 	if config.GrpcWeb != nil {
 		if middleware != nil {
@@ -270,8 +465,11 @@ func (b *Builder) buildConstructor(ctx context.Context, middlewareName string) (
 		if middleware != nil {
 			return nil, badConf
 		}
+
+		registry := b.metricsRegistry(middlewareName)
+
 		middleware = func(next http.Handler) (http.Handler, error) {
-			return inflightreq.New(ctx, next, *config.InFlightReq, middlewareName)
+			return inflightreq.New(ctx, next, *config.InFlightReq, middlewareName, registry)
 		}
 	}
 
@@ -305,6 +503,26 @@ func (b *Builder) buildConstructor(ctx context.Context, middlewareName string) (
 		}
 	}
 
+	// RedirectMap
+	if config.RedirectMap != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return redirect.NewRedirectMap(ctx, next, *config.RedirectMap, middlewareName)
+		}
+	}
+
+	// RequestID
+	if config.RequestID != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return requestid.New(ctx, next, *config.RequestID, middlewareName)
+		}
+	}
+
 	// RedirectScheme
 	if config.RedirectScheme != nil {
 		if middleware != nil {
@@ -366,6 +584,231 @@ func (b *Builder) buildConstructor(ctx context.Context, middlewareName string) (
 		}
 	}
 
+	// Fail2Ban
+	if config.Fail2Ban != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return fail2ban.New(ctx, next, b.managers.Fail2Ban, *config.Fail2Ban, middlewareName)
+		}
+	}
+
+	// Tarpit
+	if config.Tarpit != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return tarpit.New(ctx, next, *config.Tarpit, middlewareName)
+		}
+	}
+
+	// WAF
+	if config.WAF != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return waf.New(ctx, next, *config.WAF, middlewareName)
+		}
+	}
+
+	// GeoIP
+	if config.GeoIP != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return geoip.New(ctx, next, *config.GeoIP, middlewareName)
+		}
+	}
+
+	// BotManager
+	if config.BotManager != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+
+		registry := b.metricsRegistry(middlewareName)
+
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return botmanager.New(ctx, next, *config.BotManager, middlewareName, registry)
+		}
+	}
+
+	// GraphQL
+	if config.GraphQL != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+
+		registry := b.metricsRegistry(middlewareName)
+
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return graphql.New(ctx, next, *config.GraphQL, middlewareName, registry)
+		}
+	}
+
+	// WebSocket
+	if config.WebSocket != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+
+		registry := b.metricsRegistry(middlewareName)
+
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return websocket.New(ctx, next, *config.WebSocket, middlewareName, registry)
+		}
+	}
+
+	// SSE
+	if config.SSE != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return sse.New(ctx, next, *config.SSE, middlewareName)
+		}
+	}
+
+	// SignedURL
+	if config.SignedURL != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return signedurl.New(ctx, next, *config.SignedURL, middlewareName)
+		}
+	}
+
+	// APIKeyAuth
+	if config.APIKeyAuth != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+
+		registry := b.metricsRegistry(middlewareName)
+
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return auth.NewAPIKey(ctx, next, *config.APIKeyAuth, middlewareName, registry)
+		}
+	}
+
+	// BodyValidation
+	if config.BodyValidation != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return bodyvalidation.New(ctx, next, *config.BodyValidation, middlewareName)
+		}
+	}
+
+	// RewriteBody
+	if config.RewriteBody != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return rewritebody.New(ctx, next, *config.RewriteBody, middlewareName)
+		}
+	}
+
+	// Script
+	if config.Script != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return script.New(ctx, next, *config.Script, b.serviceBuilder, middlewareName)
+		}
+	}
+
+	// Query
+	if config.Query != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return query.New(ctx, next, *config.Query, middlewareName)
+		}
+	}
+
+	// Cookies
+	if config.Cookies != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return cookies.New(ctx, next, *config.Cookies, middlewareName)
+		}
+	}
+
+	// CORS
+	if config.CORS != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+
+		registry := b.metricsRegistry(middlewareName)
+
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return cors.New(ctx, next, *config.CORS, middlewareName, registry)
+		}
+	}
+
+	// CSRF
+	if config.CSRF != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return csrf.New(ctx, next, *config.CSRF, middlewareName)
+		}
+	}
+
+	// OPA
+	if config.OPA != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return opa.New(ctx, next, *config.OPA, middlewareName)
+		}
+	}
+
+	// HMACSignature
+	if config.HMACSignature != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return hmacsignature.New(ctx, next, *config.HMACSignature, middlewareName)
+		}
+	}
+
+	// AWSSigV4
+	if config.AWSSigV4 != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return awssigv4.New(ctx, next, *config.AWSSigV4, middlewareName)
+		}
+	}
+
+	// Maintenance
+	if config.Maintenance != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return maintenance.New(ctx, next, b.managers.Maintenance, *config.Maintenance, middlewareName)
+		}
+	}
+
 	// Plugin
 	if config.Plugin != nil && !reflect.ValueOf(b.pluginBuilder).IsNil() { // Using "reflect" because "b.pluginBuilder" is an interface.
 		if middleware != nil {
@@ -382,9 +825,45 @@ func (b *Builder) buildConstructor(ctx context.Context, middlewareName string) (
 			return nil, fmt.Errorf("plugin: %w", err)
 		}
 
+		// The handlers of the plugins providing a health hook are polled, with the default configuration if none is defined.
+		healthConfig := plugins.HealthCheck{}
+		healthConfig.SetDefaults()
+		if hcConfig := b.pluginBuilder.HealthCheck(pluginType); hcConfig != nil {
+			healthConfig = *hcConfig
+		}
+
+		health, err := b.getPluginHealth(middlewareName, healthConfig)
+		if err != nil {
+			return nil, fmt.Errorf("plugin: %w", err)
+		}
+
+		plug = health.constructor(plug)
+
 		middleware = func(next http.Handler) (http.Handler, error) {
 			return newTraceablePlugin(ctx, middlewareName, plug, next)
 		}
+
+		if registry := b.observabilityMgr.MetricsRegistry(); registry != nil && registry.IsPluginEnabled() && b.observabilityMgr.ShouldAddMetrics(middlewareName) {
+			pluginMiddleware := middleware
+			moduleName := b.pluginBuilder.ModuleName(pluginType)
+
+			middleware = func(next http.Handler) (http.Handler, error) {
+				return metricsMiddle.NewPluginMiddleware(ctx, next, registry, moduleName, middlewareName, pluginMiddleware)
+			}
+		}
+
+		if cbConfig := b.pluginBuilder.CircuitBreaker(pluginType); cbConfig != nil {
+			breaker, err := b.getPluginBreaker(middlewareName, *cbConfig)
+			if err != nil {
+				return nil, fmt.Errorf("plugin: %w", err)
+			}
+
+			pluginMiddleware := middleware
+
+			middleware = func(next http.Handler) (http.Handler, error) {
+				return breaker.wrap(ctx, next, pluginMiddleware)
+			}
+		}
 	}
 
 	// Gateway API HTTPRoute filters middlewares.
@@ -433,3 +912,59 @@ func (b *Builder) buildConstructor(ctx context.Context, middlewareName string) (
 	// this would not enable tracing.
 	return observability.WrapMiddleware(ctx, middleware), nil
 }
+
+// metricsRegistry returns the metrics registry of the given middleware,
+// which is nil when the metrics are disabled for this middleware.
+func (b *Builder) metricsRegistry(middlewareName string) metrics.Registry {
+	if !b.observabilityMgr.ShouldAddMetrics(middlewareName) {
+		return nil
+	}
+
+	return b.observabilityMgr.MetricsRegistry()
+}
+
+// getPluginBreaker returns the circuit breaker of the given plugin middleware,
+// shared by all the routers using this middleware.
+func (b *Builder) getPluginBreaker(middlewareName string, config plugins.CircuitBreaker) (*pluginBreaker, error) {
+	b.pluginBreakersMu.Lock()
+	defer b.pluginBreakersMu.Unlock()
+
+	if breaker, ok := b.pluginBreakers[middlewareName]; ok {
+		return breaker, nil
+	}
+
+	breaker, err := newPluginBreaker(middlewareName, config, b.configs[middlewareName])
+	if err != nil {
+		return nil, err
+	}
+
+	if b.pluginBreakers == nil {
+		b.pluginBreakers = make(map[string]*pluginBreaker)
+	}
+	b.pluginBreakers[middlewareName] = breaker
+
+	return breaker, nil
+}
+
+// getPluginHealth returns the poller of the health hook of the given plugin middleware,
+// shared by all the routers using this middleware.
+func (b *Builder) getPluginHealth(middlewareName string, config plugins.HealthCheck) (*pluginHealth, error) {
+	b.pluginHealthsMu.Lock()
+	defer b.pluginHealthsMu.Unlock()
+
+	if health, ok := b.pluginHealths[middlewareName]; ok {
+		return health, nil
+	}
+
+	health, err := newPluginHealth(middlewareName, config, b.configs[middlewareName])
+	if err != nil {
+		return nil, err
+	}
+
+	if b.pluginHealths == nil {
+		b.pluginHealths = make(map[string]*pluginHealth)
+	}
+	b.pluginHealths[middlewareName] = health
+
+	return health, nil
+}
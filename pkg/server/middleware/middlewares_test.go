@@ -18,7 +18,7 @@ func TestBuilder_BuildChainNilConfig(t *testing.T) {
 	testConfig := map[string]*runtime.MiddlewareInfo{
 		"empty": {},
 	}
-	middlewaresBuilder := NewBuilder(testConfig, nil, nil)
+	middlewaresBuilder := NewBuilder(testConfig, nil, nil, nil, Managers{})
 
 	chain := middlewaresBuilder.BuildChain(context.Background(), []string{"empty"})
 	_, err := chain.Then(nil)
@@ -29,7 +29,7 @@ func TestBuilder_BuildChainNonExistentChain(t *testing.T) {
 	testConfig := map[string]*runtime.MiddlewareInfo{
 		"foobar": {},
 	}
-	middlewaresBuilder := NewBuilder(testConfig, nil, nil)
+	middlewaresBuilder := NewBuilder(testConfig, nil, nil, nil, Managers{})
 
 	chain := middlewaresBuilder.BuildChain(context.Background(), []string{"empty"})
 	_, err := chain.Then(nil)
@@ -269,7 +269,7 @@ func TestBuilder_BuildChainWithContext(t *testing.T) {
 					Middlewares: test.configuration,
 				},
 			})
-			builder := NewBuilder(rtConf.Middlewares, nil, nil)
+			builder := NewBuilder(rtConf.Middlewares, nil, nil, nil, Managers{})
 
 			result := builder.BuildChain(ctx, test.buildChain)
 
@@ -328,7 +328,7 @@ func TestBuilder_buildConstructor(t *testing.T) {
 			Middlewares: testConfig,
 		},
 	})
-	middlewaresBuilder := NewBuilder(rtConf.Middlewares, nil, nil)
+	middlewaresBuilder := NewBuilder(rtConf.Middlewares, nil, nil, nil, Managers{})
 
 	testCases := []struct {
 		desc          string
@@ -380,3 +380,28 @@ func TestBuilder_buildConstructor(t *testing.T) {
 		})
 	}
 }
+
+func TestBuilder_buildConstructor_compressExcludedRouters(t *testing.T) {
+	rtConf := runtime.NewConfig(dynamic.Configuration{
+		HTTP: &dynamic.HTTPConfiguration{
+			Middlewares: map[string]*dynamic.Middleware{
+				"compress@file": {
+					Compress: &dynamic.Compress{
+						ExcludedRouters: []string{"foo", "bar@docker"},
+					},
+				},
+			},
+		},
+	})
+	middlewaresBuilder := NewBuilder(rtConf.Middlewares, nil, nil, nil, Managers{})
+
+	ctx := provider.AddInContext(context.Background(), "compress@file")
+
+	// The router names are qualified without altering the runtime configuration, which is exposed by the API.
+	for range 2 {
+		_, err := middlewaresBuilder.buildConstructor(ctx, "compress@file")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"foo", "bar@docker"}, rtConf.Middlewares["compress@file"].Compress.ExcludedRouters)
+}
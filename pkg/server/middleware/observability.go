@@ -48,7 +48,7 @@ func (o *ObservabilityMgr) BuildEPChain(ctx context.Context, entryPointName stri
 		return chain
 	}
 
-	if o.accessLoggerMiddleware != nil || o.metricsRegistry != nil && (o.metricsRegistry.IsEpEnabled() || o.metricsRegistry.IsRouterEnabled() || o.metricsRegistry.IsSvcEnabled()) {
+	if o.accessLoggerMiddleware != nil || o.metricsRegistry != nil && (o.metricsRegistry.IsEpEnabled() || o.metricsRegistry.IsRouterEnabled() || o.metricsRegistry.IsSvcEnabled() || o.metricsRegistry.IsPluginEnabled()) {
 		if o.ShouldAddAccessLogs(resourceName) || o.ShouldAddMetrics(resourceName) {
 			chain = chain.Append(capture.Wrap)
 		}
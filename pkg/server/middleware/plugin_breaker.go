@@ -0,0 +1,261 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/containous/alice"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/logs"
+	"github.com/traefik/traefik/v3/pkg/plugins"
+)
+
+type pluginBreakerCallKey struct{}
+
+// pluginBreaker disables a plugin after too many failures within a window.
+// A failure is either a panic of the plugin, or a server error response written by the plugin itself,
+// i.e. without calling the rest of the chain.
+type pluginBreaker struct {
+	name   string
+	config plugins.CircuitBreaker
+	info   *runtime.MiddlewareInfo
+
+	now func() time.Time
+
+	mu        sync.Mutex
+	failures  []time.Time
+	open      bool
+	openUntil time.Time
+}
+
+func newPluginBreaker(name string, config plugins.CircuitBreaker, info *runtime.MiddlewareInfo) (*pluginBreaker, error) {
+	if config.MaxFailures <= 0 {
+		return nil, errors.New("circuit breaker: maxFailures must be greater than zero")
+	}
+
+	if config.Window <= 0 {
+		return nil, errors.New("circuit breaker: window must be greater than zero")
+	}
+
+	switch config.Mode {
+	case plugins.CircuitBreakerFailOpen, plugins.CircuitBreakerFailClosed:
+	case "":
+		config.Mode = plugins.CircuitBreakerFailClosed
+	default:
+		return nil, fmt.Errorf("circuit breaker: unsupported mode %q", config.Mode)
+	}
+
+	if info != nil {
+		info.SetPluginStatus(runtime.PluginStatusEnabled)
+	}
+
+	return &pluginBreaker{
+		name:   name,
+		config: config,
+		info:   info,
+		now:    time.Now,
+	}, nil
+}
+
+// wrap builds the plugin around the next handler,
+// and returns a handler protecting the next handler from the plugin failures.
+func (b *pluginBreaker) wrap(ctx context.Context, next http.Handler, plugin alice.Constructor) (http.Handler, error) {
+	h, err := plugin(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if nextCalled, ok := req.Context().Value(pluginBreakerCallKey{}).(*bool); ok {
+			*nextCalled = true
+		}
+
+		next.ServeHTTP(rw, req)
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pluginBreakerHandler{
+		breaker: b,
+		plugin:  h,
+		next:    next,
+		logger:  log.Ctx(ctx).With().Str(logs.MiddlewareName, b.name).Logger(),
+	}, nil
+}
+
+// allow reports whether the plugin can handle the request.
+func (b *pluginBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return true
+	}
+
+	if b.config.Cooldown <= 0 || b.now().Before(b.openUntil) {
+		return false
+	}
+
+	b.open = false
+	b.failures = nil
+	b.setStatus(runtime.PluginStatusEnabled)
+
+	return true
+}
+
+// recordFailure records a failure and opens the breaker when the max number of failures is reached within the window.
+// It returns true if the breaker has been opened.
+func (b *pluginBreaker) recordFailure() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.open {
+		return false
+	}
+
+	now := b.now()
+	windowStart := now.Add(-time.Duration(b.config.Window))
+
+	failures := b.failures[:0]
+	for _, f := range b.failures {
+		if f.After(windowStart) {
+			failures = append(failures, f)
+		}
+	}
+	b.failures = append(failures, now)
+
+	if len(b.failures) < b.config.MaxFailures {
+		return false
+	}
+
+	b.open = true
+	b.openUntil = now.Add(time.Duration(b.config.Cooldown))
+
+	if b.config.Mode == plugins.CircuitBreakerFailOpen {
+		b.setStatus(runtime.PluginStatusBypassed)
+	} else {
+		b.setStatus(runtime.PluginStatusDisabled)
+	}
+
+	return true
+}
+
+func (b *pluginBreaker) setStatus(status string) {
+	if b.info != nil {
+		b.info.SetPluginStatus(status)
+	}
+}
+
+type pluginBreakerHandler struct {
+	breaker *pluginBreaker
+	plugin  http.Handler
+	next    http.Handler
+	logger  zerolog.Logger
+}
+
+func (h *pluginBreakerHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if !h.breaker.allow() {
+		if h.breaker.config.Mode == plugins.CircuitBreakerFailOpen {
+			h.next.ServeHTTP(rw, req)
+			return
+		}
+
+		http.Error(rw, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+
+	var nextCalled bool
+	recorder := &pluginStatusRecorder{ResponseWriter: rw}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if r == http.ErrAbortHandler {
+				panic(r)
+			}
+
+			h.logger.Error().Msgf("Plugin panicked: %v", r)
+
+			if !recorder.written {
+				http.Error(recorder, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+
+			h.failure()
+		}
+	}()
+
+	h.plugin.ServeHTTP(recorder, req.WithContext(context.WithValue(req.Context(), pluginBreakerCallKey{}, &nextCalled)))
+
+	if !nextCalled && recorder.status >= http.StatusInternalServerError {
+		h.failure()
+	}
+}
+
+func (h *pluginBreakerHandler) failure() {
+	if !h.breaker.recordFailure() {
+		return
+	}
+
+	if h.breaker.config.Cooldown > 0 {
+		h.logger.Error().Msgf("Too many plugin failures, plugin %s for %s", h.breaker.modeVerb(), h.breaker.config.Cooldown)
+		return
+	}
+
+	h.logger.Error().Msgf("Too many plugin failures, plugin %s until the next configuration reload", h.breaker.modeVerb())
+}
+
+func (b *pluginBreaker) modeVerb() string {
+	if b.config.Mode == plugins.CircuitBreakerFailOpen {
+		return "bypassed"
+	}
+
+	return "disabled"
+}
+
+// pluginStatusRecorder records the status code written by a plugin.
+type pluginStatusRecorder struct {
+	http.ResponseWriter
+	status  int
+	written bool
+}
+
+// WriteHeader captures the status code for later retrieval.
+func (r *pluginStatusRecorder) WriteHeader(status int) {
+	if !r.written {
+		r.status = status
+		r.written = status >= http.StatusOK || status == http.StatusSwitchingProtocols
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *pluginStatusRecorder) Write(b []byte) (int, error) {
+	if !r.written {
+		r.status = http.StatusOK
+		r.written = true
+	}
+
+	return r.ResponseWriter.Write(b)
+}
+
+// Hijack hijacks the connection.
+func (r *pluginStatusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("%T is not a http.Hijacker", r.ResponseWriter)
+	}
+
+	r.written = true
+
+	return hijacker.Hijack()
+}
+
+// Flush sends any buffered data to the client.
+func (r *pluginStatusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,216 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/containous/alice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/plugins"
+)
+
+func TestNewPluginBreaker(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		config      plugins.CircuitBreaker
+		expectedErr bool
+	}{
+		{
+			desc:   "valid configuration",
+			config: plugins.CircuitBreaker{MaxFailures: 1, Window: ptypes.Duration(time.Second), Mode: plugins.CircuitBreakerFailOpen},
+		},
+		{
+			desc:   "default mode",
+			config: plugins.CircuitBreaker{MaxFailures: 1, Window: ptypes.Duration(time.Second)},
+		},
+		{
+			desc:        "no max failures",
+			config:      plugins.CircuitBreaker{Window: ptypes.Duration(time.Second)},
+			expectedErr: true,
+		},
+		{
+			desc:        "no window",
+			config:      plugins.CircuitBreaker{MaxFailures: 1},
+			expectedErr: true,
+		},
+		{
+			desc:        "unsupported mode",
+			config:      plugins.CircuitBreaker{MaxFailures: 1, Window: ptypes.Duration(time.Second), Mode: "foo"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			info := &runtime.MiddlewareInfo{}
+
+			_, err := newPluginBreaker("test", test.config, info)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, runtime.PluginStatusEnabled, info.GetPluginStatus())
+		})
+	}
+}
+
+func TestPluginBreaker(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		mode               string
+		plugin             http.HandlerFunc
+		expectedStatus     int
+		expectedPluginCode int
+		expectedNextCalled bool
+		expectedStatusName string
+	}{
+		{
+			desc:               "panicking plugin, fail closed",
+			mode:               plugins.CircuitBreakerFailClosed,
+			plugin:             func(http.ResponseWriter, *http.Request) { panic("boom") },
+			expectedPluginCode: http.StatusInternalServerError,
+			expectedStatus:     http.StatusServiceUnavailable,
+			expectedStatusName: runtime.PluginStatusDisabled,
+		},
+		{
+			desc:               "panicking plugin, fail open",
+			mode:               plugins.CircuitBreakerFailOpen,
+			plugin:             func(http.ResponseWriter, *http.Request) { panic("boom") },
+			expectedPluginCode: http.StatusInternalServerError,
+			expectedStatus:     http.StatusNoContent,
+			expectedNextCalled: true,
+			expectedStatusName: runtime.PluginStatusBypassed,
+		},
+		{
+			desc: "plugin server error, fail closed",
+			mode: plugins.CircuitBreakerFailClosed,
+			plugin: func(rw http.ResponseWriter, _ *http.Request) {
+				rw.WriteHeader(http.StatusBadGateway)
+			},
+			expectedPluginCode: http.StatusBadGateway,
+			expectedStatus:     http.StatusServiceUnavailable,
+			expectedStatusName: runtime.PluginStatusDisabled,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			info := &runtime.MiddlewareInfo{}
+			breaker, err := newPluginBreaker("test", plugins.CircuitBreaker{
+				MaxFailures: 2,
+				Window:      ptypes.Duration(time.Minute),
+				Mode:        test.mode,
+			}, info)
+			require.NoError(t, err)
+
+			var nextCalled bool
+			next := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				nextCalled = true
+				rw.WriteHeader(http.StatusNoContent)
+			})
+
+			h, err := breaker.wrap(context.Background(), next, func(http.Handler) (http.Handler, error) {
+				return test.plugin, nil
+			})
+			require.NoError(t, err)
+
+			for range 2 {
+				rw := httptest.NewRecorder()
+				h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+				assert.Equal(t, test.expectedPluginCode, rw.Code)
+			}
+
+			assert.Equal(t, test.expectedStatusName, info.GetPluginStatus())
+			assert.False(t, nextCalled)
+
+			rw := httptest.NewRecorder()
+			h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+			assert.Equal(t, test.expectedNextCalled, nextCalled)
+		})
+	}
+}
+
+func TestPluginBreaker_nextErrorsAreNotFailures(t *testing.T) {
+	info := &runtime.MiddlewareInfo{}
+	breaker, err := newPluginBreaker("test", plugins.CircuitBreaker{MaxFailures: 1, Window: ptypes.Duration(time.Minute)}, info)
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	})
+
+	h, err := breaker.wrap(context.Background(), next, passThroughPlugin)
+	require.NoError(t, err)
+
+	for range 3 {
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusInternalServerError, rw.Code)
+	}
+
+	assert.Equal(t, runtime.PluginStatusEnabled, info.GetPluginStatus())
+}
+
+func TestPluginBreaker_windowAndCooldown(t *testing.T) {
+	info := &runtime.MiddlewareInfo{}
+	breaker, err := newPluginBreaker("test", plugins.CircuitBreaker{
+		MaxFailures: 2,
+		Window:      ptypes.Duration(time.Minute),
+		Cooldown:    ptypes.Duration(30 * time.Second),
+	}, info)
+	require.NoError(t, err)
+
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+
+	// The first failure is out of the window when the second one occurs.
+	assert.False(t, breaker.recordFailure())
+	now = now.Add(2 * time.Minute)
+	assert.False(t, breaker.recordFailure())
+
+	now = now.Add(time.Second)
+	assert.True(t, breaker.recordFailure())
+	assert.Equal(t, runtime.PluginStatusDisabled, info.GetPluginStatus())
+	assert.False(t, breaker.allow())
+
+	now = now.Add(29 * time.Second)
+	assert.False(t, breaker.allow())
+
+	now = now.Add(time.Second)
+	assert.True(t, breaker.allow())
+	assert.Equal(t, runtime.PluginStatusEnabled, info.GetPluginStatus())
+
+	// The failures before the cooldown are forgotten.
+	assert.False(t, breaker.recordFailure())
+}
+
+func TestPluginBreaker_noCooldown(t *testing.T) {
+	breaker, err := newPluginBreaker("test", plugins.CircuitBreaker{MaxFailures: 1, Window: ptypes.Duration(time.Minute)}, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+
+	assert.True(t, breaker.recordFailure())
+
+	now = now.Add(24 * time.Hour)
+	assert.False(t, breaker.allow())
+}
+
+var passThroughPlugin alice.Constructor = func(next http.Handler) (http.Handler, error) {
+	return next, nil
+}
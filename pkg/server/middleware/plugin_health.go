@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/logs"
+	"github.com/traefik/traefik/v3/pkg/plugins"
+	"github.com/traefik/traefik/v3/pkg/safe"
+)
+
+// pluginHealth polls the health hook of the handlers of a plugin middleware.
+// While one of the handlers is unhealthy, the plugin is removed from the middleware chain,
+// or the requests are answered with a 503 status code.
+type pluginHealth struct {
+	name   string
+	config plugins.HealthCheck
+	info   *runtime.MiddlewareInfo
+
+	pollOnce  sync.Once
+	mu        sync.Mutex
+	checkers  []plugins.HealthChecker
+	unhealthy atomic.Bool
+}
+
+func newPluginHealth(name string, config plugins.HealthCheck, info *runtime.MiddlewareInfo) (*pluginHealth, error) {
+	if config.Interval <= 0 {
+		return nil, errors.New("health check: interval must be greater than zero")
+	}
+
+	switch config.Mode {
+	case plugins.CircuitBreakerFailOpen, plugins.CircuitBreakerFailClosed:
+	case "":
+		config.Mode = plugins.CircuitBreakerFailOpen
+	default:
+		return nil, fmt.Errorf("health check: unsupported mode %q", config.Mode)
+	}
+
+	return &pluginHealth{
+		name:   name,
+		config: config,
+		info:   info,
+	}, nil
+}
+
+// constructor returns a constructor polling the health hook of the handlers built by the given plugin constructor.
+// The handlers without a health hook are returned as is.
+func (p *pluginHealth) constructor(plug plugins.Constructor) plugins.Constructor {
+	return func(ctx context.Context, next http.Handler) (http.Handler, error) {
+		h, err := plug(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+
+		checker, ok := h.(plugins.HealthChecker)
+		if !ok {
+			return h, nil
+		}
+
+		p.mu.Lock()
+		p.checkers = append(p.checkers, checker)
+		p.mu.Unlock()
+
+		// The polling stops with the context of the routers, once they are rebuilt.
+		p.pollOnce.Do(func() {
+			p.setHealth(nil)
+
+			safe.Go(func() {
+				p.poll(ctx)
+			})
+		})
+
+		return &pluginHealthHandler{health: p, plugin: h, next: next}, nil
+	}
+}
+
+func (p *pluginHealth) poll(ctx context.Context) {
+	logger := log.Ctx(ctx).With().Str(logs.MiddlewareName, p.name).Logger()
+
+	ticker := time.NewTicker(time.Duration(p.config.Interval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.check(logger)
+		}
+	}
+}
+
+// check calls the health hooks and updates the health of the plugin.
+func (p *pluginHealth) check(logger zerolog.Logger) {
+	p.mu.Lock()
+	checkers := slices.Clone(p.checkers)
+	p.mu.Unlock()
+
+	var err error
+	for _, checker := range checkers {
+		if err = callHealthHook(checker); err != nil {
+			break
+		}
+	}
+
+	p.setHealth(err)
+
+	if err != nil {
+		if !p.unhealthy.Swap(true) {
+			logger.Error().Err(err).Msgf("Plugin unhealthy, plugin %s until it is healthy again", p.modeVerb())
+		}
+
+		return
+	}
+
+	if p.unhealthy.Swap(false) {
+		logger.Info().Msg("Plugin healthy again")
+	}
+}
+
+func callHealthHook(checker plugins.HealthChecker) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("health hook panicked: %v", r)
+		}
+	}()
+
+	return checker.Healthy()
+}
+
+func (p *pluginHealth) setHealth(err error) {
+	if p.info != nil {
+		p.info.SetPluginHealth(err)
+	}
+}
+
+func (p *pluginHealth) modeVerb() string {
+	if p.config.Mode == plugins.CircuitBreakerFailOpen {
+		return "removed from the middleware chain"
+	}
+
+	return "disabled"
+}
+
+type pluginHealthHandler struct {
+	health *pluginHealth
+	plugin http.Handler
+	next   http.Handler
+}
+
+func (h *pluginHealthHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if !h.health.unhealthy.Load() {
+		h.plugin.ServeHTTP(rw, req)
+		return
+	}
+
+	if h.health.config.Mode == plugins.CircuitBreakerFailOpen {
+		h.next.ServeHTTP(rw, req)
+		return
+	}
+
+	http.Error(rw, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+}
@@ -0,0 +1,191 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/plugins"
+)
+
+type healthyPlugin struct {
+	err    error
+	panics bool
+}
+
+func (p *healthyPlugin) ServeHTTP(rw http.ResponseWriter, _ *http.Request) {
+	rw.WriteHeader(http.StatusTeapot)
+}
+
+func (p *healthyPlugin) Healthy() error {
+	if p.panics {
+		panic("boom")
+	}
+
+	return p.err
+}
+
+func TestNewPluginHealth(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		config       plugins.HealthCheck
+		expectedMode string
+		expectedErr  bool
+	}{
+		{
+			desc:         "valid configuration",
+			config:       plugins.HealthCheck{Interval: ptypes.Duration(time.Second), Mode: plugins.CircuitBreakerFailClosed},
+			expectedMode: plugins.CircuitBreakerFailClosed,
+		},
+		{
+			desc:         "default mode",
+			config:       plugins.HealthCheck{Interval: ptypes.Duration(time.Second)},
+			expectedMode: plugins.CircuitBreakerFailOpen,
+		},
+		{
+			desc:        "no interval",
+			config:      plugins.HealthCheck{},
+			expectedErr: true,
+		},
+		{
+			desc:        "unsupported mode",
+			config:      plugins.HealthCheck{Interval: ptypes.Duration(time.Second), Mode: "foo"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			health, err := newPluginHealth("test", test.config, nil)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedMode, health.config.Mode)
+		})
+	}
+}
+
+func TestPluginHealth(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		mode           string
+		err            error
+		panics         bool
+		expectedStatus int
+		expectedHealth string
+		expectedError  string
+	}{
+		{
+			desc:           "healthy",
+			mode:           plugins.CircuitBreakerFailClosed,
+			expectedStatus: http.StatusTeapot,
+			expectedHealth: runtime.PluginHealthHealthy,
+		},
+		{
+			desc:           "unhealthy, fail open",
+			mode:           plugins.CircuitBreakerFailOpen,
+			err:            errors.New("backend unreachable"),
+			expectedStatus: http.StatusNoContent,
+			expectedHealth: runtime.PluginHealthUnhealthy,
+			expectedError:  "backend unreachable",
+		},
+		{
+			desc:           "unhealthy, fail closed",
+			mode:           plugins.CircuitBreakerFailClosed,
+			err:            errors.New("backend unreachable"),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedHealth: runtime.PluginHealthUnhealthy,
+			expectedError:  "backend unreachable",
+		},
+		{
+			desc:           "panicking health hook",
+			mode:           plugins.CircuitBreakerFailClosed,
+			panics:         true,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedHealth: runtime.PluginHealthUnhealthy,
+			expectedError:  "health hook panicked: boom",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			info := &runtime.MiddlewareInfo{}
+			health, err := newPluginHealth("test", plugins.HealthCheck{
+				Interval: ptypes.Duration(time.Hour),
+				Mode:     test.mode,
+			}, info)
+			require.NoError(t, err)
+
+			plugin := &healthyPlugin{}
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				rw.WriteHeader(http.StatusNoContent)
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			h, err := health.constructor(func(context.Context, http.Handler) (http.Handler, error) {
+				return plugin, nil
+			})(ctx, next)
+			require.NoError(t, err)
+
+			pluginHealth, _ := info.GetPluginHealth()
+			assert.Equal(t, runtime.PluginHealthHealthy, pluginHealth)
+
+			plugin.err = test.err
+			plugin.panics = test.panics
+			health.check(zerolog.Nop())
+
+			pluginHealth, pluginHealthError := info.GetPluginHealth()
+			assert.Equal(t, test.expectedHealth, pluginHealth)
+			assert.Equal(t, test.expectedError, pluginHealthError)
+
+			rw := httptest.NewRecorder()
+			h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, test.expectedStatus, rw.Code)
+
+			// The plugin is added back to the chain once it is healthy again.
+			plugin.err = nil
+			plugin.panics = false
+			health.check(zerolog.Nop())
+
+			rw = httptest.NewRecorder()
+			h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, http.StatusTeapot, rw.Code)
+		})
+	}
+}
+
+func TestPluginHealth_noHealthHook(t *testing.T) {
+	info := &runtime.MiddlewareInfo{}
+	health, err := newPluginHealth("test", plugins.HealthCheck{Interval: ptypes.Duration(time.Hour)}, info)
+	require.NoError(t, err)
+
+	plugin := http.NotFoundHandler()
+
+	h, err := health.constructor(func(context.Context, http.Handler) (http.Handler, error) {
+		return plugin, nil
+	})(context.Background(), http.NotFoundHandler())
+	require.NoError(t, err)
+
+	assert.IsType(t, plugin, h)
+
+	pluginHealth, _ := info.GetPluginHealth()
+	assert.Empty(t, pluginHealth)
+}
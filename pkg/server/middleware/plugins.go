@@ -15,6 +15,9 @@ const typeName = "Plugin"
 // PluginsBuilder the plugin's builder interface.
 type PluginsBuilder interface {
 	Build(pName string, config map[string]interface{}, middlewareName string) (plugins.Constructor, error)
+	ModuleName(pName string) string
+	CircuitBreaker(pName string) *plugins.CircuitBreaker
+	HealthCheck(pName string) *plugins.HealthCheck
 }
 
 func findPluginConfig(rawConfig map[string]dynamic.PluginConf) (string, map[string]interface{}, error) {
@@ -11,12 +11,15 @@ import (
 	"github.com/containous/alice"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/geoip"
 	"github.com/traefik/traefik/v3/pkg/logs"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
 	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
 	"github.com/traefik/traefik/v3/pkg/middlewares/denyrouterrecursion"
 	metricsMiddle "github.com/traefik/traefik/v3/pkg/middlewares/metrics"
 	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
 	"github.com/traefik/traefik/v3/pkg/middlewares/recovery"
+	"github.com/traefik/traefik/v3/pkg/middlewares/routerlimits"
 	httpmuxer "github.com/traefik/traefik/v3/pkg/muxer/http"
 	"github.com/traefik/traefik/v3/pkg/server/middleware"
 	"github.com/traefik/traefik/v3/pkg/server/provider"
@@ -42,10 +45,13 @@ type Manager struct {
 	middlewaresBuilder middlewareBuilder
 	conf               *runtime.Configuration
 	tlsManager         *tls.Manager
+	geoIPResolver      *geoip.Resolver
+	rulePriority       string
 }
 
 // NewManager creates a new Manager.
-func NewManager(conf *runtime.Configuration, serviceManager serviceManager, middlewaresBuilder middlewareBuilder, observabilityMgr *middleware.ObservabilityMgr, tlsManager *tls.Manager) *Manager {
+// The rulePriority tells how the default priority of the routers is computed from their rule: length or specificity.
+func NewManager(conf *runtime.Configuration, serviceManager serviceManager, middlewaresBuilder middlewareBuilder, observabilityMgr *middleware.ObservabilityMgr, tlsManager *tls.Manager, geoIPResolver *geoip.Resolver, rulePriority string) *Manager {
 	return &Manager{
 		routerHandlers:     make(map[string]http.Handler),
 		serviceManager:     serviceManager,
@@ -53,6 +59,8 @@ func NewManager(conf *runtime.Configuration, serviceManager serviceManager, midd
 		middlewaresBuilder: middlewaresBuilder,
 		conf:               conf,
 		tlsManager:         tlsManager,
+		geoIPResolver:      geoIPResolver,
+		rulePriority:       rulePriority,
 	}
 }
 
@@ -115,12 +123,16 @@ func (m *Manager) buildEntryPointHandler(ctx context.Context, entryPointName str
 
 	muxer.SetDefaultHandler(defaultHandler)
 
+	if m.geoIPResolver != nil {
+		muxer.SetGeoIPResolver(m.geoIPResolver)
+	}
+
 	for routerName, routerConfig := range configs {
 		logger := log.Ctx(ctx).With().Str(logs.RouterName, routerName).Logger()
 		ctxRouter := logger.WithContext(provider.AddInContext(ctx, routerName))
 
 		if routerConfig.Priority == 0 {
-			routerConfig.Priority = httpmuxer.GetRulePriority(routerConfig.Rule)
+			routerConfig.Priority = m.getRulePriority(routerConfig)
 		}
 
 		if routerConfig.Priority > maxUserPriority && !strings.HasSuffix(routerName, "@internal") {
@@ -218,7 +230,13 @@ func (m *Manager) buildHTTPHandler(ctx context.Context, router *runtime.RouterIn
 
 	mHandler := m.middlewaresBuilder.BuildChain(ctx, router.Middlewares)
 
-	chain := alice.New()
+	// The limits of the router apply to its middlewares too.
+	chain := alice.New(routerlimits.WrapHandler(routerName, router.Router))
+
+	// The router name is made available to the middlewares, e.g. for the header templates.
+	if len(router.Middlewares) > 0 {
+		chain = chain.Append(middlewares.WrapRouterName(routerName))
+	}
 
 	if m.observabilityMgr.MetricsRegistry() != nil && m.observabilityMgr.MetricsRegistry().IsRouterEnabled() &&
 		m.observabilityMgr.ShouldAddMetrics(provider.GetQualifiedName(ctx, router.Service)) {
@@ -244,6 +262,23 @@ func (m *Manager) buildHTTPHandler(ctx context.Context, router *runtime.RouterIn
 	return chain.Extend(*mHandler).Then(sHandler)
 }
 
+// getRulePriority computes the default priority of the given router from its rule.
+func (m *Manager) getRulePriority(router *runtime.RouterInfo) int {
+	if m.rulePriority != "specificity" {
+		return httpmuxer.GetRulePriority(router.Rule)
+	}
+
+	// An invalid rule is reported when the route is added to the muxer.
+	specificity, err := httpmuxer.GetRuleSpecificity(router.Rule, router.RuleSyntax)
+	if err != nil {
+		return httpmuxer.GetRulePriority(router.Rule)
+	}
+
+	router.Specificity = &specificity
+
+	return specificity.Priority()
+}
+
 // BuildDefaultHTTPRouter creates a default HTTP router.
 func BuildDefaultHTTPRouter() http.Handler {
 	return http.NotFoundHandler()
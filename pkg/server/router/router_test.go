@@ -15,6 +15,7 @@ import (
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
 	"github.com/traefik/traefik/v3/pkg/middlewares/requestdecorator"
+	httpmuxer "github.com/traefik/traefik/v3/pkg/muxer/http"
 	"github.com/traefik/traefik/v3/pkg/server/middleware"
 	"github.com/traefik/traefik/v3/pkg/server/service"
 	"github.com/traefik/traefik/v3/pkg/testhelpers"
@@ -312,10 +313,10 @@ func TestRouterManager_Get(t *testing.T) {
 			roundTripperManager := service.NewRoundTripperManager(nil)
 			roundTripperManager.Update(map[string]*dynamic.ServersTransport{"default@internal": {}})
 			serviceManager := service.NewManager(rtConf.Services, nil, nil, roundTripperManager)
-			middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, nil)
+			middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, nil, nil, middleware.Managers{})
 			tlsManager := tls.NewManager()
 
-			routerManager := NewManager(rtConf, serviceManager, middlewaresBuilder, nil, tlsManager)
+			routerManager := NewManager(rtConf, serviceManager, middlewaresBuilder, nil, tlsManager, nil, "")
 
 			handlers := routerManager.BuildHandlers(context.Background(), test.entryPoints, false)
 
@@ -685,11 +686,11 @@ func TestRuntimeConfiguration(t *testing.T) {
 			roundTripperManager := service.NewRoundTripperManager(nil)
 			roundTripperManager.Update(map[string]*dynamic.ServersTransport{"default@internal": {}})
 			serviceManager := service.NewManager(rtConf.Services, nil, nil, roundTripperManager)
-			middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, nil)
+			middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, nil, nil, middleware.Managers{})
 			tlsManager := tls.NewManager()
 			tlsManager.UpdateConfigs(context.Background(), nil, test.tlsOptions, nil)
 
-			routerManager := NewManager(rtConf, serviceManager, middlewaresBuilder, nil, tlsManager)
+			routerManager := NewManager(rtConf, serviceManager, middlewaresBuilder, nil, tlsManager, nil, "")
 
 			_ = routerManager.BuildHandlers(context.Background(), entryPoints, false)
 			_ = routerManager.BuildHandlers(context.Background(), entryPoints, true)
@@ -762,10 +763,10 @@ func TestProviderOnMiddlewares(t *testing.T) {
 	roundTripperManager := service.NewRoundTripperManager(nil)
 	roundTripperManager.Update(map[string]*dynamic.ServersTransport{"default@internal": {}})
 	serviceManager := service.NewManager(rtConf.Services, nil, nil, roundTripperManager)
-	middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, nil)
+	middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, nil, nil, middleware.Managers{})
 	tlsManager := tls.NewManager()
 
-	routerManager := NewManager(rtConf, serviceManager, middlewaresBuilder, nil, tlsManager)
+	routerManager := NewManager(rtConf, serviceManager, middlewaresBuilder, nil, tlsManager, nil, "")
 
 	_ = routerManager.BuildHandlers(context.Background(), entryPoints, false)
 
@@ -775,6 +776,95 @@ func TestProviderOnMiddlewares(t *testing.T) {
 	assert.Equal(t, []string{"m1@docker", "m2@docker", "m1@file"}, rtConf.Middlewares["chain@docker"].Chain.Middlewares)
 }
 
+func TestRulePriority(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		rulePriority        string
+		expectedPriorities  map[string]int
+		expectedSpecificity map[string]*httpmuxer.RuleSpecificity
+	}{
+		{
+			desc: "rule length",
+			expectedPriorities: map[string]int{
+				"host@file":     len("Host(`foo.bar`)"),
+				"prefix@file":   len("PathPrefix(`/a/long/path/prefix`)"),
+				"explicit@file": 42,
+			},
+			expectedSpecificity: map[string]*httpmuxer.RuleSpecificity{},
+		},
+		{
+			desc:         "rule specificity",
+			rulePriority: "specificity",
+			expectedPriorities: map[string]int{
+				"host@file":     200_000_001,
+				"prefix@file":   190_001,
+				"explicit@file": 42,
+			},
+			expectedSpecificity: map[string]*httpmuxer.RuleSpecificity{
+				"host@file":   {Host: 2, Matchers: 1},
+				"prefix@file": {Path: 19, Matchers: 1},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rtConf := runtime.NewConfig(dynamic.Configuration{
+				HTTP: &dynamic.HTTPConfiguration{
+					Services: map[string]*dynamic.Service{
+						"test@file": {
+							LoadBalancer: &dynamic.ServersLoadBalancer{
+								Servers: []dynamic.Server{{URL: "http://127.0.0.1:8080"}},
+							},
+						},
+					},
+					Routers: map[string]*dynamic.Router{
+						"host@file": {
+							EntryPoints: []string{"web"},
+							Rule:        "Host(`foo.bar`)",
+							Service:     "test@file",
+						},
+						"prefix@file": {
+							EntryPoints: []string{"web"},
+							Rule:        "PathPrefix(`/a/long/path/prefix`)",
+							Service:     "test@file",
+						},
+						"explicit@file": {
+							EntryPoints: []string{"web"},
+							Rule:        "Host(`foo.bar`) && PathPrefix(`/api`)",
+							Priority:    42,
+							Service:     "test@file",
+						},
+					},
+				},
+			})
+
+			roundTripperManager := service.NewRoundTripperManager(nil)
+			roundTripperManager.Update(map[string]*dynamic.ServersTransport{"default@internal": {}})
+			serviceManager := service.NewManager(rtConf.Services, nil, nil, roundTripperManager)
+			middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, nil, nil, middleware.Managers{})
+
+			routerManager := NewManager(rtConf, serviceManager, middlewaresBuilder, nil, tls.NewManager(), nil, test.rulePriority)
+
+			_ = routerManager.BuildHandlers(context.Background(), []string{"web"}, false)
+
+			priorities := make(map[string]int)
+			specificity := make(map[string]*httpmuxer.RuleSpecificity)
+			for name, router := range rtConf.Routers {
+				priorities[name] = router.Priority
+				if router.Specificity != nil {
+					specificity[name] = router.Specificity
+				}
+			}
+
+			assert.Equal(t, test.expectedPriorities, priorities)
+			assert.Equal(t, test.expectedSpecificity, specificity)
+		})
+	}
+}
+
 type staticRoundTripperGetter struct {
 	res *http.Response
 }
@@ -830,10 +920,10 @@ func BenchmarkRouterServe(b *testing.B) {
 	})
 
 	serviceManager := service.NewManager(rtConf.Services, nil, nil, staticRoundTripperGetter{res})
-	middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, nil)
+	middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, nil, nil, middleware.Managers{})
 	tlsManager := tls.NewManager()
 
-	routerManager := NewManager(rtConf, serviceManager, middlewaresBuilder, nil, tlsManager)
+	routerManager := NewManager(rtConf, serviceManager, middlewaresBuilder, nil, tlsManager, nil, "")
 
 	handlers := routerManager.BuildHandlers(context.Background(), entryPoints, false)
 
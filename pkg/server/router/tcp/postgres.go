@@ -8,8 +8,10 @@ import (
 	"net"
 	"sync"
 
+	"github.com/pires/go-proxyproto"
 	"github.com/rs/zerolog/log"
 	tcpmuxer "github.com/traefik/traefik/v3/pkg/muxer/tcp"
+	"github.com/traefik/traefik/v3/pkg/proxyprotocol"
 	"github.com/traefik/traefik/v3/pkg/tcp"
 )
 
@@ -70,7 +72,9 @@ func (r *Router) servePostgres(conn tcp.WriteCloser) {
 		return
 	}
 
-	connData, err := tcpmuxer.NewConnData(hello.serverName, conn, hello.protos)
+	proxiedConn := r.GetConn(conn, hello.peeked)
+
+	connData, err := tcpmuxer.NewConnData(hello.serverName, proxiedConn, hello.protos)
 	if err != nil {
 		log.Error().Err(err).Msg("Error while reading TCP connection data")
 		conn.Close()
@@ -85,7 +89,6 @@ func (r *Router) servePostgres(conn tcp.WriteCloser) {
 	}
 
 	// We are in TLS mode and if the handler is not TLSHandler, we are in passthrough.
-	proxiedConn := r.GetConn(conn, hello.peeked)
 	if _, ok := handlerTCPTLS.(*tcp.TLSHandler); !ok {
 		proxiedConn = &postgresConn{WriteCloser: proxiedConn}
 	}
@@ -108,6 +111,16 @@ type postgresConn struct {
 	errChan   chan error
 }
 
+// SNICaptures returns the values captured from the server name by the rule of the matching router.
+func (c *postgresConn) SNICaptures() map[string]string {
+	return tcp.SNICaptures(c.WriteCloser)
+}
+
+// ProxyProtocolTLVs returns the TLVs of the PROXY protocol header the connection was received with, if any.
+func (c *postgresConn) ProxyProtocolTLVs() []proxyproto.TLV {
+	return proxyprotocol.TLVsFromConn(c.WriteCloser)
+}
+
 // Read reads bytes from the underlying connection (tcp.WriteCloser).
 // On first call, it actually only injects the PostgresStartTLSMsg,
 // in order to behave as a Postgres TLS client that initiates a STARTTLS handshake.
@@ -9,12 +9,16 @@ import (
 	"net"
 	"net/http"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
+	"github.com/pires/go-proxyproto"
 	"github.com/rs/zerolog/log"
 	tcpmuxer "github.com/traefik/traefik/v3/pkg/muxer/tcp"
+	"github.com/traefik/traefik/v3/pkg/proxyprotocol"
 	"github.com/traefik/traefik/v3/pkg/tcp"
+	"github.com/traefik/traefik/v3/pkg/tls/fingerprint"
 )
 
 const defaultBufSize = 4096
@@ -143,6 +147,10 @@ func (r *Router) ServeTCP(conn tcp.WriteCloser) {
 		log.Error().Err(err).Msg("Error while setting deadline")
 	}
 
+	// The connection replays the peeked bytes to the handlers,
+	// and provides the ClientHello fingerprints to the matchers.
+	conn = r.GetConn(conn, hello.peeked)
+
 	connData, err := tcpmuxer.NewConnData(hello.serverName, conn, hello.protos)
 	if err != nil {
 		log.Error().Err(err).Msg("Error while reading TCP connection data")
@@ -154,9 +162,9 @@ func (r *Router) ServeTCP(conn tcp.WriteCloser) {
 		handler, _ := r.muxerTCP.Match(connData)
 		switch {
 		case handler != nil:
-			handler.ServeTCP(r.GetConn(conn, hello.peeked))
+			handler.ServeTCP(conn)
 		case r.httpForwarder != nil:
-			r.httpForwarder.ServeTCP(r.GetConn(conn, hello.peeked))
+			r.httpForwarder.ServeTCP(conn)
 		default:
 			conn.Close()
 		}
@@ -165,7 +173,7 @@ func (r *Router) ServeTCP(conn tcp.WriteCloser) {
 
 	// Handling ACME-TLS/1 challenges.
 	if slices.Contains(hello.protos, tlsalpn01.ACMETLS1Protocol) {
-		r.acmeTLSALPNHandler().ServeTCP(r.GetConn(conn, hello.peeked))
+		r.acmeTLSALPNHandler().ServeTCP(conn)
 		return
 	}
 
@@ -179,14 +187,14 @@ func (r *Router) ServeTCP(conn tcp.WriteCloser) {
 		// In order not to depart from the behavior in 2.6,
 		// we only allow an HTTPS router to take precedence over a TCP-TLS router if it is _not_ an HostSNI(*) router
 		// (so basically any router that has a specific HostSNI based rule).
-		handlerHTTPS.ServeTCP(r.GetConn(conn, hello.peeked))
+		handlerHTTPS.ServeTCP(conn)
 		return
 	}
 
 	// Contains also TCP TLS passthrough routes.
 	handlerTCPTLS, catchAllTCPTLS := r.muxerTCPTLS.Match(connData)
 	if handlerTCPTLS != nil && !catchAllTCPTLS {
-		handlerTCPTLS.ServeTCP(r.GetConn(conn, hello.peeked))
+		handlerTCPTLS.ServeTCP(conn)
 		return
 	}
 
@@ -194,19 +202,19 @@ func (r *Router) ServeTCP(conn tcp.WriteCloser) {
 	// We end up here for e.g. an HTTPS router that only has a PathPrefix rule,
 	// which under the scenes is counted as an HostSNI(*) rule.
 	if handlerHTTPS != nil {
-		handlerHTTPS.ServeTCP(r.GetConn(conn, hello.peeked))
+		handlerHTTPS.ServeTCP(conn)
 		return
 	}
 
 	// Fallback on TCP TLS catchAll.
 	if handlerTCPTLS != nil {
-		handlerTCPTLS.ServeTCP(r.GetConn(conn, hello.peeked))
+		handlerTCPTLS.ServeTCP(conn)
 		return
 	}
 
 	// To handle 404s for HTTPS.
 	if r.httpsForwarder != nil {
-		r.httpsForwarder.ServeTCP(r.GetConn(conn, hello.peeked))
+		r.httpsForwarder.ServeTCP(conn)
 		return
 	}
 
@@ -241,9 +249,12 @@ func (r *Router) AddHTTPTLSConfig(sniHost string, config *tls.Config) {
 // GetConn creates a connection proxy with a peeked string.
 func (r *Router) GetConn(conn tcp.WriteCloser, peeked string) tcp.WriteCloser {
 	// TODO should it really be on Router ?
+	peekedBytes := []byte(peeked)
+
 	conn = &Conn{
-		Peeked:      []byte(peeked),
+		Peeked:      peekedBytes,
 		WriteCloser: conn,
+		hello:       peekedBytes,
 	}
 
 	return conn
@@ -328,6 +339,45 @@ type Conn struct {
 	// It can be type asserted against *net.TCPConn or other types as needed.
 	// It should not be read from directly unless Peeked is nil.
 	tcp.WriteCloser
+
+	// hello are all the peeked bytes, holding the TLS ClientHello of TLS connections.
+	hello            []byte
+	fingerprintsOnce sync.Once
+	fingerprints     *fingerprint.Fingerprints
+
+	// sniCaptures are the values captured from the server name by the rule of the matching router.
+	sniCaptures map[string]string
+}
+
+// SetSNICaptures records the values captured from the server name by the rule of the matching router.
+func (c *Conn) SetSNICaptures(captures map[string]string) {
+	c.sniCaptures = captures
+}
+
+// SNICaptures returns the values captured from the server name by the rule of the matching router.
+func (c *Conn) SNICaptures() map[string]string {
+	return c.sniCaptures
+}
+
+// ProxyProtocolTLVs returns the TLVs of the PROXY protocol header the connection was received with, if any.
+func (c *Conn) ProxyProtocolTLVs() []proxyproto.TLV {
+	return proxyprotocol.TLVsFromConn(c.WriteCloser)
+}
+
+// TLSFingerprints returns the fingerprints of the TLS ClientHello peeked from the connection,
+// or nil if the connection is not a TLS one.
+func (c *Conn) TLSFingerprints() *fingerprint.Fingerprints {
+	c.fingerprintsOnce.Do(func() {
+		fingerprints, err := fingerprint.Parse(c.hello)
+		if err != nil {
+			log.Debug().Err(err).Msg("Unable to compute the TLS ClientHello fingerprints")
+			return
+		}
+
+		c.fingerprints = fingerprints
+	})
+
+	return c.fingerprints
 }
 
 // Read reads bytes from the connection (using the buffer prior to actually reading).
@@ -6,6 +6,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
 	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/geoip"
 	"github.com/traefik/traefik/v3/pkg/server/middleware"
 	tcpmiddleware "github.com/traefik/traefik/v3/pkg/server/middleware/tcp"
 	"github.com/traefik/traefik/v3/pkg/server/router"
@@ -33,12 +34,23 @@ type RouterFactory struct {
 
 	dialerManager *tcp.DialerManager
 
+	managers middleware.Managers
+
+	geoIPResolver *geoip.Resolver
+	rulePriority  string
+
+	// tcpDrainer is shared by the TCP service managers, to drain the connections of the servers removed by a new configuration.
+	tcpDrainer *tcpsvc.Drainer
+
 	cancelPrevState func()
+
+	// drainRemovedServers drains the servers removed by the last created routers.
+	drainRemovedServers func()
 }
 
 // NewRouterFactory creates a new RouterFactory.
 func NewRouterFactory(staticConfiguration static.Configuration, managerFactory *service.ManagerFactory, tlsManager *tls.Manager,
-	observabilityMgr *middleware.ObservabilityMgr, pluginBuilder middleware.PluginsBuilder, dialerManager *tcp.DialerManager,
+	observabilityMgr *middleware.ObservabilityMgr, pluginBuilder middleware.PluginsBuilder, dialerManager *tcp.DialerManager, managers middleware.Managers,
 ) *RouterFactory {
 	var entryPointsTCP, entryPointsUDP []string
 	for name, cfg := range staticConfiguration.EntryPoints {
@@ -55,6 +67,20 @@ func NewRouterFactory(staticConfiguration static.Configuration, managerFactory *
 		}
 	}
 
+	var geoIPResolver *geoip.Resolver
+	if staticConfiguration.GeoIP != nil {
+		var err error
+		geoIPResolver, err = geoip.NewResolver(staticConfiguration.GeoIP.Databases)
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to load the GeoIP databases, the ClientCountry matcher is disabled")
+		}
+	}
+
+	var rulePriority string
+	if staticConfiguration.Core != nil {
+		rulePriority = staticConfiguration.Core.DefaultRulePriority
+	}
+
 	return &RouterFactory{
 		entryPointsTCP:   entryPointsTCP,
 		entryPointsUDP:   entryPointsUDP,
@@ -63,6 +89,10 @@ func NewRouterFactory(staticConfiguration static.Configuration, managerFactory *
 		tlsManager:       tlsManager,
 		pluginBuilder:    pluginBuilder,
 		dialerManager:    dialerManager,
+		managers:         managers,
+		geoIPResolver:    geoIPResolver,
+		rulePriority:     rulePriority,
+		tcpDrainer:       tcpsvc.NewDrainer(),
 	}
 }
 
@@ -78,9 +108,9 @@ func (f *RouterFactory) CreateRouters(rtConf *runtime.Configuration) (map[string
 	// HTTP
 	serviceManager := f.managerFactory.Build(rtConf)
 
-	middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, f.pluginBuilder)
+	middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, f.pluginBuilder, f.observabilityMgr, f.managers)
 
-	routerManager := router.NewManager(rtConf, serviceManager, middlewaresBuilder, f.observabilityMgr, f.tlsManager)
+	routerManager := router.NewManager(rtConf, serviceManager, middlewaresBuilder, f.observabilityMgr, f.tlsManager, f.geoIPResolver, f.rulePriority)
 
 	handlersNonTLS := routerManager.BuildHandlers(ctx, f.entryPointsTCP, false)
 	handlersTLS := routerManager.BuildHandlers(ctx, f.entryPointsTCP, true)
@@ -88,7 +118,7 @@ func (f *RouterFactory) CreateRouters(rtConf *runtime.Configuration) (map[string
 	serviceManager.LaunchHealthCheck(ctx)
 
 	// TCP
-	svcTCPManager := tcpsvc.NewManager(rtConf, f.dialerManager)
+	svcTCPManager := tcpsvc.NewManager(rtConf, f.dialerManager, f.tcpDrainer)
 
 	middlewaresTCPBuilder := tcpmiddleware.NewBuilder(rtConf.TCPMiddlewares)
 
@@ -102,5 +132,20 @@ func (f *RouterFactory) CreateRouters(rtConf *runtime.Configuration) (map[string
 
 	rtConf.PopulateUsedBy()
 
+	f.drainRemovedServers = func() {
+		serviceManager.DrainRemovedServers()
+		svcTCPManager.DrainRemovedServers()
+	}
+
 	return routersTCP, routersUDP
 }
+
+// DrainRemovedServers drains the in-flight requests and connections forwarded to the servers
+// which are not part of the last created routers.
+// It must be called once the entry points are switched to these routers,
+// for the requests and connections still handled by the previous routers not to be drained.
+func (f *RouterFactory) DrainRemovedServers() {
+	if f.drainRemovedServers != nil {
+		f.drainRemovedServers()
+	}
+}
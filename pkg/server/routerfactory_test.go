@@ -4,8 +4,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/api"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
 	"github.com/traefik/traefik/v3/pkg/config/static"
@@ -50,12 +53,12 @@ func TestReuseService(t *testing.T) {
 
 	roundTripperManager := service.NewRoundTripperManager(nil)
 	roundTripperManager.Update(map[string]*dynamic.ServersTransport{"default@internal": {}})
-	managerFactory := service.NewManagerFactory(staticConfig, nil, nil, roundTripperManager, nil)
+	managerFactory := service.NewManagerFactory(staticConfig, nil, nil, roundTripperManager, nil, api.Dependencies{})
 	tlsManager := tls.NewManager()
 
 	dialerManager := tcp.NewDialerManager(nil)
 	dialerManager.Update(map[string]*dynamic.TCPServersTransport{"default@internal": {}})
-	factory := NewRouterFactory(staticConfig, managerFactory, tlsManager, nil, nil, dialerManager)
+	factory := NewRouterFactory(staticConfig, managerFactory, tlsManager, nil, nil, dialerManager, middleware.Managers{})
 
 	entryPointsHandlers, _ := factory.CreateRouters(runtime.NewConfig(dynamic.Configuration{HTTP: dynamicConfigs}))
 
@@ -186,13 +189,13 @@ func TestServerResponseEmptyBackend(t *testing.T) {
 
 			roundTripperManager := service.NewRoundTripperManager(nil)
 			roundTripperManager.Update(map[string]*dynamic.ServersTransport{"default@internal": {}})
-			managerFactory := service.NewManagerFactory(staticConfig, nil, nil, roundTripperManager, nil)
+			managerFactory := service.NewManagerFactory(staticConfig, nil, nil, roundTripperManager, nil, api.Dependencies{})
 			tlsManager := tls.NewManager()
 
 			dialerManager := tcp.NewDialerManager(nil)
 			dialerManager.Update(map[string]*dynamic.TCPServersTransport{"default@internal": {}})
 			observabiltyMgr := middleware.NewObservabilityMgr(staticConfig, nil, nil, nil, nil, nil)
-			factory := NewRouterFactory(staticConfig, managerFactory, tlsManager, observabiltyMgr, nil, dialerManager)
+			factory := NewRouterFactory(staticConfig, managerFactory, tlsManager, observabiltyMgr, nil, dialerManager, middleware.Managers{})
 
 			entryPointsHandlers, _ := factory.CreateRouters(runtime.NewConfig(dynamic.Configuration{HTTP: test.config(testServer.URL)}))
 
@@ -230,12 +233,12 @@ func TestInternalServices(t *testing.T) {
 
 	roundTripperManager := service.NewRoundTripperManager(nil)
 	roundTripperManager.Update(map[string]*dynamic.ServersTransport{"default@internal": {}})
-	managerFactory := service.NewManagerFactory(staticConfig, nil, nil, roundTripperManager, nil)
+	managerFactory := service.NewManagerFactory(staticConfig, nil, nil, roundTripperManager, nil, api.Dependencies{})
 	tlsManager := tls.NewManager()
 
 	dialerManager := tcp.NewDialerManager(nil)
 	dialerManager.Update(map[string]*dynamic.TCPServersTransport{"default@internal": {}})
-	factory := NewRouterFactory(staticConfig, managerFactory, tlsManager, nil, nil, dialerManager)
+	factory := NewRouterFactory(staticConfig, managerFactory, tlsManager, nil, nil, dialerManager, middleware.Managers{})
 
 	entryPointsHandlers, _ := factory.CreateRouters(runtime.NewConfig(dynamic.Configuration{HTTP: dynamicConfigs}))
 
@@ -246,3 +249,67 @@ func TestInternalServices(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, responseRecorderOk.Result().StatusCode, "status code")
 }
+
+func TestDrainRemovedServers(t *testing.T) {
+	started := make(chan struct{})
+	canceled := make(chan struct{})
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		close(started)
+		<-req.Context().Done()
+		close(canceled)
+	}))
+	t.Cleanup(testServer.Close)
+
+	staticConfig := static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web": {},
+		},
+	}
+
+	buildConfiguration := func(serverURL string) *runtime.Configuration {
+		dynamicConfigs := th.BuildConfiguration(
+			th.WithRouters(th.WithRouter("foo",
+				th.WithEntryPoints("web"),
+				th.WithServiceName("bar"),
+				th.WithRule("PathPrefix(`/`)")),
+			),
+			th.WithLoadBalancerServices(th.WithService("bar",
+				th.WithServers(th.WithServer(serverURL))),
+			),
+		)
+		dynamicConfigs.Services["bar"].LoadBalancer.Drain = &dynamic.Drain{GracePeriod: ptypes.Duration(time.Millisecond)}
+
+		return runtime.NewConfig(dynamic.Configuration{HTTP: dynamicConfigs})
+	}
+
+	roundTripperManager := service.NewRoundTripperManager(nil)
+	roundTripperManager.Update(map[string]*dynamic.ServersTransport{"default@internal": {}})
+	managerFactory := service.NewManagerFactory(staticConfig, nil, nil, roundTripperManager, nil, api.Dependencies{})
+
+	dialerManager := tcp.NewDialerManager(nil)
+	dialerManager.Update(map[string]*dynamic.TCPServersTransport{"default@internal": {}})
+	factory := NewRouterFactory(staticConfig, managerFactory, tls.NewManager(), nil, nil, dialerManager, middleware.Managers{})
+
+	entryPointsHandlers, _ := factory.CreateRouters(buildConfiguration(testServer.URL))
+	factory.DrainRemovedServers()
+
+	go entryPointsHandlers["web"].GetHTTPHandler().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/", http.NoBody))
+	<-started
+
+	// The request is not drained before the entry points are switched to the routers without its server.
+	factory.CreateRouters(buildConfiguration("http://127.0.0.1:1"))
+
+	select {
+	case <-canceled:
+		t.Fatal("request drained before the routers switch")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	factory.DrainRemovedServers()
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("request not drained")
+	}
+}
@@ -13,3 +13,8 @@ import (
 func newListenConfig(configuration *static.EntryPoint) (lc net.ListenConfig) {
 	return
 }
+
+// setListenBacklog is not supported on this platform, the system default backlog is used.
+func setListenBacklog(listener net.Listener, backlog int) error {
+	return nil
+}
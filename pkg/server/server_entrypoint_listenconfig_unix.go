@@ -3,8 +3,10 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"net"
+	"strings"
 	"syscall"
 
 	"github.com/traefik/traefik/v3/pkg/config/static"
@@ -14,9 +16,41 @@ import (
 // newListenConfig creates a new net.ListenConfig for the given configuration of
 // the entry point.
 func newListenConfig(configuration *static.EntryPoint) (lc net.ListenConfig) {
-	if configuration != nil && configuration.ReusePort {
-		lc.Control = controlReusePort
+	if configuration == nil {
+		return
 	}
+
+	var controls []func(network, address string, c syscall.RawConn) error
+
+	if configuration.ReusePort {
+		controls = append(controls, controlReusePort)
+	}
+
+	if options := configuration.SocketOptions; options != nil {
+		if options.FreeBind {
+			controls = append(controls, controlFreeBind)
+		}
+
+		if options.TCPFastOpen > 0 {
+			controls = append(controls, controlTCPFastOpen(options.TCPFastOpen))
+		}
+	}
+
+	switch len(controls) {
+	case 0:
+	case 1:
+		lc.Control = controls[0]
+	default:
+		lc.Control = func(network, address string, c syscall.RawConn) error {
+			for _, control := range controls {
+				if err := control(network, address, c); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
 	return
 }
 
@@ -42,3 +76,67 @@ func controlReusePort(network, address string, c syscall.RawConn) error {
 	}
 	return nil
 }
+
+// controlFreeBind is a net.ListenConfig.Control function that allows binding
+// the socket to an IP address not assigned to any interface yet.
+func controlFreeBind(network, address string, c syscall.RawConn) error {
+	var setSockOptErr error
+	err := c.Control(func(fd uintptr) {
+		setSockOptErr = setFreeBind(int(fd), network)
+	})
+	if err != nil {
+		return fmt.Errorf("control: %w", err)
+	}
+	if setSockOptErr != nil {
+		return fmt.Errorf("setsockopt: %w", setSockOptErr)
+	}
+	return nil
+}
+
+// controlTCPFastOpen returns a net.ListenConfig.Control function that enables TCP Fast Open
+// on the TCP sockets, with the given maximum length of the queue of the pending connections.
+func controlTCPFastOpen(queueLength int) func(network, address string, c syscall.RawConn) error {
+	return func(network, address string, c syscall.RawConn) error {
+		if !strings.HasPrefix(network, "tcp") {
+			return nil
+		}
+
+		var setSockOptErr error
+		err := c.Control(func(fd uintptr) {
+			setSockOptErr = setTCPFastOpen(int(fd), queueLength)
+		})
+		if err != nil {
+			return fmt.Errorf("control: %w", err)
+		}
+		if setSockOptErr != nil {
+			return fmt.Errorf("setsockopt: %w", setSockOptErr)
+		}
+		return nil
+	}
+}
+
+// setListenBacklog sets the maximum length of the queue of the pending connections of the listener,
+// by listening again on its socket, which updates the backlog.
+func setListenBacklog(listener net.Listener, backlog int) error {
+	sc, ok := listener.(syscall.Conn)
+	if !ok {
+		return errors.New("listener does not expose its socket")
+	}
+
+	rawConn, err := sc.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var listenErr error
+	err = rawConn.Control(func(fd uintptr) {
+		listenErr = unix.Listen(int(fd), backlog)
+	})
+	if err != nil {
+		return fmt.Errorf("control: %w", err)
+	}
+	if listenErr != nil {
+		return fmt.Errorf("listen: %w", listenErr)
+	}
+	return nil
+}
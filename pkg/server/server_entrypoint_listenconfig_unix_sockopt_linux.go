@@ -0,0 +1,21 @@
+//go:build linux
+
+package server
+
+import (
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+func setFreeBind(fd int, network string) error {
+	if strings.HasSuffix(network, "6") {
+		return unix.SetsockoptInt(fd, unix.SOL_IPV6, unix.IPV6_FREEBIND, 1)
+	}
+
+	return unix.SetsockoptInt(fd, unix.SOL_IP, unix.IP_FREEBIND, 1)
+}
+
+func setTCPFastOpen(fd, queueLength int) error {
+	return unix.SetsockoptInt(fd, unix.IPPROTO_TCP, unix.TCP_FASTOPEN, queueLength)
+}
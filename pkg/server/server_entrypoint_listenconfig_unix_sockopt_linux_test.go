@@ -0,0 +1,54 @@
+//go:build linux
+
+package server
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	"golang.org/x/sys/unix"
+)
+
+func TestNewListenConfig_socketOptions(t *testing.T) {
+	ep := static.EntryPoint{
+		// 192.0.2.1 belongs to TEST-NET-1, which is not assigned to any interface.
+		Address: "192.0.2.1:0",
+		SocketOptions: &static.SocketOptions{
+			FreeBind:    true,
+			TCPFastOpen: 16,
+		},
+	}
+	listenConfig := newListenConfig(&ep)
+	require.NotNil(t, listenConfig.Control)
+
+	listener, err := listenConfig.Listen(context.Background(), "tcp", ep.GetAddress())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	require.NoError(t, setListenBacklog(listener, 16))
+
+	rawConn, err := listener.(syscall.Conn).SyscallConn()
+	require.NoError(t, err)
+
+	var freeBind, fastOpen int
+	err = rawConn.Control(func(fd uintptr) {
+		freeBind, err = unix.GetsockoptInt(int(fd), unix.SOL_IP, unix.IP_FREEBIND)
+		require.NoError(t, err)
+
+		fastOpen, err = unix.GetsockoptInt(int(fd), unix.IPPROTO_TCP, unix.TCP_FASTOPEN)
+		require.NoError(t, err)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, freeBind)
+	assert.Equal(t, 16, fastOpen)
+
+	// Without IP_FREEBIND, listening on an address which is not assigned fails.
+	_, err = net.Listen("tcp", "192.0.2.1:0")
+	require.Error(t, err)
+}
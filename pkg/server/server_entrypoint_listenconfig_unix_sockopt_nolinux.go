@@ -0,0 +1,13 @@
+//go:build freebsd || openbsd || darwin
+
+package server
+
+import "errors"
+
+func setFreeBind(fd int, network string) error {
+	return errors.New("IP_FREEBIND is only supported on Linux")
+}
+
+func setTCPFastOpen(fd, queueLength int) error {
+	return errors.New("TCP Fast Open is only supported on Linux")
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"expvar"
 	"fmt"
@@ -28,11 +29,13 @@ import (
 	"github.com/traefik/traefik/v3/pkg/middlewares/contenttype"
 	"github.com/traefik/traefik/v3/pkg/middlewares/forwardedheaders"
 	"github.com/traefik/traefik/v3/pkg/middlewares/requestdecorator"
+	"github.com/traefik/traefik/v3/pkg/proxyprotocol"
 	"github.com/traefik/traefik/v3/pkg/safe"
 	"github.com/traefik/traefik/v3/pkg/server/router"
 	tcprouter "github.com/traefik/traefik/v3/pkg/server/router/tcp"
 	"github.com/traefik/traefik/v3/pkg/server/service"
 	"github.com/traefik/traefik/v3/pkg/tcp"
+	"github.com/traefik/traefik/v3/pkg/tls/fingerprint"
 	"github.com/traefik/traefik/v3/pkg/types"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
@@ -49,11 +52,12 @@ var (
 	clientConnectionStates   = map[string]*connState{}
 	clientConnectionStatesMu = sync.RWMutex{}
 
-	socketActivationListeners map[string]net.Listener
+	socketActivationListeners   map[string]net.Listener
+	socketActivationPacketConns map[string]net.PacketConn
 )
 
 func init() {
-	// Populates pre-defined socketActivationListeners by socket activation.
+	// Populates pre-defined socketActivationListeners and socketActivationPacketConns by socket activation.
 	populateSocketActivationListeners()
 }
 
@@ -201,7 +205,7 @@ func NewTCPEntryPoint(ctx context.Context, name string, config *static.EntryPoin
 		return nil, fmt.Errorf("error preparing https server: %w", err)
 	}
 
-	h3Server, err := newHTTP3Server(ctx, config, httpsServer)
+	h3Server, err := newHTTP3Server(ctx, name, config, httpsServer)
 	if err != nil {
 		return nil, fmt.Errorf("error preparing http3 server: %w", err)
 	}
@@ -389,18 +393,41 @@ func (c *writeCloserWrapper) CloseWrite() error {
 	return c.writeCloser.CloseWrite()
 }
 
+// ProxyProtocolTLVs returns the TLVs of the PROXY protocol header the connection was received with, if any.
+func (c *writeCloserWrapper) ProxyProtocolTLVs() []proxyproto.TLV {
+	proxyConn, ok := c.Conn.(*proxyproto.Conn)
+	if !ok {
+		return nil
+	}
+
+	header := proxyConn.ProxyHeader()
+	if header == nil {
+		return nil
+	}
+
+	tlvs, err := header.TLVs()
+	if err != nil {
+		log.Debug().Err(err).Msg("Unable to parse the PROXY protocol TLVs")
+		return nil
+	}
+
+	return tlvs
+}
+
 // writeCloser returns the given connection, augmented with the WriteCloser
 // implementation, if any was found within the underlying conn.
 func writeCloser(conn net.Conn) (tcp.WriteCloser, error) {
 	switch typedConn := conn.(type) {
 	case *proxyproto.Conn:
-		underlying, ok := typedConn.TCPConn()
+		underlying, ok := typedConn.Raw().(tcp.WriteCloser)
 		if !ok {
-			return nil, errors.New("underlying connection is not a tcp connection")
+			return nil, errors.New("underlying connection is neither a tcp nor a unix connection")
 		}
 		return &writeCloserWrapper{writeCloser: underlying, Conn: typedConn}, nil
 	case *net.TCPConn:
 		return typedConn, nil
+	case *net.UnixConn:
+		return typedConn, nil
 	default:
 		return nil, fmt.Errorf("unknown connection type %T", typedConn)
 	}
@@ -410,6 +437,37 @@ func writeCloser(conn net.Conn) (tcp.WriteCloser, error) {
 // connections.
 type tcpKeepAliveListener struct {
 	*net.TCPListener
+
+	config net.KeepAliveConfig
+}
+
+// newKeepAliveConfig returns the TCP keep-alive configuration of the connections accepted by an entry point.
+func newKeepAliveConfig(options *static.SocketOptions) net.KeepAliveConfig {
+	config := net.KeepAliveConfig{
+		Enable:   true,
+		Idle:     static.DefaultTCPKeepAlivePeriod,
+		Interval: static.DefaultTCPKeepAlivePeriod,
+		// A negative count keeps the system default.
+		Count: -1,
+	}
+
+	if options == nil || options.KeepAlive == nil {
+		return config
+	}
+
+	if options.KeepAlive.Idle > 0 {
+		config.Idle = time.Duration(options.KeepAlive.Idle)
+	}
+
+	if options.KeepAlive.Interval > 0 {
+		config.Interval = time.Duration(options.KeepAlive.Interval)
+	}
+
+	if options.KeepAlive.Count > 0 {
+		config.Count = options.KeepAlive.Count
+	}
+
+	return config
 }
 
 func (ln tcpKeepAliveListener) Accept() (net.Conn, error) {
@@ -418,11 +476,7 @@ func (ln tcpKeepAliveListener) Accept() (net.Conn, error) {
 		return nil, err
 	}
 
-	if err := tc.SetKeepAlive(true); err != nil {
-		return nil, err
-	}
-
-	if err := tc.SetKeepAlivePeriod(3 * time.Minute); err != nil {
+	if err := tc.SetKeepAliveConfig(ln.config); err != nil {
 		// Some systems, such as OpenBSD, have no user-settable per-socket TCP keepalive options.
 		if !errors.Is(err, syscall.ENOPROTOOPT) {
 			return nil, err
@@ -451,6 +505,12 @@ func buildProxyProtocolListener(ctx context.Context, entryPoint *static.EntryPoi
 	}
 
 	proxyListener.Policy = func(upstream net.Addr) (proxyproto.Policy, error) {
+		// The peers of a unix socket have no IP to check, so they are only trusted with the insecure option.
+		if _, ok := upstream.(*net.UnixAddr); ok {
+			log.Ctx(ctx).Debug().Msg("Unix socket peers are not trusted without the insecure option, ignoring ProxyProtocol Headers")
+			return proxyproto.IGNORE, nil
+		}
+
 		ipAddr, ok := upstream.(*net.TCPAddr)
 		if !ok {
 			return proxyproto.REJECT, fmt.Errorf("type error %v", upstream)
@@ -480,14 +540,27 @@ func buildListener(ctx context.Context, name string, config *static.EntryPoint)
 			log.Warn().Str("name", name).Msg("Unable to find socket activation listener for entryPoint")
 		}
 
-		listenConfig := newListenConfig(config)
-		listener, err = listenConfig.Listen(ctx, "tcp", config.GetAddress())
+		if config.IsUnixSocket() {
+			listener, err = listenUnixSocket(ctx, config)
+		} else {
+			listenConfig := newListenConfig(config)
+			listener, err = listenConfig.Listen(ctx, "tcp", config.GetAddress())
+		}
 		if err != nil {
 			return nil, fmt.Errorf("error opening listener: %w", err)
 		}
+
+		if config.SocketOptions != nil && config.SocketOptions.Backlog > 0 {
+			if err := setListenBacklog(listener, config.SocketOptions.Backlog); err != nil {
+				_ = listener.Close()
+				return nil, fmt.Errorf("error setting listener backlog: %w", err)
+			}
+		}
 	}
 
-	listener = tcpKeepAliveListener{listener.(*net.TCPListener)}
+	if tcpListener, ok := listener.(*net.TCPListener); ok {
+		listener = tcpKeepAliveListener{TCPListener: tcpListener, config: newKeepAliveConfig(config.SocketOptions)}
+	}
 
 	if config.ProxyProtocol != nil {
 		listener, err = buildProxyProtocolListener(ctx, config, listener)
@@ -603,6 +676,13 @@ func createHTTPServer(ctx context.Context, ln net.Listener, configuration *stati
 		return nil, err
 	}
 
+	if configuration.ProxyProtocol != nil && len(configuration.ProxyProtocol.TLVHeaders) > 0 {
+		next, err = proxyProtocolTLVHeaders(configuration.ProxyProtocol.TLVHeaders, next)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var handler http.Handler
 	handler, err = forwardedheaders.NewXForwarded(
 		configuration.ForwardedHeaders.Insecure,
@@ -621,6 +701,9 @@ func createHTTPServer(ctx context.Context, ln net.Listener, configuration *stati
 
 	handler = contenttype.DisableAutoDetection(handler)
 
+	// The response controller is used by the routers overriding the responding timeouts.
+	handler = middlewares.WrapResponseController(handler)
+
 	if withH2c {
 		handler = h2c.NewHandler(handler, &http2.Server{
 			MaxConcurrentStreams: uint32(configuration.HTTP2.MaxConcurrentStreams),
@@ -665,6 +748,19 @@ func createHTTPServer(ctx context.Context, ln net.Listener, configuration *stati
 	serverHTTP.ConnContext = func(ctx context.Context, c net.Conn) context.Context {
 		// This adds an empty struct in order to store a RoundTripper in the ConnContext in case of Kerberos or NTLM.
 		ctx = service.AddTransportOnContext(ctx)
+
+		// This exposes the TLS ClientHello fingerprints, computed on demand, to the middlewares.
+		if tlsConn, ok := c.(*tls.Conn); ok {
+			if source, ok := tlsConn.NetConn().(fingerprint.Source); ok {
+				ctx = fingerprint.NewContext(ctx, source)
+			}
+		}
+
+		// This exposes the TLVs of the PROXY protocol header to the routers and the middlewares.
+		if tlvs := proxyprotocol.TLVsFromConn(c); len(tlvs) > 0 {
+			ctx = proxyprotocol.NewContext(ctx, tlvs)
+		}
+
 		if prevConnContext != nil {
 			return prevConnContext(ctx, c)
 		}
@@ -720,6 +816,11 @@ func (t *trackedConnection) Close() error {
 	return t.WriteCloser.Close()
 }
 
+// ProxyProtocolTLVs returns the TLVs of the PROXY protocol header the connection was received with, if any.
+func (t *trackedConnection) ProxyProtocolTLVs() []proxyproto.TLV {
+	return proxyprotocol.TLVsFromConn(t.WriteCloser)
+}
+
 // This function is inspired by http.AllowQuerySemicolons.
 func encodeQuerySemicolons(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
@@ -756,3 +857,31 @@ func denyFragment(h http.Handler) http.Handler {
 		h.ServeHTTP(rw, req)
 	})
 }
+
+// proxyProtocolTLVHeaders sets the given request headers to the values of the TLVs of the PROXY protocol header.
+// The headers sent by the client are always removed, so that they cannot be spoofed.
+func proxyProtocolTLVHeaders(tlvHeaders map[string]string, h http.Handler) (http.Handler, error) {
+	lookups := make(map[string]proxyprotocol.TLVLookup, len(tlvHeaders))
+	for tlv, header := range tlvHeaders {
+		lookup, err := proxyprotocol.NewTLVLookup(tlv)
+		if err != nil {
+			return nil, fmt.Errorf("building PROXY protocol TLV header %q: %w", header, err)
+		}
+
+		lookups[http.CanonicalHeaderKey(header)] = lookup
+	}
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		tlvs := proxyprotocol.FromContext(req.Context())
+
+		for header, lookup := range lookups {
+			req.Header.Del(header)
+
+			if value, ok := lookup(tlvs); ok {
+				req.Header.Set(header, value)
+			}
+		}
+
+		h.ServeHTTP(rw, req)
+	}), nil
+}
@@ -7,25 +7,32 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/quic-go/quic-go"
 	"github.com/quic-go/quic-go/http3"
+	"github.com/quic-go/webtransport-go"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/static"
 	tcprouter "github.com/traefik/traefik/v3/pkg/server/router/tcp"
+	"github.com/traefik/traefik/v3/pkg/server/service"
 )
 
 type http3server struct {
 	*http3.Server
 
+	// webTransport is the WebTransport server wrapping the HTTP/3 server, when WebTransport is enabled.
+	webTransport *webtransport.Server
+
 	http3conn net.PacketConn
 
 	lock   sync.RWMutex
 	getter func(info *tls.ClientHelloInfo) (*tls.Config, error)
 }
 
-func newHTTP3Server(ctx context.Context, configuration *static.EntryPoint, httpsServer *httpServer) (*http3server, error) {
+func newHTTP3Server(ctx context.Context, name string, configuration *static.EntryPoint, httpsServer *httpServer) (*http3server, error) {
 	if configuration.HTTP3 == nil {
 		return nil, nil
 	}
@@ -34,8 +41,11 @@ func newHTTP3Server(ctx context.Context, configuration *static.EntryPoint, https
 		return nil, errors.New("advertised port must be greater than or equal to zero")
 	}
 
-	listenConfig := newListenConfig(configuration)
-	conn, err := listenConfig.ListenPacket(ctx, "udp", configuration.GetAddress())
+	if configuration.HTTP3.MaxIdleTimeout < 0 {
+		return nil, errors.New("max idle timeout must be greater than or equal to zero")
+	}
+
+	conn, err := buildPacketConn(ctx, name, configuration)
 	if err != nil {
 		return nil, fmt.Errorf("starting listener: %w", err)
 	}
@@ -47,13 +57,32 @@ func newHTTP3Server(ctx context.Context, configuration *static.EntryPoint, https
 		},
 	}
 
-	h3.Server = &http3.Server{
-		Addr:      configuration.GetAddress(),
-		Port:      configuration.HTTP3.AdvertisedPort,
-		Handler:   httpsServer.Server.(*http.Server).Handler,
-		TLSConfig: &tls.Config{GetConfigForClient: h3.getGetConfigForClient},
+	handler := httpsServer.Server.(*http.Server).Handler
+	if configuration.HTTP3.ZeroRTT != nil {
+		handler = earlyDataHandler(configuration.HTTP3.ZeroRTT.Methods, handler)
+	}
+
+	h3.Server = &http3.Server{}
+	if configuration.HTTP3.WebTransport {
+		h3.webTransport = &webtransport.Server{
+			// The origin of the sessions is checked by the servers, to which the Origin header is forwarded.
+			CheckOrigin: func(*http.Request) bool { return true },
+		}
+		h3.Server = &h3.webTransport.H3
+
+		handler = webTransportHandler(h3.webTransport, handler)
+	}
+
+	*h3.Server = http3.Server{
+		// The address of the socket, which can differ from the configured one with socket activation.
+		Addr:            conn.LocalAddr().String(),
+		Port:            configuration.HTTP3.AdvertisedPort,
+		Handler:         handler,
+		TLSConfig:       &tls.Config{GetConfigForClient: h3.getGetConfigForClient},
+		EnableDatagrams: configuration.HTTP3.EnableDatagrams,
 		QUICConfig: &quic.Config{
-			Allow0RTT: false,
+			Allow0RTT:      configuration.HTTP3.ZeroRTT != nil,
+			MaxIdleTimeout: time.Duration(configuration.HTTP3.MaxIdleTimeout),
 		},
 	}
 
@@ -70,7 +99,55 @@ func newHTTP3Server(ctx context.Context, configuration *static.EntryPoint, https
 	return h3, nil
 }
 
+// earlyDataHandler rejects the requests received in 0-RTT early data, unless their method is one of the given ones,
+// as the early data can be replayed by an attacker.
+// The accepted requests are forwarded with the Early-Data header, so that the servers can reject them too (RFC 8470).
+func earlyDataHandler(methods []string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// The TLS connection state is captured when the request is received,
+		// so an incomplete handshake means that the request was sent in the early data.
+		if req.TLS == nil || req.TLS.HandshakeComplete {
+			next.ServeHTTP(rw, req)
+			return
+		}
+
+		if !slices.Contains(methods, req.Method) {
+			rw.WriteHeader(http.StatusTooEarly)
+			return
+		}
+
+		req.Header.Set("Early-Data", "1")
+
+		next.ServeHTTP(rw, req)
+	})
+}
+
+// webTransportHandler makes the WebTransport session requests upgradable by the services,
+// with the response writer of the HTTP/3 server.
+func webTransportHandler(server *webtransport.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !service.IsWebTransportRequest(req) {
+			next.ServeHTTP(rw, req)
+			return
+		}
+
+		upgrader := func(header http.Header) (*webtransport.Session, error) {
+			for name, values := range header {
+				rw.Header()[name] = values
+			}
+
+			return server.Upgrade(rw, req)
+		}
+
+		next.ServeHTTP(rw, req.WithContext(service.AddWebTransportUpgraderOnContext(req.Context(), upgrader)))
+	})
+}
+
 func (e *http3server) Start() error {
+	if e.webTransport != nil {
+		return e.webTransport.Serve(e.http3conn)
+	}
+
 	return e.Serve(e.http3conn)
 }
 
@@ -90,5 +167,9 @@ func (e *http3server) getGetConfigForClient(info *tls.ClientHelloInfo) (*tls.Con
 
 func (e *http3server) Shutdown(_ context.Context) error {
 	// TODO: use e.Server.CloseGracefully() when available.
+	if e.webTransport != nil {
+		return e.webTransport.Close()
+	}
+
 	return e.Server.Close()
 }
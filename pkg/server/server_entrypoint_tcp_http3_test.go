@@ -6,10 +6,12 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v3/pkg/config/static"
@@ -216,6 +218,155 @@ func TestHTTP30RTT(t *testing.T) {
 	assert.False(t, earlyConnection.ConnectionState().Used0RTT)
 }
 
+func TestHTTP30RTTEnabled(t *testing.T) {
+	certContent, err := localhostCert.Read()
+	require.NoError(t, err)
+
+	keyContent, err := localhostKey.Read()
+	require.NoError(t, err)
+
+	tlsCert, err := tls.X509KeyPair(certContent, keyContent)
+	require.NoError(t, err)
+
+	epConfig := &static.EntryPointsTransport{}
+	epConfig.SetDefaults()
+
+	zeroRTT := &static.ZeroRTTConfig{}
+	zeroRTT.SetDefaults()
+
+	entryPoint, err := NewTCPEntryPoint(context.Background(), "foo", &static.EntryPoint{
+		Address:          "127.0.0.1:0",
+		Transport:        epConfig,
+		ForwardedHeaders: &static.ForwardedHeaders{},
+		HTTP2:            &static.HTTP2Config{},
+		HTTP3:            &static.HTTP3Config{ZeroRTT: zeroRTT},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	router, err := tcprouter.NewRouter()
+	require.NoError(t, err)
+
+	router.AddHTTPTLSConfig("example.com", &tls.Config{
+		Certificates: []tls.Certificate{tlsCert},
+	})
+	router.SetHTTPSHandler(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("X-Early-Data", req.Header.Get("Early-Data"))
+		rw.WriteHeader(http.StatusOK)
+	}), nil)
+
+	ctx := context.Background()
+	go entryPoint.Start(ctx)
+	entryPoint.SwitchRouter(router)
+
+	t.Cleanup(func() {
+		entryPoint.Shutdown(ctx)
+	})
+
+	// We are racing with the http3Server readiness happening in the goroutine starting the entrypoint.
+	time.Sleep(time.Second)
+
+	certPool := x509.NewCertPool()
+	certPool.AppendCertsFromPEM(certContent)
+
+	gets := make(chan string, 100)
+	puts := make(chan string, 100)
+	cache := newClientSessionCache(tls.NewLRUClientSessionCache(10), gets, puts)
+
+	url := "https://" + entryPoint.http3Server.http3conn.LocalAddr().String()
+
+	newRoundTripper := func() *http3.RoundTripper {
+		return &http3.RoundTripper{
+			TLSClientConfig: &tls.Config{
+				RootCAs:            certPool,
+				ServerName:         "example.com",
+				ClientSessionCache: cache,
+			},
+		}
+	}
+
+	// This first request is here to populate the cache.
+	rt := newRoundTripper()
+	t.Cleanup(func() { _ = rt.Close() })
+
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("X-Early-Data"))
+
+	select {
+	case <-puts:
+	case <-time.After(time.Second):
+		t.Fatal("session ticket not received")
+	}
+
+	// The request of the new connection is sent in the 0-RTT early data.
+	earlyRT := newRoundTripper()
+	t.Cleanup(func() { _ = earlyRT.Close() })
+
+	req, err = http.NewRequest(http3.MethodGet0RTT, url, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err = earlyRT.RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("X-Early-Data"))
+}
+
+func TestEarlyDataHandler(t *testing.T) {
+	testCases := []struct {
+		desc              string
+		method            string
+		handshakeComplete bool
+		expectedStatus    int
+		expectedEarlyData string
+	}{
+		{
+			desc:              "complete handshake",
+			method:            http.MethodPost,
+			handshakeComplete: true,
+			expectedStatus:    http.StatusOK,
+		},
+		{
+			desc:              "early data with a replay-safe method",
+			method:            http.MethodGet,
+			expectedStatus:    http.StatusOK,
+			expectedEarlyData: "1",
+		},
+		{
+			desc:           "early data with another method",
+			method:         http.MethodPost,
+			expectedStatus: http.StatusTooEarly,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var earlyData string
+			handler := earlyDataHandler([]string{http.MethodGet}, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				earlyData = req.Header.Get("Early-Data")
+			}))
+
+			req := httptest.NewRequest(test.method, "https://example.com", http.NoBody)
+			req.TLS = &tls.ConnectionState{HandshakeComplete: test.handshakeComplete}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+			assert.Equal(t, test.expectedEarlyData, earlyData)
+		})
+	}
+}
+
 type clientSessionCache struct {
 	cache tls.ClientSessionCache
 
@@ -7,14 +7,17 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/pires/go-proxyproto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/proxyprotocol"
 	tcprouter "github.com/traefik/traefik/v3/pkg/server/router/tcp"
 	"github.com/traefik/traefik/v3/pkg/tcp"
 )
@@ -142,7 +145,7 @@ func startEntrypoint(entryPoint *TCPEntryPoint, router *tcprouter.Router) (net.C
 	entryPoint.SwitchRouter(router)
 
 	for range 10 {
-		conn, err := net.Dial("tcp", entryPoint.listener.Addr().String())
+		conn, err := net.Dial(entryPoint.listener.Addr().Network(), entryPoint.listener.Addr().String())
 		if err != nil {
 			time.Sleep(100 * time.Millisecond)
 			continue
@@ -318,3 +321,72 @@ func TestKeepAliveMaxTime(t *testing.T) {
 	err = resp.Body.Close()
 	require.NoError(t, err)
 }
+
+func TestNewKeepAliveConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		options  *static.SocketOptions
+		expected net.KeepAliveConfig
+	}{
+		{
+			desc: "no socket options",
+			expected: net.KeepAliveConfig{
+				Enable:   true,
+				Idle:     3 * time.Minute,
+				Interval: 3 * time.Minute,
+				Count:    -1,
+			},
+		},
+		{
+			desc: "custom keep-alive",
+			options: &static.SocketOptions{
+				KeepAlive: &static.TCPKeepAlive{
+					Idle:     ptypes.Duration(30 * time.Second),
+					Interval: ptypes.Duration(10 * time.Second),
+					Count:    3,
+				},
+			},
+			expected: net.KeepAliveConfig{
+				Enable:   true,
+				Idle:     30 * time.Second,
+				Interval: 10 * time.Second,
+				Count:    3,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, newKeepAliveConfig(test.options))
+		})
+	}
+}
+
+func TestProxyProtocolTLVHeaders(t *testing.T) {
+	var headers http.Header
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		headers = req.Header
+	})
+
+	handler, err := proxyProtocolTLVHeaders(map[string]string{
+		"aws_vpce_id": "X-Aws-Vpce-Id",
+		"0xE0":        "X-Custom-Tlv",
+	}, next)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", http.NoBody)
+	req.Header.Set("X-Custom-Tlv", "spoofed")
+	req = req.WithContext(proxyprotocol.NewContext(req.Context(), []proxyproto.TLV{
+		{Type: 0xEA, Value: append([]byte{0x01}, "vpce-08d2bf15fac5001c9"...)},
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "vpce-08d2bf15fac5001c9", headers.Get("X-Aws-Vpce-Id"))
+	assert.NotContains(t, headers, "X-Custom-Tlv")
+
+	_, err = proxyProtocolTLVHeaders(map[string]string{"foobar": "X-Foobar"}, next)
+	require.Error(t, err)
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"sync"
 	"time"
 
@@ -28,7 +29,7 @@ func NewUDPEntryPoints(cfg static.EntryPoints) (UDPEntryPoints, error) {
 			continue
 		}
 
-		ep, err := NewUDPEntryPoint(entryPoint)
+		ep, err := NewUDPEntryPoint(entryPointName, entryPoint)
 		if err != nil {
 			return nil, fmt.Errorf("error while building entryPoint %s: %w", entryPointName, err)
 		}
@@ -85,16 +86,39 @@ type UDPEntryPoint struct {
 }
 
 // NewUDPEntryPoint returns a UDP entry point.
-func NewUDPEntryPoint(cfg *static.EntryPoint) (*UDPEntryPoint, error) {
-	listenConfig := newListenConfig(cfg)
-	listener, err := udp.Listen(listenConfig, "udp", cfg.GetAddress(), time.Duration(cfg.UDP.Timeout))
+func NewUDPEntryPoint(name string, cfg *static.EntryPoint) (*UDPEntryPoint, error) {
+	ctx := log.With().Str(logs.EntryPointName, name).Logger().WithContext(context.Background())
+
+	packetConn, err := buildPacketConn(ctx, name, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("listen packet: %w", err)
+	}
+
+	listener, err := udp.ListenPacketConn(packetConn, time.Duration(cfg.UDP.Timeout))
 	if err != nil {
+		_ = packetConn.Close()
 		return nil, err
 	}
 
 	return &UDPEntryPoint{listener: listener, switcher: &udp.HandlerSwitcher{}, transportConfiguration: cfg.Transport}, nil
 }
 
+// buildPacketConn returns the UDP socket of the entry point,
+// either passed by socket activation, or opened on the entry point address.
+func buildPacketConn(ctx context.Context, name string, cfg *static.EntryPoint) (net.PacketConn, error) {
+	// if we have predefined packet connection from socket activation
+	if conn, ok := socketActivationPacketConns[name]; ok {
+		return conn, nil
+	}
+
+	if len(socketActivationPacketConns) > 0 {
+		log.Ctx(ctx).Warn().Str("name", name).Msg("Unable to find socket activation packet connection for entryPoint")
+	}
+
+	listenConfig := newListenConfig(cfg)
+	return listenConfig.ListenPacket(ctx, "udp", cfg.GetAddress())
+}
+
 // Start commences the listening for ep.
 func (ep *UDPEntryPoint) Start(ctx context.Context) {
 	log.Ctx(ctx).Debug().Msg("Start UDP Server")
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v3/pkg/config/static"
@@ -24,7 +25,7 @@ func TestShutdownUDPConn(t *testing.T) {
 	}
 	ep.SetDefaults()
 
-	entryPoint, err := NewUDPEntryPoint(&ep)
+	entryPoint, err := NewUDPEntryPoint("", &ep)
 	require.NoError(t, err)
 
 	go entryPoint.Start(context.Background())
@@ -130,3 +131,24 @@ func requireEcho(t *testing.T, data string, conn io.ReadWriter, timeout time.Dur
 		t.Fatalf("Timeout during echo for: %s", data)
 	}
 }
+
+func TestNewUDPEntryPoint_socketActivation(t *testing.T) {
+	packetConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	previous := socketActivationPacketConns
+	socketActivationPacketConns = map[string]net.PacketConn{"dns": packetConn}
+	t.Cleanup(func() { socketActivationPacketConns = previous })
+
+	ep := static.EntryPoint{
+		// The address is ignored, in favor of the socket passed by socket activation.
+		Address: "invalid/udp",
+	}
+	ep.SetDefaults()
+
+	entryPoint, err := NewUDPEntryPoint("dns", &ep)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = entryPoint.listener.Close() })
+
+	assert.Equal(t, packetConn.LocalAddr(), entryPoint.listener.Addr())
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+)
+
+// listenUnixSocket opens a listener on the unix domain socket of the given entry point,
+// and applies the configured file mode and ownership to the socket.
+// The socket file is removed when the listener is closed.
+func listenUnixSocket(ctx context.Context, config *static.EntryPoint) (net.Listener, error) {
+	path := config.GetAddress()
+
+	if err := removeStaleUnixSocket(ctx, path); err != nil {
+		return nil, err
+	}
+
+	var listenConfig net.ListenConfig
+	listener, err := listenConfig.Listen(ctx, "unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	listener.(*net.UnixListener).SetUnlinkOnClose(true)
+
+	if err := setUnixSocketPermissions(path, config.UnixSocket); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+// removeStaleUnixSocket removes the socket file left at the given path by a previous process,
+// unless another process is still listening on it.
+func removeStaleUnixSocket(ctx context.Context, path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if info.Mode()&fs.ModeSocket == 0 {
+		return fmt.Errorf("%s already exists and is not a unix socket", path)
+	}
+
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("unix socket %s is already in use", path)
+	}
+
+	log.Ctx(ctx).Debug().Str("path", path).Msg("Removing stale unix socket")
+
+	return os.Remove(path)
+}
+
+// setUnixSocketPermissions applies the configured file mode and ownership to the socket at the given path.
+func setUnixSocketPermissions(path string, config *static.UnixSocketConfig) error {
+	if config == nil {
+		return nil
+	}
+
+	mode, err := config.GetMode()
+	if err != nil {
+		return err
+	}
+
+	if mode != 0 {
+		if err := os.Chmod(path, mode); err != nil {
+			return fmt.Errorf("setting unix socket mode: %w", err)
+		}
+	}
+
+	if config.Owner == "" && config.Group == "" {
+		return nil
+	}
+
+	uid, err := lookupID(config.Owner, func(name string) (string, error) {
+		u, err := user.Lookup(name)
+		if err != nil {
+			return "", err
+		}
+		return u.Uid, nil
+	})
+	if err != nil {
+		return fmt.Errorf("looking up unix socket owner: %w", err)
+	}
+
+	gid, err := lookupID(config.Group, func(name string) (string, error) {
+		g, err := user.LookupGroup(name)
+		if err != nil {
+			return "", err
+		}
+		return g.Gid, nil
+	})
+	if err != nil {
+		return fmt.Errorf("looking up unix socket group: %w", err)
+	}
+
+	if err := os.Chown(path, uid, gid); err != nil {
+		return fmt.Errorf("setting unix socket ownership: %w", err)
+	}
+
+	return nil
+}
+
+// lookupID returns the numeric ID of the given user or group name, or -1 to keep the current one if the name is empty.
+func lookupID(name string, lookup func(name string) (string, error)) (int, error) {
+	if name == "" {
+		return -1, nil
+	}
+
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil
+	}
+
+	id, err := lookup(name)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(id)
+}
@@ -0,0 +1,150 @@
+//go:build !windows
+
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	tcprouter "github.com/traefik/traefik/v3/pkg/server/router/tcp"
+)
+
+func TestUnixSocketEntryPoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "web.sock")
+
+	// A socket file left by a previous process is replaced.
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	epConfig := &static.EntryPointsTransport{}
+	epConfig.SetDefaults()
+	epConfig.LifeCycle.GraceTimeOut = ptypes.Duration(time.Second)
+
+	entryPoint, err := NewTCPEntryPoint(context.Background(), "", &static.EntryPoint{
+		Address:          "unix:" + path,
+		Transport:        epConfig,
+		ForwardedHeaders: &static.ForwardedHeaders{},
+		HTTP2:            &static.HTTP2Config{},
+		UnixSocket:       &static.UnixSocketConfig{Mode: "0600"},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	router := &tcprouter.Router{}
+	router.SetHTTPHandler(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+
+	conn, err := startEntrypoint(entryPoint, router)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", path)
+			},
+		},
+	}
+
+	resp, err := client.Get("http://localhost/")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	client.CloseIdleConnections()
+	entryPoint.Shutdown(context.Background())
+
+	// The socket file is removed on shutdown.
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestUnixSocketEntryPoint_inUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "web.sock")
+
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	_, err = listenUnixSocket(context.Background(), &static.EntryPoint{Address: "unix:" + path})
+	require.Error(t, err)
+}
+
+func TestUnixSocketEntryPoint_proxyProtocol(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		proxyProtocol *static.ProxyProtocol
+		expectedAddr  string
+	}{
+		{
+			desc:          "trusted IPs",
+			proxyProtocol: &static.ProxyProtocol{TrustedIPs: []string{"0.0.0.0/0", "::/0"}},
+		},
+		{
+			desc:          "insecure",
+			proxyProtocol: &static.ProxyProtocol{Insecure: true},
+			expectedAddr:  "10.0.0.1:1234",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "web.sock")
+
+			epConfig := &static.EntryPointsTransport{}
+			epConfig.SetDefaults()
+
+			listener, err := buildListener(context.Background(), "web", &static.EntryPoint{
+				Address:       "unix:" + path,
+				Transport:     epConfig,
+				ProxyProtocol: test.proxyProtocol,
+			})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = listener.Close() })
+
+			go func() {
+				conn, err := net.Dial("unix", path)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				_, _ = conn.Write([]byte("PROXY TCP4 10.0.0.1 10.0.0.2 1234 80\r\nGET / HTTP/1.1\r\n\r\n"))
+				_, _ = conn.Read(make([]byte, 1))
+			}()
+
+			conn, err := listener.Accept()
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = conn.Close() })
+
+			// The remote address is read once the header is.
+			_, err = conn.Read(make([]byte, 1))
+			require.NoError(t, err)
+
+			if test.expectedAddr == "" {
+				assert.IsType(t, &net.UnixAddr{}, conn.RemoteAddr())
+				return
+			}
+
+			assert.Equal(t, test.expectedAddr, conn.RemoteAddr().String())
+		})
+	}
+}
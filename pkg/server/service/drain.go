@@ -0,0 +1,163 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/logs"
+)
+
+// drainKey identifies a server of a service.
+type drainKey struct {
+	serviceName string
+	serverURL   string
+}
+
+// drainer drains the in-flight requests forwarded to the servers removed from the configuration,
+// i.e. removed from their load balancer, or belonging to a service no longer used by any router.
+// It outlives the configurations, in order to track the requests of the previous ones.
+type drainer struct {
+	mu       sync.Mutex
+	requests map[drainKey]map[*inFlightRequest]struct{}
+}
+
+// newDrainer creates a new drainer.
+func newDrainer() *drainer {
+	return &drainer{
+		requests: make(map[drainKey]map[*inFlightRequest]struct{}),
+	}
+}
+
+// inFlightRequest is a request forwarded to a server, which can be drained.
+type inFlightRequest struct {
+	config   dynamic.Drain
+	cancel   context.CancelFunc
+	draining atomic.Bool
+}
+
+// wrap returns a handler tracking the requests forwarded to the given server of the given service,
+// in order to drain them with the given configuration once the server is removed.
+func (d *drainer) wrap(serviceName, serverURL string, config dynamic.Drain, next http.Handler) http.Handler {
+	key := drainKey{serviceName: serviceName, serverURL: serverURL}
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+
+		request := &inFlightRequest{config: config, cancel: cancel}
+
+		d.add(key, request)
+		defer d.remove(key, request)
+
+		next.ServeHTTP(&drainResponseWriter{ResponseWriter: rw, request: request}, req.WithContext(ctx))
+	})
+}
+
+// drain drains the in-flight requests forwarded to the servers which are not among the given active ones.
+func (d *drainer) drain(active map[drainKey]struct{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for key, requests := range d.requests {
+		if _, ok := active[key]; ok {
+			continue
+		}
+
+		drained := 0
+		for request := range requests {
+			if request.draining.Swap(true) {
+				continue
+			}
+
+			drained++
+			if request.config.GracePeriod > 0 {
+				time.AfterFunc(time.Duration(request.config.GracePeriod), request.cancel)
+			}
+		}
+
+		if drained > 0 {
+			log.Debug().
+				Str(logs.ServiceName, key.serviceName).
+				Str("serverURL", key.serverURL).
+				Int("requests", drained).
+				Msg("Draining in-flight requests of a removed server")
+		}
+	}
+}
+
+func (d *drainer) add(key drainKey, request *inFlightRequest) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	requests, ok := d.requests[key]
+	if !ok {
+		requests = make(map[*inFlightRequest]struct{})
+		d.requests[key] = requests
+	}
+
+	requests[request] = struct{}{}
+}
+
+func (d *drainer) remove(key drainKey, request *inFlightRequest) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.requests[key], request)
+	if len(d.requests[key]) == 0 {
+		delete(d.requests, key)
+	}
+}
+
+// drainResponseWriter asks the client to close its connection,
+// when the response headers of a drained request are written.
+type drainResponseWriter struct {
+	http.ResponseWriter
+
+	request     *inFlightRequest
+	wroteHeader bool
+}
+
+func (w *drainResponseWriter) WriteHeader(code int) {
+	// The informational responses do not end the response headers.
+	if !w.wroteHeader && code >= http.StatusOK {
+		w.wroteHeader = true
+
+		if w.request.draining.Load() && w.request.config.CloseConnections {
+			w.ResponseWriter.Header().Set("Connection", "close")
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *drainResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Hijack hijacks the connection.
+func (w *drainResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.ResponseWriter.(http.Hijacker).Hijack()
+}
+
+// Flush sends any buffered data to the client.
+func (w *drainResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the wrapped response writer, for the http.ResponseController.
+func (w *drainResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
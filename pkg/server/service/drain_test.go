@@ -0,0 +1,98 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestDrainer(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		config             dynamic.Drain
+		active             bool
+		expectedConnection string
+		expectedCanceled   bool
+	}{
+		{
+			desc:   "active server",
+			config: dynamic.Drain{GracePeriod: ptypes.Duration(time.Millisecond), CloseConnections: true},
+			active: true,
+		},
+		{
+			desc:               "removed server",
+			config:             dynamic.Drain{GracePeriod: ptypes.Duration(time.Millisecond), CloseConnections: true},
+			expectedConnection: "close",
+			expectedCanceled:   true,
+		},
+		{
+			desc:             "removed server without closing the connections",
+			config:           dynamic.Drain{GracePeriod: ptypes.Duration(time.Millisecond)},
+			expectedCanceled: true,
+		},
+		{
+			desc:               "removed server without grace period",
+			config:             dynamic.Drain{CloseConnections: true},
+			expectedConnection: "close",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			d := newDrainer()
+
+			started := make(chan struct{})
+			drained := make(chan struct{})
+			canceled := make(chan bool, 1)
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				close(started)
+				<-drained
+
+				select {
+				case <-req.Context().Done():
+					canceled <- true
+				case <-time.After(100 * time.Millisecond):
+					canceled <- false
+				}
+
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			handler := d.wrap("foo@file", "http://127.0.0.1:8080", test.config, next)
+
+			rw := httptest.NewRecorder()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.com", http.NoBody))
+			}()
+
+			<-started
+
+			active := make(map[drainKey]struct{})
+			if test.active {
+				active[drainKey{serviceName: "foo@file", serverURL: "http://127.0.0.1:8080"}] = struct{}{}
+			}
+			d.drain(active)
+			close(drained)
+
+			<-done
+
+			assert.Equal(t, test.expectedCanceled, <-canceled)
+			assert.Equal(t, test.expectedConnection, rw.Header().Get("Connection"))
+
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			require.Empty(t, d.requests)
+		})
+	}
+}
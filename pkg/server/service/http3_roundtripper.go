@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"golang.org/x/net/http/httpguts"
+)
+
+// http3FallbackPeriod is the period during which the requests to a server are forwarded over the fallback round tripper,
+// once an HTTP/3 connection to this server could not be established.
+const http3FallbackPeriod = time.Minute
+
+// http3HandshakeTimeout bounds the establishment of the QUIC connections when no forwarding timeouts are configured,
+// for the requests to the servers silently dropping the UDP packets to fall back quickly.
+const http3HandshakeTimeout = 3 * time.Second
+
+// http3DialError is the error of a QUIC connection which could not be established,
+// in which case the request was not sent, and can be forwarded over the fallback round tripper.
+type http3DialError struct {
+	err error
+}
+
+func (e *http3DialError) Error() string {
+	return e.err.Error()
+}
+
+func (e *http3DialError) Unwrap() error {
+	return e.err
+}
+
+// http3RoundTripper forwards the requests to the https servers over HTTP/3,
+// and falls back to the given round tripper, i.e. HTTP/2 or HTTP/1.1,
+// for the other servers, the connection upgrades, and the servers HTTP/3 is not available for.
+type http3RoundTripper struct {
+	http3    http.RoundTripper
+	fallback http.RoundTripper
+
+	// handshakeTimeout bounds the establishment of the QUIC connections, if not zero.
+	handshakeTimeout time.Duration
+
+	fallbackMu sync.Mutex
+	// fallbackUntil holds, by server address, the end of the period during which HTTP/3 is considered unavailable.
+	fallbackUntil map[string]time.Time
+}
+
+func newHTTP3RoundTripper(tlsConfig *tls.Config, forwardingTimeouts *dynamic.ForwardingTimeouts, fallback http.RoundTripper) *http3RoundTripper {
+	r := &http3RoundTripper{
+		fallback:         fallback,
+		handshakeTimeout: quicDialTimeout(forwardingTimeouts),
+		fallbackUntil:    make(map[string]time.Time),
+	}
+
+	var rt http.RoundTripper = &http3.RoundTripper{
+		TLSClientConfig: tlsConfig,
+		// The QUIC connections are dialed with their own UDP socket, closed with the connection.
+		Dial: func(ctx context.Context, addr string, tlsCfg *tls.Config, cfg *quic.Config) (quic.EarlyConnection, error) {
+			if r.handshakeTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, r.handshakeTimeout)
+				defer cancel()
+			}
+
+			conn, err := quic.DialAddrEarly(ctx, addr, tlsCfg, cfg)
+			if err != nil {
+				return nil, &http3DialError{err: err}
+			}
+
+			return conn, nil
+		},
+	}
+
+	if forwardingTimeouts != nil && forwardingTimeouts.ResponseHeaderTimeout > 0 {
+		rt = newResponseHeaderTimeoutRoundTripper(rt, time.Duration(forwardingTimeouts.ResponseHeaderTimeout))
+	}
+
+	r.http3 = rt
+
+	return r
+}
+
+func (r *http3RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// HTTP/3 requires TLS, and does not support the connection upgrades, such as WebSocket.
+	if req.URL.Scheme != "https" || httpguts.HeaderValuesContainsToken(req.Header["Connection"], "Upgrade") || r.isFallback(req.URL.Host) {
+		return r.fallback.RoundTrip(req)
+	}
+
+	resp, err := r.http3.RoundTrip(req)
+
+	var dialErr *http3DialError
+	if err != nil && errors.As(err, &dialErr) && req.Context().Err() == nil {
+		log.Ctx(req.Context()).Debug().Err(err).Str("server", req.URL.Host).
+			Msgf("HTTP/3 unavailable, falling back to HTTP/2 and HTTP/1.1 for %s", http3FallbackPeriod)
+
+		r.fallbackMu.Lock()
+		r.fallbackUntil[req.URL.Host] = time.Now().Add(http3FallbackPeriod)
+		r.fallbackMu.Unlock()
+
+		return r.fallback.RoundTrip(req)
+	}
+
+	return resp, err
+}
+
+// quicDialTimeout returns the timeout of the establishment of the QUIC connections to the servers,
+// which is the HTTP/3 handshake timeout, or the dial timeout when shorter, and zero when none is set.
+func quicDialTimeout(forwardingTimeouts *dynamic.ForwardingTimeouts) time.Duration {
+	if forwardingTimeouts == nil {
+		return http3HandshakeTimeout
+	}
+
+	timeout := time.Duration(forwardingTimeouts.HTTP3HandshakeTimeout)
+	if dialTimeout := time.Duration(forwardingTimeouts.DialTimeout); dialTimeout > 0 && (timeout <= 0 || dialTimeout < timeout) {
+		timeout = dialTimeout
+	}
+
+	return timeout
+}
+
+// isFallback returns whether the requests to the given server are currently forwarded over the fallback round tripper.
+func (r *http3RoundTripper) isFallback(host string) bool {
+	r.fallbackMu.Lock()
+	defer r.fallbackMu.Unlock()
+
+	until, ok := r.fallbackUntil[host]
+	if !ok {
+		return false
+	}
+
+	if time.Now().After(until) {
+		delete(r.fallbackUntil, host)
+		return false
+	}
+
+	return true
+}
@@ -11,6 +11,7 @@ import (
 type serviceManager interface {
 	BuildHTTP(rootCtx context.Context, serviceName string) (http.Handler, error)
 	LaunchHealthCheck(ctx context.Context)
+	DrainRemovedServers()
 }
 
 // InternalHandlers is the internal HTTP handlers builder.
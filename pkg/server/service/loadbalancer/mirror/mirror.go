@@ -9,12 +9,17 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strconv"
 	"sync"
+	"time"
 
+	gokitmetrics "github.com/go-kit/kit/metrics"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/healthcheck"
+	"github.com/traefik/traefik/v3/pkg/metrics"
 	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
+	"github.com/traefik/traefik/v3/pkg/middlewares/capture"
 	"github.com/traefik/traefik/v3/pkg/safe"
 )
 
@@ -22,15 +27,14 @@ import (
 type Mirroring struct {
 	handler        http.Handler
 	mirrorHandlers []*mirrorHandler
-	rw             http.ResponseWriter
 	routinePool    *safe.Pool
 
 	mirrorBody       bool
 	maxBodySize      int64
 	wantsHealthCheck bool
 
-	lock  sync.RWMutex
-	total uint64
+	serviceName string
+	reqsCounter gokitmetrics.Counter
 }
 
 // New returns a new instance of *Mirroring.
@@ -38,54 +42,87 @@ func New(handler http.Handler, pool *safe.Pool, mirrorBody bool, maxBodySize int
 	return &Mirroring{
 		routinePool:      pool,
 		handler:          handler,
-		rw:               blackHoleResponseWriter{},
 		mirrorBody:       mirrorBody,
 		maxBodySize:      maxBodySize,
 		wantsHealthCheck: hc != nil,
 	}
 }
 
-func (m *Mirroring) inc() uint64 {
-	m.lock.Lock()
-	defer m.lock.Unlock()
-	m.total++
-	return m.total
+// SetMetricsRegistry enables the metrics comparing the status codes of the mirrors with the ones of the service,
+// reported under the given mirroring service name.
+func (m *Mirroring) SetMetricsRegistry(registry metrics.Registry, serviceName string) {
+	m.serviceName = serviceName
+	m.reqsCounter = registry.MirrorReqsCounter()
+}
+
+// MirrorOptions holds the options of a mirror.
+type MirrorOptions struct {
+	// Name is the name of the mirror in the metrics.
+	Name string
+	// Percent is the percentage of the matching requests which are mirrored.
+	Percent int
+	// Match reports whether a request can be mirrored, all the requests can be mirrored when nil.
+	Match func(req *http.Request) bool
+	// MaxBodySize is the maximum size of the body of the mirrored requests, the one of the Mirroring when nil.
+	MaxBodySize *int64
+	// Timeout is the maximum duration of the mirrored requests, if positive.
+	Timeout time.Duration
 }
 
 type mirrorHandler struct {
 	http.Handler
-	percent int
+	name        string
+	percent     int
+	match       func(req *http.Request) bool
+	maxBodySize int64
+	timeout     time.Duration
 
-	lock  sync.RWMutex
+	lock  sync.Mutex
+	total uint64
 	count uint64
 }
 
-func (m *Mirroring) getActiveMirrors() []http.Handler {
-	total := m.inc()
-
-	var mirrors []http.Handler
+// getActiveMirrors returns the mirrors the given request is mirrored to,
+// according to their match function and the percentage of the matching requests they receive.
+func (m *Mirroring) getActiveMirrors(req *http.Request) []*mirrorHandler {
+	var mirrors []*mirrorHandler
 	for _, handler := range m.mirrorHandlers {
+		if handler.match != nil && !handler.match(req) {
+			continue
+		}
+
 		handler.lock.Lock()
-		if handler.count*100 < total*uint64(handler.percent) {
+		handler.total++
+		if handler.count*100 < handler.total*uint64(handler.percent) {
 			handler.count++
-			handler.lock.Unlock()
 			mirrors = append(mirrors, handler)
-		} else {
-			handler.lock.Unlock()
 		}
+		handler.lock.Unlock()
 	}
 	return mirrors
 }
 
+// getMaxBodySize returns the maximum body size of the given mirrors, negative if one of them is unbounded.
+func getMaxBodySize(mirrors []*mirrorHandler) int64 {
+	var maxBodySize int64
+	for _, handler := range mirrors {
+		if handler.maxBodySize < 0 {
+			return -1
+		}
+		maxBodySize = max(maxBodySize, handler.maxBodySize)
+	}
+	return maxBodySize
+}
+
 func (m *Mirroring) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
-	mirrors := m.getActiveMirrors()
+	mirrors := m.getActiveMirrors(req)
 	if len(mirrors) == 0 {
 		m.handler.ServeHTTP(rw, req)
 		return
 	}
 
 	logger := log.Ctx(req.Context())
-	rr, bytesRead, err := newReusableRequest(req, m.mirrorBody, m.maxBodySize)
+	rr, bytesRead, err := newReusableRequest(req, m.mirrorBody, getMaxBodySize(mirrors))
 	if err != nil && !errors.Is(err, errBodyTooLarge) {
 		http.Error(rw, fmt.Sprintf("%s: creating reusable request: %v",
 			http.StatusText(http.StatusInternalServerError), err), http.StatusInternalServerError)
@@ -99,7 +136,14 @@ func (m *Mirroring) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	m.handler.ServeHTTP(rw, rr.clone(req.Context()))
+	handler := m.handler
+	capt := &capture.Capture{}
+	if m.reqsCounter != nil {
+		// The status code of the service is compared with the ones of the mirrors.
+		handler = capt.Reset(m.handler)
+	}
+
+	handler.ServeHTTP(rw, rr.clone(req.Context()))
 
 	select {
 	case <-req.Context().Done():
@@ -109,8 +153,18 @@ func (m *Mirroring) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	default:
 	}
 
+	var primaryCode string
+	if m.reqsCounter != nil {
+		primaryCode = strconv.Itoa(capt.StatusCode())
+	}
+
 	m.routinePool.GoCtx(func(_ context.Context) {
 		for _, handler := range mirrors {
+			if handler.maxBodySize >= 0 && int64(len(rr.body)) > handler.maxBodySize {
+				logger.Debug().Str("mirror", handler.name).Msg("No mirroring, request body larger than allowed size")
+				continue
+			}
+
 			// prepare request, update body from buffer
 			r := rr.clone(req.Context())
 
@@ -126,17 +180,48 @@ func (m *Mirroring) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 			// which would trigger a cancellation of the ongoing mirrored requests.
 			// Therefore, we give a new, non-cancellable context  to each of the mirrored calls,
 			// so they can terminate by themselves.
-			handler.ServeHTTP(m.rw, r.WithContext(contextStopPropagation{ctx}))
+			ctx = contextStopPropagation{ctx}
+
+			cancel := func() {}
+			if handler.timeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, handler.timeout)
+			}
+
+			mrw := &mirrorResponseWriter{}
+			handler.ServeHTTP(mrw, r.WithContext(ctx))
+			cancel()
+
+			if m.reqsCounter != nil {
+				m.reqsCounter.With("service", m.serviceName, "mirror", handler.name, "code", strconv.Itoa(mrw.status()), "primary_code", primaryCode).Add(1)
+			}
 		}
 	})
 }
 
 // AddMirror adds an httpHandler to mirror to.
 func (m *Mirroring) AddMirror(handler http.Handler, percent int) error {
-	if percent < 0 || percent > 100 {
+	return m.AddMirrorWithOptions(handler, MirrorOptions{Percent: percent})
+}
+
+// AddMirrorWithOptions adds an httpHandler to mirror to, with the given options.
+func (m *Mirroring) AddMirrorWithOptions(handler http.Handler, opts MirrorOptions) error {
+	if opts.Percent < 0 || opts.Percent > 100 {
 		return errors.New("percent must be between 0 and 100")
 	}
-	m.mirrorHandlers = append(m.mirrorHandlers, &mirrorHandler{Handler: handler, percent: percent})
+
+	maxBodySize := m.maxBodySize
+	if opts.MaxBodySize != nil {
+		maxBodySize = *opts.MaxBodySize
+	}
+
+	m.mirrorHandlers = append(m.mirrorHandlers, &mirrorHandler{
+		Handler:     handler,
+		name:        opts.Name,
+		percent:     opts.Percent,
+		match:       opts.Match,
+		maxBodySize: maxBodySize,
+		timeout:     opts.Timeout,
+	})
 	return nil
 }
 
@@ -183,6 +268,34 @@ func (b blackHoleResponseWriter) Write(data []byte) (int, error) {
 
 func (b blackHoleResponseWriter) WriteHeader(_ int) {}
 
+// mirrorResponseWriter discards the response of a mirror, keeping its status code.
+type mirrorResponseWriter struct {
+	blackHoleResponseWriter
+
+	statusCode int
+}
+
+func (w *mirrorResponseWriter) Write(data []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return len(data), nil
+}
+
+func (w *mirrorResponseWriter) WriteHeader(statusCode int) {
+	// The informational responses are followed by the final one.
+	if w.statusCode == 0 && statusCode >= http.StatusOK {
+		w.statusCode = statusCode
+	}
+}
+
+func (w *mirrorResponseWriter) status() int {
+	if w.statusCode == 0 {
+		return http.StatusOK
+	}
+	return w.statusCode
+}
+
 type contextStopPropagation struct {
 	context.Context
 }
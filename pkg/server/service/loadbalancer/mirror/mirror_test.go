@@ -8,9 +8,13 @@ import (
 	"net/http/httptest"
 	"sync/atomic"
 	"testing"
+	"time"
 
+	gokitmetrics "github.com/go-kit/kit/metrics"
 	"github.com/stretchr/testify/assert"
+	"github.com/traefik/traefik/v3/pkg/metrics"
 	"github.com/traefik/traefik/v3/pkg/safe"
+	"github.com/traefik/traefik/v3/pkg/testhelpers"
 )
 
 const defaultMaxBodySize int64 = -1
@@ -219,6 +223,133 @@ func TestMirroringWithIgnoredBody(t *testing.T) {
 	assert.Equal(t, numMirrors, int(val))
 }
 
+func TestMirroringWithMatch(t *testing.T) {
+	var countMirror1, countMirror2 int32
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	pool := safe.NewPool(context.Background())
+	mirror := New(handler, pool, true, defaultMaxBodySize, nil)
+	err := mirror.AddMirrorWithOptions(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&countMirror1, 1)
+	}), MirrorOptions{
+		Percent: 100,
+		Match:   func(req *http.Request) bool { return req.Method == http.MethodPost },
+	})
+	assert.NoError(t, err)
+
+	err = mirror.AddMirrorWithOptions(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&countMirror2, 1)
+	}), MirrorOptions{
+		Percent: 50,
+		Match:   func(req *http.Request) bool { return req.Method == http.MethodPost },
+	})
+	assert.NoError(t, err)
+
+	for range 50 {
+		mirror.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		mirror.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+	}
+
+	pool.Stop()
+
+	// The percentage applies to the matching requests only.
+	assert.Equal(t, 50, int(atomic.LoadInt32(&countMirror1)))
+	assert.Equal(t, 25, int(atomic.LoadInt32(&countMirror2)))
+}
+
+func TestMirroringWithMaxBodySize(t *testing.T) {
+	var countMirror1, countMirror2 int32
+
+	pool := safe.NewPool(context.Background())
+
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		bb, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(`body`), bb)
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	mirror := New(handler, pool, true, 2, nil)
+
+	err := mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&countMirror1, 1)
+	}), 100)
+	assert.NoError(t, err)
+
+	maxBodySize := int64(10)
+	err = mirror.AddMirrorWithOptions(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		bb, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(`body`), bb)
+		atomic.AddInt32(&countMirror2, 1)
+	}), MirrorOptions{Percent: 100, MaxBodySize: &maxBodySize})
+	assert.NoError(t, err)
+
+	mirror.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`body`)))
+
+	pool.Stop()
+
+	assert.Equal(t, 0, int(atomic.LoadInt32(&countMirror1)))
+	assert.Equal(t, 1, int(atomic.LoadInt32(&countMirror2)))
+}
+
+func TestMirroringWithTimeout(t *testing.T) {
+	var canceled int32
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	pool := safe.NewPool(context.Background())
+	mirror := New(handler, pool, true, defaultMaxBodySize, nil)
+	err := mirror.AddMirrorWithOptions(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+			atomic.AddInt32(&canceled, 1)
+		case <-time.After(5 * time.Second):
+		}
+	}), MirrorOptions{Percent: 100, Timeout: 10 * time.Millisecond})
+	assert.NoError(t, err)
+
+	mirror.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	pool.Stop()
+
+	assert.Equal(t, 1, int(atomic.LoadInt32(&canceled)))
+}
+
+type mirrorRegistry struct {
+	metrics.Registry
+
+	counter *testhelpers.CollectingCounter
+}
+
+func (r mirrorRegistry) MirrorReqsCounter() gokitmetrics.Counter {
+	return r.counter
+}
+
+func TestMirroringMetrics(t *testing.T) {
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	pool := safe.NewPool(context.Background())
+	mirror := New(handler, pool, true, defaultMaxBodySize, nil)
+
+	registry := mirrorRegistry{Registry: metrics.NewVoidRegistry(), counter: &testhelpers.CollectingCounter{}}
+	mirror.SetMetricsRegistry(registry, "demo@file")
+
+	err := mirror.AddMirrorWithOptions(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}), MirrorOptions{Name: "shadow", Percent: 100})
+	assert.NoError(t, err)
+
+	mirror.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	pool.Stop()
+
+	assert.Equal(t, float64(1), registry.counter.CounterValue)
+	assert.Equal(t, []string{"service", "demo@file", "mirror", "shadow", "code", "500", "primary_code", "200"}, registry.counter.LastLabelValues)
+}
+
 func TestCloneRequest(t *testing.T) {
 	t.Run("http request body is nil", func(t *testing.T) {
 		req, err := http.NewRequest(http.MethodPost, "/", nil)
@@ -0,0 +1,195 @@
+package split
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"net/http"
+	"regexp"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// variantNameRegexp restricts the variant names to values that can be used as is in a cookie or a header.
+var variantNameRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+type variant struct {
+	http.Handler
+	name string
+	// upperBound is the exclusive upper bound of the variant bucket, in the [0, 100) range.
+	upperBound int
+}
+
+type stickyCookie struct {
+	name     string
+	secure   bool
+	httpOnly bool
+	sameSite string
+	maxAge   int
+}
+
+// Split is an http.Handler assigning each client to a variant,
+// and forwarding its requests to the handler of this variant.
+// A client keeps its variant thanks to a cookie, or to the hash of a request header,
+// and is otherwise randomly assigned to a variant according to the variant percentages.
+type Split struct {
+	hashSeed      string
+	hashHeader    string
+	variantHeader string
+	stickyCookie  *stickyCookie
+
+	variants   []*variant
+	variantMap map[string]*variant
+}
+
+// New creates a new Split handler.
+// The hashSeed makes the header hash assignment specific to this handler.
+func New(config *dynamic.TrafficSplit, hashSeed string) *Split {
+	s := &Split{
+		hashSeed:      hashSeed,
+		hashHeader:    config.HashHeader,
+		variantHeader: config.VariantHeader,
+		variantMap:    make(map[string]*variant),
+	}
+
+	if config.Cookie != nil {
+		s.stickyCookie = &stickyCookie{
+			name:     config.Cookie.Name,
+			secure:   config.Cookie.Secure,
+			httpOnly: config.Cookie.HTTPOnly,
+			sameSite: config.Cookie.SameSite,
+			maxAge:   config.Cookie.MaxAge,
+		}
+	}
+
+	return s
+}
+
+// Add adds the handler of a variant, receiving the given percentage of the new clients.
+// A variant with a zero percentage does not get new clients,
+// but keeps serving the clients already holding its cookie.
+func (s *Split) Add(name string, handler http.Handler, percent int) error {
+	if !variantNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid variant name %q", name)
+	}
+
+	if _, ok := s.variantMap[name]; ok {
+		return fmt.Errorf("duplicated variant %q", name)
+	}
+
+	if percent < 0 {
+		return fmt.Errorf("invalid percent %d for variant %q", percent, name)
+	}
+
+	var lowerBound int
+	if len(s.variants) > 0 {
+		lowerBound = s.variants[len(s.variants)-1].upperBound
+	}
+
+	v := &variant{Handler: handler, name: name, upperBound: lowerBound + percent}
+	if v.upperBound > 100 {
+		return errors.New("the sum of the variant percentages is greater than 100")
+	}
+
+	s.variants = append(s.variants, v)
+	s.variantMap[name] = v
+
+	return nil
+}
+
+// Validate checks that the variant percentages cover all the clients.
+func (s *Split) Validate() error {
+	if len(s.variants) == 0 || s.variants[len(s.variants)-1].upperBound != 100 {
+		return errors.New("the sum of the variant percentages must be 100")
+	}
+
+	return nil
+}
+
+func (s *Split) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	v := s.stickyVariant(req)
+	if v == nil {
+		v = s.assignVariant(req)
+
+		if s.stickyCookie != nil {
+			http.SetCookie(rw, &http.Cookie{
+				Name:     s.stickyCookie.name,
+				Value:    v.name,
+				Path:     "/",
+				HttpOnly: s.stickyCookie.httpOnly,
+				Secure:   s.stickyCookie.secure,
+				SameSite: convertSameSite(s.stickyCookie.sameSite),
+				MaxAge:   s.stickyCookie.maxAge,
+			})
+		}
+	}
+
+	if s.variantHeader != "" {
+		req.Header.Set(s.variantHeader, v.name)
+	}
+
+	v.ServeHTTP(rw, req)
+}
+
+// stickyVariant returns the variant referenced by the request cookie, if any.
+func (s *Split) stickyVariant(req *http.Request) *variant {
+	if s.stickyCookie == nil {
+		return nil
+	}
+
+	cookie, err := req.Cookie(s.stickyCookie.name)
+	if err != nil {
+		if !errors.Is(err, http.ErrNoCookie) {
+			log.Warn().Err(err).Msg("Error while reading cookie")
+		}
+		return nil
+	}
+
+	return s.variantMap[cookie.Value]
+}
+
+// assignVariant picks the variant of a new client,
+// using the hash of the configured header when present, and a random bucket otherwise.
+func (s *Split) assignVariant(req *http.Request) *variant {
+	var bucket int
+	if value := s.hashHeaderValue(req); value != "" {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(s.hashSeed))
+		_, _ = h.Write([]byte(value))
+		bucket = int(h.Sum32() % 100)
+	} else {
+		bucket = rand.IntN(100)
+	}
+
+	for _, v := range s.variants {
+		if bucket < v.upperBound {
+			return v
+		}
+	}
+
+	// Unreachable once validated, as the last upper bound is 100.
+	return s.variants[len(s.variants)-1]
+}
+
+func (s *Split) hashHeaderValue(req *http.Request) string {
+	if s.hashHeader == "" {
+		return ""
+	}
+
+	return req.Header.Get(s.hashHeader)
+}
+
+func convertSameSite(sameSite string) http.SameSite {
+	switch sameSite {
+	case "none":
+		return http.SameSiteNoneMode
+	case "lax":
+		return http.SameSiteLaxMode
+	case "strict":
+		return http.SameSiteStrictMode
+	default:
+		return http.SameSiteDefaultMode
+	}
+}
@@ -0,0 +1,175 @@
+package split
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func variantHandler(name string) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("server", name)
+		rw.Header().Set("variant", req.Header.Get("X-Variant"))
+	})
+}
+
+func TestSplit_Add(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		name    string
+		percent int
+	}{
+		{
+			desc:    "invalid name",
+			name:    "a b",
+			percent: 10,
+		},
+		{
+			desc:    "duplicated name",
+			name:    "control",
+			percent: 10,
+		},
+		{
+			desc:    "negative percent",
+			name:    "test",
+			percent: -1,
+		},
+		{
+			desc:    "sum greater than 100",
+			name:    "test",
+			percent: 60,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			split := New(&dynamic.TrafficSplit{}, "foo")
+			require.NoError(t, split.Add("control", variantHandler("control"), 50))
+
+			assert.Error(t, split.Add(test.name, variantHandler(test.name), test.percent))
+		})
+	}
+}
+
+func TestSplit_Validate(t *testing.T) {
+	split := New(&dynamic.TrafficSplit{}, "foo")
+	assert.Error(t, split.Validate())
+
+	require.NoError(t, split.Add("control", variantHandler("control"), 50))
+	assert.Error(t, split.Validate())
+
+	require.NoError(t, split.Add("test", variantHandler("test"), 50))
+	assert.NoError(t, split.Validate())
+}
+
+func TestSplit_percentages(t *testing.T) {
+	split := New(&dynamic.TrafficSplit{VariantHeader: "X-Variant"}, "foo")
+	require.NoError(t, split.Add("control", variantHandler("control"), 80))
+	require.NoError(t, split.Add("disabled", variantHandler("disabled"), 0))
+	require.NoError(t, split.Add("test", variantHandler("test"), 20))
+
+	counts := map[string]int{}
+	for range 10000 {
+		recorder := httptest.NewRecorder()
+		split.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, recorder.Header().Get("server"), recorder.Header().Get("variant"))
+		assert.Empty(t, recorder.Result().Cookies())
+
+		counts[recorder.Header().Get("server")]++
+	}
+
+	assert.InDelta(t, 8000, counts["control"], 400)
+	assert.InDelta(t, 2000, counts["test"], 400)
+	assert.Zero(t, counts["disabled"])
+}
+
+func TestSplit_cookie(t *testing.T) {
+	config := &dynamic.TrafficSplit{
+		VariantHeader: "X-Variant",
+		Cookie: &dynamic.Cookie{
+			Name:     "ab",
+			HTTPOnly: true,
+			Secure:   true,
+			SameSite: "strict",
+			MaxAge:   3600,
+		},
+	}
+
+	split := New(config, "foo")
+	require.NoError(t, split.Add("control", variantHandler("control"), 0))
+	require.NoError(t, split.Add("test", variantHandler("test"), 100))
+
+	recorder := httptest.NewRecorder()
+	split.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, "test", recorder.Header().Get("server"))
+	assert.Equal(t, "test", recorder.Header().Get("variant"))
+
+	cookies := recorder.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "ab", cookies[0].Name)
+	assert.Equal(t, "test", cookies[0].Value)
+	assert.Equal(t, "/", cookies[0].Path)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.True(t, cookies[0].Secure)
+	assert.Equal(t, http.SameSiteStrictMode, cookies[0].SameSite)
+	assert.Equal(t, 3600, cookies[0].MaxAge)
+
+	// A client holding a cookie keeps its variant, even when the variant does not get new clients anymore.
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "ab", Value: "control"})
+
+	recorder = httptest.NewRecorder()
+	split.ServeHTTP(recorder, req)
+
+	assert.Equal(t, "control", recorder.Header().Get("server"))
+	assert.Empty(t, recorder.Result().Cookies())
+
+	// A client holding a cookie of an unknown variant is assigned again.
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "ab", Value: "removed"})
+
+	recorder = httptest.NewRecorder()
+	split.ServeHTTP(recorder, req)
+
+	assert.Equal(t, "test", recorder.Header().Get("server"))
+	require.Len(t, recorder.Result().Cookies(), 1)
+	assert.Equal(t, "test", recorder.Result().Cookies()[0].Value)
+}
+
+func TestSplit_hashHeader(t *testing.T) {
+	split := New(&dynamic.TrafficSplit{HashHeader: "X-User-Id"}, "foo")
+	require.NoError(t, split.Add("control", variantHandler("control"), 50))
+	require.NoError(t, split.Add("test", variantHandler("test"), 50))
+
+	counts := map[string]int{}
+	for i := range 1000 {
+		var first string
+		for range 5 {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-User-Id", strconv.Itoa(i))
+
+			recorder := httptest.NewRecorder()
+			split.ServeHTTP(recorder, req)
+
+			server := recorder.Header().Get("server")
+			if first == "" {
+				first = server
+				counts[server]++
+			}
+
+			assert.Equal(t, first, server)
+		}
+	}
+
+	assert.InDelta(t, 500, counts["control"], 100)
+	assert.InDelta(t, 500, counts["test"], 100)
+}
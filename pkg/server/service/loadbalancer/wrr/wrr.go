@@ -11,6 +11,7 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares/circuitbreaker"
 )
 
 type namedHandler struct {
@@ -168,9 +169,14 @@ func (b *Balancer) RegisterStatusUpdater(fn func(up bool)) error {
 	return nil
 }
 
-var errNoAvailableServer = errors.New("no available server")
+var (
+	errNoAvailableServer  = errors.New("no available server")
+	errCircuitBreakerOpen = errors.New("circuit breakers of all servers are open")
+)
 
-func (b *Balancer) nextServer() (*namedHandler, error) {
+// nextServer picks the next healthy server.
+// When a circuit breaker gate is given, the servers whose circuit breaker is open are skipped.
+func (b *Balancer) nextServer(gate circuitbreaker.BackendGate) (*namedHandler, error) {
 	b.handlersMu.Lock()
 	defer b.handlersMu.Unlock()
 
@@ -179,6 +185,7 @@ func (b *Balancer) nextServer() (*namedHandler, error) {
 	}
 
 	var handler *namedHandler
+	var rejected map[string]struct{}
 	for {
 		// Pick handler with closest deadline.
 		handler = heap.Pop(b).(*namedHandler)
@@ -188,16 +195,48 @@ func (b *Balancer) nextServer() (*namedHandler, error) {
 		handler.deadline += 1 / handler.weight
 
 		heap.Push(b, handler)
-		if _, ok := b.status[handler.name]; ok {
+		if _, ok := b.status[handler.name]; !ok {
+			continue
+		}
+
+		if gate == nil || gate.Allow(handler.name) {
 			break
 		}
+
+		if rejected == nil {
+			rejected = make(map[string]struct{})
+		}
+		rejected[handler.name] = struct{}{}
+
+		if len(rejected) == b.healthyCount() {
+			return nil, errCircuitBreakerOpen
+		}
 	}
 
 	log.Debug().Msgf("Service selected by WRR: %s", handler.name)
 	return handler, nil
 }
 
+// healthyCount returns the number of healthy handlers.
+// The caller must hold the lock.
+func (b *Balancer) healthyCount() int {
+	var count int
+	for _, handler := range b.handlers {
+		if _, ok := b.status[handler.name]; ok {
+			count++
+		}
+	}
+	return count
+}
+
 func (b *Balancer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	gate := circuitbreaker.GetBackendGate(req.Context())
+	if gate != nil {
+		// The circuit breakers only apply to the children of the first balancer,
+		// the nested ones must not see the gate.
+		req = req.WithContext(circuitbreaker.WithBackendGate(req.Context(), nil))
+	}
+
 	if b.stickyCookie != nil {
 		cookie, err := req.Cookie(b.stickyCookie.name)
 
@@ -214,19 +253,22 @@ func (b *Balancer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 				b.handlersMu.RLock()
 				_, isHealthy := b.status[handler.name]
 				b.handlersMu.RUnlock()
-				if isHealthy {
-					handler.ServeHTTP(w, req)
+				if isHealthy && (gate == nil || gate.Allow(handler.name)) {
+					serve(gate, handler, w, req)
 					return
 				}
 			}
 		}
 	}
 
-	server, err := b.nextServer()
+	server, err := b.nextServer(gate)
 	if err != nil {
-		if errors.Is(err, errNoAvailableServer) {
+		switch {
+		case errors.Is(err, errCircuitBreakerOpen):
+			gate.Fallback(w, req)
+		case errors.Is(err, errNoAvailableServer):
 			http.Error(w, errNoAvailableServer.Error(), http.StatusServiceUnavailable)
-		} else {
+		default:
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
 		return
@@ -245,7 +287,17 @@ func (b *Balancer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		http.SetCookie(w, cookie)
 	}
 
-	server.ServeHTTP(w, req)
+	serve(gate, server, w, req)
+}
+
+// serve forwards the request to the given handler, through the circuit breaker gate if any.
+func serve(gate circuitbreaker.BackendGate, handler *namedHandler, w http.ResponseWriter, req *http.Request) {
+	if gate != nil {
+		gate.Serve(handler.name, handler, w, req)
+		return
+	}
+
+	handler.ServeHTTP(w, req)
 }
 
 // Add adds a handler.
@@ -5,9 +5,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares/circuitbreaker"
 )
 
 func TestBalancer(t *testing.T) {
@@ -320,6 +324,68 @@ func TestBalancerBias(t *testing.T) {
 	assert.Equal(t, wantSequence, recorder.sequence)
 }
 
+func TestBalancerCircuitBreakerPerBackend(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		secondStatusCode int
+		expectedServers  []string
+		expectedStatuses []int
+	}{
+		{
+			desc:             "open circuit breaker skips the server",
+			secondStatusCode: http.StatusOK,
+			expectedServers:  []string{"first", "second", "second", "second", "second"},
+			expectedStatuses: []int{http.StatusInternalServerError, http.StatusOK, http.StatusOK, http.StatusOK, http.StatusOK},
+		},
+		{
+			desc:             "all circuit breakers open",
+			secondStatusCode: http.StatusInternalServerError,
+			expectedServers:  []string{"first", "second", "", "", ""},
+			expectedStatuses: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			balancer := New(nil, false)
+
+			balancer.Add("first", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Header().Set("server", "first")
+				rw.WriteHeader(http.StatusInternalServerError)
+			}), Int(1))
+
+			balancer.Add("second", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Header().Set("server", "second")
+				rw.WriteHeader(test.secondStatusCode)
+			}), Int(1))
+
+			config := dynamic.CircuitBreaker{}
+			config.SetDefaults()
+			config.Expression = "ResponseCodeRatio(500, 600, 0, 600) > 0.5"
+			config.CheckPeriod = ptypes.Duration(time.Nanosecond)
+			config.PerBackend = true
+
+			handler, err := circuitbreaker.New(context.Background(), balancer, nil, config, "cb", nil)
+			require.NoError(t, err)
+
+			var servers []string
+			var statuses []int
+			for range 5 {
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+				servers = append(servers, recorder.Header().Get("server"))
+				statuses = append(statuses, recorder.Code)
+			}
+
+			assert.Equal(t, test.expectedServers, servers)
+			assert.Equal(t, test.expectedStatuses, statuses)
+		})
+	}
+}
+
 func Int(v int) *int { return &v }
 
 type responseRecorder struct {
@@ -27,19 +27,23 @@ type ManagerFactory struct {
 	acmeHTTPHandler  http.Handler
 
 	routinesPool *safe.Pool
+
+	// drainer is shared by the service managers, to drain the requests of the servers removed by a new configuration.
+	drainer *drainer
 }
 
 // NewManagerFactory creates a new ManagerFactory.
-func NewManagerFactory(staticConfiguration static.Configuration, routinesPool *safe.Pool, observabilityMgr *middleware.ObservabilityMgr, roundTripperManager *RoundTripperManager, acmeHTTPHandler http.Handler) *ManagerFactory {
+func NewManagerFactory(staticConfiguration static.Configuration, routinesPool *safe.Pool, observabilityMgr *middleware.ObservabilityMgr, roundTripperManager *RoundTripperManager, acmeHTTPHandler http.Handler, apiDeps api.Dependencies) *ManagerFactory {
 	factory := &ManagerFactory{
 		observabilityMgr:    observabilityMgr,
 		routinesPool:        routinesPool,
 		roundTripperManager: roundTripperManager,
 		acmeHTTPHandler:     acmeHTTPHandler,
+		drainer:             newDrainer(),
 	}
 
 	if staticConfiguration.API != nil {
-		apiRouterBuilder := api.NewBuilder(staticConfiguration)
+		apiRouterBuilder := api.NewBuilder(staticConfiguration, apiDeps)
 
 		if staticConfiguration.API.Dashboard {
 			factory.dashboardHandler = dashboard.Handler{}
@@ -74,6 +78,7 @@ func NewManagerFactory(staticConfiguration static.Configuration, routinesPool *s
 // Build creates a service manager.
 func (f *ManagerFactory) Build(configuration *runtime.Configuration) *InternalHandlers {
 	svcManager := NewManager(configuration.Services, f.observabilityMgr, f.routinesPool, f.roundTripperManager)
+	svcManager.drainer = f.drainer
 
 	var apiHandler http.Handler
 	if f.api != nil {
@@ -112,6 +112,12 @@ func computeStatusCode(err error) int {
 	case errors.Is(err, context.Canceled):
 		return StatusClientClosedRequest
 	default:
+		// The request body exceeded the limit set by a middleware while being forwarded.
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return http.StatusRequestEntityTooLarge
+		}
+
 		var netErr net.Error
 		if errors.As(err, &netErr) {
 			if netErr.Timeout() {
@@ -4,9 +4,12 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v3/pkg/testhelpers"
 )
 
@@ -35,3 +38,24 @@ func BenchmarkProxy(b *testing.B) {
 		handler.ServeHTTP(w, req)
 	}
 }
+
+func TestSingleHostProxy_requestBodyTooLarge(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = io.Copy(io.Discard, req.Body)
+	}))
+	t.Cleanup(backend.Close)
+
+	target, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+
+	handler := buildSingleHostProxy(target, false, 0, http.DefaultTransport, newBufferPool())
+
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "http://foo.bar/", strings.NewReader(strings.Repeat("a", 1024)))
+	req.ContentLength = -1
+	req.Body = http.MaxBytesReader(rw, req.Body, 10)
+
+	handler.ServeHTTP(rw, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/webtransport-go"
+	"github.com/rs/zerolog/log"
+)
+
+// webTransportProtocol is the protocol of the extended CONNECT requests establishing the WebTransport sessions.
+const webTransportProtocol = "webtransport"
+
+// WebTransportUpgrader accepts the WebTransport session requested by the request it was created for,
+// by sending the response with the given headers.
+type WebTransportUpgrader func(header http.Header) (*webtransport.Session, error)
+
+type webTransportUpgraderKeyType struct{}
+
+var webTransportUpgraderKey webTransportUpgraderKeyType
+
+// AddWebTransportUpgraderOnContext adds the upgrader of the WebTransport session request on the context.
+// The upgrader has to be bound to the response writer of the HTTP/3 server,
+// as the session is established on the HTTP/3 stream of the request.
+func AddWebTransportUpgraderOnContext(ctx context.Context, upgrader WebTransportUpgrader) context.Context {
+	return context.WithValue(ctx, webTransportUpgraderKey, upgrader)
+}
+
+// IsWebTransportRequest returns whether the request is establishing a WebTransport session.
+func IsWebTransportRequest(req *http.Request) bool {
+	return req.Method == http.MethodConnect && req.Proto == webTransportProtocol
+}
+
+// webTransportHopHeaders are the hop-by-hop headers, which are not forwarded to the server.
+var webTransportHopHeaders = []string{
+	"Connection",
+	"Proxy-Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// webTransportConfigGetter is implemented by the RoundTripperGetter providing the TLS configuration,
+// and the QUIC dial timeout, of their servers transports.
+type webTransportConfigGetter interface {
+	GetTLSConfig(name string) (*tls.Config, error)
+	GetQUICDialTimeout(name string) time.Duration
+}
+
+// webTransportProxy proxies the WebTransport sessions to a server,
+// and forwards the other requests to the next handler.
+// All the streams and datagrams of a session are proxied to the server the session is established with.
+type webTransportProxy struct {
+	next        http.Handler
+	target      *url.URL
+	tlsConfig   *tls.Config
+	dialTimeout time.Duration
+
+	sessionsGauge  gokitmetrics.Gauge
+	streamsCounter gokitmetrics.Counter
+}
+
+func newWebTransportProxy(target *url.URL, tlsConfig *tls.Config, dialTimeout time.Duration, sessionsGauge gokitmetrics.Gauge, streamsCounter gokitmetrics.Counter, next http.Handler) http.Handler {
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	} else {
+		tlsConfig = tlsConfig.Clone()
+	}
+
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = target.Hostname()
+	}
+
+	return &webTransportProxy{
+		next:           next,
+		target:         target,
+		tlsConfig:      tlsConfig,
+		dialTimeout:    dialTimeout,
+		sessionsGauge:  sessionsGauge,
+		streamsCounter: streamsCounter,
+	}
+}
+
+func (p *webTransportProxy) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if !IsWebTransportRequest(req) {
+		p.next.ServeHTTP(rw, req)
+		return
+	}
+
+	logger := log.Ctx(req.Context())
+
+	upgrade, ok := req.Context().Value(webTransportUpgraderKey).(WebTransportUpgrader)
+	if !ok {
+		logger.Debug().Msg("WebTransport is not enabled on the entry point")
+		http.Error(rw, http.StatusText(http.StatusNotImplemented), http.StatusNotImplemented)
+		return
+	}
+
+	// WebTransport sessions are established over HTTP/3, which requires TLS.
+	if p.target.Scheme != "https" {
+		logger.Debug().Str("scheme", p.target.Scheme).Msg("WebTransport sessions can only be proxied to https servers")
+		http.Error(rw, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+
+	outURL := *p.target
+	outURL.Path = req.URL.Path
+	outURL.RawPath = req.URL.RawPath
+	outURL.RawQuery = req.URL.RawQuery
+
+	outHeader := req.Header.Clone()
+	removeHopHeaders(outHeader)
+
+	if clientIP, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		if prior := outHeader.Values("X-Forwarded-For"); len(prior) > 0 {
+			clientIP = strings.Join(prior, ", ") + ", " + clientIP
+		}
+		outHeader.Set("X-Forwarded-For", clientIP)
+	}
+
+	// The QUIC connection to the server is dedicated to the session, and closed with it.
+	var serverConn quic.EarlyConnection
+	dialer := &webtransport.Dialer{
+		TLSClientConfig: p.tlsConfig,
+		DialAddr: func(ctx context.Context, addr string, tlsConf *tls.Config, conf *quic.Config) (quic.EarlyConnection, error) {
+			if p.dialTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, p.dialTimeout)
+				defer cancel()
+			}
+
+			conn, err := quic.DialAddrEarly(ctx, addr, tlsConf, conf)
+			serverConn = conn
+			return conn, err
+		},
+	}
+	defer func() {
+		_ = dialer.Close()
+		if serverConn != nil {
+			_ = serverConn.CloseWithError(0, "")
+		}
+	}()
+
+	resp, serverSession, err := dialer.Dial(req.Context(), outURL.String(), outHeader)
+	if err != nil {
+		if resp != nil {
+			logger.Debug().Err(err).Msg("WebTransport session rejected by the server")
+			copyHeader(rw.Header(), resp.Header)
+			rw.WriteHeader(resp.StatusCode)
+			return
+		}
+
+		statusCode := http.StatusBadGateway
+		if errors.Is(err, context.Canceled) {
+			statusCode = StatusClientClosedRequest
+		}
+
+		logger.Debug().Err(err).Msgf("%d %s", statusCode, statusText(statusCode))
+		http.Error(rw, statusText(statusCode), statusCode)
+		return
+	}
+
+	clientSession, err := upgrade(resp.Header)
+	if err != nil {
+		logger.Debug().Err(err).Msg("Error while upgrading the WebTransport session")
+		_ = serverSession.CloseWithError(0, "")
+		return
+	}
+
+	if p.sessionsGauge != nil {
+		p.sessionsGauge.Add(1)
+		defer p.sessionsGauge.Add(-1)
+	}
+
+	p.pipeSessions(req.Context(), clientSession, serverSession)
+}
+
+// pipeSessions proxies the streams and datagrams between the given sessions,
+// until one of them or the context is closed.
+func (p *webTransportProxy) pipeSessions(ctx context.Context, clientSession, serverSession *webtransport.Session) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	// pipe runs the given function in its own goroutine, and closes the sessions once it returns.
+	pipe := func(fn func(ctx context.Context, src, dst *webtransport.Session) error, src, dst *webtransport.Session) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := fn(ctx, src, dst)
+
+			// The session closed by a peer is closed with the same error on the other side.
+			var sessionErr *webtransport.SessionError
+			if errors.As(err, &sessionErr) && sessionErr.Remote {
+				_ = dst.CloseWithError(sessionErr.ErrorCode, sessionErr.Message)
+			}
+
+			cancel()
+		}()
+	}
+
+	pipe(p.pipeStreams, clientSession, serverSession)
+	pipe(p.pipeStreams, serverSession, clientSession)
+	pipe(p.pipeUniStreams, clientSession, serverSession)
+	pipe(p.pipeUniStreams, serverSession, clientSession)
+	pipe(pipeDatagrams, clientSession, serverSession)
+	pipe(pipeDatagrams, serverSession, clientSession)
+
+	<-ctx.Done()
+
+	_ = clientSession.CloseWithError(0, "")
+	_ = serverSession.CloseWithError(0, "")
+
+	wg.Wait()
+}
+
+// pipeStreams proxies the bidirectional streams opened by the peer of the src session to the peer of the dst session.
+func (p *webTransportProxy) pipeStreams(ctx context.Context, src, dst *webtransport.Session) error {
+	for {
+		srcStream, err := src.AcceptStream(ctx)
+		if err != nil {
+			return err
+		}
+
+		dstStream, err := dst.OpenStreamSync(ctx)
+		if err != nil {
+			srcStream.CancelRead(0)
+			srcStream.CancelWrite(0)
+			return err
+		}
+
+		if p.streamsCounter != nil {
+			p.streamsCounter.With("type", "bidirectional").Add(1)
+		}
+
+		go pipeStream(dstStream, srcStream)
+		go pipeStream(srcStream, dstStream)
+	}
+}
+
+// pipeUniStreams proxies the unidirectional streams opened by the peer of the src session to the peer of the dst session.
+func (p *webTransportProxy) pipeUniStreams(ctx context.Context, src, dst *webtransport.Session) error {
+	for {
+		srcStream, err := src.AcceptUniStream(ctx)
+		if err != nil {
+			return err
+		}
+
+		dstStream, err := dst.OpenUniStreamSync(ctx)
+		if err != nil {
+			srcStream.CancelRead(0)
+			return err
+		}
+
+		if p.streamsCounter != nil {
+			p.streamsCounter.With("type", "unidirectional").Add(1)
+		}
+
+		go pipeStream(dstStream, srcStream)
+	}
+}
+
+// pipeStream copies the data of the src stream to the dst stream,
+// and propagates the end of the stream, or its cancellation.
+func pipeStream(dst webtransport.SendStream, src webtransport.ReceiveStream) {
+	if _, err := io.Copy(dst, src); err != nil {
+		var code webtransport.StreamErrorCode
+		var streamErr *webtransport.StreamError
+		if errors.As(err, &streamErr) {
+			code = streamErr.ErrorCode
+		}
+
+		src.CancelRead(code)
+		dst.CancelWrite(code)
+		return
+	}
+
+	_ = dst.Close()
+}
+
+// pipeDatagrams proxies the datagrams sent by the peer of the src session to the peer of the dst session.
+// As the datagrams are unreliable, the ones which cannot be sent are dropped.
+func pipeDatagrams(ctx context.Context, src, dst *webtransport.Session) error {
+	for {
+		datagram, err := src.ReceiveDatagram(ctx)
+		if err != nil {
+			return err
+		}
+
+		_ = dst.SendDatagram(datagram)
+	}
+}
+
+// removeHopHeaders removes the hop-by-hop headers, as well as the ones listed in the Connection header.
+func removeHopHeaders(header http.Header) {
+	for _, value := range header["Connection"] {
+		for _, name := range strings.Split(value, ",") {
+			if name = textproto.TrimString(name); name != "" {
+				header.Del(name)
+			}
+		}
+	}
+
+	for _, name := range webTransportHopHeaders {
+		header.Del(name)
+	}
+}
+
+func copyHeader(dst, src http.Header) {
+	for name, values := range src {
+		dst[name] = append(dst[name], values...)
+	}
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go/http3"
+	"github.com/quic-go/webtransport-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebTransportProxy(t *testing.T) {
+	cert, err := tls.X509KeyPair(LocalhostCert, LocalhostKey)
+	require.NoError(t, err)
+
+	certPool := x509.NewCertPool()
+	certPool.AppendCertsFromPEM(LocalhostCert)
+
+	// The server echoes the streams and datagrams of the sessions.
+	server := &webtransport.Server{
+		H3: http3.Server{
+			TLSConfig: &tls.Config{Certificates: []tls.Certificate{cert}},
+		},
+	}
+	server.H3.Handler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("X-Path", req.URL.Path)
+		rw.Header().Set("X-Forwarded-For", req.Header.Get("X-Forwarded-For"))
+
+		session, err := server.Upgrade(rw, req)
+		if err != nil {
+			return
+		}
+
+		go func() {
+			for {
+				datagram, err := session.ReceiveDatagram(session.Context())
+				if err != nil {
+					return
+				}
+				_ = session.SendDatagram(datagram)
+			}
+		}()
+
+		for {
+			stream, err := session.AcceptStream(session.Context())
+			if err != nil {
+				return
+			}
+
+			go func() {
+				_, _ = io.Copy(stream, stream)
+				_ = stream.Close()
+			}()
+		}
+	})
+	serverAddr := serveWebTransport(t, server)
+
+	target, err := url.Parse("https://" + serverAddr)
+	require.NoError(t, err)
+
+	proxy := newWebTransportProxy(target, &tls.Config{RootCAs: certPool, ServerName: "example.com"}, http3HandshakeTimeout, nil, nil,
+		http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			rw.WriteHeader(http.StatusTeapot)
+		}))
+
+	front := &webtransport.Server{
+		H3: http3.Server{
+			TLSConfig: &tls.Config{Certificates: []tls.Certificate{cert}},
+		},
+	}
+	front.H3.Handler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		upgrader := func(header http.Header) (*webtransport.Session, error) {
+			for name, values := range header {
+				rw.Header()[name] = values
+			}
+			return front.Upgrade(rw, req)
+		}
+		proxy.ServeHTTP(rw, req.WithContext(AddWebTransportUpgraderOnContext(req.Context(), upgrader)))
+	})
+	frontAddr := serveWebTransport(t, front)
+
+	dialer := &webtransport.Dialer{
+		TLSClientConfig: &tls.Config{RootCAs: certPool, ServerName: "example.com"},
+	}
+	t.Cleanup(func() { _ = dialer.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, session, err := dialer.Dial(ctx, "https://"+frontAddr+"/echo", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.CloseWithError(0, "") })
+
+	assert.Equal(t, "/echo", resp.Header.Get("X-Path"))
+	assert.Equal(t, "127.0.0.1", resp.Header.Get("X-Forwarded-For"))
+
+	stream, err := session.OpenStreamSync(ctx)
+	require.NoError(t, err)
+
+	_, err = stream.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, stream.Close())
+
+	data, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(data))
+
+	require.NoError(t, session.SendDatagram([]byte("bar")))
+
+	datagram, err := session.ReceiveDatagram(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "bar", string(datagram))
+}
+
+func TestWebTransportProxy_headers(t *testing.T) {
+	cert, err := tls.X509KeyPair(LocalhostCert, LocalhostKey)
+	require.NoError(t, err)
+
+	certPool := x509.NewCertPool()
+	certPool.AppendCertsFromPEM(LocalhostCert)
+
+	// The server returns the received headers, and rejects the sessions.
+	server := &webtransport.Server{
+		H3: http3.Server{
+			TLSConfig: &tls.Config{Certificates: []tls.Certificate{cert}},
+		},
+	}
+	server.H3.Handler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		for _, name := range []string{"X-Forwarded-For", "X-Foo", "Proxy-Authorization"} {
+			rw.Header()[name] = req.Header.Values(name)
+		}
+		rw.WriteHeader(http.StatusForbidden)
+	})
+	serverAddr := serveWebTransport(t, server)
+
+	target, err := url.Parse("https://" + serverAddr)
+	require.NoError(t, err)
+
+	proxy := newWebTransportProxy(target, &tls.Config{RootCAs: certPool, ServerName: "example.com"}, http3HandshakeTimeout, nil, nil, http.NotFoundHandler())
+
+	req := httptest.NewRequest(http.MethodConnect, "https://example.com/", http.NoBody)
+	req.Proto = webTransportProtocol
+	req.RemoteAddr = "10.0.0.3:1234"
+	req.Header["X-Forwarded-For"] = []string{"10.0.0.1", "10.0.0.2"}
+	req.Header.Set("Connection", "X-Foo")
+	req.Header.Set("X-Foo", "bar")
+	req.Header.Set("Proxy-Authorization", "secret")
+
+	upgrader := func(http.Header) (*webtransport.Session, error) {
+		return nil, errors.New("unexpected upgrade")
+	}
+
+	rw := httptest.NewRecorder()
+	proxy.ServeHTTP(rw, req.WithContext(AddWebTransportUpgraderOnContext(req.Context(), upgrader)))
+
+	assert.Equal(t, http.StatusForbidden, rw.Code)
+	assert.Equal(t, []string{"10.0.0.1, 10.0.0.2, 10.0.0.3"}, rw.Header().Values("X-Forwarded-For"))
+	assert.Empty(t, rw.Header().Values("X-Foo"))
+	assert.Empty(t, rw.Header().Values("Proxy-Authorization"))
+}
+
+func TestWebTransportProxy_dialTimeout(t *testing.T) {
+	// The UDP packets sent to the server are silently dropped.
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	target, err := url.Parse("https://" + conn.LocalAddr().String())
+	require.NoError(t, err)
+
+	proxy := newWebTransportProxy(target, &tls.Config{InsecureSkipVerify: true}, 100*time.Millisecond, nil, nil, http.NotFoundHandler())
+
+	req := httptest.NewRequest(http.MethodConnect, "https://example.com/", http.NoBody)
+	req.Proto = webTransportProtocol
+
+	upgrader := func(http.Header) (*webtransport.Session, error) {
+		return nil, errors.New("unexpected upgrade")
+	}
+
+	start := time.Now()
+
+	rw := httptest.NewRecorder()
+	proxy.ServeHTTP(rw, req.WithContext(AddWebTransportUpgraderOnContext(req.Context(), upgrader)))
+
+	assert.Equal(t, http.StatusBadGateway, rw.Code)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestWebTransportProxy_notWebTransport(t *testing.T) {
+	target, err := url.Parse("https://127.0.0.1")
+	require.NoError(t, err)
+
+	proxy := newWebTransportProxy(target, nil, http3HandshakeTimeout, nil, nil, http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusTeapot)
+	}))
+
+	testCases := []struct {
+		desc           string
+		method         string
+		proto          string
+		expectedStatus int
+	}{
+		{
+			desc:           "regular request",
+			method:         http.MethodGet,
+			proto:          "HTTP/3.0",
+			expectedStatus: http.StatusTeapot,
+		},
+		{
+			desc:           "WebTransport disabled on the entry point",
+			method:         http.MethodConnect,
+			proto:          webTransportProtocol,
+			expectedStatus: http.StatusNotImplemented,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(test.method, "https://127.0.0.1/", http.NoBody)
+			req.Proto = test.proto
+
+			rw := httptest.NewRecorder()
+			proxy.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatus, rw.Code)
+		})
+	}
+}
+
+func serveWebTransport(t *testing.T, server *webtransport.Server) string {
+	t.Helper()
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+
+	go func() { _ = server.Serve(conn) }()
+
+	t.Cleanup(func() {
+		_ = server.Close()
+		_ = conn.Close()
+	})
+
+	return conn.LocalAddr().String()
+}
@@ -44,6 +44,7 @@ type SpiffeX509Source interface {
 func NewRoundTripperManager(spiffeX509Source SpiffeX509Source) *RoundTripperManager {
 	return &RoundTripperManager{
 		roundTrippers:    make(map[string]http.RoundTripper),
+		tlsConfigs:       make(map[string]transportTLSConfig),
 		configs:          make(map[string]*dynamic.ServersTransport),
 		spiffeX509Source: spiffeX509Source,
 	}
@@ -53,11 +54,18 @@ func NewRoundTripperManager(spiffeX509Source SpiffeX509Source) *RoundTripperMana
 type RoundTripperManager struct {
 	rtLock        sync.RWMutex
 	roundTrippers map[string]http.RoundTripper
+	tlsConfigs    map[string]transportTLSConfig
 	configs       map[string]*dynamic.ServersTransport
 
 	spiffeX509Source SpiffeX509Source
 }
 
+// transportTLSConfig is the TLS configuration of a servers transport, or the error preventing from creating it.
+type transportTLSConfig struct {
+	config *tls.Config
+	err    error
+}
+
 // Update updates the roundtrippers configurations.
 func (r *RoundTripperManager) Update(newConfigs map[string]*dynamic.ServersTransport) {
 	r.rtLock.Lock()
@@ -68,6 +76,7 @@ func (r *RoundTripperManager) Update(newConfigs map[string]*dynamic.ServersTrans
 		if !ok {
 			delete(r.configs, configName)
 			delete(r.roundTrippers, configName)
+			delete(r.tlsConfigs, configName)
 			continue
 		}
 
@@ -75,12 +84,7 @@ func (r *RoundTripperManager) Update(newConfigs map[string]*dynamic.ServersTrans
 			continue
 		}
 
-		var err error
-		r.roundTrippers[configName], err = r.createRoundTripper(newConfig)
-		if err != nil {
-			log.Error().Err(err).Msgf("Could not configure HTTP Transport %s, fallback on default transport", configName)
-			r.roundTrippers[configName] = http.DefaultTransport
-		}
+		r.update(configName, newConfig)
 	}
 
 	for newConfigName, newConfig := range newConfigs {
@@ -88,17 +92,26 @@ func (r *RoundTripperManager) Update(newConfigs map[string]*dynamic.ServersTrans
 			continue
 		}
 
-		var err error
-		r.roundTrippers[newConfigName], err = r.createRoundTripper(newConfig)
-		if err != nil {
-			log.Error().Err(err).Msgf("Could not configure HTTP Transport %s, fallback on default transport", newConfigName)
-			r.roundTrippers[newConfigName] = http.DefaultTransport
-		}
+		r.update(newConfigName, newConfig)
 	}
 
 	r.configs = newConfigs
 }
 
+// update creates the TLS configuration and the roundtripper of the given servers transport.
+// The TLS configuration is kept for the WebTransport sessions, so that its files are only read when the servers transport changes.
+func (r *RoundTripperManager) update(name string, cfg *dynamic.ServersTransport) {
+	roundTripper, tlsConfig, err := r.createRoundTripper(cfg)
+	r.tlsConfigs[name] = transportTLSConfig{config: tlsConfig, err: err}
+	if err != nil {
+		log.Error().Err(err).Msgf("Could not configure HTTP Transport %s, fallback on default transport", name)
+		r.roundTrippers[name] = http.DefaultTransport
+		return
+	}
+
+	r.roundTrippers[name] = roundTripper
+}
+
 // Get gets a roundtripper by name.
 func (r *RoundTripperManager) Get(name string) (http.RoundTripper, error) {
 	if len(name) == 0 {
@@ -115,13 +128,49 @@ func (r *RoundTripperManager) Get(name string) (http.RoundTripper, error) {
 	return nil, fmt.Errorf("servers transport not found %s", name)
 }
 
+// GetTLSConfig gets the TLS configuration of the connections to the servers of a servers transport by name,
+// which is nil if the default one applies, or if the servers transport has no configuration.
+func (r *RoundTripperManager) GetTLSConfig(name string) (*tls.Config, error) {
+	if len(name) == 0 {
+		name = "default@internal"
+	}
+
+	r.rtLock.RLock()
+	defer r.rtLock.RUnlock()
+
+	tlsConfig, ok := r.tlsConfigs[name]
+	if !ok {
+		return nil, nil
+	}
+
+	return tlsConfig.config, tlsConfig.err
+}
+
+// GetQUICDialTimeout gets the timeout of the establishment of the QUIC connections to the servers of a servers transport by name.
+func (r *RoundTripperManager) GetQUICDialTimeout(name string) time.Duration {
+	if len(name) == 0 {
+		name = "default@internal"
+	}
+
+	r.rtLock.RLock()
+	defer r.rtLock.RUnlock()
+
+	cfg, ok := r.configs[name]
+	if !ok || cfg == nil {
+		return quicDialTimeout(nil)
+	}
+
+	return quicDialTimeout(cfg.ForwardingTimeouts)
+}
+
 // createRoundTripper creates an http.RoundTripper configured with the Transport configuration settings.
 // For the settings that can't be configured in Traefik it uses the default http.Transport settings.
 // An exception to this is the MaxIdleConns setting as we only provide the option MaxIdleConnsPerHost in Traefik at this point in time.
 // Setting this value to the default of 100 could lead to confusing behavior and backwards compatibility issues.
-func (r *RoundTripperManager) createRoundTripper(cfg *dynamic.ServersTransport) (http.RoundTripper, error) {
+// It also returns the TLS configuration of the connections to the servers, which is nil if the default one applies.
+func (r *RoundTripperManager) createRoundTripper(cfg *dynamic.ServersTransport) (http.RoundTripper, *tls.Config, error) {
 	if cfg == nil {
-		return nil, errors.New("no transport configuration given")
+		return nil, nil, errors.New("no transport configuration given")
 	}
 
 	dialer := &net.Dialer{
@@ -149,6 +198,53 @@ func (r *RoundTripperManager) createRoundTripper(cfg *dynamic.ServersTransport)
 		transport.IdleConnTimeout = time.Duration(cfg.ForwardingTimeouts.IdleConnTimeout)
 	}
 
+	var err error
+	transport.TLSClientConfig, err = r.createTLSConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The TLS configuration is cloned, as the HTTP/2 transport adds its protocols to the one of the roundtripper.
+	tlsConfig := transport.TLSClientConfig.Clone()
+
+	// Return directly HTTP/1.1 transport when HTTP/2 is disabled
+	if cfg.DisableHTTP2 {
+		return &KerberosRoundTripper{
+			OriginalRoundTripper: withHTTP3(cfg, transport.TLSClientConfig, transport),
+			new: func() http.RoundTripper {
+				return transport.Clone()
+			},
+		}, tlsConfig, nil
+	}
+
+	rt, err := newSmartRoundTripper(transport, cfg.ForwardingTimeouts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &KerberosRoundTripper{
+		OriginalRoundTripper: withHTTP3(cfg, transport.TLSClientConfig, rt),
+		new: func() http.RoundTripper {
+			return rt.Clone()
+		},
+	}, tlsConfig, nil
+}
+
+// withHTTP3 returns a round tripper forwarding the requests over HTTP/3 when it is enabled,
+// and falling back to the given round tripper otherwise.
+// The connections dedicated to the Kerberos and NTLM authentications remain over TCP.
+func withHTTP3(cfg *dynamic.ServersTransport, tlsConfig *tls.Config, fallback http.RoundTripper) http.RoundTripper {
+	if !cfg.EnableHTTP3 {
+		return fallback
+	}
+
+	return newHTTP3RoundTripper(tlsConfig, cfg.ForwardingTimeouts, fallback)
+}
+
+// createTLSConfig creates the TLS configuration of the connections to the servers,
+// or returns nil if the default one applies.
+func (r *RoundTripperManager) createTLSConfig(cfg *dynamic.ServersTransport) (*tls.Config, error) {
+	var tlsConfig *tls.Config
+
 	if cfg.Spiffe != nil {
 		if r.spiffeX509Source == nil {
 			return nil, errors.New("SPIFFE is enabled for this transport, but not configured")
@@ -159,15 +255,15 @@ func (r *RoundTripperManager) createRoundTripper(cfg *dynamic.ServersTransport)
 			return nil, fmt.Errorf("unable to build SPIFFE authorizer: %w", err)
 		}
 
-		transport.TLSClientConfig = tlsconfig.MTLSClientConfig(r.spiffeX509Source, r.spiffeX509Source, spiffeAuthorizer)
+		tlsConfig = tlsconfig.MTLSClientConfig(r.spiffeX509Source, r.spiffeX509Source, spiffeAuthorizer)
 	}
 
 	if cfg.InsecureSkipVerify || len(cfg.RootCAs) > 0 || len(cfg.ServerName) > 0 || len(cfg.Certificates) > 0 || cfg.PeerCertURI != "" {
-		if transport.TLSClientConfig != nil {
+		if tlsConfig != nil {
 			return nil, errors.New("TLS and SPIFFE configuration cannot be defined at the same time")
 		}
 
-		transport.TLSClientConfig = &tls.Config{
+		tlsConfig = &tls.Config{
 			ServerName:         cfg.ServerName,
 			InsecureSkipVerify: cfg.InsecureSkipVerify,
 			RootCAs:            createRootCACertPool(cfg.RootCAs),
@@ -175,32 +271,13 @@ func (r *RoundTripperManager) createRoundTripper(cfg *dynamic.ServersTransport)
 		}
 
 		if cfg.PeerCertURI != "" {
-			transport.TLSClientConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
-				return traefiktls.VerifyPeerCertificate(cfg.PeerCertURI, transport.TLSClientConfig, rawCerts)
+			tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+				return traefiktls.VerifyPeerCertificate(cfg.PeerCertURI, tlsConfig, rawCerts)
 			}
 		}
 	}
 
-	// Return directly HTTP/1.1 transport when HTTP/2 is disabled
-	if cfg.DisableHTTP2 {
-		return &KerberosRoundTripper{
-			OriginalRoundTripper: transport,
-			new: func() http.RoundTripper {
-				return transport.Clone()
-			},
-		}, nil
-	}
-
-	rt, err := newSmartRoundTripper(transport, cfg.ForwardingTimeouts)
-	if err != nil {
-		return nil, err
-	}
-	return &KerberosRoundTripper{
-		OriginalRoundTripper: rt,
-		new: func() http.RoundTripper {
-			return rt.Clone()
-		},
-	}, nil
+	return tlsConfig, nil
 }
 
 type KerberosRoundTripper struct {
@@ -12,16 +12,20 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/quic-go/quic-go/http3"
 	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	traefiktls "github.com/traefik/traefik/v3/pkg/tls"
 	"github.com/traefik/traefik/v3/pkg/types"
@@ -188,6 +192,58 @@ func TestKeepConnectionWhenSameConfiguration(t *testing.T) {
 	assert.EqualValues(t, 2, count)
 }
 
+func TestGetTLSConfig(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, LocalhostCert, 0o600))
+
+	rtManager := NewRoundTripperManager(nil)
+
+	dynamicConf := map[string]*dynamic.ServersTransport{
+		"test": {
+			ServerName: "example.com",
+			RootCAs:    []types.FileOrContent{types.FileOrContent(caFile)},
+		},
+		"spiffe": {
+			Spiffe: &dynamic.Spiffe{},
+		},
+	}
+	rtManager.Update(dynamicConf)
+
+	tlsConfig, err := rtManager.GetTLSConfig("test")
+	require.NoError(t, err)
+	require.NotNil(t, tlsConfig)
+
+	assert.Equal(t, "example.com", tlsConfig.ServerName)
+	assert.NotNil(t, tlsConfig.RootCAs)
+	assert.Empty(t, tlsConfig.NextProtos)
+
+	// The TLS configuration is created when the servers transport is updated, its files are not read again.
+	require.NoError(t, os.Remove(caFile))
+
+	again, err := rtManager.GetTLSConfig("test")
+	require.NoError(t, err)
+	assert.Same(t, tlsConfig, again)
+
+	rtManager.Update(dynamicConf)
+
+	again, err = rtManager.GetTLSConfig("test")
+	require.NoError(t, err)
+	assert.Same(t, tlsConfig, again)
+
+	_, err = rtManager.GetTLSConfig("spiffe")
+	require.Error(t, err)
+
+	tlsConfig, err = rtManager.GetTLSConfig("unknown")
+	require.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+
+	rtManager.Update(map[string]*dynamic.ServersTransport{})
+
+	tlsConfig, err = rtManager.GetTLSConfig("test")
+	require.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+}
+
 func TestMTLS(t *testing.T) {
 	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(http.StatusOK)
@@ -440,6 +496,210 @@ func TestDisableHTTP2(t *testing.T) {
 	}
 }
 
+func TestEnableHTTP3(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		disableHTTP2  bool
+		serverHTTP3   bool
+		serverTLS     bool
+		expectedProto string
+	}{
+		{
+			desc:          "HTTP3 server",
+			serverHTTP3:   true,
+			expectedProto: "HTTP/3.0",
+		},
+		{
+			desc:          "HTTP2 server",
+			serverTLS:     true,
+			expectedProto: "HTTP/2.0",
+		},
+		{
+			desc:          "HTTP1 server with HTTP2 disabled",
+			disableHTTP2:  true,
+			serverTLS:     true,
+			expectedProto: "HTTP/1.1",
+		},
+		{
+			desc:          "HTTP1 server without TLS",
+			expectedProto: "HTTP/1.1",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			var serverURL string
+			switch {
+			case test.serverHTTP3:
+				cert, err := tls.X509KeyPair(LocalhostCert, LocalhostKey)
+				require.NoError(t, err)
+
+				conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+				require.NoError(t, err)
+
+				srv := &http3.Server{
+					Handler:   handler,
+					TLSConfig: &tls.Config{Certificates: []tls.Certificate{cert}},
+				}
+				go func() { _ = srv.Serve(conn) }()
+
+				t.Cleanup(func() {
+					_ = srv.Close()
+					_ = conn.Close()
+				})
+
+				serverURL = "https://" + conn.LocalAddr().String()
+
+			case test.serverTLS:
+				srv := httptest.NewUnstartedServer(handler)
+				srv.EnableHTTP2 = true
+				srv.StartTLS()
+				t.Cleanup(srv.Close)
+
+				serverURL = srv.URL
+
+			default:
+				srv := httptest.NewServer(handler)
+				t.Cleanup(srv.Close)
+
+				serverURL = srv.URL
+			}
+
+			rtManager := NewRoundTripperManager(nil)
+
+			dynamicConf := map[string]*dynamic.ServersTransport{
+				"test": {
+					DisableHTTP2:       test.disableHTTP2,
+					EnableHTTP3:        true,
+					InsecureSkipVerify: true,
+					ForwardingTimeouts: &dynamic.ForwardingTimeouts{
+						DialTimeout: ptypes.Duration(500 * time.Millisecond),
+					},
+				},
+			}
+
+			rtManager.Update(dynamicConf)
+
+			tr, err := rtManager.Get("test")
+			require.NoError(t, err)
+
+			client := http.Client{Transport: tr}
+
+			// The second request checks that the servers HTTP/3 is unavailable for are directly forwarded over the fallback.
+			for range 2 {
+				resp, err := client.Get(serverURL)
+				require.NoError(t, err)
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, test.expectedProto, resp.Proto)
+			}
+		})
+	}
+}
+
+func TestHTTP3RoundTripper_silentDrop(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	// The UDP packets sent to the server port are silently dropped.
+	conn, err := net.ListenPacket("udp", srv.Listener.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	var received atomic.Int32
+	go func() {
+		buf := make([]byte, 2048)
+		for {
+			if _, _, err := conn.ReadFrom(buf); err != nil {
+				return
+			}
+			received.Add(1)
+		}
+	}()
+
+	fallback := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	t.Cleanup(fallback.CloseIdleConnections)
+
+	rt := newHTTP3RoundTripper(&tls.Config{InsecureSkipVerify: true}, &dynamic.ForwardingTimeouts{
+		DialTimeout:           ptypes.Duration(30 * time.Second),
+		HTTP3HandshakeTimeout: ptypes.Duration(100 * time.Millisecond),
+	}, fallback)
+
+	start := time.Now()
+
+	resp, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, srv.URL, http.NoBody))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "HTTP/1.1", resp.Proto)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Positive(t, received.Load())
+}
+
+func TestQUICDialTimeout(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		forwardingTimeouts *dynamic.ForwardingTimeouts
+		expected           time.Duration
+	}{
+		{
+			desc:     "no forwarding timeouts",
+			expected: http3HandshakeTimeout,
+		},
+		{
+			desc: "handshake timeout shorter than the dial timeout",
+			forwardingTimeouts: &dynamic.ForwardingTimeouts{
+				DialTimeout:           ptypes.Duration(30 * time.Second),
+				HTTP3HandshakeTimeout: ptypes.Duration(10 * time.Second),
+			},
+			expected: 10 * time.Second,
+		},
+		{
+			desc: "dial timeout shorter than the handshake timeout",
+			forwardingTimeouts: &dynamic.ForwardingTimeouts{
+				DialTimeout:           ptypes.Duration(time.Second),
+				HTTP3HandshakeTimeout: ptypes.Duration(3 * time.Second),
+			},
+			expected: time.Second,
+		},
+		{
+			desc: "no handshake timeout",
+			forwardingTimeouts: &dynamic.ForwardingTimeouts{
+				DialTimeout: ptypes.Duration(30 * time.Second),
+			},
+			expected: 30 * time.Second,
+		},
+		{
+			desc: "no dial timeout",
+			forwardingTimeouts: &dynamic.ForwardingTimeouts{
+				HTTP3HandshakeTimeout: ptypes.Duration(10 * time.Second),
+			},
+			expected: 10 * time.Second,
+		},
+		{
+			desc:               "no timeout",
+			forwardingTimeouts: &dynamic.ForwardingTimeouts{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, quicDialTimeout(test.forwardingTimeouts))
+		})
+	}
+}
+
 // fakeSpiffePKI simulates a SPIFFE aware PKI and allows generating multiple valid SVIDs.
 type fakeSpiffePKI struct {
 	caPrivateKey *rsa.PrivateKey
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -16,6 +17,7 @@ import (
 	"time"
 
 	"github.com/containous/alice"
+	gokitmetrics "github.com/go-kit/kit/metrics"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
@@ -25,12 +27,14 @@ import (
 	"github.com/traefik/traefik/v3/pkg/middlewares/capture"
 	metricsMiddle "github.com/traefik/traefik/v3/pkg/middlewares/metrics"
 	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	httpmuxer "github.com/traefik/traefik/v3/pkg/muxer/http"
 	"github.com/traefik/traefik/v3/pkg/safe"
 	"github.com/traefik/traefik/v3/pkg/server/cookie"
 	"github.com/traefik/traefik/v3/pkg/server/middleware"
 	"github.com/traefik/traefik/v3/pkg/server/provider"
 	"github.com/traefik/traefik/v3/pkg/server/service/loadbalancer/failover"
 	"github.com/traefik/traefik/v3/pkg/server/service/loadbalancer/mirror"
+	"github.com/traefik/traefik/v3/pkg/server/service/loadbalancer/split"
 	"github.com/traefik/traefik/v3/pkg/server/service/loadbalancer/wrr"
 	"google.golang.org/grpc/status"
 )
@@ -56,6 +60,10 @@ type Manager struct {
 	configs        map[string]*runtime.ServiceInfo
 	healthCheckers map[string]*healthcheck.ServiceHealthChecker
 	rand           *rand.Rand // For the initial shuffling of load-balancers.
+
+	// drainer drains the in-flight requests of the servers of the previous configurations which are not among the activeServers.
+	drainer       *drainer
+	activeServers map[drainKey]struct{}
 }
 
 // NewManager creates a new Manager.
@@ -69,6 +77,7 @@ func NewManager(configs map[string]*runtime.ServiceInfo, observabilityMgr *middl
 		configs:             configs,
 		healthCheckers:      make(map[string]*healthcheck.ServiceHealthChecker),
 		rand:                rand.New(rand.NewSource(time.Now().UnixNano())),
+		activeServers:       make(map[drainKey]struct{}),
 	}
 }
 
@@ -125,7 +134,7 @@ func (m *Manager) BuildHTTP(rootCtx context.Context, serviceName string) (http.H
 		}
 	case conf.Mirroring != nil:
 		var err error
-		lb, err = m.getMirrorServiceHandler(ctx, conf.Mirroring)
+		lb, err = m.getMirrorServiceHandler(ctx, serviceName, conf.Mirroring)
 		if err != nil {
 			conf.AddError(err, true)
 			return nil, err
@@ -137,6 +146,13 @@ func (m *Manager) BuildHTTP(rootCtx context.Context, serviceName string) (http.H
 			conf.AddError(err, true)
 			return nil, err
 		}
+	case conf.TrafficSplit != nil:
+		var err error
+		lb, err = m.getTrafficSplitServiceHandler(ctx, serviceName, conf.TrafficSplit)
+		if err != nil {
+			conf.AddError(err, true)
+			return nil, err
+		}
 	default:
 		sErr := fmt.Errorf("the service %q does not have any type defined", serviceName)
 		conf.AddError(sErr, true)
@@ -195,7 +211,7 @@ func (m *Manager) getFailoverServiceHandler(ctx context.Context, serviceName str
 	return f, nil
 }
 
-func (m *Manager) getMirrorServiceHandler(ctx context.Context, config *dynamic.Mirroring) (http.Handler, error) {
+func (m *Manager) getMirrorServiceHandler(ctx context.Context, serviceName string, config *dynamic.Mirroring) (http.Handler, error) {
 	serviceHandler, err := m.BuildHTTP(ctx, config.Service)
 	if err != nil {
 		return nil, err
@@ -211,13 +227,33 @@ func (m *Manager) getMirrorServiceHandler(ctx context.Context, config *dynamic.M
 		maxBodySize = *config.MaxBodySize
 	}
 	handler := mirror.New(serviceHandler, m.routinePool, mirrorBody, maxBodySize, config.HealthCheck)
+
+	if m.observabilityMgr.MetricsRegistry() != nil && m.observabilityMgr.MetricsRegistry().IsSvcEnabled() &&
+		m.observabilityMgr.ShouldAddMetrics(serviceName) {
+		handler.SetMetricsRegistry(m.observabilityMgr.MetricsRegistry(), serviceName)
+	}
+
 	for _, mirrorConfig := range config.Mirrors {
 		mirrorHandler, err := m.BuildHTTP(ctx, mirrorConfig.Name)
 		if err != nil {
 			return nil, err
 		}
 
-		err = handler.AddMirror(mirrorHandler, mirrorConfig.Percent)
+		opts := mirror.MirrorOptions{
+			Name:        mirrorConfig.Name,
+			Percent:     mirrorConfig.Percent,
+			MaxBodySize: mirrorConfig.MaxBodySize,
+			Timeout:     time.Duration(mirrorConfig.Timeout),
+		}
+
+		if mirrorConfig.Match != "" {
+			opts.Match, err = httpmuxer.NewMatcher(mirrorConfig.Match)
+			if err != nil {
+				return nil, fmt.Errorf("parsing the match rule of the mirror %q: %w", mirrorConfig.Name, err)
+			}
+		}
+
+		err = handler.AddMirrorWithOptions(mirrorHandler, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -225,6 +261,30 @@ func (m *Manager) getMirrorServiceHandler(ctx context.Context, config *dynamic.M
 	return handler, nil
 }
 
+func (m *Manager) getTrafficSplitServiceHandler(ctx context.Context, serviceName string, config *dynamic.TrafficSplit) (http.Handler, error) {
+	if config.Cookie != nil {
+		config.Cookie.Name = cookie.GetName(config.Cookie.Name, serviceName)
+	}
+
+	handler := split.New(config, serviceName)
+	for _, variant := range config.Variants {
+		serviceHandler, err := m.BuildHTTP(ctx, variant.Service)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := handler.Add(variant.Name, serviceHandler, variant.Percent); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := handler.Validate(); err != nil {
+		return nil, err
+	}
+
+	return handler, nil
+}
+
 func (m *Manager) getWRRServiceHandler(ctx context.Context, serviceName string, config *dynamic.WeightedRoundRobin) (http.Handler, error) {
 	// TODO Handle accesslog and metrics with multiple service name
 	if config.Sticky != nil && config.Sticky.Cookie != nil {
@@ -322,6 +382,34 @@ func (m *Manager) getLoadBalancerServiceHandler(ctx context.Context, serviceName
 		return nil, err
 	}
 
+	if service.ResponseHeaderTimeout > 0 {
+		roundTripper = newResponseHeaderTimeoutRoundTripper(roundTripper, time.Duration(service.ResponseHeaderTimeout))
+	}
+
+	// The TLS configuration and the dial timeout of the servers transport also apply to the WebTransport sessions.
+	var tlsConfig *tls.Config
+	dialTimeout := quicDialTimeout(nil)
+	if getter, ok := m.roundTripperManager.(webTransportConfigGetter); ok {
+		tlsConfig, err = getter.GetTLSConfig(service.ServersTransport)
+		if err != nil {
+			return nil, err
+		}
+
+		dialTimeout = getter.GetQUICDialTimeout(service.ServersTransport)
+	}
+
+	var wtSessionsGauge gokitmetrics.Gauge
+	var wtStreamsCounter gokitmetrics.Counter
+	if registry := m.observabilityMgr.MetricsRegistry(); registry != nil && registry.IsSvcEnabled() &&
+		m.observabilityMgr.ShouldAddMetrics(provider.GetQualifiedName(ctx, serviceName)) {
+		if registry.WebTransportSessionsGauge() != nil {
+			wtSessionsGauge = registry.WebTransportSessionsGauge().With("service", serviceName)
+		}
+		if registry.WebTransportStreamsCounter() != nil {
+			wtStreamsCounter = registry.WebTransportStreamsCounter().With("service", serviceName)
+		}
+	}
+
 	lb := wrr.New(service.Sticky, service.HealthCheck != nil)
 	healthCheckTargets := make(map[string]*url.URL)
 
@@ -348,6 +436,12 @@ func (m *Manager) getLoadBalancerServiceHandler(ctx context.Context, serviceName
 		}
 
 		proxy := buildSingleHostProxy(target, passHostHeader, time.Duration(flushInterval), roundTripper, m.bufferPool)
+		proxy = newWebTransportProxy(target, tlsConfig, dialTimeout, wtSessionsGauge, wtStreamsCounter, proxy)
+
+		m.activeServers[drainKey{serviceName: serviceName, serverURL: server.URL}] = struct{}{}
+		if m.drainer != nil && service.Drain != nil {
+			proxy = m.drainer.wrap(serviceName, server.URL, *service.Drain, proxy)
+		}
 
 		// Prevents from enabling observability for internal resources.
 
@@ -416,6 +510,16 @@ func (m *Manager) LaunchHealthCheck(ctx context.Context) {
 	}
 }
 
+// DrainRemovedServers drains the in-flight requests forwarded to the servers of the previous configurations,
+// which are not part of the load balancers built by this manager.
+func (m *Manager) DrainRemovedServers() {
+	if m.drainer == nil {
+		return
+	}
+
+	m.drainer.drain(m.activeServers)
+}
+
 func shuffle[T any](values []T, r *rand.Rand) []T {
 	shuffled := make([]T, len(values))
 	copy(shuffled, values)
@@ -516,6 +516,56 @@ func TestManager_Build(t *testing.T) {
 	}
 }
 
+func TestManager_BuildHTTP_trafficSplit(t *testing.T) {
+	services := map[string]*runtime.ServiceInfo{
+		"split@file": {
+			Service: &dynamic.Service{
+				TrafficSplit: &dynamic.TrafficSplit{
+					Cookie:        &dynamic.Cookie{},
+					VariantHeader: "X-Variant",
+					Variants: []dynamic.SplitVariant{
+						{Name: "control", Service: "control@file", Percent: 0},
+						{Name: "test", Service: "test@file", Percent: 100},
+					},
+				},
+			},
+		},
+		"invalid@file": {
+			Service: &dynamic.Service{
+				TrafficSplit: &dynamic.TrafficSplit{
+					Variants: []dynamic.SplitVariant{
+						{Name: "control", Service: "control@file", Percent: 50},
+						{Name: "test", Service: "test@file", Percent: 40},
+					},
+				},
+			},
+		},
+		"control@file": {
+			Service: &dynamic.Service{
+				LoadBalancer: &dynamic.ServersLoadBalancer{},
+			},
+		},
+		"test@file": {
+			Service: &dynamic.Service{
+				LoadBalancer: &dynamic.ServersLoadBalancer{},
+			},
+		},
+	}
+
+	manager := NewManager(services, nil, nil, &RoundTripperManager{
+		roundTrippers: map[string]http.RoundTripper{
+			"default@internal": http.DefaultTransport,
+		},
+	})
+
+	_, err := manager.BuildHTTP(context.Background(), "split@file")
+	require.NoError(t, err)
+	assert.Equal(t, "_2e6f3", services["split@file"].TrafficSplit.Cookie.Name)
+
+	_, err = manager.BuildHTTP(context.Background(), "invalid@file")
+	assert.Error(t, err)
+}
+
 func TestMultipleTypeOnBuildHTTP(t *testing.T) {
 	services := map[string]*runtime.ServiceInfo{
 		"test@file": {
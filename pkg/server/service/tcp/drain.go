@@ -0,0 +1,110 @@
+package tcp
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/logs"
+	"github.com/traefik/traefik/v3/pkg/tcp"
+)
+
+// drainKey identifies a server of a service.
+type drainKey struct {
+	serviceName string
+	address     string
+}
+
+// Drainer drains the connections forwarded to the servers removed from the configuration,
+// i.e. removed from their load balancer, or belonging to a service no longer used by any router.
+// It outlives the configurations, in order to track the connections of the previous ones.
+type Drainer struct {
+	mu    sync.Mutex
+	conns map[drainKey]map[*inFlightConn]struct{}
+}
+
+// NewDrainer creates a new Drainer.
+func NewDrainer() *Drainer {
+	return &Drainer{
+		conns: make(map[drainKey]map[*inFlightConn]struct{}),
+	}
+}
+
+// inFlightConn is a connection forwarded to a server, which can be drained.
+type inFlightConn struct {
+	config   dynamic.TCPDrain
+	conn     tcp.WriteCloser
+	draining atomic.Bool
+}
+
+// wrap returns a handler tracking the connections forwarded to the given server of the given service,
+// in order to drain them with the given configuration once the server is removed.
+func (d *Drainer) wrap(serviceName, address string, config dynamic.TCPDrain, next tcp.Handler) tcp.Handler {
+	key := drainKey{serviceName: serviceName, address: address}
+
+	return tcp.HandlerFunc(func(conn tcp.WriteCloser) {
+		c := &inFlightConn{config: config, conn: conn}
+
+		d.add(key, c)
+		defer d.remove(key, c)
+
+		next.ServeTCP(conn)
+	})
+}
+
+// drain drains the connections forwarded to the servers which are not among the given active ones.
+func (d *Drainer) drain(active map[drainKey]struct{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for key, conns := range d.conns {
+		if _, ok := active[key]; ok {
+			continue
+		}
+
+		drained := 0
+		for c := range conns {
+			if c.draining.Swap(true) {
+				continue
+			}
+
+			drained++
+			if c.config.GracePeriod > 0 {
+				time.AfterFunc(time.Duration(c.config.GracePeriod), func() { _ = c.conn.Close() })
+			}
+		}
+
+		if drained > 0 {
+			log.Debug().
+				Str(logs.ServiceName, key.serviceName).
+				Str("serverAddress", key.address).
+				Int("connections", drained).
+				Msg("Draining connections of a removed server")
+		}
+	}
+}
+
+func (d *Drainer) add(key drainKey, c *inFlightConn) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	conns, ok := d.conns[key]
+	if !ok {
+		conns = make(map[*inFlightConn]struct{})
+		d.conns[key] = conns
+	}
+
+	conns[c] = struct{}{}
+}
+
+func (d *Drainer) remove(key drainKey, c *inFlightConn) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.conns[key], c)
+	if len(d.conns[key]) == 0 {
+		delete(d.conns, key)
+	}
+}
@@ -0,0 +1,94 @@
+package tcp
+
+import (
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/tcp"
+)
+
+func TestDrainer(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		config         dynamic.TCPDrain
+		active         bool
+		expectedClosed bool
+	}{
+		{
+			desc:   "active server",
+			config: dynamic.TCPDrain{GracePeriod: ptypes.Duration(time.Millisecond)},
+			active: true,
+		},
+		{
+			desc:           "removed server",
+			config:         dynamic.TCPDrain{GracePeriod: ptypes.Duration(time.Millisecond)},
+			expectedClosed: true,
+		},
+		{
+			desc: "removed server without grace period",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			d := NewDrainer()
+
+			started := make(chan struct{})
+			closed := make(chan bool, 1)
+
+			next := tcp.HandlerFunc(func(conn tcp.WriteCloser) {
+				close(started)
+
+				// The read fails once the connection is closed by the drainer.
+				_ = conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+				_, err := conn.Read(make([]byte, 1))
+				closed <- errors.Is(err, io.ErrClosedPipe)
+			})
+
+			handler := d.wrap("foo@file", "127.0.0.1:9000", test.config, next)
+
+			client, server := net.Pipe()
+			t.Cleanup(func() { _ = client.Close() })
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				handler.ServeTCP(&pipeConn{Conn: server})
+			}()
+
+			<-started
+
+			active := make(map[drainKey]struct{})
+			if test.active {
+				active[drainKey{serviceName: "foo@file", address: "127.0.0.1:9000"}] = struct{}{}
+			}
+			d.drain(active)
+
+			<-done
+
+			assert.Equal(t, test.expectedClosed, <-closed)
+
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			require.Empty(t, d.conns)
+		})
+	}
+}
+
+// pipeConn is a tcp.WriteCloser on a net.Pipe connection.
+type pipeConn struct {
+	net.Conn
+}
+
+func (c *pipeConn) CloseWrite() error {
+	return nil
+}
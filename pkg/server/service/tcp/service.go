@@ -13,7 +13,6 @@ import (
 	"github.com/traefik/traefik/v3/pkg/logs"
 	"github.com/traefik/traefik/v3/pkg/server/provider"
 	"github.com/traefik/traefik/v3/pkg/tcp"
-	"golang.org/x/net/proxy"
 )
 
 // Manager is the TCPHandlers factory.
@@ -21,14 +20,21 @@ type Manager struct {
 	dialerManager *tcp.DialerManager
 	configs       map[string]*runtime.TCPServiceInfo
 	rand          *rand.Rand // For the initial shuffling of load-balancers.
+
+	// drainer drains the connections of the servers of the previous configurations which are not among the activeServers.
+	drainer       *Drainer
+	activeServers map[drainKey]struct{}
 }
 
 // NewManager creates a new manager.
-func NewManager(conf *runtime.Configuration, dialerManager *tcp.DialerManager) *Manager {
+// The drainer is optional.
+func NewManager(conf *runtime.Configuration, dialerManager *tcp.DialerManager, drainer *Drainer) *Manager {
 	return &Manager{
 		dialerManager: dialerManager,
 		configs:       conf.TCPServices,
 		rand:          rand.New(rand.NewSource(time.Now().UnixNano())),
+		drainer:       drainer,
+		activeServers: make(map[drainKey]struct{}),
 	}
 }
 
@@ -91,7 +97,14 @@ func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Han
 				continue
 			}
 
-			loadBalancer.AddServer(handler)
+			m.activeServers[drainKey{serviceName: serviceQualifiedName, address: server.Address}] = struct{}{}
+
+			var serverHandler tcp.Handler = handler
+			if m.drainer != nil && conf.LoadBalancer.Drain != nil {
+				serverHandler = m.drainer.wrap(serviceQualifiedName, server.Address, *conf.LoadBalancer.Drain, handler)
+			}
+
+			loadBalancer.AddServer(serverHandler)
 			logger.Debug().Msg("Creating TCP server")
 		}
 
@@ -119,6 +132,16 @@ func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Han
 	}
 }
 
+// DrainRemovedServers drains the connections forwarded to the servers of the previous configurations,
+// which are not part of the load balancers built by this manager.
+func (m *Manager) DrainRemovedServers() {
+	if m.drainer == nil {
+		return
+	}
+
+	m.drainer.drain(m.activeServers)
+}
+
 func shuffle[T any](values []T, r *rand.Rand) []T {
 	shuffled := make([]T, len(values))
 	copy(shuffled, values)
@@ -129,7 +152,7 @@ func shuffle[T any](values []T, r *rand.Rand) []T {
 
 // dialerWrapper is only used to handle TerminationDelay deprecated option on TCPServersLoadBalancer.
 type dialerWrapper struct {
-	proxy.Dialer
+	tcp.Dialer
 	terminationDelay time.Duration
 }
 
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// errResponseHeaderTimeout is returned when the response headers of a server are not received in time.
+var errResponseHeaderTimeout = &responseHeaderTimeoutError{}
+
+type responseHeaderTimeoutError struct{}
+
+func (e *responseHeaderTimeoutError) Error() string { return "timeout awaiting response headers" }
+
+func (e *responseHeaderTimeoutError) Timeout() bool { return true }
+
+func (e *responseHeaderTimeoutError) Temporary() bool { return true }
+
+// responseHeaderTimeoutRoundTripper bounds the time to wait for the response headers, once the request is fully written.
+type responseHeaderTimeoutRoundTripper struct {
+	rt      http.RoundTripper
+	timeout time.Duration
+}
+
+func newResponseHeaderTimeoutRoundTripper(rt http.RoundTripper, timeout time.Duration) http.RoundTripper {
+	return &responseHeaderTimeoutRoundTripper{rt: rt, timeout: timeout}
+}
+
+func (t *responseHeaderTimeoutRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The context is released with the one of the request, as the response body is read after RoundTrip returns.
+	ctx, cancel := context.WithCancelCause(req.Context())
+
+	timer := time.AfterFunc(math.MaxInt64, func() { cancel(errResponseHeaderTimeout) })
+
+	var mu sync.Mutex
+	var once sync.Once
+	done := false
+	start := func() {
+		once.Do(func() {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if !done {
+				timer.Reset(t.timeout)
+			}
+		})
+	}
+
+	outReq := req.WithContext(ctx)
+	if req.Body == nil || req.Body == http.NoBody {
+		start()
+	} else {
+		outReq.Body = &writtenNotifierBody{ReadCloser: req.Body, onWritten: start}
+	}
+
+	resp, err := t.rt.RoundTrip(outReq)
+
+	mu.Lock()
+	done = true
+	timer.Stop()
+	mu.Unlock()
+
+	if errors.Is(context.Cause(ctx), errResponseHeaderTimeout) {
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		return nil, errResponseHeaderTimeout
+	}
+
+	return resp, err
+}
+
+// writtenNotifierBody calls onWritten once the request body has been fully read by the transport.
+type writtenNotifierBody struct {
+	io.ReadCloser
+	onWritten func()
+}
+
+func (b *writtenNotifierBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if errors.Is(err, io.EOF) {
+		b.onWritten()
+	}
+
+	return n, err
+}
+
+func (b *writtenNotifierBody) Close() error {
+	b.onWritten()
+	return b.ReadCloser.Close()
+}
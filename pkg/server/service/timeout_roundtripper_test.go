@@ -0,0 +1,100 @@
+package service
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseHeaderTimeoutRoundTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = io.Copy(io.Discard, req.Body)
+
+		if req.URL.Path == "/slow-headers" {
+			time.Sleep(200 * time.Millisecond)
+		}
+
+		rw.WriteHeader(http.StatusOK)
+		rw.(http.Flusher).Flush()
+
+		if req.URL.Path == "/slow-body" {
+			time.Sleep(200 * time.Millisecond)
+		}
+
+		_, _ = rw.Write([]byte("body"))
+	}))
+	t.Cleanup(server.Close)
+
+	rt := newResponseHeaderTimeoutRoundTripper(http.DefaultTransport, 100*time.Millisecond)
+
+	testCases := []struct {
+		desc          string
+		path          string
+		body          io.Reader
+		expectedError error
+	}{
+		{
+			desc: "response headers in time",
+			path: "/",
+		},
+		{
+			desc:          "slow response headers",
+			path:          "/slow-headers",
+			expectedError: errResponseHeaderTimeout,
+		},
+		{
+			desc: "slow response body",
+			path: "/slow-body",
+		},
+		{
+			desc: "slow request body",
+			path: "/",
+			body: &slowReader{reader: strings.NewReader("request body"), delay: 50 * time.Millisecond},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(http.MethodPost, server.URL+test.path, test.body)
+			require.NoError(t, err)
+
+			resp, err := rt.RoundTrip(req)
+			if test.expectedError != nil {
+				require.ErrorIs(t, err, test.expectedError)
+				assert.Equal(t, http.StatusGatewayTimeout, computeStatusCode(err))
+				return
+			}
+
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = resp.Body.Close() })
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "body", string(body))
+		})
+	}
+}
+
+// slowReader reads one byte at a time, delaying its first reads.
+type slowReader struct {
+	reader io.Reader
+	delay  time.Duration
+	reads  int
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if r.reads < 3 {
+		r.reads++
+		time.Sleep(r.delay)
+	}
+
+	return r.reader.Read(p[:1])
+}
@@ -10,10 +10,25 @@ import (
 )
 
 func populateSocketActivationListeners() {
-	listenersWithName, _ := activation.ListenersWithNames()
+	listeners := make(map[string][]net.Listener)
+	packetConns := make(map[string][]net.PacketConn)
+
+	// The stream sockets are used by the TCP entryPoints,
+	// and the datagram sockets by the UDP entryPoints and by HTTP/3.
+	for _, file := range activation.Files(true) {
+		if ln, err := net.FileListener(file); err == nil {
+			listeners[file.Name()] = append(listeners[file.Name()], ln)
+		} else if conn, err := net.FilePacketConn(file); err == nil {
+			packetConns[file.Name()] = append(packetConns[file.Name()], conn)
+		} else {
+			log.Error().Str("listenersName", file.Name()).Msg("Socket activation file descriptor is neither a stream nor a datagram socket")
+		}
+
+		file.Close()
+	}
 
 	socketActivationListeners = make(map[string]net.Listener)
-	for name, lns := range listenersWithName {
+	for name, lns := range listeners {
 		if len(lns) != 1 {
 			log.Error().Str("listenersName", name).Msg("Socket activation listeners must have one and only one listener per name")
 			continue
@@ -21,4 +36,14 @@ func populateSocketActivationListeners() {
 
 		socketActivationListeners[name] = lns[0]
 	}
+
+	socketActivationPacketConns = make(map[string]net.PacketConn)
+	for name, conns := range packetConns {
+		if len(conns) != 1 {
+			log.Error().Str("listenersName", name).Msg("Socket activation packet connections must have one and only one connection per name")
+			continue
+		}
+
+		socketActivationPacketConns[name] = conns[0]
+	}
 }
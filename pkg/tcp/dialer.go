@@ -24,17 +24,23 @@ type Dialer interface {
 	proxy.Dialer
 
 	TerminationDelay() time.Duration
+	ProxyProtocol() *dynamic.ProxyProtocol
 }
 
 type tcpDialer struct {
 	proxy.Dialer
 	terminationDelay time.Duration
+	proxyProtocol    *dynamic.ProxyProtocol
 }
 
 func (d tcpDialer) TerminationDelay() time.Duration {
 	return d.terminationDelay
 }
 
+func (d tcpDialer) ProxyProtocol() *dynamic.ProxyProtocol {
+	return d.proxyProtocol
+}
+
 // SpiffeX509Source allows to retrieve a x509 SVID and bundle.
 type SpiffeX509Source interface {
 	x509svid.Source
@@ -151,8 +157,8 @@ func (d *DialerManager) createDialers(name string, cfg *dynamic.TCPServersTransp
 		Config:    tlsConfig,
 	}
 
-	d.dialers[name] = tcpDialer{dialer, time.Duration(cfg.TerminationDelay)}
-	d.dialersTLS[name] = tcpDialer{tlsDialer, time.Duration(cfg.TerminationDelay)}
+	d.dialers[name] = tcpDialer{dialer, time.Duration(cfg.TerminationDelay), cfg.ProxyProtocol}
+	d.dialersTLS[name] = tcpDialer{tlsDialer, time.Duration(cfg.TerminationDelay), cfg.ProxyProtocol}
 
 	return nil
 }
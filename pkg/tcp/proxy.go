@@ -5,31 +5,54 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"slices"
 	"syscall"
 	"time"
 
 	"github.com/pires/go-proxyproto"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/proxyprotocol"
 )
 
 // Proxy forwards a TCP request to a TCP service.
 type Proxy struct {
 	address       string
 	proxyProtocol *dynamic.ProxyProtocol
-	dialer        Dialer
+	// proxyProtocolTLVs are the custom TLVs added to the PROXY protocol header.
+	proxyProtocolTLVs []proxyproto.TLV
+	dialer            Dialer
 }
 
 // NewProxy creates a new Proxy.
+// When no PROXY protocol configuration is given, the one of the dialer servers transport applies, if any.
 func NewProxy(address string, proxyProtocol *dynamic.ProxyProtocol, dialer Dialer) (*Proxy, error) {
+	if proxyProtocol == nil {
+		proxyProtocol = dialer.ProxyProtocol()
+	}
+
 	if proxyProtocol != nil && (proxyProtocol.Version < 1 || proxyProtocol.Version > 2) {
 		return nil, fmt.Errorf("unknown proxyProtocol version: %d", proxyProtocol.Version)
 	}
 
+	var tlvs []proxyproto.TLV
+	if proxyProtocol != nil && (len(proxyProtocol.TLVs) > 0 || proxyProtocol.PassthroughTLVs) {
+		if proxyProtocol.Version != 2 {
+			return nil, fmt.Errorf("proxyProtocol TLVs require the version 2, got version %d", proxyProtocol.Version)
+		}
+
+		var err error
+		tlvs, err = proxyprotocol.ParseTLVs(proxyProtocol.TLVs)
+		if err != nil {
+			return nil, fmt.Errorf("parsing proxyProtocol TLVs: %w", err)
+		}
+	}
+
 	return &Proxy{
-		address:       address,
-		proxyProtocol: proxyProtocol,
-		dialer:        dialer,
+		address:           address,
+		proxyProtocol:     proxyProtocol,
+		proxyProtocolTLVs: tlvs,
+		dialer:            dialer,
 	}, nil
 }
 
@@ -55,6 +78,11 @@ func (p *Proxy) ServeTCP(conn WriteCloser) {
 
 	if p.proxyProtocol != nil && p.proxyProtocol.Version > 0 && p.proxyProtocol.Version < 3 {
 		header := proxyproto.HeaderProxyFromAddrs(byte(p.proxyProtocol.Version), conn.RemoteAddr(), conn.LocalAddr())
+		if err := header.SetTLVs(p.headerTLVs(conn)); err != nil {
+			log.Error().Err(err).Msg("Error while setting TCP proxy protocol TLVs")
+			return
+		}
+
 		if _, err := header.WriteTo(connBackend); err != nil {
 			log.Error().Err(err).Msg("Error while writing TCP proxy protocol headers to backend connection")
 			return
@@ -79,6 +107,32 @@ func (p *Proxy) ServeTCP(conn WriteCloser) {
 	<-errChan
 }
 
+// headerTLVs returns the TLVs of the PROXY protocol header sent to the backend:
+// the TLVs received with the connection, when they are passed through, followed by the custom ones,
+// which take precedence over the received TLVs of the same type.
+// The CRC32C and NOOP TLVs are not passed through, as they only apply to the received header.
+func (p Proxy) headerTLVs(conn WriteCloser) []proxyproto.TLV {
+	if !p.proxyProtocol.PassthroughTLVs {
+		return p.proxyProtocolTLVs
+	}
+
+	var tlvs []proxyproto.TLV
+	for _, tlv := range proxyprotocol.TLVsFromConn(conn) {
+		if tlv.Type == proxyproto.PP2_TYPE_CRC32C || tlv.Type == proxyproto.PP2_TYPE_NOOP {
+			continue
+		}
+
+		overridden := slices.ContainsFunc(p.proxyProtocolTLVs, func(custom proxyproto.TLV) bool {
+			return custom.Type == tlv.Type
+		})
+		if !overridden {
+			tlvs = append(tlvs, tlv)
+		}
+	}
+
+	return append(tlvs, p.proxyProtocolTLVs...)
+}
+
 func (p Proxy) dialBackend() (WriteCloser, error) {
 	conn, err := p.dialer.Dial("tcp", p.address)
 	if err != nil {
@@ -52,7 +52,7 @@ func TestCloseWrite(t *testing.T) {
 	_, port, err := net.SplitHostPort(backendListener.Addr().String())
 	require.NoError(t, err)
 
-	dialer := tcpDialer{&net.Dialer{}, 10 * time.Millisecond}
+	dialer := tcpDialer{&net.Dialer{}, 10 * time.Millisecond, nil}
 
 	proxy, err := NewProxy(":"+port, nil, dialer)
 	require.NoError(t, err)
@@ -90,8 +90,10 @@ func TestCloseWrite(t *testing.T) {
 
 func TestProxyProtocol(t *testing.T) {
 	testCases := []struct {
-		desc    string
-		version int
+		desc         string
+		version      int
+		tlvs         map[string]string
+		expectedTLVs []proxyproto.TLV
 	}{
 		{
 			desc:    "PROXY protocol v1",
@@ -101,6 +103,15 @@ func TestProxyProtocol(t *testing.T) {
 			desc:    "PROXY protocol v2",
 			version: 2,
 		},
+		{
+			desc:    "PROXY protocol v2 with TLVs",
+			version: 2,
+			tlvs:    map[string]string{"0xE1": "bar", "0xE0": "foo"},
+			expectedTLVs: []proxyproto.TLV{
+				{Type: 0xE0, Value: []byte("foo")},
+				{Type: 0xE1, Value: []byte("bar")},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -109,11 +120,15 @@ func TestProxyProtocol(t *testing.T) {
 			require.NoError(t, err)
 
 			var version int
+			var tlvs []proxyproto.TLV
 			proxyBackendListener := proxyproto.Listener{
 				Listener: backendListener,
 				ValidateHeader: func(h *proxyproto.Header) error {
 					version = int(h.Version)
-					return nil
+
+					var err error
+					tlvs, err = h.TLVs()
+					return err
 				},
 				Policy: func(upstream net.Addr) (proxyproto.Policy, error) {
 					switch test.version {
@@ -131,9 +146,9 @@ func TestProxyProtocol(t *testing.T) {
 			_, port, err := net.SplitHostPort(proxyBackendListener.Addr().String())
 			require.NoError(t, err)
 
-			dialer := tcpDialer{&net.Dialer{}, 10 * time.Millisecond}
+			dialer := tcpDialer{&net.Dialer{}, 10 * time.Millisecond, nil}
 
-			proxy, err := NewProxy(":"+port, &dynamic.ProxyProtocol{Version: test.version}, dialer)
+			proxy, err := NewProxy(":"+port, &dynamic.ProxyProtocol{Version: test.version, TLVs: test.tlvs}, dialer)
 			require.NoError(t, err)
 
 			proxyListener, err := net.Listen("tcp", ":0")
@@ -168,6 +183,61 @@ func TestProxyProtocol(t *testing.T) {
 			assert.Equal(t, "PONG", buffer.String())
 
 			assert.Equal(t, test.version, version)
+			assert.Equal(t, test.expectedTLVs, tlvs)
 		})
 	}
 }
+
+func TestNewProxy_proxyProtocol(t *testing.T) {
+	transportProxyProtocol := &dynamic.ProxyProtocol{Version: 1}
+	dialer := tcpDialer{&net.Dialer{}, 10 * time.Millisecond, transportProxyProtocol}
+
+	proxy, err := NewProxy(":80", nil, dialer)
+	require.NoError(t, err)
+	assert.Equal(t, transportProxyProtocol, proxy.proxyProtocol)
+
+	proxy, err = NewProxy(":80", &dynamic.ProxyProtocol{Version: 2}, dialer)
+	require.NoError(t, err)
+	assert.Equal(t, 2, proxy.proxyProtocol.Version)
+
+	_, err = NewProxy(":80", &dynamic.ProxyProtocol{Version: 1, TLVs: map[string]string{"0xE0": "foo"}}, dialer)
+	require.Error(t, err)
+
+	_, err = NewProxy(":80", &dynamic.ProxyProtocol{Version: 2, TLVs: map[string]string{"foo": "bar"}}, dialer)
+	require.Error(t, err)
+}
+
+func TestProxy_headerTLVs(t *testing.T) {
+	conn := &tlvConn{tlvs: []proxyproto.TLV{
+		{Type: proxyproto.PP2_TYPE_AUTHORITY, Value: []byte("example.com")},
+		{Type: proxyproto.PP2_TYPE_CRC32C, Value: []byte{0x01, 0x02, 0x03, 0x04}},
+		{Type: proxyproto.PP2_TYPE_NOOP, Value: []byte{0x00, 0x00}},
+		{Type: 0xE0, Value: []byte("received")},
+	}}
+
+	dialer := tcpDialer{&net.Dialer{}, 10 * time.Millisecond, nil}
+
+	proxy, err := NewProxy(":80", &dynamic.ProxyProtocol{Version: 2, PassthroughTLVs: true, TLVs: map[string]string{"0xE0": "custom"}}, dialer)
+	require.NoError(t, err)
+
+	expected := []proxyproto.TLV{
+		{Type: proxyproto.PP2_TYPE_AUTHORITY, Value: []byte("example.com")},
+		{Type: 0xE0, Value: []byte("custom")},
+	}
+	assert.Equal(t, expected, proxy.headerTLVs(conn))
+}
+
+// tlvConn is a connection received with the given PROXY protocol TLVs.
+type tlvConn struct {
+	net.Conn
+
+	tlvs []proxyproto.TLV
+}
+
+func (c *tlvConn) CloseWrite() error {
+	return nil
+}
+
+func (c *tlvConn) ProxyProtocolTLVs() []proxyproto.TLV {
+	return c.tlvs
+}
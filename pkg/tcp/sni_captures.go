@@ -0,0 +1,27 @@
+package tcp
+
+import (
+	"crypto/tls"
+)
+
+// sniCapturesConn is implemented by the connections holding the values captured from their server name.
+type sniCapturesConn interface {
+	SNICaptures() map[string]string
+}
+
+// SNICaptures returns the values captured from the server name of the connection
+// by the named groups of the HostSNIRegexp matchers of the router rule, or nil if there are none.
+// It also applies to the TLS connections terminated by the router.
+func SNICaptures(conn WriteCloser) map[string]string {
+	var c any = conn
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		c = tlsConn.NetConn()
+	}
+
+	capturesConn, ok := c.(sniCapturesConn)
+	if !ok {
+		return nil
+	}
+
+	return capturesConn.SNICaptures()
+}
@@ -0,0 +1,327 @@
+// Package fingerprint computes the JA3 and JA4 fingerprints of TLS ClientHello messages.
+package fingerprint
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // JA3 is defined as an MD5 hash.
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/cryptobyte"
+)
+
+const (
+	recordTypeHandshake      = 0x16
+	handshakeTypeClientHello = 0x01
+)
+
+// TLS extensions used by the fingerprints.
+const (
+	extensionServerName          = 0x0000
+	extensionSupportedGroups     = 0x000a
+	extensionECPointFormats      = 0x000b
+	extensionSignatureAlgorithms = 0x000d
+	extensionALPN                = 0x0010
+	extensionSupportedVersions   = 0x002b
+)
+
+// Fingerprints are the fingerprints of a TLS ClientHello.
+type Fingerprints struct {
+	// JA3 is the MD5 hash of the JA3 string.
+	JA3 string
+	// JA4 is the JA4 fingerprint.
+	JA4 string
+}
+
+// clientHello holds the fields of a ClientHello used by the fingerprints.
+type clientHello struct {
+	version             uint16
+	cipherSuites        []uint16
+	extensions          []uint16
+	supportedGroups     []uint16
+	ecPointFormats      []uint8
+	signatureAlgorithms []uint16
+	supportedVersions   []uint16
+	alpnProtocols       []string
+	serverName          bool
+}
+
+// Parse returns the fingerprints of the ClientHello contained in the given TLS record.
+func Parse(record []byte) (*Fingerprints, error) {
+	hello, err := parseClientHello(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fingerprints{
+		JA3: hello.ja3(),
+		JA4: hello.ja4(),
+	}, nil
+}
+
+func parseClientHello(record []byte) (*clientHello, error) {
+	s := cryptobyte.String(record)
+
+	var recordType uint8
+	var fragment cryptobyte.String
+	if !s.ReadUint8(&recordType) || recordType != recordTypeHandshake || !s.Skip(2) || !s.ReadUint16LengthPrefixed(&fragment) {
+		return nil, errors.New("not a TLS handshake record")
+	}
+
+	var handshakeType uint8
+	var body cryptobyte.String
+	if !fragment.ReadUint8(&handshakeType) || handshakeType != handshakeTypeClientHello || !fragment.ReadUint24LengthPrefixed(&body) {
+		return nil, errors.New("not a ClientHello message")
+	}
+
+	hello := &clientHello{}
+
+	var sessionID, cipherSuites, compressionMethods cryptobyte.String
+	if !body.ReadUint16(&hello.version) ||
+		!body.Skip(32) || // random
+		!body.ReadUint8LengthPrefixed(&sessionID) ||
+		!body.ReadUint16LengthPrefixed(&cipherSuites) ||
+		!body.ReadUint8LengthPrefixed(&compressionMethods) {
+		return nil, errors.New("malformed ClientHello")
+	}
+
+	for !cipherSuites.Empty() {
+		var suite uint16
+		if !cipherSuites.ReadUint16(&suite) {
+			return nil, errors.New("malformed ClientHello cipher suites")
+		}
+		hello.cipherSuites = append(hello.cipherSuites, suite)
+	}
+
+	if body.Empty() {
+		// A ClientHello without extensions.
+		return hello, nil
+	}
+
+	var extensions cryptobyte.String
+	if !body.ReadUint16LengthPrefixed(&extensions) {
+		return nil, errors.New("malformed ClientHello extensions")
+	}
+
+	for !extensions.Empty() {
+		var extension uint16
+		var data cryptobyte.String
+		if !extensions.ReadUint16(&extension) || !extensions.ReadUint16LengthPrefixed(&data) {
+			return nil, errors.New("malformed ClientHello extensions")
+		}
+
+		hello.extensions = append(hello.extensions, extension)
+
+		if err := hello.parseExtension(extension, data); err != nil {
+			return nil, fmt.Errorf("malformed ClientHello extension %d: %w", extension, err)
+		}
+	}
+
+	return hello, nil
+}
+
+func (h *clientHello) parseExtension(extension uint16, data cryptobyte.String) error {
+	switch extension {
+	case extensionServerName:
+		h.serverName = true
+
+	case extensionSupportedGroups:
+		return readUint16List(data.ReadUint16LengthPrefixed, &h.supportedGroups)
+
+	case extensionSignatureAlgorithms:
+		return readUint16List(data.ReadUint16LengthPrefixed, &h.signatureAlgorithms)
+
+	case extensionSupportedVersions:
+		return readUint16List(data.ReadUint8LengthPrefixed, &h.supportedVersions)
+
+	case extensionECPointFormats:
+		var formats cryptobyte.String
+		if !data.ReadUint8LengthPrefixed(&formats) {
+			return errors.New("invalid point formats")
+		}
+		h.ecPointFormats = append(h.ecPointFormats, formats...)
+
+	case extensionALPN:
+		var protocols cryptobyte.String
+		if !data.ReadUint16LengthPrefixed(&protocols) {
+			return errors.New("invalid ALPN protocols")
+		}
+
+		for !protocols.Empty() {
+			var protocol cryptobyte.String
+			if !protocols.ReadUint8LengthPrefixed(&protocol) {
+				return errors.New("invalid ALPN protocol")
+			}
+			h.alpnProtocols = append(h.alpnProtocols, string(protocol))
+		}
+	}
+
+	return nil
+}
+
+func readUint16List(readLengthPrefixed func(*cryptobyte.String) bool, values *[]uint16) error {
+	var list cryptobyte.String
+	if !readLengthPrefixed(&list) {
+		return errors.New("invalid list")
+	}
+
+	for !list.Empty() {
+		var value uint16
+		if !list.ReadUint16(&value) {
+			return errors.New("invalid list value")
+		}
+		*values = append(*values, value)
+	}
+
+	return nil
+}
+
+// ja3 returns the JA3 fingerprint: the MD5 hash of the version, cipher suites, extensions, supported groups,
+// and point formats, the GREASE values being ignored.
+func (h *clientHello) ja3() string {
+	ja3 := strings.Join([]string{
+		strconv.Itoa(int(h.version)),
+		joinDecimal(withoutGREASE(h.cipherSuites)),
+		joinDecimal(withoutGREASE(h.extensions)),
+		joinDecimal(withoutGREASE(h.supportedGroups)),
+		joinDecimal(h.ecPointFormats),
+	}, ",")
+
+	sum := md5.Sum([]byte(ja3)) //nolint:gosec // JA3 is defined as an MD5 hash.
+	return hex.EncodeToString(sum[:])
+}
+
+// ja4 returns the JA4 fingerprint, as defined by https://github.com/FoxIO-LLC/ja4.
+func (h *clientHello) ja4() string {
+	cipherSuites := withoutGREASE(h.cipherSuites)
+	extensions := withoutGREASE(h.extensions)
+
+	sni := "i"
+	if h.serverName {
+		sni = "d"
+	}
+
+	a := fmt.Sprintf("t%s%s%02d%02d%s", h.ja4Version(), sni, min(len(cipherSuites), 99), min(len(extensions), 99), h.ja4ALPN())
+
+	sortedCipherSuites := slices.Clone(cipherSuites)
+	slices.Sort(sortedCipherSuites)
+	b := truncatedHash(joinHex(sortedCipherSuites), len(sortedCipherSuites) == 0)
+
+	// The server name and ALPN extensions are already part of the first section.
+	sortedExtensions := slices.DeleteFunc(slices.Clone(extensions), func(extension uint16) bool {
+		return extension == extensionServerName || extension == extensionALPN
+	})
+	slices.Sort(sortedExtensions)
+
+	c := joinHex(sortedExtensions)
+	if signatureAlgorithms := withoutGREASE(h.signatureAlgorithms); len(signatureAlgorithms) > 0 {
+		c += "_" + joinHex(signatureAlgorithms)
+	}
+
+	return a + "_" + b + "_" + truncatedHash(c, len(sortedExtensions) == 0)
+}
+
+func (h *clientHello) ja4Version() string {
+	version := h.version
+	if versions := withoutGREASE(h.supportedVersions); len(versions) > 0 {
+		version = slices.Max(versions)
+	}
+
+	switch version {
+	case 0x0304:
+		return "13"
+	case 0x0303:
+		return "12"
+	case 0x0302:
+		return "11"
+	case 0x0301:
+		return "10"
+	case 0x0300:
+		return "s3"
+	default:
+		return "00"
+	}
+}
+
+func (h *clientHello) ja4ALPN() string {
+	if len(h.alpnProtocols) == 0 || h.alpnProtocols[0] == "" {
+		return "00"
+	}
+
+	protocol := h.alpnProtocols[0]
+	first, last := protocol[0], protocol[len(protocol)-1]
+	if isAlphanumeric(first) && isAlphanumeric(last) {
+		return string([]byte{first, last})
+	}
+
+	encoded := hex.EncodeToString([]byte(protocol))
+	return string([]byte{encoded[0], encoded[len(encoded)-1]})
+}
+
+func truncatedHash(value string, empty bool) string {
+	if empty {
+		return "000000000000"
+	}
+
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// isGREASE returns whether the given value is a GREASE value, as defined by RFC 8701.
+func isGREASE(value uint16) bool {
+	return value&0x0f0f == 0x0a0a && value>>8 == value&0xff
+}
+
+func withoutGREASE(values []uint16) []uint16 {
+	return slices.DeleteFunc(slices.Clone(values), isGREASE)
+}
+
+func joinDecimal[T uint8 | uint16](values []T) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = strconv.Itoa(int(value))
+	}
+
+	return strings.Join(parts, "-")
+}
+
+func joinHex(values []uint16) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = fmt.Sprintf("%04x", value)
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func isAlphanumeric(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// Source is implemented by the connections providing the fingerprints of their TLS ClientHello.
+type Source interface {
+	// TLSFingerprints returns the fingerprints of the TLS ClientHello, or nil if they are not available.
+	TLSFingerprints() *Fingerprints
+}
+
+type contextKey struct{}
+
+// NewContext returns a context holding the fingerprints source of the connection.
+func NewContext(ctx context.Context, source Source) context.Context {
+	return context.WithValue(ctx, contextKey{}, source)
+}
+
+// FromContext returns the fingerprints of the connection of the given context, or nil if they are not available.
+func FromContext(ctx context.Context) *Fingerprints {
+	source, ok := ctx.Value(contextKey{}).(Source)
+	if !ok {
+		return nil
+	}
+
+	return source.TLSFingerprints()
+}
@@ -0,0 +1,186 @@
+package fingerprint
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/cryptobyte"
+)
+
+type extension struct {
+	typ  uint16
+	data func(b *cryptobyte.Builder)
+}
+
+func buildClientHello(version uint16, cipherSuites []uint16, extensions []extension) []byte {
+	var b cryptobyte.Builder
+	b.AddUint8(recordTypeHandshake)
+	b.AddUint16(0x0301)
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddUint8(handshakeTypeClientHello)
+		b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddUint16(version)
+			b.AddBytes(make([]byte, 32))
+			b.AddUint8LengthPrefixed(func(b *cryptobyte.Builder) {})
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+				for _, suite := range cipherSuites {
+					b.AddUint16(suite)
+				}
+			})
+			b.AddUint8LengthPrefixed(func(b *cryptobyte.Builder) { b.AddUint8(0) })
+
+			if extensions == nil {
+				return
+			}
+
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+				for _, ext := range extensions {
+					b.AddUint16(ext.typ)
+					b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+						if ext.data != nil {
+							ext.data(b)
+						}
+					})
+				}
+			})
+		})
+	})
+
+	return b.BytesOrPanic()
+}
+
+func uint16List(prefix func(*cryptobyte.Builder, cryptobyte.BuilderContinuation), values ...uint16) func(b *cryptobyte.Builder) {
+	return func(b *cryptobyte.Builder) {
+		prefix(b, func(b *cryptobyte.Builder) {
+			for _, value := range values {
+				b.AddUint16(value)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		record   []byte
+		expected *Fingerprints
+	}{
+		{
+			desc: "TLS 1.3 ClientHello with GREASE values",
+			record: buildClientHello(0x0303, []uint16{0x0a0a, 0x1301, 0xc02b}, []extension{
+				{typ: 0x0a0a},
+				{typ: extensionServerName, data: func(b *cryptobyte.Builder) {
+					b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+						b.AddUint8(0)
+						b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes([]byte("example.com")) })
+					})
+				}},
+				{typ: extensionSupportedGroups, data: uint16List((*cryptobyte.Builder).AddUint16LengthPrefixed, 0x1a1a, 0x001d, 0x0017)},
+				{typ: extensionECPointFormats, data: func(b *cryptobyte.Builder) {
+					b.AddUint8LengthPrefixed(func(b *cryptobyte.Builder) { b.AddUint8(0) })
+				}},
+				{typ: extensionSignatureAlgorithms, data: uint16List((*cryptobyte.Builder).AddUint16LengthPrefixed, 0x0403, 0x0804)},
+				{typ: extensionALPN, data: func(b *cryptobyte.Builder) {
+					b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+						for _, protocol := range []string{"h2", "http/1.1"} {
+							b.AddUint8LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes([]byte(protocol)) })
+						}
+					})
+				}},
+				{typ: extensionSupportedVersions, data: uint16List((*cryptobyte.Builder).AddUint8LengthPrefixed, 0x2a2a, 0x0304, 0x0303)},
+			}),
+			expected: &Fingerprints{
+				JA3: "87991a9b84cb5b4bc5f84c5ecad46032",
+				JA4: "t13d0206h2_777cda164f4b_fb71836bce29",
+			},
+		},
+		{
+			desc:   "TLS 1.0 ClientHello without extensions",
+			record: buildClientHello(0x0301, []uint16{0x002f}, nil),
+			expected: &Fingerprints{
+				JA3: "b02be259814e870a469a20ce9b2a7900",
+				JA4: "t10i010000_" + truncatedHash("002f", false) + "_000000000000",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			fingerprints, err := Parse(test.record)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, fingerprints)
+		})
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		record []byte
+	}{
+		{
+			desc:   "not TLS",
+			record: []byte("GET / HTTP/1.1\r\n"),
+		},
+		{
+			desc:   "truncated record",
+			record: buildClientHello(0x0303, []uint16{0x1301}, nil)[:20],
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse(test.record)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParse_goClient(t *testing.T) {
+	var record bytes.Buffer
+	client := tls.Client(recordConn{w: &record}, &tls.Config{ServerName: "example.com", NextProtos: []string{"h2"}})
+	_ = client.Handshake()
+
+	fingerprints, err := Parse(record.Bytes())
+	require.NoError(t, err)
+
+	assert.Len(t, fingerprints.JA3, 32)
+	assert.True(t, strings.HasPrefix(fingerprints.JA4, "t13d"), fingerprints.JA4)
+	assert.True(t, strings.HasSuffix(fingerprints.JA4[:10], "h2"), fingerprints.JA4)
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+
+	expected := &Fingerprints{JA3: "ja3", JA4: "ja4"}
+	ctx := NewContext(context.Background(), sourceFunc(func() *Fingerprints { return expected }))
+
+	assert.Equal(t, expected, FromContext(ctx))
+}
+
+type sourceFunc func() *Fingerprints
+
+func (f sourceFunc) TLSFingerprints() *Fingerprints { return f() }
+
+// recordConn is a net.Conn recording the written bytes, and failing on reads.
+type recordConn struct {
+	w        *bytes.Buffer
+	net.Conn // nil; crash on any unexpected use
+}
+
+func (c recordConn) Write(p []byte) (int, error) { return c.w.Write(p) }
+
+func (recordConn) Read([]byte) (int, error) { return 0, net.ErrClosed }
+
+func (recordConn) Close() error { return nil }
@@ -44,6 +44,17 @@ func Listen(listenConfig net.ListenConfig, network, address string, timeout time
 	if err != nil {
 		return nil, fmt.Errorf("listen packet: %w", err)
 	}
+
+	return ListenPacketConn(packetConn, timeout)
+}
+
+// ListenPacketConn creates a new listener over the given UDP packet connection,
+// such as a socket passed by systemd socket activation.
+func ListenPacketConn(packetConn net.PacketConn, timeout time.Duration) (*Listener, error) {
+	if timeout <= 0 {
+		return nil, errors.New("timeout should be greater than zero")
+	}
+
 	pConn, ok := packetConn.(*net.UDPConn)
 	if !ok {
 		return nil, errors.New("packet conn is not an UDPConn")